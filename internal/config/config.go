@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/daddia/zen/pkg/dirs"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -51,7 +52,7 @@ type Config struct {
 	Task TaskConfig `mapstructure:"task" json:"task" yaml:"task"`
 
 	// Temporary fields - to be removed when components are fully migrated
-	Integrations IntegrationsConfig `mapstructure:"integrations"`
+	Integrations IntegrationsConfig `mapstructure:"integrations" json:"integrations" yaml:"integrations"`
 
 	// Internal fields for configuration management
 	viper      *viper.Viper `mapstructure:"-" json:"-" yaml:"-"`
@@ -82,26 +83,25 @@ type TaskConfig struct {
 
 // Temporary types - to be removed when integration component is created
 type IntegrationsConfig struct {
-	TaskSystem        string                               `mapstructure:"task_system"`
-	SyncEnabled       bool                                 `mapstructure:"sync_enabled"`
-	SyncFrequency     string                               `mapstructure:"sync_frequency"`
-	PluginDirectories []string                             `mapstructure:"plugin_directories"`
-	Providers         map[string]IntegrationProviderConfig `mapstructure:"providers"`
+	TaskSystem        string                               `mapstructure:"task_system" json:"task_system" yaml:"task_system"`
+	SyncEnabled       bool                                 `mapstructure:"sync_enabled" json:"sync_enabled" yaml:"sync_enabled"`
+	SyncFrequency     string                               `mapstructure:"sync_frequency" json:"sync_frequency" yaml:"sync_frequency"`
+	PluginDirectories []string                             `mapstructure:"plugin_directories" json:"plugin_directories" yaml:"plugin_directories"`
+	Providers         map[string]IntegrationProviderConfig `mapstructure:"providers" json:"providers" yaml:"providers"`
 }
 
 type IntegrationProviderConfig struct {
-	URL           string                 `mapstructure:"url"`
-	ProjectKey    string                 `mapstructure:"project_key"`
-	Type          string                 `mapstructure:"type"`
-	Credentials   string                 `mapstructure:"credentials"`
-	Email         string                 `mapstructure:"email"`
-	APIKey        string                 `mapstructure:"api_key"`
-	FieldMapping  map[string]string      `mapstructure:"field_mapping"`
-	SyncDirection string                 `mapstructure:"sync_direction"`
-	Settings      map[string]interface{} `mapstructure:"settings"`
+	URL           string                 `mapstructure:"url" json:"url" yaml:"url"`
+	ProjectKey    string                 `mapstructure:"project_key" json:"project_key" yaml:"project_key"`
+	Type          string                 `mapstructure:"type" json:"type" yaml:"type"`
+	Credentials   string                 `mapstructure:"credentials" json:"credentials,omitempty" yaml:"credentials,omitempty"`
+	Email         string                 `mapstructure:"email" json:"email,omitempty" yaml:"email,omitempty"`
+	APIKey        string                 `mapstructure:"api_key" json:"api_key,omitempty" yaml:"api_key,omitempty"`
+	FieldMapping  map[string]string      `mapstructure:"field_mapping" json:"field_mapping" yaml:"field_mapping"`
+	SyncDirection string                 `mapstructure:"sync_direction" json:"sync_direction" yaml:"sync_direction"`
+	Settings      map[string]interface{} `mapstructure:"settings" json:"settings" yaml:"settings"`
 }
 
-
 // Load loads configuration from various sources with precedence handling
 func Load() (*Config, error) {
 	return LoadWithOptions(LoadOptions{})
@@ -225,9 +225,13 @@ func configureSimpleFileDiscovery(v *viper.Viper, configFile string) {
 	// Local/Project configuration (.zen/config)
 	v.AddConfigPath("./.zen")
 
-	// Global/User configuration (~/.zen/config) - PRIMARY LOCATION ONLY
-	if home, err := os.UserHomeDir(); err == nil {
-		v.AddConfigPath(filepath.Join(home, ".zen"))
+	// Global/User configuration, in the user config directory and then in ~/.zen, where
+	// earlier versions kept it
+	if dir, err := dirs.ConfigDir(); err == nil {
+		v.AddConfigPath(dir)
+	}
+	if dir, err := dirs.LegacyDir(); err == nil {
+		v.AddConfigPath(dir)
 	}
 
 	// System configuration (/etc/zen/config or C:\ProgramData\zen\config)
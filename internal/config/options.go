@@ -73,7 +73,7 @@ var Options = []ConfigOption{
 	{
 		Key:           "task.task_source",
 		Description:   "Task source system",
-		AllowedValues: []string{"local", "jira", "github", "linear"},
+		AllowedValues: []string{"local", "jira", "github", "linear", "notion", "servicenow"},
 		DefaultValue:  "local",
 		Type:          "string",
 	},
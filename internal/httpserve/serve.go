@@ -0,0 +1,44 @@
+// Package httpserve serves the HTTP servers zen runs locally, such as the
+// documentation and the API, until their command is stopped.
+package httpserve
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// shutdownTimeout bounds how long Serve waits for open requests when it stops
+const shutdownTimeout = 5 * time.Second
+
+// Serve serves the handler on the listener until ctx is cancelled, then waits for open
+// requests to finish. Stopping the server is not an error.
+func Serve(ctx context.Context, ln net.Listener, handler http.Handler) error {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConflictStrategyUnion keeps the values from both sides of a conflicting list field,
+// such as labels. It can only be used as a field policy.
+const ConflictStrategyUnion ConflictStrategy = "union"
+
+// Resolutions recorded on a FieldConflict once its policy has been applied
+const (
+	ResolutionLocal  = "local"
+	ResolutionRemote = "remote"
+	ResolutionMerged = "merged"
+	ResolutionManual = "manual"
+)
+
+// listFields are the fields that hold lists, and so can be merged with ConflictStrategyUnion
+var listFields = map[string]bool{
+	"labels":     true,
+	"tags":       true,
+	"components": true,
+}
+
+// FieldPolicies sets the conflict strategy of individual fields, such as
+// {"title": remote_wins, "description": local_wins, "labels": union}. Fields without a
+// policy are resolved with the sync's conflict strategy.
+type FieldPolicies map[string]ConflictStrategy
+
+// For returns the strategy that resolves conflicts in field
+func (p FieldPolicies) For(field string, fallback ConflictStrategy) ConflictStrategy {
+	if strategy, ok := p[field]; ok && strategy != "" {
+		return strategy
+	}
+	return fallback
+}
+
+// Validate checks that every policy is a known strategy, and that union is only used
+// for list fields
+func (p FieldPolicies) Validate() error {
+	fields := make([]string, 0, len(p))
+	for field := range p {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		switch strategy := p[field]; strategy {
+		case ConflictStrategyLocalWins, ConflictStrategyRemoteWins, ConflictStrategyTimestamp, ConflictStrategyManualReview:
+		case ConflictStrategyUnion:
+			if !listFields[field] {
+				return fmt.Errorf("field %s: union can only be used for list fields (labels, tags, components)", field)
+			}
+		default:
+			return fmt.Errorf("field %s: unsupported conflict strategy %q (must be one of: local_wins, remote_wins, timestamp, manual_review, union)", field, strategy)
+		}
+	}
+	return nil
+}
+
+// ResolveConflict decides a conflict with strategy. It records the outcome in the
+// conflict's Resolution and returns the value both sides should hold. Conflicts left for
+// manual review keep the local value until they are resolved.
+func ResolveConflict(conflict *FieldConflict, strategy ConflictStrategy) (interface{}, error) {
+	switch strategy {
+	case ConflictStrategyLocalWins:
+		conflict.Resolution = ResolutionLocal
+		return conflict.ZenValue, nil
+
+	case ConflictStrategyRemoteWins:
+		conflict.Resolution = ResolutionRemote
+		return conflict.ExternalValue, nil
+
+	case ConflictStrategyTimestamp:
+		if conflict.ExternalTimestamp.After(conflict.ZenTimestamp) {
+			conflict.Resolution = ResolutionRemote
+			return conflict.ExternalValue, nil
+		}
+		conflict.Resolution = ResolutionLocal
+		return conflict.ZenValue, nil
+
+	case ConflictStrategyManualReview:
+		conflict.Resolution = ResolutionManual
+		return conflict.ZenValue, nil
+
+	case ConflictStrategyUnion:
+		conflict.Resolution = ResolutionMerged
+		return unionValues(conflict.ZenValue, conflict.ExternalValue), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported conflict strategy: %s", strategy)
+	}
+}
+
+// unionValues returns the values of both lists, local ones first, without duplicates.
+// Values that differ only in case are the same value.
+func unionValues(local, remote interface{}) []string {
+	var merged []string
+	seen := map[string]bool{}
+	for _, values := range [][]string{toStrings(local), toStrings(remote)} {
+		for _, value := range values {
+			key := strings.ToLower(value)
+			if !seen[key] {
+				seen[key] = true
+				merged = append(merged, value)
+			}
+		}
+	}
+	return merged
+}
+
+// toStrings converts a list value to strings
+func toStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,100 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldPolicies_For(t *testing.T) {
+	policies := FieldPolicies{"title": ConflictStrategyRemoteWins}
+
+	assert.Equal(t, ConflictStrategyRemoteWins, policies.For("title", ConflictStrategyLocalWins))
+	assert.Equal(t, ConflictStrategyLocalWins, policies.For("status", ConflictStrategyLocalWins))
+	assert.Equal(t, ConflictStrategyTimestamp, FieldPolicies(nil).For("title", ConflictStrategyTimestamp))
+}
+
+func TestFieldPolicies_Validate(t *testing.T) {
+	assert.NoError(t, FieldPolicies{
+		"title":       ConflictStrategyRemoteWins,
+		"description": ConflictStrategyLocalWins,
+		"labels":      ConflictStrategyUnion,
+	}.Validate())
+
+	err := FieldPolicies{"title": ConflictStrategyUnion}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "list fields")
+
+	err = FieldPolicies{"status": "newest"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported conflict strategy "newest"`)
+}
+
+func TestResolveConflict(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		strategy   ConflictStrategy
+		conflict   FieldConflict
+		value      interface{}
+		resolution string
+	}{
+		{
+			name:       "local wins",
+			strategy:   ConflictStrategyLocalWins,
+			conflict:   FieldConflict{Field: "title", ZenValue: "Local", ExternalValue: "Remote"},
+			value:      "Local",
+			resolution: ResolutionLocal,
+		},
+		{
+			name:       "remote wins",
+			strategy:   ConflictStrategyRemoteWins,
+			conflict:   FieldConflict{Field: "title", ZenValue: "Local", ExternalValue: "Remote"},
+			value:      "Remote",
+			resolution: ResolutionRemote,
+		},
+		{
+			name:     "timestamp picks the newer side",
+			strategy: ConflictStrategyTimestamp,
+			conflict: FieldConflict{
+				Field: "status", ZenValue: "in_progress", ExternalValue: "done",
+				ZenTimestamp: now.Add(-time.Hour), ExternalTimestamp: now,
+			},
+			value:      "done",
+			resolution: ResolutionRemote,
+		},
+		{
+			name:       "manual review keeps the local value",
+			strategy:   ConflictStrategyManualReview,
+			conflict:   FieldConflict{Field: "status", ZenValue: "in_progress", ExternalValue: "done"},
+			value:      "in_progress",
+			resolution: ResolutionManual,
+		},
+		{
+			name:     "union merges lists",
+			strategy: ConflictStrategyUnion,
+			conflict: FieldConflict{
+				Field:         "labels",
+				ZenValue:      []string{"backend", "api"},
+				ExternalValue: []interface{}{"API", "urgent"},
+			},
+			value:      []string{"backend", "api", "urgent"},
+			resolution: ResolutionMerged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflict := tt.conflict
+			value, err := ResolveConflict(&conflict, tt.strategy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+			assert.Equal(t, tt.resolution, conflict.Resolution)
+		})
+	}
+
+	_, err := ResolveConflict(&FieldConflict{Field: "title"}, "")
+	assert.Error(t, err)
+}
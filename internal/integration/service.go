@@ -3,6 +3,7 @@ package integration
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ type Service struct {
 	logger    logging.Logger
 	auth      auth.Manager
 	cache     cache.Manager[*TaskSyncRecord]
+	cursors   cache.Manager[*SyncCursor]
 	providers map[string]IntegrationProvider
 	mu        sync.RWMutex
 
@@ -65,6 +67,34 @@ const (
 	CircuitBreakerHalfOpen
 )
 
+// SyncRecordNamespace is the cache namespace that holds task sync records
+const SyncRecordNamespace = "sync_records"
+
+// NewSyncRecordCache creates the cache that holds task sync records, in its own
+// namespace of the cache described by cfg. Sync records never expire; they are kept
+// until the task's sync is removed.
+func NewSyncRecordCache(cfg cache.Config, logger logging.Logger) cache.Manager[*TaskSyncRecord] {
+	cfg = cfg.Namespace(SyncRecordNamespace)
+	cfg.DefaultTTL = cache.NoExpiration
+	return cache.NewManager(cfg, logger, cache.NewJSONSerializer[*TaskSyncRecord]())
+}
+
+// SyncCursorNamespace is the cache namespace that holds incremental sync cursors
+const SyncCursorNamespace = "sync_cursors"
+
+// cursorOverlap is how far before its cursor an incremental sync looks for changes, so
+// that changes are not missed when the provider's clock is behind ours. Tasks changed in
+// the overlap are pulled twice, which is harmless.
+const cursorOverlap = 5 * time.Minute
+
+// NewSyncCursorCache creates the cache that holds a sync cursor per provider, in its own
+// namespace of the cache described by cfg. Cursors never expire.
+func NewSyncCursorCache(cfg cache.Config, logger logging.Logger) cache.Manager[*SyncCursor] {
+	cfg = cfg.Namespace(SyncCursorNamespace)
+	cfg.DefaultTTL = cache.NoExpiration
+	return cache.NewManager(cfg, logger, cache.NewJSONSerializer[*SyncCursor]())
+}
+
 // NewService creates a new integration service
 func NewService(
 	cfg *config.Config,
@@ -91,6 +121,12 @@ func NewService(
 	return s
 }
 
+// UseCursorStore enables incremental sync, keeping each provider's cursor in store.
+// Without a cursor store every SyncAllTasks pulls all tasks.
+func (s *Service) UseCursorStore(store cache.Manager[*SyncCursor]) {
+	s.cursors = store
+}
+
 // GetProvider returns a provider by name
 func (s *Service) GetProvider(name string) (IntegrationProvider, error) {
 	s.mu.RLock()
@@ -207,6 +243,10 @@ func (s *Service) SyncTask(ctx context.Context, taskID string, opts SyncOptions)
 		return nil, s.createIntegrationError(ErrCodeConfigError, "integration not configured", "", taskID)
 	}
 
+	if err := opts.FieldPolicies.Validate(); err != nil {
+		return nil, s.createIntegrationError(ErrCodeInvalidData, fmt.Sprintf("invalid field policies: %v", err), "", taskID)
+	}
+
 	// Get the provider
 	provider, err := s.GetProvider(s.config.Task.TaskSource)
 	if err != nil {
@@ -315,7 +355,9 @@ func (s *Service) SyncTask(ctx context.Context, taskID string, opts SyncOptions)
 	return result, nil
 }
 
-// SyncAllTasks synchronizes all tasks configured for sync
+// SyncAllTasks synchronizes all tasks configured for sync. When a cursor store is in use
+// and the provider has been synced before, pulls only sync the tasks that changed in the
+// external system since then; ForceSync pulls every task.
 func (s *Service) SyncAllTasks(ctx context.Context, opts SyncOptions) ([]*SyncResult, error) {
 	s.logger.Debug("starting sync all tasks", "direction", opts.Direction)
 
@@ -324,12 +366,31 @@ func (s *Service) SyncAllTasks(ctx context.Context, opts SyncOptions) ([]*SyncRe
 		return nil, fmt.Errorf("failed to list sync records: %w", err)
 	}
 
+	// Changes made while the sync runs are picked up by the next one
+	started := time.Now()
+
+	if changed, ok := s.changedSinceCursor(ctx, opts); ok {
+		unchanged := 0
+		filtered := syncRecords[:0]
+		for _, record := range syncRecords {
+			if changed[record.ExternalID] {
+				filtered = append(filtered, record)
+			} else {
+				unchanged++
+			}
+		}
+		syncRecords = filtered
+		s.logger.Debug("incremental sync", "changed", len(syncRecords), "unchanged", unchanged)
+	}
+
 	results := make([]*SyncResult, 0, len(syncRecords))
+	failed := 0
 
 	for _, record := range syncRecords {
 		result, err := s.SyncTask(ctx, record.TaskID, opts)
 		if err != nil {
 			s.logger.Error("failed to sync task", "task_id", record.TaskID, "error", err)
+			failed++
 			// Continue with other tasks even if one fails
 		}
 		if result != nil {
@@ -337,11 +398,71 @@ func (s *Service) SyncAllTasks(ctx context.Context, opts SyncOptions) ([]*SyncRe
 		}
 	}
 
+	// Failed tasks are retried in full by the next sync, so the cursor only moves on
+	// when every task was pulled
+	if failed == 0 && pulls(opts.Direction) {
+		s.advanceCursor(ctx, started)
+	}
+
 	s.logger.Info("sync all tasks completed", "total", len(syncRecords), "results", len(results))
 
 	return results, nil
 }
 
+// changedSinceCursor returns the external IDs of the tasks changed since the provider's
+// cursor. It reports false when every task must be synced instead.
+func (s *Service) changedSinceCursor(ctx context.Context, opts SyncOptions) (map[string]bool, bool) {
+	// Local changes are pushed whether or not the external task changed
+	if s.cursors == nil || opts.ForceSync || opts.Direction != SyncDirectionPull || !s.IsConfigured() {
+		return nil, false
+	}
+
+	providerName := s.config.Task.TaskSource
+	entry, err := s.cursors.Get(ctx, providerName)
+	if err != nil {
+		// Never synced, so everything is pulled
+		return nil, false
+	}
+
+	provider, err := s.GetProvider(providerName)
+	if err != nil {
+		return nil, false
+	}
+
+	updated, err := provider.SearchTasksUpdatedSince(ctx, entry.Data.Since.Add(-cursorOverlap))
+	if err != nil {
+		s.logger.Warn("failed to search for changed tasks, syncing all tasks", "provider", providerName, "error", err)
+		return nil, false
+	}
+
+	changed := make(map[string]bool, len(updated))
+	for _, task := range updated {
+		changed[task.ID] = true
+	}
+	return changed, true
+}
+
+// advanceCursor records that the configured provider's tasks have been pulled up to since
+func (s *Service) advanceCursor(ctx context.Context, since time.Time) {
+	if s.cursors == nil || !s.IsConfigured() {
+		return
+	}
+
+	cursor := &SyncCursor{
+		Provider:  s.config.Task.TaskSource,
+		Since:     since,
+		UpdatedAt: time.Now(),
+	}
+	if err := s.cursors.Put(ctx, cursor.Provider, cursor, cache.PutOptions{TTL: cache.NoExpiration}); err != nil {
+		s.logger.Warn("failed to save sync cursor", "provider", cursor.Provider, "error", err)
+	}
+}
+
+// pulls reports whether syncs in direction pull external changes
+func pulls(direction SyncDirection) bool {
+	return direction == SyncDirectionPull || direction == SyncDirectionBidirectional
+}
+
 // GetSyncRecord retrieves the sync record for a task
 func (s *Service) GetSyncRecord(ctx context.Context, taskID string) (*TaskSyncRecord, error) {
 	entry, err := s.cache.Get(ctx, taskID)
@@ -363,7 +484,7 @@ func (s *Service) CreateSyncRecord(ctx context.Context, record *TaskSyncRecord)
 		return fmt.Errorf("sync record already exists for task %s", record.TaskID)
 	}
 
-	opts := cache.PutOptions{}
+	opts := cache.PutOptions{TTL: cache.NoExpiration}
 	if err := s.cache.Put(ctx, record.TaskID, record, opts); err != nil {
 		return fmt.Errorf("failed to create sync record: %w", err)
 	}
@@ -385,7 +506,7 @@ func (s *Service) UpdateSyncRecord(ctx context.Context, record *TaskSyncRecord)
 		record.CreatedAt = record.UpdatedAt
 	}
 
-	opts := cache.PutOptions{}
+	opts := cache.PutOptions{TTL: cache.NoExpiration}
 	if err := s.cache.Put(ctx, record.TaskID, record, opts); err != nil {
 		return fmt.Errorf("failed to update sync record: %w", err)
 	}
@@ -410,17 +531,25 @@ func (s *Service) DeleteSyncRecord(ctx context.Context, taskID string) error {
 	return nil
 }
 
-// ListSyncRecords lists all sync records
+// ListSyncRecords lists all sync records, ordered by task ID
 func (s *Service) ListSyncRecords(ctx context.Context) ([]*TaskSyncRecord, error) {
-	// Note: This is a simplified implementation
-	// In a production system, you might want to use a different storage mechanism
-	// that supports listing all records more efficiently
+	taskIDs, err := s.cache.Keys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sync records: %w", err)
+	}
 
-	// For now, we'll return an empty list as the cache interface doesn't support listing
-	// This would need to be enhanced with a proper storage backend for sync records
-	s.logger.Debug("listing sync records - simplified implementation")
+	records := make([]*TaskSyncRecord, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		entry, err := s.cache.Get(ctx, taskID)
+		if err != nil {
+			// Deleted or evicted since it was listed
+			s.logger.Debug("skipping sync record", "task_id", taskID, "error", err)
+			continue
+		}
+		records = append(records, entry.Data)
+	}
 
-	return []*TaskSyncRecord{}, nil
+	return records, nil
 }
 
 // pullFromExternal pulls data from external system to Zen
@@ -525,14 +654,15 @@ func (s *Service) bidirectionalSync(ctx context.Context, provider IntegrationPro
 		s.metrics.ConflictCount++
 		s.metrics.mu.Unlock()
 
-		// Handle conflicts based on strategy
-		if err := s.resolveConflicts(ctx, record, conflicts, opts.ConflictStrategy); err != nil {
+		// Handle conflicts based on each field's policy
+		if err := s.resolveConflicts(ctx, record, conflicts, opts); err != nil {
 			return err
 		}
 	}
 
-	// Perform sync based on conflict resolution
-	if opts.ConflictStrategy != ConflictStrategyManualReview || len(conflicts) == 0 {
+	// Perform sync based on conflict resolution; fields left for manual review hold
+	// the sync until they are resolved
+	if !hasManualConflicts(conflicts) {
 		// Determine sync direction based on timestamps and conflict resolution
 		if s.shouldPullFirst(zenData, externalData, conflicts, opts.ConflictStrategy) {
 			if err := s.pullFromExternal(ctx, provider, record, result, opts); err != nil {
@@ -797,31 +927,40 @@ func (s *Service) detectConflicts(zenData *ZenTaskData, externalData *ExternalTa
 		conflicts = append(conflicts, conflict)
 	}
 
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Field < conflicts[j].Field })
+
 	return conflicts, nil
 }
 
-// resolveConflicts resolves conflicts based on the specified strategy
-func (s *Service) resolveConflicts(ctx context.Context, record *TaskSyncRecord, conflicts []FieldConflict, strategy ConflictStrategy) error {
-	switch strategy {
-	case ConflictStrategyLocalWins:
-		// Keep Zen data, no action needed
-		return nil
-
-	case ConflictStrategyRemoteWins:
-		// Accept external data, no action needed (will be handled in sync)
-		return nil
-
-	case ConflictStrategyTimestamp:
-		// Resolve based on timestamps - handled in shouldPullFirst
-		return nil
+// resolveConflicts resolves each conflict with its field's policy, or the sync's conflict
+// strategy when the field has none, recording the outcome in the conflict's Resolution.
+// Conflicts left for manual review are stored for manual resolution.
+func (s *Service) resolveConflicts(ctx context.Context, record *TaskSyncRecord, conflicts []FieldConflict, opts SyncOptions) error {
+	var manual []FieldConflict
+	for i := range conflicts {
+		strategy := opts.FieldPolicies.For(conflicts[i].Field, opts.ConflictStrategy)
+		if _, err := ResolveConflict(&conflicts[i], strategy); err != nil {
+			return s.createIntegrationError(ErrCodeInvalidData, err.Error(), "", record.TaskID)
+		}
+		if conflicts[i].Resolution == ResolutionManual {
+			manual = append(manual, conflicts[i])
+		}
+	}
 
-	case ConflictStrategyManualReview:
-		// Store conflicts for manual resolution
-		return s.storeConflictRecord(record.TaskID, conflicts)
+	if len(manual) > 0 {
+		return s.storeConflictRecord(record.TaskID, manual)
+	}
+	return nil
+}
 
-	default:
-		return s.createIntegrationError(ErrCodeInvalidData, fmt.Sprintf("unsupported conflict strategy: %s", strategy), "", record.TaskID)
+// hasManualConflicts reports whether any conflict is left for manual review
+func hasManualConflicts(conflicts []FieldConflict) bool {
+	for _, conflict := range conflicts {
+		if conflict.Resolution == ResolutionManual {
+			return true
+		}
 	}
+	return false
 }
 
 // storeConflictRecord stores a conflict record for manual resolution
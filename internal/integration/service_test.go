@@ -44,6 +44,11 @@ func (m *MockProvider) SearchTasks(ctx context.Context, query map[string]interfa
 	return args.Get(0).([]*ExternalTaskData), args.Error(1)
 }
 
+func (m *MockProvider) SearchTasksUpdatedSince(ctx context.Context, since time.Time) ([]*ExternalTaskData, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).([]*ExternalTaskData), args.Error(1)
+}
+
 func (m *MockProvider) ValidateConnection(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -527,7 +532,7 @@ func TestService_ConflictResolution(t *testing.T) {
 			}
 
 			// Test conflict resolution
-			err := service.resolveConflicts(context.Background(), record, tt.conflicts, tt.strategy)
+			err := service.resolveConflicts(context.Background(), record, tt.conflicts, SyncOptions{ConflictStrategy: tt.strategy})
 			tt.expected(t, err)
 
 			// For manual review, check if conflict was stored
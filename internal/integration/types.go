@@ -55,6 +55,14 @@ type TaskSyncRecord struct {
 	DataHash         string                 `json:"data_hash,omitempty" yaml:"data_hash,omitempty"`
 }
 
+// SyncCursor is the high-water mark of incremental sync with a provider: tasks changed
+// in the external system before Since have already been pulled
+type SyncCursor struct {
+	Provider  string    `json:"provider" yaml:"provider"`
+	Since     time.Time `json:"since" yaml:"since"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
 // ExternalTaskData represents task data from an external system
 type ExternalTaskData struct {
 	ID          string                 `json:"id"`
@@ -103,6 +111,7 @@ type SyncResult struct {
 type SyncOptions struct {
 	Direction        SyncDirection    `json:"direction"`
 	ConflictStrategy ConflictStrategy `json:"conflict_strategy"`
+	FieldPolicies    FieldPolicies    `json:"field_policies,omitempty"`
 	DryRun           bool             `json:"dry_run"`
 	ForceSync        bool             `json:"force_sync"`
 	Timeout          time.Duration    `json:"timeout,omitempty"`
@@ -130,6 +139,10 @@ type IntegrationProvider interface {
 	// SearchTasks searches for tasks in the external system
 	SearchTasks(ctx context.Context, query map[string]interface{}) ([]*ExternalTaskData, error)
 
+	// SearchTasksUpdatedSince returns the tasks changed in the external system at or
+	// after since, for incremental sync
+	SearchTasksUpdatedSince(ctx context.Context, since time.Time) ([]*ExternalTaskData, error)
+
 	// ValidateConnection tests the connection to the external system
 	ValidateConnection(ctx context.Context) error
 
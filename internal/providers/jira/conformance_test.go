@@ -0,0 +1,26 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/internal/integration"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/integration/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Harness{
+		Server: conformance.NewJiraServer,
+		NewProvider: func(t *testing.T, server *conformance.Server) integration.IntegrationProvider {
+			email, token := server.Credentials()
+			return NewProvider(&config.IntegrationProviderConfig{
+				URL:        server.URL,
+				ProjectKey: server.Project,
+				Type:       "basic",
+				Email:      email,
+				APIKey:     token,
+			}, logging.NewBasic(), &mockAuthManager{})
+		},
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/daddia/zen/internal/logging"
 	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/clients"
+	"github.com/daddia/zen/pkg/clients/httpx"
 )
 
 // JiraTime handles Jira's timestamp format which uses +1000 instead of +10:00
@@ -138,20 +140,12 @@ func NewProvider(
 	authManager auth.Manager,
 ) *Provider {
 	return &Provider{
-		config:     config,
-		logger:     logger,
-		auth:       authManager,
-		baseURL:    config.URL,
-		projectKey: config.ProjectKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        10,
-				IdleConnTimeout:     90 * time.Second,
-				DisableCompression:  false,
-				MaxIdleConnsPerHost: 5,
-			},
-		},
+		config:        config,
+		logger:        logger,
+		auth:          authManager,
+		baseURL:       config.URL,
+		projectKey:    config.ProjectKey,
+		httpClient:    httpx.New(httpx.Options{Provider: "jira", Logger: logger}),
 		fieldMappings: config.FieldMapping,
 	}
 }
@@ -396,22 +390,7 @@ func (p *Provider) SearchTasks(ctx context.Context, query map[string]interface{}
 	// Convert to external task data
 	tasks := make([]*integration.ExternalTaskData, 0, len(searchResp.Issues))
 	for _, issue := range searchResp.Issues {
-		taskData := &integration.ExternalTaskData{
-			ID:          issue.Key,
-			Title:       issue.Fields.Summary,
-			Description: issue.Fields.Description,
-			Status:      issue.Fields.Status.Name,
-			Priority:    issue.Fields.Priority.Name,
-			Assignee:    issue.Fields.Assignee.DisplayName,
-			Created:     issue.Fields.Created.Time,
-			Updated:     issue.Fields.Updated.Time,
-			Fields: map[string]interface{}{
-				"issue_type": issue.Fields.IssueType.Name,
-				"project":    issue.Fields.Project.Key,
-				"self":       issue.Self,
-			},
-		}
-		tasks = append(tasks, taskData)
+		tasks = append(tasks, searchResultToTaskData(issue))
 	}
 
 	p.logger.Debug("found Jira issues", "count", len(tasks), "total", searchResp.Total)
@@ -419,6 +398,66 @@ func (p *Provider) SearchTasks(ctx context.Context, query map[string]interface{}
 	return tasks, nil
 }
 
+// SearchTasksUpdatedSince returns the project's issues updated at or after since, across
+// all pages of search results
+func (p *Provider) SearchTasksUpdatedSince(ctx context.Context, since time.Time) ([]*integration.ExternalTaskData, error) {
+	// JQL reads absolute dates in the user's time zone, so ask for a relative age instead
+	minutes := int(math.Ceil(time.Since(since).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+	jql := fmt.Sprintf("project = %s AND updated >= -%dm ORDER BY updated ASC", p.projectKey, minutes)
+
+	p.logger.Debug("searching updated Jira issues", "since", since, "jql", jql)
+
+	var tasks []*integration.ExternalTaskData
+	for startAt := 0; ; {
+		searchURL := fmt.Sprintf("%s/rest/api/3/search?jql=%s&startAt=%d&maxResults=100", p.baseURL, url.QueryEscape(jql), startAt)
+
+		resp, err := p.makeAPIRequest(ctx, "GET", searchURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search updated Jira issues: %w", err)
+		}
+
+		var searchResp JiraSearchResponse
+		if err := json.Unmarshal(resp, &searchResp); err != nil {
+			return nil, fmt.Errorf("failed to parse search response: %w", err)
+		}
+
+		for _, issue := range searchResp.Issues {
+			tasks = append(tasks, searchResultToTaskData(issue))
+		}
+
+		startAt += len(searchResp.Issues)
+		if len(searchResp.Issues) == 0 || startAt >= searchResp.Total {
+			break
+		}
+	}
+
+	p.logger.Debug("found updated Jira issues", "count", len(tasks))
+
+	return tasks, nil
+}
+
+// searchResultToTaskData converts an issue from search results to external task data
+func searchResultToTaskData(issue JiraIssue) *integration.ExternalTaskData {
+	return &integration.ExternalTaskData{
+		ID:          issue.Key,
+		Title:       issue.Fields.Summary,
+		Description: issue.Fields.Description,
+		Status:      issue.Fields.Status.Name,
+		Priority:    issue.Fields.Priority.Name,
+		Assignee:    issue.Fields.Assignee.DisplayName,
+		Created:     issue.Fields.Created.Time,
+		Updated:     issue.Fields.Updated.Time,
+		Fields: map[string]interface{}{
+			"issue_type": issue.Fields.IssueType.Name,
+			"project":    issue.Fields.Project.Key,
+			"self":       issue.Self,
+		},
+	}
+}
+
 // ValidateConnection tests the connection to Jira
 func (p *Provider) ValidateConnection(ctx context.Context) error {
 	p.logger.Debug("validating Jira connection")
@@ -586,7 +625,6 @@ func (p *Provider) makeAPIRequest(ctx context.Context, method, url string, body
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "Zen-CLI/1.0")
 
 	// Add authentication
 	if err := p.addAuthentication(req); err != nil {
@@ -530,6 +530,42 @@ func TestProvider_SearchTasks(t *testing.T) {
 	authManager.AssertExpectations(t)
 }
 
+func TestProvider_SearchTasksUpdatedSince(t *testing.T) {
+	var startAts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "project = PROJ AND updated >= -30m ORDER BY updated ASC", r.URL.Query().Get("jql"))
+		startAts = append(startAts, r.URL.Query().Get("startAt"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("startAt") == "0" {
+			fmt.Fprint(w, `{"issues":[{"key":"PROJ-1","fields":{"summary":"First"}},{"key":"PROJ-2","fields":{"summary":"Second"}}],"total":3}`)
+			return
+		}
+		fmt.Fprint(w, `{"issues":[{"key":"PROJ-3","fields":{"summary":"Third"}}],"total":3}`)
+	}))
+	defer server.Close()
+
+	config := &config.IntegrationProviderConfig{
+		URL:         server.URL,
+		ProjectKey:  "PROJ",
+		Type:        "token",
+		Credentials: "jira_token",
+	}
+
+	authManager := &mockAuthManager{}
+	authManager.On("GetCredentials", "jira_token").Return("test-token", nil)
+
+	provider := NewProvider(config, logging.NewBasic(), authManager)
+
+	tasks, err := provider.SearchTasksUpdatedSince(context.Background(), time.Now().Add(-29*time.Minute-30*time.Second))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"0", "2"}, startAts, "all pages are fetched")
+	require.Len(t, tasks, 3)
+	assert.Equal(t, "PROJ-3", tasks[2].ID)
+	assert.Equal(t, "Third", tasks[2].Title)
+}
+
 func TestProvider_DataMapping(t *testing.T) {
 	config := &config.IntegrationProviderConfig{
 		URL:        "https://test.atlassian.net",
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/daddia/zen/pkg/extension"
+	"github.com/spf13/cobra"
+)
+
+// envAssignment matches a leading environment variable assignment, e.g. ZEN_DEBUG=true
+var envAssignment = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+
+// redirections are the shell operators whose next word is a file rather than an argument
+var redirections = map[string]bool{">": true, ">>": true, "<": true, "2>": true, "&>": true}
+
+// exampleDrift is an example whose command line no longer parses
+type exampleDrift struct {
+	Command string
+	Example string
+	Err     error
+}
+
+func (d exampleDrift) Error() string {
+	return fmt.Sprintf("%s: example %q: %v", d.Command, d.Example, d.Err)
+}
+
+// validateExamples checks that every zen command line in the Example blocks of the command
+// tree still resolves to a command and that its flags and arguments parse. Each example is
+// parsed against a fresh tree from newRoot, and no command is run. Extensions installed by
+// an earlier example of the same block are taken to exist, and their arguments are their own.
+func validateExamples(rootCmd *cobra.Command, newRoot func() (*cobra.Command, error)) ([]exampleDrift, error) {
+	var drift []exampleDrift
+
+	var walk func(cmd *cobra.Command) error
+	walk = func(cmd *cobra.Command) error {
+		installed := map[string]bool{}
+		for _, example := range parseExamples(cmd.Example) {
+			for _, args := range exampleInvocations(example.Command) {
+				if len(args) > 2 && args[0] == "extension" && args[1] == "install" {
+					installed[extension.NameFromRepo(args[2])] = true
+				}
+				if len(args) > 0 && installed[args[0]] {
+					continue
+				}
+
+				sandbox, err := newRoot()
+				if err != nil {
+					return err
+				}
+				if err := parseInvocation(sandbox, args); err != nil {
+					drift = append(drift, exampleDrift{Command: cmd.CommandPath(), Example: example.Command, Err: err})
+				}
+			}
+		}
+
+		for _, sub := range cmd.Commands() {
+			if sub.Hidden {
+				continue
+			}
+			if err := walk(sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return drift, walk(rootCmd)
+}
+
+// parseInvocation resolves args, without the program name, to a command and parses its
+// flags and arguments the way Execute would, without running it
+func parseInvocation(rootCmd *cobra.Command, args []string) error {
+	cmd, rest, err := rootCmd.Find(args)
+	if err != nil {
+		return err
+	}
+
+	cmd.InitDefaultHelpFlag()
+	if err := cmd.ParseFlags(rest); err != nil {
+		return err
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		return nil
+	}
+
+	// Find leaves unknown subcommands of command groups as arguments
+	if positional := cmd.Flags().Args(); !cmd.Runnable() && len(positional) > 0 {
+		return fmt.Errorf("unknown command %q for %q", positional[0], cmd.CommandPath())
+	}
+
+	if err := cmd.ValidateArgs(cmd.Flags().Args()); err != nil {
+		return err
+	}
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		return err
+	}
+	return cmd.ValidateFlagGroups()
+}
+
+// exampleInvocations returns the arguments of each zen invocation in a shell command line,
+// leaving out environment assignments, redirections, and commands other than zen
+func exampleInvocations(line string) [][]string {
+	var invocations [][]string
+
+	for _, words := range splitShellCommands(line) {
+		for len(words) > 0 && envAssignment.MatchString(words[0]) {
+			words = words[1:]
+		}
+		if len(words) == 0 || words[0] != "zen" {
+			continue
+		}
+
+		args := []string{}
+		for i := 1; i < len(words); i++ {
+			if redirections[words[i]] {
+				i++
+				continue
+			}
+			args = append(args, words[i])
+		}
+		invocations = append(invocations, args)
+	}
+	return invocations
+}
+
+// splitShellCommands splits a shell command line into the words of each command joined by
+// pipes, "&&", "||", or ";". Quotes and backslashes are handled as the shell does, and an
+// unquoted "#" starts a comment. Redirection operators are words of their own, even when
+// written against the file name as in ">file".
+func splitShellCommands(line string) [][]string {
+	var commands [][]string
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	endWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+	endCommand := func() {
+		endWord()
+		if len(words) > 0 {
+			commands = append(commands, words)
+			words = nil
+		}
+	}
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' && i+1 < len(runes) {
+				i++
+				word.WriteRune(runes[i])
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == ' ' || r == '\t':
+			endWord()
+		case r == '#' && !inWord:
+			endCommand()
+			return commands
+		case r == '|' || r == ';' || (r == '&' && i+1 < len(runes) && runes[i+1] == '&'):
+			endCommand()
+			if i+1 < len(runes) && (runes[i+1] == r) {
+				i++
+			}
+		case (r == '>' || r == '<') && !inWord, r == '>' && word.String() == "2", r == '>' && word.String() == "&":
+			// Keep the redirection operator as its own word
+			op := word.String() + string(r)
+			word.Reset()
+			inWord = false
+			if i+1 < len(runes) && runes[i+1] == '>' {
+				op += ">"
+				i++
+			}
+			words = append(words, op)
+			if i+1 < len(runes) && runes[i+1] == '&' {
+				// 2>&1 duplicates a descriptor and names no file
+				words = words[:len(words)-1]
+				for i+1 < len(runes) && runes[i+1] != ' ' {
+					i++
+				}
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	endCommand()
+	return commands
+}
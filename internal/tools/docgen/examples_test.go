@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExampleInvocations(t *testing.T) {
+	tests := []struct {
+		line string
+		want [][]string
+	}{
+		{`zen assets sync --force`, [][]string{{"assets", "sync", "--force"}}},
+		{`zen task create PROJ-1 --title "A story | with pipes"`, [][]string{{"task", "create", "PROJ-1", "--title", "A story | with pipes"}}},
+		{`zen task list -o json | jq '.[] | .id'`, [][]string{{"task", "list", "-o", "json"}}},
+		{`zen init && zen status   # check the workspace`, [][]string{{"init"}, {"status"}}},
+		{`ZEN_DEBUG=true zen status`, [][]string{{"status"}}},
+		{`zen completion zsh > "${fpath[1]}/_zen"`, [][]string{{"completion", "zsh"}}},
+		{`zen completion bash >/etc/bash_completion.d/zen 2>&1`, [][]string{{"completion", "bash"}}},
+		{`zen status 2>/dev/null; git status`, [][]string{{"status"}}},
+		{`zen task create it\'s --type=bug`, [][]string{{"task", "create", "it's", "--type=bug"}}},
+		{`git log --oneline`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.want, exampleInvocations(tt.line))
+		})
+	}
+}
+
+func TestValidateExamples(t *testing.T) {
+	newRoot := func() (*cobra.Command, error) {
+		root := &cobra.Command{Use: "zen"}
+		root.PersistentFlags().StringP("output", "o", "text", "Output format")
+
+		list := &cobra.Command{Use: "list", Args: cobra.NoArgs, Run: func(cmd *cobra.Command, args []string) {}}
+		list.Flags().String("format", "", "Go template")
+		list.Flags().String("jq", "", "jq expression")
+		list.MarkFlagsMutuallyExclusive("format", "jq")
+
+		get := &cobra.Command{Use: "get <name>", Args: cobra.ExactArgs(1), Run: func(cmd *cobra.Command, args []string) {}}
+		get.Flags().String("type", "", "Asset type")
+		_ = get.MarkFlagRequired("type")
+
+		install := &cobra.Command{Use: "install <repo>", Args: cobra.ExactArgs(1), Run: func(cmd *cobra.Command, args []string) {}}
+		ext := &cobra.Command{Use: "extension"}
+		ext.AddCommand(install)
+
+		assets := &cobra.Command{Use: "assets"}
+		assets.AddCommand(list, get)
+		root.AddCommand(assets, ext)
+		return root, nil
+	}
+
+	root, err := newRoot()
+	require.NoError(t, err)
+	assets, _, err := root.Find([]string{"assets"})
+	require.NoError(t, err)
+	ext, _, err := root.Find([]string{"extension"})
+	require.NoError(t, err)
+
+	assets.Example = `  # Each example parses on its own, so flags do not carry over
+  zen assets list --format '{{.Name}}'
+  zen assets list --jq '.[].name' -o json
+  zen assets get technical-spec --type template
+  zen assets get --help
+
+  # Drift
+  zen assets list --all
+  zen assets get technical-spec
+  zen assets get
+  zen assets lsit
+  zen assets list --format x --jq y`
+	ext.Example = `  zen extension install acme/zen-deploy
+  zen deploy --env staging`
+
+	drift, err := validateExamples(root, newRoot)
+	require.NoError(t, err)
+
+	var failed []string
+	for _, d := range drift {
+		assert.Equal(t, "zen assets", d.Command)
+		failed = append(failed, d.Example)
+	}
+	assert.Equal(t, []string{
+		"zen assets list --all",
+		"zen assets get technical-spec",
+		"zen assets get",
+		"zen assets lsit",
+		"zen assets list --format x --jq y",
+	}, failed)
+}
@@ -1,9 +1,12 @@
 // Package main implements the documentation generator for Zen CLI
-// This tool generates Markdown, Man page, and ReStructuredText documentation
-// from the Cobra command definitions, ensuring docs stay in sync with code.
+// This tool generates Markdown, Man page, and ReStructuredText documentation,
+// a JSON schema of the command tree, and shell completion scripts from the Cobra
+// command definitions, ensuring docs stay in sync with code. Every example is
+// checked to still parse before anything is generated.
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"io"
@@ -14,6 +17,7 @@ import (
 	"time"
 
 	"github.com/daddia/zen/pkg/cmd/root"
+	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 )
@@ -21,9 +25,10 @@ import (
 func main() {
 	// Command-line flags
 	out := flag.String("out", "./docs/zen", "output directory for generated documentation")
-	format := flag.String("format", "markdown", "output format: markdown|man|rest")
+	format := flag.String("format", "markdown", "output format: markdown|man|rest|json|completion, or examples to only check examples")
 	front := flag.Bool("frontmatter", false, "prepend YAML front matter to markdown files")
 	timestamp := flag.Bool("timestamp", false, "include generation timestamp in files")
+	checkExamples := flag.Bool("check-examples", true, "fail if any command example no longer parses")
 	flag.Parse()
 
 	// Ensure output directory exists
@@ -37,20 +42,48 @@ func main() {
 		log.Fatalf("failed to get root command: %v", err)
 	}
 
+	// Examples are checked before prepareCommands adds generated ones
+	if *checkExamples || *format == "examples" {
+		drift, err := validateExamples(rootCmd, root.Root)
+		if err != nil {
+			log.Fatalf("failed to check examples: %v", err)
+		}
+		for _, d := range drift {
+			log.Printf("✗ %v", d)
+		}
+		if len(drift) > 0 {
+			log.Fatalf("%d examples no longer parse; fix them or run with -check-examples=false", len(drift))
+		}
+		log.Printf("✓ All command examples parse")
+	}
+	if *format == "examples" {
+		return
+	}
+
 	// Disable auto-generated tag for stable, reproducible files (unless timestamp requested)
 	if !*timestamp {
 		rootCmd.DisableAutoGenTag = true
 	}
 
-	// Enhanced command preparation for better LLM readability
-	prepareCommands(rootCmd)
+	// Look up front matter before preparing commands, which documents deprecated
+	// commands as regular ones
+	annotations := annotationsByFile(rootCmd)
+
+	// Enhanced command preparation for better LLM readability. The JSON schema
+	// describes commands exactly as defined, without generated examples.
+	if *format != "json" {
+		prepareCommands(rootCmd, *format)
+	}
 
 	// Generate documentation based on format
 	switch *format {
 	case "markdown":
-		if err := generateMarkdown(rootCmd, *out, *front); err != nil {
+		if err := generateMarkdown(rootCmd, *out, *front, annotations); err != nil {
 			log.Fatalf("failed to generate markdown: %v", err)
 		}
+		if err := generateTopics(rootCmd, *out, *format, *front); err != nil {
+			log.Fatalf("failed to generate help topics: %v", err)
+		}
 		// Generate index file for markdown format
 		if err := generateIndex(rootCmd, *out, *front); err != nil {
 			log.Fatalf("failed to generate index: %v", err)
@@ -61,23 +94,46 @@ func main() {
 		if err := generateMan(rootCmd, *out); err != nil {
 			log.Fatalf("failed to generate man pages: %v", err)
 		}
+		if err := generateTopics(rootCmd, *out, *format, false); err != nil {
+			log.Fatalf("failed to generate help topics: %v", err)
+		}
 		log.Printf("✓ Generated Man pages in %s", *out)
 
 	case "rest":
 		if err := generateReST(rootCmd, *out); err != nil {
 			log.Fatalf("failed to generate ReStructuredText: %v", err)
 		}
+		if err := generateTopics(rootCmd, *out, *format, false); err != nil {
+			log.Fatalf("failed to generate help topics: %v", err)
+		}
 		log.Printf("✓ Generated ReStructuredText documentation in %s", *out)
 
+	case "completion":
+		if err := generateCompletions(rootCmd, *out); err != nil {
+			log.Fatalf("failed to generate shell completions: %v", err)
+		}
+		log.Printf("✓ Generated shell completion scripts in %s", *out)
+
+	case "json":
+		if err := generateJSON(rootCmd, *out); err != nil {
+			log.Fatalf("failed to generate JSON schema: %v", err)
+		}
+		log.Printf("✓ Generated JSON command schema in %s", filepath.Join(*out, schemaFile))
+
 	default:
-		log.Fatalf("unknown format: %s (must be markdown, man, or rest)", *format)
+		log.Fatalf("unknown format: %s (must be markdown, man, rest, json, completion, or examples)", *format)
 	}
 }
 
 // prepareCommands enhances command definitions for better documentation
-func prepareCommands(cmd *cobra.Command) {
+func prepareCommands(cmd *cobra.Command, format string) {
 	// Walk the command tree and enhance each command
 	for _, c := range cmd.Commands() {
+		// Help topics are documented as written
+		if cmdutil.IsHelpTopic(c) {
+			continue
+		}
+
 		// Ensure examples are present and well-formatted
 		if c.Example == "" {
 			c.Example = generateExampleForCommand(c)
@@ -88,11 +144,52 @@ func prepareCommands(cmd *cobra.Command) {
 			c.Long = c.Short
 		}
 
+		annotateCommand(c, format)
+
 		// Recursively prepare subcommands
-		prepareCommands(c)
+		prepareCommands(c, format)
 	}
 }
 
+// annotateCommand adds the release that introduced a command and any deprecation
+// warning to its description. Markdown carries the release in its front matter.
+// Deprecated commands are documented with the warning rather than left out.
+func annotateCommand(cmd *cobra.Command, format string) {
+	annotation := cmdutil.LookupAnnotation(cmd)
+
+	if cmd.Deprecated != "" {
+		warning := fmt.Sprintf("DEPRECATED: %s", cmd.Deprecated)
+		if format == "markdown" {
+			warning = fmt.Sprintf("> **Deprecated:** %s", cmd.Deprecated)
+		}
+		cmd.Long = warning + "\n\n" + cmd.Long
+		cmd.Deprecated = ""
+	}
+
+	if annotation.Since != "" && format != "markdown" {
+		cmd.Long += fmt.Sprintf("\n\nAvailable since %s.", annotation.Since)
+	}
+}
+
+// annotationsByFile returns the annotation of each documented command, keyed by the
+// name of its generated Markdown file
+func annotationsByFile(cmd *cobra.Command) map[string]cmdutil.CommandAnnotation {
+	annotation := cmdutil.LookupAnnotation(cmd)
+	if cmd.Deprecated != "" {
+		annotation.Deprecated = cmd.Deprecated
+	}
+
+	annotations := map[string]cmdutil.CommandAnnotation{
+		strings.ReplaceAll(cmd.CommandPath(), " ", "_") + ".md": annotation,
+	}
+	for _, c := range cmd.Commands() {
+		for file, annotation := range annotationsByFile(c) {
+			annotations[file] = annotation
+		}
+	}
+	return annotations
+}
+
 // generateExampleForCommand creates example usage for commands that lack them
 func generateExampleForCommand(cmd *cobra.Command) string {
 	if cmd.HasSubCommands() {
@@ -113,7 +210,7 @@ func generateExampleForCommand(cmd *cobra.Command) string {
 }
 
 // generateMarkdown generates Markdown documentation
-func generateMarkdown(rootCmd *cobra.Command, outDir string, withFrontMatter bool) error {
+func generateMarkdown(rootCmd *cobra.Command, outDir string, withFrontMatter bool, annotations map[string]cmdutil.CommandAnnotation) error {
 	if withFrontMatter {
 		// Custom prepender for front matter
 		prepender := func(filename string) string {
@@ -122,6 +219,16 @@ func generateMarkdown(rootCmd *cobra.Command, outDir string, withFrontMatter boo
 			title := strings.ReplaceAll(name, "_", " ")
 			slug := strings.ReplaceAll(strings.ToLower(name), "_", "-")
 
+			// Commands with no recorded release leave out "since" rather than guess
+			annotation := annotations[base]
+			var fields strings.Builder
+			if annotation.Since != "" {
+				fmt.Fprintf(&fields, "since: %s\n", annotation.Since)
+			}
+			if annotation.Deprecated != "" {
+				fields.WriteString("deprecated: true\n")
+			}
+
 			// Generate rich front matter for static site generators
 			return fmt.Sprintf(`---
 title: %q
@@ -129,8 +236,7 @@ slug: "/cli/%s"
 description: "CLI reference for %s"
 section: "CLI Reference"
 man_section: 1
-since: v0.0.0
-keywords:
+%skeywords:
   - zen
   - cli
 ---
@@ -139,6 +245,7 @@ keywords:
 				title,
 				slug,
 				title,
+				fields.String(),
 			)
 		}
 
@@ -167,6 +274,98 @@ func generateMan(rootCmd *cobra.Command, outDir string) error {
 	return doc.GenManTree(rootCmd, header, outDir)
 }
 
+// generateCompletions writes a completion script for each supported shell, the same
+// scripts "zen completion <shell>" prints
+func generateCompletions(rootCmd *cobra.Command, outDir string) error {
+	generators := map[string]func(io.Writer) error{
+		"zen.bash": rootCmd.GenBashCompletion,
+		"_zen":     rootCmd.GenZshCompletion,
+		"zen.fish": func(w io.Writer) error { return rootCmd.GenFishCompletion(w, true) },
+		"zen.ps1":  rootCmd.GenPowerShellCompletionWithDesc,
+	}
+
+	for name, generate := range generators {
+		file, err := os.Create(filepath.Join(outDir, name))
+		if err != nil {
+			return err
+		}
+		if err := generate(file); err != nil {
+			file.Close()
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateTopics writes a page for each help topic, which the cobra generators leave out
+// because topics are not commands
+func generateTopics(rootCmd *cobra.Command, outDir, format string, withFrontMatter bool) error {
+	for _, topic := range rootCmd.Commands() {
+		if !cmdutil.IsHelpTopic(topic) {
+			continue
+		}
+
+		var buf bytes.Buffer
+		switch format {
+		case "markdown":
+			title := "zen help " + topic.Name()
+			if withFrontMatter {
+				fmt.Fprintf(&buf, `---
+title: %q
+slug: "/cli/help/%s"
+description: %q
+section: "CLI Reference"
+keywords:
+  - zen
+  - cli
+  - help
+---
+
+`, title, topic.Name(), topic.Short)
+			}
+			fmt.Fprintf(&buf, "## %s\n\n%s\n\n%s\n", title, topic.Short, topic.Long)
+		case "man":
+			header := &doc.GenManHeader{
+				Title:   strings.ToUpper(rootCmd.Name()),
+				Section: "1",
+				Date:    &time.Time{},
+				Source:  "Zen CLI",
+				Manual:  "Zen CLI Manual",
+			}
+			if err := doc.GenMan(topic, header, &buf); err != nil {
+				return err
+			}
+		case "rest":
+			if err := doc.GenReST(topic, &buf); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+
+		if err := os.WriteFile(filepath.Join(outDir, topicFileName(topic, format)), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// topicFileName returns the name of a help topic's page, following the naming of the
+// cobra generators for commands
+func topicFileName(topic *cobra.Command, format string) string {
+	switch format {
+	case "man":
+		return strings.ReplaceAll(topic.CommandPath(), " ", "-") + ".1"
+	case "rest":
+		return strings.ReplaceAll(topic.CommandPath(), " ", "_") + ".rst"
+	default:
+		return strings.ReplaceAll(topic.CommandPath(), " ", "_") + ".md"
+	}
+}
+
 // generateReST generates ReStructuredText documentation
 func generateReST(rootCmd *cobra.Command, outDir string) error {
 	return doc.GenReSTTree(rootCmd, outDir)
@@ -216,7 +415,7 @@ keywords:
 	futureCommands := []string{}
 
 	for _, cmd := range rootCmd.Commands() {
-		if cmd.Hidden {
+		if cmd.Hidden || cmdutil.IsHelpTopic(cmd) {
 			continue
 		}
 
@@ -267,6 +466,23 @@ keywords:
 		writeCommandEntry(file, cmd, "")
 	}
 
+	// Write Help Topics section
+	var topics []*cobra.Command
+	for _, cmd := range rootCmd.Commands() {
+		if cmdutil.IsHelpTopic(cmd) {
+			topics = append(topics, cmd)
+		}
+	}
+	if len(topics) > 0 {
+		fmt.Fprintln(file, "## Help Topics")
+		fmt.Fprintln(file)
+		for _, cmd := range topics {
+			fmt.Fprintf(file, "### [zen help %s](%s)\n", cmd.Name(), topicFileName(cmd, "markdown"))
+			fmt.Fprintf(file, "%s\n", cmd.Short)
+			fmt.Fprintln(file)
+		}
+	}
+
 	// Write footer with generation info
 	fmt.Fprintln(file, "---")
 	fmt.Fprintln(file)
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// schemaVersion is bumped whenever a field of the JSON schema changes meaning or is removed
+const schemaVersion = 1
+
+// schemaFile is the name of the JSON schema written to the output directory
+const schemaFile = "commands.json"
+
+// Schema is a machine-readable description of the full command tree, for IDE plugins,
+// the docs site, and tools that drive the CLI
+type Schema struct {
+	SchemaVersion int                    `json:"schema_version"`
+	ExitCodes     []cmdutil.ExitCodeInfo `json:"exit_codes"`
+	Command       CommandSchema          `json:"command"`
+}
+
+// CommandSchema describes a command, its flags, and its subcommands
+type CommandSchema struct {
+	Name           string          `json:"name"`
+	Path           string          `json:"path"`
+	Use            string          `json:"use"`
+	Aliases        []string        `json:"aliases,omitempty"`
+	Short          string          `json:"short,omitempty"`
+	Long           string          `json:"long,omitempty"`
+	Since          string          `json:"since,omitempty"`
+	Deprecated     string          `json:"deprecated,omitempty"`
+	Replacement    string          `json:"replacement,omitempty"`
+	Runnable       bool            `json:"runnable"`
+	HelpTopic      bool            `json:"help_topic,omitempty"`
+	Examples       []ExampleSchema `json:"examples,omitempty"`
+	Flags          []FlagSchema    `json:"flags,omitempty"`
+	InheritedFlags []FlagSchema    `json:"inherited_flags,omitempty"`
+	Commands       []CommandSchema `json:"commands,omitempty"`
+}
+
+// ExampleSchema is one command line from a command's examples, with the comment above it
+type ExampleSchema struct {
+	Description string `json:"description,omitempty"`
+	Command     string `json:"command"`
+}
+
+// FlagSchema describes a flag
+type FlagSchema struct {
+	Name       string `json:"name"`
+	Shorthand  string `json:"shorthand,omitempty"`
+	Type       string `json:"type"`
+	Default    string `json:"default,omitempty"`
+	Usage      string `json:"usage"`
+	Required   bool   `json:"required,omitempty"`
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// generateJSON writes the JSON schema of the command tree to the output directory
+func generateJSON(rootCmd *cobra.Command, outDir string) error {
+	schema := Schema{
+		SchemaVersion: schemaVersion,
+		ExitCodes:     cmdutil.ExitCodes,
+		Command:       buildCommandSchema(rootCmd),
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outDir, schemaFile), append(data, '\n'), 0644)
+}
+
+// buildCommandSchema describes a command and, recursively, its visible subcommands,
+// including deprecated ones
+func buildCommandSchema(cmd *cobra.Command) CommandSchema {
+	annotation := cmdutil.LookupAnnotation(cmd)
+	schema := CommandSchema{
+		Name:           cmd.Name(),
+		Path:           cmd.CommandPath(),
+		Use:            cmd.UseLine(),
+		Aliases:        cmd.Aliases,
+		Short:          cmd.Short,
+		Long:           cmd.Long,
+		Since:          annotation.Since,
+		Deprecated:     cmd.Deprecated,
+		Replacement:    annotation.Replacement,
+		Runnable:       cmd.Runnable(),
+		HelpTopic:      cmdutil.IsHelpTopic(cmd),
+		Examples:       parseExamples(cmd.Example),
+		Flags:          buildFlagSchemas(cmd.NonInheritedFlags()),
+		InheritedFlags: buildFlagSchemas(cmd.InheritedFlags()),
+	}
+
+	for _, sub := range cmd.Commands() {
+		if sub.Hidden || (!sub.IsAvailableCommand() && sub.Deprecated == "" && !sub.IsAdditionalHelpTopicCommand()) {
+			continue
+		}
+		schema.Commands = append(schema.Commands, buildCommandSchema(sub))
+	}
+	return schema
+}
+
+// buildFlagSchemas describes the visible flags of a set, in name order
+func buildFlagSchemas(flags *pflag.FlagSet) []FlagSchema {
+	var schemas []FlagSchema
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+
+		_, required := flag.Annotations[cobra.BashCompOneRequiredFlag]
+		schemas = append(schemas, FlagSchema{
+			Name:       flag.Name,
+			Shorthand:  flag.Shorthand,
+			Type:       flag.Value.Type(),
+			Default:    flag.DefValue,
+			Usage:      flag.Usage,
+			Required:   required,
+			Deprecated: flag.Deprecated,
+		})
+	})
+	return schemas
+}
+
+// parseExamples splits an Example block into command lines. A "#" comment describes the
+// command that follows it, and a line ending in "\" continues on the next line:
+//
+//	# Sync from a specific branch
+//	zen assets sync --branch develop
+func parseExamples(text string) []ExampleSchema {
+	var examples []ExampleSchema
+	var description, command string
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case command != "":
+			// Continuation of the previous line
+			command += " " + line
+		case line == "":
+			description = ""
+			continue
+		case strings.HasPrefix(line, "#"):
+			description = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			continue
+		default:
+			command = strings.TrimPrefix(line, "$ ")
+		}
+
+		if strings.HasSuffix(command, "\\") {
+			command = strings.TrimSpace(strings.TrimSuffix(command, "\\"))
+			continue
+		}
+		examples = append(examples, ExampleSchema{Description: description, Command: command})
+		command = ""
+	}
+
+	if command != "" {
+		examples = append(examples, ExampleSchema{Description: description, Command: command})
+	}
+	return examples
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmd/root"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExamples(t *testing.T) {
+	examples := parseExamples(`  # Synchronize asset metadata
+  zen assets sync
+
+  zen assets sync --force
+  $ zen assets list
+
+  # Create a task \ with a long title
+  zen task create PROJ-1 \
+    --title "A long title" \
+    --type story`)
+
+	assert.Equal(t, []ExampleSchema{
+		{Description: "Synchronize asset metadata", Command: "zen assets sync"},
+		{Command: "zen assets sync --force"},
+		{Command: "zen assets list"},
+		{Description: `Create a task \ with a long title`, Command: `zen task create PROJ-1 --title "A long title" --type story`},
+	}, examples)
+
+	assert.Empty(t, parseExamples(""))
+}
+
+func TestGenerateJSON(t *testing.T) {
+	root := &cobra.Command{Use: "zen", Short: "Zen CLI"}
+	root.PersistentFlags().Bool("verbose", false, "Verbose output")
+
+	sync := &cobra.Command{
+		Use:     "sync [flags]",
+		Aliases: []string{"pull"},
+		Short:   "Synchronize assets",
+		Example: "  # Sync assets\n  zen assets sync --force",
+		Run:     func(cmd *cobra.Command, args []string) {},
+	}
+	sync.Flags().BoolP("force", "f", false, "Force refresh")
+	sync.Flags().String("branch", "main", "Branch to synchronize")
+	sync.Flags().String("secret", "", "Hidden flag")
+	require.NoError(t, sync.Flags().MarkHidden("secret"))
+	require.NoError(t, sync.MarkFlagRequired("branch"))
+
+	assets := &cobra.Command{Use: "assets", Short: "Manage assets"}
+	assets.AddCommand(sync, &cobra.Command{Use: "internal", Hidden: true, Run: func(cmd *cobra.Command, args []string) {}})
+	root.AddCommand(assets)
+
+	dir := t.TempDir()
+	require.NoError(t, generateJSON(root, dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, schemaFile))
+	require.NoError(t, err)
+
+	var schema Schema
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	assert.Equal(t, schemaVersion, schema.SchemaVersion)
+	assert.NotEmpty(t, schema.ExitCodes)
+	assert.Equal(t, "zen", schema.Command.Path)
+	assert.False(t, schema.Command.Runnable)
+
+	require.Len(t, schema.Command.Commands, 1)
+	group := schema.Command.Commands[0]
+	require.Len(t, group.Commands, 1, "hidden commands are left out")
+
+	cmd := group.Commands[0]
+	assert.Equal(t, "zen assets sync", cmd.Path)
+	assert.Equal(t, "zen assets sync [flags]", cmd.Use)
+	assert.Equal(t, []string{"pull"}, cmd.Aliases)
+	assert.True(t, cmd.Runnable)
+	assert.Equal(t, []ExampleSchema{{Description: "Sync assets", Command: "zen assets sync --force"}}, cmd.Examples)
+	assert.Equal(t, []FlagSchema{
+		{Name: "branch", Type: "string", Default: "main", Usage: "Branch to synchronize", Required: true},
+		{Name: "force", Shorthand: "f", Type: "bool", Default: "false", Usage: "Force refresh"},
+	}, cmd.Flags)
+	assert.Equal(t, []FlagSchema{
+		{Name: "verbose", Type: "bool", Default: "false", Usage: "Verbose output"},
+	}, cmd.InheritedFlags)
+}
+
+func TestAnnotations(t *testing.T) {
+	cmdutil.CommandAnnotations["zen legacy"] = cmdutil.CommandAnnotation{
+		Since:       "v0.1.0",
+		Deprecated:  "it will be removed in v1.0.0",
+		Replacement: "zen assets sync",
+	}
+	defer delete(cmdutil.CommandAnnotations, "zen legacy")
+
+	root := &cobra.Command{Use: "zen", Short: "Zen CLI"}
+	assets := &cobra.Command{Use: "assets", Short: "Manage assets"}
+	assets.AddCommand(&cobra.Command{Use: "sync", Short: "Synchronize assets", Run: func(cmd *cobra.Command, args []string) {}})
+	root.AddCommand(assets, &cobra.Command{Use: "legacy", Short: "Old sync", Run: func(cmd *cobra.Command, args []string) {}})
+	root.DisableAutoGenTag = true
+
+	cmdutil.ApplyDeprecations(root)
+	legacy, _, err := root.Find([]string{"legacy"})
+	require.NoError(t, err)
+	assert.Equal(t, "it will be removed in v1.0.0; use 'zen assets sync' instead", legacy.Deprecated)
+
+	// The JSON schema carries the release and the deprecation
+	schema := buildCommandSchema(root)
+	require.Len(t, schema.Commands, 2)
+	assert.Empty(t, schema.Since)
+	assert.Equal(t, "v0.2.0", schema.Commands[0].Since)
+	assert.Equal(t, "v0.2.0", schema.Commands[0].Commands[0].Since, "subcommands inherit their parent's release")
+	assert.Equal(t, legacy.Deprecated, schema.Commands[1].Deprecated)
+	assert.Equal(t, "zen assets sync", schema.Commands[1].Replacement)
+
+	// Markdown front matter replaces the placeholder release, and deprecated commands
+	// are documented with a warning
+	annotations := annotationsByFile(root)
+	prepareCommands(root, "markdown")
+	dir := t.TempDir()
+	require.NoError(t, generateMarkdown(root, dir, true, annotations))
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Contains(t, read("zen_assets_sync.md"), "\nsince: v0.2.0\n")
+	assert.NotContains(t, read("zen.md"), "since:")
+	assert.NotContains(t, read("zen.md"), "v0.0.0")
+
+	page := read("zen_legacy.md")
+	assert.Contains(t, page, "\ndeprecated: true\n")
+	assert.Contains(t, page, "> **Deprecated:** it will be removed in v1.0.0; use 'zen assets sync' instead")
+}
+
+func TestAnnotateCommand_Man(t *testing.T) {
+	root := &cobra.Command{Use: "zen"}
+	version := &cobra.Command{Use: "version", Long: "Print the version", Deprecated: "use 'zen --version'"}
+	root.AddCommand(version)
+
+	annotateCommand(version, "man")
+
+	assert.Equal(t, "DEPRECATED: use 'zen --version'\n\nPrint the version\n\nAvailable since v0.1.0.", version.Long)
+	assert.Empty(t, version.Deprecated)
+}
+
+func TestGenerateTopics(t *testing.T) {
+	rootCmd, err := root.Root()
+	require.NoError(t, err)
+	rootCmd.DisableAutoGenTag = true
+
+	prepareCommands(rootCmd, "markdown")
+	topic, _, err := rootCmd.Find([]string{"exit-codes"})
+	require.NoError(t, err)
+	assert.Empty(t, topic.Example, "help topics get no generated examples")
+
+	dir := t.TempDir()
+	require.NoError(t, generateTopics(rootCmd, dir, "markdown", true))
+	require.NoError(t, generateIndex(rootCmd, dir, false))
+
+	page, err := os.ReadFile(filepath.Join(dir, "zen_exit-codes.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "title: \"zen help exit-codes\"")
+	assert.Contains(t, string(page), "## zen help exit-codes\n\nExit codes returned to scripts\n\n")
+	assert.Contains(t, string(page), "- 4 (auth):")
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "## Help Topics")
+	assert.Contains(t, string(index), "### [zen help workflow](zen_workflow.md)")
+	assert.NotContains(t, string(index), "### [zen workflow]", "topics are not listed as commands")
+
+	assert.True(t, buildCommandSchema(topic).HelpTopic)
+}
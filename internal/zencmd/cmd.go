@@ -5,14 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 
+	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/cmd/factory"
 	"github.com/daddia/zen/pkg/cmd/root"
 	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/i18n"
 	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/types"
 )
 
 // Main is the main entry point for the Zen CLI
@@ -22,6 +26,16 @@ func Main() cmdutil.ExitCode {
 		syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	// The first Ctrl-C cancels the context, and long commands stop and report what they
+	// completed; stopping the notification lets a second one end zen at once
+	go func() {
+		<-ctx.Done()
+		cancel()
+	}()
+
+	// Translate human-readable messages for the user's locale
+	i18n.SetLocale(i18n.DetectLocale())
+
 	// Create factory
 	cmdFactory := factory.New()
 	stderr := cmdFactory.IOStreams.ErrOut
@@ -36,14 +50,72 @@ func Main() cmdutil.ExitCode {
 	// Set context
 	rootCmd.SetContext(ctx)
 
-	// Execute command
-	if err := rootCmd.Execute(); err != nil {
+	// Run installed extensions for commands zen does not provide
+	if dispatched, err := dispatchExtension(ctx, cmdFactory, rootCmd, os.Args[1:]); dispatched {
+		if err == nil {
+			return cmdutil.ExitOK
+		}
+		if code, ok := extensionExitCode(err); ok {
+			return code
+		}
+		return handleError(err, cmdFactory)
+	}
+
+	// Execute command, folding its output into a group in CI logs
+	cmdFactory.IOStreams.StartGroup(groupTitle(os.Args[1:]))
+	printVersionNotices := startVersionCheck(ctx, cmdFactory, os.Args[1:])
+	_, err = executeWithSuggestions(rootCmd)
+	cmdFactory.IOStreams.EndGroup()
+	printVersionNotices()
+	if err != nil {
+		if code, handled := offerCorrection(ctx, cmdFactory, os.Args[1:], err); handled {
+			return code
+		}
 		return handleError(err, cmdFactory)
 	}
 
 	return cmdutil.ExitOK
 }
 
+// groupTitle names the CI log group of a command by its subcommands, leaving out flags
+// and everything after them so that no flag values reach the log
+func groupTitle(args []string) string {
+	words := []string{"zen"}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// offerCorrection prompts to re-run a mistyped command with its only suggestion.
+// It reports false when the error has no single correction or prompting is not possible.
+func offerCorrection(ctx context.Context, f *cmdutil.Factory, args []string, err error) (cmdutil.ExitCode, bool) {
+	corrected := correctedArgs(args, err)
+	if corrected == nil || !f.IOStreams.CanPrompt() {
+		return cmdutil.ExitOK, false
+	}
+
+	fmt.Fprintln(f.IOStreams.ErrOut, f.IOStreams.FormatError(err.Error()))
+	if !confirmCorrection(f.IOStreams, corrected) {
+		return cmdutil.ExitError, true
+	}
+
+	rootCmd, rootErr := root.NewCmdRoot(f)
+	if rootErr != nil {
+		return handleError(rootErr, f), true
+	}
+	rootCmd.SetContext(ctx)
+	rootCmd.SetArgs(corrected)
+
+	if _, err := executeWithSuggestions(rootCmd); err != nil {
+		return handleError(err, f), true
+	}
+	return cmdutil.ExitOK, true
+}
+
 // Execute runs a command with the given arguments and streams for testing
 func Execute(ctx context.Context, args []string, streams *iostreams.IOStreams) error {
 	// Create factory
@@ -68,8 +140,14 @@ func Execute(ctx context.Context, args []string, streams *iostreams.IOStreams) e
 		rootCmd.SetErr(streams.ErrOut)
 	}
 
+	// Run installed extensions for commands zen does not provide
+	if dispatched, err := dispatchExtension(ctx, cmdFactory, rootCmd, args); dispatched {
+		return err
+	}
+
 	// Execute command
-	return rootCmd.Execute()
+	_, err = executeWithSuggestions(rootCmd)
+	return err
 }
 
 func handleError(err error, f *cmdutil.Factory) cmdutil.ExitCode {
@@ -97,8 +175,32 @@ func handleError(err error, f *cmdutil.Factory) cmdutil.ExitCode {
 		return cmdutil.ExitOK
 	}
 
-	// Print the error
-	printError(stderr, err, f.IOStreams)
+	// Print the error, keeping it machine-readable and untranslated when structured output was requested
+	if f.OutputFormat == cmdutil.OutputJSON || f.OutputFormat == cmdutil.OutputYAML {
+		printStructuredError(stderr, err, f.OutputFormat)
+	} else {
+		printError(stderr, err, f.IOStreams)
+	}
+
+	f.IOStreams.Annotate(iostreams.AnnotationError, err.Error())
+
+	return ExitCodeOf(err)
+}
+
+// ExitCodeOf returns the exit code of a command that returned err
+func ExitCodeOf(err error) cmdutil.ExitCode {
+	if err == nil {
+		return cmdutil.ExitOK
+	}
+
+	if cmdutil.IsUserCancellation(err) {
+		return cmdutil.ExitCancel
+	}
+
+	var noResultsError cmdutil.NoResultsError
+	if errors.As(err, &noResultsError) {
+		return cmdutil.ExitOK
+	}
 
 	// Check for flag errors
 	var flagError *cmdutil.FlagError
@@ -106,9 +208,78 @@ func handleError(err error, f *cmdutil.Factory) cmdutil.ExitCode {
 		return cmdutil.ExitError
 	}
 
+	var codeError *cmdutil.ExitCodeError
+	if errors.As(err, &codeError) {
+		return codeError.Code
+	}
+
+	if isAuthError(err) {
+		return cmdutil.ExitAuth
+	}
+
+	if isConfigError(err) {
+		return cmdutil.ExitConfig
+	}
+
 	return cmdutil.ExitError
 }
 
+// isConfigError reports whether an error means the configuration is missing or invalid
+func isConfigError(err error) bool {
+	var zenErr *types.Error
+	if errors.As(err, &zenErr) {
+		return zenErr.Code == types.ErrorCodeInvalidConfig || zenErr.Code == types.ErrorCodeConfigNotFound
+	}
+	return false
+}
+
+// isAuthError reports whether an error means authentication is missing or was rejected
+func isAuthError(err error) bool {
+	var authErr *auth.Error
+	if errors.As(err, &authErr) {
+		return true
+	}
+
+	var zenErr *types.Error
+	if errors.As(err, &zenErr) {
+		return zenErr.Code == types.ErrorCodeAuthenticationFailed
+	}
+
+	return false
+}
+
+// structuredError is the document written to stderr for failures under --output json|yaml
+type structuredError struct {
+	Error struct {
+		Code    string `json:"code" yaml:"code"`
+		Message string `json:"message" yaml:"message"`
+		Details string `json:"details,omitempty" yaml:"details,omitempty"`
+	} `json:"error" yaml:"error"`
+}
+
+func printStructuredError(out io.Writer, err error, format string) {
+	var doc structuredError
+	doc.Error.Code = string(types.ErrorCodeUnknown)
+	doc.Error.Message = err.Error()
+
+	var zenErr *types.Error
+	var authErr *auth.Error
+	switch {
+	case errors.As(err, &zenErr):
+		doc.Error.Code = string(zenErr.Code)
+		doc.Error.Message = zenErr.Message
+		doc.Error.Details = zenErr.Details
+	case errors.As(err, &authErr):
+		doc.Error.Code = string(types.ErrorCodeAuthenticationFailed)
+		doc.Error.Message = authErr.Message
+	}
+
+	renderer := cmdutil.NewRenderer(&iostreams.IOStreams{Out: out}, format)
+	if renderErr := renderer.Render(doc, nil); renderErr != nil {
+		fmt.Fprintln(out, err.Error())
+	}
+}
+
 func printError(out io.Writer, err error, iostreams interface {
 	FormatError(string) string
 }) {
@@ -136,26 +307,26 @@ func getErrorSuggestion(err error) string {
 
 	switch {
 	case strings.Contains(errMsg, "config") && strings.Contains(errMsg, "not found"):
-		return "Run 'zen config' to check your configuration or 'zen init' to initialize a workspace"
+		return i18n.T("suggestion.config_not_found")
 	case strings.Contains(errMsg, "config") && strings.Contains(errMsg, "invalid"):
-		return "Check your configuration file syntax with 'zen config validate'"
+		return i18n.T("suggestion.config_invalid")
 	case strings.Contains(errMsg, "workspace") && strings.Contains(errMsg, "not found"):
-		return "Run 'zen init' to initialize a new workspace in this directory"
+		return i18n.T("suggestion.workspace_not_found")
 	case strings.Contains(errMsg, "workspace") && strings.Contains(errMsg, "invalid"):
-		return "Check workspace structure with 'zen status' or reinitialize with 'zen init --force'"
+		return i18n.T("suggestion.workspace_invalid")
 	case strings.Contains(errMsg, "permission"):
-		return "Check file permissions or try running with appropriate privileges"
+		return i18n.T("suggestion.permission")
 	case strings.Contains(errMsg, "unknown flag"):
-		return "Use 'zen --help' to see available flags and options"
+		return i18n.T("suggestion.unknown_flag")
 	case strings.Contains(errMsg, "unknown command"):
-		return "Use 'zen --help' to see available commands"
+		return i18n.T("suggestion.unknown_command")
 	case strings.Contains(errMsg, "network") || strings.Contains(errMsg, "connection"):
-		return "Check your internet connection and try again"
+		return i18n.T("suggestion.network")
 	case strings.Contains(errMsg, "timeout"):
-		return "The operation timed out. Try again or check network connectivity"
+		return i18n.T("suggestion.timeout")
 	case strings.Contains(errMsg, "authentication") || strings.Contains(errMsg, "auth"):
-		return "Check your credentials or run authentication setup"
+		return i18n.T("suggestion.auth")
 	default:
-		return "Use 'zen --help' for usage information or check the documentation at https://zen.dev/docs"
+		return i18n.T("suggestion.generic")
 	}
 }
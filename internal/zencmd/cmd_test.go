@@ -3,15 +3,19 @@ package zencmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/cmd/factory"
 	"github.com/daddia/zen/pkg/cmd/root"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -664,6 +668,78 @@ func TestHandleError_EdgeCases(t *testing.T) {
 	assert.Contains(t, stderr, "flag parsing failed")
 }
 
+func TestHandleError_AuthError(t *testing.T) {
+	streams := iostreams.Test()
+	factory := cmdutil.NewTestFactory(streams)
+
+	authErr := auth.NewAuthError(auth.ErrorCodeTokenExpired, "token expired", "github")
+	assert.Equal(t, cmdutil.ExitAuth, handleError(fmt.Errorf("sync failed: %w", authErr), factory))
+
+	zenErr := &types.Error{Code: types.ErrorCodeAuthenticationFailed, Message: "authentication failed"}
+	assert.Equal(t, cmdutil.ExitAuth, handleError(zenErr, factory))
+}
+
+func TestHandleError_ExitCodes(t *testing.T) {
+	streams := iostreams.Test()
+	factory := cmdutil.NewTestFactory(streams)
+
+	configErr := &types.Error{Code: types.ErrorCodeInvalidConfig, Message: "invalid configuration"}
+	assert.Equal(t, cmdutil.ExitConfig, handleError(fmt.Errorf("load: %w", configErr), factory))
+
+	partialErr := &cmdutil.ExitCodeError{Code: cmdutil.ExitPartial, Err: errors.New("1 of 3 failed")}
+	assert.Equal(t, cmdutil.ExitPartial, handleError(fmt.Errorf("task sync: %w", partialErr), factory))
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "task sync: 1 of 3 failed")
+}
+
+func TestExitCodeOf(t *testing.T) {
+	assert.Equal(t, cmdutil.ExitOK, ExitCodeOf(nil))
+	assert.Equal(t, cmdutil.ExitOK, ExitCodeOf(cmdutil.NoResultsError{}))
+	assert.Equal(t, cmdutil.ExitCancel, ExitCodeOf(errors.New("interrupted")))
+	assert.Equal(t, cmdutil.ExitAuth, ExitCodeOf(&auth.Error{Code: auth.ErrorCodeTokenExpired}))
+	assert.Equal(t, cmdutil.ExitPartial, ExitCodeOf(&cmdutil.ExitCodeError{Code: cmdutil.ExitPartial, Err: errors.New("1 of 3 failed")}))
+	assert.Equal(t, cmdutil.ExitError, ExitCodeOf(errors.New("boom")))
+}
+
+func TestHandleError_Annotation(t *testing.T) {
+	streams := iostreams.Test()
+	streams.SetAnnotationsEnabled(true)
+	factory := cmdutil.NewTestFactory(streams)
+
+	handleError(errors.New("sync failed"), factory)
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "::error::sync failed\n")
+}
+
+func TestGroupTitle(t *testing.T) {
+	assert.Equal(t, "zen task sync PROJ-1", groupTitle([]string{"task", "sync", "PROJ-1", "--direction", "pull"}))
+	assert.Equal(t, "zen auth", groupTitle([]string{"auth", "--token", "secret", "github"}))
+	assert.Equal(t, "zen", groupTitle(nil))
+}
+
+func TestHandleError_StructuredOutput(t *testing.T) {
+	streams := iostreams.Test()
+	factory := cmdutil.NewTestFactory(streams)
+	factory.OutputFormat = cmdutil.OutputJSON
+
+	err := &types.Error{Code: types.ErrorCodeNotFound, Message: "task not found", Details: "PROJ-1"}
+	assert.Equal(t, cmdutil.ExitError, handleError(err, factory))
+
+	assert.Empty(t, streams.Out.(*bytes.Buffer).String())
+
+	var doc map[string]map[string]string
+	require.NoError(t, json.Unmarshal(streams.ErrOut.(*bytes.Buffer).Bytes(), &doc))
+	assert.Equal(t, "NOT_FOUND", doc["error"]["code"])
+	assert.Equal(t, "task not found", doc["error"]["message"])
+	assert.Equal(t, "PROJ-1", doc["error"]["details"])
+}
+
+func TestExecute_InvalidOutputFormat(t *testing.T) {
+	err := Execute(context.Background(), []string{"--output", "xml", "status"}, iostreams.Test())
+	require.Error(t, err)
+
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, err, &flagErr)
+}
+
 func TestExecute_RootCommandCreationError(t *testing.T) {
 	// Test the error path in Execute when root command creation fails
 	// This is difficult to trigger in practice, but we can test the path exists
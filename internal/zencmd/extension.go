@@ -0,0 +1,85 @@
+package zencmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// dispatchExtension runs an installed extension when the first argument is not a built-in command.
+// It reports whether an extension was run.
+func dispatchExtension(ctx context.Context, f *cmdutil.Factory, rootCmd *cobra.Command, args []string) (bool, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || strings.HasPrefix(args[0], "__") || args[0] == "help" {
+		return false, nil
+	}
+	if cmd, _, err := rootCmd.Find(args); err == nil && cmd != rootCmd {
+		return false, nil
+	}
+	if f.ExtensionManager == nil {
+		return false, nil
+	}
+
+	manager, err := f.ExtensionManager()
+	if err != nil {
+		return false, nil
+	}
+	ext, err := manager.Get(ctx, args[0])
+	if err != nil {
+		return false, nil
+	}
+
+	f.Logger.Debug("running extension", "name", ext.Name, "path", ext.Path)
+
+	cmd := exec.CommandContext(ctx, ext.Path, args[1:]...)
+	cmd.Stdin = f.IOStreams.In
+	cmd.Stdout = f.IOStreams.Out
+	cmd.Stderr = f.IOStreams.ErrOut
+	cmd.Env = append(os.Environ(), extensionEnv(f)...)
+
+	return true, cmd.Run()
+}
+
+// extensionEnv describes the zen environment to extensions
+func extensionEnv(f *cmdutil.Factory) []string {
+	env := []string{"ZEN_VERSION=" + f.AppVersion}
+
+	if executable, err := os.Executable(); err == nil {
+		env = append(env, "ZEN_BIN="+executable)
+	}
+
+	configFile := f.ConfigFile
+	if configFile == "" && f.Config != nil {
+		if cfg, err := f.Config(); err == nil {
+			configFile = cfg.GetConfigFile()
+		}
+	}
+	if configFile != "" {
+		env = append(env, "ZEN_CONFIG_FILE="+configFile)
+	}
+
+	if f.WorkspaceManager != nil {
+		if ws, err := f.WorkspaceManager(); err == nil {
+			if status, err := ws.Status(); err == nil && status.Initialized {
+				env = append(env, "ZEN_WORKSPACE="+status.Root)
+			}
+		}
+	}
+
+	if !f.IOStreams.ColorEnabled() {
+		env = append(env, "NO_COLOR=1")
+	}
+
+	return env
+}
+
+// extensionExitCode returns the exit code of a failed extension process
+func extensionExitCode(err error) (cmdutil.ExitCode, bool) {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return cmdutil.ExitCode(exitErr.ExitCode()), true
+	}
+	return cmdutil.ExitOK, false
+}
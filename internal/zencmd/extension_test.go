@@ -0,0 +1,60 @@
+package zencmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// installTestExtension installs a binary extension running script into a temporary extensions directory
+func installTestExtension(t *testing.T, name, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script extensions are not supported on Windows")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("ZEN_EXTENSIONS_DIR", dir)
+
+	extDir := filepath.Join(dir, "zen-"+name)
+	require.NoError(t, os.MkdirAll(extDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(extDir, "zen-"+name), []byte(script), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(extDir, "manifest.yaml"), []byte("owner: acme\nrepo: zen-"+name+"\ntag: v1.0.0\n"), 0644))
+}
+
+func TestExecute_DispatchesExtension(t *testing.T) {
+	installTestExtension(t, "hello", "#!/bin/sh\necho \"args=$* version=$ZEN_VERSION\"\n")
+	streams := iostreams.Test()
+
+	require.NoError(t, Execute(context.Background(), []string{"hello", "world", "--flag"}, streams))
+	assert.Contains(t, streams.Out.(*bytes.Buffer).String(), "args=world --flag version=")
+}
+
+func TestExecute_ExtensionExitCode(t *testing.T) {
+	installTestExtension(t, "hello", "#!/bin/sh\nexit 3\n")
+
+	err := Execute(context.Background(), []string{"hello"}, iostreams.Test())
+	require.Error(t, err)
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	code, ok := extensionExitCode(err)
+	assert.True(t, ok)
+	assert.EqualValues(t, 3, code)
+}
+
+func TestExecute_BuiltinCommandsWinOverExtensions(t *testing.T) {
+	installTestExtension(t, "version", "#!/bin/sh\necho extension\n")
+
+	streams := iostreams.Test()
+	require.NoError(t, Execute(context.Background(), []string{"version"}, streams))
+	assert.NotContains(t, streams.Out.(*bytes.Buffer).String(), "extension")
+}
@@ -0,0 +1,241 @@
+package zencmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// maxSuggestionDistance is the largest edit distance considered a typo
+	maxSuggestionDistance = 2
+	// maxSuggestions limits how many alternatives are listed
+	maxSuggestions = 3
+)
+
+var unknownFlagPattern = regexp.MustCompile(`^unknown flag: --([^\s=]+)`)
+
+// unknownError reports an unknown command or flag together with the closest valid names
+type unknownError struct {
+	message     string
+	typo        string
+	suggestions []string
+}
+
+func (e *unknownError) Error() string {
+	if len(e.suggestions) == 0 {
+		return e.message
+	}
+
+	quoted := make([]string, len(e.suggestions))
+	for i, s := range e.suggestions {
+		quoted[i] = "'" + s + "'"
+	}
+	if len(quoted) == 1 {
+		return fmt.Sprintf("%s — did you mean %s?", e.message, quoted[0])
+	}
+	return fmt.Sprintf("%s — did you mean one of %s?", e.message, strings.Join(quoted, ", "))
+}
+
+// executeWithSuggestions runs the root command, adding "did you mean" hints to
+// unknown command and flag errors. It returns the command that failed.
+func executeWithSuggestions(rootCmd *cobra.Command) (*cobra.Command, error) {
+	// Cobra's own suggestions only cover top-level commands; ours cover every level and flags
+	rootCmd.DisableSuggestions = true
+	rejectUnknownSubcommands(rootCmd)
+
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil && cmd != nil {
+		err = addSuggestions(cmd, err)
+	}
+	return cmd, err
+}
+
+// rejectUnknownSubcommands makes command groups fail on unknown subcommands instead of printing help
+func rejectUnknownSubcommands(cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		rejectUnknownSubcommands(sub)
+	}
+
+	if cmd == cmd.Root() || !cmd.HasSubCommands() || cmd.Runnable() {
+		return
+	}
+
+	cmd.Args = cobra.ArbitraryArgs
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return c.Help()
+		}
+		return fmt.Errorf("unknown command %q for %q", args[0], c.CommandPath())
+	}
+}
+
+// addSuggestions replaces unknown command and flag errors with an unknownError listing the closest matches
+func addSuggestions(cmd *cobra.Command, err error) error {
+	msg := err.Error()
+
+	if strings.HasPrefix(msg, "unknown command ") {
+		typo := unknownCommandName(msg)
+		if typo == "" {
+			return err
+		}
+		return &unknownError{
+			message:     fmt.Sprintf("unknown command '%s' for '%s'", typo, cmd.CommandPath()),
+			typo:        typo,
+			suggestions: closestMatches(typo, commandNames(cmd)),
+		}
+	}
+
+	if m := unknownFlagPattern.FindStringSubmatch(msg); m != nil {
+		var suggestions []string
+		for _, name := range closestMatches(m[1], flagNames(cmd)) {
+			suggestions = append(suggestions, "--"+name)
+		}
+		return &unknownError{
+			message:     msg,
+			typo:        "--" + m[1],
+			suggestions: suggestions,
+		}
+	}
+
+	return err
+}
+
+// unknownCommandName extracts the command name from cobra's `unknown command "x" for "y"` error
+func unknownCommandName(msg string) string {
+	start := strings.Index(msg, `"`)
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(msg[start+1:], `"`)
+	if end < 0 {
+		return ""
+	}
+	return msg[start+1 : start+1+end]
+}
+
+// commandNames maps each available subcommand to the names it can be invoked by
+func commandNames(cmd *cobra.Command) map[string][]string {
+	names := make(map[string][]string)
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() {
+			names[sub.Name()] = append([]string{sub.Name()}, sub.Aliases...)
+		}
+	}
+	return names
+}
+
+// flagNames maps each visible flag, local or inherited, to its name
+func flagNames(cmd *cobra.Command) map[string][]string {
+	names := make(map[string][]string)
+	collect := func(f *pflag.Flag) {
+		if !f.Hidden {
+			names[f.Name] = []string{f.Name}
+		}
+	}
+	cmd.Flags().VisitAll(collect)
+	cmd.InheritedFlags().VisitAll(collect)
+	return names
+}
+
+// closestMatches returns the candidates with a spelling within a small edit distance of typo,
+// or that typo is a prefix of, ordered from closest to furthest
+func closestMatches(typo string, candidates map[string][]string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+
+	typo = strings.ToLower(typo)
+	var matches []match
+	for name, spellings := range candidates {
+		best := -1
+		for _, spelling := range spellings {
+			spelling = strings.ToLower(spelling)
+			distance := levenshtein(typo, spelling)
+			if distance > maxSuggestionDistance && !strings.HasPrefix(spelling, typo) {
+				continue
+			}
+			if best < 0 || distance < best {
+				best = distance
+			}
+		}
+		if best >= 0 {
+			matches = append(matches, match{name, best})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var names []string
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// levenshtein returns the edit distance between a and b, counting adjacent transpositions as one edit
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+// correctedArgs returns args with a mistyped command replaced by its only suggestion,
+// or nil when the error cannot be corrected automatically
+func correctedArgs(args []string, err error) []string {
+	var unknown *unknownError
+	if !errors.As(err, &unknown) || len(unknown.suggestions) != 1 || strings.HasPrefix(unknown.typo, "-") {
+		return nil
+	}
+
+	for i, arg := range args {
+		if arg == unknown.typo {
+			corrected := append([]string{}, args...)
+			corrected[i] = unknown.suggestions[0]
+			return corrected
+		}
+	}
+	return nil
+}
+
+// confirmCorrection asks whether to run the corrected command
+func confirmCorrection(streams *iostreams.IOStreams, args []string) bool {
+	fmt.Fprintf(streams.ErrOut, "Run 'zen %s' instead? [y/N] ", strings.Join(args, " "))
+
+	answer, _ := bufio.NewReader(streams.In).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,96 @@
+package zencmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"task", "task", 0},
+		{"tsak", "task", 1}, // transposition
+		{"tas", "task", 1},
+		{"stauts", "status", 1},
+		{"asset", "assets", 1},
+		{"", "abc", 3},
+		{"config", "version", 6},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, levenshtein(tt.a, tt.b), "%s -> %s", tt.a, tt.b)
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	candidates := map[string][]string{
+		"list":   {"list", "ls"},
+		"create": {"create"},
+		"status": {"status"},
+	}
+
+	assert.Equal(t, []string{"list"}, closestMatches("lst", candidates), "aliases collapse into the command name")
+	assert.Equal(t, []string{"create"}, closestMatches("cre", candidates), "prefixes match")
+	assert.Equal(t, []string{"status"}, closestMatches("STATSU", candidates))
+	assert.Empty(t, closestMatches("deploy", candidates))
+}
+
+func TestExecute_Suggestions(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"top-level command", []string{"tsak"}, "unknown command 'tsak' for 'zen' — did you mean one of 'task', 'team'?"},
+		{"subcommand", []string{"task", "lsit"}, "unknown command 'lsit' for 'zen task' — did you mean 'list'?"},
+		{"flag", []string{"version", "--verbos"}, "unknown flag: --verbos — did you mean '--verbose'?"},
+		{"no match", []string{"deploy"}, "unknown command 'deploy' for 'zen'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Execute(context.Background(), tt.args, iostreams.Test())
+			require.Error(t, err)
+			assert.Equal(t, tt.want, err.Error())
+		})
+	}
+}
+
+func TestExecute_CommandGroupWithoutArgsShowsHelp(t *testing.T) {
+	streams := iostreams.Test()
+
+	require.NoError(t, Execute(context.Background(), []string{"task"}, streams))
+	assert.Contains(t, streams.Out.(*bytes.Buffer).String(), "Available Commands:")
+}
+
+func TestCorrectedArgs(t *testing.T) {
+	single := &unknownError{message: "unknown command", typo: "tsak", suggestions: []string{"task"}}
+	multiple := &unknownError{message: "unknown command", typo: "st", suggestions: []string{"status", "start"}}
+	flag := &unknownError{message: "unknown flag", typo: "--verbos", suggestions: []string{"--verbose"}}
+
+	assert.Equal(t, []string{"task", "list", "--type", "bug"}, correctedArgs([]string{"tsak", "list", "--type", "bug"}, single))
+	assert.Nil(t, correctedArgs([]string{"st"}, multiple))
+	assert.Nil(t, correctedArgs([]string{"--verbos"}, flag))
+	assert.Nil(t, correctedArgs([]string{"tsak"}, errors.New("other")))
+}
+
+func TestConfirmCorrection(t *testing.T) {
+	streams := iostreams.Test()
+	streams.In = io.NopCloser(strings.NewReader("y\n"))
+
+	assert.True(t, confirmCorrection(streams, []string{"task", "list"}))
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "Run 'zen task list' instead?")
+
+	streams.In = io.NopCloser(strings.NewReader("\n"))
+	assert.False(t, confirmCorrection(streams, []string{"task", "list"}))
+}
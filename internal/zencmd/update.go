@@ -0,0 +1,77 @@
+package zencmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/daddia/zen/pkg/cmd/upgrade"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/update"
+)
+
+// checkForUpdate looks up a release newer than the running zen for the update notice
+var checkForUpdate = upgrade.CheckForUpdate
+
+// requiredVersion returns the zen version the workspace asset library requires, when
+// the running zen is older than it
+var requiredVersion = upgrade.RequiredVersion
+
+// notifyUpdates reports whether commands mention newer releases: only to people at a
+// terminal, never in scripts or CI
+var notifyUpdates = func(streams *iostreams.IOStreams) bool {
+	return streams.IsStderrTTY() && !streams.IsNonInteractive()
+}
+
+// skipVersionCheck lists the commands that never print version notices: those that
+// report on versions themselves, and shell completion, whose output the shell reads
+var skipVersionCheck = map[string]bool{
+	"upgrade":          true,
+	"version":          true,
+	"completion":       true,
+	"__complete":       true,
+	"__completeNoDesc": true,
+}
+
+// startVersionCheck starts looking up a newer release in the background while a command
+// runs. The returned function prints any notices once the command has finished: a newer
+// release only to people at a terminal, and an asset library that requires a newer zen
+// everywhere, including as a CI warning.
+func startVersionCheck(ctx context.Context, f *cmdutil.Factory, args []string) func() {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if skipVersionCheck[arg] {
+			return func() {}
+		}
+		break
+	}
+
+	var latest chan *update.Release
+	if notifyUpdates(f.IOStreams) {
+		latest = make(chan *update.Release, 1)
+		go func() {
+			release, err := checkForUpdate(ctx, f)
+			if err != nil {
+				f.Logger.Debug("update check failed", "error", err)
+			}
+			latest <- release
+		}()
+	}
+
+	return func() {
+		if minimum := requiredVersion(f); minimum != "" {
+			fmt.Fprint(f.IOStreams.ErrOut, upgrade.RequirementNotice(f.IOStreams, minimum, f.AppVersion))
+			f.IOStreams.Annotate(iostreams.AnnotationWarning, upgrade.RequirementMessage(minimum, f.AppVersion))
+			return
+		}
+		if latest == nil {
+			return
+		}
+		if release := <-latest; release != nil {
+			fmt.Fprint(f.IOStreams.ErrOut, upgrade.Notice(f.IOStreams, f.AppVersion, release))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package zencmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/update"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartVersionCheck(t *testing.T) {
+	origNotify, origCheck, origRequired := notifyUpdates, checkForUpdate, requiredVersion
+	t.Cleanup(func() { notifyUpdates, checkForUpdate, requiredVersion = origNotify, origCheck, origRequired })
+
+	latest, err := update.ParseVersion("1.4.0")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		args     []string
+		notify   bool
+		required string
+		checked  bool
+		want     []string
+	}{
+		{name: "newer release at a terminal", args: []string{"status"}, notify: true, checked: true,
+			want: []string{"A new release of zen is available: v1.3.0 → v1.4.0", "Run 'zen upgrade' to install it"}},
+		{name: "not at a terminal", args: []string{"status"}},
+		{name: "skipped command", args: []string{"--verbose", "completion", "bash"}, notify: true},
+		{name: "asset library requires a newer zen", args: []string{"assets", "list"}, required: "1.5.0",
+			want: []string{"The asset library requires zen 1.5.0 or later; this is zen 1.3.0", "Run 'zen upgrade'"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checked := false
+			notifyUpdates = func(*iostreams.IOStreams) bool { return tt.notify }
+			checkForUpdate = func(ctx context.Context, f *cmdutil.Factory) (*update.Release, error) {
+				checked = true
+				return &update.Release{Version: latest, Tag: "v1.4.0"}, nil
+			}
+			requiredVersion = func(*cmdutil.Factory) string { return tt.required }
+
+			streams := iostreams.Test()
+			f := cmdutil.NewTestFactory(streams)
+			f.AppVersion = "1.3.0"
+
+			startVersionCheck(context.Background(), f, tt.args)()
+
+			stderr := streams.ErrOut.(*bytes.Buffer).String()
+			if len(tt.want) == 0 {
+				assert.Empty(t, stderr)
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, stderr, want)
+			}
+			assert.Equal(t, tt.checked, checked)
+		})
+	}
+}
+
+func TestStartVersionCheck_AnnotatesRequirement(t *testing.T) {
+	origRequired := requiredVersion
+	t.Cleanup(func() { requiredVersion = origRequired })
+	requiredVersion = func(*cmdutil.Factory) string { return "2.0.0" }
+
+	streams := iostreams.Test()
+	streams.SetAnnotationsEnabled(true)
+	f := cmdutil.NewTestFactory(streams)
+	f.AppVersion = "1.3.0"
+
+	startVersionCheck(context.Background(), f, []string{"assets", "sync"})()
+
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(),
+		"::warning::The asset library requires zen 2.0.0 or later; this is zen 1.3.0\n")
+}
@@ -0,0 +1,320 @@
+// Package apiserver serves the core operations of a workspace over a local HTTP+JSON
+// API, so editor plugins and dashboards can work with tasks, assets, and status
+// without running the zen command:
+//
+//	GET    /v1/health             liveness, without authentication
+//	GET    /v1/status             status overview, ?sections=tasks,assets
+//	GET    /v1/tasks              tasks, ?type= &status= &owner= &team= &stage= &label=
+//	POST   /v1/tasks              create a task from a task.CreateTaskRequest
+//	GET    /v1/tasks/{id}         one task
+//	PATCH  /v1/tasks/{id}         update a task with a task.TaskUpdates
+//	DELETE /v1/tasks/{id}         delete a task from the workspace
+//	POST   /v1/tasks/{id}/sync    sync a task with its sources
+//	POST   /v1/sync               sync every task
+//	GET    /v1/assets             assets, ?type= &category= &tags= &limit= &offset=
+//
+// Every other request must carry the server token as "Authorization: Bearer <token>".
+// Errors are returned as {"error": {"code", "message", "details"}} with the error codes
+// of the types package.
+package apiserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// maxBodyBytes bounds the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// Backend performs the operations the API exposes
+type Backend interface {
+	// Status returns the named sections of the status overview, or every section
+	Status(ctx context.Context, sections []string) (interface{}, error)
+
+	ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error)
+	GetTask(ctx context.Context, taskID string) (*task.Task, error)
+	CreateTask(ctx context.Context, request *task.CreateTaskRequest) (*task.Task, error)
+	UpdateTask(ctx context.Context, taskID string, updates *task.TaskUpdates) (*task.Task, error)
+	DeleteTask(ctx context.Context, taskID string) error
+	SyncTask(ctx context.Context, taskID string, opts *task.SyncOptions) (*task.SyncResult, error)
+	SyncAllTasks(ctx context.Context, opts *task.SyncOptions) ([]*task.SyncResult, error)
+
+	ListAssets(ctx context.Context, filter assets.AssetFilter) (*assets.AssetList, error)
+}
+
+// Handler serves the API of a backend
+type Handler struct {
+	backend Backend
+	token   string
+	version string
+	mux     *http.ServeMux
+}
+
+// NewHandler returns a handler serving backend to clients that present token, labelled
+// with the zen version
+func NewHandler(backend Backend, token, version string) *Handler {
+	h := &Handler{backend: backend, token: token, version: version, mux: http.NewServeMux()}
+
+	h.mux.HandleFunc("GET /v1/health", h.health)
+	h.mux.HandleFunc("GET /v1/status", h.status)
+	h.mux.HandleFunc("GET /v1/tasks", h.listTasks)
+	h.mux.HandleFunc("POST /v1/tasks", h.createTask)
+	h.mux.HandleFunc("GET /v1/tasks/{id}", h.getTask)
+	h.mux.HandleFunc("PATCH /v1/tasks/{id}", h.updateTask)
+	h.mux.HandleFunc("DELETE /v1/tasks/{id}", h.deleteTask)
+	h.mux.HandleFunc("POST /v1/tasks/{id}/sync", h.syncTask)
+	h.mux.HandleFunc("POST /v1/sync", h.syncAll)
+	h.mux.HandleFunc("GET /v1/assets", h.listAssets)
+	h.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &types.Error{Code: types.ErrorCodeNotFound, Message: "no such endpoint: " + r.URL.Path})
+	})
+
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/health" && !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="zen"`)
+		writeError(w, &types.Error{
+			Code:    types.ErrorCodeAuthenticationFailed,
+			Message: "missing or invalid API token",
+		})
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// authorized reports whether r carries the token of the server
+func (h *Handler) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && h.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
+
+func (h *Handler) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok", "version": h.version})
+}
+
+func (h *Handler) status(w http.ResponseWriter, r *http.Request) {
+	status, err := h.backend.Status(r.Context(), splitList(r.URL.Query().Get("sections")))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (h *Handler) listTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	tasks, err := h.backend.ListTasks(r.Context(), &task.TaskFilter{
+		Type:   query.Get("type"),
+		Status: query.Get("status"),
+		Owner:  query.Get("owner"),
+		Team:   query.Get("team"),
+		Stage:  query.Get("stage"),
+		Labels: query["label"],
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tasks": tasks, "total": len(tasks)})
+}
+
+func (h *Handler) createTask(w http.ResponseWriter, r *http.Request) {
+	var request task.CreateTaskRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	created, err := h.backend.CreateTask(r.Context(), &request)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (h *Handler) getTask(w http.ResponseWriter, r *http.Request) {
+	found, err := h.backend.GetTask(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, found)
+}
+
+func (h *Handler) updateTask(w http.ResponseWriter, r *http.Request) {
+	var updates task.TaskUpdates
+	if !decodeBody(w, r, &updates) {
+		return
+	}
+	updated, err := h.backend.UpdateTask(r.Context(), r.PathValue("id"), &updates)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (h *Handler) deleteTask(w http.ResponseWriter, r *http.Request) {
+	if err := h.backend.DeleteTask(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) syncTask(w http.ResponseWriter, r *http.Request) {
+	opts, ok := decodeSyncOptions(w, r)
+	if !ok {
+		return
+	}
+	result, err := h.backend.SyncTask(r.Context(), r.PathValue("id"), opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) syncAll(w http.ResponseWriter, r *http.Request) {
+	opts, ok := decodeSyncOptions(w, r)
+	if !ok {
+		return
+	}
+	results, err := h.backend.SyncAllTasks(r.Context(), opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results, "total": len(results)})
+}
+
+func (h *Handler) listAssets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := assets.AssetFilter{
+		Type:      assets.AssetType(query.Get("type")),
+		Category:  query.Get("category"),
+		Tags:      splitList(query.Get("tags")),
+		PageToken: query.Get("page_token"),
+	}
+	for name, value := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			writeError(w, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("invalid %s: %q", name, raw),
+			})
+			return
+		}
+		*value = n
+	}
+
+	list, err := h.backend.ListAssets(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// decodeSyncOptions reads the sync options of a request over the defaults of
+// "zen task sync": both directions, with conflicts resolved by timestamp
+func decodeSyncOptions(w http.ResponseWriter, r *http.Request) (*task.SyncOptions, bool) {
+	opts := &task.SyncOptions{
+		Direction:        task.SyncDirectionBidirectional,
+		ConflictStrategy: task.ConflictStrategyTimestamp,
+	}
+	if r.ContentLength == 0 {
+		return opts, true
+	}
+	return opts, decodeBody(w, r, opts)
+}
+
+// decodeBody reads the JSON body of r into v, answering with an error when it cannot
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "invalid request body",
+			Details: err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// splitList splits a comma-separated query value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// errorBody is the body of error responses
+type errorBody struct {
+	Error types.Error `json:"error"`
+}
+
+// writeError answers with err and the HTTP status of its code. Errors without a code
+// are reported as UNKNOWN with status 500.
+func writeError(w http.ResponseWriter, err error) {
+	zenErr := types.Error{Code: types.ErrorCodeUnknown, Message: err.Error()}
+	var typed *types.Error
+	if errors.As(err, &typed) {
+		zenErr = *typed
+	}
+	writeJSON(w, StatusCode(zenErr.Code), errorBody{Error: zenErr})
+}
+
+// StatusCode returns the HTTP status for an error code
+func StatusCode(code types.ErrorCode) int {
+	switch code {
+	case types.ErrorCodeInvalidInput, types.ErrorCodeInvalidConfig:
+		return http.StatusBadRequest
+	case types.ErrorCodeAuthenticationFailed:
+		return http.StatusUnauthorized
+	case types.ErrorCodePermissionDenied:
+		return http.StatusForbidden
+	case types.ErrorCodeNotFound, types.ErrorCodeAssetNotFound, types.ErrorCodeConfigNotFound:
+		return http.StatusNotFound
+	case types.ErrorCodeAlreadyExists, types.ErrorCodeLocked:
+		return http.StatusConflict
+	case types.ErrorCodeWorkspaceNotInit, types.ErrorCodeInvalidWorkspace:
+		return http.StatusPreconditionFailed
+	case types.ErrorCodeRateLimited:
+		return http.StatusTooManyRequests
+	case types.ErrorCodeTimeout:
+		return http.StatusGatewayTimeout
+	case types.ErrorCodeNetworkError, types.ErrorCodeRepositoryError:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
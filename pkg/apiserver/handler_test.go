@@ -0,0 +1,244 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "secret-token"
+
+// fakeBackend keeps tasks in memory and records the arguments of the last call
+type fakeBackend struct {
+	tasks       map[string]*task.Task
+	filter      *task.TaskFilter
+	assetFilter assets.AssetFilter
+	syncOpts    *task.SyncOptions
+	sections    []string
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{tasks: map[string]*task.Task{
+		"PROJ-1": {ID: "PROJ-1", Title: "Add login page", Status: "proposed"},
+	}}
+}
+
+func (b *fakeBackend) Status(ctx context.Context, sections []string) (interface{}, error) {
+	b.sections = sections
+	return map[string]interface{}{"workspace": map[string]bool{"initialized": true}}, nil
+}
+
+func (b *fakeBackend) ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error) {
+	b.filter = filter
+	var tasks []*task.Task
+	for _, t := range b.tasks {
+		if filter.Matches(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
+
+func (b *fakeBackend) GetTask(ctx context.Context, taskID string) (*task.Task, error) {
+	t, ok := b.tasks[taskID]
+	if !ok {
+		return nil, &types.Error{Code: types.ErrorCodeNotFound, Message: "task not found: " + taskID}
+	}
+	return t, nil
+}
+
+func (b *fakeBackend) CreateTask(ctx context.Context, request *task.CreateTaskRequest) (*task.Task, error) {
+	if _, ok := b.tasks[request.ID]; ok {
+		return nil, &types.Error{Code: types.ErrorCodeAlreadyExists, Message: "task already exists: " + request.ID}
+	}
+	t := &task.Task{ID: request.ID, Title: request.Title, Status: "proposed"}
+	b.tasks[t.ID] = t
+	return t, nil
+}
+
+func (b *fakeBackend) UpdateTask(ctx context.Context, taskID string, updates *task.TaskUpdates) (*task.Task, error) {
+	t, err := b.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if updates.Status != nil {
+		t.Status = *updates.Status
+	}
+	return t, nil
+}
+
+func (b *fakeBackend) DeleteTask(ctx context.Context, taskID string) error {
+	if _, err := b.GetTask(ctx, taskID); err != nil {
+		return err
+	}
+	delete(b.tasks, taskID)
+	return nil
+}
+
+func (b *fakeBackend) SyncTask(ctx context.Context, taskID string, opts *task.SyncOptions) (*task.SyncResult, error) {
+	b.syncOpts = opts
+	return &task.SyncResult{TaskID: taskID, Success: true, Direction: opts.Direction}, nil
+}
+
+func (b *fakeBackend) SyncAllTasks(ctx context.Context, opts *task.SyncOptions) ([]*task.SyncResult, error) {
+	b.syncOpts = opts
+	return nil, fmt.Errorf("no task system configured")
+}
+
+func (b *fakeBackend) ListAssets(ctx context.Context, filter assets.AssetFilter) (*assets.AssetList, error) {
+	b.assetFilter = filter
+	return &assets.AssetList{Assets: []assets.AssetMetadata{{Name: "prd"}}, Total: 1}, nil
+}
+
+// do sends a request to a handler for backend, with the test token unless auth is false
+func do(t *testing.T, backend Backend, method, target, body string, auth bool) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if auth {
+		req.Header.Set("Authorization", "Bearer "+testToken)
+	}
+	rec := httptest.NewRecorder()
+	NewHandler(backend, testToken, "1.2.3").ServeHTTP(rec, req)
+
+	var decoded map[string]interface{}
+	if rec.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded), rec.Body.String())
+	}
+	return rec, decoded
+}
+
+func errorCode(body map[string]interface{}) interface{} {
+	if e, ok := body["error"].(map[string]interface{}); ok {
+		return e["code"]
+	}
+	return nil
+}
+
+func TestHandler_Auth(t *testing.T) {
+	backend := newFakeBackend()
+
+	rec, body := do(t, backend, http.MethodGet, "/v1/health", "", false)
+	assert.Equal(t, http.StatusOK, rec.Code, "health needs no token")
+	assert.Equal(t, "1.2.3", body["version"])
+
+	rec, body = do(t, backend, http.MethodGet, "/v1/tasks", "", false)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "AUTHENTICATION_FAILED", errorCode(body))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/tasks", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec = httptest.NewRecorder()
+	NewHandler(backend, testToken, "").ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec, _ = do(t, backend, http.MethodGet, "/v1/tasks", "", true)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandler_Tasks(t *testing.T) {
+	backend := newFakeBackend()
+
+	rec, body := do(t, backend, http.MethodGet, "/v1/tasks?status=proposed&label=auth&label=api", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "proposed", backend.filter.Status)
+	assert.Equal(t, []string{"auth", "api"}, backend.filter.Labels)
+	assert.EqualValues(t, 0, body["total"], "no task has the labels")
+
+	rec, body = do(t, backend, http.MethodPost, "/v1/tasks", `{"id": "PROJ-2", "title": "Fix session timeout"}`, true)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "PROJ-2", body["id"])
+
+	rec, body = do(t, backend, http.MethodPost, "/v1/tasks", `{"id": "PROJ-2"}`, true)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "ALREADY_EXISTS", errorCode(body))
+
+	rec, body = do(t, backend, http.MethodGet, "/v1/tasks/PROJ-2", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Fix session timeout", body["title"])
+
+	rec, body = do(t, backend, http.MethodPatch, "/v1/tasks/PROJ-2", `{"status": "in_progress"}`, true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "in_progress", body["status"])
+
+	rec, _ = do(t, backend, http.MethodDelete, "/v1/tasks/PROJ-2", "", true)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec, body = do(t, backend, http.MethodGet, "/v1/tasks/PROJ-2", "", true)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "NOT_FOUND", errorCode(body))
+}
+
+func TestHandler_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		code   string
+	}{
+		{name: "malformed body", method: http.MethodPost, target: "/v1/tasks", body: `{"id":`, status: http.StatusBadRequest, code: "INVALID_INPUT"},
+		{name: "unknown field", method: http.MethodPatch, target: "/v1/tasks/PROJ-1", body: `{"state": "done"}`, status: http.StatusBadRequest, code: "INVALID_INPUT"},
+		{name: "invalid limit", method: http.MethodGet, target: "/v1/assets?limit=ten", status: http.StatusBadRequest, code: "INVALID_INPUT"},
+		{name: "unknown endpoint", method: http.MethodGet, target: "/v2/tasks", status: http.StatusNotFound, code: "NOT_FOUND"},
+		{name: "untyped error", method: http.MethodPost, target: "/v1/sync", status: http.StatusInternalServerError, code: "UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, body := do(t, newFakeBackend(), tt.method, tt.target, tt.body, true)
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.code, errorCode(body))
+		})
+	}
+}
+
+func TestHandler_Sync(t *testing.T) {
+	backend := newFakeBackend()
+
+	rec, body := do(t, backend, http.MethodPost, "/v1/tasks/PROJ-1/sync", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "PROJ-1", body["task_id"])
+	assert.Equal(t, task.SyncDirectionBidirectional, backend.syncOpts.Direction, "the defaults of zen task sync")
+	assert.Equal(t, task.ConflictStrategyTimestamp, backend.syncOpts.ConflictStrategy)
+
+	rec, _ = do(t, backend, http.MethodPost, "/v1/tasks/PROJ-1/sync", `{"direction": "pull", "dry_run": true}`, true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, task.SyncDirectionPull, backend.syncOpts.Direction)
+	assert.True(t, backend.syncOpts.DryRun)
+	assert.Equal(t, task.ConflictStrategyTimestamp, backend.syncOpts.ConflictStrategy)
+}
+
+func TestHandler_AssetsAndStatus(t *testing.T) {
+	backend := newFakeBackend()
+
+	rec, body := do(t, backend, http.MethodGet, "/v1/assets?type=template&tags=prd,%20planning&limit=10&offset=5", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.EqualValues(t, 1, body["total"])
+	assert.Equal(t, assets.AssetFilter{Type: "template", Tags: []string{"prd", "planning"}, Limit: 10, Offset: 5}, backend.assetFilter)
+
+	rec, _ = do(t, backend, http.MethodGet, "/v1/assets?limit=10&page_token=MTA6YWJj", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, assets.AssetFilter{Limit: 10, PageToken: "MTA6YWJj"}, backend.assetFilter)
+
+	rec, body = do(t, backend, http.MethodGet, "/v1/status?sections=tasks,assets", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, body, "workspace")
+	assert.Equal(t, []string{"tasks", "assets"}, backend.sections)
+}
+
+func TestStatusCode(t *testing.T) {
+	assert.Equal(t, http.StatusPreconditionFailed, StatusCode(types.ErrorCodeWorkspaceNotInit))
+	assert.Equal(t, http.StatusTooManyRequests, StatusCode(types.ErrorCodeRateLimited))
+	assert.Equal(t, http.StatusInternalServerError, StatusCode(types.ErrorCodeCacheError))
+}
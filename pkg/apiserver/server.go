@@ -0,0 +1,8 @@
+package apiserver
+
+import "net"
+
+// BaseURL returns the URL of the API on a server listening at addr
+func BaseURL(addr net.Addr) string {
+	return "http://" + addr.String()
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/errors"
 )
 
@@ -148,10 +149,7 @@ func (a *TokenAuthProvider) getEnvVarNames(provider string) []string {
 }
 
 func (a *TokenAuthProvider) validateGitHubToken(ctx context.Context, token string) error {
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
+	client := httpx.New(httpx.Options{Provider: "github", Timeout: 10 * time.Second, Retry: httpx.NoRetries, Logger: a.logger})
 
 	// Make a test API call to validate the token
 	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.github.com/user", nil)
@@ -160,7 +158,6 @@ func (a *TokenAuthProvider) validateGitHubToken(ctx context.Context, token strin
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
-	req.Header.Set("User-Agent", "zen-cli/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -203,10 +200,7 @@ func (a *TokenAuthProvider) validateGitHubToken(ctx context.Context, token strin
 }
 
 func (a *TokenAuthProvider) validateGitLabToken(ctx context.Context, token string) error {
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
+	client := httpx.New(httpx.Options{Provider: "gitlab", Timeout: 10 * time.Second, Retry: httpx.NoRetries, Logger: a.logger})
 
 	// Make a test API call to validate the token
 	req, err := http.NewRequestWithContext(ctx, "GET", "https://gitlab.com/api/v4/user", nil)
@@ -215,7 +209,6 @@ func (a *TokenAuthProvider) validateGitLabToken(ctx context.Context, token strin
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("User-Agent", "zen-cli/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
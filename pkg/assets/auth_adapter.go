@@ -86,6 +86,23 @@ func (a *AuthProviderAdapter) RefreshCredentials(ctx context.Context, provider s
 	return nil
 }
 
+// RequireScopes fails when the credentials of provider lack the scopes of operations
+func (a *AuthProviderAdapter) RequireScopes(ctx context.Context, provider string, operations ...string) error {
+	err := auth.RequireScopes(ctx, a.authManager, provider, operations...)
+	if err != nil {
+		// Convert auth errors to asset errors if needed
+		if authErr, ok := err.(*auth.Error); ok {
+			return &AssetClientError{
+				Code:    convertAuthErrorCode(authErr.Code),
+				Message: authErr.Message,
+				Details: authErr.Details,
+			}
+		}
+		return err
+	}
+	return nil
+}
+
 // convertAuthErrorCode converts auth error codes to asset error codes
 func convertAuthErrorCode(authCode auth.ErrorCode) AssetErrorCode {
 	switch authCode {
@@ -97,6 +114,8 @@ func convertAuthErrorCode(authCode auth.ErrorCode) AssetErrorCode {
 		return ErrorCodeRateLimited
 	case auth.ErrorCodeConfigurationError, auth.ErrorCodeProviderNotSupported:
 		return ErrorCodeConfigurationError
+	case auth.ErrorCodeInsufficientScopes:
+		return ErrorCodeInsufficientScopes
 	default:
 		return ErrorCodeAuthenticationFailed
 	}
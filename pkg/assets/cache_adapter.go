@@ -8,6 +8,9 @@ import (
 	"github.com/daddia/zen/pkg/cache"
 )
 
+// CacheNamespace names the asset cache in the "cache.encrypt" setting
+const CacheNamespace = "assets"
+
 // AssetCacheManager implements CacheManager using the generic cache package
 type AssetCacheManager struct {
 	cache cache.Manager[AssetContent]
@@ -42,20 +45,24 @@ func (s *AssetContentSerializer) ContentType() string {
 	return "text/plain" // Assets are typically text-based
 }
 
-// NewAssetCacheManager creates a new asset cache manager using the generic cache
+// NewAssetCacheManager creates a new asset cache manager using the generic file cache
 // The cache is session-based with a default TTL matching CLI session duration
 func NewAssetCacheManager(basePath string, sizeLimitMB int64, defaultTTL time.Duration, logger logging.Logger) *AssetCacheManager {
-	// Use session-based TTL if not specified
-	if defaultTTL == 0 {
-		// Default to 1 hour for CLI session cache
-		// This ensures cached assets are available for the duration of typical workflows
-		defaultTTL = 1 * time.Hour
-	}
-
-	config := cache.Config{
+	return NewAssetCache(cache.Config{
 		BasePath:    basePath,
 		SizeLimitMB: sizeLimitMB,
 		DefaultTTL:  defaultTTL,
+		Backend:     cache.BackendFile,
+	}, logger)
+}
+
+// NewAssetCache creates an asset cache manager on the backend selected by config
+func NewAssetCache(config cache.Config, logger logging.Logger) *AssetCacheManager {
+	// Use session-based TTL if not specified
+	if config.DefaultTTL == 0 {
+		// Default to 1 hour for CLI session cache
+		// This ensures cached assets are available for the duration of typical workflows
+		config.DefaultTTL = 1 * time.Hour
 	}
 
 	serializer := NewAssetContentSerializer()
@@ -133,6 +140,11 @@ func (a *AssetCacheManager) Clear(ctx context.Context) error {
 	return a.cache.Clear(ctx)
 }
 
+// Keys returns the names of all cached assets
+func (a *AssetCacheManager) Keys(ctx context.Context) ([]string, error) {
+	return a.cache.Keys(ctx)
+}
+
 // GetInfo returns cache information
 func (a *AssetCacheManager) GetInfo(ctx context.Context) (*CacheInfo, error) {
 	info, err := a.cache.GetInfo(ctx)
@@ -0,0 +1,149 @@
+package assets
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/errors"
+)
+
+// checkpointFile is the name of the sync checkpoint in the cache directory
+const checkpointFile = "sync-checkpoint.json"
+
+// maxRetryDelay caps the backoff between clone and pull retries
+const maxRetryDelay = 30 * time.Second
+
+// SyncPhase records how far a sync got
+type SyncPhase string
+
+const (
+	// SyncPhaseFetching means the clone was being updated or the manifest downloaded
+	SyncPhaseFetching SyncPhase = "fetching"
+	// SyncPhaseFetched means the manifest was fetched but not yet applied
+	SyncPhaseFetched SyncPhase = "fetched"
+	// SyncPhaseComplete means the sync finished
+	SyncPhaseComplete SyncPhase = "complete"
+)
+
+// AssetCheckpoint is the state of one asset as of the last completed sync
+type AssetCheckpoint struct {
+	Checksum  string    `json:"checksum"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SyncCheckpoint is the progress of the last sync, kept in the cache directory so that a
+// sync interrupted by the network or by the user picks up where it stopped
+type SyncCheckpoint struct {
+	Branch    string                     `json:"branch"`
+	Commit    string                     `json:"commit,omitempty"`
+	Phase     SyncPhase                  `json:"phase"`
+	Assets    map[string]AssetCheckpoint `json:"assets"`
+	StartedAt time.Time                  `json:"started_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+// Interrupted reports whether the sync that wrote the checkpoint did not finish
+func (cp *SyncCheckpoint) Interrupted() bool {
+	return cp.Phase != SyncPhaseComplete
+}
+
+// checkpointPath returns the path of the sync checkpoint
+func (c *Client) checkpointPath() (string, error) {
+	cachePath, err := c.config.ResolvedCachePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cachePath, checkpointFile), nil
+}
+
+// loadCheckpoint reads the last sync checkpoint. A missing or unreadable checkpoint
+// returns nil, and the sync starts from scratch.
+func (c *Client) loadCheckpoint() *SyncCheckpoint {
+	path, err := c.checkpointPath()
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			c.logger.Warn("failed to read sync checkpoint", "path", path, "error", err)
+		}
+		return nil
+	}
+
+	var checkpoint SyncCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		c.logger.Warn("ignoring invalid sync checkpoint", "path", path, "error", err)
+		return nil
+	}
+	return &checkpoint
+}
+
+// saveCheckpoint writes the sync checkpoint, replacing the previous one in a single
+// rename so an interrupted write never leaves a partial file
+func (c *Client) saveCheckpoint(checkpoint *SyncCheckpoint) error {
+	path, err := c.checkpointPath()
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve cache path")
+	}
+
+	checkpoint.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode sync checkpoint")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return errors.Wrap(err, "failed to create cache directory")
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "failed to write sync checkpoint")
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return errors.Wrap(err, "failed to write sync checkpoint")
+	}
+
+	c.logger.Debug("sync checkpoint saved", "path", path, "phase", checkpoint.Phase, "commit", checkpoint.Commit)
+	return nil
+}
+
+// recordPhase moves the checkpoint to the next phase. A checkpoint that cannot be saved
+// only costs the next sync its head start, so the failure is logged and not returned.
+func (c *Client) recordPhase(checkpoint *SyncCheckpoint, phase SyncPhase) {
+	checkpoint.Phase = phase
+	if err := c.saveCheckpoint(checkpoint); err != nil {
+		c.logger.Warn("failed to save sync checkpoint", "error", err)
+	}
+}
+
+// withRetry runs a git network operation, retrying failures that may be transient with
+// exponential backoff, up to the configured number of retries
+func (c *Client) withRetry(ctx context.Context, operation string, fn func() error) error {
+	delay := c.config.Sync.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt > c.config.Sync.MaxRetries || ctx.Err() != nil || !git.Retryable(err) {
+			return err
+		}
+
+		c.logger.Warn("git operation failed, retrying",
+			"operation", operation, "attempt", attempt, "delay", delay, "error", git.OutputError(err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
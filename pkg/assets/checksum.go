@@ -0,0 +1,145 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+
+	"github.com/zeebo/blake3"
+)
+
+// Checksum algorithms of asset checksums, which are written "<algorithm>:<hex digest>"
+const (
+	ChecksumSHA256 = "sha256"
+	ChecksumSHA512 = "sha512"
+	ChecksumBLAKE3 = "blake3"
+)
+
+// ChecksumAlgorithms lists the supported checksum algorithms
+var ChecksumAlgorithms = []string{ChecksumSHA256, ChecksumSHA512, ChecksumBLAKE3}
+
+// DefaultChecksumAlgorithm is the algorithm of checksums written without one, in manifests
+// that do not declare a checksum_algorithm
+const DefaultChecksumAlgorithm = ChecksumSHA256
+
+// Integrity policies, which set what happens when the content of an asset does not match
+// its checksum in the manifest
+const (
+	// IntegrityRequired fails for assets without a checksum or with a mismatching one
+	IntegrityRequired = "required"
+	// IntegrityWarn logs a warning for mismatching checksums and uses the content anyway
+	IntegrityWarn = "warn"
+	// IntegrityOff skips integrity checks
+	IntegrityOff = "off"
+)
+
+// IntegrityPolicies lists the supported integrity policies
+var IntegrityPolicies = []string{IntegrityRequired, IntegrityWarn, IntegrityOff}
+
+// newChecksumHash returns a hash of a supported checksum algorithm
+func newChecksumHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case ChecksumSHA256:
+		return sha256.New(), nil
+	case ChecksumSHA512:
+		return sha512.New(), nil
+	case ChecksumBLAKE3:
+		return blake3.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported checksum algorithm '%s', expected one of: %s", algorithm, strings.Join(ChecksumAlgorithms, ", "))
+}
+
+// ComputeChecksum returns the checksum of data with algorithm, as "<algorithm>:<hex digest>"
+func ComputeChecksum(algorithm string, data []byte) (string, error) {
+	h, err := newChecksumHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+	h.Write(data)
+	return algorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ParseChecksum splits a checksum into its algorithm and hex digest. A checksum without an
+// algorithm is a digest of fallback.
+func ParseChecksum(checksum, fallback string) (algorithm, digest string, err error) {
+	algorithm, digest, found := strings.Cut(checksum, ":")
+	if !found {
+		algorithm, digest = fallback, checksum
+	}
+	algorithm = strings.ToLower(algorithm)
+	h, err := newChecksumHash(algorithm)
+	if err != nil {
+		return "", "", err
+	}
+	digest = strings.ToLower(digest)
+	if decoded, err := hex.DecodeString(digest); err != nil || len(decoded) != h.Size() {
+		return "", "", fmt.Errorf("invalid %s checksum '%s': expected %d hex digits", algorithm, checksum, 2*h.Size())
+	}
+	return algorithm, digest, nil
+}
+
+// qualifyChecksum returns a checksum of a manifest with its algorithm, which defaults to the
+// algorithm the manifest declares
+func qualifyChecksum(checksum, algorithm string) (string, error) {
+	if checksum == "" {
+		return "", nil
+	}
+	algorithm, digest, err := ParseChecksum(checksum, algorithm)
+	if err != nil {
+		return "", err
+	}
+	return algorithm + ":" + digest, nil
+}
+
+// manifestChecksumAlgorithm returns the checksum algorithm a manifest declares, or the
+// default when it declares none
+func manifestChecksumAlgorithm(manifest manifestFile) (string, error) {
+	if manifest.ChecksumAlgorithm == "" {
+		return DefaultChecksumAlgorithm, nil
+	}
+	algorithm := strings.ToLower(manifest.ChecksumAlgorithm)
+	if !slices.Contains(ChecksumAlgorithms, algorithm) {
+		return "", &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: fmt.Sprintf("manifest checksum_algorithm must be one of: %s", strings.Join(ChecksumAlgorithms, ", ")),
+			Details: map[string]string{"checksum_algorithm": manifest.ChecksumAlgorithm},
+		}
+	}
+	return algorithm, nil
+}
+
+// verifyChecksum checks content against the checksum of an asset. The error names the
+// asset, the algorithm, and the expected and actual digests.
+func verifyChecksum(name, path, expected string, content []byte) error {
+	algorithm, digest, err := ParseChecksum(expected, DefaultChecksumAlgorithm)
+	if err != nil {
+		return &AssetClientError{
+			Code:    ErrorCodeIntegrityError,
+			Message: fmt.Sprintf("asset '%s' has an invalid checksum: %v", name, err),
+			Details: map[string]string{"asset": name, "path": path, "expected": expected},
+		}
+	}
+
+	actual, err := ComputeChecksum(algorithm, content)
+	if err != nil {
+		return err
+	}
+	if actual != algorithm+":"+digest {
+		return &AssetClientError{
+			Code:    ErrorCodeIntegrityError,
+			Message: fmt.Sprintf("asset integrity check failed for '%s': expected %s, got %s", name, expected, actual),
+			Details: map[string]string{
+				"asset":     name,
+				"path":      path,
+				"algorithm": algorithm,
+				"expected":  algorithm + ":" + digest,
+				"actual":    actual,
+			},
+		}
+	}
+	return nil
+}
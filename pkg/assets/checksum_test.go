@@ -0,0 +1,191 @@
+package assets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	helloSHA256 = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	helloSHA512 = "sha512:9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043"
+	helloBLAKE3 = "blake3:ea8f163db38682925e4491c5e58d4bb3506ef8c14eb78a86e908c5624a67200f"
+)
+
+func TestComputeChecksum(t *testing.T) {
+	for algorithm, expected := range map[string]string{
+		ChecksumSHA256: helloSHA256,
+		ChecksumSHA512: helloSHA512,
+		ChecksumBLAKE3: helloBLAKE3,
+	} {
+		checksum, err := ComputeChecksum(algorithm, []byte("hello"))
+		require.NoError(t, err, algorithm)
+		assert.Equal(t, expected, checksum)
+	}
+
+	_, err := ComputeChecksum("md5", []byte("hello"))
+	assert.ErrorContains(t, err, "unsupported checksum algorithm 'md5'")
+}
+
+func TestParseChecksum(t *testing.T) {
+	algorithm, digest, err := ParseChecksum("SHA512:"+helloSHA512[len("sha512:"):], ChecksumSHA256)
+	require.NoError(t, err)
+	assert.Equal(t, ChecksumSHA512, algorithm)
+	assert.Equal(t, helloSHA512[len("sha512:"):], digest)
+
+	// Digests without an algorithm are of the fallback
+	algorithm, _, err = ParseChecksum(helloBLAKE3[len("blake3:"):], ChecksumBLAKE3)
+	require.NoError(t, err)
+	assert.Equal(t, ChecksumBLAKE3, algorithm)
+
+	for _, checksum := range []string{"sha256:abc", "sha256:" + helloSHA512[len("sha512:"):], "crc32:0a1b2c3d", "sha256:zz"} {
+		_, _, err := ParseChecksum(checksum, ChecksumSHA256)
+		assert.Error(t, err, checksum)
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	for _, checksum := range []string{helloSHA256, helloSHA512, helloBLAKE3} {
+		assert.NoError(t, verifyChecksum("greeting", "templates/hello.md", checksum, []byte("hello")), checksum)
+	}
+
+	err := verifyChecksum("greeting", "templates/hello.md", helloSHA512, []byte("goodbye"))
+	var assetErr *AssetClientError
+	require.ErrorAs(t, err, &assetErr)
+	assert.Equal(t, ErrorCodeIntegrityError, assetErr.Code)
+	assert.Contains(t, assetErr.Message, "asset integrity check failed for 'greeting': expected "+helloSHA512)
+	details := assetErr.Details.(map[string]string)
+	assert.Equal(t, "greeting", details["asset"])
+	assert.Equal(t, "templates/hello.md", details["path"])
+	assert.Equal(t, ChecksumSHA512, details["algorithm"])
+	assert.Equal(t, helloSHA512, details["expected"])
+	actual, _ := ComputeChecksum(ChecksumSHA512, []byte("goodbye"))
+	assert.Equal(t, actual, details["actual"])
+}
+
+func TestClient_GetAsset_IntegrityPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		checksum string
+		content  string
+		code     AssetErrorCode
+	}{
+		{name: "required matching", policy: IntegrityRequired, checksum: helloBLAKE3, content: "hello"},
+		{name: "required mismatching", policy: IntegrityRequired, checksum: helloBLAKE3, content: "tampered", code: ErrorCodeIntegrityError},
+		{name: "required missing", policy: IntegrityRequired, content: "hello", code: ErrorCodeIntegrityError},
+		{name: "warn mismatching", policy: IntegrityWarn, checksum: helloSHA256, content: "tampered"},
+		{name: "warn missing", policy: IntegrityWarn, content: "hello"},
+		{name: "off mismatching", policy: IntegrityOff, checksum: helloSHA512, content: "tampered"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _, cache, git, _ := createTestClient()
+			client.config.Integrity = tt.policy
+			ctx := context.Background()
+
+			client.mu.Lock()
+			client.setManifest([]AssetMetadata{
+				{Name: "greeting", Type: AssetTypeTemplate, Path: "templates/hello.md", Checksum: tt.checksum},
+			})
+			client.mu.Unlock()
+
+			cache.On("Get", ctx, "greeting").Return(nil, &AssetClientError{Code: ErrorCodeCacheError})
+			cache.On("Put", ctx, "greeting", mock.AnythingOfType("*assets.AssetContent")).Return(nil).Maybe()
+			git.On("GetFile", ctx, "templates/hello.md").Return([]byte(tt.content), nil)
+
+			result, err := client.GetAsset(ctx, "greeting", GetAssetOptions{UseCache: true, VerifyIntegrity: true})
+			if tt.code == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.content, result.Content)
+				return
+			}
+			var assetErr *AssetClientError
+			require.ErrorAs(t, err, &assetErr)
+			assert.Equal(t, tt.code, assetErr.Code)
+			assert.Equal(t, "greeting", assetErr.Details.(map[string]string)["asset"])
+			cache.AssertNotCalled(t, "Put", ctx, "greeting", mock.Anything)
+		})
+	}
+}
+
+func TestClient_GetAsset_CachedIntegrity(t *testing.T) {
+	client, _, cache, git, _ := createTestClient()
+	client.config.Integrity = IntegrityRequired
+	ctx := context.Background()
+
+	metadata := AssetMetadata{Name: "greeting", Type: AssetTypeTemplate, Path: "templates/hello.md", Checksum: helloSHA512}
+	client.mu.Lock()
+	client.setManifest([]AssetMetadata{metadata})
+	client.mu.Unlock()
+
+	// A corrupted cache entry is replaced by the content of the repository
+	cached := &AssetContent{Metadata: metadata, Content: "corrupted", Checksum: helloSHA512, Cached: true}
+	cache.On("Get", ctx, "greeting").Return(cached, nil)
+	cache.On("Put", ctx, "greeting", mock.AnythingOfType("*assets.AssetContent")).Return(nil)
+	git.On("GetFile", ctx, "templates/hello.md").Return([]byte("hello"), nil)
+
+	result, err := client.GetAsset(ctx, "greeting", GetAssetOptions{UseCache: true, VerifyIntegrity: true})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", result.Content)
+	assert.False(t, result.Cached)
+}
+
+func TestYAMLManifestParser_ChecksumAlgorithm(t *testing.T) {
+	parser := NewYAMLManifestParser(logging.NewBasic())
+	ctx := context.Background()
+
+	manifestYAML := `
+schema_version: "2.0"
+checksum_algorithm: blake3
+activities:
+  greeting:
+    name: "greeting"
+    command: "greeting"
+    description: "A greeting"
+    checksum: "` + helloBLAKE3[len("blake3:"):] + `"
+    assets:
+      output: ["templates/hello.md"]
+assets:
+  farewell:
+    type: partial
+    path: partials/farewell.md
+    checksum: "` + helloSHA256 + `"
+`
+	require.NoError(t, parser.Validate(ctx, []byte(manifestYAML)))
+
+	assets, err := parser.Parse(ctx, []byte(manifestYAML))
+	require.NoError(t, err)
+	require.Len(t, assets, 2)
+	assert.Equal(t, helloBLAKE3, assets[0].Checksum)
+	assert.Equal(t, helloSHA256, assets[1].Checksum)
+
+	for _, manifest := range []string{
+		"schema_version: \"2.0\"\nchecksum_algorithm: md5\n",
+		"schema_version: \"2.0\"\nassets:\n  farewell: {type: partial, path: farewell.md, checksum: \"sha256:abc\"}\n",
+	} {
+		var assetErr *AssetClientError
+		require.ErrorAs(t, parser.Validate(ctx, []byte(manifest)), &assetErr, manifest)
+		assert.Equal(t, ErrorCodeConfigurationError, assetErr.Code)
+	}
+}
+
+func TestConfig_IntegrityPolicy(t *testing.T) {
+	config, err := ConfigParser{}.Parse(map[string]interface{}{"integrity": "required"})
+	require.NoError(t, err)
+	assert.NoError(t, config.Validate())
+	assert.Equal(t, IntegrityRequired, config.IntegrityPolicy())
+
+	config = DefaultConfig()
+	assert.Equal(t, IntegrityWarn, config.IntegrityPolicy())
+	config.IntegrityChecksEnabled = false
+	assert.Equal(t, IntegrityOff, config.IntegrityPolicy())
+
+	config.Integrity = "strict"
+	assert.ErrorContains(t, config.Validate(), "integrity must be one of: required, warn, off")
+}
@@ -2,17 +2,17 @@ package assets
 
 import (
 	"context"
-	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/clients/git"
 	"github.com/daddia/zen/pkg/errors"
+	"github.com/daddia/zen/pkg/fs"
 )
 
 // Client implements AssetClientInterface
@@ -29,6 +29,8 @@ type Client struct {
 	mu           sync.RWMutex
 	lastSync     time.Time
 	manifestData []AssetMetadata
+	index        *manifestIndex // Index of manifestData, rebuilt by setManifest
+	lfsBytes     int64          // Git LFS content downloaded this session
 
 	// Performance metrics
 	metrics struct {
@@ -75,14 +77,13 @@ func (c *Client) ListAssets(ctx context.Context, filter AssetFilter) (*AssetList
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	// Apply filtering
-	filtered := c.filterAssets(c.manifestData, filter)
-
-	// Apply pagination
-	total := len(filtered)
 	start := filter.Offset
-	if start > total {
-		start = total
+	if filter.PageToken != "" {
+		offset, err := c.index.parsePageToken(filter, filter.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		start = offset
 	}
 
 	limit := filter.Limit
@@ -90,24 +91,51 @@ func (c *Client) ListAssets(ctx context.Context, filter AssetFilter) (*AssetList
 		limit = 50 // Default limit
 	}
 
-	end := start + limit
-	if end > total {
-		end = total
-	}
+	// Only the assets of the page are copied out of the index; their content is loaded
+	// when an asset is fetched
+	page, total := c.index.page(filter, start, limit)
+	end := min(start, total) + len(page)
 
 	result := &AssetList{
-		Assets:  filtered[start:end],
+		Assets:  page,
 		Total:   total,
 		HasMore: end < total,
 	}
+	if result.HasMore {
+		result.NextPageToken = c.index.pageToken(filter, end)
+	}
 
 	c.logger.Debug("assets listed", "total", total, "returned", len(result.Assets))
 	return result, nil
 }
 
-// GetAsset retrieves a specific asset by name
+// GetAsset retrieves a specific asset by name, with the assets it depends on
 // Uses cache-first lookup with session TTL, falling back to Git repository
 func (c *Client) GetAsset(ctx context.Context, name string, opts GetAssetOptions) (*AssetContent, error) {
+	content, err := c.getAsset(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := c.fetchDependencies(ctx, name, opts)
+	if err != nil {
+		c.mu.Lock()
+		c.metrics.errorCount++
+		c.mu.Unlock()
+		return nil, err
+	}
+	if len(dependencies) == 0 {
+		return content, nil
+	}
+
+	// Content may be shared with the session cache, which keeps assets without their
+	// dependencies
+	result := *content
+	result.Dependencies = dependencies
+	return &result, nil
+}
+
+func (c *Client) getAsset(ctx context.Context, name string, opts GetAssetOptions) (*AssetContent, error) {
 	c.logger.Debug("getting asset", "name", name, "options", opts)
 
 	if name == "" {
@@ -158,13 +186,7 @@ func (c *Client) GetAsset(ctx context.Context, name string, opts GetAssetOptions
 
 	// First try to get metadata from manifest without loading content
 	c.mu.RLock()
-	var metadata *AssetMetadata
-	for i := range c.manifestData {
-		if c.manifestData[i].Name == name {
-			metadata = &c.manifestData[i]
-			break
-		}
-	}
+	metadata, _ := c.index.lookup(name)
 	c.mu.RUnlock()
 
 	if metadata != nil {
@@ -181,10 +203,22 @@ func (c *Client) GetAsset(ctx context.Context, name string, opts GetAssetOptions
 				if err != nil {
 					c.logger.Warn("failed to load asset content from git", "name", name, "path", metadata.Path, "error", err)
 					// Fall through to return metadata only
+				} else if content, err = c.resolveLFS(ctx, metadata.Path, content); err != nil {
+					// A pointer is not usable content
+					return nil, err
 				}
 			}
 
 			if err == nil && len(content) > 0 {
+				if opts.VerifyIntegrity {
+					if err := c.checkIntegrity(*metadata, content); err != nil {
+						c.mu.Lock()
+						c.metrics.errorCount++
+						c.mu.Unlock()
+						return nil, err
+					}
+				}
+
 				result := &AssetContent{
 					Metadata: *metadata,
 					Content:  string(content),
@@ -268,7 +302,43 @@ func (c *Client) SyncRepository(ctx context.Context, req SyncRequest) (*SyncResu
 				Details: err.Error(),
 			}
 		}
+	} else if err := c.requireScopes(ctx, auth.OperationAssetsRead); err != nil {
+		// Credentials without the scopes to read the repository would fail mid-sync
+		result.Status = "error"
+		result.Error = err.Error()
+		return result, err
+	}
+
+	// Only one operation at a time may update the cached repository
+	lock, err := c.lockRepository(ctx, req)
+	if err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+		return result, err
+	}
+	defer lock.Release()
+
+	branch := req.Branch
+	if branch == "" {
+		branch = c.config.Branch
+	}
+
+	if req.DryRun {
+		return c.previewSync(ctx, branch, startTime)
+	}
+
+	// Pick up after a sync of the same branch that did not finish
+	checkpoint := c.loadCheckpoint()
+	if checkpoint != nil && checkpoint.Branch == branch {
+		if checkpoint.Interrupted() {
+			c.logger.Info("resuming interrupted sync", "phase", checkpoint.Phase, "commit", checkpoint.Commit)
+			result.Resumed = true
+		}
+	} else {
+		checkpoint = &SyncCheckpoint{Branch: branch}
 	}
+	checkpoint.StartedAt = startTime
+	c.recordPhase(checkpoint, SyncPhaseFetching)
 
 	// Set up timeout context
 	syncCtx, cancel := context.WithTimeout(ctx, time.Duration(c.config.SyncTimeoutSeconds)*time.Second)
@@ -277,7 +347,7 @@ func (c *Client) SyncRepository(ctx context.Context, req SyncRequest) (*SyncResu
 	// Always fetch manifest (lightweight operation)
 	c.logger.Debug("fetching manifest from repository")
 	var manifestContent []byte
-	var err error
+	var updateErr error
 
 	// Use HTTP client if available (preferred for individual file fetching)
 	switch {
@@ -285,7 +355,25 @@ func (c *Client) SyncRepository(ctx context.Context, req SyncRequest) (*SyncResu
 		manifestContent, err = c.http.DownloadManifest(syncCtx, c.config.RepositoryURL, c.config.Branch)
 	case c.git != nil:
 		// Fallback to Git CLI (requires repository clone)
-		manifestContent, err = c.git.GetFile(syncCtx, "assets/manifest.yaml")
+		if c.config.LocalClone() {
+			err = c.updateClone(syncCtx, req, branch)
+			if err != nil {
+				// A clone that survived a failed update still has the last synced assets
+				if commit, commitErr := c.git.GetLastCommit(syncCtx); commitErr == nil {
+					c.logger.Warn("failed to update repository, using the local clone", "commit", commit, "error", git.OutputError(err))
+					updateErr, err = err, nil
+				}
+			}
+			if err == nil {
+				if commit, commitErr := c.git.GetLastCommit(syncCtx); commitErr == nil {
+					checkpoint.Commit = commit
+					result.Commit = commit
+				}
+			}
+		}
+		if err == nil {
+			manifestContent, err = c.git.GetFile(syncCtx, "assets/manifest.yaml")
+		}
 	default:
 		err = fmt.Errorf("no repository access method configured")
 	}
@@ -308,6 +396,8 @@ func (c *Client) SyncRepository(ctx context.Context, req SyncRequest) (*SyncResu
 		return result, nil // Don't fail the sync, just return partial status
 	}
 
+	c.recordPhase(checkpoint, SyncPhaseFetched)
+
 	// Parse manifest to validate
 	newManifest, err := c.parser.Parse(syncCtx, manifestContent)
 	if err != nil {
@@ -324,43 +414,43 @@ func (c *Client) SyncRepository(ctx context.Context, req SyncRequest) (*SyncResu
 		result.Status = "partial"
 	}
 
-	// Calculate changes before updating
-	c.mu.Lock()
-	oldAssets := make(map[string]AssetMetadata)
-	for _, asset := range c.manifestData {
-		oldAssets[asset.Name] = asset
-	}
-
-	var added, updated, removed int
-	newAssets := make(map[string]AssetMetadata)
-	for _, asset := range newManifest {
-		newAssets[asset.Name] = asset
-		if oldAsset, exists := oldAssets[asset.Name]; exists {
-			// Check if asset was updated (compare checksums or timestamps)
-			if oldAsset.Checksum != asset.Checksum || oldAsset.UpdatedAt != asset.UpdatedAt {
-				updated++
-			}
-		} else {
-			added++
-		}
+	if updateErr != nil {
+		result.Status = "partial"
+		result.Error = fmt.Sprintf("failed to update repository, using the local clone: %v", git.OutputError(updateErr))
 	}
 
-	// Check for removed assets
-	for name := range oldAssets {
-		if _, exists := newAssets[name]; !exists {
-			removed++
+	// Install the organization policy bundle the repository carries, if any
+	if name, err := c.syncPolicyBundle(syncCtx); err != nil {
+		c.logger.Warn("failed to install policy bundle", "error", err)
+		result.Status = "partial"
+		if result.Error == "" {
+			result.Error = fmt.Sprintf("failed to install policy bundle: %v", err)
 		}
+	} else {
+		result.PolicyBundle = name
 	}
 
+	// Compare with the assets at the last sync, and drop cached content only for those that changed
+	state := manifestState(newManifest)
+	delta := diffManifest(c.previousState(checkpoint), state)
+	c.invalidateChanged(ctx, delta)
+
 	// Update manifest data
-	c.manifestData = newManifest
+	c.mu.Lock()
+	c.setManifest(newManifest)
 	c.lastSync = time.Now()
 	c.metrics.syncCount++
 	c.mu.Unlock()
 
-	result.AssetsAdded = added
-	result.AssetsUpdated = updated
-	result.AssetsRemoved = removed
+	result.AssetsAdded = len(delta.Added)
+	result.AssetsUpdated = len(delta.Updated)
+	result.AssetsRemoved = len(delta.Removed)
+	if !delta.Empty() {
+		result.Changes = &delta
+	}
+
+	checkpoint.Assets = state
+	c.recordPhase(checkpoint, SyncPhaseComplete)
 
 	// Get cache info
 	if cacheInfo, err := c.cache.GetInfo(ctx); err == nil {
@@ -374,6 +464,85 @@ func (c *Client) SyncRepository(ctx context.Context, req SyncRequest) (*SyncResu
 	return result, nil
 }
 
+// scopeChecker is implemented by auth providers that can check the scopes of credentials
+type scopeChecker interface {
+	RequireScopes(ctx context.Context, provider string, operations ...string) error
+}
+
+// requireScopes fails when the credentials of the auth provider lack the scopes of
+// operations; providers that cannot check scopes accept any credentials
+func (c *Client) requireScopes(ctx context.Context, operations ...string) error {
+	checker, ok := c.auth.(scopeChecker)
+	if !ok {
+		return nil
+	}
+	return checker.RequireScopes(ctx, c.config.AuthProvider, operations...)
+}
+
+// lockRepository takes the advisory lock for the cached repository, waiting for
+// another zen process to finish with it unless the request says not to
+func (c *Client) lockRepository(ctx context.Context, req SyncRequest) (*fs.FileLock, error) {
+	repositoryPath, err := c.config.RepositoryPath()
+	if err != nil {
+		return nil, &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: fmt.Sprintf("failed to resolve cache path: %v", err),
+		}
+	}
+
+	// The lock sits next to the clone, so that workspaces sharing a clone share its lock
+	lockPath := repositoryPath + ".lock"
+	c.logger.Debug("locking asset repository", "path", lockPath, "no_wait", req.NoWait, "timeout", req.LockTimeout)
+
+	lock, err := fs.AcquireLock(ctx, lockPath, fs.LockOptions{Timeout: req.LockTimeout, NoWait: req.NoWait})
+	if err != nil {
+		if errors.Is(err, fs.ErrLocked) {
+			return nil, &AssetClientError{
+				Code:    ErrorCodeRepositoryLocked,
+				Message: "another operation is using the asset repository",
+				Details: err.Error(),
+			}
+		}
+		return nil, &AssetClientError{
+			Code:    ErrorCodeCacheError,
+			Message: fmt.Sprintf("failed to lock asset repository: %v", err),
+		}
+	}
+	return lock, nil
+}
+
+// updateClone clones the repository, checking out only the configured sparse paths, or pulls an existing clone.
+// Network failures are retried with backoff, and a shallow clone that fails falls back to a full clone.
+func (c *Client) updateClone(ctx context.Context, req SyncRequest, branch string) error {
+	if !req.Force {
+		// An existing clone, including one left by an interrupted sync, only needs new commits
+		if _, err := c.git.GetLastCommit(ctx); err == nil {
+			return c.withRetry(ctx, "pull", func() error { return c.git.Pull(ctx) })
+		}
+	}
+
+	// LFS content is fetched per asset, within the budget, when it is read
+	options := git.CloneOptions{
+		Branch:        branch,
+		Shallow:       req.Shallow,
+		SparsePaths:   c.config.Sync.SparsePaths,
+		SkipLFSSmudge: true,
+	}
+	if c.config.Sync.PartialClone {
+		options.Filter = "blob:none"
+	}
+
+	clone := func() error { return c.git.CloneWithOptions(ctx, c.config.RepositoryURL, options) }
+	err := c.withRetry(ctx, "clone", clone)
+	if err != nil && options.Shallow && ctx.Err() == nil {
+		// Some servers and proxies cannot serve shallow clones
+		c.logger.Warn("shallow clone failed, cloning full history", "error", git.OutputError(err))
+		options.Shallow = false
+		err = c.withRetry(ctx, "clone", clone)
+	}
+	return err
+}
+
 // GetCacheInfo returns current cache status
 func (c *Client) GetCacheInfo(ctx context.Context) (*CacheInfo, error) {
 	c.logger.Debug("getting cache info")
@@ -412,7 +581,7 @@ func (c *Client) Close() error {
 
 	// Perform any cleanup operations
 	c.mu.Lock()
-	c.manifestData = nil
+	c.setManifest(nil)
 	c.mu.Unlock()
 
 	c.logger.Debug("asset client closed")
@@ -436,7 +605,7 @@ func (c *Client) ensureManifestLoaded(ctx context.Context) error {
 				c.logger.Warn("failed to parse local manifest, will fetch from repository", "error", err)
 			} else {
 				c.mu.Lock()
-				c.manifestData = manifest
+				c.setManifest(manifest)
 				c.mu.Unlock()
 				return nil
 			}
@@ -470,52 +639,17 @@ func (c *Client) ensureManifestLoaded(ctx context.Context) error {
 		}
 
 		c.mu.Lock()
-		c.manifestData = manifest
+		c.setManifest(manifest)
 		c.mu.Unlock()
 	}
 
 	return nil
 }
 
-func (c *Client) filterAssets(assets []AssetMetadata, filter AssetFilter) []AssetMetadata {
-	var filtered []AssetMetadata
-
-	for _, asset := range assets {
-		// Type filter
-		if filter.Type != "" && asset.Type != filter.Type {
-			continue
-		}
-
-		// Category filter
-		if filter.Category != "" && asset.Category != filter.Category {
-			continue
-		}
-
-		// Tags filter (AND operation)
-		if len(filter.Tags) > 0 {
-			hasAllTags := true
-			for _, filterTag := range filter.Tags {
-				found := false
-				for _, assetTag := range asset.Tags {
-					if strings.EqualFold(assetTag, filterTag) {
-						found = true
-						break
-					}
-				}
-				if !found {
-					hasAllTags = false
-					break
-				}
-			}
-			if !hasAllTags {
-				continue
-			}
-		}
-
-		filtered = append(filtered, asset)
-	}
-
-	return filtered
+// setManifest replaces the manifest and rebuilds its index. The caller holds c.mu.
+func (c *Client) setManifest(manifest []AssetMetadata) {
+	c.manifestData = manifest
+	c.index = newManifestIndex(manifest)
 }
 
 func (c *Client) loadAssetFromRepository(ctx context.Context, name string, opts GetAssetOptions) (*AssetContent, error) {
@@ -524,14 +658,9 @@ func (c *Client) loadAssetFromRepository(ctx context.Context, name string, opts
 	manifestCount := len(c.manifestData)
 	c.logger.Debug("searching for asset in manifest", "name", name, "total_assets", manifestCount)
 
-	var metadata *AssetMetadata
-	for i := range c.manifestData {
-		c.logger.Debug("checking asset", "index", i, "asset_name", c.manifestData[i].Name)
-		if c.manifestData[i].Name == name {
-			metadata = &c.manifestData[i]
-			c.logger.Debug("found matching asset", "name", name, "path", metadata.Path)
-			break
-		}
+	metadata, found := c.index.lookup(name)
+	if found {
+		c.logger.Debug("found matching asset", "name", name, "path", metadata.Path)
 	}
 	c.mu.RUnlock()
 
@@ -551,23 +680,28 @@ func (c *Client) loadAssetFromRepository(ctx context.Context, name string, opts
 			Message: fmt.Sprintf("failed to load asset content: %v", err),
 		}
 	}
-
-	// Calculate checksum
-	checksum := fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+	if content, err = c.resolveLFS(ctx, metadata.Path, content); err != nil {
+		return nil, err
+	}
 
 	// Verify checksum if requested
-	if opts.VerifyIntegrity && metadata.Checksum != "" {
-		if checksum != metadata.Checksum {
-			return nil, &AssetClientError{
-				Code:    ErrorCodeIntegrityError,
-				Message: fmt.Sprintf("asset integrity check failed for '%s'", name),
-				Details: map[string]string{
-					"expected": metadata.Checksum,
-					"actual":   checksum,
-				},
-			}
+	if opts.VerifyIntegrity {
+		if err := c.checkIntegrity(*metadata, content); err != nil {
+			return nil, err
+		}
+	}
+
+	// Calculate the checksum with the algorithm of the manifest's checksum
+	algorithm := DefaultChecksumAlgorithm
+	if metadata.Checksum != "" {
+		if expected, _, err := ParseChecksum(metadata.Checksum, DefaultChecksumAlgorithm); err == nil {
+			algorithm = expected
 		}
 	}
+	checksum, err := ComputeChecksum(algorithm, content)
+	if err != nil {
+		return nil, err
+	}
 
 	result := &AssetContent{
 		Content:  string(content),
@@ -583,36 +717,58 @@ func (c *Client) loadAssetFromRepository(ctx context.Context, name string, opts
 	return result, nil
 }
 
+// verifyIntegrity checks content against its checksum under the integrity policy. With
+// "required", content without a checksum fails too.
 func (c *Client) verifyIntegrity(content *AssetContent) error {
-	if content.Checksum == "" {
-		return nil // No checksum to verify
-	}
-
-	calculated := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(content.Content)))
-	if calculated != content.Checksum {
+	switch policy := c.config.IntegrityPolicy(); {
+	case policy == IntegrityOff:
+		return nil
+	case content.Checksum == "" && policy == IntegrityRequired:
 		return &AssetClientError{
 			Code:    ErrorCodeIntegrityError,
-			Message: "asset integrity verification failed",
+			Message: fmt.Sprintf("asset '%s' has no checksum in the manifest, which assets.integrity 'required' needs", content.Metadata.Name),
+			Details: map[string]string{"asset": content.Metadata.Name, "path": content.Metadata.Path},
 		}
+	case content.Checksum == "":
+		return nil // No checksum to verify
 	}
 
-	return nil
+	return verifyChecksum(content.Metadata.Name, content.Metadata.Path, content.Checksum, []byte(content.Content))
+}
+
+// checkIntegrity checks content fetched for an asset against its checksum in the manifest.
+// With the "warn" policy, a failed check is logged and the content is used anyway.
+func (c *Client) checkIntegrity(metadata AssetMetadata, content []byte) error {
+	err := c.verifyIntegrity(&AssetContent{Metadata: metadata, Content: string(content), Checksum: metadata.Checksum})
+	if err != nil && c.config.IntegrityPolicy() == IntegrityWarn {
+		c.logger.Warn("asset integrity check failed, using the asset anyway", "asset", metadata.Name, "error", err)
+		return nil
+	}
+	return err
 }
 
 // getManifestPath returns the path to the local manifest file in workspace
 func (c *Client) getManifestPath() string {
 	// Always use workspace-local .zen/library directory for manifest
 	// The manifest is separate from the cache - it's the source of truth
+	return ManifestPath(workspaceRoot())
+}
 
+// workspaceRoot returns the root of the workspace syncs update the library of
+func workspaceRoot() string {
 	// Always use current working directory for workspace-local library
 	// This ensures the manifest is saved to the project where zen init is run
 	cwd, err := os.Getwd()
 	if err != nil {
 		// Fallback to relative path if we can't get current directory
-		return filepath.Join(".zen", "library", "manifest.yaml")
+		return ""
 	}
+	return cwd
+}
 
-	return filepath.Join(cwd, ".zen", "library", "manifest.yaml")
+// ManifestPath returns the path of the manifest saved by syncs in the workspace at root
+func ManifestPath(root string) string {
+	return filepath.Join(root, ".zen", "library", "manifest.yaml")
 }
 
 // saveManifestToDisk saves the manifest content to .zen/library/manifest.yaml
@@ -3,6 +3,7 @@ package assets
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/daddia/zen/internal/logging"
 	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/fs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -136,7 +138,7 @@ func createTestClientWithRealManifest(t *testing.T) (*Client, []AssetMetadata, f
 
 	// Pre-load manifest data in client
 	client.mu.Lock()
-	client.manifestData = parsedManifest
+	client.setManifest(parsedManifest)
 	client.mu.Unlock()
 
 	return client, parsedManifest, cleanup
@@ -168,6 +170,16 @@ func (m *mockAuthProvider) RefreshCredentials(ctx context.Context, provider stri
 	return args.Error(0)
 }
 
+// scopedAuthProvider is an auth provider that checks the scopes of credentials
+type scopedAuthProvider struct {
+	mockAuthProvider
+}
+
+func (m *scopedAuthProvider) RequireScopes(ctx context.Context, provider string, operations ...string) error {
+	args := m.Called(ctx, provider, operations)
+	return args.Error(0)
+}
+
 type mockCacheManager struct {
 	mock.Mock
 }
@@ -217,12 +229,21 @@ func (m *mockGitRepository) Clone(ctx context.Context, url, branch string, shall
 	return args.Error(0)
 }
 
+func (m *mockGitRepository) CloneWithOptions(ctx context.Context, url string, options git.CloneOptions) error {
+	args := m.Called(ctx, url, options)
+	return args.Error(0)
+}
+
 func (m *mockGitRepository) Pull(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
 }
 
 func (m *mockGitRepository) GetFile(ctx context.Context, path string) ([]byte, error) {
+	// Repositories carry no policy bundle unless a test expects one to be read
+	if path == PolicyBundleFile && !m.expectsFile(path) {
+		return nil, &git.GitError{Code: git.ErrorCodeFileNotFound, Message: "file not found"}
+	}
 	args := m.Called(ctx, path)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -230,6 +251,15 @@ func (m *mockGitRepository) GetFile(ctx context.Context, path string) ([]byte, e
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *mockGitRepository) expectsFile(path string) bool {
+	for _, call := range m.ExpectedCalls {
+		if call.Method == "GetFile" && len(call.Arguments) == 2 && call.Arguments[1] == path {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *mockGitRepository) ListFiles(ctx context.Context, pattern string) ([]string, error) {
 	args := m.Called(ctx, pattern)
 	if args.Get(0) == nil {
@@ -262,6 +292,14 @@ func (m *mockGitRepository) ExecuteCommandWithOutput(ctx context.Context, args .
 	return mockArgs.Get(0).([]byte), mockArgs.Error(1)
 }
 
+func (m *mockGitRepository) ExecuteCommandStream(ctx context.Context, args ...string) (io.ReadCloser, error) {
+	mockArgs := m.Called(ctx, args)
+	if mockArgs.Get(0) == nil {
+		return nil, mockArgs.Error(1)
+	}
+	return mockArgs.Get(0).(io.ReadCloser), mockArgs.Error(1)
+}
+
 func (m *mockGitRepository) CreateBranch(ctx context.Context, name string) error {
 	args := m.Called(ctx, name)
 	return args.Error(0)
@@ -398,6 +436,24 @@ func (m *mockGitRepository) Blame(ctx context.Context, file string) ([]git.Blame
 	return args.Get(0).([]git.BlameLine), args.Error(1)
 }
 
+func (m *mockGitRepository) DiffStream(ctx context.Context, options git.DiffOptions) (io.ReadCloser, error) {
+	args := m.Called(ctx, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *mockGitRepository) LogStream(ctx context.Context, options git.LogOptions, fn func(git.Commit) error) error {
+	args := m.Called(ctx, options, fn)
+	return args.Error(0)
+}
+
+func (m *mockGitRepository) BlameStream(ctx context.Context, file string, fn func(git.BlameLine) error) error {
+	args := m.Called(ctx, file, fn)
+	return args.Error(0)
+}
+
 func (m *mockGitRepository) Tag(ctx context.Context, name, message string) error {
 	args := m.Called(ctx, name, message)
 	return args.Error(0)
@@ -411,6 +467,39 @@ func (m *mockGitRepository) ListTags(ctx context.Context) ([]git.Tag, error) {
 	return args.Get(0).([]git.Tag), args.Error(1)
 }
 
+func (m *mockGitRepository) BranchExists(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockGitRepository) CheckoutNewBranch(ctx context.Context, name, base string) error {
+	args := m.Called(ctx, name, base)
+	return args.Error(0)
+}
+
+func (m *mockGitRepository) AddWorktree(ctx context.Context, path, branch, base string) error {
+	args := m.Called(ctx, path, branch, base)
+	return args.Error(0)
+}
+
+func (m *mockGitRepository) RemoveWorktree(ctx context.Context, path string, force bool) error {
+	args := m.Called(ctx, path, force)
+	return args.Error(0)
+}
+
+func (m *mockGitRepository) ListWorktrees(ctx context.Context) ([]git.Worktree, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]git.Worktree), args.Error(1)
+}
+
+func (m *mockGitRepository) PushUpstream(ctx context.Context, remote, branch string) error {
+	args := m.Called(ctx, remote, branch)
+	return args.Error(0)
+}
+
 func (m *mockGitRepository) Status(ctx context.Context) (git.StatusInfo, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -510,7 +599,7 @@ func TestClient_ListAssets_EmptyFilter(t *testing.T) {
 
 	// Pre-load manifest data in client to bypass ensureManifestLoaded
 	client.mu.Lock()
-	client.manifestData = testManifest
+	client.setManifest(testManifest)
 	client.mu.Unlock()
 
 	// Execute
@@ -542,7 +631,7 @@ func TestClient_ListAssets_WithTypeFilter(t *testing.T) {
 
 	// Pre-load manifest data in client to bypass ensureManifestLoaded
 	client.mu.Lock()
-	client.manifestData = testManifest
+	client.setManifest(testManifest)
 	client.mu.Unlock()
 
 	// Execute with filter
@@ -562,13 +651,13 @@ func TestClient_GetAsset_FromCache(t *testing.T) {
 
 	// Pre-load manifest data to avoid loading from git
 	client.mu.Lock()
-	client.manifestData = []AssetMetadata{
+	client.setManifest([]AssetMetadata{
 		{
 			Name: "test-asset",
 			Type: AssetTypeTemplate,
 			Path: "templates/test.template",
 		},
-	}
+	})
 	client.mu.Unlock()
 
 	// Set up test data
@@ -613,7 +702,7 @@ func TestClient_GetAsset_FromRepository(t *testing.T) {
 
 	// Pre-load manifest data in client
 	client.mu.Lock()
-	client.manifestData = testManifest
+	client.setManifest(testManifest)
 	client.mu.Unlock()
 
 	// Set up mocks
@@ -690,6 +779,40 @@ func TestClient_SyncRepository_Success(t *testing.T) {
 	cache.AssertExpectations(t)
 }
 
+func TestClient_SyncRepository_PolicyBundle(t *testing.T) {
+	client, auth, cache, git, parser, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	ctx := context.Background()
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	git.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("test manifest"), nil)
+	git.On("GetFile", timerCtx, PolicyBundleFile).Return([]byte("name: acme\nnaming:\n  branch: ^feature/\n"), nil).Once()
+	parser.On("Parse", mock.Anything, []byte("test manifest")).Return([]AssetMetadata{{Name: "asset1"}}, nil)
+	cache.On("GetInfo", ctx).Return(&CacheInfo{}, nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "success", result.Status)
+	assert.Equal(t, "acme", result.PolicyBundle)
+	assert.FileExists(t, PolicyBundlePath(workspaceRoot()))
+
+	// A bundle the repository no longer carries is removed
+	git.On("GetFile", timerCtx, PolicyBundleFile).Return(nil, &AssetClientError{Code: ErrorCodeAssetNotFound}).Once()
+	result, err = client.SyncRepository(ctx, SyncRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, result.PolicyBundle)
+	assert.NoFileExists(t, PolicyBundlePath(workspaceRoot()))
+
+	// An invalid bundle is not installed
+	git.On("GetFile", timerCtx, PolicyBundleFile).Return([]byte("naming:\n  branch: x\n"), nil).Once()
+	result, err = client.SyncRepository(ctx, SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "partial", result.Status)
+	assert.Contains(t, result.Error, "failed to install policy bundle")
+	assert.NoFileExists(t, PolicyBundlePath(workspaceRoot()))
+}
+
 func TestClient_SyncRepository_AuthenticationFailed(t *testing.T) {
 	client, auth, cache, git, _ := createTestClient()
 	ctx := context.Background()
@@ -718,6 +841,320 @@ func TestClient_SyncRepository_AuthenticationFailed(t *testing.T) {
 	cache.AssertExpectations(t)
 }
 
+func TestClient_SyncRepository_InsufficientScopes(t *testing.T) {
+	client, _, _, git, _ := createTestClient()
+	auth := &scopedAuthProvider{}
+	client.auth = auth
+	ctx := context.Background()
+
+	scopesErr := &AssetClientError{Code: ErrorCodeInsufficientScopes, Message: "github credentials lack the repo scope needed for assets:read"}
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	auth.On("RequireScopes", ctx, "github", []string{"assets:read"}).Return(scopesErr)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{Branch: "main"})
+
+	// The sync stops before the repository is contacted
+	assert.Equal(t, scopesErr, err)
+	assert.Equal(t, "error", result.Status)
+	auth.AssertExpectations(t)
+	git.AssertExpectations(t)
+}
+
+func TestClient_SyncRepository_SparseClone(t *testing.T) {
+	client, auth, cache, repo, parser, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	client.config.Sync.SparsePaths = []string{"assets/templates"}
+	ctx := context.Background()
+
+	// Set up mocks - no local clone yet, so a sparse partial clone is made
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	repo.On("GetLastCommit", timerCtx).Return("", fmt.Errorf("repository not found"))
+	repo.On("CloneWithOptions", timerCtx, client.config.RepositoryURL, git.CloneOptions{
+		Branch:        "main",
+		Shallow:       true,
+		Filter:        "blob:none",
+		SparsePaths:   []string{"assets/templates"},
+		SkipLFSSmudge: true,
+	}).Return(nil)
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("test manifest"), nil)
+	parser.On("Parse", mock.Anything, []byte("test manifest")).Return([]AssetMetadata{{Name: "asset1"}}, nil)
+	cache.On("GetInfo", ctx).Return(&CacheInfo{}, nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{Shallow: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "success", result.Status)
+	repo.AssertExpectations(t)
+}
+
+func TestClient_SyncRepository_SparsePull(t *testing.T) {
+	client, auth, cache, repo, parser, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	client.config.Sync.SparsePaths = []string{"assets/templates"}
+	ctx := context.Background()
+
+	// Set up mocks - an existing clone is updated in place
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	repo.On("GetLastCommit", timerCtx).Return("abc123", nil)
+	repo.On("Pull", timerCtx).Return(nil)
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("test manifest"), nil)
+	parser.On("Parse", mock.Anything, []byte("test manifest")).Return([]AssetMetadata{{Name: "asset1"}}, nil)
+	cache.On("GetInfo", ctx).Return(&CacheInfo{}, nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "success", result.Status)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "CloneWithOptions", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestClient_SyncRepository_RetriesClone(t *testing.T) {
+	client, auth, cache, repo, parser, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	client.config.Sync.SparsePaths = []string{"assets/templates"}
+	client.config.Sync.MaxRetries = 1
+	client.config.Sync.RetryDelay = time.Millisecond
+	ctx := context.Background()
+
+	networkErr := &git.GitError{
+		Code:    git.ErrorCodeCommandFailed,
+		Message: "git command failed: exit status 128",
+		Details: map[string]interface{}{"output": "fatal: early EOF"},
+	}
+
+	// Set up mocks - the shallow clone is dropped twice, then a full clone succeeds
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+	shallow := git.CloneOptions{
+		Branch:        "main",
+		Shallow:       true,
+		Filter:        "blob:none",
+		SparsePaths:   []string{"assets/templates"},
+		SkipLFSSmudge: true,
+	}
+	full := shallow
+	full.Shallow = false
+
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	repo.On("GetLastCommit", timerCtx).Return("", fmt.Errorf("repository not found")).Once()
+	repo.On("CloneWithOptions", timerCtx, client.config.RepositoryURL, shallow).Return(networkErr).Once()
+	repo.On("CloneWithOptions", timerCtx, client.config.RepositoryURL, shallow).Return(networkErr).Once()
+	repo.On("CloneWithOptions", timerCtx, client.config.RepositoryURL, full).Return(nil).Once()
+	repo.On("GetLastCommit", timerCtx).Return("abc123", nil)
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("test manifest"), nil)
+	parser.On("Parse", mock.Anything, []byte("test manifest")).Return([]AssetMetadata{{Name: "asset1"}}, nil)
+	cache.On("GetInfo", ctx).Return(&CacheInfo{}, nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{Shallow: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "success", result.Status)
+	assert.Equal(t, "abc123", result.Commit)
+	repo.AssertExpectations(t)
+}
+
+func TestClient_SyncRepository_PullFailureUsesClone(t *testing.T) {
+	client, auth, cache, repo, parser, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	client.config.Sync.SparsePaths = []string{"assets/templates"}
+	client.config.Sync.MaxRetries = 2
+	client.config.Sync.RetryDelay = time.Millisecond
+	ctx := context.Background()
+
+	networkErr := &git.GitError{
+		Code:    git.ErrorCodeCommandFailed,
+		Message: "git command failed: exit status 128",
+		Details: map[string]interface{}{"output": "fatal: unable to access 'https://github.com/daddia/zen-assets.git/': Could not resolve host: github.com"},
+	}
+
+	// Set up mocks - every pull fails, but the existing clone is kept
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	repo.On("GetLastCommit", timerCtx).Return("abc123", nil)
+	repo.On("Pull", timerCtx).Return(networkErr).Times(3)
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("test manifest"), nil)
+	parser.On("Parse", mock.Anything, []byte("test manifest")).Return([]AssetMetadata{{Name: "asset1"}}, nil)
+	cache.On("GetInfo", ctx).Return(&CacheInfo{}, nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "partial", result.Status)
+	assert.Contains(t, result.Error, "using the local clone")
+	assert.Contains(t, result.Error, "Could not resolve host")
+	assert.Equal(t, "abc123", result.Commit)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "CloneWithOptions", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestClient_SyncRepository_PermanentFailureNotRetried(t *testing.T) {
+	client, auth, cache, repo, _, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	client.config.Sync.SparsePaths = []string{"assets/templates"}
+	client.config.Sync.RetryDelay = time.Millisecond
+	ctx := context.Background()
+
+	authErr := &git.GitError{
+		Code:    git.ErrorCodeCommandFailed,
+		Message: "git command failed: exit status 128",
+		Details: map[string]interface{}{"output": "fatal: Authentication failed for 'https://github.com/daddia/zen-assets.git/'"},
+	}
+
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	repo.On("GetLastCommit", timerCtx).Return("", fmt.Errorf("repository not found"))
+	repo.On("CloneWithOptions", timerCtx, client.config.RepositoryURL, mock.Anything).Return(authErr).Once()
+	cache.On("GetInfo", ctx).Return(&CacheInfo{}, nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "partial", result.Status)
+	assert.Contains(t, result.Error, "failed to load manifest")
+	repo.AssertExpectations(t)
+}
+
+func TestClient_SyncRepository_Checkpoint(t *testing.T) {
+	client, auth, cache, repo, parser, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	ctx := context.Background()
+
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	cache.On("GetInfo", ctx).Return(&CacheInfo{}, nil)
+
+	// The first sync is interrupted before the manifest arrives
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return(nil, fmt.Errorf("connection reset")).Once()
+
+	result, err := client.SyncRepository(ctx, SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "partial", result.Status)
+	assert.False(t, result.Resumed)
+
+	checkpoint := client.loadCheckpoint()
+	require.NotNil(t, checkpoint)
+	assert.Equal(t, "main", checkpoint.Branch)
+	assert.Equal(t, SyncPhaseFetching, checkpoint.Phase)
+	assert.True(t, checkpoint.Interrupted())
+
+	// The next sync resumes and records the state of each asset
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("test manifest"), nil).Once()
+	parser.On("Parse", mock.Anything, []byte("test manifest")).Return([]AssetMetadata{
+		{Name: "asset1", Checksum: "sha256:aaa"},
+		{Name: "asset2", Checksum: "sha256:bbb"},
+	}, nil)
+
+	result, err = client.SyncRepository(ctx, SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "success", result.Status)
+	assert.True(t, result.Resumed)
+
+	checkpoint = client.loadCheckpoint()
+	require.NotNil(t, checkpoint)
+	assert.Equal(t, SyncPhaseComplete, checkpoint.Phase)
+	assert.False(t, checkpoint.Interrupted())
+	assert.Equal(t, "sha256:aaa", checkpoint.Assets["asset1"].Checksum)
+	assert.Equal(t, "sha256:bbb", checkpoint.Assets["asset2"].Checksum)
+
+	// A corrupt checkpoint is ignored
+	path, err := client.checkpointPath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+	assert.Nil(t, client.loadCheckpoint())
+}
+
+func TestClient_SyncRepository_DryRun(t *testing.T) {
+	client, auth, cache, repo, parser, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	ctx := context.Background()
+
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	cache.On("GetInfo", ctx).Return(&CacheInfo{}, nil)
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("v1"), nil).Once()
+	parser.On("Parse", mock.Anything, []byte("v1")).Return([]AssetMetadata{
+		{Name: "asset1", Checksum: "sha256:aaa"},
+		{Name: "asset2", Checksum: "sha256:bbb"},
+	}, nil)
+
+	_, err := client.SyncRepository(ctx, SyncRequest{})
+	require.NoError(t, err)
+	before := client.loadCheckpoint()
+	require.NotNil(t, before)
+
+	// The preview reports the changes but applies none of them
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("v2"), nil).Once()
+	parser.On("Parse", mock.Anything, []byte("v2")).Return([]AssetMetadata{
+		{Name: "asset1", Checksum: "sha256:ccc"},
+		{Name: "asset3", Checksum: "sha256:ddd"},
+	}, nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	require.NotNil(t, result.Changes)
+	assert.Equal(t, []string{"asset3"}, result.Changes.Added)
+	assert.Equal(t, []string{"asset1"}, result.Changes.Updated)
+	assert.Equal(t, []string{"asset2"}, result.Changes.Removed)
+
+	assert.Equal(t, before.Assets, client.loadCheckpoint().Assets, "checkpoint is unchanged")
+	cache.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	assert.Len(t, client.manifestData, 2)
+	assert.Equal(t, "sha256:aaa", client.manifestData[0].Checksum)
+}
+
+func TestClient_SyncRepository_DryRunLocalClone(t *testing.T) {
+	client, auth, _, repo, parser, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	client.config.Sync.SparsePaths = []string{"assets/templates"}
+	ctx := context.Background()
+
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	repo.On("GetLastCommit", timerCtx).Return("abc123", nil)
+	repo.On("ExecuteCommand", timerCtx, []string{"fetch", "origin", "main"}).Return("", nil)
+	repo.On("ExecuteCommand", timerCtx, []string{"rev-parse", "origin/main"}).Return("def456\n", nil)
+	repo.On("ExecuteCommandWithOutput", timerCtx, []string{"show", "origin/main:assets/manifest.yaml"}).Return([]byte("test manifest"), nil)
+	parser.On("Parse", mock.Anything, []byte("test manifest")).Return([]AssetMetadata{{Name: "asset1"}}, nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{DryRun: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "def456", result.Commit)
+	assert.Equal(t, 1, result.AssetsAdded)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Pull", mock.Anything)
+	assert.Nil(t, client.loadCheckpoint(), "no checkpoint is recorded")
+}
+
+func TestClient_SyncRepository_Locked(t *testing.T) {
+	client, auth, _, repo, _, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	ctx := context.Background()
+
+	// Another process holds the repository lock
+	lock, err := fs.AcquireLock(ctx, filepath.Join(client.config.CachePath, "repository.lock"), fs.LockOptions{})
+	require.NoError(t, err)
+	defer lock.Release()
+
+	auth.On("Authenticate", ctx, "github").Return(nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{NoWait: true})
+
+	var assetErr *AssetClientError
+	require.ErrorAs(t, err, &assetErr)
+	assert.Equal(t, ErrorCodeRepositoryLocked, assetErr.Code)
+	assert.Equal(t, "error", result.Status)
+	repo.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything)
+
+	// A bounded wait gives up too
+	_, err = client.SyncRepository(ctx, SyncRequest{LockTimeout: 100 * time.Millisecond})
+	require.ErrorAs(t, err, &assetErr)
+	assert.Equal(t, ErrorCodeRepositoryLocked, assetErr.Code)
+}
+
 func TestClient_GetCacheInfo(t *testing.T) {
 	client, _, cache, _, _ := createTestClient()
 	ctx := context.Background()
@@ -768,75 +1205,6 @@ func TestClient_Close(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestClient_FilterAssets(t *testing.T) {
-	client, _, _, _, _ := createTestClient()
-
-	assets := []AssetMetadata{
-		{
-			Name:     "template1",
-			Type:     AssetTypeTemplate,
-			Category: "planning",
-			Tags:     []string{"strategy", "alignment"},
-		},
-		{
-			Name:     "template2",
-			Type:     AssetTypeTemplate,
-			Category: "development",
-			Tags:     []string{"api", "design"},
-		},
-		{
-			Name:     "prompt1",
-			Type:     AssetTypePrompt,
-			Category: "planning",
-			Tags:     []string{"strategy"},
-		},
-	}
-
-	tests := []struct {
-		name     string
-		filter   AssetFilter
-		expected int
-	}{
-		{
-			name:     "no filter",
-			filter:   AssetFilter{},
-			expected: 3,
-		},
-		{
-			name:     "type filter - template",
-			filter:   AssetFilter{Type: AssetTypeTemplate},
-			expected: 2,
-		},
-		{
-			name:     "category filter - planning",
-			filter:   AssetFilter{Category: "planning"},
-			expected: 2,
-		},
-		{
-			name:     "tag filter - strategy",
-			filter:   AssetFilter{Tags: []string{"strategy"}},
-			expected: 2,
-		},
-		{
-			name:     "combined filter",
-			filter:   AssetFilter{Type: AssetTypeTemplate, Category: "planning"},
-			expected: 1,
-		},
-		{
-			name:     "no matches",
-			filter:   AssetFilter{Type: AssetTypeSchema},
-			expected: 0,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			filtered := client.filterAssets(assets, tt.filter)
-			assert.Len(t, filtered, tt.expected)
-		})
-	}
-}
-
 func TestClient_VerifyIntegrity_Success(t *testing.T) {
 	client, _, _, _, _ := createTestClient()
 
@@ -1095,25 +1463,3 @@ func BenchmarkClient_ListAssets(b *testing.B) {
 		client.ListAssets(ctx, AssetFilter{})
 	}
 }
-
-func BenchmarkClient_FilterAssets(b *testing.B) {
-	client, _, _, _, _ := createTestClient()
-
-	// Set up test data
-	var assets []AssetMetadata
-	for i := 0; i < 1000; i++ {
-		assets = append(assets, AssetMetadata{
-			Name:     fmt.Sprintf("asset-%d", i),
-			Type:     AssetTypeTemplate,
-			Category: "test",
-			Tags:     []string{"tag1", "tag2"},
-		})
-	}
-
-	filter := AssetFilter{Type: AssetTypeTemplate, Category: "test"}
-
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		client.filterAssets(assets, filter)
-	}
-}
@@ -0,0 +1,178 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ManifestDelta lists the assets that changed between two manifests
+type ManifestDelta struct {
+	Added   []string `json:"added,omitempty" yaml:"added,omitempty"`
+	Updated []string `json:"updated,omitempty" yaml:"updated,omitempty"`
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+// Empty reports whether no asset changed
+func (d ManifestDelta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Updated) == 0 && len(d.Removed) == 0
+}
+
+// manifestState returns the checkpoint state of each asset in a manifest
+func manifestState(manifest []AssetMetadata) map[string]AssetCheckpoint {
+	state := make(map[string]AssetCheckpoint, len(manifest))
+	for _, asset := range manifest {
+		state[asset.Name] = AssetCheckpoint{Checksum: asset.Checksum, UpdatedAt: asset.UpdatedAt}
+	}
+	return state
+}
+
+// diffManifest compares the state of the assets at the last sync with a new manifest.
+// An asset is updated when its checksum changed, or, for assets without checksums,
+// when its update time changed.
+func diffManifest(previous, current map[string]AssetCheckpoint) ManifestDelta {
+	var delta ManifestDelta
+
+	for name, asset := range current {
+		old, exists := previous[name]
+		switch {
+		case !exists:
+			delta.Added = append(delta.Added, name)
+		case old.Checksum != "" && asset.Checksum != "":
+			if old.Checksum != asset.Checksum {
+				delta.Updated = append(delta.Updated, name)
+			}
+		case old.Checksum != asset.Checksum || !old.UpdatedAt.Equal(asset.UpdatedAt):
+			delta.Updated = append(delta.Updated, name)
+		}
+	}
+
+	for name := range previous {
+		if _, exists := current[name]; !exists {
+			delta.Removed = append(delta.Removed, name)
+		}
+	}
+
+	sort.Strings(delta.Added)
+	sort.Strings(delta.Updated)
+	sort.Strings(delta.Removed)
+	return delta
+}
+
+// previousState returns the asset state the new manifest is compared against: the
+// checkpoint of the last completed sync, or else the manifest already loaded
+func (c *Client) previousState(checkpoint *SyncCheckpoint) map[string]AssetCheckpoint {
+	if checkpoint.Assets != nil {
+		return checkpoint.Assets
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return manifestState(c.manifestData)
+}
+
+// invalidateChanged removes the cached content of updated and removed assets, leaving
+// the rest of the cache in place
+func (c *Client) invalidateChanged(ctx context.Context, delta ManifestDelta) {
+	for _, names := range [][]string{delta.Updated, delta.Removed} {
+		for _, name := range names {
+			if err := c.cache.Delete(ctx, name); err != nil {
+				c.logger.Debug("failed to invalidate cached asset", "name", name, "error", err)
+			}
+		}
+	}
+}
+
+// previewSync compares the remote manifest with the assets at the last sync of branch and
+// reports the changes a sync would make. The clone, the manifest on disk, the cache, and
+// the checkpoint are left as they are; an existing clone only fetches the branch.
+func (c *Client) previewSync(ctx context.Context, branch string, startTime time.Time) (*SyncResult, error) {
+	result := &SyncResult{Status: "success", DryRun: true}
+
+	syncCtx, cancel := context.WithTimeout(ctx, time.Duration(c.config.SyncTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	manifestContent, commit, err := c.fetchRemoteManifest(syncCtx, branch)
+	if err != nil {
+		result.Status = "error"
+		result.Error = fmt.Sprintf("failed to load manifest: %v", err)
+		result.DurationMS = time.Since(startTime).Milliseconds()
+		return result, &AssetClientError{
+			Code:    ErrorCodeRepositoryError,
+			Message: "failed to load manifest for dry run",
+			Details: err.Error(),
+		}
+	}
+	result.Commit = commit
+
+	manifest, err := c.parser.Parse(syncCtx, manifestContent)
+	if err != nil {
+		result.Status = "error"
+		result.Error = fmt.Sprintf("invalid manifest: %v", err)
+		result.DurationMS = time.Since(startTime).Milliseconds()
+		return result, errors.Wrap(err, "failed to parse manifest")
+	}
+
+	checkpoint := c.loadCheckpoint()
+	if checkpoint == nil || checkpoint.Branch != branch {
+		checkpoint = &SyncCheckpoint{Branch: branch}
+	}
+
+	delta := diffManifest(c.previousState(checkpoint), manifestState(manifest))
+	result.AssetsAdded = len(delta.Added)
+	result.AssetsUpdated = len(delta.Updated)
+	result.AssetsRemoved = len(delta.Removed)
+	if !delta.Empty() {
+		result.Changes = &delta
+	}
+
+	c.mu.RLock()
+	result.LastSync = c.lastSync
+	c.mu.RUnlock()
+	result.DurationMS = time.Since(startTime).Milliseconds()
+
+	c.logger.Debug("repository sync preview completed", "result", result)
+	return result, nil
+}
+
+// fetchRemoteManifest reads the manifest at the tip of branch without changing local
+// assets. A local clone fetches the branch and reads the manifest from the fetched
+// commit, leaving its working tree alone; it must already exist.
+func (c *Client) fetchRemoteManifest(ctx context.Context, branch string) ([]byte, string, error) {
+	switch {
+	case c.http != nil:
+		content, err := c.http.DownloadManifest(ctx, c.config.RepositoryURL, c.config.Branch)
+		return content, "", err
+
+	case c.git != nil && c.config.LocalClone():
+		if _, err := c.git.GetLastCommit(ctx); err != nil {
+			return nil, "", fmt.Errorf("no local clone to compare with, run 'zen assets sync' first")
+		}
+		fetch := func() error {
+			_, err := c.git.ExecuteCommand(ctx, "fetch", "origin", branch)
+			return err
+		}
+		if err := c.withRetry(ctx, "fetch", fetch); err != nil {
+			return nil, "", err
+		}
+
+		ref := "origin/" + branch
+		commit, err := c.git.ExecuteCommand(ctx, "rev-parse", ref)
+		if err != nil {
+			return nil, "", err
+		}
+		content, err := c.git.ExecuteCommandWithOutput(ctx, "show", ref+":assets/manifest.yaml")
+		return content, strings.TrimSpace(commit), err
+
+	case c.git != nil:
+		content, err := c.git.GetFile(ctx, "assets/manifest.yaml")
+		return content, "", err
+
+	default:
+		return nil, "", fmt.Errorf("no repository access method configured")
+	}
+}
@@ -0,0 +1,97 @@
+package assets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffManifest(t *testing.T) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	previous := map[string]AssetCheckpoint{
+		"unchanged":       {Checksum: "sha256:aaa", UpdatedAt: day},
+		"new-checksum":    {Checksum: "sha256:bbb", UpdatedAt: day},
+		"touched":         {Checksum: "sha256:ccc", UpdatedAt: day},
+		"no-checksum":     {UpdatedAt: day},
+		"no-checksum-new": {UpdatedAt: day},
+		"gone":            {Checksum: "sha256:ddd"},
+	}
+	current := map[string]AssetCheckpoint{
+		"unchanged":       {Checksum: "sha256:aaa", UpdatedAt: day},
+		"new-checksum":    {Checksum: "sha256:eee", UpdatedAt: day},
+		"touched":         {Checksum: "sha256:ccc", UpdatedAt: day.Add(time.Hour)},
+		"no-checksum":     {UpdatedAt: day.In(time.FixedZone("CEST", 2*60*60))},
+		"no-checksum-new": {UpdatedAt: day.Add(time.Hour)},
+		"fresh":           {Checksum: "sha256:fff"},
+	}
+
+	delta := diffManifest(previous, current)
+
+	assert.Equal(t, []string{"fresh"}, delta.Added)
+	assert.Equal(t, []string{"new-checksum", "no-checksum-new"}, delta.Updated)
+	assert.Equal(t, []string{"gone"}, delta.Removed)
+	assert.False(t, delta.Empty())
+
+	assert.True(t, diffManifest(current, current).Empty())
+	assert.Len(t, diffManifest(nil, current).Added, len(current))
+}
+
+func TestClient_SyncRepository_Delta(t *testing.T) {
+	client, auth, cache, repo, parser, cleanup := createTestClientWithCleanup()
+	defer cleanup()
+	ctx := context.Background()
+
+	timerCtx := mock.AnythingOfType("*context.timerCtx")
+	auth.On("Authenticate", ctx, "github").Return(nil)
+	cache.On("GetInfo", ctx).Return(&CacheInfo{}, nil)
+
+	// The first sync adds every asset and leaves the cache alone
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("v1"), nil).Once()
+	parser.On("Parse", mock.Anything, []byte("v1")).Return([]AssetMetadata{
+		{Name: "kept", Checksum: "sha256:aaa"},
+		{Name: "changed", Checksum: "sha256:bbb"},
+		{Name: "dropped", Checksum: "sha256:ccc"},
+	}, nil)
+
+	result, err := client.SyncRepository(ctx, SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.AssetsAdded)
+	assert.Zero(t, result.AssetsUpdated)
+	assert.Zero(t, result.AssetsRemoved)
+	cache.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+
+	// A new client, as in a later zen process, compares against the checkpoint
+	next := NewClient(client.config, client.logger, auth, cache, repo, parser)
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("v2"), nil).Once()
+	parser.On("Parse", mock.Anything, []byte("v2")).Return([]AssetMetadata{
+		{Name: "kept", Checksum: "sha256:aaa"},
+		{Name: "changed", Checksum: "sha256:eee"},
+		{Name: "new", Checksum: "sha256:fff"},
+	}, nil)
+	cache.On("Delete", ctx, "changed").Return(nil).Once()
+	cache.On("Delete", ctx, "dropped").Return(nil).Once()
+
+	result, err = next.SyncRepository(ctx, SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.AssetsAdded)
+	assert.Equal(t, 1, result.AssetsUpdated)
+	assert.Equal(t, 1, result.AssetsRemoved)
+	require.NotNil(t, result.Changes)
+	assert.Equal(t, ManifestDelta{Added: []string{"new"}, Updated: []string{"changed"}, Removed: []string{"dropped"}}, *result.Changes)
+
+	cache.AssertExpectations(t)
+	cache.AssertNotCalled(t, "Delete", ctx, "kept")
+
+	// Syncing the same manifest again changes nothing
+	repo.On("GetFile", timerCtx, "assets/manifest.yaml").Return([]byte("v2"), nil).Once()
+
+	result, err = next.SyncRepository(ctx, SyncRequest{})
+	require.NoError(t, err)
+	assert.Zero(t, result.AssetsAdded+result.AssetsUpdated+result.AssetsRemoved)
+	assert.Nil(t, result.Changes)
+}
@@ -0,0 +1,97 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// dependencyClosure returns the names of the assets name depends on, directly or through
+// other assets, each after the assets it depends on in turn. It fails when a dependency
+// is not in the manifest, and when dependencies form a cycle. Assets that are not in the
+// manifest themselves have no dependencies.
+func (idx *manifestIndex) dependencyClosure(name string) ([]string, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var closure []string
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			// Report the cycle from its first asset
+			for i, step := range path {
+				if step == name {
+					cycle := append(append([]string{}, path[i:]...), name)
+					return &AssetClientError{
+						Code:    ErrorCodeDependencyError,
+						Message: fmt.Sprintf("asset dependencies form a cycle: %s", strings.Join(cycle, " -> ")),
+						Details: map[string][]string{"cycle": cycle},
+					}
+				}
+			}
+		}
+
+		asset, ok := idx.lookup(name)
+		if !ok {
+			return &AssetClientError{
+				Code:    ErrorCodeDependencyError,
+				Message: fmt.Sprintf("asset '%s' depends on '%s', which is not in the manifest", path[len(path)-1], name),
+				Details: map[string]string{"asset": path[len(path)-1], "dependency": name},
+			}
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dependency := range asset.Dependencies {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+
+		closure = append(closure, name)
+		return nil
+	}
+
+	if _, ok := idx.lookup(name); !ok {
+		return nil, nil
+	}
+	if err := visit(name); err != nil {
+		return nil, err
+	}
+	// The asset itself comes last
+	return closure[:len(closure)-1], nil
+}
+
+// fetchDependencies returns the content of the dependency closure of the asset named
+// name, fetched like the asset itself
+func (c *Client) fetchDependencies(ctx context.Context, name string, opts GetAssetOptions) ([]AssetContent, error) {
+	c.mu.RLock()
+	closure, err := c.index.dependencyClosure(name)
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies := make([]AssetContent, 0, len(closure))
+	for _, dependency := range closure {
+		content, err := c.getAsset(ctx, dependency, opts)
+		if err != nil {
+			return nil, &AssetClientError{
+				Code:    ErrorCodeDependencyError,
+				Message: fmt.Sprintf("failed to fetch '%s', a dependency of '%s': %v", dependency, name, err),
+				Details: map[string]string{"asset": name, "dependency": dependency},
+			}
+		}
+		dependencies = append(dependencies, *content)
+	}
+	return dependencies, nil
+}
@@ -0,0 +1,219 @@
+package assets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestIndex_DependencyClosure(t *testing.T) {
+	idx := newManifestIndex([]AssetMetadata{
+		{Name: "page", Dependencies: []string{"header", "footer"}},
+		{Name: "header", Dependencies: []string{"logo", "styles"}},
+		{Name: "footer", Dependencies: []string{"styles"}},
+		{Name: "logo"},
+		{Name: "styles"},
+		{Name: "broken", Dependencies: []string{"header", "missing"}},
+		{Name: "ping", Dependencies: []string{"pong"}},
+		{Name: "pong", Dependencies: []string{"ping"}},
+		{Name: "loop", Dependencies: []string{"ping"}},
+		{Name: "self", Dependencies: []string{"self"}},
+	})
+
+	t.Run("dependencies come before the assets that need them", func(t *testing.T) {
+		closure, err := idx.dependencyClosure("page")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"logo", "styles", "header", "footer"}, closure)
+	})
+
+	t.Run("no dependencies", func(t *testing.T) {
+		closure, err := idx.dependencyClosure("logo")
+		require.NoError(t, err)
+		assert.Empty(t, closure)
+	})
+
+	t.Run("asset not in the manifest", func(t *testing.T) {
+		closure, err := idx.dependencyClosure("unknown")
+		require.NoError(t, err)
+		assert.Empty(t, closure)
+	})
+
+	tests := []struct {
+		name    string
+		asset   string
+		message string
+	}{
+		{name: "missing dependency", asset: "broken", message: "asset 'broken' depends on 'missing', which is not in the manifest"},
+		{name: "cycle", asset: "ping", message: "asset dependencies form a cycle: ping -> pong -> ping"},
+		{name: "cycle below the asset", asset: "loop", message: "asset dependencies form a cycle: ping -> pong -> ping"},
+		{name: "self dependency", asset: "self", message: "asset dependencies form a cycle: self -> self"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := idx.dependencyClosure(tt.asset)
+			var assetErr *AssetClientError
+			require.ErrorAs(t, err, &assetErr)
+			assert.Equal(t, ErrorCodeDependencyError, assetErr.Code)
+			assert.Equal(t, tt.message, assetErr.Message)
+		})
+	}
+}
+
+func TestClient_GetAsset_Dependencies(t *testing.T) {
+	client, _, cache, git, _ := createTestClient()
+	ctx := context.Background()
+
+	client.mu.Lock()
+	client.setManifest([]AssetMetadata{
+		{Name: "page", Type: AssetTypeTemplate, Path: "templates/page.md", Dependencies: []string{"header"}},
+		{Name: "header", Type: AssetTypePartial, Path: "partials/header.md", Dependencies: []string{"schema"}},
+		{Name: "schema", Type: AssetTypeSchema, Path: "schemas/page.json"},
+	})
+	client.mu.Unlock()
+
+	cached := &AssetContent{Metadata: AssetMetadata{Name: "schema", Type: AssetTypeSchema}, Content: "{}", Cached: true}
+	cache.On("Get", ctx, "page").Return(nil, &AssetClientError{Code: ErrorCodeCacheError})
+	cache.On("Get", ctx, "header").Return(nil, &AssetClientError{Code: ErrorCodeCacheError})
+	cache.On("Get", ctx, "schema").Return(cached, nil)
+	cache.On("Put", ctx, mock.Anything, mock.AnythingOfType("*assets.AssetContent")).Return(nil)
+	git.On("GetFile", ctx, "templates/page.md").Return([]byte(`{{template "header" .}}`), nil)
+	git.On("GetFile", ctx, "partials/header.md").Return([]byte("# Header"), nil)
+
+	result, err := client.GetAsset(ctx, "page", GetAssetOptions{UseCache: true})
+	require.NoError(t, err)
+	assert.Equal(t, `{{template "header" .}}`, result.Content)
+	require.Len(t, result.Dependencies, 2)
+	assert.Equal(t, "schema", result.Dependencies[0].Metadata.Name)
+	assert.True(t, result.Dependencies[0].Cached)
+	assert.Equal(t, "header", result.Dependencies[1].Metadata.Name)
+	assert.Equal(t, "# Header", result.Dependencies[1].Content)
+
+	// Cached assets are kept without their dependencies
+	assert.Empty(t, cached.Dependencies)
+}
+
+func TestClient_GetAsset_MissingDependency(t *testing.T) {
+	client, _, cache, git, _ := createTestClient()
+	ctx := context.Background()
+
+	client.mu.Lock()
+	client.setManifest([]AssetMetadata{
+		{Name: "page", Type: AssetTypeTemplate, Path: "templates/page.md", Dependencies: []string{"header"}},
+	})
+	client.mu.Unlock()
+
+	cache.On("Get", ctx, "page").Return(nil, &AssetClientError{Code: ErrorCodeCacheError})
+	cache.On("Put", ctx, "page", mock.AnythingOfType("*assets.AssetContent")).Return(nil)
+	git.On("GetFile", ctx, "templates/page.md").Return([]byte("page"), nil)
+
+	_, err := client.GetAsset(ctx, "page", GetAssetOptions{})
+	var assetErr *AssetClientError
+	require.ErrorAs(t, err, &assetErr)
+	assert.Equal(t, ErrorCodeDependencyError, assetErr.Code)
+	assert.Contains(t, assetErr.Message, "'page' depends on 'header'")
+}
+
+func TestYAMLManifestParser_Dependencies(t *testing.T) {
+	parser := NewYAMLManifestParser(logging.NewBasic())
+	ctx := context.Background()
+
+	manifestYAML := `
+schema_version: "2.0"
+activities:
+  page:
+    name: "Page"
+    command: "page"
+    description: "A page"
+    dependencies: ["header", "page-schema"]
+    assets:
+      output: ["templates/page.md.tmpl"]
+assets:
+  header:
+    type: partial
+    path: partials/header.md.tmpl
+    dependencies: ["page-schema"]
+  page-schema:
+    type: schema
+    path: schemas/page.json
+    checksum: "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+`
+	require.NoError(t, parser.Validate(ctx, []byte(manifestYAML)))
+
+	assets, err := parser.Parse(ctx, []byte(manifestYAML))
+	require.NoError(t, err)
+	require.Len(t, assets, 3)
+	assert.Equal(t, "Page", assets[0].Name)
+	assert.Equal(t, []string{"header", "page-schema"}, assets[0].Dependencies)
+	assert.Equal(t, "header", assets[1].Name)
+	assert.Equal(t, AssetTypePartial, assets[1].Type)
+	assert.Equal(t, "partials/header.md.tmpl", assets[1].Path)
+	assert.Equal(t, "page-schema", assets[2].Name)
+	assert.Equal(t, "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", assets[2].Checksum)
+
+	t.Run("validation", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			manifest string
+			message  string
+		}{
+			{
+				name:     "dependencies before schema 2",
+				manifest: "schema_version: \"1.0\"\nactivities:\n  a: {name: a, command: a, description: a, dependencies: [b]}\n",
+				message:  "dependencies require schema_version 2.0",
+			},
+			{
+				name:     "newer schema",
+				manifest: "schema_version: \"3.0\"\n",
+				message:  "manifest schema_version 3.0 is newer than this version of zen reads",
+			},
+			{
+				name:     "invalid schema",
+				manifest: "schema_version: \"two\"\n",
+				message:  "invalid manifest schema_version: two",
+			},
+			{
+				name:     "missing dependency",
+				manifest: "schema_version: \"2.0\"\nactivities:\n  a: {name: a, command: a, description: a, dependencies: [b]}\n",
+				message:  "asset 'a' depends on 'b', which is not in the manifest",
+			},
+			{
+				name:     "cycle",
+				manifest: "schema_version: \"2.0\"\nassets:\n  a: {type: partial, path: a, dependencies: [b]}\n  b: {type: partial, path: b, dependencies: [a]}\n",
+				message:  "asset dependencies form a cycle: a -> b -> a",
+			},
+			{
+				name:     "asset without a path",
+				manifest: "schema_version: \"2.0\"\nassets:\n  a: {type: partial}\n",
+				message:  "asset 'a' missing required field: path",
+			},
+			{
+				name:     "asset of unknown type",
+				manifest: "schema_version: \"2.0\"\nassets:\n  a: {type: widget, path: a}\n",
+				message:  "asset 'a' has unknown type 'widget'",
+			},
+			{
+				name:     "asset named like an activity",
+				manifest: "schema_version: \"2.0\"\nactivities:\n  a: {name: a, command: a, description: a}\nassets:\n  a: {type: partial, path: a}\n",
+				message:  "duplicate asset name: a",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := parser.Validate(ctx, []byte(tt.manifest))
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.message)
+			})
+		}
+	})
+
+	t.Run("dependencies of schema 1 manifests are ignored", func(t *testing.T) {
+		assets, err := parser.Parse(ctx, []byte("schema_version: \"1.0\"\nactivities:\n  a: {name: a, command: a, description: a, dependencies: [b]}\n"))
+		require.NoError(t, err)
+		require.Len(t, assets, 1)
+		assert.Empty(t, assets[0].Dependencies)
+	})
+}
@@ -6,10 +6,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"path"
 	"strings"
-	"time"
 
 	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/errors"
 )
 
@@ -24,9 +25,7 @@ type HTTPManifestClient struct {
 // NewHTTPManifestClient creates a new HTTP manifest client
 func NewHTTPManifestClient(logger logging.Logger, auth AuthProvider, authProvider string) *HTTPManifestClient {
 	return &HTTPManifestClient{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		httpClient:   httpx.New(httpx.Options{Provider: authProvider, Logger: logger}),
 		logger:       logger,
 		auth:         auth,
 		authProvider: authProvider,
@@ -35,10 +34,15 @@ func NewHTTPManifestClient(logger logging.Logger, auth AuthProvider, authProvide
 
 // DownloadManifest downloads the manifest.yaml file from the repository using HTTP API
 func (h *HTTPManifestClient) DownloadManifest(ctx context.Context, repoURL, branch string) ([]byte, error) {
-	h.logger.Debug("downloading manifest via HTTP API", "repo", h.sanitizeURL(repoURL), "branch", branch)
+	return h.DownloadFile(ctx, repoURL, branch, "assets/manifest.yaml")
+}
+
+// DownloadFile downloads a file of the repository using HTTP API
+func (h *HTTPManifestClient) DownloadFile(ctx context.Context, repoURL, branch, filePath string) ([]byte, error) {
+	h.logger.Debug("downloading file via HTTP API", "repo", h.sanitizeURL(repoURL), "branch", branch, "path", filePath)
 
 	// Parse repository URL to determine provider and construct API URL
-	apiURL, err := h.buildAPIURL(repoURL, branch, "assets/manifest.yaml")
+	apiURL, err := h.buildAPIURL(repoURL, branch, filePath)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build API URL")
 	}
@@ -58,7 +62,6 @@ func (h *HTTPManifestClient) DownloadManifest(ctx context.Context, repoURL, bran
 
 	// Set appropriate headers
 	req.Header.Set("Accept", "application/vnd.github.v3.raw") // GitHub raw content
-	req.Header.Set("User-Agent", "zen-cli/1.0")
 
 	// Make the request
 	resp, err := h.httpClient.Do(req)
@@ -74,7 +77,7 @@ func (h *HTTPManifestClient) DownloadManifest(ctx context.Context, repoURL, bran
 	if resp.StatusCode == 404 {
 		return nil, &AssetClientError{
 			Code:    ErrorCodeAssetNotFound,
-			Message: "manifest.yaml not found in repository",
+			Message: fmt.Sprintf("%s not found in repository", path.Base(filePath)),
 		}
 	}
 
@@ -98,7 +101,7 @@ func (h *HTTPManifestClient) DownloadManifest(ctx context.Context, repoURL, bran
 		return nil, errors.Wrap(err, "failed to read response body")
 	}
 
-	h.logger.Debug("manifest downloaded successfully", "size", len(content))
+	h.logger.Debug("file downloaded successfully", "path", filePath, "size", len(content))
 	return content, nil
 }
 
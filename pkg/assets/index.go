@@ -0,0 +1,191 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// manifestIndex looks up the assets of a manifest by name, type, category and tag. It is
+// built once each time a manifest is loaded, so that listing and lookups never scan the
+// whole manifest. Each of its lists holds the positions of assets in manifest order.
+type manifestIndex struct {
+	assets     []AssetMetadata
+	byName     map[string]int
+	byType     map[AssetType][]int
+	byCategory map[string][]int
+	byTag      map[string][]int
+
+	// version identifies the content of the manifest, so that page tokens issued for one
+	// manifest are refused for another
+	version string
+}
+
+// newManifestIndex indexes assets, which must not be changed afterwards
+func newManifestIndex(assets []AssetMetadata) *manifestIndex {
+	idx := &manifestIndex{
+		assets:     assets,
+		byName:     make(map[string]int, len(assets)),
+		byType:     make(map[AssetType][]int),
+		byCategory: make(map[string][]int),
+		byTag:      make(map[string][]int),
+	}
+
+	h := sha256.New()
+	for i, asset := range assets {
+		// The first asset of a name wins, as it did when assets were looked up in order
+		if _, ok := idx.byName[asset.Name]; !ok {
+			idx.byName[asset.Name] = i
+		}
+		idx.byType[asset.Type] = append(idx.byType[asset.Type], i)
+		idx.byCategory[asset.Category] = append(idx.byCategory[asset.Category], i)
+		for _, tag := range asset.Tags {
+			key := strings.ToLower(tag)
+			// An asset that repeats a tag is listed under it once
+			if positions := idx.byTag[key]; len(positions) == 0 || positions[len(positions)-1] != i {
+				idx.byTag[key] = append(positions, i)
+			}
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00%s\n", asset.Name, asset.Path, asset.Checksum)
+	}
+	idx.version = hex.EncodeToString(h.Sum(nil)[:8])
+
+	return idx
+}
+
+// lookup returns the asset named name
+func (idx *manifestIndex) lookup(name string) (*AssetMetadata, bool) {
+	if idx == nil {
+		return nil, false
+	}
+	i, ok := idx.byName[name]
+	if !ok {
+		return nil, false
+	}
+	return &idx.assets[i], true
+}
+
+// match returns the positions of the assets that match filter. all is true when the
+// filter matches every asset, in which case no positions are returned.
+func (idx *manifestIndex) match(filter AssetFilter) (positions []int, all bool) {
+	var lists [][]int
+	if filter.Type != "" {
+		lists = append(lists, idx.byType[filter.Type])
+	}
+	if filter.Category != "" {
+		lists = append(lists, idx.byCategory[filter.Category])
+	}
+	for _, tag := range filter.Tags {
+		lists = append(lists, idx.byTag[strings.ToLower(tag)])
+	}
+	if len(lists) == 0 {
+		return nil, true
+	}
+
+	// Intersect from the shortest list, which bounds the result
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+	positions = lists[0]
+	for _, list := range lists[1:] {
+		if len(positions) == 0 {
+			break
+		}
+		positions = intersect(positions, list)
+	}
+	return positions, false
+}
+
+// page returns the assets that match filter from start, at most limit of them, and the
+// number that match in all. The assets are copies, so callers may change them freely.
+func (idx *manifestIndex) page(filter AssetFilter, start, limit int) ([]AssetMetadata, int) {
+	if idx == nil {
+		return nil, 0
+	}
+	positions, all := idx.match(filter)
+	total := len(positions)
+	if all {
+		total = len(idx.assets)
+	}
+
+	start = min(start, total)
+	end := min(start+limit, total)
+	if start == end {
+		return nil, total
+	}
+
+	assets := make([]AssetMetadata, 0, end-start)
+	if all {
+		return append(assets, idx.assets[start:end]...), total
+	}
+	for _, i := range positions[start:end] {
+		assets = append(assets, idx.assets[i])
+	}
+	return assets, total
+}
+
+// intersect returns the positions in both a and b, which are in ascending order
+func intersect(a, b []int) []int {
+	var result []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Page tokens hold the position of the next page and a fingerprint of the manifest and
+// filter they were issued for, so that a token is not applied to a different listing.
+
+func (idx *manifestIndex) pageToken(filter AssetFilter, offset int) string {
+	raw := strconv.Itoa(offset) + ":" + idx.fingerprint(filter)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func (idx *manifestIndex) parsePageToken(filter AssetFilter, token string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, invalidPageToken("the page token is malformed")
+	}
+	offsetPart, fingerprint, ok := strings.Cut(string(raw), ":")
+	offset, err := strconv.Atoi(offsetPart)
+	if !ok || err != nil || offset < 0 {
+		return 0, invalidPageToken("the page token is malformed")
+	}
+	if fingerprint != idx.fingerprint(filter) {
+		return 0, invalidPageToken("the page token was issued for another filter or an earlier manifest")
+	}
+	return offset, nil
+}
+
+// fingerprint identifies the manifest and the assets filter selects; the page size is
+// left out so that it may change between pages
+func (idx *manifestIndex) fingerprint(filter AssetFilter) string {
+	tags := make([]string, len(filter.Tags))
+	for i, tag := range filter.Tags {
+		tags[i] = strings.ToLower(tag)
+	}
+	sort.Strings(tags)
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		idx.version, string(filter.Type), filter.Category, strings.Join(tags, ","),
+	}, "\x00")))
+	return hex.EncodeToString(sum[:6])
+}
+
+func invalidPageToken(message string) error {
+	return &AssetClientError{
+		Code:    ErrorCodeInvalidPageToken,
+		Message: message,
+	}
+}
@@ -0,0 +1,251 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIndexAssets() []AssetMetadata {
+	return []AssetMetadata{
+		{
+			Name:     "template1",
+			Type:     AssetTypeTemplate,
+			Category: "planning",
+			Tags:     []string{"strategy", "alignment"},
+		},
+		{
+			Name:     "template2",
+			Type:     AssetTypeTemplate,
+			Category: "development",
+			Tags:     []string{"api", "design"},
+		},
+		{
+			Name:     "prompt1",
+			Type:     AssetTypePrompt,
+			Category: "planning",
+			Tags:     []string{"Strategy", "strategy"},
+		},
+	}
+}
+
+func TestManifestIndex_Page(t *testing.T) {
+	idx := newManifestIndex(testIndexAssets())
+
+	tests := []struct {
+		name     string
+		filter   AssetFilter
+		expected []string
+	}{
+		{
+			name:     "no filter",
+			filter:   AssetFilter{},
+			expected: []string{"template1", "template2", "prompt1"},
+		},
+		{
+			name:     "type filter - template",
+			filter:   AssetFilter{Type: AssetTypeTemplate},
+			expected: []string{"template1", "template2"},
+		},
+		{
+			name:     "category filter - planning",
+			filter:   AssetFilter{Category: "planning"},
+			expected: []string{"template1", "prompt1"},
+		},
+		{
+			name:     "tag filter ignores case",
+			filter:   AssetFilter{Tags: []string{"STRATEGY"}},
+			expected: []string{"template1", "prompt1"},
+		},
+		{
+			name:     "tags must all match",
+			filter:   AssetFilter{Tags: []string{"strategy", "alignment"}},
+			expected: []string{"template1"},
+		},
+		{
+			name:     "combined filter",
+			filter:   AssetFilter{Type: AssetTypeTemplate, Category: "planning"},
+			expected: []string{"template1"},
+		},
+		{
+			name:   "no matches",
+			filter: AssetFilter{Type: AssetTypeSchema},
+		},
+		{
+			name:   "unknown tag",
+			filter: AssetFilter{Type: AssetTypeTemplate, Tags: []string{"missing"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, total := idx.page(tt.filter, 0, 50)
+			assert.Equal(t, len(tt.expected), total)
+
+			var names []string
+			for _, asset := range page {
+				names = append(names, asset.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestManifestIndex_PageBounds(t *testing.T) {
+	idx := newManifestIndex(testIndexAssets())
+
+	page, total := idx.page(AssetFilter{}, 1, 1)
+	require.Len(t, page, 1)
+	assert.Equal(t, "template2", page[0].Name)
+	assert.Equal(t, 3, total)
+
+	page, total = idx.page(AssetFilter{Category: "planning"}, 5, 10)
+	assert.Empty(t, page)
+	assert.Equal(t, 2, total)
+
+	// Pages are copies, and leave the manifest as it was
+	page, _ = idx.page(AssetFilter{}, 0, 1)
+	page[0].Name = "changed"
+	asset, ok := idx.lookup("template1")
+	require.True(t, ok)
+	assert.Equal(t, "template1", asset.Name)
+}
+
+func TestManifestIndex_Lookup(t *testing.T) {
+	assets := append(testIndexAssets(), AssetMetadata{Name: "template1", Category: "duplicate"})
+	idx := newManifestIndex(assets)
+
+	asset, ok := idx.lookup("template1")
+	require.True(t, ok)
+	assert.Equal(t, "planning", asset.Category, "the first asset of a name wins")
+
+	_, ok = idx.lookup("missing")
+	assert.False(t, ok)
+
+	var empty *manifestIndex
+	_, ok = empty.lookup("template1")
+	assert.False(t, ok)
+}
+
+func TestManifestIndex_PageToken(t *testing.T) {
+	idx := newManifestIndex(testIndexAssets())
+	filter := AssetFilter{Tags: []string{"Strategy"}}
+
+	token := idx.pageToken(filter, 1)
+	offset, err := idx.parsePageToken(AssetFilter{Tags: []string{"strategy"}, Limit: 5}, token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, offset)
+
+	t.Run("another filter", func(t *testing.T) {
+		_, err := idx.parsePageToken(AssetFilter{Type: AssetTypePrompt}, token)
+		assertPageTokenError(t, err)
+	})
+
+	t.Run("changed manifest", func(t *testing.T) {
+		assets := testIndexAssets()
+		assets[0].Checksum = "sha256:changed"
+		_, err := newManifestIndex(assets).parsePageToken(filter, token)
+		assertPageTokenError(t, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, token := range []string{"%%%", "bm90LWEtdG9rZW4", "LTE6YWJj"} {
+			_, err := idx.parsePageToken(filter, token)
+			assertPageTokenError(t, err)
+		}
+	})
+}
+
+func assertPageTokenError(t *testing.T, err error) {
+	t.Helper()
+	var assetErr *AssetClientError
+	require.ErrorAs(t, err, &assetErr)
+	assert.Equal(t, ErrorCodeInvalidPageToken, assetErr.Code)
+}
+
+func TestClient_ListAssets_PageTokens(t *testing.T) {
+	client, _, _, _, _ := createTestClient()
+	ctx := context.Background()
+
+	var manifest []AssetMetadata
+	for i := 0; i < 25; i++ {
+		manifest = append(manifest, AssetMetadata{Name: fmt.Sprintf("asset-%02d", i), Type: AssetTypeTemplate})
+	}
+	client.mu.Lock()
+	client.setManifest(manifest)
+	client.mu.Unlock()
+
+	var names []string
+	filter := AssetFilter{Limit: 10}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		result, err := client.ListAssets(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, 25, result.Total)
+		for _, asset := range result.Assets {
+			names = append(names, asset.Name)
+		}
+		if !result.HasMore {
+			assert.Empty(t, result.NextPageToken)
+			break
+		}
+		require.NotEmpty(t, result.NextPageToken)
+		filter.PageToken = result.NextPageToken
+	}
+
+	require.Len(t, names, 25)
+	assert.Equal(t, "asset-00", names[0])
+	assert.Equal(t, "asset-24", names[24])
+
+	_, err := client.ListAssets(ctx, AssetFilter{PageToken: "invalid"})
+	assertPageTokenError(t, err)
+}
+
+func largeManifest(n int) []AssetMetadata {
+	types := []AssetType{AssetTypeTemplate, AssetTypePrompt, AssetTypeMCP, AssetTypeSchema}
+	manifest := make([]AssetMetadata, n)
+	for i := range manifest {
+		manifest[i] = AssetMetadata{
+			Name:     fmt.Sprintf("asset-%d", i),
+			Type:     types[i%len(types)],
+			Category: fmt.Sprintf("category-%d", i%20),
+			Tags:     []string{fmt.Sprintf("tag-%d", i%50), fmt.Sprintf("tag-%d", i%7)},
+			Checksum: fmt.Sprintf("sha256:%d", i),
+		}
+	}
+	return manifest
+}
+
+func BenchmarkClient_ListAssets_Large(b *testing.B) {
+	client, _, _, _, _ := createTestClient()
+	ctx := context.Background()
+	client.setManifest(largeManifest(10000))
+
+	filters := map[string]AssetFilter{
+		"all":      {},
+		"type":     {Type: AssetTypePrompt},
+		"combined": {Type: AssetTypeTemplate, Category: "category-4", Tags: []string{"tag-4"}},
+		"deep":     {Offset: 9950},
+	}
+	for name, filter := range filters {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := client.ListAssets(ctx, filter); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNewManifestIndex(b *testing.B) {
+	manifest := largeManifest(10000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newManifestIndex(manifest)
+	}
+}
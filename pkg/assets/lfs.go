@@ -0,0 +1,118 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daddia/zen/pkg/clients/git"
+)
+
+// LFSStatus describes the Git LFS content of the local asset repository clone
+type LFSStatus struct {
+	Available  bool    `json:"available" yaml:"available"`
+	Tracked    int     `json:"tracked" yaml:"tracked"`
+	Downloaded int     `json:"downloaded" yaml:"downloaded"`
+	BudgetMB   int     `json:"budget_mb" yaml:"budget_mb"`
+	UsedMB     float64 `json:"used_mb" yaml:"used_mb"`
+}
+
+// LFSStatusProvider is implemented by asset clients that can report Git LFS status
+type LFSStatusProvider interface {
+	LFSStatus(ctx context.Context) (*LFSStatus, error)
+}
+
+// LFSStatus reports Git LFS availability, tracked files, and the download budget used this
+// session. It returns nil when the client fetches files over HTTP rather than from a clone.
+func (c *Client) LFSStatus(ctx context.Context) (*LFSStatus, error) {
+	if c.git == nil {
+		return nil, nil
+	}
+
+	c.mu.RLock()
+	status := &LFSStatus{
+		BudgetMB: c.config.Sync.LFSBudgetMB,
+		UsedMB:   float64(c.lfsBytes) / (1024 * 1024),
+	}
+	c.mu.RUnlock()
+
+	lfs, ok := c.git.(git.LFSRepository)
+	if !ok || !lfs.LFSAvailable(ctx) {
+		return status, nil
+	}
+	status.Available = true
+
+	files, err := lfs.LFSFiles(ctx)
+	if err != nil {
+		return status, &AssetClientError{
+			Code:    ErrorCodeLFSError,
+			Message: fmt.Sprintf("failed to list LFS files: %v", err),
+		}
+	}
+
+	status.Tracked = len(files)
+	for _, file := range files {
+		if file.Downloaded {
+			status.Downloaded++
+		}
+	}
+	return status, nil
+}
+
+// resolveLFS returns the content of an asset file, downloading it first if the
+// clone holds only its Git LFS pointer. Downloads count against the LFS budget.
+func (c *Client) resolveLFS(ctx context.Context, path string, content []byte) ([]byte, error) {
+	pointer, ok := git.ParseLFSPointer(content)
+	if !ok {
+		return content, nil
+	}
+
+	lfs, ok := c.git.(git.LFSRepository)
+	if !ok || !lfs.LFSAvailable(ctx) {
+		return nil, &AssetClientError{
+			Code:    ErrorCodeLFSError,
+			Message: fmt.Sprintf("asset '%s' is stored in Git LFS, which is not available; install git-lfs", path),
+			Details: map[string]interface{}{"path": path, "oid": pointer.OID, "size": pointer.Size},
+		}
+	}
+
+	budget := int64(c.config.Sync.LFSBudgetMB) * 1024 * 1024
+	c.mu.Lock()
+	if c.lfsBytes+pointer.Size > budget {
+		used := c.lfsBytes
+		c.mu.Unlock()
+		return nil, &AssetClientError{
+			Code:    ErrorCodeLFSError,
+			Message: fmt.Sprintf("asset '%s' (%d bytes) exceeds the remaining Git LFS budget; raise assets.sync.lfs_budget_mb", path, pointer.Size),
+			Details: map[string]interface{}{"path": path, "size": pointer.Size, "used": used, "budget": budget},
+		}
+	}
+	// Reserve the budget before downloading so concurrent fetches cannot overrun it
+	c.lfsBytes += pointer.Size
+	c.mu.Unlock()
+
+	c.logger.Debug("fetching LFS object", "path", path, "size", pointer.Size)
+	if err := lfs.LFSPull(ctx, path); err != nil {
+		c.mu.Lock()
+		c.lfsBytes -= pointer.Size
+		c.mu.Unlock()
+		return nil, &AssetClientError{
+			Code:    ErrorCodeLFSError,
+			Message: fmt.Sprintf("failed to fetch LFS object for '%s': %v", path, err),
+		}
+	}
+
+	content, err := c.git.GetFile(ctx, path)
+	if err != nil {
+		return nil, &AssetClientError{
+			Code:    ErrorCodeRepositoryError,
+			Message: fmt.Sprintf("failed to load asset content: %v", err),
+		}
+	}
+	if _, ok := git.ParseLFSPointer(content); ok {
+		return nil, &AssetClientError{
+			Code:    ErrorCodeLFSError,
+			Message: fmt.Sprintf("LFS object for '%s' is not available from the remote", path),
+		}
+	}
+	return content, nil
+}
@@ -0,0 +1,169 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockLFSRepository struct {
+	mockGitRepository
+}
+
+func (m *mockLFSRepository) LFSAvailable(ctx context.Context) bool {
+	args := m.Called(ctx)
+	return args.Bool(0)
+}
+
+func (m *mockLFSRepository) LFSPull(ctx context.Context, paths ...string) error {
+	args := m.Called(ctx, paths)
+	return args.Error(0)
+}
+
+func (m *mockLFSRepository) LFSFiles(ctx context.Context) ([]git.LFSFile, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]git.LFSFile), args.Error(1)
+}
+
+func lfsPointer(size int64) []byte {
+	return []byte(fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:4d7a2146\nsize %d\n", size))
+}
+
+func newLFSTestClient(repo git.Repository) (*Client, *mockCacheManager) {
+	cache := &mockCacheManager{}
+	client := NewClient(DefaultConfig(), logging.NewBasic(), &mockAuthProvider{}, cache, repo, &mockManifestParser{})
+	client.setManifest([]AssetMetadata{{Name: "flow", Type: AssetTypeTemplate, Path: "assets/diagrams/flow.svg"}})
+	return client, cache
+}
+
+func TestClient_GetAsset_LFSPointer(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockLFSRepository{}
+	client, cache := newLFSTestClient(repo)
+
+	cache.On("Get", ctx, "flow").Return(nil, &AssetClientError{Code: ErrorCodeCacheError})
+	cache.On("Put", ctx, "flow", mock.AnythingOfType("*assets.AssetContent")).Return(nil)
+	repo.On("GetFile", ctx, "assets/diagrams/flow.svg").Return(lfsPointer(2048), nil).Once()
+	repo.On("LFSAvailable", ctx).Return(true)
+	repo.On("LFSPull", ctx, []string{"assets/diagrams/flow.svg"}).Return(nil)
+	repo.On("GetFile", ctx, "assets/diagrams/flow.svg").Return([]byte("<svg/>"), nil).Once()
+
+	result, err := client.GetAsset(ctx, "flow", GetAssetOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "<svg/>", result.Content)
+	assert.Equal(t, int64(2048), client.lfsBytes)
+	repo.AssertExpectations(t)
+}
+
+func TestClient_GetAsset_LFSErrors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		setup func(repo *mockLFSRepository, client *Client)
+	}{
+		{
+			name: "lfs not installed",
+			setup: func(repo *mockLFSRepository, client *Client) {
+				repo.On("LFSAvailable", ctx).Return(false)
+			},
+		},
+		{
+			name: "over budget",
+			setup: func(repo *mockLFSRepository, client *Client) {
+				repo.On("LFSAvailable", ctx).Return(true)
+				client.config.Sync.LFSBudgetMB = 1
+				client.lfsBytes = 1024 * 1024
+			},
+		},
+		{
+			name: "downloads disabled",
+			setup: func(repo *mockLFSRepository, client *Client) {
+				repo.On("LFSAvailable", ctx).Return(true)
+				client.config.Sync.LFSBudgetMB = 0
+			},
+		},
+		{
+			name: "pull failed",
+			setup: func(repo *mockLFSRepository, client *Client) {
+				repo.On("LFSAvailable", ctx).Return(true)
+				repo.On("LFSPull", ctx, mock.Anything).Return(fmt.Errorf("object not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockLFSRepository{}
+			client, cache := newLFSTestClient(repo)
+			tt.setup(repo, client)
+			used := client.lfsBytes
+
+			cache.On("Get", ctx, "flow").Return(nil, &AssetClientError{Code: ErrorCodeCacheError})
+			repo.On("GetFile", ctx, "assets/diagrams/flow.svg").Return(lfsPointer(2048), nil)
+
+			result, err := client.GetAsset(ctx, "flow", GetAssetOptions{})
+
+			assert.Nil(t, result)
+			var assetErr *AssetClientError
+			require.ErrorAs(t, err, &assetErr)
+			assert.Equal(t, ErrorCodeLFSError, assetErr.Code)
+			assert.Equal(t, used, client.lfsBytes)
+			cache.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestClient_GetAsset_LFSWithoutSupport(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockGitRepository{}
+	client, cache := newLFSTestClient(repo)
+
+	cache.On("Get", ctx, "flow").Return(nil, &AssetClientError{Code: ErrorCodeCacheError})
+	repo.On("GetFile", ctx, "assets/diagrams/flow.svg").Return(lfsPointer(10), nil)
+
+	_, err := client.GetAsset(ctx, "flow", GetAssetOptions{})
+
+	var assetErr *AssetClientError
+	require.ErrorAs(t, err, &assetErr)
+	assert.Equal(t, ErrorCodeLFSError, assetErr.Code)
+}
+
+func TestClient_LFSStatus(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockLFSRepository{}
+	client, _ := newLFSTestClient(repo)
+	client.lfsBytes = 3 * 1024 * 1024
+
+	repo.On("LFSAvailable", ctx).Return(true)
+	repo.On("LFSFiles", ctx).Return([]git.LFSFile{
+		{Path: "assets/diagrams/flow.svg", Downloaded: true},
+		{Path: "assets/bin/tool.zip"},
+	}, nil)
+
+	status, err := client.LFSStatus(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, &LFSStatus{Available: true, Tracked: 2, Downloaded: 1, BudgetMB: 100, UsedMB: 3}, status)
+
+	// Without LFS support only the budget is reported
+	status, err = NewClient(DefaultConfig(), logging.NewBasic(), nil, nil, &mockGitRepository{}, nil).LFSStatus(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.Available)
+	assert.Equal(t, 100, status.BudgetMB)
+
+	// Clients fetching over HTTP have no clone to report on
+	status, err = NewClientWithHTTP(DefaultConfig(), logging.NewBasic(), nil, nil, nil, nil).LFSStatus(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, status)
+}
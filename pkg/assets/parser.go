@@ -3,6 +3,10 @@ package assets
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/daddia/zen/internal/logging"
@@ -21,12 +25,19 @@ func NewYAMLManifestParser(logger logging.Logger) *YAMLManifestParser {
 	}
 }
 
+// ManifestSchemaMajor is the newest major schema_version of manifests the parser reads.
+// Version 2 adds the assets section and the dependencies of assets and activities.
+const ManifestSchemaMajor = 2
+
 // manifestFile represents the structure of the manifest.yaml file
 type manifestFile struct {
-	SchemaVersion string                   `yaml:"schema_version"`
-	Generated     string                   `yaml:"generated"`
-	Version       string                   `yaml:"version"`
-	Activities    map[string]manifestAsset `yaml:"activities"`
+	SchemaVersion     string                   `yaml:"schema_version"`
+	Generated         string                   `yaml:"generated"`
+	Version           string                   `yaml:"version"`
+	MinZenVersion     string                   `yaml:"min_zen_version,omitempty"`
+	ChecksumAlgorithm string                   `yaml:"checksum_algorithm,omitempty"` // Of checksums written without one
+	Activities        map[string]manifestAsset `yaml:"activities"`
+	Assets            yaml.Node                `yaml:"assets,omitempty"` // Entries since schema 2, ignored before
 }
 
 // manifestAsset represents an activity entry in the manifest
@@ -39,12 +50,29 @@ type manifestAsset struct {
 	WorkflowStages []string `yaml:"workflow_stages"`
 	Tags           []string `yaml:"tags"`
 	UseCases       []string `yaml:"use_cases"`
+	Checksum       string   `yaml:"checksum,omitempty"`
 	Assets         struct {
 		Prompt string   `yaml:"prompt,omitempty"`
 		Output []string `yaml:"output,omitempty"`
 	} `yaml:"assets"`
-	Variables []manifestVariable `yaml:"variables,omitempty"`
-	Version   string             `yaml:"version,omitempty"`
+	Variables    []manifestVariable `yaml:"variables,omitempty"`
+	Version      string             `yaml:"version,omitempty"`
+	Dependencies []string           `yaml:"dependencies,omitempty"` // Since schema 2
+}
+
+// manifestEntry represents an asset other than an activity, such as a partial or a
+// schema that activities depend on. Its name defaults to its key.
+type manifestEntry struct {
+	Name         string             `yaml:"name,omitempty"`
+	Type         AssetType          `yaml:"type"`
+	Description  string             `yaml:"description,omitempty"`
+	Format       string             `yaml:"format,omitempty"`
+	Category     string             `yaml:"category,omitempty"`
+	Tags         []string           `yaml:"tags,omitempty"`
+	Path         string             `yaml:"path"`
+	Checksum     string             `yaml:"checksum,omitempty"`
+	Variables    []manifestVariable `yaml:"variables,omitempty"`
+	Dependencies []string           `yaml:"dependencies,omitempty"`
 }
 
 // manifestVariable represents a variable in the manifest
@@ -76,23 +104,74 @@ func (p *YAMLManifestParser) Parse(ctx context.Context, content []byte) ([]Asset
 			Message: "manifest missing schema_version",
 		}
 	}
+	major, err := schemaMajor(manifest.SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	if major < 2 && hasDependencies(manifest) {
+		p.logger.Warn("ignoring dependencies of a manifest older than schema 2", "schema_version", manifest.SchemaVersion)
+		for key, activity := range manifest.Activities {
+			activity.Dependencies = nil
+			manifest.Activities[key] = activity
+		}
+	}
+	entries, err := manifestEntries(manifest, major)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := manifestChecksumAlgorithm(manifest)
+	if err != nil {
+		return nil, err
+	}
 
 	var assets []AssetMetadata
 
-	// Process activities
-	for activityKey, activity := range manifest.Activities {
+	// Process activities in the order of their keys, so that every parse of a manifest
+	// lists its assets in the same order and page tokens stay valid between runs
+	for _, activityKey := range slices.Sorted(maps.Keys(manifest.Activities)) {
+		activity := manifest.Activities[activityKey]
 		asset, err := p.convertManifestActivity(activity, activityKey)
+		if err == nil {
+			asset.Checksum, err = qualifyChecksum(activity.Checksum, algorithm)
+		}
 		if err != nil {
 			p.logger.Warn("failed to convert activity", "name", activity.Name, "key", activityKey, "error", err)
 			continue
 		}
 		assets = append(assets, asset)
 	}
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+		asset, err := convertManifestEntry(entries[key], key)
+		if err == nil {
+			asset.Checksum, err = qualifyChecksum(asset.Checksum, algorithm)
+		}
+		if err != nil {
+			p.logger.Warn("failed to convert asset", "key", key, "error", err)
+			continue
+		}
+		assets = append(assets, asset)
+	}
 
 	p.logger.Debug("manifest parsed successfully", "assets", len(assets))
 	return assets, nil
 }
 
+// MinZenVersion returns the oldest zen version that can use the assets in a manifest, from
+// its min_zen_version field, or "" when the manifest sets none
+func MinZenVersion(content []byte) (string, error) {
+	var manifest struct {
+		MinZenVersion string `yaml:"min_zen_version"`
+	}
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return "", &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: "failed to parse manifest YAML",
+			Details: err.Error(),
+		}
+	}
+	return manifest.MinZenVersion, nil
+}
+
 // Validate validates the manifest structure
 func (p *YAMLManifestParser) Validate(ctx context.Context, content []byte) error {
 	p.logger.Debug("validating manifest")
@@ -113,6 +192,24 @@ func (p *YAMLManifestParser) Validate(ctx context.Context, content []byte) error
 			Message: "manifest missing required field: schema_version",
 		}
 	}
+	major, err := schemaMajor(manifest.SchemaVersion)
+	if err != nil {
+		return err
+	}
+	if major < 2 && hasDependencies(manifest) {
+		return &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: "dependencies require schema_version 2.0",
+		}
+	}
+	entries, err := manifestEntries(manifest, major)
+	if err != nil {
+		return err
+	}
+	algorithm, err := manifestChecksumAlgorithm(manifest)
+	if err != nil {
+		return err
+	}
 
 	// Validate activity entries
 	names := make(map[string]bool)
@@ -148,6 +245,10 @@ func (p *YAMLManifestParser) Validate(ctx context.Context, content []byte) error
 		}
 		names[activity.Name] = true
 
+		if err := validateChecksum(activity.Name, activity.Checksum, algorithm); err != nil {
+			return err
+		}
+
 		// Validate variables
 		for _, variable := range activity.Variables {
 			if variable.Name == "" {
@@ -159,10 +260,104 @@ func (p *YAMLManifestParser) Validate(ctx context.Context, content []byte) error
 		}
 	}
 
+	// Validate asset entries
+	for key, entry := range entries {
+		name := entry.Name
+		if name == "" {
+			name = key
+		}
+		if _, err := convertManifestEntry(entry, key); err != nil {
+			return err
+		}
+		if err := validateChecksum(name, entry.Checksum, algorithm); err != nil {
+			return err
+		}
+		if names[name] {
+			return &AssetClientError{
+				Code:    ErrorCodeConfigurationError,
+				Message: fmt.Sprintf("duplicate asset name: %s", name),
+			}
+		}
+		names[name] = true
+	}
+
+	// Every dependency must be in the manifest, without cycles
+	if major >= 2 {
+		assets, err := p.Parse(ctx, content)
+		if err != nil {
+			return err
+		}
+		index := newManifestIndex(assets)
+		for _, asset := range assets {
+			if _, err := index.dependencyClosure(asset.Name); err != nil {
+				return err
+			}
+		}
+	}
+
 	p.logger.Debug("manifest validation successful")
 	return nil
 }
 
+// validateChecksum fails for the checksum of an asset that is not a digest of a supported
+// algorithm
+func validateChecksum(name, checksum, algorithm string) error {
+	if _, err := qualifyChecksum(checksum, algorithm); err != nil {
+		return &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: fmt.Sprintf("asset '%s' has an invalid checksum: %v", name, err),
+		}
+	}
+	return nil
+}
+
+// schemaMajor returns the major version of a manifest schema_version, and fails for
+// versions newer than the parser reads
+func schemaMajor(version string) (int, error) {
+	majorPart, _, _ := strings.Cut(version, ".")
+	major, err := strconv.Atoi(majorPart)
+	if err != nil || major < 1 {
+		return 0, &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: fmt.Sprintf("invalid manifest schema_version: %s", version),
+		}
+	}
+	if major > ManifestSchemaMajor {
+		return 0, &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: fmt.Sprintf("manifest schema_version %s is newer than this version of zen reads; upgrade zen with 'zen upgrade'", version),
+		}
+	}
+	return major, nil
+}
+
+// manifestEntries decodes the assets section of a manifest of schema 2 or later. Earlier
+// manifests used the section differently, and their assets section is ignored.
+func manifestEntries(manifest manifestFile, major int) (map[string]manifestEntry, error) {
+	if major < 2 || manifest.Assets.IsZero() {
+		return nil, nil
+	}
+	var entries map[string]manifestEntry
+	if err := manifest.Assets.Decode(&entries); err != nil {
+		return nil, &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: "invalid assets section: expected a map of assets by name",
+			Details: err.Error(),
+		}
+	}
+	return entries, nil
+}
+
+// hasDependencies reports whether any activity of manifest declares dependencies
+func hasDependencies(manifest manifestFile) bool {
+	for _, activity := range manifest.Activities {
+		if len(activity.Dependencies) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // Private helper methods
 
 func (p *YAMLManifestParser) convertManifestActivity(activity manifestAsset, activityKey string) (AssetMetadata, error) {
@@ -194,17 +389,66 @@ func (p *YAMLManifestParser) convertManifestActivity(activity manifestAsset, act
 	}
 
 	return AssetMetadata{
-		Name:        activity.Name,
-		Type:        assetType,
-		Description: activity.Description,
-		Format:      activity.Format,
-		Category:    activity.Category,
-		Tags:        activity.Tags,
-		Variables:   variables,
-		Path:        outputFile, // Use the output file as the path
-		Command:     activity.Command,
-		OutputFile:  outputFile,
-		UpdatedAt:   time.Now(), // This would ideally come from Git
+		Name:         activity.Name,
+		Type:         assetType,
+		Description:  activity.Description,
+		Format:       activity.Format,
+		Category:     activity.Category,
+		Tags:         activity.Tags,
+		Variables:    variables,
+		Path:         outputFile, // Use the output file as the path
+		Command:      activity.Command,
+		OutputFile:   outputFile,
+		Dependencies: activity.Dependencies,
+		UpdatedAt:    time.Now(), // This would ideally come from Git
+	}, nil
+}
+
+// convertManifestEntry converts an entry of the assets section, which must have a known
+// type and a path
+func convertManifestEntry(entry manifestEntry, key string) (AssetMetadata, error) {
+	name := entry.Name
+	if name == "" {
+		name = key
+	}
+
+	switch entry.Type {
+	case AssetTypeTemplate, AssetTypePrompt, AssetTypeMCP, AssetTypeSchema, AssetTypePartial:
+	case "":
+		return AssetMetadata{}, &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: fmt.Sprintf("asset '%s' missing required field: type", name),
+		}
+	default:
+		return AssetMetadata{}, &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: fmt.Sprintf("asset '%s' has unknown type '%s'", name, entry.Type),
+		}
+	}
+	if entry.Path == "" {
+		return AssetMetadata{}, &AssetClientError{
+			Code:    ErrorCodeConfigurationError,
+			Message: fmt.Sprintf("asset '%s' missing required field: path", name),
+		}
+	}
+
+	var variables []Variable
+	for _, v := range entry.Variables {
+		variables = append(variables, Variable(v))
+	}
+
+	return AssetMetadata{
+		Name:         name,
+		Type:         entry.Type,
+		Description:  entry.Description,
+		Format:       entry.Format,
+		Category:     entry.Category,
+		Tags:         entry.Tags,
+		Variables:    variables,
+		Checksum:     entry.Checksum,
+		Path:         entry.Path,
+		Dependencies: entry.Dependencies,
+		UpdatedAt:    time.Now(),
 	}, nil
 }
 
@@ -106,6 +106,26 @@ activities:
 	assert.Equal(t, "code-review.md.tmpl", codeReview.OutputFile)
 }
 
+func TestYAMLManifestParser_Parse_StableOrder(t *testing.T) {
+	parser := NewYAMLManifestParser(logging.NewBasic())
+
+	manifestYAML := `
+schema_version: "1.0"
+activities:
+  zeta: {name: "Zeta", command: "zeta", description: "Z"}
+  alpha: {name: "Alpha", command: "alpha", description: "A"}
+  mu: {name: "Mu", command: "mu", description: "M"}
+  beta: {name: "Beta", command: "beta", description: "B"}
+`
+	for i := 0; i < 10; i++ {
+		assets, err := parser.Parse(context.Background(), []byte(manifestYAML))
+		require.NoError(t, err)
+		require.Len(t, assets, 4)
+		assert.Equal(t, []string{"Alpha", "Beta", "Mu", "Zeta"},
+			[]string{assets[0].Name, assets[1].Name, assets[2].Name, assets[3].Name})
+	}
+}
+
 func TestYAMLManifestParser_Parse_InvalidYAML(t *testing.T) {
 	logger := logging.NewBasic()
 	parser := NewYAMLManifestParser(logger)
@@ -130,6 +150,20 @@ assets:
 	assert.Contains(t, assetErr.Message, "failed to parse manifest YAML")
 }
 
+func TestMinZenVersion(t *testing.T) {
+	version, err := MinZenVersion([]byte("schema_version: \"1.0\"\nmin_zen_version: v1.5.0\nactivities: {}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1.5.0", version)
+
+	version, err = MinZenVersion([]byte("schema_version: \"1.0\"\n"))
+	require.NoError(t, err)
+	assert.Empty(t, version)
+
+	_, err = MinZenVersion([]byte("min_zen_version: [unclosed"))
+	var assetErr *AssetClientError
+	assert.ErrorAs(t, err, &assetErr)
+}
+
 func TestYAMLManifestParser_Parse_MissingSchemaVersion(t *testing.T) {
 	logger := logging.NewBasic()
 	parser := NewYAMLManifestParser(logger)
@@ -0,0 +1,76 @@
+package assets
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/errors"
+	"github.com/daddia/zen/pkg/policy"
+)
+
+// PolicyBundleFile is the organization policy bundle an asset repository may carry
+const PolicyBundleFile = "assets/policy.yaml"
+
+// PolicyBundlePath returns the path of the policy bundle installed by syncs in the
+// workspace at root
+func PolicyBundlePath(root string) string {
+	return policy.BundlePath(filepath.Join(root, ".zen"))
+}
+
+// syncPolicyBundle installs the policy bundle of the repository in the workspace, and
+// returns its name. A bundle the repository no longer carries is removed, so that
+// workspaces stop enforcing it; an invalid bundle is not installed.
+func (c *Client) syncPolicyBundle(ctx context.Context) (string, error) {
+	var content []byte
+	var err error
+	switch {
+	case c.http != nil:
+		content, err = c.http.DownloadFile(ctx, c.config.RepositoryURL, c.config.Branch, PolicyBundleFile)
+	case c.git != nil:
+		content, err = c.git.GetFile(ctx, PolicyBundleFile)
+	default:
+		return "", nil
+	}
+
+	path := PolicyBundlePath(workspaceRoot())
+	if isFileNotFound(err) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return "", errors.Wrap(err, "failed to remove policy bundle")
+		}
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load policy bundle: %w", err)
+	}
+
+	bundle, err := policy.ParseBundle(content)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return "", errors.Wrap(err, "failed to create library directory")
+	}
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return "", errors.Wrap(err, "failed to write policy bundle")
+	}
+
+	c.logger.Debug("policy bundle installed", "name", bundle.Name, "path", path)
+	return bundle.Name, nil
+}
+
+// isFileNotFound reports whether err is a file missing from the repository
+func isFileNotFound(err error) bool {
+	var assetErr *AssetClientError
+	if stderrors.As(err, &assetErr) {
+		return assetErr.Code == ErrorCodeAssetNotFound
+	}
+	var gitErr *git.GitError
+	if stderrors.As(err, &gitErr) {
+		return gitErr.Code == git.ErrorCodeFileNotFound
+	}
+	return false
+}
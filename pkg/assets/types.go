@@ -2,10 +2,19 @@ package assets
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cache"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/dirs"
+	"github.com/daddia/zen/pkg/fs"
 	"github.com/go-viper/mapstructure/v2"
 )
 
@@ -17,6 +26,7 @@ const (
 	AssetTypePrompt   AssetType = "prompt"
 	AssetTypeMCP      AssetType = "mcp"
 	AssetTypeSchema   AssetType = "schema"
+	AssetTypePartial  AssetType = "partial" // A template included by other templates
 )
 
 // AssetMetadata contains metadata about an asset/activity
@@ -30,9 +40,10 @@ type AssetMetadata struct {
 	Variables      []Variable `yaml:"variables" json:"variables"`
 	Checksum       string     `yaml:"checksum" json:"checksum"`
 	Path           string     `yaml:"path" json:"path"`
-	Command        string     `yaml:"command" json:"command"`                 // CLI command for the activity
-	OutputFile     string     `yaml:"output_file" json:"output_file"`         // Primary output file
-	WorkflowStages []string   `yaml:"workflow_stages" json:"workflow_stages"` // Zenflow stages this activity belongs to
+	Command        string     `yaml:"command" json:"command"`                               // CLI command for the activity
+	OutputFile     string     `yaml:"output_file" json:"output_file"`                       // Primary output file
+	WorkflowStages []string   `yaml:"workflow_stages" json:"workflow_stages"`               // Zenflow stages this activity belongs to
+	Dependencies   []string   `yaml:"dependencies,omitempty" json:"dependencies,omitempty"` // Names of the assets this asset needs
 	UpdatedAt      time.Time  `yaml:"updated_at" json:"updated_at"`
 }
 
@@ -47,54 +58,89 @@ type Variable struct {
 
 // AssetContent represents asset content with metadata
 type AssetContent struct {
-	Metadata AssetMetadata `json:"metadata"`
-	Content  string        `json:"content"`
-	Checksum string        `json:"checksum"`
-	Cached   bool          `json:"cached"`
-	CacheAge int64         `json:"cache_age"`
+	Metadata AssetMetadata `json:"metadata" yaml:"metadata"`
+	Content  string        `json:"content" yaml:"content"`
+	Checksum string        `json:"checksum" yaml:"checksum"`
+	Cached   bool          `json:"cached" yaml:"cached"`
+	CacheAge int64         `json:"cache_age" yaml:"cache_age"`
+
+	// Dependencies are the assets this asset needs, directly or through other assets,
+	// each listed after the assets it needs in turn
+	Dependencies []AssetContent `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 }
 
 // AssetFilter represents filtering options for asset queries
 type AssetFilter struct {
-	Type     AssetType `json:"type,omitempty"`
-	Category string    `json:"category,omitempty"`
-	Tags     []string  `json:"tags,omitempty"`
-	Limit    int       `json:"limit,omitempty"`
-	Offset   int       `json:"offset,omitempty"`
+	Type     AssetType `json:"type,omitempty" yaml:"type,omitempty"`
+	Category string    `json:"category,omitempty" yaml:"category,omitempty"`
+	Tags     []string  `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Limit    int       `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Offset   int       `json:"offset,omitempty" yaml:"offset,omitempty"`
+
+	// PageToken continues a listing where an earlier page ended, in place of Offset
+	PageToken string `json:"page_token,omitempty" yaml:"page_token,omitempty"`
 }
 
 // AssetList represents a paginated list of assets
 type AssetList struct {
-	Assets  []AssetMetadata `json:"assets"`
-	Total   int             `json:"total"`
-	HasMore bool            `json:"has_more"`
+	Assets  []AssetMetadata `json:"assets" yaml:"assets"`
+	Total   int             `json:"total" yaml:"total"`
+	HasMore bool            `json:"has_more" yaml:"has_more"`
+
+	// NextPageToken continues the listing with the next page, when there is one
+	NextPageToken string `json:"next_page_token,omitempty" yaml:"next_page_token,omitempty"`
 }
 
 // SyncRequest represents a repository synchronization request
 type SyncRequest struct {
-	Force   bool   `json:"force"`
-	Shallow bool   `json:"shallow"`
-	Branch  string `json:"branch"`
+	Force   bool   `json:"force" yaml:"force"`
+	Shallow bool   `json:"shallow" yaml:"shallow"`
+	Branch  string `json:"branch" yaml:"branch"`
+
+	// NoWait fails the sync at once if another operation holds the repository lock
+	NoWait bool `json:"no_wait" yaml:"no_wait"`
+
+	// LockTimeout bounds the wait for the repository lock; zero waits as long as the context allows
+	LockTimeout time.Duration `json:"lock_timeout" yaml:"lock_timeout"`
+
+	// DryRun compares the remote manifest with the last sync without updating the clone,
+	// the local manifest, the cache, or the sync checkpoint
+	DryRun bool `json:"dry_run" yaml:"dry_run"`
 }
 
 // SyncResult represents the result of a synchronization operation
 type SyncResult struct {
-	Status        string    `json:"status"`
-	DurationMS    int64     `json:"duration_ms"`
-	AssetsUpdated int       `json:"assets_updated"`
-	AssetsAdded   int       `json:"assets_added"`
-	AssetsRemoved int       `json:"assets_removed"`
-	CacheSizeMB   float64   `json:"cache_size_mb"`
-	LastSync      time.Time `json:"last_sync"`
-	Error         string    `json:"error,omitempty"`
+	Status        string    `json:"status" yaml:"status"`
+	DurationMS    int64     `json:"duration_ms" yaml:"duration_ms"`
+	AssetsUpdated int       `json:"assets_updated" yaml:"assets_updated"`
+	AssetsAdded   int       `json:"assets_added" yaml:"assets_added"`
+	AssetsRemoved int       `json:"assets_removed" yaml:"assets_removed"`
+	CacheSizeMB   float64   `json:"cache_size_mb" yaml:"cache_size_mb"`
+	LastSync      time.Time `json:"last_sync" yaml:"last_sync"`
+	Error         string    `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// Commit is the commit of the local clone the manifest was read from
+	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
+
+	// Resumed reports that this sync picked up after an earlier one was interrupted
+	Resumed bool `json:"resumed,omitempty" yaml:"resumed,omitempty"`
+
+	// Changes names the assets added, updated, and removed since the last sync
+	Changes *ManifestDelta `json:"changes,omitempty" yaml:"changes,omitempty"`
+
+	// DryRun reports that Changes are what a sync would apply; nothing was changed
+	DryRun bool `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+
+	// PolicyBundle is the name of the organization policy bundle the sync installed
+	PolicyBundle string `json:"policy_bundle,omitempty" yaml:"policy_bundle,omitempty"`
 }
 
 // CacheInfo represents cache status information
 type CacheInfo struct {
-	TotalSize     int64     `json:"total_size"`
-	AssetCount    int       `json:"asset_count"`
-	LastSync      time.Time `json:"last_sync"`
-	CacheHitRatio float64   `json:"cache_hit_ratio"`
+	TotalSize     int64     `json:"total_size" yaml:"total_size"`
+	AssetCount    int       `json:"asset_count" yaml:"asset_count"`
+	LastSync      time.Time `json:"last_sync" yaml:"last_sync"`
+	CacheHitRatio float64   `json:"cache_hit_ratio" yaml:"cache_hit_ratio"`
 }
 
 // GetAssetOptions represents options for asset retrieval
@@ -129,6 +175,11 @@ const (
 	ErrorCodeRateLimited          AssetErrorCode = "rate_limited"
 	ErrorCodeRepositoryError      AssetErrorCode = "repository_error"
 	ErrorCodeConfigurationError   AssetErrorCode = "configuration_error"
+	ErrorCodeLFSError             AssetErrorCode = "lfs_error"
+	ErrorCodeRepositoryLocked     AssetErrorCode = "repository_locked"
+	ErrorCodeInvalidPageToken     AssetErrorCode = "invalid_page_token"
+	ErrorCodeDependencyError      AssetErrorCode = "dependency_error"
+	ErrorCodeInsufficientScopes   AssetErrorCode = "insufficient_scopes"
 )
 
 // AssetClientInterface defines the interface for asset operations
@@ -208,6 +259,15 @@ type Config struct {
 	CacheSizeMB int64         `yaml:"cache_size_mb" json:"cache_size_mb" mapstructure:"cache_size_mb"`
 	DefaultTTL  time.Duration `yaml:"default_ttl" json:"default_ttl" mapstructure:"default_ttl"`
 
+	// CacheBackend stores cached content on disk ("file"), in a bbolt database ("bolt"), or only for the current run ("memory")
+	CacheBackend string `yaml:"cache_backend" json:"cache_backend" mapstructure:"cache_backend"`
+
+	// SharedStore keeps asset content and repository clones in the content-addressed store
+	// at StorePath, shared by every workspace on the machine. Caches link to its content
+	// instead of keeping copies, and workspaces using the same repository share one clone.
+	SharedStore bool   `yaml:"shared_store" json:"shared_store" mapstructure:"shared_store"`
+	StorePath   string `yaml:"store_path" json:"store_path" mapstructure:"store_path"`
+
 	// Authentication configuration
 	AuthProvider string `yaml:"auth_provider" json:"auth_provider" mapstructure:"auth_provider"`
 
@@ -215,9 +275,94 @@ type Config struct {
 	SyncTimeoutSeconds int `yaml:"sync_timeout_seconds" json:"sync_timeout_seconds" mapstructure:"sync_timeout_seconds"`
 	MaxConcurrentOps   int `yaml:"max_concurrent_ops" json:"max_concurrent_ops" mapstructure:"max_concurrent_ops"`
 
+	// Integrity is the policy for assets whose content does not match their checksum in
+	// the manifest: "required", "warn", or "off". When it is not set, the policy is "warn",
+	// or "off" when IntegrityChecksEnabled is false.
+	Integrity string `yaml:"integrity" json:"integrity" mapstructure:"integrity"`
+
 	// Feature flags
 	IntegrityChecksEnabled bool `yaml:"integrity_checks_enabled" json:"integrity_checks_enabled" mapstructure:"integrity_checks_enabled"`
 	PrefetchEnabled        bool `yaml:"prefetch_enabled" json:"prefetch_enabled" mapstructure:"prefetch_enabled"`
+
+	// TrackUsage records which assets task creation uses in the workspace, for zen assets stats
+	TrackUsage bool `yaml:"track_usage" json:"track_usage" mapstructure:"track_usage"`
+
+	// Sync configuration
+	Sync SyncConfig `yaml:"sync" json:"sync" mapstructure:"sync"`
+}
+
+// SyncConfig configures how the asset repository is synchronized
+type SyncConfig struct {
+	// SparsePaths are directories of the asset repository to check out, e.g. "assets/templates".
+	// When set, sync keeps a sparse local clone instead of fetching files over HTTP.
+	SparsePaths []string `yaml:"sparse_paths" json:"sparse_paths" mapstructure:"sparse_paths"`
+
+	// PartialClone clones without file contents, which are fetched only for checked out paths
+	PartialClone bool `yaml:"partial_clone" json:"partial_clone" mapstructure:"partial_clone"`
+
+	// LFSBudgetMB caps the Git LFS content downloaded per session; 0 disables LFS downloads
+	LFSBudgetMB int `yaml:"lfs_budget_mb" json:"lfs_budget_mb" mapstructure:"lfs_budget_mb"`
+
+	// MaxRetries is how many times a clone or pull interrupted by the network is retried
+	MaxRetries int `yaml:"max_retries" json:"max_retries" mapstructure:"max_retries"`
+
+	// RetryDelay is the wait before the first retry; it doubles with each retry after that
+	RetryDelay time.Duration `yaml:"retry_delay" json:"retry_delay" mapstructure:"retry_delay"`
+}
+
+// Sparse reports whether sync keeps a sparse local clone
+func (c SyncConfig) Sparse() bool {
+	return len(c.SparsePaths) > 0
+}
+
+// IntegrityPolicy returns the integrity policy, from Integrity or IntegrityChecksEnabled
+func (c Config) IntegrityPolicy() string {
+	switch {
+	case c.Integrity != "":
+		return c.Integrity
+	case !c.IntegrityChecksEnabled:
+		return IntegrityOff
+	default:
+		return IntegrityWarn
+	}
+}
+
+// ResolvedCachePath returns the cache path with a leading "~/" expanded to the home directory
+func (c Config) ResolvedCachePath() (string, error) {
+	return fs.ExpandHome(c.CachePath)
+}
+
+// ResolvedStorePath returns the store path with a leading "~/" expanded to the home directory
+func (c Config) ResolvedStorePath() (string, error) {
+	return fs.ExpandHome(c.StorePath)
+}
+
+// RepositoryPath returns the directory of the local clone of the asset repository. With
+// the shared store, workspaces that check out the same paths of the same branch share a
+// clone; otherwise each cache has its own.
+func (c Config) RepositoryPath() (string, error) {
+	if !c.SharedStore {
+		cachePath, err := c.ResolvedCachePath()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(cachePath, "repository"), nil
+	}
+
+	storePath, err := c.ResolvedStorePath()
+	if err != nil {
+		return "", err
+	}
+	paths := slices.Clone(c.Sync.SparsePaths)
+	slices.Sort(paths)
+	sum := sha256.Sum256([]byte(strings.Join(append([]string{c.RepositoryURL, c.Branch}, paths...), "\n")))
+	return filepath.Join(storePath, "repositories", hex.EncodeToString(sum[:8])), nil
+}
+
+// LocalClone reports whether sync keeps a local clone of the repository rather than
+// fetching files over HTTP: for sparse checkouts, and for SSH remotes that HTTP cannot reach
+func (c Config) LocalClone() bool {
+	return c.Sync.Sparse() || git.URLScheme(c.RepositoryURL) == git.SchemeSSH
 }
 
 // DefaultConfig returns default asset client configuration
@@ -225,14 +370,23 @@ func DefaultConfig() Config {
 	return Config{
 		RepositoryURL:          "https://github.com/daddia/zen-assets.git", // Default official repository
 		Branch:                 "main",
-		CachePath:              "~/.zen/library",
+		CachePath:              dirs.CachePath(CacheNamespace),
 		CacheSizeMB:            100,
 		DefaultTTL:             24 * time.Hour,
+		CacheBackend:           cache.BackendFile,
+		StorePath:              dirs.CachePath("store"),
 		AuthProvider:           "github",
 		SyncTimeoutSeconds:     30,
 		MaxConcurrentOps:       3,
 		IntegrityChecksEnabled: true,
 		PrefetchEnabled:        true,
+		TrackUsage:             true,
+		Sync: SyncConfig{
+			PartialClone: true,
+			LFSBudgetMB:  100,
+			MaxRetries:   3,
+			RetryDelay:   time.Second,
+		},
 	}
 }
 
@@ -252,12 +406,36 @@ func (c Config) Validate() error {
 	if c.CacheSizeMB <= 0 {
 		return fmt.Errorf("cache_size_mb must be positive")
 	}
+	if c.CacheBackend != "" && !slices.Contains(cache.Backends, c.CacheBackend) {
+		return fmt.Errorf("cache_backend must be one of: %s", strings.Join(cache.Backends, ", "))
+	}
+	if c.Integrity != "" && !slices.Contains(IntegrityPolicies, c.Integrity) {
+		return fmt.Errorf("integrity must be one of: %s", strings.Join(IntegrityPolicies, ", "))
+	}
+	if c.SharedStore && c.StorePath == "" {
+		return fmt.Errorf("store_path is required when shared_store is enabled")
+	}
 	if c.SyncTimeoutSeconds <= 0 {
 		return fmt.Errorf("sync_timeout_seconds must be positive")
 	}
 	if c.MaxConcurrentOps <= 0 {
 		return fmt.Errorf("max_concurrent_ops must be positive")
 	}
+	if c.Sync.LFSBudgetMB < 0 {
+		return fmt.Errorf("sync.lfs_budget_mb must not be negative")
+	}
+	if c.Sync.MaxRetries < 0 {
+		return fmt.Errorf("sync.max_retries must not be negative")
+	}
+	if c.Sync.RetryDelay < 0 {
+		return fmt.Errorf("sync.retry_delay must not be negative")
+	}
+	for _, path := range c.Sync.SparsePaths {
+		clean := filepath.ToSlash(filepath.Clean(path))
+		if path == "" || filepath.IsAbs(path) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("sync.sparse_paths: %q must be a directory inside the repository", path)
+		}
+	}
 	return nil
 }
 
@@ -1,9 +1,11 @@
 package assets
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/daddia/zen/pkg/cache"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,17 +19,122 @@ func TestAssetClientError_Error(t *testing.T) {
 }
 
 func TestDefaultConfig(t *testing.T) {
+	t.Setenv("ZEN_CACHE_DIR", "/var/cache/zen")
 	config := DefaultConfig()
 
 	assert.Equal(t, "main", config.Branch)
-	assert.Equal(t, "~/.zen/library", config.CachePath)
+	assert.Equal(t, filepath.Join("/var/cache/zen", "assets"), config.CachePath)
 	assert.Equal(t, int64(100), config.CacheSizeMB)
 	assert.Equal(t, 24*time.Hour, config.DefaultTTL)
+	assert.Equal(t, cache.BackendFile, config.CacheBackend)
+	assert.False(t, config.SharedStore)
+	assert.Equal(t, filepath.Join("/var/cache/zen", "store"), config.StorePath)
 	assert.Equal(t, "github", config.AuthProvider)
 	assert.Equal(t, 30, config.SyncTimeoutSeconds)
 	assert.Equal(t, 3, config.MaxConcurrentOps)
 	assert.True(t, config.IntegrityChecksEnabled)
 	assert.True(t, config.PrefetchEnabled)
+	assert.True(t, config.Sync.PartialClone)
+	assert.False(t, config.Sync.Sparse())
+	assert.Equal(t, 3, config.Sync.MaxRetries)
+	assert.Equal(t, time.Second, config.Sync.RetryDelay)
+}
+
+func TestConfigParser_SyncRetries(t *testing.T) {
+	config, err := ConfigParser{}.Parse(map[string]interface{}{
+		"sync": map[string]interface{}{
+			"max_retries": 5,
+			"retry_delay": "250ms",
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, config.Validate())
+	assert.Equal(t, 5, config.Sync.MaxRetries)
+	assert.Equal(t, 250*time.Millisecond, config.Sync.RetryDelay)
+
+	config.Sync.MaxRetries = -1
+	assert.Error(t, config.Validate())
+}
+
+func TestConfigParser_CacheBackend(t *testing.T) {
+	config, err := ConfigParser{}.Parse(map[string]interface{}{
+		"cache_backend": "memory",
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, config.Validate())
+	assert.Equal(t, cache.BackendMemory, config.CacheBackend)
+
+	config.CacheBackend = "redis"
+	assert.Error(t, config.Validate())
+}
+
+func TestConfigParser_SyncSparsePaths(t *testing.T) {
+	config, err := ConfigParser{}.Parse(map[string]interface{}{
+		"sync": map[string]interface{}{
+			"sparse_paths":  []interface{}{"assets/templates", "assets/prompts"},
+			"partial_clone": false,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, config.Validate())
+	assert.Equal(t, []string{"assets/templates", "assets/prompts"}, config.Sync.SparsePaths)
+	assert.False(t, config.Sync.PartialClone)
+
+	for _, path := range []string{"", "/etc", "../outside"} {
+		config.Sync.SparsePaths = []string{path}
+		assert.Error(t, config.Validate(), path)
+	}
+
+	config.Sync.SparsePaths = nil
+	config.Sync.LFSBudgetMB = -1
+	assert.Error(t, config.Validate())
+}
+
+func TestConfigParser_SharedStore(t *testing.T) {
+	config, err := ConfigParser{}.Parse(map[string]interface{}{
+		"shared_store": true,
+		"store_path":   "/srv/zen/store",
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, config.Validate())
+	assert.True(t, config.SharedStore)
+
+	config.StorePath = ""
+	assert.Error(t, config.Validate())
+}
+
+func TestConfig_RepositoryPath(t *testing.T) {
+	config := DefaultConfig()
+	config.CachePath = "/work/.zen/cache/assets"
+	config.StorePath = "/srv/zen/store"
+
+	path, err := config.RepositoryPath()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("/work/.zen/cache/assets", "repository"), path)
+
+	config.SharedStore = true
+	config.Sync.SparsePaths = []string{"assets/templates", "assets/prompts"}
+	shared, err := config.RepositoryPath()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("/srv/zen/store", "repositories"), filepath.Dir(shared))
+
+	// Workspaces of another cache path share the clone, whatever the order of their paths
+	other := config
+	other.CachePath = "/other/.zen/cache/assets"
+	other.Sync.SparsePaths = []string{"assets/prompts", "assets/templates"}
+	otherPath, err := other.RepositoryPath()
+	assert.NoError(t, err)
+	assert.Equal(t, shared, otherPath)
+
+	// Other branches and paths get a clone of their own
+	other.Branch = "develop"
+	otherPath, err = other.RepositoryPath()
+	assert.NoError(t, err)
+	assert.NotEqual(t, shared, otherPath)
 }
 
 func TestAssetType_Constants(t *testing.T) {
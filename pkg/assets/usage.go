@@ -0,0 +1,254 @@
+package assets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/fs"
+)
+
+// UsagePath returns the path of the asset usage log of the workspace at root
+func UsagePath(root string) string {
+	return filepath.Join(root, ".zen", "library", "usage.json")
+}
+
+// usageLockTimeout bounds the wait for another command recording usage
+const usageLockTimeout = 5 * time.Second
+
+// AssetUsage counts the uses of one asset in a workspace
+type AssetUsage struct {
+	Name  string `json:"name" yaml:"name"`
+	Count int    `json:"count" yaml:"count"`
+
+	// Sources counts the uses by each command that used the asset, such as "draft"
+	Sources map[string]int `json:"sources,omitempty" yaml:"sources,omitempty"`
+
+	FirstUsed time.Time `json:"first_used,omitzero" yaml:"first_used,omitempty"`
+	LastUsed  time.Time `json:"last_used,omitzero" yaml:"last_used,omitempty"`
+}
+
+// Usage is the log of the assets that commands creating tasks and their documents used
+// in a workspace. It never leaves the workspace; UsageReport makes an anonymized copy.
+type Usage struct {
+	Assets map[string]*AssetUsage `json:"assets"`
+}
+
+// LoadUsage reads the usage log at path. A missing log is empty.
+func LoadUsage(path string) (*Usage, error) {
+	usage := &Usage{Assets: make(map[string]*AssetUsage)}
+
+	data, err := os.ReadFile(path) // #nosec G304 - path is the usage log of the workspace
+	if os.IsNotExist(err) {
+		return usage, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, usage); err != nil {
+		return nil, fmt.Errorf("invalid usage log %s: %w", path, err)
+	}
+	if usage.Assets == nil {
+		usage.Assets = make(map[string]*AssetUsage)
+	}
+	return usage, nil
+}
+
+// Record counts a use of the asset named name by source at the time at
+func (u *Usage) Record(name, source string, at time.Time) {
+	asset, ok := u.Assets[name]
+	if !ok {
+		asset = &AssetUsage{Name: name, FirstUsed: at}
+		u.Assets[name] = asset
+	}
+	asset.Count++
+	if source != "" {
+		if asset.Sources == nil {
+			asset.Sources = make(map[string]int)
+		}
+		asset.Sources[source]++
+	}
+	if at.After(asset.LastUsed) {
+		asset.LastUsed = at
+	}
+}
+
+// Save writes the usage log to path, replacing it whole so that readers never see a
+// partial log
+func (u *Usage) Save(path string) error {
+	data, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// RecordUsage records that source used the assets named names in the workspace at root.
+// It holds a lock on the log, so that commands running at the same time do not lose
+// each other's records.
+func RecordUsage(ctx context.Context, root, source string, names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	path := UsagePath(root)
+
+	lock, err := fs.AcquireLock(ctx, path+".lock", fs.LockOptions{Timeout: usageLockTimeout})
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	usage, err := LoadUsage(path)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for _, name := range names {
+		usage.Record(name, source, now)
+	}
+	return usage.Save(path)
+}
+
+// UsageTracked reports whether cfg has asset usage recorded, as assets.track_usage does
+// by default
+func UsageTracked(cfg *config.Config) bool {
+	assetsConfig, err := config.GetConfig(cfg, ConfigParser{})
+	return err == nil && assetsConfig.TrackUsage
+}
+
+// UsageStats summarizes the usage of the assets of a manifest
+type UsageStats struct {
+	TotalUses int `json:"total_uses" yaml:"total_uses"`
+	StaleDays int `json:"stale_days" yaml:"stale_days"`
+
+	// MostUsed and LeastUsed are the assets used most and least, at most the limit given
+	// to Stats of each. LeastUsed starts with assets that were never used.
+	MostUsed  []AssetUsage `json:"most_used" yaml:"most_used"`
+	LeastUsed []AssetUsage `json:"least_used" yaml:"least_used"`
+
+	// Stale are the assets not used in the last StaleDays days, never used ones first
+	Stale []AssetUsage `json:"stale" yaml:"stale"`
+}
+
+// Stats summarizes the usage of the assets in manifest as of now. Uses of assets that
+// are no longer in the manifest are left out; with an empty manifest, every asset used
+// is counted.
+func (u *Usage) Stats(manifest []AssetMetadata, staleDays, limit int, now time.Time) *UsageStats {
+	var assets []AssetUsage
+	if len(manifest) == 0 {
+		for _, asset := range u.Assets {
+			assets = append(assets, *asset)
+		}
+	} else {
+		seen := make(map[string]bool, len(manifest))
+		for _, metadata := range manifest {
+			if seen[metadata.Name] {
+				continue
+			}
+			seen[metadata.Name] = true
+			asset := AssetUsage{Name: metadata.Name}
+			if used, ok := u.Assets[metadata.Name]; ok {
+				asset = *used
+			}
+			assets = append(assets, asset)
+		}
+	}
+
+	stats := &UsageStats{StaleDays: staleDays}
+	for _, asset := range assets {
+		stats.TotalUses += asset.Count
+	}
+
+	// Most used first, ties broken by the most recent use and then by name
+	sort.Slice(assets, func(i, j int) bool {
+		a, b := assets[i], assets[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if !a.LastUsed.Equal(b.LastUsed) {
+			return a.LastUsed.After(b.LastUsed)
+		}
+		return a.Name < b.Name
+	})
+	for _, asset := range assets {
+		if asset.Count == 0 || len(stats.MostUsed) == limit {
+			break
+		}
+		stats.MostUsed = append(stats.MostUsed, asset)
+	}
+	for i := len(assets) - 1; i >= 0 && len(stats.LeastUsed) < limit; i-- {
+		stats.LeastUsed = append(stats.LeastUsed, assets[i])
+	}
+
+	cutoff := now.AddDate(0, 0, -staleDays)
+	for _, asset := range assets {
+		if asset.LastUsed.Before(cutoff) {
+			stats.Stale = append(stats.Stale, asset)
+		}
+	}
+	sort.SliceStable(stats.Stale, func(i, j int) bool {
+		return stats.Stale[i].LastUsed.Before(stats.Stale[j].LastUsed)
+	})
+
+	return stats
+}
+
+// UsageReport is the usage of a workspace with everything that could identify it left
+// out, to be shared with the maintainers of the asset repository: only assets of the
+// manifest are reported, with their counts and the month of their last use.
+type UsageReport struct {
+	SchemaVersion int             `json:"schema_version" yaml:"schema_version"`
+	Generated     string          `json:"generated" yaml:"generated"`
+	Assets        []ReportedAsset `json:"assets" yaml:"assets"`
+}
+
+// ReportedAsset is the usage of one asset in a UsageReport
+type ReportedAsset struct {
+	Name     string         `json:"name" yaml:"name"`
+	Count    int            `json:"count" yaml:"count"`
+	Sources  map[string]int `json:"sources,omitempty" yaml:"sources,omitempty"`
+	LastUsed string         `json:"last_used,omitempty" yaml:"last_used,omitempty"`
+}
+
+// Report returns the anonymized usage of the assets in manifest as of now
+func (u *Usage) Report(manifest []AssetMetadata, now time.Time) *UsageReport {
+	report := &UsageReport{SchemaVersion: 1, Generated: now.UTC().Format("2006-01-02")}
+
+	seen := make(map[string]bool, len(manifest))
+	for _, metadata := range manifest {
+		if seen[metadata.Name] {
+			continue
+		}
+		seen[metadata.Name] = true
+
+		reported := ReportedAsset{Name: metadata.Name}
+		if used, ok := u.Assets[metadata.Name]; ok {
+			reported.Count = used.Count
+			reported.Sources = used.Sources
+			if !used.LastUsed.IsZero() {
+				reported.LastUsed = used.LastUsed.UTC().Format("2006-01")
+			}
+		}
+		report.Assets = append(report.Assets, reported)
+	}
+	sort.Slice(report.Assets, func(i, j int) bool { return report.Assets[i].Name < report.Assets[j].Name })
+
+	return report
+}
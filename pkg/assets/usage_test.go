@@ -0,0 +1,119 @@
+package assets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsage_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "library", "usage.json")
+
+	usage, err := LoadUsage(path)
+	require.NoError(t, err)
+	assert.Empty(t, usage.Assets)
+
+	first := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	usage.Record("feature-spec", "draft", first)
+	usage.Record("feature-spec", "draft", first.Add(time.Hour))
+	usage.Record("story-checklist", "task create", first)
+	usage.Record("feature-spec", "", first.Add(-time.Hour))
+	require.NoError(t, usage.Save(path))
+
+	loaded, err := LoadUsage(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Assets, 2)
+
+	spec := loaded.Assets["feature-spec"]
+	assert.Equal(t, 3, spec.Count)
+	assert.Equal(t, map[string]int{"draft": 2}, spec.Sources)
+	assert.True(t, first.Equal(spec.FirstUsed))
+	assert.True(t, first.Add(time.Hour).Equal(spec.LastUsed), "an earlier use leaves the last use")
+
+	t.Run("invalid log", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+		_, err := LoadUsage(path)
+		assert.ErrorContains(t, err, "invalid usage log")
+	})
+}
+
+func TestRecordUsage(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, RecordUsage(ctx, root, "draft", "page", "header"))
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, RecordUsage(ctx, root, "draft"))
+
+	usage, err := LoadUsage(UsagePath(root))
+	require.NoError(t, err)
+	assert.Equal(t, 5, usage.Assets["page"].Count, "concurrent records are all kept")
+	assert.Equal(t, 5, usage.Assets["header"].Sources["draft"])
+}
+
+func TestUsage_Stats(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	usage := &Usage{Assets: map[string]*AssetUsage{
+		"spec":    {Name: "spec", Count: 7, LastUsed: now.AddDate(0, 0, -1)},
+		"story":   {Name: "story", Count: 3, LastUsed: now.AddDate(0, 0, -2)},
+		"epic":    {Name: "epic", Count: 3, LastUsed: now.AddDate(0, 0, -45)},
+		"removed": {Name: "removed", Count: 20, LastUsed: now},
+	}}
+	manifest := []AssetMetadata{{Name: "spec"}, {Name: "story"}, {Name: "epic"}, {Name: "roadmap"}}
+
+	stats := usage.Stats(manifest, 30, 3, now)
+	assert.Equal(t, 13, stats.TotalUses, "assets no longer in the manifest are left out")
+	assert.Equal(t, 30, stats.StaleDays)
+	assert.Equal(t, []string{"spec", "story", "epic"}, usageNames(stats.MostUsed))
+	assert.Equal(t, []string{"roadmap", "epic", "story"}, usageNames(stats.LeastUsed))
+	assert.Equal(t, []string{"roadmap", "epic"}, usageNames(stats.Stale))
+
+	t.Run("limit leaves out unused assets from the most used", func(t *testing.T) {
+		stats := usage.Stats(manifest, 30, 10, now)
+		assert.Equal(t, []string{"spec", "story", "epic"}, usageNames(stats.MostUsed))
+		assert.Len(t, stats.LeastUsed, 4)
+	})
+
+	t.Run("without a manifest every asset used counts", func(t *testing.T) {
+		stats := usage.Stats(nil, 30, 10, now)
+		assert.Equal(t, 33, stats.TotalUses)
+		assert.Equal(t, []string{"removed", "spec", "story", "epic"}, usageNames(stats.MostUsed))
+	})
+}
+
+func TestUsage_Report(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	usage := &Usage{Assets: map[string]*AssetUsage{
+		"spec":           {Name: "spec", Count: 2, Sources: map[string]int{"draft": 2}, FirstUsed: now.AddDate(0, -2, 0), LastUsed: now.AddDate(0, 0, -40)},
+		"internal-notes": {Name: "internal-notes", Count: 9, LastUsed: now},
+	}}
+
+	report := usage.Report([]AssetMetadata{{Name: "story"}, {Name: "spec"}}, now)
+	assert.Equal(t, 1, report.SchemaVersion)
+	assert.Equal(t, "2026-06-30", report.Generated)
+	assert.Equal(t, []ReportedAsset{
+		{Name: "spec", Count: 2, Sources: map[string]int{"draft": 2}, LastUsed: "2026-05"},
+		{Name: "story"},
+	}, report.Assets, "only assets of the manifest are reported, by month of last use")
+}
+
+func usageNames(assets []AssetUsage) []string {
+	var names []string
+	for _, asset := range assets {
+		names = append(names, asset.Name)
+	}
+	return names
+}
@@ -88,6 +88,13 @@ type Config struct {
 
 	// Provider configuration
 	Providers map[string]ProviderConfig `yaml:"providers" json:"providers"`
+
+	// GitHub configures how zen authenticates with GitHub
+	GitHub GitHubConfig `yaml:"github" json:"github" mapstructure:"github"`
+
+	// CI exchanges the OIDC tokens of CI jobs and cloud workloads for provider
+	// credentials, so pipelines need no long-lived tokens
+	CI CIConfig `yaml:"ci" json:"ci" mapstructure:"ci"`
 }
 
 // ProviderConfig represents provider-specific configuration
@@ -106,6 +113,8 @@ func DefaultConfig() Config {
 		StorageType:       "keychain",
 		ValidationTimeout: 10 * time.Second,
 		CacheTimeout:      1 * time.Hour,
+		GitHub:            DefaultGitHubConfig(),
+		CI:                DefaultCIConfig(),
 		Providers: map[string]ProviderConfig{
 			"github": {
 				Type:       "token",
@@ -121,6 +130,24 @@ func DefaultConfig() Config {
 				EnvVars:    []string{"ZEN_GITLAB_TOKEN", "GITLAB_TOKEN", "GL_TOKEN"},
 				ConfigKeys: []string{"gitlab.token"},
 			},
+			"notion": {
+				Type:       "token",
+				BaseURL:    "https://api.notion.com/v1",
+				EnvVars:    []string{"ZEN_NOTION_TOKEN", "NOTION_TOKEN"},
+				ConfigKeys: []string{"notion.token"},
+			},
+			"servicenow": {
+				Type:       "token",
+				BaseURL:    "", // Will be configured per instance
+				EnvVars:    []string{"ZEN_SERVICENOW_TOKEN", "SERVICENOW_TOKEN"},
+				ConfigKeys: []string{"servicenow.token"},
+			},
+			"confluence": {
+				Type:       "token",
+				BaseURL:    "", // Will be configured per instance
+				EnvVars:    []string{"ZEN_CONFLUENCE_TOKEN", "CONFLUENCE_TOKEN"},
+				ConfigKeys: []string{"confluence.token"},
+			},
 			"jira": {
 				Type:       "basic",
 				BaseURL:    "", // Will be configured per instance
@@ -164,6 +191,14 @@ func (c Config) Validate() error {
 		return fmt.Errorf("cache_timeout must be positive")
 	}
 
+	if err := c.GitHub.Validate(); err != nil {
+		return fmt.Errorf("github: %w", err)
+	}
+
+	if err := c.CI.Validate(); err != nil {
+		return fmt.Errorf("ci: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// CacheKeyProvider is the name under which the cache encryption key is kept in
+// credential storage
+const CacheKeyProvider = "zen-cache"
+
+// cacheKeySize is the length of the cache encryption key, for AES-256
+const cacheKeySize = 32
+
+// CacheKeySource provides the key that encrypts cache entries at rest. The key is
+// generated on first use and kept in credential storage, the OS keychain where it is
+// available, so it never sits next to the data it protects.
+type CacheKeySource struct {
+	storage CredentialStorage
+
+	mu  sync.Mutex
+	key []byte
+}
+
+// NewCacheKeySource creates a cache key source backed by the given credential storage
+func NewCacheKeySource(storage CredentialStorage) *CacheKeySource {
+	return &CacheKeySource{storage: storage}
+}
+
+// CacheKey returns the cache encryption key, creating and storing it if there is none
+func (s *CacheKeySource) CacheKey(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.key != nil {
+		return s.key, nil
+	}
+
+	credential, err := s.storage.Retrieve(ctx, CacheKeyProvider)
+	switch {
+	case err == nil:
+		key, err := base64.StdEncoding.DecodeString(credential.Token)
+		if err != nil || len(key) != cacheKeySize {
+			return nil, NewAuthError(ErrorCodeInvalidCredentials, "stored cache encryption key is invalid", CacheKeyProvider)
+		}
+		s.key = key
+	case GetErrorCode(err) == ErrorCodeCredentialNotFound:
+		key := make([]byte, cacheKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, NewStorageError("failed to generate cache encryption key", err.Error())
+		}
+		now := time.Now()
+		if err := s.storage.Store(ctx, CacheKeyProvider, &Credential{
+			Provider:  CacheKeyProvider,
+			Token:     base64.StdEncoding.EncodeToString(key),
+			Type:      "key",
+			CreatedAt: now,
+			LastUsed:  now,
+		}); err != nil {
+			return nil, err
+		}
+		s.key = key
+	default:
+		return nil, err
+	}
+
+	return s.key, nil
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheKeySource_CacheKey(t *testing.T) {
+	storage, err := NewMemoryStorage(DefaultConfig(), logging.NewBasic())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	key, err := NewCacheKeySource(storage).CacheKey(ctx)
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+
+	stored, err := storage.Retrieve(ctx, CacheKeyProvider)
+	require.NoError(t, err)
+	assert.NotEmpty(t, stored.Token)
+
+	again, err := NewCacheKeySource(storage).CacheKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, key, again, "the stored key is reused")
+
+	require.NoError(t, storage.Store(ctx, CacheKeyProvider, &Credential{Token: "not-a-key"}))
+	_, err = NewCacheKeySource(storage).CacheKey(ctx)
+	assert.Equal(t, ErrorCodeInvalidCredentials, GetErrorCode(err))
+}
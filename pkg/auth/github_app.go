@@ -0,0 +1,305 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/fs"
+)
+
+// GitHub authentication modes
+const (
+	// GitHubModeToken authenticates with a personal access token
+	GitHubModeToken = "token"
+	// GitHubModeApp authenticates as a GitHub App installation, with installation
+	// tokens minted from the app's private key
+	GitHubModeApp = "app"
+	// GitHubModeOAuth authenticates with an OAuth user token, such as the one of 'gh
+	// auth token'; like personal access tokens, it is stored or read from the
+	// environment
+	GitHubModeOAuth = "oauth"
+)
+
+// GitHubModes are the valid values of GitHubConfig.Mode
+var GitHubModes = []string{GitHubModeToken, GitHubModeApp, GitHubModeOAuth}
+
+const (
+	// sourceGitHubApp marks installation tokens, which are never stored
+	sourceGitHubApp = "github-app"
+
+	// appJWTLifetime is the lifetime of the JWTs the app authenticates with; GitHub
+	// accepts at most 10 minutes
+	appJWTLifetime = 9 * time.Minute
+	// appTokenRefreshMargin is how long before they expire installation tokens are
+	// replaced, so that no request is sent with a token about to expire
+	appTokenRefreshMargin = 5 * time.Minute
+)
+
+// GitHubConfig configures how zen authenticates with GitHub
+type GitHubConfig struct {
+	// Mode is how zen authenticates, one of GitHubModes
+	Mode string `yaml:"mode" json:"mode" mapstructure:"mode"`
+
+	// AppID is the ID of the GitHub App of app mode
+	AppID int64 `yaml:"app_id" json:"app_id,omitempty" mapstructure:"app_id"`
+
+	// PrivateKey is the PEM private key of the app; it may be a secret reference
+	PrivateKey string `yaml:"private_key" json:"private_key,omitempty" mapstructure:"private_key"`
+
+	// PrivateKeyPath is the file the private key of the app is in, when PrivateKey
+	// is empty
+	PrivateKeyPath string `yaml:"private_key_path" json:"private_key_path,omitempty" mapstructure:"private_key_path"`
+
+	// InstallationID is the installation of the app to mint tokens for. When zero, the
+	// installation on Owner is used, or the only installation of the app.
+	InstallationID int64 `yaml:"installation_id" json:"installation_id,omitempty" mapstructure:"installation_id"`
+
+	// Owner is the organization or user the app is installed on
+	Owner string `yaml:"owner" json:"owner,omitempty" mapstructure:"owner"`
+}
+
+// DefaultGitHubConfig returns default GitHub authentication configuration
+func DefaultGitHubConfig() GitHubConfig {
+	return GitHubConfig{Mode: GitHubModeToken}
+}
+
+// Validate validates the GitHub authentication configuration
+func (c GitHubConfig) Validate() error {
+	if c.Mode != "" && !containsString(GitHubModes, c.Mode) {
+		return fmt.Errorf("invalid mode: %s (must be one of: %s)", c.Mode, strings.Join(GitHubModes, ", "))
+	}
+	if c.Mode != GitHubModeApp {
+		return nil
+	}
+	if c.AppID <= 0 {
+		return fmt.Errorf("app_id is required when mode is 'app'")
+	}
+	if c.PrivateKey == "" && c.PrivateKeyPath == "" {
+		return fmt.Errorf("private_key or private_key_path is required when mode is 'app'")
+	}
+	return nil
+}
+
+// githubApp reports whether provider authenticates as a GitHub App
+func (t *TokenManager) githubApp(provider string) bool {
+	return provider == "github" && t.config.GitHub.Mode == GitHubModeApp
+}
+
+// getGitHubAppToken mints an installation token of the GitHub App of the configuration
+func (t *TokenManager) getGitHubAppToken(ctx context.Context) (*Credential, error) {
+	app := t.config.GitHub
+	key, err := t.githubAppKey(ctx)
+	if err != nil {
+		return nil, NewAuthErrorWithDetails(ErrorCodeConfigurationError, "failed to load the private key of the GitHub App", "github", err.Error())
+	}
+	jwt, err := githubAppJWT(app.AppID, key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	installation := app.InstallationID
+	if installation == 0 {
+		if installation, err = t.findInstallation(ctx, jwt); err != nil {
+			return nil, err
+		}
+	}
+
+	var minted struct {
+		Token       string            `json:"token"`
+		ExpiresAt   time.Time         `json:"expires_at"`
+		Permissions map[string]string `json:"permissions"`
+	}
+	path := fmt.Sprintf("/app/installations/%d/access_tokens", installation)
+	if err := t.githubAppRequest(ctx, http.MethodPost, path, jwt, &minted); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	credential := &Credential{
+		Provider:      "github",
+		Token:         minted.Token,
+		Type:          "token",
+		Metadata:      map[string]string{"source": sourceGitHubApp, "installation_id": fmt.Sprint(installation)},
+		CreatedAt:     now,
+		LastUsed:      now,
+		LastValidated: &now,
+	}
+	if len(minted.Permissions) > 0 {
+		credential.Metadata["permissions"] = strings.Join(githubAppPermissions(minted.Permissions), ",")
+	}
+	if !minted.ExpiresAt.IsZero() {
+		expiresAt := minted.ExpiresAt.Add(-appTokenRefreshMargin)
+		credential.ExpiresAt = &expiresAt
+	}
+
+	t.logger.Debug("minted GitHub App installation token", "app_id", app.AppID, "installation_id", installation)
+	return credential, nil
+}
+
+// githubAppKey returns the private key of the GitHub App, resolving secret references
+func (t *TokenManager) githubAppKey(ctx context.Context) (*rsa.PrivateKey, error) {
+	app := t.config.GitHub
+	data := app.PrivateKey
+	if data != "" && t.configValue != nil {
+		resolved, err := t.configValue(ctx, "auth.github.private_key")
+		if err != nil {
+			return nil, err
+		}
+		if resolved != "" {
+			data = resolved
+		}
+	}
+	if data == "" {
+		keyPath, _ := fs.ExpandHome(app.PrivateKeyPath)
+		content, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		data = string(content)
+	}
+
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("the private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("the private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// findInstallation returns the installation of the app on the owner of the
+// configuration, or its only installation
+func (t *TokenManager) findInstallation(ctx context.Context, jwt string) (int64, error) {
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+	if owner := t.config.GitHub.Owner; owner != "" {
+		err := t.githubAppRequest(ctx, http.MethodGet, "/orgs/"+url.PathEscape(owner)+"/installation", jwt, &installation)
+		if err != nil {
+			err = t.githubAppRequest(ctx, http.MethodGet, "/users/"+url.PathEscape(owner)+"/installation", jwt, &installation)
+		}
+		if err != nil {
+			return 0, NewAuthErrorWithDetails(ErrorCodeConfigurationError, fmt.Sprintf("the GitHub App is not installed on %s", owner), "github", err.Error())
+		}
+		return installation.ID, nil
+	}
+
+	var installations []struct {
+		ID      int64 `json:"id"`
+		Account struct {
+			Login string `json:"login"`
+		} `json:"account"`
+	}
+	if err := t.githubAppRequest(ctx, http.MethodGet, "/app/installations", jwt, &installations); err != nil {
+		return 0, err
+	}
+	switch len(installations) {
+	case 0:
+		return 0, NewAuthError(ErrorCodeConfigurationError, "the GitHub App has no installations", "github")
+	case 1:
+		return installations[0].ID, nil
+	}
+	accounts := make([]string, len(installations))
+	for i, installation := range installations {
+		accounts[i] = installation.Account.Login
+	}
+	return 0, NewAuthErrorWithDetails(
+		ErrorCodeConfigurationError,
+		"the GitHub App has several installations; set auth.github.owner or auth.github.installation_id",
+		"github",
+		strings.Join(accounts, ", "),
+	)
+}
+
+// githubAppRequest sends a request authenticated as the app to the GitHub API and
+// decodes the response into v
+func (t *TokenManager) githubAppRequest(ctx context.Context, method, path, jwt string, v interface{}) error {
+	baseURL := "https://api.github.com"
+	if provider, ok := t.config.Providers["github"]; ok && provider.BaseURL != "" {
+		baseURL = strings.TrimRight(provider.BaseURL, "/")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := t.validationClient("github").Do(req)
+	if err != nil {
+		return NewNetworkError("github", err.Error())
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return NewNetworkError("github", err.Error())
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return NewAuthErrorWithDetails(ErrorCodeInvalidCredentials, "GitHub rejected the app ID or private key of the GitHub App", "github", githubMessage(body))
+	case resp.StatusCode == http.StatusNotFound:
+		return NewAuthErrorWithDetails(ErrorCodeConfigurationError, "GitHub App installation not found", "github", path)
+	case resp.StatusCode >= 300:
+		return NewAuthErrorWithDetails(ErrorCodeAuthenticationFailed, fmt.Sprintf("GitHub App request failed with status %d", resp.StatusCode), "github", githubMessage(body))
+	}
+	return json.Unmarshal(body, v)
+}
+
+// githubAppJWT returns the JWT a GitHub App authenticates as itself with
+func githubAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	encode := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	header := encode(map[string]string{"alg": "RS256", "typ": "JWT"})
+	// Backdated a minute against clock drift, as GitHub recommends
+	claims := encode(map[string]interface{}{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": fmt.Sprint(appID),
+	})
+
+	signed := header + "." + claims
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// githubMessage returns the message of a GitHub API error response
+func githubMessage(body []byte) string {
+	var response struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &response) == nil && response.Message != "" {
+		return response.Message
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitHubAppServer returns a GitHub API that mints installation tokens for the app
+// 1234 signed with key, and the number of tokens it minted
+func newGitHubAppServer(t *testing.T, key *rsa.PrivateKey, installations string) (*httptest.Server, *int) {
+	t.Helper()
+	minted := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jwt, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		require.True(t, ok)
+		parts := strings.Split(jwt, ".")
+		require.Len(t, parts, 3)
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"A JSON web token could not be decoded"}`))
+			return
+		}
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		assert.Contains(t, string(claims), `"iss":"1234"`)
+
+		switch r.URL.Path {
+		case "/app/installations":
+			_, _ = w.Write([]byte(installations))
+		case "/orgs/acme/installation":
+			_, _ = w.Write([]byte(`{"id":42}`))
+		case "/app/installations/42/access_tokens":
+			assert.Equal(t, http.MethodPost, r.Method)
+			minted++
+			expiresAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+			_, _ = w.Write([]byte(`{"token":"ghs_installation","expires_at":"` + expiresAt + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &minted
+}
+
+// newGitHubAppManager returns a token manager authenticating as the GitHub App 1234
+// with key, against the GitHub API at baseURL
+func newGitHubAppManager(t *testing.T, key *rsa.PrivateKey, baseURL string, app GitHubConfig) *TokenManager {
+	t.Helper()
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(keyPath, data, 0600))
+
+	config := DefaultConfig()
+	github := config.Providers["github"]
+	github.BaseURL = baseURL
+	config.Providers["github"] = github
+	app.Mode, app.AppID, app.PrivateKeyPath = GitHubModeApp, 1234, keyPath
+	config.GitHub = app
+
+	logger := logging.NewBasic()
+	storage, err := NewMemoryStorage(config, logger)
+	require.NoError(t, err)
+	return NewTokenManager(config, logger, storage)
+}
+
+func generateAppKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func TestTokenManager_GitHubApp(t *testing.T) {
+	key := generateAppKey(t)
+	server, minted := newGitHubAppServer(t, key, `[{"id":42,"account":{"login":"acme"}}]`)
+	manager := newGitHubAppManager(t, key, server.URL, GitHubConfig{})
+	t.Setenv("GITHUB_TOKEN", "ghp_personal")
+
+	token, err := manager.GetCredentials("github")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation", token, "app mode is preferred over personal access tokens")
+	credential := manager.cache["github"]
+	require.NotNil(t, credential.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour-appTokenRefreshMargin), *credential.ExpiresAt, time.Minute)
+	assert.Equal(t, "42", credential.Metadata["installation_id"])
+
+	_, err = manager.GetCredentials("github")
+	require.NoError(t, err)
+	assert.Equal(t, 1, *minted, "tokens are reused until they are about to expire")
+
+	expired := time.Now().Add(-time.Second)
+	manager.cache["github"].ExpiresAt = &expired
+	_, err = manager.GetCredentials("github")
+	require.NoError(t, err)
+	assert.Equal(t, 2, *minted, "expiring tokens are minted again")
+
+	require.NoError(t, manager.Authenticate(context.Background(), "github"))
+	_, err = manager.storage.Retrieve(context.Background(), "github")
+	assert.Error(t, err, "installation tokens are not stored")
+}
+
+func TestTokenManager_GitHubAppInstallations(t *testing.T) {
+	key := generateAppKey(t)
+	server, _ := newGitHubAppServer(t, key, `[{"id":42,"account":{"login":"acme"}},{"id":43,"account":{"login":"globex"}}]`)
+
+	manager := newGitHubAppManager(t, key, server.URL, GitHubConfig{})
+	_, err := manager.GetCredentials("github")
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeConfigurationError, GetErrorCode(err))
+	assert.Equal(t, "acme, globex", err.(*Error).Details)
+
+	manager = newGitHubAppManager(t, key, server.URL, GitHubConfig{Owner: "acme"})
+	token, err := manager.GetCredentials("github")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation", token)
+}
+
+func TestTokenManager_GitHubAppWrongKey(t *testing.T) {
+	server, _ := newGitHubAppServer(t, generateAppKey(t), `[]`)
+	manager := newGitHubAppManager(t, generateAppKey(t), server.URL, GitHubConfig{InstallationID: 42})
+
+	_, err := manager.GetCredentials("github")
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeInvalidCredentials, GetErrorCode(err))
+	assert.Equal(t, "A JSON web token could not be decoded", err.(*Error).Details)
+}
+
+func TestGitHubConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultGitHubConfig().Validate())
+	assert.NoError(t, GitHubConfig{Mode: GitHubModeOAuth}.Validate())
+	assert.EqualError(t, GitHubConfig{Mode: "saml"}.Validate(), "invalid mode: saml (must be one of: token, app, oauth)")
+	assert.EqualError(t, GitHubConfig{Mode: GitHubModeApp, PrivateKeyPath: "app.pem"}.Validate(), "app_id is required when mode is 'app'")
+	assert.EqualError(t, GitHubConfig{Mode: GitHubModeApp, AppID: 1}.Validate(), "private_key or private_key_path is required when mode is 'app'")
+
+	parsed, err := ConfigParser{}.Parse(map[string]interface{}{
+		"storage_type": "memory",
+		"github":       map[string]interface{}{"mode": "app", "app_id": "1234", "private_key_path": "~/app.pem"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), parsed.GitHub.AppID)
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"runtime"
@@ -121,6 +122,9 @@ func (k *KeychainStorage) Delete(ctx context.Context, provider string) error {
 
 // List returns all stored provider names
 func (k *KeychainStorage) List(ctx context.Context) ([]string, error) {
+	if runtime.GOOS == "windows" {
+		return k.listWindows(k.getServiceName())
+	}
 	// This is a simplified implementation
 	// In practice, listing keychain entries is complex and platform-specific
 	return []string{}, nil
@@ -128,6 +132,18 @@ func (k *KeychainStorage) List(ctx context.Context) ([]string, error) {
 
 // Clear removes all stored credentials
 func (k *KeychainStorage) Clear(ctx context.Context) error {
+	if runtime.GOOS == "windows" {
+		providers, err := k.listWindows(k.getServiceName())
+		if err != nil {
+			return err
+		}
+		for _, provider := range providers {
+			if err := k.deleteWindows(k.getServiceName(), k.getAccountName(provider)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	// This is a simplified implementation
 	// Would need to list all entries and delete them individually
 	return nil
@@ -216,43 +232,110 @@ func (k *KeychainStorage) deleteMacOS(service, account string) error {
 	return nil
 }
 
-// Windows credential manager operations (simplified)
+// Windows Credential Manager operations. The token and metadata are stored together
+// in the blob of a generic credential, whose comment is too short for metadata.
+
+// maxWincredBlob is CRED_MAX_CREDENTIAL_BLOB_SIZE, the most a credential can hold
+const maxWincredBlob = 5 * 512
+
+// errWincredNotFound is returned when the Credential Manager has no such credential
+var errWincredNotFound = errors.New("credential not found")
+
+// wincredSecret is what is stored in the blob of a Windows credential
+type wincredSecret struct {
+	Token    string `json:"token"`
+	Metadata string `json:"metadata,omitempty"`
+}
+
+// encodeWincredSecret returns the blob of a Windows credential holding token and metadata
+func encodeWincredSecret(token, metadata string) ([]byte, error) {
+	blob, err := json.Marshal(wincredSecret{Token: token, Metadata: metadata})
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) > maxWincredBlob {
+		return nil, fmt.Errorf("credential is %d bytes, more than the %d bytes the Windows Credential Manager holds", len(blob), maxWincredBlob)
+	}
+	return blob, nil
+}
+
+// decodeWincredSecret returns the token and metadata in the blob of a Windows credential.
+// Blobs that are not JSON are taken to be a bare token.
+func decodeWincredSecret(blob []byte) (token, metadata string) {
+	var secret wincredSecret
+	if err := json.Unmarshal(blob, &secret); err != nil {
+		return string(blob), ""
+	}
+	return secret.Token, secret.Metadata
+}
+
+// wincredTarget is the target name of the credential of account
+func wincredTarget(service, account string) string {
+	return fmt.Sprintf("%s/%s", service, account)
+}
+
 func (k *KeychainStorage) storeWindows(service, account, password, metadata string) error {
-	// Validate inputs to prevent command injection
 	if service == "" || account == "" {
 		return NewStorageError("invalid credential parameters", "service and account cannot be empty")
 	}
 
-	target := fmt.Sprintf("%s/%s", service, account)
+	blob, err := encodeWincredSecret(password, metadata)
+	if err != nil {
+		return NewStorageError("failed to store credential in Windows Credential Manager", err.Error())
+	}
 
-	// Use cmdkey to store credential
-	cmd := exec.Command("cmdkey", "/generic:"+target, "/user:"+account, "/pass:"+password) // #nosec G204 - validated args for Windows credential manager
-	if err := cmd.Run(); err != nil {
-		return NewStorageError("failed to store credential in Windows credential manager", err.Error())
+	target := wincredTarget(service, account)
+	if err := wincredWrite(target, account, blob); err != nil {
+		return NewStorageError("failed to store credential in Windows Credential Manager", err.Error())
 	}
 
-	k.logger.Debug("stored credential in Windows credential manager", "target", target)
+	k.logger.Debug("stored credential in Windows Credential Manager", "target", target)
 	return nil
 }
 
 func (k *KeychainStorage) retrieveWindows(service, account string) (string, string, error) {
-	// This is a simplified implementation
-	// Windows credential manager access from Go is complex
-	return "", "", NewStorageError("Windows keychain retrieval not fully implemented", nil)
+	blob, err := wincredRead(wincredTarget(service, account))
+	if errors.Is(err, errWincredNotFound) {
+		return "", "", NewAuthError(
+			ErrorCodeCredentialNotFound,
+			"credential not found in Windows Credential Manager",
+			strings.TrimPrefix(account, "auth-"),
+		)
+	}
+	if err != nil {
+		return "", "", NewStorageError("failed to read credential from Windows Credential Manager", err.Error())
+	}
+
+	token, metadata := decodeWincredSecret(blob)
+	return token, metadata, nil
 }
 
 func (k *KeychainStorage) deleteWindows(service, account string) error {
-	// Validate inputs
 	if service == "" || account == "" {
 		return NewStorageError("invalid credential parameters", "service and account cannot be empty")
 	}
 
-	target := fmt.Sprintf("%s/%s", service, account)
-	cmd := exec.Command("cmdkey", "/delete:"+target) // #nosec G204 - validated args for Windows credential manager
-	_ = cmd.Run()                                    // Ignore error - entry might not exist
+	if err := wincredDelete(wincredTarget(service, account)); err != nil {
+		return NewStorageError("failed to delete credential from Windows Credential Manager", err.Error())
+	}
 	return nil
 }
 
+// listWindows returns the providers with credentials in the Windows Credential Manager
+func (k *KeychainStorage) listWindows(service string) ([]string, error) {
+	prefix := wincredTarget(service, "auth-")
+	targets, err := wincredList(prefix)
+	if err != nil {
+		return nil, NewStorageError("failed to list credentials in Windows Credential Manager", err.Error())
+	}
+
+	providers := make([]string, 0, len(targets))
+	for _, target := range targets {
+		providers = append(providers, strings.TrimPrefix(target, prefix))
+	}
+	return providers, nil
+}
+
 // Linux secret service operations (simplified)
 func (k *KeychainStorage) storeLinux(service, account, password, metadata string) error {
 	// This would require integration with libsecret or similar
@@ -276,9 +359,7 @@ func isKeychainAvailable() bool {
 		_, err := exec.LookPath("security")
 		return err == nil
 	case "windows":
-		// Check if cmdkey is available
-		_, err := exec.LookPath("cmdkey")
-		return err == nil
+		return wincredAvailable()
 	case "linux":
 		// Check if secret-tool or similar is available
 		_, err := exec.LookPath("secret-tool")
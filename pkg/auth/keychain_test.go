@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -415,3 +416,33 @@ func TestKeychainStorage_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestWincredSecret_RoundTrip(t *testing.T) {
+	// Arrange
+	metadata := `{"provider":"github","type":"token"}`
+
+	// Act
+	blob, err := encodeWincredSecret("ghp_token", metadata)
+	require.NoError(t, err)
+	token, decoded := decodeWincredSecret(blob)
+
+	// Assert
+	assert.Equal(t, "ghp_token", token)
+	assert.Equal(t, metadata, decoded)
+}
+
+func TestWincredSecret_TooLarge(t *testing.T) {
+	// Act
+	_, err := encodeWincredSecret(strings.Repeat("x", maxWincredBlob), "")
+
+	// Assert
+	assert.ErrorContains(t, err, "Windows Credential Manager")
+}
+
+func TestWincredSecret_BareToken(t *testing.T) {
+	// Credentials stored by other tools hold only the token
+	token, metadata := decodeWincredSecret([]byte("ghp_token"))
+
+	assert.Equal(t, "ghp_token", token)
+	assert.Empty(t, metadata)
+}
@@ -0,0 +1,347 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/clients/httpx"
+)
+
+// Identities CI jobs and cloud workloads prove who they are with
+const (
+	// IdentityAuto detects GitHub Actions, GitLab CI and AWS web identity from the
+	// environment
+	IdentityAuto = "auto"
+	// IdentityNone turns the exchange of OIDC tokens off
+	IdentityNone          = "none"
+	IdentityGitHubActions = "github-actions"
+	IdentityGitLabCI      = "gitlab-ci"
+	IdentityAWS           = "aws"
+	IdentityGCP           = "gcp"
+	IdentityAzure         = "azure"
+)
+
+// Identities are the valid values of CIConfig.Identity
+var Identities = []string{IdentityAuto, IdentityNone, IdentityGitHubActions, IdentityGitLabCI, IdentityAWS, IdentityGCP, IdentityAzure}
+
+const (
+	// DefaultAudience is the audience OIDC tokens are requested for
+	DefaultAudience = "zen"
+	// DefaultIDTokenEnv is the variable GitLab CI jobs declare their ID token in
+	DefaultIDTokenEnv = "ZEN_ID_TOKEN" // #nosec G101 - name of an environment variable, not a credential
+
+	// sourceOIDC marks credentials exchanged for an OIDC token, which are never stored
+	sourceOIDC = "oidc"
+
+	tokenExchangeGrant = "urn:ietf:params:oauth:grant-type:token-exchange"
+	idTokenType        = "urn:ietf:params:oauth:token-type:id_token"
+	accessTokenType    = "urn:ietf:params:oauth:token-type:access_token" // #nosec G101 - token type URN, not a credential
+)
+
+// Instance metadata endpoints that issue identity tokens to cloud workloads
+var (
+	gcpIdentityURL   = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/identity"
+	azureIdentityURL = "http://169.254.169.254/metadata/identity/oauth2/token"
+)
+
+// CIConfig configures the exchange of OIDC tokens for provider credentials
+type CIConfig struct {
+	// Identity is where the OIDC token comes from, one of Identities. gcp and azure
+	// read the instance metadata service, so they are never detected by auto.
+	Identity string `yaml:"identity" json:"identity" mapstructure:"identity"`
+
+	// Audience is the audience OIDC tokens are requested for, which the exchange
+	// endpoint checks
+	Audience string `yaml:"audience" json:"audience" mapstructure:"audience"`
+
+	// TokenEnv is the variable a GitLab CI job declares its ID token in, with id_tokens
+	TokenEnv string `yaml:"token_env" json:"token_env" mapstructure:"token_env"`
+
+	// Exchange configures the exchange endpoint of each provider. Providers without
+	// one are not authenticated with OIDC tokens.
+	Exchange map[string]ExchangeConfig `yaml:"exchange" json:"exchange,omitempty" mapstructure:"exchange"`
+}
+
+// ExchangeConfig configures the endpoint an OIDC token is exchanged at
+type ExchangeConfig struct {
+	// URL is the OAuth 2.0 token exchange (RFC 8693) endpoint that trades OIDC tokens
+	// for credentials of the provider, such as GitHub App installation tokens
+	URL string `yaml:"url" json:"url" mapstructure:"url"`
+
+	// Scope is requested from the endpoint, such as the repositories the credential
+	// is for
+	Scope string `yaml:"scope" json:"scope,omitempty" mapstructure:"scope"`
+}
+
+// DefaultCIConfig returns default OIDC exchange configuration
+func DefaultCIConfig() CIConfig {
+	return CIConfig{
+		Identity: IdentityAuto,
+		Audience: DefaultAudience,
+		TokenEnv: DefaultIDTokenEnv,
+	}
+}
+
+// Validate validates the OIDC exchange configuration
+func (c CIConfig) Validate() error {
+	if c.Identity != "" && !containsString(Identities, c.Identity) {
+		return fmt.Errorf("invalid identity: %s (must be one of: %s)", c.Identity, strings.Join(Identities, ", "))
+	}
+	for provider, exchange := range c.Exchange {
+		u, err := url.Parse(exchange.URL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("exchange.%s.url must be an absolute URL", provider)
+		}
+		if u.Scheme != "https" && !(u.Scheme == "http" && isLoopback(u.Hostname())) {
+			return fmt.Errorf("exchange.%s.url must use https", provider)
+		}
+	}
+	return nil
+}
+
+// DetectIdentity returns the identity zen can get an OIDC token from, for the
+// configured identity, or "" when there is none
+func DetectIdentity(identity string) string {
+	switch identity {
+	case "", IdentityAuto:
+		switch {
+		case os.Getenv("GITHUB_ACTIONS") == "true":
+			return IdentityGitHubActions
+		case os.Getenv("GITLAB_CI") == "true":
+			return IdentityGitLabCI
+		case os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") != "":
+			return IdentityAWS
+		}
+		return ""
+	case IdentityNone:
+		return ""
+	default:
+		return identity
+	}
+}
+
+// getTokenFromOIDC returns a credential for provider exchanged for the OIDC token of
+// the CI job or cloud workload, or nil when no exchange endpoint is configured for the
+// provider or there is no identity to get a token from
+func (t *TokenManager) getTokenFromOIDC(ctx context.Context, provider string) (*Credential, error) {
+	exchange, ok := t.config.CI.Exchange[provider]
+	if !ok {
+		return nil, nil
+	}
+	identity := DetectIdentity(t.config.CI.Identity)
+	if identity == "" {
+		return nil, nil
+	}
+
+	idToken, err := t.fetchIDToken(ctx, identity)
+	if err != nil {
+		return nil, NewAuthErrorWithDetails(
+			ErrorCodeAuthenticationFailed,
+			fmt.Sprintf("failed to get an OIDC token from %s", identity),
+			provider,
+			err.Error(),
+		)
+	}
+	credential, err := t.exchangeToken(ctx, provider, exchange, idToken)
+	if err != nil {
+		return nil, err
+	}
+	credential.Metadata["identity"] = identity
+
+	t.logger.Debug("token exchanged for OIDC token", "provider", provider, "identity", identity)
+	return credential, nil
+}
+
+// fetchIDToken returns an OIDC token for the audience of the configuration from identity
+func (t *TokenManager) fetchIDToken(ctx context.Context, identity string) (string, error) {
+	audience := t.config.CI.Audience
+	if audience == "" {
+		audience = DefaultAudience
+	}
+
+	switch identity {
+	case IdentityGitHubActions:
+		requestURL, requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"), os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+		if requestURL == "" || requestToken == "" {
+			return "", fmt.Errorf("the workflow job needs the id-token: write permission")
+		}
+		var response struct {
+			Value string `json:"value"`
+		}
+		err := t.getJSON(ctx, withQuery(requestURL, "audience", audience), map[string]string{"Authorization": "Bearer " + requestToken}, &response)
+		return response.Value, err
+
+	case IdentityGitLabCI:
+		name := t.config.CI.TokenEnv
+		if name == "" {
+			name = DefaultIDTokenEnv
+		}
+		if token := os.Getenv(name); token != "" {
+			return token, nil
+		}
+		return "", fmt.Errorf("%s is not set; declare it in the id_tokens of the job with aud: %s", name, audience)
+
+	case IdentityAWS:
+		path := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+		if path == "" {
+			return "", fmt.Errorf("AWS_WEB_IDENTITY_TOKEN_FILE is not set")
+		}
+		data, err := os.ReadFile(path) // #nosec G304 - token file set up by the platform
+		if err != nil {
+			return "", fmt.Errorf("failed to read web identity token: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+
+	case IdentityGCP:
+		body, err := t.get(ctx, withQuery(withQuery(gcpIdentityURL, "audience", audience), "format", "full"), map[string]string{"Metadata-Flavor": "Google"})
+		return strings.TrimSpace(string(body)), err
+
+	case IdentityAzure:
+		var response struct {
+			AccessToken string `json:"access_token"`
+		}
+		err := t.getJSON(ctx, withQuery(withQuery(azureIdentityURL, "api-version", "2018-02-01"), "resource", audience), map[string]string{"Metadata": "true"}, &response)
+		return response.AccessToken, err
+	}
+	return "", fmt.Errorf("unknown identity %q", identity)
+}
+
+// exchangeToken exchanges an OIDC token for a credential of provider at the exchange
+// endpoint
+func (t *TokenManager) exchangeToken(ctx context.Context, provider string, exchange ExchangeConfig, idToken string) (*Credential, error) {
+	form := url.Values{
+		"grant_type":           {tokenExchangeGrant},
+		"subject_token":        {idToken},
+		"subject_token_type":   {idTokenType},
+		"requested_token_type": {accessTokenType},
+		"audience":             {provider},
+	}
+	if exchange.Scope != "" {
+		form.Set("scope", exchange.Scope)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchange.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := t.oidcClient().Do(req)
+	if err != nil {
+		return nil, NewNetworkError(provider, err.Error())
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&response); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, NewAuthErrorWithDetails(ErrorCodeAuthenticationFailed, "invalid response from token exchange endpoint", provider, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK || response.AccessToken == "" {
+		details := strings.TrimSpace(response.Error + " " + response.ErrorDescription)
+		if details == "" {
+			details = resp.Status
+		}
+		return nil, NewAuthErrorWithDetails(ErrorCodeAuthenticationFailed, "token exchange was refused", provider, details)
+	}
+
+	now := time.Now()
+	credential := &Credential{
+		Provider:  provider,
+		Token:     response.AccessToken,
+		Type:      "token",
+		Metadata:  map[string]string{"source": sourceOIDC},
+		CreatedAt: now,
+		LastUsed:  now,
+	}
+	if response.ExpiresIn > 0 {
+		expiresAt := now.Add(time.Duration(response.ExpiresIn) * time.Second)
+		credential.ExpiresAt = &expiresAt
+	}
+	return credential, nil
+}
+
+// oidcClient returns the client OIDC tokens are requested and exchanged with
+func (t *TokenManager) oidcClient() *http.Client {
+	return httpx.New(httpx.Options{
+		Provider: "oidc",
+		Timeout:  t.config.ValidationTimeout,
+		Logger:   t.logger,
+	})
+}
+
+// get returns the body of a successful GET of rawURL
+func (t *TokenManager) get(ctx context.Context, rawURL string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := t.oidcClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", req.URL.Host, resp.Status)
+	}
+	return body, nil
+}
+
+// getJSON decodes the body of a successful GET of rawURL into v
+func (t *TokenManager) getJSON(ctx context.Context, rawURL string, headers map[string]string, v interface{}) error {
+	body, err := t.get(ctx, rawURL, headers)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// withQuery returns rawURL with a query parameter added
+func withQuery(rawURL, name, value string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	query.Set(name, value)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// isLoopback reports whether host is the local machine
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearCIEnv unsets the variables identities are detected from
+func clearCIEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"GITHUB_ACTIONS", "GITLAB_CI", "AWS_WEB_IDENTITY_TOKEN_FILE", "ACTIONS_ID_TOKEN_REQUEST_URL", "ACTIONS_ID_TOKEN_REQUEST_TOKEN", "GITHUB_TOKEN", "GH_TOKEN", "ZEN_GITHUB_TOKEN"} {
+		t.Setenv(name, "")
+	}
+}
+
+// newExchangeServer returns a token exchange endpoint that issues token for the OIDC
+// token "id-token"
+func newExchangeServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, tokenExchangeGrant, r.PostForm.Get("grant_type"))
+		assert.Equal(t, idTokenType, r.PostForm.Get("subject_token_type"))
+		assert.Equal(t, "github", r.PostForm.Get("audience"))
+		assert.Equal(t, "daddia/zen-assets", r.PostForm.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("subject_token") != "id-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "subject not trusted"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": token, "expires_in": 3600})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newOIDCTokenManager(t *testing.T, ci CIConfig) (*TokenManager, CredentialStorage) {
+	t.Helper()
+	config := DefaultConfig()
+	config.CI = ci
+	logger := logging.NewBasic()
+	storage, err := NewMemoryStorage(config, logger)
+	require.NoError(t, err)
+	return NewTokenManager(config, logger, storage), storage
+}
+
+func TestTokenManager_GetCredentials_GitHubActionsOIDC(t *testing.T) {
+	clearCIEnv(t)
+	exchange := newExchangeServer(t, "ghs_installation_token")
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer request-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "zen", r.URL.Query().Get("audience"))
+		assert.Equal(t, "2.0", r.URL.Query().Get("api-version"), "the query of the request URL is kept")
+		_ = json.NewEncoder(w).Encode(map[string]string{"value": "id-token"})
+	}))
+	defer issuer.Close()
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", issuer.URL+"/token?api-version=2.0")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+
+	ci := DefaultCIConfig()
+	ci.Exchange = map[string]ExchangeConfig{"github": {URL: exchange.URL, Scope: "daddia/zen-assets"}}
+	manager, storage := newOIDCTokenManager(t, ci)
+
+	token, err := manager.GetCredentials("github")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation_token", token)
+	require.NotNil(t, manager.cache["github"].ExpiresAt)
+	assert.Equal(t, IdentityGitHubActions, manager.cache["github"].Metadata["identity"])
+
+	_, err = storage.Retrieve(context.Background(), "github")
+	assert.Error(t, err, "exchanged tokens are not stored")
+}
+
+func TestTokenManager_GetCredentials_GitLabOIDCRefused(t *testing.T) {
+	clearCIEnv(t)
+	exchange := newExchangeServer(t, "ghs_installation_token")
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("ZEN_ID_TOKEN", "untrusted-token")
+
+	ci := DefaultCIConfig()
+	ci.Exchange = map[string]ExchangeConfig{"github": {URL: exchange.URL, Scope: "daddia/zen-assets"}}
+	manager, _ := newOIDCTokenManager(t, ci)
+
+	_, err := manager.GetCredentials("github")
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeAuthenticationFailed, GetErrorCode(err))
+	assert.Equal(t, "invalid_grant subject not trusted", err.(*Error).Details)
+}
+
+func TestTokenManager_GetCredentials_AWSWebIdentity(t *testing.T) {
+	clearCIEnv(t)
+	exchange := newExchangeServer(t, "ghs_from_aws")
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("id-token\n"), 0600))
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", tokenFile)
+
+	ci := DefaultCIConfig()
+	ci.Exchange = map[string]ExchangeConfig{"github": {URL: exchange.URL, Scope: "daddia/zen-assets"}}
+	manager, _ := newOIDCTokenManager(t, ci)
+
+	token, err := manager.GetCredentials("github")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_from_aws", token)
+}
+
+func TestTokenManager_GetCredentials_OIDCWithoutIdentity(t *testing.T) {
+	clearCIEnv(t)
+	ci := DefaultCIConfig()
+	ci.Exchange = map[string]ExchangeConfig{"github": {URL: "https://sts.example.com/token"}}
+	manager, _ := newOIDCTokenManager(t, ci)
+
+	_, err := manager.GetCredentials("github")
+	assert.Equal(t, ErrorCodeCredentialNotFound, GetErrorCode(err), "outside CI, no exchange is attempted")
+}
+
+func TestTokenManager_FetchIDToken_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gcp":
+			assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+			assert.Equal(t, "zen", r.URL.Query().Get("audience"))
+			_, _ = w.Write([]byte("gcp-token"))
+		case "/azure":
+			assert.Equal(t, "true", r.Header.Get("Metadata"))
+			assert.Equal(t, "zen", r.URL.Query().Get("resource"))
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "azure-token"})
+		}
+	}))
+	defer server.Close()
+	defer func(gcp, azure string) { gcpIdentityURL, azureIdentityURL = gcp, azure }(gcpIdentityURL, azureIdentityURL)
+	gcpIdentityURL, azureIdentityURL = server.URL+"/gcp", server.URL+"/azure"
+
+	manager, _ := newOIDCTokenManager(t, DefaultCIConfig())
+
+	token, err := manager.fetchIDToken(context.Background(), IdentityGCP)
+	require.NoError(t, err)
+	assert.Equal(t, "gcp-token", token)
+
+	token, err = manager.fetchIDToken(context.Background(), IdentityAzure)
+	require.NoError(t, err)
+	assert.Equal(t, "azure-token", token)
+}
+
+func TestCIConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CIConfig
+		wantErr string
+	}{
+		{name: "defaults", config: DefaultCIConfig()},
+		{name: "loopback http", config: CIConfig{Exchange: map[string]ExchangeConfig{"github": {URL: "http://127.0.0.1:8080/token"}}}},
+		{name: "unknown identity", config: CIConfig{Identity: "jenkins"}, wantErr: "invalid identity: jenkins"},
+		{name: "plain http", config: CIConfig{Exchange: map[string]ExchangeConfig{"github": {URL: "http://sts.example.com/token"}}}, wantErr: "exchange.github.url must use https"},
+		{name: "relative url", config: CIConfig{Exchange: map[string]ExchangeConfig{"github": {URL: "/token"}}}, wantErr: "exchange.github.url must be an absolute URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
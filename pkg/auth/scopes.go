@@ -0,0 +1,348 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Operations zen performs with the credentials of a provider, each needing scopes
+const (
+	// OperationAssetsRead reads the asset repository
+	OperationAssetsRead = "assets:read"
+	// OperationIssuesRead reads the issues tasks are synchronized with
+	OperationIssuesRead = "issues:read"
+	// OperationIssuesWrite updates the issues tasks are synchronized with
+	OperationIssuesWrite = "issues:write"
+)
+
+// Operations are all the operations scopes are checked for
+var Operations = []string{OperationAssetsRead, OperationIssuesRead, OperationIssuesWrite}
+
+// githubAppScopes is the key of the requirements of GitHub App installation tokens,
+// whose permissions are not OAuth scopes
+const githubAppScopes = "github-app"
+
+// requiredScopes are the scopes each operation needs, by provider; any one of the
+// scopes of an operation is enough
+var requiredScopes = map[string]map[string][]string{
+	"github": {
+		OperationAssetsRead:  {"repo"},
+		OperationIssuesRead:  {"repo", "public_repo"},
+		OperationIssuesWrite: {"repo", "public_repo"},
+	},
+	githubAppScopes: {
+		OperationAssetsRead:  {"contents:read"},
+		OperationIssuesRead:  {"issues:read"},
+		OperationIssuesWrite: {"issues:write"},
+	},
+	"gitlab": {
+		OperationAssetsRead:  {"read_repository", "api"},
+		OperationIssuesRead:  {"read_api", "api"},
+		OperationIssuesWrite: {"api"},
+	},
+}
+
+// MissingScope is an operation the credentials of a provider lack the scopes for
+type MissingScope struct {
+	// Operation is the operation that would fail
+	Operation string `json:"operation"`
+	// Scopes are the scopes any of which would allow the operation
+	Scopes []string `json:"scopes"`
+}
+
+// ScopeReport is the result of checking the scopes of the credentials of a provider
+type ScopeReport struct {
+	Provider string `json:"provider"`
+
+	// Checked is false when the scopes of the credentials cannot be read, as for
+	// fine-grained GitHub tokens or providers without scopes; nothing is missing then
+	Checked bool `json:"checked"`
+
+	// Granted are the scopes of the credentials; for GitHub Apps, the permissions of
+	// the installation as "name:access"
+	Granted []string `json:"granted,omitempty"`
+
+	// Missing are the operations the credentials lack scopes for
+	Missing []MissingScope `json:"missing,omitempty"`
+
+	// Remediation tells how to get credentials with the missing scopes
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// Err returns an insufficient scopes error naming the missing scopes and how to get
+// them, or nil when no scope is missing
+func (r *ScopeReport) Err() error {
+	if len(r.Missing) == 0 {
+		return nil
+	}
+	operations := make([]string, len(r.Missing))
+	for i, missing := range r.Missing {
+		operations[i] = missing.Operation
+	}
+	message := fmt.Sprintf(
+		"%s credentials lack the %s needed for %s; %s",
+		r.Provider, plural(r.suggestedScopes(), "scope"), strings.Join(operations, ", "), r.Remediation,
+	)
+	return NewAuthErrorWithDetails(ErrorCodeInsufficientScopes, message, r.Provider, r.Missing)
+}
+
+// suggestedScopes returns the first alternative of each missing operation
+func (r *ScopeReport) suggestedScopes() []string {
+	var scopes []string
+	for _, missing := range r.Missing {
+		if len(missing.Scopes) > 0 && !containsString(scopes, missing.Scopes[0]) {
+			scopes = append(scopes, missing.Scopes[0])
+		}
+	}
+	return scopes
+}
+
+// ScopeChecker is implemented by managers that can read the scopes of credentials
+type ScopeChecker interface {
+	// CheckScopes reports the operations the credentials of provider lack scopes for
+	CheckScopes(ctx context.Context, provider string, operations ...string) (*ScopeReport, error)
+}
+
+// CheckScopes checks the scopes of the credentials of provider with m, when it can
+// read scopes; the report is unchecked otherwise
+func CheckScopes(ctx context.Context, m Manager, provider string, operations ...string) (*ScopeReport, error) {
+	checker, ok := m.(ScopeChecker)
+	if !ok {
+		return &ScopeReport{Provider: provider}, nil
+	}
+	return checker.CheckScopes(ctx, provider, operations...)
+}
+
+// RequireScopes fails with an insufficient scopes error when the credentials of
+// provider lack the scopes of operations, so that commands stop before their first
+// request rather than with a 403 halfway through. Scopes that cannot be read are not
+// required.
+func RequireScopes(ctx context.Context, m Manager, provider string, operations ...string) error {
+	report, err := CheckScopes(ctx, m, provider, operations...)
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// grantedScopes are the scopes read for a token
+type grantedScopes struct {
+	token   string
+	checked bool
+	scopes  []string
+}
+
+// CheckScopes reports the operations the token of provider lacks scopes for
+func (t *TokenManager) CheckScopes(ctx context.Context, provider string, operations ...string) (*ScopeReport, error) {
+	requirements, ok := requiredScopes[provider]
+	if t.githubApp(provider) {
+		requirements = requiredScopes[githubAppScopes]
+	}
+	if !ok {
+		// Only the scopes of the tokens of Git providers can be read
+		return &ScopeReport{Provider: provider}, nil
+	}
+
+	token, err := t.GetCredentials(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := t.grantedScopes(ctx, provider, token)
+	if err != nil {
+		return nil, err
+	}
+	report := &ScopeReport{Provider: provider, Checked: granted.checked, Granted: granted.scopes}
+	if !granted.checked {
+		return report, nil
+	}
+
+	for _, operation := range operations {
+		alternatives, ok := requirements[operation]
+		if !ok || containsAny(granted.scopes, alternatives) {
+			continue
+		}
+		report.Missing = append(report.Missing, MissingScope{Operation: operation, Scopes: alternatives})
+	}
+	if len(report.Missing) > 0 {
+		report.Remediation = t.scopeRemediation(provider, report.suggestedScopes())
+	}
+	return report, nil
+}
+
+// grantedScopes returns the scopes of token, read once per token
+func (t *TokenManager) grantedScopes(ctx context.Context, provider, token string) (grantedScopes, error) {
+	t.mu.RLock()
+	cached, ok := t.scopes[provider]
+	t.mu.RUnlock()
+	if ok && cached.token == token {
+		return cached, nil
+	}
+
+	granted := grantedScopes{token: token}
+	switch {
+	case t.githubApp(provider):
+		t.mu.RLock()
+		if credential, ok := t.cache[provider]; ok && credential.Metadata["permissions"] != "" {
+			granted.checked = true
+			granted.scopes = strings.Split(credential.Metadata["permissions"], ",")
+		}
+		t.mu.RUnlock()
+	case provider == "github":
+		scopes, checked, err := t.githubTokenScopes(ctx, token)
+		if err != nil {
+			return granted, err
+		}
+		granted.checked, granted.scopes = checked, scopes
+	case provider == "gitlab":
+		scopes, checked, err := t.gitlabTokenScopes(ctx, token)
+		if err != nil {
+			return granted, err
+		}
+		granted.checked, granted.scopes = checked, scopes
+	}
+
+	t.mu.Lock()
+	t.scopes[provider] = granted
+	t.mu.Unlock()
+	return granted, nil
+}
+
+// githubTokenScopes returns the OAuth scopes of a classic GitHub token. Fine-grained
+// and installation tokens have no OAuth scopes, and are unchecked.
+func (t *TokenManager) githubTokenScopes(ctx context.Context, token string) ([]string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.providerBaseURL("github")+"/user", nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
+
+	resp, err := t.validationClient("github").Do(req)
+	if err != nil {
+		return nil, false, NewNetworkError("github", err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, false, NewAuthError(ErrorCodeInvalidCredentials, "GitHub token is invalid or expired", "github")
+	}
+
+	header := resp.Header.Values("X-OAuth-Scopes")
+	if resp.StatusCode != http.StatusOK || len(header) == 0 {
+		t.logger.Debug("GitHub token scopes unavailable", "status", resp.StatusCode)
+		return nil, false, nil
+	}
+	var scopes []string
+	for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, true, nil
+}
+
+// gitlabTokenScopes returns the scopes of a GitLab personal, project or group access
+// token. OAuth tokens cannot read their scopes, and are unchecked.
+func (t *TokenManager) gitlabTokenScopes(ctx context.Context, token string) ([]string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.providerBaseURL("gitlab")+"/personal_access_tokens/self", nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := t.validationClient("gitlab").Do(req)
+	if err != nil {
+		return nil, false, NewNetworkError("gitlab", err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, false, NewAuthError(ErrorCodeInvalidCredentials, "GitLab token is invalid or expired", "gitlab")
+	}
+
+	var self struct {
+		Scopes []string `json:"scopes"`
+	}
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&self) != nil {
+		t.logger.Debug("GitLab token scopes unavailable", "status", resp.StatusCode)
+		return nil, false, nil
+	}
+	return self.Scopes, true, nil
+}
+
+// scopeRemediation tells how to get credentials for provider with scopes
+func (t *TokenManager) scopeRemediation(provider string, scopes []string) string {
+	web := t.providerWebURL(provider)
+	switch {
+	case t.githubApp(provider):
+		return fmt.Sprintf(
+			"grant the GitHub App the %s in its settings, then approve the new permissions on the installation",
+			plural(scopes, "permission"),
+		)
+	case provider == "github":
+		return fmt.Sprintf(
+			"create a token with the %s at %s/settings/tokens/new?scopes=%s and run 'zen auth github'",
+			plural(scopes, "scope"), web, strings.Join(scopes, ","),
+		)
+	case provider == "gitlab":
+		return fmt.Sprintf(
+			"create a token with the %s at %s/-/user_settings/personal_access_tokens?scopes=%s and run 'zen auth gitlab'",
+			plural(scopes, "scope"), web, strings.Join(scopes, ","),
+		)
+	}
+	return fmt.Sprintf("authenticate with credentials granting %s and run 'zen auth %s'", plural(scopes, "scope"), provider)
+}
+
+// providerBaseURL returns the API base URL of provider, without a trailing slash
+func (t *TokenManager) providerBaseURL(provider string) string {
+	return strings.TrimRight(t.config.Providers[provider].BaseURL, "/")
+}
+
+// providerWebURL returns the web address of provider, derived from its API base URL:
+// https://api.github.com is https://github.com, and the API of GitHub Enterprise and
+// GitLab is under /api on the web address
+func (t *TokenManager) providerWebURL(provider string) string {
+	base := t.providerBaseURL(provider)
+	if base == "https://api.github.com" {
+		return "https://github.com"
+	}
+	if i := strings.Index(base, "/api/"); i >= 0 {
+		return base[:i]
+	}
+	return base
+}
+
+// githubAppPermissions returns the permissions of an installation token as sorted
+// "name:access" pairs; write access implies read access
+func githubAppPermissions(permissions map[string]string) []string {
+	var granted []string
+	for name, access := range permissions {
+		granted = append(granted, name+":"+access)
+		if access == "write" || access == "admin" {
+			granted = append(granted, name+":read")
+		}
+	}
+	sort.Strings(granted)
+	return granted
+}
+
+// containsAny reports whether values contains any of candidates
+func containsAny(values, candidates []string) bool {
+	for _, candidate := range candidates {
+		if containsString(values, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// plural returns names followed by noun, pluralized as needed, such as "repo scope"
+// or "read_repository, api scopes"
+func plural(names []string, noun string) string {
+	if len(names) == 1 {
+		return names[0] + " " + noun
+	}
+	return strings.Join(names, ", ") + " " + noun + "s"
+}
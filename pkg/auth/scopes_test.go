@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newScopesManager returns a token manager whose provider API is served by handler
+func newScopesManager(t *testing.T, provider string, handler http.HandlerFunc) (*TokenManager, *int) {
+	t.Helper()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	config := DefaultConfig()
+	providerConfig := config.Providers[provider]
+	providerConfig.BaseURL = server.URL + "/api/v4"
+	config.Providers[provider] = providerConfig
+
+	logger := logging.NewBasic()
+	storage, err := NewMemoryStorage(config, logger)
+	require.NoError(t, err)
+	return NewTokenManager(config, logger, storage), &requests
+}
+
+func TestTokenManager_CheckScopes_GitHub(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("GITHUB_TOKEN", "ghp_classic")
+	manager, requests := newScopesManager(t, "github", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/user", r.URL.Path)
+		w.Header().Set("X-OAuth-Scopes", "read:org, public_repo")
+	})
+
+	report, err := manager.CheckScopes(context.Background(), "github", OperationAssetsRead, OperationIssuesWrite)
+	require.NoError(t, err)
+	assert.True(t, report.Checked)
+	assert.Equal(t, []string{"read:org", "public_repo"}, report.Granted)
+	assert.Equal(t, []MissingScope{{Operation: OperationAssetsRead, Scopes: []string{"repo"}}}, report.Missing)
+
+	err = report.Err()
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeInsufficientScopes, GetErrorCode(err))
+	assert.Contains(t, err.Error(), "github credentials lack the repo scope needed for assets:read")
+	assert.Contains(t, err.Error(), "/settings/tokens/new?scopes=repo and run 'zen auth github'")
+
+	_, err = manager.CheckScopes(context.Background(), "github", OperationIssuesRead)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *requests, "the scopes of a token are read once")
+}
+
+func TestTokenManager_CheckScopes_FineGrainedToken(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("GITHUB_TOKEN", "github_pat_fine_grained")
+	manager, _ := newScopesManager(t, "github", func(w http.ResponseWriter, r *http.Request) {})
+
+	report, err := manager.CheckScopes(context.Background(), "github", Operations...)
+	require.NoError(t, err)
+	assert.False(t, report.Checked, "fine-grained tokens have no OAuth scopes to read")
+	assert.NoError(t, report.Err())
+}
+
+func TestTokenManager_CheckScopes_GitLab(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "glpat-read")
+	manager, _ := newScopesManager(t, "gitlab", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/personal_access_tokens/self", r.URL.Path)
+		_, _ = w.Write([]byte(`{"scopes":["read_repository","read_api"]}`))
+	})
+
+	report, err := manager.CheckScopes(context.Background(), "gitlab", Operations...)
+	require.NoError(t, err)
+	assert.Equal(t, []MissingScope{{Operation: OperationIssuesWrite, Scopes: []string{"api"}}}, report.Missing)
+	assert.Regexp(t, `^create a token with the api scope at http://127\.0\.0\.1:\d+/-/user_settings/personal_access_tokens\?scopes=api`, report.Remediation)
+}
+
+func TestTokenManager_CheckScopes_GitHubApp(t *testing.T) {
+	key := generateAppKey(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"token":"ghs_installation","permissions":{"contents":"read","issues":"write"}}`))
+	}))
+	defer server.Close()
+	manager := newGitHubAppManager(t, key, server.URL, GitHubConfig{InstallationID: 42})
+
+	report, err := manager.CheckScopes(context.Background(), "github", Operations...)
+	require.NoError(t, err)
+	assert.True(t, report.Checked)
+	assert.Equal(t, []string{"contents:read", "issues:read", "issues:write"}, report.Granted)
+	assert.Empty(t, report.Missing, "write permissions include read")
+}
+
+func TestRequireScopes_UncheckedManagers(t *testing.T) {
+	manager, _ := newScopesManager(t, "notion", func(w http.ResponseWriter, r *http.Request) {})
+	assert.NoError(t, RequireScopes(context.Background(), manager, "notion", OperationIssuesWrite), "providers without scopes are not checked")
+	assert.NoError(t, RequireScopes(context.Background(), nil, "github", OperationAssetsRead), "managers that cannot check scopes accept any credentials")
+}
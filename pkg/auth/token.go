@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/pkg/errors"
 )
 
@@ -19,9 +20,15 @@ type TokenManager struct {
 	logger  logging.Logger
 	storage CredentialStorage
 
+	// configValue reads configuration keys, resolving secret references
+	configValue func(ctx context.Context, key string) (string, error)
+
 	// In-memory cache for performance
 	mu    sync.RWMutex
 	cache map[string]*Credential
+
+	// scopes are the scopes read for the token of each provider
+	scopes map[string]grantedScopes
 }
 
 // NewTokenManager creates a new token-based authentication manager
@@ -31,13 +38,33 @@ func NewTokenManager(config Config, logger logging.Logger, storage CredentialSto
 		logger:  logger,
 		storage: storage,
 		cache:   make(map[string]*Credential),
+		scopes:  make(map[string]grantedScopes),
 	}
 }
 
+// SetConfigSource reads the tokens of providers from their configuration keys with
+// lookup, after the environment variables. Tokens read from configuration are not
+// stored, since they may come from a secret manager.
+func (t *TokenManager) SetConfigSource(lookup func(ctx context.Context, key string) (string, error)) {
+	t.configValue = lookup
+}
+
 // Authenticate authenticates with the specified provider
 func (t *TokenManager) Authenticate(ctx context.Context, provider string) error {
 	t.logger.Debug("authenticating with provider", "provider", provider)
 
+	// Minting an installation token authenticates a GitHub App
+	if t.githubApp(provider) {
+		credential, err := t.getGitHubAppToken(ctx)
+		if err != nil {
+			return err
+		}
+		t.mu.Lock()
+		t.cache[provider] = credential
+		t.mu.Unlock()
+		return nil
+	}
+
 	// Get or create credential
 	credential, err := t.getOrCreateCredential(ctx, provider)
 	if err != nil {
@@ -62,8 +89,10 @@ func (t *TokenManager) Authenticate(ctx context.Context, provider string) error
 	*credential.LastValidated = time.Now()
 	credential.LastUsed = time.Now()
 
-	if err := t.storage.Store(ctx, provider, credential); err != nil {
-		t.logger.Warn("failed to update credential storage", "provider", provider, "error", err)
+	if source := credential.Metadata["source"]; source != sourceConfig && source != sourceOIDC {
+		if err := t.storage.Store(ctx, provider, credential); err != nil {
+			t.logger.Warn("failed to update credential storage", "provider", provider, "error", err)
+		}
 	}
 
 	// Update cache
@@ -85,8 +114,21 @@ func (t *TokenManager) GetCredentials(provider string) (string, error) {
 	}
 	t.mu.RUnlock()
 
-	// Try to load from storage
 	ctx := context.Background()
+
+	// GitHub Apps mint installation tokens, replaced as they expire
+	if t.githubApp(provider) {
+		credential, err := t.getGitHubAppToken(ctx)
+		if err != nil {
+			return "", err
+		}
+		t.mu.Lock()
+		t.cache[provider] = credential
+		t.mu.Unlock()
+		return credential.Token, nil
+	}
+
+	// Try to load from storage
 	credential, err := t.storage.Retrieve(ctx, provider)
 	if err == nil && credential != nil && credential.IsValid() {
 		// Update cache
@@ -119,6 +161,30 @@ func (t *TokenManager) GetCredentials(provider string) (string, error) {
 		return token, nil
 	}
 
+	// Try configuration keys, which may refer to a secret manager
+	token, err := t.getTokenFromConfig(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		t.mu.Lock()
+		t.cache[provider] = configCredential(provider, token)
+		t.mu.Unlock()
+		return token, nil
+	}
+
+	// Try exchanging the OIDC token of the CI job or cloud workload
+	credential, err = t.getTokenFromOIDC(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+	if credential != nil {
+		t.mu.Lock()
+		t.cache[provider] = credential
+		t.mu.Unlock()
+		return credential.Token, nil
+	}
+
 	return "", NewAuthError(
 		ErrorCodeCredentialNotFound,
 		fmt.Sprintf("no authentication token found for provider '%s'", provider),
@@ -138,6 +204,18 @@ func (t *TokenManager) ValidateCredentials(ctx context.Context, provider string)
 
 // RefreshCredentials refreshes expired credentials if possible
 func (t *TokenManager) RefreshCredentials(ctx context.Context, provider string) error {
+	// Installation tokens of GitHub Apps are minted again
+	if t.githubApp(provider) {
+		credential, err := t.getGitHubAppToken(ctx)
+		if err != nil {
+			return err
+		}
+		t.mu.Lock()
+		t.cache[provider] = credential
+		t.mu.Unlock()
+		return nil
+	}
+
 	// For token-based auth, we can't refresh automatically
 	// User needs to provide a new token
 	return NewAuthError(
@@ -219,6 +297,10 @@ func (t *TokenManager) GetProviderInfo(provider string) (*ProviderInfo, error) {
 // Private helper methods
 
 func (t *TokenManager) getOrCreateCredential(ctx context.Context, provider string) (*Credential, error) {
+	if t.githubApp(provider) {
+		return t.getGitHubAppToken(ctx)
+	}
+
 	// Try to get from storage first
 	credential, err := t.storage.Retrieve(ctx, provider)
 	if err == nil && credential != nil {
@@ -236,6 +318,21 @@ func (t *TokenManager) getOrCreateCredential(ctx context.Context, provider strin
 		}, nil
 	}
 
+	// Try configuration keys, which may refer to a secret manager
+	token, err := t.getTokenFromConfig(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		return configCredential(provider, token), nil
+	}
+
+	// Try exchanging the OIDC token of the CI job or cloud workload
+	credential, err = t.getTokenFromOIDC(ctx, provider)
+	if err != nil || credential != nil {
+		return credential, err
+	}
+
 	return nil, NewAuthError(
 		ErrorCodeCredentialNotFound,
 		fmt.Sprintf("no authentication token found for provider '%s'", provider),
@@ -259,6 +356,46 @@ func (t *TokenManager) getTokenFromEnv(provider string) string {
 	return ""
 }
 
+// sourceConfig marks credentials read from configuration, which are never stored
+const sourceConfig = "config"
+
+// configCredential returns the credential of a token read from configuration
+func configCredential(provider, token string) *Credential {
+	return &Credential{
+		Provider:  provider,
+		Token:     token,
+		Type:      "token",
+		Metadata:  map[string]string{"source": sourceConfig},
+		CreatedAt: time.Now(),
+		LastUsed:  time.Now(),
+	}
+}
+
+// getTokenFromConfig returns the token of the first token key of the provider that is
+// set in configuration, such as github.token
+func (t *TokenManager) getTokenFromConfig(ctx context.Context, provider string) (string, error) {
+	config, exists := t.config.Providers[provider]
+	if !exists || t.configValue == nil {
+		return "", nil
+	}
+
+	for _, key := range config.ConfigKeys {
+		if !strings.HasSuffix(key, ".token") {
+			continue
+		}
+		token, err := t.configValue(ctx, key)
+		if err != nil {
+			return "", err
+		}
+		if token != "" {
+			t.logger.Debug("token loaded from configuration", "provider", provider, "config_key", key)
+			return token, nil
+		}
+	}
+
+	return "", nil
+}
+
 func (t *TokenManager) validateToken(ctx context.Context, provider, token string) error {
 	config, exists := t.config.Providers[provider]
 	if !exists {
@@ -271,9 +408,21 @@ func (t *TokenManager) validateToken(ctx context.Context, provider, token string
 
 	switch provider {
 	case "github":
+		if t.githubApp(provider) {
+			// Installation tokens cannot read /user; minting one authenticated the app
+			return nil
+		}
 		return t.validateGitHubToken(ctx, token, config.BaseURL)
 	case "gitlab":
 		return t.validateGitLabToken(ctx, token, config.BaseURL)
+	case "notion":
+		return t.validateNotionToken(ctx, token, config.BaseURL)
+	case "servicenow", "confluence":
+		// The instance these tokens are for is a provider setting, and for ServiceNow the
+		// token is a password, client secret or bearer token by the auth type of the
+		// provider, so they are checked by the first request to the instance
+		t.logger.Debug("token stored without validation", "provider", provider)
+		return nil
 	default:
 		return NewAuthError(
 			ErrorCodeProviderNotSupported,
@@ -283,10 +432,19 @@ func (t *TokenManager) validateToken(ctx context.Context, provider, token string
 	}
 }
 
+// validationClient returns the client tokens for provider are validated with. Validation
+// is not retried, so that a bad token is reported at once.
+func (t *TokenManager) validationClient(provider string) *http.Client {
+	return httpx.New(httpx.Options{
+		Provider: provider,
+		Timeout:  t.config.ValidationTimeout,
+		Retry:    httpx.NoRetries,
+		Logger:   t.logger,
+	})
+}
+
 func (t *TokenManager) validateGitHubToken(ctx context.Context, token, baseURL string) error {
-	client := &http.Client{
-		Timeout: t.config.ValidationTimeout,
-	}
+	client := t.validationClient("github")
 
 	url := baseURL + "/user"
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -295,7 +453,6 @@ func (t *TokenManager) validateGitHubToken(ctx context.Context, token, baseURL s
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
-	req.Header.Set("User-Agent", "zen-cli/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -327,9 +484,7 @@ func (t *TokenManager) validateGitHubToken(ctx context.Context, token, baseURL s
 }
 
 func (t *TokenManager) validateGitLabToken(ctx context.Context, token, baseURL string) error {
-	client := &http.Client{
-		Timeout: t.config.ValidationTimeout,
-	}
+	client := t.validationClient("gitlab")
 
 	url := baseURL + "/user"
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -338,7 +493,6 @@ func (t *TokenManager) validateGitLabToken(ctx context.Context, token, baseURL s
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("User-Agent", "zen-cli/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -369,12 +523,59 @@ func (t *TokenManager) validateGitLabToken(ctx context.Context, token, baseURL s
 	}
 }
 
+func (t *TokenManager) validateNotionToken(ctx context.Context, token, baseURL string) error {
+	client := t.validationClient("notion")
+
+	url := baseURL + "/users/me"
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create validation request")
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Notion-Version", "2022-06-28")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return NewNetworkError("notion", err.Error())
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		t.logger.Debug("Notion token validation successful")
+		return nil
+	case http.StatusUnauthorized:
+		return NewAuthError(
+			ErrorCodeInvalidCredentials,
+			"Notion integration token is invalid or revoked",
+			"notion",
+		)
+	case http.StatusForbidden:
+		return NewAuthError(
+			ErrorCodeInsufficientScopes,
+			"Notion integration lacks the capabilities to read users",
+			"notion",
+		)
+	case http.StatusTooManyRequests:
+		return NewRateLimitError("notion", 60)
+	default:
+		return NewNetworkError("notion", fmt.Sprintf("unexpected response: %d", resp.StatusCode))
+	}
+}
+
 func (t *TokenManager) getProviderDescription(provider string) string {
 	switch provider {
 	case "github":
 		return "GitHub Personal Access Token authentication"
 	case "gitlab":
 		return "GitLab Project Access Token authentication"
+	case "notion":
+		return "Notion internal integration token authentication"
+	case "servicenow":
+		return "ServiceNow password, OAuth client secret or token authentication"
+	case "confluence":
+		return "Confluence personal access token authentication"
 	default:
 		return fmt.Sprintf("Token-based authentication for %s", provider)
 	}
@@ -400,6 +601,32 @@ func (t *TokenManager) getProviderInstructions(provider string) []string {
 			"5. Set environment variable: export ZEN_GITLAB_TOKEN=your_token",
 			"6. Or use: zen config set gitlab.token your_token",
 		}
+	case "notion":
+		return []string{
+			"1. Go to https://www.notion.so/my-integrations",
+			"2. Create an internal integration with read, update and insert content capabilities",
+			"3. Copy the Internal Integration Secret",
+			"4. Share the task database with the integration from its Connections menu",
+			"5. Set environment variable: export ZEN_NOTION_TOKEN=your_token",
+			"6. Or use: zen config set notion.token your_token",
+		}
+	case "servicenow":
+		return []string{
+			"1. Choose how Zen signs in to your instance with the type of the servicenow provider:",
+			"   basic (email and password), oauth2 (an OAuth application registry entry) or bearer",
+			"2. For basic, the token is the password of the user in the provider's email",
+			"3. For oauth2, the token is the client secret, and settings.client_id the client ID",
+			"4. Set environment variable: export ZEN_SERVICENOW_TOKEN=your_token",
+			"5. Or use: zen config set servicenow.token your_token",
+		}
+	case "confluence":
+		return []string{
+			"1. For Confluence Cloud, set type basic with email and api_key on the confluence provider instead",
+			"2. For Confluence Data Center, open your profile and go to Personal Access Tokens",
+			"3. Create a token with write access to the space tasks are published to",
+			"4. Set environment variable: export ZEN_CONFLUENCE_TOKEN=your_token",
+			"5. Or use: zen config set confluence.token your_token",
+		}
 	default:
 		return []string{
 			fmt.Sprintf("1. Obtain a token for %s", provider),
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -141,6 +142,28 @@ func TestTokenManager_GetCredentials_NotFound(t *testing.T) {
 	assert.Equal(t, ErrorCodeCredentialNotFound, GetErrorCode(err))
 }
 
+func TestTokenManager_GetCredentials_FromConfig(t *testing.T) {
+	config := DefaultConfig()
+	logger := logging.NewBasic()
+	storage, err := NewMemoryStorage(config, logger)
+	require.NoError(t, err)
+	manager := NewTokenManager(config, logger, storage)
+
+	var keys []string
+	manager.SetConfigSource(func(ctx context.Context, key string) (string, error) {
+		keys = append(keys, key)
+		return "resolved-token", nil
+	})
+
+	token, err := manager.GetCredentials("jira")
+	require.NoError(t, err)
+	assert.Equal(t, "resolved-token", token)
+	assert.Equal(t, []string{"jira.token"}, keys, "only token keys are read")
+
+	_, err = storage.Retrieve(context.Background(), "jira")
+	assert.Error(t, err, "tokens read from configuration are not stored")
+}
+
 func TestTokenManager_GetCredentials_CacheHit(t *testing.T) {
 	// Arrange
 	config := DefaultConfig()
@@ -404,7 +427,7 @@ func TestTokenManager_ValidateGitHubToken(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, "/user", r.URL.Path)
 				assert.Contains(t, r.Header.Get("Authorization"), "token")
-				assert.Equal(t, "zen-cli/1.0", r.Header.Get("User-Agent"))
+				assert.True(t, strings.HasPrefix(r.Header.Get("User-Agent"), "zen/"), r.Header.Get("User-Agent"))
 
 				w.WriteHeader(tt.statusCode)
 				if tt.responseBody != "" {
@@ -478,7 +501,7 @@ func TestTokenManager_ValidateGitLabToken(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, "/user", r.URL.Path)
 				assert.Contains(t, r.Header.Get("Authorization"), "Bearer")
-				assert.Equal(t, "zen-cli/1.0", r.Header.Get("User-Agent"))
+				assert.True(t, strings.HasPrefix(r.Header.Get("User-Agent"), "zen/"), r.Header.Get("User-Agent"))
 
 				w.WriteHeader(tt.statusCode)
 				if tt.responseBody != "" {
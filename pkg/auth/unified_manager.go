@@ -8,6 +8,7 @@ import (
 
 	"github.com/daddia/zen/internal/config"
 	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/secretref"
 )
 
 // UnifiedAuthManager combines token and basic auth capabilities
@@ -15,23 +16,53 @@ type UnifiedAuthManager struct {
 	tokenManager *TokenManager
 	basicManager *BasicAuthManager
 	config       *config.Config
+	secrets      *secretref.Resolver
 	logger       logging.Logger
 }
 
-// NewUnifiedAuthManager creates a new unified auth manager
+// NewUnifiedAuthManager creates a new unified auth manager. Credentials in config may
+// be secret references, which are resolved through the secrets configuration when used.
 func NewUnifiedAuthManager(authConfig Config, cfg *config.Config, logger logging.Logger, storage CredentialStorage) *UnifiedAuthManager {
-	return &UnifiedAuthManager{
+	secrets, err := secretref.ForConfig(cfg)
+	if err != nil {
+		logger.Warn("invalid secrets configuration, using defaults", "error", err)
+		secrets = secretref.NewResolver(secretref.DefaultConfig())
+	}
+
+	u := &UnifiedAuthManager{
 		tokenManager: NewTokenManager(authConfig, logger, storage),
 		basicManager: NewBasicAuthManager(authConfig, logger, storage),
 		config:       cfg,
+		secrets:      secrets,
 		logger:       logger,
 	}
+	u.tokenManager.SetConfigSource(u.configValue)
+	return u
+}
+
+// configValue returns the value of a configuration key, resolving secret references
+func (u *UnifiedAuthManager) configValue(ctx context.Context, key string) (string, error) {
+	return u.secrets.Resolve(ctx, u.config.GetViper().GetString(key))
+}
+
+// providerConfig returns the configuration of an integration provider, with the secret
+// references of its credentials resolved
+func (u *UnifiedAuthManager) providerConfig(ctx context.Context, provider string) (config.IntegrationProviderConfig, bool, error) {
+	providerConfig, exists := u.config.Integrations.Providers[provider]
+	if !exists {
+		return providerConfig, false, nil
+	}
+	providerConfig, err := u.secrets.ResolveProvider(ctx, providerConfig)
+	return providerConfig, true, err
 }
 
 // Authenticate authenticates with the specified provider
 func (u *UnifiedAuthManager) Authenticate(ctx context.Context, provider string) error {
 	// Check if provider is configured
-	providerConfig, exists := u.config.Integrations.Providers[provider]
+	providerConfig, exists, err := u.providerConfig(ctx, provider)
+	if err != nil {
+		return err
+	}
 	if !exists {
 		// Fall back to token manager for non-integration providers
 		return u.tokenManager.Authenticate(ctx, provider)
@@ -50,8 +81,15 @@ func (u *UnifiedAuthManager) Authenticate(ctx context.Context, provider string)
 // GetCredentials returns credentials for the specified provider
 func (u *UnifiedAuthManager) GetCredentials(provider string) (string, error) {
 	// Check if provider needs Basic Auth
-	providerConfig, exists := u.config.Integrations.Providers[provider]
+	providerConfig, exists, err := u.providerConfig(context.Background(), provider)
+	if err != nil {
+		return "", err
+	}
 	if exists && providerConfig.Type == "basic" {
+		if providerConfig.Email != "" && providerConfig.APIKey != "" {
+			auth := providerConfig.Email + ":" + providerConfig.APIKey
+			return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth)), nil
+		}
 		// Get Basic Auth credentials
 		cred, err := u.basicManager.GetBasicAuthFromConfig(u.config, provider)
 		if err != nil {
@@ -69,7 +107,10 @@ func (u *UnifiedAuthManager) GetCredentials(provider string) (string, error) {
 // GetAuthorizationHeader returns the Authorization header value for a provider
 func (u *UnifiedAuthManager) GetAuthorizationHeader(provider string) (string, error) {
 	// Check provider configuration
-	providerConfig, exists := u.config.Integrations.Providers[provider]
+	providerConfig, exists, err := u.providerConfig(context.Background(), provider)
+	if err != nil {
+		return "", err
+	}
 	if !exists {
 		// Try token-based auth
 		token, err := u.tokenManager.GetCredentials(provider)
@@ -129,7 +170,10 @@ func (u *UnifiedAuthManager) GetAuthorizationHeader(provider string) (string, er
 // ValidateCredentials validates stored credentials for the provider
 func (u *UnifiedAuthManager) ValidateCredentials(ctx context.Context, provider string) error {
 	// Check if provider needs Basic Auth
-	providerConfig, exists := u.config.Integrations.Providers[provider]
+	providerConfig, exists, err := u.providerConfig(ctx, provider)
+	if err != nil {
+		return err
+	}
 	if exists && providerConfig.Type == "basic" {
 		// For Basic Auth, check if we have the required fields
 		if providerConfig.Email == "" || providerConfig.APIKey == "" {
@@ -144,6 +188,16 @@ func (u *UnifiedAuthManager) ValidateCredentials(ctx context.Context, provider s
 	return u.tokenManager.ValidateCredentials(ctx, provider)
 }
 
+// CheckScopes reports the operations the credentials of provider lack scopes for.
+// Basic Auth credentials have no scopes, and are unchecked.
+func (u *UnifiedAuthManager) CheckScopes(ctx context.Context, provider string, operations ...string) (*ScopeReport, error) {
+	providerConfig, exists := u.config.Integrations.Providers[provider]
+	if exists && providerConfig.Type == "basic" {
+		return &ScopeReport{Provider: provider}, nil
+	}
+	return u.tokenManager.CheckScopes(ctx, provider, operations...)
+}
+
 // RefreshCredentials refreshes expired credentials if possible
 func (u *UnifiedAuthManager) RefreshCredentials(ctx context.Context, provider string) error {
 	// Basic Auth doesn't support refresh
@@ -159,7 +213,10 @@ func (u *UnifiedAuthManager) RefreshCredentials(ctx context.Context, provider st
 // IsAuthenticated checks if credentials are available and valid for the provider
 func (u *UnifiedAuthManager) IsAuthenticated(ctx context.Context, provider string) bool {
 	// Check if provider needs Basic Auth
-	providerConfig, exists := u.config.Integrations.Providers[provider]
+	providerConfig, exists, err := u.providerConfig(ctx, provider)
+	if err != nil {
+		return false
+	}
 	if exists && providerConfig.Type == "basic" {
 		// Check if credentials are in config
 		if providerConfig.Email != "" && providerConfig.APIKey != "" {
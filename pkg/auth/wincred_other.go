@@ -0,0 +1,27 @@
+//go:build !windows
+
+package auth
+
+import "errors"
+
+var errWincredUnsupported = errors.New("windows credential manager is only available on Windows")
+
+func wincredAvailable() bool {
+	return false
+}
+
+func wincredWrite(target, user string, secret []byte) error {
+	return errWincredUnsupported
+}
+
+func wincredRead(target string) ([]byte, error) {
+	return nil, errWincredUnsupported
+}
+
+func wincredDelete(target string) error {
+	return errWincredUnsupported
+}
+
+func wincredList(prefix string) ([]string, error) {
+	return nil, errWincredUnsupported
+}
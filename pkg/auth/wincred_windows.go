@@ -0,0 +1,142 @@
+//go:build windows
+
+package auth
+
+import (
+	"errors"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Windows Credential Manager API, from advapi32.dll
+var (
+	advapi32           = windows.NewLazySystemDLL("advapi32.dll")
+	procCredWriteW     = advapi32.NewProc("CredWriteW")
+	procCredReadW      = advapi32.NewProc("CredReadW")
+	procCredDeleteW    = advapi32.NewProc("CredDeleteW")
+	procCredEnumerateW = advapi32.NewProc("CredEnumerateW")
+	procCredFree       = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+)
+
+// credential is the CREDENTIALW structure
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// wincredAvailable reports whether the Windows Credential Manager can be used
+func wincredAvailable() bool {
+	return advapi32.Load() == nil && procCredReadW.Find() == nil
+}
+
+// wincredWrite stores secret as the generic credential target, replacing any existing one
+func wincredWrite(target, user string, secret []byte) error {
+	targetPtr, err := windows.UTF16PtrFromString(target)
+	if err != nil {
+		return err
+	}
+	userPtr, err := windows.UTF16PtrFromString(user)
+	if err != nil {
+		return err
+	}
+
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         targetPtr,
+		UserName:           userPtr,
+		CredentialBlobSize: uint32(len(secret)),
+		Persist:            credPersistLocalMachine,
+	}
+	if len(secret) > 0 {
+		cred.CredentialBlob = &secret[0]
+	}
+
+	if ret, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0); ret == 0 {
+		return err
+	}
+	return nil
+}
+
+// wincredRead returns the secret of the generic credential target, or errWincredNotFound
+func wincredRead(target string) ([]byte, error) {
+	targetPtr, err := windows.UTF16PtrFromString(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var cred *credential
+	ret, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(targetPtr)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if errors.Is(err, windows.ERROR_NOT_FOUND) {
+			return nil, errWincredNotFound
+		}
+		return nil, err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred))) // #nosec G104 - CredFree returns nothing
+
+	secret := make([]byte, cred.CredentialBlobSize)
+	if cred.CredentialBlobSize > 0 {
+		copy(secret, unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize))
+	}
+	return secret, nil
+}
+
+// wincredDelete removes the generic credential target; a missing target is not an error
+func wincredDelete(target string) error {
+	targetPtr, err := windows.UTF16PtrFromString(target)
+	if err != nil {
+		return err
+	}
+	if ret, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(targetPtr)), credTypeGeneric, 0); ret == 0 && !errors.Is(err, windows.ERROR_NOT_FOUND) {
+		return err
+	}
+	return nil
+}
+
+// wincredList returns the targets of the generic credentials that start with prefix
+func wincredList(prefix string) ([]string, error) {
+	filter, err := windows.UTF16PtrFromString(prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+
+	var count uint32
+	var creds **credential
+	ret, _, err := procCredEnumerateW.Call(uintptr(unsafe.Pointer(filter)), 0,
+		uintptr(unsafe.Pointer(&count)), uintptr(unsafe.Pointer(&creds)))
+	if ret == 0 {
+		if errors.Is(err, windows.ERROR_NOT_FOUND) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(creds))) // #nosec G104 - CredFree returns nothing
+
+	var targets []string
+	for _, cred := range unsafe.Slice(creds, count) {
+		if cred.Type != credTypeGeneric {
+			continue
+		}
+		if target := windows.UTF16PtrToString(cred.TargetName); strings.HasPrefix(target, prefix) {
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
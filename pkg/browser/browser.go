@@ -0,0 +1,38 @@
+// Package browser opens URLs in the user's web browser.
+package browser
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Command returns the command used to open url, honouring ZEN_BROWSER and BROWSER
+func Command(url string) *exec.Cmd {
+	for _, env := range []string{"ZEN_BROWSER", "BROWSER"} {
+		if launcher := os.Getenv(env); launcher != "" {
+			args := strings.Fields(launcher)
+			return exec.Command(args[0], append(args[1:], url)...)
+		}
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
+
+// Open opens url in the browser without waiting for it to exit
+func Open(url string) error {
+	cmd := Command(url)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
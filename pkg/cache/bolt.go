@@ -0,0 +1,507 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/fs"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// boltFileName is the database file created under the base path
+	boltFileName = "cache.db"
+	// boltOpenTimeout bounds the wait for another process holding the database
+	boltOpenTimeout = 5 * time.Second
+)
+
+var (
+	boltEntriesBucket = []byte("entries")
+	boltMetaBucket    = []byte("meta")
+	boltStatsKey      = []byte("stats")
+)
+
+// BoltManager implements Manager in a single bbolt database file under the base path.
+// Items survive between runs like the file backend, but are written in transactions,
+// so an interrupted write never leaves a partial entry behind. The database is opened
+// on first use and held until Close.
+type BoltManager[T any] struct {
+	config     Config
+	logger     logging.Logger
+	serializer Serializer[T]
+
+	mu    sync.Mutex
+	db    *bolt.DB
+	stats cacheStats
+}
+
+type boltEntry struct {
+	Data       []byte        `json:"data"`
+	Checksum   string        `json:"checksum"`
+	CreatedAt  time.Time     `json:"created_at"`
+	AccessedAt time.Time     `json:"accessed_at"`
+	TTL        time.Duration `json:"ttl"` // 0 = no expiration
+}
+
+// NewBoltManager creates a new bbolt-backed cache manager. The database is created at
+// cache.db under the base path when the manager is first used.
+func NewBoltManager[T any](config Config, logger logging.Logger, serializer Serializer[T]) *BoltManager[T] {
+	if basePath, err := fs.ExpandHome(config.BasePath); err == nil {
+		config.BasePath = basePath
+	}
+
+	return &BoltManager[T]{
+		config:     config,
+		logger:     logger,
+		serializer: serializer,
+		stats:      cacheStats{LastCleanup: time.Now()},
+	}
+}
+
+// Get retrieves an item from cache
+func (c *BoltManager[T]) Get(ctx context.Context, key string) (*Entry[T], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	db, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+
+	var entry *boltEntry
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltEntriesBucket)
+		stored, err := readBoltEntry(bucket, key)
+		if err != nil || stored == nil {
+			return err
+		}
+		if stored.expired() {
+			return bucket.Delete([]byte(key))
+		}
+		stored.AccessedAt = time.Now()
+		entry = stored
+		return writeBoltEntry(bucket, key, stored)
+	})
+	if err != nil {
+		return nil, boltError(ErrorCodeCorrupted, fmt.Sprintf("failed to read cached item '%s'", key), err)
+	}
+	if entry == nil {
+		c.stats.MissCount++
+		return nil, &Error{
+			Code:    ErrorCodeNotFound,
+			Message: fmt.Sprintf("key '%s' not found in cache", key),
+		}
+	}
+	c.stats.HitCount++
+
+	data, err := c.serializer.Deserialize(entry.Data)
+	if errors.Is(err, ErrKeyUnavailable) {
+		return nil, keyUnavailableError(key, err)
+	}
+	if err != nil {
+		_ = c.delete(key)
+		return nil, &Error{
+			Code:    ErrorCodeSerialization,
+			Message: fmt.Sprintf("failed to deserialize cached item '%s'", key),
+			Details: err.Error(),
+		}
+	}
+
+	return &Entry[T]{
+		Data:     data,
+		Checksum: entry.Checksum,
+		Cached:   true,
+		CacheAge: int64(time.Since(entry.CreatedAt).Seconds()),
+		Size:     int64(len(entry.Data)),
+	}, nil
+}
+
+// Put stores an item in cache
+func (c *BoltManager[T]) Put(ctx context.Context, key string, data T, opts PutOptions) error {
+	if key == "" {
+		return &Error{
+			Code:    ErrorCodeInvalidKey,
+			Message: "cache key cannot be empty",
+		}
+	}
+
+	serialized, err := c.serializer.Serialize(data)
+	if err != nil {
+		return &Error{
+			Code:    ErrorCodeSerialization,
+			Message: fmt.Sprintf("failed to serialize data for key '%s'", key),
+			Details: err.Error(),
+		}
+	}
+
+	checksum := opts.Checksum
+	if checksum == "" {
+		checksum = fmt.Sprintf("sha256:%x", sha256.Sum256(serialized))
+	}
+
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = c.config.DefaultTTL
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltEntriesBucket)
+		if err := bucket.Delete([]byte(key)); err != nil {
+			return err
+		}
+		if err := c.evictLRU(bucket, int64(len(serialized))); err != nil {
+			return err
+		}
+		return writeBoltEntry(bucket, key, &boltEntry{
+			Data:       serialized,
+			Checksum:   checksum,
+			CreatedAt:  now,
+			AccessedAt: now,
+			TTL:        ttl,
+		})
+	})
+	if err != nil {
+		return boltError(ErrorCodePermission, fmt.Sprintf("failed to cache item '%s'", key), err)
+	}
+
+	c.logger.Debug("item cached in bbolt", "key", key, "size", len(serialized))
+	return nil
+}
+
+// Delete removes an item from cache
+func (c *BoltManager[T]) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.delete(key)
+}
+
+// Clear removes all cached items
+func (c *BoltManager[T]) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(boltEntriesBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(boltEntriesBucket)
+		return err
+	})
+	if err != nil {
+		return boltError(ErrorCodePermission, "failed to clear cache", err)
+	}
+
+	c.stats = cacheStats{LastCleanup: time.Now()}
+	return nil
+}
+
+// Keys returns the keys of all unexpired items, in sorted order
+func (c *BoltManager[T]) Keys(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	db, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	err = db.View(func(tx *bolt.Tx) error {
+		return forEachBoltEntry(tx.Bucket(boltEntriesBucket), func(key string, entry *boltEntry) error {
+			if !entry.expired() {
+				keys = append(keys, key)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, boltError(ErrorCodeCorrupted, "failed to list cache keys", err)
+	}
+
+	// bbolt iterates in byte order, which is already sorted
+	return keys, nil
+}
+
+// GetInfo returns cache information
+func (c *BoltManager[T]) GetInfo(ctx context.Context) (*Info, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	db, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+
+	var totalSize int64
+	var entryCount int
+	err = db.View(func(tx *bolt.Tx) error {
+		return forEachBoltEntry(tx.Bucket(boltEntriesBucket), func(key string, entry *boltEntry) error {
+			totalSize += int64(len(entry.Data))
+			entryCount++
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, boltError(ErrorCodeCorrupted, "failed to read cache info", err)
+	}
+
+	var hitRatio float64
+	if total := c.stats.HitCount + c.stats.MissCount; total > 0 {
+		hitRatio = float64(c.stats.HitCount) / float64(total)
+	}
+
+	return &Info{
+		TotalSize:     totalSize,
+		EntryCount:    entryCount,
+		LastCleanup:   c.stats.LastCleanup,
+		HitCount:      c.stats.HitCount,
+		MissCount:     c.stats.MissCount,
+		CacheHitRatio: hitRatio,
+	}, nil
+}
+
+// Cleanup removes expired items and evicts the least recently used ones over the size limit
+func (c *BoltManager[T]) Cleanup(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltEntriesBucket)
+		var expired []string
+		err := forEachBoltEntry(bucket, func(key string, entry *boltEntry) error {
+			if entry.expired() {
+				expired = append(expired, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range expired {
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+		return c.evictLRU(bucket, 0)
+	})
+	if err != nil {
+		return boltError(ErrorCodePermission, "failed to clean up cache", err)
+	}
+
+	c.stats.LastCleanup = time.Now()
+	return nil
+}
+
+// Close saves the hit and miss counts and releases the database
+func (c *BoltManager[T]) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db == nil {
+		return nil
+	}
+
+	if err := c.saveStats(); err != nil {
+		c.logger.Warn("failed to save cache stats on close", "error", err)
+	}
+
+	err := c.db.Close()
+	c.db = nil
+	if err != nil {
+		return boltError(ErrorCodePermission, "failed to close cache database", err)
+	}
+	return nil
+}
+
+// open returns the database, opening it and loading the saved stats on first use.
+// The caller holds the lock.
+func (c *BoltManager[T]) open() (*bolt.DB, error) {
+	if c.db != nil {
+		return c.db, nil
+	}
+
+	if err := os.MkdirAll(c.config.BasePath, 0755); err != nil {
+		return nil, &Error{
+			Code:    ErrorCodePermission,
+			Message: "failed to create cache directory",
+			Details: err.Error(),
+		}
+	}
+
+	path := filepath.Join(c.config.BasePath, boltFileName)
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: boltOpenTimeout})
+	if err != nil {
+		return nil, boltError(ErrorCodePermission, fmt.Sprintf("failed to open cache database %s", path), err)
+	}
+
+	var stats cacheStats
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(boltEntriesBucket); err != nil {
+			return err
+		}
+		meta, err := tx.CreateBucketIfNotExists(boltMetaBucket)
+		if err != nil {
+			return err
+		}
+		if raw := meta.Get(boltStatsKey); raw != nil {
+			return json.Unmarshal(raw, &stats)
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, boltError(ErrorCodePermission, fmt.Sprintf("failed to initialize cache database %s", path), err)
+	}
+
+	c.stats.HitCount += stats.HitCount
+	c.stats.MissCount += stats.MissCount
+	if stats.LastCleanup.After(c.stats.LastCleanup) {
+		c.stats.LastCleanup = stats.LastCleanup
+	}
+
+	c.db = db
+	c.logger.Debug("opened cache database", "path", path)
+	return db, nil
+}
+
+// delete removes key from the database. The caller holds the lock.
+func (c *BoltManager[T]) delete(key string) error {
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltEntriesBucket).Delete([]byte(key))
+	})
+	if err != nil {
+		return boltError(ErrorCodePermission, fmt.Sprintf("failed to delete cached item '%s'", key), err)
+	}
+	return nil
+}
+
+// saveStats writes the hit and miss counts to the meta bucket. The caller holds the lock.
+func (c *BoltManager[T]) saveStats() error {
+	raw, err := json.Marshal(c.stats)
+	if err != nil {
+		return err
+	}
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltMetaBucket).Put(boltStatsKey, raw)
+	})
+}
+
+// evictLRU removes the least recently used items until incoming more bytes fit in the
+// size limit. It runs inside the caller's write transaction.
+func (c *BoltManager[T]) evictLRU(bucket *bolt.Bucket, incoming int64) error {
+	type sized struct {
+		key        string
+		size       int64
+		accessedAt time.Time
+	}
+
+	var entries []sized
+	totalSize := incoming
+	err := forEachBoltEntry(bucket, func(key string, entry *boltEntry) error {
+		entries = append(entries, sized{key: key, size: int64(len(entry.Data)), accessedAt: entry.AccessedAt})
+		totalSize += int64(len(entry.Data))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	spaceNeeded := totalSize - c.config.SizeLimitMB*1024*1024
+	if spaceNeeded <= 0 {
+		return nil
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].accessedAt.Before(entries[j].accessedAt)
+	})
+	for _, entry := range entries {
+		if spaceNeeded <= 0 {
+			break
+		}
+		if err := bucket.Delete([]byte(entry.key)); err != nil {
+			return err
+		}
+		spaceNeeded -= entry.size
+		c.logger.Debug("evicted item from bbolt cache", "key", entry.key)
+	}
+	return nil
+}
+
+func readBoltEntry(bucket *bolt.Bucket, key string) (*boltEntry, error) {
+	raw := bucket.Get([]byte(key))
+	if raw == nil {
+		return nil, nil
+	}
+	var entry boltEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func writeBoltEntry(bucket *bolt.Bucket, key string, entry *boltEntry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(key), raw)
+}
+
+func forEachBoltEntry(bucket *bolt.Bucket, fn func(key string, entry *boltEntry) error) error {
+	return bucket.ForEach(func(k, v []byte) error {
+		var entry boltEntry
+		if err := json.Unmarshal(v, &entry); err != nil {
+			return err
+		}
+		return fn(string(k), &entry)
+	})
+}
+
+func boltError(code ErrorCode, message string, err error) error {
+	return &Error{
+		Code:    code,
+		Message: message,
+		Details: err.Error(),
+	}
+}
+
+func (e *boltEntry) expired() bool {
+	return e.TTL > 0 && time.Since(e.CreatedAt) > e.TTL
+}
@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBoltManager(basePath string, sizeLimitMB int64) *BoltManager[TestData] {
+	config := Config{
+		BasePath:    basePath,
+		SizeLimitMB: sizeLimitMB,
+		DefaultTTL:  time.Hour,
+		Backend:     BackendBolt,
+	}
+	return NewBoltManager(config, logging.NewBasic(), NewJSONSerializer[TestData]())
+}
+
+func TestBoltManager_Open(t *testing.T) {
+	basePath := filepath.Join(t.TempDir(), "cache")
+	manager := newTestBoltManager(basePath, 10)
+	ctx := context.Background()
+
+	_, err := os.Stat(filepath.Join(basePath, boltFileName))
+	assert.True(t, os.IsNotExist(err), "the database is opened on first use")
+
+	keys, err := manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+	assert.FileExists(t, filepath.Join(basePath, boltFileName))
+	require.NoError(t, manager.Close())
+
+	blocker := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(blocker, nil, 0644))
+	_, err = newTestBoltManager(blocker, 10).Keys(ctx)
+	var cacheErr *Error
+	require.ErrorAs(t, err, &cacheErr)
+	assert.Equal(t, ErrorCodePermission, cacheErr.Code)
+}
+
+func TestBoltManager_PutAndGet(t *testing.T) {
+	manager := newTestBoltManager(t.TempDir(), 10)
+	defer manager.Close()
+	ctx := context.Background()
+
+	require.NoError(t, manager.Put(ctx, "key", TestData{Name: "test", Value: 42}, PutOptions{}))
+
+	entry, err := manager.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, TestData{Name: "test", Value: 42}, entry.Data)
+	assert.True(t, entry.Cached)
+	assert.True(t, strings.HasPrefix(entry.Checksum, "sha256:"))
+
+	require.NoError(t, manager.Put(ctx, "key", TestData{Name: "updated"}, PutOptions{Checksum: "sha256:abc"}))
+	entry, err = manager.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", entry.Data.Name)
+	assert.Equal(t, "sha256:abc", entry.Checksum)
+
+	_, err = manager.Get(ctx, "missing")
+	var cacheErr *Error
+	require.ErrorAs(t, err, &cacheErr)
+	assert.Equal(t, ErrorCodeNotFound, cacheErr.Code)
+
+	assert.Error(t, manager.Put(ctx, "", TestData{}, PutOptions{}))
+
+	info, err := manager.GetInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, info.EntryCount)
+	assert.Equal(t, int64(2), info.HitCount)
+	assert.Equal(t, int64(1), info.MissCount)
+}
+
+func TestBoltManager_Delete(t *testing.T) {
+	manager := newTestBoltManager(t.TempDir(), 10)
+	defer manager.Close()
+	ctx := context.Background()
+
+	for _, key := range []string{"b", "a", "c"} {
+		require.NoError(t, manager.Put(ctx, key, TestData{Name: key}, PutOptions{}))
+	}
+
+	require.NoError(t, manager.Delete(ctx, "b"))
+	require.NoError(t, manager.Delete(ctx, "missing"), "deleting a missing key is not an error")
+	_, err := manager.Get(ctx, "b")
+	assert.Error(t, err)
+
+	keys, err := manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, keys)
+
+	require.NoError(t, manager.Clear(ctx))
+	keys, err = manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestBoltManager_Close(t *testing.T) {
+	basePath := t.TempDir()
+	ctx := context.Background()
+
+	manager := newTestBoltManager(basePath, 10)
+	require.NoError(t, manager.Close(), "closing an unopened manager is a no-op")
+	require.NoError(t, manager.Put(ctx, "key", TestData{Name: "kept"}, PutOptions{TTL: NoExpiration}))
+	_, err := manager.Get(ctx, "key")
+	require.NoError(t, err)
+	require.NoError(t, manager.Close())
+	require.NoError(t, manager.Close(), "closing twice is a no-op")
+
+	reopened := newTestBoltManager(basePath, 10)
+	defer reopened.Close()
+
+	entry, err := reopened.Get(ctx, "key")
+	require.NoError(t, err, "entries survive closing the database")
+	assert.Equal(t, "kept", entry.Data.Name)
+
+	info, err := reopened.GetInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), info.HitCount, "hit counts are saved on close")
+}
+
+func TestBoltManager_TTL(t *testing.T) {
+	manager := newTestBoltManager(t.TempDir(), 10)
+	defer manager.Close()
+	ctx := context.Background()
+
+	require.NoError(t, manager.Put(ctx, "short", TestData{Name: "short"}, PutOptions{TTL: 10 * time.Millisecond}))
+	require.NoError(t, manager.Put(ctx, "forever", TestData{Name: "forever"}, PutOptions{TTL: NoExpiration}))
+	time.Sleep(20 * time.Millisecond)
+
+	keys, err := manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"forever"}, keys)
+
+	_, err = manager.Get(ctx, "short")
+	assert.Error(t, err, "expired entries are not returned")
+
+	require.NoError(t, manager.Cleanup(ctx))
+	info, err := manager.GetInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, info.EntryCount)
+}
+
+func TestBoltManager_EvictsLeastRecentlyUsed(t *testing.T) {
+	manager := newTestBoltManager(t.TempDir(), 1)
+	defer manager.Close()
+	ctx := context.Background()
+	large := TestData{Name: strings.Repeat("x", 400*1024)}
+
+	require.NoError(t, manager.Put(ctx, "first", large, PutOptions{}))
+	time.Sleep(time.Millisecond)
+	require.NoError(t, manager.Put(ctx, "second", large, PutOptions{}))
+	time.Sleep(time.Millisecond)
+
+	// Reading "first" makes "second" the least recently used
+	_, err := manager.Get(ctx, "first")
+	require.NoError(t, err)
+	require.NoError(t, manager.Put(ctx, "third", large, PutOptions{}))
+
+	keys, err := manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "third"}, keys)
+}
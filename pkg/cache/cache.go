@@ -3,10 +3,14 @@ package cache
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/daddia/zen/internal/config"
 	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/dirs"
 	"github.com/go-viper/mapstructure/v2"
 )
 
@@ -24,6 +28,9 @@ type Manager[T any] interface {
 	// Clear removes all cached items
 	Clear(ctx context.Context) error
 
+	// Keys returns the keys of all unexpired items, in sorted order
+	Keys(ctx context.Context) ([]string, error)
+
 	// GetInfo returns cache information
 	GetInfo(ctx context.Context) (*Info, error)
 
@@ -43,9 +50,12 @@ type Entry[T any] struct {
 	Size     int64  `json:"size"`      // Size in bytes
 }
 
+// NoExpiration is a TTL for items that are kept until deleted or evicted to make space
+const NoExpiration time.Duration = -1
+
 // PutOptions represents options for storing items in cache
 type PutOptions struct {
-	TTL      time.Duration `json:"ttl,omitempty"`      // Time to live (0 = use default)
+	TTL      time.Duration `json:"ttl,omitempty"`      // Time to live (0 = use default, NoExpiration = never)
 	Checksum string        `json:"checksum,omitempty"` // Content checksum for integrity
 }
 
@@ -59,19 +69,46 @@ type Info struct {
 	CacheHitRatio float64   `json:"cache_hit_ratio"` // Hit ratio (0.0 - 1.0)
 }
 
+// Storage backends
+const (
+	// BackendFile stores items as files under the base path, and keeps them across runs
+	BackendFile = "file"
+	// BackendMemory keeps items in memory for the life of the manager
+	BackendMemory = "memory"
+	// BackendBolt stores items in a bbolt database under the base path, and keeps them across runs
+	BackendBolt = "bolt"
+)
+
+// Backends lists the supported storage backends
+var Backends = []string{BackendFile, BackendMemory, BackendBolt}
+
 // Config represents cache configuration
 type Config struct {
+	Backend           string        `yaml:"backend" json:"backend"` // Storage backend ("" = file)
 	BasePath          string        `yaml:"base_path" json:"base_path"`
 	SizeLimitMB       int64         `yaml:"size_limit_mb" json:"size_limit_mb"`
 	DefaultTTL        time.Duration `yaml:"default_ttl" json:"default_ttl"`
 	CleanupInterval   time.Duration `yaml:"cleanup_interval" json:"cleanup_interval"`
 	EnableCompression bool          `yaml:"enable_compression" json:"enable_compression"`
+
+	// Encrypt lists the namespaces whose entries are encrypted at rest
+	Encrypt []string `yaml:"encrypt" json:"encrypt" mapstructure:"encrypt"`
+
+	// Encrypted encrypts this cache's entries with the key from KeySource. Namespace sets
+	// it for the namespaces listed in Encrypt.
+	Encrypted bool      `yaml:"-" json:"-" mapstructure:"-"`
+	KeySource KeySource `yaml:"-" json:"-" mapstructure:"-"`
+
+	// Store keeps the contents of the file backend in a content-addressed store shared
+	// with other caches, which the cache links to
+	Store *ObjectStore `yaml:"-" json:"-" mapstructure:"-"`
 }
 
 // DefaultConfig returns default cache configuration
 func DefaultConfig() Config {
 	return Config{
-		BasePath:          "~/.zen/cache",
+		Backend:           BackendFile,
+		BasePath:          dirs.CachePath(),
 		SizeLimitMB:       100,
 		DefaultTTL:        24 * time.Hour,
 		CleanupInterval:   1 * time.Hour,
@@ -79,17 +116,30 @@ func DefaultConfig() Config {
 	}
 }
 
+// Namespace returns the configuration of a namespace of the cache. Namespaces are
+// stored under their own directory of the base path, so their keys never collide and
+// clearing one leaves the others in place. Entries of the namespaces listed in Encrypt
+// are encrypted.
+func (c Config) Namespace(name string) Config {
+	c.BasePath = filepath.Join(c.BasePath, sanitizeFileName(name))
+	c.Encrypted = c.Encrypted || slices.Contains(c.Encrypt, name)
+	return c
+}
+
 // Implement config.Configurable interface
 
 // Validate validates the cache configuration
 func (c Config) Validate() error {
+	if c.Backend != "" && !slices.Contains(Backends, c.Backend) {
+		return fmt.Errorf("backend must be one of: %s", strings.Join(Backends, ", "))
+	}
 	if c.BasePath == "" {
 		return fmt.Errorf("base_path is required")
 	}
 	if c.SizeLimitMB <= 0 {
 		return fmt.Errorf("size_limit_mb must be positive")
 	}
-	if c.DefaultTTL <= 0 {
+	if c.DefaultTTL <= 0 && c.DefaultTTL != NoExpiration {
 		return fmt.Errorf("default_ttl must be positive")
 	}
 	if c.CleanupInterval <= 0 {
@@ -108,7 +158,8 @@ type ConfigParser struct{}
 
 // Parse converts raw configuration data to Config
 func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
-	var cfg Config
+	// Start with defaults so that settings such as encrypt can be given on their own
+	cfg := DefaultConfig()
 
 	// Use mapstructure to decode the raw map into our config struct
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
@@ -157,7 +208,20 @@ const (
 	ErrorCodeSerialization ErrorCode = "serialization"
 )
 
-// NewManager creates a new cache manager with the specified configuration
+// NewManager creates a new cache manager with the specified configuration, using the
+// file backend unless config.Backend names another one. Entries are encrypted when
+// config.Encrypted is set.
 func NewManager[T any](config Config, logger logging.Logger, serializer Serializer[T]) Manager[T] {
-	return NewFileManager(config, logger, serializer)
+	if config.Encrypted {
+		serializer = NewEncryptedSerializer(serializer, config.KeySource)
+	}
+
+	switch config.Backend {
+	case BackendMemory:
+		return NewMemoryManager(config, logger, serializer)
+	case BackendBolt:
+		return NewBoltManager(config, logger, serializer)
+	default:
+		return NewFileManager(config, logger, serializer)
+	}
 }
@@ -12,9 +12,10 @@ import (
 )
 
 func TestDefaultConfig(t *testing.T) {
+	t.Setenv("ZEN_CACHE_DIR", "/var/cache/zen")
 	config := DefaultConfig()
 
-	assert.Equal(t, "~/.zen/cache", config.BasePath)
+	assert.Equal(t, "/var/cache/zen", config.BasePath)
 	assert.Equal(t, int64(100), config.SizeLimitMB)
 	assert.Equal(t, 24*time.Hour, config.DefaultTTL)
 	assert.Equal(t, 1*time.Hour, config.CleanupInterval)
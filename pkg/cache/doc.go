@@ -0,0 +1,34 @@
+// Package cache provides a typed key-value cache with expiring entries, a size limit,
+// and pluggable storage.
+//
+// A Manager stores values of one type, converted to bytes by a Serializer:
+//
+//	cfg := cache.DefaultConfig().Namespace("sync_records")
+//	records := cache.NewManager(cfg, logger, cache.NewJSONSerializer[*Record]())
+//
+//	err := records.Put(ctx, "PROJ-123", record, cache.PutOptions{TTL: cache.NoExpiration})
+//	entry, err := records.Get(ctx, "PROJ-123")
+//	keys, err := records.Keys(ctx)
+//
+// Storage is chosen by Config.Backend. BackendFile, the default, keeps each item in a
+// file under Config.BasePath with an index in metadata/index.json, so items survive
+// between runs. BackendBolt keeps items in a bbolt database at cache.db under
+// Config.BasePath, also across runs, and writes each change in a transaction; the
+// database is held open from first use until Close. BackendMemory keeps items in the
+// manager itself.
+//
+// Entries expire after their TTL: PutOptions.TTL, or Config.DefaultTTL when that is
+// zero. NoExpiration keeps an entry until it is deleted, or evicted when the cache
+// grows past Config.SizeLimitMB. The least recently used entries are evicted first.
+//
+// Config.Namespace gives a part of the application its own cache under the same base
+// path, so that keys from different parts never collide and Clear only removes the
+// namespace's own entries.
+//
+// Entries can be encrypted at rest with AES-GCM by setting Config.Encrypted and a
+// KeySource, or by listing a namespace in Config.Encrypt, which is read from the
+// "cache.encrypt" setting. Encryption is transparent to callers of the Manager; entries
+// that cannot be decrypted are treated as missing.
+//
+// Get returns an *Error with ErrorCodeNotFound for missing and expired keys.
+package cache
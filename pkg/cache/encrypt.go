@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// KeySize is the length of the keys that encrypt cache entries, selecting AES-256
+const KeySize = 32
+
+// encryptedPrefix marks serialized entries that are encrypted
+var encryptedPrefix = []byte("zenc1:")
+
+// KeySource provides the key that encrypts cache entries at rest
+type KeySource interface {
+	// CacheKey returns a key of KeySize bytes
+	CacheKey(ctx context.Context) ([]byte, error)
+}
+
+// ErrKeyUnavailable is returned when entries cannot be encrypted or decrypted because the
+// key could not be obtained. Entries are kept, since they are still valid.
+var ErrKeyUnavailable = errors.New("cache encryption key unavailable")
+
+// StaticKey is a KeySource that always returns the same key
+type StaticKey []byte
+
+// CacheKey implements KeySource
+func (k StaticKey) CacheKey(ctx context.Context) ([]byte, error) {
+	return k, nil
+}
+
+// EncryptedSerializer encrypts the output of another serializer with AES-GCM, so entries
+// are unreadable on disk without the key. The key is requested from the KeySource the
+// first time an entry is stored or read.
+type EncryptedSerializer[T any] struct {
+	inner Serializer[T]
+	keys  KeySource
+
+	once sync.Once
+	aead cipher.AEAD
+	err  error
+}
+
+// NewEncryptedSerializer creates a serializer that encrypts the output of inner
+func NewEncryptedSerializer[T any](inner Serializer[T], keys KeySource) *EncryptedSerializer[T] {
+	return &EncryptedSerializer[T]{inner: inner, keys: keys}
+}
+
+// Serialize converts data to bytes with the inner serializer and encrypts them
+func (s *EncryptedSerializer[T]) Serialize(data T) ([]byte, error) {
+	aead, err := s.cipher()
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := s.inner.Serialize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := append(bytes.Clone(encryptedPrefix), nonce...)
+	return aead.Seal(out, nonce, plaintext, encryptedPrefix), nil
+}
+
+// Deserialize decrypts data and converts it back with the inner serializer. Entries that
+// were stored unencrypted, or with another key, fail to deserialize.
+func (s *EncryptedSerializer[T]) Deserialize(data []byte) (T, error) {
+	var zero T
+
+	aead, err := s.cipher()
+	if err != nil {
+		return zero, err
+	}
+
+	if !bytes.HasPrefix(data, encryptedPrefix) {
+		return zero, errors.New("cache entry is not encrypted")
+	}
+	data = data[len(encryptedPrefix):]
+	if len(data) < aead.NonceSize() {
+		return zero, errors.New("encrypted cache entry is truncated")
+	}
+
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], encryptedPrefix)
+	if err != nil {
+		return zero, fmt.Errorf("failed to decrypt cache entry: %w", err)
+	}
+
+	return s.inner.Deserialize(plaintext)
+}
+
+// ContentType returns the content type of encrypted entries
+func (s *EncryptedSerializer[T]) ContentType() string {
+	return "application/octet-stream"
+}
+
+// cipher returns the AEAD for the source's key, requesting the key on first use
+func (s *EncryptedSerializer[T]) cipher() (cipher.AEAD, error) {
+	s.once.Do(func() {
+		if s.keys == nil {
+			s.err = fmt.Errorf("%w: no key source is configured", ErrKeyUnavailable)
+			return
+		}
+
+		key, err := s.keys.CacheKey(context.Background())
+		if err != nil {
+			s.err = fmt.Errorf("%w: %v", ErrKeyUnavailable, err)
+			return
+		}
+		if len(key) != KeySize {
+			s.err = fmt.Errorf("%w: key must be %d bytes, got %d", ErrKeyUnavailable, KeySize, len(key))
+			return
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			s.err = err
+			return
+		}
+		s.aead, s.err = cipher.NewGCM(block)
+	})
+	return s.aead, s.err
+}
+
+// keyUnavailableError reports a cached item that could not be decrypted for want of the key
+func keyUnavailableError(key string, err error) *Error {
+	return &Error{
+		Code:    ErrorCodePermission,
+		Message: fmt.Sprintf("cannot decrypt cached item '%s'", key),
+		Details: err.Error(),
+	}
+}
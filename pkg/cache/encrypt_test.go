@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingKeySource struct{}
+
+func (failingKeySource) CacheKey(ctx context.Context) ([]byte, error) {
+	return nil, errors.New("keychain locked")
+}
+
+func TestEncryptedSerializer(t *testing.T) {
+	key := StaticKey(bytes.Repeat([]byte{1}, KeySize))
+	serializer := NewEncryptedSerializer[TestData](NewJSONSerializer[TestData](), key)
+
+	data, err := serializer.Serialize(TestData{Name: "secret", Value: 7})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	decoded, err := serializer.Deserialize(data)
+	require.NoError(t, err)
+	assert.Equal(t, TestData{Name: "secret", Value: 7}, decoded)
+
+	other := NewEncryptedSerializer[TestData](NewJSONSerializer[TestData](), StaticKey(bytes.Repeat([]byte{2}, KeySize)))
+	_, err = other.Deserialize(data)
+	assert.Error(t, err, "another key cannot decrypt the entry")
+
+	_, err = serializer.Deserialize([]byte(`{"name":"plain"}`))
+	assert.Error(t, err, "unencrypted entries are rejected")
+
+	short := NewEncryptedSerializer[TestData](NewJSONSerializer[TestData](), StaticKey("short"))
+	_, err = short.Serialize(TestData{})
+	assert.ErrorIs(t, err, ErrKeyUnavailable)
+
+	missing := NewEncryptedSerializer[TestData](NewJSONSerializer[TestData](), nil)
+	_, err = missing.Serialize(TestData{})
+	assert.ErrorIs(t, err, ErrKeyUnavailable)
+}
+
+func TestManager_EncryptedNamespace(t *testing.T) {
+	logger := logging.NewBasic()
+	ctx := context.Background()
+	config := Config{
+		BasePath:    t.TempDir(),
+		SizeLimitMB: 10,
+		DefaultTTL:  time.Hour,
+		Encrypt:     []string{"sync_records"},
+		KeySource:   StaticKey(bytes.Repeat([]byte{1}, KeySize)),
+	}
+
+	records := config.Namespace("sync_records")
+	assert.True(t, records.Encrypted)
+	assert.False(t, config.Namespace("drafts").Encrypted)
+
+	manager := NewManager(records, logger, NewJSONSerializer[TestData]())
+	require.NoError(t, manager.Put(ctx, "PROJ-1", TestData{Name: "confidential"}, PutOptions{}))
+	require.NoError(t, manager.Close())
+
+	files, err := filepath.Glob(filepath.Join(records.BasePath, "content", "*"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	raw, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "confidential", "entries are encrypted on disk")
+
+	manager = NewManager(records, logger, NewJSONSerializer[TestData]())
+	entry, err := manager.Get(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "confidential", entry.Data.Name)
+
+	// Without the key, entries are reported but kept
+	records.KeySource = failingKeySource{}
+	locked := NewManager(records, logger, NewJSONSerializer[TestData]())
+	_, err = locked.Get(ctx, "PROJ-1")
+	var cacheErr *Error
+	require.ErrorAs(t, err, &cacheErr)
+	assert.Equal(t, ErrorCodePermission, cacheErr.Code)
+
+	keys, err := locked.Keys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PROJ-1"}, keys)
+}
+
+func TestConfigParser_Encrypt(t *testing.T) {
+	config, err := ConfigParser{}.Parse(map[string]interface{}{
+		"encrypt": []interface{}{"sync_records", "assets"},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, config.Validate())
+	assert.Equal(t, []string{"sync_records", "assets"}, config.Encrypt)
+	assert.Equal(t, DefaultConfig().BasePath, config.BasePath)
+}
@@ -132,6 +132,9 @@ func (c *FileManager[T]) Get(ctx context.Context, key string) (*Entry[T], error)
 
 	// Deserialize data
 	deserializedData, err := c.serializer.Deserialize(data)
+	if errors.Is(err, ErrKeyUnavailable) {
+		return nil, keyUnavailableError(key, err)
+	}
 	if err != nil {
 		c.logger.Warn("failed to deserialize cached data", "key", key, "error", err)
 		c.Delete(ctx, key) // Clean up corrupted entry
@@ -208,7 +211,7 @@ func (c *FileManager[T]) Put(ctx context.Context, key string, data T, opts PutOp
 	filePath := filepath.Join(contentDir, fileName)
 
 	// Write content to file
-	if err := os.WriteFile(filePath, serializedData, 0600); err != nil {
+	if err := c.writeContent(filePath, serializedData); err != nil {
 		return &Error{
 			Code:    ErrorCodePermission,
 			Message: fmt.Sprintf("failed to write cache file for key '%s'", key),
@@ -264,6 +267,30 @@ func (c *FileManager[T]) Put(ctx context.Context, key string, data T, opts PutOp
 	return nil
 }
 
+// writeContent writes the content of an entry to filePath, or links filePath to the
+// content in the shared store when there is one. The file is replaced rather than
+// written over, as it may be a link to an object of the store.
+func (c *FileManager[T]) writeContent(filePath string, data []byte) error {
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if c.config.Store != nil {
+		digest, err := c.config.Store.Put(data)
+		if err != nil {
+			return err
+		}
+		mode, err := c.config.Store.Link(digest, filePath)
+		if err != nil {
+			return err
+		}
+		c.logger.Debug("linked cache file to shared store", "path", filePath, "digest", digest, "mode", mode)
+		return nil
+	}
+
+	return os.WriteFile(filePath, data, 0600)
+}
+
 // Delete removes an item from cache
 func (c *FileManager[T]) Delete(ctx context.Context, key string) error {
 	c.mu.Lock()
@@ -392,6 +419,21 @@ func (c *FileManager[T]) Cleanup(ctx context.Context) error {
 	return nil
 }
 
+// Keys returns the keys of all unexpired items, in sorted order
+func (c *FileManager[T]) Keys(ctx context.Context) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]string, 0, len(c.index))
+	for key, entry := range c.index {
+		if !c.isExpired(entry) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
 // Close cleans up cache resources
 func (c *FileManager[T]) Close() error {
 	c.logger.Debug("closing cache manager")
@@ -543,6 +585,12 @@ func (c *FileManager[T]) calculateTotalSize() int64 {
 }
 
 func (c *FileManager[T]) sanitizeFileName(name string) string {
+	return sanitizeFileName(name)
+}
+
+// sanitizeFileName turns a key into a file name without path separators or characters
+// that are invalid on some file systems
+func sanitizeFileName(name string) string {
 	// Replace problematic characters with underscores
 	sanitized := name
 	problematicChars := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|"}
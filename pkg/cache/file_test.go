@@ -693,3 +693,33 @@ func TestFileManager_LoadIndexNoEntries(t *testing.T) {
 	assert.NotNil(t, manager.index)
 	assert.Equal(t, 0, len(manager.index))
 }
+
+func TestFileManager_Keys(t *testing.T) {
+	config := Config{
+		BasePath:    t.TempDir(),
+		SizeLimitMB: 10,
+		DefaultTTL:  time.Hour,
+	}
+	logger := logging.NewBasic()
+	serializer := NewJSONSerializer[TestData]()
+	manager := NewFileManager(config, logger, serializer)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Put(ctx, "beta", TestData{Name: "beta"}, PutOptions{}))
+	require.NoError(t, manager.Put(ctx, "alpha", TestData{Name: "alpha"}, PutOptions{TTL: NoExpiration}))
+	require.NoError(t, manager.Put(ctx, "expired", TestData{Name: "expired"}, PutOptions{TTL: time.Second}))
+	manager.index["expired"].CreatedAt = time.Now().Add(-time.Minute)
+
+	keys, err := manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alpha", "beta"}, keys)
+	require.NoError(t, manager.Close())
+
+	// Keys are read back from the index by a new instance
+	reopened := NewFileManager(config, logger, serializer)
+	defer reopened.Close()
+	keys, err = reopened.Keys(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, keys, "alpha")
+	assert.Contains(t, keys, "beta")
+}
@@ -0,0 +1,248 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+)
+
+// MemoryManager implements Manager in memory. Items are stored serialized, so callers
+// never share data with the cache, and are lost when the process exits. It suits tests,
+// and runs such as CI jobs where nothing outlives the process.
+type MemoryManager[T any] struct {
+	config     Config
+	logger     logging.Logger
+	serializer Serializer[T]
+
+	mu      sync.RWMutex
+	entries map[string]*memoryEntry
+	stats   cacheStats
+}
+
+type memoryEntry struct {
+	data       []byte
+	checksum   string
+	createdAt  time.Time
+	accessedAt time.Time
+	ttl        time.Duration // 0 = no expiration
+}
+
+// NewMemoryManager creates a new in-memory cache manager. The base path is not used.
+func NewMemoryManager[T any](config Config, logger logging.Logger, serializer Serializer[T]) *MemoryManager[T] {
+	return &MemoryManager[T]{
+		config:     config,
+		logger:     logger,
+		serializer: serializer,
+		entries:    make(map[string]*memoryEntry),
+		stats:      cacheStats{LastCleanup: time.Now()},
+	}
+}
+
+// Get retrieves an item from cache
+func (c *MemoryManager[T]) Get(ctx context.Context, key string) (*Entry[T], error) {
+	c.mu.Lock()
+	entry, exists := c.entries[key]
+	if exists && entry.expired() {
+		delete(c.entries, key)
+		exists = false
+	}
+	if !exists {
+		c.stats.MissCount++
+		c.mu.Unlock()
+		return nil, &Error{
+			Code:    ErrorCodeNotFound,
+			Message: fmt.Sprintf("key '%s' not found in cache", key),
+		}
+	}
+	entry.accessedAt = time.Now()
+	c.stats.HitCount++
+	c.mu.Unlock()
+
+	data, err := c.serializer.Deserialize(entry.data)
+	if errors.Is(err, ErrKeyUnavailable) {
+		return nil, keyUnavailableError(key, err)
+	}
+	if err != nil {
+		_ = c.Delete(ctx, key)
+		return nil, &Error{
+			Code:    ErrorCodeSerialization,
+			Message: fmt.Sprintf("failed to deserialize cached item '%s'", key),
+			Details: err.Error(),
+		}
+	}
+
+	return &Entry[T]{
+		Data:     data,
+		Checksum: entry.checksum,
+		Cached:   true,
+		CacheAge: int64(time.Since(entry.createdAt).Seconds()),
+		Size:     int64(len(entry.data)),
+	}, nil
+}
+
+// Put stores an item in cache
+func (c *MemoryManager[T]) Put(ctx context.Context, key string, data T, opts PutOptions) error {
+	if key == "" {
+		return &Error{
+			Code:    ErrorCodeInvalidKey,
+			Message: "cache key cannot be empty",
+		}
+	}
+
+	serialized, err := c.serializer.Serialize(data)
+	if err != nil {
+		return &Error{
+			Code:    ErrorCodeSerialization,
+			Message: fmt.Sprintf("failed to serialize data for key '%s'", key),
+			Details: err.Error(),
+		}
+	}
+
+	checksum := opts.Checksum
+	if checksum == "" {
+		checksum = fmt.Sprintf("sha256:%x", sha256.Sum256(serialized))
+	}
+
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = c.config.DefaultTTL
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	c.evictLRU(c.totalSize() + int64(len(serialized)) - c.config.SizeLimitMB*1024*1024)
+	c.entries[key] = &memoryEntry{
+		data:       serialized,
+		checksum:   checksum,
+		createdAt:  now,
+		accessedAt: now,
+		ttl:        ttl,
+	}
+
+	c.logger.Debug("item cached in memory", "key", key, "size", len(serialized))
+	return nil
+}
+
+// Delete removes an item from cache
+func (c *MemoryManager[T]) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	return nil
+}
+
+// Clear removes all cached items
+func (c *MemoryManager[T]) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*memoryEntry)
+	c.stats = cacheStats{LastCleanup: time.Now()}
+	return nil
+}
+
+// Keys returns the keys of all unexpired items, in sorted order
+func (c *MemoryManager[T]) Keys(ctx context.Context) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]string, 0, len(c.entries))
+	for key, entry := range c.entries {
+		if !entry.expired() {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// GetInfo returns cache information
+func (c *MemoryManager[T]) GetInfo(ctx context.Context) (*Info, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var hitRatio float64
+	if total := c.stats.HitCount + c.stats.MissCount; total > 0 {
+		hitRatio = float64(c.stats.HitCount) / float64(total)
+	}
+
+	return &Info{
+		TotalSize:     c.totalSize(),
+		EntryCount:    len(c.entries),
+		LastCleanup:   c.stats.LastCleanup,
+		HitCount:      c.stats.HitCount,
+		MissCount:     c.stats.MissCount,
+		CacheHitRatio: hitRatio,
+	}, nil
+}
+
+// Cleanup removes expired items and evicts the least recently used ones over the size limit
+func (c *MemoryManager[T]) Cleanup(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if entry.expired() {
+			delete(c.entries, key)
+		}
+	}
+	c.evictLRU(c.totalSize() - c.config.SizeLimitMB*1024*1024)
+	c.stats.LastCleanup = time.Now()
+	return nil
+}
+
+// Close cleans up cache resources
+func (c *MemoryManager[T]) Close() error {
+	return nil
+}
+
+// evictLRU removes the least recently used items until spaceNeeded bytes are freed.
+// The caller holds the lock.
+func (c *MemoryManager[T]) evictLRU(spaceNeeded int64) {
+	if spaceNeeded <= 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].accessedAt.Before(c.entries[keys[j]].accessedAt)
+	})
+
+	for _, key := range keys {
+		if spaceNeeded <= 0 {
+			break
+		}
+		spaceNeeded -= int64(len(c.entries[key].data))
+		delete(c.entries, key)
+		c.logger.Debug("evicted item from memory cache", "key", key)
+	}
+}
+
+// totalSize returns the size of all items. The caller holds the lock.
+func (c *MemoryManager[T]) totalSize() int64 {
+	var total int64
+	for _, entry := range c.entries {
+		total += int64(len(entry.data))
+	}
+	return total
+}
+
+func (e *memoryEntry) expired() bool {
+	return e.ttl > 0 && time.Since(e.createdAt) > e.ttl
+}
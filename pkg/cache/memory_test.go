@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemoryManager(sizeLimitMB int64) *MemoryManager[TestData] {
+	config := Config{
+		SizeLimitMB: sizeLimitMB,
+		DefaultTTL:  time.Hour,
+		Backend:     BackendMemory,
+	}
+	return NewMemoryManager(config, logging.NewBasic(), NewJSONSerializer[TestData]())
+}
+
+func TestMemoryManager_PutAndGet(t *testing.T) {
+	manager := newTestMemoryManager(10)
+	defer manager.Close()
+	ctx := context.Background()
+
+	require.NoError(t, manager.Put(ctx, "key", TestData{Name: "test", Value: 42}, PutOptions{}))
+
+	entry, err := manager.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, TestData{Name: "test", Value: 42}, entry.Data)
+	assert.True(t, entry.Cached)
+	assert.True(t, strings.HasPrefix(entry.Checksum, "sha256:"))
+
+	_, err = manager.Get(ctx, "missing")
+	var cacheErr *Error
+	require.ErrorAs(t, err, &cacheErr)
+	assert.Equal(t, ErrorCodeNotFound, cacheErr.Code)
+
+	assert.Error(t, manager.Put(ctx, "", TestData{}, PutOptions{}))
+
+	info, err := manager.GetInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, info.EntryCount)
+	assert.Equal(t, int64(1), info.HitCount)
+	assert.Equal(t, int64(1), info.MissCount)
+}
+
+func TestMemoryManager_TTL(t *testing.T) {
+	manager := newTestMemoryManager(10)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Put(ctx, "short", TestData{Name: "short"}, PutOptions{TTL: 10 * time.Millisecond}))
+	require.NoError(t, manager.Put(ctx, "forever", TestData{Name: "forever"}, PutOptions{TTL: NoExpiration}))
+	time.Sleep(20 * time.Millisecond)
+
+	_, err := manager.Get(ctx, "short")
+	assert.Error(t, err, "expired entries are not returned")
+
+	entry, err := manager.Get(ctx, "forever")
+	require.NoError(t, err)
+	assert.Equal(t, "forever", entry.Data.Name)
+}
+
+func TestMemoryManager_Keys(t *testing.T) {
+	manager := newTestMemoryManager(10)
+	ctx := context.Background()
+
+	for _, key := range []string{"b", "a", "c"} {
+		require.NoError(t, manager.Put(ctx, key, TestData{Name: key}, PutOptions{}))
+	}
+	require.NoError(t, manager.Put(ctx, "expired", TestData{}, PutOptions{TTL: time.Millisecond}))
+	time.Sleep(5 * time.Millisecond)
+
+	keys, err := manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+
+	require.NoError(t, manager.Delete(ctx, "b"))
+	keys, err = manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, keys)
+
+	require.NoError(t, manager.Clear(ctx))
+	keys, err = manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestMemoryManager_EvictsLeastRecentlyUsed(t *testing.T) {
+	manager := newTestMemoryManager(1)
+	ctx := context.Background()
+	large := TestData{Name: strings.Repeat("x", 400*1024)}
+
+	require.NoError(t, manager.Put(ctx, "first", large, PutOptions{}))
+	time.Sleep(time.Millisecond)
+	require.NoError(t, manager.Put(ctx, "second", large, PutOptions{}))
+	time.Sleep(time.Millisecond)
+
+	// Reading "first" makes "second" the least recently used
+	_, err := manager.Get(ctx, "first")
+	require.NoError(t, err)
+	require.NoError(t, manager.Put(ctx, "third", large, PutOptions{}))
+
+	keys, err := manager.Keys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "third"}, keys)
+}
+
+func TestNewManager_Backends(t *testing.T) {
+	logger := logging.NewBasic()
+	serializer := NewJSONSerializer[TestData]()
+	config := Config{BasePath: t.TempDir(), SizeLimitMB: 10, DefaultTTL: time.Hour}
+
+	assert.IsType(t, &FileManager[TestData]{}, NewManager(config, logger, serializer), "file is the default")
+
+	config.Backend = BackendMemory
+	assert.IsType(t, &MemoryManager[TestData]{}, NewManager(config, logger, serializer))
+
+	config.Backend = BackendBolt
+	assert.IsType(t, &BoltManager[TestData]{}, NewManager(config, logger, serializer))
+
+	assert.NoError(t, DefaultConfig().Validate())
+	config = DefaultConfig()
+	config.Backend = "redis"
+	assert.Error(t, config.Validate())
+	config.Backend = BackendMemory
+	config.DefaultTTL = NoExpiration
+	assert.NoError(t, config.Validate())
+}
+
+func TestConfig_Namespace(t *testing.T) {
+	logger := logging.NewBasic()
+	serializer := NewJSONSerializer[TestData]()
+	ctx := context.Background()
+	config := Config{BasePath: t.TempDir(), SizeLimitMB: 10, DefaultTTL: time.Hour}
+
+	assert.Equal(t, filepath.Join(config.BasePath, "sync-records"), config.Namespace("sync-records").BasePath)
+
+	records := NewManager(config.Namespace("records"), logger, serializer)
+	drafts := NewManager(config.Namespace("drafts"), logger, serializer)
+	defer records.Close()
+	defer drafts.Close()
+
+	require.NoError(t, records.Put(ctx, "key", TestData{Name: "record"}, PutOptions{}))
+	require.NoError(t, drafts.Put(ctx, "key", TestData{Name: "draft"}, PutOptions{}))
+	require.NoError(t, drafts.Clear(ctx))
+
+	entry, err := records.Get(ctx, "key")
+	require.NoError(t, err, "clearing one namespace leaves the others in place")
+	assert.Equal(t, "record", entry.Data.Name)
+
+	keys, err := drafts.Keys(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
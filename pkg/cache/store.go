@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// LinkMode is how a cache file refers to an object of an ObjectStore
+type LinkMode string
+
+const (
+	// LinkHard is a hard link, which survives the removal of the object
+	LinkHard LinkMode = "hardlink"
+	// LinkSymbolic is a symbolic link, used across file systems
+	LinkSymbolic LinkMode = "symlink"
+	// LinkCopy is a copy, used where links cannot be created
+	LinkCopy LinkMode = "copy"
+)
+
+// ObjectStore is a content-addressed store that file caches of several workspaces share.
+// Each content is kept once, under its SHA-256 digest, and caches link to it rather than
+// keeping copies of their own. Objects are read-only and never change once written, so
+// a cache can never alter the content of another through a link.
+//
+// Removing the store is safe: caches treat a dangling link as a missing entry.
+type ObjectStore struct {
+	root string
+}
+
+// NewObjectStore returns the object store kept in root
+func NewObjectStore(root string) *ObjectStore {
+	return &ObjectStore{root: root}
+}
+
+// Root returns the directory of the store
+func (s *ObjectStore) Root() string {
+	return s.root
+}
+
+// Path returns the file of the object with digest
+func (s *ObjectStore) Path(digest string) string {
+	if len(digest) < 2 {
+		return filepath.Join(s.root, "objects", digest)
+	}
+	return filepath.Join(s.root, "objects", digest[:2], digest)
+}
+
+// Put stores data and returns its digest. Data already in the store is not written again.
+func (s *ObjectStore) Put(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	path := s.Path(digest)
+
+	if _, err := os.Stat(path); err == nil {
+		return digest, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file and rename it into place, so that other processes never
+	// see a partial object
+	tmp := fmt.Sprintf("%s.%s.tmp", path, randomSuffix())
+	if err := os.WriteFile(tmp, data, objectPerm()); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+	return digest, nil
+}
+
+// Link makes path refer to the object with digest, replacing any file at path. It uses
+// a hard link where it can, and falls back to a symbolic link and then to a copy.
+func (s *ObjectStore) Link(digest, path string) (LinkMode, error) {
+	object := s.Path(digest)
+	if _, err := os.Stat(object); err != nil {
+		return "", err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if err := os.Link(object, path); err == nil {
+		return LinkHard, nil
+	}
+	if err := os.Symlink(object, path); err == nil {
+		return LinkSymbolic, nil
+	}
+	if err := copyObject(object, path); err != nil {
+		return "", err
+	}
+	return LinkCopy, nil
+}
+
+// objectPerm returns the permissions of objects: read-only, except on Windows, where
+// links to read-only files cannot be removed. Caches replace their files rather than
+// write to them, so objects are not changed through links there either.
+func objectPerm() os.FileMode {
+	if runtime.GOOS == "windows" {
+		return 0600
+	}
+	return 0400
+}
+
+func copyObject(object, path string) error {
+	in, err := os.Open(object) // #nosec G304 - path is an object of the store
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) // #nosec G304 - path is a cache file
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func randomSuffix() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectStore_Put(t *testing.T) {
+	store := NewObjectStore(t.TempDir())
+
+	digest, err := store.Put([]byte("template"))
+	require.NoError(t, err)
+	assert.Len(t, digest, 64)
+	assert.Equal(t, filepath.Join(store.Root(), "objects", digest[:2], digest), store.Path(digest))
+
+	info, err := os.Stat(store.Path(digest))
+	require.NoError(t, err)
+	assert.Equal(t, objectPerm(), info.Mode().Perm())
+
+	again, err := store.Put([]byte("template"))
+	require.NoError(t, err)
+	assert.Equal(t, digest, again)
+}
+
+func TestObjectStore_Link(t *testing.T) {
+	store := NewObjectStore(t.TempDir())
+	digest, err := store.Put([]byte("template"))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "entry.cache")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0600))
+
+	mode, err := store.Link(digest, path)
+	require.NoError(t, err)
+	assert.Equal(t, LinkHard, mode)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "template", string(content))
+
+	_, err = store.Link("missing", path)
+	assert.Error(t, err)
+}
+
+func TestFileManager_SharedStore(t *testing.T) {
+	store := NewObjectStore(t.TempDir())
+	ctx := context.Background()
+	newManager := func() *FileManager[string] {
+		config := Config{BasePath: t.TempDir(), SizeLimitMB: 10, DefaultTTL: time.Hour, Store: store}
+		return NewFileManager(config, logging.NewBasic(), NewStringSerializer())
+	}
+
+	first, second := newManager(), newManager()
+	require.NoError(t, first.Put(ctx, "template", "content", PutOptions{}))
+	require.NoError(t, second.Put(ctx, "template", "content", PutOptions{}))
+
+	// Both caches link to the one copy in the store
+	firstInfo, err := os.Stat(first.index["template"].Path)
+	require.NoError(t, err)
+	secondInfo, err := os.Stat(second.index["template"].Path)
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(firstInfo, secondInfo))
+
+	// Updating an entry of one cache leaves the other alone
+	require.NoError(t, first.Put(ctx, "template", "changed", PutOptions{}))
+	entry, err := second.Get(ctx, "template")
+	require.NoError(t, err)
+	assert.Equal(t, "content", entry.Data)
+
+	// Hard-linked entries outlive the store
+	require.NoError(t, os.RemoveAll(store.Root()))
+	entry, err = second.Get(ctx, "template")
+	require.NoError(t, err)
+	assert.Equal(t, "content", entry.Data)
+}
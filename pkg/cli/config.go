@@ -17,6 +17,12 @@ type Config struct {
 
 	// Output format (text, json, yaml)
 	OutputFormat string `yaml:"output_format" json:"output_format" mapstructure:"output_format"`
+
+	// Check for newer zen releases once a day and mention them after commands
+	UpdateCheck bool `yaml:"update_check" json:"update_check" mapstructure:"update_check"`
+
+	// Offline skips checks that need the network, such as the update check
+	Offline bool `yaml:"offline" json:"offline" mapstructure:"offline"`
 }
 
 // DefaultConfig returns default CLI configuration
@@ -25,6 +31,8 @@ func DefaultConfig() Config {
 		NoColor:      false,
 		Verbose:      false,
 		OutputFormat: "text",
+		UpdateCheck:  true,
+		Offline:      false,
 	}
 }
 
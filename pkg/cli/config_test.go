@@ -69,6 +69,8 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, false, config.NoColor)
 	assert.Equal(t, false, config.Verbose)
 	assert.Equal(t, "text", config.OutputFormat)
+	assert.Equal(t, true, config.UpdateCheck)
+	assert.Equal(t, false, config.Offline)
 
 	// Validate defaults
 	require.NoError(t, config.Validate())
@@ -89,6 +91,7 @@ func TestConfigParser_Parse(t *testing.T) {
 				NoColor:      false,
 				Verbose:      false,
 				OutputFormat: "text",
+				UpdateCheck:  true,
 			},
 		},
 		{
@@ -97,11 +100,14 @@ func TestConfigParser_Parse(t *testing.T) {
 				"no_color":      true,
 				"verbose":       true,
 				"output_format": "json",
+				"update_check":  "false",
+				"offline":       true,
 			},
 			expected: Config{
 				NoColor:      true,
 				Verbose:      true,
 				OutputFormat: "json",
+				Offline:      true,
 			},
 		},
 	}
@@ -114,6 +120,8 @@ func TestConfigParser_Parse(t *testing.T) {
 			assert.Equal(t, tt.expected.NoColor, config.NoColor)
 			assert.Equal(t, tt.expected.Verbose, config.Verbose)
 			assert.Equal(t, tt.expected.OutputFormat, config.OutputFormat)
+			assert.Equal(t, tt.expected.UpdateCheck, config.UpdateCheck)
+			assert.Equal(t, tt.expected.Offline, config.Offline)
 		})
 	}
 }
@@ -122,3 +130,23 @@ func TestConfigParser_Section(t *testing.T) {
 	parser := ConfigParser{}
 	assert.Equal(t, "cli", parser.Section())
 }
+
+func TestUIConfig(t *testing.T) {
+	parser := UIConfigParser{}
+	assert.Equal(t, "ui", parser.Section())
+
+	cfg, err := parser.Parse(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "default", cfg.Theme)
+	require.NoError(t, cfg.Validate())
+
+	cfg, err = parser.Parse(map[string]interface{}{"theme": "colorblind", "accessible": "true"})
+	require.NoError(t, err)
+	assert.Equal(t, "colorblind", cfg.Theme)
+	assert.True(t, cfg.Accessible)
+	require.NoError(t, cfg.Validate())
+
+	err = UIConfig{Theme: "solarized"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "colorblind, default, high-contrast")
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// UIConfig contains the settings of how zen draws its output
+type UIConfig struct {
+	// Theme is the color theme: default, high-contrast or colorblind
+	Theme string `yaml:"theme" json:"theme" mapstructure:"theme"`
+
+	// Accessible replaces symbols with text labels and spinners with progress lines,
+	// for screen readers and simple terminals
+	Accessible bool `yaml:"accessible" json:"accessible" mapstructure:"accessible"`
+}
+
+// DefaultUIConfig returns the default UI configuration
+func DefaultUIConfig() UIConfig {
+	return UIConfig{
+		Theme: iostreams.ThemeDefault,
+	}
+}
+
+// Validate validates the UI configuration
+func (c UIConfig) Validate() error {
+	if _, ok := iostreams.LookupTheme(c.Theme); !ok {
+		return fmt.Errorf("invalid theme: %s (must be one of: %s)", c.Theme, strings.Join(iostreams.ThemeNames(), ", "))
+	}
+	return nil
+}
+
+// Defaults returns a new UIConfig with default values
+func (c UIConfig) Defaults() config.Configurable {
+	return DefaultUIConfig()
+}
+
+// UIConfigParser implements config.ConfigParser[UIConfig] interface
+type UIConfigParser struct{}
+
+// Parse converts raw configuration data to UIConfig
+func (p UIConfigParser) Parse(raw map[string]interface{}) (UIConfig, error) {
+	cfg := DefaultUIConfig()
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode UI config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for the UI
+func (p UIConfigParser) Section() string {
+	return "ui"
+}
@@ -0,0 +1,160 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/daddia/zen/pkg/fs"
+)
+
+// URL schemes that select how Git operations authenticate
+const (
+	SchemeSSH   = "ssh"
+	SchemeHTTPS = "https"
+	SchemeLocal = "local"
+)
+
+// scpLikeURL matches SSH remotes written as "git@github.com:org/repo.git"
+var scpLikeURL = regexp.MustCompile(`^(?:[\w.-]+@)?[\w.-]+:[^/\\][^:]*$`)
+
+// networkCommands contact a remote and may need credentials
+var networkCommands = map[string]bool{
+	"clone":     true,
+	"fetch":     true,
+	"pull":      true,
+	"push":      true,
+	"ls-remote": true,
+}
+
+// URLScheme returns how a repository URL authenticates: over SSH, over HTTP(S), or not at all
+func URLScheme(url string) string {
+	switch {
+	case strings.HasPrefix(url, "ssh://"), strings.HasPrefix(url, "git+ssh://"), strings.HasPrefix(url, "ssh+git://"):
+		return SchemeSSH
+	case strings.HasPrefix(url, "https://"), strings.HasPrefix(url, "http://"):
+		return SchemeHTTPS
+	case !strings.Contains(url, "://") && scpLikeURL.MatchString(url) && !filepath.IsAbs(url):
+		return SchemeSSH
+	default:
+		return SchemeLocal
+	}
+}
+
+// SetSSHKeyFile sets the private key used for SSH remotes instead of the SSH agent
+func (g *CLIRepository) SetSSHKeyFile(path string) {
+	g.sshKeyFile = path
+}
+
+// authenticationEnv returns the environment that authenticates a git command, chosen by
+// the scheme of the remote it contacts. Commands that do not contact a remote get none.
+func (g *CLIRepository) authenticationEnv(ctx context.Context, workDir string, args []string) []string {
+	if len(args) == 0 || !networkCommands[args[0]] {
+		return nil
+	}
+
+	switch URLScheme(g.commandRemoteURL(ctx, workDir, args)) {
+	case SchemeSSH:
+		return g.sshEnv()
+	case SchemeHTTPS:
+		return g.httpsEnv()
+	default:
+		return nil
+	}
+}
+
+// commandRemoteURL returns the URL of the remote a network command contacts
+func (g *CLIRepository) commandRemoteURL(ctx context.Context, workDir string, args []string) string {
+	if args[0] == "clone" {
+		return g.cloneURL
+	}
+
+	remote := "origin"
+	for _, arg := range args[1:] {
+		if !strings.HasPrefix(arg, "-") {
+			remote = arg
+			break
+		}
+	}
+	if URLScheme(remote) != SchemeLocal {
+		return remote
+	}
+
+	// Look the remote up directly, as running it through the executor would recurse
+	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", remote)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		g.logger.Debug("could not resolve remote URL", "remote", remote, "error", err)
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// sshEnv uses the configured key file, or the SSH agent when none is configured. A
+// GIT_SSH_COMMAND or GIT_SSH set by the user is left alone.
+func (g *CLIRepository) sshEnv() []string {
+	if os.Getenv("GIT_SSH_COMMAND") != "" || os.Getenv("GIT_SSH") != "" {
+		return nil
+	}
+
+	// BatchMode fails instead of prompting for a passphrase or host key confirmation
+	command := "ssh -o BatchMode=yes"
+	if g.sshKeyFile != "" {
+		keyFile, _ := fs.ExpandHome(g.sshKeyFile)
+		command += fmt.Sprintf(" -i %s -o IdentitiesOnly=yes", shellQuote(keyFile))
+	} else if os.Getenv("SSH_AUTH_SOCK") == "" {
+		g.logger.Debug("no SSH agent running and no git.ssh_key_file configured, using default SSH keys")
+	}
+
+	return []string{"GIT_SSH_COMMAND=" + command}
+}
+
+// httpsEnv supplies the provider token through a credential helper. Without a token the
+// system credential helper configured in Git is used as normal.
+func (g *CLIRepository) httpsEnv() []string {
+	username, token := tokenCredentials(g.auth, g.authProvider)
+	if token == "" {
+		g.logger.Debug("no token available for git operations, using the git credential helper", "provider", g.authProvider)
+		return nil
+	}
+
+	// The empty helper clears helpers from Git config so that a stale stored
+	// credential is not used ahead of the token. The token stays in the environment
+	// rather than in the helper command line.
+	return []string{
+		"ZEN_GIT_TOKEN=" + token,
+		"GIT_CONFIG_COUNT=2",
+		"GIT_CONFIG_KEY_0=credential.helper",
+		"GIT_CONFIG_VALUE_0=",
+		"GIT_CONFIG_KEY_1=credential.helper",
+		fmt.Sprintf(`GIT_CONFIG_VALUE_1=!f() { test "$1" = get && echo username=%s && echo "password=$ZEN_GIT_TOKEN"; }; f`, username),
+	}
+}
+
+// tokenCredentials returns the user name and provider token that authenticate HTTPS
+// remotes. The token is empty when the provider has none.
+func tokenCredentials(auth AuthProvider, authProvider string) (string, string) {
+	if auth == nil {
+		return "", ""
+	}
+
+	token, err := auth.GetCredentials(authProvider)
+	if err != nil || token == "" {
+		return "", ""
+	}
+
+	// GitLab accepts tokens with the "oauth2" user; GitHub accepts any user
+	if authProvider == "gitlab" {
+		return "oauth2", token
+	}
+	return "x-access-token", token
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,96 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLScheme(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/daddia/zen-assets.git":  SchemeHTTPS,
+		"http://git.internal/assets.git":            SchemeHTTPS,
+		"ssh://git@github.com/daddia/zen-assets":    SchemeSSH,
+		"git+ssh://git@gitlab.com/group/assets.git": SchemeSSH,
+		"git@github.com:daddia/zen-assets.git":      SchemeSSH,
+		"gitlab.internal:group/assets.git":          SchemeSSH,
+		"file:///srv/git/assets.git":                SchemeLocal,
+		"/srv/git/assets.git":                       SchemeLocal,
+		"origin":                                    SchemeLocal,
+		"":                                          SchemeLocal,
+	}
+
+	for url, want := range tests {
+		assert.Equal(t, want, URLScheme(url), url)
+	}
+}
+
+func TestCLIRepository_AuthenticationEnv(t *testing.T) {
+	t.Setenv("GIT_SSH_COMMAND", "")
+	t.Setenv("GIT_SSH", "")
+	ctx := context.Background()
+
+	auth := newMockAuthProvider()
+	auth.credentials["github"] = "secret-token"
+	repo := NewCLIRepository(t.TempDir(), logging.NewBasic(), auth, "github")
+
+	// Only commands that contact a remote are authenticated
+	assert.Nil(t, repo.authenticationEnv(ctx, "", []string{"status"}))
+
+	repo.cloneURL = "https://github.com/daddia/zen-assets.git"
+	env := repo.authenticationEnv(ctx, "", []string{"clone", repo.cloneURL, "dir"})
+	assert.Contains(t, env, "ZEN_GIT_TOKEN=secret-token")
+	assert.NotContains(t, strings.Join(env[1:], "\n"), "secret-token")
+
+	repo.cloneURL = "git@github.com:daddia/zen-assets.git"
+	env = repo.authenticationEnv(ctx, "", []string{"clone", repo.cloneURL, "dir"})
+	assert.Equal(t, []string{"GIT_SSH_COMMAND=ssh -o BatchMode=yes"}, env)
+
+	repo.SetSSHKeyFile("/keys/it's_ed25519")
+	env = repo.authenticationEnv(ctx, "", []string{"push", "git@github.com:daddia/zen-assets.git", "main"})
+	assert.Equal(t, []string{`GIT_SSH_COMMAND=ssh -o BatchMode=yes -i '/keys/it'\''s_ed25519' -o IdentitiesOnly=yes`}, env)
+
+	// A user's own SSH command wins
+	t.Setenv("GIT_SSH_COMMAND", "ssh -F ~/.ssh/work_config")
+	assert.Nil(t, repo.authenticationEnv(ctx, "", []string{"fetch", "ssh://git@github.com/daddia/zen-assets"}))
+}
+
+func TestCLIRepository_AuthenticationEnv_RemoteLookup(t *testing.T) {
+	dir := initTestRepository(t)
+	ctx := context.Background()
+
+	repo := NewCLIRepository(dir, logging.NewBasic(), nil, "")
+	require.NoError(t, repo.AddRemote(ctx, "origin", "git@gitlab.com:group/assets.git"))
+	t.Setenv("GIT_SSH_COMMAND", "")
+	t.Setenv("GIT_SSH", "")
+
+	assert.Equal(t, "git@gitlab.com:group/assets.git", repo.commandRemoteURL(ctx, dir, []string{"pull"}))
+	assert.Equal(t, "git@gitlab.com:group/assets.git", repo.commandRemoteURL(ctx, dir, []string{"push", "--set-upstream", "origin", "main"}))
+	assert.NotNil(t, repo.authenticationEnv(ctx, dir, []string{"pull"}))
+}
+
+func TestCLIRepository_HTTPSCredentialHelper(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	auth := newMockAuthProvider()
+	auth.credentials["gitlab"] = "glpat-123"
+	repo := NewCLIRepository(t.TempDir(), logging.NewBasic(), auth, "gitlab")
+
+	// git credential fill runs the helper exactly as a clone or fetch would
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Env = append(os.Environ(), repo.httpsEnv()...)
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=gitlab.com\n\n")
+	out, err := cmd.Output()
+	require.NoError(t, err)
+
+	assert.Contains(t, string(out), "username=oauth2\n")
+	assert.Contains(t, string(out), "password=glpat-123\n")
+}
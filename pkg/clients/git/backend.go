@@ -0,0 +1,150 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Git backends
+const (
+	// BackendCLI runs the git binary
+	BackendCLI = "cli"
+
+	// BackendNative uses go-git in-process and needs no git binary
+	BackendNative = "native"
+)
+
+// NativeFactory creates a Repository backed by an in-process Git implementation
+type NativeFactory func(repoPath string, logger logging.Logger, auth AuthProvider, authProvider string) Repository
+
+// nativeFactory creates native repositories; go-git unless RegisterNativeBackend replaces it
+var nativeFactory NativeFactory = func(repoPath string, logger logging.Logger, auth AuthProvider, authProvider string) Repository {
+	return NewNativeRepository(repoPath, logger, auth, authProvider)
+}
+
+// lookPath is replaced in tests to simulate a missing git binary
+var lookPath = exec.LookPath
+
+// Config contains git client configuration
+type Config struct {
+	// Backend selects the Git implementation: "cli" or "native"
+	Backend string `yaml:"backend" json:"backend" mapstructure:"backend"`
+
+	// SSHKeyFile is the private key for SSH remotes; the SSH agent is used when empty
+	SSHKeyFile string `yaml:"ssh_key_file" json:"ssh_key_file" mapstructure:"ssh_key_file"`
+}
+
+// DefaultConfig returns default git client configuration
+func DefaultConfig() Config {
+	return Config{Backend: BackendCLI}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the git client configuration
+func (c Config) Validate() error {
+	switch c.Backend {
+	case BackendCLI, BackendNative:
+		return nil
+	default:
+		return fmt.Errorf("invalid backend %q: must be %q or %q", c.Backend, BackendCLI, BackendNative)
+	}
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode git config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for the git client
+func (p ConfigParser) Section() string {
+	return "git"
+}
+
+// RegisterNativeBackend replaces the native backend used by NewRepository
+func RegisterNativeBackend(factory NativeFactory) {
+	nativeFactory = factory
+}
+
+// NativeAvailable reports whether a native backend is registered
+func NativeAvailable() bool {
+	return nativeFactory != nil
+}
+
+// CLIAvailable reports whether the git binary is on the PATH
+func CLIAvailable() bool {
+	_, err := lookPath("git")
+	return err == nil
+}
+
+// NewRepository creates a Repository for the configured backend. When the configured
+// backend is unavailable the other one is used instead, so that a missing git binary
+// does not stop Git operations.
+func NewRepository(cfg Config, repoPath string, logger logging.Logger, auth AuthProvider, authProvider string) (Repository, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, &GitError{Code: ErrorCodeConfigError, Message: err.Error()}
+	}
+
+	switch {
+	case cfg.Backend == BackendNative && NativeAvailable():
+		return newNativeRepository(cfg, repoPath, logger, auth, authProvider), nil
+	case cfg.Backend == BackendCLI && CLIAvailable():
+		return newCLIRepository(cfg, repoPath, logger, auth, authProvider), nil
+	case NativeAvailable():
+		logger.Warn("git binary not found, using the native git backend")
+		return newNativeRepository(cfg, repoPath, logger, auth, authProvider), nil
+	case CLIAvailable():
+		logger.Warn("native git backend is not available, using the git binary")
+		return newCLIRepository(cfg, repoPath, logger, auth, authProvider), nil
+	default:
+		return nil, &GitError{
+			Code:    ErrorCodeConfigError,
+			Message: "no git backend available: install git",
+			Details: map[string]interface{}{"backend": cfg.Backend},
+		}
+	}
+}
+
+func newCLIRepository(cfg Config, repoPath string, logger logging.Logger, auth AuthProvider, authProvider string) *CLIRepository {
+	repo := NewCLIRepository(repoPath, logger, auth, authProvider)
+	repo.SetSSHKeyFile(cfg.SSHKeyFile)
+	return repo
+}
+
+func newNativeRepository(cfg Config, repoPath string, logger logging.Logger, auth AuthProvider, authProvider string) Repository {
+	repo := nativeFactory(repoPath, logger, auth, authProvider)
+	if keyed, ok := repo.(interface{ SetSSHKeyFile(string) }); ok {
+		keyed.SetSSHKeyFile(cfg.SSHKeyFile)
+	}
+	return repo
+}
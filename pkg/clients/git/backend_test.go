@@ -0,0 +1,83 @@
+package git
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nativeRepository struct {
+	Repository
+}
+
+func withBackends(t *testing.T, cli, native bool) {
+	t.Helper()
+	savedLookPath, savedFactory := lookPath, nativeFactory
+	t.Cleanup(func() { lookPath, nativeFactory = savedLookPath, savedFactory })
+
+	lookPath = func(file string) (string, error) {
+		if cli {
+			return "/usr/bin/git", nil
+		}
+		return "", errors.New("not found")
+	}
+	nativeFactory = nil
+	if native {
+		RegisterNativeBackend(func(repoPath string, logger logging.Logger, auth AuthProvider, authProvider string) Repository {
+			return &nativeRepository{}
+		})
+	}
+}
+
+func TestNewRepository(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		cli     bool
+		native  bool
+		want    string
+	}{
+		{"cli", BackendCLI, true, true, "cli"},
+		{"native", BackendNative, true, true, "native"},
+		{"cli falls back to native", BackendCLI, false, true, "native"},
+		{"native falls back to cli", BackendNative, true, false, "cli"},
+		{"none available", BackendCLI, false, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withBackends(t, tt.cli, tt.native)
+
+			repo, err := NewRepository(Config{Backend: tt.backend}, t.TempDir(), logging.NewBasic(), nil, "")
+
+			switch tt.want {
+			case "cli":
+				require.NoError(t, err)
+				assert.IsType(t, &CLIRepository{}, repo)
+			case "native":
+				require.NoError(t, err)
+				assert.IsType(t, &nativeRepository{}, repo)
+			default:
+				var gitErr *GitError
+				require.ErrorAs(t, err, &gitErr)
+				assert.Equal(t, ErrorCodeConfigError, gitErr.Code)
+			}
+		})
+	}
+}
+
+func TestConfigParser_Parse(t *testing.T) {
+	cfg, err := ConfigParser{}.Parse(nil)
+	require.NoError(t, err)
+	assert.Equal(t, BackendCLI, cfg.Backend)
+
+	cfg, err = ConfigParser{}.Parse(map[string]interface{}{"backend": "native"})
+	require.NoError(t, err)
+	assert.Equal(t, BackendNative, cfg.Backend)
+	assert.NoError(t, cfg.Validate())
+
+	assert.Error(t, Config{Backend: "libgit2"}.Validate())
+}
@@ -3,13 +3,16 @@ package git
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/errors"
 )
 
@@ -17,6 +20,7 @@ import (
 type Repository interface {
 	// Basic repository operations
 	Clone(ctx context.Context, url, branch string, shallow bool) error
+	CloneWithOptions(ctx context.Context, url string, options CloneOptions) error
 	Pull(ctx context.Context) error
 	GetFile(ctx context.Context, path string) ([]byte, error)
 	ListFiles(ctx context.Context, pattern string) ([]string, error)
@@ -26,6 +30,7 @@ type Repository interface {
 	// Generic Git command execution - access to ALL Git commands
 	ExecuteCommand(ctx context.Context, args ...string) (string, error)
 	ExecuteCommandWithOutput(ctx context.Context, args ...string) ([]byte, error)
+	ExecuteCommandStream(ctx context.Context, args ...string) (io.ReadCloser, error)
 
 	// Branching operations
 	CreateBranch(ctx context.Context, name string) error
@@ -33,6 +38,13 @@ type Repository interface {
 	ListBranches(ctx context.Context, remote bool) ([]Branch, error)
 	SwitchBranch(ctx context.Context, name string) error
 	GetCurrentBranch(ctx context.Context) (string, error)
+	BranchExists(ctx context.Context, name string) (bool, error)
+	CheckoutNewBranch(ctx context.Context, name, base string) error
+
+	// Worktree operations
+	AddWorktree(ctx context.Context, path, branch, base string) error
+	RemoveWorktree(ctx context.Context, path string, force bool) error
+	ListWorktrees(ctx context.Context) ([]Worktree, error)
 
 	// Commit operations
 	Commit(ctx context.Context, message string, files ...string) error
@@ -54,6 +66,7 @@ type Repository interface {
 	ListRemotes(ctx context.Context) ([]Remote, error)
 	Fetch(ctx context.Context, remote string) error
 	Push(ctx context.Context, remote, branch string) error
+	PushUpstream(ctx context.Context, remote, branch string) error
 
 	// Configuration
 	GetConfig(ctx context.Context, key string) (string, error)
@@ -63,6 +76,9 @@ type Repository interface {
 	Diff(ctx context.Context, options DiffOptions) (string, error)
 	Log(ctx context.Context, options LogOptions) ([]Commit, error)
 	Blame(ctx context.Context, file string) ([]BlameLine, error)
+	DiffStream(ctx context.Context, options DiffOptions) (io.ReadCloser, error)
+	LogStream(ctx context.Context, options LogOptions, fn func(Commit) error) error
+	BlameStream(ctx context.Context, file string, fn func(BlameLine) error) error
 	Tag(ctx context.Context, name, message string) error
 	ListTags(ctx context.Context) ([]Tag, error)
 	Status(ctx context.Context) (StatusInfo, error)
@@ -80,6 +96,9 @@ type CLIRepository struct {
 	logger       logging.Logger
 	auth         AuthProvider
 	authProvider string
+	sshKeyFile   string
+	cloneURL     string
+	skipSmudge   bool
 }
 
 // NewCLIRepository creates a new Git CLI repository wrapper
@@ -94,7 +113,14 @@ func NewCLIRepository(repoPath string, logger logging.Logger, auth AuthProvider,
 
 // Clone clones the repository to local cache
 func (g *CLIRepository) Clone(ctx context.Context, url, branch string, shallow bool) error {
-	g.logger.Info("cloning repository", "url", g.sanitizeURL(url), "branch", branch, "shallow", shallow)
+	return g.CloneWithOptions(ctx, url, CloneOptions{Branch: branch, Shallow: shallow})
+}
+
+// CloneWithOptions clones the repository to local cache, optionally as a partial clone
+// that fetches blobs on demand and with only some paths checked out
+func (g *CLIRepository) CloneWithOptions(ctx context.Context, url string, options CloneOptions) error {
+	g.logger.Info("cloning repository", "url", sanitizeURL(url), "branch", options.Branch,
+		"shallow", options.Shallow, "filter", options.Filter, "sparse_paths", len(options.SparsePaths))
 
 	// Ensure parent directory exists
 	if err := os.MkdirAll(filepath.Dir(g.repoPath), 0755); err != nil {
@@ -109,21 +135,46 @@ func (g *CLIRepository) Clone(ctx context.Context, url, branch string, shallow b
 	// Build git clone command
 	args := []string{"clone"}
 
-	if shallow {
+	if options.Shallow {
 		args = append(args, "--depth", "1")
 	}
 
-	if branch != "" {
-		args = append(args, "--branch", branch)
+	if options.Branch != "" {
+		args = append(args, "--branch", options.Branch)
+	}
+
+	if options.Filter != "" {
+		args = append(args, "--filter="+options.Filter)
+	}
+
+	// Check out only files at the top level until the sparse paths are set
+	if len(options.SparsePaths) > 0 {
+		args = append(args, "--sparse")
 	}
 
 	args = append(args, url, g.repoPath)
 
-	// Execute git clone with authentication
+	// Execute git clone with authentication for the URL's scheme
+	g.cloneURL = url
+	g.skipSmudge = options.SkipLFSSmudge
 	if err := g.executeGitCommand(ctx, "", args...); err != nil {
 		return errors.Wrap(err, "git clone failed")
 	}
 
+	if len(options.SparsePaths) > 0 {
+		args := append([]string{"sparse-checkout", "set"}, options.SparsePaths...)
+		if err := g.executeGitCommand(ctx, g.repoPath, args...); err != nil {
+			return errors.Wrap(err, "git sparse-checkout failed")
+		}
+	}
+
+	// Keep later pulls from downloading LFS content too
+	if options.SkipLFSSmudge && g.LFSAvailable(ctx) {
+		if err := g.executeGitCommand(ctx, g.repoPath, "lfs", "install", "--local", "--skip-smudge"); err != nil {
+			return errors.Wrap(err, "git lfs install failed")
+		}
+	}
+
 	g.logger.Info("repository cloned successfully", "path", g.repoPath)
 	return nil
 }
@@ -160,23 +211,9 @@ func (g *CLIRepository) GetFile(ctx context.Context, path string) ([]byte, error
 		}
 	}
 
-	filePath := filepath.Join(g.repoPath, path)
-
-	// Check if file exists
-	if _, err := os.Stat(filePath); err != nil {
-		if os.IsNotExist(err) {
-			return nil, &GitError{
-				Code:    ErrorCodeFileNotFound,
-				Message: fmt.Sprintf("file '%s' not found in repository", path),
-			}
-		}
-		return nil, errors.Wrap(err, "failed to stat file")
-	}
-
-	// Read file content
-	content, err := os.ReadFile(filePath)
+	content, err := readWorktreeFile(g.repoPath, path)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read file")
+		return nil, err
 	}
 
 	g.logger.Debug("file retrieved successfully", "path", path, "size", len(content))
@@ -194,39 +231,9 @@ func (g *CLIRepository) ListFiles(ctx context.Context, pattern string) ([]string
 		}
 	}
 
-	var files []string
-
-	err := filepath.WalkDir(g.repoPath, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip .git directory
-		if d.IsDir() && d.Name() == ".git" {
-			return filepath.SkipDir
-		}
-
-		// Skip directories
-		if d.IsDir() {
-			return nil
-		}
-
-		// Get relative path
-		relPath, err := filepath.Rel(g.repoPath, path)
-		if err != nil {
-			return err
-		}
-
-		// Apply pattern matching if specified
-		if pattern == "" || g.matchPattern(relPath, pattern) {
-			files = append(files, relPath)
-		}
-
-		return nil
-	})
-
+	files, err := listWorktreeFiles(g.repoPath, pattern)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to walk repository directory")
+		return nil, err
 	}
 
 	g.logger.Debug("files listed successfully", "count", len(files))
@@ -279,7 +286,7 @@ func (g *CLIRepository) IsClean(ctx context.Context) (bool, error) {
 
 // ExecuteCommand executes any Git command with full flexibility
 func (g *CLIRepository) ExecuteCommand(ctx context.Context, args ...string) (string, error) {
-	g.logger.Debug("executing generic git command", "args", g.sanitizeArgs(args))
+	g.logger.Debug("executing generic git command", "args", sanitizeArgs(args))
 
 	if !g.repositoryExists() {
 		return "", &GitError{
@@ -298,7 +305,7 @@ func (g *CLIRepository) ExecuteCommand(ctx context.Context, args ...string) (str
 
 // ExecuteCommandWithOutput executes any Git command and returns raw bytes
 func (g *CLIRepository) ExecuteCommandWithOutput(ctx context.Context, args ...string) ([]byte, error) {
-	g.logger.Debug("executing generic git command with output", "args", g.sanitizeArgs(args))
+	g.logger.Debug("executing generic git command with output", "args", sanitizeArgs(args))
 
 	if !g.repositoryExists() {
 		return nil, &GitError{
@@ -323,31 +330,15 @@ func (g *CLIRepository) executeGitCommand(ctx context.Context, workDir string, a
 }
 
 func (g *CLIRepository) executeGitCommandWithOutput(ctx context.Context, workDir string, args ...string) (string, error) {
-	// Create command with timeout
-	cmd := exec.CommandContext(ctx, "git", args...)
-
-	if workDir != "" {
-		cmd.Dir = workDir
-	}
+	cmd := g.gitCommand(ctx, workDir, args...)
 
-	// Set up authentication if needed
-	if err := g.setupAuthentication(cmd); err != nil {
-		return "", errors.Wrap(err, "failed to setup authentication")
-	}
-
-	// Set up environment
-	cmd.Env = append(os.Environ(),
-		"GIT_TERMINAL_PROMPT=0", // Disable interactive prompts
-		"GIT_ASKPASS=echo",      // Use echo as askpass to prevent hanging
-	)
-
-	g.logger.Debug("executing git command", "args", g.sanitizeArgs(args), "workdir", workDir)
+	g.logger.Debug("executing git command", "args", sanitizeArgs(args), "workdir", workDir)
 
 	// Execute command
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		g.logger.Error("git command failed",
-			"args", g.sanitizeArgs(args),
+			"args", sanitizeArgs(args),
 			"error", err,
 			"output", string(output))
 
@@ -355,45 +346,73 @@ func (g *CLIRepository) executeGitCommandWithOutput(ctx context.Context, workDir
 			Code:    ErrorCodeCommandFailed,
 			Message: fmt.Sprintf("git command failed: %v", err),
 			Details: map[string]interface{}{
-				"command": g.sanitizeArgs(args),
+				"command": sanitizeArgs(args),
 				"output":  string(output),
 			},
 		}
 	}
 
-	g.logger.Debug("git command completed successfully", "args", g.sanitizeArgs(args))
+	g.logger.Debug("git command completed successfully", "args", sanitizeArgs(args))
 	return string(output), nil
 }
 
-func (g *CLIRepository) setupAuthentication(cmd *exec.Cmd) error {
-	if g.auth == nil {
-		return nil // No authentication configured
-	}
+// gitCommand builds a git command with the environment every git invocation needs.
+// The process is killed when ctx is cancelled.
+func (g *CLIRepository) gitCommand(ctx context.Context, workDir string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
 
-	// Get credentials from auth provider
-	credentials, err := g.auth.GetCredentials(g.authProvider)
-	if err != nil {
-		g.logger.Debug("no credentials available for git operations", "error", err)
-		return nil // Continue without authentication
+	if workDir != "" {
+		cmd.Dir = workDir
 	}
 
-	if credentials == "" {
-		return nil // No credentials available
+	// Set up environment
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0", // Disable interactive prompts
+		"GIT_ASKPASS=echo",      // Use echo as askpass to prevent hanging
+	)
+
+	// Set up authentication for the remote the command contacts, if any
+	cmd.Env = append(cmd.Env, g.authenticationEnv(ctx, workDir, args)...)
+
+	// Send Git through the configured proxy and trust the configured CA bundle
+	cmd.Env = withGitConfig(cmd.Env, httpx.Current().GitConfig())
+
+	// LFS content is fetched only by explicit "git lfs" commands
+	if g.skipSmudge && args[0] != "lfs" {
+		cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
 	}
 
-	// Set up credential helper for HTTPS authentication
-	cmd.Env = append(cmd.Env,
-		"GIT_ASKPASS=echo",
-		"GIT_USERNAME=token",
-		fmt.Sprintf("GIT_PASSWORD=%s", credentials),
-	)
+	return cmd
+}
 
-	// For HTTPS URLs, we can use the credential helper approach
-	cmd.Env = append(cmd.Env, "GIT_CONFIG_COUNT=1")
-	cmd.Env = append(cmd.Env, "GIT_CONFIG_KEY_0=credential.helper")
-	cmd.Env = append(cmd.Env, fmt.Sprintf("GIT_CONFIG_VALUE_0=!echo 'username=token'; echo 'password=%s'", credentials))
+// withGitConfig adds Git configuration to env through GIT_CONFIG_COUNT, after any
+// configuration env already holds
+func withGitConfig(env []string, pairs [][2]string) []string {
+	if len(pairs) == 0 {
+		return env
+	}
 
-	return nil
+	count := 0
+	countIndex := -1
+	for i, entry := range env {
+		if value, ok := strings.CutPrefix(entry, "GIT_CONFIG_COUNT="); ok {
+			count, _ = strconv.Atoi(value)
+			countIndex = i
+		}
+	}
+
+	result := make([]string, 0, len(env)+2*len(pairs)+1)
+	for i, entry := range env {
+		if i != countIndex {
+			result = append(result, entry)
+		}
+	}
+	for i, pair := range pairs {
+		result = append(result,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", count+i, pair[0]),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", count+i, pair[1]))
+	}
+	return append(result, fmt.Sprintf("GIT_CONFIG_COUNT=%d", count+len(pairs)))
 }
 
 func (g *CLIRepository) repositoryExists() bool {
@@ -402,7 +421,69 @@ func (g *CLIRepository) repositoryExists() bool {
 	return err == nil
 }
 
-func (g *CLIRepository) matchPattern(path, pattern string) bool {
+// readWorktreeFile reads a file from the working tree at repoPath
+func readWorktreeFile(repoPath, path string) ([]byte, error) {
+	filePath := filepath.Join(repoPath, path)
+
+	// Check if file exists
+	if _, err := os.Stat(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, &GitError{
+				Code:    ErrorCodeFileNotFound,
+				Message: fmt.Sprintf("file '%s' not found in repository", path),
+			}
+		}
+		return nil, errors.Wrap(err, "failed to stat file")
+	}
+
+	// Read file content
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+	return content, nil
+}
+
+// listWorktreeFiles lists the files in the working tree at repoPath that match pattern
+func listWorktreeFiles(repoPath, pattern string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(repoPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip .git directory
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		// Skip directories
+		if d.IsDir() {
+			return nil
+		}
+
+		// Get relative path
+		relPath, err := filepath.Rel(repoPath, path)
+		if err != nil {
+			return err
+		}
+
+		// Apply pattern matching if specified
+		if pattern == "" || matchPattern(relPath, pattern) {
+			files = append(files, relPath)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to walk repository directory")
+	}
+	return files, nil
+}
+
+func matchPattern(path, pattern string) bool {
 	// Simple pattern matching - can be enhanced with more sophisticated matching
 	if pattern == "*" || pattern == "" {
 		return true
@@ -412,7 +493,7 @@ func (g *CLIRepository) matchPattern(path, pattern string) bool {
 	return strings.Contains(strings.ToLower(path), strings.ToLower(pattern))
 }
 
-func (g *CLIRepository) sanitizeURL(url string) string {
+func sanitizeURL(url string) string {
 	// Remove credentials from URL for logging
 	if strings.Contains(url, "@") {
 		parts := strings.Split(url, "@")
@@ -423,14 +504,14 @@ func (g *CLIRepository) sanitizeURL(url string) string {
 	return url
 }
 
-func (g *CLIRepository) sanitizeArgs(args []string) []string {
+func sanitizeArgs(args []string) []string {
 	sanitized := make([]string, len(args))
 	copy(sanitized, args)
 
 	// Replace URLs with sanitized versions
 	for i, arg := range sanitized {
 		if strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "git@") {
-			sanitized[i] = g.sanitizeURL(arg)
+			sanitized[i] = sanitizeURL(arg)
 		}
 	}
 
@@ -515,6 +596,95 @@ func (g *CLIRepository) GetCurrentBranch(ctx context.Context) (string, error) {
 	return strings.TrimSpace(output), nil
 }
 
+// BranchExists reports whether a local branch exists
+func (g *CLIRepository) BranchExists(ctx context.Context, name string) (bool, error) {
+	g.logger.Debug("checking branch exists", "name", name)
+
+	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "branch", "--list", name)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(output) != "", nil
+}
+
+// CheckoutNewBranch creates a branch from base, or from HEAD when base is empty, and switches to it
+func (g *CLIRepository) CheckoutNewBranch(ctx context.Context, name, base string) error {
+	g.logger.Debug("creating and switching branch", "name", name, "base", base)
+
+	args := []string{"checkout", "-b", name}
+	if base != "" {
+		args = append(args, base)
+	}
+
+	return g.executeGitCommand(ctx, g.repoPath, args...)
+}
+
+// Worktree operations
+
+// AddWorktree checks out branch in a new worktree at path. When base is set, the branch
+// is created from base; otherwise it must already exist.
+func (g *CLIRepository) AddWorktree(ctx context.Context, path, branch, base string) error {
+	g.logger.Debug("adding worktree", "path", path, "branch", branch, "base", base)
+
+	args := []string{"worktree", "add"}
+	if base != "" {
+		args = append(args, "-b", branch, path, base)
+	} else {
+		args = append(args, path, branch)
+	}
+
+	return g.executeGitCommand(ctx, g.repoPath, args...)
+}
+
+// RemoveWorktree removes a worktree
+func (g *CLIRepository) RemoveWorktree(ctx context.Context, path string, force bool) error {
+	g.logger.Debug("removing worktree", "path", path, "force", force)
+
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	args = append(args, path)
+
+	return g.executeGitCommand(ctx, g.repoPath, args...)
+}
+
+// ListWorktrees lists the worktrees of the repository, starting with the main worktree
+func (g *CLIRepository) ListWorktrees(ctx context.Context) ([]Worktree, error) {
+	g.logger.Debug("listing worktrees")
+
+	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	var worktrees []Worktree
+	var current *Worktree
+	for _, line := range strings.Split(output, "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch key {
+		case "worktree":
+			worktrees = append(worktrees, Worktree{Path: value})
+			current = &worktrees[len(worktrees)-1]
+		case "HEAD":
+			if current != nil {
+				current.Commit = value
+			}
+		case "branch":
+			if current != nil {
+				current.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "detached":
+			if current != nil {
+				current.Detached = true
+			}
+		}
+	}
+
+	return worktrees, nil
+}
+
 // Commit operations
 
 // Commit commits changes to the repository
@@ -545,40 +715,7 @@ func (g *CLIRepository) AddFiles(ctx context.Context, files ...string) error {
 func (g *CLIRepository) GetCommitHistory(ctx context.Context, limit int) ([]Commit, error) {
 	g.logger.Debug("getting commit history", "limit", limit)
 
-	args := []string{"log", "--format=%H|%an|%ae|%ad|%s", "--date=iso"}
-	if limit > 0 {
-		args = append(args, fmt.Sprintf("-%d", limit))
-	}
-
-	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, args...)
-	if err != nil {
-		return nil, err
-	}
-
-	var commits []Commit
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, "|")
-		if len(parts) >= 5 {
-			// Parse date
-			date, _ := time.Parse("2006-01-02 15:04:05 -0700", parts[3])
-			commit := Commit{
-				Hash:      parts[0],
-				Author:    parts[1],
-				Email:     parts[2],
-				Date:      date,
-				Message:   parts[4],
-				ShortHash: parts[0][:8],
-			}
-			commits = append(commits, commit)
-		}
-	}
-
-	return commits, nil
+	return g.Log(ctx, LogOptions{Limit: limit})
 }
 
 // ShowCommit shows detailed commit information
@@ -586,37 +723,19 @@ func (g *CLIRepository) ShowCommit(ctx context.Context, hash string) (CommitDeta
 	g.logger.Debug("showing commit details", "hash", hash)
 
 	// Get basic commit info
-	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "show", "--stat", "--format=%H|%an|%ae|%ad|%s", hash)
+	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "show", "-s", "-z", logFormat, hash)
 	if err != nil {
 		return CommitDetails{}, err
 	}
 
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	if len(lines) == 0 {
-		return CommitDetails{}, &GitError{
-			Code:    ErrorCodeFileNotFound,
-			Message: "commit not found",
-		}
-	}
-
-	// Parse commit info
-	parts := strings.Split(lines[0], "|")
-	if len(parts) < 5 {
+	commits := parseLog(output)
+	if len(commits) == 0 {
 		return CommitDetails{}, &GitError{
 			Code:    ErrorCodeCommandFailed,
 			Message: "invalid commit format",
 		}
 	}
-
-	date, _ := time.Parse("2006-01-02 15:04:05 -0700", parts[3])
-	commit := Commit{
-		Hash:      parts[0],
-		Author:    parts[1],
-		Email:     parts[2],
-		Date:      date,
-		Message:   parts[4],
-		ShortHash: parts[0][:8],
-	}
+	commit := commits[0]
 
 	// Get diff
 	diffOutput, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "show", "--format=", hash)
@@ -634,7 +753,7 @@ func (g *CLIRepository) ShowCommit(ctx context.Context, hash string) (CommitDeta
 
 // AddRemote adds a remote repository
 func (g *CLIRepository) AddRemote(ctx context.Context, name, url string) error {
-	g.logger.Debug("adding remote", "name", name, "url", g.sanitizeURL(url))
+	g.logger.Debug("adding remote", "name", name, "url", sanitizeURL(url))
 	return g.executeGitCommand(ctx, g.repoPath, "remote", "add", name, url)
 }
 
@@ -694,6 +813,12 @@ func (g *CLIRepository) Push(ctx context.Context, remote, branch string) error {
 	return g.executeGitCommand(ctx, g.repoPath, args...)
 }
 
+// PushUpstream pushes a branch to a remote and sets it as the branch's upstream
+func (g *CLIRepository) PushUpstream(ctx context.Context, remote, branch string) error {
+	g.logger.Debug("pushing branch with upstream", "remote", remote, "branch", branch)
+	return g.executeGitCommand(ctx, g.repoPath, "push", "--set-upstream", remote, branch)
+}
+
 // Configuration
 
 // GetConfig gets a Git configuration value
@@ -725,126 +850,42 @@ func (g *CLIRepository) SetConfig(ctx context.Context, key, value string, global
 
 // Diff shows differences
 func (g *CLIRepository) Diff(ctx context.Context, options DiffOptions) (string, error) {
-	g.logger.Debug("showing diff", "options", options)
-
-	args := []string{"diff"}
-
-	if options.Staged {
-		args = append(args, "--cached")
-	}
-	if options.Context > 0 {
-		args = append(args, fmt.Sprintf("-%d", options.Context))
-	}
-	if options.Commit != "" {
-		args = append(args, options.Commit)
-	}
-	if options.BaseCommit != "" {
-		args = append(args, options.BaseCommit)
-	}
-	if len(options.Files) > 0 {
-		args = append(args, "--")
-		args = append(args, options.Files...)
+	stream, err := g.DiffStream(ctx, options)
+	if err != nil {
+		return "", err
 	}
 
-	return g.executeGitCommandWithOutput(ctx, g.repoPath, args...)
+	var diff strings.Builder
+	_, readErr := io.Copy(&diff, stream)
+	if err := closeStream(stream, readErr); err != nil {
+		return "", err
+	}
+	return diff.String(), nil
 }
 
 // Log shows commit log
 func (g *CLIRepository) Log(ctx context.Context, options LogOptions) ([]Commit, error) {
-	g.logger.Debug("showing log", "options", options)
-
-	args := []string{"log", "--format=%H|%an|%ae|%ad|%s", "--date=iso"}
-
-	if options.Limit > 0 {
-		args = append(args, fmt.Sprintf("-%d", options.Limit))
-	}
-	if options.Since != "" {
-		args = append(args, "--since", options.Since)
-	}
-	if options.Until != "" {
-		args = append(args, "--until", options.Until)
-	}
-	if options.Author != "" {
-		args = append(args, "--author", options.Author)
-	}
-	if options.Grep != "" {
-		args = append(args, "--grep", options.Grep)
-	}
-	if options.Oneline {
-		args = append(args, "--oneline")
-	}
-	if options.Graph {
-		args = append(args, "--graph")
-	}
-	if options.All {
-		args = append(args, "--all")
-	}
-	if len(options.Files) > 0 {
-		args = append(args, "--")
-		args = append(args, options.Files...)
-	}
-
-	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, args...)
+	var commits []Commit
+	err := g.LogStream(ctx, options, func(commit Commit) error {
+		commits = append(commits, commit)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	var commits []Commit
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, "|")
-		if len(parts) >= 5 {
-			date, _ := time.Parse("2006-01-02 15:04:05 -0700", parts[3])
-			commit := Commit{
-				Hash:      parts[0],
-				Author:    parts[1],
-				Email:     parts[2],
-				Date:      date,
-				Message:   parts[4],
-				ShortHash: parts[0][:8],
-			}
-			commits = append(commits, commit)
-		}
-	}
-
 	return commits, nil
 }
 
 // Blame shows blame information for a file
 func (g *CLIRepository) Blame(ctx context.Context, file string) ([]BlameLine, error) {
-	g.logger.Debug("showing blame", "file", file)
-
-	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "blame", "-l", file)
+	var blameLines []BlameLine
+	err := g.BlameStream(ctx, file, func(line BlameLine) error {
+		blameLines = append(blameLines, line)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	var blameLines []BlameLine
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	for i, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		// Parse blame line format: commit author date line_num content
-		parts := strings.Split(line, " ")
-		if len(parts) >= 4 {
-			blameLine := BlameLine{
-				Commit:   parts[0],
-				Author:   parts[1],
-				Date:     parts[2],
-				LineNum:  i + 1,
-				Content:  strings.Join(parts[3:], " "),
-				Filename: file,
-			}
-			blameLines = append(blameLines, blameLine)
-		}
-	}
-
 	return blameLines, nil
 }
 
@@ -898,69 +939,12 @@ func (g *CLIRepository) ListTags(ctx context.Context) ([]Tag, error) {
 func (g *CLIRepository) Status(ctx context.Context) (StatusInfo, error) {
 	g.logger.Debug("showing status")
 
-	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "status", "--porcelain", "-b")
+	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "status", "--porcelain=v2", "-z", "--branch")
 	if err != nil {
 		return StatusInfo{}, err
 	}
 
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	if len(lines) == 0 {
-		return StatusInfo{}, nil
-	}
-
-	status := StatusInfo{
-		Clean:          true,
-		StagedFiles:    []string{},
-		ModifiedFiles:  []string{},
-		UntrackedFiles: []string{},
-		DeletedFiles:   []string{},
-		RenamedFiles:   make(map[string]string),
-	}
-
-	// Parse branch info from first line
-	if strings.HasPrefix(lines[0], "## ") {
-		branchInfo := strings.TrimPrefix(lines[0], "## ")
-		if parts := strings.Split(branchInfo, "..."); len(parts) > 0 {
-			status.Branch = parts[0]
-		}
-		lines = lines[1:]
-	}
-
-	// Parse file status
-	for _, line := range lines {
-		if len(line) < 3 {
-			continue
-		}
-
-		statusCode := line[:2]
-		filename := line[3:]
-
-		status.Clean = false
-
-		switch statusCode[0] {
-		case 'A', 'M', 'D':
-			status.StagedFiles = append(status.StagedFiles, filename)
-		}
-
-		switch statusCode[1] {
-		case 'M':
-			status.ModifiedFiles = append(status.ModifiedFiles, filename)
-		case 'D':
-			status.DeletedFiles = append(status.DeletedFiles, filename)
-		case '?':
-			status.UntrackedFiles = append(status.UntrackedFiles, filename)
-		}
-
-		if statusCode[0] == 'R' {
-			// Renamed file
-			parts := strings.Split(filename, " -> ")
-			if len(parts) == 2 {
-				status.RenamedFiles[parts[0]] = parts[1]
-			}
-		}
-	}
-
-	return status, nil
+	return parseStatusV2(output), nil
 }
 
 // Merge merges a branch into current branch
@@ -1017,30 +1001,12 @@ func (g *CLIRepository) StashPop(ctx context.Context, index int) error {
 func (g *CLIRepository) ListStashes(ctx context.Context) ([]Stash, error) {
 	g.logger.Debug("listing stashes")
 
-	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "stash", "list", "--format=%gd|%gs|%gd|%gs")
+	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "stash", "list", "-z", "--format=%gd%x00%gs")
 	if err != nil {
 		return nil, err
 	}
 
-	var stashes []Stash
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	for i, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, "|")
-		if len(parts) >= 2 {
-			stash := Stash{
-				Index:   i,
-				Message: parts[1],
-				Branch:  "current", // Could be enhanced to parse actual branch
-			}
-			stashes = append(stashes, stash)
-		}
-	}
-
-	return stashes, nil
+	return parseStashes(output), nil
 }
 
 // ValidateGitInstallation checks if Git is installed and accessible
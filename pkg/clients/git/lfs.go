@@ -0,0 +1,129 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// lfsPointerVersion is the first line of every Git LFS pointer file
+const lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+
+// lfsPointerMaxSize bounds pointer files; anything larger is real content
+const lfsPointerMaxSize = 1024
+
+// LFSPointer is the small text file Git LFS stores in place of a large file
+type LFSPointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// LFSFile is a file tracked by Git LFS in the working tree
+type LFSFile struct {
+	Path       string `json:"path"`
+	OID        string `json:"oid"`
+	Downloaded bool   `json:"downloaded"`
+}
+
+// LFSRepository is implemented by repositories that support Git LFS
+type LFSRepository interface {
+	// LFSAvailable reports whether Git LFS is installed
+	LFSAvailable(ctx context.Context) bool
+
+	// LFSPull downloads and checks out the LFS objects for the given paths
+	LFSPull(ctx context.Context, paths ...string) error
+
+	// LFSFiles lists the files tracked by Git LFS at HEAD
+	LFSFiles(ctx context.Context) ([]LFSFile, error)
+}
+
+// ParseLFSPointer parses content as a Git LFS pointer file
+func ParseLFSPointer(content []byte) (LFSPointer, bool) {
+	if len(content) > lfsPointerMaxSize || !bytes.HasPrefix(content, []byte(lfsPointerVersion+"\n")) {
+		return LFSPointer{}, false
+	}
+
+	var pointer LFSPointer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), " ")
+		switch key {
+		case "oid":
+			pointer.OID = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return LFSPointer{}, false
+			}
+			pointer.Size = size
+		}
+	}
+
+	if pointer.OID == "" {
+		return LFSPointer{}, false
+	}
+	return pointer, true
+}
+
+// LFSAvailable reports whether the git-lfs extension is installed
+func (g *CLIRepository) LFSAvailable(ctx context.Context) bool {
+	// Run directly, as a failure here is expected and not worth logging as an error
+	return exec.CommandContext(ctx, "git", "lfs", "version").Run() == nil
+}
+
+// LFSPull downloads and checks out the LFS objects for the given paths
+func (g *CLIRepository) LFSPull(ctx context.Context, paths ...string) error {
+	g.logger.Debug("pulling LFS objects", "paths", paths)
+
+	if !g.repositoryExists() {
+		return &GitError{
+			Code:    ErrorCodeRepositoryNotFound,
+			Message: "repository not found",
+		}
+	}
+
+	args := []string{"lfs", "pull"}
+	if len(paths) > 0 {
+		args = append(args, "--include="+strings.Join(paths, ","), "--exclude=")
+	}
+	return g.executeGitCommand(ctx, g.repoPath, args...)
+}
+
+// LFSFiles lists the files tracked by Git LFS at HEAD and whether their content is downloaded
+func (g *CLIRepository) LFSFiles(ctx context.Context) ([]LFSFile, error) {
+	if !g.repositoryExists() {
+		return nil, &GitError{
+			Code:    ErrorCodeRepositoryNotFound,
+			Message: "repository not found",
+		}
+	}
+
+	output, err := g.executeGitCommandWithOutput(ctx, g.repoPath, "lfs", "ls-files", "--long")
+	if err != nil {
+		return nil, err
+	}
+	return parseLFSFiles(output), nil
+}
+
+// parseLFSFiles parses "git lfs ls-files --long" output, where "*" marks downloaded
+// objects and "-" objects that are only pointers:
+//
+//	4d7a2146...  * assets/diagrams/flow.png
+func parseLFSFiles(output string) []LFSFile {
+	files := []LFSFile{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		files = append(files, LFSFile{
+			OID:        fields[0],
+			Downloaded: fields[1] == "*",
+			Path:       fields[2],
+		})
+	}
+	return files
+}
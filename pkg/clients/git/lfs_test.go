@@ -0,0 +1,56 @@
+package git
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	pointer, ok := ParseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\n" +
+		"oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\n" +
+		"size 12345\n"))
+	require.True(t, ok)
+	assert.Equal(t, "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393", pointer.OID)
+	assert.Equal(t, int64(12345), pointer.Size)
+
+	for _, content := range []string{
+		"",
+		"# Template\n\nversion https://git-lfs.github.com/spec/v1\n",
+		"version https://git-lfs.github.com/spec/v1\nsize 10\n",
+		"version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize ten\n",
+	} {
+		_, ok := ParseLFSPointer([]byte(content))
+		assert.False(t, ok, content)
+	}
+}
+
+func TestParseLFSFiles(t *testing.T) {
+	files := parseLFSFiles("4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393 * assets/diagrams/flow.png\n" +
+		"9c2d0b1e7a0e4f3c5b6a7d8e9f0a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2 - assets/bin/tool name.zip\n")
+
+	assert.Equal(t, []LFSFile{
+		{Path: "assets/diagrams/flow.png", OID: "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393", Downloaded: true},
+		{Path: "assets/bin/tool name.zip", OID: "9c2d0b1e7a0e4f3c5b6a7d8e9f0a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2", Downloaded: false},
+	}, files)
+	assert.Empty(t, parseLFSFiles(""))
+}
+
+func TestCLIRepository_LFSFiles(t *testing.T) {
+	if err := exec.Command("git", "lfs", "version").Run(); err != nil {
+		t.Skip("git lfs is not installed")
+	}
+	dir := initTestRepository(t)
+	ctx := context.Background()
+
+	repo := NewCLIRepository(dir, logging.NewBasic(), nil, "")
+	assert.True(t, repo.LFSAvailable(ctx))
+
+	files, err := repo.LFSFiles(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -0,0 +1,783 @@
+package git
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/errors"
+	"github.com/daddia/zen/pkg/fs"
+	"github.com/go-git/go-billy/v5/osfs"
+	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// installFileTransport serves local remotes in-process. go-git's own file transport
+// runs git-upload-pack, which is missing wherever the git binary is.
+var installFileTransport sync.Once
+
+// localLoader opens local remotes for the in-process file transport. Unlike go-git's
+// server.DefaultLoader it reads non-bare repositories from their .git directory.
+type localLoader struct{}
+
+// Load returns the storage of the repository at the endpoint's path
+func (localLoader) Load(ep *transport.Endpoint) (storer.Storer, error) {
+	gitDir := ep.Path
+	if info, err := os.Stat(filepath.Join(gitDir, ".git")); err == nil && info.IsDir() {
+		gitDir = filepath.Join(gitDir, ".git")
+	}
+	if _, err := os.Stat(filepath.Join(gitDir, "config")); err != nil {
+		return nil, transport.ErrRepositoryNotFound
+	}
+	return filesystem.NewStorage(osfs.New(gitDir), cache.NewObjectLRUDefault()), nil
+}
+
+// NativeRepository implements the clone, fetch, pull and read operations of Repository
+// in-process with go-git, so it works without the git binary. Other operations return a
+// GitError with ErrorCodeConfigError that names the CLI backend.
+//
+// Partial clone filters are not supported, so clones download every blob; sparse paths
+// are checked out in cone mode and recorded in .git/info/sparse-checkout, where the git
+// binary finds them too. Git LFS files are left as pointers.
+type NativeRepository struct {
+	repoPath     string
+	logger       logging.Logger
+	auth         AuthProvider
+	authProvider string
+	sshKeyFile   string
+}
+
+// NewNativeRepository creates a go-git repository for repoPath
+func NewNativeRepository(repoPath string, logger logging.Logger, auth AuthProvider, authProvider string) *NativeRepository {
+	installFileTransport.Do(func() {
+		client.InstallProtocol("file", server.NewClient(localLoader{}))
+	})
+
+	return &NativeRepository{
+		repoPath:     repoPath,
+		logger:       logger,
+		auth:         auth,
+		authProvider: authProvider,
+	}
+}
+
+// SetSSHKeyFile sets the private key used for SSH remotes instead of the SSH agent
+func (g *NativeRepository) SetSSHKeyFile(path string) {
+	g.sshKeyFile = path
+}
+
+// Clone clones the repository to local cache
+func (g *NativeRepository) Clone(ctx context.Context, url, branch string, shallow bool) error {
+	return g.CloneWithOptions(ctx, url, CloneOptions{Branch: branch, Shallow: shallow})
+}
+
+// CloneWithOptions clones the repository to local cache, with only some paths checked
+// out when options.SparsePaths is set
+func (g *NativeRepository) CloneWithOptions(ctx context.Context, url string, options CloneOptions) error {
+	g.logger.Info("cloning repository with go-git", "url", sanitizeURL(url), "branch", options.Branch,
+		"shallow", options.Shallow, "sparse_paths", len(options.SparsePaths))
+
+	if err := os.MkdirAll(filepath.Dir(g.repoPath), 0755); err != nil {
+		return errors.Wrap(err, "failed to create repository parent directory")
+	}
+	if err := os.RemoveAll(g.repoPath); err != nil {
+		return errors.Wrap(err, "failed to remove existing repository directory")
+	}
+
+	auth, err := g.authMethod(url)
+	if err != nil {
+		return err
+	}
+
+	cloneOptions := &gogit.CloneOptions{
+		URL:        url,
+		Auth:       auth,
+		NoCheckout: len(options.SparsePaths) > 0,
+	}
+	if options.Branch != "" {
+		cloneOptions.ReferenceName = plumbing.NewBranchReferenceName(options.Branch)
+	}
+	if options.Shallow {
+		// As with git clone --depth, a shallow clone only fetches its branch
+		cloneOptions.Depth = 1
+		cloneOptions.SingleBranch = true
+	}
+	if options.Filter != "" {
+		g.logger.Debug("partial clone is not supported by go-git, fetching all blobs", "filter", options.Filter)
+	}
+
+	repo, err := gogit.PlainCloneContext(ctx, g.repoPath, false, cloneOptions)
+	if err != nil {
+		return errors.Wrap(nativeError("clone", err), "git clone failed")
+	}
+
+	if len(options.SparsePaths) > 0 {
+		if err := g.checkoutSparse(repo, options.SparsePaths); err != nil {
+			return errors.Wrap(err, "git sparse-checkout failed")
+		}
+	}
+
+	g.logger.Info("repository cloned successfully", "path", g.repoPath)
+	return nil
+}
+
+// Pull fast-forwards the current branch to its upstream. A sparse clone is reset to
+// the upstream commit, keeping its sparse paths.
+func (g *NativeRepository) Pull(ctx context.Context) error {
+	g.logger.Debug("pulling repository updates")
+
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return errors.Wrap(nativeError("pull", err), "git pull failed")
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return errors.Wrap(nativeError("pull", err), "git pull failed")
+	}
+
+	sparsePaths, err := readSparseCheckout(g.repoPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read sparse-checkout paths")
+	}
+
+	if len(sparsePaths) == 0 {
+		auth, err := g.remoteAuth(repo, "origin")
+		if err != nil {
+			return err
+		}
+		err = worktree.PullContext(ctx, &gogit.PullOptions{
+			RemoteName:    "origin",
+			ReferenceName: head.Name(),
+			SingleBranch:  true,
+			Auth:          auth,
+		})
+		if err != nil && err != gogit.NoErrAlreadyUpToDate {
+			return errors.Wrap(nativeError("pull", err), "git pull failed")
+		}
+	} else {
+		branch := head.Name().Short()
+		if err := g.fetch(ctx, repo, "origin", branch); err != nil {
+			return errors.Wrap(err, "git pull failed")
+		}
+		upstream, err := repo.Reference(plumbing.NewRemoteReferenceName("origin", branch), true)
+		if err != nil {
+			return errors.Wrap(nativeError("pull", err), "git pull failed")
+		}
+		err = worktree.ResetSparsely(&gogit.ResetOptions{Commit: upstream.Hash(), Mode: gogit.MergeReset}, sparsePaths)
+		if err != nil {
+			return errors.Wrap(nativeError("pull", err), "git pull failed")
+		}
+	}
+
+	g.logger.Debug("repository updated successfully")
+	return nil
+}
+
+// Fetch downloads objects and refs from remote
+func (g *NativeRepository) Fetch(ctx context.Context, remote string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+	return g.fetch(ctx, repo, remote)
+}
+
+// GetFile retrieves a file from the repository
+func (g *NativeRepository) GetFile(ctx context.Context, path string) ([]byte, error) {
+	if !g.repositoryExists() {
+		return nil, &GitError{
+			Code:    ErrorCodeRepositoryNotFound,
+			Message: "repository not found",
+		}
+	}
+	return readWorktreeFile(g.repoPath, path)
+}
+
+// ListFiles lists all files in the repository
+func (g *NativeRepository) ListFiles(ctx context.Context, pattern string) ([]string, error) {
+	if !g.repositoryExists() {
+		return nil, &GitError{
+			Code:    ErrorCodeRepositoryNotFound,
+			Message: "repository not found",
+		}
+	}
+	return listWorktreeFiles(g.repoPath, pattern)
+}
+
+// GetLastCommit returns the last commit hash
+func (g *NativeRepository) GetLastCommit(ctx context.Context) (string, error) {
+	repo, err := g.open()
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", errors.Wrap(nativeError("rev-parse", err), "failed to get commit hash")
+	}
+	return head.Hash().String(), nil
+}
+
+// IsClean returns true if the repository has no uncommitted changes
+func (g *NativeRepository) IsClean(ctx context.Context) (bool, error) {
+	repo, err := g.open()
+	if err != nil {
+		return false, err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return false, errors.Wrap(nativeError("status", err), "failed to check repository status")
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return false, errors.Wrap(nativeError("status", err), "failed to check repository status")
+	}
+	return status.IsClean(), nil
+}
+
+// ExecuteCommand runs the git commands the asset client needs in-process:
+// "fetch [<remote> [<branch>...]]", "rev-parse <revision>" and "show <revision>:<path>".
+// Other commands return a GitError with ErrorCodeConfigError.
+func (g *NativeRepository) ExecuteCommand(ctx context.Context, args ...string) (string, error) {
+	output, err := g.ExecuteCommandWithOutput(ctx, args...)
+	return string(output), err
+}
+
+// ExecuteCommandWithOutput runs the same commands as ExecuteCommand and returns raw bytes
+func (g *NativeRepository) ExecuteCommandWithOutput(ctx context.Context, args ...string) ([]byte, error) {
+	g.logger.Debug("executing git command with go-git", "args", sanitizeArgs(args))
+
+	repo, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return nil, nativeUnsupported("git " + strings.Join(args, " "))
+		}
+	}
+
+	switch {
+	case len(args) >= 1 && args[0] == "fetch":
+		remote := "origin"
+		if len(args) > 1 {
+			remote = args[1]
+		}
+		var branches []string
+		if len(args) > 2 {
+			branches = args[2:]
+		}
+		return nil, g.fetch(ctx, repo, remote, branches...)
+
+	case len(args) == 2 && args[0] == "rev-parse":
+		hash, err := repo.ResolveRevision(plumbing.Revision(args[1]))
+		if err != nil {
+			return nil, nativeError("rev-parse", err)
+		}
+		return []byte(hash.String() + "\n"), nil
+
+	case len(args) == 2 && args[0] == "show" && strings.Contains(args[1], ":"):
+		revision, file, _ := strings.Cut(args[1], ":")
+		return g.showFile(repo, revision, file)
+
+	default:
+		return nil, nativeUnsupported("git " + strings.Join(args, " "))
+	}
+}
+
+// GetCurrentBranch returns the current branch name, or "HEAD" when HEAD is detached
+func (g *NativeRepository) GetCurrentBranch(ctx context.Context) (string, error) {
+	repo, err := g.open()
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", nativeError("rev-parse", err)
+	}
+	if !head.Name().IsBranch() {
+		return "HEAD", nil
+	}
+	return head.Name().Short(), nil
+}
+
+// BranchExists reports whether a local branch exists
+func (g *NativeRepository) BranchExists(ctx context.Context, name string) (bool, error) {
+	repo, err := g.open()
+	if err != nil {
+		return false, err
+	}
+	_, err = repo.Reference(plumbing.NewBranchReferenceName(name), false)
+	if err == plumbing.ErrReferenceNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, nativeError("show-ref", err)
+	}
+	return true, nil
+}
+
+// ListRemotes lists the configured remotes
+func (g *NativeRepository) ListRemotes(ctx context.Context) ([]Remote, error) {
+	repo, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return nil, nativeError("remote", err)
+	}
+
+	var result []Remote
+	for _, remote := range remotes {
+		config := remote.Config()
+		url := ""
+		if len(config.URLs) > 0 {
+			url = config.URLs[0]
+		}
+		result = append(result, Remote{Name: config.Name, URL: url})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// Operations the native backend does not support
+
+// ExecuteCommandStream is not supported by the native backend
+func (g *NativeRepository) ExecuteCommandStream(ctx context.Context, args ...string) (io.ReadCloser, error) {
+	return nil, nativeUnsupported("git " + strings.Join(args, " "))
+}
+
+// CreateBranch is not supported by the native backend
+func (g *NativeRepository) CreateBranch(ctx context.Context, name string) error {
+	return nativeUnsupported("git branch")
+}
+
+// DeleteBranch is not supported by the native backend
+func (g *NativeRepository) DeleteBranch(ctx context.Context, name string, force bool) error {
+	return nativeUnsupported("git branch --delete")
+}
+
+// ListBranches is not supported by the native backend
+func (g *NativeRepository) ListBranches(ctx context.Context, remote bool) ([]Branch, error) {
+	return nil, nativeUnsupported("git branch --list")
+}
+
+// SwitchBranch is not supported by the native backend
+func (g *NativeRepository) SwitchBranch(ctx context.Context, name string) error {
+	return nativeUnsupported("git switch")
+}
+
+// CheckoutNewBranch is not supported by the native backend
+func (g *NativeRepository) CheckoutNewBranch(ctx context.Context, name, base string) error {
+	return nativeUnsupported("git checkout -b")
+}
+
+// AddWorktree is not supported by the native backend
+func (g *NativeRepository) AddWorktree(ctx context.Context, path, branch, base string) error {
+	return nativeUnsupported("git worktree add")
+}
+
+// RemoveWorktree is not supported by the native backend
+func (g *NativeRepository) RemoveWorktree(ctx context.Context, path string, force bool) error {
+	return nativeUnsupported("git worktree remove")
+}
+
+// ListWorktrees is not supported by the native backend
+func (g *NativeRepository) ListWorktrees(ctx context.Context) ([]Worktree, error) {
+	return nil, nativeUnsupported("git worktree list")
+}
+
+// Commit is not supported by the native backend
+func (g *NativeRepository) Commit(ctx context.Context, message string, files ...string) error {
+	return nativeUnsupported("git commit")
+}
+
+// GetCommitHistory is not supported by the native backend
+func (g *NativeRepository) GetCommitHistory(ctx context.Context, limit int) ([]Commit, error) {
+	return nil, nativeUnsupported("git log")
+}
+
+// ShowCommit is not supported by the native backend
+func (g *NativeRepository) ShowCommit(ctx context.Context, hash string) (CommitDetails, error) {
+	return CommitDetails{}, nativeUnsupported("git show")
+}
+
+// AddFiles is not supported by the native backend
+func (g *NativeRepository) AddFiles(ctx context.Context, files ...string) error {
+	return nativeUnsupported("git add")
+}
+
+// Merge is not supported by the native backend
+func (g *NativeRepository) Merge(ctx context.Context, branch string, strategy string) error {
+	return nativeUnsupported("git merge")
+}
+
+// Rebase is not supported by the native backend
+func (g *NativeRepository) Rebase(ctx context.Context, branch string, interactive bool) error {
+	return nativeUnsupported("git rebase")
+}
+
+// Stash is not supported by the native backend
+func (g *NativeRepository) Stash(ctx context.Context, message string) error {
+	return nativeUnsupported("git stash")
+}
+
+// StashPop is not supported by the native backend
+func (g *NativeRepository) StashPop(ctx context.Context, index int) error {
+	return nativeUnsupported("git stash pop")
+}
+
+// ListStashes is not supported by the native backend
+func (g *NativeRepository) ListStashes(ctx context.Context) ([]Stash, error) {
+	return nil, nativeUnsupported("git stash list")
+}
+
+// AddRemote is not supported by the native backend
+func (g *NativeRepository) AddRemote(ctx context.Context, name, url string) error {
+	return nativeUnsupported("git remote add")
+}
+
+// Push is not supported by the native backend
+func (g *NativeRepository) Push(ctx context.Context, remote, branch string) error {
+	return nativeUnsupported("git push")
+}
+
+// PushUpstream is not supported by the native backend
+func (g *NativeRepository) PushUpstream(ctx context.Context, remote, branch string) error {
+	return nativeUnsupported("git push --set-upstream")
+}
+
+// GetConfig is not supported by the native backend
+func (g *NativeRepository) GetConfig(ctx context.Context, key string) (string, error) {
+	return "", nativeUnsupported("git config")
+}
+
+// SetConfig is not supported by the native backend
+func (g *NativeRepository) SetConfig(ctx context.Context, key, value string, global bool) error {
+	return nativeUnsupported("git config")
+}
+
+// Diff is not supported by the native backend
+func (g *NativeRepository) Diff(ctx context.Context, options DiffOptions) (string, error) {
+	return "", nativeUnsupported("git diff")
+}
+
+// Log is not supported by the native backend
+func (g *NativeRepository) Log(ctx context.Context, options LogOptions) ([]Commit, error) {
+	return nil, nativeUnsupported("git log")
+}
+
+// Blame is not supported by the native backend
+func (g *NativeRepository) Blame(ctx context.Context, file string) ([]BlameLine, error) {
+	return nil, nativeUnsupported("git blame")
+}
+
+// DiffStream is not supported by the native backend
+func (g *NativeRepository) DiffStream(ctx context.Context, options DiffOptions) (io.ReadCloser, error) {
+	return nil, nativeUnsupported("git diff")
+}
+
+// LogStream is not supported by the native backend
+func (g *NativeRepository) LogStream(ctx context.Context, options LogOptions, fn func(Commit) error) error {
+	return nativeUnsupported("git log")
+}
+
+// BlameStream is not supported by the native backend
+func (g *NativeRepository) BlameStream(ctx context.Context, file string, fn func(BlameLine) error) error {
+	return nativeUnsupported("git blame")
+}
+
+// Tag is not supported by the native backend
+func (g *NativeRepository) Tag(ctx context.Context, name, message string) error {
+	return nativeUnsupported("git tag")
+}
+
+// ListTags is not supported by the native backend
+func (g *NativeRepository) ListTags(ctx context.Context) ([]Tag, error) {
+	return nil, nativeUnsupported("git tag --list")
+}
+
+// Status is not supported by the native backend
+func (g *NativeRepository) Status(ctx context.Context) (StatusInfo, error) {
+	return StatusInfo{}, nativeUnsupported("git status")
+}
+
+// Private helper methods
+
+func (g *NativeRepository) repositoryExists() bool {
+	_, err := os.Stat(filepath.Join(g.repoPath, ".git"))
+	return err == nil
+}
+
+func (g *NativeRepository) open() (*gogit.Repository, error) {
+	if !g.repositoryExists() {
+		return nil, &GitError{
+			Code:    ErrorCodeRepositoryNotFound,
+			Message: "repository not found",
+		}
+	}
+
+	repo, err := gogit.PlainOpen(g.repoPath)
+	if err != nil {
+		return nil, nativeError("open", err)
+	}
+	return repo, nil
+}
+
+// fetch updates the remote-tracking branches of remote, or only those of branches
+func (g *NativeRepository) fetch(ctx context.Context, repo *gogit.Repository, remote string, branches ...string) error {
+	auth, err := g.remoteAuth(repo, remote)
+	if err != nil {
+		return err
+	}
+
+	options := &gogit.FetchOptions{RemoteName: remote, Auth: auth}
+	for _, branch := range branches {
+		options.RefSpecs = append(options.RefSpecs,
+			gitconfig.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branch, remote, branch)))
+	}
+
+	err = repo.FetchContext(ctx, options)
+	if err != nil && err != gogit.NoErrAlreadyUpToDate {
+		return nativeError("fetch", err)
+	}
+	return nil
+}
+
+// showFile returns the content of file at revision
+func (g *NativeRepository) showFile(repo *gogit.Repository, revision, file string) ([]byte, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, nativeError("show", err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, nativeError("show", err)
+	}
+
+	blob, err := commit.File(file)
+	if err == object.ErrFileNotFound {
+		return nil, &GitError{
+			Code:    ErrorCodeFileNotFound,
+			Message: fmt.Sprintf("file '%s' not found in %s", file, revision),
+		}
+	}
+	if err != nil {
+		return nil, nativeError("show", err)
+	}
+
+	content, err := blob.Contents()
+	if err != nil {
+		return nil, nativeError("show", err)
+	}
+	return []byte(content), nil
+}
+
+// checkoutSparse checks out only paths of a clone made without a checkout, and records
+// them in cone mode so that later pulls, and the git binary, keep to them
+func (g *NativeRepository) checkoutSparse(repo *gogit.Repository, paths []string) error {
+	head, err := repo.Head()
+	if err != nil {
+		return nativeError("sparse-checkout", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nativeError("sparse-checkout", err)
+	}
+
+	err = worktree.Checkout(&gogit.CheckoutOptions{Branch: head.Name(), SparseCheckoutDirectories: paths})
+	if err != nil {
+		return nativeError("sparse-checkout", err)
+	}
+
+	config, err := repo.Config()
+	if err != nil {
+		return nativeError("sparse-checkout", err)
+	}
+	config.Raw.Section("core").SetOption("sparseCheckout", "true")
+	config.Raw.Section("core").SetOption("sparseCheckoutCone", "true")
+	if err := repo.SetConfig(config); err != nil {
+		return nativeError("sparse-checkout", err)
+	}
+
+	return writeSparseCheckout(g.repoPath, paths)
+}
+
+// remoteAuth authenticates for the URL of remote
+func (g *NativeRepository) remoteAuth(repo *gogit.Repository, remote string) (transport.AuthMethod, error) {
+	r, err := repo.Remote(remote)
+	if err != nil {
+		return nil, nativeError("remote", err)
+	}
+	if urls := r.Config().URLs; len(urls) > 0 {
+		return g.authMethod(urls[0])
+	}
+	return nil, nil
+}
+
+// authMethod authenticates as the CLI backend does, chosen by the scheme of url: SSH
+// with the configured key file or the SSH agent, and HTTPS with the provider token.
+// Without either, go-git's defaults apply.
+func (g *NativeRepository) authMethod(url string) (transport.AuthMethod, error) {
+	switch URLScheme(url) {
+	case SchemeSSH:
+		user := "git"
+		if endpoint, err := transport.NewEndpoint(url); err == nil && endpoint.User != "" {
+			user = endpoint.User
+		}
+
+		if g.sshKeyFile != "" {
+			keyFile, _ := fs.ExpandHome(g.sshKeyFile)
+			auth, err := gitssh.NewPublicKeysFromFile(user, keyFile, "")
+			if err != nil {
+				return nil, &GitError{
+					Code:    ErrorCodeAuthFailed,
+					Message: fmt.Sprintf("failed to load SSH key %s: %v", keyFile, err),
+				}
+			}
+			return auth, nil
+		}
+
+		auth, err := gitssh.NewSSHAgentAuth(user)
+		if err != nil {
+			g.logger.Debug("no SSH agent running and no git.ssh_key_file configured", "error", err)
+			return nil, nil
+		}
+		return auth, nil
+
+	case SchemeHTTPS:
+		username, token := tokenCredentials(g.auth, g.authProvider)
+		if token == "" {
+			g.logger.Debug("no token available for git operations", "provider", g.authProvider)
+			return nil, nil
+		}
+		return &githttp.BasicAuth{Username: username, Password: token}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// nativeError converts a go-git error to a GitError. The error text is kept as the
+// output, so OutputError and Retryable treat it as they treat the git binary's.
+func nativeError(operation string, err error) error {
+	var netErr net.Error
+	code := ErrorCodeCommandFailed
+	switch {
+	case stderrors.Is(err, transport.ErrAuthenticationRequired),
+		stderrors.Is(err, transport.ErrAuthorizationFailed),
+		stderrors.Is(err, transport.ErrInvalidAuthMethod):
+		code = ErrorCodeAuthFailed
+	case stderrors.Is(err, transport.ErrRepositoryNotFound):
+		code = ErrorCodeRepositoryNotFound
+	case stderrors.As(err, &netErr):
+		code = ErrorCodeNetworkError
+	}
+
+	return &GitError{
+		Code:    code,
+		Message: fmt.Sprintf("git %s failed: %v", operation, err),
+		Details: map[string]interface{}{
+			"command": []string{operation},
+			"output":  err.Error(),
+		},
+	}
+}
+
+// nativeUnsupported reports an operation the native backend does not implement
+func nativeUnsupported(operation string) error {
+	return &GitError{
+		Code:    ErrorCodeConfigError,
+		Message: fmt.Sprintf("%s is not supported by the native git backend: set git.backend to %q", operation, BackendCLI),
+	}
+}
+
+// writeSparseCheckout writes paths to .git/info/sparse-checkout in the cone mode
+// format of git sparse-checkout set
+func writeSparseCheckout(repoPath string, paths []string) error {
+	lines := []string{"/*", "!/*/"}
+	seen := map[string]bool{}
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+
+	for _, p := range sorted {
+		p = strings.Trim(filepath.ToSlash(p), "/")
+		// Parents of a nested path are included without their other subdirectories
+		parts := strings.Split(p, "/")
+		for i := 1; i < len(parts); i++ {
+			parent := strings.Join(parts[:i], "/")
+			if !seen[parent] {
+				seen[parent] = true
+				lines = append(lines, "/"+parent+"/", "!/"+parent+"/*/")
+			}
+		}
+		lines = append(lines, "/"+p+"/")
+	}
+
+	file := filepath.Join(repoPath, ".git", "info", "sparse-checkout")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+// readSparseCheckout returns the directories recorded in cone mode by
+// writeSparseCheckout or git sparse-checkout set, or nil when there are none
+func readSparseCheckout(repoPath string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(repoPath, ".git", "info", "sparse-checkout"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "/") || !strings.HasSuffix(line, "/") || line == "/" || strings.Contains(line, "*") {
+			continue
+		}
+		dirs = append(dirs, strings.Trim(line, "/"))
+	}
+
+	// Drop the parents that are only listed for nested directories
+	var result []string
+	for _, dir := range dirs {
+		parent := false
+		for _, other := range dirs {
+			if other != dir && strings.HasPrefix(other, dir+"/") {
+				parent = true
+				break
+			}
+		}
+		if !parent {
+			result = append(result, path.Clean(dir))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,267 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sourceRepository is a repository made with go-git, so tests need no git binary
+type sourceRepository struct {
+	t    *testing.T
+	path string
+	repo *gogit.Repository
+}
+
+func newSourceRepository(t *testing.T, files map[string]string) *sourceRepository {
+	t.Helper()
+	path := t.TempDir()
+	repo, err := gogit.PlainInit(path, false)
+	require.NoError(t, err)
+
+	source := &sourceRepository{t: t, path: path, repo: repo}
+	source.commit(files)
+	return source
+}
+
+// commit writes files and commits them, returning the commit hash
+func (s *sourceRepository) commit(files map[string]string) string {
+	s.t.Helper()
+	worktree, err := s.repo.Worktree()
+	require.NoError(s.t, err)
+
+	for name, content := range files {
+		file := filepath.Join(s.path, name)
+		require.NoError(s.t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(s.t, os.WriteFile(file, []byte(content), 0644))
+		_, err := worktree.Add(name)
+		require.NoError(s.t, err)
+	}
+
+	hash, err := worktree.Commit("update", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "Test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(s.t, err)
+	return hash.String()
+}
+
+func cloneSource(t *testing.T, options CloneOptions) (*sourceRepository, *NativeRepository) {
+	t.Helper()
+	source := newSourceRepository(t, map[string]string{
+		"assets/manifest.yaml":   "version: 1\n",
+		"docs/guide.md":          "# Guide\n",
+		"templates/task/spec.md": "# Spec\n",
+	})
+
+	repo := NewNativeRepository(filepath.Join(t.TempDir(), "clone"), logging.NewBasic(), nil, "")
+	require.NoError(t, repo.CloneWithOptions(context.Background(), source.path, options))
+	return source, repo
+}
+
+func TestNativeRepository_CloneAndRead(t *testing.T) {
+	source, repo := cloneSource(t, CloneOptions{Branch: "master"})
+	ctx := context.Background()
+
+	head, err := source.repo.Head()
+	require.NoError(t, err)
+	commit, err := repo.GetLastCommit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, head.Hash().String(), commit)
+
+	content, err := repo.GetFile(ctx, "docs/guide.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Guide\n", string(content))
+
+	files, err := repo.ListFiles(ctx, "md")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{filepath.Join("docs", "guide.md"), filepath.Join("templates", "task", "spec.md")}, files)
+
+	clean, err := repo.IsClean(ctx)
+	require.NoError(t, err)
+	assert.True(t, clean)
+
+	branch, err := repo.GetCurrentBranch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "master", branch)
+
+	remotes, err := repo.ListRemotes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Remote{{Name: "origin", URL: source.path}}, remotes)
+}
+
+func TestNativeRepository_Pull(t *testing.T) {
+	source, repo := cloneSource(t, CloneOptions{})
+	ctx := context.Background()
+
+	require.NoError(t, repo.Pull(ctx), "pulling without new commits is not an error")
+
+	latest := source.commit(map[string]string{"docs/new.md": "# New\n"})
+	require.NoError(t, repo.Pull(ctx))
+
+	commit, err := repo.GetLastCommit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, latest, commit)
+	content, err := repo.GetFile(ctx, "docs/new.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# New\n", string(content))
+}
+
+func TestNativeRepository_FetchAndShow(t *testing.T) {
+	source, repo := cloneSource(t, CloneOptions{})
+	ctx := context.Background()
+
+	latest := source.commit(map[string]string{"assets/manifest.yaml": "version: 2\n"})
+
+	_, err := repo.ExecuteCommand(ctx, "fetch", "origin", "master")
+	require.NoError(t, err)
+
+	commit, err := repo.ExecuteCommand(ctx, "rev-parse", "origin/master")
+	require.NoError(t, err)
+	assert.Equal(t, latest+"\n", commit)
+
+	content, err := repo.ExecuteCommandWithOutput(ctx, "show", "origin/master:assets/manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "version: 2\n", string(content))
+
+	content, err = repo.GetFile(ctx, "assets/manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "version: 1\n", string(content), "fetching leaves the working tree alone")
+
+	_, err = repo.ExecuteCommandWithOutput(ctx, "show", "origin/master:missing.yaml")
+	var gitErr *GitError
+	require.ErrorAs(t, err, &gitErr)
+	assert.Equal(t, ErrorCodeFileNotFound, gitErr.Code)
+
+	for _, args := range [][]string{{"gc"}, {"fetch", "--prune"}} {
+		_, err = repo.ExecuteCommand(ctx, args...)
+		require.ErrorAs(t, err, &gitErr)
+		assert.Equal(t, ErrorCodeConfigError, gitErr.Code)
+		assert.False(t, Retryable(err))
+	}
+}
+
+func TestNativeRepository_SparseClone(t *testing.T) {
+	source, repo := cloneSource(t, CloneOptions{SparsePaths: []string{"docs"}})
+	ctx := context.Background()
+
+	_, err := repo.GetFile(ctx, "docs/guide.md")
+	require.NoError(t, err)
+	_, err = repo.GetFile(ctx, "templates/task/spec.md")
+	assert.Error(t, err, "paths outside the sparse paths are not checked out")
+
+	paths, err := readSparseCheckout(repo.repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs"}, paths)
+
+	source.commit(map[string]string{"docs/guide.md": "# Guide v2\n", "templates/task/plan.md": "# Plan\n"})
+	require.NoError(t, repo.Pull(ctx))
+
+	content, err := repo.GetFile(ctx, "docs/guide.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Guide v2\n", string(content))
+	_, err = repo.GetFile(ctx, "templates/task/plan.md")
+	assert.Error(t, err, "pulls keep to the sparse paths")
+
+	clean, err := repo.IsClean(ctx)
+	require.NoError(t, err)
+	assert.True(t, clean)
+}
+
+func TestNativeRepository_Errors(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNativeRepository(filepath.Join(t.TempDir(), "clone"), logging.NewBasic(), nil, "")
+
+	_, err := repo.GetLastCommit(ctx)
+	var gitErr *GitError
+	require.ErrorAs(t, err, &gitErr)
+	assert.Equal(t, ErrorCodeRepositoryNotFound, gitErr.Code)
+	require.ErrorAs(t, repo.Pull(ctx), &gitErr)
+	assert.Equal(t, ErrorCodeRepositoryNotFound, gitErr.Code)
+
+	err = repo.Clone(ctx, filepath.Join(t.TempDir(), "missing"), "", false)
+	require.ErrorAs(t, err, &gitErr)
+	assert.Equal(t, ErrorCodeRepositoryNotFound, gitErr.Code)
+	assert.False(t, Retryable(err))
+
+	_, repo = cloneSource(t, CloneOptions{})
+	require.ErrorAs(t, repo.Push(ctx, "origin", "master"), &gitErr)
+	assert.Equal(t, ErrorCodeConfigError, gitErr.Code)
+	assert.Contains(t, gitErr.Message, `set git.backend to "cli"`)
+}
+
+func TestNativeRepository_AuthMethod(t *testing.T) {
+	auth := newMockAuthProvider()
+	auth.credentials["github"] = "gh-token"
+	auth.credentials["gitlab"] = "gl-token"
+
+	method, err := NewNativeRepository(t.TempDir(), logging.NewBasic(), auth, "github").authMethod("https://github.com/org/repo.git")
+	require.NoError(t, err)
+	assert.Equal(t, &githttp.BasicAuth{Username: "x-access-token", Password: "gh-token"}, method)
+
+	method, err = NewNativeRepository(t.TempDir(), logging.NewBasic(), auth, "gitlab").authMethod("https://gitlab.com/org/repo.git")
+	require.NoError(t, err)
+	assert.Equal(t, &githttp.BasicAuth{Username: "oauth2", Password: "gl-token"}, method)
+
+	method, err = NewNativeRepository(t.TempDir(), logging.NewBasic(), nil, "").authMethod("https://github.com/org/repo.git")
+	require.NoError(t, err)
+	assert.Nil(t, method, "without a token go-git's defaults apply")
+
+	method, err = NewNativeRepository(t.TempDir(), logging.NewBasic(), auth, "github").authMethod("/srv/git/repo.git")
+	require.NoError(t, err)
+	assert.Nil(t, method, "local remotes need no authentication")
+
+	repo := NewNativeRepository(t.TempDir(), logging.NewBasic(), nil, "")
+	repo.SetSSHKeyFile(filepath.Join(t.TempDir(), "missing_key"))
+	_, err = repo.authMethod("git@github.com:org/repo.git")
+	var gitErr *GitError
+	require.ErrorAs(t, err, &gitErr)
+	assert.Equal(t, ErrorCodeAuthFailed, gitErr.Code)
+}
+
+func TestSparseCheckoutFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0755))
+
+	paths, err := readSparseCheckout(dir)
+	require.NoError(t, err)
+	assert.Nil(t, paths)
+
+	require.NoError(t, writeSparseCheckout(dir, []string{"templates/task", "docs"}))
+	content, err := os.ReadFile(filepath.Join(dir, ".git", "info", "sparse-checkout"))
+	require.NoError(t, err)
+	assert.Equal(t, "/*\n!/*/\n/docs/\n/templates/\n!/templates/*/\n/templates/task/\n", string(content))
+
+	paths, err = readSparseCheckout(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "templates/task"}, paths)
+}
+
+func TestNewRepository_WithoutGitBinary(t *testing.T) {
+	// An empty PATH hides the git binary from the real lookup
+	t.Setenv("PATH", t.TempDir())
+	require.False(t, CLIAvailable())
+
+	source := newSourceRepository(t, map[string]string{"assets/manifest.yaml": "version: 1\n"})
+	cfg := Config{Backend: BackendCLI, SSHKeyFile: "~/.ssh/assets_key"}
+
+	repo, err := NewRepository(cfg, filepath.Join(t.TempDir(), "clone"), logging.NewBasic(), nil, "")
+	require.NoError(t, err)
+	require.IsType(t, &NativeRepository{}, repo, "the native backend replaces the missing git binary")
+	assert.Equal(t, "~/.ssh/assets_key", repo.(*NativeRepository).sshKeyFile)
+
+	ctx := context.Background()
+	require.NoError(t, repo.CloneWithOptions(ctx, source.path, CloneOptions{Branch: "master"}))
+	content, err := repo.GetFile(ctx, "assets/manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "version: 1\n", string(content))
+	require.NoError(t, repo.Pull(ctx))
+}
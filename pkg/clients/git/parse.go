@@ -0,0 +1,221 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logFields is the number of NUL-separated fields logFormat produces per commit
+const logFields = 6
+
+// logFormat writes NUL-separated fields, and with -z git also ends each commit with a
+// NUL. Commit messages cannot contain NUL, so any subject or body parses.
+const logFormat = "--format=%H%x00%an%x00%ae%x00%aI%x00%s%x00%b"
+
+// parseLogRecord parses the fields of one commit written with logFormat
+func parseLogRecord(fields []string) (Commit, bool) {
+	if len(fields) != logFields || len(fields[0]) < 8 {
+		return Commit{}, false
+	}
+
+	date, _ := time.Parse(time.RFC3339, fields[3])
+	return Commit{
+		Hash:      fields[0],
+		Author:    fields[1],
+		Email:     fields[2],
+		Date:      date,
+		Message:   fields[4],
+		Body:      strings.TrimSpace(fields[5]),
+		ShortHash: fields[0][:8],
+	}, true
+}
+
+// parseLog parses "git log -z" output written with logFormat
+func parseLog(output string) []Commit {
+	var commits []Commit
+	scanner := newStreamScanner(strings.NewReader(output), splitNULRecords(logFields))
+	for scanner.Scan() {
+		if commit, ok := parseLogRecord(strings.Split(scanner.Text(), "\x00")); ok {
+			commits = append(commits, commit)
+		}
+	}
+	return commits
+}
+
+// splitNULRecords splits -z output into records of n NUL-terminated fields, returned
+// joined by NUL without the final terminator
+func splitNULRecords(n int) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		end := 0
+		for i := 0; i < n; i++ {
+			j := bytes.IndexByte(data[end:], 0)
+			if j < 0 {
+				if atEOF && len(data) > 0 {
+					// A truncated record is returned whole and fails to parse
+					return len(data), data, nil
+				}
+				return 0, nil, nil
+			}
+			end += j + 1
+		}
+		return end, data[:end-1], nil
+	}
+}
+
+// parseStatusV2 parses "git status --porcelain=v2 -z --branch" output:
+//
+//	# branch.head <name>
+//	# branch.upstream <upstream>
+//	# branch.ab +<ahead> -<behind>
+//	1 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <path>
+//	2 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <score> <path>NUL<origPath>
+//	u <XY> <sub> <m1> <m2> <m3> <mW> <h1> <h2> <h3> <path>
+//	? <path>
+//	! <path>
+//
+// Every entry ends with NUL, and paths are written as is, so paths with spaces,
+// quotes, arrows, or newlines parse.
+func parseStatusV2(output string) StatusInfo {
+	status := StatusInfo{
+		Clean:           true,
+		StagedFiles:     []string{},
+		ModifiedFiles:   []string{},
+		UntrackedFiles:  []string{},
+		DeletedFiles:    []string{},
+		ConflictedFiles: []string{},
+		RenamedFiles:    make(map[string]string),
+	}
+
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
+			continue
+		}
+
+		switch entry[0] {
+		case '#':
+			parseStatusHeader(&status, entry)
+
+		case '1':
+			fields := strings.SplitN(entry, " ", 9)
+			if len(fields) != 9 {
+				continue
+			}
+			status.Clean = false
+			addStatusChange(&status, fields[1], fields[8])
+
+		case '2':
+			// The original path of a rename or copy is the next entry
+			fields := strings.SplitN(entry, " ", 10)
+			if len(fields) != 10 || i+1 >= len(entries) {
+				continue
+			}
+			i++
+			status.Clean = false
+			path, origPath := fields[9], entries[i]
+			if fields[1][0] == 'R' {
+				status.RenamedFiles[origPath] = path
+			}
+			addStatusChange(&status, fields[1], path)
+
+		case 'u':
+			fields := strings.SplitN(entry, " ", 11)
+			if len(fields) != 11 {
+				continue
+			}
+			status.Clean = false
+			status.ConflictedFiles = append(status.ConflictedFiles, fields[10])
+
+		case '?':
+			status.Clean = false
+			status.UntrackedFiles = append(status.UntrackedFiles, strings.TrimPrefix(entry, "? "))
+		}
+	}
+
+	return status
+}
+
+func parseStatusHeader(status *StatusInfo, header string) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return
+	}
+
+	switch fields[1] {
+	case "branch.head":
+		if fields[2] == "(detached)" {
+			status.Branch = "HEAD"
+		} else {
+			status.Branch = fields[2]
+		}
+	case "branch.upstream":
+		status.Upstream = fields[2]
+	case "branch.ab":
+		if len(fields) == 4 {
+			status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "+"))
+			status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[3], "-"))
+		}
+	}
+}
+
+// addStatusChange records a changed path by its XY code: X is the staged change and Y
+// the change in the working tree, with "." for unchanged
+func addStatusChange(status *StatusInfo, xy, path string) {
+	if len(xy) != 2 {
+		return
+	}
+
+	switch xy[0] {
+	case 'A', 'M', 'D':
+		status.StagedFiles = append(status.StagedFiles, path)
+	}
+
+	switch xy[1] {
+	case 'M':
+		status.ModifiedFiles = append(status.ModifiedFiles, path)
+	case 'D':
+		status.DeletedFiles = append(status.DeletedFiles, path)
+	}
+}
+
+// parseStashes parses "git stash list -z" output written with "--format=%gd%x00%gs"
+func parseStashes(output string) []Stash {
+	var stashes []Stash
+	scanner := newStreamScanner(strings.NewReader(output), splitNULRecords(2))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\x00")
+		if len(fields) != 2 {
+			continue
+		}
+
+		// The reflog selector is "stash@{N}"
+		index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(fields[0], "stash@{"), "}"))
+		if err != nil {
+			continue
+		}
+
+		stashes = append(stashes, Stash{
+			Index:   index,
+			Message: fields[1],
+			Branch:  stashBranch(fields[1]),
+		})
+	}
+	return stashes
+}
+
+// stashBranch returns the branch from a stash subject such as "On main: message" or
+// "WIP on main: abc1234 subject"
+func stashBranch(subject string) string {
+	for _, prefix := range []string{"WIP on ", "On "} {
+		if rest, ok := strings.CutPrefix(subject, prefix); ok {
+			if branch, _, found := strings.Cut(rest, ": "); found {
+				return branch
+			}
+		}
+	}
+	return "current"
+}
@@ -0,0 +1,179 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	hashA = "a46a68c9eebdab572a32d4b79b41f9e3d01e28f4"
+	hashB = "ae715eb20d001b566256a1a7888b38594afea87e"
+)
+
+func TestParseLog(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []Commit
+	}{
+		{
+			name:   "empty",
+			output: "",
+			want:   nil,
+		},
+		{
+			name:   "subject with delimiters",
+			output: hashA + "\x00Ann\x00ann@example.com\x002026-10-16T12:19:23+02:00\x00fix: a | b -> c \x1f\x1e\x00\x00",
+			want: []Commit{{
+				Hash: hashA, ShortHash: hashA[:8], Author: "Ann", Email: "ann@example.com",
+				Date:    time.Date(2026, 10, 16, 10, 19, 23, 0, time.UTC),
+				Message: "fix: a | b -> c \x1f\x1e",
+			}},
+		},
+		{
+			name: "multi-line bodies and empty bodies",
+			output: hashA + "\x00Ann Lee\x00ann@example.com\x002026-10-16T12:19:23+00:00\x00two\x00\x00" +
+				hashB + "\x00Bo\x00bo@example.com\x002026-10-15T08:00:00+00:00\x00one\x00Refs PROJ-1\n\n| table | row |\n\x00",
+			want: []Commit{
+				{
+					Hash: hashA, ShortHash: hashA[:8], Author: "Ann Lee", Email: "ann@example.com",
+					Date: time.Date(2026, 10, 16, 12, 19, 23, 0, time.UTC), Message: "two",
+				},
+				{
+					Hash: hashB, ShortHash: hashB[:8], Author: "Bo", Email: "bo@example.com",
+					Date: time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC), Message: "one",
+					Body: "Refs PROJ-1\n\n| table | row |",
+				},
+			},
+		},
+		{
+			name:   "truncated record",
+			output: hashA + "\x00Ann\x00ann@example.com\x00",
+			want:   nil,
+		},
+		{
+			name:   "short hash",
+			output: "abc\x00Ann\x00ann@example.com\x002026-10-16T12:19:23+00:00\x00subject\x00\x00",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits := parseLog(tt.output)
+			require.Len(t, commits, len(tt.want))
+			for i := range tt.want {
+				assert.True(t, tt.want[i].Date.Equal(commits[i].Date), "date")
+				commits[i].Date = tt.want[i].Date
+				assert.Equal(t, tt.want[i], commits[i])
+			}
+		})
+	}
+}
+
+func TestParseStatusV2(t *testing.T) {
+	output := "# branch.oid " + hashA + "\x00" +
+		"# branch.head feat/PROJ-1\x00" +
+		"# branch.upstream origin/feat/PROJ-1\x00" +
+		"# branch.ab +2 -3\x00" +
+		"1 M. N... 100644 100644 100644 " + hashA + " " + hashB + " staged file.md\x00" +
+		"1 .M N... 100644 100644 100644 " + hashA + " " + hashA + " docs/a -> b.md\x00" +
+		"1 .D N... 100644 100644 000000 " + hashA + " " + hashA + " gone.txt\x00" +
+		"1 A. N... 000000 100644 100644 " + "0000000000000000000000000000000000000000" + " " + hashB + " new\nline.txt\x00" +
+		"2 R. N... 100644 100644 100644 " + hashA + " " + hashA + " R100 new name.md\x00old name.md\x00" +
+		"2 C. N... 100644 100644 100644 " + hashA + " " + hashA + " C75 copy.md\x00orig.md\x00" +
+		"u UU N... 100644 100644 100644 100644 " + hashA + " " + hashB + " " + hashA + " conflict \"quoted\".go\x00" +
+		"? untracked dir/ü.txt\x00" +
+		"! ignored.log\x00"
+
+	status := parseStatusV2(output)
+
+	assert.Equal(t, "feat/PROJ-1", status.Branch)
+	assert.Equal(t, "origin/feat/PROJ-1", status.Upstream)
+	assert.Equal(t, 2, status.Ahead)
+	assert.Equal(t, 3, status.Behind)
+	assert.False(t, status.Clean)
+	assert.Equal(t, []string{"staged file.md", "new\nline.txt"}, status.StagedFiles)
+	assert.Equal(t, []string{"docs/a -> b.md"}, status.ModifiedFiles)
+	assert.Equal(t, []string{"gone.txt"}, status.DeletedFiles)
+	assert.Equal(t, map[string]string{"old name.md": "new name.md"}, status.RenamedFiles)
+	assert.Equal(t, []string{"conflict \"quoted\".go"}, status.ConflictedFiles)
+	assert.Equal(t, []string{"untracked dir/ü.txt"}, status.UntrackedFiles)
+}
+
+func TestParseStatusV2_Branches(t *testing.T) {
+	tests := map[string]string{
+		"# branch.oid (initial)\x00# branch.head main\x00":                   "main",
+		"# branch.oid " + hashA + "\x00# branch.head (detached)\x00":         "HEAD",
+		"# branch.oid " + hashA + "\x00# branch.head release/1.2|hotfix\x00": "release/1.2|hotfix",
+	}
+
+	for output, branch := range tests {
+		status := parseStatusV2(output)
+		assert.Equal(t, branch, status.Branch)
+		assert.True(t, status.Clean)
+		assert.Empty(t, status.Upstream)
+		assert.Zero(t, status.Ahead)
+		assert.NotNil(t, status.StagedFiles)
+	}
+}
+
+func TestParseStashes(t *testing.T) {
+	stashes := parseStashes("stash@{0}\x00On feat/PROJ-1: wip | half done\x00stash@{1}\x00WIP on main: " + hashA[:7] + " initial\x00")
+
+	assert.Equal(t, []Stash{
+		{Index: 0, Message: "On feat/PROJ-1: wip | half done", Branch: "feat/PROJ-1"},
+		{Index: 1, Message: "WIP on main: " + hashA[:7] + " initial", Branch: "main"},
+	}, stashes)
+	assert.Empty(t, parseStashes(""))
+}
+
+func TestCLIRepository_Status(t *testing.T) {
+	dir := initTestRepository(t)
+	ctx := context.Background()
+	repo := NewCLIRepository(dir, logging.NewBasic(), nil, "")
+
+	status, err := repo.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "main", status.Branch)
+	assert.True(t, status.Clean)
+
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	write("a -> b.md", "one")
+	write("old name.md", "a file with enough content to be detected as renamed")
+	require.NoError(t, repo.Commit(ctx, "add files", "a -> b.md", "old name.md"))
+
+	write("a -> b.md", "two")
+	write("untracked ü.txt", "new")
+	out, err := exec.Command("git", "-C", dir, "mv", "old name.md", "new name.md").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	status, err = repo.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.Clean)
+	assert.Equal(t, []string{"a -> b.md"}, status.ModifiedFiles)
+	assert.Equal(t, map[string]string{"old name.md": "new name.md"}, status.RenamedFiles)
+	assert.Equal(t, []string{"untracked ü.txt"}, status.UntrackedFiles)
+
+	// A commit subject with delimiters round-trips through ShowCommit and history
+	require.NoError(t, repo.Commit(ctx, "rename | move -> done", "."))
+	history, err := repo.GetCommitHistory(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "rename | move -> done", history[0].Message)
+
+	details, err := repo.ShowCommit(ctx, history[0].Hash)
+	require.NoError(t, err)
+	assert.Equal(t, "rename | move -> done", details.Commit.Message)
+	assert.Contains(t, details.Diff, "new name.md")
+}
@@ -0,0 +1,298 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxStreamStderr bounds the stderr kept from a streamed command for error reporting
+const maxStreamStderr = 64 * 1024
+
+// maxStreamRecord bounds a single log record or blame line read from a stream
+const maxStreamRecord = 16 * 1024 * 1024
+
+// commandStream reads the standard output of a running git command. Close waits for
+// the command to exit and reports its failure, if any.
+type commandStream struct {
+	ctx    context.Context
+	g      *CLIRepository
+	args   []string
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+	stderr *limitedBuffer
+
+	eof       bool
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// ExecuteCommandStream starts any Git command and returns its standard output as it is
+// produced, so large output is never held in memory. The caller must Close the stream;
+// cancelling ctx kills the git process.
+func (g *CLIRepository) ExecuteCommandStream(ctx context.Context, args ...string) (io.ReadCloser, error) {
+	g.logger.Debug("executing generic git command as a stream", "args", sanitizeArgs(args))
+
+	if !g.repositoryExists() {
+		return nil, &GitError{
+			Code:    ErrorCodeRepositoryNotFound,
+			Message: "repository not found",
+		}
+	}
+
+	return g.startGitStream(ctx, g.repoPath, args...)
+}
+
+func (g *CLIRepository) startGitStream(ctx context.Context, workDir string, args ...string) (*commandStream, error) {
+	cmd := g.gitCommand(ctx, workDir, args...)
+	stderr := &limitedBuffer{limit: maxStreamStderr}
+	cmd.Stderr = stderr
+
+	// Give the process a moment to exit after being killed before Wait gives up on it
+	cmd.WaitDelay = 5 * time.Second
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, &GitError{Code: ErrorCodeCommandFailed, Message: fmt.Sprintf("git command failed: %v", err)}
+	}
+
+	g.logger.Debug("starting git command stream", "args", sanitizeArgs(args), "workdir", workDir)
+	if err := cmd.Start(); err != nil {
+		return nil, &GitError{
+			Code:    ErrorCodeCommandFailed,
+			Message: fmt.Sprintf("git command failed: %v", err),
+			Details: map[string]interface{}{"command": sanitizeArgs(args)},
+		}
+	}
+
+	return &commandStream{ctx: ctx, g: g, args: args, cmd: cmd, stdout: stdout, stderr: stderr}, nil
+}
+
+// Read reads the command's standard output
+func (s *commandStream) Read(p []byte) (int, error) {
+	n, err := s.stdout.Read(p)
+	if err == io.EOF {
+		s.eof = true
+	}
+	return n, err
+}
+
+// Close stops reading and waits for the command to exit. A command closed before its
+// output was read to the end is not treated as failed.
+func (s *commandStream) Close() error {
+	s.closeOnce.Do(func() {
+		s.stdout.Close()
+		err := s.cmd.Wait()
+
+		switch {
+		case s.ctx.Err() != nil:
+			s.closeErr = s.ctx.Err()
+		case err != nil && s.eof:
+			s.g.logger.Error("git command failed",
+				"args", sanitizeArgs(s.args),
+				"error", err,
+				"output", s.stderr.String())
+
+			s.closeErr = &GitError{
+				Code:    ErrorCodeCommandFailed,
+				Message: fmt.Sprintf("git command failed: %v", err),
+				Details: map[string]interface{}{
+					"command": sanitizeArgs(s.args),
+					"output":  s.stderr.String(),
+				},
+			}
+		default:
+			s.g.logger.Debug("git command stream closed", "args", sanitizeArgs(s.args))
+		}
+	})
+	return s.closeErr
+}
+
+// DiffStream streams the diff for the given options
+func (g *CLIRepository) DiffStream(ctx context.Context, options DiffOptions) (io.ReadCloser, error) {
+	g.logger.Debug("streaming diff", "options", options)
+	return g.startGitStream(ctx, g.repoPath, diffArgs(options)...)
+}
+
+// LogStream calls fn for each commit matching the options as git produces it. Returning
+// an error from fn stops the log and returns that error.
+func (g *CLIRepository) LogStream(ctx context.Context, options LogOptions, fn func(Commit) error) error {
+	g.logger.Debug("streaming log", "options", options)
+
+	stream, err := g.startGitStream(ctx, g.repoPath, logArgs(options)...)
+	if err != nil {
+		return err
+	}
+
+	scanner := newStreamScanner(stream, splitNULRecords(logFields))
+	for scanner.Scan() {
+		commit, ok := parseLogRecord(strings.Split(scanner.Text(), "\x00"))
+		if !ok {
+			continue
+		}
+		if err := fn(commit); err != nil {
+			stream.Close()
+			return err
+		}
+	}
+
+	return closeStream(stream, scanner.Err())
+}
+
+// BlameStream calls fn for each line of the file's blame as git produces it. Returning
+// an error from fn stops the blame and returns that error.
+func (g *CLIRepository) BlameStream(ctx context.Context, file string, fn func(BlameLine) error) error {
+	g.logger.Debug("streaming blame", "file", file)
+
+	stream, err := g.startGitStream(ctx, g.repoPath, "blame", "-l", file)
+	if err != nil {
+		return err
+	}
+
+	scanner := newStreamScanner(stream, bufio.ScanLines)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line, ok := parseBlameLine(scanner.Text(), lineNum, file)
+		if !ok {
+			continue
+		}
+		if err := fn(line); err != nil {
+			stream.Close()
+			return err
+		}
+	}
+
+	return closeStream(stream, scanner.Err())
+}
+
+func diffArgs(options DiffOptions) []string {
+	args := []string{"diff"}
+
+	if options.Staged {
+		args = append(args, "--cached")
+	}
+	if options.Context > 0 {
+		args = append(args, fmt.Sprintf("-%d", options.Context))
+	}
+	if options.Commit != "" {
+		args = append(args, options.Commit)
+	}
+	if options.BaseCommit != "" {
+		args = append(args, options.BaseCommit)
+	}
+	if len(options.Files) > 0 {
+		args = append(args, "--")
+		args = append(args, options.Files...)
+	}
+
+	return args
+}
+
+func logArgs(options LogOptions) []string {
+	args := []string{"log", "-z", logFormat}
+
+	if options.Limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", options.Limit))
+	}
+	if options.Since != "" {
+		args = append(args, "--since", options.Since)
+	}
+	if options.Until != "" {
+		args = append(args, "--until", options.Until)
+	}
+	if options.Author != "" {
+		args = append(args, "--author", options.Author)
+	}
+	if options.Grep != "" {
+		args = append(args, "--grep", options.Grep)
+	}
+	if options.Oneline {
+		args = append(args, "--oneline")
+	}
+	if options.Graph {
+		args = append(args, "--graph")
+	}
+	if options.All {
+		args = append(args, "--all")
+	}
+	if options.NoMerges {
+		args = append(args, "--no-merges")
+	}
+	if options.Range != "" {
+		args = append(args, options.Range)
+	}
+	if len(options.Files) > 0 {
+		args = append(args, "--")
+		args = append(args, options.Files...)
+	}
+
+	return args
+}
+
+// parseBlameLine parses a line of "git blame -l" output: commit author date content
+func parseBlameLine(line string, lineNum int, file string) (BlameLine, bool) {
+	if line == "" {
+		return BlameLine{}, false
+	}
+
+	parts := strings.Split(line, " ")
+	if len(parts) < 4 {
+		return BlameLine{}, false
+	}
+
+	return BlameLine{
+		Commit:   parts[0],
+		Author:   parts[1],
+		Date:     parts[2],
+		LineNum:  lineNum,
+		Content:  strings.Join(parts[3:], " "),
+		Filename: file,
+	}, true
+}
+
+func newStreamScanner(r io.Reader, split bufio.SplitFunc) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxStreamRecord)
+	scanner.Split(split)
+	return scanner
+}
+
+// closeStream closes a stream that was read to the end, preferring the command's
+// failure over a read error it caused
+func closeStream(stream io.Closer, readErr error) error {
+	if err := stream.Close(); err != nil {
+		return err
+	}
+	if readErr != nil {
+		return &GitError{Code: ErrorCodeCommandFailed, Message: fmt.Sprintf("failed to read git output: %v", readErr)}
+	}
+	return nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room > 0 {
+		if len(p) > room {
+			b.buf.Write(p[:room])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,136 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLIRepository_LogStream(t *testing.T) {
+	dir := initTestRepository(t)
+	ctx := context.Background()
+	for i := 1; i <= 20; i++ {
+		out, err := exec.Command("git", "-C", dir, "commit", "-q", "--allow-empty", "-m", fmt.Sprintf("change %d", i), "-m", "body\x1fwith | separators").CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	repo := NewCLIRepository(dir, logging.NewBasic(), nil, "")
+
+	var subjects []string
+	err := repo.LogStream(ctx, LogOptions{}, func(commit Commit) error {
+		subjects = append(subjects, commit.Message)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, subjects, 21)
+	assert.Equal(t, "change 20", subjects[0])
+	assert.Equal(t, "initial", subjects[20])
+
+	// Stopping early returns the callback's error and does not report the killed git as failed
+	errStop := errors.New("stop")
+	seen := 0
+	err = repo.LogStream(ctx, LogOptions{}, func(commit Commit) error {
+		seen++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, seen)
+
+	// Log collects the same commits
+	commits, err := repo.Log(ctx, LogOptions{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "body\x1fwith | separators", commits[0].Body)
+}
+
+func TestCLIRepository_ExecuteCommandStream(t *testing.T) {
+	dir := initTestRepository(t)
+	repo := NewCLIRepository(dir, logging.NewBasic(), nil, "")
+
+	stream, err := repo.ExecuteCommandStream(context.Background(), "rev-parse", "--abbrev-ref", "HEAD")
+	require.NoError(t, err)
+	out, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+	assert.Equal(t, "main\n", string(out))
+
+	// Failures are reported with git's error output when the stream is closed
+	stream, err = repo.ExecuteCommandStream(context.Background(), "show", "no-such-ref")
+	require.NoError(t, err)
+	_, _ = io.ReadAll(stream)
+	err = stream.Close()
+	var gitErr *GitError
+	require.ErrorAs(t, err, &gitErr)
+	assert.Equal(t, ErrorCodeCommandFailed, gitErr.Code)
+	assert.Contains(t, gitErr.Details.(map[string]interface{})["output"], "no-such-ref")
+
+	_, err = NewCLIRepository(t.TempDir(), logging.NewBasic(), nil, "").ExecuteCommandStream(context.Background(), "log")
+	require.ErrorAs(t, err, &gitErr)
+	assert.Equal(t, ErrorCodeRepositoryNotFound, gitErr.Code)
+}
+
+func TestCLIRepository_ExecuteCommandStream_Cancel(t *testing.T) {
+	dir := initTestRepository(t)
+	repo := NewCLIRepository(dir, logging.NewBasic(), nil, "")
+
+	// A blob far larger than the pipe buffer keeps git blocked writing until it is killed
+	large := filepath.Join(t.TempDir(), "large.bin")
+	require.NoError(t, os.WriteFile(large, make([]byte, 8*1024*1024), 0644))
+	out, err := exec.Command("git", "-C", dir, "hash-object", "-w", large).Output()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := repo.ExecuteCommandStream(ctx, "cat-file", "blob", strings.TrimSpace(string(out)))
+	require.NoError(t, err)
+
+	buf := make([]byte, 1024)
+	_, err = io.ReadFull(stream, buf)
+	require.NoError(t, err)
+
+	cancel()
+	assert.ErrorIs(t, stream.Close(), context.Canceled)
+	assert.False(t, stream.(*commandStream).cmd.ProcessState.Success())
+}
+
+func TestCLIRepository_DiffAndBlameStream(t *testing.T) {
+	dir := initTestRepository(t)
+	ctx := context.Background()
+	repo := NewCLIRepository(dir, logging.NewBasic(), nil, "")
+
+	file := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(file, []byte("one\ntwo\n"), 0644))
+	require.NoError(t, repo.Commit(ctx, "add notes", "notes.txt"))
+	require.NoError(t, os.WriteFile(file, []byte("one\ntwo\nthree\n"), 0644))
+
+	stream, err := repo.DiffStream(ctx, DiffOptions{})
+	require.NoError(t, err)
+	var added []string
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+			added = append(added, line)
+		}
+	}
+	require.NoError(t, stream.Close())
+	assert.Equal(t, []string{"+three"}, added)
+
+	diff, err := repo.Diff(ctx, DiffOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+three")
+
+	lines, err := repo.Blame(ctx, "notes.txt")
+	require.NoError(t, err)
+	require.Len(t, lines, 3)
+	assert.Equal(t, 2, lines[1].LineNum)
+	assert.Equal(t, "notes.txt", lines[2].Filename)
+}
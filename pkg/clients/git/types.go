@@ -1,7 +1,9 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -16,6 +18,14 @@ type Branch struct {
 	Message   string `json:"message"`
 }
 
+// Worktree represents a Git worktree
+type Worktree struct {
+	Path     string `json:"path"`
+	Branch   string `json:"branch"`
+	Commit   string `json:"commit"`
+	Detached bool   `json:"detached"`
+}
+
 // Commit represents a Git commit
 type Commit struct {
 	Hash      string    `json:"hash"`
@@ -23,6 +33,7 @@ type Commit struct {
 	Email     string    `json:"email"`
 	Date      time.Time `json:"date"`
 	Message   string    `json:"message"`
+	Body      string    `json:"body,omitempty"`
 	ShortHash string    `json:"short_hash"`
 }
 
@@ -69,13 +80,17 @@ type BlameLine struct {
 
 // StatusInfo represents git status information
 type StatusInfo struct {
-	Branch         string            `json:"branch"`
-	Clean          bool              `json:"clean"`
-	StagedFiles    []string          `json:"staged_files"`
-	ModifiedFiles  []string          `json:"modified_files"`
-	UntrackedFiles []string          `json:"untracked_files"`
-	DeletedFiles   []string          `json:"deleted_files"`
-	RenamedFiles   map[string]string `json:"renamed_files"`
+	Branch          string            `json:"branch"`
+	Upstream        string            `json:"upstream,omitempty"`
+	Ahead           int               `json:"ahead,omitempty"`
+	Behind          int               `json:"behind,omitempty"`
+	Clean           bool              `json:"clean"`
+	StagedFiles     []string          `json:"staged_files"`
+	ModifiedFiles   []string          `json:"modified_files"`
+	UntrackedFiles  []string          `json:"untracked_files"`
+	DeletedFiles    []string          `json:"deleted_files"`
+	ConflictedFiles []string          `json:"conflicted_files"`
+	RenamedFiles    map[string]string `json:"renamed_files"`
 }
 
 // DiffOptions represents options for git diff
@@ -88,17 +103,34 @@ type DiffOptions struct {
 	Context    int      `json:"context,omitempty"`
 }
 
+// CloneOptions represents options for git clone
+type CloneOptions struct {
+	Branch  string `json:"branch,omitempty"`
+	Shallow bool   `json:"shallow,omitempty"`
+
+	// Filter makes a partial clone, e.g. "blob:none" fetches file contents only when checked out
+	Filter string `json:"filter,omitempty"`
+
+	// SparsePaths limits the checkout to these directories (cone mode sparse-checkout)
+	SparsePaths []string `json:"sparse_paths,omitempty"`
+
+	// SkipLFSSmudge leaves Git LFS files as pointers; their content is fetched with LFSPull
+	SkipLFSSmudge bool `json:"skip_lfs_smudge,omitempty"`
+}
+
 // LogOptions represents options for git log
 type LogOptions struct {
-	Limit   int      `json:"limit,omitempty"`
-	Since   string   `json:"since,omitempty"`
-	Until   string   `json:"until,omitempty"`
-	Author  string   `json:"author,omitempty"`
-	Grep    string   `json:"grep,omitempty"`
-	Files   []string `json:"files,omitempty"`
-	Oneline bool     `json:"oneline,omitempty"`
-	Graph   bool     `json:"graph,omitempty"`
-	All     bool     `json:"all,omitempty"`
+	Limit    int      `json:"limit,omitempty"`
+	Since    string   `json:"since,omitempty"`
+	Until    string   `json:"until,omitempty"`
+	Author   string   `json:"author,omitempty"`
+	Grep     string   `json:"grep,omitempty"`
+	Files    []string `json:"files,omitempty"`
+	Oneline  bool     `json:"oneline,omitempty"`
+	Graph    bool     `json:"graph,omitempty"`
+	All      bool     `json:"all,omitempty"`
+	Range    string   `json:"range,omitempty"` // Revision range, e.g. "main..feature" or "v1.0.0..HEAD"
+	NoMerges bool     `json:"no_merges,omitempty"`
 }
 
 // GitError represents Git operation specific errors
@@ -114,6 +146,57 @@ func (e GitError) Error() string {
 	return e.Message
 }
 
+// OutputError replaces a failed git command's generic error with git's own output
+func OutputError(err error) error {
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) {
+		return err
+	}
+	if details, ok := gitErr.Details.(map[string]interface{}); ok {
+		if output, ok := details["output"].(string); ok && strings.TrimSpace(output) != "" {
+			return errors.New(strings.TrimSpace(output))
+		}
+	}
+	return err
+}
+
+// permanentFailures are git messages for failures that retrying cannot fix
+var permanentFailures = []string{
+	"authentication failed",
+	"could not read username",
+	"permission denied",
+	"repository not found",
+	"does not appear to be a git repository",
+	"not found in upstream",
+	"couldn't find remote ref",
+}
+
+// Retryable reports whether a failed git operation may succeed if tried again, such as
+// a clone or pull interrupted by the network. Authentication failures, missing
+// repositories or branches, and errors from outside git are not retryable.
+func Retryable(err error) bool {
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) {
+		return false
+	}
+
+	switch gitErr.Code {
+	case ErrorCodeNetworkError:
+		return true
+	case ErrorCodeCommandFailed:
+	default:
+		return false
+	}
+
+	output := strings.ToLower(OutputError(err).Error())
+	for _, failure := range permanentFailures {
+		if strings.Contains(output, failure) {
+			return false
+		}
+	}
+	return true
+}
+
 // GitErrorCode represents specific Git operation error codes
 type GitErrorCode string
 
@@ -0,0 +1,231 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cache"
+)
+
+// ResponseCacheNamespace is the cache namespace that holds provider API responses
+const ResponseCacheNamespace = "http_responses"
+
+// DefaultResponseFreshness is how long a cached response is served without contacting
+// the provider
+const DefaultResponseFreshness = time.Minute
+
+// responseRetention is how long responses are kept after they go stale, so they can be
+// revalidated with their ETag or Last-Modified date instead of downloaded again
+const responseRetention = 24 * time.Hour
+
+// CachedResponse is a provider API response held in the response cache
+type CachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	StoredAt   time.Time   `json:"stored_at"`
+}
+
+// ResponseCacheStats summarizes the response cache, across all commands that use it
+type ResponseCacheStats struct {
+	Entries   int     `json:"entries" yaml:"entries"`
+	SizeBytes int64   `json:"size_bytes" yaml:"size_bytes"`
+	Hits      int64   `json:"hits" yaml:"hits"`
+	Misses    int64   `json:"misses" yaml:"misses"`
+	HitRatio  float64 `json:"hit_ratio" yaml:"hit_ratio"`
+}
+
+// ResponseCache caches successful GET responses from provider APIs for a short time, so
+// that repeated commands do not use up rate limits. Stale responses that carry an ETag
+// or Last-Modified date are revalidated with a conditional request, and reused when the
+// provider answers 304 Not Modified.
+type ResponseCache struct {
+	cache     cache.Manager[*CachedResponse]
+	freshness time.Duration
+	logger    logging.Logger
+}
+
+// NewResponseCache creates a response cache in its own namespace of the cache described
+// by config. Responses are served from the cache for freshness after they are stored.
+func NewResponseCache(config cache.Config, freshness time.Duration, logger logging.Logger) *ResponseCache {
+	config = config.Namespace(ResponseCacheNamespace)
+	config.DefaultTTL = responseRetention
+	if freshness <= 0 {
+		freshness = DefaultResponseFreshness
+	}
+
+	return &ResponseCache{
+		cache:     cache.NewManager(config, logger, cache.NewJSONSerializer[*CachedResponse]()),
+		freshness: freshness,
+		logger:    logger,
+	}
+}
+
+// Transport returns a round tripper that answers requests from the cache where it can
+// and sends the rest to next
+func (c *ResponseCache) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return transportFunc(func(req *http.Request) (*http.Response, error) {
+		return c.roundTrip(req, next)
+	})
+}
+
+// Stats returns the size and hit counts of the cache
+func (c *ResponseCache) Stats(ctx context.Context) (*ResponseCacheStats, error) {
+	info, err := c.cache.GetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseCacheStats{
+		Entries:   info.EntryCount,
+		SizeBytes: info.TotalSize,
+		Hits:      info.HitCount,
+		Misses:    info.MissCount,
+		HitRatio:  info.CacheHitRatio,
+	}, nil
+}
+
+// Clear removes all cached responses
+func (c *ResponseCache) Clear(ctx context.Context) error {
+	return c.cache.Clear(ctx)
+}
+
+// Close releases the cache
+func (c *ResponseCache) Close() error {
+	return c.cache.Close()
+}
+
+type noCacheKey struct{}
+
+// WithoutCache returns a context whose requests are always sent to the provider. Fresh
+// responses still replace the cached ones, and cached ETags are still used to revalidate.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// CacheBypassed reports whether ctx was returned by WithoutCache
+func CacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(noCacheKey{}).(bool)
+	return bypass
+}
+
+func (c *ResponseCache) roundTrip(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	// Only plain GETs are cached; requests that are already conditional or partial
+	// belong to the caller
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" ||
+		req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return next.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	key := responseKey(req)
+
+	var cached *CachedResponse
+	if entry, err := c.cache.Get(ctx, key); err == nil {
+		cached = entry.Data
+	}
+
+	if cached != nil && !CacheBypassed(ctx) && time.Since(cached.StoredAt) < c.freshness {
+		c.logger.Debug("HTTP response served from cache", "url", req.URL.Redacted())
+		return cached.response(req), nil
+	}
+
+	if cached != nil {
+		req = req.Clone(ctx)
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		resp.Body.Close()
+		cached.StoredAt = time.Now()
+		c.store(ctx, key, cached)
+		c.logger.Debug("HTTP response revalidated", "url", req.URL.Redacted())
+		return cached.response(req), nil
+
+	case resp.StatusCode == http.StatusOK && storable(resp):
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		c.store(ctx, key, &CachedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       body,
+			StoredAt:   time.Now(),
+		})
+	}
+
+	return resp, nil
+}
+
+func (c *ResponseCache) store(ctx context.Context, key string, response *CachedResponse) {
+	if err := c.cache.Put(ctx, key, response, cache.PutOptions{}); err != nil {
+		c.logger.Debug("failed to cache HTTP response", "error", err)
+	}
+}
+
+// response rebuilds an HTTP response for req from the cached copy
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// storable reports whether the provider allows the response to be stored
+func storable(resp *http.Response) bool {
+	return !strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store")
+}
+
+// transportFunc adapts a function to http.RoundTripper
+type transportFunc func(*http.Request) (*http.Response, error)
+
+func (f transportFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// responseKey identifies a request by its URL and the headers that change the response.
+// Credentials are part of the key, so different accounts never share responses.
+func responseKey(req *http.Request) string {
+	h := sha256.New()
+	for _, part := range []string{
+		req.URL.String(),
+		req.Header.Get("Authorization"),
+		req.Header.Get("PRIVATE-TOKEN"),
+		req.Header.Get("Accept"),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
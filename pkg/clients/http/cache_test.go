@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestResponseCache(t *testing.T, freshness time.Duration) *ResponseCache {
+	config := cache.Config{BasePath: t.TempDir(), SizeLimitMB: 10}
+	responses := NewResponseCache(config, freshness, logging.NewBasic())
+	t.Cleanup(func() { responses.Close() })
+	return responses
+}
+
+func TestResponseCache(t *testing.T) {
+	var requests, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, `{"state":"open"}`)
+	}))
+	defer server.Close()
+
+	responses := newTestResponseCache(t, time.Hour)
+	client := &http.Client{Transport: responses.Transport(nil)}
+
+	get := func(ctx context.Context) string {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/pulls/1", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	ctx := context.Background()
+	assert.Equal(t, `{"state":"open"}`, get(ctx))
+	assert.Equal(t, `{"state":"open"}`, get(ctx))
+	assert.Equal(t, int32(1), requests.Load(), "fresh responses are served from the cache")
+
+	assert.Equal(t, `{"state":"open"}`, get(WithoutCache(ctx)))
+	assert.Equal(t, int32(2), requests.Load(), "--no-cache contacts the provider")
+	assert.Equal(t, int32(1), notModified.Load(), "cached responses are revalidated with their ETag")
+
+	stats, err := responses.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Entries)
+	assert.Positive(t, stats.Hits)
+}
+
+func TestResponseCache_Stale(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/secret" {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		io.WriteString(w, "body")
+	}))
+	defer server.Close()
+
+	responses := newTestResponseCache(t, time.Nanosecond)
+	client := &http.Client{Transport: responses.Transport(nil)}
+
+	for _, path := range []string{"/pulls", "/pulls", "/secret"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, int32(3), requests.Load(), "stale responses without validators are fetched again")
+
+	resp, err := client.Post(server.URL+"/pulls", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	stats, err := responses.Stats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Entries, "no-store responses and POSTs are not cached")
+}
+
+func TestResponseKey(t *testing.T) {
+	first, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/acme/app/pulls/1", nil)
+	first.Header.Set("Authorization", "Bearer one")
+	second := first.Clone(context.Background())
+	second.Header.Set("Authorization", "Bearer two")
+
+	assert.NotEqual(t, responseKey(first), responseKey(second), "accounts never share responses")
+	assert.Equal(t, responseKey(first), responseKey(first.Clone(context.Background())))
+}
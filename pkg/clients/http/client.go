@@ -12,42 +12,34 @@ import (
 
 	"github.com/daddia/zen/internal/logging"
 	"github.com/daddia/zen/pkg/clients"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"golang.org/x/time/rate"
 )
 
 // Client provides a shared HTTP client with common functionality
 type Client struct {
 	httpClient     *http.Client
+	provider       string
 	logger         logging.Logger
 	rateLimiter    *rate.Limiter
 	baseURL        string
 	defaultHeaders map[string]string
 	timeout        time.Duration
-	retryConfig    RetryConfig
 }
 
-// RetryConfig contains retry configuration
-type RetryConfig struct {
-	MaxRetries      int
-	BaseDelay       time.Duration
-	MaxDelay        time.Duration
-	RetryableStatus []int
-}
-
-// NewClient creates a new HTTP client
+// NewClient creates a new HTTP client. Requests are retried config.Retries times by the
+// shared provider transport; see httpx.
 func NewClient(config clients.HTTPConfig, logger logging.Logger) *Client {
-	transport := &http.Transport{
-		MaxIdleConns:        10,
-		IdleConnTimeout:     90 * time.Second,
-		DisableCompression:  false,
-		MaxIdleConnsPerHost: 5,
-		ForceAttemptHTTP2:   true,
-	}
-
-	httpClient := &http.Client{
-		Timeout:   config.Timeout,
-		Transport: transport,
+	retry := httpx.NoRetries
+	if config.Retries > 0 {
+		retry = httpx.RetryPolicy{MaxRetries: config.Retries}
 	}
+	httpClient := httpx.New(httpx.Options{
+		Provider: config.Provider,
+		Timeout:  config.Timeout,
+		Retry:    retry,
+		Logger:   logger,
+	})
 
 	// Create rate limiter
 	var rateLimiter *rate.Limiter
@@ -60,19 +52,23 @@ func NewClient(config clients.HTTPConfig, logger logging.Logger) *Client {
 		rateLimiter = rate.NewLimiter(limit, burst)
 	}
 
+	headers := config.Headers
+	if config.UserAgent != "" {
+		headers = make(map[string]string, len(config.Headers)+1)
+		for key, value := range config.Headers {
+			headers[key] = value
+		}
+		headers["User-Agent"] = config.UserAgent
+	}
+
 	return &Client{
 		httpClient:     httpClient,
+		provider:       config.Provider,
 		logger:         logger,
 		rateLimiter:    rateLimiter,
 		baseURL:        config.BaseURL,
-		defaultHeaders: config.Headers,
-		timeout:        config.Timeout,
-		retryConfig: RetryConfig{
-			MaxRetries:      config.Retries,
-			BaseDelay:       1 * time.Second,
-			MaxDelay:        30 * time.Second,
-			RetryableStatus: []int{429, 500, 502, 503, 504},
-		},
+		defaultHeaders: headers,
+		timeout:        httpClient.Timeout,
 	}
 }
 
@@ -93,7 +89,7 @@ type Response struct {
 	Duration   time.Duration
 }
 
-// Do executes an HTTP request with retry logic and rate limiting
+// Do executes an HTTP request with rate limiting; failed requests are retried by the transport
 func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
 	// Check rate limiting
 	if c.rateLimiter != nil {
@@ -115,58 +111,7 @@ func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
 		}
 	}
 
-	// Execute with retry logic
-	return c.executeWithRetry(ctx, req.Method, fullURL, req.Headers, req.Body)
-}
-
-// executeWithRetry executes an HTTP request with exponential backoff retry
-func (c *Client) executeWithRetry(ctx context.Context, method, url string, headers map[string]string, body []byte) (*Response, error) {
-	var lastErr error
-
-	for attempt := 0; attempt <= c.retryConfig.MaxRetries; attempt++ {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		resp, err := c.executeRequest(ctx, method, url, headers, body)
-		if err == nil {
-			return resp, nil
-		}
-
-		lastErr = err
-
-		// Check if error is retryable
-		if !c.isRetryable(err, resp) {
-			return resp, err
-		}
-
-		// Don't wait after the last attempt
-		if attempt == c.retryConfig.MaxRetries {
-			break
-		}
-
-		// Calculate backoff delay
-		delay := c.calculateBackoff(attempt)
-
-		c.logger.Debug("retrying HTTP request",
-			"attempt", attempt+1,
-			"delay", delay,
-			"error", err)
-
-		// Wait before retry
-		timer := time.NewTimer(delay)
-		select {
-		case <-ctx.Done():
-			timer.Stop()
-			return nil, ctx.Err()
-		case <-timer.C:
-			// Continue to next attempt
-		}
-	}
-
-	return nil, lastErr
+	return c.executeRequest(ctx, req.Method, fullURL, req.Headers, req.Body)
 }
 
 // executeRequest executes a single HTTP request
@@ -204,7 +149,7 @@ func (c *Client) executeRequest(ctx context.Context, method, url string, headers
 	if err != nil {
 		return nil, &clients.ClientError{
 			Code:      clients.ErrorCodeConnectionFailed,
-			Message:   fmt.Sprintf("HTTP request failed: %v", err),
+			Message:   fmt.Sprintf("HTTP request failed: %s", httpx.RedactError(err)),
 			Retryable: true,
 		}
 	}
@@ -226,14 +171,6 @@ func (c *Client) executeRequest(ctx context.Context, method, url string, headers
 		Duration:   duration,
 	}
 
-	// Log request details
-	c.logger.Debug("HTTP request completed",
-		"method", method,
-		"url", c.sanitizeURL(url),
-		"status", resp.StatusCode,
-		"duration", duration,
-		"response_size", len(respBody))
-
 	return response, nil
 }
 
@@ -268,62 +205,6 @@ func (c *Client) buildURL(path string, queryParams map[string]string) (string, e
 	return fullURL, nil
 }
 
-// isRetryable determines if an error or response is retryable
-func (c *Client) isRetryable(err error, resp *Response) bool {
-	// Check if it's a retryable client error
-	if clientErr, ok := err.(*clients.ClientError); ok {
-		return clientErr.Retryable
-	}
-
-	// Check response status codes
-	if resp != nil {
-		for _, status := range c.retryConfig.RetryableStatus {
-			if resp.StatusCode == status {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
-// calculateBackoff calculates the backoff delay for retry attempts
-func (c *Client) calculateBackoff(attempt int) time.Duration {
-	// Exponential backoff with jitter
-	delay := c.retryConfig.BaseDelay * time.Duration(1<<attempt)
-
-	// Cap at max delay
-	if delay > c.retryConfig.MaxDelay {
-		delay = c.retryConfig.MaxDelay
-	}
-
-	// Add jitter (±10%)
-	jitter := time.Duration(float64(delay) * 0.1)
-	jitterMultiplier := 2*time.Now().UnixNano()%2 - 1
-	delay += time.Duration(float64(jitter) * float64(jitterMultiplier))
-
-	return delay
-}
-
-// sanitizeURL removes sensitive information from URLs for logging
-func (c *Client) sanitizeURL(rawURL string) string {
-	// Parse URL
-	u, err := url.Parse(rawURL)
-	if err != nil {
-		return rawURL
-	}
-
-	// Remove user info
-	u.User = nil
-
-	// Remove query parameters that might contain sensitive data
-	if u.RawQuery != "" {
-		u.RawQuery = "..."
-	}
-
-	return u.String()
-}
-
 // Close cleans up the HTTP client resources
 func (c *Client) Close() error {
 	// HTTP client doesn't need explicit cleanup
@@ -338,6 +219,15 @@ func (c *Client) SetDefaultHeader(key, value string) {
 	c.defaultHeaders[key] = value
 }
 
+// UseResponseCache answers GET requests from the response cache where it can. A nil
+// cache leaves the client unchanged.
+func (c *Client) UseResponseCache(responses *ResponseCache) {
+	if responses == nil {
+		return
+	}
+	c.httpClient.Transport = responses.Transport(c.httpClient.Transport)
+}
+
 // SetRateLimit updates the rate limiting configuration
 func (c *Client) SetRateLimit(requestsPerMinute int, burstSize int) {
 	if requestsPerMinute > 0 {
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/daddia/zen/pkg/types"
+)
+
+// DoJSON sends in, unless it is nil, as the JSON body of a request, and decodes a
+// successful response into out, unless it is nil. A request that does not reach the
+// service is a network error; a response with a status of 300 or more is converted into
+// an error by errorOf, which reads the error body of the service.
+func (c *Client) DoJSON(ctx context.Context, method, path string, in, out interface{}, errorOf func(*Response) error) error {
+	req := Request{Method: method, URL: path}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		req.Body = body
+		req.Headers = map[string]string{"Content-Type": "application/json"}
+	}
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: fmt.Sprintf("%s request failed", c.provider),
+			Details: err.Error(),
+		}
+	}
+	if resp.StatusCode >= 300 {
+		return errorOf(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", c.provider, err)
+	}
+	return nil
+}
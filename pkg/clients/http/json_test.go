@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDoJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/issues":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var in map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"id": 7, "title": "`+in["title"]+`"}`)
+		case "/issues/8":
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "Not Found"}`)
+		default:
+			io.WriteString(w, `not json`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(clients.HTTPConfig{Provider: "tracker", BaseURL: server.URL}, logging.NewBasic())
+	errNotFound := errors.New("not found")
+	errorOf := func(resp *Response) error {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		return errNotFound
+	}
+	ctx := context.Background()
+
+	var created struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	}
+	require.NoError(t, client.DoJSON(ctx, http.MethodPost, "issues", map[string]string{"title": "Add login page"}, &created, errorOf))
+	assert.Equal(t, 7, created.ID)
+	assert.Equal(t, "Add login page", created.Title)
+
+	err := client.DoJSON(ctx, http.MethodGet, "issues/8", nil, &created, errorOf)
+	assert.ErrorIs(t, err, errNotFound, "unsuccessful responses are converted by errorOf")
+
+	assert.NoError(t, client.DoJSON(ctx, http.MethodGet, "other", nil, nil, errorOf), "a response is not decoded without out")
+	err = client.DoJSON(ctx, http.MethodGet, "other", nil, &created, errorOf)
+	assert.ErrorContains(t, err, "failed to parse tracker response")
+}
+
+func TestClientDoJSON_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient(clients.HTTPConfig{Provider: "tracker", BaseURL: server.URL}, logging.NewBasic())
+	err := client.DoJSON(context.Background(), http.MethodGet, "issues", nil, nil, nil)
+
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeNetworkError, zenErr.Code)
+	assert.Equal(t, "tracker request failed", zenErr.Message)
+}
@@ -0,0 +1,206 @@
+package httpx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/fs"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Config contains the network configuration shared by every client and by Git
+type Config struct {
+	// Proxy is the URL of the proxy HTTP requests and Git go through; the HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY environment variables are used when empty
+	Proxy string `yaml:"proxy" json:"proxy" mapstructure:"proxy"`
+
+	// CABundle is a PEM file of certificate authorities trusted in addition to the
+	// system ones, such as the CA of a proxy that intercepts TLS
+	CABundle string `yaml:"ca_bundle" json:"ca_bundle" mapstructure:"ca_bundle"`
+
+	// InsecureSkipVerify turns TLS certificate verification off. Anyone on the network
+	// path can then read and change requests, including credentials.
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify" json:"insecure_skip_verify" mapstructure:"insecure_skip_verify"`
+
+	// Timeout bounds each request to an external service, including its retries; zero
+	// uses the built-in timeout of the provider, or DefaultTimeout
+	Timeout time.Duration `yaml:"timeout" json:"timeout" mapstructure:"timeout"`
+
+	// ProviderTimeouts override Timeout for the requests to individual providers, such
+	// as {"jira": 1m}
+	ProviderTimeouts map[string]time.Duration `yaml:"provider_timeouts" json:"provider_timeouts,omitempty" mapstructure:"provider_timeouts"`
+}
+
+// InsecureWarning is shown whenever TLS certificate verification is turned off
+const InsecureWarning = "TLS certificate verification is disabled by network.insecure_skip_verify; " +
+	"anyone on the network path can read and change zen's requests, including credentials"
+
+// DefaultConfig returns default network configuration
+func DefaultConfig() Config {
+	return Config{}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the network configuration
+func (c Config) Validate() error {
+	if c.Proxy != "" {
+		if _, err := ParseProxy(c.Proxy); err != nil {
+			return err
+		}
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("invalid network.timeout %s: must not be negative", c.Timeout)
+	}
+	for provider, timeout := range c.ProviderTimeouts {
+		if timeout < 0 {
+			return fmt.Errorf("invalid network.provider_timeouts.%s %s: must not be negative", provider, timeout)
+		}
+	}
+	return nil
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode network config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for the network
+func (p ConfigParser) Section() string {
+	return "network"
+}
+
+var (
+	configMu sync.RWMutex
+	current  Config
+)
+
+// Configure sets the network configuration of the clients created after it and of Git
+func Configure(cfg Config) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	current = cfg
+}
+
+// Current returns the network configuration set with Configure
+func Current() Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return current
+}
+
+// ParseProxy parses a proxy URL, which needs an http, https or socks5 scheme and a host
+func ParseProxy(proxy string) (*url.URL, error) {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: expected a URL such as http://proxy.example.com:8080", RedactURL(proxy))
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return proxyURL, nil
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: unsupported scheme %q", RedactURL(proxy), proxyURL.Scheme)
+	}
+}
+
+// LoadCABundle returns the system certificate pool with the certificates of the PEM
+// file at path added, and how many certificates the file held
+func LoadCABundle(path string) (*x509.CertPool, int, error) {
+	path, _ = fs.ExpandHome(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	count := 0
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid certificate in CA bundle %s: %w", path, err)
+		}
+		pool.AddCert(cert)
+		count++
+	}
+	if count == 0 {
+		return nil, 0, fmt.Errorf("CA bundle %s holds no PEM certificates", path)
+	}
+	return pool, count, nil
+}
+
+// TLSConfig returns the TLS configuration of clients, or nil when the defaults apply
+func (c Config) TLSConfig() (*tls.Config, error) {
+	if c.CABundle == "" && !c.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify, // #nosec G402 - opt-in for TLS-intercepting proxies, with a warning
+	}
+	if c.CABundle != "" {
+		pool, _, err := LoadCABundle(c.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// GitConfig returns the Git configuration that applies the network configuration to
+// Git, as key and value pairs
+func (c Config) GitConfig() [][2]string {
+	var pairs [][2]string
+	if c.Proxy != "" {
+		pairs = append(pairs, [2]string{"http.proxy", c.Proxy})
+	}
+	if c.CABundle != "" {
+		caBundle, _ := fs.ExpandHome(c.CABundle)
+		pairs = append(pairs, [2]string{"http.sslCAInfo", caBundle})
+	}
+	if c.InsecureSkipVerify {
+		pairs = append(pairs, [2]string{"http.sslVerify", "false"})
+	}
+	return pairs
+}
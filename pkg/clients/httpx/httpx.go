@@ -0,0 +1,204 @@
+// Package httpx provides the HTTP client shared by the integration providers. Requests
+// are retried with jittered backoff, logged with credentials redacted, sent through the
+// configured proxy, and identified by a versioned user agent. With --debug-http, every
+// exchange is also recorded to a transcript that can be replayed without the network.
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+)
+
+// DefaultTimeout is the request timeout of providers without one of their own, when
+// network.timeout is not set
+const DefaultTimeout = 30 * time.Second
+
+// ProviderTimeouts are the request timeouts of providers whose APIs need longer or
+// shorter than DefaultTimeout, used when no network configuration is set
+var ProviderTimeouts = map[string]time.Duration{
+	"jira":   30 * time.Second,
+	"github": 30 * time.Second,
+	"gitlab": 30 * time.Second,
+	"assets": time.Minute,
+}
+
+// version is the zen version reported in the user agent
+var version = "dev"
+
+// SetVersion sets the zen version reported in the user agent of every client
+func SetVersion(v string) {
+	if v != "" {
+		version = v
+	}
+}
+
+// Options configures a client
+type Options struct {
+	// Provider names the integration the client talks to, for timeouts, the user
+	// agent, and logs
+	Provider string
+	// Timeout bounds each request, including retries; zero uses the provider timeout
+	Timeout time.Duration
+	// Retry controls how failed requests are retried; the zero value uses
+	// DefaultRetryPolicy, as do unset delays
+	Retry RetryPolicy
+	// Network is the proxy and TLS configuration; nil uses the one set with Configure
+	Network *Config
+	// Logger receives a debug entry for every request
+	Logger logging.Logger
+	// Transport sends the requests; nil uses a new pooled transport, or the transcripts
+	// set with StartReplay
+	Transport http.RoundTripper
+	// SecretResponses marks clients whose responses are secrets, such as those of a
+	// secret store; transcripts keep none of their response bodies
+	SecretResponses bool
+}
+
+// New creates a client from opts
+func New(opts Options) *http.Client {
+	return &http.Client{Transport: NewTransport(opts), Timeout: TimeoutFor(opts.Provider, opts.Timeout)}
+}
+
+// NewTransport creates the round tripper of a client from opts, for clients that
+// set up their own http.Client
+func NewTransport(opts Options) http.RoundTripper {
+	logger := opts.Logger
+	if logger == nil {
+		logger = logging.NewBasic()
+	}
+
+	base := opts.Transport
+	if base == nil {
+		if replayer := currentReplayer(); replayer != nil {
+			base = replayer
+		} else {
+			network := Current()
+			if opts.Network != nil {
+				network = *opts.Network
+			}
+			base = newBaseTransport(network, logger)
+		}
+	}
+	if recorder := CurrentRecorder(); recorder != nil {
+		base = &recordingTransport{base: base, provider: opts.Provider, recorder: recorder, secretResponses: opts.SecretResponses}
+	}
+
+	policy := opts.Retry
+	if policy == (RetryPolicy{}) {
+		policy = DefaultRetryPolicy
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+
+	return &transport{
+		base:      base,
+		provider:  opts.Provider,
+		userAgent: UserAgent(opts.Provider),
+		retry:     policy,
+		logger:    logger,
+		sleep:     sleepContext,
+	}
+}
+
+// newBaseTransport creates a pooled transport for the network configuration. A proxy or
+// CA bundle that cannot be used is reported and left out, rather than failing every
+// request.
+func newBaseTransport(network Config, logger logging.Logger) *http.Transport {
+	proxy := http.ProxyFromEnvironment
+	if network.Proxy != "" {
+		proxyURL, err := ParseProxy(network.Proxy)
+		if err != nil {
+			logger.Warn("ignoring network.proxy", "error", err)
+		} else {
+			proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	tlsConfig, err := network.TLSConfig()
+	if err != nil {
+		logger.Warn("ignoring network.ca_bundle", "error", err)
+		network.CABundle = ""
+		tlsConfig, _ = network.TLSConfig()
+	}
+	return &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		MaxIdleConns:          10,
+		MaxIdleConnsPerHost:   5,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+		ForceAttemptHTTP2:     true,
+	}
+}
+
+type commandTimeoutKey struct{}
+
+// WithCommandTimeout returns a context carrying timeout, the request timeout given to
+// a command such as with its --timeout flag. Zero leaves ctx as it is.
+func WithCommandTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, commandTimeoutKey{}, timeout)
+}
+
+// CommandTimeout returns the timeout set with WithCommandTimeout on ctx, or zero
+func CommandTimeout(ctx context.Context) time.Duration {
+	if ctx == nil {
+		return 0
+	}
+	timeout, _ := ctx.Value(commandTimeoutKey{}).(time.Duration)
+	return timeout
+}
+
+// TimeoutFor returns timeout when it is set, such as the CommandTimeout of the running
+// command, and otherwise the request timeout of provider. Of the timeouts set, the first
+// applies of:
+//
+//   - network.provider_timeouts of provider
+//   - network.timeout
+//   - the built-in timeout of provider, then DefaultTimeout
+func TimeoutFor(provider string, timeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+
+	configMu.RLock()
+	network := current
+	configMu.RUnlock()
+	if timeout := network.ProviderTimeouts[provider]; timeout > 0 {
+		return timeout
+	}
+	if network.Timeout > 0 {
+		return network.Timeout
+	}
+
+	if timeout, ok := ProviderTimeouts[provider]; ok {
+		return timeout
+	}
+	return DefaultTimeout
+}
+
+// UserAgent returns the user agent of requests to provider, such as
+// "zen/1.4.0 (jira; linux/amd64)"
+func UserAgent(provider string) string {
+	if provider == "" {
+		return fmt.Sprintf("zen/%s (%s/%s)", version, runtime.GOOS, runtime.GOARCH)
+	}
+	return fmt.Sprintf("zen/%s (%s; %s/%s)", version, provider, runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,402 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxRecordedBody is the most of a request or response body a transcript keeps
+const MaxRecordedBody = 1 << 20
+
+// Exchange is the transcript of one request and its response, with credentials
+// redacted
+type Exchange struct {
+	Provider string            `json:"provider,omitempty"`
+	Time     time.Time         `json:"time"`
+	Duration string            `json:"duration"`
+	Request  RecordedRequest   `json:"request"`
+	Response *RecordedResponse `json:"response,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// RecordedRequest is a request in a transcript
+type RecordedRequest struct {
+	Method  string        `json:"method"`
+	URL     string        `json:"url"`
+	Headers http.Header   `json:"headers,omitempty"`
+	Body    *RecordedBody `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response in a transcript
+type RecordedResponse struct {
+	Status  int           `json:"status"`
+	Headers http.Header   `json:"headers,omitempty"`
+	Body    *RecordedBody `json:"body,omitempty"`
+}
+
+// RecordedBody is a request or response body in a transcript: text as it is, and
+// binary content in base64
+type RecordedBody struct {
+	Text      string `json:"text,omitempty"`
+	Base64    string `json:"base64,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// Bytes returns the content of the body
+func (b *RecordedBody) Bytes() ([]byte, error) {
+	if b == nil {
+		return nil, nil
+	}
+	if b.Base64 != "" {
+		return base64.StdEncoding.DecodeString(b.Base64)
+	}
+	return []byte(b.Text), nil
+}
+
+// Recorder writes a transcript of every request the clients send to a directory, one
+// JSON file per request, for attaching to bug reports
+type Recorder struct {
+	dir string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecorder creates a recorder that writes transcripts to dir
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{dir: dir}
+}
+
+// Dir returns the directory transcripts are written to
+func (r *Recorder) Dir() string {
+	return r.dir
+}
+
+// Record writes the transcript of one exchange, returning the path of the file
+func (r *Recorder) Record(exchange Exchange) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create HTTP transcript directory: %w", err)
+	}
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode HTTP transcript: %w", err)
+	}
+
+	r.seq++
+	name := fmt.Sprintf("%04d-%s.json", r.seq, strings.ToLower(exchange.Request.Method))
+	if exchange.Provider != "" {
+		name = fmt.Sprintf("%04d-%s-%s.json", r.seq, exchange.Provider, strings.ToLower(exchange.Request.Method))
+	}
+	path := filepath.Join(r.dir, name)
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write HTTP transcript: %w", err)
+	}
+	return path, nil
+}
+
+var (
+	recorderMu sync.RWMutex
+	recorder   *Recorder
+	replayer   *Replayer
+)
+
+// StartRecording records the requests of the clients created after it with r; nil
+// stops recording
+func StartRecording(r *Recorder) {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	recorder = r
+}
+
+// CurrentRecorder returns the recorder set with StartRecording, if any
+func CurrentRecorder() *Recorder {
+	recorderMu.RLock()
+	defer recorderMu.RUnlock()
+	return recorder
+}
+
+// StartReplay answers the requests of the clients created after it from r instead of
+// the network; nil goes back to the network
+func StartReplay(r *Replayer) {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	replayer = r
+}
+
+// currentReplayer returns the replayer set with StartReplay, if any
+func currentReplayer() *Replayer {
+	recorderMu.RLock()
+	defer recorderMu.RUnlock()
+	return replayer
+}
+
+// recordingTransport records every exchange sent through base
+type recordingTransport struct {
+	base     http.RoundTripper
+	provider string
+	recorder *Recorder
+
+	// secretResponses replaces response bodies with Redacted in transcripts
+	secretResponses bool
+}
+
+// RoundTrip sends req through the base transport and records the exchange
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	exchange := Exchange{
+		Provider: t.provider,
+		Time:     time.Now().UTC(),
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     RedactURL(req.URL.String()),
+			Headers: redactHeaderMap(req.Header),
+		},
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(data))
+		exchange.Request.Body = recordBody(redactBody(data, req.Header.Get("Content-Type")))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	exchange.Duration = time.Since(start).String()
+
+	if err != nil {
+		exchange.Error = RedactError(err)
+	} else {
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if readErr != nil {
+			exchange.Error = RedactError(readErr)
+		}
+		exchange.Response = &RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: redactHeaderMap(resp.Header),
+			Body:    recordBody(redactBody(data, resp.Header.Get("Content-Type"))),
+		}
+		if t.secretResponses && len(data) > 0 {
+			exchange.Response.Body = recordBody([]byte(Redacted))
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+
+	// A transcript that cannot be written must not fail the request
+	_, _ = t.recorder.Record(exchange)
+	return resp, err
+}
+
+// CloseIdleConnections closes the idle connections of the base transport
+func (t *recordingTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// recordBody returns data as a transcript body, truncated to MaxRecordedBody
+func recordBody(data []byte) *RecordedBody {
+	if len(data) == 0 {
+		return nil
+	}
+	body := &RecordedBody{}
+	if len(data) > MaxRecordedBody {
+		data, body.Truncated = data[:MaxRecordedBody], true
+	}
+	if utf8.Valid(data) {
+		body.Text = string(data)
+	} else {
+		body.Base64 = base64.StdEncoding.EncodeToString(data)
+	}
+	return body
+}
+
+// redactHeaderMap returns a copy of header with credentials replaced
+func redactHeaderMap(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	redacted := make(http.Header, len(header))
+	for name, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			redacted[name] = []string{Redacted}
+			continue
+		}
+		redacted[name] = append([]string(nil), values...)
+	}
+	return redacted
+}
+
+// sensitiveFields are the JSON fields that carry credentials. They are narrower than
+// sensitiveParams, since fields such as "key" name issues in provider responses.
+var sensitiveFields = []string{"token", "secret", "password", "credential", "api_key", "apikey", "private_key", "authorization"}
+
+// sensitiveField reports whether a JSON field carries credentials
+func sensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveFields {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody replaces credentials in a JSON or form body: the values of fields and
+// parameters whose names look like credentials
+func redactBody(data []byte, contentType string) []byte {
+	switch {
+	case strings.Contains(contentType, "json"):
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return data
+		}
+		redacted, err := json.Marshal(redactJSON(value))
+		if err != nil {
+			return data
+		}
+		return redacted
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return data
+		}
+		for name := range form {
+			if sensitiveParam(name) {
+				form.Set(name, Redacted)
+			}
+		}
+		return []byte(form.Encode())
+	default:
+		return data
+	}
+}
+
+// redactJSON replaces the values of object fields whose names look like credentials
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if sensitiveField(name) {
+				v[name] = Redacted
+				continue
+			}
+			v[name] = redactJSON(field)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// Replayer answers requests from the transcripts a Recorder wrote, so providers can be
+// tested without the network. Requests are matched on method and redacted URL; a
+// request sent several times gets the recorded responses in order.
+type Replayer struct {
+	mu        sync.Mutex
+	exchanges map[string][]Exchange
+}
+
+// NewReplayer loads the transcripts in dir
+func NewReplayer(dir string) (*Replayer, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no HTTP transcripts in %s", dir)
+	}
+	sort.Strings(paths)
+
+	r := &Replayer{exchanges: make(map[string][]Exchange)}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read HTTP transcript: %w", err)
+		}
+		var exchange Exchange
+		if err := json.Unmarshal(data, &exchange); err != nil {
+			return nil, fmt.Errorf("invalid HTTP transcript %s: %w", filepath.Base(path), err)
+		}
+		key := replayKey(exchange.Request.Method, exchange.Request.URL)
+		r.exchanges[key] = append(r.exchanges[key], exchange)
+	}
+	return r, nil
+}
+
+// RoundTrip answers req with the next recorded response to the same request
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := replayKey(req.Method, RedactURL(req.URL.String()))
+	r.mu.Lock()
+	exchanges := r.exchanges[key]
+	if len(exchanges) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, RedactURL(req.URL.String()))
+	}
+	exchange := exchanges[0]
+	if len(exchanges) > 1 {
+		// The last response answers any further requests
+		r.exchanges[key] = exchanges[1:]
+	}
+	r.mu.Unlock()
+
+	if exchange.Response == nil {
+		return nil, fmt.Errorf("recorded error: %s", exchange.Error)
+	}
+	body, err := exchange.Response.Body.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid recorded body for %s %s: %w", req.Method, exchange.Request.URL, err)
+	}
+	header := exchange.Response.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	// Redaction may have changed the length of the body
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.Response.Status, http.StatusText(exchange.Response.Status)),
+		StatusCode:    exchange.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func replayKey(method, url string) string {
+	return method + " " + url
+}
@@ -0,0 +1,185 @@
+package httpx
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingClient returns a client that records its requests to a temporary directory
+func recordingClient(t *testing.T) (*http.Client, string) {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "http")
+	StartRecording(NewRecorder(dir))
+	t.Cleanup(func() { StartRecording(nil) })
+	return New(Options{Provider: "jira", Retry: NoRetries, Network: &Config{}, Logger: logging.NewBasic()}), dir
+}
+
+func readTranscripts(t *testing.T, dir string) []Exchange {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	var exchanges []Exchange
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var exchange Exchange
+		require.NoError(t, json.Unmarshal(data, &exchange))
+		exchanges = append(exchanges, exchange)
+	}
+	return exchanges
+}
+
+func TestRecorder_RedactsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), "hunter2", "the server still receives the real body")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		_, _ = w.Write([]byte(`{"key":"PROJ-1","access_token":"secret-token"}`))
+	}))
+	defer server.Close()
+
+	client, dir := recordingClient(t)
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/rest/api/2/issue?api_key=abc", strings.NewReader(`{"summary":"Fix login","password":"hunter2"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Contains(t, string(body), "secret-token", "callers still receive the real response")
+
+	exchanges := readTranscripts(t, dir)
+	require.Len(t, exchanges, 1)
+	exchange := exchanges[0]
+
+	assert.Equal(t, "jira", exchange.Provider)
+	assert.Equal(t, http.MethodPost, exchange.Request.Method)
+	assert.Contains(t, exchange.Request.URL, "api_key="+Redacted)
+	assert.Equal(t, Redacted, exchange.Request.Headers.Get("Authorization"))
+	assert.JSONEq(t, `{"summary":"Fix login","password":"REDACTED"}`, exchange.Request.Body.Text)
+
+	require.NotNil(t, exchange.Response)
+	assert.Equal(t, http.StatusOK, exchange.Response.Status)
+	assert.Equal(t, Redacted, exchange.Response.Headers.Get("Set-Cookie"))
+	assert.JSONEq(t, `{"key":"PROJ-1","access_token":"REDACTED"}`, exchange.Response.Body.Text)
+}
+
+func TestRecorder_RedactsSecretResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"data":{"jira_key":"resolved-secret"}}}`))
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "http")
+	StartRecording(NewRecorder(dir))
+	t.Cleanup(func() { StartRecording(nil) })
+	client := New(Options{Provider: "vault", SecretResponses: true, Retry: NoRetries, Network: &Config{}, Logger: logging.NewBasic()})
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/kv/data/zen", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Vault-Token", "hvs.secret")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Contains(t, string(body), "resolved-secret", "callers still receive the secret")
+
+	exchanges := readTranscripts(t, dir)
+	require.Len(t, exchanges, 1)
+	assert.Equal(t, Redacted, exchanges[0].Request.Headers.Get("X-Vault-Token"))
+	assert.Equal(t, Redacted, exchanges[0].Response.Body.Text)
+}
+
+func TestRecorder_RecordsFailures(t *testing.T) {
+	client, dir := recordingClient(t)
+
+	_, err := client.Get("http://127.0.0.1:1/unreachable")
+	require.Error(t, err)
+
+	exchanges := readTranscripts(t, dir)
+	require.Len(t, exchanges, 1)
+	assert.Nil(t, exchanges[0].Response)
+	assert.NotEmpty(t, exchanges[0].Error)
+}
+
+func TestRecordBody(t *testing.T) {
+	assert.Nil(t, recordBody(nil))
+
+	binary := recordBody([]byte{0xff, 0xfe, 0x00})
+	assert.Empty(t, binary.Text)
+	data, err := binary.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xfe, 0x00}, data)
+
+	large := recordBody([]byte(strings.Repeat("a", MaxRecordedBody+1)))
+	assert.True(t, large.Truncated)
+	assert.Len(t, large.Text, MaxRecordedBody)
+}
+
+func TestRedactBody_Form(t *testing.T) {
+	redacted := redactBody([]byte("grant_type=refresh&refresh_token=abc"), "application/x-www-form-urlencoded")
+	assert.Equal(t, "grant_type=refresh&refresh_token=REDACTED", string(redacted))
+
+	assert.Equal(t, "plain token=abc", string(redactBody([]byte("plain token=abc"), "text/plain")))
+}
+
+func TestReplayer(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":` + string(rune('0'+calls)) + `}`))
+	}))
+
+	client, dir := recordingClient(t)
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL + "/issues?token=abc")
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	server.Close()
+	StartRecording(nil)
+
+	replayer, err := NewReplayer(dir)
+	require.NoError(t, err)
+	StartReplay(replayer)
+	defer StartReplay(nil)
+	replay := New(Options{Retry: NoRetries, Logger: logging.NewBasic()})
+
+	var bodies []string
+	for i := 0; i < 3; i++ {
+		resp, err := replay.Get(server.URL + "/issues?token=different")
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		bodies = append(bodies, string(body))
+	}
+	assert.Equal(t, []string{`{"id":1}`, `{"id":2}`, `{"id":2}`}, bodies, "responses replay in order, the last one repeating")
+
+	_, err = replay.Get(server.URL + "/projects")
+	assert.ErrorContains(t, err, "no recorded response for GET")
+}
+
+func TestNewReplayer_Empty(t *testing.T) {
+	_, err := NewReplayer(t.TempDir())
+	assert.ErrorContains(t, err, "no HTTP transcripts")
+}
@@ -0,0 +1,82 @@
+package httpx
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces credentials in logs
+const Redacted = "REDACTED"
+
+// sensitiveHeaders are the headers that carry credentials
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"Private-Token":       true,
+	"X-Api-Key":           true,
+	"X-Atlassian-Token":   true,
+	"X-Vault-Token":       true,
+}
+
+// sensitiveParams are the query parameters that carry credentials
+var sensitiveParams = []string{"token", "key", "secret", "password", "signature", "sig", "code", "credential", "auth"}
+
+// urlPattern finds URLs in error messages
+var urlPattern = regexp.MustCompile(`https?://[^\s"']+`)
+
+// RedactURL returns rawURL without user information and with the values of query
+// parameters that look like credentials replaced, or Redacted when it cannot be parsed
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Redacted
+	}
+	if u.User != nil {
+		u.User = url.User(Redacted)
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for name := range query {
+			if sensitiveParam(name) {
+				query.Set(name, Redacted)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+// RedactHeaders returns header as "Name: value" lines, sorted by name, with credentials
+// replaced
+func RedactHeaders(header http.Header) []string {
+	lines := make([]string, 0, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = Redacted
+		}
+		lines = append(lines, name+": "+value)
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// RedactError returns the message of err with the URLs in it redacted
+func RedactError(err error) string {
+	return urlPattern.ReplaceAllStringFunc(err.Error(), RedactURL)
+}
+
+func sensitiveParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveParams {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+package httpx
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+)
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	// MaxRetries is how many times a request is retried after the first attempt; a
+	// negative value turns retries off
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry, doubled for each retry after it
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, including delays a server asks for with Retry-After
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy retries a request up to three times, over about seven seconds
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 30 * time.Second}
+
+// NoRetries sends every request once
+var NoRetries = RetryPolicy{MaxRetries: -1}
+
+// transport retries, logs and identifies the requests sent through base
+type transport struct {
+	base      http.RoundTripper
+	provider  string
+	userAgent string
+	retry     RetryPolicy
+	logger    logging.Logger
+	sleep     func(ctx context.Context, d time.Duration) error
+}
+
+// RoundTrip sends req, retrying it while it fails in a way a retry may fix
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := rewind(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		resp, err := t.base.RoundTrip(attemptReq)
+		t.log(req, resp, err, attempt, time.Since(start))
+
+		if attempt >= t.retry.MaxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if resp != nil {
+			// The response is discarded for the retry, so its connection can be reused
+			resp.Body.Close()
+		}
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// CloseIdleConnections closes the idle connections of the underlying transport
+func (t *transport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// log writes a debug entry for one attempt at req
+func (t *transport) log(req *http.Request, resp *http.Response, err error, attempt int, duration time.Duration) {
+	fields := []interface{}{
+		"provider", t.provider,
+		"method", req.Method,
+		"url", RedactURL(req.URL.String()),
+		"attempt", attempt + 1,
+		"duration", duration,
+	}
+	if err != nil {
+		t.logger.Debug("HTTP request failed", append(fields, "error", RedactError(err))...)
+		return
+	}
+	fields = append(fields,
+		"status", resp.StatusCode,
+		"request_headers", RedactHeaders(req.Header),
+		"response_headers", RedactHeaders(resp.Header))
+	t.logger.Debug("HTTP request completed", fields...)
+}
+
+// rewind returns the request to send for attempt: req itself first, and a copy with a
+// fresh body after that
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry := req.Clone(req.Context())
+	retry.Body = body
+	return retry, nil
+}
+
+// retryable reports whether a retry may succeed where this attempt at req did not.
+// Requests that change state are only retried when the server did not process them.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body was consumed by the first attempt and cannot be sent again
+		return false
+	}
+
+	if err != nil {
+		return idempotent(req.Method) && !permanent(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return idempotent(req.Method) || resp.Header.Get("Retry-After") != ""
+	case http.StatusBadGateway, http.StatusGatewayTimeout, http.StatusInternalServerError:
+		return idempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+// permanent reports whether a request failed in a way no retry can fix: a host that
+// does not exist, or a certificate that is not trusted
+func permanent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return true
+	}
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	return errors.As(err, &certErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr)
+}
+
+// idempotent reports whether sending a request with method twice has the same effect
+// as sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns how long to wait before retrying after attempt: the delay the server
+// asked for with Retry-After, or exponential backoff with full jitter
+func (t *transport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(delay, t.retry.MaxDelay)
+		}
+	}
+
+	ceiling := t.retry.BaseDelay << attempt
+	if ceiling <= 0 || ceiling > t.retry.MaxDelay {
+		ceiling = t.retry.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling) + 1
+}
+
+// retryAfter parses a Retry-After header, in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d, or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
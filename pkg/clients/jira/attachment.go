@@ -0,0 +1,178 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Attachment is a file attached to a Jira issue
+type Attachment struct {
+	ID       string
+	Filename string
+	MimeType string
+	Size     int64
+	Created  time.Time
+	// Content is the URL the file is downloaded from
+	Content string
+}
+
+// JiraAttachment represents a Jira attachment
+type JiraAttachment struct {
+	ID       string    `json:"id"`
+	Filename string    `json:"filename"`
+	MimeType string    `json:"mimeType"`
+	Size     int64     `json:"size"`
+	Created  *JiraTime `json:"created"`
+	Content  string    `json:"content"`
+}
+
+// ListAttachments returns the files attached to an issue
+func (p *Plugin) ListAttachments(ctx context.Context, externalID string) ([]*Attachment, error) {
+	params := url.Values{}
+	params.Set("fields", "attachment")
+	endpoint := fmt.Sprintf("%s?%s", p.buildJiraURL(fmt.Sprintf("rest/api/3/issue/%s", url.PathEscape(externalID))), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := p.addAuthentication(req); err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, p.handleHTTPError(resp)
+	}
+
+	var issue struct {
+		Fields struct {
+			Attachment []JiraAttachment `json:"attachment"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	attachments := make([]*Attachment, 0, len(issue.Fields.Attachment))
+	for _, a := range issue.Fields.Attachment {
+		attachments = append(attachments, convertAttachment(a))
+	}
+	return attachments, nil
+}
+
+// UploadAttachment attaches a file named name to an issue
+func (p *Plugin) UploadAttachment(ctx context.Context, externalID, name string, content io.Reader) (*Attachment, error) {
+	// The body is streamed, so large files are not held in memory
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreateFormFile("file", name)
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	endpoint := p.buildJiraURL(fmt.Sprintf("rest/api/3/issue/%s/attachments", url.PathEscape(externalID)))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, body)
+	if err != nil {
+		body.Close()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := p.addAuthentication(req); err != nil {
+		body.Close()
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	// Jira rejects attachment uploads without this header as a CSRF protection
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, p.handleHTTPError(resp)
+	}
+
+	var created []JiraAttachment
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(created) == 0 {
+		return nil, fmt.Errorf("jira did not return the uploaded attachment")
+	}
+
+	p.logger.Debug("uploaded attachment", "external_id", externalID, "attachment", created[0].ID)
+
+	return convertAttachment(created[0]), nil
+}
+
+// DownloadAttachment writes the content of an attachment to w
+func (p *Plugin) DownloadAttachment(ctx context.Context, attachment *Attachment, w io.Writer) error {
+	endpoint := attachment.Content
+	if endpoint == "" {
+		endpoint = p.buildJiraURL(fmt.Sprintf("rest/api/3/attachment/content/%s", url.PathEscape(attachment.ID)))
+	}
+	// Credentials are only sent to the Jira site
+	if !strings.HasPrefix(endpoint, strings.TrimRight(p.config.BaseURL, "/")+"/") {
+		return fmt.Errorf("attachment %s is not on the Jira site: %s", attachment.ID, endpoint)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := p.addAuthentication(req); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return p.handleHTTPError(resp)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to download attachment %s: %w", attachment.ID, err)
+	}
+	return nil
+}
+
+func convertAttachment(a JiraAttachment) *Attachment {
+	attachment := &Attachment{
+		ID:       a.ID,
+		Filename: a.Filename,
+		MimeType: a.MimeType,
+		Size:     a.Size,
+		Content:  a.Content,
+	}
+	if a.Created != nil {
+		attachment.Created = a.Created.Time()
+	}
+	return attachment
+}
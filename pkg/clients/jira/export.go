@@ -0,0 +1,178 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// exportPageSize is the number of issues requested per page when exporting, the most
+// Jira returns with all fields
+const exportPageSize = 100
+
+// Export is the issues matched by a JQL query, with their history
+type Export struct {
+	Issues []*ExportIssue
+
+	// FieldNames are the names of the fields of the issues by ID, such as "Story
+	// Points" for customfield_10016
+	FieldNames map[string]string
+}
+
+// ExportIssue is an issue of an export, with what Jira records of it that task data
+// does not hold
+type ExportIssue struct {
+	Task           *PluginTaskData
+	Project        string
+	Status         string
+	StatusCategory string
+	IssueType      string
+	Reporter       string
+	Comments       int
+
+	// Fields are the fields of the issue as Jira returns them
+	Fields map[string]interface{}
+
+	// History is the changes made to the issue, oldest first. Searches return at most
+	// the last 100 changes of an issue.
+	History []IssueChange
+}
+
+// IssueChange is a change to a field of an issue
+type IssueChange struct {
+	At     time.Time
+	Author string
+	Field  string
+	From   string
+	To     string
+}
+
+// exportedIssue is the part of an exported issue that JiraIssue leaves out
+type exportedIssue struct {
+	Fields struct {
+		Comment struct {
+			Total int `json:"total"`
+		} `json:"comment"`
+	} `json:"fields"`
+	Changelog struct {
+		Histories []struct {
+			Author  JiraUser  `json:"author"`
+			Created *JiraTime `json:"created"`
+			Items   []struct {
+				Field      string `json:"field"`
+				FromString string `json:"fromString"`
+				ToString   string `json:"toString"`
+			} `json:"items"`
+		} `json:"histories"`
+	} `json:"changelog"`
+}
+
+// ExportIssues returns the issues matched by a JQL query with all of their fields and
+// their changelog, reading every page of results. A positive limit stops the export
+// after that many issues.
+func (p *Plugin) ExportIssues(ctx context.Context, jql string, limit int) (*Export, error) {
+	p.logger.Debug("exporting issues from Jira", "jql", jql)
+
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("fields", "*all")
+	params.Set("expand", "changelog,names")
+
+	export := &Export{FieldNames: map[string]string{}}
+	for start := 0; ; {
+		pageSize := exportPageSize
+		if limit > 0 && limit-len(export.Issues) < pageSize {
+			pageSize = limit - len(export.Issues)
+		}
+		params.Set("startAt", strconv.Itoa(start))
+		params.Set("maxResults", strconv.Itoa(pageSize))
+
+		var page struct {
+			Total  int               `json:"total"`
+			Issues []json.RawMessage `json:"issues"`
+			Names  map[string]string `json:"names"`
+		}
+		if err := p.getJSON(ctx, "rest/api/3/search", params, &page); err != nil {
+			return nil, err
+		}
+
+		for id, name := range page.Names {
+			export.FieldNames[id] = name
+		}
+		for _, raw := range page.Issues {
+			issue, err := p.convertExportIssue(raw)
+			if err != nil {
+				return nil, err
+			}
+			export.Issues = append(export.Issues, issue)
+		}
+
+		start += len(page.Issues)
+		if len(page.Issues) == 0 || start >= page.Total || (limit > 0 && len(export.Issues) >= limit) {
+			break
+		}
+	}
+
+	p.logger.Debug("successfully exported issues", "found", len(export.Issues))
+
+	return export, nil
+}
+
+// convertExportIssue reads an issue of an export, keeping its fields as Jira returns
+// them as the raw data of its task
+func (p *Plugin) convertExportIssue(raw json.RawMessage) (*ExportIssue, error) {
+	var issue JiraIssue
+	if err := json.Unmarshal(raw, &issue); err != nil {
+		return nil, fmt.Errorf("failed to decode issue: %w", err)
+	}
+	var exported exportedIssue
+	var rawMap map[string]interface{}
+	if err := json.Unmarshal(raw, &exported); err != nil {
+		return nil, fmt.Errorf("failed to decode issue %s: %w", issue.Key, err)
+	}
+	if err := json.Unmarshal(raw, &rawMap); err != nil {
+		return nil, fmt.Errorf("failed to decode issue %s: %w", issue.Key, err)
+	}
+
+	// The changelog is kept as history rather than raw data, as it grows without bound
+	delete(rawMap, "changelog")
+	fields, _ := rawMap["fields"].(map[string]interface{})
+
+	taskData := p.convertJiraIssueToTaskData(&issue)
+	taskData.RawData = rawMap
+	result := &ExportIssue{
+		Task:           taskData,
+		Project:        issue.Fields.Project.Key,
+		Status:         issue.Fields.Status.Name,
+		StatusCategory: issue.Fields.Status.StatusCategory.Key,
+		IssueType:      issue.Fields.IssueType.Name,
+		Reporter:       issue.Fields.Reporter.DisplayName,
+		Comments:       exported.Fields.Comment.Total,
+		Fields:         fields,
+	}
+
+	for _, history := range exported.Changelog.Histories {
+		var at time.Time
+		if history.Created != nil {
+			at = history.Created.Time()
+		}
+		for _, item := range history.Items {
+			result.History = append(result.History, IssueChange{
+				At:     at,
+				Author: history.Author.DisplayName,
+				Field:  item.Field,
+				From:   item.FromString,
+				To:     item.ToString,
+			})
+		}
+	}
+	sort.SliceStable(result.History, func(i, j int) bool {
+		return result.History[i].At.Before(result.History[j].At)
+	})
+
+	return result, nil
+}
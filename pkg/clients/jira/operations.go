@@ -282,6 +282,101 @@ func (p *Plugin) SearchTasks(ctx context.Context, query *SearchQuery, opts *Sear
 	return tasks, nil
 }
 
+// maxBatchKeys is the number of issue keys requested per search when fetching in bulk
+const maxBatchKeys = 100
+
+// FetchTasks fetches many tasks from Jira with one search per maxBatchKeys issues.
+// Issues that do not exist or are not visible are left out of the result.
+func (p *Plugin) FetchTasks(ctx context.Context, externalIDs []string, opts *FetchOptions) (map[string]*PluginTaskData, error) {
+	if opts == nil {
+		opts = &FetchOptions{}
+	}
+
+	// Jira reports keys in upper case; answer with the IDs the caller asked for
+	requested := make(map[string]string, len(externalIDs))
+	for _, id := range externalIDs {
+		requested[strings.ToUpper(id)] = id
+	}
+
+	tasks := make(map[string]*PluginTaskData, len(externalIDs))
+	for start := 0; start < len(externalIDs); start += maxBatchKeys {
+		end := start + maxBatchKeys
+		if end > len(externalIDs) {
+			end = len(externalIDs)
+		}
+
+		issues, err := p.searchIssuesByKey(ctx, externalIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range issues {
+			taskData := p.convertJiraIssueToTaskData(&issues[i])
+			if opts.IncludeRaw {
+				rawData, _ := json.Marshal(issues[i])
+				var rawMap map[string]interface{}
+				json.Unmarshal(rawData, &rawMap)
+				taskData.RawData = rawMap
+			}
+
+			id, ok := requested[strings.ToUpper(issues[i].Key)]
+			if !ok {
+				id = issues[i].Key
+			}
+			tasks[id] = taskData
+		}
+	}
+
+	p.logger.Debug("successfully fetched tasks in bulk", "requested", len(externalIDs), "found", len(tasks))
+
+	return tasks, nil
+}
+
+// searchIssuesByKey returns the issues with the given keys, with all of their fields
+func (p *Plugin) searchIssuesByKey(ctx context.Context, keys []string) ([]JiraIssue, error) {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = strconv.Quote(key)
+	}
+
+	params := url.Values{}
+	params.Set("jql", fmt.Sprintf("key in (%s)", strings.Join(quoted, ",")))
+	params.Set("maxResults", strconv.Itoa(len(keys)))
+	params.Set("fields", "*all")
+	// Unknown keys would otherwise fail the whole query
+	params.Set("validateQuery", "warn")
+
+	fullURL := fmt.Sprintf("%s?%s", p.buildJiraURL("rest/api/3/search"), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if err := p.addAuthentication(req); err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, p.handleHTTPError(resp)
+	}
+
+	var searchResponse JiraSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return searchResponse.Issues, nil
+}
+
 // Synchronization Operations
 
 // SyncTask synchronizes a task between Zen and Jira
@@ -10,6 +10,7 @@ import (
 
 	"github.com/daddia/zen/internal/logging"
 	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/clients/httpx"
 )
 
 // Plugin implements the standardized integration plugin interface for Jira
@@ -218,18 +219,11 @@ const (
 
 // NewPlugin creates a new Jira plugin instance
 func NewPlugin(config *PluginConfig, logger logging.Logger, authMgr auth.Manager) *Plugin {
-	timeout := config.Timeout
-	if timeout == 0 {
-		timeout = 30 * time.Second
-	}
-
 	return &Plugin{
-		config:  config,
-		logger:  logger,
-		authMgr: authMgr,
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
+		config:     config,
+		logger:     logger,
+		authMgr:    authMgr,
+		httpClient: httpx.New(httpx.Options{Provider: "jira", Timeout: config.Timeout, Logger: logger}),
 	}
 }
 
@@ -308,9 +302,7 @@ func (p *Plugin) Shutdown(ctx context.Context) error {
 	p.logger.Info("shutting down Jira plugin")
 
 	// Close HTTP client connections
-	if transport, ok := p.httpClient.Transport.(*http.Transport); ok {
-		transport.CloseIdleConnections()
-	}
+	p.httpClient.CloseIdleConnections()
 
 	return nil
 }
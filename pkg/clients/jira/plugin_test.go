@@ -3,6 +3,7 @@ package jira
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -314,6 +315,235 @@ func TestPlugin_SearchTasks(t *testing.T) {
 	authMgr.AssertExpectations(t)
 }
 
+func TestPlugin_FetchTasks(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Contains(t, r.URL.Path, "/rest/api/3/search")
+		assert.Equal(t, `key in ("test-123","TEST-404")`, r.URL.Query().Get("jql"))
+		assert.Equal(t, "*all", r.URL.Query().Get("fields"))
+
+		response := JiraSearchResponse{
+			Issues:     []JiraIssue{createMockJiraIssue()},
+			Total:      1,
+			MaxResults: 2,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	plugin, authMgr := createTestPlugin()
+	plugin.config.BaseURL = server.URL
+
+	authMgr.On("GetCredentials", "jira").Return("Basic dXNlcjpwYXNz", nil)
+
+	tasks, err := plugin.FetchTasks(context.Background(), []string{"test-123", "TEST-404"}, &FetchOptions{IncludeRaw: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, requests, "all keys are fetched with one search")
+	require.Len(t, tasks, 1, "missing issues are left out")
+	require.Contains(t, tasks, "test-123", "results are keyed by the requested ID")
+	assert.Equal(t, "Test Issue", tasks["test-123"].Title)
+	assert.Contains(t, tasks["test-123"].RawData, "fields")
+
+	authMgr.AssertExpectations(t)
+}
+
+func TestPlugin_FetchSprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/agile/1.0/board":
+			assert.Equal(t, "TEST", r.URL.Query().Get("projectKeyOrId"))
+			fmt.Fprint(w, `{"values": [{"id": 7, "name": "TEST board", "type": "scrum"}]}`)
+		case "/rest/agile/1.0/board/7/sprint":
+			assert.Equal(t, "active", r.URL.Query().Get("state"))
+			fmt.Fprint(w, `{"isLast": true, "values": [{"id": 42, "name": "Sprint 42", "state": "active",
+				"startDate": "2026-10-12T09:00:00.000Z", "endDate": "2026-10-16T17:00:00.000Z"}]}`)
+		case "/rest/agile/1.0/sprint/42/issue":
+			assert.Contains(t, r.URL.Query().Get("fields"), "customfield_10016")
+			fmt.Fprint(w, `{"total": 3, "issues": [
+				{"key": "TEST-1", "fields": {"summary": "Done", "customfield_10016": 5,
+					"created": "2026-10-01T09:00:00.000+0000", "resolutiondate": "2026-10-13T10:00:00.000+0000",
+					"status": {"name": "Done", "statusCategory": {"key": "done"}}}},
+				{"key": "TEST-2", "fields": {"summary": "Added", "customfield_10016": null,
+					"created": "2026-10-14T09:00:00.000+0000", "status": {"name": "To Do", "statusCategory": {"key": "new"}}}},
+				{"key": "TEST-3", "fields": {"summary": "Reopened", "customfield_10016": 3,
+					"created": "2026-10-01T09:00:00.000+0000", "resolutiondate": "2026-10-13T10:00:00.000+0000",
+					"status": {"name": "In Progress", "statusCategory": {"key": "indeterminate"}}}}
+			]}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	plugin, authMgr := createTestPlugin()
+	plugin.config.BaseURL = server.URL
+
+	authMgr.On("GetCredentials", "jira").Return("Basic dXNlcjpwYXNz", nil)
+
+	sprint, err := plugin.FetchSprint(context.Background(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "42", sprint.ID)
+	assert.Equal(t, "Sprint 42", sprint.Name)
+	assert.Equal(t, time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC), sprint.Start.UTC())
+	require.Len(t, sprint.Issues, 3)
+
+	done := sprint.Issues[0]
+	require.NotNil(t, done.Points)
+	assert.Equal(t, 5.0, *done.Points)
+	assert.True(t, done.Added.IsZero(), "in the sprint from the start")
+	require.NotNil(t, done.Completed)
+
+	added := sprint.Issues[1]
+	assert.Nil(t, added.Points, "not estimated")
+	assert.False(t, added.Added.IsZero(), "created during the sprint")
+
+	assert.Nil(t, sprint.Issues[2].Completed, "resolved issues that are not done are open")
+}
+
+func TestPlugin_FetchSprint_ByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/agile/1.0/board/9/sprint":
+			fmt.Fprint(w, `{"isLast": true, "values": [{"id": 41, "name": "Sprint 41", "state": "closed"}]}`)
+		case "/rest/agile/1.0/sprint/41/issue":
+			fmt.Fprint(w, `{"total": 0, "issues": []}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	plugin, authMgr := createTestPlugin()
+	plugin.config.BaseURL = server.URL
+	plugin.config.Settings = map[string]interface{}{"board_id": 9}
+
+	authMgr.On("GetCredentials", "jira").Return("Basic dXNlcjpwYXNz", nil)
+
+	sprint, err := plugin.FetchSprint(context.Background(), "sprint 41")
+	require.NoError(t, err)
+	assert.Equal(t, "41", sprint.ID)
+
+	_, err = plugin.FetchSprint(context.Background(), "Sprint 99")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sprint not found: Sprint 99")
+}
+
+func TestPlugin_ExportIssues(t *testing.T) {
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search", r.URL.Path)
+		assert.Equal(t, "project = TEST", r.URL.Query().Get("jql"))
+		assert.Equal(t, "changelog,names", r.URL.Query().Get("expand"))
+		starts = append(starts, r.URL.Query().Get("startAt"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("startAt") == "0" {
+			fmt.Fprint(w, `{"total": 2, "names": {"summary": "Summary", "customfield_10016": "Story Points"}, "issues": [
+				{"id": "1", "key": "TEST-1", "fields": {"summary": "Add login", "customfield_10016": 5,
+					"status": {"name": "Ready for QA", "statusCategory": {"key": "indeterminate"}},
+					"issuetype": {"name": "Story"}, "project": {"key": "TEST"}, "reporter": {"displayName": "Ada"},
+					"comment": {"total": 3}},
+				 "changelog": {"histories": [
+					{"author": {"displayName": "Bob"}, "created": "2026-10-02T09:00:00.000+0000",
+					 "items": [{"field": "status", "fromString": "In Progress", "toString": "Ready for QA"}]},
+					{"author": {"displayName": "Ada"}, "created": "2026-10-01T09:00:00.000+0000",
+					 "items": [{"field": "status", "fromString": "To Do", "toString": "In Progress"}]}
+				 ]}}
+			]}`)
+			return
+		}
+		fmt.Fprint(w, `{"total": 2, "issues": [{"id": "2", "key": "TEST-2", "fields": {"summary": "Fix logout",
+			"status": {"name": "Done", "statusCategory": {"key": "done"}}, "issuetype": {"name": "Bug"}}}]}`)
+	}))
+	defer server.Close()
+
+	plugin, authMgr := createTestPlugin()
+	plugin.config.BaseURL = server.URL
+
+	authMgr.On("GetCredentials", "jira").Return("Basic dXNlcjpwYXNz", nil)
+
+	export, err := plugin.ExportIssues(context.Background(), "project = TEST", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "1"}, starts, "every page is read")
+	assert.Equal(t, "Story Points", export.FieldNames["customfield_10016"])
+	require.Len(t, export.Issues, 2)
+
+	issue := export.Issues[0]
+	assert.Equal(t, "Add login", issue.Task.Title)
+	assert.Equal(t, "Ready for QA", issue.Status)
+	assert.Equal(t, "indeterminate", issue.StatusCategory)
+	assert.Equal(t, "Story", issue.IssueType)
+	assert.Equal(t, "Ada", issue.Reporter)
+	assert.Equal(t, 3, issue.Comments)
+	assert.Equal(t, float64(5), issue.Fields["customfield_10016"])
+	assert.NotContains(t, issue.Task.RawData, "changelog")
+	require.Len(t, issue.History, 2)
+	assert.Equal(t, IssueChange{At: issue.History[0].At, Author: "Ada", Field: "status", From: "To Do", To: "In Progress"}, issue.History[0], "history is oldest first")
+	assert.Equal(t, "Ready for QA", issue.History[1].To)
+	assert.Equal(t, "Done", export.Issues[1].Status)
+
+	starts = nil
+	export, err = plugin.ExportIssues(context.Background(), "project = TEST", 1)
+	require.NoError(t, err)
+	assert.Len(t, export.Issues, 1)
+	assert.Equal(t, []string{"0"}, starts, "exports stop at the limit")
+}
+
+func TestPlugin_Attachments(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Basic dXNlcjpwYXNz", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/rest/api/3/issue/TEST-1":
+			assert.Equal(t, "attachment", r.URL.Query().Get("fields"))
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"fields": {"attachment": [{"id": "100", "filename": "design.pdf", "mimeType": "application/pdf",
+				"size": 7, "created": "2026-10-01T09:00:00.000+0000", "content": "%s/rest/api/3/attachment/content/100"}]}}`, server.URL)
+		case r.Method == "POST" && r.URL.Path == "/rest/api/3/issue/TEST-1/attachments":
+			assert.Equal(t, "no-check", r.Header.Get("X-Atlassian-Token"))
+			file, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			defer file.Close()
+			assert.Equal(t, "notes.txt", header.Filename)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `[{"id": "101", "filename": "notes.txt", "size": 5}]`)
+		case r.Method == "GET" && r.URL.Path == "/rest/api/3/attachment/content/100":
+			fmt.Fprint(w, "%PDF-1.")
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	plugin, authMgr := createTestPlugin()
+	plugin.config.BaseURL = server.URL
+	authMgr.On("GetCredentials", "jira").Return("Basic dXNlcjpwYXNz", nil)
+
+	attachments, err := plugin.ListAttachments(context.Background(), "TEST-1")
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "design.pdf", attachments[0].Filename)
+	assert.Equal(t, int64(7), attachments[0].Size)
+
+	var content strings.Builder
+	require.NoError(t, plugin.DownloadAttachment(context.Background(), attachments[0], &content))
+	assert.Equal(t, "%PDF-1.", content.String())
+
+	uploaded, err := plugin.UploadAttachment(context.Background(), "TEST-1", "notes.txt", strings.NewReader("notes"))
+	require.NoError(t, err)
+	assert.Equal(t, "101", uploaded.ID)
+
+	err = plugin.DownloadAttachment(context.Background(), &Attachment{ID: "9", Content: "https://example.com/file"}, &content)
+	require.Error(t, err, "credentials are not sent to other hosts")
+}
+
 func TestPlugin_HealthCheck(t *testing.T) {
 	// Create mock server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
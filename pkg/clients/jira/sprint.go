@@ -0,0 +1,286 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultStoryPointsField is the story points field of Jira Cloud company-managed
+// projects. Set settings.story_points_field on the provider when a site uses another.
+const DefaultStoryPointsField = "customfield_10016"
+
+// agilePageSize is the number of results requested per page from the Jira Agile API
+const agilePageSize = 50
+
+// Sprint is a Jira sprint with the issues in its scope
+type Sprint struct {
+	ID        string
+	Name      string
+	State     string
+	Goal      string
+	Start     time.Time
+	End       time.Time
+	Completed *time.Time
+	Issues    []SprintIssue
+}
+
+// SprintIssue is an issue in the scope of a sprint
+type SprintIssue struct {
+	Key     string
+	Summary string
+	Points  *float64
+	// Added is when the issue was created, when that is after the sprint started;
+	// Jira only reports later scope changes in the issue changelog
+	Added     time.Time
+	Completed *time.Time
+}
+
+// JiraBoard represents a Jira Agile board
+type JiraBoard struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// JiraSprint represents a Jira Agile sprint
+type JiraSprint struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	State        string    `json:"state"`
+	Goal         string    `json:"goal"`
+	StartDate    *JiraTime `json:"startDate"`
+	EndDate      *JiraTime `json:"endDate"`
+	CompleteDate *JiraTime `json:"completeDate"`
+}
+
+// jiraSprintIssue is a sprint issue with its fields left raw, as the story points
+// field differs between sites
+type jiraSprintIssue struct {
+	Key    string          `json:"key"`
+	Fields json.RawMessage `json:"fields"`
+}
+
+// FetchSprint fetches a sprint of the project's board with the issues in its scope. It
+// fetches the active sprint when name is empty. The board is settings.board_id, or the
+// first scrum board of the project.
+func (p *Plugin) FetchSprint(ctx context.Context, name string) (*Sprint, error) {
+	boardID, err := p.sprintBoard(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jiraSprint, err := p.findSprint(ctx, boardID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint := &Sprint{
+		ID:    strconv.Itoa(jiraSprint.ID),
+		Name:  jiraSprint.Name,
+		State: jiraSprint.State,
+		Goal:  jiraSprint.Goal,
+	}
+	if jiraSprint.StartDate != nil {
+		sprint.Start = jiraSprint.StartDate.Time()
+	}
+	if jiraSprint.EndDate != nil {
+		sprint.End = jiraSprint.EndDate.Time()
+	}
+	if jiraSprint.CompleteDate != nil {
+		completed := jiraSprint.CompleteDate.Time()
+		sprint.Completed = &completed
+	}
+
+	sprint.Issues, err = p.sprintIssues(ctx, jiraSprint.ID, sprint.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger.Debug("successfully fetched sprint", "sprint", sprint.Name, "issues", len(sprint.Issues))
+
+	return sprint, nil
+}
+
+// sprintBoard returns the ID of the board to read sprints from
+func (p *Plugin) sprintBoard(ctx context.Context) (int, error) {
+	switch id := p.config.Settings["board_id"].(type) {
+	case int:
+		return id, nil
+	case float64:
+		return int(id), nil
+	case string:
+		if id != "" {
+			boardID, err := strconv.Atoi(id)
+			if err != nil {
+				return 0, fmt.Errorf("invalid board_id %q: %w", id, err)
+			}
+			return boardID, nil
+		}
+	}
+
+	params := url.Values{}
+	params.Set("projectKeyOrId", p.config.ProjectKey)
+	params.Set("type", "scrum")
+
+	var boards struct {
+		Values []JiraBoard `json:"values"`
+	}
+	if err := p.getAgile(ctx, "board", params, &boards); err != nil {
+		return 0, err
+	}
+	if len(boards.Values) == 0 {
+		return 0, fmt.Errorf("no scrum board found for project %s; set settings.board_id on the jira provider", p.config.ProjectKey)
+	}
+	return boards.Values[0].ID, nil
+}
+
+// findSprint returns the sprint of a board with the given name, or the active sprint
+// when name is empty
+func (p *Plugin) findSprint(ctx context.Context, boardID int, name string) (*JiraSprint, error) {
+	params := url.Values{}
+	params.Set("maxResults", strconv.Itoa(agilePageSize))
+	if name == "" {
+		params.Set("state", "active")
+	} else {
+		params.Set("state", "active,closed,future")
+	}
+
+	for start := 0; ; start += agilePageSize {
+		params.Set("startAt", strconv.Itoa(start))
+
+		var page struct {
+			Values []JiraSprint `json:"values"`
+			IsLast bool         `json:"isLast"`
+		}
+		if err := p.getAgile(ctx, fmt.Sprintf("board/%d/sprint", boardID), params, &page); err != nil {
+			return nil, err
+		}
+
+		for i := range page.Values {
+			if name == "" || strings.EqualFold(page.Values[i].Name, name) {
+				return &page.Values[i], nil
+			}
+		}
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("no active sprint on board %d", boardID)
+	}
+	return nil, fmt.Errorf("sprint not found: %s", name)
+}
+
+// sprintIssues returns the issues in the scope of a sprint
+func (p *Plugin) sprintIssues(ctx context.Context, sprintID int, sprintStart time.Time) ([]SprintIssue, error) {
+	pointsField := DefaultStoryPointsField
+	if field, ok := p.config.Settings["story_points_field"].(string); ok && field != "" {
+		pointsField = field
+	}
+
+	params := url.Values{}
+	params.Set("fields", strings.Join([]string{"summary", "status", "created", "updated", "resolutiondate", pointsField}, ","))
+	params.Set("maxResults", strconv.Itoa(agilePageSize))
+
+	var issues []SprintIssue
+	for start := 0; ; start += agilePageSize {
+		params.Set("startAt", strconv.Itoa(start))
+
+		var page struct {
+			Issues []jiraSprintIssue `json:"issues"`
+			Total  int               `json:"total"`
+		}
+		if err := p.getAgile(ctx, fmt.Sprintf("sprint/%d/issue", sprintID), params, &page); err != nil {
+			return nil, err
+		}
+
+		for _, issue := range page.Issues {
+			issues = append(issues, convertSprintIssue(issue, pointsField, sprintStart))
+		}
+		if len(page.Issues) == 0 || start+len(page.Issues) >= page.Total {
+			break
+		}
+	}
+	return issues, nil
+}
+
+// convertSprintIssue reads the fields of a sprint issue. An issue is completed when its
+// status is in the done category, at its resolution date, or its last update when it
+// has none.
+func convertSprintIssue(issue jiraSprintIssue, pointsField string, sprintStart time.Time) SprintIssue {
+	var fields struct {
+		Summary        string     `json:"summary"`
+		Status         JiraStatus `json:"status"`
+		Created        *JiraTime  `json:"created"`
+		Updated        *JiraTime  `json:"updated"`
+		ResolutionDate *JiraTime  `json:"resolutiondate"`
+	}
+	var custom map[string]json.RawMessage
+	_ = json.Unmarshal(issue.Fields, &fields)
+	_ = json.Unmarshal(issue.Fields, &custom)
+
+	result := SprintIssue{Key: issue.Key, Summary: fields.Summary}
+	if value, ok := custom[pointsField]; ok {
+		_ = json.Unmarshal(value, &result.Points)
+	}
+
+	if fields.Created != nil && fields.Created.Time().After(sprintStart) {
+		result.Added = fields.Created.Time()
+	}
+
+	if fields.Status.StatusCategory.Key == "done" {
+		switch {
+		case fields.ResolutionDate != nil:
+			completed := fields.ResolutionDate.Time()
+			result.Completed = &completed
+		case fields.Updated != nil:
+			completed := fields.Updated.Time()
+			result.Completed = &completed
+		}
+	}
+
+	return result
+}
+
+// getAgile sends a GET request to the Jira Agile API and decodes the response into out
+func (p *Plugin) getAgile(ctx context.Context, path string, params url.Values, out interface{}) error {
+	return p.getJSON(ctx, "rest/agile/1.0/"+path, params, out)
+}
+
+// getJSON sends a GET request to a Jira API path and decodes the response into out
+func (p *Plugin) getJSON(ctx context.Context, path string, params url.Values, out interface{}) error {
+	fullURL := fmt.Sprintf("%s?%s", p.buildJiraURL(path), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if err := p.addAuthentication(req); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return p.handleHTTPError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+// Package notion is a client of the Notion API for the databases that Zen tracks tasks
+// in: it reads and writes database pages, converts their properties to plain values,
+// and converts rich text and page content to Markdown.
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// DefaultBaseURL is the Notion API
+const DefaultBaseURL = "https://api.notion.com/v1"
+
+// APIVersion is the version of the Notion API the client is written against
+const APIVersion = "2022-06-28"
+
+// RequestsPerMinute is the average request rate Notion allows an integration, three
+// requests a second. Requests over it are answered with 429 and a Retry-After header,
+// which the client honours.
+const RequestsPerMinute = 180
+
+// pageSize is the most results Notion returns in one page of a list
+const pageSize = 100
+
+// Client sends requests to the Notion API
+type Client struct {
+	http *zenhttp.Client
+}
+
+// NewClient returns a client of the Notion API at baseURL, or DefaultBaseURL when it is
+// empty, authenticated with an integration token
+func NewClient(baseURL, token string, timeout time.Duration, logger logging.Logger) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		http: zenhttp.NewClient(clients.HTTPConfig{
+			Provider: "notion",
+			BaseURL:  baseURL,
+			Timeout:  timeout,
+			Retries:  3,
+			Headers: map[string]string{
+				"Authorization":  "Bearer " + token,
+				"Notion-Version": APIVersion,
+			},
+			RateLimits: clients.RateLimitConfig{RequestsPerMinute: RequestsPerMinute, BurstSize: 3},
+		}, logger),
+	}
+}
+
+// Database returns a database and the schema of its properties
+func (c *Client) Database(ctx context.Context, id string) (*Database, error) {
+	var database Database
+	if err := c.do(ctx, http.MethodGet, "databases/"+id, nil, &database); err != nil {
+		return nil, err
+	}
+	return &database, nil
+}
+
+// Page returns a page and its properties
+func (c *Client) Page(ctx context.Context, id string) (*Page, error) {
+	var page Page
+	if err := c.do(ctx, http.MethodGet, "pages/"+id, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// QueryDatabase returns the pages of a database that match filter, or all of them when
+// filter is nil, following the pagination of the results up to limit pages; zero
+// returns every page
+func (c *Client) QueryDatabase(ctx context.Context, id string, filter map[string]interface{}, limit int) ([]*Page, error) {
+	var pages []*Page
+	cursor := ""
+	for {
+		body := map[string]interface{}{"page_size": pageSize}
+		if filter != nil {
+			body["filter"] = filter
+		}
+		if cursor != "" {
+			body["start_cursor"] = cursor
+		}
+
+		var result struct {
+			Results    []*Page `json:"results"`
+			HasMore    bool    `json:"has_more"`
+			NextCursor string  `json:"next_cursor"`
+		}
+		if err := c.do(ctx, http.MethodPost, "databases/"+id+"/query", body, &result); err != nil {
+			return nil, err
+		}
+		pages = append(pages, result.Results...)
+
+		if limit > 0 && len(pages) >= limit {
+			return pages[:limit], nil
+		}
+		if !result.HasMore || result.NextCursor == "" {
+			return pages, nil
+		}
+		cursor = result.NextCursor
+	}
+}
+
+// CreatePage adds a page with properties and content to a database
+func (c *Client) CreatePage(ctx context.Context, databaseID string, properties map[string]interface{}, children []Block) (*Page, error) {
+	var page Page
+	body := map[string]interface{}{
+		"parent":     map[string]string{"database_id": databaseID},
+		"properties": properties,
+	}
+	if len(children) > 0 {
+		body["children"] = children
+	}
+	if err := c.do(ctx, http.MethodPost, "pages", body, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// UpdatePage sets properties of a page, leaving the others unchanged
+func (c *Client) UpdatePage(ctx context.Context, id string, properties map[string]interface{}) (*Page, error) {
+	var page Page
+	if err := c.do(ctx, http.MethodPatch, "pages/"+id, map[string]interface{}{"properties": properties}, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ArchivePage moves a page to the trash
+func (c *Client) ArchivePage(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPatch, "pages/"+id, map[string]interface{}{"archived": true}, nil)
+}
+
+// BlockChildren returns the content of a page or block, with the children of nested
+// blocks fetched down to depth levels
+func (c *Client) BlockChildren(ctx context.Context, id string, depth int) ([]Block, error) {
+	var blocks []Block
+	cursor := ""
+	for {
+		path := fmt.Sprintf("blocks/%s/children?page_size=%d", id, pageSize)
+		if cursor != "" {
+			path += "&start_cursor=" + url.QueryEscape(cursor)
+		}
+
+		var result struct {
+			Results    []Block `json:"results"`
+			HasMore    bool    `json:"has_more"`
+			NextCursor string  `json:"next_cursor"`
+		}
+		if err := c.do(ctx, http.MethodGet, path, nil, &result); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, result.Results...)
+
+		if !result.HasMore || result.NextCursor == "" {
+			break
+		}
+		cursor = result.NextCursor
+	}
+
+	if depth > 1 {
+		for i := range blocks {
+			if !blocks[i].HasChildren {
+				continue
+			}
+			children, err := c.BlockChildren(ctx, blocks[i].ID, depth-1)
+			if err != nil {
+				return nil, err
+			}
+			blocks[i].Children = children
+		}
+	}
+	return blocks, nil
+}
+
+// Me returns the bot user of the integration token, which checks the token is valid
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.do(ctx, http.MethodGet, "users/me", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// do sends a JSON request and decodes a successful response into out
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	req := zenhttp.Request{Method: method, URL: path}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		req.Body = body
+		req.Headers = map[string]string{"Content-Type": "application/json"}
+	}
+
+	resp, err := c.http.Do(ctx, req)
+	if err != nil {
+		return &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: "Notion request failed",
+			Details: err.Error(),
+		}
+	}
+	if resp.StatusCode >= 300 {
+		return apiError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("failed to parse Notion response: %w", err)
+	}
+	return nil
+}
+
+// apiError converts an unsuccessful response into an error with the code and message
+// of the Notion error object
+func apiError(resp *zenhttp.Response) error {
+	var body struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(resp.Body, &body)
+
+	code := clients.ErrorCodeUnknown
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		code = clients.ErrorCodeAuthenticationFailed
+	case http.StatusNotFound:
+		code = clients.ErrorCodeNotFound
+	case http.StatusBadRequest, http.StatusConflict:
+		code = clients.ErrorCodeInvalidRequest
+	case http.StatusTooManyRequests:
+		code = clients.ErrorCodeRateLimited
+	}
+
+	message := body.Message
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	return &clients.ClientError{
+		Code:       code,
+		Message:    fmt.Sprintf("Notion API error (%d %s): %s", resp.StatusCode, body.Code, message),
+		StatusCode: resp.StatusCode,
+		Retryable:  resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500,
+	}
+}
@@ -0,0 +1,113 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_QueryDatabase(t *testing.T) {
+	var cursors []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/databases/db1/query", r.URL.Path)
+		assert.Equal(t, "Bearer secret_test", r.Header.Get("Authorization"))
+		assert.Equal(t, APIVersion, r.Header.Get("Notion-Version"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"property": "Status", "status": map[string]interface{}{"equals": "Done"}}, body["filter"])
+		cursors = append(cursors, body["start_cursor"])
+
+		if body["start_cursor"] == nil {
+			fmt.Fprint(w, `{"results": [{"id": "p1"}, {"id": "p2"}], "has_more": true, "next_cursor": "c2"}`)
+			return
+		}
+		fmt.Fprint(w, `{"results": [{"id": "p3"}], "has_more": false, "next_cursor": null}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "secret_test", time.Second, logging.NewBasic())
+	filter := map[string]interface{}{"property": "Status", "status": map[string]interface{}{"equals": "Done"}}
+
+	pages, err := client.QueryDatabase(context.Background(), "db1", filter, 0)
+	require.NoError(t, err)
+	require.Len(t, pages, 3)
+	assert.Equal(t, "p3", pages[2].ID)
+	assert.Equal(t, []interface{}{nil, "c2"}, cursors)
+
+	cursors = nil
+	pages, err = client.QueryDatabase(context.Background(), "db1", filter, 1)
+	require.NoError(t, err)
+	assert.Len(t, pages, 1)
+	assert.Len(t, cursors, 1, "no more pages are read than the limit needs")
+}
+
+func TestClient_BlockChildren(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/blocks/page1/children":
+			fmt.Fprint(w, `{"results": [
+				{"id": "b1", "type": "bulleted_list_item", "has_children": true, "bulleted_list_item": {"rich_text": [{"plain_text": "Parent"}]}},
+				{"id": "b2", "type": "paragraph", "paragraph": {"rich_text": [{"plain_text": "After"}]}}]}`)
+		case "/blocks/b1/children":
+			fmt.Fprint(w, `{"results": [{"id": "b3", "type": "bulleted_list_item", "bulleted_list_item": {"rich_text": [{"plain_text": "Child"}]}}]}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "secret_test", time.Second, logging.NewBasic())
+	blocks, err := client.BlockChildren(context.Background(), "page1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "- Parent\n  - Child\n\nAfter", BlocksToMarkdown(blocks))
+
+	blocks, err = client.BlockChildren(context.Background(), "page1", 1)
+	require.NoError(t, err)
+	assert.Empty(t, blocks[0].Children)
+}
+
+func TestClient_RateLimited(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"object": "error", "status": 429, "code": "rate_limited", "message": "You have been rate limited."}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "page1", "url": "https://www.notion.so/page1", "properties": {}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "secret_test", time.Second, logging.NewBasic())
+	page, err := client.Page(context.Background(), "page1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.notion.so/page1", page.URL)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"object": "error", "status": 404, "code": "object_not_found", "message": "Could not find page with ID: page1."}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "secret_test", time.Second, logging.NewBasic())
+	_, err := client.Page(context.Background(), "page1")
+	var clientErr *clients.ClientError
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, clients.ErrorCodeNotFound, clientErr.Code)
+	assert.Equal(t, "Notion API error (404 object_not_found): Could not find page with ID: page1.", clientErr.Message)
+}
@@ -0,0 +1,193 @@
+package notion
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxTextLength is the most characters Notion accepts in one rich text span
+const maxTextLength = 2000
+
+// RichTextToMarkdown converts rich text to Markdown, keeping bold, italic, strikethrough,
+// inline code and links. Underline has no Markdown form and is dropped.
+func RichTextToMarkdown(spans []RichText) string {
+	var b strings.Builder
+	for _, span := range spans {
+		text := span.PlainText
+		if text == "" && span.Text != nil {
+			text = span.Text.Content
+		}
+		if text == "" {
+			continue
+		}
+
+		// Markers must touch the text they style, so surrounding spaces stay outside
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" {
+			b.WriteString(text)
+			continue
+		}
+		leading := text[:strings.Index(text, trimmed)]
+		trailing := text[len(leading)+len(trimmed):]
+
+		styled := trimmed
+		if a := span.Annotations; a != nil {
+			if a.Code {
+				styled = "`" + styled + "`"
+			}
+			if a.Bold && a.Italic {
+				styled = "***" + styled + "***"
+			} else if a.Bold {
+				styled = "**" + styled + "**"
+			} else if a.Italic {
+				styled = "_" + styled + "_"
+			}
+			if a.Strikethrough {
+				styled = "~~" + styled + "~~"
+			}
+		}
+		if link := spanLink(span); link != "" {
+			styled = "[" + styled + "](" + link + ")"
+		}
+		b.WriteString(leading + styled + trailing)
+	}
+	return b.String()
+}
+
+// spanLink returns the target of a linked span
+func spanLink(span RichText) string {
+	if span.Text != nil && span.Text.Link != nil {
+		return span.Text.Link.URL
+	}
+	if span.Href != nil {
+		return *span.Href
+	}
+	return ""
+}
+
+// PlainText joins the text of rich text spans without formatting
+func PlainText(spans []RichText) string {
+	var b strings.Builder
+	for _, span := range spans {
+		if span.PlainText != "" {
+			b.WriteString(span.PlainText)
+		} else if span.Text != nil {
+			b.WriteString(span.Text.Content)
+		}
+	}
+	return b.String()
+}
+
+// TextToRichText converts text to the rich text of a property, split into spans of the
+// length Notion accepts
+func TextToRichText(text string) []RichText {
+	var spans []RichText
+	for text != "" {
+		chunk := text
+		if utf8.RuneCountInString(chunk) > maxTextLength {
+			chunk = string([]rune(text)[:maxTextLength])
+		}
+		spans = append(spans, RichText{Type: "text", Text: &Text{Content: chunk}})
+		text = text[len(chunk):]
+	}
+	return spans
+}
+
+// TextToBlocks converts text to page content, a paragraph block for each paragraph.
+// Markdown in the text is kept as written rather than converted to blocks.
+func TextToBlocks(text string) []Block {
+	var blocks []Block
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			blocks = append(blocks, Block{Type: "paragraph", Paragraph: &BlockContent{RichText: TextToRichText(paragraph)}})
+		}
+	}
+	return blocks
+}
+
+// BlocksToMarkdown converts page content to Markdown. Blocks without a Markdown form,
+// such as embeds and databases, are left out.
+func BlocksToMarkdown(blocks []Block) string {
+	return strings.Join(markdownParagraphs(blocks), "\n\n")
+}
+
+// markdownParagraphs returns the Markdown of blocks as paragraphs, with the items of a
+// list kept together in one paragraph
+func markdownParagraphs(blocks []Block) []string {
+	var paragraphs []string
+	number := 0
+	for i := range blocks {
+		block := &blocks[i]
+		if block.Type == "numbered_list_item" {
+			number++
+		} else {
+			number = 0
+		}
+
+		text := ""
+		if content := block.content(); content != nil {
+			text = RichTextToMarkdown(content.RichText)
+		}
+
+		var markdown string
+		list := false
+		switch block.Type {
+		case "paragraph":
+			markdown = text
+		case "heading_1":
+			markdown = "# " + text
+		case "heading_2":
+			markdown = "## " + text
+		case "heading_3":
+			markdown = "### " + text
+		case "bulleted_list_item", "toggle":
+			markdown, list = "- "+text, true
+		case "numbered_list_item":
+			markdown, list = fmt.Sprintf("%d. %s", number, text), true
+		case "to_do":
+			box := "[ ]"
+			if block.ToDo != nil && block.ToDo.Checked {
+				box = "[x]"
+			}
+			markdown, list = "- "+box+" "+text, true
+		case "quote", "callout":
+			markdown = "> " + strings.ReplaceAll(text, "\n", "\n> ")
+		case "code":
+			language := ""
+			if block.Code == nil {
+				continue
+			}
+			if block.Code.Language != "plain text" {
+				language = block.Code.Language
+			}
+			markdown = "```" + language + "\n" + PlainText(block.Code.RichText) + "\n```"
+		case "divider":
+			markdown = "---"
+		default:
+			continue
+		}
+
+		if nested := markdownParagraphs(block.Children); list && len(nested) > 0 {
+			markdown += "\n  " + strings.ReplaceAll(strings.Join(nested, "\n"), "\n", "\n  ")
+		}
+
+		// Consecutive list items form one list
+		if list && i > 0 && isListItem(blocks[i-1].Type) && len(paragraphs) > 0 {
+			paragraphs[len(paragraphs)-1] += "\n" + markdown
+			continue
+		}
+		paragraphs = append(paragraphs, markdown)
+	}
+	return paragraphs
+}
+
+// isListItem reports whether blocks of a type are written as list items
+func isListItem(blockType string) bool {
+	switch blockType {
+	case "bulleted_list_item", "numbered_list_item", "to_do", "toggle":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,95 @@
+package notion
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func span(text string, annotations Annotations) RichText {
+	return RichText{Type: "text", PlainText: text, Annotations: &annotations, Text: &Text{Content: text}}
+}
+
+func textBlock(blockType, text string) Block {
+	block := Block{Type: blockType}
+	content := &BlockContent{RichText: []RichText{{Type: "text", PlainText: text}}}
+	switch blockType {
+	case "paragraph":
+		block.Paragraph = content
+	case "heading_2":
+		block.Heading2 = content
+	case "bulleted_list_item":
+		block.BulletedListItem = content
+	case "numbered_list_item":
+		block.NumberedListItem = content
+	case "to_do":
+		block.ToDo = content
+	case "quote":
+		block.Quote = content
+	}
+	return block
+}
+
+func TestRichTextToMarkdown(t *testing.T) {
+	link := span("the docs", Annotations{})
+	link.Text.Link = &Link{URL: "https://example.com/docs"}
+
+	markdown := RichTextToMarkdown([]RichText{
+		span("Read ", Annotations{}),
+		link,
+		span(" and run ", Annotations{}),
+		span("zen sync", Annotations{Code: true}),
+		span(" before ", Annotations{}),
+		span("Friday ", Annotations{Bold: true}),
+		span("at the latest", Annotations{Italic: true, Strikethrough: true}),
+		span("!", Annotations{Bold: true, Italic: true, Underline: true}),
+	})
+	assert.Equal(t, "Read [the docs](https://example.com/docs) and run `zen sync` before **Friday** ~~_at the latest_~~***!***", markdown)
+	assert.Empty(t, RichTextToMarkdown(nil))
+}
+
+func TestBlocksToMarkdown(t *testing.T) {
+	todo := textBlock("to_do", "Write tests")
+	todo.ToDo.Checked = true
+	nested := textBlock("bulleted_list_item", "Parent")
+	nested.Children = []Block{textBlock("bulleted_list_item", "Child"), textBlock("paragraph", "Note")}
+
+	markdown := BlocksToMarkdown([]Block{
+		textBlock("heading_2", "Acceptance criteria"),
+		textBlock("numbered_list_item", "First"),
+		textBlock("numbered_list_item", "Second"),
+		todo,
+		textBlock("paragraph", "Some context."),
+		nested,
+		{Type: "code", Code: &BlockContent{RichText: []RichText{{PlainText: "zen task create"}}, Language: "shell"}},
+		{Type: "divider", Divider: &struct{}{}},
+		textBlock("quote", "Keep it simple"),
+		{Type: "child_database"},
+	})
+
+	assert.Equal(t, strings.Join([]string{
+		"## Acceptance criteria",
+		"1. First\n2. Second\n- [x] Write tests",
+		"Some context.",
+		"- Parent\n  - Child\n  Note",
+		"```shell\nzen task create\n```",
+		"---",
+		"> Keep it simple",
+	}, "\n\n"), markdown)
+}
+
+func TestTextToRichText(t *testing.T) {
+	assert.Empty(t, TextToRichText(""))
+
+	long := strings.Repeat("é", maxTextLength+10)
+	spans := TextToRichText(long)
+	require.Len(t, spans, 2)
+	assert.Equal(t, maxTextLength, len([]rune(spans[0].Text.Content)))
+	assert.Equal(t, long, PlainText(spans))
+
+	blocks := TextToBlocks("First paragraph.\n\n\n\nSecond paragraph.\n")
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "Second paragraph.", PlainText(blocks[1].Paragraph.RichText))
+}
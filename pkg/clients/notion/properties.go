@@ -0,0 +1,257 @@
+package notion
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// typePrefix starts a property reference that names a property type rather than a
+// property, such as "type:title"
+const typePrefix = "type:"
+
+// ResolveProperty returns the name of the property a reference names in a schema of
+// property types by name. A reference is a property name, matched case-insensitively
+// when no property has it exactly, or "type:<type>" for the first property of the type
+// in name order, as databases have one title but may have several properties of other
+// types.
+func ResolveProperty(schema map[string]string, ref string) (string, bool) {
+	if propertyType, ok := strings.CutPrefix(ref, typePrefix); ok {
+		names := make([]string, 0, len(schema))
+		for name, t := range schema {
+			if t == propertyType {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return "", false
+		}
+		sort.Strings(names)
+		return names[0], true
+	}
+
+	if _, ok := schema[ref]; ok {
+		return ref, true
+	}
+	for name := range schema {
+		if strings.EqualFold(name, ref) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// DatabaseSchema returns the types of the properties of a database by name
+func DatabaseSchema(database *Database) map[string]string {
+	schema := make(map[string]string, len(database.Properties))
+	for name, property := range database.Properties {
+		schema[name] = property.Type
+	}
+	return schema
+}
+
+// PageSchema returns the types of the properties of a page by name
+func PageSchema(page *Page) map[string]string {
+	schema := make(map[string]string, len(page.Properties))
+	for name, property := range page.Properties {
+		schema[name] = property.Type
+	}
+	return schema
+}
+
+// PropertyValue returns the value of a property as plain data: a string for text,
+// options, dates, URLs and IDs, rich text as Markdown, a []string for multi-select and
+// people, a float64 for numbers and a bool for checkboxes. Empty values and properties of
+// other types are nil.
+func PropertyValue(property Property) interface{} {
+	switch property.Type {
+	case "title":
+		return nonEmpty(PlainText(property.Title))
+	case "rich_text":
+		return nonEmpty(RichTextToMarkdown(property.RichText))
+	case "select":
+		if property.Select != nil {
+			return nonEmpty(property.Select.Name)
+		}
+	case "status":
+		if property.Status != nil {
+			return nonEmpty(property.Status.Name)
+		}
+	case "multi_select":
+		if len(property.MultiSelect) > 0 {
+			names := make([]string, 0, len(property.MultiSelect))
+			for _, option := range property.MultiSelect {
+				names = append(names, option.Name)
+			}
+			return names
+		}
+	case "people":
+		if len(property.People) > 0 {
+			names := make([]string, 0, len(property.People))
+			for _, user := range property.People {
+				names = append(names, userName(user))
+			}
+			return names
+		}
+	case "date":
+		if property.Date != nil {
+			return nonEmpty(property.Date.Start)
+		}
+	case "number":
+		if property.Number != nil {
+			return *property.Number
+		}
+	case "checkbox":
+		return property.Checkbox
+	case "url":
+		if property.URL != nil {
+			return nonEmpty(*property.URL)
+		}
+	case "email":
+		if property.Email != nil {
+			return nonEmpty(*property.Email)
+		}
+	case "unique_id":
+		if property.UniqueID != nil {
+			if property.UniqueID.Prefix != nil && *property.UniqueID.Prefix != "" {
+				return fmt.Sprintf("%s-%d", *property.UniqueID.Prefix, property.UniqueID.Number)
+			}
+			return strconv.Itoa(property.UniqueID.Number)
+		}
+	}
+	return nil
+}
+
+// PropertyInput returns the request value that sets a property of propertyType to
+// value. People are set by user ID, since Notion does not look users up by name.
+func PropertyInput(propertyType string, value interface{}) (interface{}, error) {
+	switch propertyType {
+	case "title":
+		return map[string]interface{}{"title": TextToRichText(stringValue(value))}, nil
+	case "rich_text":
+		return map[string]interface{}{"rich_text": TextToRichText(stringValue(value))}, nil
+	case "select", "status":
+		name := stringValue(value)
+		if name == "" {
+			return map[string]interface{}{propertyType: nil}, nil
+		}
+		return map[string]interface{}{propertyType: map[string]string{"name": name}}, nil
+	case "multi_select":
+		options := []map[string]string{}
+		for _, name := range stringsValue(value) {
+			options = append(options, map[string]string{"name": name})
+		}
+		return map[string]interface{}{"multi_select": options}, nil
+	case "people":
+		people := []map[string]string{}
+		for _, id := range stringsValue(value) {
+			people = append(people, map[string]string{"object": "user", "id": id})
+		}
+		return map[string]interface{}{"people": people}, nil
+	case "date":
+		start := stringValue(value)
+		if t, ok := value.(time.Time); ok {
+			start = t.Format("2006-01-02")
+		}
+		if start == "" {
+			return map[string]interface{}{"date": nil}, nil
+		}
+		return map[string]interface{}{"date": map[string]string{"start": start}}, nil
+	case "number":
+		switch v := value.(type) {
+		case float64, int, int64:
+			return map[string]interface{}{"number": v}, nil
+		case string:
+			if v == "" {
+				return map[string]interface{}{"number": nil}, nil
+			}
+			number, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a number", v)
+			}
+			return map[string]interface{}{"number": number}, nil
+		}
+	case "checkbox":
+		switch v := value.(type) {
+		case bool:
+			return map[string]interface{}{"checkbox": v}, nil
+		case string:
+			checked, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a boolean", v)
+			}
+			return map[string]interface{}{"checkbox": checked}, nil
+		}
+	case "url", "email":
+		text := stringValue(value)
+		if text == "" {
+			return map[string]interface{}{propertyType: nil}, nil
+		}
+		return map[string]interface{}{propertyType: text}, nil
+	default:
+		return nil, fmt.Errorf("properties of type %s cannot be set", propertyType)
+	}
+	return nil, fmt.Errorf("cannot set a %s property to %v", propertyType, value)
+}
+
+// userName returns the name of a user, or their email or ID when the integration cannot
+// read the name
+func userName(user User) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	if user.Person != nil && user.Person.Email != "" {
+		return user.Person.Email
+	}
+	return user.ID
+}
+
+func nonEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func stringsValue(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, stringValue(item))
+		}
+		return values
+	case string:
+		if v == "" {
+			return nil
+		}
+		var values []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	default:
+		return []string{stringValue(v)}
+	}
+}
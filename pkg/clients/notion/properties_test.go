@@ -0,0 +1,95 @@
+package notion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveProperty(t *testing.T) {
+	schema := map[string]string{
+		"Name":     "title",
+		"Status":   "status",
+		"Tags":     "multi_select",
+		"Area":     "multi_select",
+		"Due date": "date",
+	}
+
+	for ref, expected := range map[string]string{
+		"type:title":        "Name",
+		"type:multi_select": "Area",
+		"Status":            "Status",
+		"due date":          "Due date",
+	} {
+		name, ok := ResolveProperty(schema, ref)
+		assert.True(t, ok, ref)
+		assert.Equal(t, expected, name, ref)
+	}
+
+	_, ok := ResolveProperty(schema, "type:people")
+	assert.False(t, ok)
+	_, ok = ResolveProperty(schema, "Priority")
+	assert.False(t, ok)
+}
+
+func TestPropertyValue(t *testing.T) {
+	prefix := "TASK"
+	number := 3.0
+	link := "https://example.com"
+
+	for name, test := range map[string]struct {
+		property Property
+		expected interface{}
+	}{
+		"title":         {Property{Type: "title", Title: []RichText{{PlainText: "Fix "}, {PlainText: "login", Annotations: &Annotations{Bold: true}}}}, "Fix login"},
+		"rich text":     {Property{Type: "rich_text", RichText: []RichText{{PlainText: "login", Annotations: &Annotations{Bold: true}}}}, "**login**"},
+		"empty text":    {Property{Type: "rich_text"}, nil},
+		"select":        {Property{Type: "select", Select: &Option{Name: "High"}}, "High"},
+		"empty select":  {Property{Type: "select"}, nil},
+		"status":        {Property{Type: "status", Status: &Option{Name: "In progress"}}, "In progress"},
+		"multi-select":  {Property{Type: "multi_select", MultiSelect: []Option{{Name: "api"}, {Name: "auth"}}}, []string{"api", "auth"}},
+		"people":        {Property{Type: "people", People: []User{{ID: "u1", Name: "Ada"}, {ID: "u2"}}}, []string{"Ada", "u2"}},
+		"date":          {Property{Type: "date", Date: &Date{Start: "2026-11-02"}}, "2026-11-02"},
+		"number":        {Property{Type: "number", Number: &number}, 3.0},
+		"checkbox":      {Property{Type: "checkbox", Checkbox: true}, true},
+		"url":           {Property{Type: "url", URL: &link}, link},
+		"unique ID":     {Property{Type: "unique_id", UniqueID: &UniqueID{Prefix: &prefix, Number: 42}}, "TASK-42"},
+		"bare ID":       {Property{Type: "unique_id", UniqueID: &UniqueID{Number: 42}}, "42"},
+		"computed type": {Property{Type: "formula"}, nil},
+	} {
+		assert.Equal(t, test.expected, PropertyValue(test.property), name)
+	}
+}
+
+func TestPropertyInput(t *testing.T) {
+	input, err := PropertyInput("title", "Fix login")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"title": []RichText{{Type: "text", Text: &Text{Content: "Fix login"}}}}, input)
+
+	input, err = PropertyInput("status", "Done")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"status": map[string]string{"name": "Done"}}, input)
+
+	input, err = PropertyInput("select", "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"select": nil}, input)
+
+	input, err = PropertyInput("multi_select", "api, auth")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"multi_select": []map[string]string{{"name": "api"}, {"name": "auth"}}}, input)
+
+	input, err = PropertyInput("date", time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"date": map[string]string{"start": "2026-11-02"}}, input)
+
+	input, err = PropertyInput("number", "5")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"number": 5.0}, input)
+
+	_, err = PropertyInput("number", "high")
+	assert.EqualError(t, err, `"high" is not a number`)
+	_, err = PropertyInput("formula", "x")
+	assert.EqualError(t, err, "properties of type formula cannot be set")
+}
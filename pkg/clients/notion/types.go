@@ -0,0 +1,163 @@
+package notion
+
+import "time"
+
+// Database is a Notion database and the schema of the properties of its pages
+type Database struct {
+	ID         string                    `json:"id"`
+	URL        string                    `json:"url"`
+	Title      []RichText                `json:"title"`
+	Properties map[string]PropertySchema `json:"properties"`
+}
+
+// PropertySchema describes a property of the pages of a database
+type PropertySchema struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Page is a Notion page; the pages of a database are its rows
+type Page struct {
+	ID             string              `json:"id"`
+	URL            string              `json:"url"`
+	CreatedTime    time.Time           `json:"created_time"`
+	LastEditedTime time.Time           `json:"last_edited_time"`
+	Archived       bool                `json:"archived"`
+	Properties     map[string]Property `json:"properties"`
+}
+
+// Property is the value of a page property. Only the field named by Type is set.
+type Property struct {
+	ID          string     `json:"id,omitempty"`
+	Type        string     `json:"type"`
+	Title       []RichText `json:"title,omitempty"`
+	RichText    []RichText `json:"rich_text,omitempty"`
+	Select      *Option    `json:"select,omitempty"`
+	Status      *Option    `json:"status,omitempty"`
+	MultiSelect []Option   `json:"multi_select,omitempty"`
+	People      []User     `json:"people,omitempty"`
+	Date        *Date      `json:"date,omitempty"`
+	Number      *float64   `json:"number,omitempty"`
+	Checkbox    bool       `json:"checkbox,omitempty"`
+	URL         *string    `json:"url,omitempty"`
+	Email       *string    `json:"email,omitempty"`
+	UniqueID    *UniqueID  `json:"unique_id,omitempty"`
+}
+
+// Option is an option of a select, status or multi-select property
+type Option struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+// User is a Notion user
+type User struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Person *struct {
+		Email string `json:"email"`
+	} `json:"person,omitempty"`
+}
+
+// Date is the value of a date property; End is only set for ranges
+type Date struct {
+	Start string  `json:"start"`
+	End   *string `json:"end,omitempty"`
+}
+
+// UniqueID is the value of an ID property, such as TASK-42
+type UniqueID struct {
+	Prefix *string `json:"prefix"`
+	Number int     `json:"number"`
+}
+
+// RichText is a span of formatted text
+type RichText struct {
+	Type        string       `json:"type"`
+	PlainText   string       `json:"plain_text"`
+	Href        *string      `json:"href,omitempty"`
+	Annotations *Annotations `json:"annotations,omitempty"`
+	Text        *Text        `json:"text,omitempty"`
+}
+
+// Annotations are the styles of a span of rich text
+type Annotations struct {
+	Bold          bool `json:"bold"`
+	Italic        bool `json:"italic"`
+	Strikethrough bool `json:"strikethrough"`
+	Underline     bool `json:"underline"`
+	Code          bool `json:"code"`
+}
+
+// Text is the content of a text span
+type Text struct {
+	Content string `json:"content"`
+	Link    *Link  `json:"link,omitempty"`
+}
+
+// Link is the target of a linked text span
+type Link struct {
+	URL string `json:"url"`
+}
+
+// Block is a block of page content. Only the field named by Type is set.
+type Block struct {
+	ID               string        `json:"id,omitempty"`
+	Type             string        `json:"type"`
+	HasChildren      bool          `json:"has_children,omitempty"`
+	Paragraph        *BlockContent `json:"paragraph,omitempty"`
+	Heading1         *BlockContent `json:"heading_1,omitempty"`
+	Heading2         *BlockContent `json:"heading_2,omitempty"`
+	Heading3         *BlockContent `json:"heading_3,omitempty"`
+	BulletedListItem *BlockContent `json:"bulleted_list_item,omitempty"`
+	NumberedListItem *BlockContent `json:"numbered_list_item,omitempty"`
+	ToDo             *BlockContent `json:"to_do,omitempty"`
+	Toggle           *BlockContent `json:"toggle,omitempty"`
+	Quote            *BlockContent `json:"quote,omitempty"`
+	Callout          *BlockContent `json:"callout,omitempty"`
+	Code             *BlockContent `json:"code,omitempty"`
+	Divider          *struct{}     `json:"divider,omitempty"`
+
+	// Children are the nested blocks of list items, to-dos and toggles, when they
+	// have been fetched
+	Children []Block `json:"-"`
+}
+
+// BlockContent is the content of a text block
+type BlockContent struct {
+	RichText []RichText `json:"rich_text"`
+	Checked  bool       `json:"checked,omitempty"`
+	Language string     `json:"language,omitempty"`
+}
+
+// content returns the content of a text block, or nil for other blocks
+func (b *Block) content() *BlockContent {
+	switch b.Type {
+	case "paragraph":
+		return b.Paragraph
+	case "heading_1":
+		return b.Heading1
+	case "heading_2":
+		return b.Heading2
+	case "heading_3":
+		return b.Heading3
+	case "bulleted_list_item":
+		return b.BulletedListItem
+	case "numbered_list_item":
+		return b.NumberedListItem
+	case "to_do":
+		return b.ToDo
+	case "toggle":
+		return b.Toggle
+	case "quote":
+		return b.Quote
+	case "callout":
+		return b.Callout
+	case "code":
+		return b.Code
+	default:
+		return nil
+	}
+}
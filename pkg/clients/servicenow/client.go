@@ -0,0 +1,375 @@
+// Package servicenow is a client of the ServiceNow Table and Attachment APIs for the
+// incident and change request tables that ops teams manage tickets in. Requests are
+// authenticated with basic auth, a bearer token, or OAuth tokens from the instance.
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// pageSize is the most records a query reads in one request
+const pageSize = 100
+
+// tokenExpiryMargin is how long before they expire OAuth tokens are renewed
+const tokenExpiryMargin = time.Minute
+
+// Credentials authenticate requests to an instance
+type Credentials struct {
+	// Authorization is the Authorization header of every request, such as "Basic ..."
+	// or "Bearer ...", when OAuth is not used
+	Authorization string
+
+	// ClientID and ClientSecret are those of an OAuth application registry entry of
+	// the instance. Tokens are requested with the password grant when Username and
+	// Password are set, and with the client credentials grant otherwise.
+	ClientID     string
+	ClientSecret string
+	Username     string
+	Password     string
+}
+
+// OAuth reports whether the credentials request OAuth tokens
+func (c Credentials) OAuth() bool {
+	return c.ClientID != ""
+}
+
+// Client sends requests to a ServiceNow instance
+type Client struct {
+	http        *zenhttp.Client
+	credentials Credentials
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// InstanceURL returns the URL of an instance, which is given by name, such as "acme"
+// for https://acme.service-now.com, or by its URL
+func InstanceURL(instance string) string {
+	instance = strings.TrimRight(instance, "/")
+	if instance == "" || strings.Contains(instance, "://") {
+		return instance
+	}
+	if strings.Contains(instance, ".") {
+		return "https://" + instance
+	}
+	return "https://" + instance + ".service-now.com"
+}
+
+// NewClient returns a client of the instance at instanceURL
+func NewClient(instanceURL string, credentials Credentials, timeout time.Duration, logger logging.Logger) *Client {
+	return &Client{
+		http: zenhttp.NewClient(clients.HTTPConfig{
+			Provider: "servicenow",
+			BaseURL:  InstanceURL(instanceURL),
+			Timeout:  timeout,
+			Retries:  3,
+			Headers:  map[string]string{"Accept": "application/json"},
+		}, logger),
+		credentials: credentials,
+	}
+}
+
+// Get returns a record of a table by sys_id
+func (c *Client) Get(ctx context.Context, table, sysID string) (Record, error) {
+	var result struct {
+		Result Record `json:"result"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, tablePath(table, sysID), recordParams(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+// Query returns the records of a table that match an encoded query, such as
+// "number=INC0010023" or "active=true^priority=1", up to limit records; zero returns
+// every record
+func (c *Client) Query(ctx context.Context, table, query string, limit int) ([]Record, error) {
+	var records []Record
+	for offset := 0; ; offset += pageSize {
+		size := pageSize
+		if limit > 0 && limit-len(records) < size {
+			size = limit - len(records)
+		}
+		params := recordParams()
+		params.Set("sysparm_query", query)
+		params.Set("sysparm_limit", strconv.Itoa(size))
+		params.Set("sysparm_offset", strconv.Itoa(offset))
+
+		var result struct {
+			Result []Record `json:"result"`
+		}
+		if err := c.doJSON(ctx, http.MethodGet, tablePath(table, ""), params, nil, &result); err != nil {
+			return nil, err
+		}
+		records = append(records, result.Result...)
+
+		if len(result.Result) < size || (limit > 0 && len(records) >= limit) {
+			return records, nil
+		}
+	}
+}
+
+// Create inserts a record into a table, returning the record as stored
+func (c *Client) Create(ctx context.Context, table string, fields map[string]string) (Record, error) {
+	var result struct {
+		Result Record `json:"result"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, tablePath(table, ""), recordParams(), fields, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+// Update sets fields of a record, leaving the others unchanged
+func (c *Client) Update(ctx context.Context, table, sysID string, fields map[string]string) (Record, error) {
+	var result struct {
+		Result Record `json:"result"`
+	}
+	if err := c.doJSON(ctx, http.MethodPatch, tablePath(table, sysID), recordParams(), fields, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+// Attachments returns the files attached to a record
+func (c *Client) Attachments(ctx context.Context, table, sysID string) ([]Attachment, error) {
+	params := url.Values{"sysparm_query": {"table_name=" + table + "^table_sys_id=" + sysID}}
+	var result struct {
+		Result []Attachment `json:"result"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, "api/now/attachment", params, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+// UploadAttachment attaches a file to a record
+func (c *Client) UploadAttachment(ctx context.Context, table, sysID, name, contentType string, content []byte) (*Attachment, error) {
+	params := url.Values{"table_name": {table}, "table_sys_id": {sysID}, "file_name": {name}}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	resp, err := c.do(ctx, zenhttp.Request{
+		Method:  http.MethodPost,
+		URL:     "api/now/attachment/file?" + params.Encode(),
+		Headers: map[string]string{"Content-Type": contentType},
+		Body:    content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result Attachment `json:"result"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse ServiceNow response: %w", err)
+	}
+	return &result.Result, nil
+}
+
+// DownloadAttachment returns the content of an attached file
+func (c *Client) DownloadAttachment(ctx context.Context, sysID string) ([]byte, error) {
+	resp, err := c.do(ctx, zenhttp.Request{
+		Method:  http.MethodGet,
+		URL:     "api/now/attachment/" + url.PathEscape(sysID) + "/file",
+		Headers: map[string]string{"Accept": "*/*"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// doJSON sends a request with a JSON body and decodes a successful response into out
+func (c *Client) doJSON(ctx context.Context, method, path string, params url.Values, in, out interface{}) error {
+	req := zenhttp.Request{Method: method, URL: path}
+	if len(params) > 0 {
+		req.URL += "?" + params.Encode()
+	}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		req.Body = body
+		req.Headers = map[string]string{"Content-Type": "application/json"}
+	}
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("failed to parse ServiceNow response: %w", err)
+	}
+	return nil
+}
+
+// do sends an authenticated request, returning an error for unsuccessful responses. A
+// request rejected with an OAuth token is sent again with a new token, as tokens can be
+// revoked before they expire.
+func (c *Client) do(ctx context.Context, req zenhttp.Request) (*zenhttp.Response, error) {
+	for attempt := 0; ; attempt++ {
+		authorization, err := c.authorization(ctx, attempt > 0)
+		if err != nil {
+			return nil, err
+		}
+		headers := map[string]string{"Authorization": authorization}
+		for key, value := range req.Headers {
+			headers[key] = value
+		}
+		attemptReq := req
+		attemptReq.Headers = headers
+
+		resp, err := c.http.Do(ctx, attemptReq)
+		if err != nil {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeNetworkError,
+				Message: "ServiceNow request failed",
+				Details: err.Error(),
+			}
+		}
+		if resp.StatusCode == http.StatusUnauthorized && c.credentials.OAuth() && attempt == 0 {
+			continue
+		}
+		if resp.StatusCode >= 300 {
+			return nil, apiError(resp)
+		}
+		return resp, nil
+	}
+}
+
+// authorization returns the Authorization header of a request, requesting an OAuth
+// token when there is none, it is about to expire, or renew is set
+func (c *Client) authorization(ctx context.Context, renew bool) (string, error) {
+	if !c.credentials.OAuth() {
+		return c.credentials.Authorization, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && !renew && time.Now().Before(c.tokenExpiry) {
+		return "Bearer " + c.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.credentials.ClientID},
+		"client_secret": {c.credentials.ClientSecret},
+	}
+	if c.credentials.Username != "" {
+		form.Set("grant_type", "password")
+		form.Set("username", c.credentials.Username)
+		form.Set("password", c.credentials.Password)
+	}
+	resp, err := c.http.Do(ctx, zenhttp.Request{
+		Method:  http.MethodPost,
+		URL:     "oauth_token.do",
+		Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		Body:    []byte(form.Encode()),
+	})
+	if err != nil {
+		return "", &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: "ServiceNow OAuth token request failed",
+			Details: err.Error(),
+		}
+	}
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	_ = json.Unmarshal(resp.Body, &token)
+	if resp.StatusCode >= 300 || token.AccessToken == "" {
+		message := token.ErrorDescription
+		if message == "" {
+			message = token.Error
+		}
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		return "", &clients.ClientError{
+			Code:       clients.ErrorCodeAuthenticationFailed,
+			Message:    "ServiceNow did not issue an OAuth token: " + message,
+			StatusCode: resp.StatusCode,
+		}
+	}
+
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryMargin)
+	return "Bearer " + c.token, nil
+}
+
+// apiError converts an unsuccessful response into an error with the message of the
+// ServiceNow error object
+func apiError(resp *zenhttp.Response) error {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+			Detail  string `json:"detail"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(resp.Body, &body)
+
+	code := clients.ErrorCodeUnknown
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		code = clients.ErrorCodeAuthenticationFailed
+	case http.StatusNotFound:
+		code = clients.ErrorCodeNotFound
+	case http.StatusBadRequest:
+		code = clients.ErrorCodeInvalidRequest
+	case http.StatusTooManyRequests:
+		code = clients.ErrorCodeRateLimited
+	}
+
+	message := body.Error.Message
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	if body.Error.Detail != "" {
+		message += ": " + body.Error.Detail
+	}
+	return &clients.ClientError{
+		Code:       code,
+		Message:    fmt.Sprintf("ServiceNow API error (%d): %s", resp.StatusCode, message),
+		StatusCode: resp.StatusCode,
+		Retryable:  resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500,
+	}
+}
+
+// tablePath returns the Table API path of a table, or of a record of it
+func tablePath(table, sysID string) string {
+	path := "api/now/table/" + url.PathEscape(table)
+	if sysID != "" {
+		path += "/" + url.PathEscape(sysID)
+	}
+	return path
+}
+
+// recordParams returns the parameters that have records returned with both the stored
+// and display values of their fields, and references as plain values
+func recordParams() url.Values {
+	return url.Values{
+		"sysparm_display_value":          {"all"},
+		"sysparm_exclude_reference_link": {"true"},
+	}
+}
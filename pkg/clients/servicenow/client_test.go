@@ -0,0 +1,170 @@
+package servicenow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceURL(t *testing.T) {
+	assert.Equal(t, "https://acme.service-now.com", InstanceURL("acme"))
+	assert.Equal(t, "https://itsm.acme.com", InstanceURL("itsm.acme.com/"))
+	assert.Equal(t, "http://localhost:8080", InstanceURL("http://localhost:8080"))
+	assert.Empty(t, InstanceURL(""))
+}
+
+func TestClient_Query(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/now/table/incident", r.URL.Path)
+		assert.Equal(t, "Basic dGVzdA==", r.Header.Get("Authorization"))
+		assert.Equal(t, "active=true", r.URL.Query().Get("sysparm_query"))
+		assert.Equal(t, "all", r.URL.Query().Get("sysparm_display_value"))
+		offsets = append(offsets, r.URL.Query().Get("sysparm_offset"))
+
+		if r.URL.Query().Get("sysparm_offset") == "0" {
+			limit, _ := strconv.Atoi(r.URL.Query().Get("sysparm_limit"))
+			records := ""
+			for i := 0; i < limit; i++ {
+				if i > 0 {
+					records += ","
+				}
+				records += fmt.Sprintf(`{"number": {"value": "INC%07d", "display_value": "INC%07d"}}`, i, i)
+			}
+			fmt.Fprintf(w, `{"result": [%s]}`, records)
+			return
+		}
+		fmt.Fprint(w, `{"result": [{"number": {"value": "INC0000100"}, "state": {"value": "2", "display_value": "In Progress"}}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Credentials{Authorization: "Basic dGVzdA=="}, time.Second, logging.NewBasic())
+	records, err := client.Query(context.Background(), TableIncident, "active=true", 0)
+	require.NoError(t, err)
+	require.Len(t, records, pageSize+1)
+	assert.Equal(t, "INC0000100", records[pageSize].Value("number"))
+	assert.Equal(t, "In Progress", records[pageSize].Display("state"))
+	assert.Equal(t, "INC0000100", records[pageSize].Display("number"), "fields without a display value show their value")
+	assert.Equal(t, []string{"0", "100"}, offsets)
+
+	offsets = nil
+	records, err = client.Query(context.Background(), TableIncident, "active=true", 1)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Len(t, offsets, 1, "no more pages are read than the limit needs")
+}
+
+func TestClient_OAuth(t *testing.T) {
+	var grants []string
+	revoked := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth_token.do" {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "zen", r.PostForm.Get("client_id"))
+			assert.Equal(t, "s3cret", r.PostForm.Get("client_secret"))
+			grants = append(grants, r.PostForm.Get("grant_type"))
+			fmt.Fprintf(w, `{"access_token": "token%d", "expires_in": 1800}`, len(grants))
+			return
+		}
+		if revoked && r.Header.Get("Authorization") == "Bearer token1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": {"message": "User Not Authenticated"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"result": {"sys_id": {"value": "abc"}}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Credentials{ClientID: "zen", ClientSecret: "s3cret"}, time.Second, logging.NewBasic())
+	for i := 0; i < 2; i++ {
+		_, err := client.Get(context.Background(), TableIncident, "abc")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"client_credentials"}, grants, "tokens are reused until they expire")
+
+	revoked = true
+	record, err := client.Get(context.Background(), TableIncident, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", record.Value("sys_id"))
+	assert.Len(t, grants, 2, "rejected tokens are renewed")
+}
+
+func TestClient_OAuthPasswordGrant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "password", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "ops.bot", r.PostForm.Get("username"))
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": "access_denied", "error_description": "access_denied"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Credentials{ClientID: "zen", ClientSecret: "s3cret", Username: "ops.bot", Password: "pw"}, time.Second, logging.NewBasic())
+	_, err := client.Get(context.Background(), TableIncident, "abc")
+	var clientErr *clients.ClientError
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, clients.ErrorCodeAuthenticationFailed, clientErr.Code)
+	assert.Equal(t, "ServiceNow did not issue an OAuth token: access_denied", clientErr.Message)
+}
+
+func TestClient_Attachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/now/attachment":
+			assert.Equal(t, "table_name=incident^table_sys_id=abc", r.URL.Query().Get("sysparm_query"))
+			fmt.Fprint(w, `{"result": [{"sys_id": "att1", "file_name": "trace.log", "size_bytes": "11", "sys_created_on": "2026-10-14 16:30:00"}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/now/attachment/file":
+			assert.Equal(t, "trace.log", r.URL.Query().Get("file_name"))
+			assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "stack trace", string(body))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"result": {"sys_id": "att2", "file_name": "trace.log"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/now/attachment/att1/file":
+			fmt.Fprint(w, "stack trace")
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Credentials{Authorization: "Bearer t"}, time.Second, logging.NewBasic())
+	attachments, err := client.Attachments(context.Background(), TableIncident, "abc")
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, int64(11), attachments[0].Size())
+	assert.Equal(t, time.Date(2026, 10, 14, 16, 30, 0, 0, time.UTC), attachments[0].Created())
+
+	uploaded, err := client.UploadAttachment(context.Background(), TableIncident, "abc", "trace.log", "text/plain", []byte("stack trace"))
+	require.NoError(t, err)
+	assert.Equal(t, "att2", uploaded.SysID)
+
+	content, err := client.DownloadAttachment(context.Background(), "att1")
+	require.NoError(t, err)
+	assert.Equal(t, "stack trace", string(content))
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"message": "No Record found", "detail": "Record doesn't exist or ACL restricts the record retrieval"}, "status": "failure"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Credentials{Authorization: "Bearer t"}, time.Second, logging.NewBasic())
+	_, err := client.Get(context.Background(), TableIncident, "abc")
+	var clientErr *clients.ClientError
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, clients.ErrorCodeNotFound, clientErr.Code)
+	assert.Equal(t, "ServiceNow API error (404): No Record found: Record doesn't exist or ACL restricts the record retrieval", clientErr.Message)
+}
@@ -0,0 +1,107 @@
+package servicenow
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tables of the records Zen tracks tasks in
+const (
+	TableIncident      = "incident"
+	TableChangeRequest = "change_request"
+)
+
+// numberPrefixes are the tables of records by the prefix of their numbers
+var numberPrefixes = map[string]string{
+	"INC": TableIncident,
+	"CHG": TableChangeRequest,
+}
+
+// TableOf returns the table of a record by the prefix of its number, such as incident
+// for INC0010023
+func TableOf(number string) (string, bool) {
+	prefix := strings.TrimRightFunc(strings.ToUpper(number), func(r rune) bool { return r >= '0' && r <= '9' })
+	table, ok := numberPrefixes[prefix]
+	return table, ok && prefix != strings.ToUpper(number)
+}
+
+// States maps the state values of a table to task statuses, and task statuses to the
+// state records are moved to
+type States struct {
+	Statuses map[string]string
+	States   map[string]string
+}
+
+// DefaultStates are the states of the tables of an instance that has not renamed or
+// added states
+var DefaultStates = map[string]States{
+	TableIncident: {
+		Statuses: map[string]string{
+			"1": "proposed",    // New
+			"2": "in_progress", // In Progress
+			"3": "blocked",     // On Hold
+			"6": "completed",   // Resolved
+			"7": "completed",   // Closed
+			"8": "cancelled",   // Canceled
+		},
+		States: map[string]string{
+			"proposed": "1", "in_progress": "2", "in_review": "2", "blocked": "3",
+			"completed": "6", "cancelled": "8",
+		},
+	},
+	TableChangeRequest: {
+		Statuses: map[string]string{
+			"-5": "proposed",    // New
+			"-4": "in_review",   // Assess
+			"-3": "in_review",   // Authorize
+			"-2": "proposed",    // Scheduled
+			"-1": "in_progress", // Implement
+			"0":  "in_review",   // Review
+			"3":  "completed",   // Closed
+			"4":  "cancelled",   // Canceled
+		},
+		States: map[string]string{
+			"proposed": "-5", "in_progress": "-1", "in_review": "0",
+			"completed": "3", "cancelled": "4",
+		},
+	},
+}
+
+// StatesOf returns the states of a table from the task status of each state value. Each
+// status moves records to the lowest state that maps to it.
+func StatesOf(statuses map[string]string) States {
+	values := make([]string, 0, len(statuses))
+	for value := range statuses {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		a, errA := strconv.Atoi(values[i])
+		b, errB := strconv.Atoi(values[j])
+		if errA != nil || errB != nil {
+			return values[i] < values[j]
+		}
+		return a < b
+	})
+
+	states := States{Statuses: statuses, States: map[string]string{}}
+	for _, value := range values {
+		if _, ok := states.States[statuses[value]]; !ok {
+			states.States[statuses[value]] = value
+		}
+	}
+	return states
+}
+
+// DefaultCloseCodes are the close codes records are resolved with by table. Resolving
+// an incident or closing a change request requires a close code and close notes.
+var DefaultCloseCodes = map[string]string{
+	TableIncident:      "Solution provided",
+	TableChangeRequest: "successful",
+}
+
+// ClosingStates are the states of each table that records need a close code to move to
+var ClosingStates = map[string][]string{
+	TableIncident:      {"6", "7"},
+	TableChangeRequest: {"3"},
+}
@@ -0,0 +1,42 @@
+package servicenow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableOf(t *testing.T) {
+	table, ok := TableOf("INC0010023")
+	assert.True(t, ok)
+	assert.Equal(t, TableIncident, table)
+
+	table, ok = TableOf("chg0030001")
+	assert.True(t, ok)
+	assert.Equal(t, TableChangeRequest, table)
+
+	_, ok = TableOf("INC")
+	assert.False(t, ok)
+	_, ok = TableOf("PRB0040001")
+	assert.False(t, ok)
+}
+
+func TestStatesOf(t *testing.T) {
+	states := StatesOf(map[string]string{
+		"10": "proposed", "-1": "in_progress", "2": "in_progress", "7": "completed", "6": "completed",
+	})
+	assert.Equal(t, map[string]string{"proposed": "10", "in_progress": "-1", "completed": "6"}, states.States)
+	assert.Equal(t, "in_progress", states.Statuses["2"])
+}
+
+func TestDefaultStates(t *testing.T) {
+	// Every status a state maps to is pushed as a state that maps back to it
+	for table, states := range DefaultStates {
+		for status, state := range states.States {
+			if status == "in_review" && table == TableIncident {
+				continue
+			}
+			assert.Equal(t, status, states.Statuses[state], "%s state %s", table, state)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package servicenow
+
+import (
+	"strconv"
+	"time"
+)
+
+// Field is a field of a record with its stored value and the value shown to users, such
+// as "2" and "In Progress" for a state, or a sys_id and a name for a reference
+type Field struct {
+	Value        string `json:"value"`
+	DisplayValue string `json:"display_value"`
+}
+
+// Record is a row of a table, by field name
+type Record map[string]Field
+
+// Value returns the stored value of a field
+func (r Record) Value(name string) string {
+	return r[name].Value
+}
+
+// Display returns the value of a field shown to users, or its stored value when it has
+// no other
+func (r Record) Display(name string) string {
+	if field := r[name]; field.DisplayValue != "" {
+		return field.DisplayValue
+	}
+	return r[name].Value
+}
+
+// Time returns the value of a date and time field, which ServiceNow stores in UTC
+func (r Record) Time(name string) time.Time {
+	t, _ := time.Parse(timeLayout, r.Value(name))
+	return t
+}
+
+// timeLayout is the layout of the stored values of date and time fields
+const timeLayout = "2006-01-02 15:04:05"
+
+// Attachment is a file attached to a record
+type Attachment struct {
+	SysID        string `json:"sys_id"`
+	FileName     string `json:"file_name"`
+	ContentType  string `json:"content_type"`
+	SizeBytes    string `json:"size_bytes"`
+	DownloadLink string `json:"download_link"`
+	SysCreatedOn string `json:"sys_created_on"`
+}
+
+// Size returns the size of the file in bytes
+func (a *Attachment) Size() int64 {
+	size, _ := strconv.ParseInt(a.SizeBytes, 10, 64)
+	return size
+}
+
+// Created returns when the file was attached
+func (a *Attachment) Created() time.Time {
+	t, _ := time.Parse(timeLayout, a.SysCreatedOn)
+	return t
+}
@@ -19,6 +19,7 @@ type Client interface {
 
 // HTTPConfig contains common HTTP client configuration
 type HTTPConfig struct {
+	Provider   string            `json:"provider" yaml:"provider"`
 	BaseURL    string            `json:"base_url" yaml:"base_url"`
 	Timeout    time.Duration     `json:"timeout" yaml:"timeout"`
 	Retries    int               `json:"retries" yaml:"retries"`
@@ -3,7 +3,9 @@ package assets
 import (
 	"github.com/daddia/zen/pkg/cmd/assets/auth"
 	"github.com/daddia/zen/pkg/cmd/assets/info"
+	"github.com/daddia/zen/pkg/cmd/assets/lint"
 	"github.com/daddia/zen/pkg/cmd/assets/list"
+	"github.com/daddia/zen/pkg/cmd/assets/stats"
 	"github.com/daddia/zen/pkg/cmd/assets/status"
 	"github.com/daddia/zen/pkg/cmd/assets/sync"
 	"github.com/daddia/zen/pkg/cmdutil"
@@ -28,6 +30,10 @@ Authentication:
 Discovery:
   List available assets with filtering and search capabilities.
   Get detailed information about specific assets including metadata.
+  See which assets the workspace uses most, and which it no longer uses.
+
+Authoring:
+  Check the templates and prompts of an asset repository before publishing them.
 
 Synchronization:
   Keep your local asset cache synchronized with remote repositories.
@@ -47,6 +53,12 @@ Synchronization:
   # Get detailed information about a specific asset
   zen assets info technical-spec
 
+  # Show the most used and stale assets of the workspace
+  zen assets stats
+
+  # Check the assets of a checkout of the asset repository before publishing
+  zen assets lint ../zen-assets
+
   # Synchronize with remote repository
   zen assets sync
 
@@ -60,6 +72,8 @@ Synchronization:
 	cmd.AddCommand(status.NewCmdAssetsStatus(f))
 	cmd.AddCommand(list.NewCmdAssetsList(f))
 	cmd.AddCommand(info.NewCmdAssetsInfo(f))
+	cmd.AddCommand(stats.NewCmdAssetsStats(f))
+	cmd.AddCommand(lint.NewCmdAssetsLint(f))
 	cmd.AddCommand(sync.NewCmdAssetsSync(f))
 
 	return cmd
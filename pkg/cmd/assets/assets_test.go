@@ -57,7 +57,7 @@ func TestCmdAssetsHasSubcommands(t *testing.T) {
 	cmd := NewCmdAssets(f)
 
 	// Check that all expected subcommands are present
-	expectedSubcommands := []string{"auth", "status", "list", "info", "sync"}
+	expectedSubcommands := []string{"auth", "status", "list", "info", "stats", "lint", "sync"}
 
 	for _, expectedCmd := range expectedSubcommands {
 		subCmd, _, err := cmd.Find([]string{expectedCmd})
@@ -205,7 +205,10 @@ func getEnvVar(name string) string {
 
 func promptForToken(opts *AuthOptions) (string, error) {
 	if !opts.IO.CanPrompt() {
-		return "", fmt.Errorf("authentication token required but prompting is disabled")
+		return "", &cmdutil.PromptError{
+			Need: "authentication token required",
+			Hint: "pass --token or --token-file, or set the provider's token environment variable (see 'zen help environment')",
+		}
 	}
 
 	cs := internal.NewColorScheme(opts.IO)
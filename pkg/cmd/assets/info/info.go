@@ -2,8 +2,9 @@ package info
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,7 +14,6 @@ import (
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 // InfoOptions contains options for the info command
@@ -21,6 +21,8 @@ type InfoOptions struct {
 	IO              *iostreams.IOStreams
 	AssetClient     func() (assets.AssetClientInterface, error)
 	OutputFormat    string
+	Template        string
+	JQ              string
 	AssetName       string
 	IncludeContent  bool
 	VerifyIntegrity bool
@@ -43,6 +45,7 @@ This command shows comprehensive metadata about an asset including:
 - Basic information (name, type, description)
 - Categorization (category, tags)
 - Template variables (for template assets)
+- Dependencies, including those of dependencies, which are fetched with the asset
 - File information (size, checksum, last updated)
 - Cache status and integrity
 
@@ -58,12 +61,12 @@ the --include-content flag.`,
   zen assets info technical-spec --output json --include-content
 
   # Skip integrity verification for faster response
-  zen assets info large-template --no-verify`,
+  zen assets info large-template --verify=false`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.AssetName = args[0]
-			// Get output format from persistent flag
-			opts.OutputFormat, _ = cmd.Flags().GetString("output")
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
 			return infoRun(opts)
 		},
 	}
@@ -71,6 +74,8 @@ the --include-content flag.`,
 	cmd.Flags().BoolVar(&opts.IncludeContent, "include-content", false, "Include asset content in output")
 	cmd.Flags().BoolVar(&opts.VerifyIntegrity, "verify", true, "Verify asset integrity")
 
+	cmdutil.AddFormatFlags(cmd)
+
 	return cmd
 }
 
@@ -106,48 +111,30 @@ func infoRun(opts *InfoOptions) error {
 	}
 
 	// Display information based on output format
-	switch opts.OutputFormat {
-	case "json":
-		return displayInfoJSON(opts, assetContent)
-	case "yaml":
-		return displayInfoYAML(opts, assetContent)
-	default:
-		return displayInfoText(opts, assetContent)
-	}
-}
-
-func displayInfoJSON(opts *InfoOptions, content *assets.AssetContent) error {
-	output := content
-	if !opts.IncludeContent {
-		// Create a copy without content to avoid modifying original
-		output = &assets.AssetContent{
-			Metadata: content.Metadata,
-			Checksum: content.Checksum,
-			Cached:   content.Cached,
-			CacheAge: content.CacheAge,
-		}
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	if renderer.IsMachineReadable() && !opts.IncludeContent {
+		// Copy without content to avoid modifying the original
+		assetContent = withoutContent(assetContent)
 	}
 
-	encoder := json.NewEncoder(opts.IO.Out)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return renderer.Render(assetContent, func(w io.Writer) error {
+		return displayInfoText(opts, assetContent)
+	})
 }
 
-func displayInfoYAML(opts *InfoOptions, content *assets.AssetContent) error {
-	output := content
-	if !opts.IncludeContent {
-		// Create a copy without content
-		output = &assets.AssetContent{
-			Metadata: content.Metadata,
-			Checksum: content.Checksum,
-			Cached:   content.Cached,
-			CacheAge: content.CacheAge,
-		}
+// withoutContent returns a copy of content, and of its dependencies, without their content
+func withoutContent(content *assets.AssetContent) *assets.AssetContent {
+	stripped := &assets.AssetContent{
+		Metadata: content.Metadata,
+		Checksum: content.Checksum,
+		Cached:   content.Cached,
+		CacheAge: content.CacheAge,
 	}
-
-	encoder := yaml.NewEncoder(opts.IO.Out)
-	defer encoder.Close()
-	return encoder.Encode(output)
+	for _, dependency := range content.Dependencies {
+		stripped.Dependencies = append(stripped.Dependencies, *withoutContent(&dependency))
+	}
+	return stripped
 }
 
 func displayInfoText(opts *InfoOptions, content *assets.AssetContent) error {
@@ -171,7 +158,7 @@ func displayInfoText(opts *InfoOptions, content *assets.AssetContent) error {
 		typeDisplay = cs.Green(string(meta.Type))
 	case assets.AssetTypeMCP:
 		typeDisplay = cs.Yellow(string(meta.Type))
-	case assets.AssetTypeSchema:
+	case assets.AssetTypeSchema, assets.AssetTypePartial:
 		typeDisplay = cs.Blue(string(meta.Type))
 	default:
 		typeDisplay = string(meta.Type)
@@ -221,6 +208,19 @@ func displayInfoText(opts *InfoOptions, content *assets.AssetContent) error {
 		fmt.Fprintln(opts.IO.Out)
 	}
 
+	// Dependencies section, with the assets each dependency needs in turn listed first
+	if len(content.Dependencies) > 0 {
+		fmt.Fprintf(opts.IO.Out, "%s\n", cs.Bold("Dependencies"))
+		for _, dependency := range content.Dependencies {
+			direct := ""
+			if !slices.Contains(meta.Dependencies, dependency.Metadata.Name) {
+				direct = cs.Gray(" (indirect)")
+			}
+			fmt.Fprintf(opts.IO.Out, "  %s [%s]%s\n", dependency.Metadata.Name, dependency.Metadata.Type, direct)
+		}
+		fmt.Fprintln(opts.IO.Out)
+	}
+
 	// File information section
 	fmt.Fprintf(opts.IO.Out, "%s\n", cs.Bold("File information"))
 	fmt.Fprintf(opts.IO.Out, "  Path: %s\n", meta.Path)
@@ -246,7 +246,7 @@ func displayInfoText(opts *InfoOptions, content *assets.AssetContent) error {
 	// Cache status section
 	fmt.Fprintf(opts.IO.Out, "%s\n", cs.Bold("Cache status"))
 	if content.Cached {
-		fmt.Fprintf(opts.IO.Out, "  Status: %s Cached\n", cs.Green("✓"))
+		fmt.Fprintf(opts.IO.Out, "  Status: %s Cached\n", cs.SuccessIcon())
 		if content.CacheAge > 0 {
 			cacheAge := time.Duration(content.CacheAge) * time.Second
 			fmt.Fprintf(opts.IO.Out, "  Age: %s\n", formatDuration(cacheAge))
@@ -257,7 +257,7 @@ func displayInfoText(opts *InfoOptions, content *assets.AssetContent) error {
 
 	// Integrity status
 	if opts.VerifyIntegrity {
-		fmt.Fprintf(opts.IO.Out, "  Integrity: %s Verified\n", cs.Green("✓"))
+		fmt.Fprintf(opts.IO.Out, "  Integrity: %s Verified\n", cs.SuccessIcon())
 	}
 	fmt.Fprintln(opts.IO.Out)
 
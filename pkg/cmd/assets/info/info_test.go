@@ -129,6 +129,59 @@ func TestInfoTextOutput(t *testing.T) {
 	// assert.Contains(t, output, "Content Preview")
 }
 
+func TestInfoDependencies(t *testing.T) {
+	testAsset := &assets.AssetContent{
+		Metadata: assets.AssetMetadata{
+			Name:         "page",
+			Type:         assets.AssetTypeTemplate,
+			Dependencies: []string{"header"},
+		},
+		Content: `{{template "header" .}}`,
+		Dependencies: []assets.AssetContent{
+			{Metadata: assets.AssetMetadata{Name: "page-schema", Type: assets.AssetTypeSchema}, Content: "{}"},
+			{Metadata: assets.AssetMetadata{Name: "header", Type: assets.AssetTypePartial}, Content: "# Header"},
+		},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		io := iostreams.Test()
+		f := cmdutil.NewTestFactory(io)
+		f.AssetClient = func() (assets.AssetClientInterface, error) {
+			return &mockInfoAssetClient{asset: testAsset}, nil
+		}
+
+		cmd := NewCmdAssetsInfo(f)
+		cmd.SetArgs([]string{"page"})
+		cmd.SetOut(io.Out)
+		require.NoError(t, cmd.Execute())
+
+		output := io.Out.(*bytes.Buffer).String()
+		assert.Contains(t, output, "Dependencies\n  page-schema [schema] (indirect)\n  header [partial]\n")
+	})
+
+	t.Run("json without content", func(t *testing.T) {
+		io := iostreams.Test()
+		f := cmdutil.NewTestFactory(io)
+		f.AssetClient = func() (assets.AssetClientInterface, error) {
+			return &mockInfoAssetClient{asset: testAsset}, nil
+		}
+
+		parentCmd := &cobra.Command{Use: "assets"}
+		parentCmd.PersistentFlags().StringP("output", "o", "text", "Output format")
+		parentCmd.AddCommand(NewCmdAssetsInfo(f))
+		parentCmd.SetArgs([]string{"info", "page", "--output", "json"})
+		parentCmd.SetOut(io.Out)
+		require.NoError(t, parentCmd.Execute())
+
+		var content assets.AssetContent
+		require.NoError(t, json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &content))
+		require.Len(t, content.Dependencies, 2)
+		assert.Equal(t, "header", content.Dependencies[1].Metadata.Name)
+		assert.Empty(t, content.Dependencies[1].Content)
+		assert.Equal(t, "# Header", testAsset.Dependencies[1].Content, "the asset is left as it was")
+	})
+}
+
 func TestInfoWithContent(t *testing.T) {
 	io := iostreams.Test()
 	stdout := io.Out
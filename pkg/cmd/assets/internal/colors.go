@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/iostreams"
 )
 
@@ -58,28 +59,28 @@ func (cs *ColorScheme) Gray(text string) string {
 
 // SuccessIcon returns a success icon
 func (cs *ColorScheme) SuccessIcon() string {
-	return cs.Green("✓")
+	return cs.io.SuccessIcon()
 }
 
 // ErrorIcon returns an error icon
 func (cs *ColorScheme) ErrorIcon() string {
-	return cs.Red("✗")
+	return cs.io.FailureIcon()
 }
 
 // WarningIcon returns a warning icon
 func (cs *ColorScheme) WarningIcon() string {
-	return cs.Yellow("⚠")
+	return cs.io.WarningIcon()
 }
 
 // InfoIcon returns an info icon
 func (cs *ColorScheme) InfoIcon() string {
-	return cs.Blue("ℹ")
+	return cs.io.InfoIcon()
 }
 
 // PromptForPassword prompts the user for a password input
 func PromptForPassword(io *iostreams.IOStreams, prompt string) (string, error) {
 	if !io.CanPrompt() {
-		return "", fmt.Errorf("prompting is disabled")
+		return "", &cmdutil.PromptError{Need: "input required"}
 	}
 
 	fmt.Fprint(io.Out, prompt+": ")
@@ -0,0 +1,142 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmd/assets/internal"
+	"github.com/daddia/zen/pkg/cmd/draft"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// LintOptions contains options for the lint command
+type LintOptions struct {
+	IO           *iostreams.IOStreams
+	Logger       logging.Logger
+	OutputFormat string
+	Template     string
+	JQ           string
+	Path         string
+	Strict       bool
+}
+
+// NewCmdAssetsLint creates the assets lint command
+func NewCmdAssetsLint(f *cmdutil.Factory) *cobra.Command {
+	opts := &LintOptions{
+		IO:     f.IOStreams,
+		Logger: f.Logger,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lint [<path>]",
+		Short: "Check template and prompt assets for errors",
+		Long: `Check the assets of an asset repository before they are published.
+
+The path is a checkout of an asset repository, the current directory by default,
+with its manifest in assets/manifest.yaml. Every asset of the manifest must have
+its file, and template, prompt, and partial assets are checked for:
+
+- Template syntax errors
+- Undefined variables: variables read by a template that neither its front
+  matter, its manifest entry, nor the task data zen draft passes declare
+- Unreachable partials: templates included with {{template}} that the asset does
+  not define or depend on, and partials no asset depends on (a warning)
+- Front matter that does not follow the template metadata schema
+- Broken internal links to files or headings that do not exist
+
+The command exits with a failure when errors are found, or with --strict when
+warnings are found, so that it can gate publishing in CI.`,
+		Example: `  # Lint the asset repository in the current directory
+  zen assets lint
+
+  # Lint a checkout of the asset repository, failing on warnings too
+  zen assets lint ../zen-assets --strict
+
+  # Output the issues as JSON
+  zen assets lint --output json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Path = "."
+			if len(args) > 0 {
+				opts.Path = args[0]
+			}
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+			return lintRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Strict, "strict", false, "Fail on warnings as well as errors")
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func lintRun(ctx context.Context, opts *LintOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	info, err := os.Stat(opts.Path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read asset repository")
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", opts.Path)
+	}
+
+	linter := template.NewLinter(opts.Logger, template.LintOptions{Variables: draft.TemplateVariables()})
+	report, err := linter.Lint(ctx, os.DirFS(opts.Path))
+	if err != nil {
+		return err
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	if err := renderer.Render(report, func(w io.Writer) error {
+		return displayLintText(opts, report)
+	}); err != nil {
+		return err
+	}
+
+	if report.Failed(opts.Strict) {
+		return cmdutil.ErrSilent
+	}
+	return nil
+}
+
+func displayLintText(opts *LintOptions, report *template.LintReport) error {
+	cs := internal.NewColorScheme(opts.IO)
+
+	for _, issue := range report.Issues {
+		location := issue.Path
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", issue.Path, issue.Line)
+		}
+		severity := cs.Yellow(string(issue.Severity))
+		if issue.Severity == template.LintSeverityError {
+			severity = cs.Red(string(issue.Severity))
+		}
+		fmt.Fprintf(opts.IO.Out, "%s: %s: %s %s\n", cs.Bold(location), severity, issue.Message, cs.Gray("("+issue.Rule+")"))
+	}
+	if len(report.Issues) == 0 {
+		fmt.Fprintf(opts.IO.Out, "%s %d assets checked, no issues found\n", cs.SuccessIcon(), report.Assets)
+		return nil
+	}
+
+	fmt.Fprintln(opts.IO.Out)
+	icon := cs.WarningIcon()
+	if report.Failed(opts.Strict) {
+		icon = cs.ErrorIcon()
+	}
+	fmt.Fprintf(opts.IO.Out, "%s %d assets checked: %d errors, %d warnings\n", icon, report.Assets, report.Errors, report.Warnings)
+	return nil
+}
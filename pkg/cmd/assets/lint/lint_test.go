@@ -0,0 +1,109 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `schema_version: "2.0"
+activities:
+  feature-spec:
+    name: feature-spec
+    command: feature-spec
+    description: Feature specification
+    variables:
+      - {name: FEATURE, type: string}
+    assets:
+      output: ["templates/feature-spec.md.tmpl"]
+`
+
+func setupRepository(t *testing.T, spec string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"assets/manifest.yaml":           testManifest,
+		"templates/feature-spec.md.tmpl": spec,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestNewCmdAssetsLint(t *testing.T) {
+	cmd := NewCmdAssetsLint(cmdutil.NewTestFactory(iostreams.Test()))
+
+	assert.Equal(t, "lint [<path>]", cmd.Use)
+	assert.Contains(t, cmd.Example, "zen assets lint")
+	strict := cmd.Flags().Lookup("strict")
+	require.NotNil(t, strict)
+	assert.Equal(t, "false", strict.DefValue)
+}
+
+func TestLintClean(t *testing.T) {
+	dir := setupRepository(t, "# {{.FEATURE}}\n\nOwned by {{.OWNER_NAME}}\n")
+	io := iostreams.Test()
+
+	cmd := NewCmdAssetsLint(cmdutil.NewTestFactory(io))
+	cmd.SetArgs([]string{dir})
+	cmd.SetOut(io.Out)
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, io.Out.(*bytes.Buffer).String(), "1 assets checked, no issues found")
+}
+
+func TestLintIssues(t *testing.T) {
+	dir := setupRepository(t, "# {{.FEATURE}}\n\n{{.UNKNOWN}} [guide](docs/guide.md)\n")
+	io := iostreams.Test()
+
+	cmd := NewCmdAssetsLint(cmdutil.NewTestFactory(io))
+	cmd.SetArgs([]string{dir})
+	cmd.SetOut(io.Out)
+	cmd.SetErr(io.ErrOut)
+	assert.ErrorIs(t, cmd.Execute(), cmdutil.ErrSilent)
+
+	output := io.Out.(*bytes.Buffer).String()
+	assert.Contains(t, output, "templates/feature-spec.md.tmpl:3: error: variable 'UNKNOWN' is not declared by the asset (undefined-variable)")
+	assert.Contains(t, output, "templates/feature-spec.md.tmpl:3: error: link to docs/guide.md: templates/docs/guide.md is not in the repository (broken-link)")
+	assert.Contains(t, output, "1 assets checked: 2 errors, 0 warnings")
+}
+
+func TestLintJSON(t *testing.T) {
+	dir := setupRepository(t, "{{template \"missing\" .}}")
+	io := iostreams.Test()
+
+	parentCmd := &cobra.Command{Use: "assets"}
+	parentCmd.PersistentFlags().StringP("output", "o", "text", "Output format")
+	parentCmd.AddCommand(NewCmdAssetsLint(cmdutil.NewTestFactory(io)))
+	parentCmd.SetArgs([]string{"lint", dir, "--output", "json"})
+	parentCmd.SetOut(io.Out)
+	parentCmd.SetErr(io.ErrOut)
+	parentCmd.SilenceUsage = true
+	assert.ErrorIs(t, parentCmd.Execute(), cmdutil.ErrSilent)
+
+	var report template.LintReport
+	require.NoError(t, json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &report))
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, template.LintRuleUnreachablePartial, report.Issues[0].Rule)
+}
+
+func TestLintNotARepository(t *testing.T) {
+	io := iostreams.Test()
+
+	cmd := NewCmdAssetsLint(cmdutil.NewTestFactory(io))
+	cmd.SetArgs([]string{t.TempDir()})
+	cmd.SetOut(io.Out)
+	cmd.SetErr(io.ErrOut)
+	assert.ErrorContains(t, cmd.Execute(), "failed to read assets/manifest.yaml")
+}
@@ -2,8 +2,8 @@ package list
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"text/tabwriter"
@@ -14,7 +14,6 @@ import (
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 // ListOptions contains options for the list command
@@ -22,11 +21,43 @@ type ListOptions struct {
 	IO           *iostreams.IOStreams
 	AssetClient  func() (assets.AssetClientInterface, error)
 	OutputFormat string
+	Template     string
+	JQ           string
 	Type         string
 	Category     string
 	Tags         []string
 	Limit        int
 	Offset       int
+	PageToken    string
+	Columns      []string
+	Sort         string
+}
+
+// assetTable is the table assets are listed in
+var assetTable = cmdutil.Table[assets.AssetMetadata]{
+	Columns: []cmdutil.Column[assets.AssetMetadata]{
+		{Name: "name", Value: func(a assets.AssetMetadata) string { return a.Name }},
+		{Name: "command", Value: func(a assets.AssetMetadata) string { return fmt.Sprintf("`%s`", a.Command) },
+			Compare: func(a, b assets.AssetMetadata) int { return strings.Compare(a.Command, b.Command) }},
+		{Name: "description", Value: func(a assets.AssetMetadata) string {
+			if len(a.Description) > 60 {
+				return a.Description[:57] + "..."
+			}
+			return a.Description
+		}},
+		{Name: "format", Header: "OUTPUT FORMAT", Value: func(a assets.AssetMetadata) string { return a.Format }},
+		{Name: "type", Value: func(a assets.AssetMetadata) string { return string(a.Type) }},
+		{Name: "category", Value: func(a assets.AssetMetadata) string { return a.Category }},
+		{Name: "tags", Value: func(a assets.AssetMetadata) string { return strings.Join(a.Tags, ", ") }},
+		{Name: "output", Value: func(a assets.AssetMetadata) string { return a.OutputFile }},
+		{Name: "updated", Value: func(a assets.AssetMetadata) string {
+			if a.UpdatedAt.IsZero() {
+				return ""
+			}
+			return a.UpdatedAt.Format("2006-01-02")
+		}, Compare: func(a, b assets.AssetMetadata) int { return a.UpdatedAt.Compare(b.UpdatedAt) }},
+	},
+	Default: []string{"name", "command", "description", "format"},
 }
 
 // NewCmdAssetsList creates the assets list command
@@ -47,11 +78,16 @@ offline activity discovery. The manifest contains metadata about all available a
 without storing the actual content locally.
 
 Activities can be filtered by category and tags. Results are paginated
-to handle large activity repositories efficiently.
+to handle large activity repositories efficiently. Each page that has more
+results after it ends with a page token; pass it to --page-token to list the
+next page. Tokens are refused when the filters or the manifest have changed.
 
 Each activity represents a workflow step with associated templates and prompts
 for generating documentation, code, or configurations.
 
+Assets are listed by name. Use --columns to choose the columns of the table and
+--sort to order assets by columns, each prefixed with '-' for descending order.
+
 The list command works offline using the local manifest. Use 'zen assets sync'
 to update the manifest with the latest activities from the repository.`,
 		Example: `  # List all activities
@@ -72,20 +108,38 @@ to update the manifest with the latest activities from the repository.`,
   # Limit results and use pagination
   zen assets list --limit 10 --offset 20
 
+  # Continue a listing from the token of the previous page
+  zen assets list --limit 10 --page-token MjA6YjQ1Mjk4ZTY4YjM3
+
+  # Show the most recently updated assets first, with their category
+  zen assets list --columns name,category,updated --sort -updated
+
   # Output as JSON
-  zen assets list --output json`,
+  zen assets list --output json
+
+  # Print asset names with a Go template
+  zen assets list --format '{{range .Assets}}{{.Name}}{{"\n"}}{{end}}'
+
+  # Select fields with a jq expression
+  zen assets list --jq '.assets[] | select(.type == "template") | .name'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Get output format from persistent flag
-			opts.OutputFormat, _ = cmd.Flags().GetString("output")
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+			opts.Columns, opts.Sort = cmdutil.TableFlags(cmd)
 			return listRun(opts)
 		},
 	}
 
-	cmd.Flags().StringVar(&opts.Type, "type", "", "Filter by asset type (template|prompt|mcp|schema)")
+	cmd.Flags().StringVar(&opts.Type, "type", "", "Filter by asset type (template|prompt|mcp|schema|partial)")
 	cmd.Flags().StringVar(&opts.Category, "category", "", "Filter by category")
 	cmd.Flags().StringSliceVar(&opts.Tags, "tags", nil, "Filter by tags (comma-separated)")
 	cmd.Flags().IntVar(&opts.Limit, "limit", 50, "Maximum number of results")
 	cmd.Flags().IntVar(&opts.Offset, "offset", 0, "Number of results to skip")
+	cmd.Flags().StringVar(&opts.PageToken, "page-token", "", "Continue from the page token of an earlier listing")
+	cmd.MarkFlagsMutuallyExclusive("offset", "page-token")
+
+	cmdutil.AddFormatFlags(cmd)
+	cmdutil.AddTableFlags(cmd, assetTable)
 
 	return cmd
 }
@@ -101,7 +155,7 @@ func listRun(opts *ListOptions) error {
 
 	// Validate asset type if provided
 	if opts.Type != "" {
-		validTypes := []string{"template", "prompt", "mcp", "schema"}
+		validTypes := []string{"template", "prompt", "mcp", "schema", "partial"}
 		isValid := false
 		for _, validType := range validTypes {
 			if opts.Type == validType {
@@ -114,6 +168,11 @@ func listRun(opts *ListOptions) error {
 		}
 	}
 
+	columns, err := assetTable.Select(opts.Columns)
+	if err != nil {
+		return err
+	}
+
 	ctx := context.Background()
 
 	// Get asset client
@@ -125,11 +184,12 @@ func listRun(opts *ListOptions) error {
 
 	// Build filter from options
 	filter := assets.AssetFilter{
-		Type:     assets.AssetType(opts.Type),
-		Category: opts.Category,
-		Tags:     opts.Tags,
-		Limit:    opts.Limit,
-		Offset:   opts.Offset,
+		Type:      assets.AssetType(opts.Type),
+		Category:  opts.Category,
+		Tags:      opts.Tags,
+		Limit:     opts.Limit,
+		Offset:    opts.Offset,
+		PageToken: opts.PageToken,
 	}
 
 	// List assets
@@ -138,30 +198,28 @@ func listRun(opts *ListOptions) error {
 		return errors.Wrap(err, "failed to list assets")
 	}
 
-	// Display results based on output format
-	switch opts.OutputFormat {
-	case "json":
-		return displayListJSON(opts, assetList)
-	case "yaml":
-		return displayListYAML(opts, assetList)
-	default:
-		return displayListText(opts, assetList, filter)
+	// Sort assets alphabetically by name, then by --sort
+	sort.SliceStable(assetList.Assets, func(i, j int) bool {
+		return assetList.Assets[i].Name < assetList.Assets[j].Name
+	})
+	if err := assetTable.Sort(assetList.Assets, opts.Sort); err != nil {
+		return err
 	}
-}
 
-func displayListJSON(opts *ListOptions, assetList *assets.AssetList) error {
-	encoder := json.NewEncoder(opts.IO.Out)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(assetList)
-}
+	if err := opts.IO.StartPager(); err != nil {
+		fmt.Fprintf(opts.IO.ErrOut, "%s %v\n", opts.IO.WarningIcon(), err)
+	}
+	defer opts.IO.StopPager()
 
-func displayListYAML(opts *ListOptions, assetList *assets.AssetList) error {
-	encoder := yaml.NewEncoder(opts.IO.Out)
-	defer encoder.Close()
-	return encoder.Encode(assetList)
+	// Display results based on output format
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(assetList, func(w io.Writer) error {
+		return displayListText(opts, assetList, filter, columns)
+	})
 }
 
-func displayListText(opts *ListOptions, assetList *assets.AssetList, filter assets.AssetFilter) error {
+func displayListText(opts *ListOptions, assetList *assets.AssetList, filter assets.AssetFilter, columns []cmdutil.Column[assets.AssetMetadata]) error {
 	cs := internal.NewColorScheme(opts.IO)
 
 	if len(assetList.Assets) == 0 {
@@ -182,37 +240,23 @@ func displayListText(opts *ListOptions, assetList *assets.AssetList, filter asse
 		return nil
 	}
 
-	// Sort assets alphabetically by name
-	sort.Slice(assetList.Assets, func(i, j int) bool {
-		return assetList.Assets[i].Name < assetList.Assets[j].Name
-	})
-
 	// Create table writer
 	w := tabwriter.NewWriter(opts.IO.Out, 0, 0, 2, ' ', 0)
 	defer w.Flush()
 
-	// Header - new format: name | command | description | output format
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-		cs.Bold("NAME"),
-		cs.Bold("COMMAND"),
-		cs.Bold("DESCRIPTION"),
-		cs.Bold("OUTPUT FORMAT"))
-
-	// Activities
-	for _, asset := range assetList.Assets {
-		description := asset.Description
-		if len(description) > 60 {
-			description = description[:57] + "..."
-		}
-
-		// Format command with backticks for CLI commands
-		command := fmt.Sprintf("`%s`", asset.Command)
+	headers, rows := assetTable.Rows(columns, assetList.Assets)
+	for i, header := range headers {
+		headers[i] = cs.Bold(header)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			asset.Name,
-			cs.Blue(command),
-			description,
-			asset.Format)
+	for _, row := range rows {
+		for i, column := range columns {
+			if column.Name == "command" {
+				row[i] = cs.Blue(row[i])
+			}
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
 	}
 
 	if err := w.Flush(); err != nil {
@@ -227,12 +271,9 @@ func displayListText(opts *ListOptions, assetList *assets.AssetList, filter asse
 			cs.Bold(fmt.Sprintf("%d", len(assetList.Assets))),
 			cs.Bold(fmt.Sprintf("%d", assetList.Total)))
 
-		if opts.IO.IsStdoutTTY() {
-			nextOffset := filter.Offset + filter.Limit
-			if nextOffset < assetList.Total {
-				fmt.Fprintf(opts.IO.Out, "\n%s Use --offset %d to see more results",
-					cs.Gray("Tip:"), nextOffset)
-			}
+		if opts.IO.IsStdoutTTY() && assetList.NextPageToken != "" {
+			fmt.Fprintf(opts.IO.Out, "\n%s Use --page-token %s to see more results",
+				cs.Gray("Tip:"), assetList.NextPageToken)
 		}
 	} else {
 		fmt.Fprintf(opts.IO.Out, "Total: %s assets",
@@ -121,6 +121,40 @@ func TestListTextOutput(t *testing.T) {
 	assert.Contains(t, output, "Total: 2 assets")
 }
 
+func TestListColumnsAndSort(t *testing.T) {
+	io := iostreams.Test()
+	stdout := io.Out
+	f := cmdutil.NewTestFactory(io)
+
+	f.AssetClient = func() (assets.AssetClientInterface, error) {
+		return &mockListAssetClient{
+			assets: []assets.AssetMetadata{
+				{Name: "Roadmap", Category: "planning", UpdatedAt: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+				{Name: "API Design", Category: "design", UpdatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		}, nil
+	}
+
+	cmd := NewCmdAssetsList(f)
+	cmd.SetArgs([]string{"--columns", "name,updated", "--sort", "-updated"})
+	cmd.SetOut(stdout)
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(stdout.(*bytes.Buffer).String(), "\n")
+	assert.Equal(t, "NAME        UPDATED", lines[0])
+	assert.Equal(t, "API Design  2026-02-01", lines[1])
+	assert.Equal(t, "Roadmap     2026-01-05", lines[2])
+	assert.NotContains(t, lines[0], "DESCRIPTION")
+
+	cmd = NewCmdAssetsList(f)
+	cmd.SetArgs([]string{"--columns", "owner"})
+	cmd.SetOut(stdout)
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, err, &flagErr)
+}
+
 func TestListJSONOutput(t *testing.T) {
 	io := iostreams.Test()
 	stdout := io.Out
@@ -244,6 +278,31 @@ func TestListWithFilters(t *testing.T) {
 	assert.Equal(t, 10, mockClient.lastFilter.Offset)
 }
 
+func TestListPageToken(t *testing.T) {
+	io := iostreams.Test()
+	f := cmdutil.NewTestFactory(io)
+
+	mockClient := &mockListAssetClient{
+		captureFilter: true,
+		assets:        []assets.AssetMetadata{},
+	}
+	f.AssetClient = func() (assets.AssetClientInterface, error) {
+		return mockClient, nil
+	}
+
+	cmd := NewCmdAssetsList(f)
+	cmd.SetArgs([]string{"--page-token", "MTA6YWJj"})
+	cmd.SetOut(io.Out)
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "MTA6YWJj", mockClient.lastFilter.PageToken)
+
+	cmd = NewCmdAssetsList(f)
+	cmd.SetArgs([]string{"--page-token", "MTA6YWJj", "--offset", "10"})
+	cmd.SetOut(io.Out)
+	cmd.SetErr(io.ErrOut)
+	assert.Error(t, cmd.Execute())
+}
+
 func TestListEmptyResults(t *testing.T) {
 	io := iostreams.Test()
 	stdout := io.Out
@@ -370,7 +429,7 @@ func TestListPagination(t *testing.T) {
 
 	output := stdout.(*bytes.Buffer).String()
 	assert.Contains(t, output, "showing 50 of 75")
-	// Note: --offset message only shows in TTY mode, which is false in tests
+	// Note: --page-token message only shows in TTY mode, which is false in tests
 }
 
 // Mock asset client for list testing
@@ -0,0 +1,248 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/daddia/zen/pkg/cmd/assets/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// StatsOptions contains options for the stats command
+type StatsOptions struct {
+	IO               *iostreams.IOStreams
+	AssetClient      func() (assets.AssetClientInterface, error)
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	Now              func() time.Time
+	OutputFormat     string
+	Template         string
+	JQ               string
+	Days             int
+	Limit            int
+	ReportPath       string
+	Timeout          time.Duration
+}
+
+// NewCmdAssetsStats creates the assets stats command
+func NewCmdAssetsStats(f *cmdutil.Factory) *cobra.Command {
+	opts := &StatsOptions{
+		IO:               f.IOStreams,
+		AssetClient:      f.AssetClient,
+		WorkspaceManager: f.WorkspaceManager,
+		Now:              time.Now,
+		Days:             30,
+		Limit:            10,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show how often assets are used",
+		Long: `Show which assets of the library the workspace uses most and least.
+
+Uses of assets are recorded in the workspace (.zen/library/usage.json) when
+'zen draft' generates a document from a template, and when 'zen task create'
+adds the checklist of the task type to a task. Set assets.track_usage to false
+to stop recording them.
+
+Assets in the manifest that were never used count as least used, and assets not
+used in the last --days days are listed as stale. Use 'zen assets sync' first so
+that the manifest lists the assets of the repository.
+
+Use --report to write an anonymized report for the maintainers of the asset
+repository. It holds only the names of assets in the manifest with their use
+counts, the commands that used them, and the month of their last use.`,
+		Example: `  # Show the most and least used assets
+  zen assets stats
+
+  # List assets not used in the last 90 days as stale
+  zen assets stats --days 90
+
+  # Show the top 5 of each list
+  zen assets stats --limit 5
+
+  # Write an anonymized usage report to share with the asset maintainers
+  zen assets stats --report asset-usage.json
+
+  # Output as JSON
+  zen assets stats --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+			if err := cmdutil.ApplyTimeoutFlag(cmd); err != nil {
+				return err
+			}
+			opts.Timeout = httpx.TimeoutFor("assets", httpx.CommandTimeout(cmd.Context()))
+			return statsRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Days, "days", 30, "List assets not used in this many days as stale")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 10, "Maximum number of most and least used assets to show")
+	cmd.Flags().StringVar(&opts.ReportPath, "report", "", "Write an anonymized usage report to a file")
+	cmdutil.AddTimeoutFlag(cmd)
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func statsRun(ctx context.Context, opts *StatsOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if opts.Days < 0 {
+		return &types.Error{Code: types.ErrorCodeInvalidInput, Message: "--days cannot be negative"}
+	}
+	if opts.Limit <= 0 {
+		return &types.Error{Code: types.ErrorCodeInvalidInput, Message: "--limit must be greater than 0"}
+	}
+
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace manager")
+	}
+	status, err := wm.Status()
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace status")
+	}
+
+	usage, err := assets.LoadUsage(assets.UsagePath(status.Root))
+	if err != nil {
+		return errors.Wrap(err, "failed to read asset usage")
+	}
+
+	client, err := opts.AssetClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to get asset client")
+	}
+	defer client.Close()
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	manifest, err := listManifest(ctx, client)
+	if err != nil {
+		return errors.Wrap(err, "failed to list assets")
+	}
+
+	now := opts.Now()
+	if opts.ReportPath != "" {
+		return writeReport(opts, usage.Report(manifest, now))
+	}
+
+	stats := usage.Stats(manifest, opts.Days, opts.Limit, now)
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(stats, func(w io.Writer) error {
+		return displayStatsText(opts, stats, now)
+	})
+}
+
+// listManifest returns every asset in the manifest, page by page
+func listManifest(ctx context.Context, client assets.AssetClientInterface) ([]assets.AssetMetadata, error) {
+	var manifest []assets.AssetMetadata
+	filter := assets.AssetFilter{Limit: 500}
+	for {
+		page, err := client.ListAssets(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		manifest = append(manifest, page.Assets...)
+		if !page.HasMore || page.NextPageToken == "" {
+			return manifest, nil
+		}
+		filter.PageToken = page.NextPageToken
+	}
+}
+
+func writeReport(opts *StatsOptions, report *assets.UsageReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode usage report")
+	}
+	if dir := filepath.Dir(opts.ReportPath); dir != "." {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return errors.Wrap(err, "failed to create report directory")
+		}
+	}
+	if err := os.WriteFile(opts.ReportPath, append(data, '\n'), 0644); err != nil { // #nosec G306 - the report is meant to be shared
+		return errors.Wrap(err, "failed to write usage report")
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s Wrote the usage of %d assets to %s\n",
+		opts.IO.SuccessIcon(), len(report.Assets), opts.ReportPath)
+	return nil
+}
+
+func displayStatsText(opts *StatsOptions, stats *assets.UsageStats, now time.Time) error {
+	cs := internal.NewColorScheme(opts.IO)
+
+	if len(stats.MostUsed) == 0 && len(stats.LeastUsed) == 0 {
+		fmt.Fprintf(opts.IO.Out, "%s No asset usage recorded.\n", cs.Gray("Info:"))
+		if opts.IO.IsStdoutTTY() {
+			fmt.Fprintf(opts.IO.Out, "\n%s Uses are recorded when 'zen draft' and 'zen task create' use assets.\n", cs.Gray("Tip:"))
+		}
+		return nil
+	}
+
+	sections := []struct {
+		title  string
+		assets []assets.AssetUsage
+	}{
+		{"Most used", stats.MostUsed},
+		{"Least used", stats.LeastUsed},
+		{fmt.Sprintf("Stale (not used in %d days)", stats.StaleDays), stats.Stale},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(opts.IO.Out, "%s\n", cs.Bold(section.title))
+		if len(section.assets) == 0 {
+			fmt.Fprintf(opts.IO.Out, "  %s\n\n", cs.Gray("none"))
+			continue
+		}
+
+		w := tabwriter.NewWriter(opts.IO.Out, 0, 0, 2, ' ', 0)
+		for _, asset := range section.assets {
+			fmt.Fprintf(w, "  %s\t%d\t%s\n", asset.Name, asset.Count, formatLastUsed(cs, asset.LastUsed, now))
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("failed to flush output: %w", err)
+		}
+		fmt.Fprintln(opts.IO.Out)
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Total: %s uses\n", cs.Bold(fmt.Sprintf("%d", stats.TotalUses)))
+	return nil
+}
+
+// formatLastUsed describes when an asset was last used, relative to now
+func formatLastUsed(cs *internal.ColorScheme, lastUsed, now time.Time) string {
+	if lastUsed.IsZero() {
+		return cs.Gray("never used")
+	}
+	days := int(now.Sub(lastUsed).Hours() / 24)
+	switch {
+	case days < 1:
+		return "used today"
+	case days == 1:
+		return "used yesterday"
+	default:
+		return fmt.Sprintf("used %d days ago", days)
+	}
+}
@@ -0,0 +1,183 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+
+func TestNewCmdAssetsStats(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	cmd := NewCmdAssetsStats(f)
+
+	assert.Equal(t, "stats", cmd.Use)
+	assert.Equal(t, "Show how often assets are used", cmd.Short)
+	assert.Contains(t, cmd.Example, "zen assets stats --report")
+
+	for name, def := range map[string]string{"days": "30", "limit": "10", "report": "", "timeout": "0s"} {
+		flag := cmd.Flags().Lookup(name)
+		require.NotNil(t, flag, name)
+		assert.Equal(t, def, flag.DefValue, name)
+	}
+}
+
+func TestStatsText(t *testing.T) {
+	opts := setupStats(t)
+	opts.Limit = 2
+	require.NoError(t, statsRun(context.Background(), opts))
+	io := opts.IO
+
+	output := io.Out.(*bytes.Buffer).String()
+	assert.Contains(t, output, "Most used\n  feature-spec  4  used yesterday\n  user-story    1  used 40 days ago\n")
+	assert.Contains(t, output, "Least used\n  roadmap     0  never used\n  user-story  1  used 40 days ago\n")
+	assert.Contains(t, output, "Stale (not used in 30 days)\n  roadmap     0  never used\n  user-story  1  used 40 days ago\n")
+	assert.Contains(t, output, "Total: 5 uses")
+}
+
+func TestStatsJSON(t *testing.T) {
+	opts := setupStats(t)
+	opts.Days = 60
+	opts.OutputFormat = "json"
+	require.NoError(t, statsRun(context.Background(), opts))
+	io := opts.IO
+
+	var stats assets.UsageStats
+	require.NoError(t, json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &stats))
+	assert.Equal(t, 5, stats.TotalUses)
+	assert.Equal(t, 60, stats.StaleDays)
+	require.Len(t, stats.Stale, 1)
+	assert.Equal(t, "roadmap", stats.Stale[0].Name)
+}
+
+func TestStatsReport(t *testing.T) {
+	opts := setupStats(t)
+	reportPath := filepath.Join(t.TempDir(), "reports", "usage.json")
+	opts.ReportPath = reportPath
+	require.NoError(t, statsRun(context.Background(), opts))
+	io := opts.IO
+	assert.Contains(t, io.Out.(*bytes.Buffer).String(), "Wrote the usage of 3 assets to "+reportPath)
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report assets.UsageReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Len(t, report.Assets, 3)
+	assert.Equal(t, assets.ReportedAsset{Name: "feature-spec", Count: 4, Sources: map[string]int{"draft": 4}, LastUsed: "2026-06"}, report.Assets[0])
+	assert.NotContains(t, string(data), "private-notes")
+}
+
+func TestStatsCancelled(t *testing.T) {
+	opts := setupStats(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := statsRun(ctx, opts)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, opts.IO.Out.(*bytes.Buffer).String())
+}
+
+func TestStatsInvalidFlags(t *testing.T) {
+	io := iostreams.Test()
+	f := statsFactory(io, t.TempDir())
+	for _, args := range [][]string{{"--days", "-1"}, {"--limit", "0"}} {
+		cmd := NewCmdAssetsStats(f)
+		cmd.SetArgs(args)
+		cmd.SetOut(io.Out)
+		cmd.SetErr(io.ErrOut)
+		assert.Error(t, cmd.Execute(), args)
+	}
+}
+
+// setupStats records usage in a temporary workspace, and returns the options of the stats
+// command run in it
+func setupStats(t *testing.T) *StatsOptions {
+	t.Helper()
+	root := t.TempDir()
+
+	usage := &assets.Usage{Assets: make(map[string]*assets.AssetUsage)}
+	for i := 0; i < 4; i++ {
+		usage.Record("feature-spec", "draft", testNow.AddDate(0, 0, -1))
+	}
+	usage.Record("user-story", "task create", testNow.AddDate(0, 0, -40))
+	usage.Record("private-notes", "draft", testNow)
+	require.NoError(t, usage.Save(assets.UsagePath(root)))
+
+	f := statsFactory(iostreams.Test(), root)
+	return &StatsOptions{
+		IO:               f.IOStreams,
+		AssetClient:      f.AssetClient,
+		WorkspaceManager: f.WorkspaceManager,
+		Now:              func() time.Time { return testNow },
+		Days:             30,
+		Limit:            10,
+	}
+}
+
+func statsFactory(io *iostreams.IOStreams, root string) *cmdutil.Factory {
+	f := cmdutil.NewTestFactory(io)
+	f.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) {
+		return zentest.WorkspaceAt(root), nil
+	}
+	f.AssetClient = func() (assets.AssetClientInterface, error) {
+		return &mockStatsAssetClient{manifest: []assets.AssetMetadata{
+			{Name: "feature-spec", Type: assets.AssetTypeTemplate},
+			{Name: "user-story", Type: assets.AssetTypeTemplate},
+			{Name: "roadmap", Type: assets.AssetTypeTemplate},
+		}}, nil
+	}
+	return f
+}
+
+// mockStatsAssetClient lists a manifest a page of one asset at a time
+type mockStatsAssetClient struct {
+	manifest []assets.AssetMetadata
+}
+
+func (m *mockStatsAssetClient) ListAssets(ctx context.Context, filter assets.AssetFilter) (*assets.AssetList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	offset := 0
+	if filter.PageToken != "" {
+		offset = int(filter.PageToken[0] - '0')
+	}
+	list := &assets.AssetList{Assets: m.manifest[offset : offset+1], Total: len(m.manifest)}
+	if offset+1 < len(m.manifest) {
+		list.HasMore = true
+		list.NextPageToken = string(rune('0' + offset + 1))
+	}
+	return list, nil
+}
+
+func (m *mockStatsAssetClient) GetAsset(ctx context.Context, name string, opts assets.GetAssetOptions) (*assets.AssetContent, error) {
+	return nil, &assets.AssetClientError{Code: assets.ErrorCodeAssetNotFound, Message: "asset not found"}
+}
+
+func (m *mockStatsAssetClient) SyncRepository(ctx context.Context, req assets.SyncRequest) (*assets.SyncResult, error) {
+	return &assets.SyncResult{Status: "success"}, nil
+}
+
+func (m *mockStatsAssetClient) GetCacheInfo(ctx context.Context) (*assets.CacheInfo, error) {
+	return &assets.CacheInfo{}, nil
+}
+
+func (m *mockStatsAssetClient) ClearCache(ctx context.Context) error {
+	return nil
+}
+
+func (m *mockStatsAssetClient) Close() error {
+	return nil
+}
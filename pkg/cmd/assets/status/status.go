@@ -2,8 +2,8 @@ package status
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/daddia/zen/pkg/assets"
@@ -12,7 +12,6 @@ import (
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 // StatusOptions contains options for the status command
@@ -21,6 +20,8 @@ type StatusOptions struct {
 	AssetClient  func() (assets.AssetClientInterface, error)
 	AuthManager  func() (interface{}, error) // Using interface{} to avoid import cycle, will cast to auth.Manager
 	OutputFormat string
+	Template     string
+	JQ           string
 }
 
 // StatusInfo represents the status information to display
@@ -28,6 +29,7 @@ type StatusInfo struct {
 	Authentication AuthenticationInfo `json:"authentication" yaml:"authentication"`
 	Cache          CacheInfo          `json:"cache" yaml:"cache"`
 	Repository     RepositoryInfo     `json:"repository" yaml:"repository"`
+	LFS            *assets.LFSStatus  `json:"lfs,omitempty" yaml:"lfs,omitempty"`
 }
 
 type AuthenticationInfo struct {
@@ -72,6 +74,7 @@ This command shows:
 - Authentication status for configured Git providers
 - Local cache status including size and hit ratio
 - Repository synchronization status
+- Git LFS files downloaded and the LFS download budget used
 - Asset availability (online/offline mode)
 
 The status information helps troubleshoot authentication issues,
@@ -85,12 +88,14 @@ monitor cache performance, and understand asset availability.`,
   # Show status in YAML format
   zen assets status --output yaml`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Get output format from persistent flag
-			opts.OutputFormat, _ = cmd.Flags().GetString("output")
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
 			return statusRun(opts)
 		},
 	}
 
+	cmdutil.AddFormatFlags(cmd)
+
 	return cmd
 }
 
@@ -111,16 +116,11 @@ func statusRun(opts *StatusOptions) error {
 	}
 
 	// Display status based on output format
-	switch opts.OutputFormat {
-	case "json":
-		return displayStatusJSON(opts, status)
-	case "yaml":
-		return displayStatusYAML(opts, status)
-	case "text", "":
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(status, func(w io.Writer) error {
 		return displayStatusText(opts, status)
-	default:
-		return fmt.Errorf("invalid output format '%s': supported formats are text, json, yaml", opts.OutputFormat)
-	}
+	})
 }
 
 func gatherStatusInfo(ctx context.Context, client assets.AssetClientInterface, authManagerFunc func() (interface{}, error)) (*StatusInfo, error) {
@@ -155,6 +155,14 @@ func gatherStatusInfo(ctx context.Context, client assets.AssetClientInterface, a
 		Available: status.Authentication.Authenticated,
 	}
 
+	// Git LFS status, when the client keeps a local clone
+	if provider, ok := client.(assets.LFSStatusProvider); ok {
+		lfs, err := provider.LFSStatus(ctx)
+		if err == nil && lfs != nil {
+			status.LFS = lfs
+		}
+	}
+
 	return status, nil
 }
 
@@ -188,18 +196,6 @@ func getAuthenticationInfo(ctx context.Context, authManagerFunc func() (interfac
 	return authInfo
 }
 
-func displayStatusJSON(opts *StatusOptions, status *StatusInfo) error {
-	encoder := json.NewEncoder(opts.IO.Out)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(status)
-}
-
-func displayStatusYAML(opts *StatusOptions, status *StatusInfo) error {
-	encoder := yaml.NewEncoder(opts.IO.Out)
-	defer encoder.Close()
-	return encoder.Encode(status)
-}
-
 func displayStatusText(opts *StatusOptions, status *StatusInfo) error {
 	cs := internal.NewColorScheme(opts.IO)
 
@@ -209,7 +205,7 @@ func displayStatusText(opts *StatusOptions, status *StatusInfo) error {
 	// Authentication section
 	fmt.Fprintf(opts.IO.Out, "%s Authentication\n", cs.Bold("Auth"))
 	if status.Authentication.Authenticated {
-		fmt.Fprintf(opts.IO.Out, "  Status: %s Authenticated\n", cs.Green("✓"))
+		fmt.Fprintf(opts.IO.Out, "  Status: %s Authenticated\n", cs.SuccessIcon())
 		if status.Authentication.Provider != "" {
 			fmt.Fprintf(opts.IO.Out, "  Provider: %s\n", status.Authentication.Provider)
 		}
@@ -220,7 +216,7 @@ func displayStatusText(opts *StatusOptions, status *StatusInfo) error {
 			fmt.Fprintf(opts.IO.Out, "  Expires: %s\n", formatTime(status.Authentication.ExpiresAt))
 		}
 	} else {
-		fmt.Fprintf(opts.IO.Out, "  Status: %s Not authenticated\n", cs.Red("✗"))
+		fmt.Fprintf(opts.IO.Out, "  Status: %s Not authenticated\n", cs.ErrorIcon())
 		fmt.Fprintf(opts.IO.Out, "  %s Run 'zen assets auth <provider>' to authenticate\n", cs.Gray("→"))
 	}
 	fmt.Fprintln(opts.IO.Out)
@@ -229,7 +225,7 @@ func displayStatusText(opts *StatusOptions, status *StatusInfo) error {
 	fmt.Fprintf(opts.IO.Out, "%s Cache\n", cs.Bold("Cache"))
 	switch status.Cache.Status {
 	case "healthy":
-		fmt.Fprintf(opts.IO.Out, "  Status: %s Healthy\n", cs.Green("✓"))
+		fmt.Fprintf(opts.IO.Out, "  Status: %s Healthy\n", cs.SuccessIcon())
 		fmt.Fprintf(opts.IO.Out, "  Size: %.1f MB\n", status.Cache.SizeMB)
 		if status.Cache.AssetCount > 0 {
 			fmt.Fprintf(opts.IO.Out, "  Assets: %d cached\n", status.Cache.AssetCount)
@@ -241,7 +237,7 @@ func displayStatusText(opts *StatusOptions, status *StatusInfo) error {
 			fmt.Fprintf(opts.IO.Out, "  Last sync: %s\n", formatTime(status.Cache.LastSync))
 		}
 	case "unavailable":
-		fmt.Fprintf(opts.IO.Out, "  Status: %s Unavailable\n", cs.Red("✗"))
+		fmt.Fprintf(opts.IO.Out, "  Status: %s Unavailable\n", cs.ErrorIcon())
 		fmt.Fprintf(opts.IO.Out, "  %s Cache may need to be initialized\n", cs.Gray("→"))
 	default:
 		fmt.Fprintf(opts.IO.Out, "  Status: %s Unknown\n", cs.Yellow("?"))
@@ -251,7 +247,7 @@ func displayStatusText(opts *StatusOptions, status *StatusInfo) error {
 	// Repository section
 	fmt.Fprintf(opts.IO.Out, "%s Repository\n", cs.Bold("Repository"))
 	if status.Repository.Available {
-		fmt.Fprintf(opts.IO.Out, "  Status: %s Connected\n", cs.Green("✓"))
+		fmt.Fprintf(opts.IO.Out, "  Status: %s Connected\n", cs.SuccessIcon())
 		if status.Repository.URL != "" {
 			fmt.Fprintf(opts.IO.Out, "  URL: %s\n", status.Repository.URL)
 		}
@@ -262,13 +258,17 @@ func displayStatusText(opts *StatusOptions, status *StatusInfo) error {
 			fmt.Fprintf(opts.IO.Out, "  Last sync: %s\n", formatTime(status.Repository.LastSync))
 		}
 	} else {
-		fmt.Fprintf(opts.IO.Out, "  Status: %s Offline\n", cs.Yellow("⚠"))
+		fmt.Fprintf(opts.IO.Out, "  Status: %s Offline\n", cs.WarningIcon())
 		fmt.Fprintf(opts.IO.Out, "  %s Using cached assets only\n", cs.Gray("→"))
 		if !status.Authentication.Authenticated {
 			fmt.Fprintf(opts.IO.Out, "  %s Authentication required for repository access\n", cs.Gray("→"))
 		}
 	}
 
+	if status.LFS != nil {
+		displayLFSText(opts, cs, status.LFS)
+	}
+
 	// Footer with helpful commands
 	if opts.IO.IsStdoutTTY() {
 		fmt.Fprintln(opts.IO.Out)
@@ -285,6 +285,23 @@ func displayStatusText(opts *StatusOptions, status *StatusInfo) error {
 	return nil
 }
 
+func displayLFSText(opts *StatusOptions, cs *internal.ColorScheme, lfs *assets.LFSStatus) {
+	fmt.Fprintln(opts.IO.Out)
+	fmt.Fprintf(opts.IO.Out, "%s Git LFS\n", cs.Bold("LFS"))
+	if lfs.Available {
+		fmt.Fprintf(opts.IO.Out, "  Status: %s Available\n", cs.SuccessIcon())
+		fmt.Fprintf(opts.IO.Out, "  Files: %d of %d downloaded\n", lfs.Downloaded, lfs.Tracked)
+	} else {
+		fmt.Fprintf(opts.IO.Out, "  Status: %s Not available\n", cs.WarningIcon())
+		fmt.Fprintf(opts.IO.Out, "  %s Assets stored in Git LFS cannot be fetched\n", cs.Gray("→"))
+	}
+	if lfs.BudgetMB > 0 {
+		fmt.Fprintf(opts.IO.Out, "  Budget: %.1f of %d MB used\n", lfs.UsedMB, lfs.BudgetMB)
+	} else {
+		fmt.Fprintf(opts.IO.Out, "  Budget: %s LFS downloads disabled\n", cs.Gray("→"))
+	}
+}
+
 func formatTime(t time.Time) string {
 	if t.IsZero() {
 		return "never"
@@ -181,6 +181,34 @@ func TestStatusWithCacheError(t *testing.T) {
 	assert.Contains(t, output, "Unavailable")
 }
 
+func TestStatusLFSOutput(t *testing.T) {
+	io := iostreams.Test()
+	stdout := io.Out
+	f := cmdutil.NewTestFactory(io)
+
+	f.AssetClient = func() (assets.AssetClientInterface, error) {
+		return &mockLFSStatusAssetClient{
+			lfs: &assets.LFSStatus{Available: true, Tracked: 4, Downloaded: 1, BudgetMB: 100, UsedMB: 2.5},
+		}, nil
+	}
+
+	cmd := NewCmdAssetsStatus(f)
+	cmd.SetArgs([]string{})
+	cmd.SetOut(stdout)
+
+	require.NoError(t, cmd.Execute())
+
+	output := stdout.(*bytes.Buffer).String()
+	assert.Contains(t, output, "Git LFS")
+	assert.Contains(t, output, "1 of 4 downloaded")
+	assert.Contains(t, output, "2.5 of 100 MB used")
+
+	// Clients without LFS support leave the section out
+	status, err := gatherStatusInfo(context.Background(), &mockStatusAssetClient{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, status.LFS)
+}
+
 func TestFormatTime(t *testing.T) {
 	now := time.Now()
 
@@ -284,3 +312,12 @@ func (m *mockStatusAssetClient) ClearCache(ctx context.Context) error {
 func (m *mockStatusAssetClient) Close() error {
 	return nil
 }
+
+type mockLFSStatusAssetClient struct {
+	mockStatusAssetClient
+	lfs *assets.LFSStatus
+}
+
+func (m *mockLFSStatusAssetClient) LFSStatus(ctx context.Context) (*assets.LFSStatus, error) {
+	return m.lfs, nil
+}
@@ -2,36 +2,45 @@ package sync
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
+	"path/filepath"
 	"time"
 
 	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/cmd/assets/internal"
 	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/hooks"
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 // SyncOptions contains options for the sync command
 type SyncOptions struct {
-	IO           *iostreams.IOStreams
-	AssetClient  func() (assets.AssetClientInterface, error)
-	OutputFormat string
-	Force        bool
-	Branch       string
-	Timeout      int
+	IO               *iostreams.IOStreams
+	AssetClient      func() (assets.AssetClientInterface, error)
+	HookRunner       func() (*hooks.Runner, error)
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	OutputFormat     string
+	Force            bool
+	Branch           string
+	Timeout          time.Duration
+	NoWait           bool
+	LockTimeout      time.Duration
+	DryRun           bool
+	FailOn           cmdutil.FailOn
 }
 
 // NewCmdAssetsSync creates the assets sync command
 func NewCmdAssetsSync(f *cmdutil.Factory) *cobra.Command {
 	opts := &SyncOptions{
-		IO:          f.IOStreams,
-		AssetClient: f.AssetClient,
-		Branch:      "main",
-		Timeout:     60, // 1 minute default for metadata-only sync
+		IO:               f.IOStreams,
+		AssetClient:      f.AssetClient,
+		HookRunner:       f.HookRunner,
+		WorkspaceManager: f.WorkspaceManager,
+		Branch:           "main",
 	}
 
 	cmd := &cobra.Command{
@@ -48,11 +57,38 @@ What gets synchronized:
 - Asset descriptions, categories, tags, and checksums
 - Asset availability and version information
 
-Actual asset content is downloaded only when you use 'zen assets get <name>'.
+Actual asset content is downloaded only when you use
+'zen assets info <name> --include-content'.
 This keeps sync operations fast and minimizes network/disk usage.
 
+Sync compares asset checksums with the last sync and reports the assets that
+were added, updated, or removed. Only the cached content of updated and removed
+assets is discarded.
+
 The sync operation requires authentication with the Git provider.
-Use 'zen assets auth' to configure authentication first.`,
+Use 'zen assets auth' to configure authentication first.
+
+Only one sync updates the cached repository at a time. A sync started while
+another is running waits for it to finish, up to --lock-timeout, or fails at
+once with --no-wait.
+
+Network failures while cloning or pulling are retried with backoff, as set by
+assets.sync.max_retries and assets.sync.retry_delay. Progress is saved in the
+cache, so a sync that is interrupted resumes from the existing clone on the
+next run instead of cloning again.
+
+The sync times out after --timeout, or network.provider_timeouts.assets, or
+network.timeout, and one minute when none is set. Ctrl-C cancels the sync in flight and exits
+with 2; running the command again within a day resumes it.
+
+With --dry-run, the remote manifest is compared with the last sync and the
+assets that would be added, updated, or removed are reported. The local
+manifest, cache, and clone are not changed; a local clone only fetches the
+branch.
+
+The command exits with 1 when the sync fails. A sync that completes with
+warnings, such as one that falls back to the local clone, exits with 0 unless
+--fail-on partial or warning is set, in which case it exits with 6.`,
 		Example: `  # Synchronize asset metadata (manifest only)
   zen assets sync
 
@@ -63,38 +99,54 @@ Use 'zen assets auth' to configure authentication first.`,
   zen assets sync --branch develop
 
   # Sync with custom timeout
-  zen assets sync --timeout 60
+  zen assets sync --timeout 2m
+
+  # Fail instead of waiting if another sync is running
+  zen assets sync --no-wait
 
   # Output sync results as JSON
   zen assets sync --output json
 
+  # Preview the assets a sync would add, update, or remove
+  zen assets sync --dry-run
+
+  # Fail a CI job if the sync completes with warnings
+  zen assets sync --fail-on partial
+
   # After sync, list available assets
   zen assets list
 
   # Download specific asset content on-demand
-  zen assets get technical-spec`,
+  zen assets info technical-spec --include-content`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Get output format from persistent flag
-			opts.OutputFormat, _ = cmd.Flags().GetString("output")
-			return syncRun(opts)
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.DryRun = f.DryRun
+			failOn, err := cmdutil.FailOnFlag(cmd)
+			if err != nil {
+				return err
+			}
+			opts.FailOn = failOn
+			if err := cmdutil.ApplyTimeoutFlag(cmd); err != nil {
+				return err
+			}
+			opts.Timeout = httpx.TimeoutFor("assets", httpx.CommandTimeout(cmd.Context()))
+			return syncRun(cmd.Context(), opts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Force refresh of cached metadata")
 	cmd.Flags().StringVar(&opts.Branch, "branch", "main", "Branch to synchronize")
-	cmd.Flags().IntVar(&opts.Timeout, "timeout", 60, "Timeout in seconds for sync operation")
+	cmdutil.AddTimeoutFlag(cmd)
+	cmd.Flags().BoolVar(&opts.NoWait, "no-wait", false, "Fail immediately if another operation is using the asset repository")
+	cmd.Flags().DurationVar(&opts.LockTimeout, "lock-timeout", 0, "Maximum time to wait for another operation to finish (default: the sync timeout)")
+	cmdutil.AddFailOnFlag(cmd)
 
 	return cmd
 }
 
-func syncRun(opts *SyncOptions) error {
-	ctx := context.Background()
-
-	// Create timeout context
-	if opts.Timeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, time.Duration(opts.Timeout)*time.Second)
-		defer cancel()
+func syncRun(ctx context.Context, opts *SyncOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
 	// Get asset client
@@ -104,122 +156,177 @@ func syncRun(opts *SyncOptions) error {
 	}
 	defer client.Close()
 
-	// Show sync start message (unless JSON/YAML output)
-	if opts.OutputFormat == "text" || opts.OutputFormat == "" {
-		if opts.IO.IsStdoutTTY() {
-			cs := internal.NewColorScheme(opts.IO)
-			fmt.Fprintf(opts.IO.Out, "%s Synchronizing assets repository...\n", cs.Bold("Syncing"))
-
-			// Show progress indicators
-			go showProgressIndicators(ctx, opts.IO)
-		} else {
-			fmt.Fprintln(opts.IO.Out, "Synchronizing assets repository...")
+	// A dry run changes nothing, so sync hooks do not run
+	if !opts.DryRun {
+		if err := hooks.Trigger(ctx, opts.HookRunner, &hooks.Payload{
+			Event: hooks.EventPreSync,
+			Data:  map[string]interface{}{"target": "assets", "branch": opts.Branch},
+		}); err != nil {
+			return err
 		}
 	}
 
+	// A cancelled sync is reported, and resumed from the clone and the sync checkpoint
+	// in the cache when run again on the same branch
+	op := &cmdutil.LongOperation{Name: "assets sync", IO: opts.IO, Key: opts.Branch}
+	if root, err := workspaceRoot(opts); err == nil && !opts.DryRun {
+		op.CheckpointPath = cmdutil.CheckpointPath(filepath.Join(root, ".zen"), "assets-sync")
+	}
+	if checkpoint := op.Resume(); checkpoint != nil && len(checkpoint.Pending) > 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Resuming the sync cancelled at %s\n",
+			opts.IO.InfoIcon(), checkpoint.Cancelled.Local().Format("2006-01-02 15:04:05"))
+	}
+	item := "branch " + opts.Branch
+	op.Add(item)
+
+	// Show sync progress; the spinner is drawn on stderr and only on a terminal
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	spinner := opts.IO.StartSpinner("Synchronizing assets repository...")
+
 	// Perform synchronization
 	syncRequest := assets.SyncRequest{
-		Force:  opts.Force,
-		Branch: opts.Branch,
+		Force:       opts.Force,
+		Branch:      opts.Branch,
+		NoWait:      opts.NoWait,
+		LockTimeout: opts.LockTimeout,
+		DryRun:      opts.DryRun,
 	}
 
-	result, err := client.SyncRepository(ctx, syncRequest)
+	var result *assets.SyncResult
+	err = op.Run(ctx, func(ctx context.Context) error {
+		// The timeout bounds the sync, and is not a cancellation
+		syncCtx := ctx
+		if opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			syncCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+		}
+
+		var err error
+		result, err = client.SyncRepository(syncCtx, syncRequest)
+		spinner.Done(err)
+		spinner.Stop()
+		if err == nil {
+			op.Complete(item)
+		}
+		return err
+	})
+	if cmdutil.IsUserCancellation(err) {
+		return err
+	}
 	if err != nil {
 		// Check for specific error types
 		if assetErr, ok := err.(*assets.AssetClientError); ok {
 			switch assetErr.Code {
 			case assets.ErrorCodeAuthenticationFailed:
 				return fmt.Errorf("authentication failed. Run 'zen assets auth <provider>' to authenticate")
+			case assets.ErrorCodeInsufficientScopes:
+				return fmt.Errorf("insufficient permissions: %s", assetErr.Message)
 			case assets.ErrorCodeNetworkError:
 				return fmt.Errorf("network error during sync: %v", assetErr.Message)
 			case assets.ErrorCodeRepositoryError:
 				return fmt.Errorf("repository error: %v", assetErr.Message)
+			case assets.ErrorCodeRepositoryLocked:
+				return fmt.Errorf("%s: %v", assetErr.Message, assetErr.Details)
 			}
 		}
 		return errors.Wrap(err, "sync operation failed")
 	}
 
 	// Display results based on output format
-	switch opts.OutputFormat {
-	case "json":
-		return displaySyncJSON(opts, result)
-	case "yaml":
-		return displaySyncYAML(opts, result)
-	default:
+	if err := renderer.Render(result, func(w io.Writer) error {
+		if result.DryRun {
+			return displayDryRunText(opts, result)
+		}
 		return displaySyncText(opts, result)
+	}); err != nil {
+		return err
 	}
-}
-
-func showProgressIndicators(ctx context.Context, io *iostreams.IOStreams) {
-	if !io.IsStdoutTTY() {
-		return
+	if opts.DryRun {
+		return nil
 	}
 
-	cs := internal.NewColorScheme(io)
-	steps := []string{
-		"Authenticating with Git provider",
-		"Fetching repository updates",
-		"Updating local cache",
+	outcome := syncOutcome(result)
+	if result.Status != "success" && result.Error != "" {
+		opts.IO.Annotate(iostreams.AnnotationWarning, "zen assets sync: "+result.Error)
+	}
+	if outcome.Failed > 0 {
+		return fmt.Errorf("assets sync: %w", outcome.Err(opts.FailOn, "repositories"))
 	}
 
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
+	if err := hooks.Trigger(ctx, opts.HookRunner, &hooks.Payload{
+		Event: hooks.EventPostSync,
+		Data:  map[string]interface{}{"target": "assets", "result": result},
+	}); err != nil {
+		return err
+	}
 
-	stepIndex := 0
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			if stepIndex < len(steps) {
-				fmt.Fprintf(io.Out, "%s %s\n", cs.Green("✓"), steps[stepIndex])
-				stepIndex++
-			} else {
-				return
-			}
-		}
+	if err := outcome.Err(opts.FailOn, "repositories"); err != nil {
+		return fmt.Errorf("assets sync: %w", err)
 	}
+	return nil
 }
 
-func displaySyncJSON(opts *SyncOptions, result *assets.SyncResult) error {
-	encoder := json.NewEncoder(opts.IO.Out)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
+// workspaceRoot returns the root of the initialized workspace, where the checkpoint of
+// a cancelled sync is kept
+func workspaceRoot(opts *SyncOptions) (string, error) {
+	if opts.WorkspaceManager == nil {
+		return "", fmt.Errorf("no workspace")
+	}
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return "", err
+	}
+	status, err := wm.Status()
+	if err != nil {
+		return "", err
+	}
+	if !status.Initialized || status.Root == "" {
+		return "", fmt.Errorf("workspace not initialized")
+	}
+	return status.Root, nil
 }
 
-func displaySyncYAML(opts *SyncOptions, result *assets.SyncResult) error {
-	encoder := yaml.NewEncoder(opts.IO.Out)
-	defer encoder.Close()
-	return encoder.Encode(result)
+// syncOutcome counts how a repository sync ended from its status
+func syncOutcome(result *assets.SyncResult) cmdutil.Outcome {
+	switch result.Status {
+	case "success":
+		return cmdutil.Outcome{Succeeded: 1}
+	case "partial":
+		return cmdutil.Outcome{Partial: 1}
+	case "error":
+		return cmdutil.Outcome{Failed: 1}
+	default:
+		return cmdutil.Outcome{Warned: 1}
+	}
 }
 
 func displaySyncText(opts *SyncOptions, result *assets.SyncResult) error {
 	cs := internal.NewColorScheme(opts.IO)
 
-	// Clear progress indicators if we showed them
-	if opts.IO.IsStdoutTTY() {
-		fmt.Fprint(opts.IO.Out, "\033[3A\033[K\033[K\033[K") // Clear last 3 lines
-	}
-
 	// Status-based output
 	switch result.Status {
 	case "success":
-		fmt.Fprintf(opts.IO.Out, "%s Sync completed successfully\n", cs.Green("✓"))
+		fmt.Fprintf(opts.IO.Out, "%s Sync completed successfully\n", cs.SuccessIcon())
 	case "partial":
 		fmt.Fprintf(opts.IO.Out, "%s Sync completed with warnings\n", cs.Yellow("!"))
 		if result.Error != "" {
 			fmt.Fprintf(opts.IO.Out, "%s Warning: %s\n", cs.Yellow("!"), result.Error)
 		}
 	case "error":
-		fmt.Fprintf(opts.IO.Out, "%s Sync failed\n", cs.Red("✗"))
+		fmt.Fprintf(opts.IO.Out, "%s Sync failed\n", cs.ErrorIcon())
 		if result.Error != "" {
-			fmt.Fprintf(opts.IO.Out, "%s Error: %s\n", cs.Red("✗"), result.Error)
+			fmt.Fprintf(opts.IO.Out, "%s Error: %s\n", cs.ErrorIcon(), result.Error)
 		}
-		return fmt.Errorf("sync operation failed")
+		return nil
 	default:
 		fmt.Fprintf(opts.IO.Out, "%s Sync status unknown\n", cs.Yellow("?"))
 	}
 
+	if result.Resumed {
+		fmt.Fprintf(opts.IO.Out, "%s Resumed an interrupted sync\n", cs.Gray("→"))
+	}
+
 	// Show statistics
 	if result.AssetsAdded > 0 || result.AssetsUpdated > 0 || result.AssetsRemoved > 0 {
 		fmt.Fprintln(opts.IO.Out)
@@ -245,6 +352,18 @@ func displaySyncText(opts *SyncOptions, result *assets.SyncResult) error {
 	fmt.Fprintln(opts.IO.Out)
 	fmt.Fprintf(opts.IO.Out, "%s Summary:\n", cs.Bold("Summary"))
 
+	if result.Commit != "" {
+		commit := result.Commit
+		if len(commit) > 8 {
+			commit = commit[:8]
+		}
+		fmt.Fprintf(opts.IO.Out, "  Commit: %s\n", commit)
+	}
+
+	if result.PolicyBundle != "" {
+		fmt.Fprintf(opts.IO.Out, "  Policy: %s\n", result.PolicyBundle)
+	}
+
 	if result.CacheSizeMB > 0 {
 		fmt.Fprintf(opts.IO.Out, "  Cache size: %.1f MB\n", result.CacheSizeMB)
 	}
@@ -270,6 +389,40 @@ func displaySyncText(opts *SyncOptions, result *assets.SyncResult) error {
 	return nil
 }
 
+// displayDryRunText lists the assets a sync would add, update, and remove
+func displayDryRunText(opts *SyncOptions, result *assets.SyncResult) error {
+	cs := internal.NewColorScheme(opts.IO)
+
+	if result.Changes == nil || result.Changes.Empty() {
+		fmt.Fprintf(opts.IO.Out, "%s Dry run: assets are up to date, sync would change nothing\n", cs.Gray("→"))
+		return nil
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s Dry run: sync would make these changes\n", cs.Gray("→"))
+	fmt.Fprintln(opts.IO.Out)
+	for _, name := range result.Changes.Added {
+		fmt.Fprintf(opts.IO.Out, "  %s %s\n", cs.Green("+"), name)
+	}
+	for _, name := range result.Changes.Updated {
+		fmt.Fprintf(opts.IO.Out, "  %s %s\n", cs.Blue("~"), name)
+	}
+	for _, name := range result.Changes.Removed {
+		fmt.Fprintf(opts.IO.Out, "  %s %s\n", cs.Red("-"), name)
+	}
+
+	fmt.Fprintln(opts.IO.Out)
+	fmt.Fprintf(opts.IO.Out, "%d added, %d updated, %d removed\n",
+		result.AssetsAdded, result.AssetsUpdated, result.AssetsRemoved)
+	if result.Commit != "" {
+		commit := result.Commit
+		if len(commit) > 8 {
+			commit = commit[:8]
+		}
+		fmt.Fprintf(opts.IO.Out, "Remote commit: %s\n", commit)
+	}
+	return nil
+}
+
 func formatDuration(d time.Duration) string {
 	if d < time.Second {
 		return fmt.Sprintf("%dms", d.Milliseconds())
@@ -10,6 +10,7 @@ import (
 	"github.com/daddia/zen/pkg/assets"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/zentest"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,8 +49,8 @@ func TestSyncCommandFlags(t *testing.T) {
 
 	timeoutFlag := cmd.Flags().Lookup("timeout")
 	require.NotNil(t, timeoutFlag)
-	assert.Equal(t, "int", timeoutFlag.Value.Type())
-	assert.Equal(t, "60", timeoutFlag.DefValue)
+	assert.Equal(t, "duration", timeoutFlag.Value.Type())
+	assert.Equal(t, "0s", timeoutFlag.DefValue)
 }
 
 func TestSyncSuccessfulTextOutput(t *testing.T) {
@@ -65,6 +66,7 @@ func TestSyncSuccessfulTextOutput(t *testing.T) {
 		AssetsRemoved: 0,
 		CacheSizeMB:   15.2,
 		LastSync:      time.Now(),
+		PolicyBundle:  "acme",
 	}
 
 	f.AssetClient = func() (assets.AssetClientInterface, error) {
@@ -91,6 +93,7 @@ func TestSyncSuccessfulTextOutput(t *testing.T) {
 	assert.NotContains(t, output, "Removed") // Should not show 0 removals
 
 	// Check summary
+	assert.Contains(t, output, "Policy: acme")
 	assert.Contains(t, output, "Cache size: 15.2 MB")
 	assert.Contains(t, output, "Duration: 3.2s")
 }
@@ -155,6 +158,60 @@ func TestSyncPartialTextOutput(t *testing.T) {
 	assert.Contains(t, output, "Some assets could not be updated")
 }
 
+func TestSyncFailOnPartial(t *testing.T) {
+	io := iostreams.Test()
+	f := cmdutil.NewTestFactory(io)
+	f.AssetClient = func() (assets.AssetClientInterface, error) {
+		return &mockSyncAssetClient{
+			result: &assets.SyncResult{Status: "partial", Error: "failed to update repository, using the local clone"},
+		}, nil
+	}
+
+	cmd := NewCmdAssetsSync(f)
+	cmd.SetArgs([]string{"--fail-on", "partial"})
+	cmd.SetOut(io.Out)
+	cmd.SetErr(io.ErrOut)
+
+	err := cmd.Execute()
+	var codeErr *cmdutil.ExitCodeError
+	require.ErrorAs(t, err, &codeErr)
+	assert.Equal(t, cmdutil.ExitPartial, codeErr.Code)
+	assert.Contains(t, io.Out.(*bytes.Buffer).String(), "Sync completed with warnings")
+
+	cmd = NewCmdAssetsSync(f)
+	cmd.SetArgs([]string{"--fail-on", "sometimes"})
+	cmd.SetOut(io.Out)
+	cmd.SetErr(io.ErrOut)
+	var flagErr *cmdutil.FlagError
+	require.ErrorAs(t, cmd.Execute(), &flagErr)
+}
+
+func TestSyncResumedTextOutput(t *testing.T) {
+	io := iostreams.Test()
+	stdout := io.Out
+	f := cmdutil.NewTestFactory(io)
+
+	f.AssetClient = func() (assets.AssetClientInterface, error) {
+		return &mockSyncAssetClient{
+			result: &assets.SyncResult{
+				Status:  "success",
+				Commit:  "a46a68c9eebdab572a32d4b79b41f9e3d01e28f4",
+				Resumed: true,
+			},
+		}, nil
+	}
+
+	cmd := NewCmdAssetsSync(f)
+	cmd.SetArgs([]string{})
+	cmd.SetOut(stdout)
+
+	require.NoError(t, cmd.Execute())
+
+	output := stdout.(*bytes.Buffer).String()
+	assert.Contains(t, output, "Resumed an interrupted sync")
+	assert.Contains(t, output, "Commit: a46a68c9")
+}
+
 func TestSyncJSONOutput(t *testing.T) {
 	io := iostreams.Test()
 	stdout := io.Out
@@ -264,7 +321,9 @@ func TestSyncWithFlags(t *testing.T) {
 	cmd.SetArgs([]string{
 		"--force",
 		"--branch", "develop",
-		"--timeout", "600",
+		"--timeout", "10m",
+		"--no-wait",
+		"--lock-timeout", "30s",
 	})
 	cmd.SetOut(stdout)
 
@@ -274,6 +333,49 @@ func TestSyncWithFlags(t *testing.T) {
 	// The mock client should have captured the sync request
 	assert.True(t, mockClient.lastRequest.Force)
 	assert.Equal(t, "develop", mockClient.lastRequest.Branch)
+	assert.True(t, mockClient.lastRequest.NoWait)
+	assert.Equal(t, 30*time.Second, mockClient.lastRequest.LockTimeout)
+}
+
+func TestSyncDryRunTextOutput(t *testing.T) {
+	io := iostreams.Test()
+	stdout := io.Out
+	f := cmdutil.NewTestFactory(io)
+	f.DryRun = true
+
+	mockClient := &mockSyncAssetClient{
+		captureRequest: true,
+		result: &assets.SyncResult{
+			Status:        "success",
+			DryRun:        true,
+			AssetsAdded:   1,
+			AssetsUpdated: 1,
+			AssetsRemoved: 1,
+			Changes: &assets.ManifestDelta{
+				Added:   []string{"api-spec"},
+				Updated: []string{"technical-spec"},
+				Removed: []string{"old-template"},
+			},
+		},
+	}
+	f.AssetClient = func() (assets.AssetClientInterface, error) {
+		return mockClient, nil
+	}
+
+	cmd := NewCmdAssetsSync(f)
+	cmd.SetArgs([]string{})
+	cmd.SetOut(stdout)
+
+	require.NoError(t, cmd.Execute())
+	assert.True(t, mockClient.lastRequest.DryRun)
+
+	output := stdout.(*bytes.Buffer).String()
+	assert.Contains(t, output, "Dry run: sync would make these changes")
+	assert.Contains(t, output, "+ api-spec")
+	assert.Contains(t, output, "~ technical-spec")
+	assert.Contains(t, output, "- old-template")
+	assert.Contains(t, output, "1 added, 1 updated, 1 removed")
+	assert.NotContains(t, output, "Sync completed")
 }
 
 func TestSyncAuthenticationError(t *testing.T) {
@@ -300,6 +402,34 @@ func TestSyncAuthenticationError(t *testing.T) {
 	assert.Contains(t, err.Error(), "zen assets auth")
 }
 
+func TestSyncRun_Cancelled(t *testing.T) {
+	f := zentest.NewFactory(t).Build()
+	f.Workspace.SetInitialized(true)
+	opts := &SyncOptions{
+		IO:               f.IOStreams,
+		AssetClient:      f.AssetClient,
+		WorkspaceManager: f.WorkspaceManager,
+		Branch:           "main",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f.Assets.Err = context.Canceled
+	err := syncRun(ctx, opts)
+
+	var cancelErr *cmdutil.CancelError
+	require.ErrorAs(t, err, &cancelErr)
+	assert.Equal(t, 0, cancelErr.Completed)
+	checkpoint := cmdutil.CheckpointPath(f.Workspace.ZenDirectory(), "assets-sync")
+	assert.Equal(t, checkpoint, cancelErr.Checkpoint)
+	assert.Contains(t, f.Stderr(), "Not completed: branch main")
+
+	f.Assets.Err = nil
+	require.NoError(t, syncRun(context.Background(), opts))
+	assert.Contains(t, f.Stderr(), "Resuming the sync cancelled at")
+	assert.NoFileExists(t, checkpoint, "the checkpoint is removed once the sync completes")
+}
+
 func TestFormatDuration(t *testing.T) {
 	tests := []struct {
 		duration time.Duration
@@ -44,6 +44,9 @@ func NewCmdAuth(f *cmdutil.Factory) *cobra.Command {
 Supported providers:
 - github: GitHub Personal Access Token authentication
 - gitlab: GitLab Project Access Token authentication
+- notion: Notion internal integration token authentication
+- servicenow: ServiceNow password, OAuth client secret or token authentication
+- confluence: Confluence personal access token authentication
 
 Authentication tokens are stored securely using your operating system's
 credential manager (Keychain on macOS, Credential Manager on Windows,
@@ -140,9 +143,9 @@ func listProviders(opts *AuthOptions, authManager auth.Manager) error {
 	for _, provider := range providers {
 		// Check authentication status
 		isAuth := authManager.IsAuthenticated(context.Background(), provider)
-		status := "✗ Not authenticated"
+		status := opts.IO.FailureIcon() + " Not authenticated"
 		if isAuth {
-			status = "✓ Authenticated"
+			status = opts.IO.SuccessIcon() + " Authenticated"
 		}
 
 		// Get provider info
@@ -166,7 +169,7 @@ func deleteCredentials(opts *AuthOptions, authManager auth.Manager) error {
 		return errors.Wrap(err, "failed to delete credentials")
 	}
 
-	fmt.Fprintf(opts.IO.Out, "✓ Deleted credentials for %s\n", opts.Provider)
+	fmt.Fprintf(opts.IO.Out, "%s Deleted credentials for %s\n", opts.IO.SuccessIcon(), opts.Provider)
 	return nil
 }
 
@@ -202,9 +205,14 @@ func authenticateProvider(ctx context.Context, opts *AuthOptions, authManager au
 	}
 
 	// Success message
-	fmt.Fprintf(opts.IO.Out, "✓ Successfully authenticated with %s\n",
+	fmt.Fprintf(opts.IO.Out, "%s Successfully authenticated with %s\n", opts.IO.SuccessIcon(),
 		cases.Title(language.English).String(opts.Provider))
 
+	// Warn about the operations the token lacks scopes for
+	if opts.Validate {
+		printMissingScopes(ctx, opts, authManager)
+	}
+
 	// Show additional info
 	if opts.IO.IsStdoutTTY() {
 		fmt.Fprintf(opts.IO.Out, "Authentication token stored securely in system credential manager.\n")
@@ -214,6 +222,22 @@ func authenticateProvider(ctx context.Context, opts *AuthOptions, authManager au
 	return nil
 }
 
+// printMissingScopes warns about the operations the credentials of the provider lack
+// scopes for, and how to get them. Credentials whose scopes cannot be read are not
+// reported.
+func printMissingScopes(ctx context.Context, opts *AuthOptions, authManager auth.Manager) {
+	report, err := auth.CheckScopes(ctx, authManager, opts.Provider, auth.Operations...)
+	if err != nil || len(report.Missing) == 0 {
+		return
+	}
+
+	for _, missing := range report.Missing {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Missing scope for %s: %s\n", opts.IO.WarningIcon(),
+			missing.Operation, strings.Join(missing.Scopes, " or "))
+	}
+	fmt.Fprintf(opts.IO.ErrOut, "To fix, %s\n", report.Remediation)
+}
+
 // Helper functions (similar to assets auth but updated for main command)
 
 func getAuthToken(opts *AuthOptions, authManager auth.Manager) (string, error) {
@@ -243,7 +267,10 @@ func readTokenFromFile(filepath string) (string, error) {
 
 func promptForToken(opts *AuthOptions) (string, error) {
 	if !opts.IO.CanPrompt() {
-		return "", fmt.Errorf("authentication token required but prompting is disabled")
+		return "", &cmdutil.PromptError{
+			Need: "authentication token required",
+			Hint: "pass --token or --token-file, or set the provider's token environment variable (see 'zen help environment')",
+		}
 	}
 
 	// Get provider info for instructions
@@ -258,7 +285,7 @@ func promptForToken(opts *AuthOptions) (string, error) {
 	}
 
 	// Show instructions
-	fmt.Fprintf(opts.IO.Out, "⚠ Authentication required for %s\n\n", cases.Title(language.English).String(opts.Provider))
+	fmt.Fprintf(opts.IO.Out, "%s Authentication required for %s\n\n", opts.IO.WarningIcon(), cases.Title(language.English).String(opts.Provider))
 	fmt.Fprintf(opts.IO.Out, "Instructions: %s:\n", info.Description)
 	for _, instruction := range info.Instructions {
 		fmt.Fprintf(opts.IO.Out, "%s\n", instruction)
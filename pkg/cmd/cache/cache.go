@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/cache/migrate"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdCache creates the cache command with subcommands
+func NewCmdCache(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache <command>",
+		Short: "Manage where zen keeps its caches and user files",
+		Long: heredoc.Doc(`
+			Manage the files zen keeps for you rather than for a workspace.
+
+			zen follows the XDG Base Directory specification on Linux, and the
+			platform conventions on macOS and Windows:
+
+			- configuration: $XDG_CONFIG_HOME/zen, ~/Library/Application Support/zen, %AppData%\zen
+			- caches, including the asset cache: $XDG_CACHE_HOME/zen, ~/Library/Caches/zen, %LocalAppData%\zen\cache
+			- state and logs: $XDG_STATE_HOME/zen, ~/Library/Application Support/zen, %LocalAppData%\zen
+			- extensions: $XDG_DATA_HOME/zen, ~/Library/Application Support/zen, %LocalAppData%\zen
+
+			ZEN_CONFIG_DIR, ZEN_CACHE_DIR, ZEN_STATE_DIR and ZEN_DATA_DIR override each
+			of them. The asset cache is shared by all workspaces; set assets.cache_path
+			to keep it somewhere else, such as a shared volume. Workspaces with caches
+			of their own can share content and repository clones through the store
+			enabled by assets.shared_store.
+		`),
+		Example: heredoc.Doc(`
+			# Move the files earlier versions kept in ~/.zen
+			zen cache migrate --dry-run
+			zen cache migrate
+		`),
+		GroupID: "workspace",
+	}
+
+	cmd.AddCommand(migrate.NewCmdCacheMigrate(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,124 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/dirs"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// MigrateOptions contains options for the cache migrate command
+type MigrateOptions struct {
+	IO           *iostreams.IOStreams
+	Plan         func() ([]dirs.Migration, error)
+	Migrate      func([]dirs.Migration) ([]dirs.Migration, error)
+	DryRun       bool
+	OutputFormat string
+	Template     string
+	JQ           string
+}
+
+// NewCmdCacheMigrate creates the cache migrate command
+func NewCmdCacheMigrate(f *cmdutil.Factory, runF func(*MigrateOptions) error) *cobra.Command {
+	opts := &MigrateOptions{
+		IO:      f.IOStreams,
+		Plan:    dirs.PlanMigration,
+		Migrate: dirs.Migrate,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move files from ~/.zen to the user directories",
+		Long: heredoc.Doc(`
+			Move the configuration, caches, state and extensions that earlier versions
+			of zen kept in ~/.zen to the directories zen uses now. See 'zen cache --help'
+			for where they are.
+
+			Files are moved rather than copied when the directories are on the same file
+			system. Nothing is overwritten: a file whose new place is taken is left in
+			~/.zen and reported as skipped. ~/.zen is removed once it is empty.
+
+			A ~/.zen directory that holds tasks belongs to a workspace in the home
+			directory, and is left alone.
+		`),
+		Example: heredoc.Doc(`
+			# Show what would move
+			$ zen cache migrate --dry-run
+
+			# Move the files
+			$ zen cache migrate
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.DryRun = f.DryRun
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return migrateRun(opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func migrateRun(opts *MigrateOptions) error {
+	migrations, err := opts.Plan()
+	if errors.Is(err, dirs.ErrLegacyWorkspace) {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidWorkspace,
+			Message: "~/.zen holds a workspace and was not migrated",
+			Details: "move the user configuration out of ~/.zen/config by hand if you want it to apply outside that workspace",
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if !opts.DryRun && len(migrations) > 0 {
+		var migrateErr error
+		migrations, migrateErr = opts.Migrate(migrations)
+		if migrateErr != nil {
+			displayMigrations(opts.IO.ErrOut, opts.IO, migrations)
+			return migrateErr
+		}
+	}
+
+	if migrations == nil {
+		migrations = []dirs.Migration{}
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(migrations, func(w io.Writer) error {
+		if len(migrations) == 0 {
+			fmt.Fprintln(w, "Nothing to migrate: ~/.zen holds no files of earlier versions")
+			return nil
+		}
+		displayMigrations(w, opts.IO, migrations)
+		return nil
+	})
+}
+
+func displayMigrations(w io.Writer, streams *iostreams.IOStreams, migrations []dirs.Migration) {
+	for _, migration := range migrations {
+		switch migration.Status {
+		case dirs.MigrationMoved:
+			fmt.Fprintf(w, "%s Moved %s to %s\n", streams.SuccessIcon(), migration.Name, migration.To)
+		case dirs.MigrationSkipped:
+			fmt.Fprintf(w, "%s Skipped %s: %s already exists\n", streams.WarningIcon(), migration.Name, migration.To)
+		default:
+			fmt.Fprintf(w, "%s Would move %s to %s\n", streams.InfoIcon(), migration.Name, migration.To)
+		}
+	}
+}
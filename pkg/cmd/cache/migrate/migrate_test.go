@@ -0,0 +1,102 @@
+package migrate
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/dirs"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(plan []dirs.Migration, planErr error) (*MigrateOptions, *bytes.Buffer, *[]dirs.Migration) {
+	streams := iostreams.Test()
+	var migrated []dirs.Migration
+
+	return &MigrateOptions{
+		IO:   streams,
+		Plan: func() ([]dirs.Migration, error) { return plan, planErr },
+		Migrate: func(migrations []dirs.Migration) ([]dirs.Migration, error) {
+			for _, migration := range migrations {
+				migration.Status = dirs.MigrationMoved
+				if migration.Name == "extensions" {
+					migration.Status = dirs.MigrationSkipped
+				}
+				migrated = append(migrated, migration)
+			}
+			return migrated, nil
+		},
+	}, streams.Out.(*bytes.Buffer), &migrated
+}
+
+func testPlan() []dirs.Migration {
+	return []dirs.Migration{
+		{Name: "config", From: "/home/ada/.zen/config", To: "/home/ada/.config/zen/config", Status: dirs.MigrationPending},
+		{Name: "extensions", From: "/home/ada/.zen/extensions", To: "/home/ada/.local/share/zen/extensions", Status: dirs.MigrationPending},
+	}
+}
+
+func TestNewCmdCacheMigrate(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	f.DryRun = true
+
+	var got *MigrateOptions
+	cmd := NewCmdCacheMigrate(f, func(opts *MigrateOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{})
+
+	require.NoError(t, cmd.Execute())
+	assert.True(t, got.DryRun)
+}
+
+func TestMigrateRun(t *testing.T) {
+	opts, out, migrated := newTestOptions(testPlan(), nil)
+
+	require.NoError(t, migrateRun(opts))
+	assert.Len(t, *migrated, 2)
+	assert.Equal(t,
+		"✓ Moved config to /home/ada/.config/zen/config\n"+
+			"! Skipped extensions: /home/ada/.local/share/zen/extensions already exists\n",
+		out.String())
+}
+
+func TestMigrateRun_DryRun(t *testing.T) {
+	opts, out, migrated := newTestOptions(testPlan(), nil)
+	opts.DryRun = true
+
+	require.NoError(t, migrateRun(opts))
+	assert.Empty(t, *migrated)
+	assert.Contains(t, out.String(), "Would move config to /home/ada/.config/zen/config")
+}
+
+func TestMigrateRun_NothingToMigrate(t *testing.T) {
+	opts, out, _ := newTestOptions(nil, nil)
+
+	require.NoError(t, migrateRun(opts))
+	assert.Contains(t, out.String(), "Nothing to migrate")
+}
+
+func TestMigrateRun_JSON(t *testing.T) {
+	opts, out, _ := newTestOptions(testPlan(), nil)
+	opts.OutputFormat = cmdutil.OutputJSON
+	opts.JQ = `.[] | "\(.name) \(.status)"`
+
+	require.NoError(t, migrateRun(opts))
+	assert.Equal(t, "config moved\nextensions skipped\n", out.String())
+}
+
+func TestMigrateRun_Workspace(t *testing.T) {
+	opts, _, _ := newTestOptions(nil, dirs.ErrLegacyWorkspace)
+
+	err := migrateRun(opts)
+
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr))
+	assert.Equal(t, types.ErrorCodeInvalidWorkspace, zenErr.Code)
+}
@@ -0,0 +1,326 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// verifyTimeout bounds how long the installed script may take to load in the shell
+const verifyTimeout = 10 * time.Second
+
+// supportedShells lists the shells completions can be installed for
+var supportedShells = []string{"bash", "zsh", "fish"}
+
+// InstallOptions contains options for the completion install command
+type InstallOptions struct {
+	IO       *iostreams.IOStreams
+	Root     *cobra.Command
+	DryRun   bool
+	Shell    string
+	Path     string
+	NoVerify bool
+
+	// Getenv, HomeDir, BrewPrefix, ZshFpath and Verify reach outside the process and
+	// are replaced in tests
+	Getenv     func(string) string
+	HomeDir    func() (string, error)
+	BrewPrefix func() string
+	ZshFpath   func() []string
+	Verify     func(shell, path string) error
+}
+
+// NewCmdCompletionInstall creates the completion install command
+func NewCmdCompletionInstall(f *cmdutil.Factory, runF func(*InstallOptions) error) *cobra.Command {
+	opts := &InstallOptions{
+		IO:         f.IOStreams,
+		Getenv:     os.Getenv,
+		HomeDir:    os.UserHomeDir,
+		BrewPrefix: brewPrefix,
+		ZshFpath:   zshFpath,
+		Verify:     verifyScript,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install shell completions for your shell",
+		Long: heredoc.Doc(`
+			Install the completion script for your shell and check that it loads.
+
+			The shell is detected from $SHELL unless --shell is given. The script is
+			written where the shell picks it up without further setup:
+
+			- bash: the Homebrew bash_completion.d directory, or the bash-completion
+			  user directory ($XDG_DATA_HOME/bash-completion/completions)
+			- zsh: a directory on your fpath under your home directory, the Homebrew
+			  site-functions directory, or ~/.zsh/completions
+			- fish: $XDG_CONFIG_HOME/fish/completions
+
+			Open a new shell afterwards to use the completions.
+		`),
+		Example: heredoc.Doc(`
+			$ zen completion install
+			$ zen completion install --shell zsh
+			$ zen completion install --shell bash --path ~/.bash_completion.d/zen
+			$ zen completion install --dry-run
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Root = cmd.Root()
+			opts.DryRun = f.DryRun
+
+			if opts.Shell != "" && !isSupported(opts.Shell) {
+				return &cmdutil.FlagError{Err: fmt.Errorf("unsupported shell %q, must be one of: %s",
+					opts.Shell, strings.Join(supportedShells, ", "))}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return installRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Shell, "shell", "", "Shell to install completions for: {bash|zsh|fish}")
+	cmd.Flags().StringVar(&opts.Path, "path", "", "Write the completion script to this file instead")
+	cmd.Flags().BoolVar(&opts.NoVerify, "no-verify", false, "Do not check that the script loads in the shell")
+
+	return cmd
+}
+
+func installRun(opts *InstallOptions) error {
+	shell := opts.Shell
+	if shell == "" {
+		shell = detectShell(opts.Getenv("SHELL"))
+		if shell == "" {
+			return &cmdutil.FlagError{Err: fmt.Errorf("could not detect your shell from $SHELL; use --shell with one of: %s",
+				strings.Join(supportedShells, ", "))}
+		}
+	}
+
+	path := opts.Path
+	note := ""
+	if path == "" {
+		var err error
+		path, note, err = completionPath(opts, shell)
+		if err != nil {
+			return err
+		}
+	}
+
+	var script bytes.Buffer
+	if err := generateScript(opts.Root, shell, &script); err != nil {
+		return fmt.Errorf("failed to generate %s completion script: %w", shell, err)
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(opts.IO.Out, "Would install %s completions to %s\n", shell, path)
+		if note != "" {
+			fmt.Fprintln(opts.IO.Out, note)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, script.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+	fmt.Fprintf(opts.IO.Out, "%s Installed %s completions to %s\n", opts.IO.SuccessIcon(), shell, path)
+
+	if !opts.NoVerify {
+		if err := opts.Verify(shell, path); err != nil {
+			fmt.Fprintf(opts.IO.ErrOut, "%s Could not verify the completions load: %v\n", opts.IO.FormatWarning("!"), err)
+		} else {
+			fmt.Fprintf(opts.IO.Out, "%s Verified the completions load in %s\n", opts.IO.SuccessIcon(), shell)
+		}
+	}
+
+	if note != "" {
+		fmt.Fprintln(opts.IO.Out, note)
+	}
+	fmt.Fprintln(opts.IO.Out, "Open a new shell to start using completions.")
+	return nil
+}
+
+// detectShell returns the supported shell named by the $SHELL path, or "" if there is none
+func detectShell(shellPath string) string {
+	name := strings.TrimSuffix(filepath.Base(strings.TrimSpace(shellPath)), ".exe")
+	if isSupported(name) {
+		return name
+	}
+	return ""
+}
+
+func isSupported(shell string) bool {
+	for _, s := range supportedShells {
+		if s == shell {
+			return true
+		}
+	}
+	return false
+}
+
+// completionPath returns where the script for shell is installed so that the shell loads
+// it on start, and a note for the user when it only loads after further setup
+func completionPath(opts *InstallOptions, shell string) (string, string, error) {
+	home, err := opts.HomeDir()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+
+	switch shell {
+	case "bash":
+		if prefix := opts.BrewPrefix(); prefix != "" {
+			dir := filepath.Join(prefix, "etc", "bash_completion.d")
+			if isWritableDir(dir) {
+				return filepath.Join(dir, "zen"), "", nil
+			}
+		}
+		return filepath.Join(xdgDir(opts, "XDG_DATA_HOME", home, ".local", "share"),
+			"bash-completion", "completions", "zen"), "", nil
+
+	case "zsh":
+		for _, dir := range opts.ZshFpath() {
+			if isWithin(dir, home) && isWritableDir(dir) {
+				return filepath.Join(dir, "_zen"), "", nil
+			}
+		}
+		if prefix := opts.BrewPrefix(); prefix != "" {
+			dir := filepath.Join(prefix, "share", "zsh", "site-functions")
+			if isWritableDir(dir) {
+				return filepath.Join(dir, "_zen"), "", nil
+			}
+		}
+		dir := filepath.Join(home, ".zsh", "completions")
+		note := heredoc.Docf(`
+			%s is not on your fpath yet. Add these lines to ~/.zshrc, before compinit runs:
+			  fpath=(%s $fpath)
+			  autoload -U compinit && compinit
+		`, dir, dir)
+		return filepath.Join(dir, "_zen"), strings.TrimSpace(note), nil
+
+	case "fish":
+		return filepath.Join(xdgDir(opts, "XDG_CONFIG_HOME", home, ".config"),
+			"fish", "completions", "zen.fish"), "", nil
+	}
+
+	return "", "", fmt.Errorf("unsupported shell: %s", shell)
+}
+
+// xdgDir returns the directory in the XDG environment variable, or its default under home
+func xdgDir(opts *InstallOptions, env, home string, fallback ...string) string {
+	if dir := opts.Getenv(env); dir != "" && filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(append([]string{home}, fallback...)...)
+}
+
+// isWithin reports whether dir is inside parent
+func isWithin(dir, parent string) bool {
+	rel, err := filepath.Rel(parent, dir)
+	return err == nil && rel != "." && !strings.HasPrefix(rel, "..")
+}
+
+// isWritableDir reports whether dir is an existing directory a file can be created in
+func isWritableDir(dir string) bool {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	probe, err := os.CreateTemp(dir, ".zen-completion-*")
+	if err != nil {
+		return false
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return true
+}
+
+// generateScript writes the completion script for shell
+func generateScript(root *cobra.Command, shell string, w *bytes.Buffer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletion(w)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	}
+	return fmt.Errorf("unsupported shell: %s", shell)
+}
+
+// brewPrefix returns the Homebrew prefix, or "" when Homebrew is not installed
+func brewPrefix() string {
+	if _, err := exec.LookPath("brew"); err != nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "brew", "--prefix").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// zshFpath returns the directories on the fpath of an interactive zsh
+func zshFpath() []string {
+	if _, err := exec.LookPath("zsh"); err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "zsh", "-i", "-c", `print -l -- $fpath`).Output()
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			dirs = append(dirs, line)
+		}
+	}
+	return dirs
+}
+
+// verifyScript loads the installed script in a fresh shell and checks that it registers
+// completions for zen
+func verifyScript(shell, path string) error {
+	if _, err := exec.LookPath(shell); err != nil {
+		return fmt.Errorf("%s is not on your PATH", shell)
+	}
+
+	var args []string
+	switch shell {
+	case "bash":
+		args = []string{"--norc", "--noprofile", "-c", `source "$1" && complete -p zen >/dev/null`, "verify", path}
+	case "zsh":
+		args = []string{"-f", "-c", `autoload -U compinit && compinit -u -D && source "$1" && (( $+functions[_zen] ))`, "verify", path}
+	case "fish":
+		args = []string{"--no-config", "-c", `source $argv[1]; and complete -c zen | string length -q`, path}
+	default:
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+	if out, err := exec.CommandContext(ctx, shell, args...).CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
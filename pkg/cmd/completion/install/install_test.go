@@ -0,0 +1,199 @@
+package install
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOptions(t *testing.T, env map[string]string) (*InstallOptions, string, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	streams := iostreams.Test()
+	home := t.TempDir()
+	root := &cobra.Command{Use: "zen"}
+	root.AddCommand(&cobra.Command{Use: "status", Run: func(*cobra.Command, []string) {}})
+
+	opts := &InstallOptions{
+		IO:         streams,
+		Root:       root,
+		Getenv:     func(key string) string { return env[key] },
+		HomeDir:    func() (string, error) { return home, nil },
+		BrewPrefix: func() string { return "" },
+		ZshFpath:   func() []string { return nil },
+		Verify:     func(shell, path string) error { return nil },
+	}
+	return opts, home, streams.Out.(*bytes.Buffer), streams.ErrOut.(*bytes.Buffer)
+}
+
+func TestNewCmdCompletionInstall(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+		want    InstallOptions
+	}{
+		{name: "defaults", args: []string{}},
+		{name: "shell and path", args: []string{"--shell", "zsh", "--path", "/tmp/_zen", "--no-verify"},
+			want: InstallOptions{Shell: "zsh", Path: "/tmp/_zen", NoVerify: true}},
+		{name: "unsupported shell", args: []string{"--shell", "tcsh"}, wantErr: `unsupported shell "tcsh"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutil.NewTestFactory(iostreams.Test())
+			var gotOpts *InstallOptions
+			cmd := NewCmdCompletionInstall(f, func(opts *InstallOptions) error {
+				gotOpts = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				var flagErr *cmdutil.FlagError
+				require.ErrorAs(t, err, &flagErr)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Shell, gotOpts.Shell)
+			assert.Equal(t, tt.want.Path, gotOpts.Path)
+			assert.Equal(t, tt.want.NoVerify, gotOpts.NoVerify)
+			assert.NotNil(t, gotOpts.Root)
+		})
+	}
+}
+
+func TestDetectShell(t *testing.T) {
+	assert.Equal(t, "zsh", detectShell("/bin/zsh"))
+	assert.Equal(t, "bash", detectShell("/opt/homebrew/bin/bash"))
+	assert.Equal(t, "fish", detectShell("/usr/local/bin/fish"))
+	assert.Equal(t, "", detectShell("/bin/tcsh"))
+	assert.Equal(t, "", detectShell(""))
+}
+
+func TestInstallRun(t *testing.T) {
+	t.Run("detects shell and installs to the bash-completion user directory", func(t *testing.T) {
+		opts, home, stdout, _ := testOptions(t, map[string]string{"SHELL": "/bin/bash"})
+
+		require.NoError(t, installRun(opts))
+
+		path := filepath.Join(home, ".local", "share", "bash-completion", "completions", "zen")
+		script, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(script), "__start_zen")
+		assert.Contains(t, stdout.String(), "Installed bash completions to "+path)
+		assert.Contains(t, stdout.String(), "Verified the completions load in bash")
+	})
+
+	t.Run("prefers the Homebrew directory for bash", func(t *testing.T) {
+		opts, _, _, _ := testOptions(t, nil)
+		prefix := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(prefix, "etc", "bash_completion.d"), 0755))
+		opts.BrewPrefix = func() string { return prefix }
+		opts.Shell = "bash"
+
+		require.NoError(t, installRun(opts))
+
+		assert.FileExists(t, filepath.Join(prefix, "etc", "bash_completion.d", "zen"))
+	})
+
+	t.Run("installs to a zsh fpath directory under home", func(t *testing.T) {
+		opts, home, stdout, _ := testOptions(t, nil)
+		fpathDir := filepath.Join(home, ".zfunc")
+		require.NoError(t, os.MkdirAll(fpathDir, 0755))
+		opts.ZshFpath = func() []string { return []string{"/usr/share/zsh/functions", fpathDir} }
+		opts.Shell = "zsh"
+
+		require.NoError(t, installRun(opts))
+
+		assert.FileExists(t, filepath.Join(fpathDir, "_zen"))
+		assert.NotContains(t, stdout.String(), "not on your fpath")
+	})
+
+	t.Run("falls back to ~/.zsh/completions with fpath setup", func(t *testing.T) {
+		opts, home, stdout, _ := testOptions(t, nil)
+		opts.Shell = "zsh"
+
+		require.NoError(t, installRun(opts))
+
+		assert.FileExists(t, filepath.Join(home, ".zsh", "completions", "_zen"))
+		assert.Contains(t, stdout.String(), "fpath=("+filepath.Join(home, ".zsh", "completions")+" $fpath)")
+	})
+
+	t.Run("uses XDG_CONFIG_HOME for fish", func(t *testing.T) {
+		config := t.TempDir()
+		opts, _, _, _ := testOptions(t, map[string]string{"XDG_CONFIG_HOME": config})
+		opts.Shell = "fish"
+
+		require.NoError(t, installRun(opts))
+
+		assert.FileExists(t, filepath.Join(config, "fish", "completions", "zen.fish"))
+	})
+
+	t.Run("warns when the script does not load", func(t *testing.T) {
+		opts, _, stdout, stderr := testOptions(t, nil)
+		opts.Shell = "fish"
+		opts.Verify = func(shell, path string) error { return errors.New("fish is not on your PATH") }
+
+		require.NoError(t, installRun(opts))
+
+		assert.Contains(t, stderr.String(), "Could not verify the completions load: fish is not on your PATH")
+		assert.NotContains(t, stdout.String(), "Verified")
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		opts, home, stdout, _ := testOptions(t, nil)
+		opts.Shell = "fish"
+		opts.DryRun = true
+		opts.Verify = func(shell, path string) error {
+			t.Fatal("dry run must not verify")
+			return nil
+		}
+
+		require.NoError(t, installRun(opts))
+
+		path := filepath.Join(home, ".config", "fish", "completions", "zen.fish")
+		assert.NoFileExists(t, path)
+		assert.Equal(t, "Would install fish completions to "+path+"\n", stdout.String())
+	})
+
+	t.Run("undetectable shell", func(t *testing.T) {
+		opts, _, _, _ := testOptions(t, map[string]string{"SHELL": "/bin/tcsh"})
+
+		err := installRun(opts)
+
+		var flagErr *cmdutil.FlagError
+		require.ErrorAs(t, err, &flagErr)
+		assert.Contains(t, err.Error(), "use --shell")
+	})
+}
+
+func TestVerifyScript(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not available")
+	}
+	opts, _, _, _ := testOptions(t, nil)
+	dir := t.TempDir()
+
+	var script bytes.Buffer
+	require.NoError(t, generateScript(opts.Root, "bash", &script))
+	valid := filepath.Join(dir, "zen")
+	require.NoError(t, os.WriteFile(valid, script.Bytes(), 0644))
+	assert.NoError(t, verifyScript("bash", valid))
+
+	broken := filepath.Join(dir, "broken")
+	require.NoError(t, os.WriteFile(broken, []byte("echo not a completion\n"), 0644))
+	assert.Error(t, verifyScript("bash", broken))
+}
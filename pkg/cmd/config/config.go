@@ -3,15 +3,16 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/daddia/zen/internal/config"
 	"github.com/daddia/zen/pkg/cmd/config/get"
 	"github.com/daddia/zen/pkg/cmd/config/list"
 	"github.com/daddia/zen/pkg/cmd/config/set"
+	"github.com/daddia/zen/pkg/cmd/config/validate"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 // NewCmdConfig creates the config command with subcommands
@@ -47,6 +48,9 @@ func NewCmdConfig(f *cmdutil.Factory) *cobra.Command {
   # List all configuration with values
   zen config list
 
+  # Validate configuration and organization policy overrides
+  zen config validate
+
   # Output configuration as JSON
   zen config --output json
 
@@ -62,6 +66,7 @@ func NewCmdConfig(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(get.NewCmdConfigGet(f, nil))
 	cmd.AddCommand(set.NewCmdConfigSet(f, nil))
 	cmd.AddCommand(list.NewCmdConfigList(f, nil))
+	cmd.AddCommand(validate.NewCmdConfigValidate(f, nil))
 
 	return cmd
 }
@@ -74,28 +79,10 @@ func displayCurrentConfig(f *cmdutil.Factory, cmd *cobra.Command) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	// Get output format from parent command if available
-	outputFormat := "text"
-	if cmd.Parent() != nil && cmd.Parent().PersistentFlags().Changed("output") {
-		if val, err := cmd.Parent().PersistentFlags().GetString("output"); err == nil {
-			outputFormat = val
-		}
-	}
-
-	switch outputFormat {
-	case "json":
-		encoder := json.NewEncoder(f.IOStreams.Out)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(cfg)
-
-	case "yaml":
-		encoder := yaml.NewEncoder(f.IOStreams.Out)
-		defer encoder.Close()
-		return encoder.Encode(cfg)
-
-	default:
-		return displayTextConfig(f.IOStreams.Out, cfg, f.IOStreams)
-	}
+	renderer := cmdutil.NewRendererForCommand(f.IOStreams, cmd)
+	return renderer.Render(cfg, func(w io.Writer) error {
+		return displayTextConfig(w, cfg, f.IOStreams)
+	})
 }
 
 // displayTextConfig displays configuration in human-readable text format following design guide
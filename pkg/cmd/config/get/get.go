@@ -15,6 +15,7 @@ import (
 	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/cache"
 	"github.com/daddia/zen/pkg/cli"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/daddia/zen/pkg/task"
@@ -46,6 +47,7 @@ Configuration keys use dot notation to access component values:
 - assets.repository_url, assets.branch
 - workspace.root, workspace.zen_path
 - cli.no_color, cli.verbose, cli.output_format
+- ui.theme, ui.accessible
 - development.debug, development.profile
 - task.source, task.sync, task.project_key
 - auth.storage_type, auth.validation_timeout
@@ -107,10 +109,14 @@ func getRun(opts *GetOptions) error {
 		return getComponentConfig(cfg, cli.ConfigParser{}, field, opts.IO)
 	case "development":
 		return getComponentConfig(cfg, development.ConfigParser{}, field, opts.IO)
+	case "network":
+		return getComponentConfig(cfg, httpx.ConfigParser{}, field, opts.IO)
 	case "task":
 		return getComponentConfig(cfg, task.ConfigParser{}, field, opts.IO)
 	case "templates":
 		return getComponentConfig(cfg, template.ConfigParser{}, field, opts.IO)
+	case "ui":
+		return getComponentConfig(cfg, cli.UIConfigParser{}, field, opts.IO)
 	case "workspace":
 		return getComponentConfig(cfg, workspace.ConfigParser{}, field, opts.IO)
 	default:
@@ -201,6 +207,15 @@ func extractFieldValue(configStruct interface{}, fieldName string) (string, erro
 	structFieldName := toPascalCase(fieldName)
 
 	field := v.FieldByName(structFieldName)
+	if !field.IsValid() {
+		// Fields named with initialisms, such as CABundle for ca_bundle, are found by tag
+		for i := 0; i < v.NumField(); i++ {
+			if name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("mapstructure"), ","); name == fieldName {
+				field = v.Field(i)
+				break
+			}
+		}
+	}
 	if !field.IsValid() {
 		return "", fmt.Errorf("field %s not found in config", fieldName)
 	}
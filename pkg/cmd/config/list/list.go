@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/daddia/zen/internal/config"
 	"github.com/daddia/zen/internal/development"
 	"github.com/daddia/zen/internal/workspace"
@@ -14,6 +15,7 @@ import (
 	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/cache"
 	"github.com/daddia/zen/pkg/cli"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/daddia/zen/pkg/task"
@@ -25,6 +27,17 @@ import (
 type ListOptions struct {
 	IO     *iostreams.IOStreams
 	Config func() (*config.Config, error)
+
+	// Defaults lists the default of each key, with the documentation of keys that
+	// have it, rather than the effective configuration
+	Defaults bool
+}
+
+// keyDocs documents keys whose meaning is not clear from their name, shown above their
+// defaults with --defaults
+var keyDocs = map[string]string{
+	"network.timeout":           "Timeout of each request to external services, including retries; 0s uses the built-in timeout of each provider, 30s for most; --timeout overrides it for a command",
+	"network.provider_timeouts": "Timeouts of the requests to individual providers over network.timeout, e.g. {jira: 1m, github: 10s}",
 }
 
 // NewCmdConfigList creates the config list command
@@ -52,7 +65,19 @@ Configuration is organized by component:
 - Task: source, sync, project_key
 - Auth: storage_type, validation_timeout
 - Cache: base_path, size_limit_mb
-- Templates: cache_enabled, cache_ttl`,
+- Network: proxy, ca_bundle, timeout, provider_timeouts
+- Templates: cache_enabled, cache_ttl
+
+With --defaults, the default of each key is listed instead, with the
+documentation of keys such as network.timeout.
+
+Timeouts of requests to external services resolve from the most specific
+setting: the --timeout flag of a command, then network.provider_timeouts of
+the provider, then network.timeout, then the built-in timeout of the provider.`,
+		Example: heredoc.Doc(`
+			$ zen config list
+			$ zen config list --defaults
+		`),
 		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if runF != nil {
@@ -63,6 +88,8 @@ Configuration is organized by component:
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.Defaults, "defaults", false, "List the default of each key instead of its value")
+
 	return cmd
 }
 
@@ -72,9 +99,12 @@ func listRun(opts *ListOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if opts.Defaults {
+		cfg = config.LoadDefaults()
+	}
 
 	// List available components
-	components := []string{"assets", "auth", "cache", "cli", "development", "task", "templates", "workspace"}
+	components := []string{"assets", "auth", "cache", "cli", "development", "network", "task", "templates", "ui", "workspace"}
 
 	// Display core configuration first
 	fmt.Fprintln(opts.IO.Out, "[core]")
@@ -86,21 +116,25 @@ func listRun(opts *ListOptions) error {
 	for _, component := range components {
 		switch component {
 		case "assets":
-			listComponentConfig(cfg, assets.ConfigParser{}, opts.IO)
+			listComponentConfig(cfg, opts.Defaults, assets.ConfigParser{}, opts.IO)
 		case "auth":
-			listComponentConfig(cfg, auth.ConfigParser{}, opts.IO)
+			listComponentConfig(cfg, opts.Defaults, auth.ConfigParser{}, opts.IO)
 		case "cache":
-			listComponentConfig(cfg, cache.ConfigParser{}, opts.IO)
+			listComponentConfig(cfg, opts.Defaults, cache.ConfigParser{}, opts.IO)
 		case "cli":
-			listComponentConfig(cfg, cli.ConfigParser{}, opts.IO)
+			listComponentConfig(cfg, opts.Defaults, cli.ConfigParser{}, opts.IO)
 		case "development":
-			listComponentConfig(cfg, development.ConfigParser{}, opts.IO)
+			listComponentConfig(cfg, opts.Defaults, development.ConfigParser{}, opts.IO)
+		case "network":
+			listComponentConfig(cfg, opts.Defaults, httpx.ConfigParser{}, opts.IO)
 		case "task":
-			listComponentConfig(cfg, task.ConfigParser{}, opts.IO)
+			listComponentConfig(cfg, opts.Defaults, task.ConfigParser{}, opts.IO)
 		case "templates":
-			listComponentConfig(cfg, template.ConfigParser{}, opts.IO)
+			listComponentConfig(cfg, opts.Defaults, template.ConfigParser{}, opts.IO)
+		case "ui":
+			listComponentConfig(cfg, opts.Defaults, cli.UIConfigParser{}, opts.IO)
 		case "workspace":
-			listComponentConfig(cfg, workspace.ConfigParser{}, opts.IO)
+			listComponentConfig(cfg, opts.Defaults, workspace.ConfigParser{}, opts.IO)
 		}
 	}
 
@@ -108,20 +142,28 @@ func listRun(opts *ListOptions) error {
 }
 
 // listComponentConfig lists configuration for a specific component
-func listComponentConfig[T config.Configurable](cfg *config.Config, parser config.ConfigParser[T], io *iostreams.IOStreams) {
+func listComponentConfig[T config.Configurable](cfg *config.Config, defaults bool, parser config.ConfigParser[T], io *iostreams.IOStreams) {
 	componentConfig, err := config.GetConfig(cfg, parser)
+	if defaults {
+		componentConfig, err = parser.Parse(map[string]interface{}{})
+	}
 	if err != nil {
 		fmt.Fprintf(io.ErrOut, "Error loading %s config: %v\n", parser.Section(), err)
 		return
 	}
 
 	fmt.Fprintf(io.Out, "[%s]\n", parser.Section())
-	displayConfigStruct(componentConfig, io)
+	section := ""
+	if defaults {
+		section = parser.Section()
+	}
+	displayConfigStruct(componentConfig, section, io)
 	fmt.Fprintln(io.Out)
 }
 
-// displayConfigStruct displays a configuration struct in a readable format
-func displayConfigStruct(configStruct interface{}, io *iostreams.IOStreams) {
+// displayConfigStruct displays a configuration struct in a readable format, with the
+// documentation of its keys when section is set
+func displayConfigStruct(configStruct interface{}, section string, io *iostreams.IOStreams) {
 	v := reflect.ValueOf(configStruct)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
@@ -148,6 +190,9 @@ func displayConfigStruct(configStruct interface{}, io *iostreams.IOStreams) {
 			continue
 		}
 
+		if doc, ok := keyDocs[section+"."+fieldName]; ok && section != "" {
+			fmt.Fprintf(io.Out, "# %s\n", doc)
+		}
 		value := formatFieldValue(field)
 		fmt.Fprintf(io.Out, "%s = %s\n", fieldName, value)
 	}
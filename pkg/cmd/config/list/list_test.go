@@ -38,6 +38,30 @@ func TestListRun(t *testing.T) {
 	assert.Contains(t, output, "[workspace]")
 }
 
+func TestListRun_Defaults(t *testing.T) {
+	streams := iostreams.Test()
+	cfg := config.LoadDefaults()
+	cfg.Core.LogLevel = "debug"
+	opts := &ListOptions{
+		IO:       streams,
+		Config:   func() (*config.Config, error) { return cfg, nil },
+		Defaults: true,
+	}
+
+	require.NoError(t, listRun(opts))
+	output := streams.Out.(*bytes.Buffer).String()
+	assert.Contains(t, output, "log_level = info", "defaults are listed rather than the configuration")
+	assert.Contains(t, output, "[network]\n")
+	assert.Contains(t, output, "# Timeout of each request to external services")
+	assert.Contains(t, output, "timeout = 0s\n")
+
+	streams = iostreams.Test()
+	opts = &ListOptions{IO: streams, Config: func() (*config.Config, error) { return cfg, nil }}
+	require.NoError(t, listRun(opts))
+	assert.Contains(t, streams.Out.(*bytes.Buffer).String(), "log_level = debug")
+	assert.NotContains(t, streams.Out.(*bytes.Buffer).String(), "# Timeout", "keys are documented with --defaults")
+}
+
 func TestNewCmdConfigList(t *testing.T) {
 	streams := iostreams.Test()
 	factory := &cmdutil.Factory{
@@ -16,8 +16,10 @@ import (
 	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/cache"
 	"github.com/daddia/zen/pkg/cli"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/policy"
 	"github.com/daddia/zen/pkg/task"
 	"github.com/daddia/zen/pkg/template"
 	"github.com/spf13/cobra"
@@ -27,8 +29,16 @@ import (
 type SetOptions struct {
 	IO     *iostreams.IOStreams
 	Config func() (*config.Config, error)
-	Key    string
-	Value  string
+	Policy func() (*policy.Enforcer, error)
+
+	// OrgPolicy is the organization policy bundle, whose protected keys keep their value
+	OrgPolicy func() (*policy.OrgPolicy, error)
+
+	Key   string
+	Value string
+
+	// Confirm overrides the policy rules that protect the key
+	Confirm bool
 }
 
 // NewCmdConfigSet creates the config set command
@@ -36,6 +46,20 @@ func NewCmdConfigSet(f *cmdutil.Factory, runF func(*SetOptions) error) *cobra.Co
 	opts := &SetOptions{
 		IO:     f.IOStreams,
 		Config: f.Config,
+		Policy: func() (*policy.Enforcer, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return nil, err
+			}
+			return policy.Load(cfg, zenDirectory(f))
+		},
+		OrgPolicy: func() (*policy.OrgPolicy, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return nil, err
+			}
+			return policy.LoadOrg(cfg, zenDirectory(f))
+		},
 	}
 
 	cmd := &cobra.Command{
@@ -49,19 +73,36 @@ Configuration keys use dot notation to access nested values:
 - cli.no_color (true, false)
 - cli.verbose (true, false)
 - cli.output_format (text, json, yaml)
+- cli.update_check (true, false)
+- cli.offline (true, false)
+- ui.theme (default, high-contrast, colorblind)
+- ui.accessible (true, false; text labels instead of symbols and spinners)
 - workspace.root (directory path)
 - workspace.config_file (filename)
 - development.debug (true, false)
 - development.profile (true, false)
+- network.proxy (proxy URL, e.g. http://proxy.example.com:8080)
+- network.ca_bundle (PEM file of extra trusted certificate authorities)
+- network.insecure_skip_verify (true, false; disables TLS verification)
 
 The configuration is saved to the first available location:
 1. .zen/config.yaml (current directory)
-2. ~/.zen/config.yaml (user home directory)`,
+2. config.yaml in the user config directory (~/.config/zen on Linux)
+
+Keys can be protected by the config.set rules in the policy section of the
+configuration. Use --confirm to override them; the override is recorded in
+the audit log.
+
+Keys protected by the organization policy bundle of the asset repository keep
+the organization's value unless the bundle makes them overridable; --confirm
+does not override them.`,
 		Example: heredoc.Doc(`
 			$ zen config set log_level debug
 			$ zen config set cli.output_format json
 			$ zen config set cli.no_color true
+			$ zen config set ui.theme high-contrast
 			$ zen config set workspace.root /path/to/workspace
+			$ zen config set task.task_source jira --confirm
 		`),
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -76,6 +117,8 @@ The configuration is saved to the first available location:
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.Confirm, "confirm", false, "Override the policy rules that protect the key")
+
 	return cmd
 }
 
@@ -86,6 +129,26 @@ func setRun(opts *SetOptions) error {
 		return fmt.Errorf("invalid config key %s: %w", opts.Key, err)
 	}
 
+	// Protected keys can only be set by the users and roles policy allows
+	if opts.Policy != nil {
+		enforcer, err := opts.Policy()
+		if err != nil {
+			return fmt.Errorf("failed to load policy: %w", err)
+		}
+		if err := enforcer.Authorize(policy.Request{Operation: policy.OpConfigSet, Target: opts.Key, Confirm: opts.Confirm}); err != nil {
+			return err
+		}
+	}
+	if opts.OrgPolicy != nil {
+		org, err := opts.OrgPolicy()
+		if err != nil {
+			return fmt.Errorf("failed to load organization policy: %w", err)
+		}
+		if err := org.CheckSetting(opts.Key, opts.Value); err != nil {
+			return err
+		}
+	}
+
 	// Get central config manager
 	cfg, err := opts.Config()
 	if err != nil {
@@ -103,7 +166,7 @@ func setRun(opts *SetOptions) error {
 			return fmt.Errorf("failed to save core config: %w", err)
 		}
 
-		fmt.Fprintf(opts.IO.Out, "✓ Set %s to %q\n", opts.Key, opts.Value)
+		fmt.Fprintf(opts.IO.Out, "%s Set %s to %q\n", opts.IO.SuccessIcon(), opts.Key, opts.Value)
 		return nil
 	}
 
@@ -119,10 +182,14 @@ func setRun(opts *SetOptions) error {
 		return setComponentConfig(cfg, cli.ConfigParser{}, field, opts.Value, opts.IO)
 	case "development":
 		return setComponentConfig(cfg, development.ConfigParser{}, field, opts.Value, opts.IO)
+	case "network":
+		return setComponentConfig(cfg, httpx.ConfigParser{}, field, opts.Value, opts.IO)
 	case "task":
 		return setComponentConfig(cfg, task.ConfigParser{}, field, opts.Value, opts.IO)
 	case "templates":
 		return setComponentConfig(cfg, template.ConfigParser{}, field, opts.Value, opts.IO)
+	case "ui":
+		return setComponentConfig(cfg, cli.UIConfigParser{}, field, opts.Value, opts.IO)
 	case "workspace":
 		return setComponentConfig(cfg, workspace.ConfigParser{}, field, opts.Value, opts.IO)
 	default:
@@ -130,6 +197,14 @@ func setRun(opts *SetOptions) error {
 	}
 }
 
+// zenDirectory returns the .zen directory of the workspace
+func zenDirectory(f *cmdutil.Factory) string {
+	if ws, err := f.WorkspaceManager(); err == nil {
+		return ws.ZenDirectory()
+	}
+	return ".zen"
+}
+
 // setComponentConfig sets a field in a component configuration using the standard API
 func setComponentConfig[T config.Configurable](cfg *config.Config, parser config.ConfigParser[T], field, value string, io *iostreams.IOStreams) error {
 	// Get current component config
@@ -155,7 +230,7 @@ func setComponentConfig[T config.Configurable](cfg *config.Config, parser config
 		return fmt.Errorf("failed to save %s config: %w", parser.Section(), err)
 	}
 
-	fmt.Fprintf(io.Out, "✓ Set %s.%s to %q\n", parser.Section(), field, value)
+	fmt.Fprintf(io.Out, "%s Set %s.%s to %q\n", io.SuccessIcon(), parser.Section(), field, value)
 	return nil
 }
 
@@ -251,6 +326,15 @@ func updateConfigField(configStruct interface{}, fieldName, value string) (inter
 	structFieldName := toPascalCase(fieldName)
 
 	field := newStruct.FieldByName(structFieldName)
+	if !field.IsValid() {
+		// Fields named with initialisms, such as CABundle for ca_bundle, are found by tag
+		for i := 0; i < newStruct.NumField(); i++ {
+			if name, _, _ := strings.Cut(newStruct.Type().Field(i).Tag.Get("mapstructure"), ","); name == fieldName {
+				field = newStruct.Field(i)
+				break
+			}
+		}
+	}
 	if !field.IsValid() {
 		return nil, fmt.Errorf("field %s not found in config", fieldName)
 	}
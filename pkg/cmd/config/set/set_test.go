@@ -2,11 +2,15 @@ package set
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/policy"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -75,6 +79,65 @@ func TestSetRun_CoreConfig(t *testing.T) {
 	}
 }
 
+func TestSetRun_Policy(t *testing.T) {
+	// setRun saves the config file of the workspace in the working directory
+	t.Chdir(t.TempDir())
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+	enforcer := policy.NewEnforcer(policy.Config{
+		Rules: []policy.Rule{{Operation: policy.OpConfigSet, Keys: []string{"network.*"}, Roles: []string{"lead"}}},
+	}, policy.Actor{User: "ben", Roles: []string{"member"}}, auditLog)
+
+	newOptions := func(confirm bool) *SetOptions {
+		return &SetOptions{
+			IO: iostreams.Test(),
+			Config: func() (*config.Config, error) {
+				return config.LoadDefaults(), nil
+			},
+			Policy: func() (*policy.Enforcer, error) {
+				return enforcer, nil
+			},
+			Key:     "network.insecure_skip_verify",
+			Value:   "true",
+			Confirm: confirm,
+		}
+	}
+
+	err := setRun(newOptions(false))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config.set network.insecure_skip_verify is restricted by policy for ben")
+	assert.NoFileExists(t, auditLog)
+
+	require.NoError(t, setRun(newOptions(true)))
+	data, err := os.ReadFile(auditLog)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"target":"network.insecure_skip_verify"`)
+}
+
+func TestSetRun_OrgPolicy(t *testing.T) {
+	// setRun saves the config file of the workspace in the working directory
+	t.Chdir(t.TempDir())
+	bundle, err := policy.ParseBundle([]byte("name: acme\nprotected_keys:\n  network.insecure_skip_verify: \"false\"\n"))
+	require.NoError(t, err)
+	org := policy.NewOrgPolicy(bundle, nil, nil)
+
+	opts := &SetOptions{
+		IO: iostreams.Test(),
+		Config: func() (*config.Config, error) {
+			return config.LoadDefaults(), nil
+		},
+		OrgPolicy: func() (*policy.OrgPolicy, error) {
+			return org, nil
+		},
+		Key:     "network.insecure_skip_verify",
+		Value:   "true",
+		Confirm: true,
+	}
+
+	err = setRun(opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "network.insecure_skip_verify is protected by organization policy acme")
+}
+
 func TestParseConfigKey(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -144,3 +207,12 @@ func TestNewCmdConfigSet(t *testing.T) {
 	assert.Equal(t, "Update configuration with a value for the given key", cmd.Short)
 	assert.Contains(t, cmd.Long, "Set a configuration value")
 }
+
+func TestUpdateConfigField_ByTag(t *testing.T) {
+	updated, err := updateConfigField(httpx.DefaultConfig(), "ca_bundle", "/etc/zen/ca.pem")
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/zen/ca.pem", updated.(httpx.Config).CABundle)
+
+	_, err = updateConfigField(httpx.DefaultConfig(), "ca_path", "/etc/zen")
+	assert.Error(t, err)
+}
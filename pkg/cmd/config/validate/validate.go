@@ -0,0 +1,232 @@
+package validate
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/internal/development"
+	"github.com/daddia/zen/internal/workspace"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/cache"
+	"github.com/daddia/zen/pkg/cli"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/hooks"
+	"github.com/daddia/zen/pkg/identity"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/label"
+	"github.com/daddia/zen/pkg/notify"
+	"github.com/daddia/zen/pkg/policy"
+	"github.com/daddia/zen/pkg/release"
+	"github.com/daddia/zen/pkg/secretref"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/team"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/spf13/cobra"
+)
+
+// ValidateOptions contains options for the config validate command
+type ValidateOptions struct {
+	IO           *iostreams.IOStreams
+	Config       func() (*config.Config, error)
+	OrgPolicy    func(cfg *config.Config) (*policy.OrgPolicy, error)
+	OutputFormat string
+	Template     string
+	JQ           string
+}
+
+// sectionResult is the outcome of validating a configuration section
+type sectionResult struct {
+	Section string `json:"section" yaml:"section"`
+	Valid   bool   `json:"valid" yaml:"valid"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// policyResult is the organization policy bundle installed in the workspace, with the
+// local overrides of it
+type policyResult struct {
+	Name      string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Path      string            `json:"path,omitempty" yaml:"path,omitempty"`
+	Overrides []policy.Override `json:"overrides" yaml:"overrides"`
+	Error     string            `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// validateReport is the outcome of validating the configuration
+type validateReport struct {
+	Valid    bool            `json:"valid" yaml:"valid"`
+	Sections []sectionResult `json:"sections" yaml:"sections"`
+	Policy   *policyResult   `json:"policy,omitempty" yaml:"policy,omitempty"`
+}
+
+// sections validate the configuration sections of the workspace
+var sections = []func(cfg *config.Config) sectionResult{
+	section(assets.ConfigParser{}),
+	section(auth.ConfigParser{}),
+	section(cache.ConfigParser{}),
+	section(cli.ConfigParser{}),
+	section(development.ConfigParser{}),
+	section(git.ConfigParser{}),
+	section(hooks.ConfigParser{}),
+	section(identity.ConfigParser{}),
+	section(label.ConfigParser{}),
+	section(httpx.ConfigParser{}),
+	section(notify.ConfigParser{}),
+	section(policy.ConfigParser{}),
+	section(release.ConfigParser{}),
+	section(secretref.ConfigParser{}),
+	section(task.ConfigParser{}),
+	section(team.ConfigParser{}),
+	section(template.ConfigParser{}),
+	section(cli.UIConfigParser{}),
+	section(workspace.ConfigParser{}),
+}
+
+// NewCmdConfigValidate creates the config validate command
+func NewCmdConfigValidate(f *cmdutil.Factory, runF func(*ValidateOptions) error) *cobra.Command {
+	opts := &ValidateOptions{
+		IO:     f.IOStreams,
+		Config: f.Config,
+		OrgPolicy: func(cfg *config.Config) (*policy.OrgPolicy, error) {
+			zenDir := ".zen"
+			if ws, err := f.WorkspaceManager(); err == nil {
+				zenDir = ws.ZenDirectory()
+			}
+			return policy.LoadOrg(cfg, zenDir)
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration and its organization policy overrides",
+		Long: heredoc.Doc(`
+			Validate each section of the configuration, and report the organization
+			policy bundle installed from the asset repository by 'zen init' and
+			'zen assets sync'.
+
+			Bundles set the quality gates tasks must pass, configuration keys workspaces
+			must keep, and naming rules for task IDs, branches and labels. Workspaces may
+			override the items the bundle makes overridable, in the overrides of the
+			policy section:
+
+			  policy:
+			    overrides:
+			      naming:
+			        branch: "^[a-z]+/"
+			      gates:
+			        security-review: optional
+
+			Each override is reported as allowed or not, as are protected keys set to
+			another value than the organization's. Naming and gate overrides that are not
+			allowed are ignored, and the organization's rule applies. Overrides that are
+			not allowed fail validation, as do invalid sections.
+		`),
+		Example: heredoc.Doc(`
+			# Validate the configuration
+			zen config validate
+
+			# List the overrides of organization policy as JSON
+			zen config validate --output json --jq '.policy.overrides'
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return validateRun(opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func validateRun(opts *ValidateOptions) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	report := validateReport{Valid: true}
+	failed := 0
+	for _, validate := range sections {
+		result := validate(cfg)
+		if !result.Valid {
+			failed++
+		}
+		report.Sections = append(report.Sections, result)
+	}
+
+	org, err := opts.OrgPolicy(cfg)
+	if err != nil {
+		failed++
+		report.Policy = &policyResult{Overrides: []policy.Override{}, Error: err.Error()}
+	} else if org != nil {
+		report.Policy = &policyResult{Name: org.Name(), Path: org.Path, Overrides: org.Overrides}
+		if report.Policy.Overrides == nil {
+			report.Policy.Overrides = []policy.Override{}
+		}
+		failed += len(org.Violations())
+	}
+	report.Valid = failed == 0
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	if err := renderer.Render(report, func(w io.Writer) error {
+		return displayReport(w, opts.IO, report)
+	}); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return &cmdutil.ExitCodeError{Code: cmdutil.ExitError, Err: fmt.Errorf("configuration has %d problems", failed)}
+	}
+	return nil
+}
+
+// section returns the validation of the configuration section of parser
+func section[T config.Configurable](parser config.ConfigParser[T]) func(cfg *config.Config) sectionResult {
+	return func(cfg *config.Config) sectionResult {
+		result := sectionResult{Section: parser.Section(), Valid: true}
+		if _, err := config.GetConfig(cfg, parser); err != nil {
+			result.Valid, result.Error = false, err.Error()
+		}
+		return result
+	}
+}
+
+func displayReport(w io.Writer, streams *iostreams.IOStreams, report validateReport) error {
+	for _, result := range report.Sections {
+		if !result.Valid {
+			fmt.Fprintf(w, "%s %s: %s\n", streams.ColorError(iostreams.SymbolFailure), streams.ColorBold(result.Section), result.Error)
+		}
+	}
+
+	switch {
+	case report.Policy == nil:
+		fmt.Fprintf(w, "%s No organization policy installed\n", streams.ColorNeutral(iostreams.SymbolNeutral))
+	case report.Policy.Error != "":
+		fmt.Fprintf(w, "%s %s: %s\n", streams.ColorError(iostreams.SymbolFailure), streams.ColorBold("Organization policy"), report.Policy.Error)
+	default:
+		fmt.Fprintf(w, "%s Organization policy %s (%s)\n", streams.ColorSuccess(iostreams.SymbolSuccess), report.Policy.Name, report.Policy.Path)
+		for _, override := range report.Policy.Overrides {
+			if override.Allowed {
+				fmt.Fprintf(w, "  %s %s overridden: %q instead of %q\n", streams.ColorWarning(iostreams.SymbolAlert), override.Item, override.Local, override.Org)
+			} else {
+				fmt.Fprintf(w, "  %s %s is not overridable: set to %q, organization policy requires %q\n", streams.ColorError(iostreams.SymbolFailure), override.Item, override.Local, override.Org)
+			}
+		}
+	}
+
+	if report.Valid {
+		fmt.Fprintf(w, "%s Configuration is valid (%d sections)\n", streams.ColorSuccess(iostreams.SymbolSuccess), len(report.Sections))
+	}
+	return nil
+}
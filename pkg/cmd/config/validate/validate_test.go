@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBundle = `
+name: acme
+protected_keys:
+  task.task_source: jira
+naming:
+  branch: "^(feature|fix)/"
+  task_id: "^ACME-"
+overridable:
+  - naming.branch
+`
+
+func newTestOptions(t *testing.T, overrides, settings map[string]string) (*ValidateOptions, *bytes.Buffer) {
+	t.Helper()
+	streams := iostreams.Test()
+	opts := &ValidateOptions{
+		IO: streams,
+		Config: func() (*config.Config, error) {
+			return config.LoadDefaults(), nil
+		},
+		OrgPolicy: func(cfg *config.Config) (*policy.OrgPolicy, error) {
+			return nil, nil
+		},
+	}
+	if overrides != nil || settings != nil {
+		bundle, err := policy.ParseBundle([]byte(testBundle))
+		require.NoError(t, err)
+		org := policy.NewOrgPolicy(bundle, overrides, settings)
+		org.Path = ".zen/library/policy.yaml"
+		opts.OrgPolicy = func(cfg *config.Config) (*policy.OrgPolicy, error) { return org, nil }
+	}
+	return opts, streams.Out.(*bytes.Buffer)
+}
+
+func TestValidateRun(t *testing.T) {
+	opts, out := newTestOptions(t, nil, nil)
+
+	require.NoError(t, validateRun(opts))
+	assert.Contains(t, out.String(), "No organization policy installed")
+	assert.Contains(t, out.String(), "Configuration is valid")
+}
+
+func TestValidateRun_Overrides(t *testing.T) {
+	opts, out := newTestOptions(t,
+		map[string]string{"naming.branch": "^[a-z]+/", "naming.task_id": "^PROJ-"},
+		map[string]string{"task.task_source": "jira"})
+
+	err := validateRun(opts)
+	var exitErr *cmdutil.ExitCodeError
+	require.True(t, errors.As(err, &exitErr), "got %v", err)
+	assert.EqualError(t, exitErr.Err, "configuration has 1 problems")
+
+	assert.Contains(t, out.String(), "Organization policy acme (.zen/library/policy.yaml)")
+	assert.Contains(t, out.String(), `naming.branch overridden: "^[a-z]+/" instead of "^(feature|fix)/"`)
+	assert.Contains(t, out.String(), `naming.task_id is not overridable: set to "^PROJ-", organization policy requires "^ACME-"`)
+	assert.NotContains(t, out.String(), "task.task_source", "protected keys set to the organization's value are not overrides")
+	assert.NotContains(t, out.String(), "Configuration is valid")
+}
+
+func TestValidateRun_JSON(t *testing.T) {
+	opts, out := newTestOptions(t, nil, map[string]string{"task.task_source": "github"})
+	opts.OutputFormat = "json"
+
+	require.Error(t, validateRun(opts))
+
+	var report validateReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.False(t, report.Valid)
+	require.NotNil(t, report.Policy)
+	assert.Equal(t, []policy.Override{{Item: "config.task.task_source", Org: "jira", Local: "github"}}, report.Policy.Overrides)
+}
@@ -0,0 +1,191 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// DashboardOptions contains options for the dashboard command
+type DashboardOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	AssetClient      func() (assets.AssetClientInterface, error)
+	Factory          *cmdutil.Factory
+
+	Filter    string
+	View      string
+	SavedView string
+}
+
+// TaskService is the subset of the task manager used by the dashboard
+type TaskService interface {
+	ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error)
+	ProgressTask(ctx context.Context, taskID string, stage string) error
+	SyncTask(ctx context.Context, taskID string, opts *task.SyncOptions) (*task.SyncResult, error)
+}
+
+// NewCmdDashboard creates the dashboard command
+func NewCmdDashboard(f *cmdutil.Factory) *cobra.Command {
+	opts := &DashboardOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		AssetClient:      f.AssetClient,
+		Factory:          f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Open the interactive workspace dashboard",
+		Long: heredoc.Doc(`
+			Open an interactive terminal dashboard for the current workspace.
+
+			The dashboard shows every task in the workspace with its Zenflow stage,
+			status and sync state, alongside the asset cache status. Tasks can be
+			viewed as a filterable list or as a kanban board grouped by stage.
+
+			Key bindings:
+			  ↑/k ↓/j     Move selection
+			  ←/h →/l     Move between stage columns (kanban view)
+			  tab         Toggle list and kanban views
+			  /           Filter tasks by ID, title, owner or status
+			  enter       Open the task index in $EDITOR
+			  p           Progress the task to the next stage
+			  s           Sync the task with its external sources
+			  r           Refresh tasks and cache status
+			  q           Quit
+
+			--saved-view shows only the tasks of a view saved with 'zen task view save'.
+
+			The dashboard requires an interactive terminal.
+		`),
+		Example: heredoc.Doc(`
+			# Open the dashboard
+			zen dashboard
+
+			# Start in the kanban view
+			zen dashboard --view kanban
+
+			# Start with a filter applied
+			zen dashboard --filter PROJ-
+
+			# Show the tasks of a saved view
+			zen dashboard --saved-view my-open
+		`),
+		Args:    cobra.NoArgs,
+		GroupID: "core",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return dashboardRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Initial task filter")
+	cmd.Flags().StringVar(&opts.View, "view", viewList, "Initial view (list|kanban)")
+	cmd.Flags().StringVar(&opts.SavedView, "saved-view", "", "Show the tasks of a saved view of the task list")
+
+	return cmd
+}
+
+func dashboardRun(ctx context.Context, opts *DashboardOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if opts.View != viewList && opts.View != viewKanban {
+		return &cmdutil.FlagError{Err: fmt.Errorf("invalid view %q: must be one of list, kanban", opts.View)}
+	}
+
+	if opts.IO.IsAccessible() {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "dashboard is not available in accessible mode",
+			Details: "use 'zen status' and 'zen task list', which read well with screen readers",
+		}
+	}
+	if !opts.IO.IsStdinTTY() || !opts.IO.IsStdoutTTY() {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "dashboard requires an interactive terminal",
+			Details: "use 'zen status' or '--output json' for non-interactive output",
+		}
+	}
+
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	model := NewModel(ctx, opts.IO, task.NewManager(opts.Factory), opts.AssetClient)
+	model.view = opts.View
+	model.filter = opts.Filter
+	if opts.SavedView != "" {
+		filter, err := savedViewFilter(opts)
+		if err != nil {
+			return err
+		}
+		model.taskFilter, model.savedView = filter, opts.SavedView
+	}
+
+	program := tea.NewProgram(model,
+		tea.WithContext(ctx),
+		tea.WithInput(opts.IO.In),
+		tea.WithOutput(opts.IO.Out),
+		tea.WithAltScreen(),
+	)
+
+	if _, err := program.Run(); err != nil {
+		if err == tea.ErrProgramKilled {
+			return cmdutil.ErrSilent
+		}
+		return fmt.Errorf("dashboard failed: %w", err)
+	}
+
+	return nil
+}
+
+// savedViewFilter returns the filter of the saved view named by --saved-view
+func savedViewFilter(opts *DashboardOptions) (*task.TaskFilter, error) {
+	cfg, err := opts.Factory.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	tasks, err := config.GetConfig(cfg, task.ConfigParser{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task config: %w", err)
+	}
+	view, ok := tasks.View(opts.SavedView)
+	if !ok {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNotFound,
+			Message: fmt.Sprintf("view not found: %s", opts.SavedView),
+			Details: "save one with 'zen task view save <name> --filter <conditions>'",
+		}
+	}
+	conditions, err := task.ParseFilter(view.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid view %s: %w", opts.SavedView, err)
+	}
+	return &task.TaskFilter{Conditions: conditions}, nil
+}
@@ -0,0 +1,234 @@
+package dashboard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTaskService struct {
+	tasks      []*task.Task
+	filter     *task.TaskFilter
+	progressed []string
+	synced     []string
+}
+
+func (f *fakeTaskService) ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error) {
+	f.filter = filter
+	return f.tasks, nil
+}
+
+func (f *fakeTaskService) ProgressTask(ctx context.Context, taskID string, stage string) error {
+	f.progressed = append(f.progressed, taskID)
+	return nil
+}
+
+func (f *fakeTaskService) SyncTask(ctx context.Context, taskID string, opts *task.SyncOptions) (*task.SyncResult, error) {
+	f.synced = append(f.synced, taskID)
+	return &task.SyncResult{TaskID: taskID, Source: "jira", Success: true}, nil
+}
+
+func newTestModel(t *testing.T) (*Model, *fakeTaskService) {
+	t.Helper()
+
+	svc := &fakeTaskService{
+		tasks: []*task.Task{
+			{ID: "PROJ-2", Title: "Checkout redesign", Status: "in_progress", Owner: "alex", CurrentStage: "04-design"},
+			{ID: "PROJ-1", Title: "Login flow", Status: "proposed", Owner: "sam", CurrentStage: "01-align",
+				Sources: map[string]*task.TaskSource{"jira": {System: "jira", LastSync: time.Now().Add(-time.Hour)}}},
+			{ID: "PROJ-3", Title: "Search indexing", Status: "proposed", Owner: "alex", CurrentStage: "01-align"},
+		},
+	}
+
+	m := NewModel(context.Background(), iostreams.Test(), svc, nil)
+	m.Update(svc.loadAll())
+	return m, svc
+}
+
+func (f *fakeTaskService) loadAll() tea.Msg {
+	return tasksLoadedMsg{tasks: f.tasks}
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestNewCmdDashboard(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	cmd := NewCmdDashboard(f)
+
+	assert.Equal(t, "dashboard", cmd.Use)
+	assert.Equal(t, "core", cmd.GroupID)
+	assert.NotEmpty(t, cmd.Long)
+
+	viewFlag := cmd.Flags().Lookup("view")
+	require.NotNil(t, viewFlag)
+	assert.Equal(t, viewList, viewFlag.DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("filter"))
+}
+
+func TestDashboardRun_InvalidView(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	opts := &DashboardOptions{IO: f.IOStreams, WorkspaceManager: f.WorkspaceManager, Factory: f, View: "grid"}
+
+	err := dashboardRun(context.Background(), opts)
+	require.Error(t, err)
+
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, err, &flagErr)
+}
+
+func TestDashboardRun_RequiresTTY(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	opts := &DashboardOptions{IO: f.IOStreams, WorkspaceManager: f.WorkspaceManager, Factory: f, View: viewList}
+
+	err := dashboardRun(context.Background(), opts)
+	require.Error(t, err)
+
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+}
+
+func TestDashboardRun_Accessible(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	f.IOStreams.SetAccessible(true)
+	opts := &DashboardOptions{IO: f.IOStreams, WorkspaceManager: f.WorkspaceManager, Factory: f, View: viewList}
+
+	err := dashboardRun(context.Background(), opts)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Contains(t, zenErr.Message, "accessible mode")
+}
+
+func TestModel_LoadSortsTasks(t *testing.T) {
+	m, _ := newTestModel(t)
+
+	require.Len(t, m.visible, 3)
+	assert.Equal(t, "PROJ-1", m.visible[0].ID)
+	assert.Equal(t, "PROJ-1", m.Selected().ID)
+}
+
+func TestModel_Navigation(t *testing.T) {
+	m, _ := newTestModel(t)
+
+	m.Update(key("j"))
+	assert.Equal(t, "PROJ-2", m.Selected().ID)
+
+	m.Update(key("j"))
+	m.Update(key("j"))
+	assert.Equal(t, "PROJ-3", m.Selected().ID, "cursor should stop at the last task")
+
+	m.Update(key("k"))
+	assert.Equal(t, "PROJ-2", m.Selected().ID)
+}
+
+func TestModel_Filter(t *testing.T) {
+	m, _ := newTestModel(t)
+
+	m.Update(key("/"))
+	assert.True(t, m.filtering)
+
+	for _, r := range "alex" {
+		m.Update(key(string(r)))
+	}
+	m.Update(key("enter"))
+
+	assert.False(t, m.filtering)
+	require.Len(t, m.visible, 2)
+	assert.Equal(t, "PROJ-2", m.visible[0].ID)
+	assert.Equal(t, "PROJ-3", m.visible[1].ID)
+
+	m.Update(key("esc"))
+	assert.Empty(t, m.filter)
+	assert.Len(t, m.visible, 3)
+}
+
+func TestModel_SavedView(t *testing.T) {
+	m, svc := newTestModel(t)
+	conditions, err := task.ParseFilter("owner=me,status!=completed")
+	require.NoError(t, err)
+	m.taskFilter, m.savedView = &task.TaskFilter{Conditions: conditions}, "my-open"
+
+	m.loadTasks()()
+	assert.Equal(t, conditions, svc.filter.Conditions, "only the tasks of the view are loaded")
+	assert.Contains(t, m.View(), "view my-open")
+}
+
+func TestModel_KanbanView(t *testing.T) {
+	m, _ := newTestModel(t)
+
+	m.Update(key("tab"))
+	assert.Equal(t, viewKanban, m.view)
+
+	assert.Len(t, m.columnTasks(0), 2)
+	assert.Equal(t, "PROJ-1", m.Selected().ID)
+
+	m.Update(key("j"))
+	assert.Equal(t, "PROJ-3", m.Selected().ID)
+
+	m.Update(key("l"))
+	assert.Nil(t, m.Selected(), "discover column is empty")
+
+	m.Update(key("l"))
+	m.Update(key("l"))
+	assert.Equal(t, "PROJ-2", m.Selected().ID)
+
+	view := m.View()
+	assert.Contains(t, view, "Align (2)")
+	assert.Contains(t, view, "Design (1)")
+}
+
+func TestModel_Actions(t *testing.T) {
+	m, svc := newTestModel(t)
+
+	_, cmd := m.Update(key("p"))
+	require.NotNil(t, cmd)
+	msg := cmd()
+	assert.Equal(t, []string{"PROJ-1"}, svc.progressed)
+
+	_, cmd = m.Update(msg)
+	require.NotNil(t, cmd, "successful action should reload tasks")
+	assert.Contains(t, m.status, "PROJ-1 progressed")
+
+	_, cmd = m.Update(key("s"))
+	require.NotNil(t, cmd)
+	m.Update(cmd())
+	assert.Equal(t, []string{"PROJ-1"}, svc.synced)
+	assert.Contains(t, m.status, "synced with jira")
+}
+
+func TestModel_ListView(t *testing.T) {
+	m, _ := newTestModel(t)
+
+	view := m.View()
+	assert.Contains(t, view, "PROJ-1")
+	assert.Contains(t, view, "Login flow")
+	assert.Contains(t, view, "Align")
+	assert.Contains(t, view, "jira (1h0m0s ago)")
+	assert.Contains(t, view, "local")
+	assert.Contains(t, view, "cache status unknown")
+}
+
+func TestSyncSummary(t *testing.T) {
+	assert.Equal(t, "local", syncSummary(&task.Task{}))
+	assert.Equal(t, "github,jira (never)", syncSummary(&task.Task{
+		Sources: map[string]*task.TaskSource{"jira": {}, "github": {}},
+	}))
+}
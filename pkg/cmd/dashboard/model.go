@@ -0,0 +1,505 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+)
+
+const (
+	viewList   = "list"
+	viewKanban = "kanban"
+)
+
+// tasksLoadedMsg carries the result of loading workspace tasks
+type tasksLoadedMsg struct {
+	tasks []*task.Task
+	err   error
+}
+
+// cacheLoadedMsg carries the result of reading asset cache status
+type cacheLoadedMsg struct {
+	info *assets.CacheInfo
+	err  error
+}
+
+// actionDoneMsg reports the outcome of a task action
+type actionDoneMsg struct {
+	message string
+	err     error
+}
+
+// Model is the bubbletea model backing the dashboard
+type Model struct {
+	ctx         context.Context
+	io          *iostreams.IOStreams
+	tasks       TaskService
+	assetClient func() (assets.AssetClientInterface, error)
+
+	all       []*task.Task
+	visible   []*task.Task
+	cacheInfo *assets.CacheInfo
+	cacheErr  error
+
+	// taskFilter selects the tasks loaded, those of the saved view savedView
+	taskFilter *task.TaskFilter
+	savedView  string
+
+	view      string
+	filter    string
+	filtering bool
+	cursor    int
+	column    int
+	row       int
+
+	status  string
+	loading bool
+	width   int
+	height  int
+}
+
+// NewModel creates a dashboard model
+func NewModel(ctx context.Context, io *iostreams.IOStreams, tasks TaskService, assetClient func() (assets.AssetClientInterface, error)) *Model {
+	return &Model{
+		ctx:         ctx,
+		io:          io,
+		tasks:       tasks,
+		assetClient: assetClient,
+		view:        viewList,
+		loading:     true,
+	}
+}
+
+// Init loads tasks and cache status
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(m.loadTasks(), m.loadCache())
+}
+
+// Update handles messages and key presses
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case tasksLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.status = fmt.Sprintf("failed to load tasks: %v", msg.err)
+			return m, nil
+		}
+		m.all = msg.tasks
+		sort.Slice(m.all, func(i, j int) bool { return m.all[i].ID < m.all[j].ID })
+		m.applyFilter()
+		return m, nil
+
+	case cacheLoadedMsg:
+		m.cacheInfo, m.cacheErr = msg.info, msg.err
+		return m, nil
+
+	case actionDoneMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("%s: %v", msg.message, msg.err)
+			return m, nil
+		}
+		m.status = msg.message
+		return m, m.loadTasks()
+
+	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+		return m.updateKeys(msg)
+	}
+
+	return m, nil
+}
+
+func (m *Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter = ""
+	case tea.KeyBackspace:
+		if len(m.filter) > 0 {
+			runes := []rune(m.filter)
+			m.filter = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	}
+	m.applyFilter()
+	return m, nil
+}
+
+func (m *Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "/":
+		m.filtering = true
+	case "esc":
+		m.filter = ""
+		m.applyFilter()
+	case "tab":
+		if m.view == viewList {
+			m.view = viewKanban
+		} else {
+			m.view = viewList
+		}
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "left", "h":
+		m.moveColumn(-1)
+	case "right", "l":
+		m.moveColumn(1)
+	case "r":
+		m.status = "refreshing..."
+		return m, tea.Batch(m.loadTasks(), m.loadCache())
+	case "enter":
+		if t := m.Selected(); t != nil {
+			return m, m.openTask(t)
+		}
+	case "p":
+		if t := m.Selected(); t != nil {
+			m.status = fmt.Sprintf("progressing %s...", t.ID)
+			return m, m.progressTask(t)
+		}
+	case "s":
+		if t := m.Selected(); t != nil {
+			m.status = fmt.Sprintf("syncing %s...", t.ID)
+			return m, m.syncTask(t)
+		}
+	}
+	return m, nil
+}
+
+// Selected returns the currently highlighted task
+func (m *Model) Selected() *task.Task {
+	if m.view == viewKanban {
+		col := m.columnTasks(m.column)
+		if m.row >= 0 && m.row < len(col) {
+			return col[m.row]
+		}
+		return nil
+	}
+	if m.cursor >= 0 && m.cursor < len(m.visible) {
+		return m.visible[m.cursor]
+	}
+	return nil
+}
+
+func (m *Model) move(delta int) {
+	if m.view == viewKanban {
+		m.row = clamp(m.row+delta, 0, len(m.columnTasks(m.column))-1)
+		return
+	}
+	m.cursor = clamp(m.cursor+delta, 0, len(m.visible)-1)
+}
+
+func (m *Model) moveColumn(delta int) {
+	if m.view != viewKanban {
+		return
+	}
+	m.column = clamp(m.column+delta, 0, len(task.WorkflowStages)-1)
+	m.row = clamp(m.row, 0, len(m.columnTasks(m.column))-1)
+}
+
+// applyFilter recomputes the visible task list from the current filter
+func (m *Model) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(m.filter))
+	m.visible = m.visible[:0]
+	for _, t := range m.all {
+		if query == "" || matchesQuery(t, query) {
+			m.visible = append(m.visible, t)
+		}
+	}
+	m.cursor = clamp(m.cursor, 0, len(m.visible)-1)
+	m.row = clamp(m.row, 0, len(m.columnTasks(m.column))-1)
+}
+
+func matchesQuery(t *task.Task, query string) bool {
+	for _, field := range []string{t.ID, t.Title, t.Owner, t.Status, t.Type, task.StageName(t.CurrentStage)} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnTasks returns the visible tasks in the given stage column
+func (m *Model) columnTasks(column int) []*task.Task {
+	if column < 0 || column >= len(task.WorkflowStages) {
+		return nil
+	}
+	stage := task.WorkflowStages[column]
+	var tasks []*task.Task
+	for _, t := range m.visible {
+		if t.CurrentStage == stage {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+func (m *Model) loadTasks() tea.Cmd {
+	return func() tea.Msg {
+		filter := m.taskFilter
+		if filter == nil {
+			filter = &task.TaskFilter{}
+		}
+		tasks, err := m.tasks.ListTasks(m.ctx, filter)
+		return tasksLoadedMsg{tasks: tasks, err: err}
+	}
+}
+
+func (m *Model) loadCache() tea.Cmd {
+	return func() tea.Msg {
+		if m.assetClient == nil {
+			return cacheLoadedMsg{}
+		}
+		client, err := m.assetClient()
+		if err != nil {
+			return cacheLoadedMsg{err: err}
+		}
+		info, err := client.GetCacheInfo(m.ctx)
+		return cacheLoadedMsg{info: info, err: err}
+	}
+}
+
+func (m *Model) progressTask(t *task.Task) tea.Cmd {
+	id := t.ID
+	return func() tea.Msg {
+		if err := m.tasks.ProgressTask(m.ctx, id, ""); err != nil {
+			return actionDoneMsg{message: fmt.Sprintf("failed to progress %s", id), err: err}
+		}
+		return actionDoneMsg{message: fmt.Sprintf("%s progressed", id)}
+	}
+}
+
+func (m *Model) syncTask(t *task.Task) tea.Cmd {
+	id := t.ID
+	return func() tea.Msg {
+		result, err := m.tasks.SyncTask(m.ctx, id, &task.SyncOptions{
+			Direction:        task.SyncDirectionBidirectional,
+			ConflictStrategy: task.ConflictStrategyTimestamp,
+		})
+		if err != nil {
+			return actionDoneMsg{message: fmt.Sprintf("failed to sync %s", id), err: err}
+		}
+		return actionDoneMsg{message: fmt.Sprintf("%s synced with %s", id, result.Source)}
+	}
+}
+
+func (m *Model) openTask(t *task.Task) tea.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	parts := strings.Fields(editor)
+	args := append(parts[1:], t.IndexPath)
+	cmd := exec.Command(parts[0], args...) // #nosec G204 - editor is user-configured
+	id := t.ID
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return actionDoneMsg{message: fmt.Sprintf("failed to open %s", id), err: err}
+		}
+		return actionDoneMsg{message: fmt.Sprintf("closed %s", id)}
+	})
+}
+
+// View renders the dashboard
+func (m *Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.io.FormatSectionHeader("Zen Dashboard"))
+	if m.savedView != "" {
+		b.WriteString(m.io.ColorNeutral(fmt.Sprintf(" · view %s", m.savedView)))
+	}
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString("Loading tasks...\n")
+		return b.String()
+	}
+
+	if m.view == viewKanban {
+		b.WriteString(m.renderKanban())
+	} else {
+		b.WriteString(m.renderList())
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderCache())
+	b.WriteString("\n")
+
+	if m.filtering {
+		fmt.Fprintf(&b, "Filter: %s█\n", m.filter)
+	} else if m.filter != "" {
+		fmt.Fprintf(&b, "Filter: %s (esc to clear)\n", m.filter)
+	}
+	if m.status != "" {
+		b.WriteString(m.io.ColorNeutral(m.status))
+		b.WriteString("\n")
+	}
+	b.WriteString(m.io.ColorNeutral("↑/↓ move • tab view • / filter • enter open • p progress • s sync • r refresh • q quit"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m *Model) renderList() string {
+	if len(m.visible) == 0 {
+		if m.filter != "" {
+			return "No tasks match the current filter.\n"
+		}
+		return "No tasks found. Create one with 'zen task create <task-id>'.\n"
+	}
+
+	headers := []string{"", "ID", "TITLE", "STAGE", "STATUS", "OWNER", "SYNC"}
+	rows := make([][]string, 0, len(m.visible))
+	for i, t := range m.visible {
+		marker := " "
+		if i == m.cursor {
+			marker = ">"
+		}
+		rows = append(rows, []string{
+			marker,
+			t.ID,
+			truncate(t.Title, 40),
+			task.StageName(t.CurrentStage),
+			t.Status,
+			t.Owner,
+			syncSummary(t),
+		})
+	}
+	return m.io.FormatTable(headers, rows)
+}
+
+func (m *Model) renderKanban() string {
+	const colWidth = 14
+
+	var b strings.Builder
+	columns := make([][]*task.Task, len(task.WorkflowStages))
+	maxRows := 0
+	for i := range task.WorkflowStages {
+		columns[i] = m.columnTasks(i)
+		if len(columns[i]) > maxRows {
+			maxRows = len(columns[i])
+		}
+	}
+
+	for i, stage := range task.WorkflowStages {
+		header := fmt.Sprintf("%-*s", colWidth, truncate(fmt.Sprintf("%s (%d)", task.StageName(stage), len(columns[i])), colWidth))
+		if i == m.column {
+			header = m.io.ColorBold(header)
+		}
+		b.WriteString(header)
+		b.WriteString(" ")
+	}
+	b.WriteString("\n")
+
+	for row := 0; row < maxRows; row++ {
+		for col := range task.WorkflowStages {
+			cell := ""
+			if row < len(columns[col]) {
+				cell = columns[col][row].ID
+			}
+			cell = fmt.Sprintf("%-*s", colWidth, truncate(cell, colWidth))
+			if col == m.column && row == m.row && strings.TrimSpace(cell) != "" {
+				cell = m.io.ColorInfo(cell)
+			}
+			b.WriteString(cell)
+			b.WriteString(" ")
+		}
+		b.WriteString("\n")
+	}
+
+	if maxRows == 0 {
+		b.WriteString("No tasks found.\n")
+	}
+
+	return b.String()
+}
+
+func (m *Model) renderCache() string {
+	switch {
+	case m.cacheErr != nil:
+		return fmt.Sprintf("Assets: %s\n", m.io.ColorWarning("cache unavailable"))
+	case m.cacheInfo == nil:
+		return "Assets: cache status unknown\n"
+	default:
+		lastSync := "never"
+		if !m.cacheInfo.LastSync.IsZero() {
+			lastSync = m.cacheInfo.LastSync.Format("2006-01-02 15:04")
+		}
+		return fmt.Sprintf("Assets: %d cached (%.1f MB), last sync %s\n",
+			m.cacheInfo.AssetCount,
+			float64(m.cacheInfo.TotalSize)/(1024*1024),
+			lastSync)
+	}
+}
+
+// syncSummary describes the sync state of a task's external sources
+func syncSummary(t *task.Task) string {
+	if len(t.Sources) == 0 {
+		return "local"
+	}
+
+	var latest time.Time
+	names := make([]string, 0, len(t.Sources))
+	for name, source := range t.Sources {
+		names = append(names, name)
+		if source != nil && source.LastSync.After(latest) {
+			latest = source.LastSync
+		}
+	}
+	sort.Strings(names)
+
+	if latest.IsZero() {
+		return strings.Join(names, ",") + " (never)"
+	}
+	return fmt.Sprintf("%s (%s ago)", strings.Join(names, ","), time.Since(latest).Round(time.Minute))
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	if max <= 3 {
+		return string(runes[:max])
+	}
+	return string(runes[:max-3]) + "..."
+}
+
+func clamp(v, lo, hi int) int {
+	if hi < lo {
+		return lo
+	}
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
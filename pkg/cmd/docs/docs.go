@@ -0,0 +1,35 @@
+package docs
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/docs/open"
+	"github.com/daddia/zen/pkg/cmd/docs/serve"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDocs creates the docs command with subcommands
+func NewCmdDocs(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs <command>",
+		Short: "Browse the Zen CLI documentation offline",
+		Long: heredoc.Doc(`
+			Browse the Zen CLI reference in a web browser, without network access.
+
+			The reference is built into zen: a page for every command, with its flags
+			and examples, and for every help topic listed by 'zen help topics'.
+		`),
+		Example: heredoc.Doc(`
+			# Browse and search all commands
+			zen docs serve --web
+
+			# Go straight to the page for a command
+			zen docs open task create
+		`),
+	}
+
+	cmd.AddCommand(serve.NewCmdServe(f, nil))
+	cmd.AddCommand(open.NewCmdOpen(f, nil))
+
+	return cmd
+}
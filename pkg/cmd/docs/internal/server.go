@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/daddia/zen/internal/httpserve"
+	"github.com/daddia/zen/pkg/docs"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// ServeOptions configures a documentation server
+type ServeOptions struct {
+	IO      *iostreams.IOStreams
+	Browser func(url string) error
+
+	// Root is the command tree to document
+	Root *cobra.Command
+
+	// Version labels the pages
+	Version string
+
+	// Addr is the address to listen on; port 0 picks a free port
+	Addr string
+
+	// Page is the page whose URL is printed, and opened in the browser when Web is set
+	Page string
+	Web  bool
+}
+
+// Serve generates the documentation of the command tree and serves it until ctx is
+// cancelled, for example by Ctrl+C
+func Serve(ctx context.Context, opts *ServeOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	site, err := docs.NewSite(opts.Root)
+	if err != nil {
+		return fmt.Errorf("failed to generate documentation: %w", err)
+	}
+
+	ln, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to start documentation server: %w", err)
+	}
+
+	url := docs.PageURL(ln.Addr(), opts.Page)
+	fmt.Fprintf(opts.IO.Out, "%s Serving documentation at %s\n", opts.IO.SuccessIcon(), url)
+	fmt.Fprintf(opts.IO.Out, "  Press Ctrl+C to stop\n")
+
+	if opts.Web {
+		if err := opts.Browser(url); err != nil {
+			fmt.Fprintf(opts.IO.ErrOut, "%s Failed to open browser: %v\n", opts.IO.WarningIcon(), err)
+		}
+	}
+
+	return httpserve.Serve(ctx, ln, docs.NewHandler(site, opts.Version))
+}
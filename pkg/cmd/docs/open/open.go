@@ -0,0 +1,121 @@
+package open
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/browser"
+	"github.com/daddia/zen/pkg/cmd/docs/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/docs"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// OpenOptions contains options for the docs open command
+type OpenOptions struct {
+	IO         *iostreams.IOStreams
+	Browser    func(url string) error
+	AppVersion string
+	Root       *cobra.Command
+
+	// Command is the command or help topic to open, e.g. ["assets", "sync"]
+	Command []string
+}
+
+// NewCmdOpen creates the docs open command
+func NewCmdOpen(f *cmdutil.Factory, runF func(*OpenOptions) error) *cobra.Command {
+	opts := &OpenOptions{
+		IO:         f.IOStreams,
+		Browser:    browser.Open,
+		AppVersion: f.AppVersion,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "open [<command>...]",
+		Short: "Open the documentation of a command in the browser",
+		Long: heredoc.Doc(`
+			Open the documentation page of a command or help topic in the browser.
+
+			The command is given without the leading "zen", as you would type it. Without
+			a command the index of all commands opens. The documentation is served from
+			this zen binary on a free local port, like 'zen docs serve', until you press
+			Ctrl+C, so it works offline and matches the installed version.
+		`),
+		Example: heredoc.Doc(`
+			# Open the page for zen assets sync
+			zen docs open assets sync
+
+			# Open the exit codes help topic
+			zen docs open exit-codes
+
+			# Open the command index
+			zen docs open
+		`),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completeCommands(cmd.Root(), args), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Command = args
+			opts.Root = cmd.Root()
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return openRun(cmd.Context(), opts)
+		},
+	}
+
+	return cmd
+}
+
+func openRun(ctx context.Context, opts *OpenOptions) error {
+	page, err := findPage(opts.Root, opts.Command)
+	if err != nil {
+		return err
+	}
+
+	return internal.Serve(ctx, &internal.ServeOptions{
+		IO:      opts.IO,
+		Browser: opts.Browser,
+		Root:    opts.Root,
+		Version: opts.AppVersion,
+		Addr:    "127.0.0.1:0",
+		Page:    page,
+		Web:     true,
+	})
+}
+
+// findPage returns the name of the page documenting the command given by args
+func findPage(root *cobra.Command, args []string) (string, error) {
+	if len(args) == 0 {
+		return docs.IndexName, nil
+	}
+
+	cmd, rest, err := root.Find(args)
+	if err != nil || len(rest) > 0 || cmd == root {
+		return "", fmt.Errorf("unknown command %q; run 'zen docs serve' to browse all commands", strings.Join(args, " "))
+	}
+	if cmd.Hidden {
+		return "", fmt.Errorf("%q has no documentation", cmd.CommandPath())
+	}
+	return docs.PageName(cmd), nil
+}
+
+// completeCommands returns the visible subcommands and help topics of the command given by args
+func completeCommands(root *cobra.Command, args []string) []string {
+	cmd, rest, err := root.Find(args)
+	if err != nil || len(rest) > 0 {
+		return nil
+	}
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() || cmdutil.IsHelpTopic(sub) {
+			names = append(names, sub.Name()+"\t"+sub.Short)
+		}
+	}
+	return names
+}
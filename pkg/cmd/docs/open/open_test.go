@@ -0,0 +1,74 @@
+package open
+
+import (
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRoot() *cobra.Command {
+	root := &cobra.Command{Use: "zen"}
+	assets := &cobra.Command{Use: "assets", Short: "Manage assets"}
+	assets.AddCommand(
+		&cobra.Command{Use: "sync", Short: "Synchronize assets", Run: func(cmd *cobra.Command, args []string) {}},
+		&cobra.Command{Use: "internal", Hidden: true, Run: func(cmd *cobra.Command, args []string) {}},
+	)
+	root.AddCommand(assets, &cobra.Command{
+		Use:         "exit-codes",
+		Short:       "Exit codes returned to scripts",
+		Annotations: map[string]string{cmdutil.HelpTopicAnnotation: "true"},
+	})
+	return root
+}
+
+func TestFindPage(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{args: nil, want: "index"},
+		{args: []string{"assets"}, want: "zen_assets"},
+		{args: []string{"assets", "sync"}, want: "zen_assets_sync"},
+		{args: []string{"exit-codes"}, want: "zen_exit-codes"},
+		{args: []string{"assets", "snyc"}, wantErr: `unknown command "assets snyc"`},
+		{args: []string{"deploy"}, wantErr: `unknown command "deploy"`},
+		{args: []string{"assets", "internal"}, wantErr: "has no documentation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want+tt.wantErr, func(t *testing.T) {
+			page, err := findPage(testRoot(), tt.args)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, page)
+		})
+	}
+}
+
+func TestCompleteCommands(t *testing.T) {
+	root := testRoot()
+	assert.Equal(t, []string{"assets\tManage assets", "exit-codes\tExit codes returned to scripts"}, completeCommands(root, nil))
+	assert.Equal(t, []string{"sync\tSynchronize assets"}, completeCommands(root, []string{"assets"}))
+}
+
+func TestNewCmdOpen(t *testing.T) {
+	var got *OpenOptions
+	cmd := NewCmdOpen(cmdutil.NewTestFactory(iostreams.Test()), func(opts *OpenOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"assets", "sync"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"assets", "sync"}, got.Command)
+	assert.Same(t, cmd, got.Root)
+}
@@ -0,0 +1,96 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/browser"
+	"github.com/daddia/zen/pkg/cmd/docs/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// DefaultPort is the port the documentation is served on unless --port is given
+const DefaultPort = 7070
+
+// ServeOptions contains options for the docs serve command
+type ServeOptions struct {
+	IO         *iostreams.IOStreams
+	Browser    func(url string) error
+	AppVersion string
+	Root       *cobra.Command
+
+	Host string
+	Port int
+	Web  bool
+}
+
+// NewCmdServe creates the docs serve command
+func NewCmdServe(f *cmdutil.Factory, runF func(*ServeOptions) error) *cobra.Command {
+	opts := &ServeOptions{
+		IO:         f.IOStreams,
+		Browser:    browser.Open,
+		AppVersion: f.AppVersion,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the documentation on a local web server",
+		Long: heredoc.Doc(`
+			Serve the Zen CLI reference as a searchable website on this machine.
+
+			The pages are generated from the commands built into this zen binary, so they
+			always match the installed version and work without network access. Every
+			command and help topic has a page, and the search box finds pages containing
+			all of the words searched for.
+
+			The server listens on 127.0.0.1 only and runs until you press Ctrl+C.
+		`),
+		Example: heredoc.Doc(`
+			# Serve the documentation at http://127.0.0.1:7070
+			zen docs serve
+
+			# Serve on another port and open it in the browser
+			zen docs serve --port 8080 --web
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Port < 0 || opts.Port > 65535 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("invalid port %d: must be between 0 and 65535", opts.Port)}
+			}
+			opts.Root = cmd.Root()
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return serveRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Host, "host", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVarP(&opts.Port, "port", "p", DefaultPort, "Port to listen on, or 0 for any free port")
+	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open the documentation in the browser")
+
+	return cmd
+}
+
+func serveRun(ctx context.Context, opts *ServeOptions) error {
+	err := internal.Serve(ctx, &internal.ServeOptions{
+		IO:      opts.IO,
+		Browser: opts.Browser,
+		Root:    opts.Root,
+		Version: opts.AppVersion,
+		Addr:    net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)),
+		Web:     opts.Web,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s Documentation server stopped\n", opts.IO.ColorNeutral("•"))
+	return nil
+}
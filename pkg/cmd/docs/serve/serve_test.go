@@ -0,0 +1,100 @@
+package serve
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdServe(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    ServeOptions
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: ServeOptions{Host: "127.0.0.1", Port: DefaultPort},
+		},
+		{
+			name: "port and browser",
+			args: []string{"--port", "8080", "--web"},
+			want: ServeOptions{Host: "127.0.0.1", Port: 8080, Web: true},
+		},
+		{
+			name:    "invalid port",
+			args:    []string{"--port", "70000"},
+			wantErr: "invalid port 70000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *ServeOptions
+			cmd := NewCmdServe(cmdutil.NewTestFactory(iostreams.Test()), func(opts *ServeOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Host, got.Host)
+			assert.Equal(t, tt.want.Port, got.Port)
+			assert.Equal(t, tt.want.Web, got.Web)
+			assert.Same(t, cmd, got.Root)
+		})
+	}
+}
+
+func TestServeRun(t *testing.T) {
+	streams := iostreams.Test()
+	root := &cobra.Command{Use: "zen", Short: "Zen CLI"}
+	root.AddCommand(&cobra.Command{Use: "status", Short: "Show workspace status", Run: func(cmd *cobra.Command, args []string) {}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opened := make(chan string, 1)
+	opts := &ServeOptions{
+		IO:         streams,
+		AppVersion: "v1.2.3",
+		Root:       root,
+		Host:       "127.0.0.1",
+		Port:       0,
+		Web:        true,
+		Browser: func(url string) error {
+			opened <- url
+			return nil
+		},
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- serveRun(ctx, opts)
+	}()
+
+	url := <-opened
+	resp, err := http.Get(url + "zen_status")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	out := streams.Out.(*bytes.Buffer).String()
+	assert.Contains(t, out, "Serving documentation at "+url)
+	assert.Contains(t, out, "Documentation server stopped")
+}
@@ -0,0 +1,306 @@
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cli"
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/update"
+	"github.com/spf13/cobra"
+)
+
+// DefaultEndpoint is the HTTPS endpoint the connection check reaches
+const DefaultEndpoint = "https://api.github.com"
+
+// checkTimeout bounds each network check
+const checkTimeout = 10 * time.Second
+
+// Check statuses
+const (
+	StatusOK      = "ok"
+	StatusWarning = "warning"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// DoctorOptions contains options for the doctor command
+type DoctorOptions struct {
+	IO           *iostreams.IOStreams
+	Config       func() (*config.Config, error)
+	Logger       logging.Logger
+	OutputFormat string
+	Endpoint     string
+	Getenv       func(string) string
+	Dial         func(ctx context.Context, network, address string) (net.Conn, error)
+	Transport    http.RoundTripper
+}
+
+// checkResult is the outcome of one check
+type checkResult struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+	Detail string `json:"detail" yaml:"detail"`
+	Hint   string `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+// doctorReport is the outcome of all checks
+type doctorReport struct {
+	Healthy bool          `json:"healthy" yaml:"healthy"`
+	Checks  []checkResult `json:"checks" yaml:"checks"`
+}
+
+// NewCmdDoctor creates the doctor command
+func NewCmdDoctor(f *cmdutil.Factory, runF func(*DoctorOptions) error) *cobra.Command {
+	opts := &DoctorOptions{
+		IO:       f.IOStreams,
+		Config:   f.Config,
+		Logger:   f.Logger,
+		Endpoint: DefaultEndpoint,
+		Getenv:   os.Getenv,
+		Dial:     (&net.Dialer{}).DialContext,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that zen can reach the services it works with",
+		Long: heredoc.Doc(`
+			Check the network setup zen uses to reach providers and asset libraries.
+
+			The checks cover the proxy from network.proxy or the HTTPS_PROXY and
+			HTTP_PROXY environment variables, the CA bundle from network.ca_bundle,
+			whether TLS certificate verification is on, and an HTTPS connection
+			through all of them. The connection check is skipped when zen is offline.
+
+			zen doctor exits with a failure when any check fails.
+		`),
+		Example: heredoc.Doc(`
+			$ zen doctor
+			$ zen doctor --endpoint https://jira.example.com
+			$ zen doctor --output json
+		`),
+		Args:    cobra.NoArgs,
+		GroupID: "workspace",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return doctorRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Endpoint, "endpoint", DefaultEndpoint, "HTTPS URL the connection check reaches")
+
+	return cmd
+}
+
+func doctorRun(ctx context.Context, opts *DoctorOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	var checks []checkResult
+	network, err := config.GetConfig(cfg, httpx.ConfigParser{})
+	if err != nil {
+		checks = append(checks, checkResult{
+			Name:   "Network configuration",
+			Status: StatusFailed,
+			Detail: err.Error(),
+			Hint:   "Fix the network section with 'zen config set network.<key> <value>'",
+		})
+	}
+	cliConfig, err := config.GetConfig(cfg, cli.ConfigParser{})
+	if err != nil {
+		cliConfig = cli.DefaultConfig()
+	}
+
+	checks = append(checks, checkProxy(ctx, opts, network))
+	if network.CABundle != "" {
+		checks = append(checks, checkCABundle(network))
+	}
+	checks = append(checks, checkVerification(network))
+	if update.IsOffline(cliConfig) {
+		checks = append(checks, checkResult{Name: "HTTPS connection", Status: StatusSkipped, Detail: "zen is offline"})
+	} else {
+		checks = append(checks, checkConnection(ctx, opts, network))
+	}
+
+	report := doctorReport{Healthy: true, Checks: checks}
+	failed := 0
+	for _, check := range checks {
+		if check.Status == StatusFailed {
+			failed++
+			report.Healthy = false
+		}
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	if err := renderer.Render(report, func(w io.Writer) error {
+		return displayReport(w, opts.IO, report)
+	}); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return &cmdutil.ExitCodeError{Code: cmdutil.ExitError, Err: fmt.Errorf("%d of %d checks failed", failed, len(checks))}
+	}
+	return nil
+}
+
+// checkProxy checks that the proxy requests go through, if any, accepts connections
+func checkProxy(ctx context.Context, opts *DoctorOptions, network httpx.Config) checkResult {
+	result := checkResult{Name: "Proxy"}
+
+	proxy, source := network.Proxy, "network.proxy"
+	if proxy == "" {
+		for _, name := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+			if value := opts.Getenv(name); value != "" {
+				proxy, source = value, name
+				break
+			}
+		}
+	}
+	if proxy == "" {
+		result.Status, result.Detail = StatusOK, "no proxy configured; connecting directly"
+		return result
+	}
+
+	proxyURL, err := httpx.ParseProxy(proxy)
+	if err != nil {
+		result.Status, result.Detail = StatusFailed, fmt.Sprintf("%s (from %s)", err, source)
+		return result
+	}
+	address := proxyURL.Host
+	if proxyURL.Port() == "" {
+		address = net.JoinHostPort(proxyURL.Hostname(), defaultPort(proxyURL.Scheme))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	conn, err := opts.Dial(ctx, "tcp", address)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Detail = fmt.Sprintf("cannot reach %s (from %s): %s", httpx.RedactURL(proxy), source, err)
+		result.Hint = "Check the proxy address, or that the proxy is running"
+		return result
+	}
+	conn.Close()
+
+	result.Status, result.Detail = StatusOK, fmt.Sprintf("%s is reachable (from %s)", httpx.RedactURL(proxy), source)
+	return result
+}
+
+// checkCABundle checks that the CA bundle holds certificates
+func checkCABundle(network httpx.Config) checkResult {
+	result := checkResult{Name: "CA bundle"}
+	_, count, err := httpx.LoadCABundle(network.CABundle)
+	if err != nil {
+		result.Status, result.Detail = StatusFailed, err.Error()
+		result.Hint = "Set network.ca_bundle to a PEM file of certificate authorities"
+		return result
+	}
+	result.Status, result.Detail = StatusOK, fmt.Sprintf("%d certificates from %s", count, network.CABundle)
+	return result
+}
+
+// checkVerification warns when TLS certificate verification is off
+func checkVerification(network httpx.Config) checkResult {
+	result := checkResult{Name: "TLS verification"}
+	if network.InsecureSkipVerify {
+		result.Status, result.Detail = StatusWarning, httpx.InsecureWarning
+		result.Hint = "Trust the proxy's CA with network.ca_bundle, then run 'zen config set network.insecure_skip_verify false'"
+		return result
+	}
+	result.Status, result.Detail = StatusOK, "certificates are verified"
+	return result
+}
+
+// checkConnection checks that the endpoint can be reached over HTTPS with the network
+// configuration
+func checkConnection(ctx context.Context, opts *DoctorOptions, network httpx.Config) checkResult {
+	result := checkResult{Name: "HTTPS connection"}
+	client := httpx.New(httpx.Options{
+		Network:   &network,
+		Timeout:   checkTimeout,
+		Retry:     httpx.NoRetries,
+		Logger:    opts.Logger,
+		Transport: opts.Transport,
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.Endpoint, nil)
+	if err != nil {
+		result.Status, result.Detail = StatusFailed, fmt.Sprintf("invalid endpoint %s: %s", opts.Endpoint, err)
+		return result
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Status, result.Detail = StatusFailed, fmt.Sprintf("cannot reach %s: %s", req.URL.Host, httpx.RedactError(err))
+		if certificateError(err) {
+			result.Hint = "A proxy may be intercepting TLS; set network.ca_bundle to its CA certificate"
+		}
+		return result
+	}
+	resp.Body.Close()
+
+	result.Status, result.Detail = StatusOK, fmt.Sprintf("reached %s (HTTP %d)", req.URL.Host, resp.StatusCode)
+	return result
+}
+
+// certificateError reports whether err means the server certificate is not trusted
+func certificateError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	return errors.As(err, &verifyErr) || errors.As(err, &authorityErr)
+}
+
+func defaultPort(scheme string) string {
+	switch scheme {
+	case "https":
+		return "443"
+	case "socks5", "socks5h":
+		return "1080"
+	default:
+		return "80"
+	}
+}
+
+func displayReport(w io.Writer, streams *iostreams.IOStreams, report doctorReport) error {
+	for _, check := range report.Checks {
+		var symbol string
+		switch check.Status {
+		case StatusOK:
+			symbol = streams.ColorSuccess(iostreams.SymbolSuccess)
+		case StatusWarning:
+			symbol = streams.ColorWarning(iostreams.SymbolAlert)
+		case StatusFailed:
+			symbol = streams.ColorError(iostreams.SymbolFailure)
+		default:
+			symbol = streams.ColorNeutral(iostreams.SymbolNeutral)
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", symbol, streams.ColorBold(check.Name), check.Detail)
+		if check.Hint != "" {
+			fmt.Fprintf(w, "  %s\n", streams.ColorNeutral(check.Hint))
+		}
+	}
+	return nil
+}
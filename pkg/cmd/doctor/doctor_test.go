@@ -0,0 +1,137 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOptions(t *testing.T, network httpx.Config, env map[string]string) (*DoctorOptions, *bytes.Buffer) {
+	t.Helper()
+	// SetConfig saves the config file of the workspace in the working directory
+	t.Chdir(t.TempDir())
+	cfg := config.LoadDefaults()
+	require.NoError(t, config.SetConfig(cfg, httpx.ConfigParser{}, network))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	streams := iostreams.Test()
+	return &DoctorOptions{
+		IO:           streams,
+		Config:       func() (*config.Config, error) { return cfg, nil },
+		Logger:       logging.NewBasic(),
+		OutputFormat: cmdutil.OutputText,
+		Endpoint:     server.URL,
+		Getenv:       func(name string) string { return env[name] },
+		Dial:         (&net.Dialer{}).DialContext,
+	}, streams.Out.(*bytes.Buffer)
+}
+
+func TestNewCmdDoctor(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+
+	var gotOpts *DoctorOptions
+	cmd := NewCmdDoctor(f, func(opts *DoctorOptions) error {
+		gotOpts = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"--endpoint", "https://jira.example.com"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "https://jira.example.com", gotOpts.Endpoint)
+}
+
+func TestDoctorRun_Direct(t *testing.T) {
+	opts, out := testOptions(t, httpx.Config{}, nil)
+
+	require.NoError(t, doctorRun(context.Background(), opts))
+	assert.Contains(t, out.String(), "✓ Proxy: no proxy configured")
+	assert.Contains(t, out.String(), "✓ TLS verification: certificates are verified")
+	assert.Contains(t, out.String(), "✓ HTTPS connection: reached")
+	assert.NotContains(t, out.String(), "CA bundle")
+}
+
+func TestDoctorRun_Proxy(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	t.Run("reachable proxy from the environment", func(t *testing.T) {
+		opts, out := testOptions(t, httpx.Config{}, map[string]string{
+			"HTTPS_PROXY": "http://user:secret@" + listener.Addr().String(),
+		})
+		opts.Transport = http.DefaultTransport
+
+		require.NoError(t, doctorRun(context.Background(), opts))
+		assert.Contains(t, out.String(), "is reachable (from HTTPS_PROXY)")
+		assert.NotContains(t, out.String(), "secret")
+	})
+
+	t.Run("unreachable proxy from configuration", func(t *testing.T) {
+		opts, out := testOptions(t, httpx.Config{Proxy: "http://proxy.example.com:3128"}, nil)
+		opts.Dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+			assert.Equal(t, "proxy.example.com:3128", address)
+			return nil, errors.New("connection refused")
+		}
+		opts.Transport = http.DefaultTransport
+
+		err := doctorRun(context.Background(), opts)
+		var exitErr *cmdutil.ExitCodeError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, cmdutil.ExitError, exitErr.Code)
+		assert.Contains(t, out.String(), "✗ Proxy: cannot reach http://proxy.example.com:3128 (from network.proxy): connection refused")
+	})
+}
+
+func TestDoctorRun_Insecure(t *testing.T) {
+	opts, out := testOptions(t, httpx.Config{InsecureSkipVerify: true}, nil)
+
+	require.NoError(t, doctorRun(context.Background(), opts))
+	assert.Contains(t, out.String(), "! TLS verification: "+httpx.InsecureWarning)
+}
+
+func TestDoctorRun_UntrustedCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	opts, out := testOptions(t, httpx.Config{}, nil)
+	opts.Endpoint = server.URL
+
+	require.Error(t, doctorRun(context.Background(), opts))
+	assert.Contains(t, out.String(), "✗ HTTPS connection: cannot reach")
+	assert.Contains(t, out.String(), "set network.ca_bundle")
+}
+
+func TestDoctorRun_MissingCABundle(t *testing.T) {
+	opts, out := testOptions(t, httpx.Config{CABundle: "/nonexistent/ca.pem"}, nil)
+	opts.OutputFormat = cmdutil.OutputJSON
+
+	require.Error(t, doctorRun(context.Background(), opts))
+
+	var report doctorReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.False(t, report.Healthy)
+	require.NotEmpty(t, report.Checks)
+	var bundle checkResult
+	for _, check := range report.Checks {
+		if check.Name == "CA bundle" {
+			bundle = check
+		}
+	}
+	assert.Equal(t, StatusFailed, bundle.Status)
+}
@@ -203,38 +203,22 @@ func runDraft(f *cmdutil.Factory, activity string, force, preview bool, outputPa
 	}
 
 	// Step 5: Process template with task data
-	if !io.ColorEnabled() {
-		fmt.Fprintf(io.Out, "- Fetching template for %s...", activity)
-	} else {
-		fmt.Fprintf(io.Out, "%s Fetching template for %s...", "✓", activity)
-	}
+	fmt.Fprintf(io.Out, "%s Fetching template for %s...", io.SuccessIcon(), activity)
 
 	processedContent, err := processTemplate(templateContent, taskManifest, activityAsset)
 	if err != nil {
-		if !io.ColorEnabled() {
-			fmt.Fprintf(io.Out, " ✗\n")
-		} else {
-			fmt.Fprintf(io.Out, " %s\n", "✗")
-		}
+		fmt.Fprintf(io.Out, " %s\n", io.FailureIcon())
 		return &types.Error{
 			Code:    types.ErrorCodeUnknown,
 			Message: fmt.Sprintf("Failed to process template: %v", err),
 		}
 	}
 
-	if !io.ColorEnabled() {
-		fmt.Fprintf(io.Out, " ✓ Processing with task data...")
-	} else {
-		fmt.Fprintf(io.Out, " %s Processing with task data...", "✓")
-	}
+	fmt.Fprintf(io.Out, " %s Processing with task data...", io.SuccessIcon())
 
 	// Step 6: Preview or write file
 	if preview {
-		if !io.ColorEnabled() {
-			fmt.Fprintf(io.Out, " ✓\n\n--- Preview of %s ---\n", filepath.Base(outputFile))
-		} else {
-			fmt.Fprintf(io.Out, " %s\n\n--- Preview of %s ---\n", "✓", filepath.Base(outputFile))
-		}
+		fmt.Fprintf(io.Out, " %s\n\n--- Preview of %s ---\n", io.SuccessIcon(), filepath.Base(outputFile))
 		fmt.Fprint(io.Out, processedContent)
 		fmt.Fprintf(io.Out, "\n--- End Preview ---\n")
 		return nil
@@ -242,11 +226,7 @@ func runDraft(f *cmdutil.Factory, activity string, force, preview bool, outputPa
 
 	// Ensure output directory exists
 	if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
-		if !io.ColorEnabled() {
-			fmt.Fprintf(io.Out, " ✗\n")
-		} else {
-			fmt.Fprintf(io.Out, " %s\n", "✗")
-		}
+		fmt.Fprintf(io.Out, " %s\n", io.FailureIcon())
 		return &types.Error{
 			Code:    types.ErrorCodePermissionDenied,
 			Message: fmt.Sprintf("Failed to create output directory: %v", err),
@@ -255,28 +235,46 @@ func runDraft(f *cmdutil.Factory, activity string, force, preview bool, outputPa
 
 	// Write the processed content
 	if err := os.WriteFile(outputFile, []byte(processedContent), 0644); err != nil {
-		if !io.ColorEnabled() {
-			fmt.Fprintf(io.Out, " ✗\n")
-		} else {
-			fmt.Fprintf(io.Out, " %s\n", "✗")
-		}
+		fmt.Fprintf(io.Out, " %s\n", io.FailureIcon())
 		return &types.Error{
 			Code:    types.ErrorCodePermissionDenied,
 			Message: fmt.Sprintf("Failed to write file: %v", err),
 		}
 	}
 
-	if !io.ColorEnabled() {
-		fmt.Fprintf(io.Out, " ✓\n✓ Generated %s with task %s data in %s\n",
-			filepath.Base(outputFile), taskManifest.Task.ID, filepath.Dir(outputFile))
-	} else {
-		fmt.Fprintf(io.Out, " %s\n%s Generated %s with task %s data in %s\n",
-			"✓",
-			"✓",
-			filepath.Base(outputFile),
-			taskManifest.Task.ID,
-			filepath.Dir(outputFile))
-	}
+	fmt.Fprintf(io.Out, " %s\n%s Generated %s with task %s data in %s\n",
+		io.SuccessIcon(),
+		io.SuccessIcon(),
+		filepath.Base(outputFile),
+		taskManifest.Task.ID,
+		filepath.Dir(outputFile))
+
+	recordDraftUsage(ctx, f, activityAsset.Name, templateContent.Dependencies)
 
 	return nil
 }
+
+// recordDraftUsage records the use of the template and its dependencies in the workspace
+// usage log, when asset usage is tracked. Failures do not fail the draft.
+func recordDraftUsage(ctx context.Context, f *cmdutil.Factory, name string, dependencies []assets.AssetContent) {
+	cfg, err := f.Config()
+	if err != nil || !assets.UsageTracked(cfg) {
+		return
+	}
+	ws, err := f.WorkspaceManager()
+	if err != nil {
+		return
+	}
+	status, err := ws.Status()
+	if err != nil {
+		return
+	}
+
+	names := []string{name}
+	for _, dependency := range dependencies {
+		names = append(names, dependency.Metadata.Name)
+	}
+	if err := assets.RecordUsage(ctx, status.Root, "draft", names...); err != nil {
+		f.Logger.Warn("failed to record asset usage", "asset", name, "error", err)
+	}
+}
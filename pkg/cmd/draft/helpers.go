@@ -2,8 +2,10 @@ package draft
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 	"time"
@@ -306,3 +308,12 @@ func createTemplateData(manifest *TaskManifest) map[string]interface{} {
 
 	return data
 }
+
+// TemplateVariables returns the names of the task variables that draft passes to every
+// template, whether or not the template declares them
+func TemplateVariables() []string {
+	var manifest TaskManifest
+	started, completed := "", ""
+	manifest.Dates.Started, manifest.Dates.Completed = &started, &completed
+	return slices.Sorted(maps.Keys(createTemplateData(&manifest)))
+}
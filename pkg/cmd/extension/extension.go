@@ -0,0 +1,57 @@
+package extension
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/extension/install"
+	"github.com/daddia/zen/pkg/cmd/extension/list"
+	"github.com/daddia/zen/pkg/cmd/extension/remove"
+	"github.com/daddia/zen/pkg/cmd/extension/upgrade"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdExtension creates the extension command with subcommands
+func NewCmdExtension(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "extension <command>",
+		Aliases: []string{"extensions", "ext"},
+		Short:   "Manage zen extensions",
+		Long: heredoc.Doc(`
+			Install and manage extensions that add new commands to zen.
+
+			An extension is an executable named zen-<name>, published in a Git
+			repository of the same name. Once installed it runs as 'zen <name>',
+			with the arguments after the name passed through unchanged.
+
+			Extensions are installed from the latest GitHub release when a binary
+			named zen-<name>-<os>-<arch> is attached to it, and cloned from Git
+			otherwise. The following environment variables are set for extensions:
+
+			- ZEN_BIN: path to the zen executable
+			- ZEN_VERSION: version of zen running the extension
+			- ZEN_WORKSPACE: root of the current zen workspace, when there is one
+			- ZEN_CONFIG_FILE: configuration file in use, when there is one
+		`),
+		Example: heredoc.Doc(`
+			# Install an extension from GitHub
+			zen extension install acme/zen-deploy
+
+			# Install from any Git URL
+			zen extension install https://gitlab.com/acme/zen-deploy.git
+
+			# Run it
+			zen deploy --env staging
+
+			# Upgrade all extensions
+			zen extension upgrade --all
+		`),
+		GroupID: "core",
+	}
+
+	cmd.AddCommand(install.NewCmdExtensionInstall(f, nil))
+	cmd.AddCommand(upgrade.NewCmdExtensionUpgrade(f, nil))
+	cmd.AddCommand(remove.NewCmdExtensionRemove(f, nil))
+	cmd.AddCommand(list.NewCmdExtensionList(f, nil))
+
+	return cmd
+}
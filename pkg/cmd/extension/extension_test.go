@@ -0,0 +1,22 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCmdExtension(t *testing.T) {
+	cmd := NewCmdExtension(cmdutil.NewTestFactory(iostreams.Test()))
+
+	assert.Equal(t, "extension <command>", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "ext")
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"install", "upgrade", "remove", "list"}, names)
+}
@@ -0,0 +1,85 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/extension"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// InstallOptions contains options for the extension install command
+type InstallOptions struct {
+	IO               *iostreams.IOStreams
+	ExtensionManager func() (*extension.Manager, error)
+	OutputFormat     string
+	Repository       string
+}
+
+// NewCmdExtensionInstall creates the extension install command
+func NewCmdExtensionInstall(f *cmdutil.Factory, runF func(*InstallOptions) error) *cobra.Command {
+	opts := &InstallOptions{
+		IO:               f.IOStreams,
+		ExtensionManager: f.ExtensionManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "install <repository>",
+		Short: "Install a zen extension",
+		Long: heredoc.Doc(`
+			Install an extension from a Git repository.
+
+			The repository can be an owner/repo on GitHub, any Git URL, or a local
+			path. Its name must start with "zen-"; the rest of the name becomes the
+			command, so acme/zen-deploy is run as 'zen deploy'.
+		`),
+		Example: heredoc.Doc(`
+			$ zen extension install acme/zen-deploy
+			$ zen extension install https://gitlab.com/acme/zen-deploy.git
+			$ zen extension install ./zen-deploy
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Repository = args[0]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return installRun(cmd.Context(), opts)
+		},
+	}
+
+	return cmd
+}
+
+func installRun(ctx context.Context, opts *InstallOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	manager, err := opts.ExtensionManager()
+	if err != nil {
+		return err
+	}
+
+	spinner := opts.IO.StartSpinner(fmt.Sprintf("Installing %s...", opts.Repository))
+	ext, err := manager.Install(ctx, opts.Repository)
+	spinner.Done(err)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	return renderer.Render(ext, func(w io.Writer) error {
+		fmt.Fprintf(w, "%s Installed extension %s %s\n",
+			opts.IO.SuccessIcon(), opts.IO.ColorBold(ext.Name), opts.IO.ColorNeutral(ext.Version))
+		fmt.Fprintf(w, "Run it with 'zen %s'\n", ext.Name)
+		return nil
+	})
+}
@@ -0,0 +1,100 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/extension"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// ListOptions contains options for the extension list command
+type ListOptions struct {
+	IO               *iostreams.IOStreams
+	ExtensionManager func() (*extension.Manager, error)
+	OutputFormat     string
+	Template         string
+	JQ               string
+}
+
+// NewCmdExtensionList creates the extension list command
+func NewCmdExtensionList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IO:               f.IOStreams,
+		ExtensionManager: f.ExtensionManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List installed extensions",
+		Example: heredoc.Doc(`
+			$ zen extension list
+			$ zen extension list --format '{{.Name}} {{.Version}}'
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	manager, err := opts.ExtensionManager()
+	if err != nil {
+		return err
+	}
+
+	extensions, err := manager.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(extensions, func(w io.Writer) error {
+		return displayListText(w, opts.IO, extensions)
+	})
+}
+
+func displayListText(w io.Writer, streams *iostreams.IOStreams, extensions []*extension.Extension) error {
+	if len(extensions) == 0 {
+		fmt.Fprintln(w, "No extensions installed.")
+		if streams.IsStdoutTTY() {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, streams.ColorNeutral("Install one with 'zen extension install <repository>'"))
+		}
+		return nil
+	}
+
+	headers := []string{"NAME", "VERSION", "KIND", "SOURCE"}
+	rows := make([][]string, 0, len(extensions))
+	for _, ext := range extensions {
+		rows = append(rows, []string{"zen " + ext.Name, ext.Version, string(ext.Kind), ext.Source})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/extension"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(t *testing.T) (*ListOptions, *bytes.Buffer, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	streams := iostreams.Test()
+	manager := extension.NewManager(dir, logging.NewBasic())
+
+	return &ListOptions{
+		IO:               streams,
+		ExtensionManager: func() (*extension.Manager, error) { return manager, nil },
+	}, streams.Out.(*bytes.Buffer), dir
+}
+
+func writeBinaryExtension(t *testing.T, dir, name, tag string) {
+	t.Helper()
+	extDir := filepath.Join(dir, extension.Prefix+name)
+	require.NoError(t, os.MkdirAll(extDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(extDir, extension.ExecutableName(name)), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(extDir, "manifest.yaml"), []byte("owner: acme\nrepo: zen-"+name+"\ntag: "+tag+"\n"), 0644))
+}
+
+func TestListRun_Empty(t *testing.T) {
+	opts, out, _ := newTestOptions(t)
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "No extensions installed.\n", out.String())
+}
+
+func TestListRun_Text(t *testing.T) {
+	opts, out, dir := newTestOptions(t)
+	writeBinaryExtension(t, dir, "lint", "v0.2.0")
+	writeBinaryExtension(t, dir, "deploy", "v1.0.0")
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "zen deploy\tv1.0.0\tbinary\tacme/zen-deploy\nzen lint\tv0.2.0\tbinary\tacme/zen-lint\n", out.String())
+}
+
+func TestListRun_JSON(t *testing.T) {
+	opts, out, dir := newTestOptions(t)
+	opts.OutputFormat = cmdutil.OutputJSON
+	opts.JQ = ".[].name"
+	writeBinaryExtension(t, dir, "deploy", "v1.0.0")
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "deploy\n", out.String())
+}
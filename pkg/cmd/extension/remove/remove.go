@@ -0,0 +1,66 @@
+package remove
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/extension"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// RemoveOptions contains options for the extension remove command
+type RemoveOptions struct {
+	IO               *iostreams.IOStreams
+	ExtensionManager func() (*extension.Manager, error)
+	Name             string
+}
+
+// NewCmdExtensionRemove creates the extension remove command
+func NewCmdExtensionRemove(f *cmdutil.Factory, runF func(*RemoveOptions) error) *cobra.Command {
+	opts := &RemoveOptions{
+		IO:               f.IOStreams,
+		ExtensionManager: f.ExtensionManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm", "uninstall"},
+		Short:   "Remove an installed extension",
+		Example: heredoc.Doc(`
+			$ zen extension remove deploy
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return removeRun(cmd.Context(), opts)
+		},
+	}
+
+	return cmd
+}
+
+func removeRun(ctx context.Context, opts *RemoveOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	manager, err := opts.ExtensionManager()
+	if err != nil {
+		return err
+	}
+
+	if err := manager.Remove(ctx, opts.Name); err != nil {
+		return err
+	}
+
+	// Status messages go to stderr so stdout stays clean for scripts
+	fmt.Fprintf(opts.IO.ErrOut, "%s Removed extension %s\n", opts.IO.SuccessIcon(), opts.Name)
+	return nil
+}
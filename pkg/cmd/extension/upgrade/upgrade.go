@@ -0,0 +1,145 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/extension"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// UpgradeOptions contains options for the extension upgrade command
+type UpgradeOptions struct {
+	IO               *iostreams.IOStreams
+	ExtensionManager func() (*extension.Manager, error)
+	OutputFormat     string
+	Name             string
+	All              bool
+}
+
+// UpgradeResult reports the outcome of upgrading one extension
+type UpgradeResult struct {
+	Name     string `json:"name" yaml:"name"`
+	Version  string `json:"version,omitempty" yaml:"version,omitempty"`
+	Upgraded bool   `json:"upgraded" yaml:"upgraded"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewCmdExtensionUpgrade creates the extension upgrade command
+func NewCmdExtensionUpgrade(f *cmdutil.Factory, runF func(*UpgradeOptions) error) *cobra.Command {
+	opts := &UpgradeOptions{
+		IO:               f.IOStreams,
+		ExtensionManager: f.ExtensionManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade {<name> | --all}",
+		Short: "Upgrade installed extensions",
+		Long: heredoc.Doc(`
+			Upgrade an extension to the latest commit of its repository, or to the
+			latest release for extensions installed from a release binary.
+		`),
+		Example: heredoc.Doc(`
+			$ zen extension upgrade deploy
+			$ zen extension upgrade --all
+		`),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				opts.Name = args[0]
+			}
+			if opts.Name == "" && !opts.All {
+				return &cmdutil.FlagError{Err: fmt.Errorf("specify an extension to upgrade or use --all")}
+			}
+			if opts.Name != "" && opts.All {
+				return &cmdutil.FlagError{Err: fmt.Errorf("cannot use an extension name with --all")}
+			}
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return upgradeRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Upgrade all installed extensions")
+
+	return cmd
+}
+
+func upgradeRun(ctx context.Context, opts *UpgradeOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	manager, err := opts.ExtensionManager()
+	if err != nil {
+		return err
+	}
+
+	names := []string{opts.Name}
+	if opts.All {
+		installed, err := manager.List(ctx)
+		if err != nil {
+			return err
+		}
+		names = names[:0]
+		for _, ext := range installed {
+			names = append(names, ext.Name)
+		}
+	}
+
+	results := make([]UpgradeResult, 0, len(names))
+	var failed int
+	for _, name := range names {
+		ext, upgraded, err := manager.Upgrade(ctx, name)
+		result := UpgradeResult{Name: name, Upgraded: upgraded}
+		if err != nil {
+			// A single extension is reported as a plain error
+			if !opts.All {
+				return err
+			}
+			failed++
+			result.Error = err.Error()
+		} else {
+			result.Version = ext.Version
+		}
+		results = append(results, result)
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	if err := renderer.Render(results, func(w io.Writer) error {
+		return displayUpgradeText(w, opts.IO, results)
+	}); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return cmdutil.ErrSilent
+	}
+	return nil
+}
+
+func displayUpgradeText(w io.Writer, streams *iostreams.IOStreams, results []UpgradeResult) error {
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No extensions installed.")
+		return nil
+	}
+
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			fmt.Fprintf(w, "%s %s: %s\n", streams.FailureIcon(), r.Name, r.Error)
+		case r.Upgraded:
+			fmt.Fprintf(w, "%s Upgraded %s to %s\n", streams.SuccessIcon(), r.Name, r.Version)
+		default:
+			fmt.Fprintf(w, "%s %s is already up to date\n", streams.SuccessIcon(), r.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package upgrade
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdExtensionUpgrade_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+		want    UpgradeOptions
+	}{
+		{name: "single", args: []string{"deploy"}, want: UpgradeOptions{Name: "deploy"}},
+		{name: "all", args: []string{"--all"}, want: UpgradeOptions{All: true}},
+		{name: "neither", args: []string{}, wantErr: "specify an extension to upgrade or use --all"},
+		{name: "both", args: []string{"deploy", "--all"}, wantErr: "cannot use an extension name with --all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *UpgradeOptions
+			cmd := NewCmdExtensionUpgrade(cmdutil.NewTestFactory(iostreams.Test()), func(opts *UpgradeOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Name, got.Name)
+			assert.Equal(t, tt.want.All, got.All)
+		})
+	}
+}
+
+func TestDisplayUpgradeText(t *testing.T) {
+	var out bytes.Buffer
+	results := []UpgradeResult{
+		{Name: "deploy", Version: "v1.1.0", Upgraded: true},
+		{Name: "lint", Version: "abc1234"},
+		{Name: "broken", Error: "git pull failed"},
+	}
+
+	require.NoError(t, displayUpgradeText(&out, iostreams.Test(), results))
+	assert.Equal(t, "✓ Upgraded deploy to v1.1.0\n✓ lint is already up to date\n✗ broken: git pull failed\n", out.String())
+}
@@ -4,8 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/daddia/zen/internal/config"
@@ -15,7 +14,12 @@ import (
 	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/cache"
 	"github.com/daddia/zen/pkg/cli"
+	"github.com/daddia/zen/pkg/clients/git"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/extension"
+	"github.com/daddia/zen/pkg/hooks"
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/daddia/zen/pkg/template"
 	"github.com/spf13/cobra"
@@ -28,22 +32,47 @@ func New() *cmdutil.Factory {
 		ExecutableName: "zen",
 		BuildInfo:      GetBuildInfo(),
 	}
+	httpx.SetVersion(f.AppVersion)
 
 	// Build dependency chain (order matters)
 	f.Config = configFunc()                   // No dependencies
 	f.IOStreams = ioStreams(f)                // Depends on Config
 	f.Logger = loggerFunc(f)                  // Depends on Config
+	configureNetwork(f)                       // Depends on Config, IOStreams
 	f.WorkspaceManager = workspaceFunc(f)     // Depends on Config, Logger
 	f.AgentManager = agentFunc(f)             // Depends on Config, Logger
 	f.AuthManager = authFunc(f)               // Depends on Config, Logger
 	f.AssetClient = assetClientFunc(f)        // Depends on Config, Logger, AuthManager
 	f.Cache = cacheFunc(f)                    // Depends on Logger
 	f.TemplateEngine = templateEngineFunc(f)  // Depends on Config, Logger, AssetClient
+	f.ResponseCache = responseCacheFunc(f)    // Depends on Config, Logger
 	f.IntegrationManager = integrationFunc(f) // Depends on Config, Logger, AuthManager, Cache
+	f.ExtensionManager = extensionFunc(f)     // Depends on Logger
+	f.HookRunner = hookRunnerFunc(f)          // Depends on Config, Logger, WorkspaceManager
 
 	return f
 }
 
+// configureNetwork applies the network configuration to every HTTP client and to Git,
+// warning loudly when TLS certificate verification is turned off
+func configureNetwork(f *cmdutil.Factory) {
+	cfg, err := f.Config()
+	if err != nil {
+		return
+	}
+
+	network, err := config.GetConfig(cfg, httpx.ConfigParser{})
+	if err != nil {
+		fmt.Fprintf(f.IOStreams.ErrOut, "%s %s\n", f.IOStreams.WarningIcon(), err)
+	}
+	httpx.Configure(network)
+
+	if network.InsecureSkipVerify {
+		fmt.Fprintf(f.IOStreams.ErrOut, "%s %s\n", f.IOStreams.ColorWarning("! WARNING:"), httpx.InsecureWarning)
+		f.IOStreams.Annotate(iostreams.AnnotationWarning, httpx.InsecureWarning)
+	}
+}
+
 func configFunc() func() (*config.Config, error) {
 	var cachedConfig *config.Config
 	var configError error
@@ -79,15 +108,18 @@ func ioStreams(f *cmdutil.Factory) *iostreams.IOStreams {
 		// Get CLI configuration using standard API
 		cliConfig, err := config.GetConfig(cfg, cli.ConfigParser{})
 		if err == nil {
-			// Apply configuration settings
-			if cliConfig.NoColor {
+			// Apply configuration settings; FORCE_COLOR outranks cli.no_color
+			if cliConfig.NoColor && !iostreams.ColorForced() {
 				io.SetColorEnabled(false)
 			}
 		}
 
-		// Check for NO_COLOR environment variable
-		if os.Getenv("NO_COLOR") != "" {
-			io.SetColorEnabled(false)
+		// Draw with the configured color theme, accessibly when asked to
+		if uiConfig, err := config.GetConfig(cfg, cli.UIConfigParser{}); err == nil {
+			if theme, ok := iostreams.LookupTheme(uiConfig.Theme); ok {
+				io.SetTheme(theme)
+			}
+			io.SetAccessible(uiConfig.Accessible)
 		}
 
 		// Check for ZEN_PROMPT_DISABLED
@@ -96,6 +128,20 @@ func ioStreams(f *cmdutil.Factory) *iostreams.IOStreams {
 		}
 	}
 
+	// Run non-interactively in CI, unless ZEN_NON_INTERACTIVE turns it off
+	switch value := os.Getenv("ZEN_NON_INTERACTIVE"); value {
+	case "false", "0":
+	default:
+		if value != "" || iostreams.IsCI() {
+			io.SetNonInteractive(true)
+		}
+	}
+
+	// Group output and annotate failures in GitHub Actions logs
+	if iostreams.IsGitHubActions() {
+		io.SetAnnotationsEnabled(true)
+	}
+
 	return io
 }
 
@@ -333,28 +379,72 @@ func assetClientFunc(f *cmdutil.Factory) func() (assets.AssetClientInterface, er
 		authProvider := assets.NewAuthProviderAdapter(authManager)
 
 		// Set up cache path
-		cachePath := assetConfig.CachePath
-		if strings.HasPrefix(cachePath, "~/") {
-			home, err := os.UserHomeDir()
+		cachePath, err := assetConfig.ResolvedCachePath()
+		if err != nil {
+			clientError = err
+			return nil, clientError
+		}
+
+		// Encrypt cached assets when "assets" is listed in cache.encrypt
+		cacheConfig, err := config.GetConfig(cfg, cache.ConfigParser{})
+		if err != nil {
+			clientError = err
+			return nil, clientError
+		}
+		assetCacheConfig := cache.Config{
+			BasePath:    cachePath,
+			SizeLimitMB: assetConfig.CacheSizeMB,
+			DefaultTTL:  assetConfig.DefaultTTL,
+			Backend:     assetConfig.CacheBackend,
+			Encrypted:   slices.Contains(cacheConfig.Encrypt, assets.CacheNamespace),
+		}
+		if assetConfig.SharedStore {
+			storePath, err := assetConfig.ResolvedStorePath()
+			if err != nil {
+				clientError = err
+				return nil, clientError
+			}
+			assetCacheConfig.Store = cache.NewObjectStore(storePath)
+		}
+		if assetCacheConfig.Encrypted {
+			assetCacheConfig.KeySource, err = cacheKeySource(cfg, logger)
 			if err != nil {
 				clientError = err
 				return nil, clientError
 			}
-			cachePath = filepath.Join(home, cachePath[2:])
 		}
 
-		cache := assets.NewAssetCacheManager(
-			cachePath,
-			assetConfig.CacheSizeMB,
-			assetConfig.DefaultTTL,
-			logger,
-		)
+		cache := assets.NewAssetCache(assetCacheConfig, logger)
+
+		parser := assets.NewYAMLManifestParser(logger)
+
+		// Sparse paths and SSH remotes need a local clone
+		if assetConfig.LocalClone() {
+			gitConfig, err := config.GetConfig(cfg, git.ConfigParser{})
+			if err != nil {
+				clientError = err
+				return nil, clientError
+			}
+
+			repositoryPath, err := assetConfig.RepositoryPath()
+			if err != nil {
+				clientError = err
+				return nil, clientError
+			}
+
+			repo, err := git.NewRepository(gitConfig, repositoryPath, logger, authProvider, assetConfig.AuthProvider)
+			if err != nil {
+				clientError = err
+				return nil, clientError
+			}
+
+			cachedClient = assets.NewClient(assetConfig, logger, authProvider, cache, repo, parser)
+			return cachedClient, nil
+		}
 
 		// Use HTTP client for individual file fetching (no repository cloning needed)
 		httpClient := assets.NewHTTPManifestClient(logger, authProvider, assetConfig.AuthProvider)
 
-		parser := assets.NewYAMLManifestParser(logger)
-
 		// Create client with HTTP-based file fetching
 		cachedClient = assets.NewClientWithHTTP(assetConfig, logger, authProvider, cache, httpClient, parser)
 
@@ -412,6 +502,53 @@ func cacheFunc(f *cmdutil.Factory) func(basePath string) cache.Manager[string] {
 	}
 }
 
+func responseCacheFunc(f *cmdutil.Factory) func() (*zenhttp.ResponseCache, error) {
+	var cachedResponses *zenhttp.ResponseCache
+	var responsesError error
+
+	return func() (*zenhttp.ResponseCache, error) {
+		if cachedResponses != nil || responsesError != nil {
+			return cachedResponses, responsesError
+		}
+
+		cfg, err := f.Config()
+		if err != nil {
+			responsesError = err
+			return nil, responsesError
+		}
+
+		cacheConfig, err := config.GetConfig(cfg, cache.ConfigParser{})
+		if err != nil {
+			responsesError = err
+			return nil, responsesError
+		}
+		if slices.Contains(cacheConfig.Encrypt, zenhttp.ResponseCacheNamespace) {
+			cacheConfig.KeySource, err = cacheKeySource(cfg, f.Logger)
+			if err != nil {
+				responsesError = err
+				return nil, responsesError
+			}
+		}
+
+		cachedResponses = zenhttp.NewResponseCache(cacheConfig, zenhttp.DefaultResponseFreshness, f.Logger)
+		return cachedResponses, nil
+	}
+}
+
+// cacheKeySource returns the source of the key that encrypts cache entries, kept in the
+// credential storage configured for auth
+func cacheKeySource(cfg *config.Config, logger logging.Logger) (cache.KeySource, error) {
+	authConfig, err := config.GetConfig(cfg, auth.ConfigParser{})
+	if err != nil {
+		return nil, err
+	}
+	storage, err := auth.NewStorage(authConfig.StorageType, authConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewCacheKeySource(storage), nil
+}
+
 func integrationFunc(f *cmdutil.Factory) func() (cmdutil.IntegrationManagerInterface, error) {
 	var cachedIntegration cmdutil.IntegrationManagerInterface
 	var integrationError error
@@ -432,3 +569,56 @@ func integrationFunc(f *cmdutil.Factory) func() (cmdutil.IntegrationManagerInter
 		return cachedIntegration, nil
 	}
 }
+func extensionFunc(f *cmdutil.Factory) func() (*extension.Manager, error) {
+	var cachedManager *extension.Manager
+	var managerError error
+
+	return func() (*extension.Manager, error) {
+		if cachedManager != nil || managerError != nil {
+			return cachedManager, managerError
+		}
+
+		dir, err := extension.DefaultDir()
+		if err != nil {
+			managerError = fmt.Errorf("failed to locate extensions directory: %w", err)
+			return nil, managerError
+		}
+
+		cachedManager = extension.NewManager(dir, f.Logger)
+		return cachedManager, nil
+	}
+}
+
+func hookRunnerFunc(f *cmdutil.Factory) func() (*hooks.Runner, error) {
+	var cachedRunner *hooks.Runner
+	var runnerError error
+
+	return func() (*hooks.Runner, error) {
+		if cachedRunner != nil || runnerError != nil {
+			return cachedRunner, runnerError
+		}
+
+		cfg, err := f.Config()
+		if err != nil {
+			runnerError = err
+			return nil, runnerError
+		}
+
+		hookConfig, err := config.GetConfig(cfg, hooks.ConfigParser{})
+		if err != nil {
+			runnerError = fmt.Errorf("invalid hooks configuration: %w", err)
+			return nil, runnerError
+		}
+
+		// Hooks run from the workspace root, or the current directory outside a workspace
+		dir, _ := os.Getwd()
+		if ws, err := f.WorkspaceManager(); err == nil {
+			if status, err := ws.Status(); err == nil && status.Initialized {
+				dir = status.Root
+			}
+		}
+
+		cachedRunner = hooks.NewRunner(hookConfig, dir, f.IOStreams.ErrOut, f.Logger)
+		return cachedRunner, nil
+	}
+}
@@ -0,0 +1,63 @@
+package hooks
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/hooks/list"
+	"github.com/daddia/zen/pkg/cmd/hooks/test"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdHooks creates the hooks command with subcommands
+func NewCmdHooks(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "hooks <command>",
+		Aliases: []string{"hook"},
+		Short:   "Inspect and test lifecycle hooks",
+		Long: heredoc.Doc(`
+			Inspect and test the hooks configured in the "hooks" section of zen.yaml.
+
+			Hooks run a shell command or a builtin action when a lifecycle event
+			occurs. Each hook receives a JSON document describing the event, and
+			the task when there is one, on stdin.
+
+			Supported events:
+
+			- post_init: after 'zen init' completes
+			- pre_task_create, post_task_create: around 'zen task create'
+			- pre_sync, post_sync: around 'zen task sync' and 'zen assets sync'
+
+			Hooks on pre_* events abort the operation when they fail, and hooks
+			on other events print a warning. Set on_failure to "warn" or "abort"
+			to change this for a single hook.
+
+			Builtin actions:
+
+			- log: append the event payload to .zen/hooks.log
+			- git-add: stage the files created by the event
+		`),
+		Example: heredoc.Doc(`
+			# Configure hooks in zen.yaml
+			hooks:
+			  timeout: 30s
+			  pre_task_create:
+			    - name: check-id
+			      run: ./scripts/check-task-id.sh
+			  post_task_create:
+			    - builtin: git-add
+			      on_failure: warn
+
+			# Show configured hooks
+			zen hooks list
+
+			# Run the post_task_create hooks against an existing task
+			zen hooks test post_task_create --task PROJ-123
+		`),
+		GroupID: "core",
+	}
+
+	cmd.AddCommand(list.NewCmdHooksList(f, nil))
+	cmd.AddCommand(test.NewCmdHooksTest(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,131 @@
+package list
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/hooks"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// ListOptions contains options for the hooks list command
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	HookRunner   func() (*hooks.Runner, error)
+	Event        string
+	OutputFormat string
+	Template     string
+	JQ           string
+}
+
+// hookEntry is a configured hook together with its event and effective settings
+type hookEntry struct {
+	Event     hooks.Event         `json:"event"`
+	Name      string              `json:"name"`
+	Run       string              `json:"run,omitempty"`
+	Builtin   string              `json:"builtin,omitempty"`
+	Timeout   string              `json:"timeout"`
+	OnFailure hooks.FailurePolicy `json:"on_failure"`
+}
+
+// NewCmdHooksList creates the hooks list command
+func NewCmdHooksList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IO:         f.IOStreams,
+		HookRunner: f.HookRunner,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List configured hooks",
+		Example: heredoc.Doc(`
+			$ zen hooks list
+			$ zen hooks list --event pre_sync
+			$ zen hooks list --output json
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if opts.Event != "" {
+				if _, err := hooks.ParseEvent(opts.Event); err != nil {
+					return &cmdutil.FlagError{Err: err}
+				}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return listRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Event, "event", "", "Only list hooks for this event")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func listRun(opts *ListOptions) error {
+	runner, err := opts.HookRunner()
+	if err != nil {
+		return err
+	}
+
+	cfg := runner.Config()
+	entries := []hookEntry{}
+	for _, event := range hooks.Events() {
+		if opts.Event != "" && string(event) != opts.Event {
+			continue
+		}
+		for _, hook := range cfg.Hooks(event) {
+			entries = append(entries, hookEntry{
+				Event:     event,
+				Name:      hook.DisplayName(),
+				Run:       hook.Run,
+				Builtin:   hook.Builtin,
+				Timeout:   runner.Timeout(hook).String(),
+				OnFailure: runner.Policy(hook, event),
+			})
+		}
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(entries, func(w io.Writer) error {
+		return displayListText(w, opts.IO, entries)
+	})
+}
+
+func displayListText(w io.Writer, streams *iostreams.IOStreams, entries []hookEntry) error {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No hooks configured.")
+		if streams.IsStdoutTTY() {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, streams.ColorNeutral("Add hooks to the 'hooks' section of zen.yaml; see 'zen hooks --help'"))
+		}
+		return nil
+	}
+
+	headers := []string{"EVENT", "NAME", "ACTION", "TIMEOUT", "ON FAILURE"}
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		action := entry.Run
+		if entry.Builtin != "" {
+			action = "builtin:" + entry.Builtin
+		}
+		rows = append(rows, []string{string(entry.Event), entry.Name, action, entry.Timeout, string(entry.OnFailure)})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package list
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/hooks"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(t *testing.T, cfg hooks.Config) (*ListOptions, *bytes.Buffer) {
+	t.Helper()
+
+	streams := iostreams.Test()
+	runner := hooks.NewRunner(cfg, t.TempDir(), streams.ErrOut, logging.NewBasic())
+
+	return &ListOptions{
+		IO:         streams,
+		HookRunner: func() (*hooks.Runner, error) { return runner, nil },
+	}, streams.Out.(*bytes.Buffer)
+}
+
+func testConfig() hooks.Config {
+	return hooks.Config{
+		Timeout:        "30s",
+		PreTaskCreate:  []hooks.Hook{{Name: "check-id", Run: "./check.sh"}},
+		PostTaskCreate: []hooks.Hook{{Builtin: "git-add", Timeout: "5s"}},
+		PostSync:       []hooks.Hook{{Run: "notify-send synced", OnFailure: hooks.FailureAbort}},
+	}
+}
+
+func TestListRun_Empty(t *testing.T) {
+	opts, out := newTestOptions(t, hooks.DefaultConfig())
+
+	require.NoError(t, listRun(opts))
+	assert.Equal(t, "No hooks configured.\n", out.String())
+}
+
+func TestListRun_Text(t *testing.T) {
+	opts, out := newTestOptions(t, testConfig())
+
+	require.NoError(t, listRun(opts))
+	assert.Equal(t,
+		"pre_task_create\tcheck-id\t./check.sh\t30s\tabort\n"+
+			"post_task_create\tbuiltin:git-add\tbuiltin:git-add\t5s\twarn\n"+
+			"post_sync\tnotify-send synced\tnotify-send synced\t30s\tabort\n",
+		out.String())
+}
+
+func TestListRun_EventFilterJSON(t *testing.T) {
+	opts, out := newTestOptions(t, testConfig())
+	opts.Event = "pre_task_create"
+	opts.OutputFormat = cmdutil.OutputJSON
+	opts.JQ = `.[] | "\(.name) \(.on_failure)"`
+
+	require.NoError(t, listRun(opts))
+	assert.Equal(t, "check-id abort\n", out.String())
+}
@@ -0,0 +1,140 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/hooks"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TestOptions contains options for the hooks test command
+type TestOptions struct {
+	IO         *iostreams.IOStreams
+	HookRunner func() (*hooks.Runner, error)
+	GetTask    func(ctx context.Context, taskID string) (*task.Task, error)
+
+	Event  hooks.Event
+	TaskID string
+}
+
+// NewCmdHooksTest creates the hooks test command
+func NewCmdHooksTest(f *cmdutil.Factory, runF func(*TestOptions) error) *cobra.Command {
+	opts := &TestOptions{
+		IO:         f.IOStreams,
+		HookRunner: f.HookRunner,
+		GetTask: func(ctx context.Context, taskID string) (*task.Task, error) {
+			return task.NewManager(f).GetTask(ctx, taskID)
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "test <event>",
+		Short: "Run the hooks for an event with a sample payload",
+		Long: heredoc.Doc(`
+			Run every hook configured for an event and report the result of each.
+
+			Hooks receive a sample payload on stdin, built from an existing task
+			when --task is given. All hooks run even when one fails, so that every
+			problem is reported at once.
+		`),
+		Example: heredoc.Doc(`
+			$ zen hooks test pre_task_create
+			$ zen hooks test post_task_create --task PROJ-123
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			event, err := hooks.ParseEvent(args[0])
+			if err != nil {
+				return &cmdutil.FlagError{Err: err}
+			}
+			opts.Event = event
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return testRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.TaskID, "task", "", "Build the payload from an existing task")
+
+	return cmd
+}
+
+func testRun(ctx context.Context, opts *TestOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	runner, err := opts.HookRunner()
+	if err != nil {
+		return err
+	}
+
+	if len(runner.Config().Hooks(opts.Event)) == 0 {
+		fmt.Fprintf(opts.IO.Out, "No hooks configured for %s\n", opts.Event)
+		return nil
+	}
+
+	payload, err := samplePayload(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range runner.Test(ctx, payload) {
+		duration := result.Duration.Round(time.Millisecond)
+		if result.Err == nil {
+			fmt.Fprintf(opts.IO.Out, "%s %s\n",
+				opts.IO.FormatSuccess(result.Hook.DisplayName()), opts.IO.ColorNeutral(fmt.Sprintf("(%s)", duration)))
+			continue
+		}
+
+		failed++
+		fmt.Fprintf(opts.IO.Out, "%s %s\n",
+			opts.IO.FormatError(result.Hook.DisplayName()), opts.IO.ColorNeutral(fmt.Sprintf("(%s, on failure: %s)", duration, result.Policy)))
+		fmt.Fprintf(opts.IO.Out, "  %s %v\n", opts.IO.ColorNeutral("→"), result.Err)
+	}
+
+	if failed > 0 {
+		return cmdutil.ErrSilent
+	}
+	return nil
+}
+
+// samplePayload builds the payload passed to hooks under test
+func samplePayload(ctx context.Context, opts *TestOptions) (*hooks.Payload, error) {
+	payload := &hooks.Payload{
+		Event: opts.Event,
+		Data:  map[string]interface{}{"test": true},
+	}
+
+	switch opts.Event {
+	case hooks.EventPreTaskCreate, hooks.EventPostTaskCreate:
+		if opts.TaskID == "" {
+			payload.Task = &task.CreateTaskRequest{ID: "ZEN-TEST-1", Title: "Sample task", Type: "story"}
+			break
+		}
+		t, err := opts.GetTask(ctx, opts.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load task %s: %w", opts.TaskID, err)
+		}
+		payload.Task = t
+		if opts.Event == hooks.EventPostTaskCreate {
+			payload.Data["paths"] = []string{t.WorkspacePath}
+		}
+	case hooks.EventPreSync, hooks.EventPostSync:
+		payload.Data["target"] = "tasks"
+		if opts.TaskID != "" {
+			payload.Data["tasks"] = []string{opts.TaskID}
+		}
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/hooks"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(t *testing.T, event hooks.Event, cfg hooks.Config) (*TestOptions, *bytes.Buffer, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use POSIX shell commands")
+	}
+
+	dir := t.TempDir()
+	streams := iostreams.Test()
+	runner := hooks.NewRunner(cfg, dir, streams.ErrOut, logging.NewBasic())
+
+	return &TestOptions{
+		IO:         streams,
+		HookRunner: func() (*hooks.Runner, error) { return runner, nil },
+		GetTask: func(ctx context.Context, taskID string) (*task.Task, error) {
+			return &task.Task{ID: taskID, Title: "Loaded task", WorkspacePath: filepath.Join(dir, taskID)}, nil
+		},
+		Event: event,
+	}, streams.Out.(*bytes.Buffer), dir
+}
+
+func TestTestRun_NoHooks(t *testing.T) {
+	opts, out, _ := newTestOptions(t, hooks.EventPreSync, hooks.DefaultConfig())
+
+	require.NoError(t, testRun(context.Background(), opts))
+	assert.Equal(t, "No hooks configured for pre_sync\n", out.String())
+}
+
+func TestTestRun_ReportsEveryHook(t *testing.T) {
+	opts, out, _ := newTestOptions(t, hooks.EventPreSync, hooks.Config{
+		PreSync: []hooks.Hook{
+			{Name: "fails", Run: "exit 2"},
+			{Name: "passes", Run: "true"},
+		},
+	})
+
+	err := testRun(context.Background(), opts)
+	assert.Equal(t, cmdutil.ErrSilent, err)
+	assert.Regexp(t, `(?s)✗ fails \(.*, on failure: abort\)\n  → exit status 2\n✓ passes \(`, out.String())
+}
+
+func TestTestRun_TaskPayload(t *testing.T) {
+	opts, _, dir := newTestOptions(t, hooks.EventPostTaskCreate, hooks.Config{
+		PostTaskCreate: []hooks.Hook{{Run: "cat > payload.json"}},
+	})
+	opts.TaskID = "PROJ-7"
+
+	require.NoError(t, testRun(context.Background(), opts))
+
+	data, err := os.ReadFile(filepath.Join(dir, "payload.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"id":"PROJ-7"`)
+	assert.Contains(t, string(data), `"paths":["`+filepath.Join(dir, "PROJ-7")+`"]`)
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/daddia/zen/pkg/assets"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/fs"
+	"github.com/daddia/zen/pkg/hooks"
+	"github.com/daddia/zen/pkg/policy"
 	"github.com/daddia/zen/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -17,7 +19,9 @@ import (
 // NewCmdInit creates the init command
 func NewCmdInit(f *cmdutil.Factory) *cobra.Command {
 	var force bool
+	var confirm bool
 	var configFile string
+	var fromTemplate string
 
 	cmd := &cobra.Command{
 		Use:     "init",
@@ -30,7 +34,10 @@ with default settings based on your project type. It automatically detects commo
 project types like Git repositories, Node.js, Go, Python, Rust, and Java projects.
 
 Running 'zen init' in an existing workspace is safe and will reinitialize the workspace
-without errors, similar to 'git init' behavior.
+without errors, similar to 'git init' behavior. Reinitializing with --force can be
+restricted to some users or team roles by the workspace.reinit rules in the policy
+section of the configuration; use --confirm to override them, which is recorded in
+the audit log.
 
 The .zen/ directory contains:
   - Configuration files
@@ -43,7 +50,16 @@ The .zen/ directory contains:
 If GitHub authentication is configured, zen init will automatically:
   - Set up the library infrastructure
   - Download the latest library manifest (if needed)
-  - Make library available for immediate use`,
+  - Make library available for immediate use
+
+With --from-template, the workspace is set up from a starter repository, so that
+teams across an organization start from the same setup. The starter's layout is
+its .zen directory, or the repository itself when it has none: its config (or
+config.yaml) becomes the workspace configuration, with its task workflow, labels,
+hooks and policy, and its directories, such as templates/ and hooks/, are copied
+into .zen/. Other top-level files, such as a README, and directories of workspace
+state (work, cache, logs, run, auth, backups) are left out. A branch or tag can be
+given after '#'. Existing files are kept unless --force is given.`,
 		Example: `  # Initialize in current directory (safe to run multiple times)
   zen init
 
@@ -53,9 +69,18 @@ If GitHub authentication is configured, zen init will automatically:
   # Force reinitialize with backup of existing configuration
   zen init --force
 
+  # Force reinitialize a workspace whose policy restricts it, recording the override
+  zen init --force --confirm
+
   # Initialize with custom config file location
   zen init --config ./config/zen.yaml
 
+  # Initialize from the organization's starter workspace
+  zen init --from-template https://github.com/acme/zen-starter
+
+  # Initialize from a tagged release of a starter
+  zen init --from-template git@github.com:acme/zen-starter.git#v2
+
   # Initialize with verbose output to see project detection
   zen init --verbose`,
 		Args: cobra.NoArgs,
@@ -89,6 +114,17 @@ If GitHub authentication is configured, zen init will automatically:
 				}
 			}
 
+			// Clone the starter before initializing, so that a bad template changes nothing
+			var template *starterTemplate
+			if fromTemplate != "" {
+				var cleanup func()
+				template, cleanup, err = cloneTemplate(cmd.Context(), f, fromTemplate)
+				if err != nil {
+					return err
+				}
+				defer cleanup()
+			}
+
 			// Show project detection results (only in verbose mode)
 			if f.Verbose {
 				fmt.Fprintf(f.IOStreams.Out, "Analyzing project in %s...\n", cwd)
@@ -101,6 +137,21 @@ If GitHub authentication is configured, zen init will automatically:
 				wasInitialized = status.Initialized
 			}
 
+			// Forced reinitialization can be restricted by policy
+			if force && wasInitialized {
+				cfg, err := f.Config()
+				if err != nil {
+					return fmt.Errorf("failed to load config: %w", err)
+				}
+				enforcer, err := policy.Load(cfg, ws.ZenDirectory())
+				if err != nil {
+					return err
+				}
+				if err := enforcer.Authorize(policy.Request{Operation: policy.OpWorkspaceReinit, Target: status.Root, Confirm: confirm}); err != nil {
+					return err
+				}
+			}
+
 			// Initialize workspace with force flag
 			if err := ws.InitializeWithForce(force); err != nil {
 				// Handle typed errors
@@ -134,10 +185,26 @@ If GitHub authentication is configured, zen init will automatically:
 				fmt.Fprintf(f.IOStreams.Out, "Initialized empty Zen workspace in %s/.zen/\n", cwd)
 			}
 
+			// Materialize the starter template, whose configuration replaces the initial one
+			var templateConfig bool
+			if template != nil {
+				result, err := template.apply(ws.ZenDirectory(), ws.ConfigFile(), force)
+				if err != nil {
+					return err
+				}
+				templateConfig = result.Config
+				fmt.Fprintf(f.IOStreams.Out, "%s Applied starter template %s (%d files)\n", f.IOStreams.SuccessIcon(), template.URL, len(result.Written))
+				if len(result.Kept) > 0 {
+					fmt.Fprintf(f.IOStreams.ErrOut, "%s Kept %d existing files; use --force to replace them with the template's\n", f.IOStreams.WarningIcon(), len(result.Kept))
+				}
+			}
+
 			// Create initial config file if it doesn't exist
-			if err := createInitialConfig(f); err != nil {
-				// Don't fail init if config creation fails - just warn
-				fmt.Fprintf(f.IOStreams.ErrOut, "! Warning: Failed to create initial config: %v\n", err)
+			if !templateConfig {
+				if err := createInitialConfig(f); err != nil {
+					// Don't fail init if config creation fails - just warn
+					fmt.Fprintf(f.IOStreams.ErrOut, "! Warning: Failed to create initial config: %v\n", err)
+				}
 			}
 
 			// Set up library infrastructure
@@ -147,12 +214,17 @@ If GitHub authentication is configured, zen init will automatically:
 				fmt.Fprintf(f.IOStreams.ErrOut, "  You can set up library later with 'zen assets sync'\n")
 			}
 
-			return nil
+			return hooks.Trigger(cmd.Context(), f.HookRunner, &hooks.Payload{
+				Event: hooks.EventPostInit,
+				Data:  map[string]interface{}{"reinitialized": wasInitialized},
+			})
 		},
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing configuration and create backup")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Override the policy rules that restrict --force")
 	cmd.Flags().StringVarP(&configFile, "config", "c", "", "Path to configuration file (default: zen.yaml)")
+	cmd.Flags().StringVar(&fromTemplate, "from-template", "", "Set up the workspace from a starter repository (<repo-url>[#<ref>])")
 
 	return cmd
 }
@@ -248,13 +320,16 @@ func fetchManifestBestEffort(f *cmdutil.Factory, ctx context.Context, wasReinit
 		// Verify the manifest file actually exists on disk using filesystem manager
 		if fsManager.FileExists(manifestPath) {
 			if result.AssetsUpdated > 0 {
-				fmt.Fprintf(f.IOStreams.Out, "✓ Zen library synchronized (%d assets available)\n", result.AssetsUpdated)
+				fmt.Fprintf(f.IOStreams.Out, "%s Zen library synchronized (%d assets available)\n", f.IOStreams.SuccessIcon(), result.AssetsUpdated)
 			} else {
-				fmt.Fprintf(f.IOStreams.Out, "✓ Zen library is up to date\n")
+				fmt.Fprintf(f.IOStreams.Out, "%s Zen library is up to date\n", f.IOStreams.SuccessIcon())
 			}
 		}
 		// If manifest doesn't exist, don't show any success message
 	}
+	if result.PolicyBundle != "" {
+		fmt.Fprintf(f.IOStreams.Out, "%s Installed organization policy %s\n", f.IOStreams.SuccessIcon(), result.PolicyBundle)
+	}
 
 	return nil
 }
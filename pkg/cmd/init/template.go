@@ -0,0 +1,237 @@
+package init
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// starterConfigNames are the names the configuration of a starter template may have
+var starterConfigNames = []string{"config", "config.yaml", "config.yml"}
+
+// starterStateDirs hold the state of a workspace rather than its setup, and are not
+// materialized from starter templates
+var starterStateDirs = map[string]bool{
+	".git":    true,
+	"work":    true,
+	"tasks":   true,
+	"cache":   true,
+	"logs":    true,
+	"run":     true,
+	"auth":    true,
+	"backups": true,
+}
+
+// starterTemplate is a starter workspace layout cloned from a repository
+type starterTemplate struct {
+	// URL is the repository, and Ref the branch or tag cloned, or its default branch
+	URL string
+	Ref string
+
+	dir string
+}
+
+// templateResult is what materializing a starter template wrote to a workspace
+type templateResult struct {
+	Written []string
+	Kept    []string
+	Config  bool
+}
+
+// parseTemplateRef splits a template reference of the form <repo-url>[#<ref>]
+func parseTemplateRef(value string) (url, ref string) {
+	url, ref, _ = strings.Cut(strings.TrimSpace(value), "#")
+	return url, ref
+}
+
+// cloneTemplate clones a starter template into a temporary directory, which cleanup
+// removes. HTTPS repositories are cloned with the token of their provider, when one
+// is available, so that private starters of an organization can be used.
+func cloneTemplate(ctx context.Context, f *cmdutil.Factory, value string) (*starterTemplate, func(), error) {
+	url, ref := parseTemplateRef(value)
+	if url == "" {
+		return nil, nil, &cmdutil.FlagError{Err: fmt.Errorf("--from-template needs a repository URL")}
+	}
+
+	dir, err := os.MkdirTemp("", "zen-starter-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create template directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	provider := "github"
+	if strings.Contains(url, "gitlab") {
+		provider = "gitlab"
+	}
+	var credentials git.AuthProvider
+	if authManager, err := f.AuthManager(); err == nil && authManager != nil {
+		credentials = authManager
+	}
+
+	template := &starterTemplate{URL: url, Ref: ref, dir: filepath.Join(dir, "starter")}
+	repo := git.NewCLIRepository(template.dir, f.Logger, credentials, provider)
+	if err := repo.Clone(ctx, url, ref, true); err != nil {
+		cleanup()
+		return nil, nil, &types.Error{
+			Code:    types.ErrorCodeNotFound,
+			Message: fmt.Sprintf("failed to clone starter template %s", url),
+			Details: git.OutputError(err).Error(),
+		}
+	}
+
+	if err := template.validate(); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return template, cleanup, nil
+}
+
+// root returns the directory of the layout: the .zen directory of the repository, or
+// the repository itself when it has none
+func (t *starterTemplate) root() string {
+	if info, err := os.Stat(filepath.Join(t.dir, ".zen")); err == nil && info.IsDir() {
+		return filepath.Join(t.dir, ".zen")
+	}
+	return t.dir
+}
+
+// configPath returns the path of the configuration of the template, or "" without one
+func (t *starterTemplate) configPath() string {
+	for _, name := range starterConfigNames {
+		path := filepath.Join(t.root(), name)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path
+		}
+	}
+	return ""
+}
+
+// validate checks that the configuration of the template is YAML, so that a broken
+// starter fails before anything is written to the workspace
+func (t *starterTemplate) validate() error {
+	path := t.configPath()
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read template configuration: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidConfig,
+			Message: fmt.Sprintf("starter template %s has an invalid configuration", t.URL),
+			Details: err.Error(),
+		}
+	}
+	return nil
+}
+
+// apply materializes the template in a workspace: its configuration as the workspace
+// configuration, and its directories, such as task templates and local assets, in the
+// .zen directory. Other top-level files, such as a README, are left out, as are
+// directories of workspace state. Existing files are kept unless overwrite is set.
+func (t *starterTemplate) apply(zenDir, configFile string, overwrite bool) (*templateResult, error) {
+	result := &templateResult{}
+	root := t.root()
+
+	if path := t.configPath(); path != "" {
+		written, err := copyTemplateFile(path, configFile, overwrite)
+		if err != nil {
+			return result, err
+		}
+		result.record(configFile, written)
+		result.Config = written
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return result, fmt.Errorf("failed to read starter template: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || starterStateDirs[entry.Name()] {
+			continue
+		}
+		err := filepath.WalkDir(filepath.Join(root, entry.Name()), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			// Symlinks are not followed out of the template
+			if !d.Type().IsRegular() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			target := filepath.Join(zenDir, rel)
+			written, err := copyTemplateFile(path, target, overwrite)
+			if err != nil {
+				return err
+			}
+			result.record(target, written)
+			return nil
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to materialize starter template: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *templateResult) record(path string, written bool) {
+	if written {
+		r.Written = append(r.Written, path)
+	} else {
+		r.Kept = append(r.Kept, path)
+	}
+}
+
+// copyTemplateFile copies a file with its permissions, such as those of hook scripts,
+// and reports whether it was written; existing files are kept unless overwrite is set
+func copyTemplateFile(src, dst string, overwrite bool) (bool, error) {
+	if _, err := os.Stat(dst); err == nil && !overwrite {
+		return false, nil
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return false, err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return false, err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return false, fmt.Errorf("failed to create directory for %s: %w", dst, err)
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return false, fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	if err := out.Close(); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,129 @@
+package init
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStarterRepo creates a Git repository with files, by path
+func newStarterRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "-A"},
+		{"-c", "user.name=Ada", "-c", "user.email=ada@example.com", "commit", "-q", "-m", "Starter"},
+		{"tag", "v1"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	return dir
+}
+
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(oldWd)) })
+	return dir
+}
+
+func TestInitCommand_FromTemplate(t *testing.T) {
+	starter := newStarterRepo(t, map[string]string{
+		"README.md":                          "# Acme starter",
+		".zen/config.yaml":                   "labels:\n  allowed:\n    frontend:\n      color: \"#1d76db\"\n",
+		".zen/templates/tasks/rel/task.yaml": "title: Release\n",
+		".zen/hooks/notify.sh":               "#!/bin/sh\n",
+		".zen/work/tasks/PROJ-1/index.md":    "# PROJ-1",
+		".zen/hooks.log":                     "{}",
+	})
+	dir := chdirTemp(t)
+
+	streams := iostreams.Test()
+	cmd := NewCmdInit(cmdutil.NewTestFactory(streams))
+	cmd.SetArgs([]string{"--from-template", starter + "#v1"})
+	require.NoError(t, cmd.Execute())
+
+	config, err := os.ReadFile(filepath.Join(dir, ".zen", "config"))
+	require.NoError(t, err)
+	assert.Contains(t, string(config), "frontend", "the starter's configuration is the workspace's")
+	assert.FileExists(t, filepath.Join(dir, ".zen", "templates", "tasks", "rel", "task.yaml"))
+	assert.FileExists(t, filepath.Join(dir, ".zen", "hooks", "notify.sh"))
+	assert.NoDirExists(t, filepath.Join(dir, ".zen", "work", "tasks", "PROJ-1"), "workspace state is left out")
+	assert.NoFileExists(t, filepath.Join(dir, ".zen", "hooks.log"))
+	assert.NoFileExists(t, filepath.Join(dir, ".zen", "README.md"))
+	assert.Contains(t, streams.Out.(*bytes.Buffer).String(), "✓ Applied starter template "+starter+" (3 files)\n")
+}
+
+func TestInitCommand_FromTemplateKeepsFiles(t *testing.T) {
+	starter := newStarterRepo(t, map[string]string{
+		"config":              "task:\n  id_scheme: sequence\n  id_prefix: ACME\n",
+		"templates/readme.md": "from the starter",
+	})
+	dir := chdirTemp(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".zen", "templates"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".zen", "templates", "readme.md"), []byte("local"), 0644))
+
+	streams := iostreams.Test()
+	cmd := NewCmdInit(cmdutil.NewTestFactory(streams))
+	cmd.SetArgs([]string{"--from-template", starter})
+	require.NoError(t, cmd.Execute())
+
+	readme, err := os.ReadFile(filepath.Join(dir, ".zen", "templates", "readme.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "local", string(readme), "existing files are kept without --force")
+	config, err := os.ReadFile(filepath.Join(dir, ".zen", "config"))
+	require.NoError(t, err)
+	assert.Contains(t, string(config), "id_prefix: ACME", "starters without a .zen directory are their layout")
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "Kept 1 existing files")
+}
+
+func TestInitCommand_FromTemplateErrors(t *testing.T) {
+	invalid := newStarterRepo(t, map[string]string{"config.yaml": "labels: [unclosed"})
+	tests := []struct {
+		name     string
+		template string
+		code     types.ErrorCode
+	}{
+		{name: "missing repository", template: filepath.Join(t.TempDir(), "missing"), code: types.ErrorCodeNotFound},
+		{name: "invalid configuration", template: invalid, code: types.ErrorCodeInvalidConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := chdirTemp(t)
+			cmd := NewCmdInit(cmdutil.NewTestFactory(iostreams.Test()))
+			cmd.SetArgs([]string{"--from-template", tt.template})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			var zenErr *types.Error
+			require.ErrorAs(t, err, &zenErr)
+			assert.Equal(t, tt.code, zenErr.Code)
+			assert.NoDirExists(t, filepath.Join(dir, ".zen"), "nothing is written when the template fails")
+		})
+	}
+}
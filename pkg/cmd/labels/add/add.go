@@ -0,0 +1,118 @@
+package add
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/label"
+	"github.com/spf13/cobra"
+)
+
+// AddOptions contains options for the labels add command
+type AddOptions struct {
+	IO              *iostreams.IOStreams
+	LabelConfig     func() (label.Config, error)
+	SaveLabelConfig func(label.Config) error
+
+	Name        string
+	Description string
+	Color       string
+
+	// Providers are the names of the label in the providers, by provider
+	Providers map[string]string
+}
+
+// NewCmdAdd creates the labels add command
+func NewCmdAdd(f *cmdutil.Factory, runF func(*AddOptions) error) *cobra.Command {
+	opts := &AddOptions{
+		IO: f.IOStreams,
+		LabelConfig: func() (label.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return label.Config{}, err
+			}
+			return config.GetConfig(cfg, label.ConfigParser{})
+		},
+		SaveLabelConfig: func(labels label.Config) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+			return config.SetConfig(cfg, label.ConfigParser{}, labels)
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a label to the vocabulary",
+		Long: heredoc.Doc(`
+			Add a label to the vocabulary of the workspace, or update the color,
+			description, and provider names given of a label already defined.
+
+			A provider name is the name of the label in a system tasks are synced with,
+			where it differs. Syncing pushes the label under that name and maps the
+			provider's label back to this one.
+		`),
+		Example: heredoc.Doc(`
+			# Add a label
+			zen labels add bug --color "#d73a4a" --description "Something is broken"
+
+			# Map a label to its names in Jira and GitHub
+			zen labels add frontend --provider jira=team-frontend --provider "github=area: frontend"
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+
+			if err := label.ValidateName(opts.Name); err != nil {
+				return &cmdutil.FlagError{Err: err}
+			}
+			for provider, name := range opts.Providers {
+				if provider == "" || name == "" {
+					return &cmdutil.FlagError{Err: fmt.Errorf("invalid --provider %s=%s (use <provider>=<name>)", provider, name)}
+				}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return addRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the label")
+	cmd.Flags().StringVar(&opts.Color, "color", "", "Hex color of the label, such as #1d76db")
+	cmd.Flags().StringToStringVar(&opts.Providers, "provider", nil, "Name of the label in a provider, as `provider=name`")
+
+	return cmd
+}
+
+func addRun(opts *AddOptions) error {
+	labels, err := opts.LabelConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get label config: %w", err)
+	}
+
+	added, err := labels.Add(opts.Name, label.Label{
+		Description: opts.Description,
+		Color:       opts.Color,
+		Providers:   opts.Providers,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := opts.SaveLabelConfig(labels); err != nil {
+		return fmt.Errorf("failed to save label config: %w", err)
+	}
+
+	if added {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Added label %s\n", opts.IO.SuccessIcon(), opts.Name)
+	} else {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Updated label %s\n", opts.IO.SuccessIcon(), opts.Name)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package add
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/label"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    AddOptions
+		wantErr string
+	}{
+		{
+			name: "name only",
+			args: []string{"bug"},
+			want: AddOptions{Name: "bug"},
+		},
+		{
+			name: "all flags",
+			args: []string{"frontend", "--color", "#1d76db", "--description", "Web UI", "--provider", "jira=team-frontend", "--provider", "github=area: frontend"},
+			want: AddOptions{Name: "frontend", Color: "#1d76db", Description: "Web UI", Providers: map[string]string{"jira": "team-frontend", "github": "area: frontend"}},
+		},
+		{
+			name:    "invalid name",
+			args:    []string{"needs review"},
+			wantErr: "labels cannot contain whitespace or commas",
+		},
+		{
+			name:    "empty provider name",
+			args:    []string{"bug", "--provider", "jira="},
+			wantErr: "invalid --provider jira=",
+		},
+		{
+			name:    "missing name",
+			args:    []string{},
+			wantErr: "accepts 1 arg(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *AddOptions
+			cmd := NewCmdAdd(cmdutil.NewTestFactory(iostreams.Test()), func(opts *AddOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Name, got.Name)
+			assert.Equal(t, tt.want.Color, got.Color)
+			assert.Equal(t, tt.want.Description, got.Description)
+			if tt.want.Providers != nil {
+				assert.Equal(t, tt.want.Providers, got.Providers)
+			} else {
+				assert.Empty(t, got.Providers)
+			}
+		})
+	}
+}
+
+func newTestOptions(cfg label.Config) (*AddOptions, *label.Config, *bytes.Buffer) {
+	streams := iostreams.Test()
+	saved := &label.Config{}
+	return &AddOptions{
+		IO:          streams,
+		LabelConfig: func() (label.Config, error) { return cfg, nil },
+		SaveLabelConfig: func(c label.Config) error {
+			*saved = c
+			return nil
+		},
+	}, saved, streams.ErrOut.(*bytes.Buffer)
+}
+
+func TestAddRun(t *testing.T) {
+	opts, saved, errOut := newTestOptions(label.DefaultConfig())
+	opts.Name, opts.Color, opts.Providers = "frontend", "#1d76db", map[string]string{"jira": "team-frontend"}
+
+	require.NoError(t, addRun(opts))
+
+	assert.Equal(t, label.Label{Color: "#1d76db", Providers: map[string]string{"jira": "team-frontend"}}, saved.Allowed["frontend"])
+	assert.Equal(t, "✓ Added label frontend\n", errOut.String())
+}
+
+func TestAddRun_Updates(t *testing.T) {
+	opts, saved, errOut := newTestOptions(label.Config{Allowed: map[string]label.Label{
+		"bug": {Description: "Something is broken", Color: "#d73a4a"},
+	}})
+	opts.Name, opts.Color = "bug", "#b60205"
+
+	require.NoError(t, addRun(opts))
+
+	assert.Equal(t, label.Label{Description: "Something is broken", Color: "#b60205"}, saved.Allowed["bug"], "the description is kept")
+	assert.Equal(t, "✓ Updated label bug\n", errOut.String())
+}
+
+func TestAddRun_InvalidColor(t *testing.T) {
+	opts, _, _ := newTestOptions(label.DefaultConfig())
+	opts.Name, opts.Color = "bug", "red"
+
+	assert.EqualError(t, addRun(opts), "invalid color: red (must be a hex color such as #1d76db)")
+}
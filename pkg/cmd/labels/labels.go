@@ -0,0 +1,40 @@
+package labels
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/labels/add"
+	"github.com/daddia/zen/pkg/cmd/labels/list"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdLabels creates the labels command with subcommands
+func NewCmdLabels(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "labels <command>",
+		Short: "Manage the label vocabulary of the workspace",
+		Long: heredoc.Doc(`
+			Manage the labels in the labels section of the configuration: the labels
+			tasks may carry, their colors and descriptions, and their names in the
+			systems tasks are synced with.
+
+			Once a label is defined, tasks can only be created and updated with the
+			labels of the vocabulary, compared case-insensitively. Syncing pushes each
+			label under its name in the provider and maps the provider's labels back,
+			keeping those the vocabulary does not know.
+		`),
+		Example: heredoc.Doc(`
+			# List the labels
+			zen labels list
+
+			# Define a label named differently in Jira
+			zen labels add frontend --color "#1d76db" --provider jira=team-frontend
+		`),
+		GroupID: "core",
+	}
+
+	cmd.AddCommand(list.NewCmdList(f, nil))
+	cmd.AddCommand(add.NewCmdAdd(f, nil))
+
+	return cmd
+}
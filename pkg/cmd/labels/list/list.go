@@ -0,0 +1,121 @@
+package list
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/label"
+	"github.com/spf13/cobra"
+)
+
+// ListOptions contains options for the labels list command
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	LabelConfig  func() (label.Config, error)
+	OutputFormat string
+	Template     string
+	JQ           string
+}
+
+// Entry is a label as listed
+type Entry struct {
+	Name string `json:"name"`
+	label.Label
+}
+
+// NewCmdList creates the labels list command
+func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IO: f.IOStreams,
+		LabelConfig: func() (label.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return label.Config{}, err
+			}
+			return config.GetConfig(cfg, label.ConfigParser{})
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the labels of the vocabulary",
+		Example: heredoc.Doc(`
+			$ zen labels list
+			$ zen labels list --jq '.[] | select(.providers.jira) | .name'
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return listRun(opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func listRun(opts *ListOptions) error {
+	cfg, err := opts.LabelConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get label config: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(cfg.Allowed))
+	for _, name := range cfg.Names() {
+		entries = append(entries, Entry{Name: name, Label: cfg.Allowed[name]})
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(entries, func(w io.Writer) error {
+		return displayListText(w, opts.IO, entries)
+	})
+}
+
+func displayListText(w io.Writer, streams *iostreams.IOStreams, entries []Entry) error {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No labels defined; tasks may carry any label.")
+		if streams.IsStdoutTTY() {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, streams.ColorNeutral("Add one with 'zen labels add <name>'"))
+		}
+		return nil
+	}
+
+	headers := []string{"NAME", "COLOR", "DESCRIPTION", "PROVIDERS"}
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		providers := make([]string, 0, len(entry.Providers))
+		for provider, name := range entry.Providers {
+			providers = append(providers, fmt.Sprintf("%s: %s", provider, name))
+		}
+		sort.Strings(providers)
+
+		color := entry.Color
+		if color == "" {
+			color = "-"
+		}
+
+		rows = append(rows, []string{entry.Name, color, entry.Description, strings.Join(providers, ", ")})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
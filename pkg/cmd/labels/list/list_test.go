@@ -0,0 +1,59 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/label"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(cfg label.Config) (*ListOptions, *bytes.Buffer) {
+	streams := iostreams.Test()
+	return &ListOptions{
+		IO:           streams,
+		LabelConfig:  func() (label.Config, error) { return cfg, nil },
+		OutputFormat: cmdutil.OutputText,
+	}, streams.Out.(*bytes.Buffer)
+}
+
+func testConfig() label.Config {
+	return label.Config{
+		Allowed: map[string]label.Label{
+			"frontend": {Color: "#1d76db", Providers: map[string]string{"jira": "team-frontend", "github": "area: frontend"}},
+			"bug":      {Description: "Something is broken", Color: "#d73a4a"},
+		},
+	}
+}
+
+func TestListRun_Empty(t *testing.T) {
+	opts, out := newTestOptions(label.DefaultConfig())
+
+	require.NoError(t, listRun(opts))
+	assert.Equal(t, "No labels defined; tasks may carry any label.\n", out.String())
+}
+
+func TestListRun_Text(t *testing.T) {
+	opts, out := newTestOptions(testConfig())
+
+	require.NoError(t, listRun(opts))
+	assert.Equal(t, "bug\t#d73a4a\tSomething is broken\t\nfrontend\t#1d76db\t\tgithub: area: frontend, jira: team-frontend\n", out.String())
+}
+
+func TestListRun_JSON(t *testing.T) {
+	opts, out := newTestOptions(testConfig())
+	opts.OutputFormat = cmdutil.OutputJSON
+
+	require.NoError(t, listRun(opts))
+
+	var entries []map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "frontend", entries[1]["name"])
+	assert.Equal(t, "#1d76db", entries[1]["color"])
+	assert.Equal(t, map[string]interface{}{"jira": "team-frontend", "github": "area: frontend"}, entries[1]["providers"])
+}
@@ -0,0 +1,284 @@
+package flow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/metrics"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/team"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TokenEnv names the environment variable holding the token for --push
+const TokenEnv = "ZEN_METRICS_TOKEN"
+
+// TaskLister lists tasks in the workspace
+type TaskLister interface {
+	ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error)
+}
+
+// FlowOptions contains options for the metrics flow command
+type FlowOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskLister, error)
+	HTTPClient       func() *http.Client
+	TeamConfig       func() (team.Config, error)
+	Now              func() time.Time
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	Since  string
+	Filter task.TaskFilter
+	Push   string
+	Token  string
+}
+
+// NewCmdFlow creates the metrics flow command
+func NewCmdFlow(f *cmdutil.Factory, runF func(*FlowOptions) error) *cobra.Command {
+	opts := &FlowOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskLister, error) {
+			return task.NewManager(f), nil
+		},
+		HTTPClient: func() *http.Client {
+			return httpx.New(httpx.Options{Provider: "metrics", Logger: f.Logger})
+		},
+		TeamConfig: func() (team.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return team.Config{}, err
+			}
+			return config.GetConfig(cfg, team.ConfigParser{})
+		},
+		Now: time.Now,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "flow",
+		Short: "Show cycle time, lead time, throughput, and WIP",
+		Long: heredoc.Docf(`
+			Show the flow metrics of the tasks in the workspace over a period, overall,
+			by team, and by task type:
+
+			  throughput   tasks finished in the period, and per week
+			  wip          tasks started and not finished at the end of the period
+			  cycle time   time from start to finish of the tasks finished in the period
+			  lead time    time from creation to finish of the tasks finished in the period
+
+			A task starts when 'zen task start' runs or it first leaves the Align stage,
+			and finishes when 'zen task finish' runs or it reaches the %[1]s stage. Stage
+			changes are recorded in the task manifest.
+
+			Teams with a capacity in the team configuration show their WIP against it,
+			such as 4/5, and are highlighted when they are over capacity.
+
+			Use --push to post the metrics as JSON to an external endpoint, such as a
+			dashboard collector. The request carries the token in $%[2]s as a
+			bearer token when it is set.
+		`, task.StageName(metrics.DoneStage), TokenEnv),
+		Example: heredoc.Doc(`
+			# Show flow metrics for the last 30 days
+			zen metrics flow --since 30d
+
+			# Show flow metrics of a team since a date
+			zen metrics flow --team platform --since 2026-09-01
+
+			# Print the median cycle time of each task type
+			zen metrics flow --jq '.by_type[] | {name, median: .cycle_time.median_days}'
+
+			# Push the metrics to a collector
+			zen metrics flow --push https://metrics.example.com/zen
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+			opts.Token = os.Getenv(TokenEnv)
+
+			if _, err := metrics.ParseSince(opts.Since, time.Now()); err != nil {
+				return &cmdutil.FlagError{Err: err}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return flowRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Since, "since", "30d", "Start of the period: an age such as 30d, 6w, or 12h, or a date")
+	cmd.Flags().StringVar(&opts.Filter.Type, "type", "", "Filter by task type")
+	cmd.Flags().StringVar(&opts.Filter.Team, "team", "", "Filter by team")
+	cmd.Flags().StringVar(&opts.Filter.Owner, "owner", "", "Filter by owner")
+	cmd.Flags().StringSliceVar(&opts.Filter.Labels, "label", nil, "Filter by label (repeatable)")
+	cmd.Flags().StringVar(&opts.Push, "push", "", "Post the metrics as JSON to this URL")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func flowRun(ctx context.Context, opts *FlowOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	now := opts.Now()
+	since, err := metrics.ParseSince(opts.Since, now)
+	if err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	tasks, err := manager.ListTasks(ctx, &opts.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	flow := metrics.ComputeFlow(tasks, since, now)
+
+	teamConfig, err := opts.TeamConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get team config: %w", err)
+	}
+	for i := range flow.ByTeam {
+		if t, ok := teamConfig.Team(flow.ByTeam[i].Name); ok {
+			flow.ByTeam[i].Capacity = t.Capacity
+		}
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+
+	if opts.Push != "" {
+		if err := metrics.Push(ctx, opts.HTTPClient(), opts.Push, opts.Token, flow); err != nil {
+			return err
+		}
+		fmt.Fprintf(renderer.Progress(), "%s Pushed metrics to %s\n", opts.IO.SuccessIcon(), opts.Push)
+	}
+
+	return renderer.Render(flow, func(w io.Writer) error {
+		return displayFlowText(w, opts.IO, flow)
+	})
+}
+
+func displayFlowText(w io.Writer, streams *iostreams.IOStreams, flow *metrics.Flow) error {
+	fmt.Fprintln(w, streams.FormatHeader(fmt.Sprintf("Flow metrics, %s to %s",
+		flow.Since.Format("2006-01-02"), flow.Until.Format("2006-01-02"))))
+	fmt.Fprintln(w)
+
+	overall := flow.Overall
+	fmt.Fprintf(w, "  Throughput  %d finished (%s per week)\n", overall.Throughput, formatNumber(overall.ThroughputPerWeek))
+	fmt.Fprintf(w, "  WIP         %d in progress\n", overall.WIP)
+	fmt.Fprintf(w, "  Cycle time  %s\n", formatSummary(overall.CycleTime))
+	fmt.Fprintf(w, "  Lead time   %s\n", formatSummary(overall.LeadTime))
+
+	for _, group := range []struct {
+		title string
+		stats []metrics.Stats
+	}{
+		{"By team", flow.ByTeam},
+		{"By type", flow.ByType},
+	} {
+		if len(group.stats) == 0 {
+			continue
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, streams.FormatSubHeader(group.title))
+
+		headers := []string{"NAME", "THROUGHPUT", "WIP", "CYCLE P50", "CYCLE P85", "LEAD P50", "LEAD P85"}
+		rows := make([][]string, 0, len(group.stats))
+		for _, s := range group.stats {
+			rows = append(rows, []string{
+				s.Name,
+				strconv.Itoa(s.Throughput),
+				formatWIP(streams, s),
+				formatDays(s.CycleTime, s.CycleTime.MedianDays),
+				formatDays(s.CycleTime, s.CycleTime.P85Days),
+				formatDays(s.LeadTime, s.LeadTime.MedianDays),
+				formatDays(s.LeadTime, s.LeadTime.P85Days),
+			})
+		}
+		if streams.IsStdoutTTY() {
+			fmt.Fprint(w, streams.FormatTable(headers, rows))
+		} else {
+			fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+		}
+	}
+
+	return nil
+}
+
+// formatWIP formats the WIP of a group, against its capacity when it has one
+func formatWIP(streams *iostreams.IOStreams, s metrics.Stats) string {
+	if s.Capacity == 0 {
+		return strconv.Itoa(s.WIP)
+	}
+	wip := fmt.Sprintf("%d/%d", s.WIP, s.Capacity)
+	if s.WIP > s.Capacity {
+		return streams.ColorWarning(wip)
+	}
+	return wip
+}
+
+// formatSummary describes a summary, such as "median 3d, 85th percentile 6.5d, mean 4d (12 tasks)"
+func formatSummary(s metrics.Summary) string {
+	if s.Count == 0 {
+		return "no finished tasks"
+	}
+	tasks := "tasks"
+	if s.Count == 1 {
+		tasks = "task"
+	}
+	return fmt.Sprintf("median %sd, 85th percentile %sd, mean %sd (%d %s)",
+		formatNumber(s.MedianDays), formatNumber(s.P85Days), formatNumber(s.MeanDays), s.Count, tasks)
+}
+
+// formatDays formats a number of days of a summary, or "-" when it has no tasks
+func formatDays(s metrics.Summary, days float64) string {
+	if s.Count == 0 {
+		return "-"
+	}
+	return formatNumber(days) + "d"
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
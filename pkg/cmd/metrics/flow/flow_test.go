@@ -0,0 +1,186 @@
+package flow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/metrics"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/team"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	tasks  []*task.Task
+	filter *task.TaskFilter
+}
+
+func (m *mockTaskManager) ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error) {
+	m.filter = filter
+	return m.tasks, nil
+}
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func daysAgo(n int) *time.Time {
+	t := testNow.AddDate(0, 0, -n)
+	return &t
+}
+
+func newTestOptions(t *testing.T) (*FlowOptions, *mockTaskManager, *bytes.Buffer) {
+	t.Helper()
+	manager := &mockTaskManager{tasks: []*task.Task{
+		{ID: "PROJ-1", Type: "story", Team: "web", Created: *daysAgo(10),
+			Git: &task.GitInfo{StartedAt: daysAgo(6), FinishedAt: daysAgo(2)}},
+		{ID: "PROJ-2", Type: "bug", Team: "api",
+			Git: &task.GitInfo{StartedAt: daysAgo(3)}},
+	}}
+
+	streams := iostreams.Test()
+	return &FlowOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskLister, error) { return manager, nil },
+		HTTPClient:       func() *http.Client { return http.DefaultClient },
+		TeamConfig:       func() (team.Config, error) { return team.DefaultConfig(), nil },
+		Now:              func() time.Time { return testNow },
+		OutputFormat:     cmdutil.OutputText,
+		Since:            "30d",
+	}, manager, streams.Out.(*bytes.Buffer)
+}
+
+func TestNewCmdFlow(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     string
+		want    FlowOptions
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: FlowOptions{Since: "30d"},
+		},
+		{
+			name: "filters and push",
+			args: []string{"--since", "2026-09-01", "--team", "web", "--type", "bug", "--push", "https://metrics.example.com"},
+			env:  "s3cret",
+			want: FlowOptions{
+				Since:  "2026-09-01",
+				Filter: task.TaskFilter{Team: "web", Type: "bug"},
+				Push:   "https://metrics.example.com",
+				Token:  "s3cret",
+			},
+		},
+		{
+			name:    "invalid since",
+			args:    []string{"--since", "a while"},
+			wantErr: `invalid period "a while"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(TokenEnv, tt.env)
+
+			var got *FlowOptions
+			cmd := NewCmdFlow(cmdutil.NewTestFactory(iostreams.Test()), func(opts *FlowOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Since, got.Since)
+			assert.Equal(t, tt.want.Filter, got.Filter)
+			assert.Equal(t, tt.want.Push, got.Push)
+			assert.Equal(t, tt.want.Token, got.Token)
+		})
+	}
+}
+
+func TestFlowRun_Text(t *testing.T) {
+	opts, manager, out := newTestOptions(t)
+	opts.Filter = task.TaskFilter{Owner: "ana"}
+
+	require.NoError(t, flowRun(context.Background(), opts))
+
+	assert.Equal(t, "ana", manager.filter.Owner, "filters are passed to the task manager")
+	assert.Contains(t, out.String(), "Flow metrics, 2026-09-16 to 2026-10-16")
+	assert.Contains(t, out.String(), "Throughput  1 finished (0.2 per week)")
+	assert.Contains(t, out.String(), "WIP         1 in progress")
+	assert.Contains(t, out.String(), "Cycle time  median 4d, 85th percentile 4d, mean 4d (1 task)")
+	assert.Contains(t, out.String(), "Lead time   median 8d")
+	assert.Contains(t, out.String(), "By team")
+}
+
+func TestFlowRun_JSON(t *testing.T) {
+	opts, _, out := newTestOptions(t)
+	opts.OutputFormat = cmdutil.OutputJSON
+
+	require.NoError(t, flowRun(context.Background(), opts))
+
+	var flow metrics.Flow
+	require.NoError(t, json.Unmarshal(out.Bytes(), &flow))
+	assert.Equal(t, 1, flow.Overall.Throughput)
+	assert.Equal(t, 1, flow.Overall.WIP)
+	require.Len(t, flow.ByType, 2)
+	assert.Equal(t, "story", flow.ByType[0].Name)
+}
+
+func TestFlowRun_TeamCapacity(t *testing.T) {
+	opts, _, out := newTestOptions(t)
+	opts.TeamConfig = func() (team.Config, error) {
+		return team.Config{Teams: map[string]team.Team{"api": {Capacity: 3}}}, nil
+	}
+
+	require.NoError(t, flowRun(context.Background(), opts))
+
+	assert.Regexp(t, `api\s+0\s+1/3`, out.String())
+	assert.Regexp(t, `web\s+1\s+0\s`, out.String(), "teams without a capacity show their WIP alone")
+}
+
+func TestFlowRun_Push(t *testing.T) {
+	var pushed metrics.Flow
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&pushed))
+	}))
+	defer server.Close()
+
+	opts, _, out := newTestOptions(t)
+	opts.Push, opts.Token = server.URL, "s3cret"
+
+	require.NoError(t, flowRun(context.Background(), opts))
+
+	assert.Equal(t, "Bearer s3cret", auth)
+	assert.Equal(t, 1, pushed.Overall.Throughput)
+	assert.Contains(t, out.String(), "Pushed metrics to "+server.URL)
+}
+
+func TestFlowRun_WorkspaceNotInitialized(t *testing.T) {
+	opts, _, _ := newTestOptions(t)
+	opts.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) { return zentest.NewWorkspace(t), nil }
+
+	err := flowRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workspace not initialized")
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/metrics/flow"
+	"github.com/daddia/zen/pkg/cmd/metrics/sprint"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdMetrics creates the metrics command with subcommands
+func NewCmdMetrics(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics <command>",
+		Short: "Measure delivery",
+		Long: heredoc.Doc(`
+			Measure how work flows through the workspace, from the workflow stages and
+			start and finish times recorded on tasks, and how sprints in the external
+			task system burn down.
+		`),
+		Example: heredoc.Doc(`
+			# Show cycle time, lead time, throughput, and WIP for the last 30 days
+			zen metrics flow --since 30d
+
+			# Show the burndown and completion forecast of the active sprint
+			zen metrics sprint
+		`),
+		GroupID: "core",
+	}
+
+	cmd.AddCommand(flow.NewCmdFlow(f, nil))
+	cmd.AddCommand(sprint.NewCmdSprint(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,244 @@
+package sprint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/integration/factory"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/metrics"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// chartWidth is the number of columns of a full bar of the burndown chart
+const chartWidth = 40
+
+// SprintOptions contains options for the metrics sprint command
+type SprintOptions struct {
+	IO           *iostreams.IOStreams
+	Config       func() (*config.Config, error)
+	SprintSource func(ctx context.Context, source string) (plugin.SprintFetcher, error)
+	Now          func() time.Time
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	Source string
+	Sprint string
+	Trials int
+}
+
+// NewCmdSprint creates the metrics sprint command
+func NewCmdSprint(f *cmdutil.Factory, runF func(*SprintOptions) error) *cobra.Command {
+	opts := &SprintOptions{
+		IO:     f.IOStreams,
+		Config: f.Config,
+		SprintSource: func(ctx context.Context, source string) (plugin.SprintFetcher, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get config: %w", err)
+			}
+			authMgr, err := f.AuthManager()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get auth manager: %w", err)
+			}
+
+			instance, err := factory.NewClientFactory(f.Logger, cfg, authMgr, nil).CreatePlugin(ctx, source)
+			if err != nil {
+				return nil, err
+			}
+			fetcher, ok := instance.(plugin.SprintFetcher)
+			if !ok {
+				return nil, &types.Error{
+					Code:    types.ErrorCodeInvalidConfig,
+					Message: fmt.Sprintf("the %s provider does not provide sprint data", source),
+					Details: "sprint metrics are available from jira",
+				}
+			}
+			return fetcher, nil
+		},
+		Now: time.Now,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sprint",
+		Short: "Show the burndown and completion forecast of a sprint",
+		Long: heredoc.Doc(`
+			Show the burndown of a sprint in the external task system, and forecast when
+			its remaining scope completes.
+
+			The sprint scope and completion dates come from the provider configured as
+			task.task_source, or the one given with --source. The scope is measured in
+			story points when items are estimated, and in items otherwise.
+
+			The forecast is computed locally. It simulates the rest of the sprint many
+			times by repeating randomly chosen days of the sprint so far, and reports the
+			share of simulations that complete by the sprint end, and the dates by which
+			half and 85% of them complete.
+
+			For Jira, the sprint is read from settings.board_id of the provider, or the
+			first scrum board of its project, and story points from
+			settings.story_points_field, or customfield_10016.
+		`),
+		Example: heredoc.Doc(`
+			# Show the burndown and forecast of the active sprint
+			zen metrics sprint
+
+			# Show a sprint by name
+			zen metrics sprint --sprint "Sprint 42"
+
+			# Print the chance of completing the active sprint on time
+			zen metrics sprint --jq '.forecast.probability'
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if opts.Trials < 1 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--trials must be at least 1")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return sprintRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Source, "source", "", "Provider to read the sprint from (default task.task_source)")
+	cmd.Flags().StringVar(&opts.Sprint, "sprint", "", "Name of the sprint (default the active sprint)")
+	cmd.Flags().IntVar(&opts.Trials, "trials", metrics.DefaultTrials, "Number of simulated sprints for the forecast")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func sprintRun(ctx context.Context, opts *SprintOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	source := opts.Source
+	if source == "" {
+		cfg, err := opts.Config()
+		if err != nil {
+			return fmt.Errorf("failed to get config: %w", err)
+		}
+		source = cfg.Task.TaskSource
+	}
+	if source == "" || source == "none" || source == "local" {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidConfig,
+			Message: "no external task system configured",
+			Details: "use --source, or run 'zen config set task.task_source jira'",
+		}
+	}
+
+	fetcher, err := opts.SprintSource(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	sprint, err := fetcher.FetchSprint(ctx, opts.Sprint)
+	if err != nil {
+		return err
+	}
+
+	report := metrics.ComputeSprint(sprint, opts.Now(), opts.Trials)
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+
+	return renderer.Render(report, func(w io.Writer) error {
+		return displaySprintText(w, opts.IO, report)
+	})
+}
+
+func displaySprintText(w io.Writer, streams *iostreams.IOStreams, report *metrics.SprintReport) error {
+	fmt.Fprintln(w, streams.FormatHeader(fmt.Sprintf("%s (%s), %s to %s", report.Name, report.State,
+		report.Start.Format("2006-01-02"), report.End.Format("2006-01-02"))))
+	fmt.Fprintf(w, "%s %s, %s completed, %s remaining\n",
+		formatNumber(report.Scope), report.Unit, formatNumber(report.Completed), formatNumber(report.Remaining))
+	fmt.Fprintln(w)
+
+	fmt.Fprint(w, burndownChart(report))
+	fmt.Fprintf(w, "\n  %s\n\n", streams.ColorNeutral(fmt.Sprintf("# remaining %s   | ideal", report.Unit)))
+
+	fmt.Fprintf(w, "Forecast  %s\n", formatForecast(report))
+	return nil
+}
+
+// burndownChart draws a bar of the remaining scope of each day of a sprint, with the
+// ideal remaining scope marked with '|'
+func burndownChart(report *metrics.SprintReport) string {
+	scale := 0.0
+	for _, day := range report.Burndown {
+		scale = math.Max(scale, math.Max(day.Scope, day.Ideal))
+	}
+
+	column := func(value float64) int {
+		if scale == 0 {
+			return 0
+		}
+		return int(math.Round(value / scale * chartWidth))
+	}
+
+	var b strings.Builder
+	for _, day := range report.Burndown {
+		row := []rune(strings.Repeat(" ", chartWidth+1))
+		if day.Remaining != nil {
+			for i := 0; i < column(*day.Remaining); i++ {
+				row[i] = '#'
+			}
+		}
+		row[column(day.Ideal)] = '|'
+
+		line := fmt.Sprintf("  %s  %s", day.Date.Format("Mon 01-02"), string(row))
+		if day.Remaining != nil {
+			line += "  " + formatNumber(*day.Remaining)
+		}
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return b.String()
+}
+
+// formatForecast describes a forecast, such as "72% chance to complete by 2026-10-19;
+// 50% by 2026-10-18, 85% by 2026-10-21"
+func formatForecast(report *metrics.SprintReport) string {
+	forecast := report.Forecast
+	if report.Remaining <= 0 {
+		return "all scope completed"
+	}
+	if forecast.Days == 0 {
+		return "not enough history; the forecast starts after the first day of the sprint"
+	}
+	if forecast.DailyRate == 0 {
+		return "nothing completed yet; no forecast"
+	}
+
+	date := func(t *time.Time) string {
+		if t == nil {
+			return "not within a year"
+		}
+		return t.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%d%% chance to complete by %s; 50%% by %s, 85%% by %s (%s %s per day)",
+		int(math.Round(forecast.Probability*100)), report.End.Format("2006-01-02"),
+		date(forecast.P50), date(forecast.P85), formatNumber(forecast.DailyRate), report.Unit)
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,156 @@
+package sprint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sprintFetcher struct {
+	sprint *plugin.Sprint
+	name   string
+}
+
+func (f *sprintFetcher) FetchSprint(ctx context.Context, name string) (*plugin.Sprint, error) {
+	f.name = name
+	return f.sprint, nil
+}
+
+func date(d, hour int) *time.Time {
+	t := time.Date(2026, 10, d, hour, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func points(p float64) *float64 {
+	return &p
+}
+
+func newTestOptions(t *testing.T) (*SprintOptions, *sprintFetcher, *bytes.Buffer) {
+	t.Helper()
+	fetcher := &sprintFetcher{sprint: &plugin.Sprint{
+		ID:    "42",
+		Name:  "Sprint 42",
+		State: "active",
+		Start: *date(12, 9),
+		End:   *date(16, 17),
+		Items: []plugin.SprintItem{
+			{ExternalID: "PROJ-1", Points: points(5), Completed: date(12, 15)},
+			{ExternalID: "PROJ-2", Points: points(3), Completed: date(13, 11)},
+			{ExternalID: "PROJ-3", Points: points(8)},
+		},
+	}}
+
+	streams := iostreams.Test()
+	return &SprintOptions{
+		IO: streams,
+		Config: func() (*config.Config, error) {
+			cfg := &config.Config{}
+			cfg.Task.TaskSource = "jira"
+			return cfg, nil
+		},
+		SprintSource: func(ctx context.Context, source string) (plugin.SprintFetcher, error) {
+			assert.Equal(t, "jira", source)
+			return fetcher, nil
+		},
+		Now:          func() time.Time { return *date(14, 12) },
+		OutputFormat: cmdutil.OutputText,
+		Trials:       1000,
+	}, fetcher, streams.Out.(*bytes.Buffer)
+}
+
+func TestNewCmdSprint(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    SprintOptions
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: SprintOptions{Trials: metrics.DefaultTrials},
+		},
+		{
+			name: "sprint and source",
+			args: []string{"--sprint", "Sprint 41", "--source", "jira", "--trials", "500"},
+			want: SprintOptions{Sprint: "Sprint 41", Source: "jira", Trials: 500},
+		},
+		{
+			name:    "no trials",
+			args:    []string{"--trials", "0"},
+			wantErr: "--trials must be at least 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *SprintOptions
+			cmd := NewCmdSprint(cmdutil.NewTestFactory(iostreams.Test()), func(opts *SprintOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Sprint, got.Sprint)
+			assert.Equal(t, tt.want.Source, got.Source)
+			assert.Equal(t, tt.want.Trials, got.Trials)
+		})
+	}
+}
+
+func TestSprintRun_Text(t *testing.T) {
+	opts, fetcher, out := newTestOptions(t)
+	opts.Sprint = "Sprint 42"
+
+	require.NoError(t, sprintRun(context.Background(), opts))
+
+	assert.Equal(t, "Sprint 42", fetcher.name)
+	assert.Contains(t, out.String(), "Sprint 42 (active), 2026-10-12 to 2026-10-16")
+	assert.Contains(t, out.String(), "16 points, 8 completed, 8 remaining")
+	assert.Contains(t, out.String(), "Mon 10-12  ############################      |        11\n")
+	assert.Contains(t, out.String(), "Wed 10-14  ################|###                       8\n", "the ideal marker overlays the bar")
+	assert.Contains(t, out.String(), "Thu 10-15         |\n", "days to come have only the ideal marker")
+	assert.Contains(t, out.String(),
+		"Forecast  100% chance to complete by 2026-10-16; 50% by 2026-10-15, 85% by 2026-10-16 (4 points per day)")
+}
+
+func TestSprintRun_JSON(t *testing.T) {
+	opts, _, out := newTestOptions(t)
+	opts.OutputFormat = cmdutil.OutputJSON
+
+	require.NoError(t, sprintRun(context.Background(), opts))
+
+	var report metrics.SprintReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, "Sprint 42", report.Name)
+	assert.Equal(t, 8.0, report.Remaining)
+	assert.Len(t, report.Burndown, 5)
+	assert.Equal(t, 1000, report.Forecast.Trials)
+}
+
+func TestSprintRun_NoSource(t *testing.T) {
+	opts, _, _ := newTestOptions(t)
+	opts.Config = func() (*config.Config, error) { return &config.Config{}, nil }
+
+	err := sprintRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no external task system configured")
+}
@@ -0,0 +1,379 @@
+package jira
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/integration/factory"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TaskMigrator creates tasks from the items of exports
+type TaskMigrator interface {
+	MigrateTasks(ctx context.Context, request *task.MigrationRequest) (*task.MigrationReport, error)
+}
+
+// JiraOptions contains options for the migrate jira command
+type JiraOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskMigrator, error)
+	Exporter         func(ctx context.Context) (plugin.Exporter, error)
+	CanPrompt        func() bool
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	Projects []string
+	Filters  []string
+	Query    string
+	Statuses map[string]string
+	Types    map[string]string
+	Limit    int
+	NoSync   bool
+	Yes      bool
+	DryRun   bool
+}
+
+// NewCmdJira creates the migrate jira command
+func NewCmdJira(f *cmdutil.Factory, runF func(*JiraOptions) error) *cobra.Command {
+	opts := &JiraOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskMigrator, error) {
+			return task.NewManager(f), nil
+		},
+		Exporter: func(ctx context.Context) (plugin.Exporter, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get config: %w", err)
+			}
+			authMgr, err := f.AuthManager()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get auth manager: %w", err)
+			}
+
+			instance, err := factory.NewClientFactory(f.Logger, cfg, authMgr, nil).CreatePlugin(ctx, "jira")
+			if err != nil {
+				return nil, err
+			}
+			exporter, ok := instance.(plugin.Exporter)
+			if !ok {
+				return nil, &types.Error{
+					Code:    types.ErrorCodeInvalidConfig,
+					Message: "the jira provider cannot export issues",
+				}
+			}
+			return exporter, nil
+		},
+		CanPrompt: f.IOStreams.CanPrompt,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "jira",
+		Short: "Migrate the issues of Jira projects to tasks",
+		Long: heredoc.Docf(`
+			Migrate the issues of Jira projects or saved filters to tasks, once, as when a
+			team moves its work off Jira. Each issue becomes a task with the issue key as
+			its ID, in the status and of the type its issue maps to, with a summary of the
+			issue's history (who created it, its status changes and its comments) at the
+			end of its index.md.
+
+			The migration walks through the steps in a terminal: it asks for the projects
+			to migrate when none are given, then for the task status of each Jira status
+			and the task type of each issue type, offering a default that Enter accepts.
+			Statuses are mapped by name, or by their Jira category, so that custom
+			statuses such as "Ready for QA" map to in_progress unless another status is
+			chosen. Mappings given with --status and --type are not asked for, and --yes
+			accepts the defaults of the others, as happens without a terminal.
+
+			Unless --no-sync is given, or tasks are tracked locally, each task keeps a
+			sync record of its issue, as tasks created with %[1]szen task create --from jira%[1]s
+			do, so that the tasks can still be synced while the team moves over.
+
+			A report of the migration is written to .zen/work/migrations: the mappings
+			used, the result of each issue, and the Jira fields with values that tasks do
+			not hold, such as story points and custom fields. Issues whose task exists
+			are skipped, so a migration can be run again, and --dry-run shows what would
+			be created.
+		`, "`"),
+		Example: heredoc.Doc(`
+			# Walk through migrating a project
+			zen migrate jira --project PROJ
+
+			# Preview migrating two projects and a saved filter
+			zen migrate jira --project PROJ --project OPS --filter 10042 --dry-run
+
+			# Migrate open issues without prompts, mapping a custom status
+			zen migrate jira --project PROJ --jql "statusCategory != Done" --status "Ready for QA=in_review" --yes
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Limit < 0 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--limit cannot be negative")}
+			}
+			opts.DryRun = f.DryRun
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return jiraRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.Projects, "project", "p", nil, "Key of a Jira project to migrate (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.Filters, "filter", nil, "ID or name of a saved Jira filter to migrate (repeatable)")
+	cmd.Flags().StringVar(&opts.Query, "jql", "", "JQL query that selects the issues, or narrows those of --project and --filter")
+	cmd.Flags().StringToStringVar(&opts.Statuses, "status", nil, "Task status of Jira statuses, as status=task-status pairs")
+	cmd.Flags().StringToStringVar(&opts.Types, "type", nil, "Task type of Jira issue types, as type=task-type pairs")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Migrate at most this many issues")
+	cmd.Flags().BoolVar(&opts.NoSync, "no-sync", false, "Create tasks without sync records of their issues")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Accept the default mappings and migrate without asking")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func jiraRun(ctx context.Context, opts *JiraOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	interactive := !opts.Yes && opts.CanPrompt()
+	var in *bufio.Reader
+	if interactive {
+		in = bufio.NewReader(opts.IO.In)
+	}
+
+	if len(opts.Projects) == 0 && len(opts.Filters) == 0 && opts.Query == "" {
+		if !interactive {
+			return &cmdutil.FlagError{Err: fmt.Errorf("select the issues to migrate with --project, --filter or --jql")}
+		}
+		answer, err := ask(opts.IO, in, "Jira projects to migrate (keys separated by commas)", "")
+		if err != nil {
+			return err
+		}
+		opts.Projects = strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' })
+		if len(opts.Projects) == 0 {
+			return &cmdutil.FlagError{Err: fmt.Errorf("no projects to migrate")}
+		}
+	}
+	query := migrationQuery(opts.Projects, opts.Filters, opts.Query)
+
+	exporter, err := opts.Exporter(ctx)
+	if err != nil {
+		return err
+	}
+	export, err := exporter.ExportTasks(ctx, query, opts.Limit)
+	if err != nil {
+		return err
+	}
+	if len(export.Items) == 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "No Jira issues match %s\n", query)
+		return nil
+	}
+	fmt.Fprintf(opts.IO.ErrOut, "Found %d Jira issues\n", len(export.Items))
+
+	statuses, taskTypes := task.MigrationMappings(export)
+	if interactive {
+		if opts.Statuses, err = askMappings(opts.IO, in, "status", statuses, opts.Statuses, task.Statuses); err != nil {
+			return err
+		}
+		if opts.Types, err = askMappings(opts.IO, in, "issue type", taskTypes, opts.Types, task.TaskTypes); err != nil {
+			return err
+		}
+		if !opts.DryRun {
+			answer, err := ask(opts.IO, in, fmt.Sprintf("Migrate %d issues to tasks? [Y/n]", len(export.Items)), "y")
+			if err != nil {
+				return err
+			}
+			if answer := strings.ToLower(answer); answer != "y" && answer != "yes" {
+				fmt.Fprintln(opts.IO.ErrOut, "Migration cancelled")
+				return nil
+			}
+		}
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+	report, migrateErr := manager.MigrateTasks(ctx, &task.MigrationRequest{
+		Source:   "jira",
+		Query:    query,
+		Export:   export,
+		Statuses: opts.Statuses,
+		Types:    opts.Types,
+		NoLink:   opts.NoSync,
+		DryRun:   opts.DryRun,
+	})
+	if report == nil {
+		return migrateErr
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	if err := renderer.Render(report, func(w io.Writer) error {
+		return displayResults(w, opts.IO, report)
+	}); err != nil {
+		return err
+	}
+	if migrateErr != nil {
+		return migrateErr
+	}
+
+	counts := map[string]int{}
+	for _, result := range report.Results {
+		counts[result.Status]++
+	}
+	if opts.DryRun {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Would create %d tasks: %d exist\n", opts.IO.NeutralIcon(),
+			counts[task.ImportValid], counts[task.ImportExists])
+	} else {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Migrated %d Jira issues: %d created, %d exist, %d failed\n", opts.IO.SuccessIcon(),
+			len(report.Results), counts[task.ImportCreated], counts[task.ImportExists], counts[task.ImportFailed])
+		fmt.Fprintf(opts.IO.ErrOut, "Report written to %s\n", report.File)
+	}
+	if len(report.UnmappedFields) > 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "%s %d Jira fields with values are not held by tasks; see the report\n",
+			opts.IO.WarningIcon(), len(report.UnmappedFields))
+	}
+	if counts[task.ImportFailed] > 0 {
+		return fmt.Errorf("%d of %d issues failed to migrate", counts[task.ImportFailed], len(report.Results))
+	}
+	return nil
+}
+
+// migrationQuery returns the JQL of the issues of projects and saved filters, narrowed
+// by a query, or the query alone
+func migrationQuery(projects, filters []string, query string) string {
+	quote := func(values []string) string {
+		quoted := make([]string, len(values))
+		for i, value := range values {
+			quoted[i] = strconv.Quote(value)
+		}
+		return strings.Join(quoted, ", ")
+	}
+
+	var selections []string
+	if len(projects) > 0 {
+		selections = append(selections, fmt.Sprintf("project in (%s)", quote(projects)))
+	}
+	if len(filters) > 0 {
+		selections = append(selections, fmt.Sprintf("filter in (%s)", quote(filters)))
+	}
+	selection := strings.Join(selections, " OR ")
+
+	switch {
+	case selection == "":
+		return query
+	case query == "":
+		return selection + " ORDER BY key ASC"
+	default:
+		return fmt.Sprintf("(%s) AND (%s)", selection, query)
+	}
+}
+
+// askMappings asks for the task value of each Jira value not mapped already, offering
+// its default, and returns the mappings with the answers
+func askMappings(streams *iostreams.IOStreams, in *bufio.Reader, kind string, mappings []task.MigrationMapping, mapped map[string]string, values []string) (map[string]string, error) {
+	answers := map[string]string{}
+	for external, value := range mapped {
+		answers[external] = value
+	}
+
+	for _, mapping := range mappings {
+		if _, ok := answers[mapping.External]; ok {
+			continue
+		}
+		for {
+			answer, err := ask(streams, in, fmt.Sprintf("Jira %s %q (%d issues) maps to", kind, mapping.External, mapping.Tasks), mapping.Zen)
+			if err != nil {
+				return nil, err
+			}
+			if slices.Contains(values, answer) {
+				answers[mapping.External] = answer
+				break
+			}
+			fmt.Fprintf(streams.ErrOut, "%s %q is not one of %s\n", streams.WarningIcon(), answer, strings.Join(values, ", "))
+		}
+	}
+	return answers, nil
+}
+
+// ask prints a question and returns the answer, or the default when the answer is empty
+func ask(streams *iostreams.IOStreams, in *bufio.Reader, question, defaultAnswer string) (string, error) {
+	if defaultAnswer != "" && !strings.HasSuffix(question, "]") {
+		question += fmt.Sprintf(" [%s]", defaultAnswer)
+	}
+	fmt.Fprintf(streams.ErrOut, "%s: ", question)
+
+	// The end of input answers with the default
+	answer, err := in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return defaultAnswer, nil
+	}
+	return answer, nil
+}
+
+func displayResults(w io.Writer, streams *iostreams.IOStreams, report *task.MigrationReport) error {
+	headers := []string{"ISSUE", "TASK", "TITLE", "RESULT"}
+	rows := make([][]string, 0, len(report.Results))
+	for _, result := range report.Results {
+		status := result.Status
+		if result.Error != "" {
+			status += ": " + result.Error
+		}
+		if streams.IsStdoutTTY() {
+			switch result.Status {
+			case task.ImportCreated, task.ImportValid:
+				status = streams.ColorSuccess(status)
+			case task.ImportFailed:
+				status = streams.ColorError(status)
+			}
+		}
+		rows = append(rows, []string{result.ExternalID, result.TaskID, result.Title, status})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockExporter struct {
+	query string
+	items []*plugin.ExportItem
+}
+
+func (e *mockExporter) ExportTasks(ctx context.Context, query string, limit int) (*plugin.Export, error) {
+	e.query = query
+	return &plugin.Export{Items: e.items}, nil
+}
+
+type mockTaskManager struct {
+	request *task.MigrationRequest
+	report  *task.MigrationReport
+}
+
+func (m *mockTaskManager) MigrateTasks(ctx context.Context, request *task.MigrationRequest) (*task.MigrationReport, error) {
+	m.request = request
+	return m.report, nil
+}
+
+func newTestOptions(streams *iostreams.IOStreams, source *mockExporter, manager *mockTaskManager) *JiraOptions {
+	return &JiraOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskMigrator, error) { return manager, nil },
+		Exporter:         func(ctx context.Context) (plugin.Exporter, error) { return source, nil },
+		CanPrompt:        func() bool { return false },
+	}
+}
+
+func newTestExporter() *mockExporter {
+	return &mockExporter{items: []*plugin.ExportItem{
+		{Task: &plugin.TaskData{ExternalID: "PROJ-2", Title: "Add SSO"}, Status: "Ready for QA", StatusCategory: "indeterminate", Type: "Story"},
+		{Task: &plugin.TaskData{ExternalID: "PROJ-3", Title: "Fix logout"}, Status: "Done", StatusCategory: "done", Type: "Defect"},
+	}}
+}
+
+func TestNewCmdJira(t *testing.T) {
+	var got *JiraOptions
+	cmd := NewCmdJira(cmdutil.NewTestFactory(iostreams.Test()), func(opts *JiraOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"--project", "PROJ,OPS", "--filter", "10042", "--status", "Ready for QA=in_review", "--no-sync", "--yes"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"PROJ", "OPS"}, got.Projects)
+	assert.Equal(t, []string{"10042"}, got.Filters)
+	assert.Equal(t, map[string]string{"Ready for QA": "in_review"}, got.Statuses)
+	assert.True(t, got.NoSync)
+	assert.True(t, got.Yes)
+}
+
+func TestJiraRun(t *testing.T) {
+	streams := iostreams.Test()
+	source := newTestExporter()
+	manager := &mockTaskManager{report: &task.MigrationReport{
+		Source: "jira",
+		File:   "/workspace/.zen/work/migrations/jira-20261016-090000.md",
+		Results: []*task.MigrationResult{
+			{ExternalID: "PROJ-2", TaskID: "PROJ-2", Title: "Add SSO", Status: task.ImportCreated},
+			{ExternalID: "PROJ-3", TaskID: "PROJ-3", Title: "Fix logout", Status: task.ImportExists},
+		},
+		UnmappedFields: []task.UnmappedField{{ID: "customfield_10016", Name: "Story Points", Tasks: 2}},
+	}}
+	opts := newTestOptions(streams, source, manager)
+	opts.Projects = []string{"PROJ"}
+	opts.Types = map[string]string{"Defect": "bug"}
+
+	require.NoError(t, jiraRun(context.Background(), opts))
+	assert.Equal(t, `project in ("PROJ") ORDER BY key ASC`, source.query)
+	assert.Equal(t, "jira", manager.request.Source)
+	assert.Equal(t, map[string]string{"Defect": "bug"}, manager.request.Types)
+	assert.Nil(t, manager.request.Statuses, "defaults are left to the task manager without prompts")
+	assert.Equal(t, "PROJ-2\tPROJ-2\tAdd SSO\tcreated\nPROJ-3\tPROJ-3\tFix logout\texists\n", streams.Out.(*bytes.Buffer).String())
+	assert.Equal(t, "Found 2 Jira issues\n"+
+		"✓ Migrated 2 Jira issues: 1 created, 1 exist, 0 failed\n"+
+		"Report written to /workspace/.zen/work/migrations/jira-20261016-090000.md\n"+
+		"! 1 Jira fields with values are not held by tasks; see the report\n", streams.ErrOut.(*bytes.Buffer).String())
+}
+
+func TestJiraRun_Interactive(t *testing.T) {
+	streams := iostreams.Test()
+	streams.In = io.NopCloser(strings.NewReader("PROJ, OPS\nin_review\n\nfeature\n\ny\n"))
+	source := newTestExporter()
+	manager := &mockTaskManager{report: &task.MigrationReport{Source: "jira"}}
+	opts := newTestOptions(streams, source, manager)
+	opts.CanPrompt = func() bool { return true }
+	opts.Types = map[string]string{"Story": "story"}
+
+	require.NoError(t, jiraRun(context.Background(), opts))
+	assert.Equal(t, `project in ("PROJ", "OPS") ORDER BY key ASC`, source.query)
+	assert.Equal(t, map[string]string{"Ready for QA": "in_review", "Done": "completed"}, manager.request.Statuses)
+	assert.Equal(t, map[string]string{"Story": "story", "Defect": "bug"}, manager.request.Types, "invalid answers are asked again")
+
+	prompts := streams.ErrOut.(*bytes.Buffer).String()
+	assert.Contains(t, prompts, `Jira status "Ready for QA" (1 issues) maps to [in_progress]: `)
+	assert.Contains(t, prompts, `"feature" is not one of story, bug, epic, spike, task`)
+	assert.NotContains(t, prompts, `issue type "Story"`, "mapped values are not asked for")
+	assert.Contains(t, prompts, "Migrate 2 issues to tasks? [Y/n]: ")
+}
+
+func TestJiraRun_Cancelled(t *testing.T) {
+	streams := iostreams.Test()
+	streams.In = io.NopCloser(strings.NewReader("\n\n\n\nn\n"))
+	manager := &mockTaskManager{}
+	opts := newTestOptions(streams, newTestExporter(), manager)
+	opts.CanPrompt = func() bool { return true }
+	opts.Projects = []string{"PROJ"}
+
+	require.NoError(t, jiraRun(context.Background(), opts))
+	assert.Nil(t, manager.request)
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "Migration cancelled\n")
+}
+
+func TestJiraRun_NoSelection(t *testing.T) {
+	opts := newTestOptions(iostreams.Test(), newTestExporter(), &mockTaskManager{})
+
+	err := jiraRun(context.Background(), opts)
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, err, &flagErr)
+}
+
+func TestMigrationQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects []string
+		filters  []string
+		query    string
+		want     string
+	}{
+		{name: "projects", projects: []string{"PROJ", "OPS"}, want: `project in ("PROJ", "OPS") ORDER BY key ASC`},
+		{name: "projects and filters", projects: []string{"PROJ"}, filters: []string{"10042"}, want: `project in ("PROJ") OR filter in ("10042") ORDER BY key ASC`},
+		{name: "narrowed", projects: []string{"PROJ"}, query: "statusCategory != Done", want: `(project in ("PROJ")) AND (statusCategory != Done)`},
+		{name: "query", query: "assignee = currentUser()", want: "assignee = currentUser()"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, migrationQuery(tt.projects, tt.filters, tt.query))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/migrate/jira"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdMigrate creates the migrate command with subcommands
+func NewCmdMigrate(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate <command>",
+		Short: "Migrate tasks from another tracker",
+		Long: heredoc.Doc(`
+			Move the work items of another tracker to tasks of the workspace in one go,
+			with their status, type and history, and a report of what was not carried
+			over.
+		`),
+		Example: heredoc.Doc(`
+			# Walk through migrating the issues of a Jira project
+			zen migrate jira --project PROJ
+		`),
+		GroupID: "core",
+	}
+
+	cmd.AddCommand(jira.NewCmdJira(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,233 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/notify"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TaskLister lists tasks in the workspace
+type TaskLister interface {
+	ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error)
+}
+
+// DigestOptions contains options for the notify digest command
+type DigestOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskLister, error)
+	TemplateEngine   func() (template.TemplateEngine, error)
+	NotifyConfig     func() (notify.Config, error)
+	Sender           func(cfg notify.Config) (notify.Sender, error)
+	Now              func() time.Time
+
+	Period         string
+	Filter         task.TaskFilter
+	To             []string
+	DigestTemplate string
+	DryRun         bool
+}
+
+// NewCmdDigest creates the notify digest command
+func NewCmdDigest(f *cmdutil.Factory, runF func(*DigestOptions) error) *cobra.Command {
+	opts := &DigestOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskLister, error) {
+			return task.NewManager(f), nil
+		},
+		TemplateEngine: func() (template.TemplateEngine, error) {
+			return f.TemplateEngine()
+		},
+		NotifyConfig: func() (notify.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return notify.Config{}, err
+			}
+			return config.GetConfig(cfg, notify.ConfigParser{})
+		},
+		Sender: func(cfg notify.Config) (notify.Sender, error) {
+			secret := os.Getenv(notify.SMTPPasswordEnv)
+			if cfg.Provider == notify.ProviderWebhook {
+				secret = os.Getenv(notify.WebhookTokenEnv)
+			}
+			client := httpx.New(httpx.Options{Provider: "notify", Logger: f.Logger})
+			return notify.NewSender(cfg, secret, client)
+		},
+		Now: time.Now,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Send a digest of workspace activity",
+		Long: heredoc.Docf(`
+			Send a summary of the activity in the workspace over a period: the tasks
+			completed and started, the tasks that moved to another workflow stage, the
+			tasks in progress, and the sync conflicts waiting for review.
+
+			The digest is rendered with the template "%[1]s" from the asset
+			repository, or a built-in template when it has none; use --template to
+			render with another.
+
+			Digests are sent with the provider set in notify.provider:
+
+			  smtp      email through the mail server in notify.smtp.host and
+			            notify.smtp.port, signing in as notify.smtp.username with
+			            the password in $%[2]s
+			  webhook   a JSON message posted to notify.webhook.url, with the token
+			            in $%[3]s as a bearer token
+
+			Email is sent from notify.from to the addresses in notify.to, or those given
+			with --to. Use --dry-run to print the digest instead of sending it.
+		`, notify.DefaultTemplate, notify.SMTPPasswordEnv, notify.WebhookTokenEnv),
+		Example: heredoc.Doc(`
+			# Email the weekly digest
+			zen notify digest --period weekly
+
+			# Send the daily digest of a team to its lead
+			zen notify digest --period daily --team platform --to lead@example.com
+
+			# Preview the digest without sending it
+			zen notify digest --dry-run
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := notify.PeriodStart(opts.Period, time.Now()); err != nil {
+				return &cmdutil.FlagError{Err: err}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return digestRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Period, "period", "weekly", fmt.Sprintf("Period of the digest: {%s}", strings.Join(notify.Periods, "|")))
+	cmd.Flags().StringVar(&opts.Filter.Team, "team", "", "Summarize the tasks of a team")
+	cmd.Flags().StringVar(&opts.Filter.Owner, "owner", "", "Summarize the tasks of an owner")
+	cmd.Flags().StringSliceVar(&opts.To, "to", nil, "Recipient address, instead of notify.to (repeatable)")
+	cmd.Flags().StringVar(&opts.DigestTemplate, "template", "", "Name of the digest template in the asset repository")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Print the digest instead of sending it")
+
+	return cmd
+}
+
+func digestRun(ctx context.Context, opts *DigestOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	notifyConfig, err := opts.NotifyConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get notify config: %w", err)
+	}
+
+	now := opts.Now()
+	since, err := notify.PeriodStart(opts.Period, now)
+	if err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	tasks, err := manager.ListTasks(ctx, &opts.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	digest := notify.BuildDigest(tasks, opts.Period, since, now)
+	switch {
+	case opts.Filter.Team != "":
+		digest.Project = opts.Filter.Team
+	case opts.Filter.Owner != "":
+		digest.Project = opts.Filter.Owner
+	default:
+		digest.Project = filepath.Base(status.Root)
+	}
+
+	engine, err := opts.TemplateEngine()
+	if err != nil {
+		return fmt.Errorf("failed to get template engine: %w", err)
+	}
+
+	name := opts.DigestTemplate
+	if name == "" {
+		name = notifyConfig.Template
+	}
+	body, err := notify.Render(ctx, engine, name, digest)
+	if err != nil {
+		return fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	msg := &notify.Message{
+		From:    notifyConfig.From,
+		To:      notifyConfig.To,
+		Subject: digest.Subject(),
+		Body:    body,
+	}
+	if len(opts.To) > 0 {
+		msg.To = opts.To
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(opts.IO.Out, "Subject: %s\n", msg.Subject)
+		fmt.Fprintf(opts.IO.Out, "To: %s\n\n", strings.Join(msg.To, ", "))
+		fmt.Fprint(opts.IO.Out, msg.Body)
+		return nil
+	}
+
+	sender, err := opts.Sender(notifyConfig)
+	if err != nil {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidConfig,
+			Message: err.Error(),
+			Details: "configure the notify section, e.g. 'notify.smtp.host', or use --dry-run",
+		}
+	}
+	if err := sender.Send(ctx, msg); err != nil {
+		return err
+	}
+
+	recipients := strings.Join(msg.To, ", ")
+	if notifyConfig.Provider == notify.ProviderWebhook {
+		recipients = notifyConfig.Webhook.URL
+	}
+	fmt.Fprintf(opts.IO.ErrOut, "%s Sent %s digest to %s\n", opts.IO.SuccessIcon(), opts.Period, recipients)
+	return nil
+}
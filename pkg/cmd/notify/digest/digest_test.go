@@ -0,0 +1,156 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/notify"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	tasks  []*task.Task
+	filter *task.TaskFilter
+}
+
+func (m *mockTaskManager) ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error) {
+	m.filter = filter
+	return m.tasks, nil
+}
+
+type mockSender struct {
+	sent []*notify.Message
+}
+
+func (s *mockSender) Send(ctx context.Context, msg *notify.Message) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func newTestOptions(t *testing.T) (*DigestOptions, *mockTaskManager, *mockSender, *iostreams.IOStreams) {
+	t.Helper()
+	finished := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	started := finished.AddDate(0, 0, -3)
+	manager := &mockTaskManager{tasks: []*task.Task{
+		{ID: "PROJ-1", Title: "Add login", Owner: "ana", Git: &task.GitInfo{StartedAt: &started, FinishedAt: &finished}},
+	}}
+	s := &mockSender{}
+
+	streams := iostreams.Test()
+	return &DigestOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/src/web"), nil },
+		TaskManager:      func() (TaskLister, error) { return manager, nil },
+		TemplateEngine: func() (template.TemplateEngine, error) {
+			return template.NewEngine(logging.NewBasic(), zentest.NewAssetClient(), template.DefaultConfig()), nil
+		},
+		NotifyConfig: func() (notify.Config, error) {
+			cfg := notify.DefaultConfig()
+			cfg.From, cfg.To = "zen@example.com", []string{"leads@example.com"}
+			return cfg, nil
+		},
+		Sender: func(cfg notify.Config) (notify.Sender, error) { return s, nil },
+		Now:    func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) },
+		Period: "weekly",
+	}, manager, s, streams
+}
+
+func TestNewCmdDigest(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    DigestOptions
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: DigestOptions{Period: "weekly"},
+		},
+		{
+			name: "team digest",
+			args: []string{"--period", "daily", "--team", "web", "--to", "a@example.com,b@example.com", "--dry-run"},
+			want: DigestOptions{
+				Period: "daily",
+				Filter: task.TaskFilter{Team: "web"},
+				To:     []string{"a@example.com", "b@example.com"},
+				DryRun: true,
+			},
+		},
+		{
+			name:    "invalid period",
+			args:    []string{"--period", "hourly"},
+			wantErr: `invalid period "hourly"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *DigestOptions
+			cmd := NewCmdDigest(cmdutil.NewTestFactory(iostreams.Test()), func(opts *DigestOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Period, got.Period)
+			assert.Equal(t, tt.want.Filter, got.Filter)
+			assert.Equal(t, tt.want.To, got.To)
+			assert.Equal(t, tt.want.DryRun, got.DryRun)
+		})
+	}
+}
+
+func TestDigestRun_Send(t *testing.T) {
+	opts, _, s, streams := newTestOptions(t)
+
+	require.NoError(t, digestRun(context.Background(), opts))
+
+	require.Len(t, s.sent, 1)
+	msg := s.sent[0]
+	assert.Equal(t, "zen@example.com", msg.From)
+	assert.Equal(t, []string{"leads@example.com"}, msg.To)
+	assert.Equal(t, "Weekly digest for web: 1 completed, 1 started", msg.Subject)
+	assert.Contains(t, msg.Body, "- PROJ-1 Add login (ana), 2026-10-14")
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "Sent weekly digest to leads@example.com")
+}
+
+func TestDigestRun_DryRun(t *testing.T) {
+	opts, manager, s, streams := newTestOptions(t)
+	opts.DryRun = true
+	opts.Filter = task.TaskFilter{Team: "platform"}
+	opts.To = []string{"lead@example.com"}
+
+	require.NoError(t, digestRun(context.Background(), opts))
+
+	assert.Empty(t, s.sent)
+	assert.Equal(t, "platform", manager.filter.Team, "filters are passed to the task manager")
+	out := streams.Out.(*bytes.Buffer).String()
+	assert.Contains(t, out, "Subject: Weekly digest for platform: 1 completed, 1 started\nTo: lead@example.com\n\n# Weekly digest")
+}
+
+func TestDigestRun_WorkspaceNotInitialized(t *testing.T) {
+	opts, _, _, _ := newTestOptions(t)
+	opts.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) { return zentest.NewWorkspace(t), nil }
+
+	err := digestRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workspace not initialized")
+}
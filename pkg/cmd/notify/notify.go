@@ -0,0 +1,29 @@
+package notify
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/notify/digest"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdNotify creates the notify command with subcommands
+func NewCmdNotify(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify <command>",
+		Short: "Send notifications about the workspace",
+		Long: heredoc.Doc(`
+			Send summaries of workspace activity by email or to a webhook, as configured
+			in the notify section of the configuration.
+		`),
+		Example: heredoc.Doc(`
+			# Email the weekly digest of the workspace
+			zen notify digest --period weekly
+		`),
+		GroupID: "core",
+	}
+
+	cmd.AddCommand(digest.NewCmdDigest(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,328 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/pullrequest"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager loads tasks and links pull requests to them
+type TaskManager interface {
+	GetTask(ctx context.Context, taskID string) (*task.Task, error)
+	ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error)
+	LinkPullRequest(ctx context.Context, taskID string, source *task.TaskSource) error
+}
+
+// CreateOptions contains options for the pr create command
+type CreateOptions struct {
+	IO               *iostreams.IOStreams
+	Logger           logging.Logger
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskConfig       func() (task.Config, error)
+	TaskManager      func() (TaskManager, error)
+	Provider         func(remoteURL string) (pullrequest.Provider, error)
+
+	TaskID string
+	Title  string
+	Body   string
+	Base   string
+	Labels []string
+	Draft  bool
+	NoPush bool
+}
+
+// NewCmdCreate creates the pr create command
+func NewCmdCreate(f *cmdutil.Factory, runF func(*CreateOptions) error) *cobra.Command {
+	opts := &CreateOptions{
+		IO:               f.IOStreams,
+		Logger:           f.Logger,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskConfig: func() (task.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return task.Config{}, err
+			}
+			return config.GetConfig(cfg, task.ConfigParser{})
+		},
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+		Provider: func(remoteURL string) (pullrequest.Provider, error) {
+			authManager, err := f.AuthManager()
+			if err != nil {
+				return nil, err
+			}
+			return pullrequest.ForRemote(remoteURL, authManager, nil, f.Logger)
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "create [<task-id>]",
+		Short: "Open a pull request for a task",
+		Long: heredoc.Doc(`
+			Open a pull request (GitHub) or merge request (GitLab) for a task's branch.
+
+			Without a task ID the task is found from the current branch, as recorded by
+			'zen task start'. The branch is pushed first, then the request is created
+			against the task's base branch with:
+
+			- title: "<task-id>: <task title>"
+			- description: the task's index.md
+			- labels: the task's labels plus any given with --label
+
+			The pull request is linked to the task and its review status is shown by
+			'zen task status'. Authenticate first with 'zen auth github' or
+			'zen auth gitlab'.
+		`),
+		Example: heredoc.Doc(`
+			# Open a pull request for the task on the current branch
+			zen pr create
+
+			# Open a draft pull request for a specific task
+			zen pr create PROJ-123 --draft
+
+			# Override the title and add labels
+			zen pr create --title "Add login page" --label frontend --label needs-design-review
+		`),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.TaskID = args[0]
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return createRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Title, "title", "t", "", "Title (default \"<task-id>: <task title>\")")
+	cmd.Flags().StringVarP(&opts.Body, "body", "b", "", "Description (default the task's index.md)")
+	cmd.Flags().StringVarP(&opts.Base, "base", "B", "", "Branch to merge into (default the task's base branch)")
+	cmd.Flags().StringSliceVarP(&opts.Labels, "label", "l", nil, "Add a label (repeatable)")
+	cmd.Flags().BoolVarP(&opts.Draft, "draft", "d", false, "Open the pull request as a draft")
+	cmd.Flags().BoolVar(&opts.NoPush, "no-push", false, "Do not push the branch before creating the pull request")
+
+	return cmd
+}
+
+func createRun(ctx context.Context, opts *CreateOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	cfg, err := opts.TaskConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load task configuration: %w", err)
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	t, err := resolveTask(ctx, opts, manager, git.NewCLIRepository(status.Root, opts.Logger, nil, ""))
+	if err != nil {
+		return err
+	}
+
+	dir := status.Root
+	if t.Git.Worktree != "" {
+		dir = t.Git.Worktree
+	}
+	repo := git.NewCLIRepository(dir, opts.Logger, nil, "")
+
+	for _, source := range t.PullRequests {
+		if existing, ok := pullrequest.FromTaskSource(source); ok && existing.Head == t.Git.Branch && existing.State == pullrequest.StateOpen {
+			return &types.Error{
+				Code:    types.ErrorCodeAlreadyExists,
+				Message: fmt.Sprintf("pull request %s is already open for %s", existing.Reference(), t.ID),
+				Details: existing.URL,
+			}
+		}
+	}
+
+	remote := firstNonEmpty(t.Git.Remote, cfg.Remote, "origin")
+	remoteURL, err := repo.GetConfig(ctx, "remote."+remote+".url")
+	if err != nil {
+		return fmt.Errorf("failed to read URL of remote %s: %w", remote, err)
+	}
+
+	provider, err := opts.Provider(strings.TrimSpace(remoteURL))
+	if err != nil {
+		return err
+	}
+
+	base := firstNonEmpty(opts.Base, t.Git.Base, cfg.BaseBranch)
+	if base == "" {
+		return &cmdutil.FlagError{Err: fmt.Errorf("no base branch recorded for %s; use --base", t.ID)}
+	}
+
+	if !opts.NoPush {
+		if clean, err := repo.IsClean(ctx); err == nil && !clean {
+			fmt.Fprintf(opts.IO.ErrOut, "%s Uncommitted changes in %s are not pushed\n", opts.IO.WarningIcon(), dir)
+		}
+		if err := repo.PushUpstream(ctx, remote, t.Git.Branch); err != nil {
+			return fmt.Errorf("failed to push %s: %w", t.Git.Branch, git.OutputError(err))
+		}
+	}
+
+	req := &pullrequest.CreateRequest{
+		Title:  opts.Title,
+		Body:   opts.Body,
+		Head:   t.Git.Branch,
+		Base:   base,
+		Draft:  opts.Draft,
+		Labels: mergeLabels(t.Labels, opts.Labels),
+	}
+	if req.Title == "" {
+		req.Title = defaultTitle(t)
+	}
+	if req.Body == "" {
+		req.Body = taskDescription(t)
+	}
+
+	pr, err := provider.Create(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	if err := manager.LinkPullRequest(ctx, t.ID, pr.TaskSource()); err != nil {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Failed to link %s to %s: %v\n", opts.IO.WarningIcon(), pr.Reference(), t.ID, err)
+	}
+
+	if !opts.IO.IsStdoutTTY() {
+		fmt.Fprintln(opts.IO.Out, pr.URL)
+		return nil
+	}
+
+	kind := "pull request"
+	if provider.Name() == pullrequest.ProviderGitLab {
+		kind = "merge request"
+	}
+	fmt.Fprintln(opts.IO.Out, opts.IO.FormatSuccess(fmt.Sprintf("Created %s %s for %s", kind, pr.Reference(), t.ID)))
+	fmt.Fprintf(opts.IO.Out, "  %s %s into %s\n", opts.IO.ColorNeutral("→"), pr.Head, pr.Base)
+	fmt.Fprintf(opts.IO.Out, "  %s %s\n", opts.IO.ColorNeutral("→"), pr.URL)
+
+	return nil
+}
+
+// resolveTask returns the task named on the command line, or the task whose branch is checked out
+func resolveTask(ctx context.Context, opts *CreateOptions, manager TaskManager, repo *git.CLIRepository) (*task.Task, error) {
+	if opts.TaskID != "" {
+		t, err := manager.GetTask(ctx, opts.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		if t.Git == nil {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("task %s has no branch", t.ID),
+				Details: fmt.Sprintf("Run 'zen task start %s' to create one", t.ID),
+			}
+		}
+		return t, nil
+	}
+
+	branch, err := repo.GetCurrentBranch(ctx)
+	if err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "workspace is not a Git repository",
+			Details: "'zen pr create' needs a Git repository",
+		}
+	}
+
+	tasks, err := manager.ListTasks(ctx, &task.TaskFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	for _, t := range tasks {
+		if t.Git != nil && t.Git.Branch == branch {
+			return t, nil
+		}
+	}
+
+	return nil, &types.Error{
+		Code:    types.ErrorCodeNotFound,
+		Message: fmt.Sprintf("no task is being worked on in branch %s", branch),
+		Details: "Pass a task ID, or run 'zen task start <task-id>' first",
+	}
+}
+
+// defaultTitle returns "<id>: <title>", or the ID alone for untitled tasks
+func defaultTitle(t *task.Task) string {
+	if t.Title == "" {
+		return t.ID
+	}
+	return fmt.Sprintf("%s: %s", t.ID, t.Title)
+}
+
+// taskDescription returns the task's index.md without its top-level heading, which repeats the title
+func taskDescription(t *task.Task) string {
+	data, err := os.ReadFile(t.IndexPath) // #nosec G304 - reading task index from workspace path
+	if err != nil {
+		return t.Description
+	}
+
+	content := strings.TrimSpace(string(data))
+	if strings.HasPrefix(content, "# ") {
+		_, content, _ = strings.Cut(content, "\n")
+	}
+	return strings.TrimSpace(content)
+}
+
+// mergeLabels combines task and flag labels without duplicates
+func mergeLabels(groups ...[]string) []string {
+	seen := make(map[string]bool)
+	var labels []string
+	for _, group := range groups {
+		for _, label := range group {
+			if label != "" && !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
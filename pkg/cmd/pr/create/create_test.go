@@ -0,0 +1,220 @@
+package create
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/pullrequest"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	tasks  map[string]*task.Task
+	linked map[string][]*task.TaskSource
+}
+
+func (m *mockTaskManager) GetTask(ctx context.Context, taskID string) (*task.Task, error) {
+	return m.tasks[taskID], nil
+}
+
+func (m *mockTaskManager) ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error) {
+	var tasks []*task.Task
+	for _, t := range m.tasks {
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+func (m *mockTaskManager) LinkPullRequest(ctx context.Context, taskID string, source *task.TaskSource) error {
+	m.linked[taskID] = append(m.linked[taskID], source)
+	return nil
+}
+
+type mockProvider struct {
+	remoteURL string
+	request   *pullrequest.CreateRequest
+}
+
+func (p *mockProvider) Name() string {
+	return pullrequest.ProviderGitHub
+}
+
+func (p *mockProvider) Create(ctx context.Context, req *pullrequest.CreateRequest) (*pullrequest.PullRequest, error) {
+	p.request = req
+	return &pullrequest.PullRequest{
+		Provider:     pullrequest.ProviderGitHub,
+		Repository:   "acme/app",
+		Number:       42,
+		URL:          "https://github.com/acme/app/pull/42",
+		Title:        req.Title,
+		State:        pullrequest.StateOpen,
+		ReviewStatus: pullrequest.ReviewPending,
+		Head:         req.Head,
+		Base:         req.Base,
+	}, nil
+}
+
+func (p *mockProvider) Get(ctx context.Context, number int) (*pullrequest.PullRequest, error) {
+	return nil, nil
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// newTestOptions creates a workspace repository on a started task branch, with a bare origin remote
+// that is reached through a GitHub URL
+func newTestOptions(t *testing.T) (*CreateOptions, *mockProvider, *mockTaskManager, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "zen")
+	t.Setenv("GIT_AUTHOR_EMAIL", "zen@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "zen")
+	t.Setenv("GIT_COMMITTER_EMAIL", "zen@example.com")
+
+	root := filepath.Join(t.TempDir(), "workspace")
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	for _, dir := range []string{root, remote} {
+		args := []string{"init", "-q", "-b", "main", dir}
+		if dir == remote {
+			args = append(args, "--bare")
+		}
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGit(t, root, "config", "url."+remote+".insteadOf", "git@github.com:acme/app.git")
+	runGit(t, root, "remote", "add", "origin", "git@github.com:acme/app.git")
+	runGit(t, root, "checkout", "-q", "-b", "feat/PROJ-1-login")
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "PROJ-1 add login")
+
+	indexPath := filepath.Join(t.TempDir(), "index.md")
+	require.NoError(t, os.WriteFile(indexPath, []byte("# PROJ-1: Login\n\n## Overview\n\nLet users sign in.\n"), 0644))
+
+	manager := &mockTaskManager{
+		tasks: map[string]*task.Task{
+			"PROJ-1": {
+				ID:        "PROJ-1",
+				Title:     "Login",
+				Labels:    []string{"auth"},
+				IndexPath: indexPath,
+				Git:       &task.GitInfo{Branch: "feat/PROJ-1-login", Base: "main"},
+			},
+			"PROJ-2": {ID: "PROJ-2"},
+		},
+		linked: make(map[string][]*task.TaskSource),
+	}
+	fake := &mockProvider{}
+
+	return &CreateOptions{
+		IO:               iostreams.Test(),
+		Logger:           logging.NewBasic(),
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt(root), nil },
+		TaskConfig:       func() (task.Config, error) { return task.DefaultConfig(), nil },
+		TaskManager:      func() (TaskManager, error) { return manager, nil },
+		Provider: func(remoteURL string) (pullrequest.Provider, error) {
+			fake.remoteURL = remoteURL
+			return fake, nil
+		},
+	}, fake, manager, remote
+}
+
+func TestCreateRun_FromCurrentBranch(t *testing.T) {
+	opts, fake, manager, remote := newTestOptions(t)
+	opts.Labels = []string{"frontend", "auth"}
+
+	require.NoError(t, createRun(context.Background(), opts))
+
+	assert.Equal(t, "PROJ-1 add login", runGit(t, remote, "log", "-1", "--format=%s", "feat/PROJ-1-login"))
+	assert.Equal(t, "git@github.com:acme/app.git", fake.remoteURL)
+
+	require.NotNil(t, fake.request)
+	assert.Equal(t, "PROJ-1: Login", fake.request.Title)
+	assert.Equal(t, "## Overview\n\nLet users sign in.", fake.request.Body)
+	assert.Equal(t, "feat/PROJ-1-login", fake.request.Head)
+	assert.Equal(t, "main", fake.request.Base)
+	assert.Equal(t, []string{"auth", "frontend"}, fake.request.Labels)
+
+	require.Len(t, manager.linked["PROJ-1"], 1)
+	assert.Equal(t, "acme/app#42", manager.linked["PROJ-1"][0].ExternalID)
+
+	// Non-TTY output is just the URL, for scripts
+	assert.Equal(t, "https://github.com/acme/app/pull/42\n", opts.IO.Out.(*bytes.Buffer).String())
+}
+
+func TestCreateRun_Overrides(t *testing.T) {
+	opts, fake, _, remote := newTestOptions(t)
+	opts.TaskID = "PROJ-1"
+	opts.Title = "Add login page"
+	opts.Body = "Custom"
+	opts.Base = "develop"
+	opts.Draft = true
+	opts.NoPush = true
+
+	require.NoError(t, createRun(context.Background(), opts))
+
+	assert.Equal(t, "Add login page", fake.request.Title)
+	assert.Equal(t, "Custom", fake.request.Body)
+	assert.Equal(t, "develop", fake.request.Base)
+	assert.True(t, fake.request.Draft)
+
+	_, err := exec.Command("git", "-C", remote, "rev-parse", "--verify", "feat/PROJ-1-login").CombinedOutput()
+	assert.Error(t, err, "branch should not have been pushed")
+}
+
+func TestCreateRun_AlreadyOpen(t *testing.T) {
+	opts, fake, manager, _ := newTestOptions(t)
+	existing := &pullrequest.PullRequest{
+		Provider:   pullrequest.ProviderGitHub,
+		Repository: "acme/app",
+		Number:     41,
+		URL:        "https://github.com/acme/app/pull/41",
+		State:      pullrequest.StateOpen,
+		Head:       "feat/PROJ-1-login",
+	}
+	manager.tasks["PROJ-1"].PullRequests = []*task.TaskSource{existing.TaskSource()}
+
+	err := createRun(context.Background(), opts)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeAlreadyExists, zenErr.Code)
+	assert.Equal(t, "https://github.com/acme/app/pull/41", zenErr.Details)
+	assert.Nil(t, fake.request)
+}
+
+func TestCreateRun_NoTask(t *testing.T) {
+	opts, _, _, _ := newTestOptions(t)
+	opts.TaskID = "PROJ-2"
+
+	err := createRun(context.Background(), opts)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, "task PROJ-2 has no branch", zenErr.Message)
+
+	opts.TaskID = ""
+	root, _ := opts.WorkspaceManager()
+	status, _ := root.Status()
+	runGit(t, status.Root, "checkout", "-q", "main")
+
+	err = createRun(context.Background(), opts)
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeNotFound, zenErr.Code)
+	assert.Contains(t, zenErr.Message, "branch main")
+}
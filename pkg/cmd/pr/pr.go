@@ -0,0 +1,34 @@
+package pr
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/pr/create"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdPR creates the pr command with subcommands
+func NewCmdPR(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pr <command>",
+		Short: "Open pull requests for tasks",
+		Long: heredoc.Doc(`
+			Open GitHub pull requests and GitLab merge requests for tasks.
+
+			Pull requests are created from the branch recorded by 'zen task start'
+			and linked back to the task, where 'zen task status' shows their
+			review status.
+		`),
+		Example: heredoc.Doc(`
+			# Start a task, commit, then open a pull request for it
+			zen task start PROJ-123
+			git commit -am "Add login page"
+			zen pr create
+		`),
+		GroupID: "core",
+	}
+
+	cmd.AddCommand(create.NewCmdCreate(f, nil))
+
+	return cmd
+}
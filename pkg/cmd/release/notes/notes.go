@@ -0,0 +1,221 @@
+package notes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/release"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TaskLister lists workspace tasks
+type TaskLister interface {
+	ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error)
+}
+
+// NotesOptions contains options for the release notes command
+type NotesOptions struct {
+	IO               *iostreams.IOStreams
+	Logger           logging.Logger
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	ReleaseConfig    func() (release.Config, error)
+	TaskManager      func() (TaskLister, error)
+	TemplateEngine   func() (template.TemplateEngine, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	From          string
+	To            string
+	Version       string
+	NotesTemplate string
+}
+
+// NewCmdNotes creates the release notes command
+func NewCmdNotes(f *cmdutil.Factory, runF func(*NotesOptions) error) *cobra.Command {
+	opts := &NotesOptions{
+		IO:               f.IOStreams,
+		Logger:           f.Logger,
+		WorkspaceManager: f.WorkspaceManager,
+		ReleaseConfig: func() (release.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return release.Config{}, err
+			}
+			return config.GetConfig(cfg, release.ConfigParser{})
+		},
+		TaskManager: func() (TaskLister, error) {
+			return task.NewManager(f), nil
+		},
+		TemplateEngine: func() (template.TemplateEngine, error) {
+			return f.TemplateEngine()
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "notes",
+		Short: "Generate release notes from commits and completed tasks",
+		Long: heredoc.Doc(`
+			Generate a Markdown changelog for the commits between two revisions.
+
+			Commits are parsed as conventional commits ("feat(api): add users endpoint")
+			and grouped by type. Completed tasks referenced by a commit in the range are
+			grouped by label, then by task type. Commits marked as breaking, with "!" or
+			a "BREAKING CHANGE:" footer, are also listed first, and every author is
+			credited under Contributors. Commits that are not conventional commits are
+			listed under "Other Changes".
+
+			The notes are rendered with the "release-notes" template from the asset
+			repository, or a built-in template when it has none. Sections and the
+			template are configured under "release" in the workspace configuration:
+
+			    release:
+			      template: release-notes
+			      sections:
+			        - title: Features
+			          types: [feat, story]
+			        - title: Security
+			          labels: [security]
+
+			--from defaults to the most recent tag before --to.
+		`),
+		Example: heredoc.Doc(`
+			# Notes for everything since the last tag
+			zen release notes
+
+			# Notes for a release, written to a file
+			zen release notes --from v1.2.0 --to v1.3.0 --version v1.3.0 > NOTES.md
+
+			# Render with a custom template from the asset repository
+			zen release notes --from v1.2.0 --template team-release-notes
+
+			# List the contributors as JSON
+			zen release notes --from v1.2.0 --jq '.contributors[].name'
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return notesRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.From, "from", "", "Revision to start after (default the most recent tag)")
+	cmd.Flags().StringVar(&opts.To, "to", "HEAD", "Revision to end at")
+	cmd.Flags().StringVar(&opts.Version, "version", "", "Version to use as the heading")
+	cmd.Flags().StringVar(&opts.NotesTemplate, "template", "", "Name of the release notes template in the asset repository")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func notesRun(ctx context.Context, opts *NotesOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	cfg, err := opts.ReleaseConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load release configuration: %w", err)
+	}
+
+	repo := git.NewCLIRepository(status.Root, opts.Logger, nil, "")
+	if _, err := repo.GetCurrentBranch(ctx); err != nil {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "workspace is not a Git repository",
+			Details: fmt.Sprintf("'zen release notes' needs a Git repository at %s", status.Root),
+		}
+	}
+
+	from := opts.From
+	if from == "" {
+		// The tag before --to, so that notes for a tagged release do not start at the release itself
+		tag, err := repo.ExecuteCommand(ctx, "describe", "--tags", "--abbrev=0", opts.To+"^")
+		if err != nil {
+			return &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("no tag found before %s", opts.To),
+				Details: "use --from to choose the first revision",
+			}
+		}
+		from = strings.TrimSpace(tag)
+	}
+
+	commits, err := repo.Log(ctx, git.LogOptions{Range: from + ".." + opts.To, NoMerges: true})
+	if err != nil {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("failed to read commits between %s and %s", from, opts.To),
+			Details: git.OutputError(err).Error(),
+		}
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	tasks, err := manager.ListTasks(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	notes := release.Build(cfg, commits, tasks)
+	notes.Version, notes.From, notes.To = opts.Version, from, opts.To
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(notes, func(w io.Writer) error {
+		engine, err := opts.TemplateEngine()
+		if err != nil {
+			return fmt.Errorf("failed to get template engine: %w", err)
+		}
+
+		name := opts.NotesTemplate
+		if name == "" {
+			name = cfg.Template
+		}
+
+		markdown, err := release.Render(ctx, engine, name, notes)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, markdown)
+		return err
+	})
+}
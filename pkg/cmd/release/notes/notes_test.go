@@ -0,0 +1,142 @@
+package notes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/release"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	tasks []*task.Task
+}
+
+func (m *mockTaskManager) ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error) {
+	return m.tasks, nil
+}
+
+type mockAssetClient struct {
+	assets.AssetClientInterface
+}
+
+func (c *mockAssetClient) GetAsset(ctx context.Context, name string, opts assets.GetAssetOptions) (*assets.AssetContent, error) {
+	return nil, errors.New("asset not found")
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+// newTestOptions creates a repository tagged v1.2.0 with three commits after the tag
+func newTestOptions(t *testing.T) (*NotesOptions, *bytes.Buffer) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Ana")
+	t.Setenv("GIT_AUTHOR_EMAIL", "ana@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Ana")
+	t.Setenv("GIT_COMMITTER_EMAIL", "ana@example.com")
+
+	root := filepath.Join(t.TempDir(), "workspace")
+	out, err := exec.Command("git", "init", "-q", "-b", "main", root).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "feat: first release")
+	runGit(t, root, "tag", "v1.2.0")
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "feat(auth): add SSO login", "-m", "Closes PROJ-1")
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "fix!: reject expired sessions")
+	runGit(t, root, "-c", "user.name=Ben", "-c", "user.email=ben@example.com",
+		"commit", "-q", "--allow-empty", "--author", "Ben <ben@example.com>", "-m", "Tidy up")
+
+	manager := &mockTaskManager{tasks: []*task.Task{
+		{ID: "PROJ-1", Title: "Single sign-on", Type: "story", Status: "completed"},
+		{ID: "PROJ-2", Title: "Not in this release", Type: "story", Status: "completed"},
+	}}
+
+	streams := iostreams.Test()
+	return &NotesOptions{
+		IO:               streams,
+		Logger:           logging.NewBasic(),
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt(root), nil },
+		ReleaseConfig:    func() (release.Config, error) { return release.DefaultConfig(), nil },
+		TaskManager:      func() (TaskLister, error) { return manager, nil },
+		TemplateEngine: func() (template.TemplateEngine, error) {
+			return template.NewEngine(logging.NewBasic(), &mockAssetClient{}, template.DefaultConfig()), nil
+		},
+		To: "HEAD",
+	}, streams.Out.(*bytes.Buffer)
+}
+
+func TestNotesRun_Markdown(t *testing.T) {
+	opts, out := newTestOptions(t)
+	opts.Version = "v1.3.0"
+
+	require.NoError(t, notesRun(context.Background(), opts))
+
+	md := out.String()
+	assert.True(t, strings.HasPrefix(md, "## v1.3.0 ("), md)
+	assert.Contains(t, md, "### ⚠ Breaking Changes\n\n- reject expired sessions")
+	assert.Contains(t, md, "### Features\n\n- PROJ-1 Single sign-on\n- **auth:** add SSO login")
+	assert.Contains(t, md, "### Other Changes\n\n- Tidy up")
+	assert.Contains(t, md, "- Ana (2 commits)\n- Ben (1 commit)")
+	assert.NotContains(t, md, "first release")
+	assert.NotContains(t, md, "PROJ-2")
+}
+
+func TestNotesRun_JSON(t *testing.T) {
+	opts, out := newTestOptions(t)
+	opts.OutputFormat = "json"
+
+	require.NoError(t, notesRun(context.Background(), opts))
+
+	var notes release.Notes
+	require.NoError(t, json.Unmarshal(out.Bytes(), &notes))
+	assert.Equal(t, "v1.2.0", notes.From)
+	assert.Equal(t, "HEAD", notes.To)
+	require.Len(t, notes.Breaking, 1)
+	assert.Equal(t, "fix", notes.Breaking[0].Type)
+	assert.Len(t, notes.Contributors, 2)
+}
+
+func TestNotesRun_From(t *testing.T) {
+	opts, out := newTestOptions(t)
+	opts.OutputFormat = "json"
+	opts.From = "HEAD~1"
+
+	require.NoError(t, notesRun(context.Background(), opts))
+
+	var notes release.Notes
+	require.NoError(t, json.Unmarshal(out.Bytes(), &notes))
+	require.Len(t, notes.Sections, 1)
+	assert.Equal(t, "Other Changes", notes.Sections[0].Title)
+}
+
+func TestNotesRun_NoTag(t *testing.T) {
+	opts, _ := newTestOptions(t)
+	opts.To = "v1.2.0"
+
+	err := notesRun(context.Background(), opts)
+
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, "no tag found before v1.2.0", zenErr.Message)
+}
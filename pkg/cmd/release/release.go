@@ -0,0 +1,28 @@
+package release
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/release/notes"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdRelease creates the release command with subcommands
+func NewCmdRelease(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release <command>",
+		Short: "Prepare releases",
+		Long: heredoc.Doc(`
+			Prepare releases from the workspace's Git history and tasks.
+		`),
+		Example: heredoc.Doc(`
+			# Generate release notes for the changes since v1.2.0
+			zen release notes --from v1.2.0 --version v1.3.0
+		`),
+		GroupID: "core",
+	}
+
+	cmd.AddCommand(notes.NewCmdNotes(f, nil))
+
+	return cmd
+}
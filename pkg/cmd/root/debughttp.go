@@ -0,0 +1,55 @@
+package root
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/dirs"
+	"github.com/daddia/zen/pkg/iostreams"
+)
+
+// httpTranscriptDir is where --debug-http writes transcripts, under the logs directory of
+// the workspace, or the user log directory outside workspaces
+const httpTranscriptDir = "http"
+
+// startHTTPDebugging records the HTTP exchanges of the command when record is set or
+// ZEN_DEBUG_HTTP is, and answers them from the transcripts in ZEN_HTTP_REPLAY instead
+// of the network when it is set
+func startHTTPDebugging(f *cmdutil.Factory, record bool, getenv func(string) string) error {
+	if dir := getenv("ZEN_HTTP_REPLAY"); dir != "" {
+		replayer, err := httpx.NewReplayer(dir)
+		if err != nil {
+			return fmt.Errorf("failed to load HTTP transcripts from ZEN_HTTP_REPLAY: %w", err)
+		}
+		httpx.StartReplay(replayer)
+		f.Logger.Debug("replaying HTTP transcripts", "dir", dir)
+	}
+
+	if !record && getenv("ZEN_DEBUG_HTTP") == "" {
+		return nil
+	}
+
+	dir := filepath.Join(logDirectory(f), httpTranscriptDir, time.Now().Format("20060102-150405"))
+	httpx.StartRecording(httpx.NewRecorder(dir))
+
+	fmt.Fprintf(f.IOStreams.ErrOut, "%s Recording HTTP requests to %s\n", f.IOStreams.ColorWarning(iostreams.SymbolAlert), dir)
+	fmt.Fprintf(f.IOStreams.ErrOut, "  Credentials are redacted, but review the transcripts before sharing them\n")
+	return nil
+}
+
+// logDirectory returns the logs directory of the workspace, or the user log directory
+// when the command does not run in one
+func logDirectory(f *cmdutil.Factory) string {
+	if wm, err := f.WorkspaceManager(); err == nil {
+		if status, err := wm.Status(); err == nil && status.Initialized {
+			return filepath.Join(wm.ZenDirectory(), "logs")
+		}
+	}
+	if dir, err := dirs.LogDir(); err == nil {
+		return dir
+	}
+	return filepath.Join(".zen", "logs")
+}
@@ -0,0 +1,82 @@
+package root
+
+import (
+	"bytes"
+	"embed"
+	"strings"
+	"text/template"
+
+	internalconfig "github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// topicFiles holds the text of each help topic, as a text/template rendered with topicData
+//
+//go:embed topics/*.md
+var topicFiles embed.FS
+
+// helpTopic is a conceptual help page shown by "zen help <name>"
+type helpTopic struct {
+	Name  string
+	Short string
+}
+
+// helpTopics lists the help topics in the order they are shown. Each has a file of the
+// same name in topics/.
+var helpTopics = []helpTopic{
+	{Name: "topics", Short: "List the help topics"},
+	{Name: "workflow", Short: "The Zenflow stages a task moves through"},
+	{Name: "configuration", Short: "Configuration sources, precedence, and keys"},
+	{Name: "environment", Short: "Environment variables that change how zen behaves"},
+	{Name: "exit-codes", Short: "Exit codes returned to scripts"},
+}
+
+// topicData is available to the topic templates, so that lists kept in code are
+// never copied by hand
+type topicData struct {
+	Topics    []helpTopic
+	Options   []internalconfig.ConfigOption
+	ExitCodes []cmdutil.ExitCodeInfo
+}
+
+// newHelpTopic creates the command for a help topic. It has no Run function and no
+// subcommands, so cobra lists it under "Additional help topics" and shows its text
+// for both "zen help <name>" and "zen <name>".
+func newHelpTopic(topic helpTopic) (*cobra.Command, error) {
+	text, err := renderHelpTopic(topic.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cobra.Command{
+		Use:         topic.Name,
+		Short:       topic.Short,
+		Long:        text,
+		Annotations: map[string]string{cmdutil.HelpTopicAnnotation: "true"},
+	}, nil
+}
+
+// renderHelpTopic renders the text of the named topic
+func renderHelpTopic(name string) (string, error) {
+	source, err := topicFiles.ReadFile("topics/" + name + ".md")
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{"join": strings.Join}).Parse(string(source))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, topicData{
+		Topics:    helpTopics[1:],
+		Options:   internalconfig.Options,
+		ExitCodes: cmdutil.ExitCodes,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
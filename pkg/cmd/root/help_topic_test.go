@@ -0,0 +1,88 @@
+package root
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelpTopics(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		contains []string
+	}{
+		{
+			name:     "topic list",
+			args:     []string{"help", "topics"},
+			contains: []string{"- workflow: The Zenflow stages a task moves through", "- exit-codes: Exit codes returned to scripts"},
+		},
+		{
+			name:     "workflow",
+			args:     []string{"help", "workflow"},
+			contains: []string{"1. Align (01-align)", "7. Learn (07-learn)"},
+		},
+		{
+			name:     "configuration keys come from the option list",
+			args:     []string{"help", "configuration"},
+			contains: []string{"- core.log_format (string, default text): Set the logging format.\n  One of: text, json"},
+		},
+		{
+			name:     "environment",
+			args:     []string{"help", "environment"},
+			contains: []string{"ZEN_CONFIG_DIR", "ZEN_PAGER, PAGER"},
+		},
+		{
+			name:     "exit codes come from cmdutil",
+			args:     []string{"help", "exit-codes"},
+			contains: []string{"- 4 (auth): Authentication is missing, expired or was rejected"},
+		},
+		{
+			name:     "topic without help",
+			args:     []string{"exit-codes"},
+			contains: []string{"- 0 (ok):"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := NewCmdRoot(cmdutil.NewTestFactory(iostreams.Test()))
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetArgs(tt.args)
+			require.NoError(t, cmd.Execute())
+
+			output := buf.String()
+			for _, want := range tt.contains {
+				assert.Contains(t, output, want)
+			}
+			assert.NotContains(t, output, "{{", "templates are rendered")
+			assert.NotContains(t, output, "Usage:", "topics have no usage")
+		})
+	}
+}
+
+func TestHelpTopicsListedInHelp(t *testing.T) {
+	cmd, err := NewCmdRoot(cmdutil.NewTestFactory(iostreams.Test()))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--help"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, buf.String(), "Additional help topics:")
+	for _, topic := range helpTopics {
+		topicCmd, _, err := cmd.Find([]string{topic.Name})
+		require.NoError(t, err)
+		assert.True(t, cmdutil.IsHelpTopic(topicCmd))
+		assert.True(t, topicCmd.IsAdditionalHelpTopicCommand())
+		assert.Contains(t, buf.String(), "zen "+topic.Name)
+	}
+}
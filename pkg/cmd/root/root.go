@@ -2,19 +2,41 @@ package root
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	internalconfig "github.com/daddia/zen/internal/config"
 	"github.com/daddia/zen/pkg/cli"
 	"github.com/daddia/zen/pkg/cmd/assets"
 	"github.com/daddia/zen/pkg/cmd/auth"
+	"github.com/daddia/zen/pkg/cmd/cache"
+	completioninstall "github.com/daddia/zen/pkg/cmd/completion/install"
 	"github.com/daddia/zen/pkg/cmd/config"
+	"github.com/daddia/zen/pkg/cmd/dashboard"
+	"github.com/daddia/zen/pkg/cmd/docs"
+	"github.com/daddia/zen/pkg/cmd/doctor"
 	"github.com/daddia/zen/pkg/cmd/draft"
+	"github.com/daddia/zen/pkg/cmd/extension"
 	"github.com/daddia/zen/pkg/cmd/factory"
+	"github.com/daddia/zen/pkg/cmd/hooks"
 	cmdinit "github.com/daddia/zen/pkg/cmd/init"
+	"github.com/daddia/zen/pkg/cmd/labels"
+	"github.com/daddia/zen/pkg/cmd/metrics"
+	"github.com/daddia/zen/pkg/cmd/migrate"
+	"github.com/daddia/zen/pkg/cmd/notify"
+	"github.com/daddia/zen/pkg/cmd/pr"
+	"github.com/daddia/zen/pkg/cmd/release"
+	"github.com/daddia/zen/pkg/cmd/search"
+	"github.com/daddia/zen/pkg/cmd/serve"
 	"github.com/daddia/zen/pkg/cmd/status"
 	"github.com/daddia/zen/pkg/cmd/task"
+	"github.com/daddia/zen/pkg/cmd/team"
+	"github.com/daddia/zen/pkg/cmd/upgrade"
 	"github.com/daddia/zen/pkg/cmd/version"
+	"github.com/daddia/zen/pkg/cmd/watch"
 	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/i18n"
+	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/spf13/cobra"
 )
 
@@ -34,12 +56,18 @@ func NewCmdRoot(f *cmdutil.Factory) (*cobra.Command, error) {
 	var outputFormat string
 	var configFile string
 	var dryRun bool
+	var noPager bool
+	var nonInteractive bool
+	var debugHTTP bool
 
 	cmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
 	cmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
 	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format (text, json, yaml)")
 	cmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "Path to configuration file")
 	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Show what would be executed without making changes")
+	cmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "Do not pipe long output through a pager")
+	cmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Never prompt, and draw no color or progress (default in CI)")
+	cmd.PersistentFlags().BoolVar(&debugHTTP, "debug-http", false, "Record provider HTTP requests and responses to .zen/logs/http")
 
 	// Apply flag values and reload configuration with command context
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
@@ -53,6 +81,19 @@ func NewCmdRoot(f *cmdutil.Factory) (*cobra.Command, error) {
 		if cmd.Flags().Changed("dry-run") {
 			f.DryRun = dryRun
 		}
+		if err := cmdutil.ValidateOutputFormat(outputFormat); err != nil {
+			return err
+		}
+		f.OutputFormat = outputFormat
+		if outputFormat != cmdutil.OutputText {
+			f.IOStreams.SetProgressEnabled(false)
+		}
+		if noPager {
+			f.IOStreams.SetPagerEnabled(false)
+		}
+		if nonInteractive {
+			f.IOStreams.SetNonInteractive(true)
+		}
 
 		// Reload configuration with command context to ensure flag binding
 		f.Config = factory.ConfigWithCommand(cmd)
@@ -75,12 +116,15 @@ func NewCmdRoot(f *cmdutil.Factory) (*cobra.Command, error) {
 		if cliConfig.Verbose || verbose {
 			f.Logger = f.Logger.WithLevel("debug")
 		}
-		if cliConfig.NoColor || noColor {
+		if noColor || (cliConfig.NoColor && !iostreams.ColorForced()) {
 			f.IOStreams.SetColorEnabled(false)
 		}
 		if dryRun {
 			f.Logger.Info("dry-run mode enabled - no changes will be made")
 		}
+		if err := startHTTPDebugging(f, debugHTTP, os.Getenv); err != nil {
+			return err
+		}
 
 		// Log configuration sources for debugging
 		if cliConfig.Verbose {
@@ -117,16 +161,51 @@ func NewCmdRoot(f *cmdutil.Factory) (*cobra.Command, error) {
 	// Add subcommands
 	cmd.AddCommand(auth.NewCmdAuth(f))
 	cmd.AddCommand(version.NewCmdVersion(f))
+	cmd.AddCommand(upgrade.NewCmdUpgrade(f, nil))
 	cmd.AddCommand(cmdinit.NewCmdInit(f))
 	cmd.AddCommand(config.NewCmdConfig(f))
+	cmd.AddCommand(cache.NewCmdCache(f))
 	cmd.AddCommand(status.NewCmdStatus(f))
+	cmd.AddCommand(dashboard.NewCmdDashboard(f))
 	cmd.AddCommand(assets.NewCmdAssets(f))
 	cmd.AddCommand(task.NewCmdTask(f))
+	cmd.AddCommand(team.NewCmdTeam(f))
+	cmd.AddCommand(labels.NewCmdLabels(f))
+	cmd.AddCommand(search.NewCmdSearch(f, nil))
+	cmd.AddCommand(watch.NewCmdWatch(f, nil))
 	cmd.AddCommand(draft.NewCmdDraft(f))
+	cmd.AddCommand(extension.NewCmdExtension(f))
+	cmd.AddCommand(hooks.NewCmdHooks(f))
+	cmd.AddCommand(pr.NewCmdPR(f))
+	cmd.AddCommand(release.NewCmdRelease(f))
+	cmd.AddCommand(metrics.NewCmdMetrics(f))
+	cmd.AddCommand(migrate.NewCmdMigrate(f))
+	cmd.AddCommand(notify.NewCmdNotify(f))
+	cmd.AddCommand(docs.NewCmdDocs(f))
+	cmd.AddCommand(doctor.NewCmdDoctor(f, nil))
+	cmd.AddCommand(serve.NewCmdServe(f))
+
+	// Translate the help epilogue
+	cobra.AddTemplateFunc("T", i18n.T)
+	cmd.SetUsageTemplate(strings.Replace(cmd.UsageTemplate(),
+		`Use "{{.CommandPath}} [command] --help" for more information about a command.`,
+		`{{T "help.more_info" .CommandPath}}`, 1))
 
 	// Add shell completion command
 	cmd.AddCommand(newCompletionCommand(f))
 
+	// Add conceptual help topics, shown by "zen help <topic>"
+	for _, topic := range helpTopics {
+		topicCmd, err := newHelpTopic(topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load help topic %s: %w", topic.Name, err)
+		}
+		cmd.AddCommand(topicCmd)
+	}
+
+	// Warn when deprecated commands are used
+	cmdutil.ApplyDeprecations(cmd)
+
 	return cmd, nil
 }
 
@@ -139,15 +218,19 @@ func Root() (*cobra.Command, error) {
 
 // newCompletionCommand creates the shell completion command
 func newCompletionCommand(f *cmdutil.Factory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "completion [bash|zsh|fish|powershell]",
 		Short: "Generate shell completion scripts",
 		Long: `Generate shell completion scripts for Zen CLI.
 
 The completion script for each shell will be different. Please refer to your shell's
-documentation on how to install completion scripts.
+documentation on how to install completion scripts, or run "zen completion install"
+to detect your shell and install the script where it is loaded automatically.
 
 Examples:
+  # Install the completion script for your shell
+  zen completion install
+
   # Generate bash completion script
   zen completion bash > /usr/local/etc/bash_completion.d/zen
 
@@ -176,4 +259,8 @@ Examples:
 			}
 		},
 	}
+
+	cmd.AddCommand(completioninstall.NewCmdCompletionInstall(f, nil))
+
+	return cmd
 }
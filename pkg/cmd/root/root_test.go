@@ -2,8 +2,11 @@ package root
 
 import (
 	"bytes"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/stretchr/testify/assert"
@@ -389,3 +392,47 @@ func BenchmarkRootCommandHelp(b *testing.B) {
 		}
 	}
 }
+
+func TestStartHTTPDebugging(t *testing.T) {
+	defer httpx.StartRecording(nil)
+	defer httpx.StartReplay(nil)
+
+	t.Run("off by default", func(t *testing.T) {
+		streams := iostreams.Test()
+		f := cmdutil.NewTestFactoryWithWorkspace(streams, true, false)
+
+		require.NoError(t, startHTTPDebugging(f, false, func(string) string { return "" }))
+		assert.Nil(t, httpx.CurrentRecorder())
+		assert.Empty(t, streams.ErrOut.(*bytes.Buffer).String())
+	})
+
+	t.Run("records to the workspace", func(t *testing.T) {
+		streams := iostreams.Test()
+		f := cmdutil.NewTestFactoryWithWorkspace(streams, true, false)
+		wm, err := f.WorkspaceManager()
+		require.NoError(t, err)
+
+		require.NoError(t, startHTTPDebugging(f, true, func(string) string { return "" }))
+		require.NotNil(t, httpx.CurrentRecorder())
+		assert.True(t, strings.HasPrefix(httpx.CurrentRecorder().Dir(), filepath.Join(wm.ZenDirectory(), "logs", "http")))
+		assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "Recording HTTP requests to")
+	})
+
+	t.Run("records to the user log directory outside workspaces", func(t *testing.T) {
+		logs := t.TempDir()
+		t.Setenv("ZEN_STATE_DIR", logs)
+		f := cmdutil.NewTestFactoryWithWorkspace(iostreams.Test(), false, false)
+
+		require.NoError(t, startHTTPDebugging(f, true, func(string) string { return "" }))
+		require.NotNil(t, httpx.CurrentRecorder())
+		assert.True(t, strings.HasPrefix(httpx.CurrentRecorder().Dir(), filepath.Join(logs, "logs", "http")))
+	})
+
+	t.Run("replay needs transcripts", func(t *testing.T) {
+		f := cmdutil.NewTestFactoryWithWorkspace(iostreams.Test(), true, false)
+		env := map[string]string{"ZEN_HTTP_REPLAY": t.TempDir()}
+
+		err := startHTTPDebugging(f, false, func(name string) string { return env[name] })
+		assert.ErrorContains(t, err, "ZEN_HTTP_REPLAY")
+	})
+}
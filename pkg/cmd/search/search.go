@@ -0,0 +1,190 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/identity"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/search"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// SearchOptions contains options for the search command
+type SearchOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	IdentityConfig   func() (identity.Config, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	Query   search.Query
+	Reindex bool
+}
+
+// NewCmdSearch creates the search command
+func NewCmdSearch(f *cmdutil.Factory, runF func(*SearchOptions) error) *cobra.Command {
+	opts := &SearchOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		IdentityConfig: func() (identity.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return identity.Config{}, err
+			}
+			return config.GetConfig(cfg, identity.ConfigParser{})
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "search [<query>...]",
+		Short: "Search the tasks, documents, and attachments of the workspace",
+		Long: heredoc.Docf(`
+			Search the content of the workspace: the index.md and manifest of each task,
+			the attachments recorded in its manifest, and its decision records and other
+			documents. Results contain every word of the query, best matches first; a
+			word ending in * matches the words it starts.
+
+			Without a query, the tasks matching the filters are listed.
+
+			The search index is kept in .zen/cache/search-index.json. Each search updates
+			it with the files changed since the last one; --reindex rebuilds it.
+
+			Document kinds are %s.
+		`, strings.Join(search.Kinds, ", ")),
+		Example: heredoc.Doc(`
+			# Search for tasks and documents about single sign-on
+			zen search single sign-on
+
+			# Search the decision records of tasks in the Design stage
+			zen search oauth --kind adr --stage design
+
+			# Search the tasks of an owner, by words starting with "migrat"
+			zen search 'migrat*' --owner alice --type story
+
+			# List the paths of matching documents
+			zen search logout --jq '.[].path'
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Query.Text = strings.Join(args, " ")
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			for _, kind := range opts.Query.Kinds {
+				if !contains(search.Kinds, kind) {
+					return &cmdutil.FlagError{Err: fmt.Errorf("invalid kind %q: must be one of %s", kind, strings.Join(search.Kinds, ", "))}
+				}
+			}
+			if opts.Query.Limit < 0 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--limit must not be negative")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return searchRun(opts)
+		},
+		GroupID: "core",
+	}
+
+	cmd.Flags().StringVar(&opts.Query.Stage, "stage", "", "Filter by the stage of the task, by ID or name")
+	cmd.Flags().StringVar(&opts.Query.Owner, "owner", "", "Filter by the owner of the task")
+	cmd.Flags().StringVar(&opts.Query.Type, "type", "", "Filter by task type")
+	cmd.Flags().StringSliceVar(&opts.Query.Kinds, "kind", nil, "Filter by document kind (repeatable)")
+	cmd.Flags().IntVarP(&opts.Query.Limit, "limit", "L", 30, "Maximum number of results, or 0 for all")
+	cmd.Flags().BoolVar(&opts.Reindex, "reindex", false, "Rebuild the search index")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func searchRun(opts *SearchOptions) error {
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	if opts.Query.Owner != "" && opts.IdentityConfig != nil {
+		if cfg, err := opts.IdentityConfig(); err == nil {
+			opts.Query.Owner = identity.New(cfg).Resolve(opts.Query.Owner)
+		}
+	}
+
+	indexPath := search.IndexPath(wm.ZenDirectory())
+	index := search.New(status.Root, indexPath)
+	if !opts.Reindex {
+		if index, err = search.Open(status.Root, indexPath); err != nil {
+			return err
+		}
+	}
+
+	stats, err := index.Update()
+	if err != nil {
+		return fmt.Errorf("failed to update search index: %w", err)
+	}
+	if stats.Indexed > 0 || stats.Removed > 0 {
+		if err := index.Save(); err != nil {
+			return err
+		}
+	}
+	if opts.Reindex {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Indexed %d files\n", opts.IO.SuccessIcon(), stats.Indexed)
+	}
+
+	results := index.Search(opts.Query)
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(results, func(w io.Writer) error {
+		return displayResults(w, opts.IO, results)
+	})
+}
+
+func displayResults(w io.Writer, streams *iostreams.IOStreams, results []search.Result) error {
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No matches.")
+		return nil
+	}
+
+	headers := []string{"TASK", "KIND", "TITLE", "STAGE", "PATH", "MATCH"}
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		rows = append(rows, []string{result.TaskID, result.Kind, result.Title, result.Stage, result.Path, result.Snippet})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package search
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/identity"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/search"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdSearch(t *testing.T) {
+	var got *SearchOptions
+	cmd := NewCmdSearch(cmdutil.NewTestFactory(iostreams.Test()), func(opts *SearchOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"single", "sign-on", "--stage", "design", "--owner", "alice", "--kind", "adr,task", "-L", "5"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, search.Query{Text: "single sign-on", Stage: "design", Owner: "alice", Kinds: []string{"adr", "task"}, Limit: 5}, got.Query)
+
+	cmd = NewCmdSearch(cmdutil.NewTestFactory(iostreams.Test()), func(opts *SearchOptions) error { return nil })
+	cmd.SetArgs([]string{"login", "--kind", "wiki"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, cmd.Execute(), &flagErr)
+}
+
+func TestSearchRun(t *testing.T) {
+	f := zentest.NewFactory(t).Build()
+	taskDir := filepath.Join(f.Workspace.Root(), ".zen", "work", "tasks", "PROJ-1")
+	require.NoError(t, os.MkdirAll(taskDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "index.md"), []byte("# PROJ-1: Add login page\n\nUsers sign in with SSO.\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "manifest.yaml"), []byte("task:\n  id: PROJ-1\n  type: story\nowner:\n  name: alice\nworkflow:\n  current_stage: 04-design\n"), 0644))
+
+	streams := iostreams.Test()
+	opts := &SearchOptions{
+		IO:               streams,
+		WorkspaceManager: f.WorkspaceManager,
+		IdentityConfig: func() (identity.Config, error) {
+			return identity.Config{Users: map[string]identity.User{"alice": {Email: "alice@example.com"}}}, nil
+		},
+		OutputFormat: cmdutil.OutputText,
+		Query:        search.Query{Text: "sso", Owner: "alice@example.com"},
+	}
+
+	require.NoError(t, searchRun(opts))
+	assert.Equal(t, "PROJ-1\ttask\tPROJ-1: Add login page\t04-design\t.zen/work/tasks/PROJ-1/index.md\tUsers sign in with SSO.\n", streams.Out.(*bytes.Buffer).String())
+	assert.FileExists(t, search.IndexPath(f.Workspace.ZenDirectory()))
+
+	streams = iostreams.Test()
+	opts.IO = streams
+	opts.Query = search.Query{Text: "kubernetes"}
+	require.NoError(t, searchRun(opts))
+	assert.Equal(t, "No matches.\n", streams.Out.(*bytes.Buffer).String())
+}
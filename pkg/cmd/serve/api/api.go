@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/httpserve"
+	"github.com/daddia/zen/pkg/apiserver"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/cmd/status"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// DefaultPort is the port the API is served on unless --port is given
+const DefaultPort = 7071
+
+// TokenEnv names the environment variable holding the token clients must present
+const TokenEnv = "ZEN_API_TOKEN"
+
+// APIOptions contains options for the serve api command
+type APIOptions struct {
+	IO         *iostreams.IOStreams
+	Factory    *cmdutil.Factory
+	AppVersion string
+
+	Host        string
+	Port        int
+	Token       string
+	AllowRemote bool
+}
+
+// Discovery is written to .zen/run/api.json while the server runs, so clients on this
+// machine can find it and authenticate
+type Discovery struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+	PID   int    `json:"pid"`
+}
+
+// NewCmdAPI creates the serve api command
+func NewCmdAPI(f *cmdutil.Factory, runF func(*APIOptions) error) *cobra.Command {
+	opts := &APIOptions{
+		IO:         f.IOStreams,
+		Factory:    f,
+		AppVersion: f.AppVersion,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Serve tasks, assets, and status over a local HTTP API",
+		Long: heredoc.Docf(`
+			Serve the core operations of the workspace as a JSON API over HTTP, for editor
+			plugins and dashboards that would otherwise run zen for every operation.
+
+			Endpoints:
+			  GET    /v1/health             liveness, without authentication
+			  GET    /v1/status             status overview, ?sections=tasks,assets
+			  GET    /v1/tasks              tasks, ?type= &status= &owner= &team= &stage= &label=
+			  POST   /v1/tasks              create a task: {"id", "title", "type", ...}
+			  GET    /v1/tasks/{id}         one task
+			  PATCH  /v1/tasks/{id}         update a task: {"title", "status", "priority", "owner", "team"}
+			  DELETE /v1/tasks/{id}         delete a task from the workspace
+			  POST   /v1/tasks/{id}/sync    sync a task: {"direction", "conflict_strategy", "dry_run"}
+			  POST   /v1/sync               sync every task
+			  GET    /v1/assets             assets, ?type= &category= &tags= &limit= &offset= &page_token=
+
+			Requests other than /v1/health must carry a token as "Authorization: Bearer
+			<token>". The token is read from %[1]s, or generated when it is not set.
+			While the server runs, its URL and token are written to .zen/run/api.json,
+			readable only by you, and the file is removed when it stops.
+
+			Errors are answered with {"error": {"code", "message", "details"}}, using the
+			error codes of 'zen --output json'.
+
+			The server listens on 127.0.0.1 and runs until you press Ctrl+C. --host
+			may name another loopback address; other addresses, which make the API
+			reachable from other machines over plain HTTP, are refused unless
+			--allow-remote is given.
+		`, TokenEnv),
+		Example: heredoc.Doc(`
+			# Serve the API at http://127.0.0.1:7071
+			zen serve api
+
+			# List in-progress tasks
+			curl -H "Authorization: Bearer $(jq -r .token .zen/run/api.json)" \
+			  "http://127.0.0.1:7071/v1/tasks?status=in_progress"
+
+			# Serve with a token of your own on any free port
+			ZEN_API_TOKEN=s3cret zen serve api --port 0
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Port < 0 || opts.Port > 65535 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("invalid port %d: must be between 0 and 65535", opts.Port)}
+			}
+			if !opts.AllowRemote && !isLoopback(opts.Host) {
+				return &cmdutil.FlagError{Err: fmt.Errorf("refusing to serve the API on %q, which is not a loopback address: use --allow-remote to serve it to other machines", opts.Host)}
+			}
+			opts.Token = os.Getenv(TokenEnv)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return apiRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Host, "host", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVarP(&opts.Port, "port", "p", DefaultPort, "Port to listen on, or 0 for any free port")
+	cmd.Flags().BoolVar(&opts.AllowRemote, "allow-remote", false, "Allow --host to be an address reachable from other machines")
+
+	return cmd
+}
+
+func apiRun(ctx context.Context, opts *APIOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ws, err := opts.Factory.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+	wsStatus, err := ws.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+	if !wsStatus.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	token := opts.Token
+	if token == "" {
+		if token, err = generateToken(); err != nil {
+			return fmt.Errorf("failed to generate API token: %w", err)
+		}
+	}
+
+	if !isLoopback(opts.Host) {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Serving the API on %s, reachable from other machines over plain HTTP: anyone who obtains the token can read and change your tasks\n",
+			opts.IO.WarningIcon(), opts.Host)
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to start API server: %w", err)
+	}
+
+	url := apiserver.BaseURL(ln.Addr())
+	discoveryPath := filepath.Join(ws.ZenDirectory(), "run", "api.json")
+	if err := writeDiscovery(discoveryPath, Discovery{URL: url, Token: token, PID: os.Getpid()}); err != nil {
+		ln.Close()
+		return err
+	}
+	defer os.Remove(discoveryPath)
+
+	fmt.Fprintf(opts.IO.Out, "%s Serving the API at %s\n", opts.IO.SuccessIcon(), url)
+	fmt.Fprintf(opts.IO.Out, "  URL and token written to %s\n", discoveryPath)
+	fmt.Fprintf(opts.IO.Out, "  Press Ctrl+C to stop\n")
+
+	handler := apiserver.NewHandler(&backend{Manager: task.NewManager(opts.Factory), factory: opts.Factory}, token, opts.AppVersion)
+	if err := httpserve.Serve(ctx, ln, handler); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s API server stopped\n", opts.IO.ColorNeutral("•"))
+	return nil
+}
+
+// backend performs the operations of the API with the task manager and asset client
+// of the factory
+type backend struct {
+	*task.Manager
+	factory *cmdutil.Factory
+}
+
+// Status implements apiserver.Backend
+func (b *backend) Status(ctx context.Context, sections []string) (interface{}, error) {
+	return status.Gather(ctx, b.factory, sections)
+}
+
+// ListAssets implements apiserver.Backend
+func (b *backend) ListAssets(ctx context.Context, filter assets.AssetFilter) (*assets.AssetList, error) {
+	client, err := b.factory.AssetClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset client: %w", err)
+	}
+	list, err := client.ListAssets(ctx, filter)
+	var assetErr *assets.AssetClientError
+	if errors.As(err, &assetErr) && assetErr.Code == assets.ErrorCodeInvalidPageToken {
+		return nil, &types.Error{Code: types.ErrorCodeInvalidInput, Message: assetErr.Message}
+	}
+	return list, err
+}
+
+// isLoopback reports whether host, a name or an IP address, is only reachable from this
+// machine
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// generateToken returns a random token of 32 bytes, hex encoded
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// writeDiscovery writes the discovery file, readable only by the current user
+func writeDiscovery(path string, discovery Discovery) error {
+	data, err := json.MarshalIndent(discovery, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
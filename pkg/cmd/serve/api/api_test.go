@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdAPI(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     string
+		want    APIOptions
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: APIOptions{Host: "127.0.0.1", Port: DefaultPort},
+		},
+		{
+			name: "port and token",
+			args: []string{"--port", "0"},
+			env:  "s3cret",
+			want: APIOptions{Host: "127.0.0.1", Port: 0, Token: "s3cret"},
+		},
+		{
+			name:    "invalid port",
+			args:    []string{"--port", "-1"},
+			wantErr: "invalid port -1",
+		},
+		{
+			name: "loopback host",
+			args: []string{"--host", "::1"},
+			want: APIOptions{Host: "::1", Port: DefaultPort},
+		},
+		{
+			name:    "remote host",
+			args:    []string{"--host", "0.0.0.0"},
+			wantErr: `refusing to serve the API on "0.0.0.0", which is not a loopback address`,
+		},
+		{
+			name: "remote host allowed",
+			args: []string{"--host", "0.0.0.0", "--allow-remote"},
+			want: APIOptions{Host: "0.0.0.0", Port: DefaultPort, AllowRemote: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(TokenEnv, tt.env)
+
+			var got *APIOptions
+			cmd := NewCmdAPI(cmdutil.NewTestFactory(iostreams.Test()), func(opts *APIOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Host, got.Host)
+			assert.Equal(t, tt.want.Port, got.Port)
+			assert.Equal(t, tt.want.Token, got.Token)
+			assert.Equal(t, tt.want.AllowRemote, got.AllowRemote)
+		})
+	}
+}
+
+func TestAPIRun(t *testing.T) {
+	f := zentest.NewFactory(t).WithAssets(assets.AssetMetadata{Name: "prd", Type: assets.AssetTypeTemplate}).Build()
+	discoveryPath := filepath.Join(f.Workspace.ZenDirectory(), "run", "api.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- apiRun(ctx, &APIOptions{IO: f.IOStreams, Factory: f.Factory, AppVersion: "v1.2.3", Host: "127.0.0.1"})
+	}()
+
+	var discovery Discovery
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(discoveryPath)
+		return err == nil && json.Unmarshal(data, &discovery) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	info, err := os.Stat(discoveryPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "only the user can read the token")
+	assert.Len(t, discovery.Token, 64)
+
+	req, err := http.NewRequest(http.MethodGet, discovery.URL+"/v1/assets", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+discovery.Token)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	var list assets.AssetList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, list.Total)
+
+	resp, err = http.Get(discovery.URL + "/v1/tasks")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.NoFileExists(t, discoveryPath, "the discovery file is removed when the server stops")
+	assert.Contains(t, f.Stdout(), "Serving the API at "+discovery.URL)
+	assert.Contains(t, f.Stdout(), "API server stopped")
+}
+
+func TestAPIRun_WorkspaceNotInitialized(t *testing.T) {
+	f := zentest.NewFactory(t).WithoutWorkspace().Build()
+
+	err := apiRun(context.Background(), &APIOptions{IO: f.IOStreams, Factory: f.Factory, Host: "127.0.0.1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workspace not initialized")
+}
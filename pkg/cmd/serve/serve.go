@@ -0,0 +1,28 @@
+package serve
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/serve/api"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdServe creates the serve command with subcommands
+func NewCmdServe(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve <command>",
+		Short: "Serve the workspace to other programs",
+		Long: heredoc.Doc(`
+			Run a local server that lets other programs, such as editor plugins and
+			dashboards, work with the workspace without running zen for every operation.
+		`),
+		Example: heredoc.Doc(`
+			# Serve tasks, assets, and status over HTTP
+			zen serve api
+		`),
+	}
+
+	cmd.AddCommand(api.NewCmdAPI(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,442 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// section is a part of the status overview that is gathered and rendered on its own
+type section struct {
+	// Name selects the section with --sections
+	Name string
+
+	// Gather sets the section on status. Failures are recorded on the section rather
+	// than returned, so one unavailable section does not hide the others.
+	Gather func(ctx context.Context, env *statusEnv, status *Status)
+
+	// Text writes the section in human-readable form, and nothing when it was not gathered
+	Text func(out io.Writer, status *Status, iostreams textFormatter)
+}
+
+// sections are the status sections, in the order they are shown
+var sections = []section{
+	{Name: "workspace", Gather: gatherWorkspace, Text: workspaceText},
+	{Name: "configuration", Gather: gatherConfiguration, Text: configurationText},
+	{Name: "system", Gather: gatherSystem, Text: systemText},
+	{Name: "assets", Gather: gatherAssets, Text: assetsText},
+	{Name: "integrations", Gather: gatherIntegrations, Text: integrationsText},
+	{Name: "tasks", Gather: gatherTasks, Text: tasksText},
+	{Name: "conflicts", Gather: gatherConflicts, Text: conflictsText},
+}
+
+// sectionNames returns the names of the status sections, in the order they are shown
+func sectionNames() []string {
+	names := make([]string, 0, len(sections))
+	for _, section := range sections {
+		names = append(names, section.Name)
+	}
+	return names
+}
+
+// selectSections returns the named sections in display order, or every section when no
+// names are given
+func selectSections(names []string) ([]section, error) {
+	if len(names) == 0 {
+		return sections, nil
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, section := range sections {
+			if section.Name == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, &cmdutil.FlagError{Err: fmt.Errorf("unknown status section %q; valid sections are: %s",
+				name, strings.Join(sectionNames(), ", "))}
+		}
+		wanted[name] = true
+	}
+
+	var selected []section
+	for _, section := range sections {
+		if wanted[section.Name] {
+			selected = append(selected, section)
+		}
+	}
+	return selected, nil
+}
+
+// Gather returns the named sections of the status overview, or every section when no
+// names are given, for callers that serve the overview rather than print it
+func Gather(ctx context.Context, f *cmdutil.Factory, names []string) (Status, error) {
+	selected, err := selectSections(names)
+	if err != nil {
+		return Status{}, err
+	}
+
+	ws, err := f.WorkspaceManager()
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+	wsStatus, err := ws.Status()
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to get workspace status: %w", err)
+	}
+	if !wsStatus.Initialized {
+		return Status{}, &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "not a zen workspace (or any of the parent directories): .zen",
+		}
+	}
+
+	return gather(ctx, f, wsStatus, selected), nil
+}
+
+// gather gathers the selected sections for the workspace
+func gather(ctx context.Context, f *cmdutil.Factory, workspace cmdutil.WorkspaceStatus, selected []section) Status {
+	env := &statusEnv{factory: f, workspace: workspace}
+	env.config, env.configErr = f.Config()
+
+	status := Status{}
+	for _, section := range selected {
+		section.Gather(ctx, env, &status)
+	}
+	return status
+}
+
+// statusEnv holds what sections are gathered from, loading the workspace tasks once for
+// the sections that share them
+type statusEnv struct {
+	factory   *cmdutil.Factory
+	workspace cmdutil.WorkspaceStatus
+	config    *config.Config
+	configErr error
+
+	tasks    []*task.Task
+	tasksErr error
+	loaded   bool
+}
+
+// listTasks returns the tasks of the workspace
+func (e *statusEnv) listTasks(ctx context.Context) ([]*task.Task, error) {
+	if !e.loaded {
+		e.loaded = true
+		e.tasks, e.tasksErr = task.NewManager(e.factory).ListTasks(ctx, &task.TaskFilter{})
+	}
+	return e.tasks, e.tasksErr
+}
+
+// isAuthenticated reports whether credentials are stored and valid for provider
+func (e *statusEnv) isAuthenticated(ctx context.Context, provider string) bool {
+	if e.factory.AuthManager == nil || provider == "" {
+		return false
+	}
+	authManager, err := e.factory.AuthManager()
+	if err != nil {
+		return false
+	}
+	return authManager.IsAuthenticated(ctx, provider)
+}
+
+func gatherWorkspace(ctx context.Context, env *statusEnv, status *Status) {
+	status.Workspace = &WorkspaceStatus{
+		Initialized: env.workspace.Initialized,
+		Path:        env.workspace.Root,
+		ConfigFile:  env.workspace.ConfigPath,
+	}
+}
+
+func gatherConfiguration(ctx context.Context, env *statusEnv, status *Status) {
+	logLevel := "unknown"
+	if env.config != nil {
+		logLevel = env.config.Core.LogLevel
+	}
+	status.Configuration = &ConfigStatus{
+		Loaded:   env.configErr == nil && isRealConfig(env.config),
+		Source:   getConfigSource(env.config),
+		LogLevel: logLevel,
+	}
+}
+
+func gatherSystem(ctx context.Context, env *statusEnv, status *Status) {
+	status.System = &SystemStatus{
+		OS:           runtime.GOOS,
+		Architecture: runtime.GOARCH,
+		GoVersion:    runtime.Version(),
+		NumCPU:       runtime.NumCPU(),
+	}
+}
+
+func gatherAssets(ctx context.Context, env *statusEnv, status *Status) {
+	assetsStatus := &AssetsStatus{}
+	status.Assets = assetsStatus
+
+	if env.config == nil {
+		assetsStatus.Error = "configuration is not loaded"
+		return
+	}
+	assetsConfig, err := config.GetConfig(env.config, assets.ConfigParser{})
+	if err != nil {
+		assetsStatus.Error = err.Error()
+		return
+	}
+	assetsStatus.Repository = assetsConfig.RepositoryURL
+	assetsStatus.Branch = assetsConfig.Branch
+	assetsStatus.AuthProvider = assetsConfig.AuthProvider
+	assetsStatus.Authenticated = env.isAuthenticated(ctx, assetsConfig.AuthProvider)
+
+	if env.factory.AssetClient == nil {
+		return
+	}
+	client, err := env.factory.AssetClient()
+	if err != nil {
+		assetsStatus.Error = fmt.Sprintf("asset client unavailable: %v", err)
+		return
+	}
+	cacheInfo, err := client.GetCacheInfo(ctx)
+	if err != nil {
+		assetsStatus.Error = fmt.Sprintf("cache unavailable: %v", err)
+		return
+	}
+	assetsStatus.CachedAssets = cacheInfo.AssetCount
+	assetsStatus.CacheSizeMB = float64(cacheInfo.TotalSize) / (1024 * 1024)
+	assetsStatus.LastSync = cacheInfo.LastSync
+}
+
+func gatherIntegrations(ctx context.Context, env *statusEnv, status *Status) {
+	integrations := &IntegrationStatus{
+		Available: []string{"jira", "confluence", "git", "slack"},
+		Active:    []string{},
+	}
+	status.Integrations = integrations
+
+	if env.config != nil {
+		if taskConfig, err := config.GetConfig(env.config, task.ConfigParser{}); err == nil && isExternalSource(taskConfig.Source) {
+			integrations.Active = append(integrations.Active, taskConfig.Source)
+			integrations.TaskSystem = &TaskSystemStatus{
+				Source:        taskConfig.Source,
+				ProjectKey:    taskConfig.ProjectKey,
+				Sync:          taskConfig.Sync,
+				Authenticated: env.isAuthenticated(ctx, taskConfig.Source),
+			}
+		}
+	}
+
+	// Provider API responses cached across commands
+	if env.factory.ResponseCache != nil {
+		if responses, err := env.factory.ResponseCache(); err == nil {
+			integrations.ResponseCache, _ = responses.Stats(ctx)
+		}
+	}
+}
+
+// isExternalSource reports whether tasks are synced with an external system
+func isExternalSource(source string) bool {
+	return source != "" && source != "local" && source != "none"
+}
+
+func gatherTasks(ctx context.Context, env *statusEnv, status *Status) {
+	tasksStatus := &TasksStatus{Stages: []StageCount{}}
+	status.Tasks = tasksStatus
+
+	tasks, err := env.listTasks(ctx)
+	if err != nil {
+		tasksStatus.Error = err.Error()
+		return
+	}
+
+	counts := map[string]int{}
+	for _, t := range tasks {
+		if !isActiveTask(t) {
+			continue
+		}
+		counts[t.CurrentStage]++
+		tasksStatus.Active++
+	}
+
+	for _, stage := range task.WorkflowStages {
+		tasksStatus.Stages = append(tasksStatus.Stages, StageCount{
+			Stage: stage,
+			Name:  task.StageName(stage),
+			Count: counts[stage],
+		})
+		delete(counts, stage)
+	}
+	// Tasks in stages outside the standard workflow are still counted
+	others := make([]string, 0, len(counts))
+	for stage := range counts {
+		others = append(others, stage)
+	}
+	sort.Strings(others)
+	for _, stage := range others {
+		tasksStatus.Stages = append(tasksStatus.Stages, StageCount{Stage: stage, Name: task.StageName(stage), Count: counts[stage]})
+	}
+}
+
+// isActiveTask reports whether a task is still being worked on
+func isActiveTask(t *task.Task) bool {
+	switch strings.ToLower(t.Status) {
+	case "completed", "cancelled", "canceled":
+		return false
+	default:
+		return true
+	}
+}
+
+func gatherConflicts(ctx context.Context, env *statusEnv, status *Status) {
+	conflicts := &ConflictsStatus{Tasks: []PendingConflict{}}
+	status.Conflicts = conflicts
+
+	tasks, err := env.listTasks(ctx)
+	if err != nil {
+		conflicts.Error = err.Error()
+		return
+	}
+
+	for _, t := range tasks {
+		for source, taskSource := range t.Sources {
+			if len(taskSource.PendingConflicts) == 0 {
+				continue
+			}
+			fields := make([]string, 0, len(taskSource.PendingConflicts))
+			for _, conflict := range taskSource.PendingConflicts {
+				fields = append(fields, conflict.Field)
+			}
+			conflicts.Tasks = append(conflicts.Tasks, PendingConflict{TaskID: t.ID, Source: source, Fields: fields})
+		}
+	}
+	sort.Slice(conflicts.Tasks, func(i, j int) bool {
+		if conflicts.Tasks[i].TaskID != conflicts.Tasks[j].TaskID {
+			return conflicts.Tasks[i].TaskID < conflicts.Tasks[j].TaskID
+		}
+		return conflicts.Tasks[i].Source < conflicts.Tasks[j].Source
+	})
+	conflicts.Pending = len(conflicts.Tasks)
+}
+
+func workspaceText(out io.Writer, status *Status, iostreams textFormatter) {
+	ws := status.Workspace
+	if ws == nil {
+		return
+	}
+	fmt.Fprintln(out, iostreams.FormatBold("Workspace:"))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Status:      %s\n",
+		iostreams.FormatBoolStatus(ws.Initialized, "Ready", "Not Initialized")), 1))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Path:        %s\n", ws.Path), 1))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Config File: %s\n", ws.ConfigFile), 1))
+}
+
+func configurationText(out io.Writer, status *Status, iostreams textFormatter) {
+	cfg := status.Configuration
+	if cfg == nil {
+		return
+	}
+	fmt.Fprintln(out, iostreams.FormatBold("Configuration:"))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Status:    %s\n",
+		iostreams.FormatBoolStatus(cfg.Loaded, "Loaded", "Not Loaded")), 1))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Source:    %s\n", cfg.Source), 1))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Log Level: %s\n", cfg.LogLevel), 1))
+}
+
+func systemText(out io.Writer, status *Status, iostreams textFormatter) {
+	sys := status.System
+	if sys == nil {
+		return
+	}
+	fmt.Fprintln(out, iostreams.FormatBold("System:"))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("OS:           %s\n", sys.OS), 1))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Architecture: %s\n", sys.Architecture), 1))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Go Version:   %s\n", sys.GoVersion), 1))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("CPU Cores:    %d\n", sys.NumCPU), 1))
+}
+
+func assetsText(out io.Writer, status *Status, iostreams textFormatter) {
+	a := status.Assets
+	if a == nil {
+		return
+	}
+	fmt.Fprintln(out, iostreams.FormatBold("Assets:"))
+	if a.Repository != "" {
+		fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Repository: %s (%s)\n", a.Repository, a.Branch), 1))
+	}
+	if a.AuthProvider != "" {
+		fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Auth:       %s\n",
+			iostreams.FormatBoolStatus(a.Authenticated, "Authenticated with "+a.AuthProvider, "Not authenticated with "+a.AuthProvider)), 1))
+	}
+	cache := fmt.Sprintf("%d assets, %.1f MB", a.CachedAssets, a.CacheSizeMB)
+	if !a.LastSync.IsZero() {
+		cache += ", last synced " + a.LastSync.Format("2006-01-02 15:04")
+	}
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Cache:      %s\n", cache), 1))
+	if a.Error != "" {
+		fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Error:      %s\n", a.Error), 1))
+	}
+}
+
+func integrationsText(out io.Writer, status *Status, iostreams textFormatter) {
+	integrations := status.Integrations
+	if integrations == nil {
+		return
+	}
+	fmt.Fprintln(out, iostreams.FormatBold("Integrations:"))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Available: %v\n", integrations.Available), 1))
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Active:    %v\n", integrations.Active), 1))
+	if ts := integrations.TaskSystem; ts != nil {
+		fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Tasks:     %s, sync %s, %s\n", ts.Source, ts.Sync,
+			iostreams.FormatBoolStatus(ts.Authenticated, "Authenticated", "Not authenticated")), 1))
+	}
+	if responses := integrations.ResponseCache; responses != nil {
+		fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Responses: %d cached, %d hits, %d misses (%.0f%% hit rate)\n",
+			responses.Entries, responses.Hits, responses.Misses, responses.HitRatio*100), 1))
+	}
+}
+
+func tasksText(out io.Writer, status *Status, iostreams textFormatter) {
+	tasks := status.Tasks
+	if tasks == nil {
+		return
+	}
+	fmt.Fprintln(out, iostreams.FormatBold("Tasks:"))
+	if tasks.Error != "" {
+		fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Error:  %s\n", tasks.Error), 1))
+		return
+	}
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Active: %d\n", tasks.Active), 1))
+	for _, stage := range tasks.Stages {
+		if stage.Count > 0 {
+			fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("%-10s %d\n", stage.Name+":", stage.Count), 2))
+		}
+	}
+}
+
+func conflictsText(out io.Writer, status *Status, iostreams textFormatter) {
+	conflicts := status.Conflicts
+	if conflicts == nil {
+		return
+	}
+	fmt.Fprintln(out, iostreams.FormatBold("Conflicts:"))
+	if conflicts.Error != "" {
+		fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Error:   %s\n", conflicts.Error), 1))
+		return
+	}
+	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Pending: %d\n", conflicts.Pending), 1))
+	for _, pending := range conflicts.Tasks {
+		fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("%s (%s): %s\n",
+			pending.TaskID, pending.Source, strings.Join(pending.Fields, ", ")), 2))
+	}
+}
@@ -1,23 +1,30 @@
 package status
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
+	"io"
 	"path/filepath"
-	"runtime"
+	"strings"
+	"time"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/daddia/zen/internal/config"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
-// Status represents the current system status
+// Status represents the current system status. Each section is only set when it was
+// requested with --sections.
 type Status struct {
-	Workspace     WorkspaceStatus   `json:"workspace" yaml:"workspace"`
-	Configuration ConfigStatus      `json:"configuration" yaml:"configuration"`
-	System        SystemStatus      `json:"system" yaml:"system"`
-	Integrations  IntegrationStatus `json:"integrations" yaml:"integrations"`
+	Workspace     *WorkspaceStatus   `json:"workspace,omitempty" yaml:"workspace,omitempty"`
+	Configuration *ConfigStatus      `json:"configuration,omitempty" yaml:"configuration,omitempty"`
+	System        *SystemStatus      `json:"system,omitempty" yaml:"system,omitempty"`
+	Assets        *AssetsStatus      `json:"assets,omitempty" yaml:"assets,omitempty"`
+	Integrations  *IntegrationStatus `json:"integrations,omitempty" yaml:"integrations,omitempty"`
+	Tasks         *TasksStatus       `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+	Conflicts     *ConflictsStatus   `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
 }
 
 // WorkspaceStatus represents workspace information
@@ -42,114 +49,165 @@ type SystemStatus struct {
 	NumCPU       int    `json:"num_cpu" yaml:"num_cpu"`
 }
 
+// AssetsStatus represents the asset repository, its authentication and the local cache
+type AssetsStatus struct {
+	Repository    string    `json:"repository" yaml:"repository"`
+	Branch        string    `json:"branch" yaml:"branch"`
+	AuthProvider  string    `json:"auth_provider" yaml:"auth_provider"`
+	Authenticated bool      `json:"authenticated" yaml:"authenticated"`
+	CachedAssets  int       `json:"cached_assets" yaml:"cached_assets"`
+	CacheSizeMB   float64   `json:"cache_size_mb" yaml:"cache_size_mb"`
+	LastSync      time.Time `json:"last_sync" yaml:"last_sync"`
+	Error         string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
 // IntegrationStatus represents integration status
 type IntegrationStatus struct {
-	Available []string `json:"available" yaml:"available"`
-	Active    []string `json:"active" yaml:"active"`
+	Available     []string                    `json:"available" yaml:"available"`
+	Active        []string                    `json:"active" yaml:"active"`
+	TaskSystem    *TaskSystemStatus           `json:"task_system,omitempty" yaml:"task_system,omitempty"`
+	ResponseCache *zenhttp.ResponseCacheStats `json:"response_cache,omitempty" yaml:"response_cache,omitempty"`
+}
+
+// TaskSystemStatus represents the health of the external system tasks are synced with
+type TaskSystemStatus struct {
+	Source        string `json:"source" yaml:"source"`
+	ProjectKey    string `json:"project_key,omitempty" yaml:"project_key,omitempty"`
+	Sync          string `json:"sync" yaml:"sync"`
+	Authenticated bool   `json:"authenticated" yaml:"authenticated"`
+}
+
+// TasksStatus represents the number of active tasks in each workflow stage
+type TasksStatus struct {
+	Active int          `json:"active" yaml:"active"`
+	Stages []StageCount `json:"stages" yaml:"stages"`
+	Error  string       `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// StageCount is the number of active tasks in a workflow stage
+type StageCount struct {
+	Stage string `json:"stage" yaml:"stage"`
+	Name  string `json:"name" yaml:"name"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// ConflictsStatus represents the task syncs held for manual conflict review
+type ConflictsStatus struct {
+	Pending int               `json:"pending" yaml:"pending"`
+	Tasks   []PendingConflict `json:"tasks" yaml:"tasks"`
+	Error   string            `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// PendingConflict is a task sync held for manual review of the fields changed on both
+// sides
+type PendingConflict struct {
+	TaskID string   `json:"task_id" yaml:"task_id"`
+	Source string   `json:"source" yaml:"source"`
+	Fields []string `json:"fields" yaml:"fields"`
+}
+
+// StatusOptions contains options for the status command
+type StatusOptions struct {
+	Factory  *cmdutil.Factory
+	Sections []string
 }
 
 // NewCmdStatus creates the status command
 func NewCmdStatus(f *cmdutil.Factory) *cobra.Command {
+	opts := &StatusOptions{
+		Factory: f,
+	}
+
 	cmd := &cobra.Command{
 		Use:     "status",
 		Short:   "Display workspace and system status",
 		GroupID: "workspace",
-		Long: `Display comprehensive status information about your Zen workspace,
-configuration, system environment, and available integrations.
+		Long: heredoc.Docf(`
+			Display comprehensive status information about your Zen workspace,
+			configuration, system environment, assets, integrations and tasks.
+
+			This command provides a detailed overview of the current state of your Zen installation
+			and workspace, helping you troubleshoot issues and understand your environment.
+
+			The overview is made of sections, each gathered and rendered on its own. Use
+			--sections to show only some of them:
+
+			  %s
+
+			A section that cannot be gathered reports its error instead of failing the command.
+		`, strings.Join(sectionNames(), ", ")),
+		Example: heredoc.Doc(`
+			# Display status overview
+			zen status
+
+			# Show only the asset and integration health
+			zen status --sections assets,integrations
 
-This command provides a detailed overview of the current state of your Zen installation
-and workspace, helping you troubleshoot issues and understand your environment.`,
-		Example: `  # Display status overview
-  zen status
+			# Output status as JSON for scripting
+			zen status --output json
 
-  # Output status as JSON for scripting
-  zen status --output json
+			# Output status as YAML
+			zen status --output yaml
 
-  # Output status as YAML
-  zen status --output yaml
+			# List active integrations
+			zen status --jq '.integrations.active[]'
 
-  # Check status with verbose output
-  zen status --verbose`,
+			# Count the task syncs waiting for conflict review
+			zen status --sections conflicts --jq '.conflicts.pending'
+
+			# Show how often provider API responses are served from the cache
+			zen status --jq '.integrations.response_cache.hit_ratio'
+
+			# Check status with verbose output
+			zen status --verbose
+		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Get workspace manager and check if we're in a zen workspace
-			ws, err := f.WorkspaceManager()
-			if err != nil {
-				return fmt.Errorf("failed to get workspace manager: %w", err)
-			}
-
-			wsStatus, err := ws.Status()
-			if err != nil {
-				return fmt.Errorf("failed to get workspace status: %w", err)
-			}
-
-			// If not in a zen workspace, return git-like error message
-			if !wsStatus.Initialized {
-				fmt.Fprintf(f.IOStreams.ErrOut, "%s\n",
-					f.IOStreams.FormatError("Not Initialized: Not a zen workspace (or any of the parent directories): .zen"))
-				return cmdutil.ErrSilent
-			}
-
-			// Get configuration
-			cfg, configErr := f.Config()
-
-			// Build status
-			status := Status{
-				Workspace: WorkspaceStatus{
-					Initialized: wsStatus.Initialized,
-					Path:        wsStatus.Root,
-					ConfigFile:  wsStatus.ConfigPath,
-				},
-				Configuration: ConfigStatus{
-					Loaded: configErr == nil && isRealConfig(cfg),
-					Source: getConfigSource(cfg),
-					LogLevel: func() string {
-						if cfg != nil {
-							return cfg.Core.LogLevel
-						}
-						return "unknown"
-					}(),
-				},
-				System: SystemStatus{
-					OS:           runtime.GOOS,
-					Architecture: runtime.GOARCH,
-					GoVersion:    runtime.Version(),
-					NumCPU:       runtime.NumCPU(),
-				},
-				Integrations: IntegrationStatus{
-					Available: []string{"jira", "confluence", "git", "slack"},
-					Active:    []string{},
-				},
-			}
-
-			// Get output format
-			outputFormat := "text"
-			if cmd.Parent() != nil && cmd.Parent().PersistentFlags().Changed("output") {
-				if val, err := cmd.Parent().PersistentFlags().GetString("output"); err == nil {
-					outputFormat = val
-				}
-			}
-
-			switch outputFormat {
-			case "json":
-				encoder := json.NewEncoder(f.IOStreams.Out)
-				encoder.SetIndent("", "  ")
-				return encoder.Encode(status)
-
-			case "yaml":
-				encoder := yaml.NewEncoder(f.IOStreams.Out)
-				defer encoder.Close()
-				return encoder.Encode(status)
-
-			default:
-				return displayTextStatus(f.IOStreams.Out, status, f.IOStreams)
-			}
+			return statusRun(cmd, opts)
 		},
 	}
 
+	cmd.Flags().StringSliceVar(&opts.Sections, "sections", nil,
+		fmt.Sprintf("Sections to show: {%s}", strings.Join(sectionNames(), "|")))
+	cmdutil.AddFormatFlags(cmd)
+
 	return cmd
 }
 
+func statusRun(cmd *cobra.Command, opts *StatusOptions) error {
+	f := opts.Factory
+
+	selected, err := selectSections(opts.Sections)
+	if err != nil {
+		return err
+	}
+
+	// Get workspace manager and check if we're in a zen workspace
+	ws, err := f.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	wsStatus, err := ws.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	// If not in a zen workspace, return git-like error message
+	if !wsStatus.Initialized {
+		fmt.Fprintf(f.IOStreams.ErrOut, "%s\n",
+			f.IOStreams.FormatError("Not Initialized: Not a zen workspace (or any of the parent directories): .zen"))
+		return cmdutil.ErrSilent
+	}
+
+	status := gather(cmd.Context(), f, wsStatus, selected)
+
+	renderer := cmdutil.NewRendererForCommand(f.IOStreams, cmd)
+	return renderer.Render(status, func(w io.Writer) error {
+		return displayTextStatus(w, status, f.IOStreams)
+	})
+}
+
 // getConfigSource determines where configuration was loaded from
 func getConfigSource(cfg *config.Config) string {
 	if cfg == nil {
@@ -180,45 +238,31 @@ func isRealConfig(cfg *config.Config) bool {
 	return false
 }
 
-// displayTextStatus displays status in human-readable text format following design guide
-func displayTextStatus(out interface{ Write([]byte) (int, error) }, status Status, iostreams interface {
+// textFormatter formats the text output of status sections
+type textFormatter interface {
 	FormatSectionHeader(string) string
 	FormatBoolStatus(bool, string, string) string
 	FormatBold(string) string
 	Indent(string, int) string
-}) error {
+}
+
+// displayTextStatus displays status in human-readable text format following design guide,
+// rendering each section that was gathered
+func displayTextStatus(out io.Writer, status Status, iostreams textFormatter) error {
 	// Main header following design guide typography
 	fmt.Fprintln(out, iostreams.FormatSectionHeader("Zen CLI Status"))
-	fmt.Fprintln(out)
-
-	// Workspace status with proper formatting and indentation
-	fmt.Fprintln(out, iostreams.FormatBold("Workspace:"))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Status:      %s\n",
-		iostreams.FormatBoolStatus(status.Workspace.Initialized, "Ready", "Not Initialized")), 1))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Path:        %s\n", status.Workspace.Path), 1))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Config File: %s\n", status.Workspace.ConfigFile), 1))
-	fmt.Fprintln(out)
-
-	// Configuration status
-	fmt.Fprintln(out, iostreams.FormatBold("Configuration:"))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Status:    %s\n",
-		iostreams.FormatBoolStatus(status.Configuration.Loaded, "Loaded", "Not Loaded")), 1))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Source:    %s\n", status.Configuration.Source), 1))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Log Level: %s\n", status.Configuration.LogLevel), 1))
-	fmt.Fprintln(out)
-
-	// System information
-	fmt.Fprintln(out, iostreams.FormatBold("System:"))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("OS:           %s\n", status.System.OS), 1))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Architecture: %s\n", status.System.Architecture), 1))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Go Version:   %s\n", status.System.GoVersion), 1))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("CPU Cores:    %d\n", status.System.NumCPU), 1))
-	fmt.Fprintln(out)
-
-	// Integrations
-	fmt.Fprintln(out, iostreams.FormatBold("Integrations:"))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Available: %v\n", status.Integrations.Available), 1))
-	fmt.Fprint(out, iostreams.Indent(fmt.Sprintf("Active:    %v\n", status.Integrations.Active), 1))
+
+	for _, section := range sections {
+		var buf bytes.Buffer
+		section.Text(&buf, &status, iostreams)
+		if buf.Len() == 0 {
+			continue
+		}
+		fmt.Fprintln(out)
+		if _, err := buf.WriteTo(out); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -3,10 +3,15 @@ package status
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/daddia/zen/internal/config"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
 	"github.com/daddia/zen/pkg/cmd/factory"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -48,23 +53,23 @@ func TestNewCmdStatus(t *testing.T) {
 
 func TestDisplayTextStatus(t *testing.T) {
 	status := Status{
-		Workspace: WorkspaceStatus{
+		Workspace: &WorkspaceStatus{
 			Initialized: true,
 			Path:        "/home/user/project",
 			ConfigFile:  "zen.yaml",
 		},
-		Configuration: ConfigStatus{
+		Configuration: &ConfigStatus{
 			Loaded:   true,
 			Source:   "zen.yaml",
 			LogLevel: "info",
 		},
-		System: SystemStatus{
+		System: &SystemStatus{
 			OS:           "linux",
 			Architecture: "amd64",
 			GoVersion:    "go1.21.0",
 			NumCPU:       8,
 		},
-		Integrations: IntegrationStatus{
+		Integrations: &IntegrationStatus{
 			Available: []string{"jira", "confluence", "git", "slack"},
 			Active:    []string{"git"},
 		},
@@ -94,27 +99,33 @@ func TestDisplayTextStatus(t *testing.T) {
 	assert.Contains(t, output, "amd64")
 	assert.Contains(t, output, "go1.21.0")
 	assert.Contains(t, output, "8")
+	assert.NotContains(t, output, "Responses:")
+
+	status.Integrations.ResponseCache = &zenhttp.ResponseCacheStats{Entries: 4, Hits: 3, Misses: 1, HitRatio: 0.75}
+	buf.Reset()
+	require.NoError(t, displayTextStatus(buf, status, mockStreams))
+	assert.Contains(t, buf.String(), "Responses: 4 cached, 3 hits, 1 misses (75% hit rate)")
 }
 
 func TestDisplayTextStatus_NotInitialized(t *testing.T) {
 	status := Status{
-		Workspace: WorkspaceStatus{
+		Workspace: &WorkspaceStatus{
 			Initialized: false,
 			Path:        "/home/user/project",
 			ConfigFile:  "",
 		},
-		Configuration: ConfigStatus{
+		Configuration: &ConfigStatus{
 			Loaded:   false,
 			Source:   "none",
 			LogLevel: "unknown",
 		},
-		System: SystemStatus{
+		System: &SystemStatus{
 			OS:           "darwin",
 			Architecture: "arm64",
 			GoVersion:    "go1.21.0",
 			NumCPU:       4,
 		},
-		Integrations: IntegrationStatus{
+		Integrations: &IntegrationStatus{
 			Available: []string{},
 			Active:    []string{},
 		},
@@ -175,23 +186,23 @@ func TestStatusCommand_Integration(t *testing.T) {
 
 func TestStatusJSON(t *testing.T) {
 	status := Status{
-		Workspace: WorkspaceStatus{
+		Workspace: &WorkspaceStatus{
 			Initialized: true,
 			Path:        "/test",
 			ConfigFile:  "zen.yaml",
 		},
-		Configuration: ConfigStatus{
+		Configuration: &ConfigStatus{
 			Loaded:   true,
 			Source:   "zen.yaml",
 			LogLevel: "info",
 		},
-		System: SystemStatus{
+		System: &SystemStatus{
 			OS:           "linux",
 			Architecture: "amd64",
 			GoVersion:    "go1.21.0",
 			NumCPU:       4,
 		},
-		Integrations: IntegrationStatus{
+		Integrations: &IntegrationStatus{
 			Available: []string{"git"},
 			Active:    []string{},
 		},
@@ -205,3 +216,80 @@ func TestStatusJSON(t *testing.T) {
 	assert.Contains(t, string(data), `"loaded":true`)
 	assert.Contains(t, string(data), `"os":"linux"`)
 }
+
+func TestDisplayTextStatus_Sections(t *testing.T) {
+	status := Status{
+		Assets: &AssetsStatus{
+			Repository:    "https://github.com/daddia/zen-assets.git",
+			Branch:        "main",
+			AuthProvider:  "github",
+			Authenticated: true,
+			CachedAssets:  12,
+		},
+		Tasks: &TasksStatus{
+			Active: 3,
+			Stages: []StageCount{
+				{Stage: "01-align", Name: "Align", Count: 2},
+				{Stage: "05-build", Name: "Build", Count: 1},
+				{Stage: "06-ship", Name: "Ship", Count: 0},
+			},
+		},
+		Conflicts: &ConflictsStatus{
+			Pending: 1,
+			Tasks:   []PendingConflict{{TaskID: "PROJ-1", Source: "jira", Fields: []string{"title", "status"}}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, displayTextStatus(buf, status, &mockIOStreams{}))
+	output := buf.String()
+
+	assert.NotContains(t, output, "Workspace:", "sections that were not gathered are not shown")
+	assert.NotContains(t, output, "System:")
+	assert.Contains(t, output, "Repository: https://github.com/daddia/zen-assets.git (main)")
+	assert.Contains(t, output, "✓ Authenticated with github")
+	assert.Contains(t, output, "12 assets")
+	assert.Contains(t, output, "Active: 3")
+	assert.Contains(t, output, "Build:     1")
+	assert.NotContains(t, output, "Ship:", "empty stages are left out")
+	assert.Contains(t, output, "PROJ-1 (jira): title, status")
+	assert.Less(t, strings.Index(output, "Assets:"), strings.Index(output, "Conflicts:"))
+}
+
+func TestSelectSections(t *testing.T) {
+	all, err := selectSections(nil)
+	require.NoError(t, err)
+	assert.Len(t, all, len(sectionNames()))
+
+	selected, err := selectSections([]string{"integrations", " Assets"})
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	assert.Equal(t, "assets", selected[0].Name, "sections keep their display order")
+	assert.Equal(t, "integrations", selected[1].Name)
+
+	_, err = selectSections([]string{"assets", "health"})
+	var flagErr *cmdutil.FlagError
+	require.ErrorAs(t, err, &flagErr)
+	assert.Contains(t, err.Error(), `unknown status section "health"`)
+}
+
+func TestStatusCommand_Sections(t *testing.T) {
+	streams := iostreams.Test()
+	f := cmdutil.NewTestFactoryWithWorkspace(streams, true, false)
+
+	// Create parent command to inherit persistent flags
+	rootCmd := &cobra.Command{Use: "zen"}
+	rootCmd.PersistentFlags().StringP("output", "o", "text", "Output format")
+	rootCmd.AddGroup(&cobra.Group{ID: "workspace", Title: "Workspace"})
+	rootCmd.AddCommand(NewCmdStatus(f))
+	rootCmd.SetArgs([]string{"status", "--sections", "system,assets", "--output", "json"})
+
+	require.NoError(t, rootCmd.Execute())
+
+	var status map[string]interface{}
+	require.NoError(t, json.Unmarshal(streams.Out.(*bytes.Buffer).Bytes(), &status))
+	assert.Contains(t, status, "system")
+	assert.Contains(t, status, "assets")
+	assert.NotContains(t, status, "workspace")
+	assert.NotContains(t, status, "integrations")
+}
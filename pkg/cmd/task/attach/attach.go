@@ -0,0 +1,291 @@
+package attach
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager attaches files to tasks and transfers them to and from external sources
+type TaskManager interface {
+	GetTask(ctx context.Context, taskID string) (*task.Task, error)
+	Attach(ctx context.Context, taskID, target string) (*task.Attachment, bool, error)
+	UploadAttachments(ctx context.Context, taskID, source string) ([]task.Attachment, error)
+	DownloadAttachments(ctx context.Context, taskID, source string) ([]task.Attachment, error)
+}
+
+// AttachOptions contains options for the task attach command
+type AttachOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskManager, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	TaskID   string
+	Targets  []string
+	Upload   bool
+	Download bool
+	Source   string
+}
+
+// Entry is an attachment as listed, with the state of its file
+type Entry struct {
+	task.Attachment
+	State string `json:"state"`
+}
+
+// NewCmdTaskAttach creates the task attach command
+func NewCmdTaskAttach(f *cmdutil.Factory, runF func(*AttachOptions) error) *cobra.Command {
+	opts := &AttachOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "attach <task-id> [<file|url>...]",
+		Short: "Attach files and links to a task",
+		Long: heredoc.Doc(`
+			Attach files and links to a task, or list its attachments when none are given.
+
+			Files are copied to the attachments/ directory of the task and recorded in its
+			manifest with their size and SHA-256 checksum. Links are recorded as references
+			and are not downloaded. Listing attachments checks each file against its
+			checksum and reports files that are modified or missing.
+
+			With --upload, the files of the task are uploaded to the external sources it is
+			linked to, and with --download, the files attached to it there are downloaded.
+			Each file is transferred once. Jira supports both; GitHub issues support
+			downloading the files linked from the issue and its comments, as GitHub's API
+			does not accept uploads.
+		`),
+		Example: heredoc.Doc(`
+			# Attach a design and a link to the specification
+			zen task attach PROJ-123 design.pdf https://wiki.example.com/specs/login
+
+			# Attach a file and upload it to Jira
+			zen task attach PROJ-123 screenshot.png --upload --source jira
+
+			# Download the files attached to the task's issues
+			zen task attach PROJ-123 --download
+
+			# List the attachments of a task
+			zen task attach PROJ-123
+		`),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID, opts.Targets = args[0], args[1:]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if opts.Source != "" && !opts.Upload && !opts.Download {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--source requires --upload or --download")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return attachRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Upload, "upload", false, "Upload the task's files to its external sources")
+	cmd.Flags().BoolVar(&opts.Download, "download", false, "Download the files attached to the task in its external sources")
+	cmd.Flags().StringVar(&opts.Source, "source", "", "Only transfer attachments to or from this source")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func attachRun(ctx context.Context, opts *AttachOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	for _, target := range opts.Targets {
+		attachment, added, err := manager.Attach(ctx, opts.TaskID, target)
+		if err != nil {
+			return err
+		}
+		if added {
+			fmt.Fprintf(opts.IO.ErrOut, "%s Attached %s to %s\n", opts.IO.SuccessIcon(), attachment.Name, opts.TaskID)
+		} else {
+			fmt.Fprintf(opts.IO.ErrOut, "%s %s is already attached to %s as %s\n", opts.IO.NeutralIcon(), target, opts.TaskID, attachment.Name)
+		}
+	}
+
+	if opts.Upload || opts.Download {
+		return transferRun(ctx, opts, manager)
+	}
+	if len(opts.Targets) > 0 {
+		return nil
+	}
+
+	t, err := manager.GetTask(ctx, opts.TaskID)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]Entry, 0, len(t.Attachments))
+	for _, attachment := range t.Attachments {
+		entries = append(entries, Entry{Attachment: attachment, State: attachment.Check(t.WorkspacePath)})
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(entries, func(w io.Writer) error {
+		return displayAttachments(w, opts.IO, opts.TaskID, entries)
+	})
+}
+
+// transferRun uploads or downloads the attachments of the task for each of its sources,
+// skipping the sources that do not support attachments unless --source names one
+func transferRun(ctx context.Context, opts *AttachOptions, manager TaskManager) error {
+	sources := []string{opts.Source}
+	if opts.Source == "" {
+		t, err := manager.GetTask(ctx, opts.TaskID)
+		if err != nil {
+			return err
+		}
+		sources = sources[:0]
+		for source := range t.Sources {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		if len(sources) == 0 {
+			return &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("task %s is not linked to an external source", opts.TaskID),
+			}
+		}
+	}
+
+	for _, source := range sources {
+		if opts.Upload {
+			uploaded, err := manager.UploadAttachments(ctx, opts.TaskID, source)
+			if skipSource(opts, source, err) {
+				continue
+			}
+			if err != nil {
+				reportTransfer(opts.IO, "Uploaded", "to", source, uploaded, false)
+				return err
+			}
+			reportTransfer(opts.IO, "Uploaded", "to", source, uploaded, true)
+		}
+		if opts.Download {
+			downloaded, err := manager.DownloadAttachments(ctx, opts.TaskID, source)
+			if skipSource(opts, source, err) {
+				continue
+			}
+			if err != nil {
+				reportTransfer(opts.IO, "Downloaded", "from", source, downloaded, false)
+				return err
+			}
+			reportTransfer(opts.IO, "Downloaded", "from", source, downloaded, true)
+		}
+	}
+	return nil
+}
+
+// skipSource reports whether err is a source not supporting attachments, which is
+// skipped with a warning when the source was not named with --source
+func skipSource(opts *AttachOptions, source string, err error) bool {
+	var zenErr *types.Error
+	if opts.Source != "" || !errors.As(err, &zenErr) || zenErr.Code != types.ErrorCodeInvalidInput {
+		return false
+	}
+	fmt.Fprintf(opts.IO.ErrOut, "%s Skipped %s: %s\n", opts.IO.WarningIcon(), source, zenErr.Message)
+	return true
+}
+
+// reportTransfer reports the attachments transferred, and that there were none when
+// the transfer completed
+func reportTransfer(streams *iostreams.IOStreams, verb, preposition, source string, attachments []task.Attachment, completed bool) {
+	if len(attachments) == 0 {
+		if !completed {
+			return
+		}
+		fmt.Fprintf(streams.ErrOut, "%s No attachments to transfer %s %s\n", streams.NeutralIcon(), preposition, source)
+		return
+	}
+	names := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		names = append(names, attachment.Name)
+	}
+	fmt.Fprintf(streams.ErrOut, "%s %s %s %s %s\n", streams.SuccessIcon(), verb, strings.Join(names, ", "), preposition, source)
+}
+
+func displayAttachments(w io.Writer, streams *iostreams.IOStreams, taskID string, entries []Entry) error {
+	if len(entries) == 0 {
+		fmt.Fprintf(w, "No attachments on %s.\n", taskID)
+		if streams.IsStdoutTTY() {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, streams.ColorNeutral(fmt.Sprintf("Add one with 'zen task attach %s <file|url>'", taskID)))
+		}
+		return nil
+	}
+
+	headers := []string{"NAME", "SIZE", "STATE", "SOURCES", "LOCATION"}
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		size, location := "-", entry.Path
+		if entry.IsLink() {
+			location = entry.URL
+		} else {
+			size = formatSize(entry.Size)
+		}
+
+		sources := make([]string, 0, len(entry.Sources))
+		for source := range entry.Sources {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+
+		rows = append(rows, []string{entry.Name, size, entry.State, strings.Join(sources, ", "), location})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
+
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
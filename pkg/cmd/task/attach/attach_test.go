@@ -0,0 +1,121 @@
+package attach
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	task     *task.Task
+	attached []string
+	uploads  map[string][]task.Attachment
+}
+
+func (m *mockTaskManager) GetTask(ctx context.Context, taskID string) (*task.Task, error) {
+	return m.task, nil
+}
+
+func (m *mockTaskManager) Attach(ctx context.Context, taskID, target string) (*task.Attachment, bool, error) {
+	for _, attached := range m.attached {
+		if attached == target {
+			return &task.Attachment{Name: target}, false, nil
+		}
+	}
+	m.attached = append(m.attached, target)
+	return &task.Attachment{Name: target}, true, nil
+}
+
+func (m *mockTaskManager) UploadAttachments(ctx context.Context, taskID, source string) ([]task.Attachment, error) {
+	uploads, ok := m.uploads[source]
+	if !ok {
+		return nil, &types.Error{Code: types.ErrorCodeInvalidInput, Message: source + " does not support attachments"}
+	}
+	return uploads, nil
+}
+
+func (m *mockTaskManager) DownloadAttachments(ctx context.Context, taskID, source string) ([]task.Attachment, error) {
+	return nil, nil
+}
+
+func newTestOptions(streams *iostreams.IOStreams, manager *mockTaskManager) *AttachOptions {
+	return &AttachOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskManager, error) { return manager, nil },
+		TaskID:           "PROJ-1",
+	}
+}
+
+func TestNewCmdTaskAttach(t *testing.T) {
+	var got *AttachOptions
+	cmd := NewCmdTaskAttach(cmdutil.NewTestFactory(iostreams.Test()), func(opts *AttachOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1", "design.pdf", "https://example.com/spec", "--upload", "--source", "jira"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+	assert.Equal(t, []string{"design.pdf", "https://example.com/spec"}, got.Targets)
+	assert.True(t, got.Upload)
+	assert.Equal(t, "jira", got.Source)
+
+	cmd = NewCmdTaskAttach(cmdutil.NewTestFactory(iostreams.Test()), func(opts *AttachOptions) error { return nil })
+	cmd.SetArgs([]string{"PROJ-1", "--source", "jira"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, err, &flagErr)
+}
+
+func TestAttachRun(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{
+		task: &task.Task{ID: "PROJ-1", Sources: map[string]*task.TaskSource{"jira": {}, "linear": {}}},
+		uploads: map[string][]task.Attachment{
+			"jira": {{Name: "design.pdf"}},
+		},
+	}
+	opts := newTestOptions(streams, manager)
+	opts.Targets = []string{"design.pdf", "design.pdf"}
+	opts.Upload = true
+
+	require.NoError(t, attachRun(context.Background(), opts))
+	assert.Equal(t, []string{"design.pdf"}, manager.attached)
+	assert.Equal(t, "✓ Attached design.pdf to PROJ-1\n"+
+		"- design.pdf is already attached to PROJ-1 as design.pdf\n"+
+		"✓ Uploaded design.pdf to jira\n"+
+		"! Skipped linear: linear does not support attachments\n", streams.ErrOut.(*bytes.Buffer).String())
+
+	opts.Targets, opts.Source = nil, "linear"
+	assert.ErrorContains(t, attachRun(context.Background(), opts), "linear does not support attachments")
+}
+
+func TestAttachRun_List(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{task: &task.Task{ID: "PROJ-1", WorkspacePath: t.TempDir(), Attachments: []task.Attachment{
+		{Name: "design.pdf", Path: "attachments/design.pdf", Size: 2048, Checksum: "sha256:00", Sources: map[string]string{"jira": "100"}},
+		{Name: "spec", URL: "https://example.com/spec"},
+	}}}
+
+	require.NoError(t, attachRun(context.Background(), newTestOptions(streams, manager)))
+	assert.Equal(t, "design.pdf\t2.0 KB\tmissing\tjira\tattachments/design.pdf\n"+
+		"spec\t-\tlink\t\thttps://example.com/spec\n", streams.Out.(*bytes.Buffer).String())
+
+	streams = iostreams.Test()
+	manager.task.Attachments = nil
+	require.NoError(t, attachRun(context.Background(), newTestOptions(streams, manager)))
+	assert.Equal(t, "No attachments on PROJ-1.\n", streams.Out.(*bytes.Buffer).String())
+}
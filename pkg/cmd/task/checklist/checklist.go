@@ -0,0 +1,154 @@
+package checklist
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager reads the checklists of tasks
+type TaskManager interface {
+	GetTask(ctx context.Context, taskID string) (*task.Task, error)
+	GetChecklist(ctx context.Context, taskID string) (*task.Checklist, error)
+}
+
+// ChecklistOptions contains options for the task checklist command
+type ChecklistOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskManager, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	TaskID string
+}
+
+// NewCmdTaskChecklist creates the task checklist command
+func NewCmdTaskChecklist(f *cmdutil.Factory, runF func(*ChecklistOptions) error) *cobra.Command {
+	opts := &ChecklistOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "checklist <task-id>",
+		Short: "Show the definition-of-done checklist of a task",
+		Long: heredoc.Doc(`
+			Show the definition-of-done checklist of a task, with the items checked off
+			in its index.md.
+
+			Checklists are assets named by task type in task.checklists, such as
+			"story: checklists/story-dod". Each is a YAML document with a title and its
+			items; an item is its text, or a mapping with text, required (true unless
+			set) and stage:
+
+			  title: Definition of Done
+			  items:
+			    - Acceptance criteria are met
+			    - text: Design is reviewed
+			      stage: 04-design
+			    - text: Release notes are written
+			      required: false
+
+			The checklist of the task type is rendered into index.md when a task is
+			created. With task.enforce_checklists, a task cannot move past the stage of
+			a required item until it is checked; items without a stage are due before
+			Learn.
+		`),
+		Example: heredoc.Doc(`
+			# Show the checklist of a task
+			zen task checklist PROJ-123
+
+			# List the required items left unchecked
+			zen task checklist PROJ-123 --jq '.items[] | select(.required and (.checked | not)) | .text'
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return checklistRun(cmd.Context(), opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func checklistRun(ctx context.Context, opts *ChecklistOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	checklist, err := manager.GetChecklist(ctx, opts.TaskID)
+	if err != nil {
+		return err
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(checklist, func(w io.Writer) error {
+		if checklist == nil {
+			fmt.Fprintf(w, "%s has no checklist.\n", opts.TaskID)
+			return nil
+		}
+
+		t, err := manager.GetTask(ctx, opts.TaskID)
+		if err != nil {
+			return err
+		}
+		return displayChecklist(w, opts.IO, t, checklist)
+	})
+}
+
+func displayChecklist(w io.Writer, streams *iostreams.IOStreams, t *task.Task, checklist *task.Checklist) error {
+	done := 0
+	for _, item := range checklist.Items {
+		if item.Checked {
+			done++
+		}
+	}
+	fmt.Fprintf(w, "%s (%d/%d)\n\n", checklist.Title, done, len(checklist.Items))
+
+	for _, item := range checklist.Items {
+		mark := streams.ColorNeutral("[ ]")
+		if item.Checked {
+			mark = streams.ColorSuccess("[x]")
+		}
+
+		note := task.StageName(item.DueStage())
+		if !item.Required {
+			note += ", optional"
+		} else if !item.Checked && task.StageIndex(item.DueStage()) < task.StageIndex(t.CurrentStage) {
+			note += ", " + streams.ColorWarning("overdue")
+		}
+		fmt.Fprintf(w, "%s %s %s\n", mark, item.Text, streams.ColorNeutral("("+note+")"))
+	}
+	return nil
+}
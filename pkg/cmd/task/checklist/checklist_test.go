@@ -0,0 +1,55 @@
+package checklist
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	checklist *task.Checklist
+}
+
+func (m *mockTaskManager) GetTask(ctx context.Context, taskID string) (*task.Task, error) {
+	return &task.Task{ID: taskID, CurrentStage: "05-build"}, nil
+}
+
+func (m *mockTaskManager) GetChecklist(ctx context.Context, taskID string) (*task.Checklist, error) {
+	return m.checklist, nil
+}
+
+func TestChecklistRun(t *testing.T) {
+	manager := &mockTaskManager{checklist: &task.Checklist{Title: "Definition of Done", Items: []task.ChecklistItem{
+		{Text: "Acceptance criteria are met", Required: true, Checked: true},
+		{Text: "Design is reviewed", Required: true, Stage: "04-design"},
+		{Text: "Release notes are written"},
+	}}}
+
+	streams := iostreams.Test()
+	opts := &ChecklistOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskManager, error) { return manager, nil },
+		OutputFormat:     cmdutil.OutputText,
+		TaskID:           "PROJ-1",
+	}
+
+	require.NoError(t, checklistRun(context.Background(), opts))
+	assert.Equal(t, "Definition of Done (1/3)\n\n"+
+		"[x] Acceptance criteria are met (Ship)\n"+
+		"[ ] Design is reviewed (Design, overdue)\n"+
+		"[ ] Release notes are written (Ship, optional)\n", streams.Out.(*bytes.Buffer).String())
+
+	streams = iostreams.Test()
+	opts.IO = streams
+	manager.checklist = nil
+	require.NoError(t, checklistRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-1 has no checklist.\n", streams.Out.(*bytes.Buffer).String())
+}
@@ -0,0 +1,163 @@
+package clone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskCloner creates tasks from other tasks and from local task templates
+type TaskCloner interface {
+	CloneTask(ctx context.Context, sourceID string, request *task.CloneRequest) (*task.Task, error)
+	CreateFromTemplate(ctx context.Context, name string, request *task.CloneRequest) (*task.Task, error)
+	SaveTaskTemplate(ctx context.Context, taskID, name string, include []string) (*task.TaskTemplate, error)
+}
+
+// CloneOptions contains options for the task clone command
+type CloneOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskCloner, error)
+
+	SourceID string
+	NewID    string
+	Title    string
+	Owner    string
+	Include  []string
+
+	// AsTemplate saves the task as a local template of this name
+	AsTemplate string
+
+	// Template creates the task from a local template of this name
+	Template string
+}
+
+// NewCmdTaskClone creates the task clone command
+func NewCmdTaskClone(f *cmdutil.Factory, runF func(*CloneOptions) error) *cobra.Command {
+	opts := &CloneOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskCloner, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clone [<task-id>]",
+		Short: "Create a task from an existing task or a local template",
+		Long: heredoc.Doc(`
+			Create a new task with the type, priority, team and index.md of an existing
+			task, for recurring work such as releases and audits. References to the old ID
+			are replaced with the new one and the checklist starts unchecked. Without --as,
+			the new ID is allocated by the configured task ID scheme.
+
+			Sync sources, workflow history, quality gates and the git branch are never
+			copied. With --include, the clone also gets:
+
+			- documents: the documents of the work-type directories, such as decision
+			  records and research, registered with the same stages
+			- attachments: attached files and links, without their uploads
+
+			With --as-template, the task is saved as a local template in
+			.zen/templates/tasks/<name> instead, which outlives the task. Create tasks from
+			it with --template.
+		`),
+		Example: heredoc.Doc(`
+			# Start this month's release from last month's
+			zen task clone REL-9 --as REL-10
+
+			# Clone with the decision records and research of the original
+			zen task clone PROJ-1 --as PROJ-2 --title "Add signup page" --include documents
+
+			# Save a finished task as a template, then start tasks from it
+			zen task clone AUDIT-3 --as-template quarterly-audit --include documents,attachments
+			zen task clone --template quarterly-audit --as AUDIT-4
+		`),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.Template != "" {
+				if len(args) > 0 {
+					return &cmdutil.FlagError{Err: fmt.Errorf("a task ID cannot be given with --template")}
+				}
+				return nil
+			}
+			if len(args) != 1 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("a task ID or --template is required")}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.SourceID = args[0]
+			}
+			if opts.AsTemplate != "" && (opts.NewID != "" || opts.Title != "" || opts.Owner != "") {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--as-template cannot be used with --as, --title or --owner")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return cloneRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.NewID, "as", "", "ID of the new task")
+	cmd.Flags().StringVar(&opts.Title, "title", "", "Title of the new task, instead of the original's")
+	cmd.Flags().StringVar(&opts.Owner, "owner", "", "Owner of the new task")
+	cmd.Flags().StringSliceVar(&opts.Include, "include", nil,
+		fmt.Sprintf("Content to copy besides index.md: {%s}", strings.Join(task.CloneContents, "|")))
+	cmd.Flags().StringVar(&opts.AsTemplate, "as-template", "", "Save the task as a local template of this name")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Create the task from a local template")
+	cmd.MarkFlagsMutuallyExclusive("as-template", "template")
+
+	return cmd
+}
+
+func cloneRun(ctx context.Context, opts *CloneOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	out := opts.IO.ErrOut
+	if opts.AsTemplate != "" {
+		template, err := manager.SaveTaskTemplate(ctx, opts.SourceID, opts.AsTemplate, opts.Include)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s Saved %s as template %s\n", opts.IO.SuccessIcon(), opts.SourceID, template.Name)
+		fmt.Fprintf(out, "%s Create tasks from it with 'zen task clone --template %s'\n", opts.IO.NeutralIcon(), template.Name)
+		return nil
+	}
+
+	request := &task.CloneRequest{ID: opts.NewID, Title: opts.Title, Owner: opts.Owner, Include: opts.Include}
+	var created *task.Task
+	from := opts.SourceID
+	if opts.Template != "" {
+		created, err = manager.CreateFromTemplate(ctx, opts.Template, request)
+		from = "template " + opts.Template
+	} else {
+		created, err = manager.CloneTask(ctx, opts.SourceID, request)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "%s Created %s from %s\n", opts.IO.SuccessIcon(), created.ID, from)
+	fmt.Fprintf(out, "%s %s\n", opts.IO.NeutralIcon(), created.WorkspacePath)
+	return nil
+}
@@ -0,0 +1,134 @@
+package clone
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	request *task.CloneRequest
+}
+
+func (m *mockTaskManager) CloneTask(ctx context.Context, sourceID string, request *task.CloneRequest) (*task.Task, error) {
+	if sourceID != "REL-9" {
+		return nil, &types.Error{Code: types.ErrorCodeNotFound, Message: "task not found: " + sourceID}
+	}
+	m.request = request
+	return &task.Task{ID: request.ID, WorkspacePath: "/workspace/.zen/work/tasks/" + request.ID}, nil
+}
+
+func (m *mockTaskManager) CreateFromTemplate(ctx context.Context, name string, request *task.CloneRequest) (*task.Task, error) {
+	m.request = request
+	return &task.Task{ID: request.ID, WorkspacePath: "/workspace/.zen/work/tasks/" + request.ID}, nil
+}
+
+func (m *mockTaskManager) SaveTaskTemplate(ctx context.Context, taskID, name string, include []string) (*task.TaskTemplate, error) {
+	return &task.TaskTemplate{Name: name, SourceID: taskID}, nil
+}
+
+func newTestOptions(streams *iostreams.IOStreams, manager *mockTaskManager) *CloneOptions {
+	return &CloneOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskCloner, error) { return manager, nil },
+	}
+}
+
+func TestNewCmdTaskClone(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    CloneOptions
+		wantErr bool
+	}{
+		{
+			name: "clone",
+			args: []string{"REL-9", "--as", "REL-10", "--include", "documents,attachments"},
+			want: CloneOptions{SourceID: "REL-9", NewID: "REL-10", Include: []string{"documents", "attachments"}},
+		},
+		{
+			name: "save template",
+			args: []string{"AUDIT-3", "--as-template", "quarterly-audit"},
+			want: CloneOptions{SourceID: "AUDIT-3", AsTemplate: "quarterly-audit"},
+		},
+		{
+			name: "from template",
+			args: []string{"--template", "quarterly-audit", "--as", "AUDIT-4"},
+			want: CloneOptions{NewID: "AUDIT-4", Template: "quarterly-audit"},
+		},
+		{name: "no task", args: []string{"--as", "REL-10"}, wantErr: true},
+		{name: "task and template", args: []string{"REL-9", "--template", "release"}, wantErr: true},
+		{name: "template with new ID", args: []string{"REL-9", "--as-template", "release", "--as", "REL-10"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *CloneOptions
+			cmd := NewCmdTaskClone(cmdutil.NewTestFactory(iostreams.Test()), func(opts *CloneOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr {
+				var flagErr *cmdutil.FlagError
+				assert.ErrorAs(t, err, &flagErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.SourceID, got.SourceID)
+			assert.Equal(t, tt.want.NewID, got.NewID)
+			assert.Equal(t, tt.want.Include, got.Include)
+			assert.Equal(t, tt.want.AsTemplate, got.AsTemplate)
+			assert.Equal(t, tt.want.Template, got.Template)
+		})
+	}
+}
+
+func TestCloneRun(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{}
+	opts := newTestOptions(streams, manager)
+	opts.SourceID, opts.NewID, opts.Title = "REL-9", "REL-10", "Release 10"
+
+	require.NoError(t, cloneRun(context.Background(), opts))
+	assert.Equal(t, "Release 10", manager.request.Title)
+	assert.Equal(t, "✓ Created REL-10 from REL-9\n- /workspace/.zen/work/tasks/REL-10\n", streams.ErrOut.(*bytes.Buffer).String())
+
+	streams = iostreams.Test()
+	opts = newTestOptions(streams, manager)
+	opts.Template, opts.NewID = "release", "REL-11"
+	require.NoError(t, cloneRun(context.Background(), opts))
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "✓ Created REL-11 from template release\n")
+
+	streams = iostreams.Test()
+	opts = newTestOptions(streams, manager)
+	opts.SourceID, opts.AsTemplate = "REL-9", "release"
+	require.NoError(t, cloneRun(context.Background(), opts))
+	assert.Equal(t, "✓ Saved REL-9 as template release\n- Create tasks from it with 'zen task clone --template release'\n", streams.ErrOut.(*bytes.Buffer).String())
+
+	opts.SourceID, opts.AsTemplate = "REL-8", ""
+	assert.ErrorContains(t, cloneRun(context.Background(), opts), "task not found: REL-8")
+}
+
+func TestCloneRun_NotInitialized(t *testing.T) {
+	opts := newTestOptions(iostreams.Test(), &mockTaskManager{})
+	opts.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) { return zentest.NewWorkspace(t), nil }
+
+	err := cloneRun(context.Background(), opts)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeWorkspaceNotInit, zenErr.Code)
+}
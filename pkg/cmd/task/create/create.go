@@ -7,6 +7,7 @@ import (
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/hooks"
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/daddia/zen/pkg/task"
 	"github.com/daddia/zen/pkg/types"
@@ -18,6 +19,7 @@ type CreateOptions struct {
 	IO               *iostreams.IOStreams
 	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
 	TemplateEngine   func() (cmdutil.TemplateEngineInterface, error)
+	HookRunner       func() (*hooks.Runner, error)
 	Factory          *cmdutil.Factory
 
 	TaskID   string
@@ -26,6 +28,7 @@ type CreateOptions struct {
 	Owner    string
 	Team     string
 	Priority string
+	Labels   []string
 	DryRun   bool
 	Source   string // Source system to fetch task details from (jira, github, linear, etc.)
 }
@@ -68,12 +71,13 @@ func NewCmdTaskCreate(f *cmdutil.Factory) *cobra.Command {
 		IO:               f.IOStreams,
 		WorkspaceManager: f.WorkspaceManager,
 		TemplateEngine:   f.TemplateEngine,
+		HookRunner:       f.HookRunner,
 		Factory:          f,
 		DryRun:           f.DryRun,
 	}
 
 	cmd := &cobra.Command{
-		Use:   "create <task-id>",
+		Use:   "create [<task-id>]",
 		Short: "Create a new task with structured workflow",
 		Long: `Create a new task with structured workflow directories and templates.
 
@@ -83,8 +87,19 @@ This command creates a complete task structure in .zen/tasks/<task-id>/ includin
 - .taskrc.yaml: Task-specific configuration
 - Work-type directories: research/, spikes/, design/, execution/, outcomes/
 
+Without a task ID, the next ID is allocated by the task.id_scheme setting:
+- sequence: the task.id_prefix (or task.project_key) and the next number, as in ZEN-124
+- ulid: a ULID, which sorts by creation time
+- free (default): IDs are required, except when tasks are tracked locally with
+  integrations.task_system set to none, where tasks are numbered by the prefix
+  (TASK by default), as in TASK-12
+- external: IDs are required, and are the keys of tasks created --from an external
+  source
+
+IDs given are checked against the scheme and the task.id_pattern setting.
+
 Source detection (in priority order):
-1. --from flag (jira, github, linear, local)
+1. --from flag (jira, github, linear, notion, servicenow, local)
 2. config work.tasks.source setting
 3. local mode (no external sync)
 
@@ -114,20 +129,31 @@ Task types determine the workflow focus:
 			zen task create ZEN-123 --from jira
 			zen task create GH-456 --from github
 			zen task create LIN-789 --from linear
+			zen task create TASK-42 --from notion
+			zen task create INC0010023 --from servicenow
 
 			# Create local task (no external sync)
 			zen task create LOCAL-123 --from local
 
 			# Create with additional metadata
 			zen task create PROJ-200 --title "Dashboard redesign" --owner "jane.doe" --team "frontend"
+
+			# Create a task with labels
+			zen task create PROJ-201 --title "Fix login on Safari" --label frontend,bug
+
+			# Create a task with the next ID of the configured sequence
+			zen task create --title "Export reports as CSV"
 		`),
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.TaskID = args[0]
+			// Without an ID, one is allocated by the configured ID scheme
+			if len(args) > 0 {
+				opts.TaskID = args[0]
 
-			// Validate task ID format
-			if err := validateTaskID(opts.TaskID); err != nil {
-				return err
+				// Validate task ID format
+				if err := validateTaskID(opts.TaskID); err != nil {
+					return err
+				}
 			}
 
 			// Apply type rules:
@@ -153,17 +179,17 @@ Task types determine the workflow focus:
 				if err != nil {
 					// Log warning but continue with local mode
 					fmt.Fprintf(f.IOStreams.Out, "%s Failed to read config, using local mode: %v\n",
-						f.IOStreams.ColorWarning("!"), err)
+						f.IOStreams.WarningIcon(), err)
 					source = "local"
 				}
 				if source != "local" && source != "none" {
 					opts.Source = source
 					fmt.Fprintf(f.IOStreams.Out, "%s Using configured source: %s\n",
-						f.IOStreams.ColorInfo("ℹ"), source)
+						f.IOStreams.InfoIcon(), source)
 				}
 			}
 
-			return createRun(opts)
+			return createRun(cmd.Context(), opts)
 		},
 	}
 
@@ -173,7 +199,8 @@ Task types determine the workflow focus:
 	cmd.Flags().StringVar(&opts.Owner, "owner", "", "Task owner (optional, defaults to current user)")
 	cmd.Flags().StringVar(&opts.Team, "team", "", "Team name (optional)")
 	cmd.Flags().StringVar(&opts.Priority, "priority", "P2", "Task priority (P0|P1|P2|P3)")
-	cmd.Flags().StringVar(&opts.Source, "from", "", "Fetch task details from external source system (jira, github, linear, local) or use config work.tasks.source")
+	cmd.Flags().StringSliceVar(&opts.Labels, "label", nil, "Labels of the task, from the labels configuration when it defines any (defaults to the type)")
+	cmd.Flags().StringVar(&opts.Source, "from", "", "Fetch task details from external source system (jira, github, linear, notion, servicenow, local) or use config work.tasks.source")
 
 	// No required flags - type is optional with default
 
@@ -209,10 +236,9 @@ func validateTaskID(taskID string) error {
 	return nil
 }
 
-// createRun executes the task creation using the task manager
-func createRun(opts *CreateOptions) error {
-	ctx := context.Background()
-
+// createRun executes the task creation using the task manager. Cancelling ctx, as
+// Ctrl-C does, discards the task before it is moved into place.
+func createRun(ctx context.Context, opts *CreateOptions) error {
 	// Check workspace initialization
 	wm, err := opts.WorkspaceManager()
 	if err != nil {
@@ -233,9 +259,13 @@ func createRun(opts *CreateOptions) error {
 	}
 
 	if opts.DryRun {
+		taskID := opts.TaskID
+		if taskID == "" {
+			taskID = "a new task (ID allocated on creation)"
+		}
 		fmt.Fprintf(opts.IO.Out, "%s Task creation plan for %s:\n",
 			opts.IO.FormatSuccess(""),
-			opts.IO.ColorBold(opts.TaskID))
+			opts.IO.ColorBold(taskID))
 		fmt.Fprintf(opts.IO.Out, "  %s Type: %s\n",
 			opts.IO.ColorNeutral("→"), opts.TaskType)
 		if opts.Source != "" {
@@ -248,10 +278,25 @@ func createRun(opts *CreateOptions) error {
 			opts.IO.ColorNeutral("→"))
 
 		fmt.Fprintf(opts.IO.Out, "\n%s Run without --dry-run to create the task\n",
-			opts.IO.ColorInfo("ℹ"))
+			opts.IO.InfoIcon())
 		return nil
 	}
 
+	// Create task manager
+	taskManager := task.NewManager(opts.Factory)
+	if taskManager == nil {
+		return fmt.Errorf("failed to create task manager")
+	}
+
+	// Allocate the ID by the configured scheme before hooks see the task
+	if opts.TaskID == "" {
+		taskID, err := taskManager.NextTaskID(ctx)
+		if err != nil {
+			return err
+		}
+		opts.TaskID = taskID
+	}
+
 	// Show initial progress message
 	if opts.Source != "" {
 		fmt.Fprintf(opts.IO.Out, "Creating %s from %s...\n", opts.TaskID, opts.Source)
@@ -259,12 +304,6 @@ func createRun(opts *CreateOptions) error {
 		fmt.Fprintf(opts.IO.Out, "Creating %s...\n", opts.TaskID)
 	}
 
-	// Create task manager
-	taskManager := task.NewManager(opts.Factory)
-	if taskManager == nil {
-		return fmt.Errorf("failed to create task manager")
-	}
-
 	// Create task request
 	createRequest := &task.CreateTaskRequest{
 		ID:         opts.TaskID,
@@ -273,16 +312,33 @@ func createRun(opts *CreateOptions) error {
 		Owner:      opts.Owner,
 		Team:       opts.Team,
 		Priority:   opts.Priority,
+		Labels:     opts.Labels,
 		FromSource: opts.Source,
 		DryRun:     opts.DryRun,
 	}
 
+	// Pre-create hooks can veto the task before anything is written
+	if err := hooks.Trigger(ctx, opts.HookRunner, &hooks.Payload{
+		Event: hooks.EventPreTaskCreate,
+		Task:  createRequest,
+	}); err != nil {
+		return err
+	}
+
 	// Create task using task manager (this will handle folder creation, data fetch, and artifacts)
 	createdTask, err := taskManager.CreateTask(ctx, createRequest)
 	if err != nil {
 		return fmt.Errorf("failed to create task: %w", err)
 	}
 
+	if err := hooks.Trigger(ctx, opts.HookRunner, &hooks.Payload{
+		Event: hooks.EventPostTaskCreate,
+		Task:  createdTask,
+		Data:  map[string]interface{}{"paths": []string{createdTask.WorkspacePath}},
+	}); err != nil {
+		return err
+	}
+
 	// Show final success message
 	fmt.Fprintf(opts.IO.Out, "%s\n",
 		opts.IO.FormatSuccess("Initial artifacts created"))
@@ -343,12 +399,11 @@ func determineTaskSourceFromConfig(factory *cmdutil.Factory) (string, error) {
 		return "", fmt.Errorf("failed to get config: %w", err)
 	}
 
-	// Check for configured task source
-	taskSource := config.Task.TaskSource
-	
-	if taskSource == "" || taskSource == "none" {
+	// Tasks tracked locally have no source to fetch them from
+	taskSystem := task.TaskSystem(config)
+	if taskSystem == task.TaskSystemNone {
 		return "local", nil
 	}
 
-	return taskSource, nil
+	return taskSystem, nil
 }
@@ -250,7 +250,7 @@ func TestCreateRun_WorkspaceNotInitialized(t *testing.T) {
 		TaskType:         "story",
 	}
 
-	err := createRun(opts)
+	err := createRun(context.Background(), opts)
 	require.Error(t, err)
 
 	var typedErr *types.Error
@@ -285,7 +285,7 @@ func TestCreateRun_TaskAlreadyExists(t *testing.T) {
 		TaskType:         "story",
 	}
 
-	err = createRun(opts)
+	err = createRun(context.Background(), opts)
 	require.Error(t, err)
 
 	// The task manager returns a generic error, not a typed error
@@ -314,7 +314,7 @@ func TestCreateRun_DryRun(t *testing.T) {
 		DryRun:           true,
 	}
 
-	err := createRun(opts)
+	err := createRun(context.Background(), opts)
 	require.NoError(t, err)
 
 	// Check output contains dry run information
@@ -359,7 +359,7 @@ func TestCreateRun_Success(t *testing.T) {
 		Priority:         "P1",
 	}
 
-	err := createRun(opts)
+	err := createRun(context.Background(), opts)
 	require.NoError(t, err)
 
 	// Check success output matches actual implementation
@@ -411,6 +411,46 @@ func TestCreateRun_Success(t *testing.T) {
 	assert.Contains(t, string(taskrcContent), `type: "story"`)
 }
 
+func TestCreateRun_Cancelled(t *testing.T) {
+	tempDir := t.TempDir()
+	streams := iostreams.Test()
+	factory := cmdutil.NewTestFactoryWithWorkspace(streams, true, false)
+	factory.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) {
+		return &mockWorkspaceManager{
+			root:        tempDir,
+			initialized: true,
+		}, nil
+	}
+	factory.TemplateEngine = func() (cmdutil.TemplateEngineInterface, error) {
+		return &mockTemplateEngine{}, nil
+	}
+
+	opts := &CreateOptions{
+		IO:               streams,
+		WorkspaceManager: factory.WorkspaceManager,
+		TemplateEngine:   factory.TemplateEngine,
+		Factory:          factory,
+		TaskID:           "PROJ-123",
+		TaskType:         "story",
+		Title:            "Test Story",
+	}
+
+	// Ctrl-C cancels the context of the command
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := createRun(ctx, opts)
+	require.ErrorIs(t, err, context.Canceled)
+
+	workDir := filepath.Join(tempDir, ".zen", "work")
+	assert.NoDirExists(t, filepath.Join(workDir, "tasks", "PROJ-123"))
+	staged, err := os.ReadDir(filepath.Join(workDir, ".staging"))
+	if !os.IsNotExist(err) {
+		require.NoError(t, err)
+	}
+	assert.Empty(t, staged, "a cancelled creation leaves nothing staged")
+}
+
 // mockWorkspaceManager is a simple mock for testing
 type mockWorkspaceManager struct {
 	root        string
@@ -652,7 +692,7 @@ func TestNewCmdTaskCreate(t *testing.T) {
 	cmd := NewCmdTaskCreate(factory)
 
 	require.NotNil(t, cmd)
-	assert.Equal(t, "create <task-id>", cmd.Use)
+	assert.Equal(t, "create [<task-id>]", cmd.Use)
 	assert.Equal(t, "Create a new task with structured workflow", cmd.Short)
 	assert.Contains(t, cmd.Long, "Create a new task with structured workflow directories")
 	assert.Contains(t, cmd.Example, "zen task create USER-123")
@@ -748,10 +788,8 @@ func TestDetermineTaskSourceFromConfig(t *testing.T) {
 			// Mock config with task source
 			factory.Config = func() (*config.Config, error) {
 				return &config.Config{
-					Work: config.WorkConfig{
-						Tasks: config.TasksConfig{
-							Source: tt.taskSource,
-						},
+					Task: config.TaskConfig{
+						TaskSource: tt.taskSource,
 					},
 				}, nil
 			}
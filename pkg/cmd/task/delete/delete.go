@@ -0,0 +1,98 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/policy"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskDeleter deletes tasks
+type TaskDeleter interface {
+	DeleteTask(ctx context.Context, taskID string) error
+}
+
+// DeleteOptions contains options for the task delete command
+type DeleteOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskDeleter, error)
+
+	TaskID  string
+	Confirm bool
+}
+
+// NewCmdTaskDelete creates the task delete command
+func NewCmdTaskDelete(f *cmdutil.Factory, runF func(*DeleteOptions) error) *cobra.Command {
+	opts := &DeleteOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskDeleter, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "delete <task-id>",
+		Short: "Delete a task from the workspace",
+		Long: heredoc.Docf(`
+			Delete a task and everything in its directory from the workspace. The task is
+			not deleted from the external systems it is linked to.
+
+			Deleting tasks can be restricted to some users or team roles by the
+			%[1]s rules in the policy section of the configuration. Use --confirm
+			to override them; the override is recorded in the audit log.
+		`, policy.OpTaskDelete),
+		Example: heredoc.Doc(`
+			# Delete a task
+			zen task delete PROJ-123
+
+			# Delete a task that policy restricts, recording the override
+			zen task delete PROJ-123 --confirm
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return deleteRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Confirm, "confirm", false, "Override the policy rules that restrict deleting tasks")
+
+	return cmd
+}
+
+func deleteRun(ctx context.Context, opts *DeleteOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	if opts.Confirm {
+		ctx = policy.WithConfirm(ctx)
+	}
+	if err := manager.DeleteTask(ctx, opts.TaskID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "%s Deleted task %s\n", opts.IO.SuccessIcon(), opts.TaskID)
+	return nil
+}
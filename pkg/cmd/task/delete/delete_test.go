@@ -0,0 +1,64 @@
+package delete
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/policy"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	deleted   []string
+	confirmed bool
+}
+
+func (m *mockTaskManager) DeleteTask(ctx context.Context, taskID string) error {
+	m.confirmed = policy.Confirmed(ctx)
+	if !m.confirmed {
+		return &types.Error{Code: types.ErrorCodePermissionDenied, Message: "task.delete is restricted by policy"}
+	}
+	m.deleted = append(m.deleted, taskID)
+	return nil
+}
+
+func TestNewCmdTaskDelete(t *testing.T) {
+	var got *DeleteOptions
+	cmd := NewCmdTaskDelete(cmdutil.NewTestFactory(iostreams.Test()), func(opts *DeleteOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1", "--confirm"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+	assert.True(t, got.Confirm)
+}
+
+func TestDeleteRun(t *testing.T) {
+	manager := &mockTaskManager{}
+	streams := iostreams.Test()
+	opts := &DeleteOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskDeleter, error) { return manager, nil },
+		TaskID:           "PROJ-1",
+	}
+
+	err := deleteRun(context.Background(), opts)
+	assert.ErrorContains(t, err, "restricted by policy")
+	assert.Empty(t, manager.deleted)
+
+	opts.Confirm = true
+	require.NoError(t, deleteRun(context.Background(), opts))
+	assert.Equal(t, []string{"PROJ-1"}, manager.deleted, "--confirm overrides the policy")
+	assert.Equal(t, "✓ Deleted task PROJ-1\n", streams.ErrOut.(*bytes.Buffer).String())
+}
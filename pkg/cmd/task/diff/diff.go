@@ -0,0 +1,131 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskDiffer compares the documents of tasks with their revisions
+type TaskDiffer interface {
+	DiffTask(ctx context.Context, taskID string, rev int) (*task.TaskDiff, error)
+}
+
+// DiffOptions contains options for the task diff command
+type DiffOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskDiffer, error)
+
+	OutputFormat string
+
+	TaskID string
+	Rev    int
+}
+
+// NewCmdTaskDiff creates the task diff command
+func NewCmdTaskDiff(f *cmdutil.Factory, runF func(*DiffOptions) error) *cobra.Command {
+	opts := &DiffOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskDiffer, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff <task-id>",
+		Short: "Show the changes to the documents of a task since a revision",
+		Long: heredoc.Doc(`
+			Show the changes to the index.md and manifest.yaml of a task since a revision
+			listed by 'zen task history', as a unified diff. Without --rev, the changes
+			since the revision before the last are shown: the last recorded change, and
+			any edits made since.
+		`),
+		Example: heredoc.Doc(`
+			# Show the last change to a task
+			zen task diff PROJ-123
+
+			# Show what changed since revision 3
+			zen task diff PROJ-123 --rev 3
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			if cmd.Flags().Changed("rev") && opts.Rev < 1 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--rev must be a revision number of at least 1")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return diffRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Rev, "rev", 0, "Revision to compare the task with (default: the revision before the last)")
+
+	return cmd
+}
+
+func diffRun(ctx context.Context, opts *DiffOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	diff, err := manager.DiffTask(ctx, opts.TaskID, opts.Rev)
+	if err != nil {
+		return err
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	return renderer.Render(diff, func(w io.Writer) error {
+		if len(diff.Files) == 0 {
+			fmt.Fprintf(w, "%s is unchanged since revision %d.\n", opts.TaskID, diff.Rev)
+			return nil
+		}
+		for _, file := range diff.Files {
+			printDiff(w, opts.IO, file.Diff)
+		}
+		return nil
+	})
+}
+
+// printDiff writes a unified diff, coloring added and removed lines
+func printDiff(w io.Writer, streams *iostreams.IOStreams, diff string) {
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if line == "" {
+			continue
+		}
+		text := strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			text = streams.ColorBold(text)
+		case strings.HasPrefix(line, "@@"):
+			text = streams.ColorInfo(text)
+		case strings.HasPrefix(line, "+"):
+			text = streams.ColorSuccess(text)
+		case strings.HasPrefix(line, "-"):
+			text = streams.ColorError(text)
+		}
+		fmt.Fprintln(w, text)
+	}
+}
@@ -0,0 +1,71 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	diff *task.TaskDiff
+	rev  int
+}
+
+func (m *mockTaskManager) DiffTask(ctx context.Context, taskID string, rev int) (*task.TaskDiff, error) {
+	m.rev = rev
+	return m.diff, nil
+}
+
+func TestNewCmdTaskDiff(t *testing.T) {
+	var got *DiffOptions
+	cmd := NewCmdTaskDiff(cmdutil.NewTestFactory(iostreams.Test()), func(opts *DiffOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1", "--rev", "3"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+	assert.Equal(t, 3, got.Rev)
+
+	cmd = NewCmdTaskDiff(cmdutil.NewTestFactory(iostreams.Test()), func(opts *DiffOptions) error { return nil })
+	cmd.SetArgs([]string{"PROJ-1", "--rev", "0"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, cmd.Execute(), &flagErr)
+}
+
+func TestDiffRun(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{diff: &task.TaskDiff{TaskID: "PROJ-1", Rev: 3, Files: []task.FileDiff{{
+		Path: "manifest.yaml",
+		Diff: "--- manifest.yaml@3\n+++ manifest.yaml\n@@ -1 +1 @@\n-status: proposed\n+status: done\n",
+	}}}}
+	opts := &DiffOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskDiffer, error) { return manager, nil },
+		TaskID:           "PROJ-1",
+		Rev:              3,
+	}
+
+	require.NoError(t, diffRun(context.Background(), opts))
+	assert.Equal(t, 3, manager.rev)
+	assert.Equal(t, "--- manifest.yaml@3\n+++ manifest.yaml\n@@ -1 +1 @@\n-status: proposed\n+status: done\n", streams.Out.(*bytes.Buffer).String())
+
+	streams = iostreams.Test()
+	opts.IO = streams
+	manager.diff.Files = nil
+	require.NoError(t, diffRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-1 is unchanged since revision 3.\n", streams.Out.(*bytes.Buffer).String())
+}
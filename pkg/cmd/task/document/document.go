@@ -0,0 +1,167 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager scaffolds documents into tasks
+type TaskManager interface {
+	NewDocument(ctx context.Context, taskID string, request *task.DocumentRequest) (*task.Document, error)
+}
+
+// NewOptions contains options for the new subcommand of a document kind
+type NewOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskManager, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	Kind   task.DocumentKind
+	TaskID string
+	Title  string
+	Stage  string
+}
+
+// kindHelp describes a document kind in the help of its commands
+type kindHelp struct {
+	// noun names a document of the kind, with its article
+	noun    string
+	short   string
+	long    string
+	example string
+}
+
+var help = map[string]kindHelp{
+	task.DocumentADR.Name: {
+		noun:  "an architecture decision record",
+		short: "Record architecture decisions of a task",
+		long: heredoc.Doc(`
+			Record the architecture decisions of a task as numbered decision records in
+			its design/decisions/ directory, following the context, decision and
+			consequences format. Records are artifacts of the 04-design stage.
+		`),
+		example: `zen task adr new PROJ-123 "Use PostgreSQL for sessions"`,
+	},
+	task.DocumentSpike.Name: {
+		noun:  "a spike",
+		short: "Plan and report time-boxed spikes of a task",
+		long: heredoc.Doc(`
+			Plan time-boxed spikes of a task, with their questions, approach and
+			findings, in its spikes/ directory. Spikes are artifacts of the
+			02-discover stage.
+		`),
+		example: `zen task spike new PROJ-123 "Evaluate SSO providers"`,
+	},
+	task.DocumentResearch.Name: {
+		noun:  "a research document",
+		short: "Write up the research of a task",
+		long: heredoc.Doc(`
+			Write up the research behind a task, with its questions, sources and
+			conclusions, in its research/ directory. Research documents are
+			artifacts of the 02-discover stage.
+		`),
+		example: `zen task research new PROJ-123 "Login drop-off analysis"`,
+	},
+}
+
+// NewCmdTaskDocument creates the command for a document kind, such as zen task adr
+func NewCmdTaskDocument(f *cmdutil.Factory, kind task.DocumentKind) *cobra.Command {
+	h := help[kind.Name]
+	cmd := &cobra.Command{
+		Use:     kind.Name + " <command>",
+		Short:   h.short,
+		Long:    h.long,
+		Example: "  # Create " + h.noun + "\n  " + h.example,
+	}
+
+	cmd.AddCommand(NewCmdNew(f, kind, nil))
+
+	return cmd
+}
+
+// NewCmdNew creates the new subcommand of a document kind
+func NewCmdNew(f *cmdutil.Factory, kind task.DocumentKind, runF func(*NewOptions) error) *cobra.Command {
+	opts := &NewOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+		Kind: kind,
+	}
+
+	h := help[kind.Name]
+	cmd := &cobra.Command{
+		Use:   "new <task-id> <title>",
+		Short: "Create " + h.noun + " from its template",
+		Long: heredoc.Docf(`
+			Create %[1]s for a task from its template, in the %[2]s/
+			directory of the task, and register it as an artifact of the %[3]s
+			stage, or of the stage given with --stage. The file is named after the
+			title.
+		`, h.noun, kind.Dir, kind.Stage),
+		Example: "  # Create " + h.noun + "\n  " + h.example + "\n\n  # Register it with another stage\n  " + h.example + " --stage 05-build",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID, opts.Title = args[0], strings.Join(args[1:], " ")
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return newRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Stage, "stage", "", "Workflow stage the document is an artifact of")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func newRun(ctx context.Context, opts *NewOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	doc, err := manager.NewDocument(ctx, opts.TaskID, &task.DocumentRequest{
+		Kind:  opts.Kind,
+		Title: opts.Title,
+		Stage: opts.Stage,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "%s Created %s for %s\n", opts.IO.SuccessIcon(), doc.Artifact, opts.TaskID)
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(doc, func(w io.Writer) error {
+		_, err := fmt.Fprintln(w, doc.Path)
+		return err
+	})
+}
@@ -0,0 +1,77 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	request *task.DocumentRequest
+}
+
+func (m *mockTaskManager) NewDocument(ctx context.Context, taskID string, request *task.DocumentRequest) (*task.Document, error) {
+	m.request = request
+	return &task.Document{
+		Kind:     request.Kind.Name,
+		Title:    request.Title,
+		Path:     "/workspace/.zen/work/tasks/PROJ-1/design/decisions/0001-use-postgresql.md",
+		Artifact: "design/decisions/0001-use-postgresql.md",
+		Stage:    request.Kind.Stage,
+	}, nil
+}
+
+func TestNewCmdTaskDocument(t *testing.T) {
+	for _, kind := range []task.DocumentKind{task.DocumentADR, task.DocumentSpike, task.DocumentResearch} {
+		cmd := NewCmdTaskDocument(cmdutil.NewTestFactory(iostreams.Test()), kind)
+		assert.Equal(t, kind.Name+" <command>", cmd.Use)
+		assert.NotEmpty(t, cmd.Short)
+
+		newCmd, _, err := cmd.Find([]string{"new"})
+		require.NoError(t, err)
+		assert.Contains(t, newCmd.Long, kind.Dir+"/")
+	}
+}
+
+func TestNewCmdNew(t *testing.T) {
+	var got *NewOptions
+	cmd := NewCmdNew(cmdutil.NewTestFactory(iostreams.Test()), task.DocumentADR, func(opts *NewOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1", "Use", "PostgreSQL", "--stage", "05-build"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+	assert.Equal(t, "Use PostgreSQL", got.Title)
+	assert.Equal(t, "05-build", got.Stage)
+	assert.Equal(t, task.DocumentADR, got.Kind)
+}
+
+func TestNewRun(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{}
+	opts := &NewOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskManager, error) { return manager, nil },
+		OutputFormat:     cmdutil.OutputText,
+		Kind:             task.DocumentADR,
+		TaskID:           "PROJ-1",
+		Title:            "Use PostgreSQL",
+	}
+
+	require.NoError(t, newRun(context.Background(), opts))
+	assert.Equal(t, "Use PostgreSQL", manager.request.Title)
+	assert.Equal(t, "✓ Created design/decisions/0001-use-postgresql.md for PROJ-1\n", streams.ErrOut.(*bytes.Buffer).String())
+	assert.Equal(t, "/workspace/.zen/work/tasks/PROJ-1/design/decisions/0001-use-postgresql.md\n", streams.Out.(*bytes.Buffer).String())
+}
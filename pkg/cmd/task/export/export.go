@@ -0,0 +1,142 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/metrics"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskExporter writes the records of tasks
+type TaskExporter interface {
+	ExportTasks(ctx context.Context, w io.Writer, request *task.ExportRequest) (int, error)
+}
+
+// ExportOptions contains options for the task export command
+type ExportOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskExporter, error)
+
+	Format string
+	Since  string
+	File   string
+}
+
+// NewCmdTaskExport creates the task export command
+func NewCmdTaskExport(f *cmdutil.Factory, runF func(*ExportOptions) error) *cobra.Command {
+	opts := &ExportOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskExporter, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the records of tasks for data pipelines",
+		Long: heredoc.Docf(`
+			Export a record of each task of the workspace, by ID, such as to load the
+			workspace into a data warehouse. Records hold the fields of tasks with their
+			workflow stages, progress, external sources, pull requests and quality gates.
+
+			JSON Lines (jsonl), the default, writes a record per line as each task is read;
+			--format json writes an array of records. Records are written to stdout unless
+			--file is given.
+
+			Every record has a %[1]sschema_version%[1]s, which is %[2]d. Fields may be added
+			to records within a version, but the version is raised when fields are removed,
+			renamed or change meaning. Lists are empty rather than null.
+
+			--since exports the tasks updated since a date or age, as incremental loads do.
+		`, "`", task.ExportSchemaVersion),
+		Example: heredoc.Doc(`
+			# Export every task to stdout
+			zen task export
+
+			# Export the tasks updated since a date to a file
+			zen task export --format jsonl --since 2026-01-01 --file tasks.jsonl
+
+			# Export the tasks updated in the last day as a JSON array
+			zen task export --format json --since 1d
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(task.ExportFormats, opts.Format) {
+				return &cmdutil.FlagError{Err: fmt.Errorf("invalid format %q: use %s", opts.Format, strings.Join(task.ExportFormats, " or "))}
+			}
+			if opts.Since != "" {
+				if _, err := metrics.ParseSince(opts.Since, time.Now()); err != nil {
+					return &cmdutil.FlagError{Err: err}
+				}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return exportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Format, "format", task.ExportJSONL, "Format of the records: jsonl or json")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Export the tasks updated since a date, or an age such as 7d")
+	cmd.Flags().StringVarP(&opts.File, "file", "f", "", "Write the records to a file rather than stdout")
+
+	return cmd
+}
+
+func exportRun(ctx context.Context, opts *ExportOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	request := &task.ExportRequest{Format: opts.Format}
+	if opts.Since != "" {
+		since, err := metrics.ParseSince(opts.Since, time.Now())
+		if err != nil {
+			return err
+		}
+		request.Since = since
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	if opts.File == "" {
+		_, err := manager.ExportTasks(ctx, opts.IO.Out, request)
+		return err
+	}
+
+	file, err := os.Create(opts.File)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	count, err := manager.ExportTasks(ctx, file, request)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write export file: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "%s Exported %d tasks to %s\n", opts.IO.SuccessIcon(), count, opts.File)
+	return nil
+}
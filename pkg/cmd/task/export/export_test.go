@@ -0,0 +1,104 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	request *task.ExportRequest
+}
+
+func (m *mockTaskManager) ExportTasks(ctx context.Context, w io.Writer, request *task.ExportRequest) (int, error) {
+	m.request = request
+	fmt.Fprintln(w, `{"schema_version":1,"id":"PROJ-1"}`)
+	fmt.Fprintln(w, `{"schema_version":1,"id":"PROJ-2"}`)
+	return 2, nil
+}
+
+func newTestOptions(streams *iostreams.IOStreams, manager *mockTaskManager) *ExportOptions {
+	return &ExportOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskExporter, error) { return manager, nil },
+		Format:           task.ExportJSONL,
+	}
+}
+
+func TestNewCmdTaskExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    ExportOptions
+		wantErr string
+	}{
+		{name: "defaults", want: ExportOptions{Format: "jsonl"}},
+		{name: "flags", args: []string{"--format", "json", "--since", "2026-01-01", "--file", "tasks.json"},
+			want: ExportOptions{Format: "json", Since: "2026-01-01", File: "tasks.json"}},
+		{name: "unknown format", args: []string{"--format", "csv"}, wantErr: `invalid format "csv": use jsonl or json`},
+		{name: "invalid since", args: []string{"--since", "last week"}, wantErr: `invalid period "last week"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *ExportOptions
+			cmd := NewCmdTaskExport(cmdutil.NewTestFactory(iostreams.Test()), func(opts *ExportOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Format, got.Format)
+			assert.Equal(t, tt.want.Since, got.Since)
+			assert.Equal(t, tt.want.File, got.File)
+		})
+	}
+}
+
+func TestExportRun(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{}
+	opts := newTestOptions(streams, manager)
+	opts.Since = "2026-01-01"
+
+	require.NoError(t, exportRun(context.Background(), opts))
+	assert.Equal(t, "jsonl", manager.request.Format)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), manager.request.Since)
+	assert.Equal(t, "{\"schema_version\":1,\"id\":\"PROJ-1\"}\n{\"schema_version\":1,\"id\":\"PROJ-2\"}\n", streams.Out.(*bytes.Buffer).String())
+	assert.Empty(t, streams.ErrOut.(*bytes.Buffer).String(), "nothing but records is written when streaming")
+}
+
+func TestExportRun_File(t *testing.T) {
+	streams := iostreams.Test()
+	opts := newTestOptions(streams, &mockTaskManager{})
+	opts.File = filepath.Join(t.TempDir(), "tasks.jsonl")
+
+	require.NoError(t, exportRun(context.Background(), opts))
+	assert.Empty(t, streams.Out.(*bytes.Buffer).String())
+	data, err := os.ReadFile(opts.File)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"id":"PROJ-2"`)
+	assert.Equal(t, "✓ Exported 2 tasks to "+opts.File+"\n", streams.ErrOut.(*bytes.Buffer).String())
+}
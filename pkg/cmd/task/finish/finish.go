@@ -0,0 +1,178 @@
+package finish
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/browser"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager loads tasks and records their branches
+type TaskManager interface {
+	GetTask(ctx context.Context, taskID string) (*task.Task, error)
+	UpdateTaskGit(ctx context.Context, taskID string, info *task.GitInfo) error
+}
+
+// FinishOptions contains options for the task finish command
+type FinishOptions struct {
+	IO               *iostreams.IOStreams
+	Logger           logging.Logger
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskConfig       func() (task.Config, error)
+	TaskManager      func() (TaskManager, error)
+	Browser          func(url string) error
+
+	TaskID      string
+	Remote      string
+	PullRequest bool
+}
+
+// NewCmdTaskFinish creates the task finish command
+func NewCmdTaskFinish(f *cmdutil.Factory, runF func(*FinishOptions) error) *cobra.Command {
+	opts := &FinishOptions{
+		IO:               f.IOStreams,
+		Logger:           f.Logger,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskConfig:       internal.TaskConfig(f),
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+		Browser: browser.Open,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "finish <task-id>",
+		Short: "Push a task's branch and optionally open a pull request",
+		Long: heredoc.Doc(`
+			Push the branch created by 'zen task start' and set it as the upstream branch.
+
+			The branch is pushed from the task's worktree when it has one. With --pr the
+			page for opening a pull request (GitHub) or merge request (GitLab) against the
+			task's base branch is opened in the browser.
+		`),
+		Example: heredoc.Doc(`
+			# Push the task branch
+			zen task finish PROJ-123
+
+			# Push and open a pull request
+			zen task finish PROJ-123 --pr
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return finishRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Remote, "remote", "", "Remote to push to (default task.remote)")
+	cmd.Flags().BoolVar(&opts.PullRequest, "pr", false, "Open a pull request for the branch in the browser")
+
+	return cmd
+}
+
+func finishRun(ctx context.Context, opts *FinishOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	root, err := internal.WorkspaceRoot(opts.WorkspaceManager)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := opts.TaskConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load task configuration: %w", err)
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	t, err := manager.GetTask(ctx, opts.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if t.Git == nil {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("task %s has no branch", t.ID),
+			Details: fmt.Sprintf("Run 'zen task start %s' to create one", t.ID),
+		}
+	}
+
+	info := *t.Git
+	info.Remote = firstNonEmpty(opts.Remote, info.Remote, cfg.Remote, "origin")
+
+	dir := root
+	if info.Worktree != "" {
+		dir = info.Worktree
+	}
+	repo := git.NewCLIRepository(dir, opts.Logger, nil, "")
+
+	if clean, err := repo.IsClean(ctx); err == nil && !clean {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Uncommitted changes in %s are not pushed\n", opts.IO.WarningIcon(), dir)
+	}
+
+	if err := repo.PushUpstream(ctx, info.Remote, info.Branch); err != nil {
+		return fmt.Errorf("failed to push %s: %w", info.Branch, git.OutputError(err))
+	}
+
+	now := time.Now()
+	info.FinishedAt = &now
+	if err := manager.UpdateTaskGit(ctx, t.ID, &info); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(opts.IO.Out, opts.IO.FormatSuccess(fmt.Sprintf("Pushed %s to %s", info.Branch, info.Remote)))
+
+	if !opts.PullRequest {
+		return nil
+	}
+
+	remoteURL, err := repo.GetConfig(ctx, "remote."+info.Remote+".url")
+	if err != nil {
+		return fmt.Errorf("failed to read URL of remote %s: %w", info.Remote, err)
+	}
+	prURL := internal.PullRequestURL(strings.TrimSpace(remoteURL), info.Base, info.Branch)
+	if prURL == "" {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("cannot open a pull request for remote %s", info.Remote),
+			Details: "Pull requests can be opened for GitHub and GitLab remotes",
+		}
+	}
+
+	fmt.Fprintf(opts.IO.Out, "  %s Opening %s in your browser\n", opts.IO.ColorNeutral("→"), prURL)
+	if err := opts.Browser(prURL); err != nil {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Failed to open browser: %v\n", opts.IO.WarningIcon(), err)
+	}
+
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,146 @@
+package finish
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	tasks map[string]*task.Task
+}
+
+func (m *mockTaskManager) GetTask(ctx context.Context, taskID string) (*task.Task, error) {
+	return m.tasks[taskID], nil
+}
+
+func (m *mockTaskManager) UpdateTaskGit(ctx context.Context, taskID string, info *task.GitInfo) error {
+	m.tasks[taskID].Git = info
+	return nil
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// newTestOptions creates a workspace repository on a started task branch, with a bare origin remote
+func newTestOptions(t *testing.T) (*FinishOptions, *bytes.Buffer, *mockTaskManager, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "zen")
+	t.Setenv("GIT_AUTHOR_EMAIL", "zen@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "zen")
+	t.Setenv("GIT_COMMITTER_EMAIL", "zen@example.com")
+
+	root := filepath.Join(t.TempDir(), "workspace")
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	for _, dir := range []string{root, remote} {
+		args := []string{"init", "-q", "-b", "main", dir}
+		if dir == remote {
+			args = append(args, "--bare")
+		}
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGit(t, root, "remote", "add", "origin", remote)
+	runGit(t, root, "checkout", "-q", "-b", "feat/PROJ-1-login")
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "PROJ-1 add login")
+
+	streams := iostreams.Test()
+	manager := &mockTaskManager{tasks: map[string]*task.Task{
+		"PROJ-1": {ID: "PROJ-1", Git: &task.GitInfo{Branch: "feat/PROJ-1-login", Base: "main"}},
+		"PROJ-2": {ID: "PROJ-2"},
+	}}
+
+	return &FinishOptions{
+		IO:               streams,
+		Logger:           logging.NewBasic(),
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt(root), nil },
+		TaskConfig:       func() (task.Config, error) { return task.DefaultConfig(), nil },
+		TaskManager:      func() (TaskManager, error) { return manager, nil },
+		Browser:          func(string) error { return nil },
+		TaskID:           "PROJ-1",
+	}, streams.Out.(*bytes.Buffer), manager, remote
+}
+
+func TestFinishRun_Pushes(t *testing.T) {
+	opts, out, manager, remote := newTestOptions(t)
+
+	require.NoError(t, finishRun(context.Background(), opts))
+
+	assert.Equal(t, "PROJ-1 add login", runGit(t, remote, "log", "-1", "--format=%s", "feat/PROJ-1-login"))
+	assert.Contains(t, out.String(), "Pushed feat/PROJ-1-login to origin")
+
+	info := manager.tasks["PROJ-1"].Git
+	assert.Equal(t, "origin", info.Remote)
+	assert.NotNil(t, info.FinishedAt)
+}
+
+func TestFinishRun_PullRequest(t *testing.T) {
+	opts, out, _, _ := newTestOptions(t)
+	root, err := workspaceRoot(opts)
+	require.NoError(t, err)
+	// Push through a GitHub-looking URL that rewrites to the local remote
+	remoteURL := runGit(t, root, "remote", "get-url", "origin")
+	runGit(t, root, "config", "url."+remoteURL+".insteadOf", "git@github.com:acme/app.git")
+	runGit(t, root, "remote", "set-url", "origin", "git@github.com:acme/app.git")
+
+	var opened string
+	opts.Browser = func(url string) error {
+		opened = url
+		return nil
+	}
+	opts.PullRequest = true
+
+	require.NoError(t, finishRun(context.Background(), opts))
+	assert.Equal(t, "https://github.com/acme/app/compare/main...feat/PROJ-1-login?expand=1", opened)
+	assert.Contains(t, out.String(), "Opening "+opened)
+}
+
+func TestFinishRun_NotStarted(t *testing.T) {
+	opts, _, _, _ := newTestOptions(t)
+	opts.TaskID = "PROJ-2"
+
+	err := finishRun(context.Background(), opts)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, "task PROJ-2 has no branch", zenErr.Message)
+}
+
+func TestFinishRun_WarnsAboutUncommittedChanges(t *testing.T) {
+	opts, _, _, _ := newTestOptions(t)
+	root, err := workspaceRoot(opts)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "wip.txt"), []byte("wip"), 0644))
+
+	require.NoError(t, finishRun(context.Background(), opts))
+	assert.Contains(t, opts.IO.ErrOut.(*bytes.Buffer).String(), "Uncommitted changes in "+root+" are not pushed")
+}
+
+func workspaceRoot(opts *FinishOptions) (string, error) {
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return "", err
+	}
+	status, err := wm.Status()
+	return status.Root, err
+}
@@ -0,0 +1,37 @@
+package gate
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/gate/list"
+	"github.com/daddia/zen/pkg/cmd/task/gate/pass"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTaskGate creates the task gate command with subcommands
+func NewCmdTaskGate(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gate <command>",
+		Short: "Record the quality gates of a task",
+		Long: heredoc.Doc(`
+			Record the quality gates a task has passed, such as a design review or a
+			security sign-off, with the files, links and pull requests that show it.
+
+			Gates are kept in the quality_gates section of the task manifest with who
+			passed them and when, so the progress of a task is an auditable record of
+			its gates.
+		`),
+		Example: heredoc.Doc(`
+			# Pass the design review of a task with its notes and pull request
+			zen task gate pass PROJ-123 design-review --evidence design/review.md --evidence acme/web#42
+
+			# List the gates of a task
+			zen task gate list PROJ-123
+		`),
+	}
+
+	cmd.AddCommand(pass.NewCmdPass(f, nil))
+	cmd.AddCommand(list.NewCmdList(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,133 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager reports the progress of tasks
+type TaskManager interface {
+	GetTaskProgress(ctx context.Context, taskID string) (*task.TaskProgress, error)
+}
+
+// ListOptions contains options for the task gate list command
+type ListOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskManager, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	TaskID string
+}
+
+// NewCmdList creates the task gate list command
+func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list <task-id>",
+		Short: "List the quality gates of a task",
+		Long: heredoc.Doc(`
+			List the quality gates of a task by stage, with who passed them, when, and
+			their evidence. Use --output json for the full record of each gate.
+		`),
+		Example: heredoc.Doc(`
+			# List the gates of a task
+			zen task gate list PROJ-123
+
+			# Show the evidence of the design review
+			zen task gate list PROJ-123 --jq '.[] | select(.name == "design-review") | .evidence'
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	progress, err := manager.GetTaskProgress(ctx, opts.TaskID)
+	if err != nil {
+		return err
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(progress.QualityGates, func(w io.Writer) error {
+		return displayGates(w, opts.IO, opts.TaskID, progress.QualityGates)
+	})
+}
+
+func displayGates(w io.Writer, streams *iostreams.IOStreams, taskID string, gates []task.QualityGate) error {
+	if len(gates) == 0 {
+		fmt.Fprintf(w, "No quality gates recorded for %s.\n", taskID)
+		if streams.IsStdoutTTY() {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, streams.ColorNeutral(fmt.Sprintf("Pass one with 'zen task gate pass %s <gate> --evidence <path>'", taskID)))
+		}
+		return nil
+	}
+
+	headers := []string{"GATE", "STAGE", "STATUS", "BY", "AT", "EVIDENCE"}
+	rows := make([][]string, 0, len(gates))
+	for _, gate := range gates {
+		at := "-"
+		if gate.CheckedAt != nil {
+			at = gate.CheckedAt.Format("2006-01-02 15:04")
+		}
+		evidence := make([]string, 0, len(gate.Evidence))
+		for _, e := range gate.Evidence {
+			evidence = append(evidence, e.Reference)
+		}
+		rows = append(rows, []string{gate.Name, gate.Stage, gate.Status, gate.CheckedBy, at, strings.Join(evidence, ", ")})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
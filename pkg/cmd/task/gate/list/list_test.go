@@ -0,0 +1,51 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	progress *task.TaskProgress
+}
+
+func (m *mockTaskManager) GetTaskProgress(ctx context.Context, taskID string) (*task.TaskProgress, error) {
+	return m.progress, nil
+}
+
+func TestListRun(t *testing.T) {
+	checkedAt := time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC)
+	manager := &mockTaskManager{progress: &task.TaskProgress{QualityGates: []task.QualityGate{
+		{Name: "design-review", Stage: "04-design", Status: task.GatePassed, CheckedBy: "ana", CheckedAt: &checkedAt, Evidence: []task.Evidence{
+			{Type: task.EvidenceFile, Reference: "review.md"},
+			{Type: task.EvidencePullRequest, Reference: "acme/web#42"},
+		}},
+	}}}
+
+	streams := iostreams.Test()
+	opts := &ListOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskManager, error) { return manager, nil },
+		OutputFormat:     cmdutil.OutputText,
+		TaskID:           "PROJ-1",
+	}
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "design-review\t04-design\tpassed\tana\t2025-03-04 10:30\treview.md, acme/web#42\n", streams.Out.(*bytes.Buffer).String())
+
+	streams = iostreams.Test()
+	opts.IO = streams
+	manager.progress.QualityGates = nil
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "No quality gates recorded for PROJ-1.\n", streams.Out.(*bytes.Buffer).String())
+}
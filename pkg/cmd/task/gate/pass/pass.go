@@ -0,0 +1,124 @@
+package pass
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager records quality gates of tasks
+type TaskManager interface {
+	PassGate(ctx context.Context, taskID, gate string, request *task.GatePassRequest) (*task.QualityGate, error)
+}
+
+// PassOptions contains options for the task gate pass command
+type PassOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskManager, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	TaskID   string
+	Gate     string
+	Evidence []string
+	Stage    string
+}
+
+// NewCmdPass creates the task gate pass command
+func NewCmdPass(f *cmdutil.Factory, runF func(*PassOptions) error) *cobra.Command {
+	opts := &PassOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "pass <task-id> <gate>",
+		Short: "Record a quality gate as passed",
+		Long: heredoc.Doc(`
+			Record a quality gate of a task as passed by you, now, with its evidence.
+
+			Evidence is a file, an http(s) URL, or a pull request given as #42,
+			owner/repo#42, or its URL. Files are found as given or in the task
+			directory and recorded with their SHA-256 checksum. Passing a gate again
+			adds the new evidence to its record.
+
+			The gate guards the current stage of the task unless --stage is given.
+		`),
+		Example: heredoc.Doc(`
+			# Pass the design review with the review notes
+			zen task gate pass PROJ-123 design-review --evidence design/review.md
+
+			# Pass the security review of the build stage with a pull request and a report
+			zen task gate pass PROJ-123 security --stage 05-build \
+			  --evidence acme/web#42 --evidence https://scanner.example.com/reports/981
+		`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID, opts.Gate = args[0], args[1]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return passRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.Evidence, "evidence", nil, "File, URL or pull request showing the gate is passed (repeatable)")
+	cmd.Flags().StringVar(&opts.Stage, "stage", "", "Workflow stage the gate guards")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func passRun(ctx context.Context, opts *PassOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	gate, err := manager.PassGate(ctx, opts.TaskID, opts.Gate, &task.GatePassRequest{
+		Evidence: opts.Evidence,
+		Stage:    opts.Stage,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "%s Passed %s of %s (%s)\n", opts.IO.SuccessIcon(), gate.Name, opts.TaskID, gate.Stage)
+	if len(gate.Evidence) == 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "%s No evidence recorded; add it with --evidence\n", opts.IO.WarningIcon())
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(gate, func(w io.Writer) error {
+		// The evidence of the gate, one per line
+		for _, evidence := range gate.Evidence {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", evidence.Type, evidence.Reference, evidence.Checksum)
+		}
+		return nil
+	})
+}
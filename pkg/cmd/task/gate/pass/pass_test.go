@@ -0,0 +1,84 @@
+package pass
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	request *task.GatePassRequest
+}
+
+func (m *mockTaskManager) PassGate(ctx context.Context, taskID, gate string, request *task.GatePassRequest) (*task.QualityGate, error) {
+	m.request = request
+	record := &task.QualityGate{Name: gate, Status: task.GatePassed, Stage: "04-design", CheckedBy: "ana"}
+	for _, ref := range request.Evidence {
+		record.Evidence = append(record.Evidence, task.Evidence{Type: task.EvidenceFile, Reference: ref, Checksum: "sha256:00"})
+	}
+	return record, nil
+}
+
+func TestNewCmdPass(t *testing.T) {
+	var got *PassOptions
+	cmd := NewCmdPass(cmdutil.NewTestFactory(iostreams.Test()), func(opts *PassOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1", "design-review", "--evidence", "review.md", "--evidence", "acme/web#42", "--stage", "04-design"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+	assert.Equal(t, "design-review", got.Gate)
+	assert.Equal(t, []string{"review.md", "acme/web#42"}, got.Evidence)
+	assert.Equal(t, "04-design", got.Stage)
+}
+
+func TestPassRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		evidence []string
+		stdout   string
+		stderr   string
+	}{
+		{
+			name:     "with evidence",
+			evidence: []string{"review.md"},
+			stdout:   "file\treview.md\tsha256:00\n",
+			stderr:   "✓ Passed design-review of PROJ-1 (04-design)\n",
+		},
+		{
+			name:   "without evidence",
+			stderr: "✓ Passed design-review of PROJ-1 (04-design)\n! No evidence recorded; add it with --evidence\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams := iostreams.Test()
+			manager := &mockTaskManager{}
+			opts := &PassOptions{
+				IO:               streams,
+				WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+				TaskManager:      func() (TaskManager, error) { return manager, nil },
+				OutputFormat:     cmdutil.OutputText,
+				TaskID:           "PROJ-1",
+				Gate:             "design-review",
+				Evidence:         tt.evidence,
+			}
+
+			require.NoError(t, passRun(context.Background(), opts))
+			assert.Equal(t, tt.evidence, manager.request.Evidence)
+			assert.Equal(t, tt.stdout, streams.Out.(*bytes.Buffer).String())
+			assert.Equal(t, tt.stderr, streams.ErrOut.(*bytes.Buffer).String())
+		})
+	}
+}
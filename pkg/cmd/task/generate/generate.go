@@ -0,0 +1,167 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskGenerator creates the tasks of recurring tasks that are due
+type TaskGenerator interface {
+	GenerateDueTasks(ctx context.Context, now time.Time, dryRun bool) []*task.RecurringResult
+}
+
+// GenerateOptions contains options for the task generate-due command
+type GenerateOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskGenerator, error)
+	Now              func() time.Time
+
+	DryRun       bool
+	OutputFormat string
+	Template     string
+	JQ           string
+}
+
+// NewCmdTaskGenerateDue creates the task generate-due command
+func NewCmdTaskGenerateDue(f *cmdutil.Factory, runF func(*GenerateOptions) error) *cobra.Command {
+	opts := &GenerateOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskGenerator, error) {
+			return task.NewManager(f), nil
+		},
+		Now: time.Now,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "generate-due",
+		Short: "Create the tasks of recurring tasks that are due",
+		Long: heredoc.Doc(`
+			Create the task of the current period of each recurring task in task.recurring,
+			unless it already exists. The current period starts when the schedule last
+			fired; periods missed before it are not caught up. Running the command again,
+			or while 'zen watch' generates the same tasks, creates nothing twice.
+
+			Each recurring task has a name, a cron schedule in local time, and a local task
+			template or a title:
+
+			  task:
+			    recurring:
+			      - name: release
+			        schedule: "@monthly"
+			        template: release
+			        id: REL-{month}
+			        title: Release {month}
+			        variables:
+			          service: billing
+			      - name: standup
+			        schedule: "0 9 * * mon-fri"
+			        title: Standup notes {date}
+			        type: task
+
+			The ID, by default {NAME}-{date}, must contain a period: {date}, {week},
+			{month}, {quarter} or {year}. Those, {name} and the variables also fill the
+			title and the markdown documents of the template. Templates are saved with
+			'zen task clone <task-id> --as-template <name>'.
+		`),
+		Example: heredoc.Doc(`
+			# Create the tasks that are due
+			zen task generate-due
+
+			# Show which tasks are due without creating them
+			zen task generate-due --dry-run
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.DryRun = f.DryRun
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return generateRun(cmd.Context(), opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func generateRun(ctx context.Context, opts *GenerateOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	results := manager.GenerateDueTasks(ctx, opts.Now(), opts.DryRun)
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	if err := renderer.Render(results, func(w io.Writer) error {
+		if len(results) == 0 {
+			fmt.Fprintln(w, "No recurring tasks are defined in task.recurring.")
+			return nil
+		}
+		for _, result := range results {
+			displayResult(w, opts.IO, result)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == task.RecurringFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d recurring tasks failed", failed, len(results))
+	}
+	return nil
+}
+
+// displayResult writes a line describing the task of the current period of a
+// recurring task
+func displayResult(w io.Writer, streams *iostreams.IOStreams, result *task.RecurringResult) {
+	switch result.Status {
+	case task.RecurringCreated:
+		fmt.Fprintf(w, "%s Created %s (%s)\n", streams.SuccessIcon(), result.TaskID, result.Name)
+	case task.RecurringDue:
+		fmt.Fprintf(w, "%s Would create %s (%s)\n", streams.NeutralIcon(), result.TaskID, result.Name)
+	case task.RecurringExists:
+		fmt.Fprintf(w, "%s %s exists (%s, next %s)\n", streams.NeutralIcon(), result.TaskID, result.Name, formatTime(result.Next))
+	case task.RecurringNotDue:
+		fmt.Fprintf(w, "%s %s is not due until %s\n", streams.NeutralIcon(), result.Name, formatTime(result.Next))
+	default:
+		fmt.Fprintf(w, "%s %s: %s\n", streams.WarningIcon(), result.Name, result.Error)
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format("2006-01-02 15:04")
+}
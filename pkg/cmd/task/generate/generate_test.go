@@ -0,0 +1,99 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	results []*task.RecurringResult
+	now     time.Time
+	dryRun  bool
+}
+
+func (m *mockTaskManager) GenerateDueTasks(ctx context.Context, now time.Time, dryRun bool) []*task.RecurringResult {
+	m.now, m.dryRun = now, dryRun
+	return m.results
+}
+
+var now = time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+func newTestOptions(streams *iostreams.IOStreams, manager *mockTaskManager) *GenerateOptions {
+	return &GenerateOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskGenerator, error) { return manager, nil },
+		Now:              func() time.Time { return now },
+		OutputFormat:     cmdutil.OutputText,
+	}
+}
+
+func TestNewCmdTaskGenerateDue(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	f.DryRun = true
+
+	var got *GenerateOptions
+	cmd := NewCmdTaskGenerateDue(f, func(opts *GenerateOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.True(t, got.DryRun)
+}
+
+func TestGenerateRun(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{results: []*task.RecurringResult{
+		{Name: "standup", TaskID: "STANDUP-2026-10-16", Status: task.RecurringCreated},
+		{Name: "release", TaskID: "REL-2026-10", Status: task.RecurringExists, Next: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "retro", Status: task.RecurringNotDue, Next: time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC)},
+	}}
+	opts := newTestOptions(streams, manager)
+
+	require.NoError(t, generateRun(context.Background(), opts))
+	assert.Equal(t, now, manager.now)
+	assert.False(t, manager.dryRun)
+	assert.Equal(t, "✓ Created STANDUP-2026-10-16 (standup)\n"+
+		"- REL-2026-10 exists (release, next 2026-11-01 00:00)\n"+
+		"- retro is not due until 2028-02-29 09:00\n", streams.Out.(*bytes.Buffer).String())
+}
+
+func TestGenerateRun_DryRunAndFailures(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{results: []*task.RecurringResult{
+		{Name: "standup", TaskID: "STANDUP-2026-10-16", Status: task.RecurringDue},
+		{Name: "release", Status: task.RecurringFailed, Error: "task template not found: release"},
+	}}
+	opts := newTestOptions(streams, manager)
+	opts.DryRun = true
+
+	err := generateRun(context.Background(), opts)
+	assert.EqualError(t, err, "1 of 2 recurring tasks failed")
+	assert.True(t, manager.dryRun)
+	assert.Equal(t, "- Would create STANDUP-2026-10-16 (standup)\n"+
+		"! release: task template not found: release\n", streams.Out.(*bytes.Buffer).String())
+}
+
+func TestGenerateRun_NotInitialized(t *testing.T) {
+	opts := newTestOptions(iostreams.Test(), &mockTaskManager{})
+	opts.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) { return zentest.NewWorkspace(t), nil }
+
+	err := generateRun(context.Background(), opts)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeWorkspaceNotInit, zenErr.Code)
+}
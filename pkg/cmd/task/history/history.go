@@ -0,0 +1,122 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskHistorian reads the revisions of the documents of tasks
+type TaskHistorian interface {
+	TaskHistory(ctx context.Context, taskID string) ([]*task.Revision, error)
+}
+
+// HistoryOptions contains options for the task history command
+type HistoryOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskHistorian, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	TaskID string
+}
+
+// NewCmdTaskHistory creates the task history command
+func NewCmdTaskHistory(f *cmdutil.Factory, runF func(*HistoryOptions) error) *cobra.Command {
+	opts := &HistoryOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskHistorian, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "history <task-id>",
+		Short: "List the revisions of the documents of a task",
+		Long: heredoc.Doc(`
+			List the revisions of the index.md and manifest.yaml of a task, oldest first,
+			with the documents each changed.
+
+			A revision is recorded whenever zen writes a task, such as when it is created,
+			synced, progressed or renamed, and the documents differ from the revision
+			before. Edits made in an editor are recorded with the next write. Revisions
+			are kept in the .history directory of the task, each version of a document
+			stored once under its checksum.
+
+			Use 'zen task diff' to see the changes since a revision.
+		`),
+		Example: heredoc.Doc(`
+			# List the revisions of a task
+			zen task history PROJ-123
+
+			# Show what changed since revision 3
+			zen task diff PROJ-123 --rev 3
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return historyRun(cmd.Context(), opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func historyRun(ctx context.Context, opts *HistoryOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	revisions, err := manager.TaskHistory(ctx, opts.TaskID)
+	if err != nil {
+		return err
+	}
+	if revisions == nil {
+		revisions = []*task.Revision{}
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(revisions, func(w io.Writer) error {
+		if len(revisions) == 0 {
+			fmt.Fprintf(w, "%s has no history.\n", opts.TaskID)
+			return nil
+		}
+
+		fmt.Fprintf(w, "%s\n", opts.IO.ColorBold(fmt.Sprintf("%-5s %-19s  %s", "REV", "TIME", "CHANGED")))
+		for _, revision := range revisions {
+			fmt.Fprintf(w, "%-5d %s  %s\n", revision.Rev,
+				opts.IO.ColorNeutral(revision.Time.Local().Format("2006-01-02 15:04:05")), strings.Join(revision.Changed, ", "))
+		}
+		return nil
+	})
+}
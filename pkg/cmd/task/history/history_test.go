@@ -0,0 +1,67 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	revisions []*task.Revision
+}
+
+func (m *mockTaskManager) TaskHistory(ctx context.Context, taskID string) ([]*task.Revision, error) {
+	return m.revisions, nil
+}
+
+func newTestOptions(streams *iostreams.IOStreams, manager *mockTaskManager) *HistoryOptions {
+	return &HistoryOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskHistorian, error) { return manager, nil },
+		TaskID:           "PROJ-1",
+	}
+}
+
+func TestNewCmdTaskHistory(t *testing.T) {
+	var got *HistoryOptions
+	cmd := NewCmdTaskHistory(cmdutil.NewTestFactory(iostreams.Test()), func(opts *HistoryOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+}
+
+func TestHistoryRun(t *testing.T) {
+	streams := iostreams.Test()
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	manager := &mockTaskManager{revisions: []*task.Revision{
+		{Rev: 1, Time: at, Changed: []string{"index.md", "manifest.yaml"}},
+		{Rev: 2, Time: at.Add(time.Hour), Changed: []string{"manifest.yaml"}},
+	}}
+
+	require.NoError(t, historyRun(context.Background(), newTestOptions(streams, manager)))
+	assert.Equal(t, "REV   TIME                 CHANGED\n"+
+		"1     2025-03-01 12:00:00  index.md, manifest.yaml\n"+
+		"2     2025-03-01 13:00:00  manifest.yaml\n", streams.Out.(*bytes.Buffer).String())
+}
+
+func TestHistoryRun_Empty(t *testing.T) {
+	streams := iostreams.Test()
+
+	require.NoError(t, historyRun(context.Background(), newTestOptions(streams, &mockTaskManager{})))
+	assert.Equal(t, "PROJ-1 has no history.\n", streams.Out.(*bytes.Buffer).String())
+}
@@ -0,0 +1,265 @@
+// Package importcmd implements 'zen task import'; import is a keyword, so the package
+// is not named after the command.
+package importcmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskImporter creates tasks from the rows of spreadsheets
+type TaskImporter interface {
+	ImportTasks(ctx context.Context, request *task.ImportRequest) ([]*task.ImportResult, error)
+}
+
+// ImportOptions contains options for the task import command
+type ImportOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskImporter, error)
+
+	File        string
+	Mapping     map[string]string
+	DryRun      bool
+	SkipInvalid bool
+
+	OutputFormat string
+	Template     string
+	JQ           string
+}
+
+// NewCmdTaskImport creates the task import command
+func NewCmdTaskImport(f *cmdutil.Factory, runF func(*ImportOptions) error) *cobra.Command {
+	opts := &ImportOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskImporter, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "import --file <spreadsheet>",
+		Short: "Create tasks from the rows of a spreadsheet",
+		Long: heredoc.Docf(`
+			Create a task from each row of a CSV, TSV or Excel (.xlsx) spreadsheet, such as
+			a backlog kept in a shared sheet. The first row is the header, and Excel
+			workbooks are read from their first sheet.
+
+			Task fields are read from columns: %[1]s. A field is read from the column
+			named after it, ignoring case, unless --map or task.import_mapping names
+			another:
+
+			  task:
+			    import_mapping:
+			      title: Summary
+			      owner: Assignee
+
+			Titles are required, and so are IDs unless task.id_scheme allocates them.
+			Priorities are P0 to P3 or names such as high and low, and statuses are task
+			statuses or names such as "To Do" and "Done". Labels are separated by commas
+			or semicolons, and owners are mapped to users of the identity configuration.
+
+			Every row is validated first. A spreadsheet with invalid rows creates no tasks
+			unless --skip-invalid is given; --dry-run shows what would be created. Rows
+			whose task exists are skipped, so a spreadsheet can be imported again after
+			it is fixed or extended.
+
+			An import cancelled with Ctrl-C reports the rows it did not import and exits
+			with 2. Importing the same spreadsheet again resumes it, without creating the
+			tasks of the rows already imported again.
+		`, "`"+strings.Join(task.ImportFields, "`, `")+"`"),
+		Example: heredoc.Doc(`
+			# Preview the tasks of a spreadsheet
+			zen task import --file backlog.csv --map title=Summary,owner=Assignee --dry-run
+
+			# Create them
+			zen task import --file backlog.csv --map title=Summary,owner=Assignee
+
+			# Create the tasks of the valid rows of a workbook
+			zen task import --file backlog.xlsx --skip-invalid
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.File == "" {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--file is required")}
+			}
+			opts.DryRun = f.DryRun
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return importRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.File, "file", "f", "", "Spreadsheet to import (.csv, .tsv or .xlsx)")
+	cmd.Flags().StringToStringVar(&opts.Mapping, "map", nil, "Column of task fields, as field=column pairs (e.g. title=Summary,owner=Assignee)")
+	cmd.Flags().BoolVar(&opts.SkipInvalid, "skip-invalid", false, "Create the tasks of the valid rows when other rows are invalid")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func importRun(ctx context.Context, opts *ImportOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	root, err := internal.WorkspaceRoot(opts.WorkspaceManager)
+	if err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	request := &task.ImportRequest{
+		File:        opts.File,
+		Mapping:     opts.Mapping,
+		DryRun:      opts.DryRun,
+		SkipInvalid: opts.SkipInvalid,
+	}
+
+	// An import that was cancelled is resumed when the spreadsheet is imported again
+	// unchanged, so that rows whose tasks were created with allocated IDs are not
+	// created twice
+	op := &cmdutil.LongOperation{Name: "task import", IO: opts.IO, Key: importKey(opts.File)}
+	if !opts.DryRun {
+		op.CheckpointPath = cmdutil.CheckpointPath(filepath.Join(root, ".zen"), "task-import")
+		if checkpoint := op.Resume(); checkpoint != nil {
+			request.SkipRows = checkpointRows(checkpoint.Completed)
+			fmt.Fprintf(opts.IO.ErrOut, "%s Resuming the import cancelled at %s: %d rows were imported\n", opts.IO.InfoIcon(),
+				checkpoint.Cancelled.Local().Format("2006-01-02 15:04:05"), len(request.SkipRows))
+		}
+	}
+
+	var results []*task.ImportResult
+	err = op.Run(ctx, func(ctx context.Context) error {
+		var importErr error
+		results, importErr = manager.ImportTasks(ctx, request)
+		if results == nil {
+			return importErr
+		}
+		for _, result := range results {
+			if result.Status == task.ImportInvalid {
+				continue
+			}
+			op.Add(rowItem(result.Row))
+			if result.Status == task.ImportCreated || result.Status == task.ImportExists {
+				op.Complete(rowItem(result.Row))
+			}
+		}
+
+		// The rows imported before a cancellation are reported too
+		renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+		renderer.Template, renderer.JQ = opts.Template, opts.JQ
+		if err := renderer.Render(results, func(w io.Writer) error {
+			if len(results) == 0 {
+				fmt.Fprintf(w, "%s has no rows to import.\n", opts.File)
+				return nil
+			}
+			return displayResults(w, opts.IO, results)
+		}); err != nil {
+			return err
+		}
+		return importErr
+	})
+	if err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	switch {
+	case opts.DryRun:
+		fmt.Fprintf(opts.IO.ErrOut, "%s Would create %d tasks: %d exist, %d invalid\n", opts.IO.NeutralIcon(),
+			counts[task.ImportValid], counts[task.ImportExists], counts[task.ImportInvalid])
+	case counts[task.ImportFailed] > 0:
+		return fmt.Errorf("%d of %d tasks failed to import", counts[task.ImportFailed], len(results))
+	default:
+		fmt.Fprintf(opts.IO.ErrOut, "%s Created %d tasks: %d exist, %d invalid\n", opts.IO.SuccessIcon(),
+			counts[task.ImportCreated], counts[task.ImportExists], counts[task.ImportInvalid])
+	}
+	return nil
+}
+
+// importKey identifies the spreadsheet in file by its path and contents, so that the
+// checkpoint of an import is not resumed once the spreadsheet changed
+func importKey(file string) string {
+	key := file
+	if abs, err := filepath.Abs(file); err == nil {
+		key = abs
+	}
+	data, err := os.ReadFile(file) // #nosec G304 - spreadsheet given by the user
+	if err != nil {
+		return key
+	}
+	return fmt.Sprintf("%s sha256:%x", key, sha256.Sum256(data))
+}
+
+// rowItem names a row of the spreadsheet in the checkpoint of a cancelled import
+func rowItem(row int) string {
+	return "row " + strconv.Itoa(row)
+}
+
+// checkpointRows returns the numbers of the rows named in a checkpoint
+func checkpointRows(items []string) []int {
+	rows := make([]int, 0, len(items))
+	for _, item := range items {
+		if row, err := strconv.Atoi(strings.TrimPrefix(item, "row ")); err == nil {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func displayResults(w io.Writer, streams *iostreams.IOStreams, results []*task.ImportResult) error {
+	headers := []string{"ROW", "TASK", "TITLE", "STATUS"}
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		id := result.TaskID
+		if id == "" {
+			id = "(new)"
+		}
+		status := result.Status
+		if result.Error != "" {
+			status += ": " + result.Error
+		}
+		if streams.IsStdoutTTY() {
+			switch result.Status {
+			case task.ImportCreated, task.ImportValid:
+				status = streams.ColorSuccess(status)
+			case task.ImportInvalid, task.ImportFailed:
+				status = streams.ColorError(status)
+			}
+		}
+		rows = append(rows, []string{strconv.Itoa(result.Row), id, result.Title, status})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
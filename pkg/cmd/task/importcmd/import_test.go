@@ -0,0 +1,169 @@
+package importcmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	request *task.ImportRequest
+	results []*task.ImportResult
+	err     error
+}
+
+func (m *mockTaskManager) ImportTasks(ctx context.Context, request *task.ImportRequest) ([]*task.ImportResult, error) {
+	m.request = request
+	return m.results, m.err
+}
+
+func newTestOptions(streams *iostreams.IOStreams, manager *mockTaskManager) *ImportOptions {
+	return &ImportOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskImporter, error) { return manager, nil },
+		File:             "backlog.csv",
+		OutputFormat:     cmdutil.OutputText,
+	}
+}
+
+func TestNewCmdTaskImport(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	f.DryRun = true
+
+	var got *ImportOptions
+	cmd := NewCmdTaskImport(f, func(opts *ImportOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"--file", "backlog.csv", "--map", "title=Summary,owner=Assignee", "--skip-invalid"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "backlog.csv", got.File)
+	assert.Equal(t, map[string]string{"title": "Summary", "owner": "Assignee"}, got.Mapping)
+	assert.True(t, got.SkipInvalid)
+	assert.True(t, got.DryRun)
+
+	cmd = NewCmdTaskImport(cmdutil.NewTestFactory(iostreams.Test()), func(opts *ImportOptions) error { return nil })
+	cmd.SetArgs([]string{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, cmd.Execute(), &flagErr)
+}
+
+func TestImportRun(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{results: []*task.ImportResult{
+		{Row: 2, TaskID: "PROJ-2", Title: "Add SSO", Status: task.ImportCreated},
+		{Row: 3, TaskID: "PROJ-1", Title: "Add login page", Status: task.ImportExists},
+		{Row: 4, Title: "Add MFA", Status: task.ImportInvalid, Error: "the ID is empty"},
+	}}
+	opts := newTestOptions(streams, manager)
+	opts.Mapping = map[string]string{"title": "Summary"}
+	opts.SkipInvalid = true
+
+	require.NoError(t, importRun(context.Background(), opts))
+	assert.Equal(t, &task.ImportRequest{File: "backlog.csv", Mapping: map[string]string{"title": "Summary"}, SkipInvalid: true}, manager.request)
+	assert.Equal(t, "2\tPROJ-2\tAdd SSO\tcreated\n"+
+		"3\tPROJ-1\tAdd login page\texists\n"+
+		"4\t(new)\tAdd MFA\tinvalid: the ID is empty\n", streams.Out.(*bytes.Buffer).String())
+	assert.Equal(t, "✓ Created 1 tasks: 1 exist, 1 invalid\n", streams.ErrOut.(*bytes.Buffer).String())
+}
+
+func TestImportRun_Invalid(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{
+		results: []*task.ImportResult{{Row: 2, Title: "Add MFA", Status: task.ImportInvalid, Error: "the ID is empty"}},
+		err:     &types.Error{Code: types.ErrorCodeInvalidInput, Message: "1 of 1 rows of backlog.csv are invalid; no tasks were created"},
+	}
+
+	err := importRun(context.Background(), newTestOptions(streams, manager))
+	assert.ErrorContains(t, err, "no tasks were created")
+	assert.Contains(t, streams.Out.(*bytes.Buffer).String(), "invalid: the ID is empty", "invalid rows are shown")
+	assert.Empty(t, streams.ErrOut.(*bytes.Buffer).String())
+}
+
+func TestImportRun_DryRun(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{results: []*task.ImportResult{{Row: 2, Title: "Add SSO", Status: task.ImportValid}}}
+	opts := newTestOptions(streams, manager)
+	opts.DryRun = true
+
+	require.NoError(t, importRun(context.Background(), opts))
+	assert.True(t, manager.request.DryRun)
+	assert.Equal(t, "- Would create 1 tasks: 0 exist, 0 invalid\n", streams.ErrOut.(*bytes.Buffer).String())
+}
+
+func TestImportRun_Cancelled(t *testing.T) {
+	streams := iostreams.Test()
+	root := t.TempDir()
+	manager := &mockTaskManager{
+		results: []*task.ImportResult{
+			{Row: 2, TaskID: "PROJ-2", Title: "Add SSO", Status: task.ImportCreated},
+			{Row: 3, Title: "Add MFA", Status: task.ImportValid},
+		},
+		err: context.Canceled,
+	}
+	opts := newTestOptions(streams, manager)
+	opts.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt(root), nil }
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := importRun(ctx, opts)
+	var cancelErr *cmdutil.CancelError
+	require.ErrorAs(t, err, &cancelErr)
+	assert.True(t, cmdutil.IsUserCancellation(err))
+	assert.Contains(t, streams.Out.(*bytes.Buffer).String(), "2\tPROJ-2\tAdd SSO\tcreated\n", "the rows imported are shown")
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "task import cancelled: 1 of 2 completed")
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "Not completed: row 3")
+	checkpoint := filepath.Join(root, ".zen", "run", "checkpoints", "task-import.json")
+	assert.FileExists(t, checkpoint)
+
+	streams = iostreams.Test()
+	opts.IO = streams
+	manager.err = nil
+	require.NoError(t, importRun(context.Background(), opts))
+	assert.Equal(t, []int{2}, manager.request.SkipRows, "the rows imported before the cancellation are skipped")
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "Resuming the import cancelled at")
+	assert.NoFileExists(t, checkpoint, "the checkpoint is removed once the import completes")
+}
+
+func TestImportRun_CancelledFileChanged(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(t.TempDir(), "backlog.csv")
+	require.NoError(t, os.WriteFile(file, []byte("ID,Summary\nPROJ-2,Add SSO\nPROJ-3,Add MFA\n"), 0600))
+	manager := &mockTaskManager{
+		results: []*task.ImportResult{
+			{Row: 2, TaskID: "PROJ-2", Title: "Add SSO", Status: task.ImportCreated},
+			{Row: 3, Title: "Add MFA", Status: task.ImportValid},
+		},
+		err: context.Canceled,
+	}
+	opts := newTestOptions(iostreams.Test(), manager)
+	opts.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt(root), nil }
+	opts.File = file
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, importRun(ctx, opts))
+
+	require.NoError(t, os.WriteFile(file, []byte("ID,Summary\nPROJ-3,Add MFA\nPROJ-4,Add SAML\n"), 0600))
+	streams := iostreams.Test()
+	opts.IO = streams
+	manager.err = nil
+	require.NoError(t, importRun(context.Background(), opts))
+	assert.Empty(t, manager.request.SkipRows, "the rows of a changed spreadsheet are all imported")
+	assert.NotContains(t, streams.ErrOut.(*bytes.Buffer).String(), "Resuming the import")
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/daddia/zen/pkg/pullrequest"
+)
+
+// PullRequestURL returns the web page for opening a pull request from branch into base on
+// GitHub, or a merge request on GitLab. It returns "" for other hosts.
+func PullRequestURL(remoteURL, base, branch string) string {
+	repo, ok := pullrequest.ParseRemote(remoteURL)
+	if !ok {
+		return ""
+	}
+
+	switch repo.ProviderName() {
+	case pullrequest.ProviderGitLab:
+		query := url.Values{}
+		query.Set("merge_request[source_branch]", branch)
+		if base != "" {
+			query.Set("merge_request[target_branch]", base)
+		}
+		return fmt.Sprintf("https://%s/%s/-/merge_requests/new?%s", repo.Host, repo.Path, query.Encode())
+	case pullrequest.ProviderGitHub:
+		if base == "" {
+			return fmt.Sprintf("https://%s/%s/pull/new/%s", repo.Host, repo.Path, branch)
+		}
+		return fmt.Sprintf("https://%s/%s/compare/%s...%s?expand=1", repo.Host, repo.Path, base, branch)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestURL(t *testing.T) {
+	assert.Equal(t,
+		"https://github.com/acme/app/compare/main...feat/PROJ-1-login?expand=1",
+		PullRequestURL("git@github.com:acme/app.git", "main", "feat/PROJ-1-login"))
+	assert.Equal(t,
+		"https://github.com/acme/app/pull/new/feat/PROJ-1",
+		PullRequestURL("https://github.com/acme/app", "", "feat/PROJ-1"))
+	assert.Equal(t,
+		"https://gitlab.com/group/app/-/merge_requests/new?merge_request%5Bsource_branch%5D=feat%2FPROJ-1&merge_request%5Btarget_branch%5D=main",
+		PullRequestURL("git@gitlab.com:group/app.git", "main", "feat/PROJ-1"))
+	assert.Empty(t, PullRequestURL("https://bitbucket.org/acme/app.git", "main", "feat/PROJ-1"))
+}
@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// WorkspaceRoot returns the root of the initialized workspace
+func WorkspaceRoot(workspaceManager func() (cmdutil.WorkspaceManager, error)) (string, error) {
+	wm, err := workspaceManager()
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return "", &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	return status.Root, nil
+}
+
+// TaskConfig returns a function that reads the task section of the configuration
+func TaskConfig(f *cmdutil.Factory) func() (task.Config, error) {
+	return func() (task.Config, error) {
+		cfg, err := f.Config()
+		if err != nil {
+			return task.Config{}, err
+		}
+		return config.GetConfig(cfg, task.ConfigParser{})
+	}
+}
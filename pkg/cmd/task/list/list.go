@@ -0,0 +1,225 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TaskLister lists tasks in the workspace
+type TaskLister interface {
+	ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error)
+}
+
+// ListOptions contains options for the task list command
+type ListOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskLister, error)
+	TaskConfig       func() (task.Config, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	Filter task.TaskFilter
+
+	// Conditions is a filter expression such as "owner=me,status!=completed"
+	Conditions string
+
+	// View is the name of a saved view whose filter and columns are used
+	View string
+
+	// Columns are the columns tasks are listed with, instead of the view's or the default
+	Columns []string
+
+	// Sort lists the columns tasks are sorted by, such as "-updated,id"
+	Sort string
+}
+
+// NewCmdTaskList creates the task list command
+func NewCmdTaskList(f *cmdutil.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskLister, error) {
+			return task.NewManager(f), nil
+		},
+		TaskConfig: func() (task.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return task.Config{}, err
+			}
+			return config.GetConfig(cfg, task.ConfigParser{})
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List tasks in the workspace",
+		Long: heredoc.Doc(`
+			List the tasks in the current workspace.
+
+			Tasks are read from .zen/work/tasks/ and can be filtered by type, status,
+			owner, team, workflow stage and labels. Use --output, --format or --jq to
+			produce machine-readable output for scripts.
+
+			--filter takes a comma-separated list of conditions that must all hold, as
+			field=value or field!=value, on the fields type, status, priority, owner,
+			team, stage, label and source. Values list alternatives separated by '|',
+			and the owner "me" is you. Filters are saved as views with 'zen task view
+			save', which --view lists with the view's columns.
+
+			--columns selects the columns of the table and --sort orders the tasks by
+			columns, each prefixed with '-' for descending order. Tasks are sorted by ID
+			by default.
+		`),
+		Example: heredoc.Doc(`
+			# List all tasks
+			zen task list
+
+			# List in-progress stories
+			zen task list --type story --status in_progress
+
+			# List tasks in the design stage
+			zen task list --stage design
+
+			# List your tasks that are not completed
+			zen task list --filter 'owner=me,status!=completed'
+
+			# List the tasks of a saved view
+			zen task list --view my-open
+
+			# List the most recently updated tasks first, with fewer columns
+			zen task list --columns id,title,stage,owner --sort -updated
+
+			# Print one line per task with a Go template
+			zen task list --format '{{.ID}} {{.Status}}'
+
+			# Select task IDs with a jq expression
+			zen task list --jq '.[] | select(.owner == "jane.doe") | .id'
+		`),
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+			opts.Columns, opts.Sort = cmdutil.TableFlags(cmd)
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter.Type, "type", "", "Filter by task type")
+	cmd.Flags().StringVar(&opts.Filter.Status, "status", "", "Filter by status")
+	cmd.Flags().StringVar(&opts.Filter.Owner, "owner", "", "Filter by owner")
+	cmd.Flags().StringVar(&opts.Filter.Team, "team", "", "Filter by team")
+	cmd.Flags().StringVar(&opts.Filter.Stage, "stage", "", "Filter by workflow stage (e.g. 04-design or design)")
+	cmd.Flags().StringSliceVar(&opts.Filter.Labels, "label", nil, "Filter by label (repeatable)")
+	cmd.Flags().StringVar(&opts.Conditions, "filter", "", "Filter by conditions such as `owner=me,status!=completed`")
+	cmd.Flags().StringVar(&opts.View, "view", "", "List the tasks of a saved view")
+
+	cmdutil.AddFormatFlags(cmd)
+	cmdutil.AddTableFlags(cmd, task.ListTable)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	conditions, err := task.ParseFilter(opts.Conditions)
+	if err != nil {
+		return &cmdutil.FlagError{Err: fmt.Errorf("invalid --filter: %w", err)}
+	}
+	columns := opts.Columns
+	if opts.View != "" {
+		view, err := findView(opts)
+		if err != nil {
+			return err
+		}
+		viewConditions, err := task.ParseFilter(view.Filter)
+		if err != nil {
+			return fmt.Errorf("invalid view %s: %w", opts.View, err)
+		}
+		conditions = append(viewConditions, conditions...)
+		if len(columns) == 0 {
+			columns = view.Columns
+		}
+	}
+	opts.Filter.Conditions = append(opts.Filter.Conditions, conditions...)
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	tasks, err := manager.ListTasks(ctx, &opts.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	if err := opts.IO.StartPager(); err != nil {
+		fmt.Fprintf(opts.IO.ErrOut, "%s %v\n", opts.IO.WarningIcon(), err)
+	}
+	defer opts.IO.StopPager()
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	renderer.Columns, renderer.Sort = columns, opts.Sort
+	return cmdutil.RenderTable(renderer, task.ListTable, tasks, func(w io.Writer) error {
+		fmt.Fprintln(w, "No tasks found.")
+		if opts.IO.IsStdoutTTY() {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, opts.IO.ColorNeutral("Create one with 'zen task create <task-id>'"))
+		}
+		return nil
+	})
+}
+
+// findView returns the saved view named by --view
+func findView(opts *ListOptions) (task.View, error) {
+	cfg, err := opts.TaskConfig()
+	if err != nil {
+		return task.View{}, fmt.Errorf("failed to get task config: %w", err)
+	}
+	view, ok := cfg.View(opts.View)
+	if !ok {
+		return task.View{}, &types.Error{
+			Code:    types.ErrorCodeNotFound,
+			Message: fmt.Sprintf("view not found: %s", opts.View),
+			Details: "save one with 'zen task view save <name> --filter <conditions>'",
+		}
+	}
+	return view, nil
+}
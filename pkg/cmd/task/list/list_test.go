@@ -0,0 +1,189 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockLister struct {
+	tasks  []*task.Task
+	filter *task.TaskFilter
+}
+
+func (m *mockLister) ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error) {
+	m.filter = filter
+	var result []*task.Task
+	for _, t := range m.tasks {
+		if filter.Matches(t) {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func newTestOptions(t *testing.T, initialized bool) (*ListOptions, *bytes.Buffer, *mockLister) {
+	t.Helper()
+
+	streams := iostreams.Test()
+	f := cmdutil.NewTestFactoryWithWorkspace(streams, initialized, false)
+	lister := &mockLister{
+		tasks: []*task.Task{
+			{ID: "PROJ-2", Title: "Checkout", Type: "story", Status: "in_progress", Owner: "alex", CurrentStage: "04-design",
+				Updated: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+			{ID: "PROJ-1", Title: "Login", Type: "bug", Status: "proposed", Owner: "sam", CurrentStage: "01-align",
+				Updated: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	opts := &ListOptions{
+		IO:               streams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager:      func() (TaskLister, error) { return lister, nil },
+	}
+
+	return opts, streams.Out.(*bytes.Buffer), lister
+}
+
+func TestNewCmdTaskList(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	cmd := NewCmdTaskList(f)
+
+	assert.Equal(t, "list", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "ls")
+	for _, name := range []string{"type", "status", "owner", "team", "stage", "label", "filter", "view", "columns", "sort", "format", "jq"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "missing flag %s", name)
+	}
+}
+
+func TestListRun_WorkspaceNotInitialized(t *testing.T) {
+	opts, _, _ := newTestOptions(t, false)
+
+	err := listRun(context.Background(), opts)
+	require.Error(t, err)
+
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeWorkspaceNotInit, zenErr.Code)
+}
+
+func TestListRun_Text(t *testing.T) {
+	opts, out, _ := newTestOptions(t, true)
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-1\tLogin\tbug\tAlign\tproposed\tsam\nPROJ-2\tCheckout\tstory\tDesign\tin_progress\talex\n", out.String())
+}
+
+func TestListRun_Filter(t *testing.T) {
+	opts, out, lister := newTestOptions(t, true)
+	opts.Filter.Type = "story"
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "story", lister.filter.Type)
+	assert.Contains(t, out.String(), "PROJ-2")
+	assert.NotContains(t, out.String(), "PROJ-1")
+}
+
+func TestListRun_Conditions(t *testing.T) {
+	opts, out, _ := newTestOptions(t, true)
+	opts.Conditions = "status!=proposed"
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-2\tCheckout\tstory\tDesign\tin_progress\talex\n", out.String())
+
+	opts.Conditions = "assignee=alex"
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, listRun(context.Background(), opts), &flagErr)
+}
+
+func TestListRun_View(t *testing.T) {
+	opts, out, lister := newTestOptions(t, true)
+	opts.TaskConfig = func() (task.Config, error) {
+		return task.Config{Views: map[string]task.View{
+			"alex": {Filter: "owner=alex", Columns: []string{"id", "status"}},
+		}}, nil
+	}
+	opts.View = "Alex"
+	opts.Filter.Type = "story"
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "story", lister.filter.Type, "flags narrow the view")
+	assert.Equal(t, "PROJ-2\tin_progress\n", out.String(), "listed with the columns of the view")
+
+	opts.View = "mine"
+	var zenErr *types.Error
+	require.ErrorAs(t, listRun(context.Background(), opts), &zenErr)
+	assert.Equal(t, types.ErrorCodeNotFound, zenErr.Code)
+}
+
+func TestListRun_ColumnsAndSort(t *testing.T) {
+	opts, out, _ := newTestOptions(t, true)
+	opts.Columns = []string{"id", "owner", "updated"}
+	opts.Sort = "-updated"
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-2\talex\t2026-03-02\nPROJ-1\tsam\t2026-03-01\n", out.String())
+
+	out.Reset()
+	opts.TaskConfig = func() (task.Config, error) {
+		return task.Config{Views: map[string]task.View{"all": {Columns: []string{"id", "status"}}}}, nil
+	}
+	opts.View = "all"
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-2\talex\t2026-03-02\nPROJ-1\tsam\t2026-03-01\n", out.String(), "--columns overrides the view")
+
+	opts.View = ""
+	opts.Columns = []string{"assignee"}
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, listRun(context.Background(), opts), &flagErr)
+
+	opts.Columns = nil
+	opts.Sort = "due"
+	assert.ErrorAs(t, listRun(context.Background(), opts), &flagErr)
+}
+
+func TestListRun_JSON(t *testing.T) {
+	opts, out, _ := newTestOptions(t, true)
+	opts.OutputFormat = cmdutil.OutputJSON
+
+	require.NoError(t, listRun(context.Background(), opts))
+
+	var tasks []map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &tasks))
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "PROJ-1", tasks[0]["id"])
+	assert.Equal(t, "01-align", tasks[0]["current_stage"])
+}
+
+func TestListRun_Template(t *testing.T) {
+	opts, out, _ := newTestOptions(t, true)
+	opts.Template = "{{.ID}} {{.Status}}"
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-1 proposed\nPROJ-2 in_progress\n", out.String())
+}
+
+func TestListRun_JQ(t *testing.T) {
+	opts, out, _ := newTestOptions(t, true)
+	opts.JQ = `.[] | select(.owner == "alex") | .id`
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-2\n", out.String())
+}
+
+func TestListRun_Empty(t *testing.T) {
+	opts, out, lister := newTestOptions(t, true)
+	lister.tasks = nil
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "No tasks found.\n", out.String())
+}
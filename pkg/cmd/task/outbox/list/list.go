@@ -0,0 +1,132 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// OutboxLister lists the pushes waiting in the outboxes of tasks
+type OutboxLister interface {
+	ListOutbox(ctx context.Context, taskID string) ([]*task.OutboxEntry, error)
+}
+
+// ListOptions contains options for the task outbox list command
+type ListOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (OutboxLister, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	TaskID string
+}
+
+// NewCmdList creates the task outbox list command
+func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (OutboxLister, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list [<task-id>]",
+		Aliases: []string{"ls"},
+		Short:   "List the pushes waiting to be retried",
+		Example: heredoc.Doc(`
+			$ zen task outbox list
+			$ zen task outbox list PROJ-123
+			$ zen task outbox list --output json
+		`),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.TaskID = args[0]
+			}
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	entries, err := manager.ListOutbox(ctx, opts.TaskID)
+	if err != nil {
+		return err
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(entries, func(w io.Writer) error {
+		return displayListText(w, opts.IO, entries, time.Now())
+	})
+}
+
+func displayListText(w io.Writer, streams *iostreams.IOStreams, entries []*task.OutboxEntry, now time.Time) error {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No pushes waiting.")
+		return nil
+	}
+
+	headers := []string{"TASK", "SOURCE", "DIRECTION", "FIELDS", "ATTEMPTS", "NEXT ATTEMPT", "LAST ERROR"}
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		next := "now"
+		if entry.NextAttempt.After(now) {
+			next = entry.NextAttempt.Local().Format("2006-01-02 15:04:05")
+		}
+		rows = append(rows, []string{
+			entry.TaskID,
+			entry.Source,
+			string(entry.Direction),
+			strings.Join(entry.Fields, ", "),
+			strconv.Itoa(entry.Attempts),
+			next,
+			entry.LastError,
+		})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	entries []*task.OutboxEntry
+	taskID  string
+}
+
+func (m *mockTaskManager) ListOutbox(ctx context.Context, taskID string) ([]*task.OutboxEntry, error) {
+	m.taskID = taskID
+	return m.entries, nil
+}
+
+func newTestOptions(streams *iostreams.IOStreams, manager *mockTaskManager) *ListOptions {
+	return &ListOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (OutboxLister, error) { return manager, nil },
+	}
+}
+
+func TestNewCmdList(t *testing.T) {
+	var got *ListOptions
+	cmd := NewCmdList(cmdutil.NewTestFactory(iostreams.Test()), func(opts *ListOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+}
+
+func TestListRun_Text(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{entries: []*task.OutboxEntry{{
+		ID:          "01J",
+		TaskID:      "PROJ-1",
+		Source:      "jira",
+		Direction:   task.SyncDirectionPush,
+		Fields:      []string{"status", "title"},
+		Attempts:    2,
+		LastError:   "jira unavailable",
+		NextAttempt: time.Now().Add(-time.Minute),
+	}}}
+	opts := newTestOptions(streams, manager)
+	opts.TaskID = "PROJ-1"
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-1", manager.taskID)
+	assert.Equal(t, "PROJ-1\tjira\tpush\tstatus, title\t2\tnow\tjira unavailable\n", streams.Out.(*bytes.Buffer).String())
+}
+
+func TestListRun_Empty(t *testing.T) {
+	streams := iostreams.Test()
+
+	require.NoError(t, listRun(context.Background(), newTestOptions(streams, &mockTaskManager{entries: []*task.OutboxEntry{}})))
+	assert.Equal(t, "No pushes waiting.\n", streams.Out.(*bytes.Buffer).String())
+}
+
+func TestListRun_JSON(t *testing.T) {
+	streams := iostreams.Test()
+	opts := newTestOptions(streams, &mockTaskManager{entries: []*task.OutboxEntry{{ID: "01J", TaskID: "PROJ-1", Source: "jira"}}})
+	opts.OutputFormat = cmdutil.OutputJSON
+	opts.JQ = `.[] | "\(.task_id) \(.source)"`
+
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "PROJ-1 jira\n", streams.Out.(*bytes.Buffer).String())
+}
@@ -0,0 +1,44 @@
+package outbox
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/outbox/list"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTaskOutbox creates the task outbox command with subcommands
+func NewCmdTaskOutbox(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "outbox <command>",
+		Short: "Inspect the pushes of local changes waiting to be retried",
+		Long: heredoc.Doc(`
+			Inspect the outbox of tasks: the pushes of local changes to external sources
+			that have not yet succeeded.
+
+			A push or bidirectional sync of a task with local changes is recorded in the
+			outbox of the task, in its metadata/outbox directory, before it is attempted,
+			and removed once it succeeds. A push that fails, or is interrupted, stays in
+			the outbox, and is retried by 'zen watch' with increasing delays, from one
+			minute up to an hour, and at once by the next sync of the task.
+
+			Each task has at most one entry for each source; changes made while a push
+			waits join its entry, since a push sends the task as it is. An entry is
+			retried by one command at a time, and only while the task still has changes
+			to push, so that each change reaches the source once. A push the source
+			accepted is marked delivered at once, and a retry of it after zen stopped
+			records it in the task rather than sending it again.
+		`),
+		Example: heredoc.Doc(`
+			# List the pushes waiting to be retried
+			zen task outbox list
+
+			# Retry the pushes of a task now
+			zen task sync PROJ-123
+		`),
+	}
+
+	cmd.AddCommand(list.NewCmdList(f, nil))
+
+	return cmd
+}
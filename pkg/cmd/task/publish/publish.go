@@ -0,0 +1,184 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	zenpublish "github.com/daddia/zen/pkg/publish"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager publishes the artifacts of tasks
+type TaskManager interface {
+	Publish(ctx context.Context, taskID string, publisher task.Publisher, request *task.PublishRequest) ([]task.PublishResult, error)
+}
+
+// PublishOptions contains options for the task publish command
+type PublishOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskManager, error)
+	Publisher        func(target string) (task.Publisher, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	TaskID    string
+	Target    string
+	Artifacts []string
+	Force     bool
+}
+
+// NewCmdTaskPublish creates the task publish command
+func NewCmdTaskPublish(f *cmdutil.Factory, runF func(*PublishOptions) error) *cobra.Command {
+	opts := &PublishOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+		Publisher: func(target string) (task.Publisher, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return nil, err
+			}
+			authManager, err := f.AuthManager()
+			if err != nil {
+				return nil, err
+			}
+			return zenpublish.ForTarget(target, cfg, authManager, f.Logger)
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "publish <task-id> --to <target>",
+		Short: "Publish task artifacts to Confluence or a wiki",
+		Long: heredoc.Doc(`
+			Publish the artifacts of a task as wiki pages: its index.md, its decision
+			records and its outcome reports, or those selected with --artifact.
+
+			The index is published as the parent page of the other artifacts, titled
+			after the first heading of each file. The pages are recorded in the task's
+			manifest, so publishing again updates them in place; artifacts unchanged
+			since they were last published are skipped unless --force is given.
+
+			Targets are configured as integration providers:
+			- confluence: pages of the space in settings.space of the wiki at url, such
+			  as https://acme.atlassian.net/wiki, under the page in settings.parent.
+			  Authenticate with type basic, email and api_key, or a personal access
+			  token with 'zen auth confluence'.
+			- wiki: Markdown files in the directory of settings.path, such as a clone of
+			  a GitHub or GitLab wiki, linked to url when it is set.
+		`),
+		Example: heredoc.Doc(`
+			# Publish the index, decision records and outcomes of a task
+			zen task publish PROJ-123 --to confluence
+
+			# Publish only the decision records
+			zen task publish PROJ-123 --to confluence --artifact adr
+
+			# Write the pages to a clone of the project's GitHub wiki
+			zen task publish PROJ-123 --to wiki
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if opts.Target == "" {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--to is required (%s)", strings.Join(zenpublish.Targets, ", "))}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return publishRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Target, "to", "", fmt.Sprintf("Target to publish to (%s)", strings.Join(zenpublish.Targets, ", ")))
+	cmd.Flags().StringSliceVar(&opts.Artifacts, "artifact", nil, fmt.Sprintf("Artifacts to publish (%s; default all)", strings.Join(task.PublishArtifacts, ", ")))
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Republish artifacts that are unchanged since they were last published")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func publishRun(ctx context.Context, opts *PublishOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+	publisher, err := opts.Publisher(opts.Target)
+	if err != nil {
+		return err
+	}
+
+	results, publishErr := manager.Publish(ctx, opts.TaskID, publisher, &task.PublishRequest{
+		Artifacts: opts.Artifacts,
+		Force:     opts.Force,
+	})
+	if len(results) == 0 {
+		return publishErr
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	if err := renderer.Render(results, func(w io.Writer) error {
+		return displayResults(w, opts.IO, results)
+	}); err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Action]++
+	}
+	icon := opts.IO.SuccessIcon()
+	if publishErr != nil {
+		icon = opts.IO.WarningIcon()
+	}
+	fmt.Fprintf(opts.IO.ErrOut, "%s Published %s to %s: %d created, %d updated, %d unchanged\n", icon, opts.TaskID,
+		publisher.Name(), counts[task.PublishCreated], counts[task.PublishUpdated], counts[task.PublishUnchanged])
+	return publishErr
+}
+
+func displayResults(w io.Writer, streams *iostreams.IOStreams, results []task.PublishResult) error {
+	headers := []string{"ARTIFACT", "ACTION", "PAGE"}
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		page := result.URL
+		if page == "" {
+			page = result.ID
+		}
+		action := result.Action
+		if streams.IsStdoutTTY() && action != task.PublishUnchanged {
+			action = streams.ColorSuccess(action)
+		}
+		rows = append(rows, []string{result.Artifact, action, page})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
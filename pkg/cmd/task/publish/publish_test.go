@@ -0,0 +1,100 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	request *task.PublishRequest
+	results []task.PublishResult
+	err     error
+}
+
+func (m *mockTaskManager) Publish(ctx context.Context, taskID string, publisher task.Publisher, request *task.PublishRequest) ([]task.PublishResult, error) {
+	m.request = request
+	return m.results, m.err
+}
+
+type mockPublisher struct{}
+
+func (mockPublisher) Name() string {
+	return "confluence"
+}
+
+func (mockPublisher) PublishPage(ctx context.Context, page *task.PublishPage) (*task.PublishedPage, error) {
+	return nil, errors.New("not used")
+}
+
+func newTestOptions(streams *iostreams.IOStreams, manager *mockTaskManager) *PublishOptions {
+	return &PublishOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskManager, error) { return manager, nil },
+		Publisher:        func(target string) (task.Publisher, error) { return mockPublisher{}, nil },
+		TaskID:           "PROJ-1",
+		Target:           "confluence",
+	}
+}
+
+func TestNewCmdTaskPublish(t *testing.T) {
+	var got *PublishOptions
+	cmd := NewCmdTaskPublish(cmdutil.NewTestFactory(iostreams.Test()), func(opts *PublishOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1", "--to", "confluence", "--artifact", "index,adr", "--force"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+	assert.Equal(t, "confluence", got.Target)
+	assert.Equal(t, []string{"index", "adr"}, got.Artifacts)
+	assert.True(t, got.Force)
+
+	cmd = NewCmdTaskPublish(cmdutil.NewTestFactory(iostreams.Test()), func(opts *PublishOptions) error { return nil })
+	cmd.SetArgs([]string{"PROJ-1"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, cmd.Execute(), &flagErr)
+}
+
+func TestPublishRun(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{results: []task.PublishResult{
+		{Artifact: "index.md", Title: "PROJ-1: Add login page", Action: task.PublishUnchanged, PublishedPage: task.PublishedPage{ID: "7", URL: "https://acme.atlassian.net/wiki/pages/7"}},
+		{Artifact: "design/decisions/0001-use-oauth.md", Title: "PROJ-1: Use OAuth", Action: task.PublishCreated, PublishedPage: task.PublishedPage{ID: "8"}},
+	}}
+	opts := newTestOptions(streams, manager)
+	opts.Artifacts = []string{"adr"}
+
+	require.NoError(t, publishRun(context.Background(), opts))
+	assert.Equal(t, []string{"adr"}, manager.request.Artifacts)
+	assert.Equal(t, "index.md\tunchanged\thttps://acme.atlassian.net/wiki/pages/7\n"+
+		"design/decisions/0001-use-oauth.md\tcreated\t8\n", streams.Out.(*bytes.Buffer).String())
+	assert.Equal(t, "✓ Published PROJ-1 to confluence: 1 created, 0 updated, 1 unchanged\n", streams.ErrOut.(*bytes.Buffer).String())
+}
+
+func TestPublishRun_Error(t *testing.T) {
+	streams := iostreams.Test()
+	manager := &mockTaskManager{
+		results: []task.PublishResult{{Artifact: "index.md", Action: task.PublishCreated, PublishedPage: task.PublishedPage{ID: "7"}}},
+		err:     errors.New("failed to publish outcomes/release.md: confluence API error (400): invalid storage format"),
+	}
+
+	err := publishRun(context.Background(), newTestOptions(streams, manager))
+	assert.ErrorContains(t, err, "invalid storage format")
+	assert.Contains(t, streams.Out.(*bytes.Buffer).String(), "index.md\tcreated\t7\n", "pages published before the error are shown")
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "1 created")
+}
@@ -0,0 +1,113 @@
+package rename
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskRenamer renames tasks
+type TaskRenamer interface {
+	RenameTask(ctx context.Context, oldID, newID string, redirect bool) (*task.RenameResult, error)
+}
+
+// RenameOptions contains options for the task rename command
+type RenameOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskRenamer, error)
+
+	OldID    string
+	NewID    string
+	Redirect bool
+}
+
+// NewCmdTaskRename creates the task rename command
+func NewCmdTaskRename(f *cmdutil.Factory, runF func(*RenameOptions) error) *cobra.Command {
+	opts := &RenameOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskRenamer, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rename <old-id> <new-id>",
+		Short: "Give a task a new ID",
+		Long: heredoc.Doc(`
+			Move a task to a new ID, such as the key of the issue it became in an external
+			system. The task directory moves with its attachments and sync metadata, and
+			references to the old ID in its manifest, .taskrc.yaml, index.md and other
+			documents are rewritten. The git branch of the task keeps its name.
+
+			With --redirect, a stub is left under the old ID, so that commands given the
+			old ID, such as 'zen task status', find the task under its new one. Renaming
+			the task back to the old ID replaces the stub. Without a redirect, the old ID
+			is free for a new task.
+		`),
+		Example: heredoc.Doc(`
+			# Re-key a local task to the key of its Jira issue
+			zen task rename LOCAL-12 PROJ-345
+
+			# Rename a task and keep the old ID working for links in notes and commits
+			zen task rename PROJ-1 PROJ-101 --redirect
+		`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OldID, opts.NewID = args[0], args[1]
+			if opts.OldID == opts.NewID {
+				return &cmdutil.FlagError{Err: fmt.Errorf("the new ID must differ from the old one")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return renameRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Redirect, "redirect", false, "Leave a stub that resolves the old ID to the task")
+
+	return cmd
+}
+
+func renameRun(ctx context.Context, opts *RenameOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	result, err := manager.RenameTask(ctx, opts.OldID, opts.NewID, opts.Redirect)
+	if err != nil {
+		return err
+	}
+
+	out := opts.IO.ErrOut
+	fmt.Fprintf(out, "%s Renamed %s to %s\n", opts.IO.SuccessIcon(), result.OldID, result.NewID)
+	if len(result.Rewritten) > 0 {
+		fmt.Fprintf(out, "%s Rewrote references in %s\n", opts.IO.NeutralIcon(), strings.Join(result.Rewritten, ", "))
+	}
+	if result.Redirect {
+		fmt.Fprintf(out, "%s Left a redirect from %s\n", opts.IO.NeutralIcon(), result.OldID)
+	}
+	if result.Branch != "" {
+		fmt.Fprintf(out, "%s Branch %s keeps its name\n", opts.IO.WarningIcon(), result.Branch)
+	}
+	return nil
+}
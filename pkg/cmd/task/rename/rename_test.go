@@ -0,0 +1,83 @@
+package rename
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	result *task.RenameResult
+}
+
+func (m *mockTaskManager) RenameTask(ctx context.Context, oldID, newID string, redirect bool) (*task.RenameResult, error) {
+	if oldID != "PROJ-1" {
+		return nil, &types.Error{Code: types.ErrorCodeNotFound, Message: "task not found: " + oldID}
+	}
+	m.result = &task.RenameResult{OldID: oldID, NewID: newID, Rewritten: []string{"manifest.yaml", "index.md"}, Redirect: redirect, Branch: "feature/PROJ-1-login"}
+	return m.result, nil
+}
+
+func newTestOptions(streams *iostreams.IOStreams, manager *mockTaskManager) *RenameOptions {
+	return &RenameOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (TaskRenamer, error) { return manager, nil },
+	}
+}
+
+func TestNewCmdTaskRename(t *testing.T) {
+	var got *RenameOptions
+	cmd := NewCmdTaskRename(cmdutil.NewTestFactory(iostreams.Test()), func(opts *RenameOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1", "PROJ-101", "--redirect"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.OldID)
+	assert.Equal(t, "PROJ-101", got.NewID)
+	assert.True(t, got.Redirect)
+
+	cmd = NewCmdTaskRename(cmdutil.NewTestFactory(iostreams.Test()), func(opts *RenameOptions) error { return nil })
+	cmd.SetArgs([]string{"PROJ-1", "PROJ-1"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, cmd.Execute(), &flagErr)
+}
+
+func TestRenameRun(t *testing.T) {
+	streams := iostreams.Test()
+	opts := newTestOptions(streams, &mockTaskManager{})
+	opts.OldID, opts.NewID, opts.Redirect = "PROJ-1", "PROJ-101", true
+
+	require.NoError(t, renameRun(context.Background(), opts))
+	assert.Equal(t, "✓ Renamed PROJ-1 to PROJ-101\n"+
+		"- Rewrote references in manifest.yaml, index.md\n"+
+		"- Left a redirect from PROJ-1\n"+
+		"! Branch feature/PROJ-1-login keeps its name\n", streams.ErrOut.(*bytes.Buffer).String())
+
+	opts.OldID = "PROJ-2"
+	assert.ErrorContains(t, renameRun(context.Background(), opts), "task not found: PROJ-2")
+}
+
+func TestRenameRun_NotInitialized(t *testing.T) {
+	opts := newTestOptions(iostreams.Test(), &mockTaskManager{})
+	opts.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) { return zentest.NewWorkspace(t), nil }
+
+	err := renameRun(context.Background(), opts)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeWorkspaceNotInit, zenErr.Code)
+}
@@ -0,0 +1,204 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/identity"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/report"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/spf13/cobra"
+)
+
+// TaskLister lists tasks in the workspace
+type TaskLister interface {
+	ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error)
+}
+
+// ReportOptions contains options for the task report command
+type ReportOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskLister, error)
+	TemplateEngine   func() (template.TemplateEngine, error)
+	IdentityConfig   func() (identity.Config, error)
+	Now              func() time.Time
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	Filter         task.TaskFilter
+	Sections       []string
+	CSV            bool
+	HTML           bool
+	ReportTemplate string
+}
+
+// NewCmdTaskReport creates the task report command
+func NewCmdTaskReport(f *cmdutil.Factory, runF func(*ReportOptions) error) *cobra.Command {
+	opts := &ReportOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskLister, error) {
+			return task.NewManager(f), nil
+		},
+		TemplateEngine: func() (template.TemplateEngine, error) {
+			return f.TemplateEngine()
+		},
+		IdentityConfig: func() (identity.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return identity.Config{}, err
+			}
+			return config.GetConfig(cfg, identity.ConfigParser{})
+		},
+		Now: time.Now,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report on tasks by stage, owner, and cycle time",
+		Long: heredoc.Docf(`
+			Produce a status report of the tasks in the workspace, for weekly updates and
+			reviews.
+
+			The report has these sections, all shown unless --sections selects some:
+
+			  stages       tasks in each workflow stage
+			  owners       tasks of each owner, busiest first
+			  cycle-time   time from 'zen task start' to 'zen task finish' of finished tasks
+			  tasks        every task with its status, stage, and owner
+
+			Owners are shown by their user name in identity.users, so a task owned by
+			an account in Jira or GitHub counts for the user it belongs to.
+
+			The report is written as Markdown, or as a standalone HTML page with --html.
+			Both are rendered with templates from the asset repository, "%[1]s" and
+			"%[2]s", or built-in templates when it has none; use --template to
+			render with another. --csv writes a row for each task instead, for
+			spreadsheets, and --output json the report data.
+
+			Tasks can be filtered as with 'zen task list'.
+		`, report.DefaultMarkdownTemplate, report.DefaultHTMLTemplate),
+		Example: heredoc.Doc(`
+			# Report on every task
+			zen task report
+
+			# Share the report of a team as a web page
+			zen task report --team platform --html > report.html
+
+			# Export tasks in progress to a spreadsheet
+			zen task report --status in_progress --csv > tasks.csv
+
+			# Show only cycle time, with a template from the asset repository
+			zen task report --sections cycle-time --template weekly-status
+
+			# Print the median cycle time
+			zen task report --jq '.cycle_time.median_days'
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if err := report.ValidateSections(opts.Sections); err != nil {
+				return &cmdutil.FlagError{Err: err}
+			}
+			if (opts.CSV || opts.HTML) && (opts.Template != "" || opts.JQ != "" || opts.OutputFormat != cmdutil.OutputText) {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--csv and --html cannot be combined with --output, --format or --jq")}
+			}
+			if opts.CSV && opts.ReportTemplate != "" {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--template cannot be used with --csv")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return reportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter.Type, "type", "", "Filter by task type")
+	cmd.Flags().StringVar(&opts.Filter.Status, "status", "", "Filter by status")
+	cmd.Flags().StringVar(&opts.Filter.Owner, "owner", "", "Filter by owner")
+	cmd.Flags().StringVar(&opts.Filter.Team, "team", "", "Filter by team")
+	cmd.Flags().StringVar(&opts.Filter.Stage, "stage", "", "Filter by workflow stage (e.g. 04-design or design)")
+	cmd.Flags().StringSliceVar(&opts.Filter.Labels, "label", nil, "Filter by label (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.Sections, "sections", nil,
+		fmt.Sprintf("Sections to show: {%s}", strings.Join(report.Sections, "|")))
+	cmd.Flags().BoolVar(&opts.CSV, "csv", false, "Write a CSV row for each task")
+	cmd.Flags().BoolVar(&opts.HTML, "html", false, "Write the report as a standalone HTML page")
+	cmd.Flags().StringVar(&opts.ReportTemplate, "template", "", "Name of the report template in the asset repository")
+	cmd.MarkFlagsMutuallyExclusive("csv", "html")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func reportRun(ctx context.Context, opts *ReportOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	tasks, err := manager.ListTasks(ctx, &opts.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	identityConfig, err := opts.IdentityConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get identity config: %w", err)
+	}
+
+	// Owners synced from a source are named by their account there
+	directory := identity.New(identityConfig)
+	for _, t := range tasks {
+		t.Owner = directory.Resolve(t.Owner)
+	}
+
+	r := report.Build(tasks, opts.Sections, opts.Now())
+
+	if opts.CSV {
+		return report.WriteCSV(opts.IO.Out, r)
+	}
+
+	format := report.FormatMarkdown
+	if opts.HTML {
+		format = report.FormatHTML
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(r, func(w io.Writer) error {
+		engine, err := opts.TemplateEngine()
+		if err != nil {
+			return fmt.Errorf("failed to get template engine: %w", err)
+		}
+
+		out, err := report.Render(ctx, engine, format, opts.ReportTemplate, r)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, out)
+		return err
+	})
+}
@@ -0,0 +1,197 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/identity"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/report"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	tasks  []*task.Task
+	filter *task.TaskFilter
+}
+
+func (m *mockTaskManager) ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error) {
+	m.filter = filter
+	return m.tasks, nil
+}
+
+func TestNewCmdTaskReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    ReportOptions
+		wantErr string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "filters and sections",
+			args: []string{"--team", "platform", "--label", "auth", "--sections", "owners,cycle-time", "--html"},
+			want: ReportOptions{
+				Filter:   task.TaskFilter{Team: "platform", Labels: []string{"auth"}},
+				Sections: []string{"owners", "cycle-time"},
+				HTML:     true,
+			},
+		},
+		{
+			name: "template",
+			args: []string{"--template", "weekly-status"},
+			want: ReportOptions{ReportTemplate: "weekly-status"},
+		},
+		{
+			name:    "unknown section",
+			args:    []string{"--sections", "velocity"},
+			wantErr: `unknown report section "velocity"`,
+		},
+		{
+			name:    "csv and html",
+			args:    []string{"--csv", "--html"},
+			wantErr: "none of the others can be",
+		},
+		{
+			name:    "csv with jq",
+			args:    []string{"--csv", "--jq", ".total"},
+			wantErr: "--csv and --html cannot be combined",
+		},
+		{
+			name:    "csv with template",
+			args:    []string{"--csv", "--template", "weekly-status"},
+			wantErr: "--template cannot be used with --csv",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *ReportOptions
+			cmd := NewCmdTaskReport(cmdutil.NewTestFactory(iostreams.Test()), func(opts *ReportOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Filter, got.Filter)
+			assert.Equal(t, tt.want.Sections, got.Sections)
+			assert.Equal(t, tt.want.HTML, got.HTML)
+			assert.Equal(t, tt.want.CSV, got.CSV)
+			assert.Equal(t, tt.want.ReportTemplate, got.ReportTemplate)
+		})
+	}
+}
+
+func newTestOptions(t *testing.T) (*ReportOptions, *mockTaskManager, *bytes.Buffer) {
+	t.Helper()
+	started := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	finished := started.Add(48 * time.Hour)
+	manager := &mockTaskManager{tasks: []*task.Task{
+		{ID: "PROJ-2", Title: "Fix timeout", Type: "bug", Status: "in_progress", Owner: "ben", CurrentStage: "05-build",
+			Git: &task.GitInfo{Branch: "fix/PROJ-2", StartedAt: &started}},
+		{ID: "PROJ-1", Title: "Add login", Type: "story", Status: "done", Owner: "ana", CurrentStage: "06-ship",
+			Git: &task.GitInfo{Branch: "feat/PROJ-1", StartedAt: &started, FinishedAt: &finished}},
+	}}
+
+	streams := iostreams.Test()
+	return &ReportOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt(t.TempDir()), nil },
+		TaskManager:      func() (TaskLister, error) { return manager, nil },
+		TemplateEngine: func() (template.TemplateEngine, error) {
+			return template.NewEngine(logging.NewBasic(), zentest.NewAssetClient(), template.DefaultConfig()), nil
+		},
+		IdentityConfig: func() (identity.Config, error) { return identity.DefaultConfig(), nil },
+		Now:            func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) },
+		OutputFormat:   cmdutil.OutputText,
+	}, manager, streams.Out.(*bytes.Buffer)
+}
+
+func TestReportRun_Markdown(t *testing.T) {
+	opts, manager, out := newTestOptions(t)
+	opts.Filter = task.TaskFilter{Owner: "ana"}
+
+	require.NoError(t, reportRun(context.Background(), opts))
+
+	assert.Equal(t, "ana", manager.filter.Owner, "filters are passed to the task manager")
+	assert.Contains(t, out.String(), "# Task Report (2026-10-16)")
+	assert.Contains(t, out.String(), "| Ship | 1 | PROJ-1 |")
+	assert.Contains(t, out.String(), "| 1 | 2d | 2d | 2d | 2d | 2d |")
+}
+
+func TestReportRun_Owners(t *testing.T) {
+	opts, manager, out := newTestOptions(t)
+	opts.OutputFormat = cmdutil.OutputJSON
+	opts.Sections = []string{report.SectionOwners}
+	manager.tasks[0].Owner = "Ana Lopez"
+	opts.IdentityConfig = func() (identity.Config, error) {
+		return identity.Config{Users: map[string]identity.User{"ana": {Name: "Ana Lopez"}}}, nil
+	}
+
+	require.NoError(t, reportRun(context.Background(), opts))
+
+	var r report.Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &r))
+	require.Len(t, r.Owners, 1, "owners synced from a source are mapped to their local user")
+	assert.Equal(t, "ana", r.Owners[0].Name)
+	assert.Equal(t, 2, r.Owners[0].Count)
+}
+
+func TestReportRun_HTML(t *testing.T) {
+	opts, _, out := newTestOptions(t)
+	opts.HTML = true
+
+	require.NoError(t, reportRun(context.Background(), opts))
+
+	assert.Contains(t, out.String(), "<!DOCTYPE html>")
+	assert.Contains(t, out.String(), "Add login")
+}
+
+func TestReportRun_CSV(t *testing.T) {
+	opts, _, out := newTestOptions(t)
+	opts.CSV = true
+
+	require.NoError(t, reportRun(context.Background(), opts))
+
+	records, err := csv.NewReader(out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "PROJ-1", records[1][0])
+	assert.Equal(t, "2", records[1][11])
+}
+
+func TestReportRun_JSON(t *testing.T) {
+	opts, _, out := newTestOptions(t)
+	opts.OutputFormat = cmdutil.OutputJSON
+	opts.Sections = []string{report.SectionCycleTime}
+
+	require.NoError(t, reportRun(context.Background(), opts))
+
+	var r report.Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &r))
+	assert.Equal(t, 2, r.Total)
+	assert.Equal(t, []string{report.SectionCycleTime}, r.Sections)
+	require.NotNil(t, r.CycleTime)
+	assert.Equal(t, 1, r.CycleTime.InProgress)
+}
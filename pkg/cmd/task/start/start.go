@@ -0,0 +1,247 @@
+package start
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager loads tasks, checks branch names against organization policy and
+// records the branches of tasks
+type TaskManager interface {
+	GetTask(ctx context.Context, taskID string) (*task.Task, error)
+	CheckBranchName(ctx context.Context, branch string) error
+	UpdateTaskGit(ctx context.Context, taskID string, info *task.GitInfo) error
+}
+
+// StartOptions contains options for the task start command
+type StartOptions struct {
+	IO               *iostreams.IOStreams
+	Logger           logging.Logger
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskConfig       func() (task.Config, error)
+	TaskManager      func() (TaskManager, error)
+
+	TaskID   string
+	Branch   string
+	Base     string
+	Worktree bool
+	Path     string
+}
+
+// NewCmdTaskStart creates the task start command
+func NewCmdTaskStart(f *cmdutil.Factory, runF func(*StartOptions) error) *cobra.Command {
+	opts := &StartOptions{
+		IO:               f.IOStreams,
+		Logger:           f.Logger,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskConfig:       internal.TaskConfig(f),
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "start <task-id>",
+		Short: "Create a branch or worktree to work on a task",
+		Long: heredoc.Doc(`
+			Start work on a task by creating a feature branch for it and switching to it.
+
+			The branch is named from the task.branch_template setting, which defaults to
+			"{prefix}/{id}-{slug}" (for example feat/PROJ-123-add-login). The prefix is
+			"fix" for bugs, "spike" for spikes and "feat" otherwise, and the slug is taken
+			from the task title.
+
+			With --worktree the branch is checked out in a separate Git worktree instead,
+			leaving the current checkout untouched. Worktrees are created in
+			task.worktree_dir, or next to the workspace by default.
+
+			The branch and worktree are recorded in the task manifest, so running start
+			again returns to the same branch and 'zen task finish' knows what to push.
+		`),
+		Example: heredoc.Doc(`
+			# Create and switch to feat/PROJ-123-<slug>
+			zen task start PROJ-123
+
+			# Work on the task in its own worktree
+			zen task start PROJ-123 --worktree
+
+			# Choose the branch name and base
+			zen task start PROJ-123 --branch hotfix/PROJ-123 --base release/1.2
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+			if opts.Path != "" && !opts.Worktree {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--path requires --worktree")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return startRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Branch, "branch", "b", "", "Branch name (default from task.branch_template)")
+	cmd.Flags().StringVar(&opts.Base, "base", "", "Branch to create the task branch from (default task.base_branch or the current branch)")
+	cmd.Flags().BoolVarP(&opts.Worktree, "worktree", "w", false, "Check out the branch in a new Git worktree")
+	cmd.Flags().StringVar(&opts.Path, "path", "", "Directory for the worktree")
+
+	return cmd
+}
+
+func startRun(ctx context.Context, opts *StartOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	root, err := internal.WorkspaceRoot(opts.WorkspaceManager)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := opts.TaskConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load task configuration: %w", err)
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	t, err := manager.GetTask(ctx, opts.TaskID)
+	if err != nil {
+		return err
+	}
+
+	repo := git.NewCLIRepository(root, opts.Logger, nil, "")
+	current, err := repo.GetCurrentBranch(ctx)
+	if err != nil {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "workspace is not a Git repository",
+			Details: fmt.Sprintf("'zen task start' needs a Git repository at %s", root),
+		}
+	}
+
+	info := &task.GitInfo{Branch: opts.Branch, Base: opts.Base}
+	if t.Git != nil {
+		// Resume the branch and worktree recorded by an earlier start
+		if info.Branch == "" {
+			info.Branch = t.Git.Branch
+		}
+		if info.Branch == t.Git.Branch {
+			info.StartedAt = t.Git.StartedAt
+			if opts.Worktree && opts.Path == "" {
+				info.Worktree = t.Git.Worktree
+			}
+		}
+	}
+	if info.Branch == "" {
+		if info.Branch, err = task.BranchName(cfg.BranchTemplate, t); err != nil {
+			return err
+		}
+	}
+	if info.Base == "" {
+		info.Base = cfg.BaseBranch
+	}
+	if info.Base == "" {
+		info.Base = current
+	}
+	if info.StartedAt == nil {
+		now := time.Now()
+		info.StartedAt = &now
+	}
+
+	exists, err := repo.BranchExists(ctx, info.Branch)
+	if err != nil {
+		return fmt.Errorf("failed to check branch %s: %w", info.Branch, err)
+	}
+	// Branches created before the organization policy are kept
+	if !exists {
+		if err := manager.CheckBranchName(ctx, info.Branch); err != nil {
+			return err
+		}
+	}
+
+	created := !exists
+	if opts.Worktree {
+		if opts.Path != "" {
+			info.Worktree = opts.Path
+		}
+		if info.Worktree == "" {
+			info.Worktree = worktreePath(root, cfg.WorktreeDir, t.ID)
+		}
+		if info.Worktree, err = filepath.Abs(info.Worktree); err != nil {
+			return err
+		}
+
+		if _, err := os.Stat(info.Worktree); err == nil {
+			created = false
+		} else {
+			base := info.Base
+			if exists {
+				base = ""
+			}
+			if err := repo.AddWorktree(ctx, info.Worktree, info.Branch, base); err != nil {
+				return fmt.Errorf("failed to create worktree: %w", git.OutputError(err))
+			}
+		}
+	} else if current != info.Branch {
+		if exists {
+			err = repo.SwitchBranch(ctx, info.Branch)
+		} else {
+			err = repo.CheckoutNewBranch(ctx, info.Branch, info.Base)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to switch to branch %s: %w", info.Branch, git.OutputError(err))
+		}
+	}
+
+	if err := manager.UpdateTaskGit(ctx, t.ID, info); err != nil {
+		return err
+	}
+
+	verb := "Switched to"
+	if created {
+		verb = "Created"
+	}
+	fmt.Fprintln(opts.IO.Out, opts.IO.FormatSuccess(fmt.Sprintf("%s branch %s for %s", verb, info.Branch, t.ID)))
+	if created {
+		fmt.Fprintf(opts.IO.Out, "  %s Base: %s\n", opts.IO.ColorNeutral("→"), info.Base)
+	}
+	if info.Worktree != "" {
+		fmt.Fprintf(opts.IO.Out, "  %s Worktree: %s\n", opts.IO.ColorNeutral("→"), info.Worktree)
+		if opts.IO.IsStdoutTTY() {
+			fmt.Fprintf(opts.IO.Out, "\nRun 'cd %s' to start working\n", info.Worktree)
+		}
+	}
+
+	return nil
+}
+
+// worktreePath returns where a task's worktree is created: in dir relative to the workspace
+// root when set, or in a "<workspace>-worktrees" directory next to the workspace
+func worktreePath(root, dir, taskID string) string {
+	if dir == "" {
+		return filepath.Join(filepath.Dir(root), filepath.Base(root)+"-worktrees", taskID)
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	return filepath.Join(dir, taskID)
+}
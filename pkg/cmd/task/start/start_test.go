@@ -0,0 +1,182 @@
+package start
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	tasks map[string]*task.Task
+
+	// branchPrefix is the prefix organization policy requires of branch names
+	branchPrefix string
+}
+
+func (m *mockTaskManager) GetTask(ctx context.Context, taskID string) (*task.Task, error) {
+	if t, ok := m.tasks[taskID]; ok {
+		return t, nil
+	}
+	return nil, assert.AnError
+}
+
+func (m *mockTaskManager) CheckBranchName(ctx context.Context, branch string) error {
+	if !strings.HasPrefix(branch, m.branchPrefix) {
+		return fmt.Errorf("branch %s does not follow the naming rule", branch)
+	}
+	return nil
+}
+
+func (m *mockTaskManager) UpdateTaskGit(ctx context.Context, taskID string, info *task.GitInfo) error {
+	m.tasks[taskID].Git = info
+	return nil
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func newTestOptions(t *testing.T) (*StartOptions, *bytes.Buffer, *mockTaskManager, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "zen")
+	t.Setenv("GIT_AUTHOR_EMAIL", "zen@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "zen")
+	t.Setenv("GIT_COMMITTER_EMAIL", "zen@example.com")
+
+	root := filepath.Join(t.TempDir(), "workspace")
+	out, err := exec.Command("git", "init", "-q", "-b", "main", root).CombinedOutput()
+	require.NoError(t, err, string(out))
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "initial")
+
+	streams := iostreams.Test()
+	manager := &mockTaskManager{tasks: map[string]*task.Task{
+		"PROJ-1": {ID: "PROJ-1", Title: "Add login page", Type: "story"},
+	}}
+
+	return &StartOptions{
+		IO:               streams,
+		Logger:           logging.NewBasic(),
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt(root), nil },
+		TaskConfig:       func() (task.Config, error) { return task.DefaultConfig(), nil },
+		TaskManager:      func() (TaskManager, error) { return manager, nil },
+		TaskID:           "PROJ-1",
+	}, streams.Out.(*bytes.Buffer), manager, root
+}
+
+func TestNewCmdTaskStart(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+
+	var got *StartOptions
+	cmd := NewCmdTaskStart(f, func(opts *StartOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1", "--worktree", "--base", "develop"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+	assert.True(t, got.Worktree)
+	assert.Equal(t, "develop", got.Base)
+
+	cmd = NewCmdTaskStart(f, func(opts *StartOptions) error { return nil })
+	cmd.SetArgs([]string{"PROJ-1", "--path", "/tmp/wt"})
+	cmd.SetErr(&bytes.Buffer{})
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, cmd.Execute(), &flagErr)
+}
+
+func TestStartRun_CreatesBranch(t *testing.T) {
+	opts, out, manager, root := newTestOptions(t)
+
+	require.NoError(t, startRun(context.Background(), opts))
+
+	assert.Equal(t, "feat/PROJ-1-add-login-page", runGit(t, root, "rev-parse", "--abbrev-ref", "HEAD"))
+	assert.Contains(t, out.String(), "Created branch feat/PROJ-1-add-login-page for PROJ-1")
+	assert.Contains(t, out.String(), "Base: main")
+
+	info := manager.tasks["PROJ-1"].Git
+	require.NotNil(t, info)
+	assert.Equal(t, "feat/PROJ-1-add-login-page", info.Branch)
+	assert.Equal(t, "main", info.Base)
+	assert.NotNil(t, info.StartedAt)
+	assert.Empty(t, info.Worktree)
+}
+
+func TestStartRun_BranchNamingPolicy(t *testing.T) {
+	opts, _, manager, root := newTestOptions(t)
+	manager.branchPrefix = "feature/"
+
+	err := startRun(context.Background(), opts)
+	assert.EqualError(t, err, "branch feat/PROJ-1-add-login-page does not follow the naming rule")
+	assert.Equal(t, "main", runGit(t, root, "rev-parse", "--abbrev-ref", "HEAD"))
+	assert.Nil(t, manager.tasks["PROJ-1"].Git)
+
+	// Existing branches are kept
+	runGit(t, root, "branch", "hotfix/PROJ-1")
+	opts.Branch = "hotfix/PROJ-1"
+	require.NoError(t, startRun(context.Background(), opts))
+	assert.Equal(t, "hotfix/PROJ-1", runGit(t, root, "rev-parse", "--abbrev-ref", "HEAD"))
+}
+
+func TestStartRun_ResumesRecordedBranch(t *testing.T) {
+	opts, out, manager, root := newTestOptions(t)
+	require.NoError(t, startRun(context.Background(), opts))
+	started := manager.tasks["PROJ-1"].Git.StartedAt
+
+	runGit(t, root, "checkout", "-q", "main")
+	manager.tasks["PROJ-1"].Title = "Renamed"
+	out.Reset()
+
+	require.NoError(t, startRun(context.Background(), opts))
+	assert.Equal(t, "feat/PROJ-1-add-login-page", runGit(t, root, "rev-parse", "--abbrev-ref", "HEAD"))
+	assert.Contains(t, out.String(), "Switched to branch feat/PROJ-1-add-login-page")
+	assert.Equal(t, started, manager.tasks["PROJ-1"].Git.StartedAt)
+}
+
+func TestStartRun_Worktree(t *testing.T) {
+	opts, out, manager, root := newTestOptions(t)
+	opts.Worktree = true
+	opts.Branch = "task/proj-1"
+
+	require.NoError(t, startRun(context.Background(), opts))
+
+	path := filepath.Join(filepath.Dir(root), "workspace-worktrees", "PROJ-1")
+	assert.Equal(t, "task/proj-1", runGit(t, path, "rev-parse", "--abbrev-ref", "HEAD"))
+	assert.Equal(t, "main", runGit(t, root, "rev-parse", "--abbrev-ref", "HEAD"))
+	assert.Equal(t, path, manager.tasks["PROJ-1"].Git.Worktree)
+	assert.Contains(t, out.String(), "Worktree: "+path)
+
+	// Starting again reuses the existing worktree
+	out.Reset()
+	require.NoError(t, startRun(context.Background(), opts))
+	assert.Contains(t, out.String(), "Switched to branch task/proj-1")
+}
+
+func TestStartRun_NotGitRepository(t *testing.T) {
+	opts, _, _, _ := newTestOptions(t)
+	dir := t.TempDir()
+	opts.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt(dir), nil }
+
+	err := startRun(context.Background(), opts)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, "workspace is not a Git repository", zenErr.Message)
+}
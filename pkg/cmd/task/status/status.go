@@ -0,0 +1,302 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+
+	"github.com/MakeNowJust/heredoc"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/pullrequest"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager loads tasks and updates their status and linked pull requests
+type TaskManager interface {
+	GetTask(ctx context.Context, taskID string) (*task.Task, error)
+	UpdateTask(ctx context.Context, taskID string, updates *task.TaskUpdates) (*task.Task, error)
+	LinkPullRequest(ctx context.Context, taskID string, source *task.TaskSource) error
+}
+
+// StatusOptions contains options for the task status command
+type StatusOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskManager, error)
+	Provider         func(remoteURL string) (pullrequest.Provider, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	TaskID  string
+	Set     string // Status the task moves to
+	Offline bool
+	NoCache bool
+}
+
+// TaskStatus is the status reported for a task
+type TaskStatus struct {
+	ID           string                     `json:"id"`
+	Title        string                     `json:"title"`
+	Type         string                     `json:"type"`
+	Status       string                     `json:"status"`
+	Stage        string                     `json:"stage"`
+	Progress     int                        `json:"progress"`
+	Sources      map[string]string          `json:"sources"`
+	Git          *task.GitInfo              `json:"git,omitempty"`
+	PullRequests []*pullrequest.PullRequest `json:"pull_requests"`
+}
+
+// NewCmdTaskStatus creates the task status command
+func NewCmdTaskStatus(f *cmdutil.Factory, runF func(*StatusOptions) error) *cobra.Command {
+	opts := &StatusOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+		Provider: func(remoteURL string) (pullrequest.Provider, error) {
+			authManager, err := f.AuthManager()
+			if err != nil {
+				return nil, err
+			}
+			var responses *zenhttp.ResponseCache
+			if f.ResponseCache != nil {
+				if responses, err = f.ResponseCache(); err != nil {
+					f.Logger.Debug("response cache unavailable", "error", err)
+				}
+			}
+			return pullrequest.ForRemote(remoteURL, authManager, responses, f.Logger)
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "status <task-id>",
+		Short: "Show a task's stage, branch and pull request reviews",
+		Long: heredoc.Doc(`
+			Show where a task stands: its workflow stage, external sources, the branch
+			recorded by 'zen task start' and the pull requests opened by 'zen pr create'.
+
+			The state and review status of each pull request is refreshed from GitHub
+			or GitLab and saved with the task. Use --offline to show the last saved
+			status instead.
+
+			Responses from GitHub and GitLab are cached for a minute, so repeated
+			commands do not use up API rate limits. Use --no-cache to fetch the
+			current status regardless.
+
+			Use --set to move the task to another status: proposed, in_progress,
+			in_review, blocked, completed or cancelled. When tasks are tracked
+			locally, with integrations.task_system set to none, tasks follow the
+			workflow: they are started before they are reviewed or completed, and
+			completed tasks can only be reopened. Otherwise the tracker of record
+			enforces its own workflow when the task is synced.
+		`),
+		Example: heredoc.Doc(`
+			# Show the status of a task
+			zen task status PROJ-123
+
+			# Show the saved status without contacting GitHub or GitLab
+			zen task status PROJ-123 --offline
+
+			# Fetch the status again, even if it was fetched a moment ago
+			zen task status PROJ-123 --no-cache
+
+			# Print the review status of each pull request
+			zen task status PROJ-123 --jq '.pull_requests[] | .review_status'
+
+			# Move a task to review
+			zen task status PROJ-123 --set in_review
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return statusRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Offline, "offline", false, "Show the saved pull request status without refreshing it")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "Fetch pull request status without using cached API responses")
+	cmd.Flags().StringVar(&opts.Set, "set", "", "Move the task to a status (proposed|in_progress|in_review|blocked|completed|cancelled)")
+	cmd.MarkFlagsMutuallyExclusive("offline", "no-cache")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func statusRun(ctx context.Context, opts *StatusOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.NoCache {
+		ctx = zenhttp.WithoutCache(ctx)
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	var t *task.Task
+	if opts.Set != "" {
+		t, err = manager.UpdateTask(ctx, opts.TaskID, &task.TaskUpdates{Status: &opts.Set})
+	} else {
+		t, err = manager.GetTask(ctx, opts.TaskID)
+	}
+	if err != nil {
+		return err
+	}
+
+	result := &TaskStatus{
+		ID:           t.ID,
+		Title:        t.Title,
+		Type:         t.Type,
+		Status:       t.Status,
+		Stage:        t.CurrentStage,
+		Progress:     t.Progress,
+		Sources:      make(map[string]string),
+		Git:          t.Git,
+		PullRequests: []*pullrequest.PullRequest{},
+	}
+	for name, source := range t.Sources {
+		result.Sources[name] = source.ExternalID
+	}
+
+	for _, source := range t.PullRequests {
+		pr, ok := pullrequest.FromTaskSource(source)
+		if !ok {
+			continue
+		}
+		if !opts.Offline {
+			if updated, err := refresh(ctx, opts, pr); err != nil {
+				fmt.Fprintf(opts.IO.ErrOut, "%s Could not refresh %s: %v\n", opts.IO.WarningIcon(), pr.Reference(), err)
+			} else {
+				pr = updated
+				if err := manager.LinkPullRequest(ctx, t.ID, pr.TaskSource()); err != nil {
+					fmt.Fprintf(opts.IO.ErrOut, "%s Could not save status of %s: %v\n", opts.IO.WarningIcon(), pr.Reference(), err)
+				}
+			}
+		}
+		result.PullRequests = append(result.PullRequests, pr)
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(result, func(w io.Writer) error {
+		return displayStatusText(w, opts.IO, result)
+	})
+}
+
+// refresh fetches the current state of a pull request from its provider
+func refresh(ctx context.Context, opts *StatusOptions, pr *pullrequest.PullRequest) (*pullrequest.PullRequest, error) {
+	u, err := url.Parse(pr.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid pull request URL %q", pr.URL)
+	}
+
+	provider, err := opts.Provider(fmt.Sprintf("https://%s/%s", u.Host, pr.Repository))
+	if err != nil {
+		return nil, err
+	}
+	return provider.Get(ctx, pr.Number)
+}
+
+func displayStatusText(w io.Writer, streams *iostreams.IOStreams, s *TaskStatus) error {
+	title := s.ID
+	if s.Title != "" {
+		title = fmt.Sprintf("%s: %s", s.ID, s.Title)
+	}
+	fmt.Fprintln(w, streams.ColorBold(title))
+	fmt.Fprintln(w)
+
+	if idx := task.StageIndex(s.Stage); idx >= 0 {
+		fmt.Fprintf(w, "Stage:    %s (%d/%d)\n", task.StageName(s.Stage), idx+1, len(task.WorkflowStages))
+	} else if s.Stage != "" {
+		fmt.Fprintf(w, "Stage:    %s\n", s.Stage)
+	}
+	if s.Status != "" {
+		fmt.Fprintf(w, "Status:   %s\n", s.Status)
+	}
+
+	if len(s.Sources) > 0 {
+		names := make([]string, 0, len(s.Sources))
+		for name := range s.Sources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			label := "Sources:"
+			if i > 0 {
+				label = ""
+			}
+			fmt.Fprintf(w, "%-9s %s %s\n", label, name, s.Sources[name])
+		}
+	}
+
+	if s.Git != nil {
+		branch := s.Git.Branch
+		if s.Git.Base != "" {
+			branch = fmt.Sprintf("%s (from %s)", branch, s.Git.Base)
+		}
+		fmt.Fprintf(w, "Branch:   %s\n", branch)
+		if s.Git.Worktree != "" {
+			fmt.Fprintf(w, "Worktree: %s\n", s.Git.Worktree)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if len(s.PullRequests) == 0 {
+		fmt.Fprintln(w, "No pull requests.")
+		if streams.IsStdoutTTY() && s.Git != nil {
+			fmt.Fprintln(w, streams.ColorNeutral("Open one with 'zen pr create "+s.ID+"'"))
+		}
+		return nil
+	}
+
+	headers := []string{"PULL REQUEST", "STATE", "REVIEW", "URL"}
+	rows := make([][]string, 0, len(s.PullRequests))
+	for _, pr := range s.PullRequests {
+		state := pr.State
+		if pr.Draft && state == pullrequest.StateOpen {
+			state = "draft"
+		}
+		rows = append(rows, []string{pr.Reference(), state, reviewText(streams, pr.ReviewStatus), pr.URL})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
+
+func reviewText(streams *iostreams.IOStreams, status string) string {
+	switch status {
+	case pullrequest.ReviewApproved:
+		return streams.ColorSuccess("approved")
+	case pullrequest.ReviewChangesRequested:
+		return streams.ColorError("changes requested")
+	case pullrequest.ReviewPending:
+		return streams.ColorWarning("review required")
+	default:
+		return status
+	}
+}
@@ -0,0 +1,175 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/pullrequest"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	task   *task.Task
+	linked []*task.TaskSource
+}
+
+func (m *mockTaskManager) GetTask(ctx context.Context, taskID string) (*task.Task, error) {
+	return m.task, nil
+}
+
+func (m *mockTaskManager) UpdateTask(ctx context.Context, taskID string, updates *task.TaskUpdates) (*task.Task, error) {
+	if err := task.CheckStatusTransition(m.task.Status, *updates.Status); err != nil {
+		return nil, err
+	}
+	m.task.Status = *updates.Status
+	return m.task, nil
+}
+
+func (m *mockTaskManager) LinkPullRequest(ctx context.Context, taskID string, source *task.TaskSource) error {
+	m.linked = append(m.linked, source)
+	return nil
+}
+
+type mockProvider struct {
+	pr      *pullrequest.PullRequest
+	err     error
+	noCache bool
+}
+
+func (p *mockProvider) Name() string { return pullrequest.ProviderGitHub }
+
+func (p *mockProvider) Create(ctx context.Context, req *pullrequest.CreateRequest) (*pullrequest.PullRequest, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *mockProvider) Get(ctx context.Context, number int) (*pullrequest.PullRequest, error) {
+	p.noCache = zenhttp.CacheBypassed(ctx)
+	return p.pr, p.err
+}
+
+func newTestOptions(t *testing.T) (*StatusOptions, *mockTaskManager, *mockProvider, *[]string) {
+	t.Helper()
+
+	saved := &pullrequest.PullRequest{
+		Provider:     pullrequest.ProviderGitHub,
+		Repository:   "acme/app",
+		Number:       42,
+		URL:          "https://github.com/acme/app/pull/42",
+		State:        pullrequest.StateOpen,
+		ReviewStatus: pullrequest.ReviewPending,
+		Head:         "feat/PROJ-1-login",
+		Base:         "main",
+	}
+	manager := &mockTaskManager{task: &task.Task{
+		ID:           "PROJ-1",
+		Title:        "Login",
+		Status:       "in_progress",
+		CurrentStage: "05-build",
+		Sources:      map[string]*task.TaskSource{"jira": {System: "jira", ExternalID: "PROJ-1"}},
+		Git:          &task.GitInfo{Branch: "feat/PROJ-1-login", Base: "main"},
+		PullRequests: []*task.TaskSource{saved.TaskSource()},
+	}}
+
+	updated := *saved
+	updated.ReviewStatus = pullrequest.ReviewApproved
+	fake := &mockProvider{pr: &updated}
+
+	var remotes []string
+	return &StatusOptions{
+		IO:               iostreams.Test(),
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/work"), nil },
+		TaskManager:      func() (TaskManager, error) { return manager, nil },
+		Provider: func(remoteURL string) (pullrequest.Provider, error) {
+			remotes = append(remotes, remoteURL)
+			return fake, nil
+		},
+		TaskID: "PROJ-1",
+	}, manager, fake, &remotes
+}
+
+func TestStatusRun_RefreshesPullRequests(t *testing.T) {
+	opts, manager, _, remotes := newTestOptions(t)
+
+	require.NoError(t, statusRun(context.Background(), opts))
+
+	assert.Equal(t, []string{"https://github.com/acme/app"}, *remotes)
+	require.Len(t, manager.linked, 1)
+	assert.Equal(t, "approved", manager.linked[0].Metadata["review_status"])
+
+	out := opts.IO.Out.(*bytes.Buffer).String()
+	assert.Contains(t, out, "PROJ-1: Login")
+	assert.Contains(t, out, "Stage:    Build (5/7)")
+	assert.Contains(t, out, "Sources:  jira PROJ-1")
+	assert.Contains(t, out, "Branch:   feat/PROJ-1-login (from main)")
+	assert.Contains(t, out, "acme/app#42\topen\tapproved\thttps://github.com/acme/app/pull/42")
+}
+
+func TestStatusRun_Offline(t *testing.T) {
+	opts, manager, _, remotes := newTestOptions(t)
+	opts.Offline = true
+
+	require.NoError(t, statusRun(context.Background(), opts))
+
+	assert.Empty(t, *remotes)
+	assert.Empty(t, manager.linked)
+	assert.Contains(t, opts.IO.Out.(*bytes.Buffer).String(), "acme/app#42\topen\treview required")
+}
+
+func TestStatusRun_NoCache(t *testing.T) {
+	opts, _, fake, _ := newTestOptions(t)
+
+	require.NoError(t, statusRun(context.Background(), opts))
+	assert.False(t, fake.noCache)
+
+	opts.NoCache = true
+	require.NoError(t, statusRun(context.Background(), opts))
+	assert.True(t, fake.noCache, "--no-cache bypasses cached API responses")
+}
+
+func TestStatusRun_RefreshFailure(t *testing.T) {
+	opts, manager, fake, _ := newTestOptions(t)
+	fake.err = errors.New("bad credentials")
+
+	require.NoError(t, statusRun(context.Background(), opts))
+
+	assert.Empty(t, manager.linked)
+	assert.Contains(t, opts.IO.ErrOut.(*bytes.Buffer).String(), "Could not refresh acme/app#42: bad credentials")
+	assert.Contains(t, opts.IO.Out.(*bytes.Buffer).String(), "review required")
+}
+
+func TestStatusRun_JSON(t *testing.T) {
+	opts, _, _, _ := newTestOptions(t)
+	opts.OutputFormat = "json"
+
+	require.NoError(t, statusRun(context.Background(), opts))
+
+	var got TaskStatus
+	require.NoError(t, json.Unmarshal(opts.IO.Out.(*bytes.Buffer).Bytes(), &got))
+	assert.Equal(t, "PROJ-1", got.ID)
+	assert.Equal(t, "05-build", got.Stage)
+	assert.Equal(t, map[string]string{"jira": "PROJ-1"}, got.Sources)
+	require.Len(t, got.PullRequests, 1)
+	assert.Equal(t, pullrequest.ReviewApproved, got.PullRequests[0].ReviewStatus)
+}
+
+func TestStatusRun_Set(t *testing.T) {
+	opts, manager, _, _ := newTestOptions(t)
+	opts.Offline = true
+	opts.Set = task.StatusInReview
+
+	require.NoError(t, statusRun(context.Background(), opts))
+	assert.Equal(t, task.StatusInReview, manager.task.Status)
+	assert.Contains(t, opts.IO.Out.(*bytes.Buffer).String(), "Status:   in_review")
+
+	opts.Set = task.StatusProposed
+	assert.ErrorContains(t, statusRun(context.Background(), opts), "cannot move from in_review to proposed")
+}
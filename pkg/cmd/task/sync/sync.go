@@ -3,9 +3,16 @@ package sync
 import (
 	"context"
 	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/integration"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmd/task/sync/undo"
 	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/hooks"
 	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/daddia/zen/pkg/task"
 	"github.com/spf13/cobra"
@@ -13,8 +20,10 @@ import (
 
 // SyncOptions contains options for the task sync command
 type SyncOptions struct {
-	IO      *iostreams.IOStreams
-	Factory *cmdutil.Factory
+	IO         *iostreams.IOStreams
+	Factory    *cmdutil.Factory
+	HookRunner func() (*hooks.Runner, error)
+	TaskConfig func() (task.Config, error)
 
 	Direction        string   // pull, push, bidirectional
 	ConflictStrategy string   // local_wins, remote_wins, manual_review, timestamp
@@ -22,14 +31,22 @@ type SyncOptions struct {
 	DryRun           bool
 	Force            bool
 	All              bool // Sync all tasks
+	Concurrency      int  // Tasks synced at once with each source by --all
+	Plan             bool // Report how conflicting fields would be resolved
+	Explain          bool // Print the decision trail of the sync
+	FieldPolicies    map[string]string
+	OutputFormat     string
+	FailOn           cmdutil.FailOn // Outcomes that make the command fail
 }
 
 // NewCmdTaskSync creates the task sync command
 func NewCmdTaskSync(f *cmdutil.Factory) *cobra.Command {
 	opts := &SyncOptions{
-		IO:      f.IOStreams,
-		Factory: f,
-		DryRun:  f.DryRun,
+		IO:         f.IOStreams,
+		Factory:    f,
+		HookRunner: f.HookRunner,
+		TaskConfig: internal.TaskConfig(f),
+		DryRun:     f.DryRun,
 	}
 
 	cmd := &cobra.Command{
@@ -46,13 +63,59 @@ This command can:
 Sync directions:
 - pull: Fetch latest data from external sources
 - push: Send local changes to external sources  
-- bidirectional: Two-way sync with conflict resolution
+- bidirectional: Two-way sync with conflict resolution. Each side is compared
+  with the task as of its last sync, so a field changed on only one side is
+  taken from that side, and labels added or removed on either side are merged.
+  Only fields changed on both sides are conflicts.
 
 Conflict strategies:
 - local_wins: Keep local changes, discard remote
 - remote_wins: Accept remote changes, discard local
 - timestamp: Use most recent timestamp
-- manual_review: Create conflict records for manual resolution`,
+- manual_review: Create conflict records for manual resolution
+
+Individual fields can be given their own strategy with --field-policy, or with
+task.field_policies in the configuration. The union policy keeps the values
+from both sides of a list field such as labels. --plan reports how each conflicting
+field would be resolved, without changing anything.
+
+With --dry-run, every field change the sync would make to the task (local)
+and to the source (remote) is reported, in text or with --output json, and
+nothing is changed.
+
+--explain prints the decision trail of the sync of a task: the source it was
+synced with, how each field compared with its value at the last sync, the
+owner and label mappings applied, the conflict policy that settled each field
+changed on both sides and which timestamp won, and the values written to
+either side. With --output json, the trail is the decisions of the result.
+
+The command exits with 1 when every sync failed, and with 5 when every sync
+was held for manual conflict review. --fail-on makes CI pipelines fail on
+less: partial exits with 6 when only some syncs succeeded, and warning also
+exits with 7 when conflicts were resolved by a policy.
+
+Commands writing the same task take turns, and a sync fails with "task modified
+concurrently" when another command, such as a sync in another terminal, changed
+the task while it was syncing. Run the sync again to keep those changes, or use
+--force to overwrite them.
+
+When tasks are tracked locally, with integrations.task_system set to none, there
+is no external source to sync with, and the command reports so and exits with 0.
+
+Before a pull or bidirectional sync changes a task, a snapshot of it is taken;
+'zen task sync undo <task-id>' restores the task to its state before the sync.
+
+A push or bidirectional sync of a task with local changes is recorded in the
+outbox of the task before it is attempted, and removed once it succeeds. When
+it fails, 'zen watch' retries it with increasing delays, and the next sync of
+the task retries it at once; 'zen task outbox list' shows the pushes waiting.
+
+Each request to a source times out after network.timeout (30s by default), or its
+entry in network.provider_timeouts; --timeout overrides both for the command.
+
+A sync cancelled with Ctrl-C reports the tasks it did not sync and exits with 2.
+Running 'zen task sync --all' again with the same direction and sources resumes
+it with those tasks.`,
 		Example: heredoc.Doc(`
 			# Sync specific task with external sources
 			zen task sync ZEN-123
@@ -69,11 +132,29 @@ Conflict strategies:
 			# Sync all tasks in workspace
 			zen task sync --all
 
+			# Sync all tasks, at most 4 at a time with each source
+			zen task sync --all --concurrency 4
+
 			# Sync only with specific sources
 			zen task sync ZEN-123 --sources jira,github
 
 			# Dry run to see what would be synced
 			zen task sync ZEN-123 --dry-run
+
+			# Preview the changes syncing all tasks would make, as JSON
+			zen task sync --all --dry-run --output json
+
+			# Fail a CI job unless every task synced cleanly
+			zen task sync --all --fail-on warning
+
+			# Allow slow sources a minute for each request
+			zen task sync --all --timeout 1m
+
+			# Explain why the sync of a task changed or kept each field
+			zen task sync ZEN-123 --explain
+
+			# Show how conflicting fields would be resolved
+			zen task sync ZEN-123 --plan --field-policy title=remote_wins,labels=union
 		`),
 		Args: func(cmd *cobra.Command, args []string) error {
 			if opts.All && len(args) > 0 {
@@ -85,14 +166,33 @@ Conflict strategies:
 			if !opts.All && len(args) > 1 {
 				return fmt.Errorf("only one task ID allowed")
 			}
+			if opts.Explain && (opts.All || opts.Plan) {
+				return fmt.Errorf("--explain requires a task ID and cannot be used with --all or --plan")
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.DryRun = f.DryRun
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			failOn, err := cmdutil.FailOnFlag(cmd)
+			if err != nil {
+				return err
+			}
+			opts.FailOn = failOn
+			if err := cmdutil.ApplyTimeoutFlag(cmd); err != nil {
+				return err
+			}
+			if cfg, err := f.Config(); err == nil && task.LocalOnly(cfg) {
+				return localOnlyRun(opts)
+			}
+			if opts.Plan {
+				return planRun(cmd.Context(), opts, args)
+			}
 			if opts.All {
-				return syncAllRun(opts)
+				return syncAllRun(cmd.Context(), opts)
 			} else {
 				taskID := args[0]
-				return syncTaskRun(opts, taskID)
+				return syncTaskRun(cmd.Context(), opts, taskID)
 			}
 		},
 	}
@@ -101,15 +201,36 @@ Conflict strategies:
 	cmd.Flags().StringVarP(&opts.Direction, "direction", "d", "bidirectional", "Sync direction (pull|push|bidirectional)")
 	cmd.Flags().StringVar(&opts.ConflictStrategy, "conflict-strategy", "timestamp", "Conflict resolution strategy (local_wins|remote_wins|timestamp|manual_review)")
 	cmd.Flags().StringSliceVar(&opts.Sources, "sources", nil, "Specific sources to sync (comma-separated)")
-	cmd.Flags().BoolVar(&opts.Force, "force", false, "Force sync even if conflicts exist")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Force sync even if conflicts exist or another command changed the task during the sync")
 	cmd.Flags().BoolVar(&opts.All, "all", false, "Sync all tasks in workspace")
+	cmd.Flags().BoolVar(&opts.Plan, "plan", false, "Report how conflicting fields would be resolved without syncing")
+	cmd.Flags().BoolVar(&opts.Explain, "explain", false, "Print the decision trail of the sync")
+	cmd.Flags().StringToStringVar(&opts.FieldPolicies, "field-policy", nil, "Conflict strategy for individual fields, e.g. title=remote_wins,labels=union")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", task.DefaultSyncConcurrency, "Number of tasks to sync at once with each source when using --all")
+	cmdutil.AddFailOnFlag(cmd)
+	cmdutil.AddTimeoutFlag(cmd)
+
+	cmd.AddCommand(undo.NewCmdTaskSyncUndo(f, nil))
 
 	return cmd
 }
 
+// localOnlyRun reports that there is nothing to sync in a workspace that tracks tasks
+// locally, with no sync results
+func localOnlyRun(opts *SyncOptions) error {
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	return renderer.Render([]*task.SyncResult{}, func(w io.Writer) error {
+		fmt.Fprintf(w, "%s Tasks are tracked locally (integrations.task_system is none), so there is nothing to sync\n",
+			opts.IO.InfoIcon())
+		return nil
+	})
+}
+
 // syncTaskRun executes task synchronization for a specific task
-func syncTaskRun(opts *SyncOptions, taskID string) error {
-	ctx := context.Background()
+func syncTaskRun(ctx context.Context, opts *SyncOptions, taskID string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	// Create task manager
 	taskManager := task.NewManager(opts.Factory)
@@ -126,44 +247,73 @@ func syncTaskRun(opts *SyncOptions, taskID string) error {
 		return fmt.Errorf("invalid conflict strategy: %w", err)
 	}
 
-	if opts.DryRun {
-		fmt.Fprintf(opts.IO.Out, "%s Sync plan for task %s:\n",
-			opts.IO.FormatSuccess(""),
-			opts.IO.ColorBold(taskID))
-		fmt.Fprintf(opts.IO.Out, "  %s Direction: %s\n",
-			opts.IO.ColorNeutral("→"), opts.Direction)
-		fmt.Fprintf(opts.IO.Out, "  %s Conflict Strategy: %s\n",
-			opts.IO.ColorNeutral("→"), opts.ConflictStrategy)
-		if len(opts.Sources) > 0 {
-			fmt.Fprintf(opts.IO.Out, "  %s Sources: %v\n",
-				opts.IO.ColorNeutral("→"), opts.Sources)
-		}
-		fmt.Fprintf(opts.IO.Out, "\n%s Run without --dry-run to execute sync\n",
-			opts.IO.ColorInfo("ℹ"))
-		return nil
+	fieldPolicies, err := resolveFieldPolicies(opts)
+	if err != nil {
+		return err
 	}
 
 	// Execute sync
 	syncOpts := &task.SyncOptions{
 		Direction:        direction,
 		ConflictStrategy: conflictStrategy,
+		FieldPolicies:    fieldPolicies,
 		DryRun:           opts.DryRun,
 		Force:            opts.Force,
 		Sources:          opts.Sources,
+		Explain:          opts.Explain,
 	}
 
-	fmt.Fprintf(opts.IO.Out, "%s Syncing task %s with external sources...\n",
-		opts.IO.ColorInfo("ℹ"), taskID)
+	if opts.DryRun {
+		result, err := taskManager.SyncTask(ctx, taskID, syncOpts)
+		if err != nil {
+			printDecisions(opts.IO, opts.IO.Out, result)
+			return fmt.Errorf("sync preview failed: %w", err)
+		}
+		renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+		return renderer.Render(result, func(w io.Writer) error {
+			printPreview(opts.IO, w, result)
+			printDecisions(opts.IO, w, result)
+			return nil
+		})
+	}
+
+	hookData := map[string]interface{}{"target": "tasks", "tasks": []string{taskID}}
+	if err := hooks.Trigger(ctx, opts.HookRunner, &hooks.Payload{Event: hooks.EventPreSync, Data: hookData}); err != nil {
+		return err
+	}
 
-	result, err := taskManager.SyncTask(ctx, taskID, syncOpts)
+	fmt.Fprintf(opts.IO.Out, "%s Syncing task %s with external sources...\n",
+		opts.IO.InfoIcon(), taskID)
+
+	op := &cmdutil.LongOperation{Name: "task sync", IO: opts.IO}
+	op.Add(taskID)
+	var result *task.SyncResult
+	err = op.Run(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = taskManager.SyncTask(ctx, taskID, syncOpts)
+		if err == nil {
+			op.Complete(taskID)
+		}
+		return err
+	})
+	if cmdutil.IsUserCancellation(err) {
+		printQueued(opts.IO, result)
+		return err
+	}
 	if err != nil {
+		printDecisions(opts.IO, opts.IO.Out, result)
+		if task.IsConcurrentModification(err) {
+			fmt.Fprintf(opts.IO.ErrOut, "%s Run the sync again to keep the other changes, or use --force to overwrite them\n",
+				opts.IO.WarningIcon())
+		}
+		printQueued(opts.IO, result)
 		return fmt.Errorf("sync failed: %w", err)
 	}
 
 	// Display results
 	if result.Success {
 		fmt.Fprintf(opts.IO.Out, "%s Sync completed successfully\n",
-			opts.IO.FormatSuccess("✓"))
+			opts.IO.SuccessIcon())
 		fmt.Fprintf(opts.IO.Out, "  %s Task: %s\n",
 			opts.IO.ColorNeutral("→"), result.TaskID)
 		fmt.Fprintf(opts.IO.Out, "  %s Source: %s\n",
@@ -174,19 +324,40 @@ func syncTaskRun(opts *SyncOptions, taskID string) error {
 			fmt.Fprintf(opts.IO.Out, "  %s Changed fields: %v\n",
 				opts.IO.ColorNeutral("→"), result.ChangedFields)
 		}
+		printConflicts(opts, result.Conflicts)
 		fmt.Fprintf(opts.IO.Out, "  %s Duration: %v\n",
 			opts.IO.ColorNeutral("→"), result.Duration)
+		if result.UndoID != "" {
+			fmt.Fprintf(opts.IO.Out, "  %s Undo: zen task sync undo %s\n",
+				opts.IO.ColorNeutral("→"), result.TaskID)
+		}
 	} else {
 		fmt.Fprintf(opts.IO.Out, "%s Sync failed: %s\n",
-			opts.IO.FormatError("✗"), result.Error)
+			opts.IO.FailureIcon(), result.Error)
+		printConflicts(opts, result.Conflicts)
+		annotateFailures(opts.IO, []*task.SyncResult{result})
+		printQueued(opts.IO, result)
 	}
+	printDecisions(opts.IO, opts.IO.Out, result)
 
+	if err := hooks.Trigger(ctx, opts.HookRunner, &hooks.Payload{
+		Event: hooks.EventPostSync,
+		Data:  map[string]interface{}{"target": "tasks", "results": []*task.SyncResult{result}},
+	}); err != nil {
+		return err
+	}
+
+	if err := syncOutcome([]*task.SyncResult{result}).Err(opts.FailOn, "tasks"); err != nil {
+		return fmt.Errorf("task sync: %w", err)
+	}
 	return nil
 }
 
 // syncAllRun executes synchronization for all tasks
-func syncAllRun(opts *SyncOptions) error {
-	ctx := context.Background()
+func syncAllRun(ctx context.Context, opts *SyncOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	// Create task manager
 	taskManager := task.NewManager(opts.Factory)
@@ -203,60 +374,404 @@ func syncAllRun(opts *SyncOptions) error {
 		return fmt.Errorf("invalid conflict strategy: %w", err)
 	}
 
-	if opts.DryRun {
-		fmt.Fprintf(opts.IO.Out, "%s Sync plan for all tasks:\n",
-			opts.IO.FormatSuccess(""))
-		fmt.Fprintf(opts.IO.Out, "  %s Direction: %s\n",
-			opts.IO.ColorNeutral("→"), opts.Direction)
-		fmt.Fprintf(opts.IO.Out, "  %s Conflict Strategy: %s\n",
-			opts.IO.ColorNeutral("→"), opts.ConflictStrategy)
-		fmt.Fprintf(opts.IO.Out, "\n%s Run without --dry-run to execute sync\n",
-			opts.IO.ColorInfo("ℹ"))
-		return nil
+	if opts.Concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d, must be at least 1", opts.Concurrency)
+	}
+
+	fieldPolicies, err := resolveFieldPolicies(opts)
+	if err != nil {
+		return err
 	}
 
 	// Execute sync for all tasks
 	syncOpts := &task.SyncOptions{
 		Direction:        direction,
 		ConflictStrategy: conflictStrategy,
+		FieldPolicies:    fieldPolicies,
 		DryRun:           opts.DryRun,
 		Force:            opts.Force,
 		Sources:          opts.Sources,
+		Concurrency:      opts.Concurrency,
 	}
 
-	fmt.Fprintf(opts.IO.Out, "%s Syncing all tasks with external sources...\n",
-		opts.IO.ColorInfo("ℹ"))
+	if opts.DryRun {
+		results, err := taskManager.SyncAllTasks(ctx, syncOpts)
+		if err != nil {
+			return fmt.Errorf("sync preview failed: %w", err)
+		}
+		renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+		return renderer.Render(results, func(w io.Writer) error {
+			if len(results) == 0 {
+				fmt.Fprintf(w, "%s No tasks with external sources\n", opts.IO.ColorNeutral("→"))
+			}
+			for i, result := range results {
+				if i > 0 {
+					fmt.Fprintln(w)
+				}
+				printPreview(opts.IO, w, result)
+			}
+			return nil
+		})
+	}
 
-	results, err := taskManager.SyncAllTasks(ctx, syncOpts)
-	if err != nil {
-		return fmt.Errorf("sync all failed: %w", err)
+	if err := hooks.Trigger(ctx, opts.HookRunner, &hooks.Payload{
+		Event: hooks.EventPreSync,
+		Data:  map[string]interface{}{"target": "tasks", "all": true},
+	}); err != nil {
+		return err
 	}
 
-	// Display results
-	successful := 0
-	failed := 0
-	for _, result := range results {
-		if result.Success {
-			successful++
-		} else {
-			failed++
+	// A sync of all tasks that was cancelled is resumed with the tasks it did not sync,
+	// when run again with the same direction and sources
+	op := &cmdutil.LongOperation{
+		Name: "task sync",
+		IO:   opts.IO,
+		Key:  fmt.Sprintf("%s %s", direction, strings.Join(opts.Sources, ",")),
+	}
+	if root, err := internal.WorkspaceRoot(opts.Factory.WorkspaceManager); err == nil {
+		op.CheckpointPath = cmdutil.CheckpointPath(filepath.Join(root, ".zen"), "task-sync")
+	}
+	if checkpoint := op.Resume(); checkpoint != nil && len(checkpoint.Pending) > 0 {
+		syncOpts.TaskIDs = checkpoint.Pending
+		fmt.Fprintf(opts.IO.Out, "%s Resuming the sync cancelled at %s: %d tasks left\n",
+			opts.IO.InfoIcon(), checkpoint.Cancelled.Local().Format("2006-01-02 15:04:05"), len(checkpoint.Pending))
+	} else {
+		fmt.Fprintf(opts.IO.Out, "%s Syncing all tasks with external sources...\n",
+			opts.IO.InfoIcon())
+	}
+
+	var results []*task.SyncResult
+	err = op.Run(ctx, func(ctx context.Context) error {
+		bar := opts.IO.StartProgressBar("tasks synced", 0)
+		syncOpts.OnProgress = func(completed, total int, result *task.SyncResult) {
+			bar.SetTotal(int64(total))
+			bar.Set(int64(completed))
+		}
+
+		var err error
+		results, err = taskManager.SyncAllTasks(ctx, syncOpts)
+		bar.Done(err)
+		bar.Stop()
+		for _, result := range results {
+			op.Add(result.TaskID)
+			if !result.Cancelled {
+				op.Complete(result.TaskID)
+			}
+		}
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("sync all failed: %w", err)
 		}
+
+		// The tasks synced before a cancellation are reported too
+		printSummary(opts, results)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := hooks.Trigger(ctx, opts.HookRunner, &hooks.Payload{
+		Event: hooks.EventPostSync,
+		Data:  map[string]interface{}{"target": "tasks", "results": results},
+	}); err != nil {
+		return err
 	}
 
-	fmt.Fprintf(opts.IO.Out, "%s Sync completed\n",
-		opts.IO.FormatSuccess("✓"))
+	if err := syncOutcome(results).Err(opts.FailOn, "tasks"); err != nil {
+		return fmt.Errorf("task sync: %w", err)
+	}
+	return nil
+}
+
+// printSummary reports how the syncs of all tasks ended
+func printSummary(opts *SyncOptions, results []*task.SyncResult) {
+	outcome := syncOutcome(results)
+	cancelled := countCancelled(results)
+
+	if cancelled > 0 {
+		fmt.Fprintf(opts.IO.Out, "%s Sync cancelled\n",
+			opts.IO.WarningIcon())
+	} else {
+		fmt.Fprintf(opts.IO.Out, "%s Sync completed\n",
+			opts.IO.SuccessIcon())
+	}
 	fmt.Fprintf(opts.IO.Out, "  %s Total tasks: %d\n",
 		opts.IO.ColorNeutral("→"), len(results))
 	fmt.Fprintf(opts.IO.Out, "  %s Successful: %d\n",
-		opts.IO.ColorNeutral("→"), successful)
-	if failed > 0 {
+		opts.IO.ColorNeutral("→"), outcome.Succeeded+outcome.Warned)
+	if outcome.Conflicted > 0 {
+		fmt.Fprintf(opts.IO.Out, "  %s Held for conflict review: %d\n",
+			opts.IO.WarningIcon(), outcome.Conflicted)
+	}
+	if outcome.Failed > 0 {
 		fmt.Fprintf(opts.IO.Out, "  %s Failed: %d\n",
-			opts.IO.ColorWarning("!"), failed)
+			opts.IO.WarningIcon(), outcome.Failed)
+	}
+	if queued := countQueued(results); queued > 0 {
+		fmt.Fprintf(opts.IO.Out, "  %s Pushes queued for retry: %d (see 'zen task outbox list')\n",
+			opts.IO.WarningIcon(), queued)
+	}
+	if cancelled > 0 {
+		fmt.Fprintf(opts.IO.Out, "  %s Cancelled: %d\n",
+			opts.IO.WarningIcon(), cancelled)
+	}
+	annotateFailures(opts.IO, results)
+}
+
+// syncOutcome counts how the syncs of tasks ended. A sync that resolved conflicts by
+// policy completed with warnings.
+func syncOutcome(results []*task.SyncResult) cmdutil.Outcome {
+	var outcome cmdutil.Outcome
+	for _, result := range results {
+		switch {
+		case result.Cancelled:
+			continue
+		case result.Success && len(result.Conflicts) > 0:
+			outcome.Warned++
+		case result.Success:
+			outcome.Succeeded++
+		case heldForReview(result):
+			outcome.Conflicted++
+		default:
+			outcome.Failed++
+		}
+	}
+	return outcome
+}
+
+// annotateFailures adds a CI log annotation for each task that did not sync
+func annotateFailures(streams *iostreams.IOStreams, results []*task.SyncResult) {
+	for _, result := range results {
+		if !result.Success && !result.Cancelled {
+			streams.Annotate(iostreams.AnnotationWarning,
+				fmt.Sprintf("zen task sync: %s (%s): %s", result.TaskID, result.Source, result.Error))
+		}
+	}
+}
+
+// printQueued tells that the push of a failed sync waits in the outbox of the task
+func printQueued(streams *iostreams.IOStreams, result *task.SyncResult) {
+	if result == nil || !result.Queued {
+		return
+	}
+	fmt.Fprintf(streams.ErrOut, "%s The push of %s to %s is queued; 'zen watch' and the next sync retry it (see 'zen task outbox list')\n",
+		streams.WarningIcon(), result.TaskID, result.Source)
+}
+
+// countQueued counts the failed syncs whose push waits in the outbox of their task
+func countQueued(results []*task.SyncResult) int {
+	queued := 0
+	for _, result := range results {
+		if result.Queued {
+			queued++
+		}
+	}
+	return queued
+}
+
+// countCancelled counts the tasks a cancelled sync of all tasks did not sync
+func countCancelled(results []*task.SyncResult) int {
+	cancelled := 0
+	for _, result := range results {
+		if result.Cancelled {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// heldForReview reports whether a sync was held because conflicts need manual review
+func heldForReview(result *task.SyncResult) bool {
+	for _, conflict := range result.Conflicts {
+		if conflict.Resolution == integration.ResolutionManual {
+			return true
+		}
+	}
+	return false
+}
+
+// printPreview writes the field changes a dry run found as a table
+func printPreview(streams *iostreams.IOStreams, w io.Writer, result *task.SyncResult) {
+	fmt.Fprintf(w, "Dry run: sync of %s with %s (%s)\n",
+		streams.ColorBold(result.TaskID), result.Source, result.Direction)
+
+	if result.Error != "" {
+		fmt.Fprintf(w, "  %s %s\n", streams.FailureIcon(), result.Error)
+	} else if len(result.Changes) == 0 {
+		fmt.Fprintf(w, "  %s Nothing would change\n", streams.ColorNeutral("→"))
+	} else {
+		headers := []string{"SIDE", "FIELD", "FROM", "TO"}
+		rows := make([][]string, 0, len(result.Changes))
+		for _, change := range result.Changes {
+			rows = append(rows, []string{
+				change.Side,
+				change.Field,
+				formatValue(change.From),
+				formatValue(change.To),
+			})
+		}
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	}
+
+	for _, conflict := range result.Conflicts {
+		fmt.Fprintf(w, "  %s Conflict: %s (%s, %s)\n",
+			streams.WarningIcon(), conflict.Field, conflict.Strategy, resolutionLabel(conflict))
+	}
+}
+
+// printDecisions prints the decision trail of a sync, when it was explained
+func printDecisions(streams *iostreams.IOStreams, w io.Writer, result *task.SyncResult) {
+	if result == nil || len(result.Decisions) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nDecisions:\n")
+	for _, decision := range result.Decisions {
+		fmt.Fprintf(w, "  %s %s\n", streams.ColorNeutral("→"), decision)
+	}
+}
+
+// printConflicts lists the fields changed on both sides and how each was resolved
+func printConflicts(opts *SyncOptions, conflicts []task.Conflict) {
+	for _, conflict := range conflicts {
+		fmt.Fprintf(opts.IO.Out, "  %s Conflict: %s (%s, %s)\n",
+			opts.IO.WarningIcon(), conflict.Field, conflict.Strategy, resolutionLabel(conflict))
+	}
+}
+
+// planRun reports how syncing would resolve the fields that differ between tasks and
+// their sources
+func planRun(ctx context.Context, opts *SyncOptions, args []string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	direction, err := parseSyncDirection(opts.Direction)
+	if err != nil {
+		return fmt.Errorf("invalid sync direction: %w", err)
+	}
+
+	conflictStrategy, err := parseConflictStrategy(opts.ConflictStrategy)
+	if err != nil {
+		return fmt.Errorf("invalid conflict strategy: %w", err)
+	}
+
+	fieldPolicies, err := resolveFieldPolicies(opts)
+	if err != nil {
+		return err
+	}
+
+	syncOpts := &task.SyncOptions{
+		Direction:        direction,
+		ConflictStrategy: conflictStrategy,
+		FieldPolicies:    fieldPolicies,
+		Sources:          opts.Sources,
+	}
+
+	taskManager := task.NewManager(opts.Factory)
+
+	taskIDs := args
+	if opts.All {
+		tasks, err := taskManager.ListTasks(ctx, &task.TaskFilter{})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		taskIDs = nil
+		for _, t := range tasks {
+			if len(t.Sources) > 0 {
+				taskIDs = append(taskIDs, t.ID)
+			}
+		}
+	}
+
+	for i, taskID := range taskIDs {
+		if i > 0 {
+			fmt.Fprintln(opts.IO.Out)
+		}
+
+		plan, err := taskManager.PlanSync(ctx, taskID, syncOpts)
+		if err != nil {
+			if !opts.All {
+				return fmt.Errorf("sync plan failed: %w", err)
+			}
+			fmt.Fprintf(opts.IO.Out, "%s %s: %v\n", opts.IO.FailureIcon(), taskID, err)
+			continue
+		}
+		printPlan(opts.IO, plan)
 	}
 
 	return nil
 }
 
+// printPlan writes a sync plan as a table of the fields that differ
+func printPlan(io *iostreams.IOStreams, plan *task.SyncPlan) {
+	fmt.Fprintf(io.Out, "Sync plan for %s with %s (%s):\n",
+		io.ColorBold(plan.TaskID), plan.Source, plan.Direction)
+
+	if len(plan.Conflicts) == 0 {
+		fmt.Fprintf(io.Out, "  %s No fields differ\n", io.ColorNeutral("→"))
+		return
+	}
+
+	headers := []string{"FIELD", "LOCAL", "REMOTE", "POLICY", "KEEPS"}
+	rows := make([][]string, 0, len(plan.Conflicts))
+	for _, conflict := range plan.Conflicts {
+		rows = append(rows, []string{
+			conflict.Field,
+			formatValue(conflict.LocalValue),
+			formatValue(conflict.RemoteValue),
+			string(conflict.Strategy),
+			resolutionLabel(conflict),
+		})
+	}
+	fmt.Fprint(io.Out, io.FormatTable(headers, rows))
+}
+
+// resolutionLabel describes which value a resolved conflict keeps
+func resolutionLabel(conflict task.Conflict) string {
+	switch conflict.Resolution {
+	case integration.ResolutionMerged:
+		return "merged: " + formatValue(conflict.Value)
+	case integration.ResolutionManual:
+		return "manual review"
+	default:
+		return conflict.Resolution
+	}
+}
+
+// formatValue renders a field value for the plan table
+func formatValue(value interface{}) string {
+	if list, ok := value.([]string); ok {
+		return strings.Join(list, ", ")
+	}
+	text := []rune(strings.ReplaceAll(fmt.Sprint(value), "\n", " "))
+	if len(text) > 40 {
+		text = append(text[:37], []rune("...")...)
+	}
+	return string(text)
+}
+
+// resolveFieldPolicies combines the configured field policies with --field-policy, which
+// takes precedence
+func resolveFieldPolicies(opts *SyncOptions) (map[string]task.ConflictStrategy, error) {
+	policies := map[string]task.ConflictStrategy{}
+	if opts.TaskConfig != nil {
+		cfg, err := opts.TaskConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read task configuration: %w", err)
+		}
+		for field, strategy := range cfg.SyncSettings().FieldPolicies {
+			policies[field] = strategy
+		}
+	}
+	for field, strategy := range opts.FieldPolicies {
+		policies[strings.ToLower(field)] = task.ConflictStrategy(strategy)
+	}
+	if err := task.ValidateFieldPolicies(policies); err != nil {
+		return nil, &cmdutil.FlagError{Err: fmt.Errorf("invalid field policies: %w", err)}
+	}
+	return policies, nil
+}
+
 // Helper functions
 
 func parseSyncDirection(direction string) (task.SyncDirection, error) {
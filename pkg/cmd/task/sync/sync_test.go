@@ -0,0 +1,79 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/daddia/zen/internal/integration"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncOutcome(t *testing.T) {
+	results := []*task.SyncResult{
+		{TaskID: "PROJ-1", Success: true},
+		{TaskID: "PROJ-2", Success: true, Conflicts: []task.Conflict{{Field: "title", Resolution: integration.ResolutionRemote}}},
+		{TaskID: "PROJ-3", Conflicts: []task.Conflict{{Field: "status", Resolution: integration.ResolutionManual}}},
+		{TaskID: "PROJ-4", Error: "failed to fetch from jira"},
+		{TaskID: "PROJ-5", Error: "cancelled", Cancelled: true},
+	}
+
+	outcome := syncOutcome(results)
+	assert.Equal(t, cmdutil.Outcome{Succeeded: 1, Warned: 1, Conflicted: 1, Failed: 1}, outcome, "cancelled syncs are not failures")
+
+	assert.NoError(t, outcome.Err(cmdutil.FailOnError, "tasks"), "some tasks synced")
+
+	var codeErr *cmdutil.ExitCodeError
+	require.ErrorAs(t, outcome.Err(cmdutil.FailOnPartial, "tasks"), &codeErr)
+	assert.Equal(t, cmdutil.ExitPartial, codeErr.Code)
+
+	held := syncOutcome(results[2:3])
+	require.ErrorAs(t, held.Err(cmdutil.FailOnError, "tasks"), &codeErr)
+	assert.Equal(t, cmdutil.ExitConflict, codeErr.Code)
+}
+
+func TestPrintSummary_Cancelled(t *testing.T) {
+	f := zentest.NewFactory(t).Build()
+	printSummary(&SyncOptions{IO: f.IOStreams}, []*task.SyncResult{
+		{TaskID: "PROJ-1", Success: true},
+		{TaskID: "PROJ-2", Error: "cancelled", Cancelled: true},
+	})
+	assert.Contains(t, f.Stdout(), "Sync cancelled")
+	assert.Contains(t, f.Stdout(), "Successful: 1")
+	assert.Contains(t, f.Stdout(), "Cancelled: 1")
+	assert.NotContains(t, f.Stdout(), "Failed")
+}
+
+func TestSyncLocalOnly(t *testing.T) {
+	f := zentest.NewFactory(t).Build()
+
+	cmd := NewCmdTaskSync(f.Factory)
+	cmd.SetArgs([]string{"--all"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, f.Stdout(), "Tasks are tracked locally (integrations.task_system is none), so there is nothing to sync")
+
+	f = zentest.NewFactory(t).Build()
+	require.NoError(t, localOnlyRun(&SyncOptions{IO: f.IOStreams, OutputFormat: cmdutil.OutputJSON}))
+	assert.JSONEq(t, "[]", f.Stdout())
+}
+
+func TestSyncExplainArgs(t *testing.T) {
+	f := zentest.NewFactory(t).Build()
+
+	cmd := NewCmdTaskSync(f.Factory)
+	cmd.SetArgs([]string{"--all", "--explain"})
+	cmd.SilenceUsage = true
+	assert.EqualError(t, cmd.Execute(), "--explain requires a task ID and cannot be used with --all or --plan")
+}
+
+func TestPrintDecisions(t *testing.T) {
+	f := zentest.NewFactory(t).Build()
+	printDecisions(f.IOStreams, f.IOStreams.Out, &task.SyncResult{Decisions: []task.SyncDecision{
+		{Step: task.DecisionSource, Message: "syncing with jira, the source of the task"},
+		{Step: task.DecisionConflict, Field: "title", Message: "the remote value wins"},
+	}})
+
+	assert.Equal(t, "\nDecisions:\n  → source: syncing with jira, the source of the task\n  → conflict title: the remote value wins\n", f.Stdout())
+}
@@ -0,0 +1,103 @@
+package undo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// SyncUndoer restores tasks to their state before a sync
+type SyncUndoer interface {
+	UndoSync(ctx context.Context, taskID string) (*task.SyncUndoRecord, error)
+}
+
+// UndoOptions contains options for the task sync undo command
+type UndoOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (SyncUndoer, error)
+
+	TaskID string
+}
+
+// NewCmdTaskSyncUndo creates the task sync undo command
+func NewCmdTaskSyncUndo(f *cmdutil.Factory, runF func(*UndoOptions) error) *cobra.Command {
+	opts := &UndoOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (SyncUndoer, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "undo <task-id>",
+		Short: "Restore a task to its state before its last sync",
+		Long: heredoc.Doc(`
+			Restore the local task to the snapshot taken before its last sync changed it,
+			for when a sync overwrote local edits. Undoing again restores the sync before
+			that one.
+
+			Only the local task is restored; the external source is not changed. The sync
+			metadata is kept, so the restored values count as local changes: the next
+			bidirectional sync pushes them to the source, and a pull takes the values of
+			the source again.
+
+			A snapshot is taken whenever a pull or bidirectional sync changes the task.
+			task.sync_undo.keep sets how many are kept for each task (default 10, 0 turns
+			snapshots off), and task.sync_undo.max_age removes older ones (default 720h).
+		`),
+		Example: heredoc.Doc(`
+			# Get back the local edits the last sync of a task overwrote
+			zen task sync undo ZEN-123
+
+			# Go back two syncs
+			zen task sync undo ZEN-123
+			zen task sync undo ZEN-123
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return undoRun(cmd.Context(), opts)
+		},
+	}
+
+	return cmd
+}
+
+func undoRun(ctx context.Context, opts *UndoOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := internal.WorkspaceRoot(opts.WorkspaceManager); err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	record, err := manager.UndoSync(ctx, opts.TaskID)
+	if err != nil {
+		return err
+	}
+
+	out := opts.IO.ErrOut
+	fmt.Fprintf(out, "%s Restored %s to its state before the %s sync with %s at %s\n", opts.IO.SuccessIcon(),
+		record.TaskID, record.Direction, record.Source, record.TakenAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(out, "%s %s is unchanged; run 'zen task sync %s' to push the restored values\n",
+		opts.IO.NeutralIcon(), record.Source, record.TaskID)
+	return nil
+}
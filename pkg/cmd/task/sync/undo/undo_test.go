@@ -0,0 +1,61 @@
+package undo
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct{}
+
+func (m *mockTaskManager) UndoSync(ctx context.Context, taskID string) (*task.SyncUndoRecord, error) {
+	if taskID != "PROJ-1" {
+		return nil, &types.Error{Code: types.ErrorCodeNotFound, Message: "no sync of " + taskID + " to undo"}
+	}
+	return &task.SyncUndoRecord{
+		TaskID:    taskID,
+		Source:    "jira",
+		Direction: task.SyncDirectionPull,
+		TakenAt:   time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local),
+	}, nil
+}
+
+func TestNewCmdTaskSyncUndo(t *testing.T) {
+	var got *UndoOptions
+	cmd := NewCmdTaskSyncUndo(cmdutil.NewTestFactory(iostreams.Test()), func(opts *UndoOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"PROJ-1"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "PROJ-1", got.TaskID)
+}
+
+func TestUndoRun(t *testing.T) {
+	streams := iostreams.Test()
+	opts := &UndoOptions{
+		IO:               streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt("/workspace"), nil },
+		TaskManager:      func() (SyncUndoer, error) { return &mockTaskManager{}, nil },
+		TaskID:           "PROJ-1",
+	}
+
+	require.NoError(t, undoRun(context.Background(), opts))
+	assert.Equal(t, "✓ Restored PROJ-1 to its state before the pull sync with jira at 2025-03-01 12:00:00\n"+
+		"- jira is unchanged; run 'zen task sync PROJ-1' to push the restored values\n", streams.ErrOut.(*bytes.Buffer).String())
+
+	opts.TaskID = "PROJ-2"
+	assert.ErrorContains(t, undoRun(context.Background(), opts), "no sync of PROJ-2 to undo")
+}
@@ -1,8 +1,30 @@
 package task
 
 import (
+	"github.com/daddia/zen/pkg/cmd/task/attach"
+	"github.com/daddia/zen/pkg/cmd/task/checklist"
+	"github.com/daddia/zen/pkg/cmd/task/clone"
 	"github.com/daddia/zen/pkg/cmd/task/create"
+	"github.com/daddia/zen/pkg/cmd/task/delete"
+	"github.com/daddia/zen/pkg/cmd/task/diff"
+	"github.com/daddia/zen/pkg/cmd/task/document"
+	"github.com/daddia/zen/pkg/cmd/task/export"
+	"github.com/daddia/zen/pkg/cmd/task/finish"
+	"github.com/daddia/zen/pkg/cmd/task/gate"
+	"github.com/daddia/zen/pkg/cmd/task/generate"
+	"github.com/daddia/zen/pkg/cmd/task/history"
+	"github.com/daddia/zen/pkg/cmd/task/importcmd"
+	"github.com/daddia/zen/pkg/cmd/task/list"
+	"github.com/daddia/zen/pkg/cmd/task/outbox"
+	"github.com/daddia/zen/pkg/cmd/task/publish"
+	"github.com/daddia/zen/pkg/cmd/task/rename"
+	"github.com/daddia/zen/pkg/cmd/task/report"
+	"github.com/daddia/zen/pkg/cmd/task/start"
+	"github.com/daddia/zen/pkg/cmd/task/status"
+	"github.com/daddia/zen/pkg/cmd/task/trace"
+	"github.com/daddia/zen/pkg/cmd/task/view"
 	"github.com/daddia/zen/pkg/cmdutil"
+	tasks "github.com/daddia/zen/pkg/task"
 	"github.com/spf13/cobra"
 )
 
@@ -17,14 +39,18 @@ Tasks are the core unit of work in Zen, following the seven-stage Zenflow
 workflow: Align → Discover → Prioritize → Design → Build → Ship → Learn.
 
 Each task creates a minimal directory in .zen/tasks/ with:
-- index.md: Human-readable task overview
+- index.md: Human-readable task overview, with the checklist of its type
 - manifest.yaml: Machine-readable metadata and workflow state
 - .taskrc.yaml: Task-specific configuration
 - .zenflow/: Workflow state tracking
 - metadata/: External system snapshots
+- attachments/: Files attached with 'zen task attach'
+- .history/: Revisions of index.md and manifest.yaml, listed by 'zen task history'
 
 Work-type directories (research/, spikes/, design/, execution/, outcomes/)
-are created on-demand when artifacts are added.
+are created on-demand when artifacts are added, such as the decision records,
+spikes and research documents created with 'zen task adr new', 'zen task spike
+new' and 'zen task research new'.
 
 Tasks support different types:
 - story: User-facing feature development
@@ -42,12 +68,95 @@ Tasks support different types:
   zen task create EPIC-789 --type epic
 
   # Create a research spike
-  zen task create SPIKE-101 --type spike`,
+  zen task create SPIKE-101 --type spike
+
+  # List tasks in the workspace
+  zen task list
+
+  # Save and use a view of your open tasks
+  zen task view save my-open --filter 'owner=me,status!=completed'
+  zen task list --view my-open
+
+  # Create a branch for a task, then push it and open a pull request
+  zen task start PROJ-123
+  zen task finish PROJ-123 --pr
+
+  # Show a task's stage, branch and pull request reviews
+  zen task status PROJ-123
+
+  # Report the commits and issues behind a task
+  zen task trace PROJ-123 --markdown
+
+  # Report on tasks by stage, owner and cycle time
+  zen task report --html > report.html
+
+  # Attach a design to a task and upload it to Jira
+  zen task attach PROJ-123 design.pdf --upload
+
+  # Record an architecture decision for a task
+  zen task adr new PROJ-123 "Use PostgreSQL for sessions"
+
+  # Record a passed quality gate with its evidence
+  zen task gate pass PROJ-123 design-review --evidence design/review.md
+
+  # Show the definition-of-done checklist of a task
+  zen task checklist PROJ-123
+
+  # Publish the index, decision records and outcomes of a task to Confluence
+  zen task publish PROJ-123 --to confluence
+
+  # Start a recurring task from the last one
+  zen task clone REL-9 --as REL-10
+
+  # Create the recurring tasks that are due
+  zen task generate-due
+
+  # Create tasks from a spreadsheet backlog
+  zen task import --file backlog.csv --map title=Summary,owner=Assignee
+
+  # Export the tasks updated this year for a data warehouse
+  zen task export --since 2026-01-01 --file tasks.jsonl
+
+  # Re-key a task, keeping its old ID working
+  zen task rename LOCAL-12 PROJ-345 --redirect
+
+  # Show what changed in a task since revision 3
+  zen task history PROJ-123
+  zen task diff PROJ-123 --rev 3
+
+  # List the pushes of local changes waiting to be retried
+  zen task outbox list
+
+  # Delete a task from the workspace
+  zen task delete PROJ-123`,
 		GroupID: "core",
 	}
 
 	// Add subcommands
 	cmd.AddCommand(create.NewCmdTaskCreate(f))
+	cmd.AddCommand(list.NewCmdTaskList(f))
+	cmd.AddCommand(view.NewCmdTaskView(f))
+	cmd.AddCommand(start.NewCmdTaskStart(f, nil))
+	cmd.AddCommand(finish.NewCmdTaskFinish(f, nil))
+	cmd.AddCommand(status.NewCmdTaskStatus(f, nil))
+	cmd.AddCommand(trace.NewCmdTaskTrace(f, nil))
+	cmd.AddCommand(report.NewCmdTaskReport(f, nil))
+	cmd.AddCommand(attach.NewCmdTaskAttach(f, nil))
+	cmd.AddCommand(document.NewCmdTaskDocument(f, tasks.DocumentADR))
+	cmd.AddCommand(document.NewCmdTaskDocument(f, tasks.DocumentSpike))
+	cmd.AddCommand(document.NewCmdTaskDocument(f, tasks.DocumentResearch))
+	cmd.AddCommand(gate.NewCmdTaskGate(f))
+	cmd.AddCommand(checklist.NewCmdTaskChecklist(f, nil))
+	cmd.AddCommand(publish.NewCmdTaskPublish(f, nil))
+	cmd.AddCommand(clone.NewCmdTaskClone(f, nil))
+	cmd.AddCommand(generate.NewCmdTaskGenerateDue(f, nil))
+	cmd.AddCommand(importcmd.NewCmdTaskImport(f, nil))
+	cmd.AddCommand(export.NewCmdTaskExport(f, nil))
+	cmd.AddCommand(rename.NewCmdTaskRename(f, nil))
+	cmd.AddCommand(history.NewCmdTaskHistory(f, nil))
+	cmd.AddCommand(diff.NewCmdTaskDiff(f, nil))
+	cmd.AddCommand(outbox.NewCmdTaskOutbox(f))
+	cmd.AddCommand(delete.NewCmdTaskDelete(f, nil))
 
 	return cmd
 }
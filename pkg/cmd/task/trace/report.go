@@ -0,0 +1,260 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/pullrequest"
+	"github.com/daddia/zen/pkg/task"
+)
+
+// Report links a task to its external issues, pull requests and commits
+type Report struct {
+	Task         ReportTask                 `json:"task"`
+	Issues       []Issue                    `json:"issues"`
+	PullRequests []*pullrequest.PullRequest `json:"pull_requests"`
+	Branch       *task.GitInfo              `json:"branch,omitempty"`
+	Commits      []Commit                   `json:"commits"`
+	Authors      []string                   `json:"authors"`
+	GeneratedAt  time.Time                  `json:"generated_at"`
+}
+
+// ReportTask summarises the traced task
+type ReportTask struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Stage  string `json:"stage"`
+}
+
+// Issue is an external issue the task is synced with
+type Issue struct {
+	System   string    `json:"system"`
+	ID       string    `json:"id"`
+	Title    string    `json:"title,omitempty"`
+	Status   string    `json:"status,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	LastSync time.Time `json:"last_sync"`
+	Commits  int       `json:"commits"`
+}
+
+// Commit is a commit that references the task or one of its issues, or that was made on the task branch
+type Commit struct {
+	Hash       string    `json:"hash"`
+	ShortHash  string    `json:"short_hash"`
+	Author     string    `json:"author"`
+	Email      string    `json:"email"`
+	Date       time.Time `json:"date"`
+	Subject    string    `json:"subject"`
+	References []string  `json:"references"`
+	OnBranch   bool      `json:"on_branch"`
+}
+
+// newReport creates a report for a task with its synced issues and linked pull requests
+func newReport(t *task.Task) *Report {
+	report := &Report{
+		Task: ReportTask{
+			ID:     t.ID,
+			Title:  t.Title,
+			Type:   t.Type,
+			Status: t.Status,
+			Stage:  t.CurrentStage,
+		},
+		Issues:       []Issue{},
+		PullRequests: []*pullrequest.PullRequest{},
+		Branch:       t.Git,
+		Commits:      []Commit{},
+		Authors:      []string{},
+		GeneratedAt:  time.Now(),
+	}
+
+	for _, source := range t.Sources {
+		issue := Issue{
+			System:   source.System,
+			ID:       source.ExternalID,
+			URL:      source.ExternalURL,
+			LastSync: source.LastSync,
+		}
+		if data, ok := source.Metadata["task_data"].(map[string]interface{}); ok {
+			issue.Title, _ = data["title"].(string)
+			issue.Status, _ = data["status"].(string)
+			if issue.URL == "" {
+				issue.URL, _ = data["external_url"].(string)
+			}
+		}
+		if issue.ID == "" {
+			issue.ID = t.ID
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+	sort.Slice(report.Issues, func(i, j int) bool { return report.Issues[i].System < report.Issues[j].System })
+
+	for _, source := range t.PullRequests {
+		if pr, ok := pullrequest.FromTaskSource(source); ok {
+			report.PullRequests = append(report.PullRequests, pr)
+		}
+	}
+
+	return report
+}
+
+// references returns the IDs that commit messages may use to refer to the task
+func (r *Report) references() []string {
+	refs := []string{r.Task.ID}
+	for _, issue := range r.Issues {
+		if !contains(refs, issue.ID) {
+			refs = append(refs, issue.ID)
+		}
+	}
+	return refs
+}
+
+// addCommit records a commit, merging it with an earlier record of the same commit
+func (r *Report) addCommit(c git.Commit, refs []string, onBranch bool) {
+	for i := range r.Commits {
+		if r.Commits[i].Hash == c.Hash {
+			r.Commits[i].OnBranch = r.Commits[i].OnBranch || onBranch
+			return
+		}
+	}
+
+	r.Commits = append(r.Commits, Commit{
+		Hash:       c.Hash,
+		ShortHash:  c.ShortHash,
+		Author:     c.Author,
+		Email:      c.Email,
+		Date:       c.Date,
+		Subject:    c.Message,
+		References: refs,
+		OnBranch:   onBranch,
+	})
+}
+
+// finish orders the commits newest first and fills in per-issue counts and authors
+func (r *Report) finish() {
+	sort.SliceStable(r.Commits, func(i, j int) bool { return r.Commits[i].Date.After(r.Commits[j].Date) })
+
+	for i := range r.Issues {
+		for _, c := range r.Commits {
+			if contains(c.References, r.Issues[i].ID) {
+				r.Issues[i].Commits++
+			}
+		}
+	}
+
+	for _, c := range r.Commits {
+		if !contains(r.Authors, c.Author) {
+			r.Authors = append(r.Authors, c.Author)
+		}
+	}
+	sort.Strings(r.Authors)
+}
+
+// matchReferences returns the references that appear in message as whole IDs, so that
+// "PROJ-1" matches "Fix PROJ-1: login" but not "PROJ-12"
+func matchReferences(message string, refs []string) []string {
+	matched := []string{}
+	for _, ref := range refs {
+		pattern := regexp.MustCompile(`(^|[^A-Za-z0-9_-])` + regexp.QuoteMeta(ref) + `($|[^A-Za-z0-9_])`)
+		if pattern.MatchString(message) {
+			matched = append(matched, ref)
+		}
+	}
+	return matched
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMarkdown writes the report as a Markdown document for audits and release notes
+func (r *Report) writeMarkdown(w io.Writer) error {
+	title := r.Task.ID
+	if r.Task.Title != "" {
+		title += ": " + r.Task.Title
+	}
+	fmt.Fprintf(w, "# Traceability report: %s\n\n", title)
+	fmt.Fprintf(w, "Generated %s.\n\n", r.GeneratedAt.Format(time.RFC3339))
+
+	fmt.Fprintln(w, "| Field | Value |")
+	fmt.Fprintln(w, "|---|---|")
+	fmt.Fprintf(w, "| Type | %s |\n", markdownCell(r.Task.Type))
+	fmt.Fprintf(w, "| Status | %s |\n", markdownCell(r.Task.Status))
+	fmt.Fprintf(w, "| Stage | %s |\n", markdownCell(task.StageName(r.Task.Stage)))
+	if r.Branch != nil {
+		fmt.Fprintf(w, "| Branch | `%s` |\n", r.Branch.Branch)
+	}
+	fmt.Fprintf(w, "| Commits | %d |\n", len(r.Commits))
+	fmt.Fprintf(w, "| Authors | %s |\n", markdownCell(strings.Join(r.Authors, ", ")))
+
+	fmt.Fprintln(w, "\n## External issues")
+	fmt.Fprintln(w)
+	if len(r.Issues) == 0 {
+		fmt.Fprintln(w, "None.")
+	} else {
+		fmt.Fprintln(w, "| System | Issue | Title | Status | Commits |")
+		fmt.Fprintln(w, "|---|---|---|---|---|")
+		for _, issue := range r.Issues {
+			fmt.Fprintf(w, "| %s | %s | %s | %s | %d |\n",
+				issue.System, markdownLink(issue.ID, issue.URL), markdownCell(issue.Title), markdownCell(issue.Status), issue.Commits)
+		}
+	}
+
+	fmt.Fprintln(w, "\n## Pull requests")
+	fmt.Fprintln(w)
+	if len(r.PullRequests) == 0 {
+		fmt.Fprintln(w, "None.")
+	} else {
+		fmt.Fprintln(w, "| Pull request | State | Review | Branch |")
+		fmt.Fprintln(w, "|---|---|---|---|")
+		for _, pr := range r.PullRequests {
+			fmt.Fprintf(w, "| %s | %s | %s | `%s` → `%s` |\n",
+				markdownLink(pr.Reference(), pr.URL), pr.State, pr.ReviewStatus, pr.Head, pr.Base)
+		}
+	}
+
+	fmt.Fprintln(w, "\n## Commits")
+	fmt.Fprintln(w)
+	if len(r.Commits) == 0 {
+		fmt.Fprintln(w, "None.")
+		return nil
+	}
+	fmt.Fprintln(w, "| Commit | Date | Author | Subject | References |")
+	fmt.Fprintln(w, "|---|---|---|---|---|")
+	for _, c := range r.Commits {
+		fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s |\n",
+			c.ShortHash, c.Date.Format("2006-01-02"), markdownCell(c.Author), markdownCell(c.Subject), markdownCell(commitReferences(c)))
+	}
+	return nil
+}
+
+// commitReferences describes why a commit is part of the report
+func commitReferences(c Commit) string {
+	refs := append([]string{}, c.References...)
+	if c.OnBranch {
+		refs = append(refs, "branch")
+	}
+	return strings.Join(refs, ", ")
+}
+
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
+
+func markdownLink(text, url string) string {
+	if url == "" {
+		return markdownCell(text)
+	}
+	return fmt.Sprintf("[%s](%s)", markdownCell(text), url)
+}
@@ -0,0 +1,230 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/cmd/task/internal"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// TaskGetter loads tasks
+type TaskGetter interface {
+	GetTask(ctx context.Context, taskID string) (*task.Task, error)
+}
+
+// TraceOptions contains options for the task trace command
+type TraceOptions struct {
+	IO               *iostreams.IOStreams
+	Logger           logging.Logger
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskGetter, error)
+
+	OutputFormat string
+	Template     string
+	JQ           string
+
+	TaskID   string
+	Range    string
+	Markdown bool
+}
+
+// NewCmdTaskTrace creates the task trace command
+func NewCmdTaskTrace(f *cmdutil.Factory, runF func(*TraceOptions) error) *cobra.Command {
+	opts := &TraceOptions{
+		IO:               f.IOStreams,
+		Logger:           f.Logger,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskGetter, error) {
+			return task.NewManager(f), nil
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "trace <task-id>",
+		Short: "Report the commits, issues and pull requests behind a task",
+		Long: heredoc.Doc(`
+			Produce a traceability report for a task from its Git history.
+
+			Commits are included when their message mentions the task ID or the ID of
+			an external issue the task is synced with (for example a Jira key), or
+			when they were made on the task branch recorded by 'zen task start'. The
+			report lists each external issue with the number of commits that reference
+			it, the pull requests opened with 'zen pr create', and every matching
+			commit with its author and date.
+
+			All branches are searched by default. Use --rev to limit the search to a
+			revision range, for example the commits in a release.
+
+			Use --markdown for a document to attach to audits or release notes, or
+			--output json for tooling.
+		`),
+		Example: heredoc.Doc(`
+			# Show the commits and issues behind a task
+			zen task trace PROJ-123
+
+			# Write a Markdown report for the commits in a release
+			zen task trace PROJ-123 --rev v1.2.0..v1.3.0 --markdown > PROJ-123-trace.md
+
+			# List the commit hashes as JSON
+			zen task trace PROJ-123 --jq '.commits[].hash'
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TaskID = args[0]
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if opts.Markdown && (opts.Template != "" || opts.JQ != "" || opts.OutputFormat != cmdutil.OutputText) {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--markdown cannot be combined with --output, --format or --jq")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return traceRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Range, "rev", "", "Revision range to search, e.g. main or v1.2.0..v1.3.0 (default all branches)")
+	cmd.Flags().BoolVar(&opts.Markdown, "markdown", false, "Write the report as Markdown")
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func traceRun(ctx context.Context, opts *TraceOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	root, err := internal.WorkspaceRoot(opts.WorkspaceManager)
+	if err != nil {
+		return err
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	t, err := manager.GetTask(ctx, opts.TaskID)
+	if err != nil {
+		return err
+	}
+
+	repo := git.NewCLIRepository(root, opts.Logger, nil, "")
+	if _, err := repo.GetCurrentBranch(ctx); err != nil {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "workspace is not a Git repository",
+			Details: fmt.Sprintf("'zen task trace' needs a Git repository at %s", root),
+		}
+	}
+
+	report := newReport(t)
+	if err := collectCommits(ctx, opts, repo, report); err != nil {
+		return err
+	}
+	report.finish()
+
+	if opts.Markdown {
+		return report.writeMarkdown(opts.IO.Out)
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(report, func(w io.Writer) error {
+		return displayReportText(w, opts.IO, report)
+	})
+}
+
+// collectCommits adds the commits that mention the task's references, and those on its branch
+func collectCommits(ctx context.Context, opts *TraceOptions, repo *git.CLIRepository, report *Report) error {
+	refs := report.references()
+
+	for _, ref := range refs {
+		commits, err := repo.Log(ctx, git.LogOptions{Grep: ref, All: opts.Range == "", Range: opts.Range})
+		if err != nil {
+			return fmt.Errorf("failed to search history for %s: %w", ref, git.OutputError(err))
+		}
+		for _, c := range commits {
+			// --grep matches substrings, so "PROJ-1" also finds "PROJ-12"
+			if matched := matchReferences(c.Message+"\n"+c.Body, refs); len(matched) > 0 {
+				report.addCommit(c, matched, false)
+			}
+		}
+	}
+
+	if report.Branch == nil || report.Branch.Base == "" || opts.Range != "" {
+		return nil
+	}
+
+	commits, err := repo.Log(ctx, git.LogOptions{Range: report.Branch.Base + ".." + report.Branch.Branch})
+	if err != nil {
+		// The branch may have been deleted after it was merged
+		opts.Logger.Debug("task branch not found", "branch", report.Branch.Branch, "error", err)
+		return nil
+	}
+	for _, c := range commits {
+		report.addCommit(c, matchReferences(c.Message+"\n"+c.Body, refs), true)
+	}
+	return nil
+}
+
+func displayReportText(w io.Writer, streams *iostreams.IOStreams, r *Report) error {
+	title := r.Task.ID
+	if r.Task.Title != "" {
+		title += ": " + r.Task.Title
+	}
+	fmt.Fprintln(w, streams.ColorBold("Traceability for "+title))
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, streams.FormatSectionHeader("External issues"))
+	if len(r.Issues) == 0 {
+		fmt.Fprintln(w, "  None")
+	}
+	for _, issue := range r.Issues {
+		fmt.Fprintf(w, "  %s %s", issue.System, issue.ID)
+		if issue.Status != "" {
+			fmt.Fprintf(w, " (%s)", issue.Status)
+		}
+		fmt.Fprintf(w, " %s\n", streams.ColorNeutral(fmt.Sprintf("%d commits", issue.Commits)))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, streams.FormatSectionHeader("Pull requests"))
+	if len(r.PullRequests) == 0 {
+		fmt.Fprintln(w, "  None")
+	}
+	for _, pr := range r.PullRequests {
+		fmt.Fprintf(w, "  %s %s %s\n", pr.Reference(), pr.State, streams.ColorNeutral(pr.URL))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, streams.FormatSectionHeader(fmt.Sprintf("Commits (%d)", len(r.Commits))))
+	if len(r.Commits) == 0 {
+		fmt.Fprintln(w, "  None")
+		return nil
+	}
+
+	headers := []string{"COMMIT", "DATE", "AUTHOR", "SUBJECT", "REFERENCES"}
+	rows := make([][]string, 0, len(r.Commits))
+	for _, c := range r.Commits {
+		rows = append(rows, []string{c.ShortHash, c.Date.Format("2006-01-02"), c.Author, c.Subject, commitReferences(c)})
+	}
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/pullrequest"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	task *task.Task
+}
+
+func (m *mockTaskManager) GetTask(ctx context.Context, taskID string) (*task.Task, error) {
+	return m.task, nil
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// newTestOptions creates a repository with commits for PROJ-1, its Jira issue and an unrelated task
+func newTestOptions(t *testing.T) (*TraceOptions, *bytes.Buffer) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Ana")
+	t.Setenv("GIT_AUTHOR_EMAIL", "ana@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Ana")
+	t.Setenv("GIT_COMMITTER_EMAIL", "ana@example.com")
+
+	root := filepath.Join(t.TempDir(), "workspace")
+	out, err := exec.Command("git", "init", "-q", "-b", "main", root).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "PROJ-12 unrelated work")
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "Add session store", "-m", "Refs PROJ-1")
+	runGit(t, root, "checkout", "-q", "-b", "feat/PROJ-1-login")
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "Fix JIRA-77 | login redirect")
+	runGit(t, root, "commit", "-q", "--allow-empty", "-m", "Tidy up")
+
+	pr := &pullrequest.PullRequest{
+		Provider:   pullrequest.ProviderGitHub,
+		Repository: "acme/app",
+		Number:     42,
+		URL:        "https://github.com/acme/app/pull/42",
+		State:      pullrequest.StateMerged,
+		Head:       "feat/PROJ-1-login",
+		Base:       "main",
+	}
+	manager := &mockTaskManager{task: &task.Task{
+		ID:           "PROJ-1",
+		Title:        "Login",
+		Status:       "in_progress",
+		CurrentStage: "05-build",
+		Sources: map[string]*task.TaskSource{
+			"jira": {
+				System:     "jira",
+				ExternalID: "JIRA-77",
+				Metadata: map[string]interface{}{
+					"task_data": map[string]interface{}{
+						"status":       "In Review",
+						"external_url": "https://acme.atlassian.net/browse/JIRA-77",
+					},
+				},
+			},
+		},
+		Git:          &task.GitInfo{Branch: "feat/PROJ-1-login", Base: "main"},
+		PullRequests: []*task.TaskSource{pr.TaskSource()},
+	}}
+
+	streams := iostreams.Test()
+	return &TraceOptions{
+		IO:               streams,
+		Logger:           logging.NewBasic(),
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) { return zentest.WorkspaceAt(root), nil },
+		TaskManager:      func() (TaskGetter, error) { return manager, nil },
+		TaskID:           "PROJ-1",
+	}, streams.Out.(*bytes.Buffer)
+}
+
+func TestTraceRun_JSON(t *testing.T) {
+	opts, out := newTestOptions(t)
+	opts.OutputFormat = "json"
+
+	require.NoError(t, traceRun(context.Background(), opts))
+
+	var report Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+
+	subjects := make(map[string]Commit)
+	for _, c := range report.Commits {
+		subjects[c.Subject] = c
+	}
+	assert.Len(t, report.Commits, 3)
+	assert.NotContains(t, subjects, "PROJ-12 unrelated work")
+	assert.Equal(t, []string{"PROJ-1"}, subjects["Add session store"].References)
+	assert.False(t, subjects["Add session store"].OnBranch)
+	assert.Equal(t, []string{"JIRA-77"}, subjects["Fix JIRA-77 | login redirect"].References)
+	assert.True(t, subjects["Fix JIRA-77 | login redirect"].OnBranch)
+	assert.Empty(t, subjects["Tidy up"].References)
+	assert.True(t, subjects["Tidy up"].OnBranch)
+
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "JIRA-77", report.Issues[0].ID)
+	assert.Equal(t, "In Review", report.Issues[0].Status)
+	assert.Equal(t, 1, report.Issues[0].Commits)
+
+	require.Len(t, report.PullRequests, 1)
+	assert.Equal(t, 42, report.PullRequests[0].Number)
+	assert.Equal(t, []string{"Ana"}, report.Authors)
+}
+
+func TestTraceRun_Range(t *testing.T) {
+	opts, out := newTestOptions(t)
+	opts.OutputFormat = "json"
+	opts.Range = "main"
+
+	require.NoError(t, traceRun(context.Background(), opts))
+
+	var report Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	require.Len(t, report.Commits, 1)
+	assert.Equal(t, "Add session store", report.Commits[0].Subject)
+}
+
+func TestTraceRun_Markdown(t *testing.T) {
+	opts, out := newTestOptions(t)
+	opts.Markdown = true
+
+	require.NoError(t, traceRun(context.Background(), opts))
+
+	md := out.String()
+	assert.Contains(t, md, "# Traceability report: PROJ-1: Login")
+	assert.Contains(t, md, "| jira | [JIRA-77](https://acme.atlassian.net/browse/JIRA-77) |  | In Review | 1 |")
+	assert.Contains(t, md, "| [acme/app#42](https://github.com/acme/app/pull/42) | merged |")
+	assert.Contains(t, md, `| Fix JIRA-77 \| login redirect | JIRA-77, branch |`)
+}
+
+func TestTraceRun_Text(t *testing.T) {
+	opts, out := newTestOptions(t)
+
+	require.NoError(t, traceRun(context.Background(), opts))
+
+	text := out.String()
+	assert.Contains(t, text, "Traceability for PROJ-1: Login")
+	assert.Contains(t, text, "jira JIRA-77 (In Review) 1 commits")
+	assert.Contains(t, text, "Commits (3)")
+}
+
+func TestMatchReferences(t *testing.T) {
+	refs := []string{"PROJ-1", "JIRA-77"}
+	assert.Equal(t, []string{"PROJ-1"}, matchReferences("PROJ-1: add login", refs))
+	assert.Equal(t, []string{"PROJ-1"}, matchReferences("Merge branch 'feat/PROJ-1-login'", refs))
+	assert.Equal(t, []string{"PROJ-1", "JIRA-77"}, matchReferences("Fix (PROJ-1)\n\nCloses JIRA-77.", refs))
+	assert.Empty(t, matchReferences("PROJ-12 and XPROJ-1 and JIRA-770", refs))
+}
@@ -0,0 +1,88 @@
+package delete
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// DeleteOptions contains options for the task view delete command
+type DeleteOptions struct {
+	IO             *iostreams.IOStreams
+	TaskConfig     func() (task.Config, error)
+	SaveTaskConfig func(task.Config) error
+
+	Name string
+}
+
+// NewCmdDelete creates the task view delete command
+func NewCmdDelete(f *cmdutil.Factory, runF func(*DeleteOptions) error) *cobra.Command {
+	opts := &DeleteOptions{
+		IO: f.IOStreams,
+		TaskConfig: func() (task.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return task.Config{}, err
+			}
+			return config.GetConfig(cfg, task.ConfigParser{})
+		},
+		SaveTaskConfig: func(tasks task.Config) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+			return config.SetConfig(cfg, task.ConfigParser{}, tasks)
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a saved view",
+		Example: heredoc.Doc(`
+			$ zen task view delete my-open
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return deleteRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func deleteRun(opts *DeleteOptions) error {
+	tasks, err := opts.TaskConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get task config: %w", err)
+	}
+
+	for _, name := range tasks.ViewNames() {
+		if !strings.EqualFold(name, opts.Name) {
+			continue
+		}
+		delete(tasks.Views, name)
+		if err := opts.SaveTaskConfig(tasks); err != nil {
+			return fmt.Errorf("failed to save task config: %w", err)
+		}
+		fmt.Fprintf(opts.IO.ErrOut, "%s Deleted view %s\n", opts.IO.SuccessIcon(), name)
+		return nil
+	}
+
+	return &types.Error{
+		Code:    types.ErrorCodeNotFound,
+		Message: fmt.Sprintf("view not found: %s", opts.Name),
+	}
+}
@@ -0,0 +1,49 @@
+package delete
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(cfg task.Config) (*DeleteOptions, *task.Config, *bytes.Buffer) {
+	streams := iostreams.Test()
+	saved := &task.Config{}
+	return &DeleteOptions{
+		IO:         streams,
+		TaskConfig: func() (task.Config, error) { return cfg, nil },
+		SaveTaskConfig: func(c task.Config) error {
+			*saved = c
+			return nil
+		},
+	}, saved, streams.ErrOut.(*bytes.Buffer)
+}
+
+func TestDeleteRun(t *testing.T) {
+	opts, saved, errOut := newTestOptions(task.Config{Views: map[string]task.View{
+		"my-open": {Filter: "owner=me"},
+		"web":     {Filter: "team=web"},
+	}})
+	opts.Name = "My-Open"
+
+	require.NoError(t, deleteRun(opts))
+
+	assert.Equal(t, map[string]task.View{"web": {Filter: "team=web"}}, saved.Views)
+	assert.Equal(t, "✓ Deleted view my-open\n", errOut.String())
+}
+
+func TestDeleteRun_NotFound(t *testing.T) {
+	opts, _, _ := newTestOptions(task.DefaultConfig())
+	opts.Name = "my-open"
+
+	err := deleteRun(opts)
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, types.ErrorCodeNotFound, zenErr.Code)
+}
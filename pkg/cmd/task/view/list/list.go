@@ -0,0 +1,117 @@
+package list
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// ListOptions contains options for the task view list command
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	TaskConfig   func() (task.Config, error)
+	OutputFormat string
+	Template     string
+	JQ           string
+}
+
+// Entry is a view as listed
+type Entry struct {
+	Name string `json:"name"`
+	task.View
+}
+
+// NewCmdList creates the task view list command
+func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IO: f.IOStreams,
+		TaskConfig: func() (task.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return task.Config{}, err
+			}
+			return config.GetConfig(cfg, task.ConfigParser{})
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the saved views of the task list",
+		Example: heredoc.Doc(`
+			$ zen task view list
+			$ zen task view list --jq '.[].name'
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return listRun(opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func listRun(opts *ListOptions) error {
+	cfg, err := opts.TaskConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get task config: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(cfg.Views))
+	for _, name := range cfg.ViewNames() {
+		entries = append(entries, Entry{Name: name, View: cfg.Views[name]})
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(entries, func(w io.Writer) error {
+		return displayListText(w, opts.IO, entries)
+	})
+}
+
+func displayListText(w io.Writer, streams *iostreams.IOStreams, entries []Entry) error {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No views saved.")
+		if streams.IsStdoutTTY() {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, streams.ColorNeutral("Save one with 'zen task view save <name> --filter <conditions>'"))
+		}
+		return nil
+	}
+
+	headers := []string{"NAME", "FILTER", "COLUMNS"}
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		filter := entry.Filter
+		if filter == "" {
+			filter = "-"
+		}
+		columns := "-"
+		if len(entry.Columns) > 0 {
+			columns = strings.Join(entry.Columns, ",")
+		}
+		rows = append(rows, []string{entry.Name, filter, columns})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(cfg task.Config) (*ListOptions, *bytes.Buffer) {
+	streams := iostreams.Test()
+	return &ListOptions{
+		IO:           streams,
+		TaskConfig:   func() (task.Config, error) { return cfg, nil },
+		OutputFormat: cmdutil.OutputText,
+	}, streams.Out.(*bytes.Buffer)
+}
+
+func testConfig() task.Config {
+	return task.Config{Views: map[string]task.View{
+		"my-open": {Filter: "owner=me,status!=completed"},
+		"wide":    {Columns: []string{"id", "title", "labels"}},
+	}}
+}
+
+func TestListRun_Empty(t *testing.T) {
+	opts, out := newTestOptions(task.DefaultConfig())
+
+	require.NoError(t, listRun(opts))
+	assert.Equal(t, "No views saved.\n", out.String())
+}
+
+func TestListRun_Text(t *testing.T) {
+	opts, out := newTestOptions(testConfig())
+
+	require.NoError(t, listRun(opts))
+	assert.Equal(t, "my-open\towner=me,status!=completed\t-\nwide\t-\tid,title,labels\n", out.String())
+}
+
+func TestListRun_JSON(t *testing.T) {
+	opts, out := newTestOptions(testConfig())
+	opts.OutputFormat = cmdutil.OutputJSON
+
+	require.NoError(t, listRun(opts))
+
+	var entries []map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "my-open", entries[0]["name"])
+	assert.Equal(t, "owner=me,status!=completed", entries[0]["filter"])
+	assert.Equal(t, []interface{}{"id", "title", "labels"}, entries[1]["columns"])
+}
@@ -0,0 +1,120 @@
+package save
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// SaveOptions contains options for the task view save command
+type SaveOptions struct {
+	IO             *iostreams.IOStreams
+	TaskConfig     func() (task.Config, error)
+	SaveTaskConfig func(task.Config) error
+
+	Name    string
+	Filter  string
+	Columns []string
+}
+
+// NewCmdSave creates the task view save command
+func NewCmdSave(f *cmdutil.Factory, runF func(*SaveOptions) error) *cobra.Command {
+	opts := &SaveOptions{
+		IO: f.IOStreams,
+		TaskConfig: func() (task.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return task.Config{}, err
+			}
+			return config.GetConfig(cfg, task.ConfigParser{})
+		},
+		SaveTaskConfig: func(tasks task.Config) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+			return config.SetConfig(cfg, task.ConfigParser{}, tasks)
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save a filter of the task list as a view",
+		Long: heredoc.Docf(`
+			Save a filter of the task list, and the columns its tasks are listed with,
+			as a view in task.views. A view of the same name is replaced.
+
+			Columns are %s; by default
+			tasks are listed with %s.
+		`, strings.Join(task.ListColumns, ", "), strings.Join(task.DefaultListColumns, ", ")),
+		Example: heredoc.Doc(`
+			# Save a view of your open tasks
+			zen task view save my-open --filter 'owner=me,status!=completed'
+
+			# Save a view of the urgent work of a team, with its own columns
+			zen task view save web-urgent --filter 'team=web,priority=P0|P1' --columns id,title,priority,owner
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+
+			if opts.Filter == "" && len(opts.Columns) == 0 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("specify --filter or --columns")}
+			}
+			if _, err := task.ParseFilter(opts.Filter); err != nil {
+				return &cmdutil.FlagError{Err: fmt.Errorf("invalid --filter: %w", err)}
+			}
+			for i, column := range opts.Columns {
+				opts.Columns[i] = strings.ToLower(strings.TrimSpace(column))
+			}
+			if err := task.ValidateColumns(opts.Columns); err != nil {
+				return &cmdutil.FlagError{Err: fmt.Errorf("invalid --columns: %w", err)}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return saveRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Conditions the tasks of the view meet, such as `owner=me,status!=completed`")
+	cmd.Flags().StringSliceVar(&opts.Columns, "columns", nil, "Columns the tasks of the view are listed with")
+
+	return cmd
+}
+
+func saveRun(opts *SaveOptions) error {
+	tasks, err := opts.TaskConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get task config: %w", err)
+	}
+
+	name, replaced := opts.Name, false
+	for _, existing := range tasks.ViewNames() {
+		if strings.EqualFold(existing, opts.Name) {
+			name, replaced = existing, true
+		}
+	}
+	if tasks.Views == nil {
+		tasks.Views = map[string]task.View{}
+	}
+	tasks.Views[name] = task.View{Filter: opts.Filter, Columns: opts.Columns}
+
+	if err := opts.SaveTaskConfig(tasks); err != nil {
+		return fmt.Errorf("failed to save view: %w", err)
+	}
+
+	if replaced {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Updated view %s\n", opts.IO.SuccessIcon(), name)
+	} else {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Saved view %s\n", opts.IO.SuccessIcon(), name)
+	}
+	return nil
+}
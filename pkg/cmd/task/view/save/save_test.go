@@ -0,0 +1,109 @@
+package save
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdSave(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    SaveOptions
+		wantErr string
+	}{
+		{
+			name: "filter",
+			args: []string{"my-open", "--filter", "owner=me,status!=completed"},
+			want: SaveOptions{Name: "my-open", Filter: "owner=me,status!=completed"},
+		},
+		{
+			name: "columns",
+			args: []string{"wide", "--columns", "ID,title,Labels"},
+			want: SaveOptions{Name: "wide", Columns: []string{"id", "title", "labels"}},
+		},
+		{
+			name:    "nothing to save",
+			args:    []string{"my-open"},
+			wantErr: "specify --filter or --columns",
+		},
+		{
+			name:    "invalid filter",
+			args:    []string{"my-open", "--filter", "assignee=me"},
+			wantErr: `invalid --filter: unknown field "assignee"`,
+		},
+		{
+			name:    "invalid column",
+			args:    []string{"my-open", "--columns", "id,due"},
+			wantErr: `invalid --columns: unknown column "due"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *SaveOptions
+			cmd := NewCmdSave(cmdutil.NewTestFactory(iostreams.Test()), func(opts *SaveOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Name, got.Name)
+			assert.Equal(t, tt.want.Filter, got.Filter)
+			assert.Equal(t, tt.want.Columns, got.Columns)
+		})
+	}
+}
+
+func newTestOptions(cfg task.Config) (*SaveOptions, *task.Config, *bytes.Buffer) {
+	streams := iostreams.Test()
+	saved := &task.Config{}
+	return &SaveOptions{
+		IO:         streams,
+		TaskConfig: func() (task.Config, error) { return cfg, nil },
+		SaveTaskConfig: func(c task.Config) error {
+			*saved = c
+			return nil
+		},
+	}, saved, streams.ErrOut.(*bytes.Buffer)
+}
+
+func TestSaveRun(t *testing.T) {
+	opts, saved, errOut := newTestOptions(task.Config{Source: "jira"})
+	opts.Name, opts.Filter = "my-open", "owner=me,status!=completed"
+
+	require.NoError(t, saveRun(opts))
+
+	assert.Equal(t, map[string]task.View{"my-open": {Filter: "owner=me,status!=completed"}}, saved.Views)
+	assert.Equal(t, "jira", saved.Source, "the rest of the task config is kept")
+	assert.Equal(t, "✓ Saved view my-open\n", errOut.String())
+}
+
+func TestSaveRun_Replaces(t *testing.T) {
+	opts, saved, errOut := newTestOptions(task.Config{Views: map[string]task.View{
+		"my-open": {Filter: "owner=me"},
+		"web":     {Filter: "team=web"},
+	}})
+	opts.Name, opts.Filter, opts.Columns = "My-Open", "owner=me,status!=completed", []string{"id", "title"}
+
+	require.NoError(t, saveRun(opts))
+
+	assert.Equal(t, task.View{Filter: "owner=me,status!=completed", Columns: []string{"id", "title"}}, saved.Views["my-open"])
+	assert.Len(t, saved.Views, 2)
+	assert.Equal(t, "✓ Updated view my-open\n", errOut.String())
+}
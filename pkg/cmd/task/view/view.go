@@ -0,0 +1,43 @@
+package view
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/task/view/delete"
+	"github.com/daddia/zen/pkg/cmd/task/view/list"
+	"github.com/daddia/zen/pkg/cmd/task/view/save"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTaskView creates the task view command with subcommands
+func NewCmdTaskView(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "view <command>",
+		Short: "Manage saved views of the task list",
+		Long: heredoc.Doc(`
+			Manage the views in task.views: named filters of the task list, each with
+			the columns its tasks are listed with.
+
+			A view's filter is a comma-separated list of conditions that must all hold,
+			as field=value or field!=value, on the fields type, status, priority, owner,
+			team, stage, label and source. Values list alternatives separated by '|',
+			and the owner "me" is whoever lists the tasks.
+
+			Views are used with 'zen task list --view <name>' and
+			'zen dashboard --saved-view <name>'.
+		`),
+		Example: heredoc.Doc(`
+			# Save a view of your open tasks
+			zen task view save my-open --filter 'owner=me,status!=completed'
+
+			# List the tasks of the view
+			zen task list --view my-open
+		`),
+	}
+
+	cmd.AddCommand(save.NewCmdSave(f, nil))
+	cmd.AddCommand(list.NewCmdList(f, nil))
+	cmd.AddCommand(delete.NewCmdDelete(f, nil))
+
+	return cmd
+}
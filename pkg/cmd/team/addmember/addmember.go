@@ -0,0 +1,156 @@
+package addmember
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/identity"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/team"
+	"github.com/spf13/cobra"
+)
+
+// AddMemberOptions contains options for the team add-member command
+type AddMemberOptions struct {
+	IO             *iostreams.IOStreams
+	TeamConfig     func() (team.Config, error)
+	IdentityConfig func() (identity.Config, error)
+	SaveTeamConfig func(team.Config) error
+
+	Team     string
+	User     string
+	Role     string
+	Reviewer bool
+
+	// Capacity is set on the team when SetCapacity is true
+	Capacity    int
+	SetCapacity bool
+}
+
+// NewCmdAddMember creates the team add-member command
+func NewCmdAddMember(f *cmdutil.Factory, runF func(*AddMemberOptions) error) *cobra.Command {
+	opts := &AddMemberOptions{
+		IO: f.IOStreams,
+		TeamConfig: func() (team.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return team.Config{}, err
+			}
+			return config.GetConfig(cfg, team.ConfigParser{})
+		},
+		IdentityConfig: func() (identity.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return identity.Config{}, err
+			}
+			return config.GetConfig(cfg, identity.ConfigParser{})
+		},
+		SaveTeamConfig: func(teams team.Config) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+			return config.SetConfig(cfg, team.ConfigParser{}, teams)
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "add-member <team> <user>",
+		Short: "Add a user to a team",
+		Long: heredoc.Doc(`
+			Add a user to a team, defining the team when it does not exist yet. A user
+			already on the team has their role updated.
+
+			The user can be given by their local user name or by anything the identity
+			section maps to them, such as their email address or a provider account,
+			and is added by their local user name.
+		`),
+		Example: heredoc.Doc(`
+			# Add a developer to the platform team
+			zen team add-member platform ben
+
+			# Add the lead of the team, who reviews its pull requests
+			zen team add-member platform ana --role lead --reviewer
+
+			# Define a team that works on at most five tasks at once
+			zen team add-member web carla --capacity 5
+		`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Team, opts.User = args[0], args[1]
+			opts.SetCapacity = cmd.Flags().Changed("capacity")
+
+			if opts.Capacity < 0 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--capacity cannot be negative")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return addMemberRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Role, "role", team.DefaultRole, "Role of the user on the team")
+	cmd.Flags().BoolVar(&opts.Reviewer, "reviewer", false, "Also add the user to the team's default reviewers")
+	cmd.Flags().IntVar(&opts.Capacity, "capacity", 0, "Set the number of tasks the team works on at once")
+
+	return cmd
+}
+
+func addMemberRun(opts *AddMemberOptions) error {
+	teams, err := opts.TeamConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get team config: %w", err)
+	}
+
+	identityConfig, err := opts.IdentityConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get identity config: %w", err)
+	}
+	user := identity.New(identityConfig).Resolve(opts.User)
+
+	added, err := teams.AddMember(opts.Team, user, opts.Role)
+	if err != nil {
+		return err
+	}
+
+	name := opts.Team
+	for teamName := range teams.Teams {
+		if strings.EqualFold(teamName, opts.Team) {
+			name = teamName
+		}
+	}
+	t := teams.Teams[name]
+	if opts.Reviewer && !containsFold(t.Reviewers, user) {
+		t.Reviewers = append(t.Reviewers, user)
+	}
+	if opts.SetCapacity {
+		t.Capacity = opts.Capacity
+	}
+	teams.Teams[name] = t
+
+	if err := opts.SaveTeamConfig(teams); err != nil {
+		return fmt.Errorf("failed to save team config: %w", err)
+	}
+
+	role := t.Member(user).Role
+	if added {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Added %s to team %s as %s\n", opts.IO.SuccessIcon(), user, name, role)
+	} else {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Updated %s on team %s to %s\n", opts.IO.SuccessIcon(), user, name, role)
+	}
+	return nil
+}
+
+func containsFold(items []string, item string) bool {
+	for _, i := range items {
+		if strings.EqualFold(i, item) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package addmember
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/identity"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdAddMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    AddMemberOptions
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			args: []string{"web", "ana"},
+			want: AddMemberOptions{Team: "web", User: "ana", Role: team.DefaultRole},
+		},
+		{
+			name: "lead and capacity",
+			args: []string{"web", "ana", "--role", "lead", "--reviewer", "--capacity", "5"},
+			want: AddMemberOptions{Team: "web", User: "ana", Role: "lead", Reviewer: true, Capacity: 5, SetCapacity: true},
+		},
+		{
+			name:    "negative capacity",
+			args:    []string{"web", "ana", "--capacity", "-1"},
+			wantErr: "--capacity cannot be negative",
+		},
+		{
+			name:    "missing user",
+			args:    []string{"web"},
+			wantErr: "accepts 2 arg(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *AddMemberOptions
+			cmd := NewCmdAddMember(cmdutil.NewTestFactory(iostreams.Test()), func(opts *AddMemberOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Team, got.Team)
+			assert.Equal(t, tt.want.User, got.User)
+			assert.Equal(t, tt.want.Role, got.Role)
+			assert.Equal(t, tt.want.Reviewer, got.Reviewer)
+			assert.Equal(t, tt.want.Capacity, got.Capacity)
+			assert.Equal(t, tt.want.SetCapacity, got.SetCapacity)
+		})
+	}
+}
+
+func newTestOptions(cfg team.Config) (*AddMemberOptions, *team.Config, *bytes.Buffer) {
+	streams := iostreams.Test()
+	saved := &team.Config{}
+	return &AddMemberOptions{
+		IO:         streams,
+		TeamConfig: func() (team.Config, error) { return cfg, nil },
+		IdentityConfig: func() (identity.Config, error) {
+			return identity.Config{Users: map[string]identity.User{"ana": {Name: "Ana Lopez", Email: "ana@example.com"}}}, nil
+		},
+		SaveTeamConfig: func(c team.Config) error {
+			*saved = c
+			return nil
+		},
+		Role: team.DefaultRole,
+	}, saved, streams.ErrOut.(*bytes.Buffer)
+}
+
+func TestAddMemberRun(t *testing.T) {
+	opts, saved, errOut := newTestOptions(team.DefaultConfig())
+	opts.Team, opts.User, opts.Role = "web", "ana@example.com", "lead"
+	opts.Reviewer, opts.Capacity, opts.SetCapacity = true, 5, true
+
+	require.NoError(t, addMemberRun(opts))
+
+	assert.Equal(t, team.Team{Members: []team.Member{{Name: "ana", Role: "lead"}}, Reviewers: []string{"ana"}, Capacity: 5}, saved.Teams["web"])
+	assert.Equal(t, "✓ Added ana to team web as lead\n", errOut.String(), "users are added by their local name")
+}
+
+func TestAddMemberRun_UpdatesRole(t *testing.T) {
+	opts, saved, errOut := newTestOptions(team.Config{Teams: map[string]team.Team{
+		"web": {Members: []team.Member{{Name: "ben", Role: "member"}}, Capacity: 3},
+	}})
+	opts.Team, opts.User, opts.Role = "Web", "ben", "lead"
+
+	require.NoError(t, addMemberRun(opts))
+
+	assert.Equal(t, team.Team{Members: []team.Member{{Name: "ben", Role: "lead"}}, Capacity: 3}, saved.Teams["web"], "the capacity is kept")
+	assert.Equal(t, "✓ Updated ben on team web to lead\n", errOut.String())
+}
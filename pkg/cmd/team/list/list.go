@@ -0,0 +1,130 @@
+package list
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/team"
+	"github.com/spf13/cobra"
+)
+
+// ListOptions contains options for the team list command
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	TeamConfig   func() (team.Config, error)
+	OutputFormat string
+	Template     string
+	JQ           string
+}
+
+// Entry is a team as listed
+type Entry struct {
+	Name string `json:"name"`
+	team.Team
+	Default bool `json:"default"`
+}
+
+// NewCmdList creates the team list command
+func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IO: f.IOStreams,
+		TeamConfig: func() (team.Config, error) {
+			cfg, err := f.Config()
+			if err != nil {
+				return team.Config{}, err
+			}
+			return config.GetConfig(cfg, team.ConfigParser{})
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the teams and their members",
+		Example: heredoc.Doc(`
+			$ zen team list
+			$ zen team list --jq '.[] | select(.name == "platform") | .members[].name'
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.Template, opts.JQ = cmdutil.FormatFlags(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return listRun(opts)
+		},
+	}
+
+	cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+func listRun(opts *ListOptions) error {
+	cfg, err := opts.TeamConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get team config: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(cfg.Teams))
+	for _, name := range cfg.Names() {
+		entries = append(entries, Entry{Name: name, Team: cfg.Teams[name], Default: strings.EqualFold(name, cfg.Default)})
+	}
+
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	renderer.Template, renderer.JQ = opts.Template, opts.JQ
+	return renderer.Render(entries, func(w io.Writer) error {
+		return displayListText(w, opts.IO, entries)
+	})
+}
+
+func displayListText(w io.Writer, streams *iostreams.IOStreams, entries []Entry) error {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No teams defined.")
+		if streams.IsStdoutTTY() {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, streams.ColorNeutral("Add one with 'zen team add-member <team> <user>'"))
+		}
+		return nil
+	}
+
+	headers := []string{"NAME", "MEMBERS", "REVIEWERS", "CAPACITY"}
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name
+		if entry.Default {
+			name += " (default)"
+		}
+
+		members := make([]string, 0, len(entry.Members))
+		for _, member := range entry.Members {
+			if member.Role == "" {
+				members = append(members, member.Name)
+				continue
+			}
+			members = append(members, fmt.Sprintf("%s (%s)", member.Name, member.Role))
+		}
+
+		capacity := "-"
+		if entry.Capacity > 0 {
+			capacity = strconv.Itoa(entry.Capacity)
+		}
+
+		rows = append(rows, []string{name, strings.Join(members, ", "), strings.Join(entry.Reviewers, ", "), capacity})
+	}
+
+	if streams.IsStdoutTTY() {
+		fmt.Fprint(w, streams.FormatTable(headers, rows))
+	} else {
+		fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	}
+	return nil
+}
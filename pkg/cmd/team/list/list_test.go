@@ -0,0 +1,61 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(cfg team.Config) (*ListOptions, *bytes.Buffer) {
+	streams := iostreams.Test()
+	return &ListOptions{
+		IO:           streams,
+		TeamConfig:   func() (team.Config, error) { return cfg, nil },
+		OutputFormat: cmdutil.OutputText,
+	}, streams.Out.(*bytes.Buffer)
+}
+
+func testConfig() team.Config {
+	return team.Config{
+		Default: "web",
+		Teams: map[string]team.Team{
+			"web":      {Members: []team.Member{{Name: "ana", Role: "lead"}, {Name: "ben"}}, Reviewers: []string{"ana"}, Capacity: 5},
+			"platform": {Members: []team.Member{{Name: "carla", Role: "member"}}},
+		},
+	}
+}
+
+func TestListRun_Empty(t *testing.T) {
+	opts, out := newTestOptions(team.DefaultConfig())
+
+	require.NoError(t, listRun(opts))
+	assert.Equal(t, "No teams defined.\n", out.String())
+}
+
+func TestListRun_Text(t *testing.T) {
+	opts, out := newTestOptions(testConfig())
+
+	require.NoError(t, listRun(opts))
+	assert.Equal(t, "platform\tcarla (member)\t\t-\nweb (default)\tana (lead), ben\tana\t5\n", out.String())
+}
+
+func TestListRun_JSON(t *testing.T) {
+	opts, out := newTestOptions(testConfig())
+	opts.OutputFormat = cmdutil.OutputJSON
+
+	require.NoError(t, listRun(opts))
+
+	var entries []map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "web", entries[1]["name"])
+	assert.Equal(t, true, entries[1]["default"])
+	assert.Equal(t, float64(5), entries[1]["capacity"])
+	assert.Len(t, entries[1]["members"], 2)
+}
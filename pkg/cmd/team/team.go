@@ -0,0 +1,39 @@
+package team
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmd/team/addmember"
+	"github.com/daddia/zen/pkg/cmd/team/list"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTeam creates the team command with subcommands
+func NewCmdTeam(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "team <command>",
+		Short: "Manage the teams of the workspace",
+		Long: heredoc.Doc(`
+			Manage the teams in the team section of the configuration: their members and
+			roles, the reviewers of their pull requests, and their capacity.
+
+			New tasks are given the team of their owner, or team.default when the owner
+			is on no team, and tasks of a team that lists its members can only be
+			assigned to them. Flow metrics show the WIP of each team against its
+			capacity.
+		`),
+		Example: heredoc.Doc(`
+			# List the teams
+			zen team list
+
+			# Add a lead to the platform team
+			zen team add-member platform ana --role lead
+		`),
+		GroupID: "core",
+	}
+
+	cmd.AddCommand(list.NewCmdList(f, nil))
+	cmd.AddCommand(addmember.NewCmdAddMember(f, nil))
+
+	return cmd
+}
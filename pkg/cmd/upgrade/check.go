@@ -0,0 +1,101 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/cli"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/update"
+)
+
+// CheckTimeout bounds how long a version check may take
+const CheckTimeout = 2 * time.Second
+
+// CheckForUpdate returns a release newer than the running zen, or nil when there is none,
+// the running zen is a development build, or version checks are turned off or offline.
+// Releases are looked up at most once per update.CheckInterval.
+func CheckForUpdate(ctx context.Context, f *cmdutil.Factory) (*update.Release, error) {
+	if !update.ChecksEnabled(cliConfig(f)) {
+		return nil, nil
+	}
+	stateFile, err := update.DefaultStateFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+	defer cancel()
+	return update.NewChecker(update.NewUpdater(f.Logger), stateFile).Check(ctx, f.AppVersion)
+}
+
+// RequiredVersion returns the min_zen_version of the asset manifest synced to the
+// workspace when the running zen is older than it, and "" otherwise or when version
+// notices are turned off
+func RequiredVersion(f *cmdutil.Factory) string {
+	if !update.NoticesEnabled(cliConfig(f)) {
+		return ""
+	}
+
+	root := ""
+	if wm, err := f.WorkspaceManager(); err == nil {
+		root = wm.Root()
+	}
+	content, err := os.ReadFile(assets.ManifestPath(root))
+	if err != nil {
+		return ""
+	}
+	minimum, err := assets.MinZenVersion(content)
+	if err != nil || !update.Requires(minimum, f.AppVersion) {
+		return ""
+	}
+	return minimum
+}
+
+// cliConfig returns the CLI configuration, or its defaults when it cannot be loaded
+func cliConfig(f *cmdutil.Factory) cli.Config {
+	cfg, err := f.Config()
+	if err != nil {
+		return cli.DefaultConfig()
+	}
+	cliCfg, err := config.GetConfig(cfg, cli.ConfigParser{})
+	if err != nil {
+		return cli.DefaultConfig()
+	}
+	return cliCfg
+}
+
+// Notice tells the user that latest can replace the installed zen at current
+func Notice(streams *iostreams.IOStreams, current string, latest *update.Release) string {
+	channel := update.ChannelStable
+	if latest.Version.IsPrerelease() {
+		channel = update.ChannelBeta
+	}
+	if version, err := update.ParseVersion(current); err == nil {
+		current = version.String()
+	}
+	return fmt.Sprintf("\n%s %s → %s\nRun '%s' to install it\n",
+		streams.ColorWarning("A new release of zen is available:"),
+		streams.ColorNeutral(current), streams.ColorBold(latest.Version.String()),
+		UpgradeCommand(channel))
+}
+
+// RequirementNotice tells the user that the asset library needs at least zen minimum,
+// newer than the installed zen at current
+func RequirementNotice(streams *iostreams.IOStreams, minimum, current string) string {
+	return fmt.Sprintf("\n%s\nRun 'zen upgrade' to install a newer release\n",
+		streams.ColorWarning(RequirementMessage(minimum, current)))
+}
+
+// RequirementMessage is the plain text of RequirementNotice, for CI annotations
+func RequirementMessage(minimum, current string) string {
+	return fmt.Sprintf("The asset library requires zen %s or later; this is zen %s", minimum, current)
+}
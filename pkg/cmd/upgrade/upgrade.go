@@ -0,0 +1,216 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/update"
+	"github.com/spf13/cobra"
+)
+
+// Updater looks up and installs zen releases
+type Updater interface {
+	Latest(ctx context.Context, channel update.Channel) (*update.Release, error)
+	Install(ctx context.Context, release *update.Release, exe string, opts update.InstallOptions) (*update.Result, error)
+}
+
+// UpgradeOptions contains options for the upgrade command
+type UpgradeOptions struct {
+	IO             *iostreams.IOStreams
+	Updater        func() Updater
+	Executable     func() (string, error)
+	OutputFormat   string
+	CurrentVersion string
+	Channel        string
+	Check          bool
+	Force          bool
+	DryRun         bool
+	SkipSignature  bool
+}
+
+// upgradeResult is the outcome of an upgrade or update check
+type upgradeResult struct {
+	CurrentVersion    string         `json:"current_version" yaml:"current_version"`
+	LatestVersion     string         `json:"latest_version" yaml:"latest_version"`
+	Channel           update.Channel `json:"channel" yaml:"channel"`
+	UpdateAvailable   bool           `json:"update_available" yaml:"update_available"`
+	Upgraded          bool           `json:"upgraded" yaml:"upgraded"`
+	Path              string         `json:"path,omitempty" yaml:"path,omitempty"`
+	SignatureVerified bool           `json:"signature_verified,omitempty" yaml:"signature_verified,omitempty"`
+	ReleaseURL        string         `json:"release_url,omitempty" yaml:"release_url,omitempty"`
+}
+
+// NewCmdUpgrade creates the upgrade command
+func NewCmdUpgrade(f *cmdutil.Factory, runF func(*UpgradeOptions) error) *cobra.Command {
+	opts := &UpgradeOptions{
+		IO:             f.IOStreams,
+		Updater:        func() Updater { return update.NewUpdater(f.Logger) },
+		Executable:     update.Executable,
+		CurrentVersion: f.AppVersion,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade zen to the latest release",
+		Long: heredoc.Doc(`
+			Upgrade zen to the latest release from GitHub Releases.
+
+			The release archive is checked against the release checksums, and the
+			checksums against the release signature with gpg. Releases that are not
+			signed, or whose signature cannot be checked because gpg is not installed,
+			are refused unless --skip-signature is given; the checksums alone only show
+			that the download is intact, not who made it. The zen binary is then
+			replaced in place; if the new binary does not run, the previous one is
+			restored.
+
+			The stable channel follows full releases. The beta channel also follows
+			prereleases.
+
+			If zen was installed with a package manager such as Homebrew, upgrade it
+			with that package manager instead.
+		`),
+		Example: heredoc.Doc(`
+			# Upgrade to the latest stable release
+			$ zen upgrade
+
+			# Check for a new release without installing it
+			$ zen upgrade --check
+
+			# Follow beta releases
+			$ zen upgrade --channel beta
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OutputFormat = cmdutil.OutputFormat(cmd)
+			opts.DryRun = f.DryRun
+
+			if _, err := update.ParseChannel(opts.Channel); err != nil {
+				return &cmdutil.FlagError{Err: err}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return upgradeRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Channel, "channel", string(update.ChannelStable), "Release channel to follow: {stable|beta}")
+	cmd.Flags().BoolVar(&opts.Check, "check", false, "Only check whether a new release is available")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Install the latest release even if it is not newer, or over a package manager install")
+	cmd.Flags().BoolVar(&opts.SkipSignature, "skip-signature", false, "Install a release whose signature cannot be verified, checking its checksums only")
+
+	return cmd
+}
+
+func upgradeRun(ctx context.Context, opts *UpgradeOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	channel, err := update.ParseChannel(opts.Channel)
+	if err != nil {
+		return &cmdutil.FlagError{Err: err}
+	}
+
+	updater := opts.Updater()
+	spinner := opts.IO.StartSpinner(fmt.Sprintf("Checking for %s releases...", channel))
+	latest, err := updater.Latest(ctx, channel)
+	spinner.Done(err)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	// Development builds have no release version to compare against
+	current, versionErr := update.ParseVersion(opts.CurrentVersion)
+	result := upgradeResult{
+		CurrentVersion:  opts.CurrentVersion,
+		LatestVersion:   latest.Version.String(),
+		Channel:         channel,
+		UpdateAvailable: versionErr == nil && latest.Version.Compare(current) > 0,
+		ReleaseURL:      latest.URL,
+	}
+	if versionErr == nil {
+		result.CurrentVersion = current.String()
+	}
+
+	install := result.UpdateAvailable || opts.Force
+	if opts.Check || opts.DryRun || !install {
+		return render(opts, result)
+	}
+
+	exe, err := opts.Executable()
+	if err != nil {
+		return err
+	}
+	if pm := update.PackageManager(exe); pm != "" && !opts.Force {
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("zen was installed with %s", pm),
+			Details: fmt.Sprintf("Run '%s upgrade zen' instead, or use --force to replace %s anyway", pm, exe),
+		}
+	}
+
+	spinner = opts.IO.StartSpinner(fmt.Sprintf("Installing zen %s...", latest.Version))
+	installed, err := updater.Install(ctx, latest, exe, update.InstallOptions{SkipSignature: opts.SkipSignature})
+	spinner.Done(err)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	result.Upgraded = true
+	result.Path = installed.Path
+	result.SignatureVerified = installed.SignatureVerified
+	if installed.SignatureSkipped != "" {
+		fmt.Fprintf(opts.IO.ErrOut, "%s Release signature not verified: %s; the download matched the release checksums\n",
+			opts.IO.WarningIcon(), installed.SignatureSkipped)
+	}
+	return render(opts, result)
+}
+
+func render(opts *UpgradeOptions, result upgradeResult) error {
+	renderer := cmdutil.NewRenderer(opts.IO, opts.OutputFormat)
+	return renderer.Render(result, func(w io.Writer) error {
+		switch {
+		case result.Upgraded:
+			fmt.Fprintf(w, "%s Upgraded zen %s → %s\n",
+				opts.IO.SuccessIcon(), opts.IO.ColorNeutral(result.CurrentVersion), opts.IO.ColorBold(result.LatestVersion))
+		case result.UpdateAvailable && opts.DryRun && !opts.Check:
+			fmt.Fprintf(w, "Would upgrade zen %s → %s\n", result.CurrentVersion, result.LatestVersion)
+		case result.UpdateAvailable:
+			fmt.Fprintf(w, "A new release of zen is available: %s → %s\n",
+				opts.IO.ColorNeutral(result.CurrentVersion), opts.IO.ColorBold(result.LatestVersion))
+			if result.ReleaseURL != "" {
+				fmt.Fprintln(w, result.ReleaseURL)
+			}
+			fmt.Fprintf(w, "Run '%s' to install it\n", UpgradeCommand(result.Channel))
+		case opts.Force && opts.DryRun:
+			fmt.Fprintf(w, "Would reinstall zen %s\n", result.LatestVersion)
+		default:
+			if _, err := update.ParseVersion(result.CurrentVersion); err != nil {
+				fmt.Fprintf(w, "zen %s is a development build; the latest %s release is %s\n",
+					result.CurrentVersion, result.Channel, result.LatestVersion)
+				fmt.Fprintln(w, "Use 'zen upgrade --force' to install it")
+				return nil
+			}
+			fmt.Fprintf(w, "%s zen %s is up to date on the %s channel\n",
+				opts.IO.SuccessIcon(), result.CurrentVersion, result.Channel)
+		}
+		return nil
+	})
+}
+
+// UpgradeCommand returns the command that upgrades zen on channel
+func UpgradeCommand(channel update.Channel) string {
+	if channel == update.ChannelBeta {
+		return "zen upgrade --channel beta"
+	}
+	return "zen upgrade"
+}
@@ -0,0 +1,183 @@
+package upgrade
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/update"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUpdater struct {
+	latest    *update.Release
+	result    *update.Result
+	err       error
+	channel   update.Channel
+	installed string
+	options   update.InstallOptions
+}
+
+func (u *fakeUpdater) Latest(ctx context.Context, channel update.Channel) (*update.Release, error) {
+	u.channel = channel
+	return u.latest, nil
+}
+
+func (u *fakeUpdater) Install(ctx context.Context, release *update.Release, exe string, opts update.InstallOptions) (*update.Result, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	u.installed, u.options = exe, opts
+	return u.result, nil
+}
+
+func newRelease(version string) *update.Release {
+	v, _ := update.ParseVersion(version)
+	return &update.Release{Version: v, Tag: v.String(), URL: "https://github.com/daddia/zen/releases/tag/" + v.String()}
+}
+
+func testOptions(current string, updater *fakeUpdater) (*UpgradeOptions, *bytes.Buffer, *bytes.Buffer) {
+	streams := iostreams.Test()
+	return &UpgradeOptions{
+		IO:             streams,
+		Updater:        func() Updater { return updater },
+		Executable:     func() (string, error) { return "/usr/local/bin/zen", nil },
+		OutputFormat:   cmdutil.OutputText,
+		CurrentVersion: current,
+		Channel:        string(update.ChannelStable),
+	}, streams.Out.(*bytes.Buffer), streams.ErrOut.(*bytes.Buffer)
+}
+
+func TestNewCmdUpgrade(t *testing.T) {
+	f := cmdutil.NewTestFactory(iostreams.Test())
+	f.DryRun = true
+
+	var gotOpts *UpgradeOptions
+	cmd := NewCmdUpgrade(f, func(opts *UpgradeOptions) error {
+		gotOpts = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"--channel", "beta", "--check"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "beta", gotOpts.Channel)
+	assert.True(t, gotOpts.Check)
+	assert.True(t, gotOpts.DryRun)
+
+	cmd = NewCmdUpgrade(f, func(*UpgradeOptions) error { return nil })
+	cmd.SetArgs([]string{"--channel", "nightly"})
+	cmd.SetErr(&bytes.Buffer{})
+	var flagErr *cmdutil.FlagError
+	require.ErrorAs(t, cmd.Execute(), &flagErr)
+}
+
+func TestUpgradeRun(t *testing.T) {
+	t.Run("installs a newer release", func(t *testing.T) {
+		release := newRelease("1.4.0")
+		updater := &fakeUpdater{latest: release, result: &update.Result{Release: release, Path: "/usr/local/bin/zen", SignatureVerified: true}}
+		opts, stdout, stderr := testOptions("1.3.0", updater)
+
+		require.NoError(t, upgradeRun(context.Background(), opts))
+
+		assert.Equal(t, "/usr/local/bin/zen", updater.installed)
+		assert.Contains(t, stdout.String(), "Upgraded zen v1.3.0 → v1.4.0")
+		assert.NotContains(t, stderr.String(), "signature not verified")
+	})
+
+	t.Run("warns when the signature was not verified", func(t *testing.T) {
+		release := newRelease("1.4.0")
+		updater := &fakeUpdater{latest: release, result: &update.Result{Release: release, SignatureSkipped: "signature verification was skipped"}}
+		opts, _, stderr := testOptions("1.3.0", updater)
+		opts.SkipSignature = true
+
+		require.NoError(t, upgradeRun(context.Background(), opts))
+
+		assert.True(t, updater.options.SkipSignature)
+		assert.Contains(t, stderr.String(), "Release signature not verified: signature verification was skipped")
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		updater := &fakeUpdater{latest: newRelease("1.4.0")}
+		opts, stdout, _ := testOptions("v1.4.0", updater)
+
+		require.NoError(t, upgradeRun(context.Background(), opts))
+
+		assert.Empty(t, updater.installed)
+		assert.Contains(t, stdout.String(), "zen v1.4.0 is up to date on the stable channel")
+	})
+
+	t.Run("check reports without installing", func(t *testing.T) {
+		updater := &fakeUpdater{latest: newRelease("1.5.0-beta.1")}
+		opts, stdout, _ := testOptions("1.4.0", updater)
+		opts.Channel = "beta"
+		opts.Check = true
+
+		require.NoError(t, upgradeRun(context.Background(), opts))
+
+		assert.Equal(t, update.ChannelBeta, updater.channel)
+		assert.Empty(t, updater.installed)
+		assert.Contains(t, stdout.String(), "A new release of zen is available: v1.4.0 → v1.5.0-beta.1")
+		assert.Contains(t, stdout.String(), "Run 'zen upgrade --channel beta' to install it")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		updater := &fakeUpdater{latest: newRelease("1.4.0")}
+		opts, stdout, _ := testOptions("1.3.0", updater)
+		opts.DryRun = true
+
+		require.NoError(t, upgradeRun(context.Background(), opts))
+
+		assert.Empty(t, updater.installed)
+		assert.Equal(t, "Would upgrade zen v1.3.0 → v1.4.0\n", stdout.String())
+	})
+
+	t.Run("development build needs force", func(t *testing.T) {
+		updater := &fakeUpdater{latest: newRelease("1.4.0")}
+		opts, stdout, _ := testOptions("dev", updater)
+
+		require.NoError(t, upgradeRun(context.Background(), opts))
+
+		assert.Empty(t, updater.installed)
+		assert.Contains(t, stdout.String(), "zen dev is a development build")
+	})
+
+	t.Run("package manager install", func(t *testing.T) {
+		updater := &fakeUpdater{latest: newRelease("1.4.0")}
+		opts, _, _ := testOptions("1.3.0", updater)
+		opts.Executable = func() (string, error) { return "/opt/homebrew/Cellar/zen/1.3.0/bin/zen", nil }
+
+		err := upgradeRun(context.Background(), opts)
+
+		var zenErr *types.Error
+		require.ErrorAs(t, err, &zenErr)
+		assert.Equal(t, "zen was installed with brew", zenErr.Message)
+		assert.Empty(t, updater.installed)
+	})
+
+	t.Run("install failure", func(t *testing.T) {
+		updater := &fakeUpdater{latest: newRelease("1.4.0"), err: errors.New("checksum mismatch")}
+		opts, _, _ := testOptions("1.3.0", updater)
+
+		assert.EqualError(t, upgradeRun(context.Background(), opts), "checksum mismatch")
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		updater := &fakeUpdater{latest: newRelease("1.4.0")}
+		opts, stdout, _ := testOptions("1.3.0", updater)
+		opts.Check = true
+		opts.OutputFormat = cmdutil.OutputJSON
+
+		require.NoError(t, upgradeRun(context.Background(), opts))
+
+		var result upgradeResult
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.True(t, result.UpdateAvailable)
+		assert.False(t, result.Upgraded)
+		assert.Equal(t, "v1.4.0", result.LatestVersion)
+	})
+}
@@ -1,15 +1,26 @@
 package version
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"io"
 	"runtime"
 
+	"github.com/daddia/zen/pkg/cmd/upgrade"
 	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
+// checkForUpdate looks up a release newer than the running zen for the update notice
+var checkForUpdate = upgrade.CheckForUpdate
+
+// notifyUpdates reports whether zen version mentions newer releases: only to people at a
+// terminal, never in scripts or CI
+var notifyUpdates = func(streams *iostreams.IOStreams) bool {
+	return streams.IsStderrTTY() && !streams.IsNonInteractive()
+}
+
 // BuildInfo contains build information
 type BuildInfo struct {
 	Version   string `json:"version" yaml:"version"`
@@ -21,7 +32,6 @@ type BuildInfo struct {
 
 // NewCmdVersion creates the version command
 func NewCmdVersion(f *cmdutil.Factory) *cobra.Command {
-	var outputFormat string
 	var buildOptions bool
 
 	cmd := &cobra.Command{
@@ -55,39 +65,26 @@ build information including Git commit, build date, Go version, and platform.`,
 				Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
 			}
 
-			// Check parent command flags for output format
-			if cmd.Parent() != nil && cmd.Parent().PersistentFlags().Changed("output") {
-				if val, err := cmd.Parent().PersistentFlags().GetString("output"); err == nil {
-					outputFormat = val
+			renderer := cmdutil.NewRendererForCommand(f.IOStreams, cmd)
+			err := renderer.Render(info, func(w io.Writer) error {
+				// Simple version output (like git version)
+				if !buildOptions {
+					fmt.Fprintf(w, "zen version %s\n", info.Version)
+					return nil
 				}
+				return displayDetailedVersion(w, info)
+			})
+			if err != nil {
+				return err
 			}
 
-			// Simple version output (like git version)
-			if !buildOptions && outputFormat == "text" {
-				fmt.Fprintf(f.IOStreams.Out, "zen version %s\n", info.Version)
-				return nil
-			}
-
-			// Detailed output for --build-options or non-text formats
-			switch outputFormat {
-			case "json":
-				encoder := json.NewEncoder(f.IOStreams.Out)
-				encoder.SetIndent("", "  ")
-				return encoder.Encode(info)
-
-			case "yaml":
-				encoder := yaml.NewEncoder(f.IOStreams.Out)
-				defer encoder.Close()
-				return encoder.Encode(info)
-
-			default:
-				return displayDetailedVersion(f.IOStreams.Out, info)
+			if cmdutil.OutputFormat(cmd) == cmdutil.OutputText && notifyUpdates(f.IOStreams) {
+				fmt.Fprint(f.IOStreams.ErrOut, updateNotice(cmd.Context(), f))
 			}
+			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text",
-		"Output format (text, json, yaml)")
 	cmd.Flags().BoolVar(&buildOptions, "build-options", false,
 		"Show detailed build information")
 
@@ -108,3 +105,17 @@ func displayDetailedVersion(out interface{ Write([]byte) (int, error) }, info Bu
 	fmt.Fprintf(out, "go version: %s\n", info.GoVersion)
 	return nil
 }
+
+// updateNotice returns a notice that a newer release than the running zen is available,
+// or "" when there is none or it cannot be looked up
+func updateNotice(ctx context.Context, f *cmdutil.Factory) string {
+	latest, err := checkForUpdate(ctx, f)
+	if err != nil {
+		f.Logger.Debug("update check failed", "error", err)
+		return ""
+	}
+	if latest == nil {
+		return ""
+	}
+	return upgrade.Notice(f.IOStreams, f.AppVersion, latest)
+}
@@ -2,12 +2,14 @@ package version
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
 
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/update"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -337,6 +339,50 @@ func BenchmarkVersionCommand_JSONOutput(b *testing.B) {
 	}
 }
 
+func TestVersionCommand_UpdateNotice(t *testing.T) {
+	origNotify, origCheck := notifyUpdates, checkForUpdate
+	t.Cleanup(func() { notifyUpdates, checkForUpdate = origNotify, origCheck })
+	notifyUpdates = func(*iostreams.IOStreams) bool { return true }
+
+	tests := []struct {
+		name    string
+		version string
+		latest  string
+		notice  string
+	}{
+		{name: "newer release", version: "1.3.2", latest: "v1.4.0", notice: "A new release of zen is available: v1.3.2 → v1.4.0\nRun 'zen upgrade' to install it"},
+		{name: "newer beta", version: "v1.4.0-beta.1", latest: "v1.4.0-beta.2", notice: "Run 'zen upgrade --channel beta' to install it"},
+		{name: "up to date", version: "1.4.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkForUpdate = func(ctx context.Context, f *cmdutil.Factory) (*update.Release, error) {
+				if tt.latest == "" {
+					return nil, nil
+				}
+				version, err := update.ParseVersion(tt.latest)
+				require.NoError(t, err)
+				return &update.Release{Version: version, Tag: tt.latest}, nil
+			}
+			streams := iostreams.Test()
+			factory := cmdutil.NewTestFactory(streams)
+			factory.AppVersion = tt.version
+
+			cmd := NewCmdVersion(factory)
+			cmd.SetArgs([]string{})
+			require.NoError(t, cmd.Execute())
+
+			stderr := streams.ErrOut.(*bytes.Buffer).String()
+			if tt.notice == "" {
+				assert.Empty(t, stderr)
+				return
+			}
+			assert.Contains(t, stderr, tt.notice)
+		})
+	}
+}
+
 func BenchmarkGetVersionInfo(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
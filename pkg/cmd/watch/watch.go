@@ -0,0 +1,285 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/search"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/watch"
+	"github.com/spf13/cobra"
+)
+
+// TaskManager lists tasks, which brings the task index up to date, creates the tasks
+// of recurring tasks as they fall due, and retries the pushes waiting in the outboxes
+// of tasks
+type TaskManager interface {
+	ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error)
+	GenerateDueTasks(ctx context.Context, now time.Time, dryRun bool) []*task.RecurringResult
+	DeliverOutbox(ctx context.Context, now time.Time) []*task.OutboxResult
+}
+
+// recurringInterval is how often recurring tasks are checked for tasks due
+const recurringInterval = time.Minute
+
+// outboxInterval is how often the outboxes of tasks are checked for pushes due
+const outboxInterval = time.Minute
+
+// WatchOptions contains options for the watch command
+type WatchOptions struct {
+	IO               *iostreams.IOStreams
+	WorkspaceManager func() (cmdutil.WorkspaceManager, error)
+	TaskManager      func() (TaskManager, error)
+
+	Delay time.Duration
+
+	// RecurringInterval is how often recurring tasks are checked for tasks due
+	RecurringInterval time.Duration
+
+	// OutboxInterval is how often the outboxes of tasks are checked for pushes due
+	OutboxInterval time.Duration
+}
+
+// NewCmdWatch creates the watch command
+func NewCmdWatch(f *cmdutil.Factory, runF func(*WatchOptions) error) *cobra.Command {
+	opts := &WatchOptions{
+		IO:               f.IOStreams,
+		WorkspaceManager: f.WorkspaceManager,
+		TaskManager: func() (TaskManager, error) {
+			return task.NewManager(f), nil
+		},
+		RecurringInterval: recurringInterval,
+		OutboxInterval:    outboxInterval,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Keep the task and search indexes up to date as files change",
+		Long: heredoc.Doc(`
+			Watch the tasks of the workspace, in .zen/work/tasks, and update the task index
+			and the search index as their files change, so 'zen task list' and 'zen search'
+			find them current and only check which files changed.
+
+			Changes are applied once no more followed for --delay, so that saving many files
+			at once updates the indexes once.
+
+			While it runs, the tasks of the recurring tasks in task.recurring are created
+			as they fall due, as 'zen task generate-due' does, checking every minute.
+
+			Pushes of local changes that failed, listed by 'zen task outbox list', are
+			retried as they fall due, checking every minute.
+
+			The command runs until you press Ctrl+C.
+		`),
+		Example: heredoc.Doc(`
+			# Keep the indexes up to date while you work
+			zen watch
+
+			# Wait a second for changes to settle
+			zen watch --delay 1s
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Delay <= 0 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("--delay must be positive")}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return watchRun(cmd.Context(), opts)
+		},
+		GroupID: "workspace",
+	}
+
+	cmd.Flags().DurationVar(&opts.Delay, "delay", watch.DefaultDelay, "How long changes must settle before the indexes are updated")
+
+	return cmd
+}
+
+func watchRun(ctx context.Context, opts *WatchOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	wm, err := opts.WorkspaceManager()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := wm.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	if !status.Initialized {
+		return &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+	}
+
+	manager, err := opts.TaskManager()
+	if err != nil {
+		return fmt.Errorf("failed to get task manager: %w", err)
+	}
+
+	tasksDir := filepath.Join(status.Root, ".zen", "work", "tasks")
+	if err := os.MkdirAll(tasksDir, 0755); err != nil {
+		return fmt.Errorf("failed to create tasks directory: %w", err)
+	}
+
+	index, err := search.Open(status.Root, search.IndexPath(wm.ZenDirectory()))
+	if err != nil {
+		return err
+	}
+
+	// The watcher starts before the first update, so no change falls between them
+	watcher, err := watch.New(tasksDir, opts.Delay)
+	if err != nil {
+		return err
+	}
+
+	refresh := func() (int, *search.UpdateStats, error) {
+		tasks, err := manager.ListTasks(ctx, nil)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to update task index: %w", err)
+		}
+		stats, err := index.Update()
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to update search index: %w", err)
+		}
+		if stats.Indexed > 0 || stats.Removed > 0 {
+			if err := index.Save(); err != nil {
+				return 0, nil, err
+			}
+		}
+		return len(tasks), stats, nil
+	}
+
+	count, stats, err := refresh()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(opts.IO.ErrOut, "%s Indexed %d tasks (%d files updated)\n", opts.IO.SuccessIcon(), count, stats.Indexed)
+	fmt.Fprintf(opts.IO.ErrOut, "  Watching %s for changes; press Ctrl+C to stop\n", tasksDir)
+
+	// Recurring tasks are generated, and outboxes delivered, alongside the watcher,
+	// which reports as well
+	var mu sync.Mutex
+	report := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(opts.IO.ErrOut, format, args...)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var background sync.WaitGroup
+	defer func() {
+		cancel()
+		background.Wait()
+	}()
+	background.Add(2)
+	go func() {
+		defer background.Done()
+		generateDueTasks(ctx, opts, manager, report)
+	}()
+	go func() {
+		defer background.Done()
+		deliverOutbox(ctx, opts, manager, report)
+	}()
+
+	return watcher.Run(ctx, func(paths []string) error {
+		count, stats, err := refresh()
+		if err != nil {
+			// A file being written may not parse yet; the next change retries
+			report("%s %v\n", opts.IO.WarningIcon(), err)
+			return nil
+		}
+		if stats.Indexed > 0 || stats.Removed > 0 {
+			report("%s Updated indexes: %d files updated, %d removed, %d tasks\n",
+				opts.IO.SuccessIcon(), stats.Indexed, stats.Removed, count)
+		}
+		return nil
+	})
+}
+
+// generateDueTasks creates the tasks of recurring tasks as they fall due, until ctx is
+// done. The tasks created are indexed by the watcher as their files appear. A failure
+// is reported once, rather than on every check, until it changes.
+func generateDueTasks(ctx context.Context, opts *WatchOptions, manager TaskManager, report func(string, ...interface{})) {
+	interval := opts.RecurringInterval
+	if interval <= 0 {
+		interval = recurringInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failures := map[string]string{}
+	for {
+		for _, result := range manager.GenerateDueTasks(ctx, time.Now(), false) {
+			switch result.Status {
+			case task.RecurringCreated:
+				report("%s Created %s (recurring task %s)\n", opts.IO.SuccessIcon(), result.TaskID, result.Name)
+			case task.RecurringFailed:
+				if failures[result.Name] != result.Error {
+					report("%s Recurring task %s: %s\n", opts.IO.WarningIcon(), result.Name, result.Error)
+				}
+				failures[result.Name] = result.Error
+				continue
+			}
+			delete(failures, result.Name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverOutbox retries the pushes waiting in the outboxes of tasks as they fall due,
+// until ctx is done. A push that fails again is reported once, rather than on every
+// retry, until its failure changes.
+func deliverOutbox(ctx context.Context, opts *WatchOptions, manager TaskManager, report func(string, ...interface{})) {
+	interval := opts.OutboxInterval
+	if interval <= 0 {
+		interval = outboxInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failures := map[string]string{}
+	for {
+		for _, result := range manager.DeliverOutbox(ctx, time.Now()) {
+			key := result.TaskID + "/" + result.Source
+			switch result.Status {
+			case task.OutboxDelivered:
+				report("%s Pushed %s to %s\n", opts.IO.SuccessIcon(), result.TaskID, result.Source)
+			case task.OutboxFailed:
+				if failures[key] != result.Error {
+					report("%s Push of %s to %s: %s\n", opts.IO.WarningIcon(), result.TaskID, result.Source, result.Error)
+				}
+				failures[key] = result.Error
+				continue
+			}
+			delete(failures, key)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
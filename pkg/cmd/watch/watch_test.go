@@ -0,0 +1,144 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/search"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskManager struct {
+	listed    chan struct{}
+	generated chan struct{}
+	delivered chan struct{}
+}
+
+func (m *mockTaskManager) ListTasks(ctx context.Context, filter *task.TaskFilter) ([]*task.Task, error) {
+	m.listed <- struct{}{}
+	return []*task.Task{{ID: "PROJ-1"}}, nil
+}
+
+func (m *mockTaskManager) GenerateDueTasks(ctx context.Context, now time.Time, dryRun bool) []*task.RecurringResult {
+	select {
+	case m.generated <- struct{}{}:
+	default:
+		return []*task.RecurringResult{{Name: "release", Status: task.RecurringFailed, Error: "task template not found: release"}}
+	}
+	return []*task.RecurringResult{
+		{Name: "standup", TaskID: "STANDUP-2026-10-16", Status: task.RecurringCreated},
+		{Name: "release", Status: task.RecurringFailed, Error: "task template not found: release"},
+	}
+}
+
+func (m *mockTaskManager) DeliverOutbox(ctx context.Context, now time.Time) []*task.OutboxResult {
+	select {
+	case m.delivered <- struct{}{}:
+	default:
+		return []*task.OutboxResult{{TaskID: "PROJ-2", Source: "jira", Status: task.OutboxFailed, Error: "jira unavailable"}}
+	}
+	return []*task.OutboxResult{
+		{TaskID: "PROJ-1", Source: "jira", Status: task.OutboxDelivered},
+		{TaskID: "PROJ-2", Source: "jira", Status: task.OutboxFailed, Error: "jira unavailable"},
+	}
+}
+
+func TestNewCmdWatch(t *testing.T) {
+	var got *WatchOptions
+	cmd := NewCmdWatch(cmdutil.NewTestFactory(iostreams.Test()), func(opts *WatchOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"--delay", "1s"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, time.Second, got.Delay)
+
+	cmd = NewCmdWatch(cmdutil.NewTestFactory(iostreams.Test()), func(opts *WatchOptions) error { return nil })
+	cmd.SetArgs([]string{"--delay", "0s"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, cmd.Execute(), &flagErr)
+}
+
+func TestWatchRun(t *testing.T) {
+	root := t.TempDir()
+	streams := iostreams.Test()
+	manager := &mockTaskManager{listed: make(chan struct{}, 10), generated: make(chan struct{}, 1), delivered: make(chan struct{}, 1)}
+	opts := &WatchOptions{
+		IO: streams,
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) {
+			return zentest.WorkspaceAt(root), nil
+		},
+		TaskManager:       func() (TaskManager, error) { return manager, nil },
+		Delay:             20 * time.Millisecond,
+		RecurringInterval: 10 * time.Millisecond,
+		OutboxInterval:    10 * time.Millisecond,
+	}
+
+	taskDir := filepath.Join(root, ".zen", "work", "tasks", "PROJ-1")
+	require.NoError(t, os.MkdirAll(taskDir, 0755))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- watchRun(ctx, opts) }()
+
+	listed := func() {
+		select {
+		case <-manager.listed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("indexes not updated")
+		}
+	}
+	listed()
+
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "manifest.yaml"), []byte("task:\n  id: PROJ-1\n  title: Add login page\n"), 0644))
+	listed()
+
+	// Recurring tasks are checked until the watch stops, and a failure is reported once
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "✓ Created STANDUP-2026-10-16 (recurring task standup)\n")
+	assert.Equal(t, 1, strings.Count(streams.ErrOut.(*bytes.Buffer).String(), "! Recurring task release: task template not found: release\n"))
+
+	// So are outboxes, and a push failing again is reported once
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "✓ Pushed PROJ-1 to jira\n")
+	assert.Equal(t, 1, strings.Count(streams.ErrOut.(*bytes.Buffer).String(), "! Push of PROJ-2 to jira: jira unavailable\n"))
+
+	// The manifest is indexed by the first update or on its change, depending on
+	// whether it was written before the first update finished
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "✓ Indexed 1 tasks (")
+
+	index, err := search.Open(root, search.IndexPath(filepath.Join(root, ".zen")))
+	require.NoError(t, err)
+	assert.NotEmpty(t, index.Search(search.Query{Text: "login"}))
+}
+
+func TestWatchRun_NotInitialized(t *testing.T) {
+	opts := &WatchOptions{
+		IO: iostreams.Test(),
+		WorkspaceManager: func() (cmdutil.WorkspaceManager, error) {
+			return zentest.NewWorkspace(t), nil
+		},
+	}
+
+	err := watchRun(context.Background(), opts)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeWorkspaceNotInit, zenErr.Code)
+}
@@ -0,0 +1,79 @@
+package cmdutil
+
+import "github.com/spf13/cobra"
+
+// HelpTopicAnnotation marks the commands that are help topics, so documentation
+// generators can tell them apart from commands
+const HelpTopicAnnotation = "zen:help-topic"
+
+// IsHelpTopic reports whether cmd is a help topic rather than a command
+func IsHelpTopic(cmd *cobra.Command) bool {
+	return cmd.Annotations[HelpTopicAnnotation] == "true"
+}
+
+// CommandAnnotation records the release that introduced a command and whether it is deprecated
+type CommandAnnotation struct {
+	// Since is the release that introduced the command
+	Since string `json:"since,omitempty"`
+
+	// Deprecated explains why a command is deprecated; empty for supported commands
+	Deprecated string `json:"deprecated,omitempty"`
+
+	// Replacement is the command to use instead of a deprecated one, e.g. "zen task list"
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// CommandAnnotations maps command paths to their annotations, for generated documentation
+// and deprecation warnings. Subcommands inherit Since from their parent, so new top-level
+// commands need an entry, and subcommands only when added in a later release than their
+// parent. Commands added since v0.7.0 ship in v0.8.0.
+var CommandAnnotations = map[string]CommandAnnotation{
+	"zen init":       {Since: "v0.1.0"},
+	"zen config":     {Since: "v0.1.0"},
+	"zen status":     {Since: "v0.1.0"},
+	"zen version":    {Since: "v0.1.0"},
+	"zen completion": {Since: "v0.1.0"},
+	"zen assets":     {Since: "v0.2.0"},
+	"zen task":       {Since: "v0.4.0"},
+
+	"zen dashboard":   {Since: "v0.8.0"},
+	"zen docs":        {Since: "v0.8.0"},
+	"zen extension":   {Since: "v0.8.0"},
+	"zen hooks":       {Since: "v0.8.0"},
+	"zen pr":          {Since: "v0.8.0"},
+	"zen release":     {Since: "v0.8.0"},
+	"zen task list":   {Since: "v0.8.0"},
+	"zen task start":  {Since: "v0.8.0"},
+	"zen task finish": {Since: "v0.8.0"},
+	"zen task status": {Since: "v0.8.0"},
+	"zen task trace":  {Since: "v0.8.0"},
+}
+
+// LookupAnnotation returns a command's annotation, with Since inherited from the nearest
+// parent that has one
+func LookupAnnotation(cmd *cobra.Command) CommandAnnotation {
+	annotation := CommandAnnotations[cmd.CommandPath()]
+	for parent := cmd.Parent(); annotation.Since == "" && parent != nil; parent = parent.Parent() {
+		annotation.Since = CommandAnnotations[parent.CommandPath()].Since
+	}
+	return annotation
+}
+
+// DeprecationNotice returns the message shown for a deprecated command, naming its replacement
+func (a CommandAnnotation) DeprecationNotice() string {
+	if a.Deprecated == "" || a.Replacement == "" {
+		return a.Deprecated
+	}
+	return a.Deprecated + "; use '" + a.Replacement + "' instead"
+}
+
+// ApplyDeprecations marks the commands deprecated in CommandAnnotations, so that running
+// them prints the deprecation notice and help no longer lists them
+func ApplyDeprecations(root *cobra.Command) {
+	if annotation := CommandAnnotations[root.CommandPath()]; annotation.Deprecated != "" {
+		root.Deprecated = annotation.DeprecationNotice()
+	}
+	for _, cmd := range root.Commands() {
+		ApplyDeprecations(cmd)
+	}
+}
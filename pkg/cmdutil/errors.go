@@ -1,8 +1,23 @@
 package cmdutil
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
-// ExitCode represents CLI exit codes
+// ExitCode represents CLI exit codes.
+//
+// Exit codes are part of the scripting contract and do not change between releases:
+//
+//	0  success, including list commands that found no results
+//	1  general failure, including invalid flags and arguments
+//	2  operation cancelled by the user
+//	3  configuration is missing or invalid
+//	4  authentication is missing, expired or was rejected
+//	5  a sync was held for manual review of conflicting changes
+//	6  an operation succeeded for only some of its items (with --fail-on partial)
+//	7  an operation completed with warnings (with --fail-on warning)
 type ExitCode int
 
 const (
@@ -12,10 +27,37 @@ const (
 	ExitError ExitCode = 1
 	// ExitCancel indicates user cancellation
 	ExitCancel ExitCode = 2
+	// ExitConfig indicates missing or invalid configuration
+	ExitConfig ExitCode = 3
 	// ExitAuth indicates authentication failure
 	ExitAuth ExitCode = 4
+	// ExitConflict indicates a sync held for manual conflict review
+	ExitConflict ExitCode = 5
+	// ExitPartial indicates an operation that succeeded for only some of its items
+	ExitPartial ExitCode = 6
+	// ExitWarning indicates an operation that completed with warnings
+	ExitWarning ExitCode = 7
 )
 
+// ExitCodeInfo describes an exit code for generated documentation
+type ExitCodeInfo struct {
+	Code        ExitCode `json:"code"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+}
+
+// ExitCodes lists every exit code zen returns
+var ExitCodes = []ExitCodeInfo{
+	{Code: ExitOK, Name: "ok", Description: "Success, including list commands that found no results"},
+	{Code: ExitError, Name: "error", Description: "General failure, including invalid flags and arguments"},
+	{Code: ExitCancel, Name: "cancel", Description: "Operation cancelled by the user"},
+	{Code: ExitConfig, Name: "config", Description: "Configuration is missing or invalid"},
+	{Code: ExitAuth, Name: "auth", Description: "Authentication is missing, expired or was rejected"},
+	{Code: ExitConflict, Name: "conflict", Description: "A sync was held for manual review of conflicting changes"},
+	{Code: ExitPartial, Name: "partial", Description: "An operation succeeded for only some of its items; only with --fail-on partial or warning"},
+	{Code: ExitWarning, Name: "warning", Description: "An operation completed with warnings; only with --fail-on warning"},
+}
+
 // Common errors
 var (
 	// ErrSilent is returned when an error should not be displayed
@@ -33,6 +75,37 @@ func (e *FlagError) Error() string {
 	return e.Err.Error()
 }
 
+// ExitCodeError is an error that makes zen exit with Code rather than ExitError
+type ExitCodeError struct {
+	Code ExitCode
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// PromptError is returned when a command needs input it would prompt for, but prompting
+// is disabled: in CI, with --non-interactive, or without a terminal
+type PromptError struct {
+	// Need says what input is needed, e.g. "authentication token required"
+	Need string
+	// Hint says how to provide the input without a prompt
+	Hint string
+}
+
+func (e *PromptError) Error() string {
+	msg := fmt.Sprintf("%s but prompting is disabled", e.Need)
+	if e.Hint != "" {
+		msg += "; " + e.Hint
+	}
+	return msg
+}
+
 // NoResultsError indicates no results were found
 type NoResultsError struct {
 	Message string
@@ -45,10 +118,15 @@ func (e NoResultsError) Error() string {
 	return "no results found"
 }
 
-// IsUserCancellation checks if an error represents user cancellation
+// IsUserCancellation checks if an error represents user cancellation, including long
+// operations cancelled with Ctrl-C
 func IsUserCancellation(err error) bool {
 	if err == nil {
 		return false
 	}
+	var cancelErr *CancelError
+	if errors.As(err, &cancelErr) || errors.Is(err, context.Canceled) {
+		return true
+	}
 	return err.Error() == "canceled" || err.Error() == "interrupted"
 }
@@ -1,17 +1,36 @@
 package cmdutil
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExitCodes(t *testing.T) {
 	assert.Equal(t, ExitCode(0), ExitOK)
 	assert.Equal(t, ExitCode(1), ExitError)
 	assert.Equal(t, ExitCode(2), ExitCancel)
+	assert.Equal(t, ExitCode(3), ExitConfig)
 	assert.Equal(t, ExitCode(4), ExitAuth)
+	assert.Equal(t, ExitCode(5), ExitConflict)
+	assert.Equal(t, ExitCode(6), ExitPartial)
+	assert.Equal(t, ExitCode(7), ExitWarning)
+	assert.Len(t, ExitCodes, 8, "every exit code is documented")
+}
+
+func TestExitCodeError(t *testing.T) {
+	baseErr := errors.New("1 of 3 tasks failed to sync")
+	err := fmt.Errorf("sync: %w", &ExitCodeError{Code: ExitPartial, Err: baseErr})
+
+	var codeErr *ExitCodeError
+	require.ErrorAs(t, err, &codeErr)
+	assert.Equal(t, ExitPartial, codeErr.Code)
+	assert.ErrorIs(t, err, baseErr)
+	assert.Equal(t, "sync: 1 of 3 tasks failed to sync", err.Error())
 }
 
 func TestFlagError(t *testing.T) {
@@ -54,6 +73,16 @@ func TestIsUserCancellation(t *testing.T) {
 			err:      errors.New("interrupted"),
 			expected: true,
 		},
+		{
+			name:     "cancelled long operation",
+			err:      fmt.Errorf("task sync: %w", &CancelError{Operation: "task sync"}),
+			expected: true,
+		},
+		{
+			name:     "cancelled context",
+			err:      fmt.Errorf("sync failed: %w", context.Canceled),
+			expected: true,
+		},
 		{
 			name:     "other error",
 			err:      errors.New("something else"),
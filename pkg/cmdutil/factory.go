@@ -11,6 +11,9 @@ import (
 	"github.com/daddia/zen/pkg/assets"
 	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/cache"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+	"github.com/daddia/zen/pkg/extension"
+	"github.com/daddia/zen/pkg/hooks"
 	"github.com/daddia/zen/pkg/iostreams"
 	zentemplate "github.com/daddia/zen/pkg/template"
 	"github.com/daddia/zen/pkg/types"
@@ -30,13 +33,17 @@ type Factory struct {
 	AuthManager        func() (auth.Manager, error)
 	AssetClient        func() (assets.AssetClientInterface, error)
 	Cache              func(basePath string) cache.Manager[string]
+	ResponseCache      func() (*zenhttp.ResponseCache, error)
 	TemplateEngine     func() (TemplateEngineInterface, error)
 	IntegrationManager func() (IntegrationManagerInterface, error)
+	ExtensionManager   func() (*extension.Manager, error)
+	HookRunner         func() (*hooks.Runner, error)
 
 	// Global flag values
-	ConfigFile string
-	DryRun     bool
-	Verbose    bool
+	ConfigFile   string
+	DryRun       bool
+	Verbose      bool
+	OutputFormat string
 
 	// Build information
 	BuildInfo map[string]string
@@ -131,6 +138,13 @@ func NewTestFactory(streams *iostreams.IOStreams) *Factory {
 			serializer := cache.NewStringSerializer()
 			return cache.NewManager(config, logging.NewBasic(), serializer)
 		},
+		ResponseCache: func() (*zenhttp.ResponseCache, error) {
+			config := cache.Config{
+				Backend:     cache.BackendMemory,
+				SizeLimitMB: 10,
+			}
+			return zenhttp.NewResponseCache(config, zenhttp.DefaultResponseFreshness, logging.NewBasic()), nil
+		},
 		TemplateEngine: func() (TemplateEngineInterface, error) {
 			return &testTemplateEngine{}, nil
 		},
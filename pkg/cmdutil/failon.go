@@ -0,0 +1,109 @@
+package cmdutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// FailOn is the policy for which outcomes of a multi-item operation, such as a sync,
+// make the command exit with a failure
+type FailOn string
+
+const (
+	// FailOnError fails only when nothing succeeded
+	FailOnError FailOn = "error"
+	// FailOnPartial also fails when only some items succeeded
+	FailOnPartial FailOn = "partial"
+	// FailOnWarning also fails when the operation completed with warnings
+	FailOnWarning FailOn = "warning"
+)
+
+// failOnLevels orders the policies from the most to the least lenient
+var failOnLevels = []FailOn{FailOnError, FailOnPartial, FailOnWarning}
+
+// AddFailOnFlag adds the --fail-on flag that sets the failure policy of a command
+func AddFailOnFlag(cmd *cobra.Command) {
+	cmd.Flags().String("fail-on", string(FailOnError),
+		"Exit with a failure on this outcome or worse: {error|partial|warning}")
+}
+
+// FailOnFlag returns the policy set with --fail-on, or FailOnError when the command has
+// no such flag
+func FailOnFlag(cmd *cobra.Command) (FailOn, error) {
+	flag := cmd.Flags().Lookup("fail-on")
+	if flag == nil {
+		return FailOnError, nil
+	}
+	return ParseFailOn(flag.Value.String())
+}
+
+// ParseFailOn parses a failure policy, treating an empty value as FailOnError
+func ParseFailOn(value string) (FailOn, error) {
+	policy := FailOn(strings.ToLower(strings.TrimSpace(value)))
+	if policy == "" {
+		return FailOnError, nil
+	}
+	for _, level := range failOnLevels {
+		if policy == level {
+			return policy, nil
+		}
+	}
+	return "", &FlagError{Err: fmt.Errorf("invalid --fail-on value %q, must be one of: error, partial, warning", value)}
+}
+
+// includes reports whether the policy fails on outcomes at level
+func (p FailOn) includes(level FailOn) bool {
+	return p.rank() >= level.rank()
+}
+
+func (p FailOn) rank() int {
+	for i, level := range failOnLevels {
+		if p == level {
+			return i
+		}
+	}
+	return 0
+}
+
+// Outcome counts how each item of a multi-item operation ended
+type Outcome struct {
+	// Succeeded items completed cleanly
+	Succeeded int
+	// Warned items completed with warnings, such as conflicts resolved by policy
+	Warned int
+	// Partial items completed only in part
+	Partial int
+	// Failed items did not complete
+	Failed int
+	// Conflicted items were held for manual review of conflicting changes
+	Conflicted int
+}
+
+// Total returns the number of items
+func (o Outcome) Total() int {
+	return o.Succeeded + o.Warned + o.Partial + o.Failed + o.Conflicted
+}
+
+// Err returns the error the command exits with under policy, carrying the exit code of
+// the outcome, or nil when the outcome passes. Items names what was counted, e.g. "tasks".
+// When nothing succeeded the command always fails, with ExitConflict if every item was
+// held for conflict review.
+func (o Outcome) Err(policy FailOn, items string) error {
+	failed := o.Failed + o.Conflicted
+	completed := o.Succeeded + o.Warned + o.Partial
+
+	switch {
+	case failed > 0 && completed == 0:
+		if o.Failed == 0 {
+			return &ExitCodeError{Code: ExitConflict, Err: fmt.Errorf("%d of %d %s held for conflict review", o.Conflicted, o.Total(), items)}
+		}
+		return &ExitCodeError{Code: ExitError, Err: fmt.Errorf("%d of %d %s failed", failed, o.Total(), items)}
+	case (failed > 0 || o.Partial > 0) && policy.includes(FailOnPartial):
+		return &ExitCodeError{Code: ExitPartial, Err: fmt.Errorf("%d of %d %s did not complete", failed+o.Partial, o.Total(), items)}
+	case o.Warned > 0 && policy.includes(FailOnWarning):
+		return &ExitCodeError{Code: ExitWarning, Err: fmt.Errorf("%d of %d %s completed with warnings", o.Warned, o.Total(), items)}
+	}
+	return nil
+}
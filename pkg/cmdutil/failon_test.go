@@ -0,0 +1,63 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailOnFlag(t *testing.T) {
+	cmd := &cobra.Command{Use: "sync", Run: func(*cobra.Command, []string) {}}
+	AddFailOnFlag(cmd)
+
+	policy, err := FailOnFlag(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, FailOnError, policy)
+
+	cmd.SetArgs([]string{"--fail-on", "Partial"})
+	require.NoError(t, cmd.Execute())
+	policy, err = FailOnFlag(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, FailOnPartial, policy)
+
+	_, err = ParseFailOn("never")
+	var flagErr *FlagError
+	require.ErrorAs(t, err, &flagErr)
+}
+
+func TestOutcomeErr(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome Outcome
+		policy  FailOn
+		code    ExitCode
+	}{
+		{name: "all succeeded", outcome: Outcome{Succeeded: 3}, policy: FailOnWarning, code: ExitOK},
+		{name: "all failed", outcome: Outcome{Failed: 2, Conflicted: 1}, policy: FailOnError, code: ExitError},
+		{name: "all held for conflicts", outcome: Outcome{Conflicted: 2}, policy: FailOnError, code: ExitConflict},
+		{name: "partial passes by default", outcome: Outcome{Succeeded: 2, Failed: 1}, policy: FailOnError, code: ExitOK},
+		{name: "partial fails on partial", outcome: Outcome{Succeeded: 2, Failed: 1}, policy: FailOnPartial, code: ExitPartial},
+		{name: "partial item fails on partial", outcome: Outcome{Partial: 1}, policy: FailOnPartial, code: ExitPartial},
+		{name: "warnings pass on partial", outcome: Outcome{Succeeded: 1, Warned: 1}, policy: FailOnPartial, code: ExitOK},
+		{name: "warnings fail on warning", outcome: Outcome{Succeeded: 1, Warned: 1}, policy: FailOnWarning, code: ExitWarning},
+		{name: "partial outranks warnings", outcome: Outcome{Warned: 1, Failed: 1}, policy: FailOnWarning, code: ExitPartial},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.outcome.Err(tt.policy, "tasks")
+			if tt.code == ExitOK {
+				assert.NoError(t, err)
+				return
+			}
+			var codeErr *ExitCodeError
+			require.ErrorAs(t, err, &codeErr)
+			assert.Equal(t, tt.code, codeErr.Code)
+		})
+	}
+
+	err := Outcome{Succeeded: 2, Failed: 1}.Err(FailOnPartial, "tasks")
+	assert.EqualError(t, err, "1 of 3 tasks did not complete")
+}
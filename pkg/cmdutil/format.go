@@ -0,0 +1,129 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+)
+
+// AddFormatFlags adds the --format and --jq flags used to customise command output
+func AddFormatFlags(cmd *cobra.Command) {
+	cmd.Flags().String("format", "", "Format output using a Go template, applied to each item of a list, e.g. '{{.ID}} {{.Status}}'")
+	cmd.Flags().StringP("jq", "q", "", "Filter JSON output using a jq expression")
+	cmd.MarkFlagsMutuallyExclusive("format", "jq")
+}
+
+// FormatFlags returns the --format and --jq values for a command
+func FormatFlags(cmd *cobra.Command) (tmpl, query string) {
+	if flag := cmd.Flags().Lookup("format"); flag != nil {
+		tmpl = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup("jq"); flag != nil {
+		query = flag.Value.String()
+	}
+	return tmpl, query
+}
+
+// templateFuncs are the helpers available to --format templates
+var templateFuncs = template.FuncMap{
+	"join":  func(sep string, items []string) string { return strings.Join(items, sep) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"timeago": func(t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return time.Since(t).Round(time.Second).String() + " ago"
+	},
+	"truncate": func(length int, s string) string {
+		runes := []rune(s)
+		if len(runes) <= length {
+			return s
+		}
+		return string(runes[:length])
+	},
+}
+
+// renderTemplate executes a Go template against data.
+// When data is a slice the template is executed once per element, one line each.
+func renderTemplate(w io.Writer, text string, data interface{}) error {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return &FlagError{Err: fmt.Errorf("invalid --format template: %w", err)}
+	}
+
+	items := []interface{}{data}
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		items = make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).Interface()
+		}
+	}
+
+	for _, item := range items {
+		if err := tmpl.Execute(w, item); err != nil {
+			return fmt.Errorf("failed to execute --format template: %w", err)
+		}
+		if !strings.HasSuffix(text, "\n") {
+			fmt.Fprintln(w)
+		}
+	}
+
+	return nil
+}
+
+// renderJQ filters the JSON form of data with a jq expression.
+// String results are printed raw; other values are printed as JSON.
+func renderJQ(w io.Writer, expr string, data interface{}) error {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return &FlagError{Err: fmt.Errorf("invalid --jq expression: %w", err)}
+	}
+
+	// Round-trip through JSON so the query sees the same field names as --output json
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	var input interface{}
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return fmt.Errorf("failed to decode output: %w", err)
+	}
+
+	iter := query.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			return fmt.Errorf("jq: %w", err)
+		}
+
+		switch value := v.(type) {
+		case string:
+			fmt.Fprintln(w, value)
+		case nil:
+			fmt.Fprintln(w, "null")
+		default:
+			out, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to encode jq result: %w", err)
+			}
+			fmt.Fprintln(w, string(out))
+		}
+	}
+
+	return nil
+}
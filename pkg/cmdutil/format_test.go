@@ -0,0 +1,109 @@
+package cmdutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type formatTestItem struct {
+	ID     string   `json:"id"`
+	Status string   `json:"status"`
+	Labels []string `json:"labels"`
+}
+
+func TestAddFormatFlags(t *testing.T) {
+	cmd := &cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}}
+	AddFormatFlags(cmd)
+
+	cmd.SetArgs([]string{"--format", "{{.ID}}"})
+	require.NoError(t, cmd.Execute())
+
+	tmpl, query := FormatFlags(cmd)
+	assert.Equal(t, "{{.ID}}", tmpl)
+	assert.Empty(t, query)
+
+	cmd.SetArgs([]string{"--format", "{{.ID}}", "--jq", ".id"})
+	assert.Error(t, cmd.Execute(), "--format and --jq are mutually exclusive")
+}
+
+func TestRenderer_Template(t *testing.T) {
+	items := []formatTestItem{
+		{ID: "PROJ-1", Status: "done", Labels: []string{"api", "backend"}},
+		{ID: "PROJ-2", Status: "proposed"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     interface{}
+		want     string
+	}{
+		{"per list item", "{{.ID}} {{.Status}}", items, "PROJ-1 done\nPROJ-2 proposed\n"},
+		{"single value", "{{.ID}}", items[0], "PROJ-1\n"},
+		{"helpers", `{{upper .Status}} {{join "," .Labels}}`, items[0], "DONE api,backend\n"},
+		{"trailing newline kept", "{{.ID}}\n", items[0], "PROJ-1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams := iostreams.Test()
+			r := NewRenderer(streams, OutputText)
+			r.Template = tt.template
+
+			require.NoError(t, r.Render(tt.data, nil))
+			assert.Equal(t, tt.want, streams.Out.(*bytes.Buffer).String())
+		})
+	}
+}
+
+func TestRenderer_TemplateInvalid(t *testing.T) {
+	r := NewRenderer(iostreams.Test(), OutputText)
+	r.Template = "{{.ID"
+
+	err := r.Render(formatTestItem{}, nil)
+	var flagErr *FlagError
+	assert.ErrorAs(t, err, &flagErr)
+}
+
+func TestRenderer_JQ(t *testing.T) {
+	items := []formatTestItem{
+		{ID: "PROJ-1", Status: "done"},
+		{ID: "PROJ-2", Status: "proposed"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"strings are raw", ".[].id", "PROJ-1\nPROJ-2\n"},
+		{"uses json field names", `.[] | select(.status == "done") | .id`, "PROJ-1\n"},
+		{"objects are json", ".[0] | {id}", "{\"id\":\"PROJ-1\"}\n"},
+		{"numbers", "length", "2\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams := iostreams.Test()
+			r := NewRenderer(streams, OutputJSON)
+			r.JQ = tt.query
+
+			require.NoError(t, r.Render(items, nil))
+			assert.Equal(t, tt.want, streams.Out.(*bytes.Buffer).String())
+		})
+	}
+}
+
+func TestRenderer_JQInvalid(t *testing.T) {
+	r := NewRenderer(iostreams.Test(), OutputText)
+	r.JQ = ".[] |"
+
+	err := r.Render([]string{}, nil)
+	var flagErr *FlagError
+	assert.ErrorAs(t, err, &flagErr)
+}
@@ -0,0 +1,180 @@
+package cmdutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/pkg/iostreams"
+)
+
+// CheckpointMaxAge is the age past which the checkpoint of a cancelled operation is no
+// longer resumed, and the operation starts over
+const CheckpointMaxAge = 24 * time.Hour
+
+// maxListedPending bounds the items not completed that are listed when an operation is
+// cancelled
+const maxListedPending = 10
+
+// CancelError is returned by a long operation cancelled by the user, such as with
+// Ctrl-C, once it has reported what it completed. zen exits with ExitCancel.
+type CancelError struct {
+	Operation string
+	Completed int
+	Total     int
+
+	// Checkpoint is the file recording the items not completed, which running the
+	// command again resumes; empty when none was written
+	Checkpoint string
+}
+
+func (e *CancelError) Error() string {
+	return fmt.Sprintf("%s cancelled: %d of %d completed", e.Operation, e.Completed, e.Total)
+}
+
+// Checkpoint records the items a cancelled long operation did and did not complete
+type Checkpoint struct {
+	Operation string    `json:"operation"`
+	Key       string    `json:"key,omitempty"`
+	Completed []string  `json:"completed"`
+	Pending   []string  `json:"pending"`
+	Cancelled time.Time `json:"cancelled_at"`
+}
+
+// CheckpointPath returns the checkpoint file of the operation name in the workspace
+// whose .zen directory is zenDir
+func CheckpointPath(zenDir, name string) string {
+	return filepath.Join(zenDir, "run", "checkpoints", name+".json")
+}
+
+// LongOperation is the work of a command over many items, such as the tasks of a sync
+// or the rows of an import, that the user may cancel. Commands add the items and mark
+// each completed as it is; when the context of Run is cancelled, the operation reports
+// what it completed and what it did not, writes a checkpoint that running the command
+// again resumes, and returns a CancelError.
+type LongOperation struct {
+	// Name names the operation in messages, e.g. "task sync"
+	Name string
+
+	IO *iostreams.IOStreams
+
+	// CheckpointPath is the file the checkpoint of a cancelled run is written to; none
+	// is written when it is empty
+	CheckpointPath string
+
+	// Key identifies what the operation works on, such as the contents of the file
+	// imported, so that a checkpoint is only resumed by a run on the same
+	Key string
+
+	mu        sync.Mutex
+	items     []string
+	completed map[string]bool
+}
+
+// Add adds items to the operation, in the order they are reported
+func (op *LongOperation) Add(items ...string) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	op.items = append(op.items, items...)
+}
+
+// Complete marks item completed. It is safe to call from concurrent workers.
+func (op *LongOperation) Complete(item string) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	if op.completed == nil {
+		op.completed = map[string]bool{}
+	}
+	op.completed[item] = true
+}
+
+// Resume returns the checkpoint of the last run of the operation when it was cancelled
+// less than CheckpointMaxAge ago with the same Key, or nil. A checkpoint that does not
+// apply, such as one of a file changed since, is removed.
+func (op *LongOperation) Resume() *Checkpoint {
+	if op.CheckpointPath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(op.CheckpointPath) // #nosec G304 - checkpoint in the workspace
+	if err != nil {
+		return nil
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil ||
+		checkpoint.Key != op.Key || time.Since(checkpoint.Cancelled) > CheckpointMaxAge {
+		_ = os.Remove(op.CheckpointPath)
+		return nil
+	}
+	return &checkpoint
+}
+
+// Run runs fn, the work of the operation. When fn succeeds, the checkpoint of an
+// earlier cancelled run is removed. When ctx is cancelled, the completed and pending
+// items are reported on stderr, the pending ones are checkpointed, and a CancelError
+// is returned in place of the error of fn.
+func (op *LongOperation) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	if ctx.Err() == nil {
+		if err == nil && op.CheckpointPath != "" {
+			_ = os.Remove(op.CheckpointPath)
+		}
+		return err
+	}
+
+	op.mu.Lock()
+	checkpoint := &Checkpoint{Operation: op.Name, Key: op.Key, Completed: []string{}, Pending: []string{}, Cancelled: time.Now().UTC()}
+	for _, item := range op.items {
+		if op.completed[item] {
+			checkpoint.Completed = append(checkpoint.Completed, item)
+		} else {
+			checkpoint.Pending = append(checkpoint.Pending, item)
+		}
+	}
+	op.mu.Unlock()
+
+	cancelErr := &CancelError{Operation: op.Name, Completed: len(checkpoint.Completed), Total: len(op.items)}
+	fmt.Fprintf(op.IO.ErrOut, "%s %s\n", op.IO.WarningIcon(), cancelErr.Error())
+	if len(checkpoint.Pending) > 0 {
+		listed := checkpoint.Pending
+		if len(listed) > maxListedPending {
+			listed = append(listed[:maxListedPending:maxListedPending], fmt.Sprintf("and %d more", len(checkpoint.Pending)-maxListedPending))
+		}
+		fmt.Fprintf(op.IO.ErrOut, "  Not completed: %s\n", strings.Join(listed, ", "))
+
+		if op.CheckpointPath != "" {
+			if err := writeCheckpoint(op.CheckpointPath, checkpoint); err != nil {
+				fmt.Fprintf(op.IO.ErrOut, "  Failed to save progress: %v\n", err)
+			} else {
+				cancelErr.Checkpoint = op.CheckpointPath
+				fmt.Fprintf(op.IO.ErrOut, "  Run the command again to resume with the %d not completed\n", len(checkpoint.Pending))
+			}
+		}
+	}
+	return cancelErr
+}
+
+// writeCheckpoint writes checkpoint to path, replacing the file so that an interrupted
+// write leaves the last checkpoint as it was
+func writeCheckpoint(path string, checkpoint *Checkpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmdutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLongOperation_Cancelled(t *testing.T) {
+	streams := iostreams.Test()
+	path := CheckpointPath(t.TempDir(), "task-sync")
+	op := &LongOperation{Name: "task sync", IO: streams, CheckpointPath: path, Key: "pull"}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := op.Run(ctx, func(ctx context.Context) error {
+		op.Add("PROJ-1", "PROJ-2", "PROJ-3")
+		op.Complete("PROJ-1")
+		cancel()
+		return ctx.Err()
+	})
+	var cancelErr *CancelError
+	require.ErrorAs(t, err, &cancelErr)
+	assert.Equal(t, &CancelError{Operation: "task sync", Completed: 1, Total: 3, Checkpoint: path}, cancelErr)
+	assert.True(t, IsUserCancellation(err))
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "task sync cancelled: 1 of 3 completed")
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "Not completed: PROJ-2, PROJ-3")
+
+	checkpoint := op.Resume()
+	require.NotNil(t, checkpoint)
+	assert.Equal(t, []string{"PROJ-1"}, checkpoint.Completed)
+	assert.Equal(t, []string{"PROJ-2", "PROJ-3"}, checkpoint.Pending)
+
+	require.NoError(t, op.Run(context.Background(), func(ctx context.Context) error { return nil }))
+	assert.NoFileExists(t, path, "completed operations remove their checkpoint")
+}
+
+func TestLongOperation_OtherKey(t *testing.T) {
+	path := CheckpointPath(t.TempDir(), "task-import")
+	require.NoError(t, writeCheckpoint(path, &Checkpoint{
+		Operation: "task import",
+		Key:       "backlog.csv sha256:1",
+		Pending:   []string{"row 3"},
+		Cancelled: time.Now(),
+	}))
+
+	op := &LongOperation{Name: "task import", IO: iostreams.Test(), CheckpointPath: path, Key: "backlog.csv sha256:2"}
+	assert.Nil(t, op.Resume(), "checkpoints are resumed by runs on the same")
+	assert.NoFileExists(t, path, "checkpoints that do not apply are removed")
+}
+
+func TestLongOperation_Failed(t *testing.T) {
+	streams := iostreams.Test()
+	path := CheckpointPath(t.TempDir(), "task-import")
+	op := &LongOperation{Name: "task import", IO: streams, CheckpointPath: path}
+
+	err := op.Run(context.Background(), func(ctx context.Context) error {
+		op.Add("row 2")
+		return errors.New("failed to read backlog.csv")
+	})
+	assert.EqualError(t, err, "failed to read backlog.csv")
+	assert.False(t, IsUserCancellation(err))
+	assert.Empty(t, streams.ErrOut.(*bytes.Buffer).String())
+	assert.NoFileExists(t, path)
+}
+
+func TestLongOperation_StaleCheckpoint(t *testing.T) {
+	path := CheckpointPath(t.TempDir(), "task-sync")
+	require.NoError(t, writeCheckpoint(path, &Checkpoint{
+		Operation: "task sync",
+		Pending:   []string{"PROJ-2"},
+		Cancelled: time.Now().Add(-2 * CheckpointMaxAge),
+	}))
+	op := &LongOperation{Name: "task sync", IO: iostreams.Test(), CheckpointPath: path}
+	assert.Nil(t, op.Resume(), "old checkpoints are not resumed")
+	assert.NoFileExists(t, path)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+	assert.Nil(t, op.Resume(), "unreadable checkpoints are not resumed")
+}
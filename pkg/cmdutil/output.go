@@ -0,0 +1,134 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats supported by the global --output flag
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+// OutputFormats lists the valid values for the --output flag
+var OutputFormats = []string{OutputText, OutputJSON, OutputYAML}
+
+// ValidateOutputFormat returns a FlagError if the format is not supported
+func ValidateOutputFormat(format string) error {
+	for _, f := range OutputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return &FlagError{Err: fmt.Errorf("invalid output format %q: must be one of %s", format, strings.Join(OutputFormats, ", "))}
+}
+
+// OutputFormat returns the --output value for a command, including values set on a parent command.
+// Commands without an --output flag report text.
+func OutputFormat(cmd *cobra.Command) string {
+	flag := cmd.Flag("output")
+	if flag == nil || flag.Value.Type() != "string" || flag.Value.String() == "" {
+		return OutputText
+	}
+	return flag.Value.String()
+}
+
+// Renderer writes command results in the selected output format.
+//
+// The contract for machine-readable formats is:
+//   - stdout carries exactly one JSON or YAML document and nothing else
+//   - progress and status messages go to stderr via Progress
+//   - field names come from the json/yaml struct tags and do not change between releases
+//   - failures are reported on stderr and signalled through the exit code (see ExitCode)
+type Renderer struct {
+	IO     *iostreams.IOStreams
+	Format string
+
+	// Template is a Go template applied to the result instead of Format (--format)
+	Template string
+	// JQ is a jq expression applied to the JSON form of the result (--jq)
+	JQ string
+
+	// Columns are the columns of tables rendered as text (--columns)
+	Columns []string
+	// Sort lists the columns tables are sorted by (--sort)
+	Sort string
+}
+
+// NewRenderer creates a renderer for the given output format
+func NewRenderer(io *iostreams.IOStreams, format string) *Renderer {
+	if format == "" {
+		format = OutputText
+	}
+	return &Renderer{IO: io, Format: format}
+}
+
+// NewRendererForCommand creates a renderer using the command's --output, --format, --jq,
+// --columns and --sort flags
+func NewRendererForCommand(io *iostreams.IOStreams, cmd *cobra.Command) *Renderer {
+	r := NewRenderer(io, OutputFormat(cmd))
+	r.Template, r.JQ = FormatFlags(cmd)
+	r.Columns, r.Sort = TableFlags(cmd)
+	return r
+}
+
+// IsMachineReadable reports whether output is JSON, YAML, a custom template or a jq query
+func (r *Renderer) IsMachineReadable() bool {
+	return r.Format == OutputJSON || r.Format == OutputYAML || r.Template != "" || r.JQ != ""
+}
+
+// Progress returns the writer for progress and status messages.
+// For text output this is stdout; for machine-readable output it is stderr so
+// that stdout only carries the rendered document.
+func (r *Renderer) Progress() io.Writer {
+	if r.IsMachineReadable() {
+		return r.IO.ProgressWriter()
+	}
+	return r.IO.Out
+}
+
+// Render writes data as JSON or YAML, or calls text for human-readable output.
+// A --jq query or --format template takes precedence over --output.
+// Unknown formats fall back to text; the root command rejects them before any command runs.
+func (r *Renderer) Render(data interface{}, text func(w io.Writer) error) error {
+	if r.JQ != "" {
+		return renderJQ(r.IO.Out, r.JQ, data)
+	}
+	if r.Template != "" {
+		return renderTemplate(r.IO.Out, r.Template, data)
+	}
+
+	switch r.Format {
+	case OutputJSON:
+		return EncodeJSON(r.IO.Out, data)
+	case OutputYAML:
+		return EncodeYAML(r.IO.Out, data)
+	default:
+		if text == nil {
+			return EncodeYAML(r.IO.Out, data)
+		}
+		return text(r.IO.Out)
+	}
+}
+
+// EncodeJSON writes data as indented JSON
+func EncodeJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// EncodeYAML writes data as YAML
+func EncodeYAML(w io.Writer, data interface{}) error {
+	encoder := yaml.NewEncoder(w)
+	defer encoder.Close()
+	return encoder.Encode(data)
+}
@@ -0,0 +1,90 @@
+package cmdutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type renderTestData struct {
+	TaskID string `json:"task_id" yaml:"task_id"`
+	Count  int    `json:"count" yaml:"count"`
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, format := range OutputFormats {
+		assert.NoError(t, ValidateOutputFormat(format))
+	}
+
+	err := ValidateOutputFormat("xml")
+	require.Error(t, err)
+
+	var flagErr *FlagError
+	assert.ErrorAs(t, err, &flagErr)
+	assert.Contains(t, err.Error(), "text, json, yaml")
+}
+
+func TestOutputFormat(t *testing.T) {
+	root := &cobra.Command{Use: "zen"}
+	root.PersistentFlags().StringP("output", "o", "text", "")
+	child := &cobra.Command{Use: "child", Run: func(*cobra.Command, []string) {}}
+	grandchild := &cobra.Command{Use: "grandchild", Run: func(*cobra.Command, []string) {}}
+	child.AddCommand(grandchild)
+	root.AddCommand(child)
+
+	root.SetArgs([]string{"child", "grandchild", "-o", "json"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, OutputJSON, OutputFormat(grandchild))
+
+	standalone := &cobra.Command{Use: "standalone"}
+	assert.Equal(t, OutputText, OutputFormat(standalone))
+}
+
+func TestRenderer_Render(t *testing.T) {
+	data := renderTestData{TaskID: "PROJ-1", Count: 3}
+	text := func(w io.Writer) error {
+		_, err := io.WriteString(w, "PROJ-1 (3)\n")
+		return err
+	}
+
+	t.Run("json", func(t *testing.T) {
+		streams := iostreams.Test()
+		require.NoError(t, NewRenderer(streams, OutputJSON).Render(data, text))
+
+		var got map[string]interface{}
+		require.NoError(t, json.Unmarshal(streams.Out.(*bytes.Buffer).Bytes(), &got))
+		assert.Equal(t, "PROJ-1", got["task_id"])
+	})
+
+	t.Run("yaml uses the same field names", func(t *testing.T) {
+		streams := iostreams.Test()
+		require.NoError(t, NewRenderer(streams, OutputYAML).Render(data, text))
+
+		var got map[string]interface{}
+		require.NoError(t, yaml.Unmarshal(streams.Out.(*bytes.Buffer).Bytes(), &got))
+		assert.Equal(t, "PROJ-1", got["task_id"])
+		assert.Equal(t, 3, got["count"])
+	})
+
+	t.Run("text", func(t *testing.T) {
+		streams := iostreams.Test()
+		require.NoError(t, NewRenderer(streams, "").Render(data, text))
+		assert.Equal(t, "PROJ-1 (3)\n", streams.Out.(*bytes.Buffer).String())
+	})
+}
+
+func TestRenderer_Progress(t *testing.T) {
+	streams := iostreams.Test()
+
+	assert.Same(t, streams.Out, NewRenderer(streams, OutputText).Progress())
+	assert.Same(t, streams.ErrOut, NewRenderer(streams, OutputJSON).Progress())
+	assert.True(t, NewRenderer(streams, OutputYAML).IsMachineReadable())
+	assert.False(t, NewRenderer(streams, OutputText).IsMachineReadable())
+}
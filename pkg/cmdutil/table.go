@@ -0,0 +1,188 @@
+package cmdutil
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// Column is a column of the tabular output of a list
+type Column[T any] struct {
+	// Name identifies the column in --columns and --sort
+	Name string
+
+	// Header is the heading of the column; defaults to the name in upper case
+	Header string
+
+	// Value returns the cell of an item in the column
+	Value func(T) string
+
+	// Compare orders items by the column; defaults to comparing their values
+	Compare func(a, b T) int
+}
+
+// Table describes the columns a list of items can be shown with, selected with
+// --columns, and sorted by, with --sort
+type Table[T any] struct {
+	Columns []Column[T]
+
+	// Default are the names of the columns shown without --columns
+	Default []string
+}
+
+// AddTableFlags adds the --columns and --sort flags that select the columns of the
+// tabular output of a list and order its items
+func AddTableFlags[T any](cmd *cobra.Command, table Table[T]) {
+	cmd.Flags().StringSlice("columns", nil, fmt.Sprintf("Columns to show (%s)", strings.Join(table.Names(), ", ")))
+	cmd.Flags().String("sort", "", "Sort by columns, descending when prefixed with '-', e.g. '-updated,id'")
+}
+
+// TableFlags returns the --columns and --sort values for a command
+func TableFlags(cmd *cobra.Command) (columns []string, sort string) {
+	if flag := cmd.Flags().Lookup("columns"); flag != nil {
+		columns, _ = cmd.Flags().GetStringSlice("columns")
+	}
+	if flag := cmd.Flags().Lookup("sort"); flag != nil {
+		sort = flag.Value.String()
+	}
+	return columns, sort
+}
+
+// Names returns the names of the columns of the table
+func (t Table[T]) Names() []string {
+	names := make([]string, 0, len(t.Columns))
+	for _, column := range t.Columns {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+// column returns the column named name, compared case-insensitively
+func (t Table[T]) column(name string) (Column[T], error) {
+	name = strings.TrimSpace(name)
+	for _, column := range t.Columns {
+		if strings.EqualFold(column.Name, name) {
+			return column, nil
+		}
+	}
+	return Column[T]{}, &FlagError{Err: fmt.Errorf("unknown column %q: must be one of %s", name, strings.Join(t.Names(), ", "))}
+}
+
+// Select returns the columns named, or the default columns when none are
+func (t Table[T]) Select(names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		names = t.Default
+	}
+	columns := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		column, err := t.column(name)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// Sort orders items by the columns of spec, a comma-separated list of column names
+// each prefixed with '-' to sort in descending order. Items equal in every column keep
+// their order.
+func (t Table[T]) Sort(items []T, spec string) error {
+	type key struct {
+		column     Column[T]
+		descending bool
+	}
+
+	var keys []key
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		descending := strings.HasPrefix(name, "-")
+		column, err := t.column(strings.TrimPrefix(strings.TrimPrefix(name, "-"), "+"))
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key{column: column, descending: descending})
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		for _, k := range keys {
+			var c int
+			if k.column.Compare != nil {
+				c = k.column.Compare(a, b)
+			} else {
+				c = cmp.Compare(strings.ToLower(k.column.Value(a)), strings.ToLower(k.column.Value(b)))
+			}
+			if k.descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return nil
+}
+
+// Rows returns the headers of columns and the cells of items in them
+func (t Table[T]) Rows(columns []Column[T], items []T) (headers []string, rows [][]string) {
+	headers = make([]string, 0, len(columns))
+	for _, column := range columns {
+		header := column.Header
+		if header == "" {
+			header = strings.ToUpper(column.Name)
+		}
+		headers = append(headers, header)
+	}
+	rows = make([][]string, 0, len(items))
+	for _, item := range items {
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			row = append(row, column.Value(item))
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+// RenderTable sorts items by the renderer's Sort and renders them, as a table of the
+// renderer's Columns for text output: aligned with headers on a terminal, and tab
+// separated without headers otherwise. empty writes the text output of an empty list.
+func RenderTable[T any](r *Renderer, table Table[T], items []T, empty func(w io.Writer) error) error {
+	columns, err := table.Select(r.Columns)
+	if err != nil {
+		return err
+	}
+	if err := table.Sort(items, r.Sort); err != nil {
+		return err
+	}
+
+	return r.Render(items, func(w io.Writer) error {
+		if len(items) == 0 && empty != nil {
+			return empty(w)
+		}
+		return WriteTable(w, r.IO, table, columns, items)
+	})
+}
+
+// WriteTable writes items as a table of columns
+func WriteTable[T any](w io.Writer, streams *iostreams.IOStreams, table Table[T], columns []Column[T], items []T) error {
+	headers, rows := table.Rows(columns, items)
+	if streams.IsStdoutTTY() {
+		_, err := fmt.Fprint(w, streams.FormatTable(headers, rows))
+		return err
+	}
+	_, err := fmt.Fprint(w, streams.FormatMachineTable(headers, rows))
+	return err
+}
@@ -0,0 +1,99 @@
+package cmdutil
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tableTestItem struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+var tableTest = Table[tableTestItem]{
+	Columns: []Column[tableTestItem]{
+		{Name: "name", Value: func(i tableTestItem) string { return i.Name }},
+		{Name: "size", Header: "BYTES", Value: func(i tableTestItem) string { return strconv.Itoa(i.Size) },
+			Compare: func(a, b tableTestItem) int { return a.Size - b.Size }},
+	},
+	Default: []string{"name"},
+}
+
+func TestTableSelect(t *testing.T) {
+	columns, err := tableTest.Select(nil)
+	require.NoError(t, err)
+	require.Len(t, columns, 1)
+	assert.Equal(t, "name", columns[0].Name)
+
+	columns, err = tableTest.Select([]string{"Size", "name"})
+	require.NoError(t, err)
+	assert.Equal(t, "size", columns[0].Name)
+
+	_, err = tableTest.Select([]string{"owner"})
+	var flagErr *FlagError
+	require.ErrorAs(t, err, &flagErr)
+	assert.Contains(t, err.Error(), "name, size")
+}
+
+func TestTableSort(t *testing.T) {
+	items := []tableTestItem{{"b", 10}, {"a", 2}, {"c", 10}}
+
+	require.NoError(t, tableTest.Sort(items, "-size"))
+	assert.Equal(t, []tableTestItem{{"b", 10}, {"c", 10}, {"a", 2}}, items, "equal items keep their order")
+
+	require.NoError(t, tableTest.Sort(items, "-size,-name"))
+	assert.Equal(t, []tableTestItem{{"c", 10}, {"b", 10}, {"a", 2}}, items)
+
+	require.NoError(t, tableTest.Sort(items, "name"))
+	assert.Equal(t, []tableTestItem{{"a", 2}, {"b", 10}, {"c", 10}}, items)
+
+	var flagErr *FlagError
+	assert.ErrorAs(t, tableTest.Sort(items, "owner"), &flagErr)
+}
+
+func TestRenderTable(t *testing.T) {
+	streams := iostreams.Test()
+	out := streams.Out.(*bytes.Buffer)
+	items := []tableTestItem{{"b", 10}, {"a", 2}}
+
+	r := NewRenderer(streams, OutputText)
+	r.Columns, r.Sort = []string{"name", "size"}, "size"
+	require.NoError(t, RenderTable(r, tableTest, items, nil))
+	assert.Equal(t, "a\t2\nb\t10\n", out.String())
+
+	out.Reset()
+	r = NewRenderer(streams, OutputJSON)
+	r.Sort = "-name"
+	require.NoError(t, RenderTable(r, tableTest, items, nil))
+	assert.JSONEq(t, `[{"name":"b","size":10},{"name":"a","size":2}]`, out.String(), "machine-readable output is sorted")
+
+	out.Reset()
+	r = NewRenderer(streams, OutputText)
+	require.NoError(t, RenderTable(r, tableTest, nil, func(w io.Writer) error {
+		_, err := io.WriteString(w, "No items.\n")
+		return err
+	}))
+	assert.Equal(t, "No items.\n", out.String())
+}
+
+func TestTableFlags(t *testing.T) {
+	cmd := &cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}}
+	AddTableFlags(cmd, tableTest)
+	require.NoError(t, cmd.ParseFlags([]string{"--columns", "name,size", "--sort", "-size"}))
+
+	columns, sort := TableFlags(cmd)
+	assert.Equal(t, []string{"name", "size"}, columns)
+	assert.Equal(t, "-size", sort)
+	assert.Contains(t, cmd.Flags().Lookup("columns").Usage, "name, size")
+
+	r := NewRendererForCommand(iostreams.Test(), cmd)
+	assert.Equal(t, columns, r.Columns)
+	assert.Equal(t, "-size", r.Sort)
+}
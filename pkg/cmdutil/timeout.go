@@ -0,0 +1,38 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/spf13/cobra"
+)
+
+// AddTimeoutFlag adds the --timeout flag that bounds each request of a command to
+// external services, over network.timeout and network.provider_timeouts
+func AddTimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().Duration("timeout", 0, "Timeout of each request to external services, e.g. 1m (default: network.timeout)")
+}
+
+// ApplyTimeoutFlag sets the --timeout of commands with the flag on the context of cmd,
+// where httpx.CommandTimeout finds it. The timeout applies to this run of the command
+// only, not to commands run after it in the same process.
+func ApplyTimeoutFlag(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("timeout")
+	if flag == nil {
+		return nil
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return &FlagError{Err: err}
+	}
+	if timeout < 0 {
+		return &FlagError{Err: fmt.Errorf("invalid --timeout %s, must not be negative", timeout)}
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd.SetContext(httpx.WithCommandTimeout(ctx, timeout))
+	return nil
+}
@@ -0,0 +1,29 @@
+package cmdutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTimeoutFlag(t *testing.T) {
+	cmd := &cobra.Command{Use: "sync"}
+	AddTimeoutFlag(cmd)
+	require.NoError(t, cmd.Flags().Parse([]string{"--timeout", "90s"}))
+	require.NoError(t, ApplyTimeoutFlag(cmd))
+	assert.Equal(t, 90*time.Second, httpx.CommandTimeout(cmd.Context()))
+	assert.Equal(t, time.Duration(0), httpx.CommandTimeout(context.Background()), "the timeout does not outlive the command")
+
+	cmd = &cobra.Command{Use: "sync"}
+	AddTimeoutFlag(cmd)
+	require.NoError(t, cmd.Flags().Parse([]string{"--timeout", "-1s"}))
+	var flagErr *FlagError
+	assert.ErrorAs(t, ApplyTimeoutFlag(cmd), &flagErr)
+
+	assert.NoError(t, ApplyTimeoutFlag(&cobra.Command{Use: "list"}), "commands without the flag keep the configured timeouts")
+}
@@ -0,0 +1,201 @@
+// Package cron parses cron schedules and finds the times they fire
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchDays bounds how far Prev and Next look for a day the schedule fires on, enough
+// for schedules such as "0 0 29 2 *" that fire every four years
+const searchDays = 8 * 366
+
+// descriptors are the schedules with names
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 1",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field is the range of a schedule field
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// Schedule is a parsed cron schedule
+type Schedule struct {
+	spec string
+
+	minutes, hours, days, months, weekdays uint64
+
+	// anyDay and anyWeekday are set when the day fields are '*', which decides how they
+	// combine: a day must match both, or either when both are restricted
+	anyDay, anyWeekday bool
+}
+
+// Parse parses a schedule of five fields: minute, hour, day of month, month and day of
+// week. Fields are '*', values, ranges (1-5), steps (*/15, 1-30/2) and lists of them
+// (1,15); months and days of the week may be named (jan, mon), and Sunday is 0 or 7.
+// The descriptors @yearly, @monthly, @weekly (Mondays), @daily and @hourly are also
+// accepted.
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	expr := spec
+	if strings.HasPrefix(expr, "@") {
+		var ok bool
+		if expr, ok = descriptors[strings.ToLower(expr)]; !ok {
+			return nil, fmt.Errorf("unknown schedule %s", spec)
+		}
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("schedule %q has %d fields, want 5: minute hour day-of-month month day-of-week", spec, len(parts))
+	}
+
+	s := &Schedule{spec: spec}
+	sets := []*uint64{&s.minutes, &s.hours, &s.days, &s.months, &s.weekdays}
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		*sets[i] = set
+	}
+
+	// Sunday is 0 or 7
+	if s.weekdays&(1<<7) != 0 {
+		s.weekdays |= 1
+	}
+	s.anyDay, s.anyWeekday = parts[2] == "*", parts[4] == "*"
+	return s, nil
+}
+
+// String returns the schedule as it was given
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(expr, ",") {
+		rng, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %s %q", f.name, item)
+			}
+			rng, step = item[:i], n
+		}
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = f.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// "5/15" runs from 5 to the end of the range
+				hi = f.max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range in %s %q", f.name, item)
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func (f field) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s %q is not between %d and %d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Prev returns the last time, at or before t, the schedule fires, or the zero time
+// when it fired in none of the last years
+func (s *Schedule) Prev(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 0; i < searchDays; i++ {
+		if s.firesOn(day) {
+			for h := 23; h >= 0; h-- {
+				for m := 59; m >= 0; m-- {
+					fire := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location())
+					if s.hours&(1<<h) != 0 && s.minutes&(1<<m) != 0 && !fire.After(t) {
+						return fire
+					}
+				}
+			}
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Time{}
+}
+
+// Next returns the first time, after t, the schedule fires, or the zero time when it
+// fires in none of the next years
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 0; i < searchDays; i++ {
+		if s.firesOn(day) {
+			for h := 0; h < 24; h++ {
+				for m := 0; m < 60; m++ {
+					fire := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location())
+					if s.hours&(1<<h) != 0 && s.minutes&(1<<m) != 0 && fire.After(t) {
+						return fire
+					}
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+// firesOn reports whether the schedule fires on the day
+func (s *Schedule) firesOn(day time.Time) bool {
+	if s.months&(1<<uint(day.Month())) == 0 {
+		return false
+	}
+	dayOK := s.days&(1<<uint(day.Day())) != 0
+	weekdayOK := s.weekdays&(1<<uint(day.Weekday())) != 0
+	if s.anyDay || s.anyWeekday {
+		return dayOK && weekdayOK
+	}
+	return dayOK || weekdayOK
+}
@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestSchedule(t *testing.T) {
+	// Friday 16 October 2026, 10:30
+	now := date("2026-10-16 10:30")
+
+	tests := []struct {
+		spec string
+		prev string
+		next string
+	}{
+		{spec: "@daily", prev: "2026-10-16 00:00", next: "2026-10-17 00:00"},
+		{spec: "@weekly", prev: "2026-10-12 00:00", next: "2026-10-19 00:00"},
+		{spec: "@monthly", prev: "2026-10-01 00:00", next: "2026-11-01 00:00"},
+		{spec: "*/15 * * * *", prev: "2026-10-16 10:30", next: "2026-10-16 10:45"},
+		{spec: "0 9 * * mon-fri", prev: "2026-10-16 09:00", next: "2026-10-19 09:00"},
+		{spec: "0 9 * * 7", prev: "2026-10-11 09:00", next: "2026-10-18 09:00"},
+		{spec: "0 9 1,15 jan,jul *", prev: "2026-07-15 09:00", next: "2027-01-01 09:00"},
+		{spec: "0 0 1 * 5", prev: "2026-10-16 00:00", next: "2026-10-23 00:00"},
+		{spec: "0 0 29 2 *", prev: "2024-02-29 00:00", next: "2028-02-29 00:00"},
+		{spec: "5/20 10 16 10 *", prev: "2026-10-16 10:25", next: "2026-10-16 10:45"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, date(tt.prev), s.Prev(now))
+			assert.Equal(t, date(tt.next), s.Next(now))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, spec := range []string{"", "@fortnightly", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *"} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,202 @@
+// Package dirs locates the files zen keeps for the user rather than for a workspace:
+// configuration, caches, state such as logs, and installed extensions.
+//
+// On Linux and other Unix systems they follow the XDG Base Directory specification.
+// macOS and Windows use their platform conventions unless the XDG variables are set:
+//
+//	        Linux                     macOS                              Windows
+//	config  $XDG_CONFIG_HOME/zen      ~/Library/Application Support/zen  %AppData%\zen
+//	cache   $XDG_CACHE_HOME/zen       ~/Library/Caches/zen               %LocalAppData%\zen\cache
+//	state   $XDG_STATE_HOME/zen       ~/Library/Application Support/zen  %LocalAppData%\zen
+//	logs    $XDG_STATE_HOME/zen/logs  ~/Library/Logs/zen                 %LocalAppData%\zen\logs
+//	data    $XDG_DATA_HOME/zen        ~/Library/Application Support/zen  %LocalAppData%\zen
+//
+// The XDG variables default to ~/.config, ~/.cache, ~/.local/state and ~/.local/share.
+// ZEN_CONFIG_DIR, ZEN_CACHE_DIR, ZEN_STATE_DIR and ZEN_DATA_DIR override each
+// directory. Earlier versions kept all of these in ~/.zen, which LegacyDir returns.
+package dirs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// App is the name of zen's directory within each base directory
+const App = "zen"
+
+// Kind identifies one of the user-level directories
+type Kind string
+
+// Directory kinds
+const (
+	Config Kind = "config"
+	Cache  Kind = "cache"
+	State  Kind = "state"
+	Logs   Kind = "logs"
+	Data   Kind = "data"
+)
+
+// Kinds lists the directory kinds
+var Kinds = []Kind{Config, Cache, State, Logs, Data}
+
+// locator resolves directories for an operating system, environment and home directory,
+// so that every platform can be tested on any of them
+type locator struct {
+	goos   string
+	getenv func(string) string
+	home   string
+}
+
+// system returns the locator of the running system
+func system() (locator, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return locator{}, fmt.Errorf("failed to find the home directory: %w", err)
+	}
+	return locator{goos: runtime.GOOS, getenv: os.Getenv, home: home}, nil
+}
+
+// Dir returns the user-level directory of kind. The directory is not created.
+func Dir(kind Kind) (string, error) {
+	l, err := system()
+	if err != nil {
+		return "", err
+	}
+	return l.dir(kind)
+}
+
+// ConfigDir returns the directory of the user configuration file
+func ConfigDir() (string, error) {
+	return Dir(Config)
+}
+
+// CacheDir returns the directory of caches, which zen can rebuild when they are removed
+func CacheDir() (string, error) {
+	return Dir(Cache)
+}
+
+// StateDir returns the directory of state kept between runs, such as the last update check
+func StateDir() (string, error) {
+	return Dir(State)
+}
+
+// LogDir returns the directory of logs
+func LogDir() (string, error) {
+	return Dir(Logs)
+}
+
+// DataDir returns the directory of data installed by the user, such as extensions
+func DataDir() (string, error) {
+	return Dir(Data)
+}
+
+// LegacyDir returns ~/.zen, where earlier versions kept all user-level files
+func LegacyDir() (string, error) {
+	l, err := system()
+	if err != nil {
+		return "", err
+	}
+	return l.legacy(), nil
+}
+
+// CachePath returns elem joined to the cache directory, or to the legacy directory when
+// the cache directory cannot be found, for use in configuration defaults
+func CachePath(elem ...string) string {
+	dir, err := CacheDir()
+	if err != nil {
+		dir = filepath.Join("~", ".zen", "cache")
+	}
+	return filepath.Join(append([]string{dir}, elem...)...)
+}
+
+func (l locator) legacy() string {
+	return filepath.Join(l.home, ".zen")
+}
+
+func (l locator) dir(kind Kind) (string, error) {
+	switch kind {
+	case Config:
+		return l.base("ZEN_CONFIG_DIR", "XDG_CONFIG_HOME", l.platformConfig()), nil
+	case Cache:
+		return l.base("ZEN_CACHE_DIR", "XDG_CACHE_HOME", l.platformCache()), nil
+	case State:
+		return l.base("ZEN_STATE_DIR", "XDG_STATE_HOME", l.platformState()), nil
+	case Data:
+		return l.base("ZEN_DATA_DIR", "XDG_DATA_HOME", l.platformData()), nil
+	case Logs:
+		// Logs are state, and follow the state directory wherever it is set explicitly
+		if l.goos == "darwin" && l.getenv("ZEN_STATE_DIR") == "" && !filepath.IsAbs(l.getenv("XDG_STATE_HOME")) {
+			return filepath.Join(l.home, "Library", "Logs", App), nil
+		}
+		state, err := l.dir(State)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(state, "logs"), nil
+	}
+	return "", fmt.Errorf("unknown directory kind: %s", kind)
+}
+
+// base returns the directory named by the zen variable, else zen's directory in the one
+// named by the XDG variable, else the platform default. The XDG specification requires
+// relative paths in its variables to be ignored.
+func (l locator) base(zenVar, xdgVar, platform string) string {
+	if dir := l.getenv(zenVar); dir != "" {
+		return dir
+	}
+	if dir := l.getenv(xdgVar); filepath.IsAbs(dir) {
+		return filepath.Join(dir, App)
+	}
+	return platform
+}
+
+func (l locator) platformConfig() string {
+	switch l.goos {
+	case "darwin":
+		return filepath.Join(l.home, "Library", "Application Support", App)
+	case "windows":
+		return filepath.Join(l.windowsDir("AppData", "Roaming"), App)
+	}
+	return filepath.Join(l.home, ".config", App)
+}
+
+func (l locator) platformCache() string {
+	switch l.goos {
+	case "darwin":
+		return filepath.Join(l.home, "Library", "Caches", App)
+	case "windows":
+		return filepath.Join(l.windowsDir("LocalAppData", "Local"), App, "cache")
+	}
+	return filepath.Join(l.home, ".cache", App)
+}
+
+func (l locator) platformState() string {
+	switch l.goos {
+	case "darwin":
+		return filepath.Join(l.home, "Library", "Application Support", App)
+	case "windows":
+		return filepath.Join(l.windowsDir("LocalAppData", "Local"), App)
+	}
+	return filepath.Join(l.home, ".local", "state", App)
+}
+
+func (l locator) platformData() string {
+	switch l.goos {
+	case "darwin":
+		return filepath.Join(l.home, "Library", "Application Support", App)
+	case "windows":
+		return filepath.Join(l.windowsDir("LocalAppData", "Local"), App)
+	}
+	return filepath.Join(l.home, ".local", "share", App)
+}
+
+// windowsDir returns the folder named by the environment variable, else its usual place
+// in the profile
+func (l locator) windowsDir(envVar, profileDir string) string {
+	if dir := l.getenv(envVar); dir != "" {
+		return dir
+	}
+	return filepath.Join(l.home, "AppData", profileDir)
+}
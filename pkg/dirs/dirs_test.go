@@ -0,0 +1,111 @@
+package dirs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLocator(goos string, env map[string]string) locator {
+	return locator{goos: goos, getenv: func(name string) string { return env[name] }, home: "/home/ada"}
+}
+
+func TestLocatorDefaults(t *testing.T) {
+	tests := []struct {
+		goos string
+		want map[Kind]string
+	}{
+		{
+			goos: "linux",
+			want: map[Kind]string{
+				Config: "/home/ada/.config/zen",
+				Cache:  "/home/ada/.cache/zen",
+				State:  "/home/ada/.local/state/zen",
+				Logs:   "/home/ada/.local/state/zen/logs",
+				Data:   "/home/ada/.local/share/zen",
+			},
+		},
+		{
+			goos: "darwin",
+			want: map[Kind]string{
+				Config: "/home/ada/Library/Application Support/zen",
+				Cache:  "/home/ada/Library/Caches/zen",
+				State:  "/home/ada/Library/Application Support/zen",
+				Logs:   "/home/ada/Library/Logs/zen",
+				Data:   "/home/ada/Library/Application Support/zen",
+			},
+		},
+		{
+			goos: "windows",
+			want: map[Kind]string{
+				Config: "/home/ada/AppData/Roaming/zen",
+				Cache:  "/home/ada/AppData/Local/zen/cache",
+				State:  "/home/ada/AppData/Local/zen",
+				Logs:   "/home/ada/AppData/Local/zen/logs",
+				Data:   "/home/ada/AppData/Local/zen",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			l := testLocator(tt.goos, nil)
+			for _, kind := range Kinds {
+				dir, err := l.dir(kind)
+				require.NoError(t, err)
+				assert.Equal(t, filepath.FromSlash(tt.want[kind]), dir, kind)
+			}
+		})
+	}
+}
+
+func TestLocatorEnvironment(t *testing.T) {
+	t.Run("xdg variables apply on every platform", func(t *testing.T) {
+		env := map[string]string{"XDG_CONFIG_HOME": "/xdg/config", "XDG_STATE_HOME": "/xdg/state"}
+		for _, goos := range []string{"linux", "darwin", "windows"} {
+			l := testLocator(goos, env)
+			dir, err := l.dir(Config)
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join("/xdg/config", "zen"), dir, goos)
+			dir, err = l.dir(Logs)
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join("/xdg/state", "zen", "logs"), dir, goos)
+		}
+	})
+
+	t.Run("relative xdg variables are ignored", func(t *testing.T) {
+		dir, err := testLocator("linux", map[string]string{"XDG_CACHE_HOME": "cache"}).dir(Cache)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.FromSlash("/home/ada/.cache/zen"), dir)
+	})
+
+	t.Run("zen variables take precedence", func(t *testing.T) {
+		l := testLocator("linux", map[string]string{"ZEN_CACHE_DIR": "/shared/zen", "XDG_CACHE_HOME": "/xdg/cache"})
+		dir, err := l.dir(Cache)
+		require.NoError(t, err)
+		assert.Equal(t, "/shared/zen", dir)
+	})
+
+	t.Run("windows folders", func(t *testing.T) {
+		l := testLocator("windows", map[string]string{"AppData": `C:\Users\ada\AppData\Roaming`, "LocalAppData": `D:\Local`})
+		dir, err := l.dir(Config)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(`C:\Users\ada\AppData\Roaming`, "zen"), dir)
+		dir, err = l.dir(Cache)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(`D:\Local`, "zen", "cache"), dir)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		_, err := testLocator("linux", nil).dir("temp")
+		assert.ErrorContains(t, err, "unknown directory kind")
+	})
+}
+
+func TestCachePath(t *testing.T) {
+	t.Setenv("ZEN_CACHE_DIR", "/var/cache/zen")
+
+	assert.Equal(t, filepath.Join("/var/cache/zen", "assets"), CachePath("assets"))
+}
@@ -0,0 +1,190 @@
+package dirs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// MigrationStatus is the outcome of a migration
+type MigrationStatus string
+
+const (
+	// MigrationPending is a migration that has not been carried out, as in a dry run
+	MigrationPending MigrationStatus = "pending"
+	// MigrationMoved is a migration whose file or directory was moved
+	MigrationMoved MigrationStatus = "moved"
+	// MigrationSkipped is a migration left undone because its destination exists
+	MigrationSkipped MigrationStatus = "skipped"
+)
+
+// Migration moves a file or directory from the legacy ~/.zen directory to its place in
+// the user-level directories
+type Migration struct {
+	Name   string          `json:"name"`
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Status MigrationStatus `json:"status"`
+}
+
+// legacyEntry is a file or directory earlier versions kept in ~/.zen
+type legacyEntry struct {
+	name string
+	kind Kind
+	to   string
+}
+
+// legacyEntries lists the files and directories of ~/.zen and where they belong now.
+// The asset cache was kept in ~/.zen/library, and other caches in ~/.zen/cache, whose
+// namespaces are moved one by one so that they join any already in the cache directory.
+var legacyEntries = []legacyEntry{
+	{name: "config", kind: Config, to: "config"},
+	{name: "config.yaml", kind: Config, to: "config.yaml"},
+	{name: "config.yml", kind: Config, to: "config.yml"},
+	{name: "library", kind: Cache, to: "assets"},
+	{name: "update-check.json", kind: State, to: "update-check.json"},
+	{name: "extensions", kind: Data, to: "extensions"},
+	{name: "logs", kind: Logs},
+}
+
+// legacyCacheDir is the directory of ~/.zen that held caches other than the asset cache
+const legacyCacheDir = "cache"
+
+// ErrLegacyWorkspace is returned when ~/.zen is the .zen directory of a workspace in
+// the home directory, whose files must stay where they are
+var ErrLegacyWorkspace = errors.New("~/.zen is the .zen directory of a workspace in the home directory")
+
+// PlanMigration returns the migrations that move the files of ~/.zen to the user-level
+// directories, all pending. It returns none when there is no ~/.zen.
+func PlanMigration() ([]Migration, error) {
+	l, err := system()
+	if err != nil {
+		return nil, err
+	}
+	return l.planMigration()
+}
+
+func (l locator) planMigration() ([]Migration, error) {
+	legacy := l.legacy()
+	if _, err := os.Stat(legacy); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	// Workspaces keep their tasks in .zen/work
+	if _, err := os.Stat(filepath.Join(legacy, "work")); err == nil {
+		return nil, ErrLegacyWorkspace
+	}
+
+	var migrations []Migration
+	add := func(name, from, to string) {
+		if _, err := os.Lstat(from); err == nil {
+			migrations = append(migrations, Migration{Name: name, From: from, To: to, Status: MigrationPending})
+		}
+	}
+
+	for _, entry := range legacyEntries {
+		dir, err := l.dir(entry.kind)
+		if err != nil {
+			return nil, err
+		}
+		add(entry.name, filepath.Join(legacy, entry.name), filepath.Join(dir, entry.to))
+	}
+
+	cacheDir, err := l.dir(Cache)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := os.ReadDir(filepath.Join(legacy, legacyCacheDir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, namespace := range namespaces {
+		add(filepath.Join(legacyCacheDir, namespace.Name()),
+			filepath.Join(legacy, legacyCacheDir, namespace.Name()),
+			filepath.Join(cacheDir, namespace.Name()))
+	}
+
+	return migrations, nil
+}
+
+// Migrate carries out the migrations in order and returns them with their outcome.
+// Destinations that exist are never overwritten: their migrations are skipped. It stops
+// at the first failure, and returns the migrations carried out until then. ~/.zen is
+// removed once it is empty.
+func Migrate(migrations []Migration) ([]Migration, error) {
+	done := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		if _, err := os.Lstat(migration.To); err == nil {
+			migration.Status = MigrationSkipped
+			done = append(done, migration)
+			continue
+		}
+		if err := move(migration.From, migration.To); err != nil {
+			return done, fmt.Errorf("failed to move %s to %s: %w", migration.From, migration.To, err)
+		}
+		migration.Status = MigrationMoved
+		done = append(done, migration)
+	}
+
+	if legacy, err := LegacyDir(); err == nil {
+		removeEmpty(filepath.Join(legacy, legacyCacheDir))
+		removeEmpty(legacy)
+	}
+	return done, nil
+}
+
+// rename is os.Rename, replaced in tests to move across file systems
+var rename = os.Rename
+
+// move renames from to to, creating the parent directories of to. Across file systems,
+// where renames fail, it copies from and then removes it, so that an interrupted move
+// leaves the original in place.
+func move(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0700); err != nil {
+		return err
+	}
+	if err := rename(from, to); err == nil {
+		return nil
+	}
+
+	info, err := os.Lstat(from)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		err = os.CopyFS(to, os.DirFS(from))
+	} else {
+		err = copyFile(from, to, info.Mode().Perm())
+	}
+	if err != nil {
+		_ = os.RemoveAll(to)
+		return err
+	}
+	return os.RemoveAll(from)
+}
+
+func copyFile(from, to string, perm os.FileMode) error {
+	in, err := os.Open(from) // #nosec G304 - path is a file of the legacy directory
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm) // #nosec G304 - path is in a user-level directory
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// removeEmpty removes dir when it is an empty directory
+func removeEmpty(dir string) {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+		_ = os.Remove(dir)
+	}
+}
@@ -0,0 +1,126 @@
+package dirs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupHome points the home and user-level directories at temporary directories, and
+// returns the home directory
+func setupHome(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	for _, name := range []string{"ZEN_CONFIG_DIR", "ZEN_CACHE_DIR", "ZEN_STATE_DIR", "ZEN_DATA_DIR"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg", "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "xdg", "cache"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "xdg", "state"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "xdg", "data"))
+	return home
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestPlanMigration_NoLegacyDir(t *testing.T) {
+	setupHome(t)
+
+	migrations, err := PlanMigration()
+
+	require.NoError(t, err)
+	assert.Empty(t, migrations)
+}
+
+func TestPlanMigration_Workspace(t *testing.T) {
+	home := setupHome(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".zen", "work", "tasks"), 0700))
+
+	_, err := PlanMigration()
+
+	assert.ErrorIs(t, err, ErrLegacyWorkspace)
+}
+
+func TestMigrate(t *testing.T) {
+	home := setupHome(t)
+	legacy := filepath.Join(home, ".zen")
+	writeFile(t, filepath.Join(legacy, "config"), "log_level: debug\n")
+	writeFile(t, filepath.Join(legacy, "library", "index.json"), "{}")
+	writeFile(t, filepath.Join(legacy, "cache", "responses", "index.json"), "{}")
+	writeFile(t, filepath.Join(legacy, "update-check.json"), "{}")
+	writeFile(t, filepath.Join(legacy, "extensions", "zen-hello", "zen-hello"), "#!/bin/sh\n")
+	// An existing extensions directory is not overwritten
+	writeFile(t, filepath.Join(home, "xdg", "data", "zen", "extensions", "zen-other", "zen-other"), "#!/bin/sh\n")
+
+	migrations, err := PlanMigration()
+	require.NoError(t, err)
+	require.Len(t, migrations, 5)
+	for _, migration := range migrations {
+		assert.Equal(t, MigrationPending, migration.Status)
+	}
+
+	migrated, err := Migrate(migrations)
+	require.NoError(t, err)
+
+	status := map[string]MigrationStatus{}
+	for _, migration := range migrated {
+		status[migration.Name] = migration.Status
+	}
+	assert.Equal(t, map[string]MigrationStatus{
+		"config":                            MigrationMoved,
+		"library":                           MigrationMoved,
+		filepath.Join("cache", "responses"): MigrationMoved,
+		"update-check.json":                 MigrationMoved,
+		"extensions":                        MigrationSkipped,
+	}, status)
+
+	assert.FileExists(t, filepath.Join(home, "xdg", "config", "zen", "config"))
+	assert.FileExists(t, filepath.Join(home, "xdg", "cache", "zen", "assets", "index.json"))
+	assert.FileExists(t, filepath.Join(home, "xdg", "cache", "zen", "responses", "index.json"))
+	assert.FileExists(t, filepath.Join(home, "xdg", "state", "zen", "update-check.json"))
+	assert.FileExists(t, filepath.Join(legacy, "extensions", "zen-hello", "zen-hello"), "skipped files stay in place")
+	assert.NoDirExists(t, filepath.Join(legacy, "cache"), "empty directories are removed")
+}
+
+func TestMigrate_RemovesEmptyLegacyDir(t *testing.T) {
+	home := setupHome(t)
+	writeFile(t, filepath.Join(home, ".zen", "config"), "log_level: debug\n")
+
+	migrations, err := PlanMigration()
+	require.NoError(t, err)
+	_, err = Migrate(migrations)
+	require.NoError(t, err)
+
+	assert.NoDirExists(t, filepath.Join(home, ".zen"))
+}
+
+func TestMove_CopiesWhenRenameFails(t *testing.T) {
+	rename = func(string, string) error {
+		return &os.LinkError{Op: "rename", Err: errors.New("invalid cross-device link")}
+	}
+	t.Cleanup(func() { rename = os.Rename })
+
+	dir := t.TempDir()
+	from := filepath.Join(dir, "from")
+	writeFile(t, filepath.Join(from, "a", "b.json"), "{}")
+	writeFile(t, filepath.Join(dir, "config"), "log_level: debug\n")
+
+	require.NoError(t, move(from, filepath.Join(dir, "to")))
+	assert.FileExists(t, filepath.Join(dir, "to", "a", "b.json"))
+	assert.NoDirExists(t, from)
+
+	require.NoError(t, move(filepath.Join(dir, "config"), filepath.Join(dir, "user", "config")))
+	assert.FileExists(t, filepath.Join(dir, "user", "config"))
+	assert.NoFileExists(t, filepath.Join(dir, "config"))
+}
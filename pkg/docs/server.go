@@ -0,0 +1,125 @@
+package docs
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+// layoutSource is the HTML page every document, the index, and search results are
+// rendered into
+//
+//go:embed templates/layout.html
+var layoutSource string
+
+var layout = template.Must(template.New("layout").Parse(layoutSource))
+
+// layoutData is passed to the layout template
+type layoutData struct {
+	Title    string
+	Version  string
+	Query    string
+	Content  template.HTML
+	Results  []SearchResult
+	Commands []*Page
+	Topics   []*Page
+}
+
+// Handler serves the pages of a site as HTML:
+//
+//	/               index of commands and help topics
+//	/<page>         a command or topic, e.g. /zen_assets_sync
+//	/search?q=...   pages matching the query
+type Handler struct {
+	site    *Site
+	version string
+}
+
+// NewHandler returns a handler serving site, labelled with the zen version
+func NewHandler(site *Site, version string) *Handler {
+	return &Handler{site: site, version: version}
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	data := layoutData{Version: h.version}
+	name := strings.Trim(r.URL.Path, "/")
+
+	switch name {
+	case "", IndexName:
+		data.Title = "Reference"
+		for _, page := range h.site.Pages() {
+			if page.Topic {
+				data.Topics = append(data.Topics, page)
+			} else {
+				data.Commands = append(data.Commands, page)
+			}
+		}
+	case "search":
+		data.Query = strings.TrimSpace(r.URL.Query().Get("q"))
+		data.Title = "Search"
+		data.Results = h.site.Search(data.Query)
+	default:
+		page, ok := h.site.Page(strings.TrimSuffix(name, ".md"))
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data.Title = page.Title
+		data.Content = template.HTML(renderMarkdown(page.Markdown))
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := layout.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// renderMarkdown converts a page to HTML. Quotes and dashes are kept as written, since
+// they are often part of flags and commands, and placeholders such as <name> are shown
+// as text rather than passed through as HTML.
+func renderMarkdown(markdown string) []byte {
+	renderer := escapingRenderer{blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.UseXHTML,
+	})}
+	return blackfriday.Run([]byte(markdown), blackfriday.WithRenderer(renderer))
+}
+
+// escapingRenderer renders raw HTML in the markdown as escaped text
+type escapingRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+// RenderNode implements blackfriday.Renderer
+func (r escapingRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	switch node.Type {
+	case blackfriday.HTMLSpan:
+		template.HTMLEscape(w, node.Literal)
+		return blackfriday.GoToNext
+	case blackfriday.HTMLBlock:
+		io.WriteString(w, "<p>")
+		template.HTMLEscape(w, node.Literal)
+		io.WriteString(w, "</p>\n")
+		return blackfriday.GoToNext
+	}
+	return r.HTMLRenderer.RenderNode(w, node, entering)
+}
+
+// PageURL returns the URL of the named page on a server listening at addr
+func PageURL(addr net.Addr, name string) string {
+	url := "http://" + addr.String() + "/"
+	if name != "" && name != IndexName {
+		url += name
+	}
+	return url
+}
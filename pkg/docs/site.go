@@ -0,0 +1,189 @@
+// Package docs builds the command reference of a command tree and serves it as a local,
+// searchable website.
+//
+// Pages are generated from the command tree built into the binary, the same way docgen
+// generates the published reference, so the documentation always matches the version
+// of zen that serves it and needs no network access.
+package docs
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// IndexName is the name of the page listing all commands and help topics
+const IndexName = "index"
+
+// Page is the documentation of a command or help topic
+type Page struct {
+	// Name identifies the page in URLs, e.g. "zen_assets_sync"
+	Name string
+
+	// Title is the command path, e.g. "zen assets sync", or "zen help <topic>" for topics
+	Title string
+
+	// Short is the one-line description of the command
+	Short string
+
+	// Topic is true for help topics
+	Topic bool
+
+	// Markdown is the text of the page
+	Markdown string
+}
+
+// SearchResult is a page matching a search, with the line that matched best
+type SearchResult struct {
+	Page    *Page
+	Snippet string
+	score   int
+}
+
+// Site is the documentation of a command tree
+type Site struct {
+	pages  []*Page
+	byName map[string]*Page
+}
+
+// NewSite generates a page for every visible command and help topic under root
+func NewSite(root *cobra.Command) (*Site, error) {
+	site := &Site{byName: map[string]*Page{}}
+
+	var walk func(cmd *cobra.Command) error
+	walk = func(cmd *cobra.Command) error {
+		page, err := newPage(cmd)
+		if err != nil {
+			return err
+		}
+		site.pages = append(site.pages, page)
+		site.byName[page.Name] = page
+
+		for _, sub := range cmd.Commands() {
+			if !sub.IsAvailableCommand() && !cmdutil.IsHelpTopic(sub) {
+				continue
+			}
+			if err := walk(sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(root); err != nil {
+		return nil, err
+	}
+	return site, nil
+}
+
+// newPage generates the page of a command
+func newPage(cmd *cobra.Command) (*Page, error) {
+	page := &Page{
+		Name:  PageName(cmd),
+		Title: cmd.CommandPath(),
+		Short: cmd.Short,
+		Topic: cmdutil.IsHelpTopic(cmd),
+	}
+
+	if page.Topic {
+		page.Title = "zen help " + cmd.Name()
+		page.Markdown = "## " + page.Title + "\n\n" + cmd.Short + "\n\n" + cmd.Long + "\n"
+		return page, nil
+	}
+
+	var buf bytes.Buffer
+	linkHandler := func(name string) string {
+		return "/" + strings.TrimSuffix(name, ".md")
+	}
+	if err := doc.GenMarkdownCustom(cmd, &buf, linkHandler); err != nil {
+		return nil, err
+	}
+	page.Markdown = buf.String()
+	return page, nil
+}
+
+// PageName returns the name of a command's page, matching the generated Markdown file
+// without its extension
+func PageName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "_")
+}
+
+// Pages returns the pages in command tree order
+func (s *Site) Pages() []*Page {
+	return s.pages
+}
+
+// Page returns the named page
+func (s *Site) Page(name string) (*Page, bool) {
+	page, ok := s.byName[name]
+	return page, ok
+}
+
+// Search returns the pages containing every word of the query, ignoring case. Pages
+// whose title or description match come first.
+func (s *Site) Search(query string) []SearchResult {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var results []SearchResult
+	for _, page := range s.pages {
+		title := strings.ToLower(page.Title)
+		short := strings.ToLower(page.Short)
+		body := strings.ToLower(page.Markdown)
+
+		score := 0
+		for _, term := range terms {
+			if !strings.Contains(title, term) && !strings.Contains(body, term) {
+				score = 0
+				break
+			}
+			if strings.Contains(title, term) {
+				score += 10
+			}
+			if strings.Contains(short, term) {
+				score += 5
+			}
+			score += min(strings.Count(body, term), 5)
+		}
+		if score == 0 {
+			continue
+		}
+
+		results = append(results, SearchResult{Page: page, Snippet: snippet(page.Markdown, terms), score: score})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+	return results
+}
+
+// snippet returns the line of markdown that contains the most search terms, skipping
+// headings and the description that is already shown with the title
+func snippet(markdown string, terms []string) string {
+	best, bestCount := "", 0
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "```") {
+			continue
+		}
+
+		lower := strings.ToLower(line)
+		count := 0
+		for _, term := range terms {
+			if strings.Contains(lower, term) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = line, count
+		}
+	}
+	return best
+}
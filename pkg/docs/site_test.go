@@ -0,0 +1,123 @@
+package docs
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRoot() *cobra.Command {
+	root := &cobra.Command{Use: "zen", Short: "Zen CLI"}
+	root.DisableAutoGenTag = true
+
+	sync := &cobra.Command{
+		Use:     "sync",
+		Short:   "Synchronize assets",
+		Long:    "Fetch the asset manifest. Content is fetched by 'zen assets info <name> --include-content'.",
+		Example: "  zen assets sync --force",
+		Run:     func(cmd *cobra.Command, args []string) {},
+	}
+	sync.Flags().Bool("force", false, "Force a full refresh")
+
+	assets := &cobra.Command{Use: "assets", Short: "Manage assets"}
+	assets.AddCommand(sync, &cobra.Command{Use: "internal", Hidden: true, Run: func(cmd *cobra.Command, args []string) {}})
+
+	topic := &cobra.Command{
+		Use:         "exit-codes",
+		Short:       "Exit codes returned to scripts",
+		Long:        "- 4 (auth): Authentication is missing",
+		Annotations: map[string]string{cmdutil.HelpTopicAnnotation: "true"},
+	}
+
+	root.AddCommand(assets, topic)
+	return root
+}
+
+func TestNewSite(t *testing.T) {
+	site, err := NewSite(testRoot())
+	require.NoError(t, err)
+
+	var names []string
+	for _, page := range site.Pages() {
+		names = append(names, page.Name)
+	}
+	assert.Equal(t, []string{"zen", "zen_assets", "zen_assets_sync", "zen_exit-codes"}, names, "hidden commands have no page")
+
+	page, ok := site.Page("zen_assets_sync")
+	require.True(t, ok)
+	assert.Equal(t, "zen assets sync", page.Title)
+	assert.Contains(t, page.Markdown, "--force")
+	assert.Contains(t, page.Markdown, "[zen assets](/zen_assets)", "links point at pages on the server")
+
+	topic, ok := site.Page("zen_exit-codes")
+	require.True(t, ok)
+	assert.True(t, topic.Topic)
+	assert.Equal(t, "zen help exit-codes", topic.Title)
+}
+
+func TestSite_Search(t *testing.T) {
+	site, err := NewSite(testRoot())
+	require.NoError(t, err)
+
+	results := site.Search("SYNC force")
+	require.Len(t, results, 1)
+	assert.Equal(t, "zen_assets_sync", results[0].Page.Name)
+	assert.Contains(t, results[0].Snippet, "--force")
+
+	results = site.Search("assets")
+	require.NotEmpty(t, results)
+	assert.Equal(t, "zen_assets", results[0].Page.Name, "title matches come first")
+
+	assert.Empty(t, site.Search("deploy"))
+	assert.Empty(t, site.Search("  "))
+}
+
+func TestHandler(t *testing.T) {
+	site, err := NewSite(testRoot())
+	require.NoError(t, err)
+	server := httptest.NewServer(NewHandler(site, "v1.2.3"))
+	defer server.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := get("/")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "Zen CLI v1.2.3")
+	assert.Contains(t, body, `<a href="/zen_assets_sync">zen assets sync</a>`)
+	assert.Contains(t, body, `<a href="/zen_exit-codes">zen help exit-codes</a>`)
+
+	status, body = get("/zen_assets_sync")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "'zen assets info &lt;name&gt; --include-content'", "placeholders are text and dashes are kept")
+
+	status, body = get("/search?q=force")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `<a href="/zen_assets_sync">zen assets sync</a>`)
+
+	_, body = get("/search?q=deploy")
+	assert.Contains(t, body, "No results for “deploy”")
+
+	status, _ = get("/zen_deploy")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestPageURL(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 6060}
+	assert.Equal(t, "http://127.0.0.1:6060/", PageURL(addr, ""))
+	assert.Equal(t, "http://127.0.0.1:6060/", PageURL(addr, IndexName))
+	assert.Equal(t, "http://127.0.0.1:6060/zen_assets", PageURL(addr, "zen_assets"))
+}
@@ -22,6 +22,11 @@ func Wrapf(err error, format string, args ...interface{}) error {
 	return errors.Wrapf(err, format, args...)
 }
 
+// Is reports whether any error in err's chain matches target
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
 // NewWithCode creates a new error with a specific error code
 func NewWithCode(code types.ErrorCode, message string) error {
 	return &types.Error{
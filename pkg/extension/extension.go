@@ -0,0 +1,94 @@
+// Package extension manages third-party zen subcommands.
+//
+// An extension is an executable named zen-<name> that is run as `zen <name>`.
+// Extensions are installed either by cloning a Git repository that contains the
+// executable at its root, or by downloading a prebuilt binary from the latest
+// GitHub release of an owner/repo.
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/dirs"
+)
+
+// Prefix is the name prefix shared by every extension executable and repository
+const Prefix = "zen-"
+
+// manifestFile records how a binary extension was installed
+const manifestFile = "manifest.yaml"
+
+// Kind describes how an extension was installed
+type Kind string
+
+const (
+	// KindGit extensions are Git clones containing the zen-<name> executable
+	KindGit Kind = "git"
+	// KindBinary extensions are executables downloaded from a GitHub release
+	KindBinary Kind = "binary"
+)
+
+// Extension is an installed extension
+type Extension struct {
+	Name        string    `json:"name" yaml:"name"`
+	Kind        Kind      `json:"kind" yaml:"kind"`
+	Source      string    `json:"source" yaml:"source"`
+	Version     string    `json:"version" yaml:"version"`
+	Path        string    `json:"path" yaml:"path"`
+	InstalledAt time.Time `json:"installed_at,omitempty" yaml:"installed_at,omitempty"`
+}
+
+// manifest is written alongside binary extensions
+type manifest struct {
+	Owner       string    `yaml:"owner"`
+	Repo        string    `yaml:"repo"`
+	Tag         string    `yaml:"tag"`
+	InstalledAt time.Time `yaml:"installed_at"`
+}
+
+// DefaultDir returns the directory extensions are installed to.
+// ZEN_EXTENSIONS_DIR overrides the default of extensions in the user data directory.
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("ZEN_EXTENSIONS_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := dirs.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "extensions"), nil
+}
+
+// ExecutableName returns the file name of the executable for an extension name
+func ExecutableName(name string) string {
+	if runtime.GOOS == "windows" {
+		return Prefix + name + ".exe"
+	}
+	return Prefix + name
+}
+
+// NameFromRepo derives the extension name from a repository URL, path or owner/repo,
+// e.g. "github.com/acme/zen-deploy.git" becomes "deploy"
+func NameFromRepo(repo string) string {
+	repo = strings.TrimSuffix(strings.TrimRight(repo, "/"), ".git")
+	if i := strings.LastIndexAny(repo, `/\:`); i >= 0 {
+		repo = repo[i+1:]
+	}
+	if !strings.HasPrefix(repo, Prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(repo, Prefix)
+}
+
+// releaseAssetName returns the release asset expected for the current platform
+func releaseAssetName(name string) string {
+	asset := Prefix + name + "-" + runtime.GOOS + "-" + runtime.GOARCH
+	if runtime.GOOS == "windows" {
+		asset += ".exe"
+	}
+	return asset
+}
@@ -0,0 +1,380 @@
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/errors"
+	"github.com/daddia/zen/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultAPIURL is the GitHub API used to look up release binaries
+const defaultAPIURL = "https://api.github.com"
+
+var ownerRepoPattern = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// errNoRelease means a repository has no release binary for this platform
+var errNoRelease = errors.New("no release binary for this platform")
+
+// Manager installs, upgrades, removes and lists extensions in a directory
+type Manager struct {
+	dir    string
+	logger logging.Logger
+	client *http.Client
+	apiURL string
+}
+
+// NewManager creates a manager for extensions installed in dir
+func NewManager(dir string, logger logging.Logger) *Manager {
+	return &Manager{
+		dir:    dir,
+		logger: logger,
+		client: &http.Client{Timeout: 60 * time.Second},
+		apiURL: defaultAPIURL,
+	}
+}
+
+// Dir returns the directory extensions are installed to
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+// List returns the installed extensions sorted by name
+func (m *Manager) List(ctx context.Context) ([]*Extension, error) {
+	entries, err := os.ReadDir(m.dir)
+	if os.IsNotExist(err) {
+		return []*Extension{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read extensions directory")
+	}
+
+	extensions := []*Extension{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), Prefix) {
+			continue
+		}
+		ext, err := m.load(ctx, strings.TrimPrefix(entry.Name(), Prefix))
+		if err != nil {
+			m.logger.Debug("skipping invalid extension", "dir", entry.Name(), "error", err)
+			continue
+		}
+		extensions = append(extensions, ext)
+	}
+
+	sort.Slice(extensions, func(i, j int) bool { return extensions[i].Name < extensions[j].Name })
+	return extensions, nil
+}
+
+// Get returns an installed extension by name
+func (m *Manager) Get(ctx context.Context, name string) (*Extension, error) {
+	if _, err := os.Stat(m.extensionDir(name)); err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNotFound,
+			Message: fmt.Sprintf("extension %q is not installed", name),
+		}
+	}
+	return m.load(ctx, name)
+}
+
+// Install installs an extension from a Git repository URL or path, or from an owner/repo on GitHub.
+// GitHub repositories are installed from their latest release binary when one exists for this
+// platform, and cloned otherwise.
+func (m *Manager) Install(ctx context.Context, source string) (*Extension, error) {
+	name := NameFromRepo(source)
+	if name == "" {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("invalid extension repository %q", source),
+			Details: fmt.Sprintf("extension repositories must be named %s<name>", Prefix),
+		}
+	}
+
+	if _, err := os.Stat(m.extensionDir(name)); err == nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeAlreadyExists,
+			Message: fmt.Sprintf("extension %q is already installed", name),
+			Details: fmt.Sprintf("Run 'zen extension upgrade %s' to update it", name),
+		}
+	}
+
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create extensions directory")
+	}
+
+	url := source
+	if isOwnerRepo(source) {
+		owner, repo, _ := strings.Cut(source, "/")
+		err := m.installRelease(ctx, owner, repo, name)
+		if err == nil {
+			return m.load(ctx, name)
+		}
+		if err != errNoRelease {
+			return nil, err
+		}
+		url = "https://github.com/" + source + ".git"
+	}
+
+	if err := m.installGit(ctx, url, name); err != nil {
+		return nil, err
+	}
+	return m.load(ctx, name)
+}
+
+// Upgrade updates an extension to the latest commit or release.
+// It reports whether a newer version was installed.
+func (m *Manager) Upgrade(ctx context.Context, name string) (*Extension, bool, error) {
+	ext, err := m.Get(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch ext.Kind {
+	case KindGit:
+		if err := m.repository(name).Pull(ctx); err != nil {
+			return nil, false, errors.Wrap(err, "failed to upgrade extension")
+		}
+	case KindBinary:
+		mf, err := m.readManifest(name)
+		if err != nil {
+			return nil, false, err
+		}
+		release, err := m.latestRelease(ctx, mf.Owner, mf.Repo)
+		if err != nil {
+			return nil, false, err
+		}
+		if release.TagName != mf.Tag {
+			if err := m.installRelease(ctx, mf.Owner, mf.Repo, name); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	upgraded, err := m.load(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	return upgraded, upgraded.Version != ext.Version, nil
+}
+
+// Remove uninstalls an extension
+func (m *Manager) Remove(ctx context.Context, name string) error {
+	if _, err := m.Get(ctx, name); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(m.extensionDir(name)); err != nil {
+		return errors.Wrap(err, "failed to remove extension")
+	}
+	return nil
+}
+
+func (m *Manager) extensionDir(name string) string {
+	return filepath.Join(m.dir, Prefix+name)
+}
+
+func (m *Manager) repository(name string) *git.CLIRepository {
+	return git.NewCLIRepository(m.extensionDir(name), m.logger, nil, "")
+}
+
+// load reads an installed extension from disk
+func (m *Manager) load(ctx context.Context, name string) (*Extension, error) {
+	dir := m.extensionDir(name)
+	ext := &Extension{
+		Name: name,
+		Path: filepath.Join(dir, ExecutableName(name)),
+	}
+
+	if _, err := os.Stat(ext.Path); err != nil {
+		return nil, errors.Wrapf(err, "extension %q has no executable", name)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		repo := m.repository(name)
+		ext.Kind = KindGit
+		if remote, err := repo.ExecuteCommand(ctx, "remote", "get-url", "origin"); err == nil {
+			ext.Source = strings.TrimSpace(remote)
+		}
+		if commit, err := repo.GetLastCommit(ctx); err == nil && len(commit) >= 7 {
+			ext.Version = commit[:7]
+		}
+		if info, err := os.Stat(dir); err == nil {
+			ext.InstalledAt = info.ModTime()
+		}
+		return ext, nil
+	}
+
+	mf, err := m.readManifest(name)
+	if err != nil {
+		return nil, err
+	}
+	ext.Kind = KindBinary
+	ext.Source = mf.Owner + "/" + mf.Repo
+	ext.Version = mf.Tag
+	ext.InstalledAt = mf.InstalledAt
+	return ext, nil
+}
+
+func (m *Manager) installGit(ctx context.Context, url, name string) error {
+	if err := m.repository(name).Clone(ctx, url, "", false); err != nil {
+		_ = os.RemoveAll(m.extensionDir(name))
+		return errors.Wrap(err, "failed to clone extension repository")
+	}
+
+	executable := filepath.Join(m.extensionDir(name), ExecutableName(name))
+	if _, err := os.Stat(executable); err != nil {
+		_ = os.RemoveAll(m.extensionDir(name))
+		return &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("repository %s does not contain an executable named %s", url, ExecutableName(name)),
+		}
+	}
+	return nil
+}
+
+// githubRelease is the subset of the GitHub release API response used for installs
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	Assets  []struct {
+		Name        string `json:"name"`
+		DownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+func (m *Manager) latestRelease(ctx context.Context, owner, repo string) (*githubRelease, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", m.apiURL, owner, repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: fmt.Sprintf("failed to look up releases for %s/%s", owner, repo),
+			Details: err.Error(),
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNoRelease
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: fmt.Sprintf("failed to look up releases for %s/%s: HTTP %d", owner, repo, resp.StatusCode),
+		}
+	}
+
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, errors.Wrap(err, "failed to parse release")
+	}
+	return &release, nil
+}
+
+func (m *Manager) installRelease(ctx context.Context, owner, repo, name string) error {
+	release, err := m.latestRelease(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	var downloadURL string
+	for _, asset := range release.Assets {
+		if asset.Name == releaseAssetName(name) {
+			downloadURL = asset.DownloadURL
+			break
+		}
+	}
+	if downloadURL == "" {
+		return errNoRelease
+	}
+
+	m.logger.Debug("downloading extension release", "repo", owner+"/"+repo, "tag", release.TagName)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: fmt.Sprintf("failed to download %s", releaseAssetName(name)),
+			Details: err.Error(),
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: fmt.Sprintf("failed to download %s: HTTP %d", releaseAssetName(name), resp.StatusCode),
+		}
+	}
+
+	dir := m.extensionDir(name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create extension directory")
+	}
+
+	// Write to a temporary file first so a failed download never replaces a working binary
+	executable := filepath.Join(dir, ExecutableName(name))
+	tmp := executable + ".download"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return errors.Wrap(err, "failed to write extension binary")
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return errors.Wrap(err, "failed to write extension binary")
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmp)
+		return errors.Wrap(err, "failed to write extension binary")
+	}
+	if err := os.Rename(tmp, executable); err != nil {
+		return errors.Wrap(err, "failed to install extension binary")
+	}
+
+	data, err := yaml.Marshal(&manifest{Owner: owner, Repo: repo, Tag: release.TagName, InstalledAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFile), data, 0644)
+}
+
+func (m *Manager) readManifest(name string) (*manifest, error) {
+	data, err := os.ReadFile(filepath.Join(m.extensionDir(name), manifestFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "extension %q has no manifest", name)
+	}
+	var mf manifest
+	if err := yaml.Unmarshal(data, &mf); err != nil {
+		return nil, errors.Wrapf(err, "invalid manifest for extension %q", name)
+	}
+	return &mf, nil
+}
+
+// isOwnerRepo reports whether source is a GitHub owner/repo rather than a URL or local path
+func isOwnerRepo(source string) bool {
+	if !ownerRepoPattern.MatchString(source) {
+		return false
+	}
+	_, err := os.Stat(source)
+	return os.IsNotExist(err)
+}
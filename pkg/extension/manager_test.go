@@ -0,0 +1,189 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExtensionRepo creates a Git repository containing an extension executable
+func newExtensionRepo(t *testing.T, name string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script extensions are not supported on Windows")
+	}
+
+	dir := filepath.Join(t.TempDir(), Prefix+name)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, Prefix+name), []byte("#!/bin/sh\necho "+name+"\n"), 0755))
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=zen", "-c", "user.email=zen@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	return NewManager(filepath.Join(t.TempDir(), "extensions"), logging.NewBasic())
+}
+
+func TestNameFromRepo(t *testing.T) {
+	tests := map[string]string{
+		"acme/zen-deploy":                         "deploy",
+		"https://github.com/acme/zen-deploy.git":  "deploy",
+		"git@github.com:acme/zen-deploy.git":      "deploy",
+		"/home/me/src/zen-deploy/":                "deploy",
+		"acme/deploy":                             "",
+		"https://github.com/acme/zen-deploy-tool": "deploy-tool",
+	}
+
+	for repo, want := range tests {
+		assert.Equal(t, want, NameFromRepo(repo), repo)
+	}
+}
+
+func TestManager_InstallGit(t *testing.T) {
+	ctx := context.Background()
+	repo := newExtensionRepo(t, "hello")
+	m := newTestManager(t)
+
+	ext, err := m.Install(ctx, repo)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", ext.Name)
+	assert.Equal(t, KindGit, ext.Kind)
+	assert.Equal(t, repo, ext.Source)
+	assert.Len(t, ext.Version, 7)
+	assert.FileExists(t, ext.Path)
+
+	// Installing twice is an error
+	_, err = m.Install(ctx, repo)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeAlreadyExists, zenErr.Code)
+
+	list, err := m.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "hello", list[0].Name)
+}
+
+func TestManager_InstallGitWithoutExecutable(t *testing.T) {
+	repo := newExtensionRepo(t, "hello")
+	renamed := filepath.Join(filepath.Dir(repo), "zen-other")
+	require.NoError(t, os.Rename(repo, renamed))
+
+	m := newTestManager(t)
+	_, err := m.Install(context.Background(), renamed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not contain an executable named zen-other")
+	assert.NoDirExists(t, filepath.Join(m.Dir(), "zen-other"))
+}
+
+func TestManager_InstallInvalidName(t *testing.T) {
+	_, err := newTestManager(t).Install(context.Background(), "acme/deploy")
+
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+}
+
+func TestManager_UpgradeGit(t *testing.T) {
+	ctx := context.Background()
+	repo := newExtensionRepo(t, "hello")
+	m := newTestManager(t)
+
+	installed, err := m.Install(ctx, repo)
+	require.NoError(t, err)
+
+	_, upgraded, err := m.Upgrade(ctx, "hello")
+	require.NoError(t, err)
+	assert.False(t, upgraded)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("hello"), 0644))
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-q", "-m", "update")
+
+	ext, upgraded, err := m.Upgrade(ctx, "hello")
+	require.NoError(t, err)
+	assert.True(t, upgraded)
+	assert.NotEqual(t, installed.Version, ext.Version)
+}
+
+func TestManager_InstallRelease(t *testing.T) {
+	tag := "v1.0.0"
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/zen-deploy/releases/latest":
+			fmt.Fprintf(w, `{"tag_name": %q, "assets": [{"name": %q, "browser_download_url": %q}]}`,
+				tag, releaseAssetName("deploy"), server.URL+"/download")
+		case "/download":
+			fmt.Fprint(w, "#!/bin/sh\necho deploy\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	m := newTestManager(t)
+	m.apiURL = server.URL
+
+	ext, err := m.Install(ctx, "acme/zen-deploy")
+	require.NoError(t, err)
+	assert.Equal(t, KindBinary, ext.Kind)
+	assert.Equal(t, "acme/zen-deploy", ext.Source)
+	assert.Equal(t, "v1.0.0", ext.Version)
+
+	info, err := os.Stat(ext.Path)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.NotZero(t, info.Mode()&0100, "binary must be executable")
+	}
+
+	_, upgraded, err := m.Upgrade(ctx, "deploy")
+	require.NoError(t, err)
+	assert.False(t, upgraded)
+
+	tag = "v1.1.0"
+	ext, upgraded, err = m.Upgrade(ctx, "deploy")
+	require.NoError(t, err)
+	assert.True(t, upgraded)
+	assert.Equal(t, "v1.1.0", ext.Version)
+}
+
+func TestManager_Remove(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	_, err := m.Install(ctx, newExtensionRepo(t, "hello"))
+	require.NoError(t, err)
+	require.NoError(t, m.Remove(ctx, "hello"))
+
+	list, err := m.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	var zenErr *types.Error
+	require.ErrorAs(t, m.Remove(ctx, "hello"), &zenErr)
+	assert.Equal(t, types.ErrorCodeNotFound, zenErr.Code)
+}
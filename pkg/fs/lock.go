@@ -0,0 +1,118 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrLocked is returned when a lock is held by another process and could not be acquired
+var ErrLocked = errors.New("locked by another process")
+
+// lockPollInterval is how often a waiting AcquireLock retries
+const lockPollInterval = 100 * time.Millisecond
+
+// LockOptions controls how AcquireLock waits for a lock held elsewhere
+type LockOptions struct {
+	// Timeout bounds how long to wait for the lock; zero waits until the context is done
+	Timeout time.Duration
+
+	// NoWait fails immediately when the lock is held
+	NoWait bool
+}
+
+// FileLock is an advisory lock on a file, held until Release is called. The lock is
+// released by the operating system if the process exits without releasing it.
+type FileLock struct {
+	path string
+	file *os.File
+}
+
+// AcquireLock takes an exclusive advisory lock on path, creating the file if needed.
+// While another process holds the lock it waits, polling, until the lock is free, the
+// timeout passes, or ctx is done. A lock that cannot be acquired returns an error
+// wrapping ErrLocked that names the process holding it.
+func AcquireLock(ctx context.Context, path string, opts LockOptions) (*FileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s: %w", path, err)
+	}
+
+	var deadline <-chan time.Time
+	if opts.Timeout > 0 {
+		timer := time.NewTimer(opts.Timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			// Record the holder so that processes waiting on the lock can report it
+			_ = file.Truncate(0)
+			_, _ = file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+			return &FileLock{path: path, file: file}, nil
+		}
+
+		if opts.NoWait {
+			file.Close()
+			return nil, lockedError(path)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			file.Close()
+			return nil, fmt.Errorf("timed out after %s waiting for lock: %w", opts.Timeout, lockedError(path))
+		case <-ctx.Done():
+			file.Close()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Path returns the path of the lock file
+func (l *FileLock) Path() string {
+	return l.path
+}
+
+// Release releases the lock. Releasing a lock more than once has no effect.
+func (l *FileLock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+
+	_ = l.file.Truncate(0)
+	err := unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+func lockedError(path string) error {
+	holder := ""
+	if content, err := os.ReadFile(path); err == nil {
+		if pid := strings.TrimSpace(string(content)); pid != "" {
+			holder = fmt.Sprintf(" (pid %s)", pid)
+		}
+	}
+	return fmt.Errorf("%s is %w%s", path, ErrLocked, holder)
+}
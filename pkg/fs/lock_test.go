@@ -0,0 +1,74 @@
+package fs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireLock(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache", "repository.lock")
+
+	lock, err := AcquireLock(ctx, path, LockOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, path, lock.Path())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(content))
+
+	// A second holder fails fast with NoWait and names the holder
+	_, err = AcquireLock(ctx, path, LockOptions{NoWait: true})
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.Contains(t, err.Error(), "pid "+strconv.Itoa(os.Getpid()))
+
+	// Or gives up once the timeout passes
+	start := time.Now()
+	_, err = AcquireLock(ctx, path, LockOptions{Timeout: 150 * time.Millisecond})
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	require.NoError(t, lock.Release())
+	require.NoError(t, lock.Release())
+
+	lock, err = AcquireLock(ctx, path, LockOptions{NoWait: true})
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+}
+
+func TestAcquireLock_WaitsForRelease(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "repository.lock")
+
+	held, err := AcquireLock(ctx, path, LockOptions{})
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = held.Release()
+	}()
+
+	lock, err := AcquireLock(ctx, path, LockOptions{Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+}
+
+func TestAcquireLock_ContextCancelled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repository.lock")
+
+	held, err := AcquireLock(context.Background(), path, LockOptions{})
+	require.NoError(t, err)
+	defer held.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	_, err = AcquireLock(ctx, path, LockOptions{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package fs
+
+// LongPath returns path unchanged: only Windows limits the length of paths to MAX_PATH
+func LongPath(path string) string {
+	return path
+}
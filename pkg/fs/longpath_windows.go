@@ -0,0 +1,19 @@
+//go:build windows
+
+package fs
+
+import "path/filepath"
+
+// LongPath returns path in a form Windows accepts beyond MAX_PATH: absolute, with the
+// extended-length prefix, when it is long, and unchanged otherwise. Deeply nested task
+// directories need it on systems without long path support enabled.
+func LongPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if long := extendedLengthPath(abs); long != abs {
+		return long
+	}
+	return path
+}
@@ -24,7 +24,7 @@ func New(logger logging.Logger) *Manager {
 func (m *Manager) CreateDirectory(dirPath string, perm os.FileMode) error {
 	m.logger.Debug("Creating directory", "path", dirPath, "permissions", perm)
 
-	if err := os.MkdirAll(dirPath, perm); err != nil {
+	if err := os.MkdirAll(LongPath(dirPath), perm); err != nil {
 		return fmt.Errorf("failed to create directory %s: %w", dirPath, err)
 	}
 
@@ -53,7 +53,7 @@ func (m *Manager) EnsureDirectory(dirPath string, perm os.FileMode) error {
 
 // DirectoryExists checks if a directory exists
 func (m *Manager) DirectoryExists(dirPath string) bool {
-	info, err := os.Stat(dirPath)
+	info, err := os.Stat(LongPath(dirPath))
 	if err != nil {
 		return false
 	}
@@ -62,7 +62,7 @@ func (m *Manager) DirectoryExists(dirPath string) bool {
 
 // FileExists checks if a file exists
 func (m *Manager) FileExists(filePath string) bool {
-	info, err := os.Stat(filePath)
+	info, err := os.Stat(LongPath(filePath))
 	if err != nil {
 		return false
 	}
@@ -79,7 +79,7 @@ func (m *Manager) CreateFile(filePath string, content []byte, perm os.FileMode)
 		return err
 	}
 
-	if err := os.WriteFile(filePath, content, perm); err != nil {
+	if err := os.WriteFile(LongPath(filePath), content, perm); err != nil {
 		return fmt.Errorf("failed to create file %s: %w", filePath, err)
 	}
 
@@ -96,7 +96,7 @@ func (m *Manager) ReadFile(filePath string) ([]byte, error) {
 		m.logger.Debug("reading absolute path", "path", filePath)
 	}
 
-	content, err := os.ReadFile(filePath) // #nosec G304 - path validation implemented above
+	content, err := os.ReadFile(LongPath(filePath)) // #nosec G304 - path validation implemented above
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
@@ -108,7 +108,7 @@ func (m *Manager) ReadFile(filePath string) ([]byte, error) {
 func (m *Manager) RemoveDirectory(dirPath string) error {
 	m.logger.Debug("Removing directory", "path", dirPath)
 
-	if err := os.RemoveAll(dirPath); err != nil {
+	if err := os.RemoveAll(LongPath(dirPath)); err != nil {
 		return fmt.Errorf("failed to remove directory %s: %w", dirPath, err)
 	}
 
@@ -119,7 +119,7 @@ func (m *Manager) RemoveDirectory(dirPath string) error {
 func (m *Manager) RemoveFile(filePath string) error {
 	m.logger.Debug("Removing file", "path", filePath)
 
-	if err := os.Remove(filePath); err != nil {
+	if err := os.Remove(LongPath(filePath)); err != nil {
 		return fmt.Errorf("failed to remove file %s: %w", filePath, err)
 	}
 
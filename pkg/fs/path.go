@@ -0,0 +1,82 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxShortPath is the length from which Windows paths need the extended-length prefix:
+// MAX_PATH is 260 characters, and directories must leave room for an 8.3 file name
+const maxShortPath = 248
+
+// reservedNames are the device names Windows reserves in every directory, with or
+// without an extension
+var reservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// IsReservedName reports whether Windows reserves name for a device, as it does CON,
+// NUL and COM1 in any case and with any extension, such as nul.txt
+func IsReservedName(name string) bool {
+	base, _, _ := strings.Cut(name, ".")
+	return reservedNames[strings.ToUpper(strings.TrimRight(base, " "))]
+}
+
+// ValidateFileName checks that name can name a file or directory on every platform zen
+// runs on, so that workspaces can be shared between them: it has no path separators or
+// characters Windows reserves, is not a reserved device name, and does not end with a
+// period or space, which Windows drops
+func ValidateFileName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("name is empty")
+	case name == "." || name == "..":
+		return fmt.Errorf("%q is not a valid name", name)
+	case strings.ContainsAny(name, `/\:*?"<>|`):
+		return fmt.Errorf("%q contains characters reserved by file systems", name)
+	case strings.ContainsFunc(name, func(r rune) bool { return r < 0x20 || r == 0x7f }):
+		return fmt.Errorf("%q contains control characters", name)
+	case IsReservedName(name):
+		return fmt.Errorf("%q is a device name reserved by Windows", name)
+	case strings.HasSuffix(name, ".") || strings.HasSuffix(name, " "):
+		return fmt.Errorf("%q ends with a period or space", name)
+	}
+	return nil
+}
+
+// extendedLengthPath returns an absolute Windows path with the \\?\ prefix that lifts
+// the MAX_PATH limit when it is too long to use without, and the path unchanged
+// otherwise. UNC paths (\\server\share) take the \\?\UNC\ form. Extended-length paths
+// are not normalized by Windows, so forward slashes are turned into backslashes.
+func extendedLengthPath(path string) string {
+	if len(path) < maxShortPath || strings.HasPrefix(path, `\\?\`) || strings.HasPrefix(path, `\\.\`) {
+		return path
+	}
+	path = strings.ReplaceAll(path, "/", `\`)
+	switch {
+	case strings.HasPrefix(path, `\\`):
+		return `\\?\UNC\` + path[2:]
+	case len(path) >= 3 && path[1] == ':' && path[2] == '\\':
+		return `\\?\` + path
+	}
+	return path
+}
+
+// ExpandHome expands a leading "~/" of path to the home directory of the user. When the
+// home directory is unknown it returns path unchanged along with the error.
+func ExpandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path, err
+	}
+	return filepath.Join(home, path[2:]), nil
+}
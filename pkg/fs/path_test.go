@@ -0,0 +1,99 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReservedName(t *testing.T) {
+	for _, name := range []string{"CON", "con", "Nul", "nul.txt", "COM1", "lpt9.tar.gz", "AUX "} {
+		assert.True(t, IsReservedName(name), name)
+	}
+	for _, name := range []string{"CONSOLE", "COM0", "COM10", "NULL", "PROJ-1", "x.con", ""} {
+		assert.False(t, IsReservedName(name), name)
+	}
+}
+
+func TestValidateFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		errorMsg string
+	}{
+		{name: "PROJ-1"},
+		{name: "notes.md"},
+		{name: "", errorMsg: "empty"},
+		{name: "..", errorMsg: "not a valid name"},
+		{name: "a/b", errorMsg: "reserved by file systems"},
+		{name: `a\b`, errorMsg: "reserved by file systems"},
+		{name: "a:b", errorMsg: "reserved by file systems"},
+		{name: "a\tb", errorMsg: "control characters"},
+		{name: "PRN", errorMsg: "reserved by Windows"},
+		{name: "com3.log", errorMsg: "reserved by Windows"},
+		{name: "draft.", errorMsg: "period or space"},
+		{name: "draft ", errorMsg: "period or space"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFileName(tt.name)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestExtendedLengthPath(t *testing.T) {
+	long := strings.Repeat("d", maxShortPath)
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "short", path: `C:\work\.zen`, want: `C:\work\.zen`},
+		{name: "drive", path: `C:\` + long, want: `\\?\C:\` + long},
+		{name: "forward slashes", path: `C:/work/` + long, want: `\\?\C:\work\` + long},
+		{name: "unc", path: `\\server\share\` + long, want: `\\?\UNC\server\share\` + long},
+		{name: "already extended", path: `\\?\C:\` + long, want: `\\?\C:\` + long},
+		{name: "device", path: `\\.\pipe\` + long, want: `\\.\pipe\` + long},
+		{name: "relative", path: long, want: long},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extendedLengthPath(tt.path))
+		})
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "home", path: "~/.ssh/id_ed25519", want: filepath.Join(home, ".ssh", "id_ed25519")},
+		{name: "absolute", path: "/etc/ssl/ca.pem", want: "/etc/ssl/ca.pem"},
+		{name: "relative", path: "keys/app.pem", want: "keys/app.pem"},
+		{name: "other user", path: "~alice/key.pem", want: "~alice/key.pem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandHome(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// builtinFunc implements a builtin hook action
+type builtinFunc func(ctx context.Context, r *Runner, payload *Payload) error
+
+var builtins = map[string]builtinFunc{
+	"log":     logBuiltin,
+	"git-add": gitAddBuiltin,
+}
+
+// Builtins returns the names of the builtin actions in sorted order
+func Builtins() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsBuiltin reports whether name is a builtin action
+func IsBuiltin(name string) bool {
+	_, ok := builtins[name]
+	return ok
+}
+
+// logBuiltin appends the payload as a JSON line to .zen/hooks.log in the workspace
+func logBuiltin(ctx context.Context, r *Runner, payload *Payload) error {
+	path := filepath.Join(r.dir, ".zen", "hooks.log")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// gitAddBuiltin stages the paths listed in the payload's "paths" data, such as a new task directory
+func gitAddBuiltin(ctx context.Context, r *Runner, payload *Payload) error {
+	paths := payloadPaths(payload)
+	if len(paths) == 0 {
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"add", "--"}, paths...)...)
+	cmd.Dir = r.dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git add failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func payloadPaths(payload *Payload) []string {
+	switch paths := payload.Data["paths"].(type) {
+	case []string:
+		return paths
+	case []interface{}:
+		result := make([]string, 0, len(paths))
+		for _, p := range paths {
+			if s, ok := p.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,162 @@
+package hooks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// DefaultTimeout is used for hooks that do not set their own timeout
+const DefaultTimeout = 30 * time.Second
+
+// FailurePolicy decides what happens when a hook fails
+type FailurePolicy string
+
+const (
+	// FailureWarn prints a warning and continues
+	FailureWarn FailurePolicy = "warn"
+	// FailureAbort stops the operation; only meaningful for pre_* events
+	FailureAbort FailurePolicy = "abort"
+)
+
+// Hook is a single user script or builtin action bound to an event
+type Hook struct {
+	// Name identifies the hook in output; defaults to the command or builtin
+	Name string `yaml:"name" json:"name,omitempty" mapstructure:"name"`
+
+	// Run is a shell command executed from the workspace root
+	Run string `yaml:"run" json:"run,omitempty" mapstructure:"run"`
+
+	// Builtin names a builtin action to run instead of a command
+	Builtin string `yaml:"builtin" json:"builtin,omitempty" mapstructure:"builtin"`
+
+	// Timeout limits how long the hook may run (e.g. "10s")
+	Timeout string `yaml:"timeout" json:"timeout,omitempty" mapstructure:"timeout"`
+
+	// OnFailure is "warn" or "abort"; defaults to abort for pre_* events and warn otherwise
+	OnFailure FailurePolicy `yaml:"on_failure" json:"on_failure,omitempty" mapstructure:"on_failure"`
+
+	// Env adds environment variables for the hook
+	Env map[string]string `yaml:"env" json:"env,omitempty" mapstructure:"env"`
+}
+
+// DisplayName returns the name shown for the hook
+func (h Hook) DisplayName() string {
+	switch {
+	case h.Name != "":
+		return h.Name
+	case h.Builtin != "":
+		return "builtin:" + h.Builtin
+	default:
+		return h.Run
+	}
+}
+
+// Config contains hook configuration, keyed by event name
+type Config struct {
+	// Timeout is the default timeout for hooks (e.g. "30s")
+	Timeout string `yaml:"timeout" json:"timeout" mapstructure:"timeout"`
+
+	PreTaskCreate  []Hook `yaml:"pre_task_create" json:"pre_task_create,omitempty" mapstructure:"pre_task_create"`
+	PostTaskCreate []Hook `yaml:"post_task_create" json:"post_task_create,omitempty" mapstructure:"post_task_create"`
+	PreSync        []Hook `yaml:"pre_sync" json:"pre_sync,omitempty" mapstructure:"pre_sync"`
+	PostSync       []Hook `yaml:"post_sync" json:"post_sync,omitempty" mapstructure:"post_sync"`
+	PostInit       []Hook `yaml:"post_init" json:"post_init,omitempty" mapstructure:"post_init"`
+}
+
+// DefaultConfig returns default hook configuration with no hooks
+func DefaultConfig() Config {
+	return Config{
+		Timeout: DefaultTimeout.String(),
+	}
+}
+
+// Hooks returns the hooks configured for an event
+func (c Config) Hooks(event Event) []Hook {
+	switch event {
+	case EventPreTaskCreate:
+		return c.PreTaskCreate
+	case EventPostTaskCreate:
+		return c.PostTaskCreate
+	case EventPreSync:
+		return c.PreSync
+	case EventPostSync:
+		return c.PostSync
+	case EventPostInit:
+		return c.PostInit
+	default:
+		return nil
+	}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the hook configuration
+func (c Config) Validate() error {
+	if c.Timeout != "" {
+		if _, err := time.ParseDuration(c.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout: %s", c.Timeout)
+		}
+	}
+
+	for _, event := range Events() {
+		for i, hook := range c.Hooks(event) {
+			if (hook.Run == "") == (hook.Builtin == "") {
+				return fmt.Errorf("%s[%d]: exactly one of run or builtin must be set", event, i)
+			}
+			if hook.Builtin != "" && !IsBuiltin(hook.Builtin) {
+				return fmt.Errorf("%s[%d]: unknown builtin %q", event, i, hook.Builtin)
+			}
+			if hook.Timeout != "" {
+				if _, err := time.ParseDuration(hook.Timeout); err != nil {
+					return fmt.Errorf("%s[%d]: invalid timeout: %s", event, i, hook.Timeout)
+				}
+			}
+			switch hook.OnFailure {
+			case "", FailureWarn, FailureAbort:
+			default:
+				return fmt.Errorf("%s[%d]: invalid on_failure: %s (must be one of: warn, abort)", event, i, hook.OnFailure)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode hooks config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for hooks
+func (p ConfigParser) Section() string {
+	return "hooks"
+}
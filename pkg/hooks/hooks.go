@@ -0,0 +1,262 @@
+// Package hooks runs user-configured scripts and builtin actions at lifecycle events.
+//
+// Hooks are configured in the "hooks" section of zen.yaml, keyed by event:
+//
+//	hooks:
+//	  timeout: 30s
+//	  pre_task_create:
+//	    - name: check-id
+//	      run: ./scripts/check-task-id.sh
+//	  post_task_create:
+//	    - builtin: git-add
+//	      on_failure: warn
+//
+// Each hook receives a JSON Payload describing the event on stdin. Hooks on
+// pre_* events abort the operation when they fail, unless on_failure is "warn";
+// hooks on other events only warn.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+)
+
+// Event is a lifecycle event hooks can be attached to
+type Event string
+
+const (
+	EventPreTaskCreate  Event = "pre_task_create"
+	EventPostTaskCreate Event = "post_task_create"
+	EventPreSync        Event = "pre_sync"
+	EventPostSync       Event = "post_sync"
+	EventPostInit       Event = "post_init"
+)
+
+// Events returns every supported event in lifecycle order
+func Events() []Event {
+	return []Event{EventPostInit, EventPreTaskCreate, EventPostTaskCreate, EventPreSync, EventPostSync}
+}
+
+// ParseEvent validates an event name
+func ParseEvent(name string) (Event, error) {
+	for _, event := range Events() {
+		if string(event) == name {
+			return event, nil
+		}
+	}
+
+	names := make([]string, 0, len(Events()))
+	for _, event := range Events() {
+		names = append(names, string(event))
+	}
+	return "", fmt.Errorf("unknown hook event %q (must be one of: %s)", name, strings.Join(names, ", "))
+}
+
+// IsPre reports whether the event runs before an operation and can abort it
+func (e Event) IsPre() bool {
+	return strings.HasPrefix(string(e), "pre_")
+}
+
+// Payload is the JSON document written to each hook's stdin
+type Payload struct {
+	Event     Event                  `json:"event"`
+	Timestamp time.Time              `json:"timestamp"`
+	Workspace string                 `json:"workspace,omitempty"`
+	Task      interface{}            `json:"task,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// Result is the outcome of running a single hook
+type Result struct {
+	Hook     Hook          `json:"hook"`
+	Event    Event         `json:"event"`
+	Policy   FailurePolicy `json:"policy"`
+	Duration time.Duration `json:"duration"`
+	Err      error         `json:"-"`
+}
+
+// AbortError is returned when a hook with the abort policy fails
+type AbortError struct {
+	Event Event
+	Hook  string
+	Err   error
+}
+
+func (e *AbortError) Error() string {
+	return fmt.Sprintf("%s hook %q failed: %v", e.Event, e.Hook, e.Err)
+}
+
+func (e *AbortError) Unwrap() error {
+	return e.Err
+}
+
+// Runner runs the hooks configured for an event
+type Runner struct {
+	config Config
+	dir    string
+	out    io.Writer
+	logger logging.Logger
+}
+
+// NewRunner creates a runner for the given configuration. Hooks run from dir,
+// and their output and any failure warnings are written to out.
+func NewRunner(cfg Config, dir string, out io.Writer, logger logging.Logger) *Runner {
+	return &Runner{
+		config: cfg,
+		dir:    dir,
+		out:    out,
+		logger: logger,
+	}
+}
+
+// Config returns the hook configuration
+func (r *Runner) Config() Config {
+	return r.config
+}
+
+// Trigger runs the hooks for payload.Event using a lazily created runner.
+// It does nothing when no runner is available.
+func Trigger(ctx context.Context, newRunner func() (*Runner, error), payload *Payload) error {
+	if newRunner == nil {
+		return nil
+	}
+	runner, err := newRunner()
+	if err != nil {
+		return err
+	}
+	_, err = runner.Run(ctx, payload)
+	return err
+}
+
+// Run runs every hook for the payload's event in order. A failing hook with the
+// abort policy stops the remaining hooks and is returned as an *AbortError;
+// other failures are reported as warnings.
+func (r *Runner) Run(ctx context.Context, payload *Payload) ([]Result, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	hooks := r.config.Hooks(payload.Event)
+	results := make([]Result, 0, len(hooks))
+	for _, hook := range hooks {
+		result := r.runHook(ctx, hook, r.prepare(payload))
+		results = append(results, result)
+
+		if result.Err == nil {
+			continue
+		}
+		if result.Policy == FailureAbort {
+			return results, &AbortError{Event: payload.Event, Hook: hook.DisplayName(), Err: result.Err}
+		}
+		fmt.Fprintf(r.out, "! Warning: %s hook %q failed: %v\n", payload.Event, hook.DisplayName(), result.Err)
+	}
+
+	return results, nil
+}
+
+// Test runs every hook for the payload's event regardless of failures and
+// returns their results without printing warnings
+func (r *Runner) Test(ctx context.Context, payload *Payload) []Result {
+	hooks := r.config.Hooks(payload.Event)
+	results := make([]Result, 0, len(hooks))
+	for _, hook := range hooks {
+		results = append(results, r.runHook(ctx, hook, r.prepare(payload)))
+	}
+	return results
+}
+
+// prepare fills in the payload fields the caller left unset
+func (r *Runner) prepare(payload *Payload) *Payload {
+	if payload.Timestamp.IsZero() {
+		payload.Timestamp = time.Now()
+	}
+	if payload.Workspace == "" {
+		payload.Workspace = r.dir
+	}
+	return payload
+}
+
+func (r *Runner) runHook(ctx context.Context, hook Hook, payload *Payload) Result {
+	result := Result{Hook: hook, Event: payload.Event, Policy: r.Policy(hook, payload.Event)}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	timeout := r.Timeout(hook)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.logger.Debug("running hook", "event", payload.Event, "hook", hook.DisplayName(), "timeout", timeout)
+
+	if hook.Builtin != "" {
+		builtin, ok := builtins[hook.Builtin]
+		if !ok {
+			result.Err = fmt.Errorf("unknown builtin %q", hook.Builtin)
+			return result
+		}
+		result.Err = builtin(ctx, r, payload)
+		return result
+	}
+
+	input, err := json.Marshal(payload)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to encode payload: %w", err)
+		return result
+	}
+
+	cmd := shellCommand(ctx, hook.Run)
+	cmd.Dir = r.dir
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = r.out
+	cmd.Stderr = r.out
+	cmd.Env = append(os.Environ(), "ZEN_HOOK_EVENT="+string(payload.Event), "ZEN_WORKSPACE="+payload.Workspace)
+	for key, value := range hook.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	// Don't wait for background processes holding the output open after a timeout
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	result.Err = err
+	return result
+}
+
+// Policy returns the failure policy that applies to hook on event
+func (r *Runner) Policy(hook Hook, event Event) FailurePolicy {
+	if hook.OnFailure != "" {
+		return hook.OnFailure
+	}
+	if event.IsPre() {
+		return FailureAbort
+	}
+	return FailureWarn
+}
+
+// Timeout returns the timeout that applies to hook
+func (r *Runner) Timeout(hook Hook) time.Duration {
+	for _, value := range []string{hook.Timeout, r.config.Timeout} {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultTimeout
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
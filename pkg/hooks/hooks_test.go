@@ -0,0 +1,219 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRunner(t *testing.T, cfg Config) (*Runner, *bytes.Buffer, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use POSIX shell commands")
+	}
+
+	dir := t.TempDir()
+	out := &bytes.Buffer{}
+	return NewRunner(cfg, dir, out, logging.NewBasic()), out, dir
+}
+
+func TestParseEvent(t *testing.T) {
+	event, err := ParseEvent("pre_sync")
+	require.NoError(t, err)
+	assert.Equal(t, EventPreSync, event)
+	assert.True(t, event.IsPre())
+	assert.False(t, EventPostSync.IsPre())
+
+	_, err = ParseEvent("pre_deploy")
+	assert.ErrorContains(t, err, `unknown hook event "pre_deploy"`)
+}
+
+func TestConfigParser_Parse(t *testing.T) {
+	cfg, err := ConfigParser{}.Parse(map[string]interface{}{
+		"timeout": "5s",
+		"pre_task_create": []interface{}{
+			map[string]interface{}{"name": "check", "run": "true", "on_failure": "warn"},
+		},
+		"post_init": []interface{}{
+			map[string]interface{}{"builtin": "log"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, "5s", cfg.Timeout)
+	require.Len(t, cfg.Hooks(EventPreTaskCreate), 1)
+	assert.Equal(t, FailureWarn, cfg.PreTaskCreate[0].OnFailure)
+	assert.Equal(t, "builtin:log", cfg.Hooks(EventPostInit)[0].DisplayName())
+	assert.Empty(t, cfg.Hooks(EventPreSync))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		cfg     Config
+		wantErr string
+	}{
+		"no action":        {Config{PreSync: []Hook{{Name: "empty"}}}, "exactly one of run or builtin"},
+		"both actions":     {Config{PreSync: []Hook{{Run: "true", Builtin: "log"}}}, "exactly one of run or builtin"},
+		"unknown builtin":  {Config{PostSync: []Hook{{Builtin: "deploy"}}}, `unknown builtin "deploy"`},
+		"invalid timeout":  {Config{PostSync: []Hook{{Run: "true", Timeout: "soon"}}}, "invalid timeout"},
+		"invalid policy":   {Config{PostSync: []Hook{{Run: "true", OnFailure: "ignore"}}}, "invalid on_failure"},
+		"invalid default":  {Config{Timeout: "1 minute"}, "invalid timeout"},
+		"valid":            {Config{PreSync: []Hook{{Run: "true", Timeout: "1s", OnFailure: FailureWarn}}}, ""},
+		"empty is allowed": {DefaultConfig(), ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunner_PassesPayloadOnStdin(t *testing.T) {
+	runner, _, dir := newTestRunner(t, Config{
+		PostTaskCreate: []Hook{{Run: `cat > payload.json; echo "$ZEN_HOOK_EVENT $GREETING" > env.txt`, Env: map[string]string{"GREETING": "hello"}}},
+	})
+
+	results, err := runner.Run(context.Background(), &Payload{
+		Event: EventPostTaskCreate,
+		Task:  map[string]string{"id": "ZEN-1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "payload.json"))
+	require.NoError(t, err)
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &payload))
+	assert.Equal(t, "post_task_create", payload["event"])
+	assert.Equal(t, dir, payload["workspace"])
+	assert.Equal(t, map[string]interface{}{"id": "ZEN-1"}, payload["task"])
+
+	env, err := os.ReadFile(filepath.Join(dir, "env.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "post_task_create hello\n", string(env))
+}
+
+func TestRunner_AbortStopsRemainingHooks(t *testing.T) {
+	runner, _, dir := newTestRunner(t, Config{
+		PreTaskCreate: []Hook{
+			{Name: "reject", Run: "exit 3"},
+			{Name: "never", Run: "touch ran"},
+		},
+	})
+
+	results, err := runner.Run(context.Background(), &Payload{Event: EventPreTaskCreate})
+
+	var abortErr *AbortError
+	require.ErrorAs(t, err, &abortErr)
+	assert.Equal(t, "reject", abortErr.Hook)
+	assert.Equal(t, `pre_task_create hook "reject" failed: exit status 3`, err.Error())
+	assert.Len(t, results, 1)
+	assert.NoFileExists(t, filepath.Join(dir, "ran"))
+}
+
+func TestRunner_WarnContinues(t *testing.T) {
+	runner, out, dir := newTestRunner(t, Config{
+		PreSync: []Hook{
+			{Name: "flaky", Run: "exit 1", OnFailure: FailureWarn},
+			{Name: "next", Run: "touch ran"},
+		},
+		PostSync: []Hook{{Name: "notify", Run: "exit 1"}},
+	})
+	ctx := context.Background()
+
+	results, err := runner.Run(ctx, &Payload{Event: EventPreSync})
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.FileExists(t, filepath.Join(dir, "ran"))
+	assert.Contains(t, out.String(), `! Warning: pre_sync hook "flaky" failed: exit status 1`)
+
+	// post_* hooks only warn by default
+	_, err = runner.Run(ctx, &Payload{Event: EventPostSync})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `post_sync hook "notify" failed`)
+}
+
+func TestRunner_Timeout(t *testing.T) {
+	runner, _, _ := newTestRunner(t, Config{
+		Timeout:  "10s",
+		PostInit: []Hook{{Name: "slow", Run: "sleep 5", Timeout: "100ms", OnFailure: FailureAbort}},
+	})
+
+	_, err := runner.Run(context.Background(), &Payload{Event: EventPostInit})
+	assert.ErrorContains(t, err, "timed out after 100ms")
+}
+
+func TestRunner_Test(t *testing.T) {
+	runner, out, _ := newTestRunner(t, Config{
+		PreSync: []Hook{
+			{Name: "fails", Run: "exit 1"},
+			{Name: "passes", Run: "true"},
+		},
+	})
+
+	results := runner.Test(context.Background(), &Payload{Event: EventPreSync})
+	require.Len(t, results, 2)
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, FailureAbort, results[0].Policy)
+	assert.NoError(t, results[1].Err)
+	assert.Empty(t, out.String())
+}
+
+func TestTrigger_NilRunner(t *testing.T) {
+	assert.NoError(t, Trigger(context.Background(), nil, &Payload{Event: EventPreSync}))
+}
+
+func TestBuiltin_Log(t *testing.T) {
+	runner, _, dir := newTestRunner(t, Config{PostInit: []Hook{{Builtin: "log"}}})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := runner.Run(ctx, &Payload{Event: EventPostInit})
+		require.NoError(t, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ".zen", "hooks.log"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"event":"post_init"`)
+}
+
+func TestBuiltin_GitAdd(t *testing.T) {
+	runner, _, dir := newTestRunner(t, Config{PostTaskCreate: []Hook{{Builtin: "git-add"}}})
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Skipf("git not available: %s", out)
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "tasks", "ZEN-1"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks", "ZEN-1", "index.md"), []byte("# ZEN-1\n"), 0644))
+
+	_, err := runner.Run(context.Background(), &Payload{
+		Event: EventPostTaskCreate,
+		Data:  map[string]interface{}{"paths": []string{filepath.Join(dir, "tasks", "ZEN-1")}},
+	})
+	require.NoError(t, err)
+
+	cmd := exec.Command("git", "diff", "--cached", "--name-only")
+	cmd.Dir = dir
+	staged, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "tasks/ZEN-1/index.md\n", string(staged))
+}
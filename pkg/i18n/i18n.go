@@ -0,0 +1,144 @@
+// Package i18n translates user-facing CLI messages.
+//
+// Messages are looked up by key in catalogs embedded from locales/*.yaml, with
+// the English catalog as the fallback. Only human-readable text should go
+// through this package; JSON and YAML output, error codes and field names must
+// stay locale-independent so scripts behave the same everywhere.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed locales/*.yaml
+var catalogFiles embed.FS
+
+// DefaultLocale is used when no supported locale is configured
+const DefaultLocale = "en"
+
+// localeEnvVars lists the environment variables consulted for the locale, in order of precedence
+var localeEnvVars = []string{"ZEN_LANG", "LC_ALL", "LC_MESSAGES", "LANG"}
+
+var (
+	mu       sync.RWMutex
+	current  = DefaultLocale
+	catalogs map[string]map[string]string
+	loadOnce sync.Once
+	loadErr  error
+)
+
+// loadCatalogs parses every embedded catalog once
+func loadCatalogs() (map[string]map[string]string, error) {
+	loadOnce.Do(func() {
+		entries, err := catalogFiles.ReadDir("locales")
+		if err != nil {
+			loadErr = err
+			return
+		}
+
+		catalogs = make(map[string]map[string]string, len(entries))
+		for _, entry := range entries {
+			data, err := catalogFiles.ReadFile(path.Join("locales", entry.Name()))
+			if err != nil {
+				loadErr = err
+				return
+			}
+
+			messages := make(map[string]string)
+			if err := yaml.Unmarshal(data, &messages); err != nil {
+				loadErr = fmt.Errorf("invalid message catalog %s: %w", entry.Name(), err)
+				return
+			}
+			catalogs[strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))] = messages
+		}
+	})
+
+	return catalogs, loadErr
+}
+
+// Locales returns the available locales in sorted order
+func Locales() []string {
+	all, _ := loadCatalogs()
+
+	locales := make([]string, 0, len(all))
+	for locale := range all {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// DetectLocale returns the best available locale for the environment,
+// checking ZEN_LANG first and then the standard LC_ALL, LC_MESSAGES and LANG variables
+func DetectLocale() string {
+	for _, name := range localeEnvVars {
+		if value := os.Getenv(name); value != "" {
+			return resolve(value)
+		}
+	}
+	return DefaultLocale
+}
+
+// SetLocale sets the locale used by T. Unsupported locales fall back to English.
+func SetLocale(locale string) {
+	resolved := resolve(locale)
+
+	mu.Lock()
+	current = resolved
+	mu.Unlock()
+}
+
+// Locale returns the current locale
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T returns the message for key in the current locale, formatted with args.
+// Keys missing from the current catalog fall back to English, then to the key itself.
+func T(key string, args ...interface{}) string {
+	all, _ := loadCatalogs()
+
+	message, ok := all[Locale()][key]
+	if !ok {
+		message, ok = all[DefaultLocale][key]
+	}
+	if !ok {
+		message = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// resolve maps a POSIX locale such as "es_ES.UTF-8" to an available catalog
+func resolve(locale string) string {
+	all, _ := loadCatalogs()
+
+	// Strip encoding and modifier, e.g. "de_DE.UTF-8@euro"
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	locale = strings.ToLower(strings.ReplaceAll(locale, "-", "_"))
+
+	if _, ok := all[locale]; ok {
+		return locale
+	}
+	if language, _, found := strings.Cut(locale, "_"); found {
+		if _, ok := all[language]; ok {
+			return language
+		}
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogs(t *testing.T) {
+	all, err := loadCatalogs()
+	require.NoError(t, err)
+	require.Contains(t, all, DefaultLocale)
+	assert.Contains(t, Locales(), "es")
+
+	// Every translation must translate an English key and keep its format verbs
+	for locale, messages := range all {
+		for key, message := range messages {
+			english, ok := all[DefaultLocale][key]
+			if assert.True(t, ok, "%s: key %q is not in the English catalog", locale, key) {
+				assert.Equal(t, strings.Count(english, "%"), strings.Count(message, "%"),
+					"%s: key %q has different format verbs", locale, key)
+			}
+		}
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		zenLang string
+		lang    string
+		want    string
+	}{
+		{"posix locale", "", "es_ES.UTF-8", "es"},
+		{"ZEN_LANG wins", "en", "es_ES.UTF-8", "en"},
+		{"ZEN_LANG only", "es", "", "es"},
+		{"unsupported", "", "ja_JP.UTF-8", DefaultLocale},
+		{"C locale", "", "C", DefaultLocale},
+		{"unset", "", "", DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", "")
+			t.Setenv("LC_MESSAGES", "")
+			t.Setenv("ZEN_LANG", tt.zenLang)
+			t.Setenv("LANG", tt.lang)
+
+			assert.Equal(t, tt.want, DetectLocale())
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { SetLocale(DefaultLocale) })
+
+	SetLocale("en_US")
+	assert.Equal(t, "en", Locale())
+	assert.Equal(t, "Use 'zen --help' to see available commands", T("suggestion.unknown_command"))
+	assert.Equal(t, `Use "zen task [command] --help" for more information about a command.`, T("help.more_info", "zen task"))
+
+	SetLocale("es-MX")
+	assert.Equal(t, "es", Locale())
+	assert.Equal(t, "Use 'zen --help' para ver los comandos disponibles", T("suggestion.unknown_command"))
+
+	// Unknown keys are returned unchanged
+	assert.Equal(t, "missing.key", T("missing.key"))
+}
@@ -0,0 +1,90 @@
+package identity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// User is a person who owns tasks, with their accounts in the task systems
+type User struct {
+	// Name is the display name of the user, e.g. "Ana Lopez"
+	Name string `yaml:"name" json:"name" mapstructure:"name"`
+
+	// Email is the email address of the user
+	Email string `yaml:"email" json:"email,omitempty" mapstructure:"email"`
+
+	// Accounts are the user's account IDs by provider, e.g. the Jira accountId or the
+	// GitHub login
+	Accounts map[string]string `yaml:"accounts" json:"accounts,omitempty" mapstructure:"accounts"`
+}
+
+// Config contains identity configuration
+type Config struct {
+	// Me is the local user who owns the tasks created in this workspace; $USER when
+	// empty
+	Me string `yaml:"me" json:"me" mapstructure:"me"`
+
+	// Users are the known users by local user name
+	Users map[string]User `yaml:"users" json:"users,omitempty" mapstructure:"users"`
+}
+
+// DefaultConfig returns default identity configuration
+func DefaultConfig() Config {
+	return Config{
+		Users: map[string]User{},
+	}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the identity configuration
+func (c Config) Validate() error {
+	for name, user := range c.Users {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("user name cannot be empty")
+		}
+		if user.Email != "" && !strings.Contains(user.Email, "@") {
+			return fmt.Errorf("invalid email for user %s: %s", name, user.Email)
+		}
+	}
+	return nil
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode identity config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for identities
+func (p ConfigParser) Section() string {
+	return "identity"
+}
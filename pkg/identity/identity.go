@@ -0,0 +1,119 @@
+// Package identity maps the local users who own tasks to their accounts in the task
+// systems, such as the Jira accountId or the GitHub login, so that the owner of a task
+// is the same person locally and in every provider.
+package identity
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Unknown is the owner of tasks created when no user can be determined
+const Unknown = "unknown"
+
+// Directory looks up the users in the identity configuration
+type Directory struct {
+	me    string
+	users map[string]User
+}
+
+// New returns a directory of the users in cfg
+func New(cfg Config) *Directory {
+	users := make(map[string]User, len(cfg.Users))
+	for name, user := range cfg.Users {
+		users[strings.ToLower(name)] = user
+	}
+	return &Directory{me: cfg.Me, users: users}
+}
+
+// Current returns the local user: identity.me, else the user matching $USER, else
+// $USER, else Unknown
+func (d *Directory) Current() string {
+	if d.me != "" {
+		return d.Resolve(d.me)
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return d.Resolve(user)
+	}
+	return Unknown
+}
+
+// Lookup returns the local name and details of the user known by value, which may be
+// their local name, display name, email address, or an account ID in any provider,
+// compared case-insensitively
+func (d *Directory) Lookup(value string) (string, User, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", User{}, false
+	}
+	if user, ok := d.users[strings.ToLower(value)]; ok {
+		return strings.ToLower(value), user, true
+	}
+
+	// Sorted so that a value shared by two users always matches the same one
+	for _, name := range d.names() {
+		user := d.users[name]
+		if strings.EqualFold(user.Name, value) || strings.EqualFold(user.Email, value) {
+			return name, user, true
+		}
+		for _, account := range user.Accounts {
+			if strings.EqualFold(account, value) {
+				return name, user, true
+			}
+		}
+	}
+	return "", User{}, false
+}
+
+// Resolve returns the local name of the user known by value, or value when no user
+// matches
+func (d *Directory) Resolve(value string) string {
+	if name, _, ok := d.Lookup(value); ok {
+		return name
+	}
+	return value
+}
+
+// Name returns the display name of owner, or owner when it has none
+func (d *Directory) Name(owner string) string {
+	if _, user, ok := d.Lookup(owner); ok && user.Name != "" {
+		return user.Name
+	}
+	return owner
+}
+
+// Email returns the email address of owner, or "" when it is unknown
+func (d *Directory) Email(owner string) string {
+	if _, user, ok := d.Lookup(owner); ok && user.Email != "" {
+		return user.Email
+	}
+	if strings.Contains(owner, "@") {
+		return owner
+	}
+	return ""
+}
+
+// Account returns the account ID of owner in provider, or "" when it is unknown
+func (d *Directory) Account(owner, provider string) string {
+	_, user, ok := d.Lookup(owner)
+	if !ok {
+		return ""
+	}
+	for name, account := range user.Accounts {
+		if strings.EqualFold(name, provider) {
+			return account
+		}
+	}
+	return ""
+}
+
+// names returns the local names of the users, sorted
+func (d *Directory) names() []string {
+	names := make([]string, 0, len(d.users))
+	for name := range d.users {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
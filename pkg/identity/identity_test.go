@@ -0,0 +1,104 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDirectory() *Directory {
+	return New(Config{
+		Me: "ana",
+		Users: map[string]User{
+			"ana": {
+				Name:     "Ana Lopez",
+				Email:    "ana@example.com",
+				Accounts: map[string]string{"jira": "5b10ac8d82e05b22cc7d4ef5", "github": "ana-lopez"},
+			},
+			"Ben": {Name: "Ben Okafor"},
+		},
+	})
+}
+
+func TestDirectory_Lookup(t *testing.T) {
+	d := testDirectory()
+
+	for _, value := range []string{"ana", "ANA", "Ana Lopez", "ana@example.com", "5b10ac8d82e05b22cc7d4ef5", "Ana-Lopez"} {
+		name, user, ok := d.Lookup(value)
+		require.True(t, ok, value)
+		assert.Equal(t, "ana", name, value)
+		assert.Equal(t, "Ana Lopez", user.Name, value)
+	}
+
+	name, _, ok := d.Lookup("ben okafor")
+	require.True(t, ok)
+	assert.Equal(t, "ben", name, "local names are lower case")
+
+	_, _, ok = d.Lookup("Carla")
+	assert.False(t, ok)
+	_, _, ok = d.Lookup("")
+	assert.False(t, ok)
+}
+
+func TestDirectory_Resolve(t *testing.T) {
+	d := testDirectory()
+
+	assert.Equal(t, "ana", d.Resolve("Ana Lopez"))
+	assert.Equal(t, "Carla", d.Resolve("Carla"), "unknown users are kept")
+	assert.Equal(t, "Ana Lopez", d.Name("ana"))
+	assert.Equal(t, "Carla", d.Name("Carla"))
+}
+
+func TestDirectory_Email(t *testing.T) {
+	d := testDirectory()
+
+	assert.Equal(t, "ana@example.com", d.Email("Ana Lopez"))
+	assert.Equal(t, "", d.Email("ben"), "no email is guessed")
+	assert.Equal(t, "carla@example.com", d.Email("carla@example.com"))
+	assert.Equal(t, "", d.Email("Carla"))
+}
+
+func TestDirectory_Account(t *testing.T) {
+	d := testDirectory()
+
+	assert.Equal(t, "5b10ac8d82e05b22cc7d4ef5", d.Account("ana", "jira"))
+	assert.Equal(t, "ana-lopez", d.Account("ana@example.com", "GitHub"))
+	assert.Equal(t, "", d.Account("ana", "linear"))
+	assert.Equal(t, "", d.Account("Carla", "jira"))
+}
+
+func TestDirectory_Current(t *testing.T) {
+	assert.Equal(t, "ana", testDirectory().Current())
+
+	d := New(Config{Users: map[string]User{"ana": {Name: "Ana Lopez", Accounts: map[string]string{"unix": "alopez"}}}})
+	t.Setenv("USER", "alopez")
+	assert.Equal(t, "ana", d.Current(), "$USER is mapped to its user")
+
+	t.Setenv("USER", "carla")
+	assert.Equal(t, "carla", d.Current())
+
+	t.Setenv("USER", "")
+	assert.Equal(t, Unknown, d.Current())
+}
+
+func TestConfigParser(t *testing.T) {
+	cfg, err := ConfigParser{}.Parse(map[string]interface{}{
+		"me": "ana",
+		"users": map[string]interface{}{
+			"ana": map[string]interface{}{
+				"name":     "Ana Lopez",
+				"email":    "ana@example.com",
+				"accounts": map[string]interface{}{"jira": "5b10ac8d"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "ana", cfg.Me)
+	assert.Equal(t, "5b10ac8d", cfg.Users["ana"].Accounts["jira"])
+	assert.Equal(t, "identity", ConfigParser{}.Section())
+
+	cfg.Users["ben"] = User{Email: "ben"}
+	assert.ErrorContains(t, cfg.Validate(), "invalid email for user ben")
+}
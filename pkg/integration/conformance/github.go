@@ -0,0 +1,267 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (s *Server) githubRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /user", s.githubUser)
+	mux.HandleFunc("GET /repos/{owner}/{repo}", s.githubRepo)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/issues", s.githubListIssues)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/issues", s.githubCreateIssue)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/issues/{number}", s.githubGetIssue)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/issues/{number}", s.githubUpdateIssue)
+}
+
+// githubRepoFound reports whether r is for the server's repository, answering with
+// Not Found when it is not
+func (s *Server) githubRepoFound(w http.ResponseWriter, r *http.Request) bool {
+	if r.PathValue("owner")+"/"+r.PathValue("repo") != s.Project {
+		s.writeError(w, http.StatusNotFound, "Not Found")
+		return false
+	}
+	return true
+}
+
+func (s *Server) githubUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-OAuth-Scopes", "repo, read:org")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"login": "zen-conformance",
+		"id":    1,
+		"type":  "User",
+	})
+}
+
+func (s *Server) githubRepo(w http.ResponseWriter, r *http.Request) {
+	if !s.githubRepoFound(w, r) {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":             1,
+		"full_name":      s.Project,
+		"default_branch": "main",
+		"has_issues":     true,
+	})
+}
+
+func (s *Server) githubGetIssue(w http.ResponseWriter, r *http.Request) {
+	if !s.githubRepoFound(w, r) {
+		return
+	}
+	issue, ok := s.Issue(r.PathValue("number"))
+	if !ok {
+		s.writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.githubIssue(issue))
+}
+
+// githubIssueRequest is the body of requests that create and update issues
+type githubIssueRequest struct {
+	Title     *string  `json:"title"`
+	Body      *string  `json:"body"`
+	State     *string  `json:"state"`
+	Labels    []string `json:"labels"`
+	Assignees []string `json:"assignees"`
+}
+
+// apply sets the fields of issue that the request names
+func (req githubIssueRequest) apply(issue *Issue) error {
+	if req.Title != nil {
+		issue.Title = *req.Title
+	}
+	if req.Body != nil {
+		issue.Description = *req.Body
+	}
+	if req.State != nil {
+		if *req.State != "open" && *req.State != "closed" {
+			return fmt.Errorf("state must be open or closed")
+		}
+		issue.Status = *req.State
+	}
+	if req.Labels != nil {
+		issue.Labels = req.Labels
+	}
+	if req.Assignees != nil {
+		issue.Assignee = ""
+		if len(req.Assignees) > 0 {
+			issue.Assignee = req.Assignees[0]
+		}
+	}
+	return nil
+}
+
+func (s *Server) githubCreateIssue(w http.ResponseWriter, r *http.Request) {
+	if !s.githubRepoFound(w, r) {
+		return
+	}
+	var req githubIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+	if req.Title == nil || *req.Title == "" {
+		s.writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+
+	issue := Issue{Status: "open"}
+	if err := req.apply(&issue); err != nil {
+		s.writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+
+	s.mu.Lock()
+	issue = s.addLocked(issue)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, s.githubIssue(issue))
+}
+
+func (s *Server) githubUpdateIssue(w http.ResponseWriter, r *http.Request) {
+	if !s.githubRepoFound(w, r) {
+		return
+	}
+	var req githubIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issue, ok := s.issues[r.PathValue("number")]
+	if !ok {
+		s.writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	updated := *issue
+	if err := req.apply(&updated); err != nil {
+		s.writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+	updated.Updated = time.Now().Truncate(time.Second)
+	*issue = updated
+
+	writeJSON(w, http.StatusOK, s.githubIssue(updated))
+}
+
+// githubListIssues lists issues filtered by state, labels, assignee, and since, a page
+// at a time, with a Link header to the next page as GitHub sends
+func (s *Server) githubListIssues(w http.ResponseWriter, r *http.Request) {
+	if !s.githubRepoFound(w, r) {
+		return
+	}
+	query := r.URL.Query()
+
+	state := query.Get("state")
+	if state == "" {
+		state = "open"
+	}
+	var since time.Time
+	if value := query.Get("since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			s.writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+			return
+		}
+		since = parsed
+	}
+	var labels []string
+	if value := query.Get("labels"); value != "" {
+		labels = strings.Split(value, ",")
+	}
+	assignee := query.Get("assignee")
+
+	s.mu.Lock()
+	var matched []Issue
+	for _, issue := range s.sortedLocked() {
+		if state != "all" && issue.Status != state {
+			continue
+		}
+		if !since.IsZero() && issue.Updated.Before(since) {
+			continue
+		}
+		if assignee != "" && assignee != "*" && issue.Assignee != assignee {
+			continue
+		}
+		if !hasLabels(issue, labels) {
+			continue
+		}
+		matched = append(matched, issue)
+	}
+	s.mu.Unlock()
+
+	perPage := s.pageSize(query.Get("per_page"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	page = max(page, 1)
+	start := min((page-1)*perPage, len(matched))
+	end := min(start+perPage, len(matched))
+
+	if end < len(matched) {
+		next := *r.URL
+		next.Scheme, next.Host = "http", r.Host
+		values := url.Values{}
+		for name, value := range query {
+			values[name] = value
+		}
+		values.Set("page", strconv.Itoa(page+1))
+		values.Set("per_page", strconv.Itoa(perPage))
+		next.RawQuery = values.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+
+	issues := make([]map[string]interface{}, 0, end-start)
+	for _, issue := range matched[start:end] {
+		issues = append(issues, s.githubIssue(issue))
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+// hasLabels reports whether issue has every one of labels
+func hasLabels(issue Issue, labels []string) bool {
+	for _, label := range labels {
+		if !slices.Contains(issue.Labels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// githubIssue returns issue as the GitHub REST API does
+func (s *Server) githubIssue(issue Issue) map[string]interface{} {
+	number, _ := strconv.Atoi(issue.Key)
+	id, _ := strconv.Atoi(issue.ID)
+	labels := make([]map[string]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, map[string]string{"name": label})
+	}
+	body := map[string]interface{}{
+		"id":         id,
+		"number":     number,
+		"title":      issue.Title,
+		"body":       issue.Description,
+		"state":      issue.Status,
+		"labels":     labels,
+		"assignee":   nil,
+		"assignees":  []interface{}{},
+		"created_at": issue.Created.UTC().Format(time.RFC3339),
+		"updated_at": issue.Updated.UTC().Format(time.RFC3339),
+		"url":        fmt.Sprintf("%s/repos/%s/issues/%d", s.URL, s.Project, number),
+		"html_url":   fmt.Sprintf("https://github.com/%s/issues/%d", s.Project, number),
+	}
+	if issue.Assignee != "" {
+		assignee := map[string]string{"login": issue.Assignee}
+		body["assignee"] = assignee
+		body["assignees"] = []interface{}{assignee}
+	}
+	return body
+}
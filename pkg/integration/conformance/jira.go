@@ -0,0 +1,381 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jiraTimeFormat is the timestamp format of the Jira REST API
+const jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+func (s *Server) jiraRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /rest/api/3/serverInfo", s.jiraServerInfo)
+	mux.HandleFunc("GET /rest/api/3/myself", s.jiraMyself)
+	mux.HandleFunc("GET /rest/api/3/issue/{key}", s.jiraGetIssue)
+	mux.HandleFunc("POST /rest/api/3/issue", s.jiraCreateIssue)
+	mux.HandleFunc("PUT /rest/api/3/issue/{key}", s.jiraUpdateIssue)
+	mux.HandleFunc("DELETE /rest/api/3/issue/{key}", s.jiraDeleteIssue)
+	mux.HandleFunc("GET /rest/api/3/search", s.jiraSearch)
+}
+
+func (s *Server) jiraServerInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"baseUrl":        s.URL,
+		"version":        "1001.0.0-SNAPSHOT",
+		"deploymentType": "Cloud",
+		"serverTitle":    "Zen Conformance",
+	})
+}
+
+func (s *Server) jiraMyself(w http.ResponseWriter, r *http.Request) {
+	email, _ := s.Credentials()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"accountId":    "conformance",
+		"emailAddress": email,
+		"displayName":  "Zen Conformance",
+	})
+}
+
+func (s *Server) jiraGetIssue(w http.ResponseWriter, r *http.Request) {
+	issue, ok := s.Issue(r.PathValue("key"))
+	if !ok {
+		s.writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.jiraIssue(issue))
+}
+
+// jiraIssueRequest is the body of requests that create and update issues
+type jiraIssueRequest struct {
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+func (s *Server) jiraCreateIssue(w http.ResponseWriter, r *http.Request) {
+	var req jiraIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Request body is not valid JSON.")
+		return
+	}
+
+	var project struct {
+		Key string `json:"key"`
+	}
+	_ = json.Unmarshal(req.Fields["project"], &project)
+	if project.Key != s.Project {
+		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("Project %q does not exist.", project.Key))
+		return
+	}
+
+	issue := Issue{Status: "To Do"}
+	if err := applyJiraFields(&issue, req.Fields); err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if issue.Title == "" {
+		s.writeError(w, http.StatusBadRequest, "You must specify a summary of the issue.")
+		return
+	}
+
+	s.mu.Lock()
+	issue = s.addLocked(issue)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"id":   issue.ID,
+		"key":  issue.Key,
+		"self": s.URL + "/rest/api/3/issue/" + issue.ID,
+	})
+}
+
+func (s *Server) jiraUpdateIssue(w http.ResponseWriter, r *http.Request) {
+	var req jiraIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Request body is not valid JSON.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issue, ok := s.issues[r.PathValue("key")]
+	if !ok {
+		s.writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+		return
+	}
+	updated := *issue
+	if err := applyJiraFields(&updated, req.Fields); err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated.Updated = time.Now().Truncate(time.Millisecond)
+	*issue = updated
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) jiraDeleteIssue(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.PathValue("key")
+	if _, ok := s.issues[key]; !ok {
+		s.writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+		return
+	}
+	delete(s.issues, key)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyJiraFields sets the fields of issue that a create or update request names
+func applyJiraFields(issue *Issue, fields map[string]json.RawMessage) error {
+	for name, raw := range fields {
+		var err error
+		switch name {
+		case "summary":
+			err = json.Unmarshal(raw, &issue.Title)
+		case "description":
+			err = json.Unmarshal(raw, &issue.Description)
+		case "labels":
+			err = json.Unmarshal(raw, &issue.Labels)
+		case "priority":
+			var priority struct {
+				Name string `json:"name"`
+			}
+			if err = json.Unmarshal(raw, &priority); err == nil && priority.Name != "" {
+				issue.Priority = priority.Name
+			}
+		case "assignee":
+			var assignee struct {
+				AccountID string `json:"accountId"`
+			}
+			if err = json.Unmarshal(raw, &assignee); err == nil && assignee.AccountID != "" {
+				issue.Assignee = assignee.AccountID
+			}
+		case "project", "issuetype":
+			// Every issue belongs to the server's project and is a task
+		default:
+			return fmt.Errorf("field '%s' cannot be set: it is not on the appropriate screen, or unknown", name)
+		}
+		if err != nil {
+			return fmt.Errorf("field '%s' is invalid: %s", name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) jiraSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	match, err := s.parseJQL(query.Get("jql"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	var matched []Issue
+	for _, issue := range s.sortedLocked() {
+		if match(issue) {
+			matched = append(matched, issue)
+		}
+	}
+	s.mu.Unlock()
+
+	startAt, _ := strconv.Atoi(query.Get("startAt"))
+	startAt = min(max(startAt, 0), len(matched))
+	end := min(startAt+s.pageSize(query.Get("maxResults")), len(matched))
+
+	issues := make([]map[string]interface{}, 0, end-startAt)
+	for _, issue := range matched[startAt:end] {
+		issues = append(issues, s.jiraIssue(issue))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"startAt":    startAt,
+		"maxResults": s.pageSize(query.Get("maxResults")),
+		"total":      len(matched),
+		"issues":     issues,
+	})
+}
+
+// jiraIssue returns issue as the Jira REST API does
+func (s *Server) jiraIssue(issue Issue) map[string]interface{} {
+	fields := map[string]interface{}{
+		"summary":     issue.Title,
+		"description": issue.Description,
+		"status":      map[string]string{"name": issue.Status, "id": jiraStatusID(issue.Status)},
+		"issuetype":   map[string]string{"name": "Task", "id": "10002"},
+		"project":     map[string]string{"key": s.Project, "name": s.Project, "id": "10000"},
+		"labels":      issue.Labels,
+		"created":     issue.Created.Format(jiraTimeFormat),
+		"updated":     issue.Updated.Format(jiraTimeFormat),
+		"assignee":    nil,
+		"priority":    nil,
+	}
+	if issue.Priority != "" {
+		fields["priority"] = map[string]string{"name": issue.Priority}
+	}
+	if issue.Assignee != "" {
+		fields["assignee"] = map[string]string{
+			"displayName": issue.Assignee,
+			"accountId":   strings.ToLower(strings.ReplaceAll(issue.Assignee, " ", "-")),
+		}
+	}
+	return map[string]interface{}{
+		"id":     issue.ID,
+		"key":    issue.Key,
+		"self":   s.URL + "/rest/api/3/issue/" + issue.ID,
+		"fields": fields,
+	}
+}
+
+func jiraStatusID(status string) string {
+	switch status {
+	case "To Do":
+		return "1"
+	case "In Progress":
+		return "3"
+	case "Done":
+		return "10001"
+	default:
+		return "10100"
+	}
+}
+
+// jqlAnd separates the conditions of a JQL query
+var jqlAnd = regexp.MustCompile(`(?i)\s+AND\s+`)
+
+// jqlClause matches one condition of the JQL subset the server understands
+var jqlClause = regexp.MustCompile(`(?i)^(\w+)\s*(=|!=|>=|<=|\bin\b)\s*(.+)$`)
+
+// parseJQL returns a filter for a JQL query made of conditions joined with AND on
+// project, key, status, priority, assignee, labels, and updated, with an optional
+// ORDER BY that is ignored. Other queries are rejected, as Jira rejects invalid ones.
+func (s *Server) parseJQL(jql string) (func(Issue) bool, error) {
+	if i := strings.Index(strings.ToUpper(jql), "ORDER BY"); i >= 0 {
+		jql = jql[:i]
+	}
+	jql = strings.TrimSpace(jql)
+	if jql == "" {
+		return func(Issue) bool { return true }, nil
+	}
+
+	var filters []func(Issue) bool
+	for _, clause := range jqlAnd.Split(jql, -1) {
+		parts := jqlClause.FindStringSubmatch(strings.TrimSpace(clause))
+		if parts == nil {
+			return nil, fmt.Errorf("error in the JQL query: unsupported condition %q", clause)
+		}
+		field, op, value := strings.ToLower(parts[1]), strings.ToLower(parts[2]), strings.TrimSpace(parts[3])
+
+		filter, err := s.jqlFilter(field, op, value)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return func(issue Issue) bool {
+		for _, filter := range filters {
+			if !filter(issue) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func (s *Server) jqlFilter(field, op, value string) (func(Issue) bool, error) {
+	if field == "updated" {
+		since, err := jqlTime(value)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case ">=":
+			return func(issue Issue) bool { return !issue.Updated.Before(since) }, nil
+		case "<=":
+			return func(issue Issue) bool { return !issue.Updated.After(since) }, nil
+		}
+		return nil, fmt.Errorf("error in the JQL query: operator %q is not supported for updated", op)
+	}
+
+	var get func(Issue) []string
+	switch field {
+	case "project":
+		get = func(Issue) []string { return []string{s.Project} }
+	case "key", "issuekey":
+		get = func(issue Issue) []string { return []string{issue.Key} }
+	case "status":
+		get = func(issue Issue) []string { return []string{issue.Status} }
+	case "priority":
+		get = func(issue Issue) []string { return []string{issue.Priority} }
+	case "assignee":
+		get = func(issue Issue) []string { return []string{issue.Assignee} }
+	case "labels":
+		get = func(issue Issue) []string { return issue.Labels }
+	default:
+		return nil, fmt.Errorf("error in the JQL query: field %q is not supported", field)
+	}
+
+	var values []string
+	if op == "in" {
+		list := strings.TrimSuffix(strings.TrimPrefix(value, "("), ")")
+		for _, item := range strings.Split(list, ",") {
+			values = append(values, jqlValue(item))
+		}
+	} else {
+		values = []string{jqlValue(value)}
+	}
+
+	matches := func(issue Issue) bool {
+		for _, actual := range get(issue) {
+			for _, want := range values {
+				if strings.EqualFold(actual, want) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	switch op {
+	case "=", "in":
+		return matches, nil
+	case "!=":
+		return func(issue Issue) bool { return !matches(issue) }, nil
+	}
+	return nil, fmt.Errorf("error in the JQL query: operator %q is not supported for %s", op, field)
+}
+
+// jqlValue unquotes a JQL value
+func jqlValue(value string) string {
+	value = strings.TrimSpace(value)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return strings.Trim(value, `'`)
+}
+
+// jqlTime parses a JQL date: a relative age such as "-90m", "-2h", or "-1d", or an
+// absolute "2006-01-02" or "2006-01-02 15:04"
+func jqlTime(value string) (time.Time, error) {
+	value = jqlValue(value)
+	if rest, ok := strings.CutPrefix(value, "-"); ok && len(rest) > 1 {
+		n, err := strconv.Atoi(rest[:len(rest)-1])
+		if err == nil {
+			units := map[byte]time.Duration{'m': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+			if unit, ok := units[rest[len(rest)-1]]; ok {
+				return time.Now().Add(-time.Duration(n) * unit), nil
+			}
+		}
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02", "2006/01/02 15:04", "2006/01/02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("error in the JQL query: %q is not a valid date", value)
+}
@@ -0,0 +1,338 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Flavor names the API a mock server speaks
+type Flavor string
+
+const (
+	FlavorJira   Flavor = "jira"
+	FlavorGitHub Flavor = "github"
+)
+
+// Default credentials and project of mock servers
+const (
+	DefaultEmail   = "zen@example.com"
+	DefaultToken   = "conformance-token"
+	DefaultProject = "ZEN"
+	DefaultRepo    = "zen-org/zen"
+)
+
+// DefaultPageSize is the most results a mock server returns per page, unless a request
+// asks for fewer
+const DefaultPageSize = 50
+
+// Issue is an issue held by a mock server, in the terms of the API it speaks: Jira
+// statuses such as "In Progress", or GitHub states such as "open"
+type Issue struct {
+	Key         string
+	ID          string
+	Title       string
+	Description string
+	Status      string
+	Priority    string
+	Assignee    string
+	Labels      []string
+	Created     time.Time
+	Updated     time.Time
+}
+
+// Request is a request a mock server received
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+}
+
+// Server is an in-memory issue tracker that speaks a subset of the Jira Cloud REST API
+// (v3) or the GitHub REST API, for testing providers without the network. It starts
+// with the issues of Fixtures, accepts only the credentials set with SetCredentials,
+// DefaultEmail and DefaultToken at first, and pages results like the real APIs.
+type Server struct {
+	// URL is the base URL of the server, as configured for a provider
+	URL string
+	// Project is the Jira project key, or the GitHub "owner/repo", issues belong to
+	Project string
+	// MaxPageSize caps the results per page, to exercise pagination
+	MaxPageSize int
+
+	flavor Flavor
+	server *httptest.Server
+
+	mu       sync.Mutex
+	email    string
+	token    string
+	issues   map[string]*Issue
+	nextID   int
+	faults   []fault
+	requests []Request
+}
+
+// fault is an error response injected with FailNext
+type fault struct {
+	status int
+}
+
+// NewJiraServer starts a mock Jira server, closed when the test finishes
+func NewJiraServer(t testing.TB) *Server {
+	return newServer(t, FlavorJira, DefaultProject)
+}
+
+// NewGitHubServer starts a mock GitHub server, closed when the test finishes
+func NewGitHubServer(t testing.TB) *Server {
+	return newServer(t, FlavorGitHub, DefaultRepo)
+}
+
+func newServer(t testing.TB, flavor Flavor, project string) *Server {
+	s := &Server{
+		email:       DefaultEmail,
+		token:       DefaultToken,
+		Project:     project,
+		MaxPageSize: DefaultPageSize,
+		flavor:      flavor,
+		issues:      make(map[string]*Issue),
+	}
+
+	mux := http.NewServeMux()
+	switch flavor {
+	case FlavorJira:
+		s.jiraRoutes(mux)
+	case FlavorGitHub:
+		s.githubRoutes(mux)
+	}
+	s.server = httptest.NewServer(s.middleware(mux))
+	s.URL = s.server.URL
+	t.Cleanup(s.server.Close)
+
+	s.Seed(Fixtures(flavor)...)
+	return s
+}
+
+// Credentials returns the email and token the server accepts
+func (s *Server) Credentials() (email, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.email, s.token
+}
+
+// SetCredentials changes the email and token the server accepts, as when a token is
+// revoked
+func (s *Server) SetCredentials(email, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.email, s.token = email, token
+}
+
+// Flavor returns the API the server speaks
+func (s *Server) Flavor() Flavor {
+	return s.flavor
+}
+
+// Fixtures returns the issues mock servers of flavor start with: work in every status,
+// last updated a month ago
+func Fixtures(flavor Flavor) []Issue {
+	updated := time.Now().Add(-30 * 24 * time.Hour).Truncate(time.Second)
+	created := updated.Add(-7 * 24 * time.Hour)
+
+	if flavor == FlavorGitHub {
+		return []Issue{
+			{Title: "Add login page", Description: "Users sign in with SSO", Status: "open", Assignee: "octocat", Labels: []string{"feature"}, Created: created, Updated: updated},
+			{Title: "Fix session timeout", Description: "Sessions expire after a minute", Status: "open", Labels: []string{"bug"}, Created: created, Updated: updated},
+			{Title: "Document the API", Description: "Reference for every endpoint", Status: "closed", Assignee: "hubot", Created: created, Updated: updated},
+		}
+	}
+	return []Issue{
+		{Title: "Add login page", Description: "Users sign in with SSO", Status: "To Do", Priority: "High", Assignee: "Ada Lovelace", Labels: []string{"feature"}, Created: created, Updated: updated},
+		{Title: "Fix session timeout", Description: "Sessions expire after a minute", Status: "In Progress", Priority: "Medium", Assignee: "Alan Turing", Labels: []string{"bug"}, Created: created, Updated: updated},
+		{Title: "Document the API", Description: "Reference for every endpoint", Status: "Done", Priority: "Low", Created: created, Updated: updated},
+	}
+}
+
+// Seed adds issues to the server, assigning keys and IDs to those without
+func (s *Server) Seed(issues ...Issue) []Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seeded := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		seeded = append(seeded, s.addLocked(issue))
+	}
+	return seeded
+}
+
+// addLocked stores issue, assigning a key and ID when it has none
+func (s *Server) addLocked(issue Issue) Issue {
+	s.nextID++
+	if issue.ID == "" {
+		issue.ID = strconv.Itoa(10000 + s.nextID)
+	}
+	if issue.Key == "" {
+		issue.Key = s.keyFor(s.nextID)
+	}
+	if issue.Created.IsZero() {
+		issue.Created = time.Now().Truncate(time.Second)
+	}
+	if issue.Updated.IsZero() {
+		issue.Updated = issue.Created
+	}
+	issue.Labels = append([]string(nil), issue.Labels...)
+
+	stored := issue
+	s.issues[issue.Key] = &stored
+	return issue
+}
+
+// keyFor returns the key of the nth issue: "ZEN-3" in Jira, "3" in GitHub
+func (s *Server) keyFor(n int) string {
+	if s.flavor == FlavorGitHub {
+		return strconv.Itoa(n)
+	}
+	return fmt.Sprintf("%s-%d", s.Project, n)
+}
+
+// Issue returns the issue with key
+func (s *Server) Issue(key string) (Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issue, ok := s.issues[key]
+	if !ok {
+		return Issue{}, false
+	}
+	return *issue, true
+}
+
+// Issues returns every issue, in the order they were created
+func (s *Server) Issues() []Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedLocked()
+}
+
+func (s *Server) sortedLocked() []Issue {
+	issues := make([]Issue, 0, len(s.issues))
+	for _, issue := range s.issues {
+		issues = append(issues, *issue)
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		a, _ := strconv.Atoi(issues[i].ID)
+		b, _ := strconv.Atoi(issues[j].ID)
+		return a < b
+	})
+	return issues
+}
+
+// Touch sets when the issue with key was last updated
+func (s *Server) Touch(key string, updated time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if issue, ok := s.issues[key]; ok {
+		issue.Updated = updated
+	}
+}
+
+// FailNext answers the next n requests with status, and with "Retry-After: 0" when
+// the status asks clients to come back later
+func (s *Server) FailNext(n int, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.faults = append(s.faults, fault{status: status})
+	}
+}
+
+// Requests returns the requests the server received, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// middleware records requests, injects faults, and checks credentials
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery})
+		var injected *fault
+		if len(s.faults) > 0 {
+			injected = &s.faults[0]
+			s.faults = s.faults[1:]
+		}
+		s.mu.Unlock()
+
+		if injected != nil {
+			if injected.status == http.StatusTooManyRequests || injected.status == http.StatusServiceUnavailable {
+				w.Header().Set("Retry-After", "0")
+			}
+			s.writeError(w, injected.status, http.StatusText(injected.status))
+			return
+		}
+
+		if !s.authorized(r) {
+			s.writeError(w, http.StatusUnauthorized, "Client must be authenticated to access this resource.")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorized reports whether r carries the credentials of the server: basic
+// authentication with the email and token, or the token as a bearer token
+func (s *Server) authorized(r *http.Request) bool {
+	wantEmail, wantToken := s.Credentials()
+	if email, token, ok := r.BasicAuth(); ok {
+		return email == wantEmail && token == wantToken
+	}
+	authorization := r.Header.Get("Authorization")
+	for _, scheme := range []string{"Bearer ", "token "} {
+		if token, ok := strings.CutPrefix(authorization, scheme); ok {
+			return token == wantToken
+		}
+	}
+	return false
+}
+
+// writeError writes an error in the format of the API the server speaks
+func (s *Server) writeError(w http.ResponseWriter, status int, message string) {
+	if s.flavor == FlavorGitHub {
+		writeJSON(w, status, map[string]interface{}{
+			"message":           message,
+			"documentation_url": "https://docs.github.com/rest",
+		})
+		return
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"errorMessages": []string{message},
+		"errors":        map[string]string{},
+	})
+}
+
+// pageSize returns the page size a request asked for, capped at MaxPageSize
+func (s *Server) pageSize(requested string) int {
+	size := s.MaxPageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	if n, err := strconv.Atoi(requested); err == nil && n > 0 && n < size {
+		size = n
+	}
+	return size
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
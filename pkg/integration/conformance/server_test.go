@@ -0,0 +1,132 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// get sends an authenticated GET to server, decoding the JSON response into out
+func get(t *testing.T, server *Server, path, token string, out interface{}) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp
+}
+
+func TestGitHubServer(t *testing.T) {
+	server := NewGitHubServer(t)
+	require.Len(t, server.Issues(), 3)
+
+	t.Run("get issue", func(t *testing.T) {
+		var issue map[string]interface{}
+		resp := get(t, server, "/repos/zen-org/zen/issues/1", DefaultToken, &issue)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, float64(1), issue["number"])
+		assert.Equal(t, "Add login page", issue["title"])
+		assert.Equal(t, "octocat", issue["assignee"].(map[string]interface{})["login"])
+	})
+
+	t.Run("list filters by state and pages", func(t *testing.T) {
+		var issues []map[string]interface{}
+		resp := get(t, server, "/repos/zen-org/zen/issues?state=open&per_page=1", DefaultToken, &issues)
+		require.Len(t, issues, 1)
+		assert.Contains(t, resp.Header.Get("Link"), `page=2`)
+		assert.Contains(t, resp.Header.Get("Link"), `rel="next"`)
+
+		resp = get(t, server, "/repos/zen-org/zen/issues?state=open&per_page=1&page=2", DefaultToken, &issues)
+		require.Len(t, issues, 1)
+		assert.Equal(t, "Fix session timeout", issues[0]["title"])
+		assert.Empty(t, resp.Header.Get("Link"), "the last page has no next link")
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		resp := get(t, server, "/repos/zen-org/other/issues/1", DefaultToken, nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("bad token", func(t *testing.T) {
+		var body map[string]interface{}
+		resp := get(t, server, "/user", "wrong", &body)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.NotEmpty(t, body["message"])
+	})
+
+	t.Run("injected faults", func(t *testing.T) {
+		server.FailNext(1, http.StatusTooManyRequests)
+		resp := get(t, server, "/user", DefaultToken, nil)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "0", resp.Header.Get("Retry-After"))
+
+		resp = get(t, server, "/user", DefaultToken, nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	requests := server.Requests()
+	require.NotEmpty(t, requests)
+	assert.Equal(t, "/user", requests[len(requests)-1].Path)
+}
+
+func TestParseJQL(t *testing.T) {
+	server := NewJiraServer(t)
+	recent := server.Seed(Issue{Title: "Recent", Status: "In Progress", Priority: "High", Labels: []string{"bug"}, Updated: time.Now()})[0]
+
+	tests := []struct {
+		jql  string
+		want []string
+	}{
+		{"", []string{"ZEN-1", "ZEN-2", "ZEN-3", recent.Key}},
+		{"project = ZEN ORDER BY updated ASC", []string{"ZEN-1", "ZEN-2", "ZEN-3", recent.Key}},
+		{`project = ZEN AND status = "In Progress"`, []string{"ZEN-2", recent.Key}},
+		{`status != "In Progress"`, []string{"ZEN-1", "ZEN-3"}},
+		{`priority in (High, Low)`, []string{"ZEN-1", "ZEN-3", recent.Key}},
+		{`labels = bug and status = 'In Progress'`, []string{"ZEN-2", recent.Key}},
+		{"updated >= -60m", []string{recent.Key}},
+		{"key = ZEN-3", []string{"ZEN-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.jql, func(t *testing.T) {
+			match, err := server.parseJQL(tt.jql)
+			require.NoError(t, err)
+
+			var got []string
+			for _, issue := range server.Issues() {
+				if match(issue) {
+					got = append(got, issue.Key)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, jql := range []string{"summary ~ login", "sprint = 4", "updated = -1d", "updated >= yesterday"} {
+		_, err := server.parseJQL(jql)
+		assert.Error(t, err, jql)
+	}
+}
+
+func TestHarnessSkips(t *testing.T) {
+	h := Harness{Skip: []string{"CreateTask", "SearchTasksUpdatedSince"}}
+
+	assert.True(t, h.skips("CreateTask"))
+	assert.True(t, h.skips("SearchTasksUpdatedSince/Pagination"))
+	assert.False(t, h.skips("SearchTasks"))
+	assert.False(t, h.skips("CreateTaskLater"))
+
+	for _, name := range Behaviors() {
+		assert.False(t, strings.HasSuffix(name, "/"), name)
+	}
+}
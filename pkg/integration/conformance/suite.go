@@ -0,0 +1,356 @@
+// Package conformance holds the contract every integration provider must meet: a suite
+// of golden behaviors for reading, writing, searching, mapping, and error handling, and
+// mock Jira and GitHub servers to run it against without the network.
+//
+// A provider is validated with one test:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, conformance.Harness{
+//			NewProvider: func(t *testing.T, server *conformance.Server) integration.IntegrationProvider {
+//				email, token := server.Credentials()
+//				return NewProvider(server.URL, server.Project, email, token)
+//			},
+//		})
+//	}
+package conformance
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/integration"
+	"github.com/daddia/zen/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Harness describes the provider under test
+type Harness struct {
+	// Server starts the mock server the provider talks to; nil uses NewJiraServer
+	Server func(t testing.TB) *Server
+	// NewProvider creates the provider under test, configured with the URL, project,
+	// and credentials of server
+	NewProvider func(t *testing.T, server *Server) integration.IntegrationProvider
+	// Skip names behaviors the provider does not support, such as "CreateTask"; a
+	// behavior is skipped with all of the behaviors under it
+	Skip []string
+}
+
+// behavior is one golden behavior of the suite
+type behavior struct {
+	name string
+	run  func(t *testing.T, server *Server, provider integration.IntegrationProvider)
+}
+
+// behaviors are the golden behaviors every provider is checked for
+var behaviors = []behavior{
+	{"Identity", testIdentity},
+	{"ValidateConnection", testValidateConnection},
+	{"ValidateConnection/BadCredentials", testValidateConnectionBadCredentials},
+	{"GetTaskData", testGetTaskData},
+	{"GetTaskData/NotFound", testGetTaskDataNotFound},
+	{"CreateTask", testCreateTask},
+	{"UpdateTask", testUpdateTask},
+	{"SearchTasks", testSearchTasks},
+	{"SearchTasks/Filter", testSearchTasksFilter},
+	{"SearchTasksUpdatedSince", testSearchTasksUpdatedSince},
+	{"SearchTasksUpdatedSince/Pagination", testSearchTasksUpdatedSincePagination},
+	{"Mapping/RoundTrip", testMappingRoundTrip},
+	{"Mapping/Nil", testMappingNil},
+	{"Mapping/FieldMapping", testFieldMapping},
+	{"Errors/Unauthorized", testErrorUnauthorized},
+	{"Errors/TransientFailure", testErrorTransientFailure},
+	{"Errors/RateLimited", testErrorRateLimited},
+	{"HealthCheck", testHealthCheck},
+	{"HealthCheck/Unhealthy", testHealthCheckUnhealthy},
+}
+
+// Behaviors returns the names of the behaviors of the suite, for Harness.Skip
+func Behaviors() []string {
+	names := make([]string, len(behaviors))
+	for i, b := range behaviors {
+		names[i] = b.name
+	}
+	return names
+}
+
+// Run checks the provider of h for every golden behavior, each against a new mock
+// server
+func Run(t *testing.T, h Harness) {
+	t.Helper()
+	require.NotNil(t, h.NewProvider, "Harness.NewProvider is required")
+
+	newServer := h.Server
+	if newServer == nil {
+		newServer = NewJiraServer
+	}
+
+	for _, b := range behaviors {
+		t.Run(b.name, func(t *testing.T) {
+			if h.skips(b.name) {
+				t.Skip("not supported by the provider")
+			}
+			server := newServer(t)
+			b.run(t, server, h.NewProvider(t, server))
+		})
+	}
+}
+
+// skips reports whether name, or a behavior it falls under, is in h.Skip
+func (h Harness) skips(name string) bool {
+	for _, skip := range h.Skip {
+		if name == skip || len(name) > len(skip) && name[:len(skip)+1] == skip+"/" {
+			return true
+		}
+	}
+	return false
+}
+
+// testContext returns a context that bounds each behavior
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// requireClientError requires err to be a client error with code
+func requireClientError(t *testing.T, err error, code string, retryable bool) {
+	t.Helper()
+	require.Error(t, err)
+	var clientErr *clients.ClientError
+	require.True(t, errors.As(err, &clientErr), "errors must wrap *clients.ClientError, got %T: %v", err, err)
+	assert.Equal(t, code, clientErr.Code)
+	assert.Equal(t, retryable, clientErr.Retryable, "retryable")
+}
+
+// assertMatches asserts that task is the provider's view of issue
+func assertMatches(t *testing.T, issue Issue, task *integration.ExternalTaskData) {
+	t.Helper()
+	require.NotNil(t, task)
+	assert.Equal(t, issue.Key, task.ID, "ID is the key people know the issue by")
+	assert.Equal(t, issue.Title, task.Title)
+	assert.Equal(t, issue.Description, task.Description)
+	assert.Equal(t, issue.Status, task.Status)
+	assert.Equal(t, issue.Priority, task.Priority)
+	assert.Equal(t, issue.Assignee, task.Assignee)
+	assert.WithinDuration(t, issue.Created, task.Created, time.Second)
+	assert.WithinDuration(t, issue.Updated, task.Updated, time.Second)
+}
+
+func taskIDs(tasks []*integration.ExternalTaskData) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func issueKeys(issues []Issue) []string {
+	keys := make([]string, len(issues))
+	for i, issue := range issues {
+		keys[i] = issue.Key
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func testIdentity(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	assert.NotEmpty(t, provider.Name())
+	assert.Regexp(t, `^[a-z][a-z0-9_-]*$`, provider.Name(), "names are lower case, as in configuration")
+}
+
+func testValidateConnection(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	require.NoError(t, provider.ValidateConnection(testContext(t)))
+}
+
+func testValidateConnectionBadCredentials(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	email, _ := server.Credentials()
+	server.SetCredentials(email, "revoked")
+
+	assert.Error(t, provider.ValidateConnection(testContext(t)))
+}
+
+func testGetTaskData(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	for _, issue := range server.Issues() {
+		task, err := provider.GetTaskData(testContext(t), issue.Key)
+		require.NoError(t, err, issue.Key)
+		assertMatches(t, issue, task)
+	}
+}
+
+func testGetTaskDataNotFound(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	_, err := provider.GetTaskData(testContext(t), server.keyFor(9999))
+	requireClientError(t, err, clients.ErrorCodeNotFound, false)
+}
+
+func testCreateTask(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	before := len(server.Issues())
+
+	created, err := provider.CreateTask(testContext(t), &integration.ZenTaskData{
+		Title:       "Rotate signing keys",
+		Description: "Keys are rotated every 90 days",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	require.NotEmpty(t, created.ID, "the created task has the key of the new issue")
+	assert.Equal(t, "Rotate signing keys", created.Title)
+
+	require.Len(t, server.Issues(), before+1, "exactly one issue is created")
+	issue, ok := server.Issue(created.ID)
+	require.True(t, ok, "the returned ID is the key of the new issue")
+	assert.Equal(t, "Rotate signing keys", issue.Title)
+	assert.Equal(t, "Keys are rotated every 90 days", issue.Description)
+
+	fetched, err := provider.GetTaskData(testContext(t), created.ID)
+	require.NoError(t, err)
+	assertMatches(t, issue, fetched)
+}
+
+func testUpdateTask(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	issue := server.Issues()[0]
+
+	updated, err := provider.UpdateTask(testContext(t), issue.Key, &integration.ZenTaskData{
+		ID:    issue.Key,
+		Title: "Add login page with SSO",
+	})
+	require.NoError(t, err)
+
+	stored, _ := server.Issue(issue.Key)
+	assert.Equal(t, "Add login page with SSO", stored.Title)
+	assert.Equal(t, issue.Description, stored.Description, "fields left empty are not cleared")
+	require.NotNil(t, updated)
+	assert.Equal(t, issue.Key, updated.ID)
+	assert.Equal(t, "Add login page with SSO", updated.Title)
+}
+
+func testSearchTasks(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	tasks, err := provider.SearchTasks(testContext(t), map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, issueKeys(server.Issues()), taskIDs(tasks))
+
+	for _, task := range tasks {
+		issue, ok := server.Issue(task.ID)
+		require.True(t, ok)
+		assertMatches(t, issue, task)
+	}
+}
+
+func testSearchTasksFilter(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	status := server.Issues()[1].Status
+	var want []Issue
+	for _, issue := range server.Issues() {
+		if issue.Status == status {
+			want = append(want, issue)
+		}
+	}
+
+	tasks, err := provider.SearchTasks(testContext(t), map[string]interface{}{"status": status})
+	require.NoError(t, err)
+	assert.Equal(t, issueKeys(want), taskIDs(tasks))
+}
+
+func testSearchTasksUpdatedSince(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	issue := server.Issues()[0]
+	server.Touch(issue.Key, time.Now().Add(-10*time.Minute))
+
+	tasks, err := provider.SearchTasksUpdatedSince(testContext(t), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{issue.Key}, taskIDs(tasks), "only issues changed since are returned")
+}
+
+func testSearchTasksUpdatedSincePagination(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	server.MaxPageSize = 2
+	recent := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+	var seeded []Issue
+	for _, title := range []string{"One", "Two", "Three", "Four", "Five"} {
+		seeded = append(seeded, Issue{Title: title, Status: server.Issues()[0].Status, Created: recent, Updated: recent})
+	}
+	seeded = server.Seed(seeded...)
+
+	tasks, err := provider.SearchTasksUpdatedSince(testContext(t), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, issueKeys(seeded), taskIDs(tasks), "every page of results is read")
+}
+
+func testMappingRoundTrip(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	for _, issue := range server.Issues() {
+		external, err := provider.GetTaskData(testContext(t), issue.Key)
+		require.NoError(t, err)
+
+		zen, err := provider.MapToZen(external)
+		require.NoError(t, err)
+		assert.Equal(t, external.ID, zen.ID)
+		assert.Equal(t, external.Title, zen.Title)
+		assert.Equal(t, external.Description, zen.Description)
+		assert.NotEmpty(t, zen.Status, "every status maps to a Zen status")
+
+		back, err := provider.MapToExternal(zen)
+		require.NoError(t, err)
+		assert.Equal(t, external.ID, back.ID, issue.Key)
+		assert.Equal(t, external.Title, back.Title, issue.Key)
+		assert.Equal(t, external.Description, back.Description, issue.Key)
+		assert.Equal(t, external.Status, back.Status, "%s: statuses survive a round trip", issue.Key)
+		assert.Equal(t, external.Priority, back.Priority, "%s: priorities survive a round trip", issue.Key)
+	}
+}
+
+func testMappingNil(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	_, err := provider.MapToZen(nil)
+	assert.Error(t, err)
+	_, err = provider.MapToExternal(nil)
+	assert.Error(t, err)
+}
+
+func testFieldMapping(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	mapping := provider.GetFieldMapping()
+	for _, field := range []string{"title", "status"} {
+		assert.NotEmpty(t, mapping[field], "the %s field is mapped", field)
+	}
+}
+
+func testErrorUnauthorized(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	email, _ := server.Credentials()
+	server.SetCredentials(email, "revoked")
+	before := len(server.Requests())
+
+	_, err := provider.GetTaskData(testContext(t), server.Issues()[0].Key)
+	requireClientError(t, err, clients.ErrorCodeAuthenticationFailed, false)
+	assert.Len(t, server.Requests(), before+1, "authentication failures are not retried")
+}
+
+func testErrorTransientFailure(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	issue := server.Issues()[0]
+	server.FailNext(1, 503)
+
+	task, err := provider.GetTaskData(testContext(t), issue.Key)
+	require.NoError(t, err, "reads are retried after a transient failure")
+	assertMatches(t, issue, task)
+}
+
+func testErrorRateLimited(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	server.FailNext(100, 429)
+
+	_, err := provider.GetTaskData(testContext(t), server.Issues()[0].Key)
+	requireClientError(t, err, clients.ErrorCodeRateLimited, true)
+}
+
+func testHealthCheck(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	health, err := provider.HealthCheck(testContext(t))
+	require.NoError(t, err)
+	assert.True(t, health.Healthy)
+	assert.Equal(t, provider.Name(), health.Provider)
+}
+
+func testHealthCheckUnhealthy(t *testing.T, server *Server, provider integration.IntegrationProvider) {
+	email, _ := server.Credentials()
+	server.SetCredentials(email, "revoked")
+
+	health, err := provider.HealthCheck(testContext(t))
+	require.NoError(t, err, "an unhealthy provider is reported, not an error")
+	assert.False(t, health.Healthy)
+	assert.NotEmpty(t, health.LastError)
+}
@@ -3,15 +3,22 @@ package factory
 import (
 	"context"
 	"fmt"
+	"io"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/daddia/zen/internal/config"
 	"github.com/daddia/zen/internal/logging"
 	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/clients/httpx"
 	"github.com/daddia/zen/pkg/clients/jira"
+	"github.com/daddia/zen/pkg/clients/notion"
+	"github.com/daddia/zen/pkg/clients/servicenow"
+	"github.com/daddia/zen/pkg/integration/mapping"
 	"github.com/daddia/zen/pkg/integration/plugin"
 	pluginpkg "github.com/daddia/zen/pkg/plugin"
+	"github.com/daddia/zen/pkg/secretref"
 )
 
 // ClientFactory creates and manages integration plugin instances
@@ -105,7 +112,7 @@ func (f *ClientFactory) CreatePlugin(ctx context.Context, providerName string) (
 	f.mu.RUnlock()
 
 	// Load plugin configuration
-	pluginConfig, err := f.loadPluginConfiguration(providerName)
+	pluginConfig, err := f.loadPluginConfiguration(ctx, providerName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load plugin configuration: %w", err)
 	}
@@ -127,6 +134,10 @@ func (f *ClientFactory) CreatePlugin(ctx context.Context, providerName string) (
 		pluginInstance = f.createGitHubPlugin(pluginConfig)
 	case "linear":
 		pluginInstance = f.createLinearPlugin(pluginConfig)
+	case "notion":
+		pluginInstance = f.createNotionPlugin(pluginConfig)
+	case "servicenow":
+		pluginInstance = f.createServiceNowPlugin(pluginConfig)
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", providerName)
 	}
@@ -211,6 +222,10 @@ func (f *ClientFactory) ValidatePlugin(ctx context.Context, providerName string,
 		pluginInstance = f.createGitHubPlugin(config)
 	case "linear":
 		pluginInstance = f.createLinearPlugin(config)
+	case "notion":
+		pluginInstance = f.createNotionPlugin(config)
+	case "servicenow":
+		pluginInstance = f.createServiceNowPlugin(config)
 	default:
 		return fmt.Errorf("unsupported provider: %s", providerName)
 	}
@@ -267,13 +282,23 @@ func (f *ClientFactory) ShutdownAll(ctx context.Context) error {
 
 // Helper methods
 
-// loadPluginConfiguration loads configuration for a specific provider
-func (f *ClientFactory) loadPluginConfiguration(providerName string) (*plugin.PluginConfig, error) {
+// loadPluginConfiguration loads configuration for a specific provider, resolving the
+// secret references of its credentials
+func (f *ClientFactory) loadPluginConfiguration(ctx context.Context, providerName string) (*plugin.PluginConfig, error) {
 	// Get provider configuration from main config
 	providerConfig, exists := f.config.Integrations.Providers[providerName]
 	if !exists {
 		return nil, fmt.Errorf("provider configuration not found: %s", providerName)
 	}
+	if secretref.IsRef(providerConfig.Email) || secretref.IsRef(providerConfig.APIKey) {
+		secrets, err := secretref.ForConfig(f.config)
+		if err != nil {
+			return nil, err
+		}
+		if providerConfig, err = secrets.ResolveProvider(ctx, providerConfig); err != nil {
+			return nil, err
+		}
+	}
 
 	// Convert to plugin configuration
 	pluginConfig := &plugin.PluginConfig{
@@ -281,8 +306,8 @@ func (f *ClientFactory) loadPluginConfiguration(providerName string) (*plugin.Pl
 		Version:    "1.0.0", // Default version
 		Enabled:    true,
 		BaseURL:    providerConfig.URL,
-		Timeout:    30 * time.Second, // Default timeout
-		MaxRetries: 3,                // Default retries
+		Timeout:    httpx.TimeoutFor(providerName, httpx.CommandTimeout(ctx)),
+		MaxRetries: 3, // Default retries
 		Auth: &plugin.AuthConfig{
 			Type:           plugin.AuthType(providerConfig.Type),
 			CredentialsRef: providerName, // Use provider name as credentials reference
@@ -295,9 +320,18 @@ func (f *ClientFactory) loadPluginConfiguration(providerName string) (*plugin.Pl
 	}
 
 	// Apply provider-specific settings
+	for key, value := range providerConfig.Settings {
+		pluginConfig.Settings[key] = value
+	}
+	if pluginConfig.BaseURL == "" {
+		pluginConfig.BaseURL = defaultBaseURL(providerName, pluginConfig.Settings)
+	}
 	if providerConfig.ProjectKey != "" {
 		pluginConfig.Settings["project_key"] = providerConfig.ProjectKey
 	}
+	if len(providerConfig.FieldMapping) > 0 {
+		pluginConfig.FieldMapping = fieldMappingConfig(providerConfig.FieldMapping)
+	}
 
 	return pluginConfig, nil
 }
@@ -361,6 +395,40 @@ func (f *ClientFactory) createLinearPlugin(config *plugin.PluginConfig) plugin.I
 	}
 }
 
+// createNotionPlugin creates a Notion plugin instance
+func (f *ClientFactory) createNotionPlugin(config *plugin.PluginConfig) plugin.IntegrationPluginInterface {
+	return &NotionPluginAdapter{
+		config:  config,
+		logger:  f.logger,
+		authMgr: f.authMgr,
+		mapper:  mapping.NewDataMapper(f.logger),
+	}
+}
+
+// createServiceNowPlugin creates a ServiceNow plugin instance
+func (f *ClientFactory) createServiceNowPlugin(config *plugin.PluginConfig) plugin.IntegrationPluginInterface {
+	return &ServiceNowPluginAdapter{
+		config:  config,
+		logger:  f.logger,
+		authMgr: f.authMgr,
+		mapper:  mapping.NewDataMapper(f.logger),
+	}
+}
+
+// defaultBaseURL returns the base URL of a provider that has no url configured: the
+// public API of hosted services, or for ServiceNow the instance named by settings.instance
+func defaultBaseURL(providerName string, settings map[string]interface{}) string {
+	switch providerName {
+	case "notion":
+		return notion.DefaultBaseURL
+	case "servicenow":
+		instance, _ := settings["instance"].(string)
+		return servicenow.InstanceURL(instance)
+	default:
+		return ""
+	}
+}
+
 // Plugin adapters - these would be replaced by actual WASM plugins
 
 // JiraPluginAdapter adapts the existing Jira client to the plugin interface
@@ -440,6 +508,157 @@ func (j *JiraPluginAdapter) FetchTask(ctx context.Context, externalID string, op
 	return j.convertJiraTaskDataToPluginTaskData(jiraTaskData), nil
 }
 
+// FetchTasks implements plugin.BatchFetcher with Jira's issue search
+func (j *JiraPluginAdapter) FetchTasks(ctx context.Context, externalIDs []string, opts *plugin.FetchOptions) (map[string]*plugin.TaskData, error) {
+	j.logger.Debug("fetching tasks from Jira adapter", "count", len(externalIDs))
+
+	if j.jiraPlugin == nil {
+		return nil, fmt.Errorf("jira plugin not initialized")
+	}
+
+	jiraTasks, err := j.jiraPlugin.FetchTasks(ctx, externalIDs, &jira.FetchOptions{
+		IncludeRaw: opts.IncludeRaw,
+		Timeout:    opts.Timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from Jira: %w", err)
+	}
+
+	tasks := make(map[string]*plugin.TaskData, len(jiraTasks))
+	for id, jiraTaskData := range jiraTasks {
+		tasks[id] = j.convertJiraTaskDataToPluginTaskData(jiraTaskData)
+	}
+	return tasks, nil
+}
+
+// FetchSprint implements plugin.SprintFetcher with Jira's Agile API
+func (j *JiraPluginAdapter) FetchSprint(ctx context.Context, name string) (*plugin.Sprint, error) {
+	j.logger.Debug("fetching sprint from Jira adapter", "sprint", name)
+
+	if j.jiraPlugin == nil {
+		return nil, fmt.Errorf("jira plugin not initialized")
+	}
+
+	jiraSprint, err := j.jiraPlugin.FetchSprint(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sprint from Jira: %w", err)
+	}
+
+	sprint := &plugin.Sprint{
+		ID:        jiraSprint.ID,
+		Name:      jiraSprint.Name,
+		State:     jiraSprint.State,
+		Goal:      jiraSprint.Goal,
+		Start:     jiraSprint.Start,
+		End:       jiraSprint.End,
+		Completed: jiraSprint.Completed,
+		Items:     make([]plugin.SprintItem, 0, len(jiraSprint.Issues)),
+	}
+	for _, issue := range jiraSprint.Issues {
+		sprint.Items = append(sprint.Items, plugin.SprintItem{
+			ExternalID: issue.Key,
+			Title:      issue.Summary,
+			Points:     issue.Points,
+			Added:      issue.Added,
+			Completed:  issue.Completed,
+		})
+	}
+	return sprint, nil
+}
+
+// ExportTasks implements plugin.Exporter with a JQL search of issues and their changelog
+func (j *JiraPluginAdapter) ExportTasks(ctx context.Context, query string, limit int) (*plugin.Export, error) {
+	j.logger.Debug("exporting tasks from Jira adapter", "jql", query)
+
+	if j.jiraPlugin == nil {
+		return nil, fmt.Errorf("jira plugin not initialized")
+	}
+
+	jiraExport, err := j.jiraPlugin.ExportIssues(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export from Jira: %w", err)
+	}
+
+	export := &plugin.Export{
+		Items:      make([]*plugin.ExportItem, 0, len(jiraExport.Issues)),
+		FieldNames: jiraExport.FieldNames,
+	}
+	for _, issue := range jiraExport.Issues {
+		item := &plugin.ExportItem{
+			Task:           j.convertJiraTaskDataToPluginTaskData(issue.Task),
+			Project:        issue.Project,
+			Status:         issue.Status,
+			StatusCategory: issue.StatusCategory,
+			Type:           issue.IssueType,
+			Reporter:       issue.Reporter,
+			Comments:       issue.Comments,
+			Fields:         issue.Fields,
+		}
+		for _, change := range issue.History {
+			item.History = append(item.History, plugin.Change{
+				At:     change.At,
+				Author: change.Author,
+				Field:  change.Field,
+				From:   change.From,
+				To:     change.To,
+			})
+		}
+		export.Items = append(export.Items, item)
+	}
+	return export, nil
+}
+
+// ListAttachments implements plugin.AttachmentTransferer with Jira's issue attachments
+func (j *JiraPluginAdapter) ListAttachments(ctx context.Context, externalID string) ([]*plugin.Attachment, error) {
+	if j.jiraPlugin == nil {
+		return nil, fmt.Errorf("jira plugin not initialized")
+	}
+
+	jiraAttachments, err := j.jiraPlugin.ListAttachments(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments in Jira: %w", err)
+	}
+
+	attachments := make([]*plugin.Attachment, 0, len(jiraAttachments))
+	for _, a := range jiraAttachments {
+		attachments = append(attachments, convertJiraAttachment(a))
+	}
+	return attachments, nil
+}
+
+// UploadAttachment implements plugin.AttachmentTransferer
+func (j *JiraPluginAdapter) UploadAttachment(ctx context.Context, externalID, name string, content io.Reader) (*plugin.Attachment, error) {
+	if j.jiraPlugin == nil {
+		return nil, fmt.Errorf("jira plugin not initialized")
+	}
+
+	uploaded, err := j.jiraPlugin.UploadAttachment(ctx, externalID, name, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload attachment to Jira: %w", err)
+	}
+	return convertJiraAttachment(uploaded), nil
+}
+
+// DownloadAttachment implements plugin.AttachmentTransferer
+func (j *JiraPluginAdapter) DownloadAttachment(ctx context.Context, attachment *plugin.Attachment, w io.Writer) error {
+	if j.jiraPlugin == nil {
+		return fmt.Errorf("jira plugin not initialized")
+	}
+
+	return j.jiraPlugin.DownloadAttachment(ctx, &jira.Attachment{ID: attachment.ID, Filename: attachment.Name, Content: attachment.URL}, w)
+}
+
+func convertJiraAttachment(a *jira.Attachment) *plugin.Attachment {
+	return &plugin.Attachment{
+		ID:       a.ID,
+		Name:     a.Filename,
+		URL:      a.Content,
+		MimeType: a.MimeType,
+		Size:     a.Size,
+		Created:  a.Created,
+	}
+}
+
 // convertJiraTaskDataToPluginTaskData converts Jira plugin data to standard plugin data
 func (j *JiraPluginAdapter) convertJiraTaskDataToPluginTaskData(jiraData *jira.PluginTaskData) *plugin.TaskData {
 	return &plugin.TaskData{
@@ -567,6 +786,9 @@ func (g *GitHubPluginAdapter) MapToExternal(ctx context.Context, zenData *plugin
 	return nil, fmt.Errorf("not implemented")
 }
 func (g *GitHubPluginAdapter) GetFieldMapping() *plugin.FieldMappingConfig {
+	if g.config.FieldMapping != nil {
+		return g.config.FieldMapping
+	}
 	return &plugin.FieldMappingConfig{}
 }
 func (g *GitHubPluginAdapter) GetAuthConfig() *plugin.AuthConfig { return g.config.Auth }
@@ -638,3 +860,65 @@ func getProjectKeyFromSettings(settings map[string]interface{}) string {
 	}
 	return ""
 }
+
+// fieldMappingConfig converts the field_mapping of a provider, which maps Zen fields to
+// the fields of the provider, to the plugin's field mapping
+func fieldMappingConfig(fieldMapping map[string]string) *plugin.FieldMappingConfig {
+	zenFields := make([]string, 0, len(fieldMapping))
+	for zenField := range fieldMapping {
+		zenFields = append(zenFields, zenField)
+	}
+	sort.Strings(zenFields)
+
+	mapping := &plugin.FieldMappingConfig{}
+	for _, zenField := range zenFields {
+		mapping.Mappings = append(mapping.Mappings, plugin.FieldMapping{
+			ZenField:      zenField,
+			ExternalField: fieldMapping[zenField],
+			Direction:     plugin.SyncDirectionBidirectional,
+		})
+	}
+	return mapping
+}
+
+// mergeFieldMapping returns the default field mapping of a provider with the external
+// fields of the Zen fields that configured maps replaced, and the fields it adds appended.
+// Transforms are left to the caller.
+func mergeFieldMapping(defaults, configured *plugin.FieldMappingConfig) *plugin.FieldMappingConfig {
+	overrides := map[string]string{}
+	if configured != nil {
+		for _, m := range configured.Mappings {
+			overrides[m.ZenField] = m.ExternalField
+		}
+	}
+
+	result := &plugin.FieldMappingConfig{}
+	for _, m := range defaults.Mappings {
+		if field, ok := overrides[m.ZenField]; ok {
+			m.ExternalField = field
+			delete(overrides, m.ZenField)
+		}
+		result.Mappings = append(result.Mappings, m)
+	}
+	if configured != nil {
+		for _, m := range configured.Mappings {
+			if _, ok := overrides[m.ZenField]; ok {
+				result.Mappings = append(result.Mappings, m)
+			}
+		}
+	}
+	return result
+}
+
+// externalField returns the field of the provider a Zen field maps to, or fallback when
+// the mapping does not name one
+func externalField(mapping *plugin.FieldMappingConfig, zenField, fallback string) string {
+	if mapping != nil {
+		for _, m := range mapping.Mappings {
+			if m.ZenField == zenField && m.ExternalField != "" {
+				return m.ExternalField
+			}
+		}
+	}
+	return fallback
+}
@@ -2,6 +2,10 @@ package factory
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/daddia/zen/internal/config"
@@ -344,3 +348,43 @@ func TestJiraPluginAdapter_Interface(t *testing.T) {
 	fieldMapping := adapter.GetFieldMapping()
 	assert.NotNil(t, fieldMapping)
 }
+
+func TestGitHubPluginAdapter_ListAttachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer ghp_test", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/repos/owner/repo/issues/12":
+			fmt.Fprint(w, `{"body": "Mockup: ![mockup](https://github.com/user-attachments/assets/0b1c-22) and https://example.com/spec",
+				"created_at": "2026-10-01T09:00:00Z"}`)
+		case "/repos/owner/repo/issues/12/comments":
+			fmt.Fprint(w, `[{"body": "Logs: [build.log](https://github.com/user-attachments/files/123/build.log)", "created_at": "2026-10-02T09:00:00Z"},
+				{"body": "Same mockup https://github.com/user-attachments/assets/0b1c-22", "created_at": "2026-10-03T09:00:00Z"}]`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	authMgr := &mockAuthManager{}
+	authMgr.On("GetCredentials", "github").Return("ghp_test", nil)
+	adapter := &GitHubPluginAdapter{
+		config:  &plugin.PluginConfig{BaseURL: server.URL, Settings: map[string]interface{}{"repository": "owner/repo"}},
+		logger:  logging.NewBasic(),
+		authMgr: authMgr,
+	}
+
+	var _ plugin.AttachmentTransferer = adapter
+
+	attachments, err := adapter.ListAttachments(context.Background(), "12")
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "0b1c-22", attachments[0].Name)
+	assert.Equal(t, "build.log", attachments[1].Name)
+	assert.Equal(t, "https://github.com/user-attachments/files/123/build.log", attachments[1].URL)
+
+	_, err = adapter.ListAttachments(context.Background(), "other#12")
+	assert.Error(t, err)
+
+	_, err = adapter.UploadAttachment(context.Background(), "12", "notes.txt", strings.NewReader("notes"))
+	assert.Error(t, err, "GitHub has no upload API")
+}
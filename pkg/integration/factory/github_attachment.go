@@ -0,0 +1,169 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/plugin"
+)
+
+// githubAttachmentLinks matches the files uploaded to GitHub issues, which GitHub keeps
+// as links in the issue body and comments rather than as attachments
+var githubAttachmentLinks = regexp.MustCompile(`https://(?:github\.com/(?:user-attachments/(?:assets|files)|[\w.-]+/[\w.-]+/(?:assets|files))|(?:private-)?user-images\.githubusercontent\.com)/[^\s)"'<>\]]+`)
+
+// ListAttachments implements plugin.AttachmentTransferer with the files linked from the
+// body and comments of a GitHub issue. externalID is an issue number of the repository
+// in settings.repository, or a reference such as "owner/name#123".
+func (g *GitHubPluginAdapter) ListAttachments(ctx context.Context, externalID string) ([]*plugin.Attachment, error) {
+	repo, number, err := g.issueReference(externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue struct {
+		Body      string    `json:"body"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	if err := g.getJSON(ctx, fmt.Sprintf("repos/%s/issues/%s", repo, number), &issue); err != nil {
+		return nil, err
+	}
+	var comments []struct {
+		Body      string    `json:"body"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	if err := g.getJSON(ctx, fmt.Sprintf("repos/%s/issues/%s/comments?per_page=100", repo, number), &comments); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var attachments []*plugin.Attachment
+	add := func(body string, created time.Time) {
+		for _, link := range githubAttachmentLinks.FindAllString(body, -1) {
+			if seen[link] {
+				continue
+			}
+			seen[link] = true
+			attachments = append(attachments, &plugin.Attachment{ID: link, Name: attachmentName(link), URL: link, Created: created})
+		}
+	}
+	add(issue.Body, issue.CreatedAt)
+	for _, comment := range comments {
+		add(comment.Body, comment.CreatedAt)
+	}
+	return attachments, nil
+}
+
+// UploadAttachment implements plugin.AttachmentTransferer. GitHub's API has no endpoint
+// to upload files to issues, so it always fails.
+func (g *GitHubPluginAdapter) UploadAttachment(ctx context.Context, externalID, name string, content io.Reader) (*plugin.Attachment, error) {
+	return nil, fmt.Errorf("GitHub does not accept attachment uploads through its API; attach %s to the issue in the browser", name)
+}
+
+// DownloadAttachment implements plugin.AttachmentTransferer
+func (g *GitHubPluginAdapter) DownloadAttachment(ctx context.Context, attachment *plugin.Attachment, w io.Writer) error {
+	if !githubAttachmentLinks.MatchString(attachment.URL) {
+		return fmt.Errorf("not a GitHub attachment: %s", attachment.URL)
+	}
+
+	resp, err := g.get(ctx, attachment.URL, "application/octet-stream")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to download attachment %s: %w", attachment.Name, err)
+	}
+	return nil
+}
+
+// issueReference returns the repository and number of a GitHub issue
+func (g *GitHubPluginAdapter) issueReference(externalID string) (string, string, error) {
+	repo, number, ok := strings.Cut(externalID, "#")
+	if !ok {
+		number = externalID
+		repo, _ = g.config.Settings["repository"].(string)
+		if repo == "" {
+			repo = getProjectKeyFromSettings(g.config.Settings)
+		}
+	}
+	if strings.Count(repo, "/") != 1 || number == "" {
+		return "", "", fmt.Errorf("cannot find the GitHub issue of %s; use owner/name#number or set settings.repository on the github provider", externalID)
+	}
+	return repo, number, nil
+}
+
+// getJSON sends a GET request to the GitHub API and decodes the response into out
+func (g *GitHubPluginAdapter) getJSON(ctx context.Context, apiPath string, out interface{}) error {
+	resp, err := g.get(ctx, g.apiURL()+"/"+apiPath, "application/vnd.github+json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// apiURL returns the GitHub API in base_url, or api.github.com when it is not set
+func (g *GitHubPluginAdapter) apiURL() string {
+	baseURL := strings.TrimRight(g.config.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return baseURL
+}
+
+// get sends an authenticated GET request, returning an error for unsuccessful responses
+func (g *GitHubPluginAdapter) get(ctx context.Context, target, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	return g.do(req)
+}
+
+// do sends an authenticated request, returning an error for unsuccessful responses
+func (g *GitHubPluginAdapter) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if g.authMgr != nil {
+		if token, err := g.authMgr.GetCredentials("github"); err == nil && token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	timeout := g.config.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	// Redirects to other hosts, such as the storage of private attachments, drop the
+	// Authorization header
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GitHub API error: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// attachmentName returns the file name of an attachment link
+func attachmentName(link string) string {
+	if u, err := url.Parse(link); err == nil {
+		return path.Base(u.Path)
+	}
+	return path.Base(link)
+}
@@ -0,0 +1,417 @@
+package factory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/plugin"
+)
+
+// Projects fields the status and iteration of a task map to, unless the field_mapping of
+// the github provider names others
+const (
+	defaultGitHubStatusField    = "Status"
+	defaultGitHubIterationField = "Iteration"
+)
+
+// githubProjectItemsQuery reads the Projects items of an issue with their single-select
+// and iteration field values
+const githubProjectItemsQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    issue(number: $number) {
+      id
+      projectItems(first: 20) {
+        nodes {
+          id
+          project { number owner { ... on Organization { login } ... on User { login } } }
+          fieldValues(first: 50) {
+            nodes {
+              ... on ProjectV2ItemFieldSingleSelectValue { name field { ... on ProjectV2FieldCommon { name } } }
+              ... on ProjectV2ItemFieldIterationValue { title startDate duration field { ... on ProjectV2FieldCommon { name } } }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// githubProjectQuery reads a Projects board with the options of its single-select fields
+// and the iterations of its iteration fields
+const githubProjectQuery = `query($owner: String!, $number: Int!) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        id
+        fields(first: 50) {
+          nodes {
+            ... on ProjectV2FieldCommon { id name }
+            ... on ProjectV2SingleSelectField { options { id name } }
+            ... on ProjectV2IterationField {
+              configuration {
+                iterations { id title startDate duration }
+                completedIterations { id title startDate duration }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const githubAddProjectItemMutation = `mutation($project: ID!, $content: ID!) {
+  addProjectV2ItemById(input: {projectId: $project, contentId: $content}) { item { id } }
+}`
+
+const githubUpdateProjectFieldMutation = `mutation($project: ID!, $item: ID!, $field: ID!, $value: ProjectV2FieldValue!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: $value}) { projectV2Item { id } }
+}`
+
+// githubProject is a Projects board, named by the login of its owner and its number
+type githubProject struct {
+	Owner  string
+	Number int
+}
+
+func (p githubProject) String() string {
+	return fmt.Sprintf("%s/%d", p.Owner, p.Number)
+}
+
+type githubProjectItem struct {
+	ID      string `json:"id"`
+	Project struct {
+		Number int `json:"number"`
+		Owner  struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"project"`
+	FieldValues struct {
+		Nodes []githubProjectFieldValue `json:"nodes"`
+	} `json:"fieldValues"`
+}
+
+// githubProjectFieldValue is the value of a single-select field, with the option name,
+// or of an iteration field, with the iteration title and dates
+type githubProjectFieldValue struct {
+	Field struct {
+		Name string `json:"name"`
+	} `json:"field"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"`
+	Duration  int    `json:"duration"`
+}
+
+type githubProjectBoard struct {
+	ID     string `json:"id"`
+	Fields struct {
+		Nodes []githubProjectField `json:"nodes"`
+	} `json:"fields"`
+}
+
+type githubProjectField struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Options []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"options"`
+	Configuration *struct {
+		Iterations          []githubIteration `json:"iterations"`
+		CompletedIterations []githubIteration `json:"completedIterations"`
+	} `json:"configuration"`
+}
+
+type githubIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"`
+	Duration  int    `json:"duration"`
+}
+
+// FetchProjectFields implements plugin.ProjectFieldSyncer with the item an issue has on
+// the Projects board in settings.project. Without a board, tasks are on none.
+func (g *GitHubPluginAdapter) FetchProjectFields(ctx context.Context, externalID string) (*plugin.ProjectFields, error) {
+	project, err := g.projectReference()
+	if err != nil || project.Number == 0 {
+		return nil, err
+	}
+	_, item, err := g.projectItem(ctx, externalID, project)
+	if err != nil || item == nil {
+		return nil, err
+	}
+	return g.projectFields(project, item), nil
+}
+
+// UpdateProjectFields implements plugin.ProjectFieldSyncer, setting the status and
+// iteration fields of the item an issue has on the board in settings.project
+func (g *GitHubPluginAdapter) UpdateProjectFields(ctx context.Context, externalID string, fields *plugin.ProjectFields) (*plugin.ProjectFields, error) {
+	project, err := g.projectReference()
+	if err != nil || project.Number == 0 {
+		return nil, err
+	}
+	board, err := g.projectBoard(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the options before changing the item, so that unknown values change nothing
+	type fieldValue struct {
+		fieldID string
+		value   map[string]string
+	}
+	var values []fieldValue
+	if fields.Status != "" {
+		field, err := board.field(project, externalField(g.config.FieldMapping, "stage", defaultGitHubStatusField))
+		if err != nil {
+			return nil, err
+		}
+		optionID, err := field.option(project, fields.Status)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, fieldValue{field.ID, map[string]string{"singleSelectOptionId": optionID}})
+	}
+	if fields.Iteration != "" {
+		field, err := board.field(project, externalField(g.config.FieldMapping, "iteration", defaultGitHubIterationField))
+		if err != nil {
+			return nil, err
+		}
+		iterationID, err := field.iteration(project, fields.Iteration)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, fieldValue{field.ID, map[string]string{"iterationId": iterationID}})
+	}
+
+	issueID, item, err := g.projectItem(ctx, externalID, project)
+	if err != nil {
+		return nil, err
+	}
+	itemID := ""
+	if item != nil {
+		itemID = item.ID
+	} else {
+		var added struct {
+			AddProjectV2ItemByID struct {
+				Item struct {
+					ID string `json:"id"`
+				} `json:"item"`
+			} `json:"addProjectV2ItemById"`
+		}
+		if err := g.graphql(ctx, githubAddProjectItemMutation, map[string]interface{}{
+			"project": board.ID,
+			"content": issueID,
+		}, &added); err != nil {
+			return nil, fmt.Errorf("failed to add %s to project %s: %w", externalID, project, err)
+		}
+		itemID = added.AddProjectV2ItemByID.Item.ID
+	}
+
+	for _, v := range values {
+		if err := g.graphql(ctx, githubUpdateProjectFieldMutation, map[string]interface{}{
+			"project": board.ID,
+			"item":    itemID,
+			"field":   v.fieldID,
+			"value":   v.value,
+		}, nil); err != nil {
+			return nil, fmt.Errorf("failed to update %s on project %s: %w", externalID, project, err)
+		}
+	}
+
+	return g.FetchProjectFields(ctx, externalID)
+}
+
+// projectReference returns the board in settings.project, written "owner/number", or a
+// zero project when the setting is empty
+func (g *GitHubPluginAdapter) projectReference() (githubProject, error) {
+	reference, _ := g.config.Settings["project"].(string)
+	if reference == "" {
+		return githubProject{}, nil
+	}
+	owner, number, ok := strings.Cut(reference, "/")
+	n, err := strconv.Atoi(number)
+	if !ok || owner == "" || err != nil || n <= 0 {
+		return githubProject{}, fmt.Errorf("set settings.project on the github provider to the owner/number of the Projects board, such as my-org/7 (got %q)", reference)
+	}
+	return githubProject{Owner: owner, Number: n}, nil
+}
+
+// projectItem returns the node ID of an issue and its item on project, which is nil when
+// the issue is not on the board
+func (g *GitHubPluginAdapter) projectItem(ctx context.Context, externalID string, project githubProject) (string, *githubProjectItem, error) {
+	repo, number, err := g.issueReference(externalID)
+	if err != nil {
+		return "", nil, err
+	}
+	issueNumber, err := strconv.Atoi(number)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid GitHub issue number: %s", number)
+	}
+	owner, name, _ := strings.Cut(repo, "/")
+
+	var result struct {
+		Repository *struct {
+			Issue *struct {
+				ID           string `json:"id"`
+				ProjectItems struct {
+					Nodes []githubProjectItem `json:"nodes"`
+				} `json:"projectItems"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+	if err := g.graphql(ctx, githubProjectItemsQuery, map[string]interface{}{
+		"owner":  owner,
+		"repo":   name,
+		"number": issueNumber,
+	}, &result); err != nil {
+		return "", nil, err
+	}
+	if result.Repository == nil || result.Repository.Issue == nil {
+		return "", nil, fmt.Errorf("GitHub issue %s#%s not found", repo, number)
+	}
+
+	issue := result.Repository.Issue
+	for i, item := range issue.ProjectItems.Nodes {
+		if item.Project.Number == project.Number && strings.EqualFold(item.Project.Owner.Login, project.Owner) {
+			return issue.ID, &issue.ProjectItems.Nodes[i], nil
+		}
+	}
+	return issue.ID, nil, nil
+}
+
+// projectFields returns the status and iteration of a project item
+func (g *GitHubPluginAdapter) projectFields(project githubProject, item *githubProjectItem) *plugin.ProjectFields {
+	statusField := externalField(g.config.FieldMapping, "stage", defaultGitHubStatusField)
+	iterationField := externalField(g.config.FieldMapping, "iteration", defaultGitHubIterationField)
+
+	fields := &plugin.ProjectFields{Project: project.String()}
+	for _, value := range item.FieldValues.Nodes {
+		switch {
+		case strings.EqualFold(value.Field.Name, statusField):
+			fields.Status = value.Name
+		case strings.EqualFold(value.Field.Name, iterationField):
+			fields.Iteration = value.Title
+			if start, err := time.Parse("2006-01-02", value.StartDate); err == nil {
+				fields.IterationStart = start
+				fields.IterationEnd = start.AddDate(0, 0, value.Duration)
+			}
+		}
+	}
+	return fields
+}
+
+// projectBoard returns a board with its fields
+func (g *GitHubPluginAdapter) projectBoard(ctx context.Context, project githubProject) (*githubProjectBoard, error) {
+	var result struct {
+		RepositoryOwner *struct {
+			ProjectV2 *githubProjectBoard `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+	if err := g.graphql(ctx, githubProjectQuery, map[string]interface{}{
+		"owner":  project.Owner,
+		"number": project.Number,
+	}, &result); err != nil {
+		return nil, err
+	}
+	if result.RepositoryOwner == nil || result.RepositoryOwner.ProjectV2 == nil {
+		return nil, fmt.Errorf("GitHub project %s not found", project)
+	}
+	return result.RepositoryOwner.ProjectV2, nil
+}
+
+// field returns the field of a board with the given name
+func (b *githubProjectBoard) field(project githubProject, name string) (*githubProjectField, error) {
+	for i, field := range b.Fields.Nodes {
+		if strings.EqualFold(field.Name, name) {
+			return &b.Fields.Nodes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("project %s has no %s field; name the field in field_mapping on the github provider", project, name)
+}
+
+// option returns the ID of the option of a single-select field with the given name
+func (f *githubProjectField) option(project githubProject, name string) (string, error) {
+	names := make([]string, 0, len(f.Options))
+	for _, option := range f.Options {
+		if strings.EqualFold(option.Name, name) {
+			return option.ID, nil
+		}
+		names = append(names, option.Name)
+	}
+	return "", fmt.Errorf("the %s field of project %s has no option %q (options: %s)", f.Name, project, name, strings.Join(names, ", "))
+}
+
+// iteration returns the ID of the iteration of an iteration field with the given title
+func (f *githubProjectField) iteration(project githubProject, title string) (string, error) {
+	if f.Configuration == nil {
+		return "", fmt.Errorf("the %s field of project %s is not an iteration field", f.Name, project)
+	}
+	iterations := append(append([]githubIteration{}, f.Configuration.Iterations...), f.Configuration.CompletedIterations...)
+	for _, iteration := range iterations {
+		if strings.EqualFold(iteration.Title, title) {
+			return iteration.ID, nil
+		}
+	}
+	return "", fmt.Errorf("the %s field of project %s has no iteration %q", f.Name, project, title)
+}
+
+// graphql sends a query to the GitHub GraphQL API and decodes its data into out
+func (g *GitHubPluginAdapter) graphql(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode query: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", g.graphqlURL(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		messages := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("GitHub GraphQL error: %s", strings.Join(messages, "; "))
+	}
+	if out == nil || len(result.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// graphqlURL returns the GraphQL endpoint of the API in base_url, which is /api/graphql
+// rather than /api/v3/graphql on GitHub Enterprise Server
+func (g *GitHubPluginAdapter) graphqlURL() string {
+	baseURL := g.apiURL()
+	if strings.HasSuffix(baseURL, "/api/v3") {
+		return strings.TrimSuffix(baseURL, "/v3") + "/graphql"
+	}
+	return baseURL + "/graphql"
+}
@@ -0,0 +1,188 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// githubProjectServer answers the GraphQL requests of the Projects board my-org/7, on
+// which issue 12 is in the "Todo" column of the "Sprint 4" iteration when it is on the
+// board. The issue is also on board my-org/2.
+func githubProjectServer(t *testing.T, onBoard bool, mutations *[]map[string]interface{}) *httptest.Server {
+	status := "Todo"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		assert.Equal(t, "Bearer ghp_test", r.Header.Get("Authorization"))
+
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		switch {
+		case strings.Contains(req.Query, "projectItems"):
+			assert.Equal(t, "owner", req.Variables["owner"])
+			assert.Equal(t, "repo", req.Variables["repo"])
+			if req.Variables["number"] != float64(12) {
+				fmt.Fprint(w, `{"data": {"repository": {"issue": null}}, "errors": [{"message": "Could not resolve to an Issue"}]}`)
+				return
+			}
+			items := `{"id": "PVTI_other", "project": {"number": 2, "owner": {"login": "my-org"}}, "fieldValues": {"nodes": []}}`
+			if onBoard {
+				items += fmt.Sprintf(`, {"id": "PVTI_12", "project": {"number": 7, "owner": {"login": "My-Org"}}, "fieldValues": {"nodes": [
+					{},
+					{"name": %q, "field": {"name": "Status"}},
+					{"title": "Sprint 4", "startDate": "2026-10-05", "duration": 14, "field": {"name": "Iteration"}}]}}`, status)
+			}
+			fmt.Fprintf(w, `{"data": {"repository": {"issue": {"id": "I_12", "projectItems": {"nodes": [%s]}}}}}`, items)
+		case strings.Contains(req.Query, "projectV2(number"):
+			assert.Equal(t, "my-org", req.Variables["owner"])
+			fmt.Fprint(w, `{"data": {"repositoryOwner": {"projectV2": {"id": "PVT_7", "fields": {"nodes": [
+				{"id": "F_title", "name": "Title"},
+				{"id": "F_status", "name": "Status", "options": [{"id": "O_todo", "name": "Todo"}, {"id": "O_progress", "name": "In Progress"}, {"id": "O_done", "name": "Done"}]},
+				{"id": "F_iteration", "name": "Iteration", "configuration": {
+					"iterations": [{"id": "IT_5", "title": "Sprint 5", "startDate": "2026-10-19", "duration": 14}],
+					"completedIterations": [{"id": "IT_4", "title": "Sprint 4", "startDate": "2026-10-05", "duration": 14}]}}]}}}}}`)
+		case strings.Contains(req.Query, "addProjectV2ItemById"):
+			*mutations = append(*mutations, req.Variables)
+			onBoard = true
+			fmt.Fprint(w, `{"data": {"addProjectV2ItemById": {"item": {"id": "PVTI_12"}}}}`)
+		case strings.Contains(req.Query, "updateProjectV2ItemFieldValue"):
+			*mutations = append(*mutations, req.Variables)
+			if value := req.Variables["value"].(map[string]interface{}); value["singleSelectOptionId"] == "O_progress" {
+				status = "In Progress"
+			}
+			fmt.Fprint(w, `{"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "PVTI_12"}}}}`)
+		default:
+			t.Errorf("unexpected query: %s", req.Query)
+		}
+	}))
+}
+
+func newGitHubProjectAdapter(t *testing.T, baseURL string, settings map[string]interface{}) *GitHubPluginAdapter {
+	authMgr := &mockAuthManager{}
+	authMgr.On("GetCredentials", "github").Return("ghp_test", nil)
+	return &GitHubPluginAdapter{
+		config:  &plugin.PluginConfig{BaseURL: baseURL, Settings: settings},
+		logger:  logging.NewBasic(),
+		authMgr: authMgr,
+	}
+}
+
+func TestGitHubPluginAdapter_FetchProjectFields(t *testing.T) {
+	var mutations []map[string]interface{}
+	server := githubProjectServer(t, true, &mutations)
+	defer server.Close()
+
+	adapter := newGitHubProjectAdapter(t, server.URL, map[string]interface{}{"repository": "owner/repo", "project": "my-org/7"})
+	var _ plugin.ProjectFieldSyncer = adapter
+
+	fields, err := adapter.FetchProjectFields(context.Background(), "12")
+	require.NoError(t, err)
+	require.NotNil(t, fields)
+	assert.Equal(t, "my-org/7", fields.Project)
+	assert.Equal(t, "Todo", fields.Status)
+	assert.Equal(t, "Sprint 4", fields.Iteration)
+	assert.Equal(t, time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), fields.IterationStart)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), fields.IterationEnd)
+
+	_, err = adapter.FetchProjectFields(context.Background(), "13")
+	assert.ErrorContains(t, err, "Could not resolve to an Issue")
+
+	// Without a board, tasks are on none
+	adapter.config.Settings = map[string]interface{}{"repository": "owner/repo"}
+	fields, err = adapter.FetchProjectFields(context.Background(), "12")
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+
+	adapter.config.Settings["project"] = "my-org"
+	_, err = adapter.FetchProjectFields(context.Background(), "12")
+	assert.ErrorContains(t, err, "owner/number of the Projects board")
+	assert.Empty(t, mutations)
+}
+
+func TestGitHubPluginAdapter_UpdateProjectFields(t *testing.T) {
+	var mutations []map[string]interface{}
+	server := githubProjectServer(t, true, &mutations)
+	defer server.Close()
+
+	adapter := newGitHubProjectAdapter(t, server.URL, map[string]interface{}{"repository": "owner/repo", "project": "my-org/7"})
+	ctx := context.Background()
+
+	fields, err := adapter.UpdateProjectFields(ctx, "owner/repo#12", &plugin.ProjectFields{Status: "in progress", Iteration: "Sprint 5"})
+	require.NoError(t, err)
+	assert.Equal(t, "In Progress", fields.Status)
+	require.Len(t, mutations, 2)
+	assert.Equal(t, map[string]interface{}{
+		"project": "PVT_7",
+		"item":    "PVTI_12",
+		"field":   "F_status",
+		"value":   map[string]interface{}{"singleSelectOptionId": "O_progress"},
+	}, mutations[0])
+	assert.Equal(t, map[string]interface{}{"iterationId": "IT_5"}, mutations[1]["value"])
+
+	// Unknown options change nothing
+	mutations = nil
+	_, err = adapter.UpdateProjectFields(ctx, "12", &plugin.ProjectFields{Status: "Shipped"})
+	assert.EqualError(t, err, `the Status field of project my-org/7 has no option "Shipped" (options: Todo, In Progress, Done)`)
+	_, err = adapter.UpdateProjectFields(ctx, "12", &plugin.ProjectFields{Iteration: "Sprint 9"})
+	assert.ErrorContains(t, err, `has no iteration "Sprint 9"`)
+	assert.Empty(t, mutations)
+
+	// Fields are found by the names in field_mapping
+	adapter.config.FieldMapping = fieldMappingConfig(map[string]string{"stage": "Stage"})
+	_, err = adapter.UpdateProjectFields(ctx, "12", &plugin.ProjectFields{Status: "Done"})
+	assert.ErrorContains(t, err, "project my-org/7 has no Stage field")
+}
+
+func TestGitHubPluginAdapter_UpdateProjectFields_AddsItem(t *testing.T) {
+	var mutations []map[string]interface{}
+	server := githubProjectServer(t, false, &mutations)
+	defer server.Close()
+
+	adapter := newGitHubProjectAdapter(t, server.URL, map[string]interface{}{"repository": "owner/repo", "project": "my-org/7"})
+	ctx := context.Background()
+
+	fields, err := adapter.FetchProjectFields(ctx, "12")
+	require.NoError(t, err)
+	assert.Nil(t, fields, "items on other boards are not the task's")
+
+	fields, err = adapter.UpdateProjectFields(ctx, "12", &plugin.ProjectFields{Status: "In Progress"})
+	require.NoError(t, err)
+	assert.Equal(t, "In Progress", fields.Status)
+	require.Len(t, mutations, 2)
+	assert.Equal(t, map[string]interface{}{"project": "PVT_7", "content": "I_12"}, mutations[0])
+	assert.Equal(t, "PVTI_12", mutations[1]["item"])
+}
+
+func TestGitHubPluginAdapter_GraphQLURL(t *testing.T) {
+	for baseURL, expected := range map[string]string{
+		"":                                  "https://api.github.com/graphql",
+		"https://api.github.com/":           "https://api.github.com/graphql",
+		"https://github.example.com/api/v3": "https://github.example.com/api/graphql",
+	} {
+		adapter := &GitHubPluginAdapter{config: &plugin.PluginConfig{BaseURL: baseURL}}
+		assert.Equal(t, expected, adapter.graphqlURL(), baseURL)
+	}
+}
+
+func TestFieldMappingConfig(t *testing.T) {
+	mapping := fieldMappingConfig(map[string]string{"stage": "Stage", "iteration": "Sprint"})
+	require.Len(t, mapping.Mappings, 2)
+	assert.Equal(t, "iteration", mapping.Mappings[0].ZenField)
+	assert.Equal(t, "Sprint", externalField(mapping, "iteration", defaultGitHubIterationField))
+	assert.Equal(t, "Stage", externalField(mapping, "stage", defaultGitHubStatusField))
+	assert.Equal(t, "Status", externalField(nil, "stage", defaultGitHubStatusField))
+}
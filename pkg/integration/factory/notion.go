@@ -0,0 +1,639 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/clients/notion"
+	"github.com/daddia/zen/pkg/integration/mapping"
+	"github.com/daddia/zen/pkg/integration/plugin"
+)
+
+// notionContentDepth is how deep the nested blocks of a page are read for its description
+const notionContentDepth = 3
+
+// notionPageID matches the ID of a Notion page, with or without dashes, at the end of an
+// ID or page URL
+var notionPageID = regexp.MustCompile(`([0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12})(?:[?#].*)?$`)
+
+// notionReadOnlyTypes are the types of the properties Notion computes, which are read but
+// never written
+var notionReadOnlyTypes = map[string]bool{
+	"unique_id": true, "formula": true, "rollup": true, "relation": true, "files": true,
+	"created_time": true, "created_by": true, "last_edited_time": true, "last_edited_by": true,
+	// People are set by user ID, but tasks name them
+	"people": true,
+}
+
+// NotionPluginAdapter tracks tasks in a Notion database, the pages of which are the
+// tasks. Properties are mapped to task fields by the notion field mapping, which
+// field_mapping of the provider overrides, and the content of a page is its description.
+type NotionPluginAdapter struct {
+	config  *plugin.PluginConfig
+	logger  logging.Logger
+	authMgr auth.Manager
+	mapper  *mapping.DataMapper
+
+	mu     sync.Mutex
+	client *notion.Client
+	schema map[string]string
+}
+
+func (n *NotionPluginAdapter) Name() string    { return "notion" }
+func (n *NotionPluginAdapter) Version() string { return n.config.Version }
+func (n *NotionPluginAdapter) Description() string {
+	return "Notion database integration plugin adapter"
+}
+
+func (n *NotionPluginAdapter) Initialize(ctx context.Context, config *plugin.PluginConfig) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.config = config
+	n.client = nil
+	n.schema = nil
+	return nil
+}
+
+func (n *NotionPluginAdapter) Validate(ctx context.Context) error {
+	if n.databaseID() == "" {
+		return fmt.Errorf("settings.database of the notion provider must be the ID of the task database")
+	}
+	return nil
+}
+
+func (n *NotionPluginAdapter) HealthCheck(ctx context.Context) (*plugin.PluginHealth, error) {
+	health := &plugin.PluginHealth{Provider: n.Name(), LastChecked: time.Now()}
+	start := time.Now()
+	_, err := n.databaseSchema(ctx)
+	health.ResponseTime = time.Since(start)
+	if err != nil {
+		health.ErrorCount = 1
+		health.LastError = err.Error()
+		return health, nil
+	}
+	health.Healthy = true
+	return health, nil
+}
+
+func (n *NotionPluginAdapter) Shutdown(ctx context.Context) error { return nil }
+
+// FetchTask reads the task of a database page. externalID is the ID of the page, its URL,
+// or the value of its ID property, such as TASK-42.
+func (n *NotionPluginAdapter) FetchTask(ctx context.Context, externalID string, opts *plugin.FetchOptions) (*plugin.TaskData, error) {
+	client, err := n.notionClient()
+	if err != nil {
+		return nil, err
+	}
+	pageID, err := n.pageIDOf(ctx, client, externalID)
+	if err != nil {
+		return nil, err
+	}
+	page, err := client.Page(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Notion page %s: %w", externalID, err)
+	}
+	return n.pageTaskData(ctx, page, opts)
+}
+
+// CreateTask adds a page for a task to the database, with its description as the
+// content of the page unless the description is mapped to a property
+func (n *NotionPluginAdapter) CreateTask(ctx context.Context, taskData *plugin.TaskData, opts *plugin.CreateOptions) (*plugin.TaskData, error) {
+	client, err := n.notionClient()
+	if err != nil {
+		return nil, err
+	}
+	properties, err := n.pageProperties(ctx, taskData)
+	if err != nil {
+		return nil, err
+	}
+	var children []notion.Block
+	if externalField(n.config.FieldMapping, "description", "") == "" {
+		children = notion.TextToBlocks(taskData.Description)
+	}
+
+	page, err := client.CreatePage(ctx, n.databaseID(), properties, children)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Notion page: %w", err)
+	}
+	return n.pageTaskData(ctx, page, &plugin.FetchOptions{})
+}
+
+// UpdateTask sets the mapped properties of a page. The content of the page is left as it
+// is, so descriptions are only updated when they are mapped to a property.
+func (n *NotionPluginAdapter) UpdateTask(ctx context.Context, externalID string, taskData *plugin.TaskData, opts *plugin.UpdateOptions) (*plugin.TaskData, error) {
+	client, err := n.notionClient()
+	if err != nil {
+		return nil, err
+	}
+	pageID, err := n.pageIDOf(ctx, client, externalID)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := n.pageProperties(ctx, taskData)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := client.UpdatePage(ctx, pageID, properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update Notion page %s: %w", externalID, err)
+	}
+	return n.pageTaskData(ctx, page, &plugin.FetchOptions{})
+}
+
+// DeleteTask moves the page of a task to the trash, from which it can be restored
+func (n *NotionPluginAdapter) DeleteTask(ctx context.Context, externalID string, opts *plugin.DeleteOptions) error {
+	client, err := n.notionClient()
+	if err != nil {
+		return err
+	}
+	pageID, err := n.pageIDOf(ctx, client, externalID)
+	if err != nil {
+		return err
+	}
+	if err := client.ArchivePage(ctx, pageID); err != nil {
+		return fmt.Errorf("failed to archive Notion page %s: %w", externalID, err)
+	}
+	return nil
+}
+
+// SearchTasks queries the database for the pages whose title contains query.Query and
+// whose properties match query.Filters, which are keyed by task field
+func (n *NotionPluginAdapter) SearchTasks(ctx context.Context, query *plugin.SearchQuery, opts *plugin.SearchOptions) ([]*plugin.TaskData, error) {
+	client, err := n.notionClient()
+	if err != nil {
+		return nil, err
+	}
+	filter, err := n.queryFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	limit := 0
+	if opts != nil {
+		limit = opts.MaxResults
+	}
+
+	pages, err := client.QueryDatabase(ctx, n.databaseID(), filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Notion database: %w", err)
+	}
+	tasks := make([]*plugin.TaskData, 0, len(pages))
+	for _, page := range pages {
+		// Descriptions of search results are left to FetchTask, which reads page content
+		task, err := n.mapPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func (n *NotionPluginAdapter) SyncTask(ctx context.Context, taskID string, opts *plugin.SyncOptions) (*plugin.SyncResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (n *NotionPluginAdapter) GetSyncMetadata(ctx context.Context, taskID string) (*plugin.SyncMetadata, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// MapToZen maps a *notion.Page to a task
+func (n *NotionPluginAdapter) MapToZen(ctx context.Context, externalData interface{}) (*plugin.TaskData, error) {
+	page, ok := externalData.(*notion.Page)
+	if !ok {
+		return nil, fmt.Errorf("expected a Notion page, got %T", externalData)
+	}
+	return n.mapPage(ctx, page)
+}
+
+// MapToExternal maps a task to the properties of a database page
+func (n *NotionPluginAdapter) MapToExternal(ctx context.Context, zenData *plugin.TaskData) (interface{}, error) {
+	return n.pageProperties(ctx, zenData)
+}
+
+// GetFieldMapping returns the notion field mapping with the fields that field_mapping of
+// the provider maps overridden, and the status options of settings.status_map
+func (n *NotionPluginAdapter) GetFieldMapping() *plugin.FieldMappingConfig {
+	defaults := n.dataMapper().GetDefaultMapping("notion")
+	result := mergeFieldMapping(defaults, n.config.FieldMapping)
+
+	statusMap, _ := n.config.Settings["status_map"].(map[string]interface{})
+	if len(statusMap) == 0 {
+		result.Transforms = defaults.Transforms
+		return result
+	}
+	pull := map[string]interface{}{}
+	push := map[string]interface{}{}
+	options := make([]string, 0, len(statusMap))
+	for option := range statusMap {
+		options = append(options, option)
+	}
+	// The first of the options mapped to a status is the one it is pushed as
+	sort.Sort(sort.Reverse(sort.StringSlice(options)))
+	for _, option := range options {
+		status := fmt.Sprintf("%v", statusMap[option])
+		pull[option] = status
+		push[status] = option
+	}
+	result.Transforms = []plugin.FieldTransform{
+		{Field: "status", Type: plugin.TransformTypeMap, Direction: plugin.SyncDirectionPull, Config: map[string]interface{}{"mappings": pull}},
+		{Field: "status", Type: plugin.TransformTypeMap, Direction: plugin.SyncDirectionPush, Config: map[string]interface{}{"mappings": push}},
+	}
+	return result
+}
+
+func (n *NotionPluginAdapter) GetAuthConfig() *plugin.AuthConfig { return n.config.Auth }
+
+// GetRateLimitInfo reports the request rate Notion allows an integration. Notion does
+// not report what is left of it, so the whole rate is reported as remaining.
+func (n *NotionPluginAdapter) GetRateLimitInfo(ctx context.Context) (*plugin.RateLimitInfo, error) {
+	return &plugin.RateLimitInfo{
+		Limit:     notion.RequestsPerMinute,
+		Remaining: notion.RequestsPerMinute,
+		ResetTime: time.Now().Add(time.Minute),
+	}, nil
+}
+
+func (n *NotionPluginAdapter) SupportsOperation(operation plugin.OperationType) bool {
+	switch operation {
+	case plugin.OperationTypeFetch, plugin.OperationTypeCreate, plugin.OperationTypeUpdate,
+		plugin.OperationTypeDelete, plugin.OperationTypeSearch:
+		return true
+	default:
+		return false
+	}
+}
+
+// databaseID returns settings.database, the ID of the task database
+func (n *NotionPluginAdapter) databaseID() string {
+	database, _ := n.config.Settings["database"].(string)
+	return notionPageIDOf(database)
+}
+
+// notionClient returns the client of the Notion API, authenticated with the notion token
+func (n *NotionPluginAdapter) notionClient() (*notion.Client, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.client != nil {
+		return n.client, nil
+	}
+	if n.authMgr == nil {
+		return nil, fmt.Errorf("no Notion token; set ZEN_NOTION_TOKEN or run zen auth notion")
+	}
+	token, err := n.authMgr.GetCredentials("notion")
+	if err != nil || token == "" {
+		return nil, fmt.Errorf("no Notion token; set ZEN_NOTION_TOKEN or run zen auth notion")
+	}
+	n.client = notion.NewClient(n.config.BaseURL, token, n.config.Timeout, n.logger)
+	return n.client, nil
+}
+
+func (n *NotionPluginAdapter) dataMapper() *mapping.DataMapper {
+	if n.mapper == nil {
+		n.mapper = mapping.NewDataMapper(n.logger)
+	}
+	return n.mapper
+}
+
+// pageIDOf returns the ID of the page of a task: the page ID in externalID, or the ID of
+// the page whose ID property has the value externalID
+func (n *NotionPluginAdapter) pageIDOf(ctx context.Context, client *notion.Client, externalID string) (string, error) {
+	if notionPageID.MatchString(externalID) {
+		return notionPageIDOf(externalID), nil
+	}
+	number, err := strconv.Atoi(externalID[strings.LastIndex(externalID, "-")+1:])
+	if err != nil {
+		return "", fmt.Errorf("%s is not a Notion page ID, URL or task ID", externalID)
+	}
+
+	schema, err := n.databaseSchema(ctx)
+	if err != nil {
+		return "", err
+	}
+	name, ok := notion.ResolveProperty(schema, externalField(n.GetFieldMapping(), "id", "type:unique_id"))
+	if !ok || schema[name] != "unique_id" {
+		return "", fmt.Errorf("the Notion database has no ID property to find %s by", externalID)
+	}
+	pages, err := client.QueryDatabase(ctx, n.databaseID(), map[string]interface{}{
+		"property":  name,
+		"unique_id": map[string]interface{}{"equals": number},
+	}, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to find Notion page %s: %w", externalID, err)
+	}
+	if len(pages) == 0 {
+		return "", fmt.Errorf("no page of the Notion database has ID %s", externalID)
+	}
+	return pages[0].ID, nil
+}
+
+// databaseSchema returns the types of the properties of the task database by name,
+// reading them once
+func (n *NotionPluginAdapter) databaseSchema(ctx context.Context) (map[string]string, error) {
+	if err := n.Validate(ctx); err != nil {
+		return nil, err
+	}
+	client, err := n.notionClient()
+	if err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	schema := n.schema
+	n.mu.Unlock()
+	if schema != nil {
+		return schema, nil
+	}
+
+	database, err := client.Database(ctx, n.databaseID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Notion database: %w", err)
+	}
+	schema = notion.DatabaseSchema(database)
+	n.mu.Lock()
+	n.schema = schema
+	n.mu.Unlock()
+	return schema, nil
+}
+
+// pageTaskData maps a page to a task, with the content of the page as its description
+// unless the description is mapped to a property
+func (n *NotionPluginAdapter) pageTaskData(ctx context.Context, page *notion.Page, opts *plugin.FetchOptions) (*plugin.TaskData, error) {
+	task, err := n.mapPage(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Description == "" && externalField(n.config.FieldMapping, "description", "") == "" {
+		client, err := n.notionClient()
+		if err != nil {
+			return nil, err
+		}
+		blocks, err := client.BlockChildren(ctx, page.ID, notionContentDepth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the content of Notion page %s: %w", page.ID, err)
+		}
+		task.Description = notion.BlocksToMarkdown(blocks)
+	}
+
+	if opts != nil && opts.IncludeRaw {
+		raw := map[string]interface{}{"id": page.ID, "url": page.URL}
+		properties := map[string]interface{}{}
+		for name, property := range page.Properties {
+			properties[name] = notion.PropertyValue(property)
+		}
+		raw["properties"] = properties
+		task.RawData = raw
+	}
+	return task, nil
+}
+
+// mapPage maps the properties of a page to a task through the field mapping
+func (n *NotionPluginAdapter) mapPage(ctx context.Context, page *notion.Page) (*plugin.TaskData, error) {
+	fieldMapping := n.GetFieldMapping()
+	schema := notion.PageSchema(page)
+
+	// Property names may hold the dots the mapper reads as paths, so properties are
+	// given to it under keys of their own
+	source := map[string]interface{}{}
+	resolved := &plugin.FieldMappingConfig{Transforms: fieldMapping.Transforms}
+	for _, m := range fieldMapping.Mappings {
+		if name, ok := notion.ResolveProperty(schema, m.ExternalField); ok {
+			m.ExternalField = notionPropertyKey(name)
+			source[m.ExternalField] = notion.PropertyValue(page.Properties[name])
+		}
+		resolved.Mappings = append(resolved.Mappings, m)
+	}
+
+	fields, err := n.dataMapper().MapFields(ctx, source, resolved, plugin.SyncDirectionPull)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map Notion page %s: %w", page.ID, err)
+	}
+
+	task := mappedTaskData(fields)
+	if task.ID == "" {
+		task.ID = page.ID
+	}
+	task.ExternalID = page.ID
+	task.ExternalURL = page.URL
+	task.Created = page.CreatedTime
+	task.Updated = page.LastEditedTime
+	return task, nil
+}
+
+// pageProperties maps a task to the properties of a database page through the field
+// mapping. Fields mapped to properties the database does not have, or that Notion
+// computes, are left out.
+func (n *NotionPluginAdapter) pageProperties(ctx context.Context, taskData *plugin.TaskData) (map[string]interface{}, error) {
+	schema, err := n.databaseSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fieldMapping := n.GetFieldMapping()
+
+	// The mapper maps from external fields to Zen fields, so pushes map the other way
+	// round, with the transforms applied to the property a field is pushed to
+	names := map[string]string{}
+	reversed := &plugin.FieldMappingConfig{}
+	for _, m := range fieldMapping.Mappings {
+		name, ok := notion.ResolveProperty(schema, m.ExternalField)
+		if !ok || notionReadOnlyTypes[schema[name]] {
+			continue
+		}
+		key := notionPropertyKey(name)
+		names[key] = name
+		reversed.Mappings = append(reversed.Mappings, plugin.FieldMapping{
+			ZenField:      key,
+			ExternalField: m.ZenField,
+			Direction:     m.Direction,
+		})
+		for _, transform := range fieldMapping.Transforms {
+			if transform.Field == m.ZenField {
+				transform.Field = key
+				reversed.Transforms = append(reversed.Transforms, transform)
+			}
+		}
+	}
+
+	values, err := n.dataMapper().MapFields(ctx, zenFields(taskData), reversed, plugin.SyncDirectionPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map task %s to Notion properties: %w", taskData.ID, err)
+	}
+
+	properties := map[string]interface{}{}
+	for key, value := range values {
+		name := names[key]
+		input, err := notion.PropertyInput(schema[name], value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot set Notion property %s: %w", name, err)
+		}
+		properties[name] = input
+	}
+	return properties, nil
+}
+
+// queryFilter returns the database filter of a search: title contains the query, and
+// the properties of filtered fields equal, or for multi-select contain, their values
+func (n *NotionPluginAdapter) queryFilter(ctx context.Context, query *plugin.SearchQuery) (map[string]interface{}, error) {
+	if query == nil || (query.Query == "" && len(query.Filters) == 0) {
+		return nil, nil
+	}
+	schema, err := n.databaseSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fieldMapping := n.GetFieldMapping()
+
+	var conditions []interface{}
+	if query.Query != "" {
+		if name, ok := notion.ResolveProperty(schema, externalField(fieldMapping, "title", "type:title")); ok {
+			conditions = append(conditions, map[string]interface{}{
+				"property": name,
+				"title":    map[string]interface{}{"contains": query.Query},
+			})
+		}
+	}
+
+	fields := make([]string, 0, len(query.Filters))
+	for field := range query.Filters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		name, ok := notion.ResolveProperty(schema, externalField(fieldMapping, field, field))
+		if !ok {
+			return nil, fmt.Errorf("the Notion database has no property for %s", field)
+		}
+		value := query.Filters[field]
+		propertyType := schema[name]
+		operator := "equals"
+		switch propertyType {
+		case "title", "rich_text", "multi_select", "people":
+			operator = "contains"
+		}
+		conditions = append(conditions, map[string]interface{}{
+			"property":   name,
+			propertyType: map[string]interface{}{operator: value},
+		})
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0].(map[string]interface{}), nil
+	}
+	return map[string]interface{}{"and": conditions}, nil
+}
+
+// notionPageIDOf returns the page ID in an ID or page URL in its dashed form, or
+// externalID when it has none
+func notionPageIDOf(externalID string) string {
+	match := notionPageID.FindStringSubmatch(externalID)
+	if match == nil {
+		return externalID
+	}
+	id := strings.ToLower(strings.ReplaceAll(match[1], "-", ""))
+	return id[:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:]
+}
+
+// notionPropertyKey returns the key a property is given to the mapper under
+func notionPropertyKey(name string) string {
+	return "property:" + strings.ReplaceAll(name, ".", "_")
+}
+
+// zenFields returns the fields of a task by the names the field mapping uses, leaving
+// out fields without a value
+func zenFields(task *plugin.TaskData) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for field, value := range task.Metadata {
+		fields[field] = value
+	}
+	for field, value := range map[string]string{
+		"id": task.ID, "title": task.Title, "description": task.Description,
+		"status": task.Status, "priority": task.Priority, "type": task.Type,
+		"owner": task.Owner, "assignee": task.Assignee, "team": task.Team,
+	} {
+		if value != "" {
+			fields[field] = value
+		}
+	}
+	for field, value := range map[string][]string{"labels": task.Labels, "tags": task.Tags, "components": task.Components} {
+		if len(value) > 0 {
+			fields[field] = value
+		}
+	}
+	if task.DueDate != nil {
+		fields["due_date"] = task.DueDate.Format("2006-01-02")
+	}
+	return fields
+}
+
+// mappedTaskData returns the task of the fields mapped from a page or record. Fields
+// that are not task fields are kept in its metadata.
+func mappedTaskData(fields map[string]interface{}) *plugin.TaskData {
+	task := &plugin.TaskData{Metadata: map[string]interface{}{}}
+	text := func(value interface{}) string {
+		if list, ok := value.([]string); ok {
+			return strings.Join(list, ", ")
+		}
+		return fmt.Sprintf("%v", value)
+	}
+	list := func(value interface{}) []string {
+		if list, ok := value.([]string); ok {
+			return list
+		}
+		return []string{text(value)}
+	}
+
+	for field, value := range fields {
+		switch field {
+		case "id":
+			task.ID = text(value)
+		case "title":
+			task.Title = text(value)
+		case "description":
+			task.Description = text(value)
+		case "status":
+			task.Status = text(value)
+		case "priority":
+			task.Priority = text(value)
+		case "type":
+			task.Type = text(value)
+		case "owner":
+			task.Owner = text(value)
+		case "assignee":
+			task.Assignee = text(value)
+		case "team":
+			task.Team = text(value)
+		case "labels":
+			task.Labels = list(value)
+		case "tags":
+			task.Tags = list(value)
+		case "components":
+			task.Components = list(value)
+		case "due_date":
+			if due, ok := parseNotionDate(text(value)); ok {
+				task.DueDate = &due
+			}
+		default:
+			task.Metadata[field] = value
+		}
+	}
+	return task
+}
+
+// parseNotionDate parses the start of a date property, which is a date or a date and time
+func parseNotionDate(value string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,203 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const notionTestPage = "0f6c0a6e-1c1d-4d6b-9f69-5c0b7e3a9d21"
+
+// notionTestPageJSON is task TASK-42 of the database, which is in progress
+const notionTestPageJSON = `{
+	"id": "0f6c0a6e-1c1d-4d6b-9f69-5c0b7e3a9d21",
+	"url": "https://www.notion.so/Fix-login-0f6c0a6e1c1d4d6b9f695c0b7e3a9d21",
+	"created_time": "2026-10-01T09:00:00.000Z",
+	"last_edited_time": "2026-10-14T16:30:00.000Z",
+	"properties": {
+		"Name": {"type": "title", "title": [{"plain_text": "Fix login"}]},
+		"Status": {"type": "status", "status": {"name": "In progress"}},
+		"Priority": {"type": "select", "select": {"name": "High"}},
+		"Tags": {"type": "multi_select", "multi_select": [{"name": "auth"}, {"name": "web"}]},
+		"Assignee": {"type": "people", "people": [{"id": "u1", "name": "Ada Lovelace"}]},
+		"Due": {"type": "date", "date": {"start": "2026-10-30"}},
+		"ID": {"type": "unique_id", "unique_id": {"prefix": "TASK", "number": 42}},
+		"Team.Name": {"type": "rich_text", "rich_text": [{"plain_text": "Identity"}]}
+	}
+}`
+
+// notionServer answers the requests for the task database db1 and its page TASK-42,
+// recording the bodies of the requests that change pages
+type notionServer struct {
+	*httptest.Server
+	filters []interface{}
+	writes  []map[string]interface{}
+}
+
+func newNotionServer(t *testing.T) *notionServer {
+	s := &notionServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret_test", r.Header.Get("Authorization"))
+
+		var body map[string]interface{}
+		if r.Body != nil && r.Method != http.MethodGet {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/databases/db1":
+			fmt.Fprint(w, `{"id": "db1", "properties": {
+				"Name": {"type": "title"}, "Status": {"type": "status"}, "Priority": {"type": "select"},
+				"Tags": {"type": "multi_select"}, "Assignee": {"type": "people"}, "Due": {"type": "date"},
+				"ID": {"type": "unique_id"}, "Team.Name": {"type": "rich_text"}}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/pages/"+notionTestPage:
+			fmt.Fprint(w, notionTestPageJSON)
+		case r.Method == http.MethodGet && r.URL.Path == "/blocks/"+notionTestPage+"/children":
+			fmt.Fprint(w, `{"results": [
+				{"type": "paragraph", "paragraph": {"rich_text": [{"plain_text": "Users are logged out "}, {"plain_text": "every hour", "annotations": {"bold": true}}]}},
+				{"type": "to_do", "to_do": {"rich_text": [{"plain_text": "Reproduce"}], "checked": true}}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/databases/db1/query":
+			s.filters = append(s.filters, body["filter"])
+			fmt.Fprintf(w, `{"results": [%s], "has_more": false}`, notionTestPageJSON)
+		case r.Method == http.MethodPatch && r.URL.Path == "/pages/"+notionTestPage, r.Method == http.MethodPost && r.URL.Path == "/pages":
+			s.writes = append(s.writes, body)
+			fmt.Fprint(w, notionTestPageJSON)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func newNotionAdapter(baseURL string, fieldMapping map[string]string, settings map[string]interface{}) *NotionPluginAdapter {
+	authMgr := &mockAuthManager{}
+	authMgr.On("GetCredentials", "notion").Return("secret_test", nil)
+	if settings == nil {
+		settings = map[string]interface{}{}
+	}
+	settings["database"] = "db1"
+	config := &plugin.PluginConfig{Name: "notion", BaseURL: baseURL, Timeout: 5 * time.Second, Settings: settings}
+	if fieldMapping != nil {
+		config.FieldMapping = fieldMappingConfig(fieldMapping)
+	}
+	return &NotionPluginAdapter{config: config, logger: logging.NewBasic(), authMgr: authMgr}
+}
+
+func TestNotionPluginAdapter_FetchTask(t *testing.T) {
+	server := newNotionServer(t)
+	defer server.Close()
+	adapter := newNotionAdapter(server.URL, map[string]string{"team": "Team.Name"}, nil)
+
+	task, err := adapter.FetchTask(context.Background(), "https://www.notion.so/Fix-login-0f6c0a6e1c1d4d6b9f695c0b7e3a9d21", &plugin.FetchOptions{IncludeRaw: true})
+	require.NoError(t, err)
+	assert.Equal(t, "TASK-42", task.ID)
+	assert.Equal(t, notionTestPage, task.ExternalID)
+	assert.Equal(t, "Fix login", task.Title)
+	assert.Equal(t, "in_progress", task.Status)
+	assert.Equal(t, "High", task.Priority)
+	assert.Equal(t, "Ada Lovelace", task.Assignee)
+	assert.Equal(t, "Identity", task.Team)
+	assert.Equal(t, []string{"auth", "web"}, task.Labels)
+	require.NotNil(t, task.DueDate)
+	assert.Equal(t, "2026-10-30", task.DueDate.Format("2006-01-02"))
+	assert.Equal(t, "Users are logged out **every hour**\n\n- [x] Reproduce", task.Description)
+	assert.Equal(t, time.Date(2026, 10, 14, 16, 30, 0, 0, time.UTC), task.Updated)
+	assert.Equal(t, "https://www.notion.so/Fix-login-0f6c0a6e1c1d4d6b9f695c0b7e3a9d21", task.RawData["url"])
+
+	// Tasks are also found by their ID property
+	task, err = adapter.FetchTask(context.Background(), "TASK-42", nil)
+	require.NoError(t, err)
+	assert.Equal(t, notionTestPage, task.ExternalID)
+	require.Len(t, server.filters, 1)
+	assert.Equal(t, map[string]interface{}{"property": "ID", "unique_id": map[string]interface{}{"equals": float64(42)}}, server.filters[0])
+
+	_, err = adapter.FetchTask(context.Background(), "login-bug", nil)
+	assert.EqualError(t, err, "login-bug is not a Notion page ID, URL or task ID")
+}
+
+func TestNotionPluginAdapter_UpdateTask(t *testing.T) {
+	server := newNotionServer(t)
+	defer server.Close()
+	adapter := newNotionAdapter(server.URL, nil, map[string]interface{}{
+		"status_map": map[string]interface{}{"Backlog": "proposed", "Todo": "proposed", "Doing": "in_progress"},
+	})
+
+	due := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	_, err := adapter.UpdateTask(context.Background(), notionTestPage, &plugin.TaskData{
+		ID:       "TASK-42",
+		Title:    "Fix login",
+		Status:   "proposed",
+		Assignee: "Ada Lovelace",
+		Labels:   []string{"auth"},
+		DueDate:  &due,
+	}, nil)
+	require.NoError(t, err)
+
+	require.Len(t, server.writes, 1)
+	properties := server.writes[0]["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"status": map[string]interface{}{"name": "Backlog"}}, properties["Status"])
+	assert.Equal(t, map[string]interface{}{"multi_select": []interface{}{map[string]interface{}{"name": "auth"}}}, properties["Tags"])
+	assert.Equal(t, map[string]interface{}{"date": map[string]interface{}{"start": "2026-11-02"}}, properties["Due"])
+	assert.Contains(t, properties, "Name")
+	assert.NotContains(t, properties, "ID", "IDs are computed by Notion")
+	assert.NotContains(t, properties, "Assignee", "people are set by user ID")
+	assert.NotContains(t, properties, "Priority", "fields without a value are left as they are")
+}
+
+func TestNotionPluginAdapter_CreateTask(t *testing.T) {
+	server := newNotionServer(t)
+	defer server.Close()
+	adapter := newNotionAdapter(server.URL, nil, nil)
+
+	task, err := adapter.CreateTask(context.Background(), &plugin.TaskData{Title: "Fix login", Description: "First.\n\nSecond."}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "TASK-42", task.ID)
+
+	require.Len(t, server.writes, 1)
+	assert.Equal(t, map[string]interface{}{"database_id": "db1"}, server.writes[0]["parent"])
+	assert.Len(t, server.writes[0]["children"], 2)
+}
+
+func TestNotionPluginAdapter_SearchTasks(t *testing.T) {
+	server := newNotionServer(t)
+	defer server.Close()
+	adapter := newNotionAdapter(server.URL, nil, nil)
+
+	tasks, err := adapter.SearchTasks(context.Background(), &plugin.SearchQuery{
+		Query:   "login",
+		Filters: map[string]interface{}{"labels": "auth", "status": "In progress"},
+	}, &plugin.SearchOptions{MaxResults: 10})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "TASK-42", tasks[0].ID)
+	assert.Empty(t, tasks[0].Description, "search results are not read")
+
+	require.Len(t, server.filters, 1)
+	assert.Equal(t, map[string]interface{}{"and": []interface{}{
+		map[string]interface{}{"property": "Name", "title": map[string]interface{}{"contains": "login"}},
+		map[string]interface{}{"property": "Tags", "multi_select": map[string]interface{}{"contains": "auth"}},
+		map[string]interface{}{"property": "Status", "status": map[string]interface{}{"equals": "In progress"}},
+	}}, server.filters[0])
+
+	_, err = adapter.SearchTasks(context.Background(), &plugin.SearchQuery{Filters: map[string]interface{}{"sprint": "12"}}, nil)
+	assert.EqualError(t, err, "the Notion database has no property for sprint")
+}
+
+func TestNotionPluginAdapter_Validate(t *testing.T) {
+	adapter := newNotionAdapter("", nil, nil)
+	assert.NoError(t, adapter.Validate(context.Background()))
+	adapter.config.Settings = map[string]interface{}{}
+	assert.ErrorContains(t, adapter.Validate(context.Background()), "settings.database")
+	assert.True(t, adapter.SupportsOperation(plugin.OperationTypeSearch))
+	assert.False(t, adapter.SupportsOperation(plugin.OperationTypeSync))
+}
@@ -0,0 +1,611 @@
+package factory
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/clients/servicenow"
+	"github.com/daddia/zen/pkg/integration/mapping"
+	"github.com/daddia/zen/pkg/integration/plugin"
+)
+
+// serviceNowSysID matches the sys_id of a record
+var serviceNowSysID = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// serviceNowUnwritableFields are the fields of records that are read but never written:
+// fields ServiceNow computes, and references, which are set by sys_id while tasks name
+// what they reference
+var serviceNowUnwritableFields = map[string]bool{
+	"number": true, "priority": true, "sys_id": true, "sys_class_name": true,
+	"sys_created_on": true, "sys_updated_on": true, "opened_by": true, "caller_id": true,
+	"assigned_to": true, "assignment_group": true,
+}
+
+// ServiceNowPluginAdapter tracks tasks as incidents and change requests of a ServiceNow
+// instance. Tasks are found by record number, such as INC0010023, and the state of a
+// record maps to the task status by the states of its table.
+type ServiceNowPluginAdapter struct {
+	config  *plugin.PluginConfig
+	logger  logging.Logger
+	authMgr auth.Manager
+	mapper  *mapping.DataMapper
+
+	mu     sync.Mutex
+	client *servicenow.Client
+}
+
+func (s *ServiceNowPluginAdapter) Name() string    { return "servicenow" }
+func (s *ServiceNowPluginAdapter) Version() string { return s.config.Version }
+func (s *ServiceNowPluginAdapter) Description() string {
+	return "ServiceNow incident and change request integration plugin adapter"
+}
+
+func (s *ServiceNowPluginAdapter) Initialize(ctx context.Context, config *plugin.PluginConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = config
+	s.client = nil
+	return nil
+}
+
+func (s *ServiceNowPluginAdapter) Validate(ctx context.Context) error {
+	if s.config.BaseURL == "" {
+		return fmt.Errorf("the servicenow provider needs the URL of the instance, or its name as settings.instance")
+	}
+	if s.config.Auth != nil && s.config.Auth.Type == plugin.AuthTypeOAuth2 && s.setting("client_id") == "" {
+		return fmt.Errorf("settings.client_id of the servicenow provider must be the client ID of the OAuth application")
+	}
+	return nil
+}
+
+func (s *ServiceNowPluginAdapter) HealthCheck(ctx context.Context) (*plugin.PluginHealth, error) {
+	health := &plugin.PluginHealth{Provider: s.Name(), LastChecked: time.Now()}
+	start := time.Now()
+	client, err := s.serviceNowClient()
+	if err == nil {
+		_, err = client.Query(ctx, s.defaultTable(), "", 1)
+	}
+	health.ResponseTime = time.Since(start)
+	if err != nil {
+		health.ErrorCount = 1
+		health.LastError = err.Error()
+		return health, nil
+	}
+	health.Healthy = true
+	return health, nil
+}
+
+func (s *ServiceNowPluginAdapter) Shutdown(ctx context.Context) error { return nil }
+
+// FetchTask reads the task of a record. externalID is the number of the record, its
+// sys_id in settings.table, or either prefixed with a table, such as "problem:PRB0040001".
+func (s *ServiceNowPluginAdapter) FetchTask(ctx context.Context, externalID string, opts *plugin.FetchOptions) (*plugin.TaskData, error) {
+	table, record, err := s.record(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.recordTaskData(ctx, table, record, opts)
+}
+
+// CreateTask adds a record for a task to the table in its servicenow_table metadata, or
+// to settings.table
+func (s *ServiceNowPluginAdapter) CreateTask(ctx context.Context, taskData *plugin.TaskData, opts *plugin.CreateOptions) (*plugin.TaskData, error) {
+	client, err := s.serviceNowClient()
+	if err != nil {
+		return nil, err
+	}
+	table := s.defaultTable()
+	if t, ok := taskData.Metadata["servicenow_table"].(string); ok && t != "" {
+		table = t
+	}
+	fields, err := s.recordFields(ctx, table, taskData)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := client.Create(ctx, table, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ServiceNow %s: %w", table, err)
+	}
+	return s.recordTaskData(ctx, table, record, &plugin.FetchOptions{})
+}
+
+// UpdateTask sets the mapped fields of a record. Records moved to a closing state, such
+// as a resolved incident, are given the close code of their table and close notes.
+func (s *ServiceNowPluginAdapter) UpdateTask(ctx context.Context, externalID string, taskData *plugin.TaskData, opts *plugin.UpdateOptions) (*plugin.TaskData, error) {
+	client, err := s.serviceNowClient()
+	if err != nil {
+		return nil, err
+	}
+	table, current, err := s.record(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := s.recordFields(ctx, table, taskData)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := client.Update(ctx, table, current.Value("sys_id"), fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update ServiceNow record %s: %w", externalID, err)
+	}
+	return s.recordTaskData(ctx, table, record, &plugin.FetchOptions{})
+}
+
+// DeleteTask is not supported: ITSM records are kept for audit, so tasks are cancelled
+// rather than deleted
+func (s *ServiceNowPluginAdapter) DeleteTask(ctx context.Context, externalID string, opts *plugin.DeleteOptions) error {
+	return fmt.Errorf("ServiceNow records are not deleted; set the status of the task to cancelled instead")
+}
+
+// SearchTasks queries settings.table for the records whose short description contains
+// query.Query and whose fields equal query.Filters, which are keyed by task field, most
+// recently updated first
+func (s *ServiceNowPluginAdapter) SearchTasks(ctx context.Context, query *plugin.SearchQuery, opts *plugin.SearchOptions) ([]*plugin.TaskData, error) {
+	client, err := s.serviceNowClient()
+	if err != nil {
+		return nil, err
+	}
+	table := s.defaultTable()
+	encoded, err := s.encodedQuery(table, query)
+	if err != nil {
+		return nil, err
+	}
+	limit := 0
+	if opts != nil {
+		limit = opts.MaxResults
+	}
+
+	records, err := client.Query(ctx, table, encoded, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ServiceNow %s: %w", table, err)
+	}
+	tasks := make([]*plugin.TaskData, 0, len(records))
+	for _, record := range records {
+		task, err := s.mapRecord(ctx, table, record)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func (s *ServiceNowPluginAdapter) SyncTask(ctx context.Context, taskID string, opts *plugin.SyncOptions) (*plugin.SyncResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *ServiceNowPluginAdapter) GetSyncMetadata(ctx context.Context, taskID string) (*plugin.SyncMetadata, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// MapToZen maps a servicenow.Record of settings.table to a task
+func (s *ServiceNowPluginAdapter) MapToZen(ctx context.Context, externalData interface{}) (*plugin.TaskData, error) {
+	record, ok := externalData.(servicenow.Record)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServiceNow record, got %T", externalData)
+	}
+	return s.mapRecord(ctx, s.defaultTable(), record)
+}
+
+// MapToExternal maps a task to the fields of a record of settings.table
+func (s *ServiceNowPluginAdapter) MapToExternal(ctx context.Context, zenData *plugin.TaskData) (interface{}, error) {
+	return s.recordFields(ctx, s.defaultTable(), zenData)
+}
+
+// GetFieldMapping returns the servicenow field mapping with the fields that field_mapping
+// of the provider maps overridden. Fields named without ".value" or ".display_value" are
+// read as their display value.
+func (s *ServiceNowPluginAdapter) GetFieldMapping() *plugin.FieldMappingConfig {
+	defaults := s.dataMapper().GetDefaultMapping("servicenow")
+	result := mergeFieldMapping(defaults, s.config.FieldMapping)
+	result.Transforms = defaults.Transforms
+	for i, m := range result.Mappings {
+		if !strings.Contains(m.ExternalField, ".") {
+			result.Mappings[i].ExternalField = m.ExternalField + ".display_value"
+		}
+	}
+	return result
+}
+
+func (s *ServiceNowPluginAdapter) GetAuthConfig() *plugin.AuthConfig { return s.config.Auth }
+
+// GetRateLimitInfo is not supported, as instances set their own rate limit rules and do
+// not report them
+func (s *ServiceNowPluginAdapter) GetRateLimitInfo(ctx context.Context) (*plugin.RateLimitInfo, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *ServiceNowPluginAdapter) SupportsOperation(operation plugin.OperationType) bool {
+	switch operation {
+	case plugin.OperationTypeFetch, plugin.OperationTypeCreate, plugin.OperationTypeUpdate,
+		plugin.OperationTypeSearch:
+		return true
+	default:
+		return false
+	}
+}
+
+// ListAttachments implements plugin.AttachmentTransferer with the attachments of a record
+func (s *ServiceNowPluginAdapter) ListAttachments(ctx context.Context, externalID string) ([]*plugin.Attachment, error) {
+	client, err := s.serviceNowClient()
+	if err != nil {
+		return nil, err
+	}
+	table, record, err := s.record(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := client.Attachments(ctx, table, record.Value("sys_id"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments in ServiceNow: %w", err)
+	}
+	attachments := make([]*plugin.Attachment, 0, len(files))
+	for i := range files {
+		attachments = append(attachments, convertServiceNowAttachment(&files[i]))
+	}
+	return attachments, nil
+}
+
+// UploadAttachment implements plugin.AttachmentTransferer, with the content type of the
+// file given by the extension of its name
+func (s *ServiceNowPluginAdapter) UploadAttachment(ctx context.Context, externalID, name string, content io.Reader) (*plugin.Attachment, error) {
+	client, err := s.serviceNowClient()
+	if err != nil {
+		return nil, err
+	}
+	table, record, err := s.record(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment %s: %w", name, err)
+	}
+
+	uploaded, err := client.UploadAttachment(ctx, table, record.Value("sys_id"), name, mime.TypeByExtension(filepath.Ext(name)), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload attachment to ServiceNow: %w", err)
+	}
+	return convertServiceNowAttachment(uploaded), nil
+}
+
+// DownloadAttachment implements plugin.AttachmentTransferer
+func (s *ServiceNowPluginAdapter) DownloadAttachment(ctx context.Context, attachment *plugin.Attachment, w io.Writer) error {
+	client, err := s.serviceNowClient()
+	if err != nil {
+		return err
+	}
+	data, err := client.DownloadAttachment(ctx, attachment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to download attachment %s from ServiceNow: %w", attachment.Name, err)
+	}
+	_, err = io.Copy(w, bytes.NewReader(data))
+	return err
+}
+
+func convertServiceNowAttachment(a *servicenow.Attachment) *plugin.Attachment {
+	return &plugin.Attachment{
+		ID:       a.SysID,
+		Name:     a.FileName,
+		URL:      a.DownloadLink,
+		MimeType: a.ContentType,
+		Size:     a.Size(),
+		Created:  a.Created(),
+	}
+}
+
+// setting returns a string setting of the provider
+func (s *ServiceNowPluginAdapter) setting(key string) string {
+	value, _ := s.config.Settings[key].(string)
+	return value
+}
+
+// defaultTable returns settings.table, the table tasks are created in and searched,
+// which is incident by default
+func (s *ServiceNowPluginAdapter) defaultTable() string {
+	if table := s.setting("table"); table != "" {
+		return table
+	}
+	return servicenow.TableIncident
+}
+
+// states returns the states of a table: settings.states.<table>, which maps state values
+// to task statuses, or the default states of the table
+func (s *ServiceNowPluginAdapter) states(table string) servicenow.States {
+	configured, _ := s.config.Settings["states"].(map[string]interface{})
+	if statuses, ok := configured[table].(map[string]interface{}); ok && len(statuses) > 0 {
+		values := map[string]string{}
+		for value, status := range statuses {
+			values[value] = fmt.Sprintf("%v", status)
+		}
+		return servicenow.StatesOf(values)
+	}
+	return servicenow.DefaultStates[table]
+}
+
+// closeCode returns the close code records of a table are closed with:
+// settings.close_codes.<table>, or the default close code of the table
+func (s *ServiceNowPluginAdapter) closeCode(table string) string {
+	configured, _ := s.config.Settings["close_codes"].(map[string]interface{})
+	if code, ok := configured[table].(string); ok && code != "" {
+		return code
+	}
+	return servicenow.DefaultCloseCodes[table]
+}
+
+// serviceNowClient returns the client of the instance, authenticated by the auth type of
+// the provider: OAuth tokens for oauth2, the email and token for basic, and the token as
+// a bearer token otherwise
+func (s *ServiceNowPluginAdapter) serviceNowClient() (*servicenow.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client != nil {
+		return s.client, nil
+	}
+	if err := s.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	var token string
+	if s.authMgr != nil {
+		token, _ = s.authMgr.GetCredentials("servicenow")
+	}
+	var authType plugin.AuthType
+	var email, apiKey string
+	if s.config.Auth != nil {
+		authType = s.config.Auth.Type
+		email = s.config.Auth.CustomFields["email"]
+		apiKey = s.config.Auth.CustomFields["api_key"]
+	}
+
+	var credentials servicenow.Credentials
+	switch authType {
+	case plugin.AuthTypeOAuth2:
+		// The password grant authenticates as the user of email and api_key
+		credentials = servicenow.Credentials{
+			ClientID:     s.setting("client_id"),
+			ClientSecret: token,
+			Username:     email,
+			Password:     apiKey,
+		}
+	case plugin.AuthTypeBasic:
+		if strings.HasPrefix(token, "Basic ") {
+			credentials.Authorization = token
+			break
+		}
+		if token == "" {
+			token = apiKey
+		}
+		credentials.Authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(email+":"+token))
+	default:
+		credentials.Authorization = "Bearer " + token
+	}
+	if token == "" && apiKey == "" {
+		return nil, fmt.Errorf("no ServiceNow credentials; set ZEN_SERVICENOW_TOKEN or run zen auth servicenow")
+	}
+
+	s.client = servicenow.NewClient(s.config.BaseURL, credentials, s.config.Timeout, s.logger)
+	return s.client, nil
+}
+
+func (s *ServiceNowPluginAdapter) dataMapper() *mapping.DataMapper {
+	if s.mapper == nil {
+		s.mapper = mapping.NewDataMapper(s.logger)
+	}
+	return s.mapper
+}
+
+// record returns the table and record of a task by externalID, which FetchTask describes
+func (s *ServiceNowPluginAdapter) record(ctx context.Context, externalID string) (string, servicenow.Record, error) {
+	client, err := s.serviceNowClient()
+	if err != nil {
+		return "", nil, err
+	}
+
+	id := externalID
+	table, ok := servicenow.TableOf(id)
+	if prefix, rest, found := strings.Cut(externalID, ":"); found {
+		table, id, ok = prefix, rest, true
+	}
+	if serviceNowSysID.MatchString(id) {
+		if !ok {
+			table = s.defaultTable()
+		}
+		record, err := client.Get(ctx, table, id)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to fetch ServiceNow record %s: %w", externalID, err)
+		}
+		return table, record, nil
+	}
+	if !ok {
+		return "", nil, fmt.Errorf("%s is not a ServiceNow incident or change number, or a sys_id", externalID)
+	}
+
+	records, err := client.Query(ctx, table, "number="+id, 1)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch ServiceNow record %s: %w", externalID, err)
+	}
+	if len(records) == 0 {
+		return "", nil, fmt.Errorf("no ServiceNow %s has number %s", table, id)
+	}
+	return table, records[0], nil
+}
+
+// recordTaskData maps a record to a task, keeping the record when opts ask for raw data
+func (s *ServiceNowPluginAdapter) recordTaskData(ctx context.Context, table string, record servicenow.Record, opts *plugin.FetchOptions) (*plugin.TaskData, error) {
+	task, err := s.mapRecord(ctx, table, record)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.IncludeRaw {
+		raw := map[string]interface{}{"table": table}
+		for name, field := range record {
+			raw[name] = field.Value
+		}
+		task.RawData = raw
+	}
+	return task, nil
+}
+
+// mapRecord maps the fields of a record to a task through the field mapping. The mapper
+// reads each field as its value and display value, and the state also as the task
+// status it maps to.
+func (s *ServiceNowPluginAdapter) mapRecord(ctx context.Context, table string, record servicenow.Record) (*plugin.TaskData, error) {
+	source := map[string]interface{}{}
+	for name, field := range record {
+		source[name] = map[string]interface{}{"value": field.Value, "display_value": field.DisplayValue}
+	}
+	if state, ok := source["state"].(map[string]interface{}); ok {
+		if status, ok := s.states(table).Statuses[record.Value("state")]; ok {
+			state["status"] = status
+		}
+	}
+
+	fields, err := s.dataMapper().MapFields(ctx, source, s.GetFieldMapping(), plugin.SyncDirectionPull)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map ServiceNow record %s: %w", record.Value("number"), err)
+	}
+
+	task := mappedTaskData(fields)
+	if task.ID == "" {
+		task.ID = record.Value("number")
+	}
+	task.ExternalID = record.Value("number")
+	if task.ExternalID == "" {
+		task.ExternalID = table + ":" + record.Value("sys_id")
+	}
+	task.ExternalURL = fmt.Sprintf("%s/%s.do?sys_id=%s", strings.TrimRight(s.config.BaseURL, "/"), table, record.Value("sys_id"))
+	if task.Type == "" {
+		task.Type = table
+	}
+	task.Created = record.Time("sys_created_on")
+	task.Updated = record.Time("sys_updated_on")
+	task.Metadata["servicenow_table"] = table
+	return task, nil
+}
+
+// recordFields maps a task to the fields of a record of a table through the field
+// mapping. The status is set as the state of the table it maps to, with a close code
+// and close notes when that state closes the record.
+func (s *ServiceNowPluginAdapter) recordFields(ctx context.Context, table string, taskData *plugin.TaskData) (map[string]string, error) {
+	fieldMapping := s.GetFieldMapping()
+
+	// The mapper maps from external fields to Zen fields, so pushes map the other way
+	// round, with the transforms applied to the field a task field is pushed to
+	reversed := &plugin.FieldMappingConfig{}
+	for _, m := range fieldMapping.Mappings {
+		name, _, _ := strings.Cut(m.ExternalField, ".")
+		if serviceNowUnwritableFields[name] || m.ZenField == "status" {
+			continue
+		}
+		reversed.Mappings = append(reversed.Mappings, plugin.FieldMapping{
+			ZenField:      name,
+			ExternalField: m.ZenField,
+			Direction:     m.Direction,
+		})
+		for _, transform := range fieldMapping.Transforms {
+			if transform.Field == m.ZenField {
+				transform.Field = name
+				reversed.Transforms = append(reversed.Transforms, transform)
+			}
+		}
+	}
+
+	values, err := s.dataMapper().MapFields(ctx, zenFields(taskData), reversed, plugin.SyncDirectionPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map task %s to ServiceNow fields: %w", taskData.ID, err)
+	}
+	fields := map[string]string{}
+	for name, value := range values {
+		if list, ok := value.([]string); ok {
+			fields[name] = strings.Join(list, ", ")
+			continue
+		}
+		fields[name] = fmt.Sprintf("%v", value)
+	}
+
+	if taskData.Status == "" {
+		return fields, nil
+	}
+	state, ok := s.states(table).States[taskData.Status]
+	if !ok {
+		return nil, fmt.Errorf("no state of ServiceNow %s maps to status %s", table, taskData.Status)
+	}
+	fields["state"] = state
+	for _, closing := range servicenow.ClosingStates[table] {
+		if state != closing {
+			continue
+		}
+		if code := s.closeCode(table); code != "" {
+			fields["close_code"] = code
+		}
+		notes, _ := taskData.Metadata["close_notes"].(string)
+		if notes == "" {
+			notes = "Resolved in Zen task " + taskData.ID
+		}
+		fields["close_notes"] = notes
+	}
+	return fields, nil
+}
+
+// encodedQuery returns the encoded query of a search of a table. Filters are matched
+// against the stored value of the fields they map to, other than status, which is
+// matched against the states that map to it.
+func (s *ServiceNowPluginAdapter) encodedQuery(table string, query *plugin.SearchQuery) (string, error) {
+	var conditions []string
+	if query != nil && query.Query != "" {
+		name, _, _ := strings.Cut(externalField(s.GetFieldMapping(), "title", "short_description"), ".")
+		conditions = append(conditions, name+"LIKE"+serviceNowQueryValue(query.Query))
+	}
+
+	var fields []string
+	if query != nil {
+		for field := range query.Filters {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		value := fmt.Sprintf("%v", query.Filters[field])
+		if field == "status" {
+			var states []string
+			for state, status := range s.states(table).Statuses {
+				if status == value {
+					states = append(states, state)
+				}
+			}
+			if len(states) == 0 {
+				return "", fmt.Errorf("no state of ServiceNow %s maps to status %s", table, value)
+			}
+			sort.Strings(states)
+			conditions = append(conditions, "stateIN"+strings.Join(states, ","))
+			continue
+		}
+		name, _, _ := strings.Cut(externalField(s.GetFieldMapping(), field, field), ".")
+		conditions = append(conditions, name+"="+serviceNowQueryValue(value))
+	}
+
+	conditions = append(conditions, "ORDERBYDESCsys_updated_on")
+	return strings.Join(conditions, "^"), nil
+}
+
+// serviceNowQueryValue escapes the carets of a value of an encoded query, which would
+// otherwise separate conditions
+func serviceNowQueryValue(value string) string {
+	return strings.ReplaceAll(value, "^", "^^")
+}
@@ -0,0 +1,239 @@
+package factory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serviceNowTestSysID = "46d44a5ea9fe198100f8f24ab28ba2c9"
+
+// serviceNowTestIncident is incident INC0010023, which is in progress
+const serviceNowTestIncident = `{
+	"sys_id": {"value": "46d44a5ea9fe198100f8f24ab28ba2c9", "display_value": "46d44a5ea9fe198100f8f24ab28ba2c9"},
+	"number": {"value": "INC0010023", "display_value": "INC0010023"},
+	"short_description": {"value": "Checkout returns 502", "display_value": "Checkout returns 502"},
+	"description": {"value": "Since the 14:00 deploy", "display_value": "Since the 14:00 deploy"},
+	"state": {"value": "2", "display_value": "In Progress"},
+	"priority": {"value": "2", "display_value": "2 - High"},
+	"assigned_to": {"value": "5137153cc611227c000bbd1bd8cd2005", "display_value": "Ada Lovelace"},
+	"assignment_group": {"value": "8a4dde73c6112278017a6a4baf547aa7", "display_value": "Payments"},
+	"category": {"value": "software", "display_value": "Software"},
+	"sys_created_on": {"value": "2026-10-14 14:05:00", "display_value": "2026-10-14 07:05:00"},
+	"sys_updated_on": {"value": "2026-10-14 16:30:00", "display_value": "2026-10-14 09:30:00"}
+}`
+
+// serviceNowServer answers the requests for incident INC0010023, recording the queries
+// of table reads and the bodies of the requests that change records
+type serviceNowServer struct {
+	*httptest.Server
+	queries []string
+	writes  []map[string]string
+	uploads []string
+}
+
+func newServiceNowServer(t *testing.T) *serviceNowServer {
+	s := &serviceNowServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer snow_test", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/now/table/incident":
+			s.queries = append(s.queries, r.URL.Query().Get("sysparm_query"))
+			fmt.Fprintf(w, `{"result": [%s]}`, serviceNowTestIncident)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/now/table/incident/"+serviceNowTestSysID:
+			fmt.Fprintf(w, `{"result": %s}`, serviceNowTestIncident)
+		case (r.Method == http.MethodPatch && r.URL.Path == "/api/now/table/incident/"+serviceNowTestSysID),
+			(r.Method == http.MethodPost && r.URL.Path == "/api/now/table/change_request"):
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			s.writes = append(s.writes, body)
+			fmt.Fprintf(w, `{"result": %s}`, serviceNowTestIncident)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/now/attachment":
+			fmt.Fprint(w, `{"result": [{"sys_id": "att1", "file_name": "trace.log", "content_type": "text/plain", "size_bytes": "11", "download_link": "https://acme.service-now.com/api/now/attachment/att1/file"}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/now/attachment/file":
+			body, _ := io.ReadAll(r.Body)
+			s.uploads = append(s.uploads, r.URL.Query().Get("table_sys_id")+" "+r.Header.Get("Content-Type")+" "+string(body))
+			fmt.Fprint(w, `{"result": {"sys_id": "att2", "file_name": "notes.txt"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/now/attachment/att1/file":
+			fmt.Fprint(w, "stack trace")
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func newServiceNowAdapter(baseURL string, fieldMapping map[string]string, settings map[string]interface{}) *ServiceNowPluginAdapter {
+	authMgr := &mockAuthManager{}
+	authMgr.On("GetCredentials", "servicenow").Return("snow_test", nil)
+	if settings == nil {
+		settings = map[string]interface{}{}
+	}
+	config := &plugin.PluginConfig{
+		Name:     "servicenow",
+		BaseURL:  baseURL,
+		Timeout:  5 * time.Second,
+		Auth:     &plugin.AuthConfig{Type: plugin.AuthTypeBearer},
+		Settings: settings,
+	}
+	if fieldMapping != nil {
+		config.FieldMapping = fieldMappingConfig(fieldMapping)
+	}
+	return &ServiceNowPluginAdapter{config: config, logger: logging.NewBasic(), authMgr: authMgr}
+}
+
+func TestServiceNowPluginAdapter_FetchTask(t *testing.T) {
+	server := newServiceNowServer(t)
+	defer server.Close()
+	adapter := newServiceNowAdapter(server.URL, map[string]string{"category": "category"}, nil)
+
+	task, err := adapter.FetchTask(context.Background(), "INC0010023", &plugin.FetchOptions{IncludeRaw: true})
+	require.NoError(t, err)
+	assert.Equal(t, "INC0010023", task.ID)
+	assert.Equal(t, "INC0010023", task.ExternalID)
+	assert.Equal(t, "Checkout returns 502", task.Title)
+	assert.Equal(t, "Since the 14:00 deploy", task.Description)
+	assert.Equal(t, "in_progress", task.Status)
+	assert.Equal(t, "P1", task.Priority)
+	assert.Equal(t, "Ada Lovelace", task.Assignee)
+	assert.Equal(t, "Payments", task.Team)
+	assert.Equal(t, "incident", task.Type)
+	assert.Equal(t, "Software", task.Metadata["category"], "fields named without a suffix are read as their display value")
+	assert.Equal(t, time.Date(2026, 10, 14, 16, 30, 0, 0, time.UTC), task.Updated)
+	assert.Equal(t, server.URL+"/incident.do?sys_id="+serviceNowTestSysID, task.ExternalURL)
+	assert.Equal(t, serviceNowTestSysID, task.RawData["sys_id"])
+	assert.Equal(t, []string{"number=INC0010023"}, server.queries)
+
+	// Records are also found by sys_id
+	task, err = adapter.FetchTask(context.Background(), "incident:"+serviceNowTestSysID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "INC0010023", task.ID)
+	assert.Len(t, server.queries, 1)
+
+	_, err = adapter.FetchTask(context.Background(), "login-bug", nil)
+	assert.EqualError(t, err, "login-bug is not a ServiceNow incident or change number, or a sys_id")
+}
+
+func TestServiceNowPluginAdapter_UpdateTask(t *testing.T) {
+	server := newServiceNowServer(t)
+	defer server.Close()
+	adapter := newServiceNowAdapter(server.URL, nil, nil)
+
+	_, err := adapter.UpdateTask(context.Background(), "INC0010023", &plugin.TaskData{
+		ID:       "INC0010023",
+		Title:    "Checkout returns 502",
+		Status:   "in_progress",
+		Priority: "P0",
+		Assignee: "Grace Hopper",
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, server.writes, 1)
+	assert.Equal(t, map[string]string{"short_description": "Checkout returns 502", "state": "2"}, server.writes[0],
+		"priority is computed and references are set by sys_id, so neither is written")
+
+	// Resolving an incident gives it a close code and close notes
+	adapter = newServiceNowAdapter(server.URL, nil, map[string]interface{}{
+		"close_codes": map[string]interface{}{"incident": "Workaround provided"},
+	})
+	_, err = adapter.UpdateTask(context.Background(), "INC0010023", &plugin.TaskData{
+		ID:       "PAY-12",
+		Status:   "completed",
+		Metadata: map[string]interface{}{"close_notes": "Rolled back the deploy"},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, server.writes, 2)
+	assert.Equal(t, map[string]string{
+		"state":       "6",
+		"close_code":  "Workaround provided",
+		"close_notes": "Rolled back the deploy",
+	}, server.writes[1])
+
+	_, err = adapter.UpdateTask(context.Background(), "INC0010023", &plugin.TaskData{Status: "shipped"}, nil)
+	assert.EqualError(t, err, "no state of ServiceNow incident maps to status shipped")
+}
+
+func TestServiceNowPluginAdapter_CreateTask(t *testing.T) {
+	server := newServiceNowServer(t)
+	defer server.Close()
+	adapter := newServiceNowAdapter(server.URL, nil, map[string]interface{}{
+		"table": "change_request",
+		"states": map[string]interface{}{
+			"change_request": map[string]interface{}{"-5": "proposed", "-1": "in_progress", "3": "completed"},
+		},
+	})
+
+	_, err := adapter.CreateTask(context.Background(), &plugin.TaskData{ID: "OPS-7", Title: "Rotate TLS certificates", Status: "completed"}, nil)
+	require.NoError(t, err)
+	require.Len(t, server.writes, 1)
+	assert.Equal(t, map[string]string{
+		"short_description": "Rotate TLS certificates",
+		"state":             "3",
+		"close_code":        "successful",
+		"close_notes":       "Resolved in Zen task OPS-7",
+	}, server.writes[0])
+}
+
+func TestServiceNowPluginAdapter_SearchTasks(t *testing.T) {
+	server := newServiceNowServer(t)
+	defer server.Close()
+	adapter := newServiceNowAdapter(server.URL, nil, nil)
+
+	tasks, err := adapter.SearchTasks(context.Background(), &plugin.SearchQuery{
+		Query:   "502^",
+		Filters: map[string]interface{}{"status": "completed", "team": "Payments"},
+	}, &plugin.SearchOptions{MaxResults: 10})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "INC0010023", tasks[0].ID)
+	assert.Equal(t, []string{"short_descriptionLIKE502^^^stateIN6,7^assignment_group=Payments^ORDERBYDESCsys_updated_on"}, server.queries)
+}
+
+func TestServiceNowPluginAdapter_Attachments(t *testing.T) {
+	server := newServiceNowServer(t)
+	defer server.Close()
+	adapter := newServiceNowAdapter(server.URL, nil, nil)
+
+	attachments, err := adapter.ListAttachments(context.Background(), "INC0010023")
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "trace.log", attachments[0].Name)
+	assert.Equal(t, int64(11), attachments[0].Size)
+
+	uploaded, err := adapter.UploadAttachment(context.Background(), "INC0010023", "notes.txt", strings.NewReader("rolled back"))
+	require.NoError(t, err)
+	assert.Equal(t, "att2", uploaded.ID)
+	assert.Equal(t, []string{serviceNowTestSysID + " text/plain; charset=utf-8 rolled back"}, server.uploads)
+
+	var content bytes.Buffer
+	require.NoError(t, adapter.DownloadAttachment(context.Background(), attachments[0], &content))
+	assert.Equal(t, "stack trace", content.String())
+}
+
+func TestServiceNowPluginAdapter_Validate(t *testing.T) {
+	adapter := newServiceNowAdapter("https://acme.service-now.com", nil, nil)
+	assert.NoError(t, adapter.Validate(context.Background()))
+
+	adapter.config.Auth.Type = plugin.AuthTypeOAuth2
+	assert.ErrorContains(t, adapter.Validate(context.Background()), "settings.client_id")
+
+	adapter.config.BaseURL = ""
+	assert.ErrorContains(t, adapter.Validate(context.Background()), "settings.instance")
+
+	assert.True(t, adapter.SupportsOperation(plugin.OperationTypeSearch))
+	assert.False(t, adapter.SupportsOperation(plugin.OperationTypeDelete))
+	assert.Error(t, adapter.DeleteTask(context.Background(), "INC0010023", nil))
+}
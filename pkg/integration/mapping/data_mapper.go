@@ -224,6 +224,10 @@ func (dm *DataMapper) GetDefaultMapping(provider string) *plugin.FieldMappingCon
 		return dm.getGitHubDefaultMapping()
 	case "linear":
 		return dm.getLinearDefaultMapping()
+	case "notion":
+		return dm.getNotionDefaultMapping()
+	case "servicenow":
+		return dm.getServiceNowDefaultMapping()
 	default:
 		return dm.getGenericDefaultMapping()
 	}
@@ -486,6 +490,76 @@ func (dm *DataMapper) getLinearDefaultMapping() *plugin.FieldMappingConfig {
 	}
 }
 
+// getNotionDefaultMapping maps the properties of a Notion database by name, or by type
+// with "type:<type>" for the properties a database has one of. The description is not
+// mapped, as it is the content of the page.
+func (dm *DataMapper) getNotionDefaultMapping() *plugin.FieldMappingConfig {
+	return &plugin.FieldMappingConfig{
+		Mappings: []plugin.FieldMapping{
+			{ZenField: "id", ExternalField: "type:unique_id", Direction: plugin.SyncDirectionBidirectional},
+			{ZenField: "title", ExternalField: "type:title", Direction: plugin.SyncDirectionBidirectional, Required: true},
+			{ZenField: "status", ExternalField: "type:status", Direction: plugin.SyncDirectionBidirectional},
+			{ZenField: "priority", ExternalField: "Priority", Direction: plugin.SyncDirectionBidirectional},
+			{ZenField: "type", ExternalField: "Type", Direction: plugin.SyncDirectionBidirectional},
+			{ZenField: "assignee", ExternalField: "type:people", Direction: plugin.SyncDirectionBidirectional},
+			{ZenField: "labels", ExternalField: "type:multi_select", Direction: plugin.SyncDirectionBidirectional},
+			{ZenField: "due_date", ExternalField: "type:date", Direction: plugin.SyncDirectionBidirectional},
+		},
+		Transforms: []plugin.FieldTransform{
+			{
+				Field:     "status",
+				Type:      plugin.TransformTypeMap,
+				Direction: plugin.SyncDirectionPull,
+				Config: map[string]interface{}{
+					"mappings": map[string]interface{}{
+						"Not started": "proposed", "In progress": "in_progress", "Done": "completed",
+					},
+				},
+			},
+			{
+				Field:     "status",
+				Type:      plugin.TransformTypeMap,
+				Direction: plugin.SyncDirectionPush,
+				Config: map[string]interface{}{
+					"mappings": map[string]interface{}{
+						"proposed": "Not started", "in_progress": "In progress", "completed": "Done",
+					},
+				},
+			},
+		},
+	}
+}
+
+// getServiceNowDefaultMapping maps the fields of incidents and change requests, which are
+// read with their stored value and display value as "<field>.value" and
+// "<field>.display_value". The task status is "state.status", as the status a state maps
+// to depends on the table of the record.
+func (dm *DataMapper) getServiceNowDefaultMapping() *plugin.FieldMappingConfig {
+	return &plugin.FieldMappingConfig{
+		Mappings: []plugin.FieldMapping{
+			{ZenField: "id", ExternalField: "number.value", Direction: plugin.SyncDirectionPull, Required: true},
+			{ZenField: "title", ExternalField: "short_description.value", Direction: plugin.SyncDirectionBidirectional, Required: true},
+			{ZenField: "description", ExternalField: "description.value", Direction: plugin.SyncDirectionBidirectional},
+			{ZenField: "status", ExternalField: "state.status", Direction: plugin.SyncDirectionBidirectional},
+			{ZenField: "priority", ExternalField: "priority.value", Direction: plugin.SyncDirectionPull},
+			{ZenField: "assignee", ExternalField: "assigned_to.display_value", Direction: plugin.SyncDirectionPull},
+			{ZenField: "team", ExternalField: "assignment_group.display_value", Direction: plugin.SyncDirectionPull},
+		},
+		Transforms: []plugin.FieldTransform{
+			{
+				Field:     "priority",
+				Type:      plugin.TransformTypeMap,
+				Direction: plugin.SyncDirectionPull,
+				Config: map[string]interface{}{
+					"mappings": map[string]interface{}{
+						"1": "P0", "2": "P1", "3": "P2", "4": "P3", "5": "P3",
+					},
+				},
+			},
+		},
+	}
+}
+
 func (dm *DataMapper) getGenericDefaultMapping() *plugin.FieldMappingConfig {
 	return &plugin.FieldMappingConfig{
 		Mappings: []plugin.FieldMapping{
@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -40,6 +41,58 @@ type IntegrationPluginInterface interface {
 	SupportsOperation(operation OperationType) bool
 }
 
+// BatchFetcher is implemented by plugins that can fetch many tasks in one request.
+// Callers check for it with a type assertion and fall back to FetchTask per task.
+type BatchFetcher interface {
+	// FetchTasks returns the tasks that exist, keyed by external ID. IDs that are not
+	// found are left out of the result rather than reported as errors.
+	FetchTasks(ctx context.Context, externalIDs []string, opts *FetchOptions) (map[string]*TaskData, error)
+}
+
+// SprintFetcher is implemented by plugins that can report the scope and progress of a
+// sprint. Callers check for it with a type assertion.
+type SprintFetcher interface {
+	// FetchSprint returns the sprint with the given name, or the active sprint when the
+	// name is empty
+	FetchSprint(ctx context.Context, name string) (*Sprint, error)
+}
+
+// Exporter is implemented by plugins that can export every task matching a query with
+// its history, as migrating off the external system does. Callers check for it with a
+// type assertion.
+type Exporter interface {
+	// ExportTasks returns the tasks matching a query in the query language of the
+	// system, such as JQL, reading every page of results. A positive limit stops the
+	// export after that many tasks.
+	ExportTasks(ctx context.Context, query string, limit int) (*Export, error)
+}
+
+// AttachmentTransferer is implemented by plugins that can list, upload, and download the
+// files attached to tasks. Callers check for it with a type assertion.
+type AttachmentTransferer interface {
+	// ListAttachments returns the files attached to a task
+	ListAttachments(ctx context.Context, externalID string) ([]*Attachment, error)
+
+	// UploadAttachment attaches a file named name to a task
+	UploadAttachment(ctx context.Context, externalID, name string, content io.Reader) (*Attachment, error)
+
+	// DownloadAttachment writes the content of an attachment to w
+	DownloadAttachment(ctx context.Context, attachment *Attachment, w io.Writer) error
+}
+
+// ProjectFieldSyncer is implemented by plugins that can read and write the fields of the
+// item a task has on a project board, such as a GitHub Projects board. Callers check for
+// it with a type assertion.
+type ProjectFieldSyncer interface {
+	// FetchProjectFields returns the board fields of a task, or nil when the task is not
+	// on the board
+	FetchProjectFields(ctx context.Context, externalID string) (*ProjectFields, error)
+
+	// UpdateProjectFields sets the board fields of a task, adding the task to the board
+	// when it is not on it. Empty fields are left as they are.
+	UpdateProjectFields(ctx context.Context, externalID string, fields *ProjectFields) (*ProjectFields, error)
+}
+
 // LifecycleInterface defines plugin lifecycle methods
 type LifecycleInterface interface {
 	Initialize(ctx context.Context, config *PluginConfig) error
@@ -119,6 +172,101 @@ type TaskData struct {
 	Checksum string                 `json:"checksum" yaml:"checksum"`
 }
 
+// Sprint represents a time-boxed iteration and the items in its scope
+type Sprint struct {
+	ID    string `json:"id" yaml:"id"`
+	Name  string `json:"name" yaml:"name"`
+	State string `json:"state" yaml:"state"` // future, active, or closed
+	Goal  string `json:"goal,omitempty" yaml:"goal,omitempty"`
+
+	Start     time.Time  `json:"start" yaml:"start"`
+	End       time.Time  `json:"end" yaml:"end"`
+	Completed *time.Time `json:"completed,omitempty" yaml:"completed,omitempty"`
+
+	Items []SprintItem `json:"items" yaml:"items"`
+}
+
+// SprintItem is an item in the scope of a sprint
+type SprintItem struct {
+	ExternalID string `json:"external_id" yaml:"external_id"`
+	Title      string `json:"title" yaml:"title"`
+
+	// Points is the estimate of the item, or nil when it is not estimated
+	Points *float64 `json:"points,omitempty" yaml:"points,omitempty"`
+
+	// Added is when the item joined the sprint scope, or zero when it was in the
+	// scope from the start
+	Added time.Time `json:"added,omitempty" yaml:"added,omitempty"`
+
+	// Completed is when the item was done, or nil when it is not done
+	Completed *time.Time `json:"completed,omitempty" yaml:"completed,omitempty"`
+}
+
+// Export is the tasks of a query in an external system, with their history
+type Export struct {
+	Items []*ExportItem `json:"items" yaml:"items"`
+
+	// FieldNames are the names of the fields of items by ID, such as "Story Points"
+	// for a custom field
+	FieldNames map[string]string `json:"field_names,omitempty" yaml:"field_names,omitempty"`
+}
+
+// ExportItem is a task of an export, with what the system records of it that task data
+// does not hold
+type ExportItem struct {
+	Task    *TaskData `json:"task" yaml:"task"`
+	Project string    `json:"project,omitempty" yaml:"project,omitempty"`
+
+	// Status and Type are the status and type of the task in the system, before they
+	// are mapped to Zen's. StatusCategory groups statuses as new, indeterminate, or
+	// done, where the system does.
+	Status         string `json:"status" yaml:"status"`
+	StatusCategory string `json:"status_category,omitempty" yaml:"status_category,omitempty"`
+	Type           string `json:"type" yaml:"type"`
+
+	Reporter string `json:"reporter,omitempty" yaml:"reporter,omitempty"`
+	Comments int    `json:"comments" yaml:"comments"`
+
+	// Fields are the fields of the task as the system returns them, by ID
+	Fields map[string]interface{} `json:"fields,omitempty" yaml:"fields,omitempty"`
+
+	// History is the changes made to the task, oldest first
+	History []Change `json:"history,omitempty" yaml:"history,omitempty"`
+}
+
+// Change is a change to a field of a task in an external system
+type Change struct {
+	At     time.Time `json:"at" yaml:"at"`
+	Author string    `json:"author,omitempty" yaml:"author,omitempty"`
+	Field  string    `json:"field" yaml:"field"`
+	From   string    `json:"from,omitempty" yaml:"from,omitempty"`
+	To     string    `json:"to,omitempty" yaml:"to,omitempty"`
+}
+
+// Attachment is a file attached to a task in an external system
+type Attachment struct {
+	ID       string    `json:"id" yaml:"id"`
+	Name     string    `json:"name" yaml:"name"`
+	URL      string    `json:"url" yaml:"url"`
+	MimeType string    `json:"mime_type,omitempty" yaml:"mime_type,omitempty"`
+	Size     int64     `json:"size,omitempty" yaml:"size,omitempty"`
+	Created  time.Time `json:"created,omitempty" yaml:"created,omitempty"`
+}
+
+// ProjectFields are the fields of the item a task has on a project board
+type ProjectFields struct {
+	// Project identifies the board, such as "owner/number" for GitHub Projects
+	Project string `json:"project" yaml:"project"`
+
+	// Status is the option of the board's status field
+	Status string `json:"status,omitempty" yaml:"status,omitempty"`
+
+	// Iteration is the title of the iteration the item is planned in, with its dates
+	Iteration      string    `json:"iteration,omitempty" yaml:"iteration,omitempty"`
+	IterationStart time.Time `json:"iteration_start,omitempty" yaml:"iteration_start,omitempty"`
+	IterationEnd   time.Time `json:"iteration_end,omitempty" yaml:"iteration_end,omitempty"`
+}
+
 // AuthConfig contains authentication configuration
 type AuthConfig struct {
 	Type           AuthType          `json:"type" yaml:"type" validate:"required"`
@@ -0,0 +1,54 @@
+package iostreams
+
+// Plain text labels that replace the symbols of status messages in accessible mode
+const (
+	LabelSuccess = "Success:"
+	LabelFailure = "Error:"
+	LabelAlert   = "Warning:"
+	LabelInfo    = "Info:"
+	LabelNeutral = "Note:"
+	LabelChange  = "Change:"
+)
+
+// SetAccessible turns accessible mode on or off. Accessible mode suits screen readers
+// and simple terminals: status messages start with plain text labels instead of
+// symbols, and progress is reported in discrete lines without spinners or cursor
+// movement.
+func (s *IOStreams) SetAccessible(enabled bool) {
+	s.accessible = enabled
+}
+
+// IsAccessible reports whether accessible mode is on
+func (s *IOStreams) IsAccessible() bool {
+	return s.accessible
+}
+
+// SuccessIcon returns the prefix of success messages
+func (s *IOStreams) SuccessIcon() string {
+	return s.ColorSuccess(s.symbol(SymbolSuccess, LabelSuccess))
+}
+
+// FailureIcon returns the prefix of failure messages
+func (s *IOStreams) FailureIcon() string {
+	return s.ColorError(s.symbol(SymbolFailure, LabelFailure))
+}
+
+// WarningIcon returns the prefix of warnings
+func (s *IOStreams) WarningIcon() string {
+	return s.ColorWarning(s.symbol(SymbolAlert, LabelAlert))
+}
+
+// InfoIcon returns the prefix of informational messages
+func (s *IOStreams) InfoIcon() string {
+	return s.ColorInfo(s.symbol(SymbolInfo, LabelInfo))
+}
+
+// NeutralIcon returns the prefix of neutral messages, such as skipped steps
+func (s *IOStreams) NeutralIcon() string {
+	return s.ColorNeutral(s.symbol(SymbolNeutral, LabelNeutral))
+}
+
+// ChangeIcon returns the prefix of messages about changes
+func (s *IOStreams) ChangeIcon() string {
+	return s.ColorWarning(s.symbol(SymbolChange, LabelChange))
+}
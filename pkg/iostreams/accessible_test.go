@@ -0,0 +1,25 @@
+package iostreams
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessibleIcons(t *testing.T) {
+	streams := Test()
+	assert.Equal(t, SymbolSuccess, streams.SuccessIcon())
+	assert.Equal(t, SymbolAlert+" careful", streams.FormatWarning("careful"))
+
+	streams.SetAccessible(true)
+	assert.True(t, streams.IsAccessible())
+	assert.Equal(t, "Success:", streams.SuccessIcon())
+	assert.Equal(t, "Error:", streams.FailureIcon())
+	assert.Equal(t, "Warning:", streams.WarningIcon())
+	assert.Equal(t, "Info:", streams.InfoIcon())
+	assert.Equal(t, "Note:", streams.NeutralIcon())
+	assert.Equal(t, "Warning: careful", streams.FormatWarning("careful"))
+
+	streams.SetColorEnabled(true)
+	assert.Equal(t, ColorGreen+"Success:"+ColorReset, streams.SuccessIcon())
+}
@@ -0,0 +1,104 @@
+package iostreams
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ciEnvVars lists environment variables set by CI systems, any of which marks a run as
+// non-interactive
+var ciEnvVars = []string{
+	"CI",                     // Most CI systems, including GitHub Actions, GitLab, CircleCI and Buildkite
+	"GITHUB_ACTIONS",         // GitHub Actions
+	"GITLAB_CI",              // GitLab CI/CD
+	"BUILDKITE",              // Buildkite
+	"CIRCLECI",               // CircleCI
+	"JENKINS_URL",            // Jenkins
+	"TF_BUILD",               // Azure Pipelines
+	"TEAMCITY_VERSION",       // TeamCity
+	"BITBUCKET_BUILD_NUMBER", // Bitbucket Pipelines
+	"CODEBUILD_BUILD_ID",     // AWS CodeBuild
+}
+
+// IsCI reports whether zen is running in a CI system, from the environment variables
+// CI systems set. A variable set to "false" or "0" does not count.
+func IsCI() bool {
+	for _, name := range ciEnvVars {
+		value := strings.TrimSpace(os.Getenv(name))
+		if value != "" && value != "false" && value != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGitHubActions reports whether zen is running in a GitHub Actions workflow
+func IsGitHubActions() bool {
+	return os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// SetNonInteractive turns non-interactive mode on or off. Non-interactive mode never
+// prompts and draws no color, spinners, progress bars or pager, so output suits CI logs.
+// Color stays on when FORCE_COLOR or CLICOLOR_FORCE asks for it.
+func (s *IOStreams) SetNonInteractive(enabled bool) {
+	s.nonInteractive = enabled
+	if enabled {
+		s.SetNeverPrompt(true)
+		s.SetColorEnabled(ColorForced())
+		s.SetProgressEnabled(false)
+		s.SetPagerEnabled(false)
+	}
+}
+
+// IsNonInteractive reports whether non-interactive mode is on
+func (s *IOStreams) IsNonInteractive() bool {
+	return s.nonInteractive
+}
+
+// Annotation levels for CI log annotations
+const (
+	AnnotationError   = "error"
+	AnnotationWarning = "warning"
+	AnnotationNotice  = "notice"
+)
+
+// SetAnnotationsEnabled turns GitHub Actions workflow commands for log groups and
+// annotations on or off
+func (s *IOStreams) SetAnnotationsEnabled(enabled bool) {
+	s.annotationsEnabled = enabled
+}
+
+// AnnotationsEnabled reports whether log groups and annotations are written
+func (s *IOStreams) AnnotationsEnabled() bool {
+	return s.annotationsEnabled
+}
+
+// StartGroup starts a collapsible group in the CI log; output until EndGroup is folded
+// under title. It does nothing unless annotations are enabled.
+func (s *IOStreams) StartGroup(title string) {
+	if s.annotationsEnabled {
+		fmt.Fprintf(s.ErrOut, "::group::%s\n", escapeWorkflowCommand(title))
+	}
+}
+
+// EndGroup ends the group started by StartGroup
+func (s *IOStreams) EndGroup() {
+	if s.annotationsEnabled {
+		fmt.Fprintln(s.ErrOut, "::endgroup::")
+	}
+}
+
+// Annotate adds an annotation at level (AnnotationError, AnnotationWarning or
+// AnnotationNotice) to the CI run summary. It does nothing unless annotations are
+// enabled.
+func (s *IOStreams) Annotate(level, message string) {
+	if s.annotationsEnabled {
+		fmt.Fprintf(s.ErrOut, "::%s::%s\n", level, escapeWorkflowCommand(message))
+	}
+}
+
+// escapeWorkflowCommand escapes the characters a workflow command message cannot contain
+func escapeWorkflowCommand(text string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(text)
+}
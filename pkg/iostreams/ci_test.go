@@ -0,0 +1,57 @@
+package iostreams
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCI(t *testing.T) {
+	for _, name := range ciEnvVars {
+		t.Setenv(name, "")
+	}
+	assert.False(t, IsCI())
+
+	t.Setenv("CI", "false")
+	assert.False(t, IsCI(), "CI=false is not a CI run")
+
+	t.Setenv("GITLAB_CI", "true")
+	assert.True(t, IsCI())
+	assert.False(t, IsGitHubActions())
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	assert.True(t, IsGitHubActions())
+}
+
+func TestSetNonInteractive(t *testing.T) {
+	clearColorEnv(t)
+	streams := Test()
+	streams.SetColorEnabled(true)
+
+	streams.SetNonInteractive(true)
+
+	assert.True(t, streams.IsNonInteractive())
+	assert.False(t, streams.CanPrompt())
+	assert.False(t, streams.ColorEnabled())
+	assert.False(t, streams.IsProgressEnabled())
+	assert.False(t, streams.IsPagerEnabled())
+}
+
+func TestAnnotations(t *testing.T) {
+	streams := Test()
+	stderr := streams.ErrOut.(*bytes.Buffer)
+
+	streams.StartGroup("zen task sync")
+	streams.Annotate(AnnotationError, "sync failed")
+	assert.Empty(t, stderr.String(), "nothing is written unless annotations are enabled")
+
+	streams.SetAnnotationsEnabled(true)
+	streams.StartGroup("zen task sync --all")
+	streams.Annotate(AnnotationWarning, "PROJ-1: 100% done\nbut held")
+	streams.EndGroup()
+
+	assert.Equal(t, "::group::zen task sync --all\n"+
+		"::warning::PROJ-1: 100%25 done%0Abut held\n"+
+		"::endgroup::\n", stderr.String())
+}
@@ -39,88 +39,66 @@ const (
 	SymbolFailure = "✗" // Failure
 	SymbolAlert   = "!" // Alert
 	SymbolChange  = "+" // Changes requested
+	SymbolInfo    = "ℹ" // Information
 )
 
 // ColorFunc represents a function that applies color to text
 type ColorFunc func(string) string
 
-// Color functions for semantic meaning (following design guide)
+// Color functions for semantic meaning (following design guide), drawn with the
+// colors of the theme
 func (s *IOStreams) ColorSuccess(text string) string {
-	if !s.ColorEnabled() {
-		return text
-	}
-	return ColorGreen + text + ColorReset
+	return s.paint(s.Theme().Success, text)
 }
 
 func (s *IOStreams) ColorError(text string) string {
-	if !s.ColorEnabled() {
-		return text
-	}
-	return ColorRed + text + ColorReset
+	return s.paint(s.Theme().Error, text)
 }
 
 func (s *IOStreams) ColorWarning(text string) string {
-	if !s.ColorEnabled() {
-		return text
-	}
-	return ColorYellow + text + ColorReset
+	return s.paint(s.Theme().Warning, text)
 }
 
 func (s *IOStreams) ColorInfo(text string) string {
-	if !s.ColorEnabled() {
-		return text
-	}
-	return ColorBlue + text + ColorReset
+	return s.paint(s.Theme().Info, text)
 }
 
 func (s *IOStreams) ColorBold(text string) string {
-	if !s.ColorEnabled() {
-		return text
-	}
-	return ColorBold + text + ColorReset
+	return s.paint(s.Theme().Bold, text)
 }
 
 func (s *IOStreams) ColorNeutral(text string) string {
-	if !s.ColorEnabled() {
-		return text
-	}
-	return ColorCyan + text + ColorReset
+	return s.paint(s.Theme().Neutral, text)
 }
 
-// Status formatting functions with symbols and colors
+// Status formatting functions with symbols and colors; accessible mode uses plain text
+// labels instead of symbols
 func (s *IOStreams) FormatSuccess(text string) string {
-	if s.ColorEnabled() {
-		return s.ColorSuccess(SymbolSuccess + " " + text)
-	}
-	return SymbolSuccess + " " + text
+	return s.ColorSuccess(s.symbol(SymbolSuccess, LabelSuccess) + " " + text)
 }
 
 func (s *IOStreams) FormatError(text string) string {
-	if s.ColorEnabled() {
-		return s.ColorError(SymbolFailure + " " + text)
-	}
-	return SymbolFailure + " " + text
+	return s.ColorError(s.symbol(SymbolFailure, LabelFailure) + " " + text)
 }
 
 func (s *IOStreams) FormatWarning(text string) string {
-	if s.ColorEnabled() {
-		return s.ColorWarning(SymbolAlert + " " + text)
-	}
-	return SymbolAlert + " " + text
+	return s.ColorWarning(s.symbol(SymbolAlert, LabelAlert) + " " + text)
 }
 
 func (s *IOStreams) FormatNeutral(text string) string {
-	if s.ColorEnabled() {
-		return s.ColorNeutral(SymbolNeutral + " " + text)
-	}
-	return SymbolNeutral + " " + text
+	return s.ColorNeutral(s.symbol(SymbolNeutral, LabelNeutral) + " " + text)
 }
 
 func (s *IOStreams) FormatChange(text string) string {
-	if s.ColorEnabled() {
-		return s.ColorWarning(SymbolChange + " " + text)
+	return s.ColorWarning(s.symbol(SymbolChange, LabelChange) + " " + text)
+}
+
+// symbol returns the symbol, or its label in accessible mode
+func (s *IOStreams) symbol(symbol, label string) string {
+	if s.accessible {
+		return label
 	}
-	return SymbolChange + " " + text
+	return symbol
 }
 
 // Header formatting for consistent typography
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package iostreams
+
+import "os"
+
+// enableVirtualTerminal reports whether f can render ANSI escape sequences, which
+// terminals outside Windows always can
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
@@ -0,0 +1,30 @@
+//go:build windows
+
+package iostreams
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal turns on ANSI escape sequence processing for the console f,
+// which Windows 10 and later consoles, including ConPTY sessions, support but do not
+// enable by default. It reports whether f can render escape sequences. Terminals such
+// as mintty that are connected through a pipe render them already.
+func enableVirtualTerminal(f *os.File) bool {
+	if isatty.IsCygwinTerminal(f.Fd()) {
+		return true
+	}
+
+	handle := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
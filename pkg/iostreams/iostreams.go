@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"os/exec"
 
 	"github.com/mattn/go-isatty"
 )
@@ -14,22 +15,57 @@ type IOStreams struct {
 	Out    io.Writer
 	ErrOut io.Writer
 
-	colorEnabled   bool
-	neverPrompt    bool
-	progressWriter io.Writer
+	colorEnabled    bool
+	theme           Theme
+	accessible      bool
+	neverPrompt     bool
+	nonInteractive  bool
+	progressWriter  io.Writer
+	progressSet     bool
+	progressEnabled bool
+
+	annotationsEnabled bool
+
+	pagerCommand  string
+	pagerDisabled bool
+	pagerProcess  *exec.Cmd
+	pagerOut      io.Writer
 }
 
 // System returns IOStreams connected to os.Stdin, os.Stdout, and os.Stderr
 func System() *IOStreams {
-	stdoutIsTTY := isatty.IsTerminal(os.Stdout.Fd())
-	stderrIsTTY := isatty.IsTerminal(os.Stderr.Fd())
+	stdoutIsTTY := isTerminal(os.Stdout)
+	stderrIsTTY := isTerminal(os.Stderr)
 
-	return &IOStreams{
+	s := &IOStreams{
 		In:           os.Stdin,
 		Out:          os.Stdout,
 		ErrOut:       os.Stderr,
-		colorEnabled: stdoutIsTTY && stderrIsTTY && os.Getenv("NO_COLOR") == "",
+		colorEnabled: ColorFromEnv(stdoutIsTTY && stderrIsTTY),
+		pagerCommand: PagerFromEnv(),
+	}
+
+	if (stdoutIsTTY && !enableVirtualTerminal(os.Stdout)) || (stderrIsTTY && !enableVirtualTerminal(os.Stderr)) {
+		s.disableEscapeSequences()
+	}
+
+	return s
+}
+
+// isTerminal reports whether f is a terminal, counting the Cygwin and MSYS2 terminals
+// that Windows connects through pipes
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// disableEscapeSequences turns off the output that relies on ANSI escape sequences, for
+// terminals that print them literally, such as legacy Windows consoles. Color stays on
+// when the environment forces it.
+func (s *IOStreams) disableEscapeSequences() {
+	if !ColorForced() {
+		s.colorEnabled = false
 	}
+	s.SetProgressEnabled(false)
 }
 
 // Test returns IOStreams suitable for testing
@@ -54,15 +90,19 @@ func (s *IOStreams) SetColorEnabled(enabled bool) {
 // IsStdinTTY returns true if stdin is a terminal
 func (s *IOStreams) IsStdinTTY() bool {
 	if f, ok := s.In.(*os.File); ok {
-		return isatty.IsTerminal(f.Fd())
+		return isTerminal(f)
 	}
 	return false
 }
 
 // IsStdoutTTY returns true if stdout is a terminal
 func (s *IOStreams) IsStdoutTTY() bool {
+	if s.pagerProcess != nil {
+		// Output is piped to a pager, which is only started for a terminal
+		return true
+	}
 	if f, ok := s.Out.(*os.File); ok {
-		return isatty.IsTerminal(f.Fd())
+		return isTerminal(f)
 	}
 	return false
 }
@@ -70,7 +110,7 @@ func (s *IOStreams) IsStdoutTTY() bool {
 // IsStderrTTY returns true if stderr is a terminal
 func (s *IOStreams) IsStderrTTY() bool {
 	if f, ok := s.ErrOut.(*os.File); ok {
-		return isatty.IsTerminal(f.Fd())
+		return isTerminal(f)
 	}
 	return false
 }
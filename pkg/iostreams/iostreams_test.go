@@ -44,6 +44,31 @@ func TestColorEnabled(t *testing.T) {
 	assert.False(t, streams.ColorEnabled())
 }
 
+func TestDisableEscapeSequences(t *testing.T) {
+	t.Run("legacy console", func(t *testing.T) {
+		clearColorEnv(t)
+		streams := Test()
+		streams.SetColorEnabled(true)
+
+		streams.disableEscapeSequences()
+
+		assert.False(t, streams.ColorEnabled())
+		assert.False(t, streams.IsProgressEnabled())
+	})
+
+	t.Run("forced color", func(t *testing.T) {
+		clearColorEnv(t)
+		t.Setenv("FORCE_COLOR", "1")
+		streams := Test()
+		streams.SetColorEnabled(true)
+
+		streams.disableEscapeSequences()
+
+		assert.True(t, streams.ColorEnabled())
+		assert.False(t, streams.IsProgressEnabled())
+	})
+}
+
 func TestCanPrompt(t *testing.T) {
 	t.Run("test streams cannot prompt", func(t *testing.T) {
 		streams := Test()
@@ -0,0 +1,131 @@
+package iostreams
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// pagerEnvVars lists the environment variables consulted for the pager command, in order of precedence
+var pagerEnvVars = []string{"ZEN_PAGER", "PAGER"}
+
+// defaultPager is used when no pager is configured and it is installed
+const defaultPager = "less"
+
+// PagerFromEnv returns the pager command configured in the environment.
+// Setting ZEN_PAGER or PAGER to an empty string or "cat" disables paging.
+func PagerFromEnv() string {
+	for _, name := range pagerEnvVars {
+		if value, ok := os.LookupEnv(name); ok {
+			return value
+		}
+	}
+	if _, err := exec.LookPath(defaultPager); err == nil {
+		return defaultPager
+	}
+	return ""
+}
+
+// SetPager sets the pager command used by StartPager
+func (s *IOStreams) SetPager(cmd string) {
+	s.pagerCommand = cmd
+}
+
+// GetPager returns the pager command used by StartPager
+func (s *IOStreams) GetPager() string {
+	return s.pagerCommand
+}
+
+// SetPagerEnabled enables or disables the pager, e.g. for --no-pager
+func (s *IOStreams) SetPagerEnabled(enabled bool) {
+	s.pagerDisabled = !enabled
+}
+
+// IsPagerEnabled reports whether StartPager will start a pager process.
+// A pager is only used when a pager command is configured and stdout is a terminal.
+func (s *IOStreams) IsPagerEnabled() bool {
+	if s.pagerDisabled || s.pagerProcess != nil {
+		return false
+	}
+	pager := strings.TrimSpace(s.pagerCommand)
+	if pager == "" || pager == "cat" {
+		return false
+	}
+	return s.IsStdoutTTY()
+}
+
+// StartPager pipes stdout through the configured pager until StopPager is called.
+// It does nothing when the pager is disabled, so commands can call it unconditionally.
+func (s *IOStreams) StartPager() error {
+	if !s.IsPagerEnabled() {
+		return nil
+	}
+
+	args := strings.Fields(s.pagerCommand)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = pagerEnv(os.Environ())
+	cmd.Stdout = s.Out
+	cmd.Stderr = s.ErrOut
+
+	pipe, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start pager %q: %w", s.pagerCommand, err)
+	}
+
+	s.pagerProcess = cmd
+	s.pagerOut = s.Out
+	s.Out = &pagerWriter{pipe}
+	return nil
+}
+
+// StopPager closes the pager input, waits for the user to quit and restores stdout
+func (s *IOStreams) StopPager() {
+	if s.pagerProcess == nil {
+		return
+	}
+
+	_ = s.Out.(*pagerWriter).Close()
+	_ = s.pagerProcess.Wait()
+
+	s.Out = s.pagerOut
+	s.pagerOut = nil
+	s.pagerProcess = nil
+}
+
+// pagerEnv sets defaults that make less and lv behave well for command output,
+// without overriding values the user has chosen
+func pagerEnv(env []string) []string {
+	defaults := map[string]string{
+		"LESS": "FRX", // quit if one screen, keep colors, don't clear the screen
+		"LV":   "-c",  // keep colors
+	}
+	for _, kv := range env {
+		if name, _, ok := strings.Cut(kv, "="); ok {
+			delete(defaults, name)
+		}
+	}
+	for name, value := range defaults {
+		env = append(env, name+"="+value)
+	}
+	return env
+}
+
+// pagerWriter hides broken pipe errors so quitting the pager early is not reported as a failure
+type pagerWriter struct {
+	io.WriteCloser
+}
+
+func (w *pagerWriter) Write(p []byte) (int, error) {
+	n, err := w.WriteCloser.Write(p)
+	if err != nil && (errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed)) {
+		return len(p), nil
+	}
+	return n, err
+}
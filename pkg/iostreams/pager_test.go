@@ -0,0 +1,78 @@
+package iostreams
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagerFromEnv(t *testing.T) {
+	t.Setenv("ZEN_PAGER", "more")
+	t.Setenv("PAGER", "less -S")
+	assert.Equal(t, "more", PagerFromEnv())
+
+	os.Unsetenv("ZEN_PAGER")
+	assert.Equal(t, "less -S", PagerFromEnv())
+
+	// An empty value disables paging rather than falling back to the default
+	t.Setenv("PAGER", "")
+	assert.Equal(t, "", PagerFromEnv())
+}
+
+func TestIsPagerEnabled(t *testing.T) {
+	streams := Test()
+	streams.SetPager("less")
+
+	// Test streams are not terminals
+	assert.False(t, streams.IsPagerEnabled())
+
+	streams.SetPager("cat")
+	assert.False(t, streams.IsPagerEnabled())
+}
+
+func TestStartPager_NotTTY(t *testing.T) {
+	streams := Test()
+	streams.SetPager("less")
+	out := streams.Out
+
+	require.NoError(t, streams.StartPager())
+	assert.Same(t, out, streams.Out, "stdout must not be replaced when it is not a terminal")
+
+	streams.StopPager()
+	assert.Same(t, out, streams.Out)
+}
+
+func TestSetPagerEnabled(t *testing.T) {
+	streams := Test()
+	streams.SetPager("less")
+	streams.SetPagerEnabled(false)
+
+	assert.False(t, streams.IsPagerEnabled())
+	assert.Equal(t, "less", streams.GetPager())
+}
+
+func TestPagerEnv(t *testing.T) {
+	env := pagerEnv([]string{"HOME=/home/zen"})
+	assert.Contains(t, env, "LESS=FRX")
+	assert.Contains(t, env, "LV=-c")
+
+	env = pagerEnv([]string{"LESS=-R"})
+	assert.Contains(t, env, "LESS=-R")
+	assert.NotContains(t, env, "LESS=FRX")
+}
+
+type brokenPipe struct{}
+
+func (b *brokenPipe) Write(p []byte) (int, error) { return 0, syscall.EPIPE }
+func (b *brokenPipe) Close() error                { return nil }
+
+func TestPagerWriter_IgnoresBrokenPipe(t *testing.T) {
+	w := &pagerWriter{&brokenPipe{}}
+
+	n, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+}
@@ -0,0 +1,274 @@
+package iostreams
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	progressBarWidth = 30
+	spinnerInterval  = 100 * time.Millisecond
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// SetProgressEnabled forces progress indicators on or off, overriding TTY detection
+func (s *IOStreams) SetProgressEnabled(enabled bool) {
+	s.progressSet = true
+	s.progressEnabled = enabled
+}
+
+// IsProgressEnabled reports whether progress indicators should be drawn.
+// By default they are shown only when the progress writer is a terminal.
+func (s *IOStreams) IsProgressEnabled() bool {
+	if s.progressSet {
+		return s.progressEnabled
+	}
+	if f, ok := s.ProgressWriter().(*os.File); ok {
+		return isTerminal(f)
+	}
+	return false
+}
+
+// Progress draws one or more progress lines on the progress writer.
+// Tasks with a total are drawn as bars; tasks without a total show a spinner.
+// In accessible mode nothing is redrawn: each change of a task is reported on a line
+// of its own, and bars are reported every quarter of the way.
+// All methods are no-ops when progress is disabled, so callers never need to check.
+type Progress struct {
+	io         *IOStreams
+	out        io.Writer
+	enabled    bool
+	accessible bool
+
+	mu      sync.Mutex
+	tasks   []*ProgressTask
+	frame   int
+	lines   int
+	stopped bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// ProgressTask is a single line within a Progress display
+type ProgressTask struct {
+	progress *Progress
+	label    string
+	total    int64
+	current  int64
+	finished bool
+	failed   bool
+	reported string
+}
+
+// NewProgress creates and starts a progress display
+func (s *IOStreams) NewProgress() *Progress {
+	p := &Progress{
+		io:         s,
+		out:        s.ProgressWriter(),
+		enabled:    s.IsProgressEnabled(),
+		accessible: s.IsAccessible(),
+		done:       make(chan struct{}),
+	}
+
+	if p.enabled && !p.accessible {
+		p.wg.Add(1)
+		go p.animate()
+	}
+
+	return p
+}
+
+// StartSpinner starts an indeterminate progress display with a single task
+func (s *IOStreams) StartSpinner(label string) *ProgressTask {
+	return s.NewProgress().AddTask(label, 0)
+}
+
+// StartProgressBar starts a determinate progress display with a single task
+func (s *IOStreams) StartProgressBar(label string, total int64) *ProgressTask {
+	return s.NewProgress().AddTask(label, total)
+}
+
+// AddTask adds a line to the display. A total of 0 shows a spinner instead of a bar.
+func (p *Progress) AddTask(label string, total int64) *ProgressTask {
+	task := &ProgressTask{progress: p, label: label, total: total}
+
+	p.mu.Lock()
+	p.tasks = append(p.tasks, task)
+	p.mu.Unlock()
+
+	p.redraw()
+	return task
+}
+
+// Stop finishes the display, leaving the final state of each task on screen
+func (p *Progress) Stop() {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	p.stopped = true
+	p.mu.Unlock()
+
+	close(p.done)
+	p.wg.Wait()
+	p.redraw()
+}
+
+// Stop finishes the owning display; use it for displays created with StartSpinner or StartProgressBar
+func (t *ProgressTask) Stop() {
+	t.progress.Stop()
+}
+
+// SetLabel changes the task label
+func (t *ProgressTask) SetLabel(label string) {
+	t.progress.mu.Lock()
+	t.label = label
+	t.progress.mu.Unlock()
+	t.progress.redraw()
+}
+
+// SetTotal changes the task total, turning a spinner into a bar when total > 0
+func (t *ProgressTask) SetTotal(total int64) {
+	t.progress.mu.Lock()
+	t.total = total
+	t.progress.mu.Unlock()
+	t.progress.redraw()
+}
+
+// Add advances the task by n units
+func (t *ProgressTask) Add(n int64) {
+	t.progress.mu.Lock()
+	t.current += n
+	t.progress.mu.Unlock()
+	t.progress.redraw()
+}
+
+// Set sets the task position
+func (t *ProgressTask) Set(n int64) {
+	t.progress.mu.Lock()
+	t.current = n
+	t.progress.mu.Unlock()
+	t.progress.redraw()
+}
+
+// Done marks the task complete, or failed when err is non-nil
+func (t *ProgressTask) Done(err error) {
+	t.progress.mu.Lock()
+	t.finished = true
+	t.failed = err != nil
+	if t.total > 0 && err == nil {
+		t.current = t.total
+	}
+	t.progress.mu.Unlock()
+	t.progress.redraw()
+}
+
+func (p *Progress) animate() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			p.frame = (p.frame + 1) % len(spinnerFrames)
+			p.mu.Unlock()
+			p.redraw()
+		}
+	}
+}
+
+// redraw rewrites every task line in place
+func (p *Progress) redraw() {
+	if !p.enabled {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.accessible {
+		p.report()
+		return
+	}
+
+	var b strings.Builder
+	if p.lines > 0 {
+		// Move to the start of the first line we drew
+		fmt.Fprintf(&b, "\r\033[%dA", p.lines)
+	}
+	for _, task := range p.tasks {
+		b.WriteString("\r\033[K")
+		b.WriteString(p.renderTask(task))
+		b.WriteString("\n")
+	}
+	p.lines = len(p.tasks)
+
+	fmt.Fprint(p.out, b.String())
+}
+
+func (p *Progress) renderTask(t *ProgressTask) string {
+	var icon string
+	switch {
+	case t.finished && t.failed:
+		icon = p.io.ColorError("✗")
+	case t.finished:
+		icon = p.io.ColorSuccess("✓")
+	default:
+		icon = p.io.ColorInfo(spinnerFrames[p.frame])
+	}
+
+	if t.total <= 0 {
+		return fmt.Sprintf("%s %s", icon, t.label)
+	}
+
+	current := t.current
+	if current > t.total {
+		current = t.total
+	}
+	filled := int(current * progressBarWidth / t.total)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	percent := current * 100 / t.total
+
+	return fmt.Sprintf("%s %s %s %3d%% (%d/%d)", icon, bar, t.label, percent, current, t.total)
+}
+
+// report writes a line for each task whose state changed since it was last reported
+func (p *Progress) report() {
+	for _, task := range p.tasks {
+		line := p.reportTask(task)
+		if line == task.reported {
+			continue
+		}
+		task.reported = line
+		fmt.Fprintln(p.out, line)
+	}
+}
+
+// reportTask returns the line that reports the state of a task in accessible mode
+func (p *Progress) reportTask(t *ProgressTask) string {
+	switch {
+	case t.finished && t.failed:
+		return p.io.FailureIcon() + " " + t.label
+	case t.finished:
+		return p.io.SuccessIcon() + " " + t.label
+	case t.total <= 0:
+		return t.label + "..."
+	}
+
+	current := t.current
+	if current > t.total {
+		current = t.total
+	}
+	return fmt.Sprintf("%s: %d%%", t.label, current*4/t.total*25)
+}
@@ -0,0 +1,126 @@
+package iostreams
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsProgressEnabled(t *testing.T) {
+	streams := Test()
+
+	// Test streams are not terminals
+	assert.False(t, streams.IsProgressEnabled())
+
+	streams.SetProgressEnabled(true)
+	assert.True(t, streams.IsProgressEnabled())
+
+	streams.SetProgressEnabled(false)
+	assert.False(t, streams.IsProgressEnabled())
+}
+
+func TestProgress_Disabled(t *testing.T) {
+	streams := Test()
+
+	spinner := streams.StartSpinner("Working")
+	spinner.SetLabel("Still working")
+	spinner.Done(nil)
+	spinner.Stop()
+
+	bar := streams.StartProgressBar("Downloading", 10)
+	bar.Add(5)
+	bar.Done(nil)
+	bar.Stop()
+
+	assert.Empty(t, streams.ErrOut.(*bytes.Buffer).String())
+	assert.Empty(t, streams.Out.(*bytes.Buffer).String())
+}
+
+func TestProgress_Spinner(t *testing.T) {
+	streams := Test()
+	streams.SetProgressEnabled(true)
+
+	spinner := streams.StartSpinner("Syncing")
+	spinner.Done(nil)
+	spinner.Stop()
+
+	out := streams.ErrOut.(*bytes.Buffer).String()
+	assert.Contains(t, out, "Syncing")
+	assert.Contains(t, out, "✓ Syncing")
+	assert.Empty(t, streams.Out.(*bytes.Buffer).String(), "progress must not be written to stdout")
+}
+
+func TestProgress_Bar(t *testing.T) {
+	streams := Test()
+	streams.SetProgressEnabled(true)
+
+	bar := streams.StartProgressBar("tasks", 4)
+	bar.Add(1)
+	bar.Add(1)
+	bar.Stop()
+
+	out := streams.ErrOut.(*bytes.Buffer).String()
+	assert.Contains(t, out, " 50% (2/4)")
+	assert.Contains(t, out, "tasks")
+}
+
+func TestProgress_MultiTask(t *testing.T) {
+	streams := Test()
+	streams.SetProgressEnabled(true)
+
+	progress := streams.NewProgress()
+	ok := progress.AddTask("PROJ-1", 0)
+	failed := progress.AddTask("PROJ-2", 0)
+	ok.Done(nil)
+	failed.Done(errors.New("boom"))
+	progress.Stop()
+	progress.Stop() // stopping twice is safe
+
+	out := streams.ErrOut.(*bytes.Buffer).String()
+	assert.Contains(t, out, "✓ PROJ-1")
+	assert.Contains(t, out, "✗ PROJ-2")
+	// Later frames move the cursor back over both lines
+	assert.Contains(t, out, "\033[2A")
+}
+
+func TestProgress_DoneCompletesBar(t *testing.T) {
+	streams := Test()
+	streams.SetProgressEnabled(true)
+
+	bar := streams.StartProgressBar("files", 3)
+	bar.Done(nil)
+	bar.Stop()
+
+	assert.Contains(t, streams.ErrOut.(*bytes.Buffer).String(), "100% (3/3)")
+}
+
+func TestProgress_Accessible(t *testing.T) {
+	streams := Test()
+	streams.SetProgressEnabled(true)
+	streams.SetAccessible(true)
+	stderr := streams.ErrOut.(*bytes.Buffer)
+
+	spinner := streams.StartSpinner("Syncing")
+	spinner.SetLabel("Syncing")
+	spinner.Done(nil)
+	spinner.Stop()
+
+	bar := streams.StartProgressBar("Downloading", 8)
+	for i := 0; i < 8; i++ {
+		bar.Add(1)
+	}
+	bar.Done(errors.New("interrupted"))
+	bar.Stop()
+
+	assert.Equal(t, "Syncing...\n"+
+		"Success: Syncing\n"+
+		"Downloading: 0%\n"+
+		"Downloading: 25%\n"+
+		"Downloading: 50%\n"+
+		"Downloading: 75%\n"+
+		"Downloading: 100%\n"+
+		"Error: Downloading\n", stderr.String())
+	assert.NotContains(t, stderr.String(), "\033[", "no cursor movement")
+}
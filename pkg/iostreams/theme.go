@@ -0,0 +1,151 @@
+package iostreams
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Theme is the palette the semantic color functions draw with. Each field is the ANSI
+// sequence that starts the color.
+type Theme struct {
+	Name    string
+	Success string
+	Error   string
+	Warning string
+	Info    string
+	Neutral string
+	Bold    string
+}
+
+// Theme names
+const (
+	ThemeDefault      = "default"
+	ThemeHighContrast = "high-contrast"
+	ThemeColorblind   = "colorblind"
+)
+
+// themes are the built-in themes, by name
+var themes = map[string]Theme{
+	ThemeDefault: {
+		Name:    ThemeDefault,
+		Success: ColorGreen,
+		Error:   ColorRed,
+		Warning: ColorYellow,
+		Info:    ColorBlue,
+		Neutral: ColorCyan,
+		Bold:    ColorBold,
+	},
+	// Bright, bold colors that stand out on dark and light backgrounds alike
+	ThemeHighContrast: {
+		Name:    ThemeHighContrast,
+		Success: ColorBold + ColorBrightGreen,
+		Error:   ColorBold + ColorBrightRed,
+		Warning: ColorBold + ColorBrightYellow,
+		Info:    ColorBold + ColorBrightCyan,
+		Neutral: ColorBrightWhite,
+		Bold:    ColorBold + ColorBrightWhite,
+	},
+	// Blue and orange from the Okabe-Ito palette in place of green and red, which
+	// readers with red-green color blindness cannot tell apart
+	ThemeColorblind: {
+		Name:    ThemeColorblind,
+		Success: "\033[38;5;32m",
+		Error:   "\033[38;5;208m",
+		Warning: "\033[38;5;220m",
+		Info:    "\033[38;5;75m",
+		Neutral: ColorBrightBlack,
+		Bold:    ColorBold,
+	},
+}
+
+// DefaultTheme returns the theme used unless another is configured
+func DefaultTheme() Theme {
+	return themes[ThemeDefault]
+}
+
+// LookupTheme returns the built-in theme named name
+func LookupTheme(name string) (Theme, bool) {
+	theme, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	return theme, ok
+}
+
+// ThemeNames returns the names of the built-in themes, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Theme returns the theme the color functions draw with
+func (s *IOStreams) Theme() Theme {
+	if s.theme.Name == "" {
+		return DefaultTheme()
+	}
+	return s.theme
+}
+
+// SetTheme sets the theme the color functions draw with
+func (s *IOStreams) SetTheme(theme Theme) {
+	s.theme = theme
+}
+
+// paint wraps text in the color sequence when color is enabled
+func (s *IOStreams) paint(color, text string) string {
+	if !s.ColorEnabled() || color == "" {
+		return text
+	}
+	return color + text + ColorReset
+}
+
+// ColorFromEnv reports whether output should be colored, given whether it goes to a
+// terminal, following the NO_COLOR, FORCE_COLOR and CLICOLOR conventions in order of
+// precedence:
+//   - NO_COLOR set to any value but the empty string disables color
+//   - FORCE_COLOR or CLICOLOR_FORCE set to anything but "0" or "false" enables color,
+//     even when output does not go to a terminal; set to "0" or "false", they disable it
+//     (empty values count as unset)
+//   - CLICOLOR=0 disables color
+func ColorFromEnv(isTTY bool) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if forced, ok := ColorForcedFromEnv(); ok {
+		return forced
+	}
+	if strings.TrimSpace(os.Getenv("CLICOLOR")) == "0" {
+		return false
+	}
+	return isTTY
+}
+
+// ColorForcedFromEnv returns the setting of FORCE_COLOR, or of CLICOLOR_FORCE when it
+// is unset or empty, and whether either is set. NO_COLOR is not considered.
+func ColorForcedFromEnv() (forced bool, ok bool) {
+	for _, name := range []string{"FORCE_COLOR", "CLICOLOR_FORCE"} {
+		value := strings.ToLower(strings.TrimSpace(os.Getenv(name)))
+		if value == "" {
+			continue
+		}
+		switch value {
+		case "0", "false":
+			return false, true
+		default:
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// ColorForced reports whether the environment forces color on, so that configuration
+// and non-interactive mode leave it enabled
+func ColorForced() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	forced, ok := ColorForcedFromEnv()
+	return ok && forced
+}
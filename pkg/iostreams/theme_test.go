@@ -0,0 +1,95 @@
+package iostreams
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clearColorEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE", "CLICOLOR"} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestColorFromEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		isTTY bool
+		want  bool
+	}{
+		{name: "terminal", isTTY: true, want: true},
+		{name: "not a terminal", isTTY: false, want: false},
+		{name: "NO_COLOR", env: map[string]string{"NO_COLOR": "1"}, isTTY: true, want: false},
+		{name: "NO_COLOR outranks FORCE_COLOR", env: map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, isTTY: true, want: false},
+		{name: "FORCE_COLOR without a terminal", env: map[string]string{"FORCE_COLOR": "1"}, isTTY: false, want: true},
+		{name: "FORCE_COLOR=0", env: map[string]string{"FORCE_COLOR": "0"}, isTTY: true, want: false},
+		{name: "CLICOLOR_FORCE", env: map[string]string{"CLICOLOR_FORCE": "1"}, isTTY: false, want: true},
+		{name: "FORCE_COLOR outranks CLICOLOR_FORCE", env: map[string]string{"FORCE_COLOR": "false", "CLICOLOR_FORCE": "1"}, isTTY: true, want: false},
+		{name: "CLICOLOR=0", env: map[string]string{"CLICOLOR": "0"}, isTTY: true, want: false},
+		{name: "CLICOLOR=1 without a terminal", env: map[string]string{"CLICOLOR": "1"}, isTTY: false, want: false},
+		{name: "FORCE_COLOR outranks CLICOLOR=0", env: map[string]string{"CLICOLOR": "0", "FORCE_COLOR": "1"}, isTTY: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearColorEnv(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			assert.Equal(t, tt.want, ColorFromEnv(tt.isTTY))
+		})
+	}
+}
+
+func TestSetNonInteractive_ForceColor(t *testing.T) {
+	clearColorEnv(t)
+	t.Setenv("FORCE_COLOR", "1")
+
+	streams := Test()
+	streams.SetNonInteractive(true)
+	assert.True(t, streams.ColorEnabled(), "FORCE_COLOR keeps color in non-interactive mode")
+
+	t.Setenv("NO_COLOR", "1")
+	streams.SetNonInteractive(true)
+	assert.False(t, streams.ColorEnabled())
+}
+
+func TestThemes(t *testing.T) {
+	assert.Equal(t, []string{ThemeColorblind, ThemeDefault, ThemeHighContrast}, ThemeNames())
+
+	theme, ok := LookupTheme("High-Contrast")
+	require.True(t, ok)
+	assert.Equal(t, ThemeHighContrast, theme.Name)
+
+	_, ok = LookupTheme("solarized")
+	assert.False(t, ok)
+
+	for _, name := range ThemeNames() {
+		theme, _ := LookupTheme(name)
+		for _, color := range []string{theme.Success, theme.Error, theme.Warning, theme.Info, theme.Neutral, theme.Bold} {
+			assert.NotEmpty(t, color, "theme %s", name)
+		}
+	}
+
+	colorblind, _ := LookupTheme(ThemeColorblind)
+	assert.NotEqual(t, ColorGreen, colorblind.Success)
+	assert.NotEqual(t, ColorRed, colorblind.Error)
+}
+
+func TestSetTheme(t *testing.T) {
+	streams := Test()
+	assert.Equal(t, ThemeDefault, streams.Theme().Name)
+
+	theme, _ := LookupTheme(ThemeColorblind)
+	streams.SetTheme(theme)
+	assert.Equal(t, "error", streams.ColorError("error"), "no color while color is disabled")
+
+	streams.SetColorEnabled(true)
+	assert.Equal(t, theme.Success+"done"+ColorReset, streams.ColorSuccess("done"))
+	assert.Equal(t, theme.Error+"error"+ColorReset, streams.ColorError("error"))
+	assert.Equal(t, theme.Success+SymbolSuccess+" done"+ColorReset, streams.FormatSuccess("done"))
+}
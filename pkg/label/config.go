@@ -0,0 +1,94 @@
+package label
+
+import (
+	"fmt"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Label is a label of the vocabulary
+type Label struct {
+	Description string `yaml:"description" json:"description,omitempty" mapstructure:"description"`
+
+	// Color is the hex color of the label, such as #1d76db
+	Color string `yaml:"color" json:"color,omitempty" mapstructure:"color"`
+
+	// Providers maps integrations to the name of the label there, where it differs
+	// (e.g. jira: team-frontend)
+	Providers map[string]string `yaml:"providers" json:"providers,omitempty" mapstructure:"providers"`
+}
+
+// Config contains label configuration
+type Config struct {
+	// Allowed are the labels tasks may carry, by name; when none are defined, any
+	// label is allowed
+	Allowed map[string]Label `yaml:"allowed" json:"allowed" mapstructure:"allowed"`
+}
+
+// DefaultConfig returns default label configuration
+func DefaultConfig() Config {
+	return Config{
+		Allowed: map[string]Label{},
+	}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the label configuration
+func (c Config) Validate() error {
+	seen := map[string]string{}
+	for _, name := range c.Names() {
+		label := c.Allowed[name]
+		if err := ValidateName(name); err != nil {
+			return err
+		}
+		if label.Color != "" && !color.MatchString(label.Color) {
+			return fmt.Errorf("invalid color of label %s: %s (must be a hex color such as #1d76db)", name, label.Color)
+		}
+		for provider, external := range label.Providers {
+			key := provider + "\x00" + normalize(external)
+			if other, ok := seen[key]; ok {
+				return fmt.Errorf("labels %s and %s both map to %s in %s", other, name, external, provider)
+			}
+			seen[key] = name
+		}
+	}
+	return nil
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode label config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for labels
+func (p ConfigParser) Section() string {
+	return "labels"
+}
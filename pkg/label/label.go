@@ -0,0 +1,171 @@
+// Package label defines the vocabulary of task labels of a workspace: the labels
+// allowed, their colors and descriptions, and their names in the systems tasks are
+// synced with. Labels are checked against the vocabulary when tasks are created and
+// updated, and mapped to and from the names of the providers when tasks are synced.
+package label
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var color = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// ValidateName returns an error unless name can be a label: not empty, and without
+// whitespace or commas, which separate labels on the command line
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("label name cannot be empty")
+	}
+	if strings.ContainsAny(name, ", \t\r\n") {
+		return fmt.Errorf("invalid label %q (labels cannot contain whitespace or commas)", name)
+	}
+	return nil
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Controlled reports whether labels are restricted to the vocabulary
+func (c Config) Controlled() bool {
+	return len(c.Allowed) > 0
+}
+
+// Names returns the names of the labels of the vocabulary, sorted
+func (c Config) Names() []string {
+	names := make([]string, 0, len(c.Allowed))
+	for name := range c.Allowed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup returns the name of the label of the vocabulary named name, compared
+// case-insensitively
+func (c Config) lookup(name string) (string, bool) {
+	if _, ok := c.Allowed[name]; ok {
+		return name, true
+	}
+	for allowed := range c.Allowed {
+		if normalize(allowed) == normalize(name) {
+			return allowed, true
+		}
+	}
+	return "", false
+}
+
+// Check returns labels with the names of the vocabulary and without duplicates, or an
+// error naming the labels that are not in the vocabulary. Without a vocabulary, any
+// valid label is accepted.
+func (c Config) Check(labels []string) ([]string, error) {
+	checked := make([]string, 0, len(labels))
+	seen := map[string]bool{}
+	var unknown []string
+	for _, name := range labels {
+		name = strings.TrimSpace(name)
+		if err := ValidateName(name); err != nil {
+			return nil, err
+		}
+		if c.Controlled() {
+			allowed, ok := c.lookup(name)
+			if !ok {
+				unknown = append(unknown, name)
+				continue
+			}
+			name = allowed
+		}
+		if !seen[normalize(name)] {
+			seen[normalize(name)] = true
+			checked = append(checked, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown labels: %s (allowed: %s)", strings.Join(unknown, ", "), strings.Join(c.Names(), ", "))
+	}
+	return checked, nil
+}
+
+// ToProvider returns the names in provider of labels. Labels without a name of their
+// own there keep theirs.
+func (c Config) ToProvider(provider string, labels []string) []string {
+	if labels == nil {
+		return nil
+	}
+	mapped := make([]string, 0, len(labels))
+	for _, name := range labels {
+		if allowed, ok := c.lookup(name); ok {
+			if external := c.Allowed[allowed].Providers[provider]; external != "" {
+				name = external
+			}
+		}
+		mapped = append(mapped, name)
+	}
+	return mapped
+}
+
+// FromProvider returns the labels of the vocabulary that the labels of provider map
+// to. Labels the vocabulary does not know keep their names, so that syncing never
+// loses them.
+func (c Config) FromProvider(provider string, labels []string) []string {
+	if labels == nil {
+		return nil
+	}
+	mapped := make([]string, 0, len(labels))
+	for _, external := range labels {
+		name := external
+		for _, allowed := range c.Names() {
+			if mappedName := c.Allowed[allowed].Providers[provider]; mappedName != "" && normalize(mappedName) == normalize(external) {
+				name = allowed
+				break
+			}
+		}
+		if name == external {
+			if allowed, ok := c.lookup(external); ok {
+				name = allowed
+			}
+		}
+		mapped = append(mapped, name)
+	}
+	return mapped
+}
+
+// Add defines a label of the vocabulary, or updates the fields of label that are set
+// on a label already defined. It reports whether the label was added rather than
+// updated.
+func (c *Config) Add(name string, label Label) (bool, error) {
+	if err := ValidateName(name); err != nil {
+		return false, err
+	}
+	if label.Color != "" && !color.MatchString(label.Color) {
+		return false, fmt.Errorf("invalid color: %s (must be a hex color such as #1d76db)", label.Color)
+	}
+	if c.Allowed == nil {
+		c.Allowed = map[string]Label{}
+	}
+
+	existing, ok := c.lookup(name)
+	if !ok {
+		c.Allowed[name] = label
+		return true, nil
+	}
+
+	updated := c.Allowed[existing]
+	if label.Description != "" {
+		updated.Description = label.Description
+	}
+	if label.Color != "" {
+		updated.Color = label.Color
+	}
+	for provider, external := range label.Providers {
+		if updated.Providers == nil {
+			updated.Providers = map[string]string{}
+		}
+		updated.Providers[provider] = external
+	}
+	c.Allowed[existing] = updated
+	return false, nil
+}
@@ -0,0 +1,101 @@
+package label
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() Config {
+	return Config{
+		Allowed: map[string]Label{
+			"bug":      {Description: "Something is broken", Color: "#d73a4a", Providers: map[string]string{"jira": "Bug"}},
+			"frontend": {Color: "#1d76db", Providers: map[string]string{"jira": "team-frontend", "github": "area: frontend"}},
+			"docs":     {},
+		},
+	}
+}
+
+func TestConfig_Check(t *testing.T) {
+	cfg := testConfig()
+
+	labels, err := cfg.Check([]string{"Frontend", " bug ", "frontend"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "bug"}, labels, "named as in the vocabulary, without duplicates")
+
+	_, err = cfg.Check([]string{"bug", "urgent", "ux"})
+	assert.EqualError(t, err, "unknown labels: urgent, ux (allowed: bug, docs, frontend)")
+
+	_, err = cfg.Check([]string{"needs review"})
+	assert.EqualError(t, err, `invalid label "needs review" (labels cannot contain whitespace or commas)`)
+
+	labels, err = (Config{}).Check([]string{"anything", "Anything"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"anything"}, labels, "any label is allowed without a vocabulary")
+}
+
+func TestConfig_ProviderMapping(t *testing.T) {
+	cfg := testConfig()
+
+	assert.Equal(t, []string{"team-frontend", "Bug", "docs", "other"}, cfg.ToProvider("jira", []string{"frontend", "bug", "docs", "other"}))
+	assert.Equal(t, []string{"area: frontend", "bug"}, cfg.ToProvider("github", []string{"frontend", "bug"}))
+
+	assert.Equal(t, []string{"frontend", "bug", "docs", "other"}, cfg.FromProvider("jira", []string{"TEAM-FRONTEND", "Bug", "Docs", "other"}))
+	assert.Equal(t, []string{"frontend"}, cfg.FromProvider("github", []string{"area: frontend"}))
+	assert.Nil(t, cfg.FromProvider("jira", nil))
+}
+
+func TestConfig_Add(t *testing.T) {
+	cfg := testConfig()
+
+	added, err := cfg.Add("ux", Label{Color: "#c5def5"})
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, Label{Color: "#c5def5"}, cfg.Allowed["ux"])
+
+	added, err = cfg.Add("Bug", Label{Description: "A defect", Providers: map[string]string{"github": "type: bug"}})
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, Label{
+		Description: "A defect",
+		Color:       "#d73a4a",
+		Providers:   map[string]string{"jira": "Bug", "github": "type: bug"},
+	}, cfg.Allowed["bug"], "updates the fields given")
+
+	added, err = (&Config{}).Add("bug", Label{})
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	_, err = cfg.Add("ux", Label{Color: "blue"})
+	assert.EqualError(t, err, "invalid color: blue (must be a hex color such as #1d76db)")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := testConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Allowed["ux"] = Label{Providers: map[string]string{"jira": "team-FRONTEND"}}
+	assert.EqualError(t, cfg.Validate(), "labels frontend and ux both map to team-FRONTEND in jira")
+
+	cfg = testConfig()
+	cfg.Allowed["ux"] = Label{Color: "#fff"}
+	assert.EqualError(t, cfg.Validate(), "invalid color of label ux: #fff (must be a hex color such as #1d76db)")
+}
+
+func TestConfigParser(t *testing.T) {
+	cfg, err := ConfigParser{}.Parse(map[string]interface{}{
+		"allowed": map[string]interface{}{
+			"bug": map[string]interface{}{
+				"description": "Something is broken",
+				"color":       "#d73a4a",
+				"providers":   map[string]interface{}{"jira": "Bug"},
+			},
+			"docs": nil,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Label{Description: "Something is broken", Color: "#d73a4a", Providers: map[string]string{"jira": "Bug"}}, cfg.Allowed["bug"])
+	assert.Contains(t, cfg.Allowed, "docs")
+	assert.Equal(t, "labels", ConfigParser{}.Section())
+}
@@ -0,0 +1,227 @@
+// Package metrics computes delivery metrics from workspace tasks, such as the cycle
+// time, lead time, throughput, and work in progress of the tasks finished over a period.
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/daddia/zen/pkg/task"
+)
+
+// DoneStage is the workflow stage that finishes a task which 'zen task finish' has not
+const DoneStage = "06-ship"
+
+// Flow is the flow metrics of tasks over a period
+type Flow struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+
+	Overall Stats   `json:"overall"`
+	ByTeam  []Stats `json:"by_team"`
+	ByType  []Stats `json:"by_type"`
+}
+
+// Stats are the flow metrics of a group of tasks
+type Stats struct {
+	Name string `json:"name,omitempty"`
+
+	// Throughput is the number of tasks finished in the period
+	Throughput        int     `json:"throughput"`
+	ThroughputPerWeek float64 `json:"throughput_per_week"`
+	// WIP is the number of tasks started and not finished at the end of the period
+	WIP int `json:"wip"`
+	// Capacity is the number of tasks a team works on at once, when it is limited
+	Capacity int `json:"capacity,omitempty"`
+
+	// CycleTime is the time from start to finish of the tasks finished in the period
+	CycleTime Summary `json:"cycle_time"`
+	// LeadTime is the time from creation to finish of the tasks finished in the period
+	LeadTime Summary `json:"lead_time"`
+}
+
+// Summary summarizes durations in days
+type Summary struct {
+	Count      int     `json:"count"`
+	MeanDays   float64 `json:"mean_days"`
+	MedianDays float64 `json:"median_days"`
+	P85Days    float64 `json:"p85_days"`
+	MaxDays    float64 `json:"max_days"`
+}
+
+// Timeline is when a task was created, started, and finished, as far as it is known
+type Timeline struct {
+	Created  *time.Time `json:"created,omitempty"`
+	Started  *time.Time `json:"started,omitempty"`
+	Finished *time.Time `json:"finished,omitempty"`
+}
+
+// TimelineOf returns the timeline of a task. A task starts when 'zen task start' runs
+// or it first leaves the first workflow stage, and finishes when 'zen task finish' runs
+// or it reaches DoneStage.
+func TimelineOf(t *task.Task) Timeline {
+	var timeline Timeline
+	if !t.Created.IsZero() {
+		created := t.Created
+		timeline.Created = &created
+	}
+	if first := t.Stages[task.WorkflowStages[0]]; first != nil && first.Started != nil {
+		if timeline.Created == nil || first.Started.Before(*timeline.Created) {
+			timeline.Created = first.Started
+		}
+	}
+
+	if t.Git != nil {
+		timeline.Started, timeline.Finished = t.Git.StartedAt, t.Git.FinishedAt
+	}
+	gitStarted, gitFinished := timeline.Started != nil, timeline.Finished != nil
+	for stage, times := range t.Stages {
+		if times == nil || times.Started == nil {
+			continue
+		}
+		i := task.StageIndex(stage)
+		if !gitStarted && i > 0 {
+			timeline.Started = earliest(timeline.Started, times.Started)
+		}
+		if !gitFinished && i >= task.StageIndex(DoneStage) {
+			timeline.Finished = earliest(timeline.Finished, times.Started)
+		}
+	}
+	return timeline
+}
+
+// ComputeFlow computes the flow metrics of tasks over the period from since to until,
+// overall and by team and by task type
+func ComputeFlow(tasks []*task.Task, since, until time.Time) *Flow {
+	timelines := make([]Timeline, len(tasks))
+	for i, t := range tasks {
+		timelines[i] = TimelineOf(t)
+	}
+
+	all := make([]int, len(tasks))
+	byTeam := map[string][]int{}
+	byType := map[string][]int{}
+	for i, t := range tasks {
+		all[i] = i
+		byTeam[groupName(t.Team)] = append(byTeam[groupName(t.Team)], i)
+		byType[groupName(t.Type)] = append(byType[groupName(t.Type)], i)
+	}
+
+	stats := func(name string, indexes []int) Stats {
+		group := make([]Timeline, len(indexes))
+		for i, index := range indexes {
+			group[i] = timelines[index]
+		}
+		s := computeStats(group, since, until)
+		s.Name = name
+		return s
+	}
+
+	flow := &Flow{Since: since, Until: until, Overall: stats("", all)}
+	for name, indexes := range byTeam {
+		flow.ByTeam = append(flow.ByTeam, stats(name, indexes))
+	}
+	for name, indexes := range byType {
+		flow.ByType = append(flow.ByType, stats(name, indexes))
+	}
+	sortStats(flow.ByTeam)
+	sortStats(flow.ByType)
+	return flow
+}
+
+func computeStats(timelines []Timeline, since, until time.Time) Stats {
+	var s Stats
+	var cycle, lead []float64
+	for _, tl := range timelines {
+		finished := tl.Finished != nil && !tl.Finished.After(until)
+		if tl.Started != nil && !tl.Started.After(until) && !finished {
+			s.WIP++
+		}
+		if !finished || tl.Finished.Before(since) {
+			continue
+		}
+		s.Throughput++
+		if tl.Started != nil && !tl.Finished.Before(*tl.Started) {
+			cycle = append(cycle, days(tl.Finished.Sub(*tl.Started)))
+		}
+		if tl.Created != nil && !tl.Finished.Before(*tl.Created) {
+			lead = append(lead, days(tl.Finished.Sub(*tl.Created)))
+		}
+	}
+
+	if weeks := until.Sub(since).Hours() / (24 * 7); weeks > 0 {
+		s.ThroughputPerWeek = round(float64(s.Throughput) / weeks)
+	}
+	s.CycleTime = Summarize(cycle)
+	s.LeadTime = Summarize(lead)
+	return s
+}
+
+// Summarize summarizes durations in days
+func Summarize(days []float64) Summary {
+	summary := Summary{Count: len(days)}
+	if len(days) == 0 {
+		return summary
+	}
+	sorted := append([]float64(nil), days...)
+	sort.Float64s(sorted)
+
+	total := 0.0
+	for _, d := range sorted {
+		total += d
+	}
+	summary.MeanDays = round(total / float64(len(sorted)))
+	summary.MedianDays = round(Percentile(sorted, 50))
+	summary.P85Days = round(Percentile(sorted, 85))
+	summary.MaxDays = round(sorted[len(sorted)-1])
+	return summary
+}
+
+// Percentile returns the pth percentile of sorted values, interpolating between the
+// closest ranks
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// sortStats orders groups by throughput, then by name
+func sortStats(stats []Stats) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Throughput != stats[j].Throughput {
+			return stats[i].Throughput > stats[j].Throughput
+		}
+		return stats[i].Name < stats[j].Name
+	})
+}
+
+func groupName(name string) string {
+	if name == "" {
+		return "none"
+	}
+	return name
+}
+
+func earliest(current, candidate *time.Time) *time.Time {
+	if current == nil || candidate.Before(*current) {
+		return candidate
+	}
+	return current
+}
+
+func days(d time.Duration) float64 {
+	return d.Hours() / 24
+}
+
+// round rounds to one decimal place
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
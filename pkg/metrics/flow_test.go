@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 10, 31, 12, 0, 0, 0, time.UTC)
+
+func day(d int) *time.Time {
+	t := time.Date(2026, 10, d, 12, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func testTasks() []*task.Task {
+	return []*task.Task{
+		// Finished with 'zen task finish'
+		{ID: "PROJ-1", Type: "story", Team: "web", Created: *day(1),
+			Git: &task.GitInfo{StartedAt: day(3), FinishedAt: day(7)}},
+		// Finished by reaching the Ship stage
+		{ID: "PROJ-2", Type: "bug", Team: "web", Stages: map[string]*task.StageTimes{
+			"01-align": {Started: day(10), Completed: day(11)},
+			"05-build": {Started: day(11), Completed: day(13)},
+			"06-ship":  {Started: day(13)},
+			"07-learn": {Started: day(20)},
+			"custom":   {Started: day(2)}, // unknown stages are ignored
+		}},
+		// In progress
+		{ID: "PROJ-3", Type: "story", Team: "api", Stages: map[string]*task.StageTimes{
+			"01-align":    {Started: day(12), Completed: day(14)},
+			"02-discover": {Started: day(14)},
+		}, Git: &task.GitInfo{StartedAt: day(15)}},
+		// Finished before the period
+		{ID: "PROJ-4", Type: "story", Team: "api", Created: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC),
+			Git: &task.GitInfo{StartedAt: day(1), FinishedAt: day(1)}},
+		// Not started
+		{ID: "PROJ-5", Type: "story"},
+	}
+}
+
+func TestTimelineOf(t *testing.T) {
+	tasks := testTasks()
+
+	assert.Equal(t, Timeline{Created: day(1), Started: day(3), Finished: day(7)}, TimelineOf(tasks[0]))
+	assert.Equal(t, Timeline{Created: day(10), Started: day(11), Finished: day(13)}, TimelineOf(tasks[1]),
+		"started on leaving the first stage, finished on reaching the Ship stage")
+	assert.Equal(t, day(15), TimelineOf(tasks[2]).Started, "'zen task start' takes precedence over stages")
+	assert.Equal(t, Timeline{}, TimelineOf(tasks[4]))
+}
+
+func TestComputeFlow(t *testing.T) {
+	flow := ComputeFlow(testTasks(), testNow.AddDate(0, 0, -28), testNow)
+
+	assert.Equal(t, Stats{
+		Throughput:        2,
+		ThroughputPerWeek: 0.5,
+		WIP:               1,
+		CycleTime:         Summary{Count: 2, MeanDays: 3, MedianDays: 3, P85Days: 3.7, MaxDays: 4},
+		LeadTime:          Summary{Count: 2, MeanDays: 4.5, MedianDays: 4.5, P85Days: 5.6, MaxDays: 6},
+	}, flow.Overall)
+
+	require.Len(t, flow.ByTeam, 3)
+	assert.Equal(t, "web", flow.ByTeam[0].Name, "highest throughput first")
+	assert.Equal(t, 2, flow.ByTeam[0].Throughput)
+	assert.Equal(t, "api", flow.ByTeam[1].Name)
+	assert.Equal(t, 1, flow.ByTeam[1].WIP)
+	assert.Equal(t, "none", flow.ByTeam[2].Name)
+
+	require.Len(t, flow.ByType, 2)
+	assert.Equal(t, "bug", flow.ByType[0].Name)
+	assert.Equal(t, "story", flow.ByType[1].Name)
+
+	earlier := ComputeFlow(testTasks(), testNow.AddDate(0, 0, -28), *day(5))
+	assert.Equal(t, 0, earlier.Overall.Throughput)
+	assert.Equal(t, 1, earlier.Overall.WIP, "in progress at the end of the period")
+}
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "30d", want: testNow.AddDate(0, 0, -30)},
+		{value: "2w", want: testNow.AddDate(0, 0, -14)},
+		{value: "12h", want: testNow.Add(-12 * time.Hour)},
+		{value: "2026-09-01", want: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "0d", wantErr: true},
+		{value: "-3d", wantErr: true},
+		{value: "last month", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSince(tt.value, testNow)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPush(t *testing.T) {
+	var auth string
+	var body Flow
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if r.URL.Path == "/fail" {
+			http.Error(w, "nope", http.StatusForbidden)
+			return
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	flow := ComputeFlow(testTasks(), testNow.AddDate(0, 0, -28), testNow)
+	require.NoError(t, Push(context.Background(), server.Client(), server.URL+"/metrics", "s3cret", flow))
+	assert.Equal(t, "Bearer s3cret", auth)
+	assert.Equal(t, 2, body.Overall.Throughput)
+
+	err := Push(context.Background(), server.Client(), server.URL+"/fail", "", flow)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403 Forbidden")
+	assert.Empty(t, auth, "no token, no authorization")
+
+	assert.Error(t, Push(context.Background(), server.Client(), "file:///etc/passwd", "", flow))
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSince returns the start of a period ending at now: a relative age such as "30d",
+// "6w", or "12h", or a date such as "2026-09-01"
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			n, err := strconv.Atoi(number)
+			if err != nil || n <= 0 {
+				return time.Time{}, invalidSince(value)
+			}
+			return now.Add(-time.Duration(n) * unit), nil
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, invalidSince(value)
+	}
+	return now.Add(-d), nil
+}
+
+func invalidSince(value string) error {
+	return fmt.Errorf("invalid period %q: use an age such as 30d, 6w, or 12h, or a date such as 2026-09-01", value)
+}
@@ -0,0 +1,223 @@
+package metrics
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/plugin"
+)
+
+// DefaultTrials is the number of simulated sprints a forecast runs
+const DefaultTrials = 10000
+
+// maxForecastDays bounds a simulated sprint that finishes too slowly to forecast
+const maxForecastDays = 365
+
+// Units of sprint scope
+const (
+	UnitPoints = "points"
+	UnitItems  = "items"
+)
+
+// SprintReport is the burndown and forecast of a sprint
+type SprintReport struct {
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`
+	State string    `json:"state"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// Unit is points when items are estimated, and items otherwise
+	Unit      string  `json:"unit"`
+	Scope     float64 `json:"scope"`
+	Completed float64 `json:"completed"`
+	Remaining float64 `json:"remaining"`
+
+	Burndown []BurndownDay `json:"burndown"`
+	Forecast Forecast      `json:"forecast"`
+}
+
+// BurndownDay is the scope of a sprint at the end of a day. Remaining is nil for days
+// that have not ended.
+type BurndownDay struct {
+	Date      time.Time `json:"date"`
+	Scope     float64   `json:"scope"`
+	Remaining *float64  `json:"remaining,omitempty"`
+	Ideal     float64   `json:"ideal"`
+}
+
+// Forecast is a Monte Carlo forecast of when the remaining scope of a sprint completes,
+// from simulated sprints that repeat the days of the sprint so far
+type Forecast struct {
+	Trials int `json:"trials"`
+	// Days is the number of past days of the sprint the simulations sample
+	Days int `json:"days"`
+	// Probability is the share of simulated sprints that complete by the sprint end
+	Probability float64 `json:"probability"`
+	// DailyRate is the mean scope completed per day so far
+	DailyRate float64 `json:"daily_rate"`
+	// P50 and P85 are the days on which half and 85% of the simulated sprints complete,
+	// or nil when nothing remains or too few complete within a year
+	P50 *time.Time `json:"p50,omitempty"`
+	P85 *time.Time `json:"p85,omitempty"`
+}
+
+// ComputeSprint computes the daily burndown of a sprint up to now, and forecasts when
+// its remaining scope completes with the given number of trials. The forecast is seeded
+// so that the same sprint data gives the same forecast.
+func ComputeSprint(sprint *plugin.Sprint, now time.Time, trials int) *SprintReport {
+	report := &SprintReport{
+		ID:    sprint.ID,
+		Name:  sprint.Name,
+		State: sprint.State,
+		Start: sprint.Start,
+		End:   sprint.End,
+		Unit:  UnitItems,
+	}
+	for _, item := range sprint.Items {
+		if item.Points != nil {
+			report.Unit = UnitPoints
+			break
+		}
+	}
+
+	value := func(item plugin.SprintItem) float64 {
+		if report.Unit == UnitItems {
+			return 1
+		}
+		if item.Points == nil {
+			return 0
+		}
+		return *item.Points
+	}
+
+	// scopeAt returns the scope and the completed scope at a time
+	scopeAt := func(at time.Time) (scope, completed float64) {
+		for _, item := range sprint.Items {
+			if !item.Added.IsZero() && item.Added.After(at) {
+				continue
+			}
+			scope += value(item)
+			if item.Completed != nil && !item.Completed.After(at) {
+				completed += value(item)
+			}
+		}
+		return scope, completed
+	}
+
+	for _, item := range sprint.Items {
+		report.Scope += value(item)
+		if item.Completed != nil {
+			report.Completed += value(item)
+		}
+	}
+	report.Remaining = report.Scope - report.Completed
+
+	until := now
+	if sprint.Completed != nil && sprint.Completed.Before(until) {
+		until = *sprint.Completed
+	}
+
+	initial, previous := scopeAt(sprint.Start)
+	length := sprint.End.Sub(sprint.Start)
+	var dailyCompleted []float64
+	for dayStart := startOfDay(sprint.Start); !sprint.End.IsZero() && dayStart.Before(sprint.End); dayStart = dayStart.AddDate(0, 0, 1) {
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		if dayEnd.After(sprint.End) {
+			dayEnd = sprint.End
+		}
+
+		day := BurndownDay{Date: dayStart}
+		if length > 0 {
+			elapsed := float64(dayEnd.Sub(sprint.Start)) / float64(length)
+			day.Ideal = round(initial * (1 - math.Min(elapsed, 1)))
+		}
+		if dayStart.After(until) {
+			day.Scope = round(report.Scope)
+			report.Burndown = append(report.Burndown, day)
+			continue
+		}
+
+		at := dayEnd
+		if at.After(until) {
+			at = until
+		}
+		scope, completed := scopeAt(at)
+		remaining := round(scope - completed)
+		day.Scope, day.Remaining = round(scope), &remaining
+		report.Burndown = append(report.Burndown, day)
+
+		// Only whole days tell how much a day completes
+		if !dayEnd.After(until) {
+			dailyCompleted = append(dailyCompleted, completed-previous)
+			previous = completed
+		}
+	}
+
+	// Simulated days follow the last whole day of the sprint
+	next := startOfDay(sprint.Start).AddDate(0, 0, len(dailyCompleted))
+	report.Forecast = forecast(dailyCompleted, report.Remaining, next, sprint.End, trials)
+	report.Scope, report.Completed, report.Remaining = round(report.Scope), round(report.Completed), round(report.Remaining)
+	return report
+}
+
+// forecast simulates sprints that complete the remaining scope from the day starting
+// at from, by sampling the scope completed on past days
+func forecast(daily []float64, remaining float64, from, end time.Time, trials int) Forecast {
+	result := Forecast{Trials: trials, Days: len(daily)}
+	if remaining <= 0 {
+		result.Probability = 1
+		return result
+	}
+	if len(daily) == 0 || trials <= 0 {
+		return result
+	}
+
+	total := 0.0
+	for _, d := range daily {
+		total += d
+	}
+	result.DailyRate = round(total / float64(len(daily)))
+
+	daysLeft := int(math.Ceil(end.Sub(from).Hours() / 24))
+	random := rand.New(rand.NewSource(1))
+
+	var finished []int
+	onTime := 0
+	for i := 0; i < trials; i++ {
+		left := remaining
+		days := 0
+		for left > 0 && days < maxForecastDays {
+			left -= daily[random.Intn(len(daily))]
+			days++
+		}
+		if left > 0 {
+			continue
+		}
+		finished = append(finished, days)
+		if days <= daysLeft {
+			onTime++
+		}
+	}
+
+	result.Probability = math.Round(float64(onTime)/float64(trials)*100) / 100
+	sort.Ints(finished)
+	// Trials that do not finish within a year count as finishing last
+	percentile := func(p float64) *time.Time {
+		rank := int(math.Ceil(p/100*float64(trials))) - 1
+		if rank >= len(finished) {
+			return nil
+		}
+		date := from.AddDate(0, 0, finished[rank]-1)
+		return &date
+	}
+	result.P50, result.P85 = percentile(50), percentile(85)
+	return result
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func points(p float64) *float64 {
+	return &p
+}
+
+func testSprint() *plugin.Sprint {
+	return &plugin.Sprint{
+		ID:    "42",
+		Name:  "Sprint 42",
+		State: "active",
+		Start: time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, 10, 9, 17, 0, 0, 0, time.UTC),
+		Items: []plugin.SprintItem{
+			{ExternalID: "PROJ-1", Points: points(5), Completed: day(5)},
+			{ExternalID: "PROJ-2", Points: points(3), Completed: day(6)},
+			{ExternalID: "PROJ-3", Points: points(8)},
+			{ExternalID: "PROJ-4", Points: points(2), Added: *day(6)},
+			{ExternalID: "PROJ-5"}, // not estimated
+		},
+	}
+}
+
+func TestComputeSprint(t *testing.T) {
+	report := ComputeSprint(testSprint(), *day(7), 1000)
+
+	assert.Equal(t, UnitPoints, report.Unit)
+	assert.Equal(t, 18.0, report.Scope)
+	assert.Equal(t, 8.0, report.Completed)
+	assert.Equal(t, 10.0, report.Remaining)
+
+	require.Len(t, report.Burndown, 5, "one day per day of the sprint")
+	assert.Equal(t, 13.7, report.Burndown[0].Ideal, "the ideal line burns down the initial scope")
+	assert.Equal(t, 0.0, report.Burndown[4].Ideal)
+	require.NotNil(t, report.Burndown[0].Remaining)
+	assert.Equal(t, 11.0, *report.Burndown[0].Remaining)
+	assert.Equal(t, 18.0, report.Burndown[1].Scope, "scope added during the sprint")
+	assert.Equal(t, 10.0, *report.Burndown[1].Remaining)
+	assert.Equal(t, 10.0, *report.Burndown[2].Remaining, "up to now")
+	assert.Nil(t, report.Burndown[3].Remaining, "days to come")
+
+	forecast := report.Forecast
+	assert.Equal(t, 1000, forecast.Trials)
+	assert.Equal(t, 2, forecast.Days, "whole days only")
+	assert.Equal(t, 4.0, forecast.DailyRate)
+	assert.Greater(t, forecast.Probability, 0.0)
+	assert.Less(t, forecast.Probability, 1.0)
+	require.NotNil(t, forecast.P50)
+	require.NotNil(t, forecast.P85)
+	assert.False(t, forecast.P85.Before(*forecast.P50))
+
+	assert.Equal(t, forecast, ComputeSprint(testSprint(), *day(7), 1000).Forecast, "forecasts are repeatable")
+}
+
+func TestComputeSprint_Items(t *testing.T) {
+	sprint := testSprint()
+	for i := range sprint.Items {
+		sprint.Items[i].Points = nil
+	}
+
+	report := ComputeSprint(sprint, *day(7), 100)
+	assert.Equal(t, UnitItems, report.Unit)
+	assert.Equal(t, 5.0, report.Scope)
+	assert.Equal(t, 3.0, report.Remaining)
+}
+
+func TestComputeSprint_NoForecast(t *testing.T) {
+	notStarted := ComputeSprint(testSprint(), *day(1), 100)
+	assert.Equal(t, 0, notStarted.Forecast.Days)
+	assert.Nil(t, notStarted.Forecast.P50)
+	for _, d := range notStarted.Burndown {
+		assert.Nil(t, d.Remaining)
+	}
+
+	stalled := testSprint()
+	stalled.Items = stalled.Items[2:3]
+	report := ComputeSprint(stalled, *day(8), 100)
+	assert.Equal(t, 0.0, report.Forecast.Probability)
+	assert.Nil(t, report.Forecast.P50, "nothing completes within a year")
+
+	done := testSprint()
+	done.Items = done.Items[:2]
+	report = ComputeSprint(done, *day(7), 100)
+	assert.Equal(t, 1.0, report.Forecast.Probability)
+}
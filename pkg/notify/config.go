@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"fmt"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Providers digests are sent with
+const (
+	ProviderSMTP    = "smtp"
+	ProviderWebhook = "webhook"
+)
+
+// Environment variables holding the secrets of the providers
+const (
+	SMTPPasswordEnv = "ZEN_SMTP_PASSWORD"
+	WebhookTokenEnv = "ZEN_NOTIFY_TOKEN"
+)
+
+// DefaultTemplate is the asset name of the digest template
+const DefaultTemplate = "workspace-digest"
+
+// SMTPConfig contains the mail server digests are sent through
+type SMTPConfig struct {
+	Host     string `yaml:"host" json:"host" mapstructure:"host"`
+	Port     int    `yaml:"port" json:"port" mapstructure:"port"`
+	Username string `yaml:"username" json:"username,omitempty" mapstructure:"username"`
+}
+
+// WebhookConfig contains the endpoint digests are posted to as JSON, such as a mail
+// service or a chat integration
+type WebhookConfig struct {
+	URL string `yaml:"url" json:"url" mapstructure:"url"`
+}
+
+// Config contains notification configuration
+type Config struct {
+	// Provider sends digests: smtp or webhook
+	Provider string `yaml:"provider" json:"provider" mapstructure:"provider"`
+
+	// From is the sender address of digests
+	From string `yaml:"from" json:"from" mapstructure:"from"`
+
+	// To are the recipient addresses of digests
+	To []string `yaml:"to" json:"to,omitempty" mapstructure:"to"`
+
+	// Template is the name of the digest template in the asset repository
+	Template string `yaml:"template" json:"template" mapstructure:"template"`
+
+	SMTP    SMTPConfig    `yaml:"smtp" json:"smtp" mapstructure:"smtp"`
+	Webhook WebhookConfig `yaml:"webhook" json:"webhook" mapstructure:"webhook"`
+}
+
+// DefaultConfig returns default notification configuration
+func DefaultConfig() Config {
+	return Config{
+		Provider: ProviderSMTP,
+		Template: DefaultTemplate,
+		SMTP:     SMTPConfig{Port: 587},
+	}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the notification configuration
+func (c Config) Validate() error {
+	switch c.Provider {
+	case ProviderSMTP:
+		if c.SMTP.Port < 1 || c.SMTP.Port > 65535 {
+			return fmt.Errorf("invalid smtp.port: %d", c.SMTP.Port)
+		}
+	case ProviderWebhook:
+	default:
+		return fmt.Errorf("invalid provider: %s (must be one of: %s, %s)", c.Provider, ProviderSMTP, ProviderWebhook)
+	}
+	if c.Template == "" {
+		return fmt.Errorf("template cannot be empty")
+	}
+	return nil
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode notify config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for notifications
+func (p ConfigParser) Section() string {
+	return "notify"
+}
@@ -0,0 +1,225 @@
+// Package notify composes digests of workspace activity and sends them by email or to
+// a webhook.
+package notify
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/metrics"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+)
+
+// Periods a digest can cover
+var Periods = []string{"daily", "weekly", "monthly"}
+
+// Built-in digest template, used when the asset repository has no digest template
+//
+//go:embed templates/workspace-digest.md.tmpl
+var defaultTemplate string
+
+// Digest summarizes the activity of a workspace over a period
+type Digest struct {
+	Project string    `json:"project,omitempty"`
+	Period  string    `json:"period"`
+	Since   time.Time `json:"since"`
+	Until   time.Time `json:"until"`
+
+	// Completed and Started are the tasks finished and started in the period
+	Completed []Item `json:"completed"`
+	Started   []Item `json:"started"`
+	// Progressed are the tasks that moved to another workflow stage in the period
+	Progressed []Item `json:"progressed"`
+	// InProgress are the tasks started and not finished at the end of the period
+	InProgress []Item `json:"in_progress"`
+	// Conflicts are the sync conflicts waiting for manual review
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// Item is a task in a digest
+type Item struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Owner string `json:"owner,omitempty"`
+	Team  string `json:"team,omitempty"`
+	Stage string `json:"stage,omitempty"`
+	// Date is when the task was completed, started, or reached its stage
+	Date string `json:"date,omitempty"`
+}
+
+// Conflict is a task whose sync with a source waits for manual review
+type Conflict struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Source string   `json:"source"`
+	Fields []string `json:"fields"`
+}
+
+// PeriodStart returns the start of a period that ends at until
+func PeriodStart(period string, until time.Time) (time.Time, error) {
+	switch period {
+	case "daily":
+		return until.AddDate(0, 0, -1), nil
+	case "weekly":
+		return until.AddDate(0, 0, -7), nil
+	case "monthly":
+		return until.AddDate(0, -1, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid period %q: must be one of %s", period, strings.Join(Periods, ", "))
+	}
+}
+
+// BuildDigest summarizes the activity of tasks over the period from since to until
+func BuildDigest(tasks []*task.Task, period string, since, until time.Time) *Digest {
+	d := &Digest{
+		Period:     period,
+		Since:      since,
+		Until:      until,
+		Completed:  []Item{},
+		Started:    []Item{},
+		Progressed: []Item{},
+		InProgress: []Item{},
+		Conflicts:  []Conflict{},
+	}
+
+	inPeriod := func(t *time.Time) bool {
+		return t != nil && !t.Before(since) && !t.After(until)
+	}
+
+	for _, t := range tasks {
+		timeline := metrics.TimelineOf(t)
+		finished := timeline.Finished != nil && !timeline.Finished.After(until)
+
+		if inPeriod(timeline.Finished) {
+			d.Completed = append(d.Completed, newItem(t, t.CurrentStage, timeline.Finished))
+		}
+		if inPeriod(timeline.Started) {
+			d.Started = append(d.Started, newItem(t, t.CurrentStage, timeline.Started))
+		}
+		if timeline.Started != nil && !timeline.Started.After(until) && !finished {
+			d.InProgress = append(d.InProgress, newItem(t, t.CurrentStage, timeline.Started))
+		}
+
+		// The latest stage the task reached in the period
+		var stage string
+		var reached *time.Time
+		for id, times := range t.Stages {
+			if times == nil || task.StageIndex(id) <= 0 || !inPeriod(times.Started) {
+				continue
+			}
+			if reached == nil || times.Started.After(*reached) {
+				stage, reached = id, times.Started
+			}
+		}
+		if reached != nil {
+			d.Progressed = append(d.Progressed, newItem(t, stage, reached))
+		}
+
+		for _, source := range sortedSources(t) {
+			conflicts := t.Sources[source].PendingConflicts
+			if len(conflicts) == 0 {
+				continue
+			}
+			fields := make([]string, len(conflicts))
+			for i, c := range conflicts {
+				fields[i] = c.Field
+			}
+			d.Conflicts = append(d.Conflicts, Conflict{ID: t.ID, Title: t.Title, Source: source, Fields: fields})
+		}
+	}
+
+	for _, items := range [][]Item{d.Completed, d.Started, d.Progressed, d.InProgress} {
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].Date != items[j].Date {
+				return items[i].Date < items[j].Date
+			}
+			return items[i].ID < items[j].ID
+		})
+	}
+	sort.SliceStable(d.Conflicts, func(i, j int) bool { return d.Conflicts[i].ID < d.Conflicts[j].ID })
+	return d
+}
+
+// Subject returns the subject line of a digest, such as
+// "Weekly digest for web: 3 completed, 2 started, 1 conflict"
+func (d *Digest) Subject() string {
+	subject := strings.ToUpper(d.Period[:1]) + d.Period[1:] + " digest"
+	if d.Project != "" {
+		subject += " for " + d.Project
+	}
+	subject += fmt.Sprintf(": %d completed, %d started", len(d.Completed), len(d.Started))
+	switch len(d.Conflicts) {
+	case 0:
+	case 1:
+		subject += ", 1 conflict"
+	default:
+		subject += fmt.Sprintf(", %d conflicts", len(d.Conflicts))
+	}
+	return subject
+}
+
+// Render renders the body of a digest with the named template from the asset
+// repository, or DefaultTemplate when name is empty. When the default template is not
+// available there, the built-in template is used instead.
+//
+// Templates receive the digest under its JSON field names, e.g. {{ .completed }} and
+// {{ range .conflicts }}{{ .id }}{{ end }}, with .subject and the period dates as
+// .since_date and .until_date.
+func Render(ctx context.Context, engine template.TemplateEngine, name string, d *Digest) (string, error) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+
+	tmpl, err := engine.LoadTemplate(ctx, name)
+	if err != nil {
+		if name != DefaultTemplate {
+			return "", err
+		}
+		tmpl, err = engine.CompileTemplate(ctx, name, defaultTemplate, &template.TemplateMetadata{Name: name})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode digest: %w", err)
+	}
+	var variables map[string]interface{}
+	if err := json.Unmarshal(data, &variables); err != nil {
+		return "", fmt.Errorf("failed to encode digest: %w", err)
+	}
+	variables["subject"] = d.Subject()
+	variables["since_date"] = d.Since.Format("2006-01-02")
+	variables["until_date"] = d.Until.Format("2006-01-02")
+
+	return engine.RenderTemplate(ctx, tmpl, variables)
+}
+
+func newItem(t *task.Task, stage string, date *time.Time) Item {
+	item := Item{ID: t.ID, Title: t.Title, Owner: t.Owner, Team: t.Team}
+	if stage != "" {
+		item.Stage = task.StageName(stage)
+	}
+	if date != nil {
+		item.Date = date.Format("2006-01-02")
+	}
+	return item
+}
+
+func sortedSources(t *task.Task) []string {
+	sources := make([]string, 0, len(t.Sources))
+	for source, info := range t.Sources {
+		if info != nil {
+			sources = append(sources, source)
+		}
+	}
+	sort.Strings(sources)
+	return sources
+}
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func day(d int) *time.Time {
+	t := time.Date(2026, 10, d, 9, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func testTasks() []*task.Task {
+	return []*task.Task{
+		{ID: "PROJ-1", Title: "Add login", Owner: "ana", CurrentStage: "06-ship",
+			Git: &task.GitInfo{StartedAt: day(1), FinishedAt: day(12)}},
+		{ID: "PROJ-2", Title: "Fix timeout", Owner: "ben", CurrentStage: "05-build",
+			Stages: map[string]*task.StageTimes{
+				"01-align":    {Started: day(8), Completed: day(10)},
+				"02-discover": {Started: day(10), Completed: day(14)},
+				"05-build":    {Started: day(14)},
+			},
+			Sources: map[string]*task.TaskSource{
+				"jira": {PendingConflicts: []task.Conflict{{Field: "title"}, {Field: "priority"}}},
+			}},
+		{ID: "PROJ-3", Title: "Old work", Git: &task.GitInfo{StartedAt: day(1)}},
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	d := BuildDigest(testTasks(), "weekly", testNow.AddDate(0, 0, -7), testNow)
+
+	require.Len(t, d.Completed, 1)
+	assert.Equal(t, Item{ID: "PROJ-1", Title: "Add login", Owner: "ana", Stage: "Ship", Date: "2026-10-12"}, d.Completed[0])
+
+	require.Len(t, d.Started, 1)
+	assert.Equal(t, "PROJ-2", d.Started[0].ID)
+	assert.Equal(t, "2026-10-10", d.Started[0].Date, "started on leaving the first stage")
+
+	require.Len(t, d.Progressed, 1)
+	assert.Equal(t, "Build", d.Progressed[0].Stage, "the latest stage reached")
+	assert.Equal(t, "2026-10-14", d.Progressed[0].Date)
+
+	require.Len(t, d.InProgress, 2)
+	assert.Equal(t, "PROJ-3", d.InProgress[0].ID, "oldest first")
+
+	require.Len(t, d.Conflicts, 1)
+	assert.Equal(t, Conflict{ID: "PROJ-2", Title: "Fix timeout", Source: "jira", Fields: []string{"title", "priority"}}, d.Conflicts[0])
+
+	d.Project = "web"
+	assert.Equal(t, "Weekly digest for web: 1 completed, 1 started, 1 conflict", d.Subject())
+}
+
+func TestPeriodStart(t *testing.T) {
+	since, err := PeriodStart("daily", testNow)
+	require.NoError(t, err)
+	assert.Equal(t, testNow.AddDate(0, 0, -1), since)
+
+	since, err = PeriodStart("monthly", testNow)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 9, 16, 12, 0, 0, 0, time.UTC), since)
+
+	_, err = PeriodStart("yearly", testNow)
+	assert.ErrorContains(t, err, `invalid period "yearly"`)
+}
+
+func TestRender(t *testing.T) {
+	engine := template.NewEngine(logging.NewBasic(), zentest.NewAssetClient(), template.DefaultConfig())
+	d := BuildDigest(testTasks(), "weekly", testNow.AddDate(0, 0, -7), testNow)
+
+	body, err := Render(context.Background(), engine, "", d)
+	require.NoError(t, err)
+
+	assert.Contains(t, body, "# Weekly digest: 1 completed, 1 started, 1 conflict")
+	assert.Contains(t, body, "2026-10-09 to 2026-10-16")
+	assert.Contains(t, body, "- PROJ-2 Fix timeout (jira: title, priority)")
+	assert.Contains(t, body, "- PROJ-1 Add login (ana), 2026-10-12")
+	assert.Contains(t, body, "- PROJ-2 Fix timeout reached Build, 2026-10-14")
+	assert.Contains(t, body, "- PROJ-3 Old work\n")
+
+	_, err = Render(context.Background(), engine, "missing-digest", d)
+	assert.Error(t, err, "only the default template falls back to the built-in one")
+}
@@ -0,0 +1,159 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is a digest ready to send
+type Message struct {
+	From    string   `json:"from"`
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
+
+// Sender sends messages
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewSender returns the sender of the configured provider. secret is the SMTP password
+// or the webhook bearer token, and may be empty.
+func NewSender(cfg Config, secret string, client *http.Client) (Sender, error) {
+	switch cfg.Provider {
+	case ProviderSMTP:
+		if cfg.SMTP.Host == "" {
+			return nil, fmt.Errorf("notify.smtp.host is not configured")
+		}
+		return &SMTPSender{
+			Addr:     net.JoinHostPort(cfg.SMTP.Host, strconv.Itoa(cfg.SMTP.Port)),
+			Username: cfg.SMTP.Username,
+			Password: secret,
+		}, nil
+	case ProviderWebhook:
+		if cfg.Webhook.URL == "" {
+			return nil, fmt.Errorf("notify.webhook.url is not configured")
+		}
+		return &WebhookSender{URL: cfg.Webhook.URL, Token: secret, Client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported notify provider: %s", cfg.Provider)
+	}
+}
+
+// SMTPSender sends messages as plain text email through a mail server, upgrading the
+// connection with STARTTLS when the server supports it
+type SMTPSender struct {
+	Addr     string
+	Username string
+	Password string
+
+	// sendMail sends the message; it is smtp.SendMail unless replaced in tests
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// Send sends a message. Mail servers are not cancelled with ctx.
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	if msg.From == "" {
+		return fmt.Errorf("no sender address: set notify.from")
+	}
+	if len(msg.To) == 0 {
+		return fmt.Errorf("no recipients: set notify.to or use --to")
+	}
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Addr)
+		if err != nil {
+			return fmt.Errorf("invalid mail server address %q: %w", s.Addr, err)
+		}
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+
+	send := s.sendMail
+	if send == nil {
+		send = smtp.SendMail
+	}
+	if err := send(s.Addr, auth, msg.From, msg.To, formatEmail(msg, time.Now())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// formatEmail formats a message as an RFC 5322 email with a UTF-8 plain text body
+func formatEmail(msg *Message, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", msg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	for _, line := range strings.Split(body, "\n") {
+		// A line with a single dot ends the message in SMTP
+		if strings.HasPrefix(line, ".") {
+			line = "." + line
+		}
+		b.WriteString(line + "\r\n")
+	}
+	return b.Bytes()
+}
+
+// WebhookSender posts messages as JSON to an endpoint, such as a mail service or a chat
+// integration, with Token as a bearer token when it is set
+type WebhookSender struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+// Send posts a message
+func (s *WebhookSender) Send(ctx context.Context, msg *Message) error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q: must be an http or https URL", s.URL)
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to send digest: %s responded %s", u.Host, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMessage() *Message {
+	return &Message{
+		From:    "zen@example.com",
+		To:      []string{"ana@example.com", "ben@example.com"},
+		Subject: "Weekly digest für web",
+		Body:    "# Weekly digest\n.\nDone\n",
+	}
+}
+
+func TestNewSender(t *testing.T) {
+	cfg := DefaultConfig()
+	_, err := NewSender(cfg, "", nil)
+	assert.ErrorContains(t, err, "notify.smtp.host is not configured")
+
+	cfg.SMTP.Host = "smtp.example.com"
+	sender, err := NewSender(cfg, "s3cret", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", sender.(*SMTPSender).Addr)
+
+	cfg.Provider = ProviderWebhook
+	_, err = NewSender(cfg, "", nil)
+	assert.ErrorContains(t, err, "notify.webhook.url is not configured")
+}
+
+func TestSMTPSender(t *testing.T) {
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+	var gotAuth smtp.Auth
+	sender := &SMTPSender{
+		Addr:     "smtp.example.com:587",
+		Username: "zen",
+		Password: "s3cret",
+		sendMail: func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+			gotAddr, gotAuth, gotFrom, gotTo, gotMsg = addr, auth, from, to, msg
+			return nil
+		},
+	}
+
+	require.NoError(t, sender.Send(context.Background(), testMessage()))
+
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.NotNil(t, gotAuth)
+	assert.Equal(t, "zen@example.com", gotFrom)
+	assert.Equal(t, []string{"ana@example.com", "ben@example.com"}, gotTo)
+	assert.Contains(t, string(gotMsg), "To: ana@example.com, ben@example.com\r\n")
+	assert.Contains(t, string(gotMsg), "Subject: =?utf-8?q?Weekly_digest_f=C3=BCr_web?=\r\n")
+	assert.Contains(t, string(gotMsg), "\r\n\r\n# Weekly digest\r\n..\r\nDone\r\n", "lines starting with a dot are escaped")
+
+	msg := testMessage()
+	msg.To = nil
+	assert.ErrorContains(t, sender.Send(context.Background(), msg), "no recipients")
+}
+
+func TestFormatEmail(t *testing.T) {
+	date := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	email := string(formatEmail(&Message{From: "a@example.com", To: []string{"b@example.com"}, Subject: "Digest", Body: "Hi"}, date))
+
+	assert.Equal(t, "From: a@example.com\r\n"+
+		"To: b@example.com\r\n"+
+		"Subject: Digest\r\n"+
+		"Date: Fri, 16 Oct 2026 09:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n"+
+		"Content-Transfer-Encoding: 8bit\r\n"+
+		"\r\n"+
+		"Hi\r\n", email)
+}
+
+func TestWebhookSender(t *testing.T) {
+	var auth string
+	var got Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if r.URL.Path == "/fail" {
+			http.Error(w, "nope", http.StatusBadGateway)
+			return
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer server.Close()
+
+	sender := &WebhookSender{URL: server.URL + "/digest", Token: "s3cret", Client: server.Client()}
+	require.NoError(t, sender.Send(context.Background(), testMessage()))
+	assert.Equal(t, "Bearer s3cret", auth)
+	assert.Equal(t, *testMessage(), got)
+
+	sender.URL = server.URL + "/fail"
+	assert.ErrorContains(t, sender.Send(context.Background(), testMessage()), "502 Bad Gateway")
+
+	sender.URL = "ftp://example.com"
+	assert.ErrorContains(t, sender.Send(context.Background(), testMessage()), "invalid webhook URL")
+}
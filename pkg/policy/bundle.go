@@ -0,0 +1,336 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of names organization policy can set rules for
+const (
+	NamingTaskID = "task_id"
+	NamingBranch = "branch"
+	NamingLabel  = "label"
+)
+
+// NamingKinds are the kinds of names naming rules apply to
+var NamingKinds = []string{NamingTaskID, NamingBranch, NamingLabel}
+
+// GateOptional is the override that makes a required quality gate optional
+const GateOptional = "optional"
+
+// BundlePath returns where asset syncs install the organization policy bundle of the
+// asset repository, in the workspace with zenDir
+func BundlePath(zenDir string) string {
+	return filepath.Join(zenDir, "library", "policy.yaml")
+}
+
+// Bundle is the policy an organization distributes to its workspaces through the
+// asset repository
+type Bundle struct {
+	Name string `yaml:"name" json:"name"`
+
+	// RequiredGates are the quality gates tasks must pass before they leave a stage
+	RequiredGates []RequiredGate `yaml:"required_gates" json:"required_gates,omitempty"`
+
+	// ProtectedKeys are configuration values workspaces must keep, by key
+	ProtectedKeys map[string]string `yaml:"protected_keys" json:"protected_keys,omitempty"`
+
+	// Naming are the patterns names must match, by kind of name
+	Naming map[string]string `yaml:"naming" json:"naming,omitempty"`
+
+	// Overridable are the items of the bundle workspaces may override, such as
+	// "naming.branch", "gates.security-review", "config.task.id_prefix" or "gates.*"
+	Overridable []string `yaml:"overridable" json:"overridable,omitempty"`
+}
+
+// RequiredGate is a quality gate tasks must pass before they leave a stage
+type RequiredGate struct {
+	Name  string `yaml:"name" json:"name"`
+	Stage string `yaml:"stage" json:"stage"`
+
+	// Types are the task types the gate applies to; every type when empty
+	Types []string `yaml:"types" json:"types,omitempty"`
+}
+
+// Override is an item of the bundle the workspace departs from, with the policy
+// overrides of its configuration or with a protected configuration value
+type Override struct {
+	Item  string `json:"item"`
+	Org   string `json:"org"`
+	Local string `json:"local"`
+
+	// Allowed reports whether the bundle lets workspaces override the item; naming and
+	// gate overrides that are not allowed are ignored, and the organization's rule applies
+	Allowed bool `json:"allowed"`
+}
+
+// OrgPolicy is the organization policy bundle installed in a workspace, with the
+// overrides of the workspace applied where the bundle allows them. A nil OrgPolicy,
+// as loaded when no bundle is installed, allows everything.
+type OrgPolicy struct {
+	Bundle *Bundle `json:"bundle"`
+	Path   string  `json:"path"`
+
+	// Overrides are the items the workspace departs from, by item
+	Overrides []Override `json:"overrides,omitempty"`
+
+	naming map[string]*regexp.Regexp
+	gates  []RequiredGate
+}
+
+// ParseBundle reads and validates a policy bundle
+func ParseBundle(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid policy bundle: %w", err)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// Validate checks that the gates, naming rules and overridable items of the bundle are
+// well formed
+func (b *Bundle) Validate() error {
+	if b.Name == "" {
+		return fmt.Errorf("invalid policy bundle: name is required")
+	}
+	for i, gate := range b.RequiredGates {
+		if gate.Name == "" || gate.Stage == "" {
+			return fmt.Errorf("invalid policy bundle: required gate %d needs a name and a stage", i+1)
+		}
+	}
+	for kind, pattern := range b.Naming {
+		if err := validNamingRule(kind, pattern); err != nil {
+			return fmt.Errorf("invalid policy bundle: %w", err)
+		}
+	}
+	for _, item := range b.Overridable {
+		section, _, _ := strings.Cut(item, ".")
+		if section != "gates" && section != "naming" && section != "config" {
+			return fmt.Errorf("invalid policy bundle: overridable item %q must start with gates., naming. or config.", item)
+		}
+	}
+	return nil
+}
+
+// LoadOrg returns the organization policy installed in the workspace with zenDir, with
+// the overrides in the policy section of cfg, or nil when no bundle is installed
+func LoadOrg(cfg *config.Config, zenDir string) (*OrgPolicy, error) {
+	path := BundlePath(zenDir)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read organization policy: %w", err)
+	}
+	bundle, err := ParseBundle(data)
+	if err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidConfig,
+			Message: "the organization policy bundle is invalid",
+			Details: fmt.Sprintf("%s: %v; run 'zen assets sync' to install it again", path, err),
+		}
+	}
+
+	policyConfig, err := config.GetConfig(cfg, ConfigParser{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy config: %w", err)
+	}
+	settings := map[string]string{}
+	if v := cfg.GetViper(); v != nil {
+		for key := range bundle.ProtectedKeys {
+			settings[key] = v.GetString(key)
+		}
+	}
+	org := NewOrgPolicy(bundle, policyConfig.Overrides.Items(), settings)
+	org.Path = path
+	return org, nil
+}
+
+// NewOrgPolicy returns the policy of bundle for a workspace with overrides, its policy
+// overrides by item, and settings, its values of the keys the bundle protects
+func NewOrgPolicy(bundle *Bundle, overrides, settings map[string]string) *OrgPolicy {
+	org := &OrgPolicy{Bundle: bundle, naming: map[string]*regexp.Regexp{}}
+
+	naming := map[string]string{}
+	for kind, pattern := range bundle.Naming {
+		naming[kind] = pattern
+	}
+	for _, kind := range NamingKinds {
+		item := "naming." + kind
+		local, ok := overrides[item]
+		if !ok || local == naming[kind] {
+			continue
+		}
+		allowed := bundle.overridable(item)
+		org.Overrides = append(org.Overrides, Override{Item: item, Org: naming[kind], Local: local, Allowed: allowed})
+		if allowed {
+			naming[kind] = local
+		}
+	}
+	for kind, pattern := range naming {
+		// Patterns of overrides are validated with the policy configuration
+		if compiled, err := regexp.Compile(pattern); err == nil && pattern != "" {
+			org.naming[kind] = compiled
+		}
+	}
+
+	for _, gate := range bundle.RequiredGates {
+		item := "gates." + gate.Name
+		if local, ok := overrides[item]; ok {
+			allowed := bundle.overridable(item)
+			org.Overrides = append(org.Overrides, Override{Item: item, Org: "required", Local: local, Allowed: allowed})
+			if allowed {
+				continue
+			}
+		}
+		org.gates = append(org.gates, gate)
+	}
+
+	for key, value := range bundle.ProtectedKeys {
+		if local := settings[key]; local != value {
+			item := "config." + key
+			org.Overrides = append(org.Overrides, Override{Item: item, Org: value, Local: local, Allowed: bundle.overridable(item)})
+		}
+	}
+
+	sort.Slice(org.Overrides, func(i, j int) bool { return org.Overrides[i].Item < org.Overrides[j].Item })
+	return org
+}
+
+// Name returns the name of the bundle, or "" without one
+func (o *OrgPolicy) Name() string {
+	if o == nil {
+		return ""
+	}
+	return o.Bundle.Name
+}
+
+// Violations returns the overrides the bundle does not allow
+func (o *OrgPolicy) Violations() []Override {
+	if o == nil {
+		return nil
+	}
+	var violations []Override
+	for _, override := range o.Overrides {
+		if !override.Allowed {
+			violations = append(violations, override)
+		}
+	}
+	return violations
+}
+
+// CheckName returns an error unless name follows the naming rule of its kind
+func (o *OrgPolicy) CheckName(kind, name string) error {
+	if o == nil {
+		return nil
+	}
+	pattern, ok := o.naming[kind]
+	if !ok || pattern.MatchString(name) {
+		return nil
+	}
+	return &types.Error{
+		Code:    types.ErrorCodeInvalidInput,
+		Message: fmt.Sprintf("%s %s does not follow the naming rule of organization policy %s", namingLabel(kind), name, o.Bundle.Name),
+		Details: fmt.Sprintf("%s must match %s", namingLabel(kind)+"s", pattern),
+	}
+}
+
+// RequiredGates returns the quality gates tasks of taskType must pass before they
+// leave stage
+func (o *OrgPolicy) RequiredGates(stage, taskType string) []RequiredGate {
+	if o == nil {
+		return nil
+	}
+	var gates []RequiredGate
+	for _, gate := range o.gates {
+		if gate.Stage != stage {
+			continue
+		}
+		if len(gate.Types) > 0 && !containsFold(gate.Types, taskType) {
+			continue
+		}
+		gates = append(gates, gate)
+	}
+	return gates
+}
+
+// ProtectedValue returns the value the organization requires for a configuration key,
+// when the bundle protects it and does not let workspaces override it
+func (o *OrgPolicy) ProtectedValue(key string) (string, bool) {
+	if o == nil {
+		return "", false
+	}
+	for protected, value := range o.Bundle.ProtectedKeys {
+		if strings.EqualFold(protected, key) && !o.Bundle.overridable("config."+protected) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// CheckSetting returns an error unless value may be set for a configuration key: keys
+// the bundle protects keep the organization's value, whatever the rules of the workspace
+func (o *OrgPolicy) CheckSetting(key, value string) error {
+	required, ok := o.ProtectedValue(key)
+	if !ok || value == required {
+		return nil
+	}
+	return &types.Error{
+		Code:    types.ErrorCodePermissionDenied,
+		Message: fmt.Sprintf("%s is protected by organization policy %s", key, o.Bundle.Name),
+		Details: fmt.Sprintf("the organization requires %q; ask the owners of the asset repository to make config.%s overridable", required, key),
+	}
+}
+
+// overridable reports whether workspaces may override item
+func (b *Bundle) overridable(item string) bool {
+	for _, pattern := range b.Overridable {
+		if matchKey(pattern, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// validNamingRule checks the kind and pattern of a naming rule
+func validNamingRule(kind, pattern string) error {
+	if !slices.Contains(NamingKinds, kind) {
+		return fmt.Errorf("unknown naming rule %q (must be one of: %s)", kind, strings.Join(NamingKinds, ", "))
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid pattern of naming rule %s: %w", kind, err)
+	}
+	return nil
+}
+
+func namingLabel(kind string) string {
+	switch kind {
+	case NamingTaskID:
+		return "task ID"
+	default:
+		return kind
+	}
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBundle = `
+name: acme
+required_gates:
+  - name: security-review
+    stage: 05-build
+    types: [story, bug]
+  - name: design-review
+    stage: 02-discover
+protected_keys:
+  task.id_prefix: ACME
+  task.task_source: jira
+naming:
+  task_id: "^ACME-[0-9]+$"
+  branch: "^(feature|fix)/"
+overridable:
+  - naming.branch
+  - gates.design-review
+`
+
+func testOrgPolicy(t *testing.T, overrides, settings map[string]string) *OrgPolicy {
+	t.Helper()
+	bundle, err := ParseBundle([]byte(testBundle))
+	require.NoError(t, err)
+	return NewOrgPolicy(bundle, overrides, settings)
+}
+
+func TestParseBundle(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "valid", data: testBundle},
+		{name: "no name", data: "naming:\n  branch: x\n", wantErr: "name is required"},
+		{name: "unknown naming rule", data: "name: acme\nnaming:\n  project: x\n", wantErr: `unknown naming rule "project"`},
+		{name: "invalid pattern", data: "name: acme\nnaming:\n  label: \"[\"\n", wantErr: "invalid pattern of naming rule label"},
+		{name: "gate without stage", data: "name: acme\nrequired_gates:\n  - name: review\n", wantErr: "required gate 1 needs a name and a stage"},
+		{name: "unknown item", data: "name: acme\noverridable: [rules.delete]\n", wantErr: `overridable item "rules.delete"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBundle([]byte(tt.data))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestOrgPolicy_CheckName(t *testing.T) {
+	org := testOrgPolicy(t, nil, nil)
+
+	assert.NoError(t, org.CheckName(NamingTaskID, "ACME-12"))
+	assert.NoError(t, org.CheckName(NamingBranch, "feature/ACME-12-login"))
+	assert.NoError(t, org.CheckName(NamingLabel, "anything"), "kinds without rules are not restricted")
+
+	err := org.CheckName(NamingTaskID, "PROJ-1")
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr))
+	assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+	assert.Equal(t, "task ID PROJ-1 does not follow the naming rule of organization policy acme", zenErr.Message)
+	assert.Equal(t, "task IDs must match ^ACME-[0-9]+$", zenErr.Details)
+
+	var none *OrgPolicy
+	assert.NoError(t, none.CheckName(NamingTaskID, "PROJ-1"), "workspaces without a bundle are not restricted")
+}
+
+func TestOrgPolicy_Overrides(t *testing.T) {
+	org := testOrgPolicy(t, map[string]string{
+		"naming.branch":         "^[a-z]+/",
+		"naming.task_id":        "^PROJ-",
+		"gates.design-review":   GateOptional,
+		"gates.security-review": GateOptional,
+	}, map[string]string{
+		"task.id_prefix":   "ACME",
+		"task.task_source": "github",
+	})
+
+	assert.Equal(t, []Override{
+		{Item: "config.task.task_source", Org: "jira", Local: "github"},
+		{Item: "gates.design-review", Org: "required", Local: GateOptional, Allowed: true},
+		{Item: "gates.security-review", Org: "required", Local: GateOptional},
+		{Item: "naming.branch", Org: "^(feature|fix)/", Local: "^[a-z]+/", Allowed: true},
+		{Item: "naming.task_id", Org: "^ACME-[0-9]+$", Local: "^PROJ-"},
+	}, org.Overrides)
+	assert.Len(t, org.Violations(), 3)
+
+	assert.NoError(t, org.CheckName(NamingBranch, "chore/cleanup"), "allowed overrides apply")
+	assert.Error(t, org.CheckName(NamingTaskID, "PROJ-1"), "overrides that are not allowed are ignored")
+	assert.Empty(t, org.RequiredGates("02-discover", "story"))
+	assert.Equal(t, []RequiredGate{{Name: "security-review", Stage: "05-build", Types: []string{"story", "bug"}}}, org.RequiredGates("05-build", "Story"))
+	assert.Empty(t, org.RequiredGates("05-build", "spike"), "gates apply to their task types")
+}
+
+func TestOrgPolicy_ProtectedValue(t *testing.T) {
+	org := testOrgPolicy(t, nil, nil)
+
+	value, ok := org.ProtectedValue("task.id_prefix")
+	assert.True(t, ok)
+	assert.Equal(t, "ACME", value)
+	_, ok = org.ProtectedValue("cli.verbose")
+	assert.False(t, ok)
+
+	assert.NoError(t, org.CheckSetting("task.id_prefix", "ACME"))
+	err := org.CheckSetting("task.id_prefix", "WEB")
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr))
+	assert.Equal(t, types.ErrorCodePermissionDenied, zenErr.Code)
+	assert.Equal(t, "task.id_prefix is protected by organization policy acme", zenErr.Message)
+}
+
+func TestConfig_ValidateOverrides(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Overrides.Gates = map[string]string{"security-review": "skip"}
+	assert.EqualError(t, cfg.Validate(), `overrides: gate security-review can only be overridden as "optional"`)
+
+	cfg = DefaultConfig()
+	cfg.Overrides.Naming = map[string]string{"branch": "("}
+	assert.ErrorContains(t, cfg.Validate(), "overrides: invalid pattern of naming rule branch")
+}
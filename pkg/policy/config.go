@@ -0,0 +1,153 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Operations restricted by policy
+const (
+	OpTaskDelete      = "task.delete"
+	OpWorkspaceReinit = "workspace.reinit"
+	OpConfigSet       = "config.set"
+)
+
+// Operations are the operations rules can restrict
+var Operations = []string{OpTaskDelete, OpWorkspaceReinit, OpConfigSet}
+
+// DefaultAuditLog is the audit log, relative to the .zen directory
+const DefaultAuditLog = "audit.log"
+
+// Rule restricts an operation to users and to the members of teams with a role
+type Rule struct {
+	// Operation is the restricted operation, one of Operations
+	Operation string `yaml:"operation" json:"operation" mapstructure:"operation"`
+
+	// Keys are the configuration keys a config.set rule protects, such as
+	// "task.task_source" or "network.*"; every key when empty
+	Keys []string `yaml:"keys" json:"keys,omitempty" mapstructure:"keys"`
+
+	// Users may run the operation
+	Users []string `yaml:"users" json:"users,omitempty" mapstructure:"users"`
+
+	// Roles are the team roles whose members may run the operation
+	Roles []string `yaml:"roles" json:"roles,omitempty" mapstructure:"roles"`
+}
+
+// Config contains policy configuration
+type Config struct {
+	// Rules restrict operations. An operation is allowed when every rule for it lists
+	// the user or one of their roles; operations without rules are not restricted.
+	Rules []Rule `yaml:"rules" json:"rules,omitempty" mapstructure:"rules"`
+
+	// AuditLog is the file overrides are recorded in, relative to the .zen directory
+	AuditLog string `yaml:"audit_log" json:"audit_log" mapstructure:"audit_log"`
+
+	// Overrides depart from the organization policy bundle of the asset repository,
+	// where the bundle allows it
+	Overrides Overrides `yaml:"overrides" json:"overrides" mapstructure:"overrides"`
+}
+
+// Overrides are the local overrides of an organization policy bundle
+type Overrides struct {
+	// Naming are the patterns names must match instead of the bundle's, by kind
+	Naming map[string]string `yaml:"naming" json:"naming,omitempty" mapstructure:"naming"`
+
+	// Gates make required quality gates of the bundle optional, by gate name
+	Gates map[string]string `yaml:"gates" json:"gates,omitempty" mapstructure:"gates"`
+}
+
+// Items returns the overrides by bundle item, such as "naming.branch"
+func (o Overrides) Items() map[string]string {
+	items := map[string]string{}
+	for kind, pattern := range o.Naming {
+		items["naming."+strings.ToLower(kind)] = pattern
+	}
+	for gate, value := range o.Gates {
+		items["gates."+gate] = value
+	}
+	return items
+}
+
+// DefaultConfig returns default policy configuration
+func DefaultConfig() Config {
+	return Config{
+		AuditLog: DefaultAuditLog,
+	}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the policy configuration
+func (c Config) Validate() error {
+	for i, rule := range c.Rules {
+		if !isOperation(rule.Operation) {
+			return fmt.Errorf("invalid operation in rule %d: %q (must be one of: %s)", i+1, rule.Operation, strings.Join(Operations, ", "))
+		}
+		if len(rule.Keys) > 0 && rule.Operation != OpConfigSet {
+			return fmt.Errorf("rule %d: keys only apply to %s", i+1, OpConfigSet)
+		}
+	}
+	if c.AuditLog == "" {
+		return fmt.Errorf("audit_log cannot be empty")
+	}
+	for kind, pattern := range c.Overrides.Naming {
+		if err := validNamingRule(kind, pattern); err != nil {
+			return fmt.Errorf("overrides: %w", err)
+		}
+	}
+	for gate, value := range c.Overrides.Gates {
+		if value != GateOptional {
+			return fmt.Errorf("overrides: gate %s can only be overridden as %q", gate, GateOptional)
+		}
+	}
+	return nil
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode policy config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for policies
+func (p ConfigParser) Section() string {
+	return "policy"
+}
+
+func isOperation(operation string) bool {
+	for _, op := range Operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+// Package policy restricts destructive operations, such as deleting tasks or
+// reinitializing the workspace, to the users and team roles listed in the policy
+// section of the configuration. A restricted operation can be overridden with
+// --confirm, and every override is recorded in an audit log, so that shared
+// workspaces, such as those on build servers, keep a record of who changed them.
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/identity"
+	"github.com/daddia/zen/pkg/team"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Actor is the user running an operation
+type Actor struct {
+	User  string   `json:"user"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Request is an operation to authorize
+type Request struct {
+	Operation string
+
+	// Target is what the operation changes, such as a task ID or a configuration key
+	Target string
+
+	// Confirm overrides the rules that restrict the operation
+	Confirm bool
+}
+
+// AuditEntry is an override recorded in the audit log
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"`
+	Roles     []string  `json:"roles,omitempty"`
+	Operation string    `json:"operation"`
+	Target    string    `json:"target,omitempty"`
+	Action    string    `json:"action"`
+}
+
+// Enforcer authorizes operations against the policy rules
+type Enforcer struct {
+	cfg      Config
+	actor    Actor
+	auditLog string
+
+	// Now returns the time of audit entries
+	Now func() time.Time
+}
+
+// NewEnforcer returns an enforcer of cfg for actor, recording overrides in auditLog
+func NewEnforcer(cfg Config, actor Actor, auditLog string) *Enforcer {
+	return &Enforcer{cfg: cfg, actor: actor, auditLog: auditLog, Now: time.Now}
+}
+
+// Load returns the enforcer of the policy in cfg for the current user, whose roles are
+// those they have on any team. Overrides are recorded in the audit log in zenDir.
+func Load(cfg *config.Config, zenDir string) (*Enforcer, error) {
+	policyConfig, err := config.GetConfig(cfg, ConfigParser{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy config: %w", err)
+	}
+	identityConfig, err := config.GetConfig(cfg, identity.ConfigParser{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity config: %w", err)
+	}
+	teamConfig, err := config.GetConfig(cfg, team.ConfigParser{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team config: %w", err)
+	}
+
+	user := identity.New(identityConfig).Current()
+	actor := Actor{User: user, Roles: teamConfig.RolesOf(user)}
+
+	auditLog := policyConfig.AuditLog
+	if !filepath.IsAbs(auditLog) {
+		auditLog = filepath.Join(zenDir, auditLog)
+	}
+	return NewEnforcer(policyConfig, actor, auditLog), nil
+}
+
+// Authorize returns nil when the actor may run the operation in req. An operation the
+// rules restrict is allowed when req.Confirm is set, once the override is recorded in
+// the audit log; otherwise it is denied with a permission error.
+func (e *Enforcer) Authorize(req Request) error {
+	var denied []Rule
+	for _, rule := range e.cfg.Rules {
+		if rule.applies(req) && !rule.allows(e.actor) {
+			denied = append(denied, rule)
+		}
+	}
+	if len(denied) == 0 {
+		return nil
+	}
+
+	if req.Confirm {
+		if err := e.audit(req, "override"); err != nil {
+			return fmt.Errorf("failed to record override in the audit log: %w", err)
+		}
+		return nil
+	}
+
+	var allowed []string
+	for _, rule := range denied {
+		allowed = append(allowed, rule.describe())
+	}
+	target := ""
+	if req.Target != "" {
+		target = " " + req.Target
+	}
+	return &types.Error{
+		Code:    types.ErrorCodePermissionDenied,
+		Message: fmt.Sprintf("%s%s is restricted by policy for %s", req.Operation, target, e.actor.User),
+		Details: fmt.Sprintf("allowed for %s; run with --confirm to override, which is recorded in %s",
+			strings.Join(allowed, " and "), e.auditLog),
+	}
+}
+
+// audit appends an entry for req to the audit log
+func (e *Enforcer) audit(req Request, action string) error {
+	entry := AuditEntry{
+		Time:      e.Now().UTC(),
+		User:      e.actor.User,
+		Roles:     e.actor.Roles,
+		Operation: req.Operation,
+		Target:    req.Target,
+		Action:    action,
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(e.auditLog), 0750); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(e.auditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// applies reports whether the rule restricts req
+func (r Rule) applies(req Request) bool {
+	if r.Operation != req.Operation {
+		return false
+	}
+	if len(r.Keys) == 0 {
+		return true
+	}
+	for _, key := range r.Keys {
+		if matchKey(key, req.Target) {
+			return true
+		}
+	}
+	return false
+}
+
+// allows reports whether the rule lets actor run its operation
+func (r Rule) allows(actor Actor) bool {
+	for _, user := range r.Users {
+		if strings.EqualFold(user, actor.User) {
+			return true
+		}
+	}
+	for _, role := range r.Roles {
+		for _, actorRole := range actor.Roles {
+			if strings.EqualFold(role, actorRole) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// describe describes who the rule allows, such as "users ana, ben or role lead"
+func (r Rule) describe() string {
+	var parts []string
+	if len(r.Users) > 0 {
+		parts = append(parts, "users "+strings.Join(r.Users, ", "))
+	}
+	if len(r.Roles) > 0 {
+		parts = append(parts, "roles "+strings.Join(r.Roles, ", "))
+	}
+	if len(parts) == 0 {
+		return "nobody"
+	}
+	return strings.Join(parts, " or ")
+}
+
+// matchKey reports whether key matches pattern, a configuration key or a section
+// followed by ".*"
+func matchKey(pattern, key string) bool {
+	if section, ok := strings.CutSuffix(pattern, ".*"); ok {
+		return strings.HasPrefix(strings.ToLower(key), strings.ToLower(section)+".")
+	}
+	return strings.EqualFold(pattern, key)
+}
+
+type confirmKey struct{}
+
+// WithConfirm returns a context whose operations override the rules that restrict
+// them, for operations authorized deep in a call stack
+func WithConfirm(ctx context.Context) context.Context {
+	return context.WithValue(ctx, confirmKey{}, true)
+}
+
+// Confirmed reports whether ctx overrides the rules, as set with WithConfirm
+func Confirmed(ctx context.Context) bool {
+	confirmed, _ := ctx.Value(confirmKey{}).(bool)
+	return confirmed
+}
@@ -0,0 +1,114 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() Config {
+	cfg := DefaultConfig()
+	cfg.Rules = []Rule{
+		{Operation: OpTaskDelete, Roles: []string{"lead"}},
+		{Operation: OpWorkspaceReinit, Users: []string{"ci-admin"}},
+		{Operation: OpConfigSet, Keys: []string{"task.task_source", "network.*"}, Users: []string{"ana"}, Roles: []string{"lead"}},
+	}
+	return cfg
+}
+
+func TestEnforcer_Authorize(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   Actor
+		req     Request
+		allowed bool
+	}{
+		{"role allowed", Actor{User: "ben", Roles: []string{"Lead"}}, Request{Operation: OpTaskDelete, Target: "PROJ-1"}, true},
+		{"role denied", Actor{User: "ben", Roles: []string{"member"}}, Request{Operation: OpTaskDelete, Target: "PROJ-1"}, false},
+		{"user allowed", Actor{User: "CI-Admin"}, Request{Operation: OpWorkspaceReinit}, true},
+		{"user denied", Actor{User: "ana", Roles: []string{"lead"}}, Request{Operation: OpWorkspaceReinit}, false},
+		{"protected key", Actor{User: "ben"}, Request{Operation: OpConfigSet, Target: "task.task_source"}, false},
+		{"protected section", Actor{User: "ben"}, Request{Operation: OpConfigSet, Target: "network.proxy"}, false},
+		{"protected key allowed", Actor{User: "ana"}, Request{Operation: OpConfigSet, Target: "network.proxy"}, true},
+		{"unprotected key", Actor{User: "ben"}, Request{Operation: OpConfigSet, Target: "cli.verbose"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewEnforcer(testConfig(), tt.actor, filepath.Join(t.TempDir(), "audit.log")).Authorize(tt.req)
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+			var zenErr *types.Error
+			require.True(t, errors.As(err, &zenErr), "got %v", err)
+			assert.Equal(t, types.ErrorCodePermissionDenied, zenErr.Code)
+		})
+	}
+}
+
+func TestEnforcer_AuthorizeError(t *testing.T) {
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+	err := NewEnforcer(testConfig(), Actor{User: "ben"}, auditLog).Authorize(Request{Operation: OpTaskDelete, Target: "PROJ-1"})
+
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr))
+	assert.Equal(t, "task.delete PROJ-1 is restricted by policy for ben", zenErr.Message)
+	assert.Equal(t, "allowed for roles lead; run with --confirm to override, which is recorded in "+auditLog, zenErr.Details)
+	assert.NoFileExists(t, auditLog, "denials are not recorded")
+}
+
+func TestEnforcer_Override(t *testing.T) {
+	auditLog := filepath.Join(t.TempDir(), "logs", "audit.log")
+	enforcer := NewEnforcer(testConfig(), Actor{User: "ben", Roles: []string{"member"}}, auditLog)
+	enforcer.Now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, enforcer.Authorize(Request{Operation: OpTaskDelete, Target: "PROJ-1", Confirm: true}))
+	require.NoError(t, enforcer.Authorize(Request{Operation: OpConfigSet, Target: "network.proxy", Confirm: true}))
+	require.NoError(t, enforcer.Authorize(Request{Operation: OpConfigSet, Target: "cli.verbose", Confirm: true}))
+
+	data, err := os.ReadFile(auditLog)
+	require.NoError(t, err)
+	var entries []AuditEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 2, "only overrides of restricted operations are recorded")
+	assert.Equal(t, AuditEntry{
+		Time:      time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+		User:      "ben",
+		Roles:     []string{"member"},
+		Operation: OpTaskDelete,
+		Target:    "PROJ-1",
+		Action:    "override",
+	}, entries[0])
+	assert.Equal(t, "network.proxy", entries[1].Target)
+}
+
+func TestConfirm(t *testing.T) {
+	assert.False(t, Confirmed(context.Background()))
+	assert.True(t, Confirmed(WithConfirm(context.Background())))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	require.NoError(t, testConfig().Validate())
+
+	cfg := testConfig()
+	cfg.Rules = append(cfg.Rules, Rule{Operation: "task.rename"})
+	assert.ErrorContains(t, cfg.Validate(), `invalid operation in rule 4: "task.rename"`)
+
+	cfg = testConfig()
+	cfg.Rules[0].Keys = []string{"task.*"}
+	assert.EqualError(t, cfg.Validate(), "rule 1: keys only apply to config.set")
+}
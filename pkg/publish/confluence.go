@@ -0,0 +1,192 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/clients"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Confluence publishes pages to a space of a Confluence site through its REST API
+type Confluence struct {
+	client   *zenhttp.Client
+	space    string
+	parentID string
+}
+
+// NewConfluence returns a publisher to a space. wikiURL is the base of the wiki, such as
+// https://acme.atlassian.net/wiki for Confluence Cloud; pages without a parent are
+// published under the page parentID, or at the top of the space when it is empty.
+func NewConfluence(wikiURL, space, parentID, authorization string, logger logging.Logger) *Confluence {
+	return &Confluence{
+		client: zenhttp.NewClient(clients.HTTPConfig{
+			Provider: TargetConfluence,
+			BaseURL:  strings.TrimRight(wikiURL, "/") + "/rest/api",
+			Retries:  2,
+			Headers: map[string]string{
+				"Accept":        "application/json",
+				"Authorization": authorization,
+			},
+		}, logger),
+		space:    space,
+		parentID: parentID,
+	}
+}
+
+// Name returns "confluence"
+func (c *Confluence) Name() string {
+	return TargetConfluence
+}
+
+type confluencePage struct {
+	ID        string                 `json:"id,omitempty"`
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Space     *confluenceSpace       `json:"space,omitempty"`
+	Ancestors []confluenceAncestor   `json:"ancestors,omitempty"`
+	Version   *confluenceVersion     `json:"version,omitempty"`
+	Body      *confluenceBody        `json:"body,omitempty"`
+	Links     map[string]interface{} `json:"_links,omitempty"`
+}
+
+type confluenceSpace struct {
+	Key string `json:"key"`
+}
+
+type confluenceAncestor struct {
+	ID string `json:"id"`
+}
+
+type confluenceVersion struct {
+	Number  int    `json:"number"`
+	Message string `json:"message,omitempty"`
+}
+
+type confluenceBody struct {
+	Storage struct {
+		Value          string `json:"value"`
+		Representation string `json:"representation"`
+	} `json:"storage"`
+}
+
+// PublishPage creates a page in the space, or updates the page of page.ID with a new
+// version. A page of the same title in the space is updated rather than duplicated, as
+// titles are unique within a space, and a page that was deleted is created again.
+func (c *Confluence) PublishPage(ctx context.Context, page *task.PublishPage) (*task.PublishedPage, error) {
+	current, err := c.currentPage(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &confluenceBody{}
+	body.Storage.Value = StorageFormat(page.Markdown)
+	body.Storage.Representation = "storage"
+	request := confluencePage{Type: "page", Title: page.Title, Space: &confluenceSpace{Key: c.space}, Body: body}
+	if parent := firstNonEmpty(page.ParentID, c.parentID); parent != "" {
+		request.Ancestors = []confluenceAncestor{{ID: parent}}
+	}
+
+	var published confluencePage
+	if current == nil {
+		err = c.doJSON(ctx, http.MethodPost, "content", request, &published)
+	} else {
+		request.ID = current.ID
+		request.Version = &confluenceVersion{Number: current.Version.Number + 1, Message: "Published by zen task publish"}
+		err = c.doJSON(ctx, http.MethodPut, "content/"+url.PathEscape(current.ID), request, &published)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &task.PublishedPage{ID: published.ID}
+	if published.Version != nil {
+		result.Version = published.Version.Number
+	}
+	base, _ := published.Links["base"].(string)
+	webui, _ := published.Links["webui"].(string)
+	if webui != "" {
+		result.URL = base + webui
+	}
+	return result, nil
+}
+
+// currentPage returns the page a page is published over: the page of its ID, or the
+// page of its title in the space. It returns nil when there is none.
+func (c *Confluence) currentPage(ctx context.Context, page *task.PublishPage) (*confluencePage, error) {
+	if page.ID != "" {
+		var current confluencePage
+		err := c.doJSON(ctx, http.MethodGet, "content/"+url.PathEscape(page.ID)+"?expand=version", nil, &current)
+		var zenErr *types.Error
+		switch {
+		case err == nil && current.Version != nil:
+			return &current, nil
+		case err != nil && !(errors.As(err, &zenErr) && zenErr.Code == types.ErrorCodeNotFound):
+			return nil, err
+		}
+	}
+
+	params := url.Values{"spaceKey": {c.space}, "title": {page.Title}, "type": {"page"}, "expand": {"version"}}
+	var found struct {
+		Results []confluencePage `json:"results"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, "content?"+params.Encode(), nil, &found); err != nil {
+		return nil, err
+	}
+	if len(found.Results) == 0 || found.Results[0].Version == nil {
+		return nil, nil
+	}
+	return &found.Results[0], nil
+}
+
+// doJSON sends a JSON request and decodes a successful response into out
+func (c *Confluence) doJSON(ctx context.Context, method, path string, in, out interface{}) error {
+	return c.client.DoJSON(ctx, method, path, in, out, confluenceError)
+}
+
+// confluenceError converts an unsuccessful response into an error with the message of
+// the Confluence error body
+func confluenceError(resp *zenhttp.Response) error {
+	var body struct {
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(resp.Body, &body)
+	message := body.Message
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+
+	code := types.ErrorCodeUnknown
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		code = types.ErrorCodeAuthenticationFailed
+	case http.StatusNotFound:
+		code = types.ErrorCodeNotFound
+	case http.StatusBadRequest, http.StatusConflict:
+		code = types.ErrorCodeInvalidInput
+	case http.StatusTooManyRequests:
+		code = types.ErrorCodeRateLimited
+	}
+
+	return &types.Error{
+		Code:    code,
+		Message: fmt.Sprintf("confluence API error (%d): %s", resp.StatusCode, message),
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
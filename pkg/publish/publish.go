@@ -0,0 +1,87 @@
+// Package publish publishes task artifacts to wikis: Confluence spaces, and wikis kept
+// as directories of Markdown files, such as a clone of a GitHub or GitLab wiki.
+package publish
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Targets
+const (
+	TargetConfluence = "confluence"
+	TargetWiki       = "wiki"
+)
+
+// Targets are the targets artifacts can be published to
+var Targets = []string{TargetConfluence, TargetWiki}
+
+// ForTarget returns the publisher of a target, configured by the integration provider
+// of the same name
+func ForTarget(target string, cfg *config.Config, authManager auth.Manager, logger logging.Logger) (task.Publisher, error) {
+	provider, ok := cfg.Integrations.Providers[target]
+	if !ok {
+		switch target {
+		case TargetConfluence, TargetWiki:
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidConfig,
+				Message: fmt.Sprintf("%s is not configured", target),
+				Details: fmt.Sprintf("Configure it under integrations.providers.%s", target),
+			}
+		}
+		return nil, unknownTarget(target)
+	}
+
+	setting := func(key string) string {
+		value, _ := provider.Settings[key].(string)
+		return value
+	}
+
+	switch target {
+	case TargetConfluence:
+		if provider.URL == "" || setting("space") == "" {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidConfig,
+				Message: "confluence needs the URL of the wiki and the key of a space",
+				Details: "Set integrations.providers.confluence.url and settings.space",
+			}
+		}
+		authorization, err := authManager.GetCredentials(TargetConfluence)
+		if err != nil || authorization == "" {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeAuthenticationFailed,
+				Message: "not authenticated with confluence",
+				Details: "Set email and api_key with type basic, or run 'zen auth confluence'",
+			}
+		}
+		if !strings.HasPrefix(authorization, "Basic ") {
+			authorization = "Bearer " + authorization
+		}
+		return NewConfluence(provider.URL, setting("space"), setting("parent"), authorization, logger), nil
+	case TargetWiki:
+		if setting("path") == "" {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidConfig,
+				Message: "wiki needs the directory of the wiki",
+				Details: "Set integrations.providers.wiki.settings.path to a clone of the wiki",
+			}
+		}
+		return NewWiki(setting("path"), provider.URL), nil
+	default:
+		return nil, unknownTarget(target)
+	}
+}
+
+func unknownTarget(target string) error {
+	return &types.Error{
+		Code:    types.ErrorCodeInvalidInput,
+		Message: fmt.Sprintf("unknown publish target: %s", target),
+		Details: fmt.Sprintf("targets are %s", strings.Join(Targets, ", ")),
+	}
+}
@@ -0,0 +1,170 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageFormat(t *testing.T) {
+	storage := StorageFormat("Intro with <b>raw</b> HTML<!-- hidden -->\n\n<!-- placeholder -->\n\n```go\nx := a[b[0]]>0\n```\n\n- item\n")
+
+	assert.Contains(t, storage, "Intro with &lt;b&gt;raw&lt;/b&gt; HTML")
+	assert.NotContains(t, storage, "hidden")
+	assert.NotContains(t, storage, "placeholder")
+	assert.Contains(t, storage, `<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter>`)
+	assert.Contains(t, storage, "<![CDATA[x := a[b[0]]]]><![CDATA[>0\n]]>", "CDATA ends are split")
+	assert.Contains(t, storage, "<li>item</li>")
+}
+
+func TestConfluence_PublishPage(t *testing.T) {
+	pages := map[string]map[string]interface{}{
+		"42": {"id": "42", "title": "PROJ-1: Use OAuth", "version": map[string]int{"number": 3}},
+	}
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/wiki/rest/api/content":
+			assert.Equal(t, "PROJ", r.URL.Query().Get("spaceKey"))
+			var results []map[string]interface{}
+			for _, page := range pages {
+				if page["title"] == r.URL.Query().Get("title") {
+					results = append(results, page)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		case r.Method == http.MethodGet:
+			page, ok := pages[filepath.Base(r.URL.Path)]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"No content found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		default:
+			var page confluencePage
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&page))
+			assert.Equal(t, "PROJ", page.Space.Key)
+			assert.Equal(t, "storage", page.Body.Storage.Representation)
+			if page.ID == "" {
+				page.ID = "7"
+				page.Version = &confluenceVersion{Number: 1}
+			}
+			if page.Title == "PROJ-1: Add login page" {
+				assert.Equal(t, []confluenceAncestor{{ID: "100"}}, page.Ancestors, "pages without a parent go under the configured one")
+			}
+			pages[page.ID] = map[string]interface{}{"id": page.ID, "title": page.Title, "version": page.Version}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":      page.ID,
+				"version": page.Version,
+				"_links":  map[string]string{"base": "https://acme.atlassian.net/wiki", "webui": "/spaces/PROJ/pages/" + page.ID},
+			})
+		}
+	}))
+	defer server.Close()
+
+	publisher := NewConfluence(server.URL+"/wiki/", "PROJ", "100", "Bearer secret", logging.NewBasic())
+	ctx := context.Background()
+
+	t.Run("update by ID", func(t *testing.T) {
+		requests = nil
+		published, err := publisher.PublishPage(ctx, &task.PublishPage{Title: "PROJ-1: Use OAuth", Markdown: "We use OAuth.", ID: "42", ParentID: "7"})
+		require.NoError(t, err)
+		assert.Equal(t, "42", published.ID)
+		assert.Equal(t, 4, published.Version)
+		assert.Equal(t, "https://acme.atlassian.net/wiki/spaces/PROJ/pages/42", published.URL)
+		assert.Equal(t, []string{"GET /wiki/rest/api/content/42", "PUT /wiki/rest/api/content/42"}, requests)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		requests = nil
+		published, err := publisher.PublishPage(ctx, &task.PublishPage{Title: "PROJ-1: Add login page", Markdown: "Users sign in."})
+		require.NoError(t, err)
+		assert.Equal(t, "7", published.ID)
+		assert.Equal(t, 1, published.Version)
+		assert.Equal(t, []string{"GET /wiki/rest/api/content", "POST /wiki/rest/api/content"}, requests)
+	})
+
+	t.Run("deleted page is found by title", func(t *testing.T) {
+		requests = nil
+		published, err := publisher.PublishPage(ctx, &task.PublishPage{Title: "PROJ-1: Add login page", Markdown: "Users sign in with SSO.", ID: "99"})
+		require.NoError(t, err)
+		assert.Equal(t, "7", published.ID)
+		assert.Equal(t, 2, published.Version)
+		assert.Equal(t, []string{"GET /wiki/rest/api/content/99", "GET /wiki/rest/api/content", "PUT /wiki/rest/api/content/7"}, requests)
+	})
+}
+
+func TestConfluence_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Not permitted to use Confluence"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewConfluence(server.URL, "PROJ", "", "Bearer secret", logging.NewBasic()).
+		PublishPage(context.Background(), &task.PublishPage{Title: "PROJ-1: Add login page"})
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, types.ErrorCodeAuthenticationFailed, zenErr.Code)
+	assert.Contains(t, zenErr.Message, "Not permitted to use Confluence")
+}
+
+func TestWiki_PublishPage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "app.wiki")
+	publisher := NewWiki(dir, "https://github.com/acme/app/wiki/")
+
+	published, err := publisher.PublishPage(context.Background(), &task.PublishPage{Title: "PROJ-1: Use OAuth", Markdown: "We use OAuth.\n"})
+	require.NoError(t, err)
+	assert.Equal(t, "proj-1-use-oauth.md", published.ID)
+	assert.Equal(t, "https://github.com/acme/app/wiki/proj-1-use-oauth", published.URL)
+
+	// Renamed pages keep their file
+	published, err = publisher.PublishPage(context.Background(), &task.PublishPage{Title: "PROJ-1: Use OAuth with PKCE", Markdown: "We use PKCE.\n", ID: published.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "proj-1-use-oauth.md", published.ID)
+
+	data, err := os.ReadFile(filepath.Join(dir, "proj-1-use-oauth.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# PROJ-1: Use OAuth with PKCE\n\nWe use PKCE.\n", string(data))
+}
+
+func TestForTarget_Errors(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Integrations.Providers = map[string]config.IntegrationProviderConfig{
+		"confluence": {URL: "https://acme.atlassian.net/wiki"},
+	}
+
+	tests := []struct {
+		target string
+		code   types.ErrorCode
+	}{
+		{target: "confluence", code: types.ErrorCodeInvalidConfig},
+		{target: "wiki", code: types.ErrorCodeInvalidConfig},
+		{target: "notion", code: types.ErrorCodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			_, err := ForTarget(tt.target, cfg, nil, logging.NewBasic())
+			var zenErr *types.Error
+			require.True(t, errors.As(err, &zenErr), "got %v", err)
+			assert.Equal(t, tt.code, zenErr.Code)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package publish
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+// StorageFormat converts Markdown to the XHTML storage format of Confluence pages. Fenced
+// code becomes code macros, HTML comments are left out, and other raw HTML is shown as
+// text, as pages reject markup that is not valid storage format.
+func StorageFormat(markdown string) string {
+	renderer := storageRenderer{blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.UseXHTML,
+	})}
+	return string(blackfriday.Run([]byte(markdown), blackfriday.WithRenderer(renderer)))
+}
+
+// storageRenderer renders Markdown as Confluence storage format
+type storageRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+// RenderNode implements blackfriday.Renderer
+func (r storageRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	switch node.Type {
+	case blackfriday.CodeBlock:
+		io.WriteString(w, `<ac:structured-macro ac:name="code">`)
+		if language, _, _ := strings.Cut(string(node.Info), " "); language != "" {
+			io.WriteString(w, `<ac:parameter ac:name="language">`)
+			template.HTMLEscape(w, []byte(language))
+			io.WriteString(w, `</ac:parameter>`)
+		}
+		// CDATA sections end at "]]>", so code holding it is split across sections
+		io.WriteString(w, `<ac:plain-text-body><![CDATA[`)
+		io.WriteString(w, strings.ReplaceAll(string(node.Literal), "]]>", "]]]]><![CDATA[>"))
+		io.WriteString(w, "]]></ac:plain-text-body></ac:structured-macro>\n")
+		return blackfriday.GoToNext
+	case blackfriday.HTMLSpan:
+		if !isComment(node.Literal) {
+			template.HTMLEscape(w, node.Literal)
+		}
+		return blackfriday.GoToNext
+	case blackfriday.HTMLBlock:
+		if !isComment(node.Literal) {
+			io.WriteString(w, "<p>")
+			template.HTMLEscape(w, node.Literal)
+			io.WriteString(w, "</p>\n")
+		}
+		return blackfriday.GoToNext
+	}
+	return r.HTMLRenderer.RenderNode(w, node, entering)
+}
+
+// isComment reports whether raw HTML is a comment, such as the placeholders of templates
+func isComment(html []byte) bool {
+	html = bytes.TrimSpace(html)
+	return bytes.HasPrefix(html, []byte("<!--")) && bytes.HasSuffix(html, []byte("-->"))
+}
@@ -0,0 +1,52 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daddia/zen/pkg/task"
+)
+
+// Wiki publishes pages as Markdown files of a directory, such as a clone of a GitHub or
+// GitLab wiki, which is committed and pushed like any other repository
+type Wiki struct {
+	dir     string
+	baseURL string
+}
+
+// NewWiki returns a publisher to the wiki in dir. Pages link to baseURL followed by
+// their name, as GitHub and GitLab wikis serve them, when baseURL is set.
+func NewWiki(dir, baseURL string) *Wiki {
+	return &Wiki{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Name returns "wiki"
+func (w *Wiki) Name() string {
+	return TargetWiki
+}
+
+// PublishPage writes a page to the file of page.ID, or to a new file named after its
+// title. Wikis of files are flat, so pages are not nested under their parent.
+func (w *Wiki) PublishPage(ctx context.Context, page *task.PublishPage) (*task.PublishedPage, error) {
+	name := page.ID
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		name = task.Slugify(page.Title) + ".md"
+	}
+	if err := os.MkdirAll(w.dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create wiki directory: %w", err)
+	}
+
+	content := "# " + page.Title + "\n\n" + page.Markdown
+	if err := os.WriteFile(filepath.Join(w.dir, name), []byte(content), 0644); err != nil { // #nosec G306 - wiki pages are shared documents
+		return nil, fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	published := &task.PublishedPage{ID: name}
+	if w.baseURL != "" {
+		published.URL = w.baseURL + "/" + strings.TrimSuffix(name, ".md")
+	}
+	return published, nil
+}
@@ -0,0 +1,146 @@
+package pullrequest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/daddia/zen/internal/logging"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+)
+
+// GitHub creates and reads pull requests through the GitHub REST API
+type GitHub struct {
+	client *zenhttp.Client
+	repo   string
+}
+
+// NewGitHub returns a GitHub provider for the "owner/name" repository. apiURL is
+// https://api.github.com for github.com or https://<host>/api/v3 for GitHub Enterprise.
+func NewGitHub(apiURL, repo, token string, logger logging.Logger) *GitHub {
+	return &GitHub{
+		client: newHTTPClient(ProviderGitHub, apiURL, map[string]string{
+			"Accept":               "application/vnd.github+json",
+			"Authorization":        "Bearer " + token,
+			"X-GitHub-Api-Version": "2022-11-28",
+		}, logger),
+		repo: repo,
+	}
+}
+
+// Name returns "github"
+func (g *GitHub) Name() string {
+	return ProviderGitHub
+}
+
+type githubPullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Draft   bool   `json:"draft"`
+	Merged  bool   `json:"merged"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+type githubReview struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	State string `json:"state"`
+}
+
+// Create opens a pull request and applies its labels
+func (g *GitHub) Create(ctx context.Context, req *CreateRequest) (*PullRequest, error) {
+	var created githubPullRequest
+	err := g.client.DoJSON(ctx, http.MethodPost, fmt.Sprintf("repos/%s/pulls", g.repo), map[string]interface{}{
+		"title": req.Title,
+		"body":  req.Body,
+		"head":  req.Head,
+		"base":  req.Base,
+		"draft": req.Draft,
+	}, &created, g.apiError)
+	if err != nil {
+		return nil, err
+	}
+
+	// Labels are set through the issues API; pull requests share their numbers with issues
+	if len(req.Labels) > 0 {
+		path := fmt.Sprintf("repos/%s/issues/%d/labels", g.repo, created.Number)
+		if err := g.client.DoJSON(ctx, http.MethodPost, path, map[string]interface{}{"labels": req.Labels}, nil, g.apiError); err != nil {
+			return nil, fmt.Errorf("pull request #%d created but labels could not be added: %w", created.Number, err)
+		}
+	}
+
+	pr := g.convert(&created)
+	pr.ReviewStatus = ReviewPending
+	return pr, nil
+}
+
+// Get returns a pull request with the review status derived from the latest review of each reviewer
+func (g *GitHub) Get(ctx context.Context, number int) (*PullRequest, error) {
+	var found githubPullRequest
+	if err := g.client.DoJSON(ctx, http.MethodGet, fmt.Sprintf("repos/%s/pulls/%d", g.repo, number), nil, &found, g.apiError); err != nil {
+		return nil, err
+	}
+
+	var reviews []githubReview
+	if err := g.client.DoJSON(ctx, http.MethodGet, fmt.Sprintf("repos/%s/pulls/%d/reviews?per_page=100", g.repo, number), nil, &reviews, g.apiError); err != nil {
+		return nil, err
+	}
+
+	pr := g.convert(&found)
+	pr.ReviewStatus = githubReviewStatus(reviews)
+	return pr, nil
+}
+
+func (g *GitHub) convert(p *githubPullRequest) *PullRequest {
+	state := p.State
+	if p.Merged {
+		state = StateMerged
+	}
+	return &PullRequest{
+		Provider:   ProviderGitHub,
+		Repository: g.repo,
+		Number:     p.Number,
+		URL:        p.HTMLURL,
+		Title:      p.Title,
+		State:      state,
+		Draft:      p.Draft,
+		Head:       p.Head.Ref,
+		Base:       p.Base.Ref,
+	}
+}
+
+// githubReviewStatus combines the latest approving or blocking review of each reviewer:
+// any outstanding change request wins over approvals
+func githubReviewStatus(reviews []githubReview) string {
+	latest := make(map[string]string)
+	for _, review := range reviews {
+		switch review.State {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latest[review.User.Login] = review.State
+		}
+	}
+
+	status := ReviewPending
+	for _, state := range latest {
+		switch state {
+		case "CHANGES_REQUESTED":
+			return ReviewChangesRequested
+		case "APPROVED":
+			status = ReviewApproved
+		}
+	}
+	return status
+}
+
+// apiError converts an unsuccessful response of the GitHub API into an error
+func (g *GitHub) apiError(resp *zenhttp.Response) error {
+	return apiError(g.Name(), resp)
+}
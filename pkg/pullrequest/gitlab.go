@@ -0,0 +1,129 @@
+package pullrequest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/daddia/zen/internal/logging"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+)
+
+// GitLab creates and reads merge requests through the GitLab REST API
+type GitLab struct {
+	client  *zenhttp.Client
+	repo    string
+	project string
+}
+
+// NewGitLab returns a GitLab provider for the "group/name" project. apiURL is
+// https://<host>/api/v4.
+func NewGitLab(apiURL, repo, token string, logger logging.Logger) *GitLab {
+	return &GitLab{
+		client: newHTTPClient(ProviderGitLab, apiURL, map[string]string{
+			"PRIVATE-TOKEN": token,
+		}, logger),
+		repo:    repo,
+		project: url.PathEscape(repo),
+	}
+}
+
+// Name returns "gitlab"
+func (g *GitLab) Name() string {
+	return ProviderGitLab
+}
+
+type gitlabMergeRequest struct {
+	IID                 int    `json:"iid"`
+	WebURL              string `json:"web_url"`
+	Title               string `json:"title"`
+	State               string `json:"state"`
+	Draft               bool   `json:"draft"`
+	SourceBranch        string `json:"source_branch"`
+	TargetBranch        string `json:"target_branch"`
+	DetailedMergeStatus string `json:"detailed_merge_status"`
+}
+
+type gitlabApprovals struct {
+	Approved   bool          `json:"approved"`
+	ApprovedBy []interface{} `json:"approved_by"`
+}
+
+// Create opens a merge request; drafts are marked with the "Draft:" title prefix
+func (g *GitLab) Create(ctx context.Context, req *CreateRequest) (*PullRequest, error) {
+	title := req.Title
+	if req.Draft && !strings.HasPrefix(title, "Draft:") {
+		title = "Draft: " + title
+	}
+
+	body := map[string]interface{}{
+		"title":         title,
+		"description":   req.Body,
+		"source_branch": req.Head,
+		"target_branch": req.Base,
+	}
+	if len(req.Labels) > 0 {
+		body["labels"] = strings.Join(req.Labels, ",")
+	}
+
+	var created gitlabMergeRequest
+	if err := g.client.DoJSON(ctx, http.MethodPost, fmt.Sprintf("projects/%s/merge_requests", g.project), body, &created, g.apiError); err != nil {
+		return nil, err
+	}
+
+	mr := g.convert(&created)
+	mr.ReviewStatus = ReviewPending
+	return mr, nil
+}
+
+// Get returns a merge request with its approval status
+func (g *GitLab) Get(ctx context.Context, number int) (*PullRequest, error) {
+	path := fmt.Sprintf("projects/%s/merge_requests/%d", g.project, number)
+
+	var found gitlabMergeRequest
+	if err := g.client.DoJSON(ctx, http.MethodGet, path, nil, &found, g.apiError); err != nil {
+		return nil, err
+	}
+
+	var approvals gitlabApprovals
+	if err := g.client.DoJSON(ctx, http.MethodGet, path+"/approvals", nil, &approvals, g.apiError); err != nil {
+		return nil, err
+	}
+
+	mr := g.convert(&found)
+	switch {
+	case found.DetailedMergeStatus == "requested_changes":
+		mr.ReviewStatus = ReviewChangesRequested
+	case approvals.Approved && len(approvals.ApprovedBy) > 0:
+		mr.ReviewStatus = ReviewApproved
+	default:
+		mr.ReviewStatus = ReviewPending
+	}
+	return mr, nil
+}
+
+func (g *GitLab) convert(mr *gitlabMergeRequest) *PullRequest {
+	state := mr.State
+	switch state {
+	case "opened", "locked":
+		state = StateOpen
+	}
+	return &PullRequest{
+		Provider:   ProviderGitLab,
+		Repository: g.repo,
+		Number:     mr.IID,
+		URL:        mr.WebURL,
+		Title:      mr.Title,
+		State:      state,
+		Draft:      mr.Draft,
+		Head:       mr.SourceBranch,
+		Base:       mr.TargetBranch,
+	}
+}
+
+// apiError converts an unsuccessful response of the GitLab API into an error
+func (g *GitLab) apiError(resp *zenhttp.Response) error {
+	return apiError(g.Name(), resp)
+}
@@ -0,0 +1,230 @@
+// Package pullrequest opens and tracks GitHub pull requests and GitLab merge requests.
+package pullrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/clients"
+	zenhttp "github.com/daddia/zen/pkg/clients/http"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Pull request states
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+	StateMerged = "merged"
+)
+
+// Review states
+const (
+	ReviewPending          = "pending"
+	ReviewApproved         = "approved"
+	ReviewChangesRequested = "changes_requested"
+)
+
+// Provider names
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// PullRequest describes a pull request (GitHub) or merge request (GitLab)
+type PullRequest struct {
+	Provider     string `json:"provider"`
+	Repository   string `json:"repository"`
+	Number       int    `json:"number"`
+	URL          string `json:"url"`
+	Title        string `json:"title"`
+	State        string `json:"state"`
+	Draft        bool   `json:"draft"`
+	ReviewStatus string `json:"review_status"`
+	Head         string `json:"head"`
+	Base         string `json:"base"`
+}
+
+// Reference returns the short reference for the pull request, e.g. "acme/app#42" or "group/app!7"
+func (pr *PullRequest) Reference() string {
+	if pr.Provider == ProviderGitLab {
+		return fmt.Sprintf("%s!%d", pr.Repository, pr.Number)
+	}
+	return fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+}
+
+// CreateRequest contains the fields of a new pull request
+type CreateRequest struct {
+	Title  string
+	Body   string
+	Head   string
+	Base   string
+	Draft  bool
+	Labels []string
+}
+
+// Provider creates and reads pull requests in a single repository
+type Provider interface {
+	// Name returns the provider name, "github" or "gitlab"
+	Name() string
+
+	// Create opens a pull request
+	Create(ctx context.Context, req *CreateRequest) (*PullRequest, error)
+
+	// Get returns a pull request with its current state and review status
+	Get(ctx context.Context, number int) (*PullRequest, error)
+}
+
+// Repository identifies a repository on a Git hosting service
+type Repository struct {
+	Host string
+	Path string
+}
+
+// ParseRemote splits a Git remote URL into its host and repository path,
+// e.g. "git@github.com:acme/app.git" becomes "github.com" and "acme/app"
+func ParseRemote(remoteURL string) (Repository, bool) {
+	remoteURL = strings.TrimSpace(remoteURL)
+
+	var host, path string
+	if !strings.Contains(remoteURL, "://") {
+		// scp-like syntax: [user@]host:path
+		userHost, p, found := strings.Cut(remoteURL, ":")
+		if !found {
+			return Repository{}, false
+		}
+		if _, h, found := strings.Cut(userHost, "@"); found {
+			userHost = h
+		}
+		host, path = userHost, p
+	} else {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return Repository{}, false
+		}
+		host, path = u.Hostname(), u.Path
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || !strings.Contains(path, "/") {
+		return Repository{}, false
+	}
+	return Repository{Host: host, Path: path}, true
+}
+
+// ProviderName returns "github" or "gitlab" for a repository host, or "" when the host is not recognised
+func (r Repository) ProviderName() string {
+	switch {
+	case strings.Contains(r.Host, "gitlab"):
+		return ProviderGitLab
+	case strings.Contains(r.Host, "github"):
+		return ProviderGitHub
+	default:
+		return ""
+	}
+}
+
+// APIURL returns the REST API base URL for the repository's host
+func (r Repository) APIURL() string {
+	switch r.ProviderName() {
+	case ProviderGitHub:
+		if r.Host == "github.com" {
+			return "https://api.github.com"
+		}
+		return fmt.Sprintf("https://%s/api/v3", r.Host)
+	case ProviderGitLab:
+		return fmt.Sprintf("https://%s/api/v4", r.Host)
+	default:
+		return ""
+	}
+}
+
+// ForRemote returns the provider for the repository behind a Git remote URL,
+// authenticated with the token stored for "github" or "gitlab". Reads are answered from
+// responses when it is not nil.
+func ForRemote(remoteURL string, authManager auth.Manager, responses *zenhttp.ResponseCache, logger logging.Logger) (Provider, error) {
+	repo, ok := ParseRemote(remoteURL)
+	if !ok || repo.ProviderName() == "" {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("unsupported remote %s", remoteURL),
+			Details: "Pull requests can be created for GitHub and GitLab remotes",
+		}
+	}
+
+	name := repo.ProviderName()
+	token, err := authManager.GetCredentials(name)
+	if err != nil || token == "" {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeAuthenticationFailed,
+			Message: fmt.Sprintf("not authenticated with %s", name),
+			Details: fmt.Sprintf("Run 'zen auth %s' to authenticate", name),
+		}
+	}
+
+	if name == ProviderGitLab {
+		provider := NewGitLab(repo.APIURL(), repo.Path, token, logger)
+		provider.client.UseResponseCache(responses)
+		return provider, nil
+	}
+	provider := NewGitHub(repo.APIURL(), repo.Path, token, logger)
+	provider.client.UseResponseCache(responses)
+	return provider, nil
+}
+
+// newHTTPClient creates the API client of provider
+func newHTTPClient(provider, baseURL string, headers map[string]string, logger logging.Logger) *zenhttp.Client {
+	return zenhttp.NewClient(clients.HTTPConfig{
+		Provider: provider,
+		BaseURL:  baseURL,
+		Retries:  2,
+		Headers:  headers,
+	}, logger)
+}
+
+// apiError converts an unsuccessful API response into an error
+func apiError(provider string, resp *zenhttp.Response) error {
+	var body struct {
+		Message interface{} `json:"message"`
+		Error   string      `json:"error"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	_ = json.Unmarshal(resp.Body, &body)
+
+	message := body.Error
+	if body.Message != nil {
+		message = fmt.Sprint(body.Message)
+	}
+	for _, e := range body.Errors {
+		if e.Message != "" {
+			message += ": " + e.Message
+		}
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+
+	code := types.ErrorCodeUnknown
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		code = types.ErrorCodeAuthenticationFailed
+	case http.StatusNotFound:
+		code = types.ErrorCodeNotFound
+	case http.StatusConflict, http.StatusUnprocessableEntity:
+		code = types.ErrorCodeInvalidInput
+	case http.StatusTooManyRequests:
+		code = types.ErrorCodeRateLimited
+	}
+
+	return &types.Error{
+		Code:    code,
+		Message: fmt.Sprintf("%s API error (%d): %s", provider, resp.StatusCode, message),
+	}
+}
@@ -0,0 +1,68 @@
+package pullrequest
+
+import (
+	"time"
+
+	"github.com/daddia/zen/pkg/task"
+)
+
+// sourceKind marks task sources that record a pull request
+const sourceKind = "pull_request"
+
+// TaskSource converts a pull request into the source record linked to a task
+func (pr *PullRequest) TaskSource() *task.TaskSource {
+	return &task.TaskSource{
+		System:        pr.Provider,
+		ExternalID:    pr.Reference(),
+		ExternalURL:   pr.URL,
+		LastSync:      time.Now(),
+		SyncEnabled:   false,
+		SyncDirection: string(task.SyncDirectionPull),
+		Metadata: map[string]interface{}{
+			"kind":          sourceKind,
+			"repository":    pr.Repository,
+			"number":        pr.Number,
+			"title":         pr.Title,
+			"state":         pr.State,
+			"draft":         pr.Draft,
+			"review_status": pr.ReviewStatus,
+			"head":          pr.Head,
+			"base":          pr.Base,
+		},
+	}
+}
+
+// FromTaskSource returns the pull request recorded in a task source, or false when the
+// source does not describe one
+func FromTaskSource(source *task.TaskSource) (*PullRequest, bool) {
+	if source == nil || source.Metadata["kind"] != sourceKind {
+		return nil, false
+	}
+
+	str := func(key string) string {
+		s, _ := source.Metadata[key].(string)
+		return s
+	}
+
+	pr := &PullRequest{
+		Provider:     source.System,
+		Repository:   str("repository"),
+		URL:          source.ExternalURL,
+		Title:        str("title"),
+		State:        str("state"),
+		ReviewStatus: str("review_status"),
+		Head:         str("head"),
+		Base:         str("base"),
+	}
+	pr.Draft, _ = source.Metadata["draft"].(bool)
+
+	// Numbers decode from JSON as float64
+	switch n := source.Metadata["number"].(type) {
+	case float64:
+		pr.Number = int(n)
+	case int:
+		pr.Number = n
+	}
+
+	return pr, pr.Number > 0
+}
@@ -0,0 +1,112 @@
+package release
+
+import (
+	"fmt"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// DefaultTemplate is the asset name of the release notes template
+const DefaultTemplate = "release-notes"
+
+// SectionConfig describes a group of changes in the release notes
+type SectionConfig struct {
+	// Title is the heading of the section, e.g. "Features"
+	Title string `yaml:"title" json:"title" mapstructure:"title"`
+
+	// Types are the conventional commit types (feat, fix, ...) and task types (story, bug, ...) in the section
+	Types []string `yaml:"types" json:"types,omitempty" mapstructure:"types"`
+
+	// Labels place tasks with any of these labels in the section, ahead of their type
+	Labels []string `yaml:"labels" json:"labels,omitempty" mapstructure:"labels"`
+}
+
+// Config contains release notes configuration
+type Config struct {
+	// Template is the name of the release notes template in the asset repository
+	Template string `yaml:"template" json:"template" mapstructure:"template"`
+
+	// Sections group changes in order; changes matching no section go under "Other Changes"
+	Sections []SectionConfig `yaml:"sections" json:"sections" mapstructure:"sections"`
+
+	// CompletedStatuses are the task statuses that count as done
+	CompletedStatuses []string `yaml:"completed_statuses" json:"completed_statuses" mapstructure:"completed_statuses"`
+}
+
+// DefaultConfig returns default release notes configuration
+func DefaultConfig() Config {
+	return Config{
+		Template: DefaultTemplate,
+		Sections: []SectionConfig{
+			{Title: "Features", Types: []string{"feat", "feature", "story", "epic"}},
+			{Title: "Bug Fixes", Types: []string{"fix", "bug", "bugfix", "hotfix"}},
+			{Title: "Performance", Types: []string{"perf"}},
+			{Title: "Documentation", Types: []string{"docs"}},
+			{Title: "Maintenance", Types: []string{"chore", "refactor", "build", "ci", "test", "style", "spike"}},
+		},
+		CompletedStatuses: []string{"completed", "done", "closed", "resolved"},
+	}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the release notes configuration
+func (c Config) Validate() error {
+	if c.Template == "" {
+		return fmt.Errorf("template cannot be empty")
+	}
+	for i, section := range c.Sections {
+		if section.Title == "" {
+			return fmt.Errorf("sections[%d]: title cannot be empty", i)
+		}
+		if len(section.Types) == 0 && len(section.Labels) == 0 {
+			return fmt.Errorf("sections[%d]: at least one of types or labels must be set", i)
+		}
+	}
+	return nil
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	// Configured lists replace the defaults rather than merging with them
+	if _, ok := raw["sections"]; ok {
+		cfg.Sections = nil
+	}
+	if _, ok := raw["completed_statuses"]; ok {
+		cfg.CompletedStatuses = nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode release config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for release notes
+func (p ConfigParser) Section() string {
+	return "release"
+}
@@ -0,0 +1,271 @@
+package release
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/task"
+)
+
+// otherSection collects changes that match no configured section
+const otherSection = "Other Changes"
+
+// Notes are the release notes for a range of commits
+type Notes struct {
+	Version      string        `json:"version,omitempty"`
+	From         string        `json:"from"`
+	To           string        `json:"to"`
+	Date         string        `json:"date"`
+	Breaking     []Commit      `json:"breaking"`
+	Sections     []Section     `json:"sections"`
+	Contributors []Contributor `json:"contributors"`
+}
+
+// Section is a group of tasks and commits in the release notes
+type Section struct {
+	Title   string   `json:"title"`
+	Tasks   []Task   `json:"tasks"`
+	Commits []Commit `json:"commits"`
+}
+
+// Task is a completed task referenced by a commit in the release
+type Task struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Type   string   `json:"type"`
+	Labels []string `json:"labels"`
+	URL    string   `json:"url,omitempty"`
+}
+
+// Commit is a commit in the release, parsed as a conventional commit where possible
+type Commit struct {
+	Hash         string    `json:"hash"`
+	ShortHash    string    `json:"short_hash"`
+	Type         string    `json:"type,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Description  string    `json:"description"`
+	Breaking     bool      `json:"breaking"`
+	BreakingNote string    `json:"breaking_note,omitempty"`
+	Author       string    `json:"author"`
+	Email        string    `json:"email"`
+	Date         time.Time `json:"date"`
+	Tasks        []string  `json:"tasks,omitempty"`
+}
+
+// Contributor is an author of commits in the release
+type Contributor struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Commits int    `json:"commits"`
+}
+
+// Conventional is a commit message parsed per the Conventional Commits specification
+type Conventional struct {
+	Type         string
+	Scope        string
+	Description  string
+	Breaking     bool
+	BreakingNote string
+}
+
+var (
+	conventionalSubject = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+	breakingFooter      = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:\s*(.+)$`)
+)
+
+// ParseConventional parses a commit subject and body such as "feat(api)!: drop v1 endpoints".
+// Messages that are not conventional commits keep the subject as the description and have no type.
+func ParseConventional(subject, body string) Conventional {
+	c := Conventional{Description: strings.TrimSpace(subject)}
+
+	if m := conventionalSubject.FindStringSubmatch(c.Description); m != nil {
+		c.Type = strings.ToLower(m[1])
+		c.Scope = m[2]
+		c.Breaking = m[3] == "!"
+		c.Description = m[4]
+	}
+
+	if m := breakingFooter.FindStringSubmatch(body); m != nil {
+		c.Breaking = true
+		c.BreakingNote = strings.TrimSpace(m[1])
+	}
+
+	return c
+}
+
+// Build groups commits and the completed tasks they reference into release notes sections.
+// Merge commits should be excluded by the caller.
+func Build(cfg Config, commits []git.Commit, tasks []*task.Task) *Notes {
+	notes := &Notes{
+		Date:         time.Now().Format("2006-01-02"),
+		Breaking:     []Commit{},
+		Sections:     []Section{},
+		Contributors: []Contributor{},
+	}
+
+	sections := make([]Section, len(cfg.Sections)+1)
+	for i, s := range cfg.Sections {
+		sections[i] = Section{Title: s.Title, Tasks: []Task{}, Commits: []Commit{}}
+	}
+	sections[len(cfg.Sections)] = Section{Title: otherSection, Tasks: []Task{}, Commits: []Commit{}}
+
+	completed := completedTasks(cfg, tasks)
+	referenced := make(map[string]bool)
+	contributors := make(map[string]*Contributor)
+
+	for _, gc := range commits {
+		parsed := ParseConventional(gc.Message, gc.Body)
+		c := Commit{
+			Hash:         gc.Hash,
+			ShortHash:    gc.ShortHash,
+			Type:         parsed.Type,
+			Scope:        parsed.Scope,
+			Description:  parsed.Description,
+			Breaking:     parsed.Breaking,
+			BreakingNote: parsed.BreakingNote,
+			Author:       gc.Author,
+			Email:        gc.Email,
+			Date:         gc.Date,
+		}
+
+		message := gc.Message + "\n" + gc.Body
+		for _, t := range completed {
+			if referencesTask(message, t) {
+				c.Tasks = append(c.Tasks, t.ID)
+				referenced[t.ID] = true
+			}
+		}
+
+		if c.Breaking {
+			notes.Breaking = append(notes.Breaking, c)
+		}
+		i := cfg.sectionIndex(c.Type, nil)
+		sections[i].Commits = append(sections[i].Commits, c)
+
+		key := strings.ToLower(gc.Email)
+		if key == "" {
+			key = gc.Author
+		}
+		if contributors[key] == nil {
+			contributors[key] = &Contributor{Name: gc.Author, Email: gc.Email}
+		}
+		contributors[key].Commits++
+	}
+
+	for _, t := range completed {
+		if !referenced[t.ID] {
+			continue
+		}
+		i := cfg.sectionIndex(t.Type, t.Labels)
+		sections[i].Tasks = append(sections[i].Tasks, Task{
+			ID:     t.ID,
+			Title:  t.Title,
+			Type:   t.Type,
+			Labels: t.Labels,
+			URL:    taskURL(t),
+		})
+	}
+
+	for _, s := range sections {
+		if len(s.Tasks) > 0 || len(s.Commits) > 0 {
+			notes.Sections = append(notes.Sections, s)
+		}
+	}
+
+	for _, c := range contributors {
+		notes.Contributors = append(notes.Contributors, *c)
+	}
+	sort.Slice(notes.Contributors, func(i, j int) bool {
+		a, b := notes.Contributors[i], notes.Contributors[j]
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		return a.Name < b.Name
+	})
+
+	return notes
+}
+
+// sectionIndex returns the section for a change, matching labels before types.
+// Changes that match no section go in the trailing "Other Changes" section.
+func (c Config) sectionIndex(changeType string, labels []string) int {
+	for _, label := range labels {
+		for i, s := range c.Sections {
+			if containsFold(s.Labels, label) {
+				return i
+			}
+		}
+	}
+	if changeType != "" {
+		for i, s := range c.Sections {
+			if containsFold(s.Types, changeType) {
+				return i
+			}
+		}
+	}
+	return len(c.Sections)
+}
+
+// completedTasks returns the tasks with a completed status, ordered by ID
+func completedTasks(cfg Config, tasks []*task.Task) []*task.Task {
+	var completed []*task.Task
+	for _, t := range tasks {
+		if containsFold(cfg.CompletedStatuses, t.Status) {
+			completed = append(completed, t)
+		}
+	}
+	sort.Slice(completed, func(i, j int) bool { return completed[i].ID < completed[j].ID })
+	return completed
+}
+
+// referencesTask reports whether a commit message mentions the task ID or the ID of an
+// external issue the task is synced with, as a whole ID so "PROJ-1" does not match "PROJ-12"
+func referencesTask(message string, t *task.Task) bool {
+	refs := []string{t.ID}
+	for _, source := range t.Sources {
+		if source.ExternalID != "" {
+			refs = append(refs, source.ExternalID)
+		}
+	}
+	for _, ref := range refs {
+		pattern := regexp.MustCompile(`(^|[^A-Za-z0-9_-])` + regexp.QuoteMeta(ref) + `($|[^A-Za-z0-9_])`)
+		if pattern.MatchString(message) {
+			return true
+		}
+	}
+	return false
+}
+
+// taskURL returns the URL of the first external issue the task is synced with
+func taskURL(t *task.Task) string {
+	systems := make([]string, 0, len(t.Sources))
+	for system := range t.Sources {
+		systems = append(systems, system)
+	}
+	sort.Strings(systems)
+
+	for _, system := range systems {
+		source := t.Sources[system]
+		if source.ExternalURL != "" {
+			return source.ExternalURL
+		}
+		if data, ok := source.Metadata["task_data"].(map[string]interface{}); ok {
+			if url, _ := data["external_url"].(string); url != "" {
+				return url
+			}
+		}
+	}
+	return ""
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
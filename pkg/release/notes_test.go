@@ -0,0 +1,164 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/clients/git"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type assetClient struct {
+	assets.AssetClientInterface
+	templates map[string]string
+}
+
+func (c *assetClient) GetAsset(ctx context.Context, name string, opts assets.GetAssetOptions) (*assets.AssetContent, error) {
+	content, ok := c.templates[name]
+	if !ok {
+		return nil, errors.New("asset not found")
+	}
+	return &assets.AssetContent{Metadata: assets.AssetMetadata{Name: name}, Content: content}, nil
+}
+
+func commit(hash, author, subject, body string) git.Commit {
+	return git.Commit{
+		Hash:      hash + "0000000000",
+		ShortHash: hash + "0000",
+		Author:    author,
+		Email:     author + "@example.com",
+		Date:      time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Message:   subject,
+		Body:      body,
+	}
+}
+
+func testNotes() *Notes {
+	commits := []git.Commit{
+		commit("aaaa", "ana", "feat(auth): add SSO login", "Closes PROJ-1"),
+		commit("bbbb", "ben", "fix: handle expired sessions", ""),
+		commit("cccc", "ana", "refactor!: drop legacy config", "BREAKING CHANGE: the v1 config format is no longer read"),
+		commit("dddd", "ana", "Update README for PROJ-2", ""),
+	}
+	tasks := []*task.Task{
+		{ID: "PROJ-1", Title: "Single sign-on", Type: "story", Status: "completed"},
+		{ID: "PROJ-2", Title: "Docs refresh", Type: "task", Status: "done", Labels: []string{"docs"}},
+		{ID: "PROJ-3", Title: "Unreleased", Type: "story", Status: "completed"},
+		{ID: "PROJ-12", Title: "In progress", Type: "bug", Status: "in_progress"},
+	}
+
+	cfg := DefaultConfig()
+	cfg.Sections[3].Labels = []string{"docs"}
+	return Build(cfg, commits, tasks)
+}
+
+func TestParseConventional(t *testing.T) {
+	tests := []struct {
+		subject string
+		body    string
+		want    Conventional
+	}{
+		{"feat(api): add users endpoint", "", Conventional{Type: "feat", Scope: "api", Description: "add users endpoint"}},
+		{"Fix!: remove v1", "", Conventional{Type: "fix", Description: "remove v1", Breaking: true}},
+		{"chore: bump deps", "BREAKING CHANGE: requires Go 1.23", Conventional{Type: "chore", Description: "bump deps", Breaking: true, BreakingNote: "requires Go 1.23"}},
+		{"Update README", "", Conventional{Description: "Update README"}},
+		{"WIP: feat(x): nested", "", Conventional{Type: "wip", Description: "feat(x): nested"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseConventional(tt.subject, tt.body))
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	notes := testNotes()
+
+	titles := make([]string, 0, len(notes.Sections))
+	for _, s := range notes.Sections {
+		titles = append(titles, s.Title)
+	}
+	assert.Equal(t, []string{"Features", "Bug Fixes", "Documentation", "Maintenance", "Other Changes"}, titles)
+
+	features := notes.Sections[0]
+	require.Len(t, features.Tasks, 1)
+	assert.Equal(t, "PROJ-1", features.Tasks[0].ID)
+	require.Len(t, features.Commits, 1)
+	assert.Equal(t, "auth", features.Commits[0].Scope)
+	assert.Equal(t, []string{"PROJ-1"}, features.Commits[0].Tasks)
+
+	// Labels take precedence over the task type
+	docs := notes.Sections[2]
+	require.Len(t, docs.Tasks, 1)
+	assert.Equal(t, "PROJ-2", docs.Tasks[0].ID)
+	assert.Empty(t, docs.Commits)
+
+	require.Len(t, notes.Breaking, 1)
+	assert.Equal(t, "the v1 config format is no longer read", notes.Breaking[0].BreakingNote)
+
+	other := notes.Sections[4]
+	require.Len(t, other.Commits, 1)
+	assert.Equal(t, "Update README for PROJ-2", other.Commits[0].Description)
+
+	assert.Equal(t, []Contributor{
+		{Name: "ana", Email: "ana@example.com", Commits: 3},
+		{Name: "ben", Email: "ben@example.com", Commits: 1},
+	}, notes.Contributors)
+}
+
+func TestRender_DefaultTemplate(t *testing.T) {
+	engine := template.NewEngine(logging.NewBasic(), &assetClient{}, template.DefaultConfig())
+	notes := testNotes()
+	notes.Version = "v1.3.0"
+	notes.Date = "2026-10-16"
+
+	out, err := Render(context.Background(), engine, DefaultTemplate, notes)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "## v1.3.0 (2026-10-16)")
+	assert.Contains(t, out, "### ⚠ Breaking Changes\n\n- drop legacy config (cccc0000)\n  the v1 config format is no longer read")
+	assert.Contains(t, out, "### Features\n\n- PROJ-1 Single sign-on\n- **auth:** add SSO login (aaaa0000, PROJ-1) by ana")
+	assert.Contains(t, out, "### Contributors\n\n- ana (3 commits)\n- ben (1 commit)")
+}
+
+func TestRender_AssetTemplate(t *testing.T) {
+	client := &assetClient{templates: map[string]string{
+		"team-notes": "{{ .version }}:{{ range .sections }} {{ .title }}{{ end }}",
+	}}
+	engine := template.NewEngine(logging.NewBasic(), client, template.DefaultConfig())
+	notes := testNotes()
+	notes.Version = "v1.3.0"
+
+	out, err := Render(context.Background(), engine, "team-notes", notes)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.3.0: Features Bug Fixes Documentation Maintenance Other Changes", out)
+
+	_, err = Render(context.Background(), engine, "missing", notes)
+	assert.Error(t, err)
+}
+
+func TestConfigParser_Parse(t *testing.T) {
+	cfg, err := ConfigParser{}.Parse(map[string]interface{}{
+		"template": "team-notes",
+		"sections": []interface{}{
+			map[string]interface{}{"title": "Security", "labels": []interface{}{"security"}},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, "team-notes", cfg.Template)
+	assert.Equal(t, []SectionConfig{{Title: "Security", Labels: []string{"security"}}}, cfg.Sections)
+	assert.Equal(t, DefaultConfig().CompletedStatuses, cfg.CompletedStatuses)
+
+	cfg.Sections = append(cfg.Sections, SectionConfig{Title: "Empty"})
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,58 @@
+package release
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/daddia/zen/pkg/template"
+)
+
+// defaultTemplate is used when the asset repository has no release notes template
+//
+//go:embed templates/release-notes.md.tmpl
+var defaultTemplate string
+
+// Render renders the notes with the named template from the asset repository. When the
+// default template is not available there, the built-in template is used instead.
+//
+// Templates receive the notes under their JSON field names, e.g. {{ .version }} and
+// {{ range .sections }}{{ .title }}{{ end }}.
+func Render(ctx context.Context, engine template.TemplateEngine, name string, notes *Notes) (string, error) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+
+	tmpl, err := engine.LoadTemplate(ctx, name)
+	if err != nil {
+		if name != DefaultTemplate {
+			return "", err
+		}
+		tmpl, err = engine.CompileTemplate(ctx, name, defaultTemplate, &template.TemplateMetadata{Name: name})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	variables, err := templateVariables(notes)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.RenderTemplate(ctx, tmpl, variables)
+}
+
+// templateVariables converts notes to template variables keyed by their JSON field names
+func templateVariables(notes *Notes) (map[string]interface{}, error) {
+	data, err := json.Marshal(notes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode release notes: %w", err)
+	}
+
+	var variables map[string]interface{}
+	if err := json.Unmarshal(data, &variables); err != nil {
+		return nil, fmt.Errorf("failed to encode release notes: %w", err)
+	}
+	return variables, nil
+}
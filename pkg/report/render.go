@@ -0,0 +1,131 @@
+package report
+
+import (
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/template"
+)
+
+// Formats a report is rendered in
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatCSV      = "csv"
+)
+
+// Asset names of the report templates
+const (
+	DefaultMarkdownTemplate = "task-report"
+	DefaultHTMLTemplate     = "task-report-html"
+)
+
+// Built-in templates, used when the asset repository has no report templates
+var (
+	//go:embed templates/task-report.md.tmpl
+	defaultMarkdownTemplate string
+
+	//go:embed templates/task-report.html.tmpl
+	defaultHTMLTemplate string
+)
+
+// Render renders the report as Markdown or HTML with the named template from the asset
+// repository, or the default template of the format when name is empty. When the
+// default template is not available there, the built-in template is used instead.
+//
+// Templates receive the report under its JSON field names, e.g. {{ .total }} and
+// {{ range .stages }}{{ .name }}{{ end }}, and .show, which is true for each section
+// to show, e.g. {{ if .show.cycle_time }}.
+func Render(ctx context.Context, engine template.TemplateEngine, format, name string, r *Report) (string, error) {
+	defaultName, builtin := DefaultMarkdownTemplate, defaultMarkdownTemplate
+	switch format {
+	case FormatMarkdown:
+	case FormatHTML:
+		defaultName, builtin = DefaultHTMLTemplate, defaultHTMLTemplate
+	default:
+		return "", fmt.Errorf("reports cannot be rendered with templates as %s", format)
+	}
+	if name == "" {
+		name = defaultName
+	}
+
+	tmpl, err := engine.LoadTemplate(ctx, name)
+	if err != nil {
+		if name != defaultName {
+			return "", err
+		}
+		tmpl, err = engine.CompileTemplate(ctx, name, builtin, &template.TemplateMetadata{Name: name})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	variables, err := templateVariables(r)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.RenderTemplate(ctx, tmpl, variables)
+}
+
+// templateVariables converts a report to template variables keyed by their JSON field
+// names
+func templateVariables(r *Report) (map[string]interface{}, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	var variables map[string]interface{}
+	if err := json.Unmarshal(data, &variables); err != nil {
+		return nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	show := map[string]interface{}{}
+	for _, section := range r.Sections {
+		show[strings.ReplaceAll(section, "-", "_")] = true
+	}
+	variables["show"] = show
+	return variables, nil
+}
+
+// csvHeader is the header row of CSV reports
+var csvHeader = []string{"id", "title", "type", "status", "priority", "stage", "owner", "team", "labels", "started", "finished", "cycle_time_days", "url"}
+
+// WriteCSV writes a row for each task in the report, for spreadsheets
+func WriteCSV(w io.Writer, r *Report) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, t := range r.Tasks {
+		var started, finished, cycleTime string
+		if t.Started != nil {
+			started = t.Started.Format(time.RFC3339)
+		}
+		if t.Finished != nil {
+			finished = t.Finished.Format(time.RFC3339)
+		}
+		if t.CycleTimeDays != nil {
+			cycleTime = strconv.FormatFloat(*t.CycleTimeDays, 'f', -1, 64)
+		}
+		row := []string{
+			t.ID, t.Title, t.Type, t.Status, t.Priority, t.Stage, t.Owner, t.Team,
+			strings.Join(t.Labels, ";"), started, finished, cycleTime, t.URL,
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
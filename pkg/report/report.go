@@ -0,0 +1,299 @@
+// Package report builds status reports of workspace tasks: tasks grouped by workflow
+// stage and by owner, with cycle-time statistics, rendered as Markdown, CSV, or a
+// standalone HTML page.
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/task"
+)
+
+// Sections of a report
+const (
+	SectionStages    = "stages"
+	SectionOwners    = "owners"
+	SectionCycleTime = "cycle-time"
+	SectionTasks     = "tasks"
+)
+
+// Sections are the sections of a report, in the order they are shown
+var Sections = []string{SectionStages, SectionOwners, SectionCycleTime, SectionTasks}
+
+// Names for tasks without a stage or an owner
+const (
+	noStage = "Not started"
+	noOwner = "Unassigned"
+)
+
+// Report is a report of workspace tasks
+type Report struct {
+	Title     string     `json:"title"`
+	Date      string     `json:"date"`
+	Total     int        `json:"total"`
+	Sections  []string   `json:"sections"`
+	Stages    []Group    `json:"stages,omitempty"`
+	Owners    []Group    `json:"owners,omitempty"`
+	CycleTime *CycleTime `json:"cycle_time,omitempty"`
+
+	// Tasks are every task reported on, whether or not the tasks section is shown
+	Tasks []Task `json:"tasks"`
+}
+
+// Group is the tasks in one stage, or of one owner
+type Group struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Tasks []string `json:"tasks"`
+}
+
+// CycleTime summarizes the time from 'zen task start' to 'zen task finish', in days, of
+// the finished tasks in a report
+type CycleTime struct {
+	Finished   int     `json:"finished"`
+	InProgress int     `json:"in_progress"`
+	MeanDays   float64 `json:"mean_days"`
+	MedianDays float64 `json:"median_days"`
+	P85Days    float64 `json:"p85_days"`
+	MinDays    float64 `json:"min_days"`
+	MaxDays    float64 `json:"max_days"`
+}
+
+// Task is one task in a report
+type Task struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Type          string     `json:"type"`
+	Status        string     `json:"status"`
+	Priority      string     `json:"priority"`
+	Owner         string     `json:"owner"`
+	Team          string     `json:"team"`
+	Stage         string     `json:"stage"`
+	Labels        []string   `json:"labels"`
+	Started       *time.Time `json:"started,omitempty"`
+	Finished      *time.Time `json:"finished,omitempty"`
+	CycleTimeDays *float64   `json:"cycle_time_days,omitempty"`
+	URL           string     `json:"url,omitempty"`
+}
+
+// ValidateSections returns an error naming the first of sections that is unknown
+func ValidateSections(sections []string) error {
+	for _, name := range sections {
+		if !contains(Sections, name) {
+			return fmt.Errorf("unknown report section %q; valid sections are: %s", name, strings.Join(Sections, ", "))
+		}
+	}
+	return nil
+}
+
+// Build reports on tasks with the named sections, or every section when none are named.
+// Tasks are listed by ID.
+func Build(tasks []*task.Task, sections []string, now time.Time) *Report {
+	if len(sections) == 0 {
+		sections = Sections
+	}
+	r := &Report{
+		Title: "Task Report",
+		Date:  now.Format("2006-01-02"),
+		Total: len(tasks),
+	}
+	for _, name := range Sections {
+		if contains(sections, name) {
+			r.Sections = append(r.Sections, name)
+		}
+	}
+
+	sorted := append([]*task.Task(nil), tasks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	rows := make([]Task, len(sorted))
+	for i, t := range sorted {
+		rows[i] = newTask(t)
+	}
+
+	if contains(r.Sections, SectionStages) {
+		r.Stages = groupByStage(sorted)
+	}
+	if contains(r.Sections, SectionOwners) {
+		r.Owners = groupByOwner(sorted)
+	}
+	if contains(r.Sections, SectionCycleTime) {
+		r.CycleTime = cycleTime(rows)
+	}
+	r.Tasks = rows
+	return r
+}
+
+func newTask(t *task.Task) Task {
+	row := Task{
+		ID:       t.ID,
+		Title:    t.Title,
+		Type:     t.Type,
+		Status:   t.Status,
+		Priority: t.Priority,
+		Owner:    t.Owner,
+		Team:     t.Team,
+		Stage:    stageName(t.CurrentStage),
+		Labels:   t.Labels,
+		URL:      taskURL(t),
+	}
+	if row.Labels == nil {
+		row.Labels = []string{}
+	}
+	if t.Git != nil {
+		row.Started, row.Finished = t.Git.StartedAt, t.Git.FinishedAt
+		if row.Started != nil && row.Finished != nil && !row.Finished.Before(*row.Started) {
+			days := roundDays(row.Finished.Sub(*row.Started).Hours() / 24)
+			row.CycleTimeDays = &days
+		}
+	}
+	return row
+}
+
+// groupByStage groups tasks by workflow stage, in workflow order, with tasks in no
+// known stage last
+func groupByStage(tasks []*task.Task) []Group {
+	groups := make([]Group, len(task.WorkflowStages)+1)
+	for i, stage := range task.WorkflowStages {
+		groups[i] = Group{Name: task.StageName(stage), Tasks: []string{}}
+	}
+	groups[len(task.WorkflowStages)] = Group{Name: noStage, Tasks: []string{}}
+
+	for _, t := range tasks {
+		i := task.StageIndex(t.CurrentStage)
+		if i < 0 {
+			i = len(task.WorkflowStages)
+		}
+		groups[i].Count++
+		groups[i].Tasks = append(groups[i].Tasks, t.ID)
+	}
+
+	var nonEmpty []Group
+	for _, g := range groups {
+		if g.Count > 0 {
+			nonEmpty = append(nonEmpty, g)
+		}
+	}
+	return nonEmpty
+}
+
+// groupByOwner groups tasks by owner, busiest first, with unassigned tasks last
+func groupByOwner(tasks []*task.Task) []Group {
+	byOwner := map[string]*Group{}
+	for _, t := range tasks {
+		owner := t.Owner
+		if owner == "" {
+			owner = noOwner
+		}
+		if byOwner[owner] == nil {
+			byOwner[owner] = &Group{Name: owner, Tasks: []string{}}
+		}
+		byOwner[owner].Count++
+		byOwner[owner].Tasks = append(byOwner[owner].Tasks, t.ID)
+	}
+
+	groups := make([]Group, 0, len(byOwner))
+	for _, g := range byOwner {
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if (a.Name == noOwner) != (b.Name == noOwner) {
+			return b.Name == noOwner
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Name < b.Name
+	})
+	return groups
+}
+
+// cycleTime summarizes the cycle times of rows
+func cycleTime(rows []Task) *CycleTime {
+	var days []float64
+	inProgress := 0
+	for _, row := range rows {
+		switch {
+		case row.CycleTimeDays != nil:
+			days = append(days, *row.CycleTimeDays)
+		case row.Started != nil && row.Finished == nil:
+			inProgress++
+		}
+	}
+
+	ct := &CycleTime{Finished: len(days), InProgress: inProgress}
+	if len(days) == 0 {
+		return ct
+	}
+	sort.Float64s(days)
+
+	total := 0.0
+	for _, d := range days {
+		total += d
+	}
+	ct.MeanDays = roundDays(total / float64(len(days)))
+	ct.MedianDays = roundDays(percentile(days, 50))
+	ct.P85Days = roundDays(percentile(days, 85))
+	ct.MinDays = days[0]
+	ct.MaxDays = days[len(days)-1]
+	return ct
+}
+
+// percentile returns the pth percentile of sorted values, interpolating between the
+// closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// roundDays rounds a number of days to one decimal place
+func roundDays(days float64) float64 {
+	return math.Round(days*10) / 10
+}
+
+func stageName(stage string) string {
+	if task.StageIndex(stage) < 0 {
+		return noStage
+	}
+	return task.StageName(stage)
+}
+
+// taskURL returns the web URL of the task in the first of its external sources that has one
+func taskURL(t *task.Task) string {
+	systems := make([]string, 0, len(t.Sources))
+	for system := range t.Sources {
+		systems = append(systems, system)
+	}
+	sort.Strings(systems)
+
+	for _, system := range systems {
+		source := t.Sources[system]
+		if source == nil {
+			continue
+		}
+		// Only web links, since reports link to the URL
+		if strings.HasPrefix(source.ExternalURL, "https://") || strings.HasPrefix(source.ExternalURL, "http://") {
+			return source.ExternalURL
+		}
+	}
+	return ""
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
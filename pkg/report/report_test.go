@@ -0,0 +1,147 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/template"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+func at(day int) *time.Time {
+	t := time.Date(2026, 10, day, 9, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func testTasks() []*task.Task {
+	return []*task.Task{
+		{ID: "PROJ-3", Title: "Document <the> API", Type: "task", Status: "proposed", CurrentStage: "01-align"},
+		{ID: "PROJ-1", Title: "Add login", Type: "story", Status: "done", Owner: "ana", CurrentStage: "06-ship",
+			Labels: []string{"auth", "web"},
+			Git:    &task.GitInfo{Branch: "feat/PROJ-1", StartedAt: at(1), FinishedAt: at(5)},
+			Sources: map[string]*task.TaskSource{
+				"jira": {ExternalURL: "https://example.atlassian.net/browse/PROJ-1"},
+			}},
+		{ID: "PROJ-2", Title: "Fix timeout", Type: "bug", Status: "done", Owner: "ana", CurrentStage: "06-ship",
+			Git: &task.GitInfo{Branch: "fix/PROJ-2", StartedAt: at(2), FinishedAt: at(4)}},
+		{ID: "PROJ-4", Title: "Rotate keys", Type: "task", Status: "in_progress", Owner: "ben", CurrentStage: "05-build",
+			Git: &task.GitInfo{Branch: "feat/PROJ-4", StartedAt: at(10)},
+			Sources: map[string]*task.TaskSource{
+				"jira": {ExternalURL: "javascript:alert(1)"},
+			}},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	r := Build(testTasks(), nil, testNow)
+
+	assert.Equal(t, "2026-10-16", r.Date)
+	assert.Equal(t, 4, r.Total)
+	assert.Equal(t, Sections, r.Sections)
+
+	assert.Equal(t, []Group{
+		{Name: "Align", Count: 1, Tasks: []string{"PROJ-3"}},
+		{Name: "Build", Count: 1, Tasks: []string{"PROJ-4"}},
+		{Name: "Ship", Count: 2, Tasks: []string{"PROJ-1", "PROJ-2"}},
+	}, r.Stages, "stages in workflow order")
+
+	assert.Equal(t, []Group{
+		{Name: "ana", Count: 2, Tasks: []string{"PROJ-1", "PROJ-2"}},
+		{Name: "ben", Count: 1, Tasks: []string{"PROJ-4"}},
+		{Name: "Unassigned", Count: 1, Tasks: []string{"PROJ-3"}},
+	}, r.Owners, "busiest owners first, unassigned last")
+
+	assert.Equal(t, &CycleTime{
+		Finished:   2,
+		InProgress: 1,
+		MeanDays:   3,
+		MedianDays: 3,
+		P85Days:    3.7,
+		MinDays:    2,
+		MaxDays:    4,
+	}, r.CycleTime)
+
+	require.Len(t, r.Tasks, 4)
+	assert.Equal(t, "PROJ-1", r.Tasks[0].ID, "tasks by ID")
+	assert.Equal(t, "https://example.atlassian.net/browse/PROJ-1", r.Tasks[0].URL)
+	assert.Empty(t, r.Tasks[3].URL, "only web links")
+}
+
+func TestBuild_Sections(t *testing.T) {
+	r := Build(testTasks(), []string{SectionTasks, SectionOwners}, testNow)
+
+	assert.Equal(t, []string{SectionOwners, SectionTasks}, r.Sections, "in report order")
+	assert.Nil(t, r.Stages)
+	assert.Nil(t, r.CycleTime)
+	assert.NotEmpty(t, r.Owners)
+
+	assert.NoError(t, ValidateSections([]string{"stages", "cycle-time"}))
+	assert.Error(t, ValidateSections([]string{"velocity"}))
+}
+
+func TestRender_DefaultTemplates(t *testing.T) {
+	engine := template.NewEngine(logging.NewBasic(), zentest.NewAssetClient(), template.DefaultConfig())
+	r := Build(testTasks(), nil, testNow)
+
+	out, err := Render(context.Background(), engine, FormatMarkdown, "", r)
+	require.NoError(t, err)
+	assert.Contains(t, out, "# Task Report (2026-10-16)\n\n4 tasks")
+	assert.Contains(t, out, "| Ship | 2 | PROJ-1, PROJ-2 |")
+	assert.Contains(t, out, "| Unassigned | 1 | PROJ-3 |")
+	assert.Contains(t, out, "| 2 | 3d | 3d | 3.7d | 2d | 4d |")
+	assert.Contains(t, out, "1 in progress")
+	assert.Contains(t, out, "| [PROJ-1](https://example.atlassian.net/browse/PROJ-1) | Add login | story | done | Ship | ana |")
+
+	out, err = Render(context.Background(), engine, FormatHTML, "", r)
+	require.NoError(t, err)
+	assert.Contains(t, out, "<!DOCTYPE html>")
+	assert.Contains(t, out, "<td>Document &lt;the&gt; API</td>", "task fields are escaped")
+	assert.NotContains(t, out, "javascript:")
+
+	out, err = Render(context.Background(), engine, FormatMarkdown, "", Build(testTasks(), []string{SectionStages}, testNow))
+	require.NoError(t, err)
+	assert.Contains(t, out, "## By Stage")
+	assert.NotContains(t, out, "## By Owner")
+	assert.NotContains(t, out, "## Tasks")
+}
+
+func TestRender_AssetTemplate(t *testing.T) {
+	client := zentest.NewAssetClient()
+	client.Add(assets.AssetMetadata{Name: "weekly-status"}, "{{ .title }}:{{ range .owners }} {{ .name }}={{ .count }}{{ end }}")
+	engine := template.NewEngine(logging.NewBasic(), client, template.DefaultConfig())
+
+	out, err := Render(context.Background(), engine, FormatMarkdown, "weekly-status", Build(testTasks(), nil, testNow))
+	require.NoError(t, err)
+	assert.Equal(t, "Task Report: ana=2 ben=1 Unassigned=1", out)
+
+	_, err = Render(context.Background(), engine, FormatMarkdown, "missing", Build(testTasks(), nil, testNow))
+	assert.Error(t, err)
+
+	_, err = Render(context.Background(), engine, FormatCSV, "", Build(testTasks(), nil, testNow))
+	assert.Error(t, err)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, Build(testTasks(), []string{SectionStages}, testNow)))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 5, "a header and every task, whatever the sections")
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, []string{
+		"PROJ-1", "Add login", "story", "done", "", "Ship", "ana", "", "auth;web",
+		"2026-10-01T09:00:00Z", "2026-10-05T09:00:00Z", "4", "https://example.atlassian.net/browse/PROJ-1",
+	}, records[1])
+	assert.Equal(t, "", records[4][11], "no cycle time until the task is finished")
+}
@@ -0,0 +1,576 @@
+// Package search keeps a full-text index of the content of a workspace: the index.md
+// and manifest of each task, the attachments recorded in its manifest, and its decision
+// records and other documents. The index is stored as JSON under .zen/cache and updated
+// incrementally, reindexing only the files that changed since the last update.
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// indexVersion is bumped when the stored index format changes, which discards indexes
+// of older versions
+const indexVersion = 1
+
+// Document kinds
+const (
+	KindTask       = "task"
+	KindManifest   = "manifest"
+	KindAttachment = "attachment"
+	KindADR        = "adr"
+	KindDocument   = "document"
+)
+
+// Kinds lists the document kinds
+var Kinds = []string{KindTask, KindManifest, KindAttachment, KindADR, KindDocument}
+
+// skippedDirs are the directories of a task that hold no searchable documents
+var skippedDirs = map[string]bool{"attachments": true, "metadata": true, ".zenflow": true}
+
+// IndexPath returns the path of the search index of the workspace with zenDir
+func IndexPath(zenDir string) string {
+	return filepath.Join(zenDir, "cache", "search-index.json")
+}
+
+// Document is an indexed document
+type Document struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	TaskID string `json:"task_id"`
+	Title  string `json:"title"`
+
+	// Path is the file the document was read from, relative to the workspace root
+	Path string `json:"path"`
+
+	// Text is shown for documents that are not the content of their file, such as
+	// attachments
+	Text string `json:"text,omitempty"`
+
+	Terms  map[string]int `json:"terms"`
+	Length int            `json:"length"`
+}
+
+// TaskInfo is the metadata of a task that results are filtered by
+type TaskInfo struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Stage  string `json:"stage"`
+	Owner  string `json:"owner"`
+}
+
+// fileState is an indexed file with the documents read from it
+type fileState struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Docs    []string  `json:"docs"`
+}
+
+// Index is the search index of a workspace
+type Index struct {
+	Version int                   `json:"version"`
+	Files   map[string]*fileState `json:"files"`
+	Docs    map[string]*Document  `json:"docs"`
+	Tasks   map[string]*TaskInfo  `json:"tasks"`
+
+	root string
+	path string
+}
+
+// UpdateStats reports what an update of the index did
+type UpdateStats struct {
+	Indexed   int `json:"indexed"`
+	Removed   int `json:"removed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Query selects documents
+type Query struct {
+	// Text is the words documents must all contain; a word ending in * matches the
+	// words it starts. An empty text matches every task.
+	Text string
+
+	// Stage, Owner and Type filter results by their task; a stage is matched by its ID
+	// or name, such as "04-design" or "design"
+	Stage string
+	Owner string
+	Type  string
+
+	// Kinds limits results to these document kinds
+	Kinds []string
+
+	Limit int
+}
+
+// Result is a document matching a query
+type Result struct {
+	Kind    string  `json:"kind"`
+	TaskID  string  `json:"task_id"`
+	Title   string  `json:"title"`
+	Path    string  `json:"path"`
+	Stage   string  `json:"stage"`
+	Owner   string  `json:"owner"`
+	Type    string  `json:"type"`
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet"`
+}
+
+// New returns an empty index for the workspace at root, stored at indexPath
+func New(root, indexPath string) *Index {
+	return &Index{
+		Version: indexVersion,
+		Files:   map[string]*fileState{},
+		Docs:    map[string]*Document{},
+		Tasks:   map[string]*TaskInfo{},
+		root:    root,
+		path:    indexPath,
+	}
+}
+
+// Open loads the index stored at indexPath for the workspace at root, or returns an
+// empty index when there is none or it is of another version
+func Open(root, indexPath string) (*Index, error) {
+	index := New(root, indexPath)
+
+	data, err := os.ReadFile(indexPath) // #nosec G304 - reading search index from workspace path
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search index: %w", err)
+	}
+
+	var stored Index
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != indexVersion {
+		// A corrupt or outdated index is rebuilt
+		return index, nil
+	}
+	if stored.Files != nil && stored.Docs != nil && stored.Tasks != nil {
+		index.Files, index.Docs, index.Tasks = stored.Files, stored.Docs, stored.Tasks
+	}
+	return index, nil
+}
+
+// Save writes the index to its path
+func (i *Index) Save() error {
+	if err := os.MkdirAll(filepath.Dir(i.path), 0750); err != nil {
+		return fmt.Errorf("failed to create search index directory: %w", err)
+	}
+
+	data, err := json.Marshal(i)
+	if err != nil {
+		return fmt.Errorf("failed to encode search index: %w", err)
+	}
+
+	temp := i.path + ".tmp"
+	if err := os.WriteFile(temp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	if err := os.Rename(temp, i.path); err != nil {
+		os.Remove(temp)
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	return nil
+}
+
+// Update brings the index up to date with the tasks of the workspace, reindexing the
+// files added or changed since the last update and dropping those removed
+func (i *Index) Update() (*UpdateStats, error) {
+	stats := &UpdateStats{}
+	seen := map[string]bool{}
+
+	tasksDir := filepath.Join(i.root, ".zen", "work", "tasks")
+	entries, err := os.ReadDir(tasksDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read tasks directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		taskDir := filepath.Join(tasksDir, entry.Name())
+		err := filepath.WalkDir(taskDir, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if file != taskDir && skippedDirs[d.Name()] {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !isIndexed(taskDir, file) {
+				return nil
+			}
+
+			rel := i.relative(file)
+			seen[rel] = true
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			if state, ok := i.Files[rel]; ok && state.ModTime.Equal(info.ModTime()) && state.Size == info.Size() {
+				stats.Unchanged++
+				return nil
+			}
+			if err := i.indexFile(entry.Name(), file, info); err != nil {
+				return err
+			}
+			stats.Indexed++
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for rel := range i.Files {
+		if !seen[rel] {
+			i.removeFile(rel)
+			stats.Removed++
+		}
+	}
+	return stats, nil
+}
+
+// isIndexed reports whether a file of a task directory is indexed: its index.md and
+// manifest.yaml, and the markdown documents in its work-type directories
+func isIndexed(taskDir, file string) bool {
+	rel, err := filepath.Rel(taskDir, file)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "index.md" || rel == "manifest.yaml" {
+		return true
+	}
+	return strings.Contains(rel, "/") && strings.EqualFold(path.Ext(rel), ".md")
+}
+
+// indexFile replaces the documents read from a file
+func (i *Index) indexFile(taskID, file string, info fs.FileInfo) error {
+	rel := i.relative(file)
+	i.removeFile(rel)
+
+	data, err := os.ReadFile(file) // #nosec G304 - reading task files from workspace path
+	if err != nil {
+		return fmt.Errorf("failed to index %s: %w", rel, err)
+	}
+
+	var docs []*Document
+	switch path.Base(rel) {
+	case "manifest.yaml":
+		docs = i.indexManifest(taskID, rel, data)
+	case "index.md":
+		docs = []*Document{newDocument("task:"+taskID, KindTask, taskID, heading(data, taskID), rel, string(data))}
+	default:
+		kind := KindDocument
+		if strings.Contains(rel, "/design/decisions/") {
+			kind = KindADR
+		}
+		docs = []*Document{newDocument("doc:"+rel, kind, taskID, heading(data, path.Base(rel)), rel, string(data))}
+	}
+
+	state := &fileState{ModTime: info.ModTime(), Size: info.Size()}
+	for _, doc := range docs {
+		i.Docs[doc.ID] = doc
+		state.Docs = append(state.Docs, doc.ID)
+	}
+	i.Files[rel] = state
+	return nil
+}
+
+// indexManifest reads the task metadata and attachments of a manifest
+func (i *Index) indexManifest(taskID, rel string, data []byte) []*Document {
+	var manifest struct {
+		Task struct {
+			ID     string `yaml:"id"`
+			Title  string `yaml:"title"`
+			Type   string `yaml:"type"`
+			Status string `yaml:"status"`
+		} `yaml:"task"`
+		Owner struct {
+			Name string `yaml:"name"`
+		} `yaml:"owner"`
+		Workflow struct {
+			CurrentStage string `yaml:"current_stage"`
+		} `yaml:"workflow"`
+		Attachments []struct {
+			Name string `yaml:"name"`
+			Path string `yaml:"path"`
+			URL  string `yaml:"url"`
+		} `yaml:"attachments"`
+	}
+	// A manifest that does not parse is still searchable as text
+	_ = yaml.Unmarshal(data, &manifest)
+
+	i.Tasks[taskID] = &TaskInfo{
+		ID:     taskID,
+		Title:  manifest.Task.Title,
+		Type:   manifest.Task.Type,
+		Status: manifest.Task.Status,
+		Stage:  manifest.Workflow.CurrentStage,
+		Owner:  manifest.Owner.Name,
+	}
+
+	docs := []*Document{newDocument("manifest:"+taskID, KindManifest, taskID, "manifest.yaml", rel, string(data))}
+	for _, attachment := range manifest.Attachments {
+		location := attachment.URL
+		if location == "" {
+			location = path.Join(path.Dir(rel), attachment.Path)
+		}
+		doc := newDocument("attachment:"+taskID+"/"+attachment.Name, KindAttachment, taskID, attachment.Name, rel, attachment.Name+" "+location)
+		doc.Text = location
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// removeFile drops a file and the documents read from it
+func (i *Index) removeFile(rel string) {
+	state, ok := i.Files[rel]
+	if !ok {
+		return
+	}
+	for _, id := range state.Docs {
+		delete(i.Docs, id)
+	}
+	delete(i.Files, rel)
+	if path.Base(rel) == "manifest.yaml" {
+		delete(i.Tasks, path.Base(path.Dir(rel)))
+	}
+}
+
+func (i *Index) relative(file string) string {
+	rel, err := filepath.Rel(i.root, file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Search returns the documents matching a query, best first
+func (i *Index) Search(query Query) []Result {
+	terms := queryTerms(query.Text)
+	kinds := query.Kinds
+	if len(terms) == 0 && len(kinds) == 0 {
+		kinds = []string{KindTask}
+	}
+
+	idf := i.idf(terms)
+
+	var results []Result
+	for _, doc := range i.Docs {
+		if len(kinds) > 0 && !contains(kinds, doc.Kind) {
+			continue
+		}
+		task := i.Tasks[doc.TaskID]
+		if task == nil {
+			task = &TaskInfo{ID: doc.TaskID}
+		}
+		if !matchesTask(task, query) {
+			continue
+		}
+
+		score, ok := score(doc, terms, idf)
+		if !ok {
+			continue
+		}
+		results = append(results, Result{
+			Kind:   doc.Kind,
+			TaskID: doc.TaskID,
+			Title:  doc.Title,
+			Path:   doc.Path,
+			Stage:  task.Stage,
+			Owner:  task.Owner,
+			Type:   task.Type,
+			Score:  math.Round(score*1000) / 1000,
+		})
+	}
+
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		if results[a].Path != results[b].Path {
+			return results[a].Path < results[b].Path
+		}
+		return results[a].Title < results[b].Title
+	})
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+
+	for n := range results {
+		results[n].Snippet = i.snippet(results[n], terms)
+	}
+	return results
+}
+
+// idf returns the inverse document frequency of each term
+func (i *Index) idf(terms []string) map[string]float64 {
+	idf := make(map[string]float64, len(terms))
+	for _, term := range terms {
+		matching := 0
+		for _, doc := range i.Docs {
+			if termCount(doc, term) > 0 {
+				matching++
+			}
+		}
+		idf[term] = math.Log(1 + float64(len(i.Docs))/float64(max(matching, 1)))
+	}
+	return idf
+}
+
+// score returns the TF-IDF score of a document for the terms, and whether it contains
+// them all
+func score(doc *Document, terms []string, idf map[string]float64) (float64, bool) {
+	total := 0.0
+	for _, term := range terms {
+		count := termCount(doc, term)
+		if count == 0 {
+			return 0, false
+		}
+		total += float64(count) / float64(doc.Length) * idf[term]
+	}
+	return total, true
+}
+
+// termCount returns how often a document has a term, or words a prefix term ending in
+// * starts
+func termCount(doc *Document, term string) int {
+	prefix, ok := strings.CutSuffix(term, "*")
+	if !ok {
+		return doc.Terms[term]
+	}
+	count := 0
+	for word, n := range doc.Terms {
+		if strings.HasPrefix(word, prefix) {
+			count += n
+		}
+	}
+	return count
+}
+
+// snippet returns the first line of the result's document with one of the terms
+func (i *Index) snippet(result Result, terms []string) string {
+	if result.Kind == KindAttachment {
+		return i.Docs["attachment:"+result.TaskID+"/"+result.Title].Text
+	}
+	if len(terms) == 0 {
+		return ""
+	}
+
+	file, err := os.Open(filepath.Join(i.root, filepath.FromSlash(result.Path))) // #nosec G304 - reading task files from workspace path
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		words := tokenize(line)
+		for _, term := range terms {
+			prefix, isPrefix := strings.CutSuffix(term, "*")
+			for _, word := range words {
+				if word == term || (isPrefix && strings.HasPrefix(word, prefix)) {
+					return truncate(line, 100)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// matchesTask reports whether a task passes the filters of a query
+func matchesTask(task *TaskInfo, query Query) bool {
+	if query.Type != "" && !strings.EqualFold(task.Type, query.Type) {
+		return false
+	}
+	if query.Owner != "" && !strings.EqualFold(task.Owner, query.Owner) {
+		return false
+	}
+	if query.Stage != "" {
+		name := task.Stage
+		if _, after, ok := strings.Cut(task.Stage, "-"); ok {
+			name = after
+		}
+		if !strings.EqualFold(task.Stage, query.Stage) && !strings.EqualFold(name, query.Stage) {
+			return false
+		}
+	}
+	return true
+}
+
+func newDocument(id, kind, taskID, title, rel, text string) *Document {
+	words := tokenize(text)
+	terms := make(map[string]int, len(words))
+	for _, word := range words {
+		terms[word]++
+	}
+	return &Document{ID: id, Kind: kind, TaskID: taskID, Title: title, Path: rel, Terms: terms, Length: max(len(words), 1)}
+}
+
+// tokenize splits text into lowercase words of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// queryTerms splits a query into its terms. A word ending in * is kept as a prefix term.
+func queryTerms(text string) []string {
+	var terms []string
+	for _, field := range strings.Fields(text) {
+		words := tokenize(field)
+		if len(words) > 0 && strings.HasSuffix(field, "*") {
+			words[len(words)-1] += "*"
+		}
+		terms = append(terms, words...)
+	}
+	return terms
+}
+
+// heading returns the first markdown heading of a document, or fallback
+func heading(data []byte, fallback string) string {
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+	}
+	return fallback
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// newTestWorkspace returns a workspace root with tasks PROJ-1 and PROJ-2
+func newTestWorkspace(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	tasks := filepath.Join(root, ".zen", "work", "tasks")
+
+	writeFile(t, filepath.Join(tasks, "PROJ-1", "index.md"), "# PROJ-1: Add login page\n\nUsers sign in with single sign-on.\n")
+	writeFile(t, filepath.Join(tasks, "PROJ-1", "manifest.yaml"), `task:
+  id: PROJ-1
+  title: Add login page
+  type: story
+  status: in_progress
+owner:
+  name: alice
+workflow:
+  current_stage: 04-design
+attachments:
+  - name: wireframes.pdf
+    path: attachments/wireframes.pdf
+  - name: spec
+    url: https://wiki.example.com/login-spec
+`)
+	writeFile(t, filepath.Join(tasks, "PROJ-1", "design", "decisions", "0001-use-oauth.md"), "# ADR 0001: Use OAuth\n\n## Decision\n\nWe sign in with OAuth providers.\n")
+	writeFile(t, filepath.Join(tasks, "PROJ-1", "attachments", "notes.md"), "sign in notes are not indexed\n")
+
+	writeFile(t, filepath.Join(tasks, "PROJ-2", "index.md"), "# PROJ-2: Fix logout\n\nLogout keeps the session.\n")
+	writeFile(t, filepath.Join(tasks, "PROJ-2", "manifest.yaml"), `task:
+  id: PROJ-2
+  title: Fix logout
+  type: bug
+owner:
+  name: bob
+workflow:
+  current_stage: 05-build
+`)
+	return root
+}
+
+func TestIndexSearch(t *testing.T) {
+	root := newTestWorkspace(t)
+	index, err := Open(root, IndexPath(filepath.Join(root, ".zen")))
+	require.NoError(t, err)
+
+	stats, err := index.Update()
+	require.NoError(t, err)
+	assert.Equal(t, &UpdateStats{Indexed: 5}, stats)
+
+	results := index.Search(Query{Text: "sign in"})
+	require.Len(t, results, 2)
+	paths := []string{results[0].Path, results[1].Path}
+	assert.ElementsMatch(t, []string{".zen/work/tasks/PROJ-1/index.md", ".zen/work/tasks/PROJ-1/design/decisions/0001-use-oauth.md"}, paths)
+	for _, result := range results {
+		assert.Equal(t, "04-design", result.Stage)
+		assert.Equal(t, "alice", result.Owner)
+		assert.Contains(t, result.Snippet, "sign in")
+	}
+
+	adrs := index.Search(Query{Text: "oauth", Kinds: []string{KindADR}})
+	require.Len(t, adrs, 1)
+	assert.Equal(t, "ADR 0001: Use OAuth", adrs[0].Title)
+
+	attachments := index.Search(Query{Text: "wirefr*"})
+	require.Len(t, attachments, 2, "the attachment and the manifest recording it")
+	for _, result := range attachments {
+		if result.Kind == KindAttachment {
+			assert.Equal(t, "wireframes.pdf", result.Title)
+			assert.Equal(t, ".zen/work/tasks/PROJ-1/attachments/wireframes.pdf", result.Snippet)
+		}
+	}
+
+	assert.Empty(t, index.Search(Query{Text: "sign", Owner: "bob"}))
+	assert.Len(t, index.Search(Query{Text: "logout", Stage: "build", Type: "bug"}), 2)
+
+	tasks := index.Search(Query{Stage: "04-design"})
+	require.Len(t, tasks, 1, "an empty query lists tasks")
+	assert.Equal(t, KindTask, tasks[0].Kind)
+	assert.Equal(t, "PROJ-1", tasks[0].TaskID)
+}
+
+func TestIndexUpdate_Incremental(t *testing.T) {
+	root := newTestWorkspace(t)
+	indexPath := IndexPath(filepath.Join(root, ".zen"))
+	index, err := Open(root, indexPath)
+	require.NoError(t, err)
+	_, err = index.Update()
+	require.NoError(t, err)
+	require.NoError(t, index.Save())
+
+	tasks := filepath.Join(root, ".zen", "work", "tasks")
+	changed := filepath.Join(tasks, "PROJ-2", "index.md")
+	writeFile(t, changed, "# PROJ-2: Fix logout\n\nLogout clears the refresh token.\n")
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(changed, later, later))
+	require.NoError(t, os.RemoveAll(filepath.Join(tasks, "PROJ-1", "design")))
+
+	index, err = Open(root, indexPath)
+	require.NoError(t, err)
+	assert.Len(t, index.Search(Query{Text: "oauth"}), 1, "the stored index is loaded")
+
+	stats, err := index.Update()
+	require.NoError(t, err)
+	assert.Equal(t, &UpdateStats{Indexed: 1, Removed: 1, Unchanged: 3}, stats)
+	assert.Empty(t, index.Search(Query{Text: "oauth"}))
+	assert.Len(t, index.Search(Query{Text: "refresh token"}), 1)
+
+	require.NoError(t, os.WriteFile(indexPath, []byte("{not json"), 0644))
+	index, err = Open(root, indexPath)
+	require.NoError(t, err)
+	assert.Empty(t, index.Docs, "a corrupt index is rebuilt")
+}
+
+func TestQueryTerms(t *testing.T) {
+	assert.Equal(t, []string{"sign", "on*"}, queryTerms("Sign-on*"))
+	assert.Equal(t, []string{"status", "done"}, tokenize("**Status:** done"))
+}
@@ -0,0 +1,54 @@
+package secretref
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AWSBackend reads secrets from AWS Secrets Manager through the AWS CLI, with its
+// credentials. The path of a reference is the name or ARN of the secret; the field
+// reads a key of a JSON secret, and references without one read the whole secret.
+type AWSBackend struct {
+	cfg AWSConfig
+	run CommandRunner
+}
+
+// NewAWSBackend creates an AWS Secrets Manager backend configured by cfg, running the
+// AWS CLI with run, or RunCommand when nil
+func NewAWSBackend(cfg AWSConfig, run CommandRunner) *AWSBackend {
+	if run == nil {
+		run = RunCommand
+	}
+	return &AWSBackend{cfg: cfg, run: run}
+}
+
+// Resolve returns the AWS secret ref refers to, or its field
+func (a *AWSBackend) Resolve(ctx context.Context, ref Ref) (string, error) {
+	args := []string{"secretsmanager", "get-secret-value", "--secret-id", ref.Path, "--query", "SecretString", "--output", "text"}
+	if a.cfg.Region != "" {
+		args = append(args, "--region", a.cfg.Region)
+	}
+	if a.cfg.Profile != "" {
+		args = append(args, "--profile", a.cfg.Profile)
+	}
+	out, err := a.run(ctx, "aws", args...)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimRight(string(out), "\r\n")
+	if ref.Field == "" {
+		return secret, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not JSON, so it has no field %s", ref.Path, ref.Field)
+	}
+	value, ok := fields[ref.Field]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no field %s", ref.Path, ref.Field)
+	}
+	return fmt.Sprint(value), nil
+}
@@ -0,0 +1,116 @@
+package secretref
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// DefaultTimeout bounds reading a secret from its backend
+const DefaultTimeout = 30 * time.Second
+
+// Config contains the configuration of the secret backends
+type Config struct {
+	// Timeout bounds reading a secret from its backend
+	Timeout time.Duration `yaml:"timeout" json:"timeout" mapstructure:"timeout"`
+
+	// Vault configures the HashiCorp Vault backend
+	Vault VaultConfig `yaml:"vault" json:"vault" mapstructure:"vault"`
+
+	// AWS configures the AWS Secrets Manager backend
+	AWS AWSConfig `yaml:"aws" json:"aws" mapstructure:"aws"`
+
+	// OnePassword configures the 1Password CLI backend
+	OnePassword OnePasswordConfig `yaml:"onepassword" json:"onepassword" mapstructure:"onepassword"`
+}
+
+// VaultConfig configures the HashiCorp Vault backend
+type VaultConfig struct {
+	// Address is the URL of the Vault server; VAULT_ADDR is used when empty
+	Address string `yaml:"address" json:"address" mapstructure:"address"`
+
+	// Namespace is the Vault Enterprise namespace; VAULT_NAMESPACE is used when empty
+	Namespace string `yaml:"namespace" json:"namespace" mapstructure:"namespace"`
+
+	// KVVersion is the version of the key/value secrets engine, 1 or 2
+	KVVersion int `yaml:"kv_version" json:"kv_version" mapstructure:"kv_version"`
+}
+
+// AWSConfig configures the AWS Secrets Manager backend
+type AWSConfig struct {
+	// Region of the secrets; the AWS CLI configuration is used when empty
+	Region string `yaml:"region" json:"region" mapstructure:"region"`
+
+	// Profile is the AWS CLI profile to read secrets with
+	Profile string `yaml:"profile" json:"profile" mapstructure:"profile"`
+}
+
+// OnePasswordConfig configures the 1Password CLI backend
+type OnePasswordConfig struct {
+	// Account is the 1Password account to read secrets from, when signed in to several
+	Account string `yaml:"account" json:"account" mapstructure:"account"`
+}
+
+// DefaultConfig returns default secrets configuration
+func DefaultConfig() Config {
+	return Config{
+		Timeout: DefaultTimeout,
+		Vault: VaultConfig{
+			KVVersion: 2,
+		},
+	}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the secrets configuration
+func (c Config) Validate() error {
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	if c.Vault.KVVersion != 1 && c.Vault.KVVersion != 2 {
+		return fmt.Errorf("invalid vault.kv_version: %d (must be 1 or 2)", c.Vault.KVVersion)
+	}
+	return nil
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+		),
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode secrets config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for secrets
+func (p ConfigParser) Section() string {
+	return "secrets"
+}
@@ -0,0 +1,41 @@
+package secretref
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// OnePasswordBackend reads secrets through the 1Password CLI, signed in with 'op
+// signin' or a service account token in OP_SERVICE_ACCOUNT_TOKEN. The path of a
+// reference is the vault and item, and the field is the field of the item, so
+// secretref://1password/Engineering/Jira#token reads op://Engineering/Jira/token.
+type OnePasswordBackend struct {
+	cfg OnePasswordConfig
+	run CommandRunner
+}
+
+// NewOnePasswordBackend creates a 1Password backend configured by cfg, running the
+// 1Password CLI with run, or RunCommand when nil
+func NewOnePasswordBackend(cfg OnePasswordConfig, run CommandRunner) *OnePasswordBackend {
+	if run == nil {
+		run = RunCommand
+	}
+	return &OnePasswordBackend{cfg: cfg, run: run}
+}
+
+// Resolve returns the field of the 1Password item ref refers to
+func (o *OnePasswordBackend) Resolve(ctx context.Context, ref Ref) (string, error) {
+	if ref.Field == "" {
+		return "", fmt.Errorf("1Password references name the field of the item to read, as in %s#password", ref)
+	}
+	args := []string{"read", "--no-newline", "op://" + ref.Path + "/" + ref.Field}
+	if o.cfg.Account != "" {
+		args = append(args, "--account", o.cfg.Account)
+	}
+	out, err := o.run(ctx, "op", args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
@@ -0,0 +1,197 @@
+// Package secretref resolves configuration values that refer to secret managers. A
+// value such as "secretref://vault/kv/zen#token" is read from the secret manager when
+// zen needs it, so tokens never have to be stored in configuration files:
+//
+//	jira:
+//	  token: secretref://vault/kv/zen#token
+//
+// References name a backend, the path of the secret in it, and the field of the secret
+// to read. The backends are HashiCorp Vault ("vault"), AWS Secrets Manager ("aws") and
+// the 1Password CLI ("1password" or "op"); others can be registered on a Resolver.
+package secretref
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Prefix starts every secret reference
+const Prefix = "secretref://"
+
+// Ref is a reference to a secret in a secret manager
+type Ref struct {
+	// Backend is the secret manager the secret is stored in, such as "vault"
+	Backend string
+	// Path locates the secret in the backend, such as "kv/zen"
+	Path string
+	// Field is the field of the secret to read; backends that store plain values
+	// accept references without one
+	Field string
+}
+
+// String returns the reference in its configuration form
+func (r Ref) String() string {
+	s := Prefix + r.Backend + "/" + r.Path
+	if r.Field != "" {
+		s += "#" + r.Field
+	}
+	return s
+}
+
+// IsRef reports whether value is a secret reference rather than a plain value
+func IsRef(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), Prefix)
+}
+
+// Parse parses a secret reference of the form secretref://<backend>/<path>#<field>
+func Parse(value string) (Ref, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(value), Prefix)
+	if !ok {
+		return Ref{}, invalidRef(value, "secret references start with "+Prefix)
+	}
+
+	var ref Ref
+	rest, ref.Field, _ = strings.Cut(rest, "#")
+	ref.Backend, ref.Path, _ = strings.Cut(rest, "/")
+	ref.Backend = strings.ToLower(ref.Backend)
+	ref.Path = strings.Trim(ref.Path, "/")
+	if ref.Backend == "" || ref.Path == "" {
+		return Ref{}, invalidRef(value, "secret references have the form "+Prefix+"<backend>/<path>#<field>")
+	}
+	return ref, nil
+}
+
+// Backend reads secrets from a secret manager
+type Backend interface {
+	// Resolve returns the value of the secret ref refers to
+	Resolve(ctx context.Context, ref Ref) (string, error)
+}
+
+// Resolver resolves secret references through the backends registered on it. Resolved
+// values are kept for the life of the resolver, so each secret is read once per run.
+type Resolver struct {
+	cfg      Config
+	backends map[string]Backend
+
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// NewResolver creates a resolver with the Vault, AWS Secrets Manager and 1Password
+// backends, configured by cfg
+func NewResolver(cfg Config) *Resolver {
+	r := &Resolver{
+		cfg:      cfg,
+		backends: map[string]Backend{},
+		values:   map[string]string{},
+	}
+	r.Register("vault", NewVaultBackend(cfg.Vault))
+	r.Register("aws", NewAWSBackend(cfg.AWS, nil))
+	onePassword := NewOnePasswordBackend(cfg.OnePassword, nil)
+	r.Register("1password", onePassword)
+	r.Register("op", onePassword)
+	return r
+}
+
+// ForConfig creates a resolver configured by the secrets section of cfg
+func ForConfig(cfg *config.Config) (*Resolver, error) {
+	secrets, err := config.GetConfig(cfg, ConfigParser{})
+	if err != nil {
+		return nil, err
+	}
+	return NewResolver(secrets), nil
+}
+
+// Register adds a backend for the references naming it, replacing the backend of
+// that name if there is one
+func (r *Resolver) Register(name string, backend Backend) {
+	r.backends[strings.ToLower(name)] = backend
+}
+
+// Backends returns the names of the registered backends
+func (r *Resolver) Backends() []string {
+	names := make([]string, 0, len(r.backends))
+	for name := range r.backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the secret value refers to, or value itself when it is not a secret
+// reference
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsRef(value) {
+		return value, nil
+	}
+	ref, err := Parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	key := ref.String()
+	r.mu.Lock()
+	secret, ok := r.values[key]
+	r.mu.Unlock()
+	if ok {
+		return secret, nil
+	}
+
+	backend, ok := r.backends[ref.Backend]
+	if !ok {
+		return "", invalidRef(value, fmt.Sprintf("unknown secret backend %q (available: %s)", ref.Backend, strings.Join(r.Backends(), ", ")))
+	}
+
+	if r.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Timeout)
+		defer cancel()
+	}
+	secret, err = backend.Resolve(ctx, ref)
+	if err != nil {
+		return "", &types.Error{
+			Code:    types.ErrorCodeAuthenticationFailed,
+			Message: fmt.Sprintf("failed to resolve secret %s", key),
+			Details: err.Error(),
+		}
+	}
+	if secret == "" {
+		return "", &types.Error{
+			Code:    types.ErrorCodeNotFound,
+			Message: fmt.Sprintf("secret %s is empty", key),
+		}
+	}
+
+	r.mu.Lock()
+	r.values[key] = secret
+	r.mu.Unlock()
+	return secret, nil
+}
+
+// ResolveProvider returns the configuration of an integration provider with the secret
+// references of its credentials resolved
+func (r *Resolver) ResolveProvider(ctx context.Context, provider config.IntegrationProviderConfig) (config.IntegrationProviderConfig, error) {
+	for _, field := range []*string{&provider.Email, &provider.APIKey} {
+		value, err := r.Resolve(ctx, *field)
+		if err != nil {
+			return provider, err
+		}
+		*field = value
+	}
+	return provider, nil
+}
+
+// invalidRef returns the error of a malformed secret reference
+func invalidRef(value, details string) error {
+	return &types.Error{
+		Code:    types.ErrorCodeInvalidConfig,
+		Message: fmt.Sprintf("invalid secret reference %q", value),
+		Details: details,
+	}
+}
@@ -0,0 +1,174 @@
+package secretref
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend resolves references from a map and counts the reads
+type fakeBackend struct {
+	secrets map[string]string
+	reads   int
+}
+
+func (f *fakeBackend) Resolve(ctx context.Context, ref Ref) (string, error) {
+	f.reads++
+	secret, ok := f.secrets[ref.Path+"#"+ref.Field]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return secret, nil
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Ref
+		wantErr bool
+	}{
+		{value: "secretref://vault/kv/zen#token", want: Ref{Backend: "vault", Path: "kv/zen", Field: "token"}},
+		{value: "secretref://AWS/prod/zen", want: Ref{Backend: "aws", Path: "prod/zen"}},
+		{value: "secretref://1password/Engineering/Jira API#credential", want: Ref{Backend: "1password", Path: "Engineering/Jira API", Field: "credential"}},
+		{value: "secretref://vault", wantErr: true},
+		{value: "secretref:///kv/zen#token", wantErr: true},
+		{value: "vault://kv/zen#token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			ref, err := Parse(tt.value)
+			if tt.wantErr {
+				var zenErr *types.Error
+				require.True(t, errors.As(err, &zenErr), "got %v", err)
+				assert.Equal(t, types.ErrorCodeInvalidConfig, zenErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ref)
+		})
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	backend := &fakeBackend{secrets: map[string]string{"kv/zen#token": "s3cret"}}
+	r := NewResolver(DefaultConfig())
+	r.Register("vault", backend)
+	ctx := context.Background()
+
+	value, err := r.Resolve(ctx, "plain-token")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-token", value, "plain values are returned as they are")
+
+	for i := 0; i < 2; i++ {
+		value, err = r.Resolve(ctx, "secretref://vault/kv/zen#token")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", value)
+	}
+	assert.Equal(t, 1, backend.reads, "secrets are read once")
+
+	_, err = r.Resolve(ctx, "secretref://vault/kv/zen#password")
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, "failed to resolve secret secretref://vault/kv/zen#password", zenErr.Message)
+	assert.Equal(t, "secret not found", zenErr.Details)
+
+	_, err = r.Resolve(ctx, "secretref://keepass/zen#token")
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Contains(t, zenErr.Details, `unknown secret backend "keepass" (available: 1password, aws, op, vault)`)
+}
+
+func TestResolver_ResolveProvider(t *testing.T) {
+	r := NewResolver(DefaultConfig())
+	r.Register("vault", &fakeBackend{secrets: map[string]string{"kv/jira#api_key": "jira-key"}})
+
+	provider, err := r.ResolveProvider(context.Background(), config.IntegrationProviderConfig{
+		Type:   "basic",
+		Email:  "dev@example.com",
+		APIKey: "secretref://vault/kv/jira#api_key",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "dev@example.com", provider.Email)
+	assert.Equal(t, "jira-key", provider.APIKey)
+}
+
+func TestVaultBackend_Resolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		kvVersion int
+		path      string
+		body      string
+		want      string
+	}{
+		{name: "kv version 2", kvVersion: 2, path: "/v1/kv/data/zen", body: `{"data":{"data":{"token":"v2-token"}}}`, want: "v2-token"},
+		{name: "kv version 1", kvVersion: 1, path: "/v1/kv/zen", body: `{"data":{"token":"v1-token"}}`, want: "v1-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+				assert.Equal(t, "team-a", r.Header.Get("X-Vault-Namespace"))
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			t.Setenv("VAULT_TOKEN", "vault-token")
+
+			v := NewVaultBackend(VaultConfig{Address: server.URL, Namespace: "team-a", KVVersion: tt.kvVersion})
+			value, err := v.Resolve(context.Background(), Ref{Backend: "vault", Path: "kv/zen", Field: "token"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+
+			_, err = v.Resolve(context.Background(), Ref{Backend: "vault", Path: "kv/zen", Field: "password"})
+			assert.EqualError(t, err, "secret kv/zen has no field password")
+		})
+	}
+}
+
+func TestAWSBackend_Resolve(t *testing.T) {
+	var args []string
+	a := NewAWSBackend(AWSConfig{Region: "eu-west-1"}, func(ctx context.Context, name string, arg ...string) ([]byte, error) {
+		assert.Equal(t, "aws", name)
+		args = arg
+		return []byte(`{"token":"aws-token"}` + "\n"), nil
+	})
+
+	value, err := a.Resolve(context.Background(), Ref{Backend: "aws", Path: "prod/zen", Field: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "aws-token", value)
+	assert.Equal(t, []string{"secretsmanager", "get-secret-value", "--secret-id", "prod/zen", "--query", "SecretString", "--output", "text", "--region", "eu-west-1"}, args)
+
+	value, err = a.Resolve(context.Background(), Ref{Backend: "aws", Path: "prod/zen"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"token":"aws-token"}`, value, "references without a field read the whole secret")
+}
+
+func TestOnePasswordBackend_Resolve(t *testing.T) {
+	o := NewOnePasswordBackend(OnePasswordConfig{Account: "acme"}, func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		assert.Equal(t, "op", name)
+		assert.Equal(t, []string{"read", "--no-newline", "op://Engineering/Jira/token", "--account", "acme"}, args)
+		return []byte("op-token"), nil
+	})
+
+	value, err := o.Resolve(context.Background(), Ref{Backend: "op", Path: "Engineering/Jira", Field: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "op-token", value)
+
+	_, err = o.Resolve(context.Background(), Ref{Backend: "op", Path: "Engineering/Jira"})
+	assert.Error(t, err)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg, err := ConfigParser{}.Parse(map[string]interface{}{"timeout": "5s", "vault": map[string]interface{}{"kv_version": 3}})
+	require.NoError(t, err)
+	assert.EqualError(t, cfg.Validate(), "invalid vault.kv_version: 3 (must be 1 or 2)")
+	assert.NoError(t, DefaultConfig().Validate())
+}
@@ -0,0 +1,119 @@
+package secretref
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daddia/zen/pkg/clients/httpx"
+)
+
+// VaultBackend reads secrets from the key/value secrets engine of HashiCorp Vault. The
+// path of a reference is the mount of the engine followed by the path of the secret,
+// so secretref://vault/kv/zen#token reads the token field of the secret zen in the kv
+// mount. It authenticates with VAULT_TOKEN, or the token 'vault login' saved in
+// ~/.vault-token.
+type VaultBackend struct {
+	cfg    VaultConfig
+	client *http.Client
+}
+
+// NewVaultBackend creates a Vault backend configured by cfg
+func NewVaultBackend(cfg VaultConfig) *VaultBackend {
+	return &VaultBackend{cfg: cfg, client: httpx.New(httpx.Options{Provider: "vault", SecretResponses: true})}
+}
+
+// Resolve returns the field of the Vault secret ref refers to
+func (v *VaultBackend) Resolve(ctx context.Context, ref Ref) (string, error) {
+	if ref.Field == "" {
+		return "", fmt.Errorf("vault references name the field of the secret to read, as in %s#token", ref)
+	}
+	address := v.cfg.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return "", fmt.Errorf("no Vault address: set secrets.vault.address or VAULT_ADDR")
+	}
+	token := vaultToken()
+	if token == "" {
+		return "", fmt.Errorf("no Vault token: set VAULT_TOKEN or run 'vault login'")
+	}
+
+	mount, path, _ := strings.Cut(ref.Path, "/")
+	if path == "" {
+		return "", fmt.Errorf("vault references name the mount and path of the secret, as in %skv/zen#token", Prefix+"vault/")
+	}
+	url := strings.TrimRight(address, "/") + "/v1/" + mount + "/" + path
+	if v.cfg.KVVersion != 1 {
+		url = strings.TrimRight(address, "/") + "/v1/" + mount + "/data/" + path
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	namespace := v.cfg.Namespace
+	if namespace == "" {
+		namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+	if namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach Vault: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Vault response: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("secret %s not found in Vault", ref.Path)
+	case resp.StatusCode == http.StatusForbidden:
+		return "", fmt.Errorf("access to %s denied by Vault; check the policies of the token", ref.Path)
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("unexpected response from Vault: %s", resp.Status)
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("failed to parse Vault response: %w", err)
+	}
+	data := secret.Data
+	if v.cfg.KVVersion != 1 {
+		data, _ = data["data"].(map[string]interface{})
+	}
+	value, ok := data[ref.Field]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no field %s", ref.Path, ref.Field)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// vaultToken returns the token to authenticate to Vault with
+func vaultToken() string {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".vault-token"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
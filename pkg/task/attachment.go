@@ -0,0 +1,370 @@
+package task
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// attachmentsDir holds the files attached to a task, in the task directory
+const attachmentsDir = "attachments"
+
+// Attachment states reported by Attachment.Check
+const (
+	AttachmentOK       = "ok"
+	AttachmentModified = "modified"
+	AttachmentMissing  = "missing"
+	AttachmentLink     = "link"
+)
+
+// Attachment is a file stored with a task, or a link to one kept elsewhere
+type Attachment struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Path is the file of the attachment, relative to the task directory; links have none
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// URL is the address of a link
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+
+	Size     int64     `json:"size,omitempty" yaml:"size,omitempty"`
+	Checksum string    `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+	Added    time.Time `json:"added" yaml:"added"`
+
+	// Sources maps the systems the attachment was uploaded to or downloaded from to its
+	// ID there
+	Sources map[string]string `json:"sources,omitempty" yaml:"sources,omitempty"`
+}
+
+// IsLink reports whether the attachment is a link rather than a stored file
+func (a Attachment) IsLink() bool {
+	return a.Path == ""
+}
+
+// Check reports whether the file of the attachment in taskDir still matches its
+// checksum: AttachmentOK, AttachmentModified, AttachmentMissing, or AttachmentLink for
+// links, which have no file
+func (a Attachment) Check(taskDir string) string {
+	if a.IsLink() {
+		return AttachmentLink
+	}
+	file, err := os.Open(filepath.Join(taskDir, filepath.FromSlash(a.Path))) // #nosec G304 - reading task attachment from workspace path
+	if err != nil {
+		return AttachmentMissing
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return AttachmentMissing
+	}
+	if checksumOf(hasher) != a.Checksum {
+		return AttachmentModified
+	}
+	return AttachmentOK
+}
+
+// Attach attaches a file or an http(s) link to a task. Files are copied to the task's
+// attachments directory with their checksum; links are kept as references. Attaching a
+// link or file content the task already has returns the existing attachment and false.
+func (m *Manager) Attach(ctx context.Context, taskID, target string) (*Attachment, bool, error) {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var attachment *Attachment
+	if isLink(target) {
+		for i := range task.Attachments {
+			if task.Attachments[i].URL == target {
+				return &task.Attachments[i], false, nil
+			}
+		}
+		attachment = &Attachment{Name: linkName(target), URL: target, Added: time.Now()}
+	} else {
+		file, err := os.Open(target) // #nosec G304 - attaching a file the user named
+		if err != nil {
+			return nil, false, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("cannot attach %s", target),
+				Details: err.Error(),
+			}
+		}
+		defer file.Close()
+
+		attachment, err = storeAttachment(task, filepath.Base(target), file)
+		if err != nil {
+			return nil, false, err
+		}
+		for i := range task.Attachments {
+			if existing := task.Attachments[i]; !existing.IsLink() && existing.Checksum == attachment.Checksum {
+				os.Remove(filepath.Join(task.WorkspacePath, filepath.FromSlash(attachment.Path)))
+				return &task.Attachments[i], false, nil
+			}
+		}
+	}
+
+	task.Attachments = append(task.Attachments, *attachment)
+	if err := saveAttachments(task); err != nil {
+		return nil, false, err
+	}
+
+	m.logger.Debug("attachment added", "task_id", taskID, "name", attachment.Name)
+	return attachment, true, nil
+}
+
+// UploadAttachments uploads the files attached to a task to source, skipping those
+// already uploaded to it. It returns the uploaded attachments.
+func (m *Manager) UploadAttachments(ctx context.Context, taskID, source string) ([]Attachment, error) {
+	task, transferer, externalID, err := m.attachmentSource(ctx, taskID, source)
+	if err != nil {
+		return nil, err
+	}
+	return uploadAttachments(ctx, task, source, externalID, transferer)
+}
+
+// DownloadAttachments downloads the files attached to a task in source that the task
+// does not have yet. It returns the downloaded attachments.
+func (m *Manager) DownloadAttachments(ctx context.Context, taskID, source string) ([]Attachment, error) {
+	task, transferer, externalID, err := m.attachmentSource(ctx, taskID, source)
+	if err != nil {
+		return nil, err
+	}
+	return downloadAttachments(ctx, task, source, externalID, transferer)
+}
+
+// attachmentSource returns a task with the plugin that transfers its attachments to
+// source and its ID there
+func (m *Manager) attachmentSource(ctx context.Context, taskID, source string) (*Task, plugin.AttachmentTransferer, string, error) {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	taskSource, ok := task.Sources[source]
+	if !ok {
+		return nil, nil, "", fmt.Errorf("task %s is not linked to source %s", taskID, source)
+	}
+
+	pluginInstance, err := m.getOrCreatePlugin(ctx, source)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to get plugin: %w", err)
+	}
+	transferer, ok := pluginInstance.(plugin.AttachmentTransferer)
+	if !ok {
+		return nil, nil, "", &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("%s does not support attachments", source),
+		}
+	}
+	return task, transferer, taskSource.ExternalID, nil
+}
+
+// uploadAttachments uploads the files of task not yet in source and records their IDs
+func uploadAttachments(ctx context.Context, task *Task, source, externalID string, transferer plugin.AttachmentTransferer) ([]Attachment, error) {
+	var uploaded []Attachment
+	var uploadErr error
+	for i := range task.Attachments {
+		attachment := &task.Attachments[i]
+		if attachment.IsLink() || attachment.Sources[source] != "" {
+			continue
+		}
+
+		remote, err := uploadAttachment(ctx, task, attachment, externalID, transferer)
+		if err != nil {
+			uploadErr = fmt.Errorf("failed to upload %s: %w", attachment.Name, err)
+			break
+		}
+		if attachment.Sources == nil {
+			attachment.Sources = map[string]string{}
+		}
+		attachment.Sources[source] = remote.ID
+		uploaded = append(uploaded, *attachment)
+	}
+
+	// Record the uploads that succeeded, so they are not uploaded again
+	if len(uploaded) > 0 {
+		if err := saveAttachments(task); err != nil {
+			return uploaded, err
+		}
+	}
+	return uploaded, uploadErr
+}
+
+func uploadAttachment(ctx context.Context, task *Task, attachment *Attachment, externalID string, transferer plugin.AttachmentTransferer) (*plugin.Attachment, error) {
+	if state := attachment.Check(task.WorkspacePath); state != AttachmentOK {
+		return nil, fmt.Errorf("attachment is %s; its checksum is %s", state, attachment.Checksum)
+	}
+
+	file, err := os.Open(filepath.Join(task.WorkspacePath, filepath.FromSlash(attachment.Path))) // #nosec G304 - reading task attachment from workspace path
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return transferer.UploadAttachment(ctx, externalID, attachment.Name, file)
+}
+
+// downloadAttachments stores the attachments of the task in source that it has no
+// record of
+func downloadAttachments(ctx context.Context, task *Task, source, externalID string, transferer plugin.AttachmentTransferer) ([]Attachment, error) {
+	remotes, err := transferer.ListAttachments(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, attachment := range task.Attachments {
+		if id := attachment.Sources[source]; id != "" {
+			known[id] = true
+		}
+	}
+
+	var downloaded []Attachment
+	var downloadErr error
+	for _, remote := range remotes {
+		if known[remote.ID] {
+			continue
+		}
+
+		attachment, err := downloadAttachment(ctx, task, remote, transferer)
+		if err != nil {
+			downloadErr = fmt.Errorf("failed to download %s: %w", remote.Name, err)
+			break
+		}
+		attachment.Sources = map[string]string{source: remote.ID}
+		task.Attachments = append(task.Attachments, *attachment)
+		downloaded = append(downloaded, *attachment)
+	}
+
+	if len(downloaded) > 0 {
+		if err := saveAttachments(task); err != nil {
+			return downloaded, err
+		}
+	}
+	return downloaded, downloadErr
+}
+
+func downloadAttachment(ctx context.Context, task *Task, remote *plugin.Attachment, transferer plugin.AttachmentTransferer) (*Attachment, error) {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(transferer.DownloadAttachment(ctx, remote, writer))
+	}()
+	defer reader.Close()
+
+	return storeAttachment(task, remote.Name, reader)
+}
+
+// storeAttachment writes content to a new file of the task's attachments directory,
+// named after name unless a file of that name exists
+func storeAttachment(task *Task, name string, content io.Reader) (*Attachment, error) {
+	dir := filepath.Join(task.WorkspacePath, attachmentsDir)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	temp, err := os.CreateTemp(dir, ".attach-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	defer os.Remove(temp.Name())
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(temp, hasher), content)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	fileName := uniqueFileName(dir, attachmentFileName(name))
+	if err := os.Rename(temp.Name(), filepath.Join(dir, fileName)); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	return &Attachment{
+		Name:     fileName,
+		Path:     attachmentsDir + "/" + fileName,
+		Size:     size,
+		Checksum: checksumOf(hasher),
+		Added:    time.Now(),
+	}, nil
+}
+
+// saveAttachments writes the attachments of a task to its manifest
+func saveAttachments(task *Task) error {
+	if err := setManifestValue(task.ManifestPath, "attachments", task.Attachments); err != nil {
+		return fmt.Errorf("failed to save attachments: %w", err)
+	}
+	if err := updateManifestFields(task.ManifestPath, map[string]string{
+		"dates.last_updated": time.Now().Format("2006-01-02 15:04:05"),
+	}); err != nil {
+		return fmt.Errorf("failed to update task manifest: %w", err)
+	}
+	return nil
+}
+
+// isLink reports whether target is an http(s) URL rather than a file
+func isLink(target string) bool {
+	u, err := url.Parse(target)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// linkName names a link after the last element of its path, or its host
+func linkName(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	if name := filepath.Base(u.Path); name != "." && name != "/" {
+		return name
+	}
+	return u.Host
+}
+
+// attachmentFileName makes name, which may come from an external system, safe to use
+// as a file name in the attachments directory
+func attachmentFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < ' ' {
+			return '-'
+		}
+		return r
+	}, name)
+	name = strings.TrimLeft(strings.TrimSpace(name), ".")
+	if name == "" {
+		return "attachment"
+	}
+	return name
+}
+
+// uniqueFileName returns name, or name with a numeric suffix when dir has a file of
+// that name
+func uniqueFileName(dir, name string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := name
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, candidate)); os.IsNotExist(err) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+}
+
+func checksumOf(hasher hash.Hash) string {
+	return fmt.Sprintf("sha256:%x", hasher.Sum(nil))
+}
@@ -0,0 +1,145 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransferer keeps attachments in memory
+type fakeTransferer struct {
+	files    map[string]string
+	remotes  []*plugin.Attachment
+	uploaded []string
+}
+
+func (f *fakeTransferer) ListAttachments(ctx context.Context, externalID string) ([]*plugin.Attachment, error) {
+	return f.remotes, nil
+}
+
+func (f *fakeTransferer) UploadAttachment(ctx context.Context, externalID, name string, content io.Reader) (*plugin.Attachment, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	f.uploaded = append(f.uploaded, name+"="+string(data))
+	return &plugin.Attachment{ID: fmt.Sprintf("%d", len(f.uploaded)), Name: name}, nil
+}
+
+func (f *fakeTransferer) DownloadAttachment(ctx context.Context, attachment *plugin.Attachment, w io.Writer) error {
+	content, ok := f.files[attachment.ID]
+	if !ok {
+		return fmt.Errorf("attachment %s not found", attachment.ID)
+	}
+	_, err := io.WriteString(w, content)
+	return err
+}
+
+func TestManagerAttach(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "design.pdf")
+	require.NoError(t, os.WriteFile(file, []byte("%PDF-1."), 0644))
+
+	attachment, added, err := m.Attach(ctx, "PROJ-1", file)
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, "design.pdf", attachment.Name)
+	assert.Equal(t, "attachments/design.pdf", attachment.Path)
+	assert.Equal(t, int64(7), attachment.Size)
+	assert.True(t, strings.HasPrefix(attachment.Checksum, "sha256:"))
+
+	_, added, err = m.Attach(ctx, "PROJ-1", file)
+	require.NoError(t, err)
+	assert.False(t, added, "the same content is attached once")
+
+	link, added, err := m.Attach(ctx, "PROJ-1", "https://example.com/specs/login.html")
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, "login.html", link.Name)
+	assert.True(t, link.IsLink())
+
+	_, _, err = m.Attach(ctx, "PROJ-1", filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	require.Len(t, task.Attachments, 2)
+	assert.Equal(t, AttachmentOK, task.Attachments[0].Check(task.WorkspacePath))
+	assert.Equal(t, AttachmentLink, task.Attachments[1].Check(task.WorkspacePath))
+
+	data, err := os.ReadFile(filepath.Join(tasksDir, "PROJ-1", "manifest.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# shown in lists")
+
+	require.NoError(t, os.WriteFile(filepath.Join(tasksDir, "PROJ-1", "attachments", "design.pdf"), []byte("changed"), 0644))
+	assert.Equal(t, AttachmentModified, task.Attachments[0].Check(task.WorkspacePath))
+	require.NoError(t, os.Remove(filepath.Join(tasksDir, "PROJ-1", "attachments", "design.pdf")))
+	assert.Equal(t, AttachmentMissing, task.Attachments[0].Check(task.WorkspacePath))
+}
+
+func TestUploadAndDownloadAttachments(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(file, []byte("notes"), 0644))
+	_, _, err := m.Attach(ctx, "PROJ-1", file)
+	require.NoError(t, err)
+	_, _, err = m.Attach(ctx, "PROJ-1", "https://example.com/spec")
+	require.NoError(t, err)
+
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+
+	transferer := &fakeTransferer{
+		files: map[string]string{"100": "remote notes", "101": "log"},
+		remotes: []*plugin.Attachment{
+			{ID: "100", Name: "notes.txt"},
+			{ID: "101", Name: "../build.log"},
+		},
+	}
+
+	uploaded, err := uploadAttachments(ctx, task, "jira", "PROJ-1", transferer)
+	require.NoError(t, err)
+	require.Len(t, uploaded, 1, "links are not uploaded")
+	assert.Equal(t, []string{"notes.txt=notes"}, transferer.uploaded)
+
+	uploaded, err = uploadAttachments(ctx, task, "jira", "PROJ-1", transferer)
+	require.NoError(t, err)
+	assert.Empty(t, uploaded, "attachments are uploaded once")
+
+	// The uploaded attachment is 1 in the source; the others are downloaded
+	transferer.remotes = append(transferer.remotes, &plugin.Attachment{ID: "1", Name: "notes.txt"})
+	downloaded, err := downloadAttachments(ctx, task, "jira", "PROJ-1", transferer)
+	require.NoError(t, err)
+	require.Len(t, downloaded, 2)
+	assert.Equal(t, "notes-2.txt", downloaded[0].Name, "names of existing files are not reused")
+	assert.Equal(t, "build.log", downloaded[1].Name)
+	assert.Equal(t, map[string]string{"jira": "101"}, downloaded[1].Sources)
+
+	content, err := os.ReadFile(filepath.Join(task.WorkspacePath, "attachments", "notes-2.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "remote notes", string(content))
+
+	reloaded, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Len(t, reloaded.Attachments, 4)
+	assert.Equal(t, "1", reloaded.Attachments[0].Sources["jira"])
+
+	transferer.remotes = append(transferer.remotes, &plugin.Attachment{ID: "102", Name: "gone.txt"})
+	_, err = downloadAttachments(ctx, task, "jira", "PROJ-1", transferer)
+	assert.Error(t, err)
+	entries, err := os.ReadDir(filepath.Join(task.WorkspacePath, "attachments"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "failed downloads leave no files")
+}
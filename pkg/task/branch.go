@@ -0,0 +1,116 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultBranchTemplate names task branches like "feat/PROJ-123-add-login"
+const DefaultBranchTemplate = "{prefix}/{id}-{slug}"
+
+// maxSlugLength keeps generated branch names readable
+const maxSlugLength = 40
+
+var (
+	slugInvalidChars  = regexp.MustCompile(`[^a-z0-9]+`)
+	branchInvalidRefs = regexp.MustCompile(`[\s~^:?*\[\\]+|\.\.|@\{|//+`)
+)
+
+// GitInfo records the branch and worktree a task is being worked on in
+type GitInfo struct {
+	Branch     string     `json:"branch" yaml:"branch"`
+	Base       string     `json:"base,omitempty" yaml:"base,omitempty"`
+	Worktree   string     `json:"worktree,omitempty" yaml:"worktree,omitempty"`
+	Remote     string     `json:"remote,omitempty" yaml:"remote,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" yaml:"finished_at,omitempty"`
+}
+
+// Slugify converts a title into a lowercase, hyphen-separated slug
+func Slugify(title string) string {
+	slug := strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if idx := strings.LastIndex(slug, "-"); idx > 0 {
+			slug = slug[:idx]
+		}
+	}
+	return slug
+}
+
+// BranchPrefix returns the conventional branch prefix for a task type
+func BranchPrefix(taskType string) string {
+	switch taskType {
+	case "bug":
+		return "fix"
+	case "spike":
+		return "spike"
+	case "chore":
+		return "chore"
+	default:
+		return "feat"
+	}
+}
+
+// BranchName renders a branch name for a task from a template such as "{prefix}/{id}-{slug}"
+func BranchName(template string, task *Task) (string, error) {
+	if template == "" {
+		template = DefaultBranchTemplate
+	}
+
+	slug := Slugify(task.Title)
+	name := strings.NewReplacer(
+		"{prefix}", BranchPrefix(task.Type),
+		"{type}", task.Type,
+		"{id}", task.ID,
+		"{slug}", slug,
+	).Replace(template)
+
+	// Drop separators left behind by empty placeholders, e.g. "feat/PROJ-1-" when there is no title
+	name = strings.Trim(strings.ReplaceAll(name, "/-", "/"), "-/")
+	name = branchInvalidRefs.ReplaceAllString(name, "-")
+	name = strings.TrimSuffix(strings.TrimSuffix(name, ".lock"), ".")
+
+	if name == "" || !strings.Contains(name, task.ID) {
+		return "", fmt.Errorf("branch template %q does not produce a valid name for task %s", template, task.ID)
+	}
+	return name, nil
+}
+
+// UpdateTaskGit records the task's branch, worktree and start/finish times in its manifest
+func (m *Manager) UpdateTaskGit(ctx context.Context, taskID string, info *GitInfo) error {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]string{
+		"git.branch":         info.Branch,
+		"dates.last_updated": time.Now().Format("2006-01-02 15:04:05"),
+	}
+	if info.Base != "" {
+		fields["git.base"] = info.Base
+	}
+	if info.Worktree != "" {
+		fields["git.worktree"] = info.Worktree
+	}
+	if info.Remote != "" {
+		fields["git.remote"] = info.Remote
+	}
+	if info.StartedAt != nil {
+		fields["git.started_at"] = info.StartedAt.Format(time.RFC3339)
+	}
+	if info.FinishedAt != nil {
+		fields["git.finished_at"] = info.FinishedAt.Format(time.RFC3339)
+	}
+
+	if err := updateManifestFields(task.ManifestPath, fields); err != nil {
+		return fmt.Errorf("failed to record task branch: %w", err)
+	}
+
+	m.logger.Debug("task git info updated", "task_id", taskID, "branch", info.Branch, "worktree", info.Worktree)
+	return nil
+}
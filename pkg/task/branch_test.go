@@ -0,0 +1,76 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "add-login-page", Slugify("Add login page"))
+	assert.Equal(t, "fix-crash-on-empty-input", Slugify("  Fix: crash on *empty* input!  "))
+	assert.Equal(t, "", Slugify(""))
+	assert.Equal(t, "support-single-sign-on-for-enterprise", Slugify("Support single sign-on for enterprise customers via SAML"))
+}
+
+func TestBranchName(t *testing.T) {
+	story := &Task{ID: "PROJ-123", Title: "Add login page", Type: "story"}
+	bug := &Task{ID: "PROJ-7", Title: "Crash on start", Type: "bug"}
+
+	tests := []struct {
+		name     string
+		template string
+		task     *Task
+		want     string
+		wantErr  bool
+	}{
+		{name: "default", task: story, want: "feat/PROJ-123-add-login-page"},
+		{name: "bug prefix", task: bug, want: "fix/PROJ-7-crash-on-start"},
+		{name: "custom", template: "{type}/{id}", task: story, want: "story/PROJ-123"},
+		{name: "no title", task: &Task{ID: "PROJ-9", Type: "task"}, want: "feat/PROJ-9"},
+		{name: "invalid characters", template: "users/me:{id}..{slug}", task: story, want: "users/me-PROJ-123-add-login-page"},
+		{name: "missing id", template: "{slug}", task: story, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BranchName(tt.template, tt.task)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManifestGitInfo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("task:\n  id: PROJ-1\n"), 0644))
+
+	doc, err := readManifest(path)
+	require.NoError(t, err)
+	task := &Task{}
+	doc.applyTo(task)
+	assert.Nil(t, task.Git)
+
+	require.NoError(t, updateManifestFields(path, map[string]string{
+		"git.branch":     "feat/PROJ-1",
+		"git.base":       "main",
+		"git.started_at": "2025-01-02T03:04:05Z",
+	}))
+
+	doc, err = readManifest(path)
+	require.NoError(t, err)
+	doc.applyTo(task)
+	require.NotNil(t, task.Git)
+	assert.Equal(t, "feat/PROJ-1", task.Git.Branch)
+	assert.Equal(t, "main", task.Git.Base)
+	require.NotNil(t, task.Git.StartedAt)
+	assert.Equal(t, 2025, task.Git.StartedAt.Year())
+	assert.Nil(t, task.Git.FinishedAt)
+}
@@ -0,0 +1,234 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// checklistStage is the stage of checklist items that name none: they are due before a
+// task moves on to Learn
+const checklistStage = "06-ship"
+
+// checkboxLine matches a markdown task list item, such as "- [x] Tests pass"
+var checkboxLine = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.+?)\s*$`)
+
+// Checklist is a definition-of-done checklist of a task. Its items are defined by a
+// checklist asset, recorded in the manifest when the task is created, and checked off in
+// the task's index.md.
+type Checklist struct {
+	// Asset is the checklist asset the items came from
+	Asset string          `json:"asset" yaml:"asset"`
+	Title string          `json:"title" yaml:"title"`
+	Items []ChecklistItem `json:"items" yaml:"items"`
+}
+
+// ChecklistItem is an item of a checklist
+type ChecklistItem struct {
+	Text string `json:"text" yaml:"text"`
+
+	// Required items block progression past their stage when checklists are enforced
+	Required bool `json:"required" yaml:"required"`
+
+	// Stage is the workflow stage the item is due in
+	Stage string `json:"stage,omitempty" yaml:"stage,omitempty"`
+
+	// Checked is read from index.md
+	Checked bool `json:"checked" yaml:"-"`
+}
+
+// UnmarshalYAML decodes an item given as its text, which is required, or as a mapping
+// with text, required and stage
+func (i *ChecklistItem) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*i = ChecklistItem{Text: node.Value, Required: true}
+		return nil
+	}
+
+	var item struct {
+		Text     string `yaml:"text"`
+		Required *bool  `yaml:"required"`
+		Stage    string `yaml:"stage"`
+	}
+	if err := node.Decode(&item); err != nil {
+		return err
+	}
+	*i = ChecklistItem{Text: item.Text, Required: item.Required == nil || *item.Required, Stage: item.Stage}
+	return nil
+}
+
+// DueStage returns the stage the item is due in
+func (i ChecklistItem) DueStage() string {
+	if i.Stage == "" {
+		return checklistStage
+	}
+	return i.Stage
+}
+
+// Blocking returns the required items left unchecked that are due before stage
+func (c *Checklist) Blocking(stage string) []ChecklistItem {
+	target := StageIndex(stage)
+	var blocking []ChecklistItem
+	for _, item := range c.Items {
+		if item.Required && !item.Checked && StageIndex(item.DueStage()) < target {
+			blocking = append(blocking, item)
+		}
+	}
+	return blocking
+}
+
+// Markdown renders the checklist as a section of index.md with every item unchecked
+func (c *Checklist) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", c.Title)
+	for _, item := range c.Items {
+		text := item.Text
+		if !item.Required {
+			text += " (optional)"
+		}
+		fmt.Fprintf(&b, "- [ ] %s\n", text)
+	}
+	return b.String()
+}
+
+// parseChecklist parses the content of a checklist asset: a YAML document with a title
+// and its items
+func parseChecklist(asset, content string) (*Checklist, error) {
+	checklist := &Checklist{Asset: asset}
+	if err := yaml.Unmarshal([]byte(content), checklist); err != nil {
+		return nil, fmt.Errorf("invalid checklist %s: %w", asset, err)
+	}
+	checklist.Asset = asset
+	if checklist.Title == "" {
+		checklist.Title = "Definition of Done"
+	}
+
+	for _, item := range checklist.Items {
+		if strings.TrimSpace(item.Text) == "" {
+			return nil, fmt.Errorf("invalid checklist %s: items must have text", asset)
+		}
+		if item.Stage != "" && StageIndex(item.Stage) < 0 {
+			return nil, fmt.Errorf("invalid checklist %s: unknown workflow stage %s", asset, item.Stage)
+		}
+	}
+	return checklist, nil
+}
+
+// loadChecklist fetches and parses the checklist asset configured for a task type. It
+// returns nil when the type has none.
+func (m *Manager) loadChecklist(ctx context.Context, taskType string) (*Checklist, error) {
+	name := m.taskConfig().Checklists[taskType]
+	if name == "" {
+		return nil, nil
+	}
+
+	client, err := m.factory.AssetClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset client: %w", err)
+	}
+	asset, err := client.GetAsset(ctx, name, assets.GetAssetOptions{UseCache: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist %s: %w", name, err)
+	}
+	return parseChecklist(name, asset.Content)
+}
+
+// recordChecklistUsage records the use of the checklist asset of a task type in the
+// workspace usage log, when asset usage is tracked. Failures are only logged.
+func (m *Manager) recordChecklistUsage(ctx context.Context, taskType string) {
+	name := m.taskConfig().Checklists[taskType]
+	if name == "" {
+		return
+	}
+	cfg, err := m.factory.Config()
+	if err != nil || !assets.UsageTracked(cfg) {
+		return
+	}
+	ws, err := m.factory.WorkspaceManager()
+	if err != nil {
+		return
+	}
+	status, err := ws.Status()
+	if err != nil {
+		return
+	}
+	if err := assets.RecordUsage(ctx, status.Root, "task create", name); err != nil {
+		m.logger.Warn("failed to record asset usage", "asset", name, "error", err)
+	}
+}
+
+// GetChecklist returns the checklist of a task with the items checked in its index.md,
+// or nil when the task has none
+func (m *Manager) GetChecklist(ctx context.Context, taskID string) (*Checklist, error) {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return readChecklist(task)
+}
+
+// readChecklist reads the checklist recorded in a task's manifest and the state of its
+// items in index.md. Items missing from index.md are unchecked.
+func readChecklist(task *Task) (*Checklist, error) {
+	doc, err := readManifest(task.ManifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if doc.Checklist == nil {
+		return nil, nil
+	}
+
+	index, err := os.ReadFile(task.IndexPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read index.md: %w", err)
+	}
+
+	checked := map[string]bool{}
+	for _, line := range strings.Split(string(index), "\n") {
+		if match := checkboxLine.FindStringSubmatch(line); match != nil {
+			text := strings.TrimSuffix(match[2], " (optional)")
+			checked[text] = checked[text] || match[1] != " "
+		}
+	}
+
+	checklist := *doc.Checklist
+	checklist.Items = make([]ChecklistItem, len(doc.Checklist.Items))
+	for i, item := range doc.Checklist.Items {
+		item.Checked = checked[item.Text]
+		checklist.Items[i] = item
+	}
+	return &checklist, nil
+}
+
+// checkChecklist returns an error listing the required items of the task's checklist
+// left unchecked before stage, when checklists are enforced
+func (m *Manager) checkChecklist(task *Task, stage string) error {
+	if !m.taskConfig().EnforceChecklists || StageIndex(stage) <= StageIndex(task.CurrentStage) {
+		return nil
+	}
+
+	checklist, err := readChecklist(task)
+	if err != nil || checklist == nil {
+		return err
+	}
+
+	blocking := checklist.Blocking(stage)
+	if len(blocking) == 0 {
+		return nil
+	}
+	items := make([]string, 0, len(blocking))
+	for _, item := range blocking {
+		items = append(items, fmt.Sprintf("[ ] %s (%s)", item.Text, StageName(item.DueStage())))
+	}
+	return &types.Error{
+		Code:    types.ErrorCodeInvalidInput,
+		Message: fmt.Sprintf("%s cannot move to %s: %d required checklist items are unchecked", task.ID, StageName(stage), len(blocking)),
+		Details: fmt.Sprintf("check them in %s:\n  %s", task.IndexPath, strings.Join(items, "\n  ")),
+	}
+}
@@ -0,0 +1,90 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const storyChecklist = `title: Definition of Done
+items:
+  - Acceptance criteria are met
+  - text: Design is reviewed
+    stage: 04-design
+  - text: Release notes are written
+    required: false
+`
+
+func TestParseChecklist(t *testing.T) {
+	checklist, err := parseChecklist("checklists/story-dod", storyChecklist)
+	require.NoError(t, err)
+	assert.Equal(t, "Definition of Done", checklist.Title)
+	assert.Equal(t, []ChecklistItem{
+		{Text: "Acceptance criteria are met", Required: true},
+		{Text: "Design is reviewed", Required: true, Stage: "04-design"},
+		{Text: "Release notes are written"},
+	}, checklist.Items)
+	assert.Equal(t, "## Definition of Done\n\n"+
+		"- [ ] Acceptance criteria are met\n"+
+		"- [ ] Design is reviewed\n"+
+		"- [ ] Release notes are written (optional)\n", checklist.Markdown())
+
+	_, err = parseChecklist("bad", "items:\n  - text: Shipped\n    stage: 09-done\n")
+	assert.Error(t, err)
+	_, err = parseChecklist("bad", "items:\n  - stage: 05-build\n")
+	assert.Error(t, err)
+}
+
+func TestManagerChecklist(t *testing.T) {
+	client := zentest.NewAssetClient()
+	client.Add(assets.AssetMetadata{Name: "checklists/story-dod", Type: assets.AssetTypeSchema}, storyChecklist)
+	f := zentest.NewFactory(t).WithAssetClient(client).Build()
+	m := NewManager(f.Factory)
+	m.settings = &Config{Checklists: map[string]string{"story": "checklists/story-dod"}, EnforceChecklists: true}
+	ctx := context.Background()
+
+	taskDir := filepath.Join(f.Workspace.Root(), ".zen", "work", "tasks", "PROJ-2")
+	require.NoError(t, os.MkdirAll(taskDir, 0755))
+	task := &Task{
+		ID: "PROJ-2", Title: "Add SSO", Type: "story", Status: "proposed", CurrentStage: "01-align",
+		WorkspacePath: taskDir,
+		IndexPath:     filepath.Join(taskDir, "index.md"),
+		ManifestPath:  filepath.Join(taskDir, "manifest.yaml"),
+	}
+	require.NoError(t, m.generateTaskFiles(ctx, task, &CreateTaskRequest{ID: "PROJ-2"}, nil))
+
+	index, err := os.ReadFile(task.IndexPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "## Definition of Done\n\n- [ ] Acceptance criteria are met\n")
+
+	checklist, err := m.GetChecklist(ctx, "PROJ-2")
+	require.NoError(t, err)
+	require.Len(t, checklist.Items, 3)
+	assert.False(t, checklist.Items[1].Checked)
+
+	require.NoError(t, m.ProgressTask(ctx, "PROJ-2", "04-design"), "items due in Design do not block entering it")
+
+	err = m.ProgressTask(ctx, "PROJ-2", "05-build")
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Contains(t, zenErr.Details, "[ ] Design is reviewed (Design)")
+
+	checkedIndex := strings.Replace(string(index), "- [ ] Design is reviewed", "- [x] Design is reviewed", 1)
+	require.NoError(t, os.WriteFile(task.IndexPath, []byte(checkedIndex), 0644))
+	require.NoError(t, m.ProgressTask(ctx, "PROJ-2", "05-build"))
+
+	err = m.ProgressTask(ctx, "PROJ-2", "07-learn")
+	require.ErrorAs(t, err, &zenErr, "items without a stage are due before Learn")
+	assert.NotContains(t, zenErr.Details, "Release notes", "optional items do not block")
+
+	m.settings.EnforceChecklists = false
+	assert.NoError(t, m.ProgressTask(ctx, "PROJ-2", "07-learn"))
+}
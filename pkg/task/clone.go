@@ -0,0 +1,412 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Content a clone can copy besides the index.md of the task
+const (
+	// CloneDocuments are the documents of the work-type directories, such as decision
+	// records and research, registered as the artifacts of the same stages
+	CloneDocuments = "documents"
+
+	// CloneAttachments are the attached files and links
+	CloneAttachments = "attachments"
+)
+
+// CloneContents are the kinds of content a clone can copy
+var CloneContents = []string{CloneDocuments, CloneAttachments}
+
+// templateFile describes a task template in its directory
+const templateFile = "template.yaml"
+
+var (
+	templateName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+	// checkedItem matches a checked markdown checklist item
+	checkedItem = regexp.MustCompile(`(?m)^(\s*[-*] )\[[xX]\]`)
+)
+
+// uncopiedDirs are never copied: sync metadata, workflow history and document revisions
+var uncopiedDirs = map[string]bool{"metadata": true, ".zenflow": true, historyDir: true}
+
+// CloneRequest contains parameters for creating a task from another task or a template
+type CloneRequest struct {
+	// ID of the new task; empty allocates one by the configured ID scheme
+	ID string
+
+	// Title, Owner and Team override those of the original
+	Title string
+	Owner string
+	Team  string
+
+	// Include are the content kinds copied besides index.md (CloneContents)
+	Include []string
+
+	// Variables fill {name} placeholders in the markdown documents copied
+	Variables map[string]string
+}
+
+// TaskTemplate is a task saved to start new tasks from, in .zen/templates/tasks/<name>
+type TaskTemplate struct {
+	Name string `json:"name" yaml:"-"`
+	Path string `json:"path" yaml:"-"`
+
+	// SourceID is the ID of the task the template was saved from, which its files
+	// reference and which new tasks replace with their own
+	SourceID string `json:"source_id" yaml:"source_id"`
+
+	Title    string `json:"title" yaml:"title"`
+	Type     string `json:"type" yaml:"type"`
+	Priority string `json:"priority" yaml:"priority"`
+	Team     string `json:"team,omitempty" yaml:"team,omitempty"`
+
+	// Artifacts are the documents of the template, by stage
+	Artifacts map[string][]string `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
+
+	Attachments []Attachment `json:"attachments,omitempty" yaml:"attachments,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	CreatedBy string    `json:"created_by" yaml:"created_by"`
+}
+
+// taskTemplatesDirectory returns the directory of the local task templates
+func (m *Manager) taskTemplatesDirectory() (string, error) {
+	tasksDir, err := m.tasksDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(filepath.Dir(tasksDir)), "templates", "tasks"), nil
+}
+
+// CloneTask creates a task with the structure and index.md of another, and the content
+// kinds included. Sync sources, workflow history, quality gates and branches are not
+// copied, and the items of checklists are unchecked, so the clone starts afresh.
+func (m *Manager) CloneTask(ctx context.Context, sourceID string, request *CloneRequest) (*Task, error) {
+	source, err := m.GetTask(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := templateOf(source)
+	if err != nil {
+		return nil, err
+	}
+	return m.instantiate(ctx, template, source.WorkspacePath, request)
+}
+
+// CreateFromTemplate creates a task from a local task template
+func (m *Manager) CreateFromTemplate(ctx context.Context, name string, request *CloneRequest) (*Task, error) {
+	template, err := m.GetTaskTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.instantiate(ctx, template, template.Path, request)
+}
+
+// SaveTaskTemplate saves a task as a local task template, with its index.md and the
+// content kinds included, to start new tasks from with CreateFromTemplate
+func (m *Manager) SaveTaskTemplate(ctx context.Context, taskID, name string, include []string) (*TaskTemplate, error) {
+	if !templateName.MatchString(name) {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("invalid template name: %q", name),
+			Details: "template names contain letters, digits, '-' and '_'",
+		}
+	}
+	if err := validateInclude(include); err != nil {
+		return nil, err
+	}
+
+	source, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := templateOf(source)
+	if err != nil {
+		return nil, err
+	}
+
+	templatesDir, err := m.taskTemplatesDirectory()
+	if err != nil {
+		return nil, err
+	}
+	template.Name, template.Path = name, filepath.Join(templatesDir, name)
+	if _, err := os.Stat(template.Path); err == nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeAlreadyExists,
+			Message: fmt.Sprintf("task template already exists: %s", name),
+			Details: fmt.Sprintf("remove %s to replace it", template.Path),
+		}
+	}
+	if err := os.MkdirAll(template.Path, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create task template: %w", err)
+	}
+
+	// Task IDs stay in the files of a template and are replaced when it is used
+	if err := copyTaskFiles(source.WorkspacePath, template.Path, source.ID, source.ID, include, nil); err != nil {
+		return nil, err
+	}
+	template.Artifacts = copiedArtifacts(template.Artifacts, template.Path)
+	if !slices.Contains(include, CloneAttachments) {
+		template.Attachments = nil
+	}
+	template.CreatedAt, template.CreatedBy = time.Now(), m.identity().Current()
+
+	data, err := yaml.Marshal(map[string]*TaskTemplate{"template": template})
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(template.Path, templateFile), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write task template: %w", err)
+	}
+
+	m.logger.Info("task template saved", "task_id", taskID, "template", name)
+	return template, nil
+}
+
+// GetTaskTemplate returns a local task template
+func (m *Manager) GetTaskTemplate(name string) (*TaskTemplate, error) {
+	templatesDir, err := m.taskTemplatesDirectory()
+	if err != nil {
+		return nil, err
+	}
+	if !templateName.MatchString(name) {
+		return nil, &types.Error{Code: types.ErrorCodeNotFound, Message: fmt.Sprintf("task template not found: %s", name)}
+	}
+
+	path := filepath.Join(templatesDir, name)
+	data, err := os.ReadFile(filepath.Join(path, templateFile)) // #nosec G304 - reading task template from workspace path
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeNotFound,
+				Message: fmt.Sprintf("task template not found: %s", name),
+				Details: "save a task as a template with 'zen task clone <task-id> --as-template <name>'",
+			}
+		}
+		return nil, fmt.Errorf("failed to read task template: %w", err)
+	}
+
+	var doc struct {
+		Template TaskTemplate `yaml:"template"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse task template %s: %w", name, err)
+	}
+	doc.Template.Name, doc.Template.Path = name, path
+	return &doc.Template, nil
+}
+
+// templateOf describes a task as a template of itself
+func templateOf(task *Task) (*TaskTemplate, error) {
+	artifacts, err := stageArtifacts(task.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+	return &TaskTemplate{
+		SourceID:    task.ID,
+		Title:       task.Title,
+		Type:        task.Type,
+		Priority:    task.Priority,
+		Team:        task.Team,
+		Artifacts:   artifacts,
+		Attachments: task.Attachments,
+	}, nil
+}
+
+// instantiate creates a task from a template, whose files are in dir
+func (m *Manager) instantiate(ctx context.Context, template *TaskTemplate, dir string, request *CloneRequest) (*Task, error) {
+	if err := validateInclude(request.Include); err != nil {
+		return nil, err
+	}
+
+	create := &CreateTaskRequest{
+		ID:       request.ID,
+		Title:    firstNonEmpty(request.Title, template.Title),
+		Type:     template.Type,
+		Priority: template.Priority,
+		Owner:    request.Owner,
+		Team:     firstNonEmpty(request.Team, template.Team),
+	}
+	task, err := m.CreateTask(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := copyTaskFiles(dir, task.WorkspacePath, template.SourceID, task.ID, request.Include, request.Variables); err != nil {
+		return nil, err
+	}
+
+	// The heading of index.md carries the title, which a clone may change
+	if request.Title != "" && request.Title != template.Title {
+		if err := retitleIndex(task.IndexPath, template.Title, request.Title); err != nil {
+			return nil, err
+		}
+	}
+
+	for stage, artifacts := range copiedArtifacts(template.Artifacts, task.WorkspacePath) {
+		if err := setManifestValue(task.ManifestPath, "workflow.stages."+stage+".artifacts", artifacts); err != nil {
+			return nil, fmt.Errorf("failed to register artifacts: %w", err)
+		}
+	}
+
+	if slices.Contains(request.Include, CloneAttachments) && len(template.Attachments) > 0 {
+		attachments := make([]Attachment, 0, len(template.Attachments))
+		for _, attachment := range template.Attachments {
+			// Uploads belong to the issues of the original
+			attachment.Sources = nil
+			attachment.Added = time.Now()
+			attachments = append(attachments, attachment)
+		}
+		if err := setManifestValue(task.ManifestPath, "attachments", attachments); err != nil {
+			return nil, fmt.Errorf("failed to record attachments: %w", err)
+		}
+	}
+
+	m.recordRevision(task)
+
+	m.logger.Info("task cloned", "from", template.SourceID, "template", template.Name, "task_id", task.ID)
+	return m.GetTask(ctx, task.ID)
+}
+
+// copyTaskFiles copies the index.md of a task and the content kinds included to
+// another task directory, replacing references to oldID with newID and the {name}
+// placeholders of variables in markdown, and unchecking its checklist items
+func copyTaskFiles(srcDir, dstDir, oldID, newID string, include []string, variables map[string]string) error {
+	pairs := make([]string, 0, 2*len(variables))
+	for name, value := range variables {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	placeholders := strings.NewReplacer(pairs...)
+
+	err := filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel == "." {
+				return nil
+			}
+			top := strings.Split(rel, "/")[0]
+			switch {
+			case uncopiedDirs[top]:
+				return filepath.SkipDir
+			case top == "attachments":
+				if !slices.Contains(include, CloneAttachments) {
+					return filepath.SkipDir
+				}
+			case !slices.Contains(include, CloneDocuments):
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.Contains(rel, "/") && rel != "index.md" {
+			// The manifest and other files of the task itself are not copied
+			return nil
+		}
+
+		target := filepath.Join(dstDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if filepath.Ext(path) != ".md" || strings.HasPrefix(rel, "attachments/") {
+			return copyFile(path, target)
+		}
+
+		data, err := os.ReadFile(path) // #nosec G304 - reading task documents from workspace path
+		if err != nil {
+			return err
+		}
+		data = checkedItem.ReplaceAll(replaceTaskID(data, oldID, newID), []byte("${1}[ ]"))
+		return os.WriteFile(target, []byte(placeholders.Replace(string(data))), 0644)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy task files: %w", err)
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src) // #nosec G304 - copying task files within the workspace
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst) // #nosec G304 - copying task files within the workspace
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// copiedArtifacts returns the artifacts, by stage, whose files are in dir
+func copiedArtifacts(artifacts map[string][]string, dir string) map[string][]string {
+	copied := map[string][]string{}
+	for stage, files := range artifacts {
+		for _, file := range files {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file))); err == nil {
+				copied[stage] = append(copied[stage], file)
+			}
+		}
+	}
+	return copied
+}
+
+// retitleIndex replaces the title in the heading of an index.md
+func retitleIndex(indexPath, oldTitle, newTitle string) error {
+	data, err := os.ReadFile(indexPath) // #nosec G304 - reading task index from workspace path
+	if err != nil {
+		return err
+	}
+	lines := strings.SplitN(string(data), "\n", 2)
+	if !strings.HasPrefix(lines[0], "# ") || !strings.HasSuffix(lines[0], oldTitle) {
+		return nil
+	}
+	lines[0] = strings.TrimSuffix(lines[0], oldTitle) + newTitle
+	return os.WriteFile(indexPath, []byte(strings.Join(lines, "\n")), 0644)
+}
+
+func validateInclude(include []string) error {
+	for _, kind := range include {
+		if !slices.Contains(CloneContents, kind) {
+			return &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("invalid content to copy: %s", kind),
+				Details: fmt.Sprintf("content is one of %s", strings.Join(CloneContents, ", ")),
+			}
+		}
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
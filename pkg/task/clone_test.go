@@ -0,0 +1,143 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCloneSource adds documents, an attachment and sync metadata to PROJ-1
+func newCloneSource(t *testing.T, tasksDir string) {
+	t.Helper()
+	taskDir := filepath.Join(tasksDir, "PROJ-1")
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "index.md"), []byte("# PROJ-1: Add login page\n\n- [x] Design is reviewed\n- [ ] Shipped\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "design", "decisions"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "design", "decisions", "0001-use-sso.md"), []byte("**Task:** PROJ-1\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "attachments"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "attachments", "mockup.png"), []byte("png"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "metadata"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "metadata", "jira.json"), []byte(`{"external_id": "PROJ-1"}`), 0644))
+
+	manifest := filepath.Join(taskDir, "manifest.yaml")
+	require.NoError(t, setManifestValue(manifest, "workflow.stages.04-design.artifacts", []string{"design/decisions/0001-use-sso.md"}))
+	require.NoError(t, setManifestValue(manifest, "attachments", []Attachment{
+		{Name: "mockup.png", Path: "attachments/mockup.png", Sources: map[string]string{"jira": "10001"}},
+	}))
+}
+
+func TestManagerCloneTask(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	newCloneSource(t, tasksDir)
+	ctx := context.Background()
+
+	clone, err := m.CloneTask(ctx, "PROJ-1", &CloneRequest{ID: "PROJ-2", Owner: "bob", Include: []string{CloneDocuments}})
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-2", clone.ID)
+	assert.Equal(t, "Add login page", clone.Title)
+	assert.Equal(t, "bob", clone.Owner)
+	assert.Equal(t, "proposed", clone.Status)
+	assert.Empty(t, clone.Sources, "sync sources are not copied")
+	assert.Empty(t, clone.Attachments)
+
+	index, err := os.ReadFile(clone.IndexPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# PROJ-2: Add login page\n\n- [ ] Design is reviewed\n- [ ] Shipped\n", string(index))
+
+	decision, err := os.ReadFile(filepath.Join(tasksDir, "PROJ-2", "design", "decisions", "0001-use-sso.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "**Task:** PROJ-2\n", string(decision))
+
+	artifacts, err := stageArtifacts(clone.ManifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"04-design": {"design/decisions/0001-use-sso.md"}}, artifacts)
+
+	assert.NoFileExists(t, filepath.Join(tasksDir, "PROJ-2", "metadata", "jira.json"))
+	assert.NoFileExists(t, filepath.Join(tasksDir, "PROJ-2", "attachments", "mockup.png"))
+
+	// Attachments are copied when included, without the uploads of the original
+	clone, err = m.CloneTask(ctx, "PROJ-1", &CloneRequest{ID: "PROJ-3", Title: "Add signup page", Include: []string{CloneAttachments}})
+	require.NoError(t, err)
+	assert.Equal(t, "Add signup page", clone.Title)
+	require.Len(t, clone.Attachments, 1)
+	assert.Empty(t, clone.Attachments[0].Sources)
+	assert.FileExists(t, filepath.Join(tasksDir, "PROJ-3", "attachments", "mockup.png"))
+	assert.NoFileExists(t, filepath.Join(tasksDir, "PROJ-3", "design", "decisions", "0001-use-sso.md"))
+
+	index, err = os.ReadFile(clone.IndexPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "# PROJ-3: Add signup page\n")
+}
+
+func TestManagerCloneTask_Errors(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		sourceID string
+		request  *CloneRequest
+		code     types.ErrorCode
+	}{
+		{name: "missing task", sourceID: "PROJ-9", request: &CloneRequest{ID: "PROJ-2"}, code: types.ErrorCodeNotFound},
+		{name: "existing task", sourceID: "PROJ-1", request: &CloneRequest{ID: "PROJ-1"}, code: types.ErrorCodeAlreadyExists},
+		{name: "invalid content", sourceID: "PROJ-1", request: &CloneRequest{ID: "PROJ-2", Include: []string{"history"}}, code: types.ErrorCodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.CloneTask(ctx, tt.sourceID, tt.request)
+			var zenErr *types.Error
+			require.ErrorAs(t, err, &zenErr)
+			assert.Equal(t, tt.code, zenErr.Code)
+		})
+	}
+}
+
+func TestManagerTaskTemplate(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	newCloneSource(t, tasksDir)
+	ctx := context.Background()
+
+	template, err := m.SaveTaskTemplate(ctx, "PROJ-1", "login-page", []string{CloneDocuments, CloneAttachments})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(filepath.Dir(filepath.Dir(tasksDir)), "templates", "tasks", "login-page"), template.Path)
+	assert.FileExists(t, filepath.Join(template.Path, "design", "decisions", "0001-use-sso.md"))
+	assert.FileExists(t, filepath.Join(template.Path, "attachments", "mockup.png"))
+	assert.NoDirExists(t, filepath.Join(template.Path, "metadata"))
+	assert.NoFileExists(t, filepath.Join(template.Path, "manifest.yaml"))
+
+	_, err = m.SaveTaskTemplate(ctx, "PROJ-1", "login-page", nil)
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeAlreadyExists, zenErr.Code)
+
+	_, err = m.SaveTaskTemplate(ctx, "PROJ-1", "../login", nil)
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+
+	saved, err := m.GetTaskTemplate("login-page")
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-1", saved.SourceID)
+	assert.Equal(t, "Add login page", saved.Title)
+	assert.Equal(t, map[string][]string{"04-design": {"design/decisions/0001-use-sso.md"}}, saved.Artifacts)
+
+	// The template outlives the task it was saved from
+	require.NoError(t, os.RemoveAll(filepath.Join(tasksDir, "PROJ-1")))
+
+	task, err := m.CreateFromTemplate(ctx, "login-page", &CloneRequest{ID: "PROJ-4", Include: []string{CloneDocuments}})
+	require.NoError(t, err)
+	assert.Equal(t, "Add login page", task.Title)
+
+	decision, err := os.ReadFile(filepath.Join(tasksDir, "PROJ-4", "design", "decisions", "0001-use-sso.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "**Task:** PROJ-4\n", string(decision))
+
+	_, err = m.CreateFromTemplate(ctx, "signup-page", &CloneRequest{ID: "PROJ-5"})
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeNotFound, zenErr.Code)
+}
@@ -0,0 +1,127 @@
+package task
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/daddia/zen/pkg/fs"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// taskLockTimeout bounds the wait for the lock of a task held by another command
+const taskLockTimeout = 10 * time.Second
+
+type forceWritesKey struct{}
+
+// withForcedWrites returns a context whose writes overwrite tasks modified by other
+// commands since they were read, as 'zen task sync --force' does
+func withForcedWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceWritesKey{}, true)
+}
+
+// forcedWrites reports whether writes in ctx skip the check for concurrent modification
+func forcedWrites(ctx context.Context) bool {
+	forced, _ := ctx.Value(forceWritesKey{}).(bool)
+	return forced
+}
+
+// concurrentModification returns the error of a write to a task another command changed
+// since it was read. Running the command again reads the task again, and succeeds.
+func concurrentModification(taskID string) error {
+	return &types.Error{
+		Code:    types.ErrorCodeConflict,
+		Message: fmt.Sprintf("task %s modified concurrently", taskID),
+		Details: "another command changed it since it was read; run the command again",
+	}
+}
+
+// lockedByOtherCommand returns the error of a command that timed out waiting for a lock
+// another command holds. It is not a concurrent modification: nothing was overwritten,
+// and running the command again once the other finished succeeds.
+func lockedByOtherCommand(message string, err error) error {
+	return &types.Error{
+		Code:    types.ErrorCodeLocked,
+		Message: message,
+		Details: err.Error(),
+	}
+}
+
+// IsConcurrentModification reports whether err means a task was modified concurrently,
+// so that the operation can be retried. A task locked by another command is not: its
+// error has the code ErrorCodeLocked, and --force does not help with it.
+func IsConcurrentModification(err error) bool {
+	var zenErr *types.Error
+	return errors.As(err, &zenErr) && zenErr.Code == types.ErrorCodeConflict
+}
+
+// manifestVersion returns the checksum of a manifest, its version for optimistic
+// concurrency checks
+func manifestVersion(path string) (string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - reading task manifest from workspace path
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data)), nil
+}
+
+// taskLockPath returns the lock file of the task in taskDir. Task directories are
+// .zen/work/tasks/<id>, and locks are kept with the other locks of the workspace in
+// .zen/run.
+func taskLockPath(taskDir string) string {
+	zenDir := filepath.Dir(filepath.Dir(filepath.Dir(taskDir)))
+	return filepath.Join(zenDir, "run", "tasks", filepath.Base(taskDir)+".lock")
+}
+
+// lockTask takes the lock of a task, so that no other command writes it until the lock
+// is released, and checks that its manifest is the version that was read. Tasks without
+// a directory are not locked.
+func lockTask(ctx context.Context, task *Task) (*fs.FileLock, error) {
+	if task.WorkspacePath == "" {
+		return nil, nil
+	}
+
+	lock, err := fs.AcquireLock(ctx, taskLockPath(task.WorkspacePath), fs.LockOptions{Timeout: taskLockTimeout})
+	if err != nil {
+		if errors.Is(err, fs.ErrLocked) {
+			return nil, lockedByOtherCommand(fmt.Sprintf("task %s is being written by another command", task.ID), err)
+		}
+		return nil, fmt.Errorf("failed to lock task %s: %w", task.ID, err)
+	}
+
+	if err := checkTaskVersion(ctx, task); err != nil {
+		lock.Release()
+		return nil, err
+	}
+	return lock, nil
+}
+
+// checkTaskVersion fails when the manifest of task changed since the task was read,
+// unless writes in ctx are forced
+func checkTaskVersion(ctx context.Context, task *Task) error {
+	if task.version == "" || task.ManifestPath == "" || forcedWrites(ctx) {
+		return nil
+	}
+	current, err := manifestVersion(task.ManifestPath)
+	if err != nil {
+		return nil
+	}
+	if current != task.version {
+		return concurrentModification(task.ID)
+	}
+	return nil
+}
+
+// refreshTaskVersion records the manifest of task, as written, as the version read
+func refreshTaskVersion(task *Task) {
+	if task.ManifestPath == "" {
+		return
+	}
+	if version, err := manifestVersion(task.ManifestPath); err == nil {
+		task.version = version
+	}
+}
@@ -0,0 +1,68 @@
+package task
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/fs"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveTask_ConcurrentModification(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	stale, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+
+	status := "in_progress"
+	_, err = m.UpdateTask(ctx, "PROJ-1", &TaskUpdates{Status: &status})
+	require.NoError(t, err)
+
+	stale.Title = "Add SSO login page"
+	err = m.saveTask(ctx, stale)
+	require.Error(t, err)
+	assert.True(t, IsConcurrentModification(err))
+	assert.EqualError(t, err, "task PROJ-1 modified concurrently: another command changed it since it was read; run the command again")
+
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Add login page", task.Title, "the task is left as the other command wrote it")
+	assert.Equal(t, "in_progress", task.Status)
+
+	require.NoError(t, m.saveTask(withForcedWrites(ctx), stale), "forced writes overwrite the task")
+	task, err = m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Add SSO login page", task.Title)
+}
+
+func TestSaveTask_Sequential(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+
+	task.Title = "Add SSO login page"
+	require.NoError(t, m.saveTask(ctx, task))
+	task.Priority = "P1"
+	require.NoError(t, m.saveTask(ctx, task), "a task's own writes are not concurrent modifications")
+}
+
+func TestTaskLockPath(t *testing.T) {
+	taskDir := filepath.Join("ws", ".zen", "work", "tasks", "PROJ-1")
+	assert.Equal(t, filepath.Join("ws", ".zen", "run", "tasks", "PROJ-1.lock"), taskLockPath(taskDir))
+}
+
+func TestLockedByOtherCommand(t *testing.T) {
+	err := lockedByOtherCommand("task PROJ-1 is being written by another command", fs.ErrLocked)
+
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeLocked, zenErr.Code)
+	assert.False(t, IsConcurrentModification(err), "--force does not help with a lock held by another command")
+	assert.True(t, IsConcurrentModification(concurrentModification("PROJ-1")))
+}
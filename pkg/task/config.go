@@ -2,6 +2,9 @@ package task
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/daddia/zen/internal/config"
 	"github.com/go-viper/mapstructure/v2"
@@ -17,14 +20,63 @@ type Config struct {
 
 	// Project key or identifier for tasks
 	ProjectKey string `yaml:"project_key" json:"project_key" mapstructure:"project_key"`
+
+	// Branch name template for 'zen task start' (placeholders: {prefix}, {type}, {id}, {slug})
+	BranchTemplate string `yaml:"branch_template" json:"branch_template" mapstructure:"branch_template"`
+
+	// Branch new task branches are created from; defaults to the current branch
+	BaseBranch string `yaml:"base_branch" json:"base_branch" mapstructure:"base_branch"`
+
+	// Remote task branches are pushed to by 'zen task finish'
+	Remote string `yaml:"remote" json:"remote" mapstructure:"remote"`
+
+	// Directory task worktrees are created in, relative to the workspace root
+	WorktreeDir string `yaml:"worktree_dir" json:"worktree_dir" mapstructure:"worktree_dir"`
+
+	// Conflict strategy of individual fields for 'zen task sync' (e.g. title: remote_wins, labels: union)
+	FieldPolicies map[string]string `yaml:"field_policies,omitempty" json:"field_policies,omitempty" mapstructure:"field_policies"`
+
+	// Checklist asset rendered into the index.md of new tasks, by task type (e.g. story: checklists/story-dod)
+	Checklists map[string]string `yaml:"checklists,omitempty" json:"checklists,omitempty" mapstructure:"checklists"`
+
+	// Block stage progression until the required checklist items of the stages left are checked
+	EnforceChecklists bool `yaml:"enforce_checklists" json:"enforce_checklists" mapstructure:"enforce_checklists"`
+
+	// Task ID scheme (free, sequence, ulid, external)
+	IDScheme string `yaml:"id_scheme" json:"id_scheme" mapstructure:"id_scheme"`
+
+	// Prefix of sequence IDs; defaults to the project key
+	IDPrefix string `yaml:"id_prefix" json:"id_prefix" mapstructure:"id_prefix"`
+
+	// Regular expression the IDs of new tasks must match
+	IDPattern string `yaml:"id_pattern" json:"id_pattern" mapstructure:"id_pattern"`
+
+	// Tasks created on a schedule by 'zen task generate-due' and 'zen watch'
+	Recurring []RecurringTask `yaml:"recurring,omitempty" json:"recurring,omitempty" mapstructure:"recurring"`
+
+	// Saved filters of 'zen task list --view', by name
+	Views map[string]View `yaml:"views,omitempty" json:"views,omitempty" mapstructure:"views"`
+
+	// Spreadsheet column 'zen task import' reads each task field from (e.g. title: Summary, owner: Assignee)
+	ImportMapping map[string]string `yaml:"import_mapping,omitempty" json:"import_mapping,omitempty" mapstructure:"import_mapping"`
+
+	// Snapshots taken before syncs change a task, restored by 'zen task sync undo'
+	SyncUndo SyncUndoConfig `yaml:"sync_undo" json:"sync_undo" mapstructure:"sync_undo"`
 }
 
 // DefaultConfig returns default task configuration
 func DefaultConfig() Config {
 	return Config{
-		Source:     "local",
-		Sync:       "manual",
-		ProjectKey: "",
+		Source:         "local",
+		Sync:           "manual",
+		ProjectKey:     "",
+		BranchTemplate: DefaultBranchTemplate,
+		Remote:         "origin",
+		IDScheme:       IDSchemeFree,
+		SyncUndo: SyncUndoConfig{
+			Keep:   DefaultSyncUndoKeep,
+			MaxAge: DefaultSyncUndoMaxAge,
+		},
 	}
 }
 
@@ -32,7 +84,7 @@ func DefaultConfig() Config {
 
 // Validate validates the task configuration
 func (c Config) Validate() error {
-	validSources := []string{"jira", "github", "linear", "monday", "asana", "local", "none", ""}
+	validSources := []string{"jira", "github", "linear", "notion", "servicenow", "monday", "asana", "local", "none", ""}
 	validSource := false
 	for _, s := range validSources {
 		if c.Source == s {
@@ -41,7 +93,7 @@ func (c Config) Validate() error {
 		}
 	}
 	if !validSource {
-		return fmt.Errorf("invalid source: %s (must be one of: jira, github, linear, monday, asana, local, none)", c.Source)
+		return fmt.Errorf("invalid source: %s (must be one of: jira, github, linear, notion, servicenow, monday, asana, local, none)", c.Source)
 	}
 
 	validSyncs := []string{"hourly", "daily", "manual", "none", ""}
@@ -56,9 +108,78 @@ func (c Config) Validate() error {
 		return fmt.Errorf("invalid sync: %s (must be one of: hourly, daily, manual, none)", c.Sync)
 	}
 
+	if c.BranchTemplate != "" && !strings.Contains(c.BranchTemplate, "{id}") {
+		return fmt.Errorf("invalid branch_template: %s (must contain {id})", c.BranchTemplate)
+	}
+
+	switch c.IDScheme {
+	case IDSchemeFree, IDSchemeULID, IDSchemeExternal, "":
+	case IDSchemeSequence:
+		if !idPrefix.MatchString(c.IDPrefixOf()) {
+			return fmt.Errorf("invalid id_prefix: %q (sequence IDs need a prefix of letters, digits and '_', such as ZEN)", c.IDPrefixOf())
+		}
+	default:
+		return fmt.Errorf("invalid id_scheme: %s (must be one of: %s)", c.IDScheme, strings.Join(IDSchemes, ", "))
+	}
+
+	if c.IDPattern != "" {
+		if _, err := regexp.Compile(c.IDPattern); err != nil {
+			return fmt.Errorf("invalid id_pattern: %w", err)
+		}
+	}
+
+	names := map[string]bool{}
+	for _, recurring := range c.Recurring {
+		if err := recurring.Validate(); err != nil {
+			return fmt.Errorf("invalid recurring task: %w", err)
+		}
+		if names[recurring.Name] {
+			return fmt.Errorf("invalid recurring task: %s is defined twice", recurring.Name)
+		}
+		names[recurring.Name] = true
+	}
+
+	for _, name := range c.ViewNames() {
+		if !templateName.MatchString(name) {
+			return fmt.Errorf("invalid view name %q (names contain letters, digits, '-' and '_')", name)
+		}
+		if err := c.Views[name].Validate(); err != nil {
+			return fmt.Errorf("invalid view %s: %w", name, err)
+		}
+	}
+
+	for field := range c.ImportMapping {
+		if !slices.Contains(ImportFields, strings.ToLower(field)) {
+			return fmt.Errorf("invalid import_mapping field: %s (must be one of: %s)", field, strings.Join(ImportFields, ", "))
+		}
+	}
+
+	if err := ValidateFieldPolicies(c.SyncSettings().FieldPolicies); err != nil {
+		return fmt.Errorf("invalid field_policies: %w", err)
+	}
+
+	if err := c.SyncUndo.Validate(); err != nil {
+		return fmt.Errorf("invalid sync_undo: %w", err)
+	}
+
 	return nil
 }
 
+// SyncSettings returns the sync settings the configuration describes
+func (c Config) SyncSettings() *SyncSettings {
+	settings := &SyncSettings{
+		Enabled:   c.Sync != "none",
+		Frequency: c.Sync,
+	}
+	if len(c.FieldPolicies) > 0 {
+		settings.FieldPolicies = make(map[string]ConflictStrategy, len(c.FieldPolicies))
+		for field, strategy := range c.FieldPolicies {
+			settings.FieldPolicies[strings.ToLower(field)] = ConflictStrategy(strategy)
+		}
+	}
+	return settings
+}
+
 // Defaults returns a new Config with default values
 func (c Config) Defaults() config.Configurable {
 	return DefaultConfig()
@@ -81,6 +202,7 @@ func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		Result:           &cfg,
 		WeaklyTypedInput: true,
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
 	})
 	if err != nil {
 		return cfg, fmt.Errorf("failed to create decoder: %w", err)
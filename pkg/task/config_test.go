@@ -43,6 +43,100 @@ func TestConfig_Validate(t *testing.T) {
 			wantError: true,
 			errorMsg:  "invalid sync",
 		},
+		{
+			name: "field policies",
+			config: Config{
+				Source:        "jira",
+				FieldPolicies: map[string]string{"title": "remote_wins", "Labels": "union"},
+			},
+			wantError: false,
+		},
+		{
+			name: "union for a field that is not a list",
+			config: Config{
+				Source:        "jira",
+				FieldPolicies: map[string]string{"title": "union"},
+			},
+			wantError: true,
+			errorMsg:  "invalid field_policies",
+		},
+		{
+			name:      "sequence IDs with the project key as prefix",
+			config:    Config{Source: "local", ProjectKey: "ZEN", IDScheme: IDSchemeSequence},
+			wantError: false,
+		},
+		{
+			name:      "sequence IDs without a prefix",
+			config:    Config{Source: "local", IDScheme: IDSchemeSequence},
+			wantError: true,
+			errorMsg:  "invalid id_prefix",
+		},
+		{
+			name:      "invalid ID scheme",
+			config:    Config{Source: "local", IDScheme: "uuid"},
+			wantError: true,
+			errorMsg:  "invalid id_scheme",
+		},
+		{
+			name:      "invalid ID pattern",
+			config:    Config{Source: "local", IDPattern: "^[A-Z+$"},
+			wantError: true,
+			errorMsg:  "invalid id_pattern",
+		},
+		{
+			name: "recurring tasks",
+			config: Config{Source: "local", Recurring: []RecurringTask{
+				{Name: "standup", Schedule: "0 9 * * mon-fri", Title: "Standup {date}"},
+				{Name: "release", Schedule: "@monthly", Template: "release", ID: "REL-{month}"},
+			}},
+			wantError: false,
+		},
+		{
+			name:      "recurring task with an invalid schedule",
+			config:    Config{Source: "local", Recurring: []RecurringTask{{Name: "standup", Schedule: "0 9 * *", Title: "Standup"}}},
+			wantError: true,
+			errorMsg:  "invalid recurring task: standup: schedule",
+		},
+		{
+			name:      "recurring task without a period in its ID",
+			config:    Config{Source: "local", Recurring: []RecurringTask{{Name: "release", Schedule: "@monthly", Template: "release", ID: "REL-{name}"}}},
+			wantError: true,
+			errorMsg:  "every period would get the same task",
+		},
+		{
+			name: "recurring task defined twice",
+			config: Config{Source: "local", Recurring: []RecurringTask{
+				{Name: "standup", Schedule: "@daily", Title: "Standup"},
+				{Name: "standup", Schedule: "@weekly", Title: "Standup"},
+			}},
+			wantError: true,
+			errorMsg:  "standup is defined twice",
+		},
+		{
+			name: "views",
+			config: Config{Source: "local", Views: map[string]View{
+				"my-open": {Filter: "owner=me,status!=completed", Columns: []string{"id", "title", "labels"}},
+			}},
+			wantError: false,
+		},
+		{
+			name:      "view with an unknown field",
+			config:    Config{Source: "local", Views: map[string]View{"mine": {Filter: "assignee=me"}}},
+			wantError: true,
+			errorMsg:  `invalid view mine: unknown field "assignee"`,
+		},
+		{
+			name:      "view with an unknown column",
+			config:    Config{Source: "local", Views: map[string]View{"mine": {Columns: []string{"id", "due"}}}},
+			wantError: true,
+			errorMsg:  `invalid view mine: unknown column "due"`,
+		},
+		{
+			name:      "import mapping of an unknown field",
+			config:    Config{Source: "local", ImportMapping: map[string]string{"Title": "Summary", "points": "Story Points"}},
+			wantError: true,
+			errorMsg:  "invalid import_mapping field: points",
+		},
 	}
 
 	for _, tt := range tests {
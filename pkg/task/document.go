@@ -0,0 +1,227 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/templates"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// DocumentKind is a type of document scaffolded into a work-type directory of a task
+type DocumentKind struct {
+	// Name is the kind, as used on the command line
+	Name string
+
+	// Template renders new documents of the kind
+	Template string
+
+	// Dir is where documents of the kind are written, relative to the task directory.
+	// Its first element is the work-type directory.
+	Dir string
+
+	// Stage is the workflow stage documents of the kind are artifacts of
+	Stage string
+
+	// Numbered documents are named with a sequence number, such as "0001-use-postgres.md"
+	Numbered bool
+}
+
+// Document kinds
+var (
+	DocumentADR      = DocumentKind{Name: "adr", Template: "adr.md", Dir: "design/decisions", Stage: "04-design", Numbered: true}
+	DocumentSpike    = DocumentKind{Name: "spike", Template: "spike.md", Dir: "spikes", Stage: "02-discover"}
+	DocumentResearch = DocumentKind{Name: "research", Template: "research.md", Dir: "research", Stage: "02-discover"}
+)
+
+var documentNumber = regexp.MustCompile(`^(\d{4})-`)
+
+// DocumentRequest contains parameters for scaffolding a document
+type DocumentRequest struct {
+	Kind  DocumentKind
+	Title string
+
+	// Stage overrides the stage the document is an artifact of
+	Stage string
+}
+
+// Document is a document scaffolded into a task
+type Document struct {
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+
+	// Number is the sequence number of numbered documents
+	Number int `json:"number,omitempty"`
+
+	// Path is the document file; Artifact is its path relative to the task directory, as
+	// registered in the stage's artifacts
+	Path     string `json:"path"`
+	Artifact string `json:"artifact"`
+	Stage    string `json:"stage"`
+}
+
+// NewDocument scaffolds a document of a kind from its template into the task's
+// work-type directory and registers it as an artifact of its workflow stage
+func (m *Manager) NewDocument(ctx context.Context, taskID string, request *DocumentRequest) (*Document, error) {
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return nil, &types.Error{Code: types.ErrorCodeInvalidInput, Message: "document title cannot be empty"}
+	}
+	stage := request.Stage
+	if stage == "" {
+		stage = request.Kind.Stage
+	}
+	if StageIndex(stage) < 0 {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("unknown workflow stage: %s", stage),
+			Details: fmt.Sprintf("stages are %s", strings.Join(WorkflowStages, ", ")),
+		}
+	}
+
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := m.factory.WorkspaceManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+	workType := strings.Split(request.Kind.Dir, "/")[0]
+	if err := ws.CreateWorkTypeDirectory(task.WorkspacePath, workType); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(task.WorkspacePath, filepath.FromSlash(request.Kind.Dir))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", request.Kind.Dir, err)
+	}
+
+	doc := &Document{Kind: request.Kind.Name, Title: title, Stage: stage}
+	name := Slugify(title)
+	if name == "" {
+		name = request.Kind.Name
+	}
+	if request.Kind.Numbered {
+		doc.Number, err = nextDocumentNumber(dir)
+		if err != nil {
+			return nil, err
+		}
+		name = fmt.Sprintf("%04d-%s", doc.Number, name)
+	}
+	doc.Path = filepath.Join(dir, name+".md")
+	doc.Artifact = request.Kind.Dir + "/" + name + ".md"
+
+	if _, err := os.Stat(doc.Path); err == nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeAlreadyExists,
+			Message: fmt.Sprintf("%s already exists", doc.Artifact),
+			Details: "choose another title",
+		}
+	}
+
+	content, err := templates.NewLocalTemplateLoader().RenderTemplate(request.Kind.Template, map[string]interface{}{
+		"TASK_ID":    task.ID,
+		"TASK_TITLE": task.Title,
+		"TITLE":      title,
+		"NUMBER":     fmt.Sprintf("%04d", doc.Number),
+		"DATE":       time.Now().Format("2006-01-02"),
+		"OWNER_NAME": m.identity().Name(task.Owner),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(doc.Path, []byte(content), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", doc.Artifact, err)
+	}
+
+	if err := m.addArtifact(task, stage, doc.Artifact); err != nil {
+		return nil, err
+	}
+
+	m.logger.Debug("document created", "task_id", taskID, "kind", doc.Kind, "path", doc.Artifact)
+	return doc, nil
+}
+
+// addArtifact registers a file of the task, relative to its directory, as an artifact
+// of a workflow stage in the manifest
+func (m *Manager) addArtifact(task *Task, stage, artifact string) error {
+	artifacts, err := stageArtifacts(task.ManifestPath)
+	if err != nil {
+		return err
+	}
+	for _, existing := range artifacts[stage] {
+		if existing == artifact {
+			return nil
+		}
+	}
+
+	if err := setManifestValue(task.ManifestPath, "workflow.stages."+stage+".artifacts", append(artifacts[stage], artifact)); err != nil {
+		return fmt.Errorf("failed to register artifact: %w", err)
+	}
+	return updateManifestFields(task.ManifestPath, map[string]string{
+		"dates.last_updated": time.Now().Format("2006-01-02 15:04:05"),
+	})
+}
+
+// stageArtifacts returns the artifacts registered in a manifest, by stage
+func stageArtifacts(manifestPath string) (map[string][]string, error) {
+	doc, err := readManifest(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	artifacts := map[string][]string{}
+	for stage, times := range doc.Workflow.Stages {
+		if len(times.Artifacts) > 0 {
+			artifacts[stage] = times.Artifacts
+		}
+	}
+	return artifacts, nil
+}
+
+// orderedArtifacts returns the artifacts of all stages, in stage order
+func orderedArtifacts(artifacts map[string][]string) []string {
+	stages := make([]string, 0, len(artifacts))
+	for stage := range artifacts {
+		stages = append(stages, stage)
+	}
+	sort.Slice(stages, func(i, j int) bool {
+		a, b := StageIndex(stages[i]), StageIndex(stages[j])
+		if a != b {
+			return a < b
+		}
+		return stages[i] < stages[j]
+	})
+
+	all := []string{}
+	for _, stage := range stages {
+		all = append(all, artifacts[stage]...)
+	}
+	return all
+}
+
+// nextDocumentNumber returns the number following the highest numbered document in dir
+func nextDocumentNumber(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	highest := 0
+	for _, entry := range entries {
+		if match := documentNumber.FindStringSubmatch(entry.Name()); match != nil {
+			if n, _ := strconv.Atoi(match[1]); n > highest {
+				highest = n
+			}
+		}
+	}
+	return highest + 1, nil
+}
@@ -0,0 +1,78 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerNewDocument(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+
+	adr, err := m.NewDocument(ctx, "PROJ-1", &DocumentRequest{Kind: DocumentADR, Title: "Use PostgreSQL"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, adr.Number)
+	assert.Equal(t, "design/decisions/0001-use-postgresql.md", adr.Artifact)
+	assert.Equal(t, "04-design", adr.Stage)
+
+	content, err := os.ReadFile(adr.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# ADR 0001: Use PostgreSQL")
+	assert.Contains(t, string(content), "PROJ-1")
+
+	next, err := m.NewDocument(ctx, "PROJ-1", &DocumentRequest{Kind: DocumentADR, Title: "Use JWT sessions"})
+	require.NoError(t, err)
+	assert.Equal(t, "design/decisions/0002-use-jwt-sessions.md", next.Artifact, "decisions are numbered in sequence")
+
+	spike, err := m.NewDocument(ctx, "PROJ-1", &DocumentRequest{Kind: DocumentSpike, Title: "SSO providers"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tasksDir, "PROJ-1", "spikes", "sso-providers.md"), spike.Path)
+
+	_, err = m.NewDocument(ctx, "PROJ-1", &DocumentRequest{Kind: DocumentResearch, Title: "Login analytics", Stage: "03-prioritize"})
+	require.NoError(t, err)
+
+	progress, err := m.GetTaskProgress(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"spikes/sso-providers.md",
+		"research/login-analytics.md",
+		"design/decisions/0001-use-postgresql.md",
+		"design/decisions/0002-use-jwt-sessions.md",
+	}, progress.Artifacts)
+
+	data, err := os.ReadFile(filepath.Join(tasksDir, "PROJ-1", "manifest.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# shown in lists")
+}
+
+func TestManagerNewDocument_Errors(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	_, err := m.NewDocument(ctx, "PROJ-1", &DocumentRequest{Kind: DocumentSpike, Title: "SSO providers"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		request *DocumentRequest
+		code    types.ErrorCode
+	}{
+		{"empty title", &DocumentRequest{Kind: DocumentSpike, Title: " "}, types.ErrorCodeInvalidInput},
+		{"unknown stage", &DocumentRequest{Kind: DocumentSpike, Title: "Caching", Stage: "09-ship"}, types.ErrorCodeInvalidInput},
+		{"existing document", &DocumentRequest{Kind: DocumentSpike, Title: "SSO Providers"}, types.ErrorCodeAlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.NewDocument(ctx, "PROJ-1", tt.request)
+			var zenErr *types.Error
+			require.ErrorAs(t, err, &zenErr)
+			assert.Equal(t, tt.code, zenErr.Code)
+		})
+	}
+}
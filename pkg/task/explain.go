@@ -0,0 +1,205 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/internal/integration"
+)
+
+// Steps of the decision trail of a sync
+const (
+	// DecisionSource records the source and direction a task was synced with
+	DecisionSource = "source"
+	// DecisionMapping records how people and labels were mapped between the task and
+	// its source
+	DecisionMapping = "mapping"
+	// DecisionCompare records how a field compared on each side and at the last sync
+	DecisionCompare = "compare"
+	// DecisionConflict records the policy that settled a field changed on both sides,
+	// and which side won
+	DecisionConflict = "conflict"
+	// DecisionApply records a value written to the task or its source
+	DecisionApply = "apply"
+)
+
+// SyncDecision is a step of the decision trail of a sync, recorded when
+// SyncOptions.Explain is set
+type SyncDecision struct {
+	Step    string `json:"step"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// String returns the decision as "step field: message"
+func (d SyncDecision) String() string {
+	if d.Field == "" {
+		return fmt.Sprintf("%s: %s", d.Step, d.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", d.Step, d.Field, d.Message)
+}
+
+// syncTrail collects the decisions of a sync
+type syncTrail struct {
+	mu        sync.Mutex
+	decisions []SyncDecision
+}
+
+type syncTrailKey struct{}
+
+// withSyncTrail returns a context whose sync records its decisions in the returned trail
+func withSyncTrail(ctx context.Context) (context.Context, *syncTrail) {
+	trail := &syncTrail{}
+	return context.WithValue(ctx, syncTrailKey{}, trail), trail
+}
+
+// explain records a decision in the trail of ctx, if it has one
+func explain(ctx context.Context, step, field, format string, args ...interface{}) {
+	trail, ok := ctx.Value(syncTrailKey{}).(*syncTrail)
+	if !ok {
+		return
+	}
+	trail.mu.Lock()
+	defer trail.mu.Unlock()
+	trail.decisions = append(trail.decisions, SyncDecision{Step: step, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// explainAll records decisions in the trail of ctx, if it has one
+func explainAll(ctx context.Context, decisions []SyncDecision) {
+	for _, decision := range decisions {
+		explain(ctx, decision.Step, decision.Field, "%s", decision.Message)
+	}
+}
+
+// Decisions returns the decisions recorded so far
+func (t *syncTrail) Decisions() []SyncDecision {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]SyncDecision(nil), t.decisions...)
+}
+
+// explainSync syncs a task with run, attaching the decision trail to its result when
+// opts.Explain is set
+func explainSync(ctx context.Context, opts *SyncOptions, run func(ctx context.Context) (*SyncResult, error)) (*SyncResult, error) {
+	if !opts.Explain {
+		return run(ctx)
+	}
+	ctx, trail := withSyncTrail(ctx)
+	result, err := run(ctx)
+	if result != nil {
+		result.Decisions = trail.Decisions()
+	}
+	return result, err
+}
+
+// explainSource records why source was synced, and how
+func explainSource(ctx context.Context, task *Task, source string, opts *SyncOptions) {
+	switch {
+	case len(opts.Sources) > 0:
+		explain(ctx, DecisionSource, "", "syncing with %s, as requested", source)
+	case len(task.Sources) > 1:
+		explain(ctx, DecisionSource, "", "syncing with %s, the first of the sources of the task: %s", source, strings.Join(sortedSources(task), ", "))
+	default:
+		explain(ctx, DecisionSource, "", "syncing with %s, the source of the task", source)
+	}
+
+	message := fmt.Sprintf("direction %s", opts.Direction)
+	if opts.Direction == SyncDirectionBidirectional {
+		message += fmt.Sprintf("; fields changed on both sides are settled by their field policy, or the %s strategy", opts.ConflictStrategy)
+	}
+	if opts.DryRun {
+		message += "; dry run, so neither side is changed"
+	}
+	explain(ctx, DecisionSource, "", "%s", message)
+}
+
+// sortedSources returns the names of the sources of a task, sorted
+func sortedSources(task *Task) []string {
+	sources := make([]string, 0, len(task.Sources))
+	for source := range task.Sources {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// explainPull records the fields taking the values of remote, the task in source, when
+// before is pulled into after, and the fields kept because source has no value for them
+func explainPull(ctx context.Context, before, after *Task, remote *TaskData, source string) {
+	for _, change := range diffTaskFields(ChangeSideLocal, before, after, previewFields) {
+		explain(ctx, DecisionApply, change.Field, "taken from %s, from %s to %s; a pull always takes the values of the source",
+			source, describeValue(change.From), describeValue(change.To))
+	}
+
+	kept := map[string]bool{
+		"description": remote.Description == "" && before.Description != "",
+		"owner":       remote.Owner == "" && before.Owner != "",
+		"team":        remote.Team == "" && before.Team != "",
+		"labels":      len(remote.Labels) == 0 && len(before.Labels) > 0,
+	}
+	for _, field := range previewFields {
+		if kept[field] {
+			explain(ctx, DecisionApply, field, "empty in %s, so the local value is kept", source)
+		}
+	}
+}
+
+// explainMerge records the fields a merge sets on each side
+func explainMerge(ctx context.Context, merge *mergeResult, merged *Task, source string) {
+	values := taskFieldValues(merged)
+	for _, field := range merge.LocalChanges {
+		explain(ctx, DecisionApply, field, "set locally to %s", describeValue(values[field]))
+	}
+	for _, field := range merge.RemoteChanges {
+		explain(ctx, DecisionApply, field, "set in %s to %s", source, describeValue(values[field]))
+	}
+}
+
+// explainResolution describes how strategy settled a conflict, keeping value
+func explainResolution(fc *integration.FieldConflict, strategy integration.ConflictStrategy, value interface{}) string {
+	switch {
+	case fc.Resolution == integration.ResolutionManual:
+		return "left for manual review; the local value is kept until it is resolved"
+	case fc.Resolution == integration.ResolutionMerged:
+		return fmt.Sprintf("the values of both sides are kept: %s", describeValue(toLabels(value)))
+	case strategy == integration.ConflictStrategyTimestamp && fc.Resolution == integration.ResolutionRemote:
+		return fmt.Sprintf("remote was updated at %s, after local at %s; the remote value wins",
+			formatTime(fc.ExternalTimestamp), formatTime(fc.ZenTimestamp))
+	case strategy == integration.ConflictStrategyTimestamp:
+		return fmt.Sprintf("local was updated at %s, not before remote at %s; the local value wins",
+			formatTime(fc.ZenTimestamp), formatTime(fc.ExternalTimestamp))
+	case fc.Resolution == integration.ResolutionRemote:
+		return "the remote value wins"
+	default:
+		return "the local value wins"
+	}
+}
+
+// describeValue returns a field value for the decision trail: strings quoted and cut
+// short, lists in brackets
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return "[" + strings.Join(v, ", ") + "]"
+	case string:
+		const limit = 60
+		if runes := []rune(v); len(runes) > limit {
+			v = string(runes[:limit]) + "…"
+		}
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// formatTime returns a timestamp for the decision trail
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "an unknown time"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
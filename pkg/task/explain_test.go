@@ -0,0 +1,93 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainSync_Bidirectional(t *testing.T) {
+	updated := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	task := &Task{
+		ID:       "PROJ-1",
+		Title:    "Add login",
+		Status:   "in_progress",
+		Priority: "P1",
+		Labels:   []string{"auth"},
+		Updated:  updated,
+		Sources: map[string]*TaskSource{"jira": {
+			ExternalID: "PROJ-1",
+			Snapshot:   &SyncSnapshot{Title: "Login", Status: "proposed", Priority: "P2", Labels: []string{"auth"}},
+		}},
+	}
+	remote := &TaskData{Title: "Add SSO login", Status: "proposed", Priority: "P2", Labels: []string{"auth"}, Updated: updated.Add(time.Hour)}
+	opts := &SyncOptions{Direction: SyncDirectionBidirectional, ConflictStrategy: ConflictStrategyTimestamp, DryRun: true, Explain: true}
+
+	result, err := explainSync(context.Background(), opts, func(ctx context.Context) (*SyncResult, error) {
+		explainSource(ctx, task, "jira", opts)
+		return (&Manager{}).previewSync(ctx, task, "jira", opts, remote)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []SyncDecision{
+		{Step: DecisionSource, Message: "syncing with jira, the source of the task"},
+		{Step: DecisionSource, Message: "direction bidirectional; fields changed on both sides are settled by their field policy, or the timestamp strategy; dry run, so neither side is changed"},
+		{Step: DecisionCompare, Field: "title", Message: `changed on both sides since the last sync: local "Add login", remote "Add SSO login"`},
+		{Step: DecisionConflict, Field: "title", Message: "settled by the timestamp conflict strategy, as it has no field policy"},
+		{Step: DecisionConflict, Field: "title", Message: "remote was updated at 2025-03-01T13:00:00Z, after local at 2025-03-01T12:00:00Z; the remote value wins"},
+		{Step: DecisionCompare, Field: "description", Message: `same on both sides: ""`},
+		{Step: DecisionCompare, Field: "status", Message: `changed locally since the last sync, from "proposed" to "in_progress"; the local value is kept`},
+		{Step: DecisionCompare, Field: "priority", Message: `changed locally since the last sync, from "P2" to "P1"; the local value is kept`},
+		{Step: DecisionCompare, Field: "owner", Message: `same on both sides: ""`},
+		{Step: DecisionCompare, Field: "labels", Message: "same on both sides: [auth]"},
+		{Step: DecisionApply, Field: "title", Message: `set locally to "Add SSO login"`},
+		{Step: DecisionApply, Field: "priority", Message: `set in jira to "P1"`},
+		{Step: DecisionApply, Field: "status", Message: `set in jira to "in_progress"`},
+	}, result.Decisions)
+}
+
+func TestExplainSync_Pull(t *testing.T) {
+	task := &Task{ID: "PROJ-1", Title: "Add login", Description: "Local notes", Sources: map[string]*TaskSource{"jira": {ExternalID: "PROJ-1"}}}
+	remote := &TaskData{Title: "Add SSO login"}
+	opts := &SyncOptions{Direction: SyncDirectionPull, DryRun: true, Explain: true}
+
+	result, err := explainSync(context.Background(), opts, func(ctx context.Context) (*SyncResult, error) {
+		return (&Manager{}).previewSync(ctx, task, "jira", opts, remote)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []SyncDecision{
+		{Step: DecisionApply, Field: "title", Message: `taken from jira, from "Add login" to "Add SSO login"; a pull always takes the values of the source`},
+		{Step: DecisionApply, Field: "description", Message: "empty in jira, so the local value is kept"},
+	}, result.Decisions)
+}
+
+func TestExplainSync_NotExplained(t *testing.T) {
+	result, err := explainSync(context.Background(), &SyncOptions{}, func(ctx context.Context) (*SyncResult, error) {
+		explain(ctx, DecisionSource, "", "recorded only when explained")
+		return &SyncResult{}, nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Decisions)
+}
+
+func TestExplainPolicy_FieldPolicy(t *testing.T) {
+	merge, err := mergeThreeWay(nil, &Task{Labels: []string{"auth"}}, &TaskData{Labels: []string{"api"}}, &SyncOptions{
+		ConflictStrategy: ConflictStrategyLocalWins,
+		FieldPolicies:    map[string]ConflictStrategy{"labels": ConflictStrategyUnion},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, merge.Decisions, SyncDecision{Step: DecisionConflict, Field: "labels", Message: "settled by the union field policy"})
+	assert.Contains(t, merge.Decisions, SyncDecision{Step: DecisionConflict, Field: "labels", Message: "the values of both sides are kept: [auth, api]"})
+}
+
+func TestDescribeValue(t *testing.T) {
+	assert.Equal(t, `"Add login"`, describeValue("Add login"))
+	assert.Equal(t, "[a, b]", describeValue([]string{"a", "b"}))
+	long := describeValue("0123456789012345678901234567890123456789012345678901234567890123456789")
+	assert.Equal(t, `"012345678901234567890123456789012345678901234567890123456789…"`, long)
+}
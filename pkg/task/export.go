@@ -0,0 +1,226 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+)
+
+// ExportSchemaVersion is the version of the schema of exported task records. Fields may
+// be added to records within a version; it is raised when fields are removed, renamed or
+// change meaning, so that pipelines loading exports can tell which schema they read.
+const ExportSchemaVersion = 1
+
+// Export formats
+const (
+	// ExportJSONL writes a record per line, as data warehouses load
+	ExportJSONL = "jsonl"
+
+	// ExportJSON writes an array of records
+	ExportJSON = "json"
+)
+
+// ExportFormats are the formats tasks can be exported in
+var ExportFormats = []string{ExportJSONL, ExportJSON}
+
+// ExportRequest contains parameters for exporting tasks
+type ExportRequest struct {
+	Format string
+
+	// Since leaves out tasks last updated before it, unless it is zero
+	Since time.Time
+}
+
+// ExportRecord is the exported record of a task
+type ExportRecord struct {
+	SchemaVersion int        `json:"schema_version"`
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Type          string     `json:"type"`
+	Status        string     `json:"status"`
+	Priority      string     `json:"priority"`
+	Owner         string     `json:"owner"`
+	Team          string     `json:"team"`
+	Created       time.Time  `json:"created"`
+	Updated       time.Time  `json:"updated"`
+	DueDate       *time.Time `json:"due_date"`
+	Labels        []string   `json:"labels"`
+	Tags          []string   `json:"tags"`
+	Stage         string     `json:"stage"`
+	Progress      int        `json:"progress"`
+	Branch        string     `json:"branch"`
+
+	// Stages records when the task entered and left workflow stages, by stage ID
+	Stages map[string]*StageTimes `json:"stages"`
+
+	Sources      []ExportSource `json:"sources"`
+	PullRequests []ExportSource `json:"pull_requests"`
+
+	// Gates are the quality gates of the task, by stage, then name
+	Gates []QualityGate `json:"gates"`
+}
+
+// ExportSource is an external item a task is linked to, such as its issue or a pull
+// request, without the sync state kept for it
+type ExportSource struct {
+	System        string     `json:"system"`
+	ExternalID    string     `json:"external_id"`
+	ExternalURL   string     `json:"external_url"`
+	LastSync      *time.Time `json:"last_sync"`
+	SyncEnabled   bool       `json:"sync_enabled"`
+	SyncDirection string     `json:"sync_direction"`
+}
+
+// ExportTasks writes the records of the workspace's tasks to w, by ID, and returns how
+// many were written. Records are written as they are read, so that exports of large
+// workspaces can be streamed.
+func (m *Manager) ExportTasks(ctx context.Context, w io.Writer, request *ExportRequest) (int, error) {
+	format := request.Format
+	if format == "" {
+		format = ExportJSONL
+	}
+	if format != ExportJSONL && format != ExportJSON {
+		return 0, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("unknown export format: %s", request.Format),
+			Details: "formats are jsonl and json",
+		}
+	}
+
+	tasks, err := m.ListTasks(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	if format == ExportJSON {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return 0, fmt.Errorf("failed to write export: %w", err)
+		}
+	}
+
+	count := 0
+	for _, task := range tasks {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		if !request.Since.IsZero() && task.Updated.Before(request.Since) {
+			continue
+		}
+
+		record, err := m.exportRecord(task)
+		if err != nil {
+			return count, fmt.Errorf("failed to export task %s: %w", task.ID, err)
+		}
+
+		var data []byte
+		switch format {
+		case ExportJSON:
+			data, err = json.MarshalIndent(record, "  ", "  ")
+			separator := ",\n  "
+			if count == 0 {
+				separator = "\n  "
+			}
+			data = append([]byte(separator), data...)
+		default:
+			data, err = json.Marshal(record)
+			data = append(data, '\n')
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to encode task %s: %w", task.ID, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return count, fmt.Errorf("failed to write export: %w", err)
+		}
+		count++
+	}
+
+	if format == ExportJSON {
+		closing := "]\n"
+		if count > 0 {
+			closing = "\n]\n"
+		}
+		if _, err := io.WriteString(w, closing); err != nil {
+			return count, fmt.Errorf("failed to write export: %w", err)
+		}
+	}
+
+	m.logger.Debug("exported tasks", "format", format, "count", count)
+	return count, nil
+}
+
+// exportRecord returns the record of a task, with the quality gates of its manifest.
+// Lists are empty rather than null, so that every record has the same shape.
+func (m *Manager) exportRecord(task *Task) (*ExportRecord, error) {
+	gates, err := qualityGates(task.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &ExportRecord{
+		SchemaVersion: ExportSchemaVersion,
+		ID:            task.ID,
+		Title:         task.Title,
+		Description:   task.Description,
+		Type:          task.Type,
+		Status:        task.Status,
+		Priority:      task.Priority,
+		Owner:         task.Owner,
+		Team:          task.Team,
+		Created:       task.Created,
+		Updated:       task.Updated,
+		DueDate:       task.DueDate,
+		Labels:        append([]string{}, task.Labels...),
+		Tags:          append([]string{}, task.Tags...),
+		Stage:         task.CurrentStage,
+		Progress:      task.Progress,
+		Stages:        map[string]*StageTimes{},
+		Sources:       []ExportSource{},
+		PullRequests:  []ExportSource{},
+		Gates:         orderedGates(gates),
+	}
+	if task.Git != nil {
+		record.Branch = task.Git.Branch
+	}
+	for stage, times := range task.Stages {
+		record.Stages[stage] = times
+	}
+
+	names := make([]string, 0, len(task.Sources))
+	for name := range task.Sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		record.Sources = append(record.Sources, exportSource(name, task.Sources[name]))
+	}
+	for _, pr := range task.PullRequests {
+		record.PullRequests = append(record.PullRequests, exportSource(pr.System, pr))
+	}
+
+	return record, nil
+}
+
+func exportSource(system string, source *TaskSource) ExportSource {
+	exported := ExportSource{
+		System:        system,
+		ExternalID:    source.ExternalID,
+		ExternalURL:   source.ExternalURL,
+		SyncEnabled:   source.SyncEnabled,
+		SyncDirection: source.SyncDirection,
+	}
+	if source.System != "" {
+		exported.System = source.System
+	}
+	if !source.LastSync.IsZero() {
+		lastSync := source.LastSync
+		exported.LastSync = &lastSync
+	}
+	return exported
+}
@@ -0,0 +1,80 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerExportTasks(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+
+	_, err := m.PassGate(ctx, "PROJ-1", "design-review", &GatePassRequest{Evidence: []string{"https://github.com/acme/web/pull/42"}})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(tasksDir, "PROJ-2"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tasksDir, "PROJ-2", "manifest.yaml"), []byte(`task:
+  id: PROJ-2
+  title: Add SSO
+  status: completed
+`), 0644))
+	old := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(filepath.Join(tasksDir, "PROJ-2", "manifest.yaml"), old, old))
+
+	var out bytes.Buffer
+	count, err := m.ExportTasks(ctx, &out, &ExportRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2, "a record per line")
+	var record ExportRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, ExportSchemaVersion, record.SchemaVersion)
+	assert.Equal(t, "PROJ-1", record.ID)
+	assert.Equal(t, "alice", record.Owner)
+	assert.Equal(t, "01-align", record.Stage)
+	require.Len(t, record.Gates, 1)
+	assert.Equal(t, "design-review", record.Gates[0].Name)
+	assert.Equal(t, GatePassed, record.Gates[0].Status)
+	assert.Contains(t, lines[1], `"labels":[],"tags":[]`, "lists are empty rather than null")
+	assert.Contains(t, lines[1], `"sources":[],"pull_requests":[],"gates":[]`)
+
+	out.Reset()
+	count, err = m.ExportTasks(ctx, &out, &ExportRequest{Format: ExportJSON, Since: old.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "tasks updated before since are left out")
+	var records []ExportRecord
+	require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+	require.Len(t, records, 1)
+	assert.Equal(t, "PROJ-1", records[0].ID)
+}
+
+func TestManagerExportTasks_Empty(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	var out bytes.Buffer
+	count, err := m.ExportTasks(context.Background(), &out, &ExportRequest{Format: ExportJSON, Since: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Equal(t, "[]\n", out.String())
+}
+
+func TestManagerExportTasks_InvalidFormat(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	_, err := m.ExportTasks(context.Background(), &bytes.Buffer{}, &ExportRequest{Format: "csv"})
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+}
@@ -0,0 +1,223 @@
+package task
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Quality gate statuses
+const (
+	GatePassed  = "passed"
+	GateFailed  = "failed"
+	GatePending = "pending"
+)
+
+// Evidence types
+const (
+	EvidenceFile        = "file"
+	EvidenceURL         = "url"
+	EvidencePullRequest = "pr"
+)
+
+var (
+	gateName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+	// pullRequestRef matches "#42" and "owner/repo#42"
+	pullRequestRef = regexp.MustCompile(`^([\w.-]+/[\w.-]+)?#\d+$`)
+	pullRequestURL = regexp.MustCompile(`/(pull|pulls|merge_requests)/\d+`)
+)
+
+// Evidence is a file, link or pull request backing a quality gate, with who recorded
+// it and when
+type Evidence struct {
+	Type string `json:"type" yaml:"type"`
+
+	// Reference is the URL or pull request, or the path of the file: relative to the
+	// task directory for files in it, else absolute
+	Reference string `json:"reference" yaml:"reference"`
+
+	// Checksum is the SHA-256 of a file when it was recorded
+	Checksum string `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+
+	AddedBy string    `json:"added_by" yaml:"added_by"`
+	AddedAt time.Time `json:"added_at" yaml:"added_at"`
+}
+
+// GatePassRequest contains parameters for passing a quality gate
+type GatePassRequest struct {
+	// Evidence are files, URLs and pull requests ("#42", "owner/repo#42" or their URL)
+	Evidence []string
+
+	// Stage is the stage the gate guards; it defaults to the current stage of the task,
+	// and is kept when the gate was passed before
+	Stage string
+}
+
+// PassGate records a quality gate of a task as passed by the current user, with its
+// evidence. Passing a gate again adds the new evidence to the existing record.
+func (m *Manager) PassGate(ctx context.Context, taskID, gate string, request *GatePassRequest) (*QualityGate, error) {
+	if !gateName.MatchString(gate) {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("invalid gate name: %q", gate),
+			Details: "gate names contain letters, digits, '-' and '_'",
+		}
+	}
+	if request.Stage != "" && StageIndex(request.Stage) < 0 {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("unknown workflow stage: %s", request.Stage),
+			Details: fmt.Sprintf("stages are %s", strings.Join(WorkflowStages, ", ")),
+		}
+	}
+
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	gates, err := qualityGates(task.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	by := m.identity().Current()
+	record := gates[gate]
+	for _, ref := range request.Evidence {
+		evidence, err := resolveEvidence(task.WorkspacePath, ref)
+		if err != nil {
+			return nil, err
+		}
+		evidence.AddedBy, evidence.AddedAt = by, now
+		record.Evidence = append(record.Evidence, *evidence)
+	}
+
+	record.Name = gate
+	record.Status = GatePassed
+	record.CheckedAt = &now
+	record.CheckedBy = by
+	if request.Stage != "" {
+		record.Stage = request.Stage
+	} else if record.Stage == "" {
+		record.Stage = task.CurrentStage
+	}
+
+	if err := setManifestValue(task.ManifestPath, "quality_gates."+gate, record); err != nil {
+		return nil, fmt.Errorf("failed to record quality gate: %w", err)
+	}
+	if err := updateManifestFields(task.ManifestPath, map[string]string{
+		"dates.last_updated": now.Format("2006-01-02 15:04:05"),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update task manifest: %w", err)
+	}
+
+	m.logger.Debug("quality gate passed", "task_id", taskID, "gate", gate, "by", by, "evidence", len(request.Evidence))
+	return &record, nil
+}
+
+// qualityGates returns the quality gates recorded in a manifest, by name
+func qualityGates(manifestPath string) (map[string]QualityGate, error) {
+	doc, err := readManifest(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	gates := make(map[string]QualityGate, len(doc.QualityGates))
+	for name, gate := range doc.QualityGates {
+		gate.Name = name
+		gates[name] = gate
+	}
+	return gates, nil
+}
+
+// orderedGates returns quality gates by stage, then name
+func orderedGates(gates map[string]QualityGate) []QualityGate {
+	ordered := make([]QualityGate, 0, len(gates))
+	for _, gate := range gates {
+		ordered = append(ordered, gate)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		a, b := StageIndex(ordered[i].Stage), StageIndex(ordered[j].Stage)
+		if a != b {
+			return a < b
+		}
+		return ordered[i].Name < ordered[j].Name
+	})
+	return ordered
+}
+
+// resolveEvidence classifies a reference as a pull request, URL or file. Files must
+// exist, either as given or relative to the task directory, and are checksummed.
+func resolveEvidence(taskDir, ref string) (*Evidence, error) {
+	ref = strings.TrimSpace(ref)
+	if pullRequestRef.MatchString(ref) {
+		return &Evidence{Type: EvidencePullRequest, Reference: ref}, nil
+	}
+	if isLink(ref) {
+		u, _ := url.Parse(ref)
+		if pullRequestURL.MatchString(u.Path) {
+			return &Evidence{Type: EvidencePullRequest, Reference: ref}, nil
+		}
+		return &Evidence{Type: EvidenceURL, Reference: ref}, nil
+	}
+
+	path := ref
+	if _, err := os.Stat(path); err != nil && !filepath.IsAbs(ref) {
+		path = filepath.Join(taskDir, filepath.FromSlash(ref))
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := fileChecksum(path)
+	if err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("invalid evidence: %s", ref),
+			Details: "evidence is a file, an http(s) URL, or a pull request such as #42 or owner/repo#42",
+		}
+	}
+
+	if taskDir, err := filepath.Abs(taskDir); err == nil {
+		if rel, err := filepath.Rel(taskDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = filepath.ToSlash(rel)
+		}
+	}
+	return &Evidence{Type: EvidenceFile, Reference: path, Checksum: checksum}, nil
+}
+
+// fileChecksum returns the SHA-256 of a regular file
+func fileChecksum(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%s is not a file", path)
+	}
+
+	file, err := os.Open(path) // #nosec G304 - reading gate evidence the user named
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+	return checksumOf(hasher), nil
+}
@@ -0,0 +1,83 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerPassGate(t *testing.T) {
+	t.Setenv("USER", "ana")
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+
+	taskDir := filepath.Join(tasksDir, "PROJ-1")
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "review.md"), []byte("approved"), 0644))
+
+	gate, err := m.PassGate(ctx, "PROJ-1", "design-review", &GatePassRequest{
+		Evidence: []string{"review.md", "https://github.com/acme/web/pull/42", "https://wiki.example.com/login"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, GatePassed, gate.Status)
+	assert.Equal(t, "ana", gate.CheckedBy)
+	assert.Equal(t, "01-align", gate.Stage, "gates default to the current stage")
+	require.Len(t, gate.Evidence, 3)
+	assert.Equal(t, EvidenceFile, gate.Evidence[0].Type)
+	assert.Equal(t, "review.md", gate.Evidence[0].Reference, "files in the task are relative to it")
+	assert.True(t, strings.HasPrefix(gate.Evidence[0].Checksum, "sha256:"))
+	assert.Equal(t, EvidencePullRequest, gate.Evidence[1].Type)
+	assert.Equal(t, EvidenceURL, gate.Evidence[2].Type)
+
+	_, err = m.PassGate(ctx, "PROJ-1", "security", &GatePassRequest{Evidence: []string{"acme/web#7"}, Stage: "05-build"})
+	require.NoError(t, err)
+	_, err = m.PassGate(ctx, "PROJ-1", "design-review", &GatePassRequest{Evidence: []string{"#43"}})
+	require.NoError(t, err)
+
+	progress, err := m.GetTaskProgress(ctx, "PROJ-1")
+	require.NoError(t, err)
+	require.Len(t, progress.QualityGates, 2)
+	assert.Equal(t, "design-review", progress.QualityGates[0].Name)
+	assert.Len(t, progress.QualityGates[0].Evidence, 4, "passing a gate again adds its evidence")
+	assert.Equal(t, "ana", progress.QualityGates[0].Evidence[3].AddedBy)
+	assert.NotNil(t, progress.QualityGates[0].CheckedAt)
+	assert.Equal(t, "security", progress.QualityGates[1].Name)
+	assert.Equal(t, "05-build", progress.QualityGates[1].Stage)
+
+	data, err := os.ReadFile(filepath.Join(taskDir, "manifest.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# shown in lists")
+}
+
+func TestManagerPassGate_Errors(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		gate    string
+		request *GatePassRequest
+	}{
+		{"invalid name", "design.review", &GatePassRequest{}},
+		{"unknown stage", "review", &GatePassRequest{Stage: "09-ship"}},
+		{"missing file", "review", &GatePassRequest{Evidence: []string{"missing.md"}}},
+		{"directory", "review", &GatePassRequest{Evidence: []string{t.TempDir()}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.PassGate(ctx, "PROJ-1", tt.gate, tt.request)
+			var zenErr *types.Error
+			require.ErrorAs(t, err, &zenErr)
+			assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+		})
+	}
+
+	progress, err := m.GetTaskProgress(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Empty(t, progress.QualityGates, "failed passes record nothing")
+}
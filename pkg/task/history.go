@@ -0,0 +1,251 @@
+package task
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// historyDir is the directory, in a task directory, holding the revisions of its
+// documents
+const historyDir = ".history"
+
+// historyLog is the file, in the history directory, listing the revisions of a task,
+// one JSON document a line
+const historyLog = "revisions.jsonl"
+
+// historyFiles are the documents of a task whose revisions are kept
+var historyFiles = []string{"index.md", "manifest.yaml"}
+
+// Revision is the state of the documents of a task after a write
+type Revision struct {
+	Rev  int       `json:"rev"`
+	Time time.Time `json:"time"`
+
+	// Files are the checksums of the documents, which name their content in the
+	// history; documents missing from the task have none
+	Files map[string]string `json:"files"`
+
+	// Changed are the documents that differ from the revision before
+	Changed []string `json:"changed"`
+}
+
+// TaskDiff is the difference between a revision of the documents of a task and the
+// documents as they are
+type TaskDiff struct {
+	TaskID string     `json:"task_id"`
+	Rev    int        `json:"rev"`
+	Files  []FileDiff `json:"files"`
+}
+
+// FileDiff is the unified diff of a document of a task
+type FileDiff struct {
+	Path string `json:"path"`
+	Diff string `json:"diff"`
+}
+
+// recordRevision records the documents of the task in taskDir as a new revision, when
+// they differ from the last one. Each version of a document is stored once, named by
+// its checksum.
+func recordRevision(taskDir string) error {
+	dir := filepath.Join(taskDir, historyDir)
+	revisions, err := readRevisions(dir)
+	if err != nil {
+		return err
+	}
+
+	files := map[string]string{}
+	contents := map[string][]byte{}
+	for _, name := range historyFiles {
+		data, err := os.ReadFile(filepath.Join(taskDir, name)) // #nosec G304 - reading task documents from workspace path
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		files[name] = fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+		contents[name] = data
+	}
+
+	revision := &Revision{Rev: 1, Time: time.Now().UTC(), Files: files}
+	previous := map[string]string{}
+	if len(revisions) > 0 {
+		last := revisions[len(revisions)-1]
+		revision.Rev = last.Rev + 1
+		previous = last.Files
+	}
+	for _, name := range historyFiles {
+		if files[name] != previous[name] {
+			revision.Changed = append(revision.Changed, name)
+		}
+	}
+	if len(revision.Changed) == 0 {
+		return nil
+	}
+
+	objects := filepath.Join(dir, "objects")
+	if err := os.MkdirAll(objects, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	for _, name := range revision.Changed {
+		if _, ok := contents[name]; !ok {
+			continue
+		}
+		object := filepath.Join(objects, strings.TrimPrefix(files[name], "sha256:"))
+		if _, err := os.Stat(object); err == nil {
+			continue
+		}
+		if err := os.WriteFile(object, contents[name], 0644); err != nil {
+			return fmt.Errorf("failed to store %s: %w", name, err)
+		}
+	}
+
+	line, err := json.Marshal(revision)
+	if err != nil {
+		return fmt.Errorf("failed to encode revision: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, historyLog), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) // #nosec G304 - the history of a task
+	if err != nil {
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+	return f.Close()
+}
+
+// recordRevision records the documents of task as a new revision, logging failures;
+// the history is kept on a best-effort basis and never fails a write
+func (m *Manager) recordRevision(task *Task) {
+	if task.WorkspacePath == "" {
+		return
+	}
+	if err := recordRevision(task.WorkspacePath); err != nil {
+		m.logger.Warn("failed to record task revision", "task_id", task.ID, "error", err)
+	}
+}
+
+// readRevisions reads the revisions in the history directory dir, oldest first
+func readRevisions(dir string) ([]*Revision, error) {
+	f, err := os.Open(filepath.Join(dir, historyLog)) // #nosec G304 - the history of a task
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task history: %w", err)
+	}
+	defer f.Close()
+
+	revisions := []*Revision{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		revision := &Revision{}
+		if err := json.Unmarshal(scanner.Bytes(), revision); err != nil {
+			return nil, fmt.Errorf("invalid task history: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read task history: %w", err)
+	}
+	return revisions, nil
+}
+
+// TaskHistory returns the revisions of the documents of a task, oldest first
+func (m *Manager) TaskHistory(ctx context.Context, taskID string) ([]*Revision, error) {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return readRevisions(filepath.Join(task.WorkspacePath, historyDir))
+}
+
+// DiffTask returns the changes to the documents of a task since revision rev. A rev
+// of 0 is the revision before the last, or the only one.
+func (m *Manager) DiffTask(ctx context.Context, taskID string, rev int) (*TaskDiff, error) {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(task.WorkspacePath, historyDir)
+	revisions, err := readRevisions(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, &types.Error{Code: types.ErrorCodeNotFound, Message: fmt.Sprintf("%s has no history", taskID)}
+	}
+	if rev == 0 {
+		rev = revisions[max(len(revisions)-2, 0)].Rev
+	}
+
+	var revision *Revision
+	for _, r := range revisions {
+		if r.Rev == rev {
+			revision = r
+			break
+		}
+	}
+	if revision == nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNotFound,
+			Message: fmt.Sprintf("%s has no revision %d", taskID, rev),
+			Details: fmt.Sprintf("revisions run from %d to %d; see 'zen task history %s'", revisions[0].Rev, revisions[len(revisions)-1].Rev, taskID),
+		}
+	}
+
+	result := &TaskDiff{TaskID: taskID, Rev: rev, Files: []FileDiff{}}
+	for _, name := range historyFiles {
+		var before []byte
+		if checksum := revision.Files[name]; checksum != "" {
+			before, err = os.ReadFile(filepath.Join(dir, "objects", strings.TrimPrefix(checksum, "sha256:"))) // #nosec G304 - the history of a task
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s at revision %d: %w", name, rev, err)
+			}
+		}
+		after, err := os.ReadFile(filepath.Join(task.WorkspacePath, name)) // #nosec G304 - reading task documents from workspace path
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if bytes.Equal(before, after) {
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        diffLines(before),
+			B:        diffLines(after),
+			FromFile: fmt.Sprintf("%s@%d", name, rev),
+			ToFile:   name,
+			Context:  3,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s: %w", name, err)
+		}
+		result.Files = append(result.Files, FileDiff{Path: name, Diff: diff})
+	}
+	return result, nil
+}
+
+// diffLines splits a document into the lines of a diff; an empty document has none
+func diffLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	return difflib.SplitLines(string(data))
+}
@@ -0,0 +1,70 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordRevision(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	taskDir := filepath.Join(tasksDir, "PROJ-1")
+
+	title := "Add SSO login page"
+	_, err := m.UpdateTask(ctx, "PROJ-1", &TaskUpdates{Title: &title})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "index.md"), []byte("# Add SSO login page\n"), 0644))
+	require.NoError(t, recordRevision(taskDir))
+	require.NoError(t, recordRevision(taskDir), "unchanged documents are not recorded again")
+
+	revisions, err := m.TaskHistory(ctx, "PROJ-1")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 1, revisions[0].Rev)
+	assert.Equal(t, []string{"manifest.yaml"}, revisions[0].Changed, "a write of the manager records a revision")
+	assert.Equal(t, 2, revisions[1].Rev)
+	assert.Equal(t, []string{"index.md"}, revisions[1].Changed)
+	assert.Equal(t, revisions[0].Files["manifest.yaml"], revisions[1].Files["manifest.yaml"])
+
+	objects, err := os.ReadDir(filepath.Join(taskDir, historyDir, "objects"))
+	require.NoError(t, err)
+	assert.Len(t, objects, 2, "each version of a document is stored once")
+}
+
+func TestDiffTask(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	taskDir := filepath.Join(tasksDir, "PROJ-1")
+
+	_, err := m.DiffTask(ctx, "PROJ-1", 0)
+	var typed *types.Error
+	require.ErrorAs(t, err, &typed)
+	assert.Equal(t, types.ErrorCodeNotFound, typed.Code)
+
+	require.NoError(t, recordRevision(taskDir))
+	status := "in_progress"
+	_, err = m.UpdateTask(ctx, "PROJ-1", &TaskUpdates{Status: &status})
+	require.NoError(t, err)
+
+	diff, err := m.DiffTask(ctx, "PROJ-1", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, diff.Rev, "the default is the revision before the last")
+	require.Len(t, diff.Files, 1)
+	assert.Equal(t, "manifest.yaml", diff.Files[0].Path)
+	assert.Contains(t, diff.Files[0].Diff, "--- manifest.yaml@1\n+++ manifest.yaml\n")
+	assert.Contains(t, diff.Files[0].Diff, "-  status: proposed\n")
+	assert.Contains(t, diff.Files[0].Diff, "+    status: in_progress\n")
+
+	diff, err = m.DiffTask(ctx, "PROJ-1", 2)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Files)
+
+	_, err = m.DiffTask(ctx, "PROJ-1", 7)
+	assert.ErrorContains(t, err, "PROJ-1 has no revision 7")
+}
@@ -0,0 +1,238 @@
+package task
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/fs"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Task ID schemes
+const (
+	// IDSchemeFree accepts any valid ID and allocates none
+	IDSchemeFree = "free"
+
+	// IDSchemeSequence allocates a prefix and the next number of a sequence, as in ZEN-123
+	IDSchemeSequence = "sequence"
+
+	// IDSchemeULID allocates a ULID, which sorts by creation time
+	IDSchemeULID = "ulid"
+
+	// IDSchemeExternal mirrors the key of the task in its external source
+	IDSchemeExternal = "external"
+)
+
+// IDSchemes are the task ID schemes
+var IDSchemes = []string{IDSchemeFree, IDSchemeSequence, IDSchemeULID, IDSchemeExternal}
+
+// MaxTaskIDLength is the length task IDs cannot exceed
+const MaxTaskIDLength = 64
+
+var (
+	idPrefix = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	ulidID   = regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)
+)
+
+// crockford is the base32 alphabet of ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ValidateTaskID checks the rules every task ID follows, whatever the scheme: IDs name
+// a directory, so they are 3 to 64 characters, start with a letter or digit, and have
+// no spaces or characters reserved by file systems. They name a directory on Windows
+// too, so they are not device names such as CON or NUL and do not end with a period.
+func ValidateTaskID(taskID string) error {
+	switch {
+	case taskID == "":
+		return fmt.Errorf("task ID is required")
+	case len(taskID) < 3:
+		return fmt.Errorf("task ID must be at least 3 characters long")
+	case len(taskID) > MaxTaskIDLength:
+		return fmt.Errorf("task ID must be at most %d characters long", MaxTaskIDLength)
+	case strings.ContainsAny(taskID, " /\\:*?\"<>|"):
+		return fmt.Errorf("task ID contains invalid characters")
+	case !isAlphanumeric(taskID[0]):
+		return fmt.Errorf("task ID must start with a letter or digit")
+	}
+	if err := fs.ValidateFileName(taskID); err != nil {
+		return fmt.Errorf("task ID cannot name a directory on every platform: %w", err)
+	}
+	return nil
+}
+
+func isAlphanumeric(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// IDPrefixOf returns the prefix of sequence IDs: id_prefix, else the project key
+func (c Config) IDPrefixOf() string {
+	if c.IDPrefix != "" {
+		return c.IDPrefix
+	}
+	return c.ProjectKey
+}
+
+// ValidateID checks that an ID given for a new task follows the ID scheme and pattern.
+// IDs of tasks created from an external source are its keys, so they need only
+// match the pattern.
+func (c Config) ValidateID(taskID, fromSource string) error {
+	if err := ValidateTaskID(taskID); err != nil {
+		return err
+	}
+
+	if fromSource == "" {
+		switch c.IDScheme {
+		case IDSchemeSequence:
+			prefix := c.IDPrefixOf()
+			number := strings.TrimPrefix(taskID, prefix+"-")
+			if !strings.HasPrefix(taskID, prefix+"-") || number == "" || strings.Trim(number, "0123456789") != "" {
+				return fmt.Errorf("task ID %s does not follow the sequence scheme: IDs are %s-<number>; omit the ID to allocate the next one", taskID, prefix)
+			}
+		case IDSchemeULID:
+			if !ulidID.MatchString(taskID) {
+				return fmt.Errorf("task ID %s is not a ULID; omit the ID to allocate one", taskID)
+			}
+		case IDSchemeExternal:
+			return fmt.Errorf("task IDs mirror the keys of an external source: create tasks with --from")
+		}
+	}
+
+	if c.IDPattern != "" {
+		pattern, err := regexp.Compile(c.IDPattern)
+		if err != nil {
+			return fmt.Errorf("invalid id_pattern: %w", err)
+		}
+		if !pattern.MatchString(taskID) {
+			return fmt.Errorf("task ID %s does not match the pattern %s", taskID, c.IDPattern)
+		}
+	}
+	return nil
+}
+
+// sequencePath is the counter file of sequence IDs, holding the last number allocated
+// for each prefix
+func sequencePath(zenDir string) string {
+	return filepath.Join(zenDir, "work", "sequence.json")
+}
+
+// NextTaskID allocates the ID of a new task by the configured ID scheme. Sequence
+// numbers are allocated under a lock, so concurrent allocations never return the same
+// ID, and follow the highest number of the existing tasks as well as the counter. Tasks
+// tracked locally are numbered by the free scheme too, as in TASK-12.
+func (m *Manager) NextTaskID(ctx context.Context) (string, error) {
+	cfg := m.taskConfig()
+	switch cfg.IDScheme {
+	case IDSchemeULID:
+		return newULID(time.Now())
+	case IDSchemeSequence:
+		return m.nextSequenceID(ctx, cfg.IDPrefixOf())
+	case IDSchemeFree, "":
+		// Tasks tracked locally have no tracker to take IDs from, so they are numbered
+		if m.localOnly() {
+			prefix := cfg.IDPrefixOf()
+			if prefix == "" {
+				prefix = DefaultLocalIDPrefix
+			}
+			return m.nextSequenceID(ctx, prefix)
+		}
+		fallthrough
+	default:
+		scheme := cfg.IDScheme
+		if scheme == "" {
+			scheme = IDSchemeFree
+		}
+		return "", &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "task ID is required",
+			Details: fmt.Sprintf("the %s ID scheme allocates no IDs; set task.id_scheme to sequence or ulid to have them allocated", scheme),
+		}
+	}
+}
+
+func (m *Manager) nextSequenceID(ctx context.Context, prefix string) (string, error) {
+	if !idPrefix.MatchString(prefix) {
+		return "", &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "sequence IDs need a prefix",
+			Details: "set task.id_prefix or task.project_key to a prefix such as ZEN",
+		}
+	}
+
+	ws, err := m.factory.WorkspaceManager()
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+	tasksDir, err := m.tasksDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	lock, err := fs.AcquireLock(ctx, filepath.Join(ws.ZenDirectory(), "run", "sequence.lock"), fs.LockOptions{Timeout: 10 * time.Second})
+	if err != nil {
+		return "", fmt.Errorf("failed to lock the task ID sequence: %w", err)
+	}
+	defer lock.Release()
+
+	path := sequencePath(ws.ZenDirectory())
+	counters := map[string]int{}
+	if data, err := os.ReadFile(path); err == nil { // #nosec G304 - reading counter file from workspace path
+		if err := json.Unmarshal(data, &counters); err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	next := counters[prefix] + 1
+	if entries, err := os.ReadDir(tasksDir); err == nil {
+		for _, entry := range entries {
+			number, ok := strings.CutPrefix(entry.Name(), prefix+"-")
+			if n, err := strconv.Atoi(number); ok && err == nil && n >= next {
+				next = n + 1
+			}
+		}
+	}
+	counters[prefix] = next
+
+	data, err := json.MarshalIndent(counters, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return fmt.Sprintf("%s-%d", prefix, next), nil
+}
+
+// newULID returns a ULID: 48 bits of milliseconds since the epoch and 80 random bits,
+// in Crockford base32
+func newULID(t time.Time) (string, error) {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(t.UnixMilli())<<16)
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", fmt.Errorf("failed to generate ULID: %w", err)
+	}
+
+	// 128 bits are 26 characters of 5 bits, the first holding the 3 leading bits
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out), nil
+}
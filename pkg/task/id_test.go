@@ -0,0 +1,143 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidateID(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     Config
+		id         string
+		fromSource string
+		errorMsg   string
+	}{
+		{name: "free", config: Config{}, id: "PROJ-1"},
+		{name: "too short", config: Config{}, id: "P1", errorMsg: "at least 3 characters"},
+		{name: "too long", config: Config{}, id: "PROJ-" + strings.Repeat("1", 60), errorMsg: "at most 64 characters"},
+		{name: "reserved characters", config: Config{}, id: "PROJ/1", errorMsg: "invalid characters"},
+		{name: "leading dot", config: Config{}, id: ".PROJ-1", errorMsg: "start with a letter or digit"},
+		{name: "windows device name", config: Config{}, id: "CON", errorMsg: "reserved by Windows"},
+		{name: "windows device name with extension", config: Config{}, id: "nul.txt", errorMsg: "reserved by Windows"},
+		{name: "trailing period", config: Config{}, id: "PROJ-1.", errorMsg: "ends with a period"},
+		{name: "device name prefix", config: Config{}, id: "CONSOLE-1"},
+		{name: "sequence", config: Config{IDScheme: IDSchemeSequence, ProjectKey: "ZEN"}, id: "ZEN-12"},
+		{name: "sequence with another prefix", config: Config{IDScheme: IDSchemeSequence, ProjectKey: "ZEN"}, id: "PROJ-12", errorMsg: "ZEN-<number>"},
+		{name: "sequence without a number", config: Config{IDScheme: IDSchemeSequence, IDPrefix: "ZEN"}, id: "ZEN-abc", errorMsg: "ZEN-<number>"},
+		{name: "sequence from a source", config: Config{IDScheme: IDSchemeSequence, ProjectKey: "ZEN"}, id: "PROJ-12", fromSource: "jira"},
+		{name: "ulid", config: Config{IDScheme: IDSchemeULID}, id: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		{name: "not a ulid", config: Config{IDScheme: IDSchemeULID}, id: "PROJ-1", errorMsg: "not a ULID"},
+		{name: "external without a source", config: Config{IDScheme: IDSchemeExternal}, id: "PROJ-1", errorMsg: "--from"},
+		{name: "external", config: Config{IDScheme: IDSchemeExternal}, id: "PROJ-1", fromSource: "jira"},
+		{name: "pattern", config: Config{IDPattern: `^[A-Z]+-\d+$`}, id: "PROJ-1"},
+		{name: "pattern mismatch", config: Config{IDPattern: `^[A-Z]+-\d+$`}, id: "proj-1", errorMsg: "does not match the pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.ValidateID(tt.id, tt.fromSource)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestManagerNextTaskID_Sequence(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	m.settings = &Config{IDScheme: IDSchemeSequence, ProjectKey: "PROJ"}
+	ctx := context.Background()
+
+	// Numbers follow the existing tasks, then the counter
+	id, err := m.NextTaskID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-2", id)
+	id, err = m.NextTaskID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-3", id, "allocated numbers are not reused")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tasksDir, "PROJ-10"), 0755))
+	id, err = m.NextTaskID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-11", id)
+
+	m.settings.IDPrefix = "ZEN"
+	id, err = m.NextTaskID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ZEN-1", id, "each prefix has its own sequence")
+}
+
+func TestManagerNextTaskID_Concurrent(t *testing.T) {
+	m, _ := newTestManager(t)
+	m.settings = &Config{IDScheme: IDSchemeSequence, ProjectKey: "PROJ"}
+
+	var mu sync.Mutex
+	ids := map[string]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := m.NextTaskID(context.Background())
+			assert.NoError(t, err)
+			mu.Lock()
+			ids[id] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, ids, 10)
+}
+
+func TestManagerNextTaskID_Schemes(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	m.settings = &Config{IDScheme: IDSchemeULID}
+	id, err := m.NextTaskID(context.Background())
+	require.NoError(t, err)
+	assert.NoError(t, m.settings.ValidateID(id, ""))
+
+	// Tasks tracked locally are numbered by the free scheme
+	m.settings = &Config{IDScheme: IDSchemeFree}
+	id, err = m.NextTaskID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "TASK-1", id)
+
+	cfg, err := m.factory.Config()
+	require.NoError(t, err)
+	cfg.Integrations.TaskSystem = "jira"
+	for _, scheme := range []string{"", IDSchemeFree, IDSchemeExternal} {
+		m.settings = &Config{IDScheme: scheme}
+		_, err := m.NextTaskID(context.Background())
+		var zenErr *types.Error
+		require.ErrorAs(t, err, &zenErr, scheme)
+		assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+	}
+
+	m.settings = &Config{IDScheme: IDSchemeSequence}
+	_, err = m.NextTaskID(context.Background())
+	assert.ErrorContains(t, err, "sequence IDs need a prefix")
+}
+
+func TestNewULID(t *testing.T) {
+	earlier, err := newULID(time.UnixMilli(1469918176385))
+	require.NoError(t, err)
+	assert.Len(t, earlier, 26)
+	assert.Equal(t, "01ARYZ6S41", earlier[:10], "the first 10 characters encode the time")
+
+	later, err := newULID(time.UnixMilli(1469918176386))
+	require.NoError(t, err)
+	assert.Less(t, earlier, later, "ULIDs sort by time")
+}
@@ -0,0 +1,393 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Task fields that can be imported from the columns of a spreadsheet
+const (
+	ImportFieldID       = "id"
+	ImportFieldTitle    = "title"
+	ImportFieldType     = "type"
+	ImportFieldOwner    = "owner"
+	ImportFieldTeam     = "team"
+	ImportFieldPriority = "priority"
+	ImportFieldStatus   = "status"
+	ImportFieldLabels   = "labels"
+)
+
+// ImportFields are the task fields that can be imported, in the order they are shown
+var ImportFields = []string{ImportFieldID, ImportFieldTitle, ImportFieldType, ImportFieldOwner,
+	ImportFieldTeam, ImportFieldPriority, ImportFieldStatus, ImportFieldLabels}
+
+// Import statuses reported by ImportResult
+const (
+	// ImportCreated is a row whose task was created
+	ImportCreated = "created"
+
+	// ImportValid is a row whose task a dry run would create
+	ImportValid = "valid"
+
+	// ImportExists is a row whose task already exists, as when a spreadsheet is imported
+	// again
+	ImportExists = "exists"
+
+	// ImportInvalid is a row that cannot be imported as it is
+	ImportInvalid = "invalid"
+
+	// ImportFailed is a row whose task could not be created
+	ImportFailed = "failed"
+)
+
+// ImportRequest contains parameters for importing tasks from a spreadsheet
+type ImportRequest struct {
+	// File is a .csv, .tsv or .xlsx file with a header row
+	File string
+
+	// Mapping names the column of each task field, over task.import_mapping; fields
+	// without one are read from the column named after them, if any
+	Mapping map[string]string
+
+	// DryRun validates the rows without creating tasks
+	DryRun bool
+
+	// SkipInvalid creates the tasks of the valid rows when other rows are invalid,
+	// rather than none
+	SkipInvalid bool
+
+	// SkipRows are the numbers of rows whose tasks an import that was cancelled
+	// created. They are reported as existing rather than created again, which rows
+	// without an ID would otherwise be.
+	SkipRows []int
+}
+
+// ImportResult describes the task of a row of a spreadsheet
+type ImportResult struct {
+	// Row is the number of the row in the spreadsheet, counting the header
+	Row int `json:"row"`
+
+	// TaskID is the ID of the task; empty until the task is created when IDs are
+	// allocated
+	TaskID string `json:"task_id,omitempty"`
+
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	request *CreateTaskRequest
+	status  string
+}
+
+// ImportTasks creates a task from each row of a spreadsheet. Every row is validated
+// first, and unless the request skips them, a spreadsheet with invalid rows creates no
+// tasks. Rows whose task exists are skipped, so a spreadsheet can be imported again
+// after it is fixed or extended. When ctx is cancelled, the rows not imported stay
+// valid, and the results are returned with ctx.Err().
+func (m *Manager) ImportTasks(ctx context.Context, request *ImportRequest) ([]*ImportResult, error) {
+	header, rows, err := readSpreadsheet(request.File)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := m.importColumns(header, request.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := map[int]bool{}
+	for _, row := range request.SkipRows {
+		skip[row] = true
+	}
+
+	results := make([]*ImportResult, 0, len(rows))
+	ids := map[string]int{}
+	invalid := 0
+	for _, row := range rows {
+		result := m.importRow(row, columns)
+		results = append(results, result)
+
+		if result.Status == ImportValid && result.TaskID != "" {
+			if first, ok := ids[result.TaskID]; ok {
+				result.Status, result.Error = ImportInvalid, fmt.Sprintf("task %s is also in row %d", result.TaskID, first)
+			} else {
+				ids[result.TaskID] = row.Number
+			}
+		}
+		if result.Status == ImportValid && (skip[row.Number] || result.TaskID != "" && m.taskExists(result.TaskID)) {
+			result.Status = ImportExists
+		}
+		if result.Status == ImportInvalid {
+			invalid++
+		}
+	}
+
+	if request.DryRun {
+		return results, nil
+	}
+	if invalid > 0 && !request.SkipInvalid {
+		return results, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("%d of %d rows of %s are invalid; no tasks were created", invalid, len(rows), filepath.Base(request.File)),
+			Details: "fix the rows, or import the valid rows with --skip-invalid",
+		}
+	}
+
+	for _, result := range results {
+		if result.Status != ImportValid {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		task, err := m.CreateTask(ctx, result.request)
+		var zenErr *types.Error
+		switch {
+		case err != nil && ctx.Err() != nil:
+			// The row stays valid, to be imported when the import is resumed
+			return results, ctx.Err()
+		case err == nil:
+			result.TaskID, result.Status = task.ID, ImportCreated
+		case errors.As(err, &zenErr) && zenErr.Code == types.ErrorCodeAlreadyExists:
+			result.Status = ImportExists
+			continue
+		default:
+			result.Status, result.Error = ImportFailed, err.Error()
+			continue
+		}
+
+		// Imported tasks start in the status of their row, which is not a transition of
+		// the status workflow
+		if result.status != "" && result.status != task.Status {
+			if err := updateManifestFields(task.ManifestPath, map[string]string{"task.status": result.status}); err != nil {
+				result.Status, result.Error = ImportFailed, fmt.Sprintf("created, but failed to set its status: %v", err)
+			}
+		}
+	}
+
+	m.logger.Info("tasks imported", "file", request.File, "rows", len(rows))
+	return results, nil
+}
+
+// importColumns returns the column of each task field that is imported. Mapped fields
+// must name a column of the header; other fields are read from the column named after
+// them, ignoring case, spaces and underscores.
+func (m *Manager) importColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	merged := map[string]string{}
+	for field, column := range m.taskConfig().ImportMapping {
+		merged[strings.ToLower(field)] = column
+	}
+	for field, column := range mapping {
+		merged[strings.ToLower(field)] = column
+	}
+
+	index := map[string]int{}
+	for i, column := range header {
+		if _, ok := index[importColumnKey(column)]; !ok {
+			index[importColumnKey(column)] = i
+		}
+	}
+
+	columns := map[string]int{}
+	for field, column := range merged {
+		if !slices.Contains(ImportFields, field) {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("unknown task field: %s", field),
+				Details: fmt.Sprintf("fields are %s", strings.Join(ImportFields, ", ")),
+			}
+		}
+		i, ok := index[importColumnKey(column)]
+		if !ok {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("column %q of %s is not in the spreadsheet", column, field),
+				Details: fmt.Sprintf("columns are %s", strings.Join(header, ", ")),
+			}
+		}
+		columns[field] = i
+	}
+	for _, field := range ImportFields {
+		if _, ok := columns[field]; ok {
+			continue
+		}
+		if i, ok := index[importColumnKey(field)]; ok {
+			columns[field] = i
+		}
+	}
+
+	if _, ok := columns[ImportFieldTitle]; !ok {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "no column holds the titles of tasks",
+			Details: "map one with --map title=<column>",
+		}
+	}
+	if _, ok := columns[ImportFieldID]; !ok && !m.allocatesTaskIDs() {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "no column holds the IDs of tasks",
+			Details: "map one with --map id=<column>, or set task.id_scheme to sequence or ulid to have IDs allocated",
+		}
+	}
+	return columns, nil
+}
+
+// importRow returns the result of validating a row, with the task it creates
+func (m *Manager) importRow(row spreadsheetRow, columns map[string]int) *ImportResult {
+	cell := func(field string) string {
+		column, ok := columns[field]
+		if !ok {
+			return ""
+		}
+		return row.Cell(column)
+	}
+
+	request := &CreateTaskRequest{
+		ID:    cell(ImportFieldID),
+		Title: cell(ImportFieldTitle),
+		Owner: cell(ImportFieldOwner),
+		Team:  cell(ImportFieldTeam),
+	}
+	result := &ImportResult{Row: row.Number, TaskID: request.ID, Title: request.Title, Status: ImportValid, request: request}
+	invalid := func(format string, args ...interface{}) *ImportResult {
+		result.Status, result.Error = ImportInvalid, fmt.Sprintf(format, args...)
+		return result
+	}
+
+	if request.Title == "" {
+		return invalid("the title is empty")
+	}
+	if request.ID == "" && !m.allocatesTaskIDs() {
+		return invalid("the ID is empty")
+	}
+	if request.ID != "" {
+		if err := m.taskConfig().ValidateID(request.ID, ""); err != nil {
+			return invalid("%v", err)
+		}
+	}
+
+	if value := cell(ImportFieldType); value != "" {
+		request.Type = importTaskType(value)
+	}
+	if value := cell(ImportFieldPriority); value != "" {
+		priority, ok := importPriority(value)
+		if !ok {
+			return invalid("unknown priority %q (use P0 to P3, or highest, high, medium, low)", value)
+		}
+		request.Priority = priority
+	}
+	if value := cell(ImportFieldStatus); value != "" {
+		status, ok := importStatus(value)
+		if !ok {
+			return invalid("unknown status %q (use %s)", value, strings.Join(Statuses, ", "))
+		}
+		result.status = status
+	}
+	if value := cell(ImportFieldLabels); value != "" {
+		labels := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' })
+		for i := range labels {
+			labels[i] = strings.TrimSpace(labels[i])
+		}
+		checked, err := m.labelConfig().Check(labels)
+		if err != nil {
+			return invalid("%v", err)
+		}
+		request.Labels = checked
+	}
+
+	// Spreadsheets name people as trackers do, so they are mapped to local users
+	if owner, _, ok := m.identity().Lookup(request.Owner); ok && request.Owner != "" {
+		request.Owner = owner
+	}
+	return result
+}
+
+// allocatesTaskIDs reports whether the ID scheme allocates the IDs of new tasks
+func (m *Manager) allocatesTaskIDs() bool {
+	switch m.taskConfig().IDScheme {
+	case IDSchemeSequence, IDSchemeULID:
+		return true
+	case IDSchemeFree, "":
+		return m.localOnly()
+	default:
+		return false
+	}
+}
+
+// importColumnKey normalizes the name of a column for matching
+func importColumnKey(column string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(column)))
+}
+
+// TaskTypes are the types of tasks
+var TaskTypes = []string{"story", "bug", "epic", "spike", "task"}
+
+// importTaskType returns the task type of a spreadsheet value, which trackers name in
+// their own terms
+func importTaskType(value string) string {
+	value = strings.ToLower(value)
+	if slices.Contains(TaskTypes, value) {
+		return value
+	}
+	switch value {
+	case "feature", "user story":
+		return "story"
+	case "defect":
+		return "bug"
+	case "initiative":
+		return "epic"
+	case "research":
+		return "spike"
+	default:
+		return "task"
+	}
+}
+
+// importPriority returns the priority of a spreadsheet value: P0 to P3, or the priority
+// names trackers use
+func importPriority(value string) (string, bool) {
+	switch strings.ToLower(value) {
+	case "p0", "0", "highest", "critical", "urgent", "blocker":
+		return "P0", true
+	case "p1", "1", "high", "major":
+		return "P1", true
+	case "p2", "2", "medium", "normal":
+		return "P2", true
+	case "p3", "3", "4", "low", "lowest", "minor", "trivial":
+		return "P3", true
+	default:
+		return "", false
+	}
+}
+
+// importStatus returns the task status of a spreadsheet value: a task status, or the
+// status names trackers use
+func importStatus(value string) (string, bool) {
+	status := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(value)), " ", "_")
+	if slices.Contains(Statuses, status) {
+		return status, true
+	}
+	switch status {
+	case "to_do", "todo", "open", "new", "backlog":
+		return StatusProposed, true
+	case "doing", "started", "active":
+		return StatusInProgress, true
+	case "review", "in_qa", "testing":
+		return StatusInReview, true
+	case "on_hold":
+		return StatusBlocked, true
+	case "done", "closed", "resolved":
+		return StatusCompleted, true
+	case "canceled", "won't_do", "wont_do":
+		return StatusCancelled, true
+	default:
+		return "", false
+	}
+}
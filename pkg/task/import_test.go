@@ -0,0 +1,181 @@
+package task
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSpreadsheet(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	return file
+}
+
+func TestManagerImportTasks(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	file := writeSpreadsheet(t, "backlog.csv", "\ufeffKey,Summary,Issue Type,Priority,Status,Labels\n"+
+		"PROJ-2,Add SSO,Story,High,In Progress,\n"+
+		",,,,,\n"+
+		"PROJ-3,\"Fix login, again\",Defect,p0,done,\n")
+	request := &ImportRequest{File: file, Mapping: map[string]string{"id": "key", "title": "Summary", "type": "Issue Type"}, DryRun: true}
+
+	results, err := m.ImportTasks(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, ImportResult{Row: 2, TaskID: "PROJ-2", Title: "Add SSO", Status: ImportValid}, withoutRequest(results[0]))
+	assert.Equal(t, 4, results[1].Row, "rows keep their numbers when empty rows are left out")
+	_, err = os.Stat(filepath.Join(tasksDir, "PROJ-2"))
+	assert.True(t, os.IsNotExist(err), "dry runs create no tasks")
+
+	request.DryRun = false
+	results, err = m.ImportTasks(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, ImportCreated, results[0].Status)
+	assert.Equal(t, ImportCreated, results[1].Status)
+
+	sso, err := m.GetTask(ctx, "PROJ-2")
+	require.NoError(t, err)
+	assert.Equal(t, "story", sso.Type)
+	assert.Equal(t, "P1", sso.Priority)
+	assert.Equal(t, StatusInProgress, sso.Status)
+	fix, err := m.GetTask(ctx, "PROJ-3")
+	require.NoError(t, err)
+	assert.Equal(t, "Fix login, again", fix.Title)
+	assert.Equal(t, "bug", fix.Type)
+	assert.Equal(t, StatusCompleted, fix.Status, "imported tasks start in any status")
+
+	results, err = m.ImportTasks(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, ImportExists, results[0].Status, "importing again skips the tasks created")
+	assert.Equal(t, ImportExists, results[1].Status)
+}
+
+func TestManagerImportTasks_Cancelled(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	file := writeSpreadsheet(t, "backlog.csv", "ID,Title\nPROJ-2,Add SSO\nPROJ-3,Fix login\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := m.ImportTasks(ctx, &ImportRequest{File: file})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, results, 2)
+	assert.Equal(t, ImportValid, results[0].Status, "rows not imported stay valid")
+	assert.Equal(t, ImportValid, results[1].Status)
+	assert.NoDirExists(t, filepath.Join(tasksDir, "PROJ-2"))
+
+	results, err = m.ImportTasks(context.Background(), &ImportRequest{File: file, SkipRows: []int{2}})
+	require.NoError(t, err)
+	assert.Equal(t, ImportExists, results[0].Status, "rows imported before the cancellation are skipped")
+	assert.Equal(t, ImportCreated, results[1].Status)
+	assert.NoDirExists(t, filepath.Join(tasksDir, "PROJ-2"))
+	assert.DirExists(t, filepath.Join(tasksDir, "PROJ-3"))
+}
+
+func TestManagerImportTasks_Invalid(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	file := writeSpreadsheet(t, "backlog.tsv", "ID\tTitle\tPriority\tStatus\n"+
+		"PROJ-2\tAdd SSO\tsoon\t\n"+
+		"PROJ-3\t\t\t\n"+
+		"PROJ-4\tAdd audit log\t\tparked\n"+
+		"PROJ-5\tAdd MFA\t\t\n"+
+		"PROJ-5\tAdd MFA again\t\t\n"+
+		"PROJ-1\tAdd login page\t\t\n")
+
+	results, err := m.ImportTasks(ctx, &ImportRequest{File: file})
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+	require.Len(t, results, 6)
+	assert.Contains(t, results[0].Error, `unknown priority "soon"`)
+	assert.Equal(t, "the title is empty", results[1].Error)
+	assert.Contains(t, results[2].Error, `unknown status "parked"`)
+	assert.Equal(t, ImportValid, results[3].Status)
+	assert.Equal(t, "task PROJ-5 is also in row 5", results[4].Error)
+	assert.Equal(t, ImportExists, results[5].Status)
+	_, err = os.Stat(filepath.Join(tasksDir, "PROJ-5"))
+	assert.True(t, os.IsNotExist(err), "spreadsheets with invalid rows create no tasks")
+
+	results, err = m.ImportTasks(ctx, &ImportRequest{File: file, SkipInvalid: true})
+	require.NoError(t, err)
+	assert.Equal(t, ImportCreated, results[3].Status)
+	assert.Equal(t, ImportInvalid, results[4].Status)
+	_, err = m.GetTask(ctx, "PROJ-5")
+	assert.NoError(t, err)
+}
+
+func TestManagerImportTasks_Errors(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+	file := writeSpreadsheet(t, "backlog.csv", "Key,Summary\nPROJ-2,Add SSO\n")
+
+	tests := []struct {
+		name    string
+		request *ImportRequest
+	}{
+		{name: "unknown field", request: &ImportRequest{File: file, Mapping: map[string]string{"summary": "Summary"}}},
+		{name: "unknown column", request: &ImportRequest{File: file, Mapping: map[string]string{"title": "Name"}}},
+		{name: "no title column", request: &ImportRequest{File: file}},
+		{name: "unsupported file", request: &ImportRequest{File: writeSpreadsheet(t, "backlog.numbers", "")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.ImportTasks(ctx, tt.request)
+			var zenErr *types.Error
+			require.True(t, errors.As(err, &zenErr), "got %v", err)
+			assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+		})
+	}
+}
+
+func TestReadSpreadsheet_Workbook(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "backlog.xlsx")
+	out, err := os.Create(file)
+	require.NoError(t, err)
+	archive := zip.NewWriter(out)
+	for name, content := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Backlog" sheetId="1" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Target="sharedStrings.xml"/><Relationship Id="rId2" Target="worksheets/backlog.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Summary</t></si><si><t>Points</t></si><si><r><t>Add </t></r><r><t>SSO</t></r></si></sst>`,
+		"xl/worksheets/backlog.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>
+<row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3"><v>5</v></c></row>
+<row r="4"><c r="A4" t="inlineStr"><is><t>Add MFA</t></is></c><c r="B4" t="b"><v>1</v></c></row>
+</sheetData></worksheet>`,
+	} {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, out.Close())
+
+	header, rows, err := readSpreadsheet(file)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Summary", "", "Points"}, header)
+	assert.Equal(t, []spreadsheetRow{
+		{Number: 3, Cells: []string{"Add SSO", "", "5"}},
+		{Number: 4, Cells: []string{"Add MFA", "true"}},
+	}, rows)
+}
+
+func withoutRequest(result *ImportResult) ImportResult {
+	copied := *result
+	copied.request, copied.status = nil, ""
+	return copied
+}
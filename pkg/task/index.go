@@ -0,0 +1,135 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// taskIndexVersion is bumped when the stored task index format changes, which
+// discards indexes of older versions
+const taskIndexVersion = 1
+
+// TaskIndexPath returns the path of the task index of the workspace with zenDir
+func TaskIndexPath(zenDir string) string {
+	return filepath.Join(zenDir, "cache", "task-index.json")
+}
+
+// taskIndex caches the tasks loaded from their manifests and metadata, so listing
+// tasks only reads the tasks whose files changed since they were indexed
+type taskIndex struct {
+	Version int                     `json:"version"`
+	Tasks   map[string]*indexedTask `json:"tasks"`
+
+	path    string
+	changed bool
+}
+
+// indexedTask is a task with the stamp of the files it was loaded from
+type indexedTask struct {
+	Stamp string `json:"stamp"`
+	Task  *Task  `json:"task"`
+}
+
+// openTaskIndex loads the task index stored at path, or returns an empty index when
+// there is none or it cannot be read
+func openTaskIndex(path string) *taskIndex {
+	index := &taskIndex{Version: taskIndexVersion, Tasks: map[string]*indexedTask{}, path: path}
+
+	data, err := os.ReadFile(path) // #nosec G304 - reading task index from workspace path
+	if err != nil {
+		return index
+	}
+	var stored taskIndex
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != taskIndexVersion || stored.Tasks == nil {
+		return index
+	}
+	index.Tasks = stored.Tasks
+	return index
+}
+
+// lookup returns the indexed task when it was indexed with stamp
+func (x *taskIndex) lookup(taskID, stamp string) *Task {
+	if entry, ok := x.Tasks[taskID]; ok && entry.Stamp == stamp && entry.Task != nil {
+		return entry.Task
+	}
+	return nil
+}
+
+func (x *taskIndex) store(taskID, stamp string, task *Task) {
+	x.Tasks[taskID] = &indexedTask{Stamp: stamp, Task: task}
+	x.changed = true
+}
+
+// remove drops a task
+func (x *taskIndex) remove(taskID string) {
+	if _, ok := x.Tasks[taskID]; ok {
+		delete(x.Tasks, taskID)
+		x.changed = true
+	}
+}
+
+// prune drops the tasks not in seen
+func (x *taskIndex) prune(seen map[string]bool) {
+	for taskID := range x.Tasks {
+		if !seen[taskID] {
+			delete(x.Tasks, taskID)
+			x.changed = true
+		}
+	}
+}
+
+// save writes the index when it changed
+func (x *taskIndex) save() error {
+	if !x.changed {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(x.path), 0750); err != nil {
+		return err
+	}
+	data, err := json.Marshal(x)
+	if err != nil {
+		return err
+	}
+
+	temp := x.path + ".tmp"
+	if err := os.WriteFile(temp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(temp, x.path); err != nil {
+		os.Remove(temp)
+		return err
+	}
+	x.changed = false
+	return nil
+}
+
+// taskStamp identifies the state of the files a task is loaded from: its manifest and
+// the files of its metadata directory, by modification time and size
+func taskStamp(taskDir string) (string, error) {
+	info, err := os.Stat(filepath.Join(taskDir, "manifest.yaml"))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "manifest.yaml:%d:%d", info.ModTime().UnixNano(), info.Size())
+
+	metadataDir := filepath.Join(taskDir, "metadata")
+	_ = filepath.WalkDir(metadataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(metadataDir, path)
+		fmt.Fprintf(&b, ";%s:%d:%d", filepath.ToSlash(rel), info.ModTime().UnixNano(), info.Size())
+		return nil
+	})
+	return b.String(), nil
+}
@@ -0,0 +1,73 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerListTasks_Index(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	indexPath := TaskIndexPath(filepath.Dir(filepath.Dir(tasksDir)))
+
+	tasks, err := m.ListTasks(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Add login page", tasks[0].Title)
+
+	// Unchanged tasks are served from the index
+	index := openTaskIndex(indexPath)
+	require.Contains(t, index.Tasks, "PROJ-1")
+	index.Tasks["PROJ-1"].Task.Title = "Indexed title"
+	index.changed = true
+	require.NoError(t, index.save())
+
+	tasks, err = m.ListTasks(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Indexed title", tasks[0].Title)
+
+	// Changed manifests and metadata are read again
+	title := "Add SSO login page"
+	_, err = m.UpdateTask(ctx, "PROJ-1", &TaskUpdates{Title: &title})
+	require.NoError(t, err)
+	tasks, err = m.ListTasks(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Add SSO login page", tasks[0].Title)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tasksDir, "PROJ-1", "metadata"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tasksDir, "PROJ-1", "metadata", "jira.json"), []byte(`{"external_id": "PROJ-1"}`), 0644))
+	tasks, err = m.ListTasks(ctx, nil)
+	require.NoError(t, err)
+	require.Contains(t, tasks[0].Sources, "jira")
+	assert.Equal(t, "PROJ-1", tasks[0].Sources["jira"].ExternalID)
+
+	// Removed tasks leave the index
+	require.NoError(t, os.RemoveAll(filepath.Join(tasksDir, "PROJ-1")))
+	tasks, err = m.ListTasks(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+	assert.Empty(t, openTaskIndex(indexPath).Tasks)
+}
+
+func TestOpenTaskIndex_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "task-index.json")
+
+	assert.Empty(t, openTaskIndex(path).Tasks, "missing index")
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+	assert.Empty(t, openTaskIndex(path).Tasks, "corrupt index")
+
+	data, err := json.Marshal(map[string]interface{}{
+		"version": taskIndexVersion + 1,
+		"tasks":   map[string]interface{}{"PROJ-1": map[string]interface{}{"stamp": "x", "task": map[string]interface{}{"id": "PROJ-1"}}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	assert.Empty(t, openTaskIndex(path).Tasks, "index of another version")
+}
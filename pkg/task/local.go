@@ -0,0 +1,100 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// TaskSystemNone is the task system of workspaces that track tasks only locally, without
+// an external tracker
+const TaskSystemNone = "none"
+
+// DefaultLocalIDPrefix is the prefix of the IDs allocated to tasks tracked locally, when
+// neither task.id_prefix nor task.project_key is set
+const DefaultLocalIDPrefix = "TASK"
+
+// Task statuses
+const (
+	StatusProposed   = "proposed"
+	StatusInProgress = "in_progress"
+	StatusInReview   = "in_review"
+	StatusBlocked    = "blocked"
+	StatusCompleted  = "completed"
+	StatusCancelled  = "cancelled"
+)
+
+// Statuses are the task statuses, in workflow order
+var Statuses = []string{StatusProposed, StatusInProgress, StatusInReview, StatusBlocked, StatusCompleted, StatusCancelled}
+
+// statusTransitions are the statuses each status can move to when tasks are tracked locally.
+// Completed tasks can be reopened and cancelled tasks proposed again.
+var statusTransitions = map[string][]string{
+	StatusProposed:   {StatusInProgress, StatusBlocked, StatusCancelled},
+	StatusInProgress: {StatusInReview, StatusBlocked, StatusCompleted, StatusCancelled},
+	StatusInReview:   {StatusInProgress, StatusBlocked, StatusCompleted, StatusCancelled},
+	StatusBlocked:    {StatusProposed, StatusInProgress, StatusCancelled},
+	StatusCompleted:  {StatusInProgress},
+	StatusCancelled:  {StatusProposed},
+}
+
+// TaskSystem returns the task system of record: integrations.task_system, else
+// task.task_source. Workspaces that configure "local", or no system, track tasks
+// locally and have the task system "none".
+func TaskSystem(cfg *config.Config) string {
+	system := cfg.Integrations.TaskSystem
+	if system == "" {
+		system = cfg.Task.TaskSource
+	}
+	if system == "" || system == "local" {
+		return TaskSystemNone
+	}
+	return system
+}
+
+// LocalOnly reports whether tasks are tracked only in the workspace
+func LocalOnly(cfg *config.Config) bool {
+	return TaskSystem(cfg) == TaskSystemNone
+}
+
+// LocalOnlyError is the error of an operation that needs an external task system, in a
+// workspace that tracks tasks locally
+func LocalOnlyError(operation string) error {
+	return &types.Error{
+		Code:    types.ErrorCodeInvalidConfig,
+		Message: fmt.Sprintf("cannot %s: tasks are tracked locally, integrations.task_system is none", operation),
+		Details: "set integrations.task_system to the tracker of record, such as jira, github or linear, to use it",
+	}
+}
+
+// CheckStatusTransition checks that a task tracked locally can move from one status to
+// another. A task without a status is proposed.
+func CheckStatusTransition(from, to string) error {
+	if !slices.Contains(Statuses, to) {
+		return fmt.Errorf("unknown status %s (valid statuses: %s)", to, strings.Join(Statuses, ", "))
+	}
+	if from == "" {
+		from = StatusProposed
+	}
+	if from == to {
+		return nil
+	}
+	allowed, ok := statusTransitions[from]
+	if !ok {
+		// Statuses of other workflows, such as those pulled from a tracker, can move to any
+		return nil
+	}
+	if !slices.Contains(allowed, to) {
+		return fmt.Errorf("a task cannot move from %s to %s (%s tasks can move to: %s)", from, to, from, strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+// localOnly reports whether the workspace tracks tasks locally
+func (m *Manager) localOnly() bool {
+	cfg, err := m.factory.Config()
+	return err != nil || LocalOnly(cfg)
+}
@@ -0,0 +1,95 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskSystem(t *testing.T) {
+	tests := []struct {
+		system string
+		source string
+		want   string
+	}{
+		{want: TaskSystemNone},
+		{source: "local", want: TaskSystemNone},
+		{system: "none", source: "jira", want: TaskSystemNone},
+		{source: "jira", want: "jira"},
+		{system: "github", source: "local", want: "github"},
+	}
+
+	for _, tt := range tests {
+		cfg := &config.Config{}
+		cfg.Integrations.TaskSystem = tt.system
+		cfg.Task.TaskSource = tt.source
+		assert.Equal(t, tt.want, TaskSystem(cfg), "%+v", tt)
+		assert.Equal(t, tt.want == TaskSystemNone, LocalOnly(cfg))
+	}
+}
+
+func TestCheckStatusTransition(t *testing.T) {
+	for _, move := range [][2]string{
+		{"", StatusInProgress},
+		{StatusProposed, StatusInProgress},
+		{StatusInProgress, StatusInReview},
+		{StatusInReview, StatusCompleted},
+		{StatusCompleted, StatusInProgress},
+		{StatusBlocked, StatusBlocked},
+		{"To Do", StatusInProgress},
+	} {
+		assert.NoError(t, CheckStatusTransition(move[0], move[1]), "%s -> %s", move[0], move[1])
+	}
+
+	assert.EqualError(t, CheckStatusTransition(StatusProposed, StatusCompleted),
+		"a task cannot move from proposed to completed (proposed tasks can move to: in_progress, blocked, cancelled)")
+	assert.ErrorContains(t, CheckStatusTransition(StatusProposed, "done"), "unknown status done")
+}
+
+func TestManagerUpdateTask_StatusWorkflow(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+	completed := StatusCompleted
+
+	_, err := m.UpdateTask(ctx, "PROJ-1", &TaskUpdates{Status: &completed})
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+	assert.Contains(t, zenErr.Details, "cannot move from proposed to completed")
+
+	// A tracker of record enforces its own workflow
+	cfg, err := m.factory.Config()
+	require.NoError(t, err)
+	cfg.Integrations.TaskSystem = "jira"
+	task, err := m.UpdateTask(ctx, "PROJ-1", &TaskUpdates{Status: &completed})
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, task.Status)
+}
+
+func TestManagerCreateTask_LocalOnly(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	// Tasks are numbered, and the local source links no tracker
+	task, err := m.CreateTask(ctx, &CreateTaskRequest{Title: "Add signup page", FromSource: "local"})
+	require.NoError(t, err)
+	assert.Equal(t, "TASK-1", task.ID)
+	assert.Empty(t, task.Sources)
+
+	_, err = m.CreateTask(ctx, &CreateTaskRequest{ID: "PROJ-2", FromSource: "jira"})
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, types.ErrorCodeInvalidConfig, zenErr.Code)
+	assert.Equal(t, "cannot create a task from jira: tasks are tracked locally, integrations.task_system is none", zenErr.Message)
+	assert.False(t, m.taskExists("PROJ-2"))
+
+	_, err = m.PushToSource(ctx, "PROJ-1", "jira")
+	assert.ErrorContains(t, err, "not linked to source jira")
+	_, err = m.getOrCreatePlugin(ctx, "jira")
+	assert.ErrorContains(t, err, "cannot reach jira: tasks are tracked locally")
+}
@@ -6,15 +6,23 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/daddia/zen/internal/config"
 	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/auth"
 	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/identity"
 	"github.com/daddia/zen/pkg/integration/factory"
 	"github.com/daddia/zen/pkg/integration/orchestrator"
 	"github.com/daddia/zen/pkg/integration/plugin"
 	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/label"
+	"github.com/daddia/zen/pkg/policy"
+	"github.com/daddia/zen/pkg/team"
 	"github.com/daddia/zen/pkg/templates"
+	"github.com/daddia/zen/pkg/types"
 )
 
 // Manager provides comprehensive task management functionality
@@ -24,6 +32,13 @@ type Manager struct {
 	io            *iostreams.IOStreams
 	clientFactory factory.ClientFactoryInterface
 	orchestrator  orchestrator.OperationOrchestratorInterface
+	identities    *identity.Directory
+	teams         *team.Config
+	labels        *label.Config
+	settings      *Config
+	enforcer      *policy.Enforcer
+	org           *policy.OrgPolicy
+	orgLoaded     bool
 }
 
 // ManagerInterface defines the task manager interface
@@ -82,9 +97,25 @@ type Task struct {
 	CurrentStage string `json:"current_stage" yaml:"current_stage"`
 	Progress     int    `json:"progress" yaml:"progress"`
 
+	// Stages records when the task entered and left workflow stages, by stage ID
+	Stages map[string]*StageTimes `json:"stages,omitempty" yaml:"stages,omitempty"`
+
 	// External sources
 	Sources map[string]*TaskSource `json:"sources" yaml:"sources"`
 
+	// Branch and worktree recorded by 'zen task start'
+	Git *GitInfo `json:"git,omitempty" yaml:"git,omitempty"`
+
+	// Pull or merge requests opened by 'zen pr create'
+	PullRequests []*TaskSource `json:"pull_requests,omitempty" yaml:"pull_requests,omitempty"`
+
+	// Files and links attached by 'zen task attach'
+	Attachments []Attachment `json:"attachments,omitempty" yaml:"attachments,omitempty"`
+
+	// Pages the task's artifacts were published as by 'zen task publish', by target and
+	// artifact
+	Published map[string]map[string]PublishedPage `json:"published,omitempty" yaml:"published,omitempty"`
+
 	// File paths
 	WorkspacePath string `json:"workspace_path" yaml:"workspace_path"`
 	IndexPath     string `json:"index_path" yaml:"index_path"`
@@ -93,6 +124,10 @@ type Task struct {
 
 	// Metadata
 	Metadata map[string]interface{} `json:"metadata" yaml:"metadata"`
+
+	// version is the checksum of the manifest as the task was read, which writes check
+	// to detect changes other commands made since
+	version string
 }
 
 // TaskSource represents an external source for a task
@@ -104,6 +139,18 @@ type TaskSource struct {
 	SyncEnabled   bool                   `json:"sync_enabled" yaml:"sync_enabled"`
 	SyncDirection string                 `json:"sync_direction" yaml:"sync_direction"`
 	Metadata      map[string]interface{} `json:"metadata" yaml:"metadata"`
+
+	// Snapshot is the task's synced fields as of its last sync with this source, the
+	// base of bidirectional three-way merges
+	Snapshot *SyncSnapshot `json:"snapshot,omitempty" yaml:"snapshot,omitempty"`
+
+	// PendingConflicts are the conflicts that held the last bidirectional sync with this
+	// source for manual review; they are cleared by the next successful sync
+	PendingConflicts []Conflict `json:"pending_conflicts,omitempty" yaml:"pending_conflicts,omitempty"`
+
+	// Project is the status and iteration of the task's item on the project board of this
+	// source, as of its last sync
+	Project *plugin.ProjectFields `json:"project,omitempty" yaml:"project,omitempty"`
 }
 
 // CreateTaskRequest contains parameters for creating a new task
@@ -115,10 +162,17 @@ type CreateTaskRequest struct {
 	Team     string `json:"team"`
 	Priority string `json:"priority"`
 
+	// Labels of the task, from the label vocabulary when one is defined
+	Labels []string `json:"labels,omitempty"`
+
 	// External source integration
 	FromSource string `json:"from_source,omitempty"`
 	ExternalID string `json:"external_id,omitempty"`
 
+	// SourceData is the task in FromSource when it was already fetched, such as by an
+	// export, and is used rather than fetching it again
+	SourceData *TaskData `json:"-"`
+
 	// Template options
 	TemplateVars map[string]interface{} `json:"template_vars,omitempty"`
 
@@ -148,6 +202,9 @@ type TaskFilter struct {
 	Labels  []string `json:"labels,omitempty"`
 	Sources []string `json:"sources,omitempty"`
 	Stage   string   `json:"stage,omitempty"`
+
+	// Conditions all tasks listed must meet, such as those of a saved view
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
 // SyncOptions contains options for synchronization operations
@@ -157,6 +214,24 @@ type SyncOptions struct {
 	DryRun           bool             `json:"dry_run"`
 	Force            bool             `json:"force"`
 	Sources          []string         `json:"sources,omitempty"` // Specific sources to sync
+
+	// FieldPolicies overrides ConflictStrategy for individual fields, such as
+	// {"title": remote_wins, "labels": union}
+	FieldPolicies map[string]ConflictStrategy `json:"field_policies,omitempty"`
+
+	// Concurrency is the number of tasks SyncAllTasks syncs at once with each source,
+	// DefaultSyncConcurrency when zero
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// OnProgress is called after each task is synced by SyncAllTasks
+	OnProgress func(completed, total int, result *SyncResult) `json:"-"`
+
+	// TaskIDs limits SyncAllTasks to the tasks listed, such as those a cancelled sync
+	// did not complete; all tasks with sources when empty
+	TaskIDs []string `json:"task_ids,omitempty"`
+
+	// Explain records the decision trail of SyncTask in SyncResult.Decisions
+	Explain bool `json:"explain,omitempty"`
 }
 
 // SyncDirection represents sync direction
@@ -176,6 +251,10 @@ const (
 	ConflictStrategyRemoteWins   ConflictStrategy = "remote_wins"
 	ConflictStrategyManualReview ConflictStrategy = "manual_review"
 	ConflictStrategyTimestamp    ConflictStrategy = "timestamp"
+
+	// ConflictStrategyUnion keeps the values from both sides of a list field such as
+	// labels; it can only be used as a field policy
+	ConflictStrategyUnion ConflictStrategy = "union"
 )
 
 // SyncResult represents the result of a sync operation
@@ -189,6 +268,27 @@ type SyncResult struct {
 	Error         string        `json:"error,omitempty"`
 	Duration      time.Duration `json:"duration"`
 	Timestamp     time.Time     `json:"timestamp"`
+
+	// DryRun marks a result worked out without changing either side, and Changes lists
+	// the field changes the sync would make
+	DryRun  bool          `json:"dry_run,omitempty"`
+	Changes []FieldChange `json:"changes,omitempty"`
+
+	// Decisions are the decision trail of the sync: the fields compared, the mappings
+	// applied and the conflict policies that fired, when SyncOptions.Explain is set
+	Decisions []SyncDecision `json:"decisions,omitempty"`
+
+	// UndoID is the snapshot of the task taken before the sync changed it, which
+	// UndoSync restores
+	UndoID string `json:"undo_id,omitempty"`
+
+	// Queued marks a failed sync whose push waits in the outbox of the task, retried by
+	// 'zen watch' and the next sync
+	Queued bool `json:"queued,omitempty"`
+
+	// Cancelled marks a task SyncAllTasks did not sync, or did not finish syncing,
+	// because its context was cancelled
+	Cancelled bool `json:"cancelled,omitempty"`
 }
 
 // Conflict represents a data conflict between local and remote
@@ -199,6 +299,10 @@ type Conflict struct {
 	LocalTime   time.Time   `json:"local_time"`
 	RemoteTime  time.Time   `json:"remote_time"`
 	Resolution  string      `json:"resolution,omitempty"`
+
+	// Strategy is the policy that resolved the conflict, and Value the value it keeps
+	Strategy ConflictStrategy `json:"strategy,omitempty"`
+	Value    interface{}      `json:"value,omitempty"`
 }
 
 // TaskProgress represents task workflow progress
@@ -216,12 +320,18 @@ type TaskProgress struct {
 
 // QualityGate represents a quality gate for stage progression
 type QualityGate struct {
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Required    bool       `json:"required"`
-	Status      string     `json:"status"` // passed, failed, pending
-	CheckedAt   *time.Time `json:"checked_at,omitempty"`
-	CheckedBy   string     `json:"checked_by,omitempty"`
+	Name        string     `json:"name" yaml:"-"`
+	Description string     `json:"description" yaml:"description,omitempty"`
+	Required    bool       `json:"required" yaml:"required,omitempty"`
+	Status      string     `json:"status" yaml:"status"` // passed, failed, pending
+	CheckedAt   *time.Time `json:"checked_at,omitempty" yaml:"checked_at,omitempty"`
+	CheckedBy   string     `json:"checked_by,omitempty" yaml:"checked_by,omitempty"`
+
+	// Stage is the workflow stage the gate guards
+	Stage string `json:"stage,omitempty" yaml:"stage,omitempty"`
+
+	// Evidence are the files, links and pull requests recorded when the gate was passed
+	Evidence []Evidence `json:"evidence,omitempty" yaml:"evidence,omitempty"`
 }
 
 // TaskMetadata represents task metadata and configuration
@@ -255,12 +365,13 @@ type WorkflowStage struct {
 
 // SyncSettings contains synchronization settings
 type SyncSettings struct {
-	Enabled          bool                  `json:"enabled"`
-	Frequency        string                `json:"frequency"` // manual, hourly, daily
-	Direction        SyncDirection         `json:"direction"`
-	ConflictStrategy ConflictStrategy      `json:"conflict_strategy"`
-	Sources          map[string]SourceSync `json:"sources"`
-	LastSync         time.Time             `json:"last_sync"`
+	Enabled          bool                        `json:"enabled"`
+	Frequency        string                      `json:"frequency"` // manual, hourly, daily
+	Direction        SyncDirection               `json:"direction"`
+	ConflictStrategy ConflictStrategy            `json:"conflict_strategy"`
+	FieldPolicies    map[string]ConflictStrategy `json:"field_policies,omitempty"`
+	Sources          map[string]SourceSync       `json:"sources"`
+	LastSync         time.Time                   `json:"last_sync"`
 }
 
 // SourceSync contains source-specific sync settings
@@ -303,7 +414,7 @@ func (m *Manager) initializeIntegrationComponents() error {
 	}
 
 	// Check if task source is configured for external integration
-	if config.Task.TaskSource == "" || config.Task.TaskSource == "none" || config.Task.TaskSource == "local" {
+	if LocalOnly(config) {
 		return fmt.Errorf("no external task system configured")
 	}
 
@@ -333,15 +444,68 @@ func (m *Manager) initializeIntegrationComponents() error {
 func (m *Manager) CreateTask(ctx context.Context, request *CreateTaskRequest) (*Task, error) {
 	m.logger.Debug("creating task", "id", request.ID, "from_source", request.FromSource)
 
+	// Tasks created from the local source are not linked to a tracker, and tasks cannot be
+	// created from a tracker when tasks are tracked locally
+	if request.FromSource == "local" || request.FromSource == TaskSystemNone {
+		request.FromSource = ""
+	}
+	if request.FromSource != "" && m.localOnly() {
+		return nil, LocalOnlyError(fmt.Sprintf("create a task from %s", request.FromSource))
+	}
+
+	// Allocate an ID by the configured scheme when none is given
+	if request.ID == "" && request.FromSource == "" {
+		id, err := m.NextTaskID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		request.ID = id
+	}
+
 	// Validate request
 	if err := m.validateCreateRequest(request); err != nil {
-		return nil, fmt.Errorf("invalid create request: %w", err)
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "invalid create request",
+			Details: err.Error(),
+		}
+	}
+
+	var labels []string
+	if request.Labels != nil {
+		checked, err := m.labelConfig().Check(request.Labels)
+		if err != nil {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: "invalid task labels",
+				Details: err.Error(),
+			}
+		}
+		labels = checked
+	}
+	if err := m.checkTaskNames(request.ID, labels); err != nil {
+		return nil, err
 	}
 
 	// Check if task already exists
 	if m.taskExists(request.ID) {
-		return nil, fmt.Errorf("task already exists: %s", request.ID)
+		return nil, &types.Error{
+			Code:    types.ErrorCodeAlreadyExists,
+			Message: fmt.Sprintf("task already exists: %s", request.ID),
+		}
+	}
+
+	// Write the task in a staging directory, moved into place once complete, so that
+	// failed and interrupted creations leave no half-created task behind
+	tasksDir, err := m.tasksDirectory()
+	if err != nil {
+		return nil, err
+	}
+	staged, err := stageTaskDirectory(tasksDir, request.ID)
+	if err != nil {
+		return nil, err
 	}
+	defer staged.discard()
 
 	// Create task structure
 	task := &Task{
@@ -352,6 +516,7 @@ func (m *Manager) CreateTask(ctx context.Context, request *CreateTaskRequest) (*
 		Priority:     request.Priority,
 		Owner:        request.Owner,
 		Team:         request.Team,
+		Labels:       labels,
 		Created:      time.Now(),
 		Updated:      time.Now(),
 		CurrentStage: "01-align",
@@ -368,18 +533,24 @@ func (m *Manager) CreateTask(ctx context.Context, request *CreateTaskRequest) (*
 		task.Priority = "P2"
 	}
 	if task.Owner == "" {
-		if user := os.Getenv("USER"); user != "" {
-			task.Owner = user
-		} else {
-			task.Owner = "unknown"
-		}
+		task.Owner = m.identity().Current()
+	}
+	if task.Team == "" {
+		task.Team = m.teamConfig().DefaultTeam(m.identity().Resolve(task.Owner))
 	}
 	if task.Team == "" {
 		task.Team = "default"
 	}
+	if err := m.teamConfig().CheckAssignment(task.Team, m.identity().Resolve(task.Owner)); err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "invalid task owner",
+			Details: err.Error(),
+		}
+	}
 
 	// Create task directory structure first
-	if err := m.createTaskStructure(ctx, task, request); err != nil {
+	if err := m.createTaskStructure(ctx, task, request, staged.dir); err != nil {
 		return nil, fmt.Errorf("failed to create task structure: %w", err)
 	}
 
@@ -387,7 +558,11 @@ func (m *Manager) CreateTask(ctx context.Context, request *CreateTaskRequest) (*
 	var sourceData *TaskData
 	if request.FromSource != "" {
 		var err error
-		sourceData, err = m.fetchFromSource(ctx, request.ID, request.FromSource)
+		if request.SourceData != nil {
+			sourceData = request.SourceData
+		} else {
+			sourceData, err = m.fetchFromSource(ctx, request.ID, request.FromSource)
+		}
 		if err != nil {
 			// Log debug message but continue with local task creation
 			m.logger.Debug("failed to fetch from external source, creating local task",
@@ -446,6 +621,21 @@ func (m *Manager) CreateTask(ctx context.Context, request *CreateTaskRequest) (*
 		}
 	}
 
+	// An interrupt cancels ctx; the staged task is discarded rather than moved into place
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("task creation interrupted: %w", err)
+	}
+	if err := staged.commit(); err != nil {
+		return nil, err
+	}
+	setTaskPaths(task, staged.path())
+
+	// Show folder creation success
+	fmt.Fprintf(m.io.Out, "%s\n",
+		m.io.FormatSuccess(fmt.Sprintf("Task folder created: %s", task.WorkspacePath)))
+
+	m.recordChecklistUsage(ctx, task.Type)
+
 	m.logger.Info("task created successfully", "id", task.ID, "type", task.Type, "source", request.FromSource)
 
 	return task, nil
@@ -455,9 +645,12 @@ func (m *Manager) CreateTask(ctx context.Context, request *CreateTaskRequest) (*
 func (m *Manager) GetTask(ctx context.Context, taskID string) (*Task, error) {
 	m.logger.Debug("getting task", "id", taskID)
 
+	// The old IDs of renamed tasks lead to the task when a redirect was left
+	taskID = m.resolveTaskID(taskID)
+
 	// Check if task exists
 	if !m.taskExists(taskID) {
-		return nil, fmt.Errorf("task not found: %s", taskID)
+		return nil, taskNotFound(taskID)
 	}
 
 	// Load task from manifest
@@ -469,45 +662,227 @@ func (m *Manager) GetTask(ctx context.Context, taskID string) (*Task, error) {
 	return task, nil
 }
 
+// UpdateTask changes the title, status, priority, owner, team, or labels of a task in
+// its manifest. The other fields of updates are not kept in the manifest and are
+// rejected.
+func (m *Manager) UpdateTask(ctx context.Context, taskID string, updates *TaskUpdates) (*Task, error) {
+	m.logger.Debug("updating task", "id", taskID)
+
+	if updates == nil {
+		updates = &TaskUpdates{}
+	}
+	var unsupported []string
+	if updates.Description != nil {
+		unsupported = append(unsupported, "description")
+	}
+	if updates.DueDate != nil {
+		unsupported = append(unsupported, "due_date")
+	}
+	if updates.Tags != nil {
+		unsupported = append(unsupported, "tags")
+	}
+	if len(unsupported) > 0 {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "fields cannot be updated",
+			Details: strings.Join(unsupported, ", "),
+		}
+	}
+
+	if updates.Title != nil && strings.TrimSpace(*updates.Title) == "" {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: "task title cannot be empty",
+		}
+	}
+
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tasks tracked locally follow the status workflow; trackers enforce their own
+	if updates.Status != nil && m.localOnly() {
+		if err := CheckStatusTransition(task.Status, *updates.Status); err != nil {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: "invalid task status",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	if updates.Owner != nil || updates.Team != nil {
+		owner, teamName := task.Owner, task.Team
+		if updates.Owner != nil {
+			owner = *updates.Owner
+		}
+		if updates.Team != nil {
+			teamName = *updates.Team
+		}
+		if err := m.teamConfig().CheckAssignment(teamName, m.identity().Resolve(owner)); err != nil {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: "invalid task owner",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	var labels []string
+	if updates.Labels != nil {
+		if labels, err = m.labelConfig().Check(updates.Labels); err != nil {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: "invalid task labels",
+				Details: err.Error(),
+			}
+		}
+		if err := m.checkTaskNames("", labels); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := map[string]string{}
+	for key, update := range map[string]*string{
+		"task.title":    updates.Title,
+		"task.status":   updates.Status,
+		"task.priority": updates.Priority,
+		"owner.name":    updates.Owner,
+		"team.name":     updates.Team,
+	} {
+		if update != nil {
+			fields[key] = *update
+		}
+	}
+	if len(fields) == 0 && labels == nil {
+		return task, nil
+	}
+	fields["dates.last_updated"] = time.Now().Format("2006-01-02 15:04:05")
+
+	lock, err := lockTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Release()
+
+	if err := updateManifestFields(task.ManifestPath, fields); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	if labels != nil {
+		if err := setManifestValue(task.ManifestPath, "labels", labels); err != nil {
+			return nil, fmt.Errorf("failed to update task labels: %w", err)
+		}
+	}
+
+	m.logger.Info("task updated", "task_id", taskID)
+	return m.GetTask(ctx, taskID)
+}
+
+// DeleteTask removes a task and everything in its directory from the workspace. The
+// task is not deleted from the external sources it is linked to. Deleting tasks can be
+// restricted by policy, which ctx overrides when it is set with policy.WithConfirm.
+func (m *Manager) DeleteTask(ctx context.Context, taskID string) error {
+	m.logger.Debug("deleting task", "id", taskID)
+
+	if !m.taskExists(taskID) {
+		return taskNotFound(taskID)
+	}
+
+	if err := m.authorize(ctx, policy.OpTaskDelete, taskID); err != nil {
+		return err
+	}
+
+	tasksDir, err := m.tasksDirectory()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(tasksDir, taskID)); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	m.logger.Info("task deleted", "task_id", taskID)
+	return nil
+}
+
+// taskNotFound returns the error for a task that is not in the workspace
+func taskNotFound(taskID string) error {
+	return &types.Error{
+		Code:    types.ErrorCodeNotFound,
+		Message: fmt.Sprintf("task not found: %s", taskID),
+	}
+}
+
 // PullFromSource pulls latest data from external source
 func (m *Manager) PullFromSource(ctx context.Context, taskID string, source string) (*Task, error) {
+	task, _, err := m.pullFromSource(ctx, taskID, source, nil)
+	return task, err
+}
+
+// pullFromSource pulls a task from source, using prefetched instead of fetching when it
+// is set. It returns the ID of the snapshot 'zen task sync undo' restores.
+func (m *Manager) pullFromSource(ctx context.Context, taskID string, source string, prefetched *TaskData) (*Task, string, error) {
 	m.logger.Debug("pulling task from source", "task_id", taskID, "source", source)
 
 	// Get current task
 	task, err := m.GetTask(ctx, taskID)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// Check if task has this source
 	taskSource, exists := task.Sources[source]
 	if !exists {
-		return nil, fmt.Errorf("task %s is not linked to source %s", taskID, source)
+		return nil, "", fmt.Errorf("task %s is not linked to source %s", taskID, source)
 	}
 
 	// Fetch latest data from source
-	sourceData, err := m.fetchFromSource(ctx, taskSource.ExternalID, source)
+	sourceData := prefetched
+	if sourceData == nil {
+		sourceData, err = m.fetchFromSource(ctx, taskSource.ExternalID, source)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to fetch from %s: %w", source, err)
+		}
+	}
+
+	// Snapshot the task before the pull first writes it, so that it can be undone
+	undo, err := m.takeSyncUndo(task, source, SyncDirectionPull)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from %s: %w", source, err)
+		m.logger.Warn("failed to snapshot task before sync", "task_id", taskID, "source", source, "error", err)
 	}
 
 	// Update task with source data
+	before := *task
 	if err := m.updateTaskFromSourceData(ctx, task, sourceData, source); err != nil {
-		return nil, fmt.Errorf("failed to update task: %w", err)
+		return nil, "", fmt.Errorf("failed to update task: %w", err)
 	}
+	explainPull(ctx, &before, task, sourceData, source)
+	m.pullProjectFields(ctx, task, source)
 
 	// Update source metadata
 	taskSource.LastSync = time.Now()
+	taskSource.Snapshot = snapshotOf(task)
+	taskSource.PendingConflicts = nil
 	task.Sources[source] = taskSource
 
+	// Keep the snapshot when the pull changed the task, its stage included
+	var undoID string
+	changed := len(diffTaskFields(ChangeSideLocal, &before, task, previewFields)) > 0 || before.CurrentStage != task.CurrentStage
+	if undo != nil && changed {
+		undoID, err = m.saveSyncUndo(task, undo)
+		if err != nil {
+			m.logger.Warn("failed to snapshot task before sync", "task_id", taskID, "source", source, "error", err)
+		}
+	}
+
 	// Save updated task
 	if err := m.saveTask(ctx, task); err != nil {
-		return nil, fmt.Errorf("failed to save task: %w", err)
+		return nil, "", fmt.Errorf("failed to save task: %w", err)
 	}
 
 	m.logger.Info("task pulled from source successfully", "task_id", taskID, "source", source)
 
-	return task, nil
+	return task, undoID, nil
 }
 
 // PushToSource pushes task data to external source
@@ -520,14 +895,32 @@ func (m *Manager) PushToSource(ctx context.Context, taskID string, source string
 		return nil, err
 	}
 
+	return m.pushTask(ctx, task, source)
+}
+
+// pushTask pushes a loaded task to source and records the pushed fields as its snapshot
+func (m *Manager) pushTask(ctx context.Context, task *Task, source string) (*SyncResult, error) {
+	taskID := task.ID
+
 	// Check if task has this source
 	taskSource, exists := task.Sources[source]
 	if !exists {
 		return nil, fmt.Errorf("task %s is not linked to source %s", taskID, source)
 	}
 
-	// Convert task to plugin format
+	// Convert task to plugin format, assigning the owner's account and the labels in the
+	// source
 	pluginTaskData := m.convertTaskToPluginData(task)
+	if account := m.identity().Account(task.Owner, source); account != "" {
+		pluginTaskData.Assignee = account
+		if account != task.Owner {
+			explain(ctx, DecisionMapping, "owner", "local user %s is %s account %s", describeValue(task.Owner), source, describeValue(account))
+		}
+	}
+	pluginTaskData.Labels = m.labelConfig().ToProvider(source, task.Labels)
+	if !sameValue(pluginTaskData.Labels, task.Labels) {
+		explain(ctx, DecisionMapping, "labels", "local labels %s are %s in %s", describeValue(task.Labels), describeValue(pluginTaskData.Labels), source)
+	}
 
 	// Get plugin instance
 	pluginInstance, err := m.getOrCreatePlugin(ctx, source)
@@ -558,13 +951,25 @@ func (m *Manager) PushToSource(ctx context.Context, taskID string, source string
 
 	result.Success = true
 	result.ChangedFields = m.detectChangedFields(pluginTaskData, updatedData)
+	explain(ctx, DecisionApply, "", "the local values were pushed to %s", source)
+	accepted := snapshotOf(task)
+	m.markPushDelivered(task, source, accepted)
+	m.pushProjectFields(ctx, task, source)
 
 	// Update source metadata
-	taskSource.LastSync = time.Now()
+	taskSource.LastSync = accepted.SyncedAt
+	taskSource.Snapshot = accepted
+	taskSource.PendingConflicts = nil
 	task.Sources[source] = taskSource
 
-	// Save updated task
+	// Save updated task; the push went through, but a task changed by another command
+	// since it was read is left as that command wrote it
 	if err := m.saveTask(ctx, task); err != nil {
+		if IsConcurrentModification(err) {
+			result.Success = false
+			result.Error = err.Error()
+			return result, err
+		}
 		m.logger.Warn("failed to save task after push", "task_id", taskID, "error", err)
 	}
 
@@ -575,6 +980,13 @@ func (m *Manager) PushToSource(ctx context.Context, taskID string, source string
 
 // SyncTask synchronizes a task with all its external sources
 func (m *Manager) SyncTask(ctx context.Context, taskID string, opts *SyncOptions) (*SyncResult, error) {
+	return explainSync(ctx, opts, func(ctx context.Context) (*SyncResult, error) {
+		return m.syncTask(ctx, taskID, opts, nil)
+	})
+}
+
+// syncTask synchronizes a task, pulling from prefetched instead of the source when it is set
+func (m *Manager) syncTask(ctx context.Context, taskID string, opts *SyncOptions, prefetched *TaskData) (*SyncResult, error) {
 	m.logger.Debug("syncing task", "task_id", taskID, "direction", opts.Direction)
 
 	// Get current task
@@ -583,26 +995,35 @@ func (m *Manager) SyncTask(ctx context.Context, taskID string, opts *SyncOptions
 		return nil, err
 	}
 
-	// Determine sources to sync
-	sourcesToSync := opts.Sources
-	if len(sourcesToSync) == 0 {
-		// Sync all sources
-		for source := range task.Sources {
-			sourcesToSync = append(sourcesToSync, source)
-		}
-	}
-
 	// For now, sync with the first source (single source sync)
 	// In the future, this would handle multi-source sync with conflict resolution
-	if len(sourcesToSync) == 0 {
+	source := syncSource(task, opts)
+	if source == "" {
 		return nil, fmt.Errorf("no sources to sync for task: %s", taskID)
 	}
+	explainSource(ctx, task, source, opts)
+
+	// Stop before the first request when the credentials cannot do what the sync does
+	operation := auth.OperationIssuesWrite
+	if opts.DryRun || opts.Direction == SyncDirectionPull {
+		operation = auth.OperationIssuesRead
+	}
+	if err := m.requireScopes(ctx, source, operation); err != nil {
+		return nil, err
+	}
 
-	source := sourcesToSync[0]
+	if opts.DryRun {
+		return m.previewSync(ctx, task, source, opts, prefetched)
+	}
+
+	// --force also overwrites the task when another command changed it during the sync
+	if opts.Force {
+		ctx = withForcedWrites(ctx)
+	}
 
 	switch opts.Direction {
 	case SyncDirectionPull:
-		_, err := m.PullFromSource(ctx, taskID, source)
+		_, undoID, err := m.pullFromSource(ctx, taskID, source, prefetched)
 		if err != nil {
 			return &SyncResult{
 				TaskID:    taskID,
@@ -613,24 +1034,26 @@ func (m *Manager) SyncTask(ctx context.Context, taskID string, opts *SyncOptions
 				Timestamp: time.Now(),
 			}, err
 		}
-
+		return &SyncResult{
+			TaskID:    taskID,
+			Source:    source,
+			Success:   true,
+			Direction: opts.Direction,
+			Timestamp: time.Now(),
+			UndoID:    undoID,
+		}, nil
+
+	// Pushes are recorded in the outbox of the task until they succeed, so that a failed
+	// push is retried rather than lost
 	case SyncDirectionPush:
-		return m.PushToSource(ctx, taskID, source)
+		return m.throughOutbox(ctx, task, source, opts, func(ctx context.Context) (*SyncResult, error) {
+			return m.pushTask(ctx, task, source)
+		})
 
 	case SyncDirectionBidirectional:
-		// First pull, then push (simple bidirectional sync)
-		if _, err := m.PullFromSource(ctx, taskID, source); err != nil {
-			return &SyncResult{
-				TaskID:    taskID,
-				Source:    source,
-				Success:   false,
-				Direction: opts.Direction,
-				Error:     fmt.Sprintf("pull failed: %v", err),
-				Timestamp: time.Now(),
-			}, err
-		}
-
-		return m.PushToSource(ctx, taskID, source)
+		return m.throughOutbox(ctx, task, source, opts, func(ctx context.Context) (*SyncResult, error) {
+			return m.mergeWithSource(ctx, task, source, opts, prefetched)
+		})
 	}
 
 	return &SyncResult{
@@ -642,14 +1065,217 @@ func (m *Manager) SyncTask(ctx context.Context, taskID string, opts *SyncOptions
 	}, nil
 }
 
-// ListTasks returns a list of tasks matching the given filter
+// requireScopes fails when the credentials of source lack the scopes of operation.
+// Sources whose scopes cannot be read are not checked.
+func (m *Manager) requireScopes(ctx context.Context, source, operation string) error {
+	if m.factory == nil || m.factory.AuthManager == nil {
+		return nil
+	}
+	authMgr, err := m.factory.AuthManager()
+	if err != nil {
+		m.logger.Debug("scopes not checked", "source", source, "error", err)
+		return nil
+	}
+	return auth.RequireScopes(ctx, authMgr, source, operation)
+}
+
+// ListTasks returns a list of tasks matching the given filter, whose owner "me" is the
+// current user
 func (m *Manager) ListTasks(ctx context.Context, filter *TaskFilter) ([]*Task, error) {
-	// TODO: Implement proper task listing from workspace
-	// For now, return empty list to satisfy interface
-	return []*Task{}, nil
+	filter = filter.forUser(m.identity().Current())
+
+	tasksDir, err := m.tasksDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(tasksDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Task{}, nil
+		}
+		return nil, fmt.Errorf("failed to read tasks directory: %w", err)
+	}
+
+	// Tasks whose manifest and metadata are unchanged since they were indexed are not
+	// read again; the task index lives in the .zen directory above work/tasks
+	index := openTaskIndex(TaskIndexPath(filepath.Dir(filepath.Dir(tasksDir))))
+	seen := make(map[string]bool, len(entries))
+
+	tasks := []*Task{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		taskID := entry.Name()
+		stamp, err := taskStamp(filepath.Join(tasksDir, taskID))
+		if err != nil {
+			m.logger.Debug("skipping task without readable manifest", "task_id", taskID, "error", err)
+			continue
+		}
+		seen[taskID] = true
+
+		task := index.lookup(taskID, stamp)
+		if task == nil {
+			task, err = m.loadTaskFromManifest(taskID)
+			if err != nil {
+				m.logger.Debug("skipping task without readable manifest", "task_id", taskID, "error", err)
+				continue
+			}
+			index.store(taskID, stamp, task)
+		}
+
+		if filter.Matches(task) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	index.prune(seen)
+	if err := index.save(); err != nil {
+		m.logger.Debug("failed to save task index", "error", err)
+	}
+
+	return tasks, nil
 }
 
-// SyncAllTasks synchronizes all tasks with their external sources
+// Matches reports whether a task satisfies every criterion set on the filter
+func (f *TaskFilter) Matches(task *Task) bool {
+	if f == nil {
+		return true
+	}
+	if f.Type != "" && !strings.EqualFold(task.Type, f.Type) {
+		return false
+	}
+	if f.Status != "" && !strings.EqualFold(task.Status, f.Status) {
+		return false
+	}
+	if f.Owner != "" && !strings.EqualFold(task.Owner, f.Owner) {
+		return false
+	}
+	if f.Team != "" && !strings.EqualFold(task.Team, f.Team) {
+		return false
+	}
+	if f.Stage != "" && task.CurrentStage != f.Stage && !strings.EqualFold(StageName(task.CurrentStage), f.Stage) {
+		return false
+	}
+	for _, label := range f.Labels {
+		found := false
+		for _, taskLabel := range task.Labels {
+			if strings.EqualFold(taskLabel, label) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, source := range f.Sources {
+		if _, ok := task.Sources[source]; !ok {
+			return false
+		}
+	}
+	for _, condition := range f.Conditions {
+		if !condition.Matches(task) {
+			return false
+		}
+	}
+	return true
+}
+
+// ProgressTask moves a task to the given workflow stage, or to the next stage when stage is empty
+func (m *Manager) ProgressTask(ctx context.Context, taskID string, stage string) error {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if stage == "" {
+		stage, err = NextStage(task.CurrentStage)
+		if err != nil {
+			return err
+		}
+	} else if StageIndex(stage) < 0 {
+		return fmt.Errorf("unknown workflow stage: %s (valid stages: %s)", stage, strings.Join(WorkflowStages, ", "))
+	}
+
+	if err := m.checkChecklist(task, stage); err != nil {
+		return err
+	}
+	if err := m.checkRequiredGates(task, stage); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	fields := map[string]string{
+		"workflow.current_stage": stage,
+		"dates.last_updated":     now.Format("2006-01-02 15:04:05"),
+	}
+	if task.Status == "proposed" || task.Status == "" {
+		fields["task.status"] = "in_progress"
+	}
+	for key, value := range stageTransitionFields(task, stage, now) {
+		fields[key] = value
+	}
+
+	if err := updateManifestFields(task.ManifestPath, fields); err != nil {
+		return fmt.Errorf("failed to progress task: %w", err)
+	}
+
+	m.logger.Info("task progressed", "task_id", taskID, "from", task.CurrentStage, "to", stage)
+
+	// Move the task's items on project boards along with it
+	task.CurrentStage = stage
+	for source := range task.Sources {
+		if m.pushProjectFields(ctx, task, source) {
+			if err := m.updateSourceMetadata(task.MetadataPath, source, task.Sources[source]); err != nil {
+				m.logger.Warn("failed to update source metadata", "source", source, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetTaskProgress returns workflow progress for a task
+func (m *Manager) GetTaskProgress(ctx context.Context, taskID string) (*TaskProgress, error) {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := StageIndex(task.CurrentStage)
+	completed := []string{}
+	if idx > 0 {
+		completed = append(completed, WorkflowStages[:idx]...)
+	}
+
+	artifacts, err := stageArtifacts(task.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+	gates, err := qualityGates(task.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskProgress{
+		CurrentStage:    task.CurrentStage,
+		StageNumber:     idx + 1,
+		TotalStages:     len(WorkflowStages),
+		Progress:        task.Progress,
+		CompletedStages: completed,
+		Artifacts:       orderedArtifacts(artifacts),
+		QualityGates:    orderedGates(gates),
+		Metadata:        map[string]interface{}{},
+	}, nil
+}
+
+// SyncAllTasks synchronizes all tasks with their external sources. Tasks are synced
+// concurrently, up to opts.Concurrency at a time with each source, and pulls fetch the
+// tasks of each source in bulk when its plugin supports it. Results are in task order.
+// When ctx is cancelled, the calls in flight are cut short and no further task is
+// synced; the results are returned with ctx.Err(), those not synced marked Cancelled.
 func (m *Manager) SyncAllTasks(ctx context.Context, opts *SyncOptions) ([]*SyncResult, error) {
 	// List all tasks
 	tasks, err := m.ListTasks(ctx, &TaskFilter{})
@@ -657,36 +1283,126 @@ func (m *Manager) SyncAllTasks(ctx context.Context, opts *SyncOptions) ([]*SyncR
 		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
 
-	var results []*SyncResult
+	only := map[string]bool{}
+	for _, id := range opts.TaskIDs {
+		only[id] = true
+	}
+
+	var jobs []syncJob
 	for _, task := range tasks {
 		// Skip tasks without external sources
 		if len(task.Sources) == 0 {
 			continue
 		}
+		if len(only) > 0 && !only[task.ID] {
+			continue
+		}
+		job := syncJob{taskID: task.ID, source: syncSource(task, opts)}
+		if taskSource, ok := task.Sources[job.source]; ok {
+			job.externalID = taskSource.ExternalID
+		}
+		jobs = append(jobs, job)
+	}
+
+	prefetched := map[string]map[string]*TaskData{}
+	if opts.DryRun || opts.Direction == SyncDirectionPull || opts.Direction == SyncDirectionBidirectional {
+		prefetched = m.prefetchSourceData(ctx, jobs)
+	}
+
+	results := make([]*SyncResult, len(jobs))
+	var mu sync.Mutex
+	completed := 0
 
-		// Sync each task
-		result, err := m.SyncTask(ctx, task.ID, opts)
+	runPerSource(jobs, opts.Concurrency, func(i int) {
+		job := jobs[i]
+		// Once cancelled, no further task is synced, and the syncs cut short by the
+		// cancellation are reported as cancelled rather than failed
+		if ctx.Err() != nil {
+			results[i] = cancelledSync(job, opts)
+			return
+		}
+		result, err := m.syncTask(ctx, job.taskID, opts, prefetched[job.source][job.externalID])
+		if err != nil && ctx.Err() != nil {
+			results[i] = cancelledSync(job, opts)
+			return
+		}
 		if err != nil {
 			// Log error but continue with other tasks
-			m.logger.Warn("failed to sync task", "task_id", task.ID, "error", err)
+			m.logger.Warn("failed to sync task", "task_id", job.taskID, "error", err)
+		}
+		if err != nil && result == nil {
 			result = &SyncResult{
-				TaskID:    task.ID,
+				TaskID:    job.taskID,
+				Source:    job.source,
 				Success:   false,
 				Direction: opts.Direction,
 				Error:     err.Error(),
 				Timestamp: time.Now(),
 			}
 		}
-		results = append(results, result)
+		results[i] = result
+
+		mu.Lock()
+		defer mu.Unlock()
+		completed++
+		if opts.OnProgress != nil {
+			opts.OnProgress(completed, len(jobs), result)
+		}
+	})
+
+	// The results of a cancelled sync are returned with the cancellation, so that what
+	// completed can be reported
+	return results, ctx.Err()
+}
+
+// cancelledSync is the result of the sync of a task cut short by cancellation
+func cancelledSync(job syncJob, opts *SyncOptions) *SyncResult {
+	return &SyncResult{
+		TaskID:    job.taskID,
+		Source:    job.source,
+		Direction: opts.Direction,
+		Error:     "cancelled",
+		Cancelled: true,
+		Timestamp: time.Now(),
+	}
+}
+
+// prefetchSourceData fetches the tasks of each source in bulk, keyed by source and
+// external ID. Tasks missing from the result are fetched one at a time when synced.
+func (m *Manager) prefetchSourceData(ctx context.Context, jobs []syncJob) map[string]map[string]*TaskData {
+	ids := map[string][]string{}
+	for _, job := range jobs {
+		if job.externalID != "" {
+			ids[job.source] = append(ids[job.source], job.externalID)
+		}
 	}
 
-	return results, nil
+	ops := NewOperations(m.factory)
+	prefetched := make(map[string]map[string]*TaskData, len(ids))
+	for source, externalIDs := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+		data, err := ops.FetchBatchFromSource(ctx, externalIDs, source)
+		if err != nil {
+			m.logger.Warn("failed to fetch tasks in bulk, fetching one at a time", "source", source, "error", err)
+			continue
+		}
+		m.logger.Debug("fetched tasks in bulk", "source", source, "requested", len(externalIDs), "found", len(data))
+		prefetched[source] = data
+	}
+	return prefetched
 }
 
 // Helper methods
 
 // taskExists checks if a task exists in the workspace
 func (m *Manager) taskExists(taskID string) bool {
+	// IDs name a directory of the tasks directory, never a path out of it
+	if taskID == "" || taskID == "." || taskID == ".." || strings.ContainsAny(taskID, `/\`) {
+		return false
+	}
+
 	ws, err := m.factory.WorkspaceManager()
 	if err != nil {
 		return false
@@ -704,33 +1420,152 @@ func (m *Manager) taskExists(taskID string) bool {
 
 // validateCreateRequest validates a create task request
 func (m *Manager) validateCreateRequest(request *CreateTaskRequest) error {
-	if request.ID == "" {
-		return fmt.Errorf("task ID is required")
+	return m.taskConfig().ValidateID(request.ID, request.FromSource)
+}
+
+// fetchFromSource fetches task data from external source
+func (m *Manager) fetchFromSource(ctx context.Context, taskID string, source string) (*TaskData, error) {
+	// Use the existing operations for now
+	// In the future, this would use the integration framework directly
+	ops := NewOperations(m.factory)
+	data, err := ops.FetchFromSource(ctx, taskID, source)
+	if err != nil {
+		return nil, err
+	}
+	return m.localizeSourceData(ctx, data, source), nil
+}
+
+// localizeSourceData maps the people and labels of a task in a source to those of the
+// workspace
+func (m *Manager) localizeSourceData(ctx context.Context, data *TaskData, source string) *TaskData {
+	// Sources name the assignee by their own account, so map it to the local user
+	assignee := data.Owner
+	if assignee == "" {
+		assignee = data.Assignee
+	}
+	if owner, _, ok := m.identity().Lookup(assignee); ok {
+		data.Owner = owner
+		if owner != assignee {
+			explain(ctx, DecisionMapping, "owner", "%s account %s is local user %s", source, describeValue(assignee), describeValue(owner))
+		}
+	} else if assignee != "" {
+		explain(ctx, DecisionMapping, "owner", "%s account %s is not a known user, so it is kept as it is", source, describeValue(assignee))
 	}
 
-	// Validate task ID format
-	if len(request.ID) < 3 {
-		return fmt.Errorf("task ID must be at least 3 characters long")
+	// and their labels to those of the label vocabulary
+	labels := m.labelConfig().FromProvider(source, data.Labels)
+	if !sameValue(labels, data.Labels) {
+		explain(ctx, DecisionMapping, "labels", "%s labels %s are %s locally", source, describeValue(data.Labels), describeValue(labels))
 	}
+	data.Labels = labels
+	return data
+}
 
-	if strings.ContainsAny(request.ID, " /\\:*?\"<>|") {
-		return fmt.Errorf("task ID contains invalid characters")
+// identity returns the directory of the users in the identity configuration, loading
+// it on first use
+func (m *Manager) identity() *identity.Directory {
+	if m.identities != nil {
+		return m.identities
 	}
 
-	return nil
+	cfg := identity.DefaultConfig()
+	if zenConfig, err := m.factory.Config(); err == nil {
+		if parsed, err := config.GetConfig(zenConfig, identity.ConfigParser{}); err == nil {
+			cfg = parsed
+		} else {
+			m.logger.Warn("failed to load identity config", "error", err)
+		}
+	}
+	m.identities = identity.New(cfg)
+	return m.identities
 }
 
-// fetchFromSource fetches task data from external source
-func (m *Manager) fetchFromSource(ctx context.Context, taskID string, source string) (*TaskData, error) {
-	// Use the existing operations for now
-	// In the future, this would use the integration framework directly
-	ops := NewOperations(m.factory)
-	return ops.FetchFromSource(ctx, taskID, source)
+// authorize returns an error unless policy allows operation on target, overriding the
+// policy when ctx is set with policy.WithConfirm
+func (m *Manager) authorize(ctx context.Context, operation, target string) error {
+	if m.enforcer == nil {
+		zenConfig, err := m.factory.Config()
+		if err != nil {
+			return fmt.Errorf("failed to get config: %w", err)
+		}
+		ws, err := m.factory.WorkspaceManager()
+		if err != nil {
+			return fmt.Errorf("failed to get workspace manager: %w", err)
+		}
+		status, err := ws.Status()
+		if err != nil {
+			return fmt.Errorf("failed to get workspace status: %w", err)
+		}
+		enforcer, err := policy.Load(zenConfig, filepath.Join(status.Root, ".zen"))
+		if err != nil {
+			return err
+		}
+		m.enforcer = enforcer
+	}
+
+	return m.enforcer.Authorize(policy.Request{Operation: operation, Target: target, Confirm: policy.Confirmed(ctx)})
+}
+
+// teamConfig returns the team configuration, loading it on first use
+func (m *Manager) teamConfig() *team.Config {
+	if m.teams != nil {
+		return m.teams
+	}
+
+	cfg := team.DefaultConfig()
+	if zenConfig, err := m.factory.Config(); err == nil {
+		if parsed, err := config.GetConfig(zenConfig, team.ConfigParser{}); err == nil {
+			cfg = parsed
+		} else {
+			m.logger.Warn("failed to load team config", "error", err)
+		}
+	}
+	m.teams = &cfg
+	return m.teams
+}
+
+// labelConfig returns the label vocabulary, loading it on first use
+func (m *Manager) labelConfig() *label.Config {
+	if m.labels != nil {
+		return m.labels
+	}
+
+	cfg := label.DefaultConfig()
+	if zenConfig, err := m.factory.Config(); err == nil {
+		if parsed, err := config.GetConfig(zenConfig, label.ConfigParser{}); err == nil {
+			cfg = parsed
+		} else {
+			m.logger.Warn("failed to load label config", "error", err)
+		}
+	}
+	m.labels = &cfg
+	return m.labels
+}
+
+// taskConfig returns the task configuration, loading it on first use
+func (m *Manager) taskConfig() *Config {
+	if m.settings != nil {
+		return m.settings
+	}
+
+	cfg := DefaultConfig()
+	if zenConfig, err := m.factory.Config(); err == nil {
+		if parsed, err := config.GetConfig(zenConfig, ConfigParser{}); err == nil {
+			cfg = parsed
+		} else {
+			m.logger.Warn("failed to load task config", "error", err)
+		}
+	}
+	m.settings = &cfg
+	return m.settings
 }
 
 // getOrCreatePlugin gets or creates a plugin instance
 func (m *Manager) getOrCreatePlugin(ctx context.Context, source string) (plugin.IntegrationPluginInterface, error) {
 	if m.clientFactory == nil {
+		if m.localOnly() {
+			return nil, LocalOnlyError(fmt.Sprintf("reach %s", source))
+		}
 		return nil, fmt.Errorf("integration not available")
 	}
 
@@ -786,6 +1621,9 @@ func (m *Manager) updateTaskFromSourceData(ctx context.Context, task *Task, sour
 	task.Priority = sourceData.Priority
 	task.Updated = time.Now()
 
+	if sourceData.Description != "" {
+		task.Description = sourceData.Description
+	}
 	if sourceData.Owner != "" {
 		task.Owner = sourceData.Owner
 	}
@@ -799,34 +1637,22 @@ func (m *Manager) updateTaskFromSourceData(ctx context.Context, task *Task, sour
 	return nil
 }
 
-// createTaskStructure creates the complete task directory structure
-func (m *Manager) createTaskStructure(ctx context.Context, task *Task, request *CreateTaskRequest) error {
+// createTaskStructure creates the complete task directory structure in taskDir, the
+// staging directory of the task
+func (m *Manager) createTaskStructure(ctx context.Context, task *Task, request *CreateTaskRequest, taskDir string) error {
 	// Get workspace manager
 	ws, err := m.factory.WorkspaceManager()
 	if err != nil {
 		return fmt.Errorf("failed to get workspace manager: %w", err)
 	}
 
-	status, err := ws.Status()
-	if err != nil {
-		return fmt.Errorf("failed to get workspace status: %w", err)
-	}
-
 	// Create task directory
-	taskDir := filepath.Join(status.Root, ".zen", "work", "tasks", task.ID)
 	if err := ws.CreateTaskDirectory(taskDir); err != nil {
 		return fmt.Errorf("failed to create task directories: %w", err)
 	}
 
-	// Show folder creation success
-	fmt.Fprintf(m.io.Out, "%s\n",
-		m.io.FormatSuccess(fmt.Sprintf("Task folder created: %s", taskDir)))
-
 	// Set task paths
-	task.WorkspacePath = taskDir
-	task.IndexPath = filepath.Join(taskDir, "index.md")
-	task.ManifestPath = filepath.Join(taskDir, "manifest.yaml")
-	task.MetadataPath = filepath.Join(taskDir, "metadata")
+	setTaskPaths(task, taskDir)
 
 	// Generate initial task files from templates (without source data)
 	if err := m.generateTaskFiles(ctx, task, request, nil); err != nil {
@@ -836,6 +1662,14 @@ func (m *Manager) createTaskStructure(ctx context.Context, task *Task, request *
 	return nil
 }
 
+// setTaskPaths points the paths of task at the task directory taskDir
+func setTaskPaths(task *Task, taskDir string) {
+	task.WorkspacePath = taskDir
+	task.IndexPath = filepath.Join(taskDir, "index.md")
+	task.ManifestPath = filepath.Join(taskDir, "manifest.yaml")
+	task.MetadataPath = filepath.Join(taskDir, "metadata")
+}
+
 // updateTaskWithSourceData updates task files with source data and saves metadata
 func (m *Manager) updateTaskWithSourceData(ctx context.Context, task *Task, request *CreateTaskRequest, sourceData *TaskData) error {
 	// Regenerate task files with source data
@@ -861,6 +1695,15 @@ func (m *Manager) generateTaskFiles(ctx context.Context, task *Task, request *Cr
 	// Build template variables with source data sync
 	variables := m.buildTemplateVariables(task, request, sourceData)
 
+	// Render the definition-of-done checklist of the task type into index.md
+	checklist, err := m.loadChecklist(ctx, task.Type)
+	if err != nil {
+		m.logger.Warn("failed to load checklist", "type", task.Type, "error", err)
+	}
+	if checklist != nil {
+		variables["CHECKLIST"] = checklist.Markdown()
+	}
+
 	// Generate files
 	files := map[string]string{
 		"index.md":      "index.md",
@@ -874,6 +1717,13 @@ func (m *Manager) generateTaskFiles(ctx context.Context, task *Task, request *Cr
 		}
 	}
 
+	if checklist != nil {
+		if err := setManifestValue(task.ManifestPath, "checklist", checklist); err != nil {
+			return fmt.Errorf("failed to record checklist: %w", err)
+		}
+	}
+
+	m.recordRevision(task)
 	return nil
 }
 
@@ -891,13 +1741,14 @@ func (m *Manager) buildTemplateVariables(task *Task, request *CreateTaskRequest,
 		"PRIORITY":    task.Priority,
 
 		// Ownership and team
-		"OWNER_NAME":      task.Owner,
-		"OWNER_EMAIL":     fmt.Sprintf("%s@company.com", strings.ReplaceAll(task.Owner, " ", ".")),
-		"GITHUB_USERNAME": strings.ToLower(strings.ReplaceAll(task.Owner, " ", "")),
+		"OWNER_NAME":      m.identity().Name(task.Owner),
+		"OWNER_EMAIL":     m.identity().Email(task.Owner),
+		"GITHUB_USERNAME": m.identity().Account(task.Owner, "github"),
 		"TEAM_NAME":       task.Team,
 
 		// Dates
 		"CREATED_DATE": now.Format("2006-01-02"),
+		"CREATED_AT":   now.Format(time.RFC3339),
 		"LAST_UPDATED": now.Format("2006-01-02 15:04:05"),
 		"TARGET_DATE":  now.AddDate(0, 0, 14).Format("2006-01-02"),
 
@@ -915,7 +1766,7 @@ func (m *Manager) buildTemplateVariables(task *Task, request *CreateTaskRequest,
 		"EXTERNAL_SYSTEM":    "",
 
 		// Labels and organization
-		"LABELS": []string{task.Type},
+		"LABELS": taskLabels(task),
 		"TAGS":   []string{task.Type, task.Team},
 	}
 
@@ -934,6 +1785,15 @@ func (m *Manager) buildTemplateVariables(task *Task, request *CreateTaskRequest,
 	return variables
 }
 
+// taskLabels returns the labels of a new task's manifest: those it was created with,
+// or its type
+func taskLabels(task *Task) []string {
+	if task.Labels != nil {
+		return task.Labels
+	}
+	return []string{task.Type}
+}
+
 // syncDataToTemplateVariables syncs external source data to template variables
 func (m *Manager) syncDataToTemplateVariables(variables map[string]interface{}, sourceData *TaskData, source string) {
 	// Set integration flags
@@ -956,8 +1816,8 @@ func (m *Manager) syncDataToTemplateVariables(variables map[string]interface{},
 	}
 	if sourceData.Assignee != "" {
 		variables["OWNER_NAME"] = sourceData.Assignee
-		variables["OWNER_EMAIL"] = m.extractEmailFromAssignee(sourceData.Assignee)
-		variables["GITHUB_USERNAME"] = m.extractUsernameFromAssignee(sourceData.Assignee)
+		variables["OWNER_EMAIL"] = m.identity().Email(sourceData.Assignee)
+		variables["GITHUB_USERNAME"] = m.identity().Account(sourceData.Assignee, "github")
 	}
 	if sourceData.Team != "" {
 		variables["TEAM_NAME"] = sourceData.Team
@@ -1002,6 +1862,10 @@ func (m *Manager) syncSourceSpecificData(variables map[string]interface{}, rawDa
 		m.syncGitHubSpecificData(variables, rawData)
 	case "linear":
 		m.syncLinearSpecificData(variables, rawData)
+	case "notion":
+		m.syncNotionSpecificData(variables, rawData)
+	case "servicenow":
+		m.syncServiceNowSpecificData(variables, rawData)
 	}
 }
 
@@ -1055,15 +1919,3 @@ func (m *Manager) mapExternalPriorityToZenPriority(externalPriority, source stri
 		return "P2"
 	}
 }
-
-func (m *Manager) extractEmailFromAssignee(assignee string) string {
-	if strings.Contains(assignee, "@") {
-		return assignee
-	}
-	username := strings.ToLower(strings.ReplaceAll(assignee, " ", "."))
-	return fmt.Sprintf("%s@company.com", username)
-}
-
-func (m *Manager) extractUsernameFromAssignee(assignee string) string {
-	return strings.ToLower(strings.ReplaceAll(assignee, " ", ""))
-}
@@ -0,0 +1,243 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/identity"
+	"github.com/daddia/zen/pkg/label"
+	"github.com/daddia/zen/pkg/policy"
+	"github.com/daddia/zen/pkg/team"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestManager returns a manager for a temporary workspace holding a task PROJ-1
+func newTestManager(t *testing.T) (*Manager, string) {
+	t.Helper()
+	f := zentest.NewFactory(t).Build()
+
+	tasksDir := filepath.Join(f.Workspace.Root(), ".zen", "work", "tasks")
+	require.NoError(t, os.MkdirAll(filepath.Join(tasksDir, "PROJ-1"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tasksDir, "PROJ-1", "manifest.yaml"), []byte(`task:
+  id: PROJ-1
+  title: Add login page # shown in lists
+  status: proposed
+  priority: P2
+owner:
+  name: alice
+workflow:
+  current_stage: 01-align
+`), 0644))
+
+	return NewManager(f.Factory), tasksDir
+}
+
+func TestManagerUpdateTask(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	title, status := "Add SSO login page", "in_progress"
+
+	task, err := m.UpdateTask(context.Background(), "PROJ-1", &TaskUpdates{Title: &title, Status: &status})
+	require.NoError(t, err)
+	assert.Equal(t, "Add SSO login page", task.Title)
+	assert.Equal(t, "in_progress", task.Status)
+	assert.Equal(t, "P2", task.Priority, "fields not updated are kept")
+	assert.Equal(t, "alice", task.Owner)
+
+	data, err := os.ReadFile(filepath.Join(tasksDir, "PROJ-1", "manifest.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# shown in lists")
+}
+
+func TestManagerUpdateTask_Errors(t *testing.T) {
+	m, _ := newTestManager(t)
+	empty, description := " ", "Users sign in with SSO"
+
+	tests := []struct {
+		name    string
+		taskID  string
+		updates *TaskUpdates
+		code    types.ErrorCode
+	}{
+		{name: "not found", taskID: "PROJ-2", updates: &TaskUpdates{}, code: types.ErrorCodeNotFound},
+		{name: "path", taskID: "../tasks/PROJ-1", updates: &TaskUpdates{}, code: types.ErrorCodeNotFound},
+		{name: "empty title", taskID: "PROJ-1", updates: &TaskUpdates{Title: &empty}, code: types.ErrorCodeInvalidInput},
+		{name: "unsupported field", taskID: "PROJ-1", updates: &TaskUpdates{Description: &description}, code: types.ErrorCodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.UpdateTask(context.Background(), tt.taskID, tt.updates)
+			var zenErr *types.Error
+			require.True(t, errors.As(err, &zenErr), "got %v", err)
+			assert.Equal(t, tt.code, zenErr.Code)
+		})
+	}
+}
+
+func TestManagerUpdateTask_TeamMembers(t *testing.T) {
+	m, _ := newTestManager(t)
+	m.identities = identity.New(identity.DefaultConfig())
+	m.teams = &team.Config{Teams: map[string]team.Team{
+		"web": {Members: []team.Member{{Name: "alice", Role: "lead"}}},
+	}}
+	web, bob := "web", "bob"
+
+	_, err := m.UpdateTask(context.Background(), "PROJ-1", &TaskUpdates{Team: &web, Owner: &bob})
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, "bob is not a member of team web", zenErr.Details)
+
+	task, err := m.UpdateTask(context.Background(), "PROJ-1", &TaskUpdates{Team: &web})
+	require.NoError(t, err)
+	assert.Equal(t, "web", task.Team)
+}
+
+func TestManagerUpdateTask_Labels(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	m.labels = &label.Config{Allowed: map[string]label.Label{"frontend": {}, "bug": {}}}
+
+	task, err := m.UpdateTask(context.Background(), "PROJ-1", &TaskUpdates{Labels: []string{"Frontend", "bug"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "bug"}, task.Labels)
+
+	data, err := os.ReadFile(filepath.Join(tasksDir, "PROJ-1", "manifest.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# shown in lists")
+
+	_, err = m.UpdateTask(context.Background(), "PROJ-1", &TaskUpdates{Labels: []string{"urgent"}})
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, "unknown labels: urgent (allowed: bug, frontend)", zenErr.Details)
+}
+
+func TestManagerCreateTask_Labels(t *testing.T) {
+	m, _ := newTestManager(t)
+	m.labels = &label.Config{Allowed: map[string]label.Label{"frontend": {}}}
+	ctx := context.Background()
+
+	task, err := m.CreateTask(ctx, &CreateTaskRequest{ID: "PROJ-2", Title: "Add signup page", Labels: []string{"FRONTEND"}})
+	require.NoError(t, err)
+	task, err = m.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, task.Labels)
+
+	task, err = m.CreateTask(ctx, &CreateTaskRequest{ID: "PROJ-3", Title: "Add settings page", Type: "bug"})
+	require.NoError(t, err)
+	task, err = m.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bug"}, task.Labels, "labelled with the type by default")
+
+	_, err = m.CreateTask(ctx, &CreateTaskRequest{ID: "PROJ-4", Title: "Add help page", Labels: []string{"backend"}})
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+	assert.False(t, m.taskExists("PROJ-4"))
+}
+
+func TestManagerDeleteTask_Policy(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+	m.enforcer = policy.NewEnforcer(policy.Config{
+		Rules: []policy.Rule{{Operation: policy.OpTaskDelete, Roles: []string{"lead"}}},
+	}, policy.Actor{User: "alice", Roles: []string{"member"}}, auditLog)
+
+	err := m.DeleteTask(context.Background(), "PROJ-1")
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, types.ErrorCodePermissionDenied, zenErr.Code)
+	assert.DirExists(t, filepath.Join(tasksDir, "PROJ-1"))
+
+	require.NoError(t, m.DeleteTask(policy.WithConfirm(context.Background()), "PROJ-1"))
+	assert.NoDirExists(t, filepath.Join(tasksDir, "PROJ-1"))
+
+	data, err := os.ReadFile(auditLog)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"operation":"task.delete","target":"PROJ-1","action":"override"`)
+}
+
+func TestManagerDeleteTask(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+
+	err := m.DeleteTask(context.Background(), "..")
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr))
+	assert.Equal(t, types.ErrorCodeNotFound, zenErr.Code)
+	assert.DirExists(t, tasksDir)
+
+	require.NoError(t, m.DeleteTask(context.Background(), "PROJ-1"))
+	assert.NoDirExists(t, filepath.Join(tasksDir, "PROJ-1"))
+
+	err = m.DeleteTask(context.Background(), "PROJ-1")
+	require.True(t, errors.As(err, &zenErr))
+	assert.Equal(t, types.ErrorCodeNotFound, zenErr.Code)
+}
+
+func TestManagerProgressTask_RecordsStageTimes(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, m.ProgressTask(ctx, "PROJ-1", ""))
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "02-discover", task.CurrentStage)
+	assert.Equal(t, "in_progress", task.Status)
+	require.Contains(t, task.Stages, "01-align")
+	require.Contains(t, task.Stages, "02-discover")
+	assert.NotNil(t, task.Stages["01-align"].Completed, "the stage left is completed")
+	assert.NotNil(t, task.Stages["02-discover"].Started)
+	assert.Nil(t, task.Stages["02-discover"].Completed)
+
+	require.NoError(t, m.ProgressTask(ctx, "PROJ-1", "05-build"))
+	require.NoError(t, m.ProgressTask(ctx, "PROJ-1", "02-discover"))
+	task, err = m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.NotNil(t, task.Stages["05-build"].Completed)
+	assert.Nil(t, task.Stages["02-discover"].Completed, "a stage entered again is no longer completed")
+	assert.NotContains(t, task.Stages, "03-prioritize", "skipped stages have no times")
+}
+
+func TestManagerBuildTemplateVariables_Identity(t *testing.T) {
+	m, _ := newTestManager(t)
+	m.identities = identity.New(identity.Config{Users: map[string]identity.User{
+		"alice": {Name: "Alice Chen", Email: "alice@example.com", Accounts: map[string]string{"github": "achen"}},
+	}})
+
+	variables := m.buildTemplateVariables(&Task{ID: "PROJ-2", Owner: "alice"}, &CreateTaskRequest{}, nil)
+	assert.Equal(t, "Alice Chen", variables["OWNER_NAME"])
+	assert.Equal(t, "alice@example.com", variables["OWNER_EMAIL"])
+	assert.Equal(t, "achen", variables["GITHUB_USERNAME"])
+
+	variables = m.buildTemplateVariables(&Task{ID: "PROJ-3", Owner: "bob"}, &CreateTaskRequest{}, nil)
+	assert.Equal(t, "bob", variables["OWNER_NAME"])
+	assert.Equal(t, "", variables["OWNER_EMAIL"], "unknown users get no made-up email")
+	assert.Equal(t, "", variables["GITHUB_USERNAME"])
+}
+
+func TestManagerSyncAllTasks_Cancelled(t *testing.T) {
+	m, tracker := newOutboxTestManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := m.SyncAllTasks(ctx, &SyncOptions{Direction: SyncDirectionPush})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, results, 1)
+	assert.Equal(t, "PROJ-1", results[0].TaskID)
+	assert.True(t, results[0].Cancelled)
+	assert.Empty(t, tracker.updates, "no task is synced once cancelled")
+
+	results, err = m.SyncAllTasks(context.Background(), &SyncOptions{Direction: SyncDirectionPush, TaskIDs: []string{"PROJ-2"}})
+	require.NoError(t, err)
+	assert.Empty(t, results, "only the tasks listed are synced")
+
+	results, err = m.SyncAllTasks(context.Background(), &SyncOptions{Direction: SyncDirectionPush, TaskIDs: []string{"PROJ-1"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Success)
+	assert.Len(t, tracker.updates, 1)
+}
@@ -0,0 +1,358 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WorkflowStages lists the seven Zenflow stages in order
+var WorkflowStages = []string{
+	"01-align",
+	"02-discover",
+	"03-prioritize",
+	"04-design",
+	"05-build",
+	"06-ship",
+	"07-learn",
+}
+
+// manifestDocument mirrors the subset of manifest.yaml that the manager reads
+type manifestDocument struct {
+	Task struct {
+		ID       string `yaml:"id"`
+		Title    string `yaml:"title"`
+		Type     string `yaml:"type"`
+		Status   string `yaml:"status"`
+		Priority string `yaml:"priority"`
+	} `yaml:"task"`
+	Owner struct {
+		Name string `yaml:"name"`
+	} `yaml:"owner"`
+	Team struct {
+		Name string `yaml:"name"`
+	} `yaml:"team"`
+	Dates struct {
+		Created string `yaml:"created"`
+	} `yaml:"dates"`
+	Workflow struct {
+		CurrentStage    string   `yaml:"current_stage"`
+		CompletedStages []string `yaml:"completed_stages"`
+		Stages          map[string]struct {
+			Started   string   `yaml:"started"`
+			Completed string   `yaml:"completed"`
+			Artifacts []string `yaml:"artifacts"`
+		} `yaml:"stages"`
+	} `yaml:"workflow"`
+	Git struct {
+		Branch     string `yaml:"branch"`
+		Base       string `yaml:"base"`
+		Worktree   string `yaml:"worktree"`
+		Remote     string `yaml:"remote"`
+		StartedAt  string `yaml:"started_at"`
+		FinishedAt string `yaml:"finished_at"`
+	} `yaml:"git"`
+	Labels       []string                            `yaml:"labels"`
+	Attachments  []Attachment                        `yaml:"attachments"`
+	Published    map[string]map[string]PublishedPage `yaml:"published"`
+	QualityGates map[string]QualityGate              `yaml:"quality_gates"`
+	Checklist    *Checklist                          `yaml:"checklist"`
+}
+
+// StageTimes are when a task entered and left a workflow stage. A stage entered more
+// than once keeps the times of its last visit.
+type StageTimes struct {
+	Started   *time.Time `json:"started,omitempty" yaml:"started,omitempty"`
+	Completed *time.Time `json:"completed,omitempty" yaml:"completed,omitempty"`
+}
+
+// StageIndex returns the zero-based position of a stage, or -1 if unknown
+func StageIndex(stage string) int {
+	for i, s := range WorkflowStages {
+		if s == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+// StageName returns the display name for a stage ID (e.g. "01-align" -> "Align")
+func StageName(stage string) string {
+	name := stage
+	if idx := strings.Index(stage, "-"); idx >= 0 {
+		name = stage[idx+1:]
+	}
+	if name == "" {
+		return stage
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// NextStage returns the stage following the given one
+func NextStage(stage string) (string, error) {
+	idx := StageIndex(stage)
+	if idx < 0 {
+		return "", fmt.Errorf("unknown workflow stage: %s", stage)
+	}
+	if idx == len(WorkflowStages)-1 {
+		return "", fmt.Errorf("task is already in the final stage: %s", stage)
+	}
+	return WorkflowStages[idx+1], nil
+}
+
+// stageProgress converts a stage position into a 0-100 progress value
+func stageProgress(stage string) int {
+	idx := StageIndex(stage)
+	if idx < 0 {
+		return 0
+	}
+	return idx * 100 / len(WorkflowStages)
+}
+
+// readManifest parses a task manifest file
+func readManifest(path string) (*manifestDocument, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - reading task manifest from workspace path
+	if err != nil {
+		return nil, err
+	}
+
+	var doc manifestDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// updateManifestFields sets scalar values in a manifest while preserving its comments and layout.
+// Keys are dotted paths such as "workflow.current_stage".
+func updateManifestFields(path string, fields map[string]string) error {
+	return editManifest(path, func(root *yaml.Node) error {
+		for key, value := range fields {
+			setNodeValue(root, strings.Split(key, "."), value)
+		}
+		return nil
+	})
+}
+
+// setManifestValue sets a key of a manifest, a dotted path such as
+// "workflow.stages.04-design.artifacts", to value, which may be a list or mapping, while
+// preserving the comments and layout of the other keys
+func setManifestValue(path, key string, value interface{}) error {
+	return editManifest(path, func(root *yaml.Node) error {
+		var node yaml.Node
+		if err := node.Encode(value); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+
+		keys := strings.Split(key, ".")
+		parent := root
+		for _, name := range keys[:len(keys)-1] {
+			child := mappingValue(parent, name)
+			if child == nil || child.Kind != yaml.MappingNode {
+				child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				setMappingValue(parent, name, child)
+			}
+			parent = child
+		}
+		setMappingValue(parent, keys[len(keys)-1], &node)
+		return nil
+	})
+}
+
+// mappingValue returns the value of key in a mapping node, or nil when it has none
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets the value of key in a mapping node, adding the key when needed
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// editManifest applies edit to the top-level mapping of a manifest and writes it back
+func editManifest(path string, edit func(root *yaml.Node) error) error {
+	data, err := os.ReadFile(path) // #nosec G304 - reading task manifest from workspace path
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return fmt.Errorf("manifest is empty: %s", path)
+	}
+
+	if err := edit(root.Content[0]); err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(&root)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, out, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+
+	// The history is best effort: the manifest is written whether it is recorded or not
+	if filepath.Base(path) == "manifest.yaml" {
+		_ = recordRevision(filepath.Dir(path))
+	}
+
+	return nil
+}
+
+// setNodeValue walks a mapping node and sets the scalar at the given path, creating keys as needed
+func setNodeValue(node *yaml.Node, path []string, value string) {
+	if node.Kind != yaml.MappingNode || len(path) == 0 {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != path[0] {
+			continue
+		}
+		child := node.Content[i+1]
+		if len(path) == 1 {
+			child.Kind = yaml.ScalarNode
+			child.Tag = "!!str"
+			child.Value = value
+			child.Content = nil
+			return
+		}
+		setNodeValue(child, path[1:], value)
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}
+	if len(path) == 1 {
+		node.Content = append(node.Content, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+		return
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	node.Content = append(node.Content, key, child)
+	setNodeValue(child, path[1:], value)
+}
+
+// applyTo copies manifest values onto a task
+func (doc *manifestDocument) applyTo(task *Task) {
+	if doc.Task.Title != "" {
+		task.Title = doc.Task.Title
+	}
+	task.Type = doc.Task.Type
+	task.Status = doc.Task.Status
+	task.Priority = doc.Task.Priority
+	task.Owner = doc.Owner.Name
+	task.Team = doc.Team.Name
+	task.Labels = doc.Labels
+	task.CurrentStage = doc.Workflow.CurrentStage
+	task.Progress = stageProgress(doc.Workflow.CurrentStage)
+	if created := parseManifestDate(doc.Dates.Created); created != nil {
+		task.Created = *created
+	}
+
+	for stage, times := range doc.Workflow.Stages {
+		started, completed := parseManifestTime(times.Started), parseManifestTime(times.Completed)
+		if started == nil && completed == nil {
+			continue
+		}
+		if task.Stages == nil {
+			task.Stages = map[string]*StageTimes{}
+		}
+		task.Stages[stage] = &StageTimes{Started: started, Completed: completed}
+	}
+
+	task.Attachments = doc.Attachments
+	task.Published = doc.Published
+
+	if doc.Git.Branch != "" {
+		task.Git = &GitInfo{
+			Branch:     doc.Git.Branch,
+			Base:       doc.Git.Base,
+			Worktree:   doc.Git.Worktree,
+			Remote:     doc.Git.Remote,
+			StartedAt:  parseManifestTime(doc.Git.StartedAt),
+			FinishedAt: parseManifestTime(doc.Git.FinishedAt),
+		}
+	}
+}
+
+// parseManifestTime parses an RFC 3339 manifest timestamp, returning nil when unset or invalid
+func parseManifestTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// parseManifestDate parses a manifest date, either RFC 3339 or a plain 2006-01-02 date,
+// returning nil when unset or invalid
+func parseManifestDate(value string) *time.Time {
+	if t := parseManifestTime(value); t != nil {
+		return t
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// stageTransitionFields returns the manifest fields recording that task moves to stage
+// at now: the stage it leaves is completed and the one it enters is started
+func stageTransitionFields(task *Task, stage string, now time.Time) map[string]string {
+	timestamp := now.Format(time.RFC3339)
+	fields := map[string]string{
+		"workflow.stages." + stage + ".status":  "in_progress",
+		"workflow.stages." + stage + ".started": timestamp,
+	}
+	if times := task.Stages[stage]; times != nil && times.Completed != nil {
+		// Re-entering a stage, which is no longer completed
+		fields["workflow.stages."+stage+".completed"] = ""
+	}
+	if from := task.CurrentStage; from != stage && StageIndex(from) >= 0 {
+		fields["workflow.stages."+from+".status"] = "completed"
+		fields["workflow.stages."+from+".completed"] = timestamp
+	}
+	return fields
+}
+
+// tasksDirectory returns the directory that holds all task folders
+func (m *Manager) tasksDirectory() (string, error) {
+	ws, err := m.factory.WorkspaceManager()
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+
+	status, err := ws.Status()
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace status: %w", err)
+	}
+
+	return filepath.Join(status.Root, ".zen", "work", "tasks"), nil
+}
@@ -0,0 +1,94 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStageName(t *testing.T) {
+	assert.Equal(t, "Align", StageName("01-align"))
+	assert.Equal(t, "Prioritize", StageName("03-prioritize"))
+	assert.Equal(t, "Custom", StageName("custom"))
+	assert.Equal(t, "", StageName(""))
+}
+
+func TestNextStage(t *testing.T) {
+	next, err := NextStage("01-align")
+	require.NoError(t, err)
+	assert.Equal(t, "02-discover", next)
+
+	_, err = NextStage("07-learn")
+	assert.Error(t, err)
+
+	_, err = NextStage("unknown")
+	assert.Error(t, err)
+}
+
+func TestUpdateManifestFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	content := `# Task manifest
+task:
+  id: PROJ-1
+  status: proposed # initial status
+workflow:
+  current_stage: 01-align
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	err := updateManifestFields(path, map[string]string{
+		"task.status":            "in_progress",
+		"workflow.current_stage": "02-discover",
+		"dates.last_updated":     "2025-01-01",
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Task manifest")
+	assert.Contains(t, string(data), "# initial status")
+
+	doc, err := readManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, "in_progress", doc.Task.Status)
+	assert.Equal(t, "02-discover", doc.Workflow.CurrentStage)
+	assert.Contains(t, string(data), "last_updated: \"2025-01-01\"")
+}
+
+func TestTaskFilter_Matches(t *testing.T) {
+	task := &Task{
+		ID:           "PROJ-1",
+		Type:         "story",
+		Status:       "in_progress",
+		Owner:        "Alex",
+		CurrentStage: "04-design",
+		Labels:       []string{"frontend", "q3"},
+		Sources:      map[string]*TaskSource{"jira": {}},
+	}
+
+	tests := []struct {
+		name   string
+		filter *TaskFilter
+		want   bool
+	}{
+		{"nil filter", nil, true},
+		{"empty filter", &TaskFilter{}, true},
+		{"status match", &TaskFilter{Status: "IN_PROGRESS"}, true},
+		{"status mismatch", &TaskFilter{Status: "done"}, false},
+		{"owner case-insensitive", &TaskFilter{Owner: "alex"}, true},
+		{"stage by name", &TaskFilter{Stage: "design"}, true},
+		{"labels all present", &TaskFilter{Labels: []string{"frontend", "q3"}}, true},
+		{"label missing", &TaskFilter{Labels: []string{"backend"}}, false},
+		{"source present", &TaskFilter{Sources: []string{"jira"}}, true},
+		{"source missing", &TaskFilter{Sources: []string{"github"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(task))
+		})
+	}
+}
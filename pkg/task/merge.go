@@ -0,0 +1,438 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/internal/integration"
+)
+
+// SyncSnapshot is the state of a task's synced fields when it was last synced with a
+// source. It is the common base of three-way merges, so a field changed on only one side
+// since then is taken from that side without a conflict.
+type SyncSnapshot struct {
+	Title       string    `json:"title" yaml:"title"`
+	Description string    `json:"description" yaml:"description"`
+	Status      string    `json:"status" yaml:"status"`
+	Priority    string    `json:"priority" yaml:"priority"`
+	Owner       string    `json:"owner" yaml:"owner"`
+	Labels      []string  `json:"labels" yaml:"labels"`
+	SyncedAt    time.Time `json:"synced_at" yaml:"synced_at"`
+}
+
+// mergeResult is the outcome of a three-way merge
+type mergeResult struct {
+	// Merged holds the value of every synced field once the merge is applied
+	Merged *SyncSnapshot
+
+	// LocalChanges and RemoteChanges are the fields that must be updated on each side
+	LocalChanges  []string
+	RemoteChanges []string
+
+	// Conflicts are the fields changed on both sides, resolved by their policy
+	Conflicts []Conflict
+
+	// Decisions explain how each field was compared and settled
+	Decisions []SyncDecision
+}
+
+// explain records a decision about field
+func (r *mergeResult) explain(step, field, format string, args ...interface{}) {
+	r.Decisions = append(r.Decisions, SyncDecision{Step: step, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// manualConflicts returns the number of conflicts left for manual review
+func (r *mergeResult) manualConflicts() int {
+	count := 0
+	for _, conflict := range r.Conflicts {
+		if conflict.Resolution == integration.ResolutionManual {
+			count++
+		}
+	}
+	return count
+}
+
+// pendingConflicts returns the conflicts left for manual review
+func (r *mergeResult) pendingConflicts() []Conflict {
+	var pending []Conflict
+	for _, conflict := range r.Conflicts {
+		if conflict.Resolution == integration.ResolutionManual {
+			pending = append(pending, conflict)
+		}
+	}
+	return pending
+}
+
+// snapshotOf records the synced fields of a task
+func snapshotOf(task *Task) *SyncSnapshot {
+	return &SyncSnapshot{
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		Owner:       task.Owner,
+		Labels:      task.Labels,
+		SyncedAt:    time.Now(),
+	}
+}
+
+// mergeThreeWay merges the synced fields of a task and its source against base, the
+// snapshot of their last sync. A field changed on one side only takes that side's value,
+// and labels added or removed on either side are merged. Fields changed on both sides,
+// or every differing field when there is no base, are conflicts resolved with
+// opts.FieldPolicies and opts.ConflictStrategy. An empty value on either side counts as
+// unchanged, since sync never clears a field.
+func mergeThreeWay(base *SyncSnapshot, task *Task, remote *TaskData, opts *SyncOptions) (*mergeResult, error) {
+	if err := ValidateFieldPolicies(opts.FieldPolicies); err != nil {
+		return nil, fmt.Errorf("invalid field policies: %w", err)
+	}
+	fallback := integration.ConflictStrategy(opts.ConflictStrategy)
+
+	var baseline SyncSnapshot
+	if base != nil {
+		baseline = *base
+	}
+
+	result := &mergeResult{Merged: &SyncSnapshot{SyncedAt: time.Now()}}
+
+	scalars := []struct {
+		name                string
+		base, local, remote string
+		merged              *string
+	}{
+		{"title", baseline.Title, task.Title, remote.Title, &result.Merged.Title},
+		{"description", baseline.Description, task.Description, remote.Description, &result.Merged.Description},
+		{"status", baseline.Status, task.Status, remote.Status, &result.Merged.Status},
+		{"priority", baseline.Priority, task.Priority, remote.Priority, &result.Merged.Priority},
+		{"owner", baseline.Owner, task.Owner, remote.Owner, &result.Merged.Owner},
+	}
+
+	for _, field := range scalars {
+		local, theirs := field.local, field.remote
+		if local == "" {
+			local = field.base
+		}
+		if theirs == "" {
+			theirs = field.base
+		}
+		if local == "" {
+			local = theirs
+		}
+		if theirs == "" {
+			theirs = local
+		}
+
+		if field.local == "" && local != "" {
+			result.explain(DecisionCompare, field.name, "empty locally, which counts as unchanged")
+		}
+		if field.remote == "" && theirs != "" {
+			result.explain(DecisionCompare, field.name, "empty remotely, which counts as unchanged")
+		}
+
+		var value interface{}
+		switch {
+		case local == theirs:
+			value = local
+			result.explain(DecisionCompare, field.name, "same on both sides: %s", describeValue(local))
+		case base != nil && local == field.base:
+			value = theirs
+			result.explain(DecisionCompare, field.name, "changed remotely since the last sync, from %s to %s; the remote value is taken",
+				describeValue(field.base), describeValue(theirs))
+		case base != nil && theirs == field.base:
+			value = local
+			result.explain(DecisionCompare, field.name, "changed locally since the last sync, from %s to %s; the local value is kept",
+				describeValue(field.base), describeValue(local))
+		default:
+			result.explainConflict(base, field.name, local, theirs)
+			resolved, err := result.resolve(field.name, local, theirs, task, remote, result.explainPolicy(opts.FieldPolicies, field.name, fallback))
+			if err != nil {
+				return nil, err
+			}
+			value = resolved
+		}
+
+		*field.merged = fmt.Sprint(value)
+		result.track(field.name, *field.merged, local, theirs)
+	}
+
+	labels, err := result.mergeLabels(base, task, remote, opts.FieldPolicies, fallback)
+	if err != nil {
+		return nil, err
+	}
+	result.Merged.Labels = labels
+
+	sort.Strings(result.LocalChanges)
+	sort.Strings(result.RemoteChanges)
+	return result, nil
+}
+
+// mergeLabels merges the labels of both sides. With a base, labels added on either side
+// are kept and labels removed on either side are dropped; without one, differing labels
+// are a conflict.
+func (r *mergeResult) mergeLabels(base *SyncSnapshot, task *Task, remote *TaskData, policies map[string]ConflictStrategy, fallback integration.ConflictStrategy) ([]string, error) {
+	local, theirs := task.Labels, remote.Labels
+	if base != nil {
+		if len(local) == 0 {
+			local = base.Labels
+		}
+		if len(theirs) == 0 {
+			theirs = base.Labels
+		}
+	}
+	if len(local) == 0 {
+		local = theirs
+	}
+	if len(theirs) == 0 {
+		theirs = local
+	}
+
+	var merged []string
+	switch {
+	case sameValue(local, theirs):
+		merged = local
+		r.explain(DecisionCompare, "labels", "same on both sides: %s", describeValue(local))
+	case base == nil:
+		r.explainConflict(base, "labels", local, theirs)
+		resolved, err := r.resolve("labels", local, theirs, task, remote, r.explainPolicy(policies, "labels", fallback))
+		if err != nil {
+			return nil, err
+		}
+		merged = toLabels(resolved)
+	default:
+		merged = mergeLists(base.Labels, local, theirs)
+		r.explain(DecisionCompare, "labels", "changed since the last sync %s: local %s, remote %s; the labels added and removed on both sides make %s",
+			describeValue(base.Labels), describeValue(local), describeValue(theirs), describeValue(merged))
+	}
+
+	if !sameValue(merged, local) {
+		r.LocalChanges = append(r.LocalChanges, "labels")
+	}
+	if !sameValue(merged, theirs) {
+		r.RemoteChanges = append(r.RemoteChanges, "labels")
+	}
+	return merged, nil
+}
+
+// resolve settles a field changed on both sides with strategy and records the conflict
+func (r *mergeResult) resolve(field string, local, remote interface{}, task *Task, remoteData *TaskData, strategy integration.ConflictStrategy) (interface{}, error) {
+	fc := integration.FieldConflict{
+		Field:             field,
+		ZenValue:          local,
+		ExternalValue:     remote,
+		ZenTimestamp:      task.Updated,
+		ExternalTimestamp: remoteData.Updated,
+	}
+	value, err := integration.ResolveConflict(&fc, strategy)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", field, err)
+	}
+	r.explain(DecisionConflict, field, "%s", explainResolution(&fc, strategy, value))
+
+	r.Conflicts = append(r.Conflicts, Conflict{
+		Field:       field,
+		LocalValue:  local,
+		RemoteValue: remote,
+		LocalTime:   task.Updated,
+		RemoteTime:  remoteData.Updated,
+		Resolution:  fc.Resolution,
+		Strategy:    ConflictStrategy(strategy),
+		Value:       value,
+	})
+	return value, nil
+}
+
+// explainConflict records why field is a conflict
+func (r *mergeResult) explainConflict(base *SyncSnapshot, field string, local, remote interface{}) {
+	if base == nil {
+		r.explain(DecisionCompare, field, "differs with no earlier sync to compare with: local %s, remote %s",
+			describeValue(local), describeValue(remote))
+		return
+	}
+	r.explain(DecisionCompare, field, "changed on both sides since the last sync: local %s, remote %s",
+		describeValue(local), describeValue(remote))
+}
+
+// explainPolicy records and returns the strategy that settles a conflicting field: its
+// field policy, or fallback
+func (r *mergeResult) explainPolicy(policies map[string]ConflictStrategy, field string, fallback integration.ConflictStrategy) integration.ConflictStrategy {
+	if policy, ok := policies[field]; ok && policy != "" {
+		r.explain(DecisionConflict, field, "settled by the %s field policy", policy)
+		return integration.ConflictStrategy(policy)
+	}
+	r.explain(DecisionConflict, field, "settled by the %s conflict strategy, as it has no field policy", fallback)
+	return fallback
+}
+
+// track records which sides need a scalar field updated to reach its merged value. Sides
+// without a value are compared by the value they stand in for.
+func (r *mergeResult) track(field, merged, local, remote string) {
+	if merged != local {
+		r.LocalChanges = append(r.LocalChanges, field)
+	}
+	if merged != remote {
+		r.RemoteChanges = append(r.RemoteChanges, field)
+	}
+}
+
+// mergeLists applies the values added and removed on each side since base. Local values
+// come first, in their order, and values differing only in case are the same value.
+func mergeLists(base, local, remote []string) []string {
+	inBase := map[string]bool{}
+	for _, value := range base {
+		inBase[strings.ToLower(value)] = true
+	}
+	removed := map[string]bool{}
+	for _, side := range [][]string{local, remote} {
+		present := map[string]bool{}
+		for _, value := range side {
+			present[strings.ToLower(value)] = true
+		}
+		for key := range inBase {
+			if !present[key] {
+				removed[key] = true
+			}
+		}
+	}
+
+	var merged []string
+	seen := map[string]bool{}
+	for _, side := range [][]string{local, remote} {
+		for _, value := range side {
+			key := strings.ToLower(value)
+			if seen[key] || removed[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, value)
+		}
+	}
+	return merged
+}
+
+// toLabels converts a resolved labels value back to a list
+func toLabels(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		labels := make([]string, 0, len(v))
+		for _, item := range v {
+			labels = append(labels, fmt.Sprint(item))
+		}
+		return labels
+	default:
+		return nil
+	}
+}
+
+// mergeWithSource syncs a task both ways with a three-way merge against the snapshot of
+// its last sync with source, using prefetched instead of fetching when it is set. The
+// merged fields are saved locally and pushed when the source lacks any of them. When
+// conflicts are left for manual review, nothing is changed unless opts.Force is set.
+func (m *Manager) mergeWithSource(ctx context.Context, task *Task, source string, opts *SyncOptions, prefetched *TaskData) (*SyncResult, error) {
+	start := time.Now()
+	result := &SyncResult{
+		TaskID:    task.ID,
+		Source:    source,
+		Direction: SyncDirectionBidirectional,
+	}
+	fail := func(err error) (*SyncResult, error) {
+		result.Success = false
+		result.Error = err.Error()
+		result.Duration = time.Since(start)
+		result.Timestamp = time.Now()
+		return result, err
+	}
+
+	taskSource, ok := task.Sources[source]
+	if !ok {
+		return nil, fmt.Errorf("task %s is not linked to source %s", task.ID, source)
+	}
+
+	remote := prefetched
+	if remote == nil {
+		var err error
+		remote, err = m.fetchFromSource(ctx, taskSource.ExternalID, source)
+		if err != nil {
+			return fail(fmt.Errorf("pull failed: failed to fetch from %s: %w", source, err))
+		}
+	}
+
+	merge, err := mergeThreeWay(taskSource.Snapshot, task, remote, opts)
+	if err != nil {
+		return fail(err)
+	}
+	explainAll(ctx, merge.Decisions)
+	result.Conflicts = merge.Conflicts
+
+	if manual := merge.manualConflicts(); manual > 0 && !opts.Force {
+		taskSource.PendingConflicts = merge.pendingConflicts()
+		if err := m.updateSourceMetadata(task.MetadataPath, source, taskSource); err != nil {
+			m.logger.Warn("failed to record pending conflicts", "task_id", task.ID, "source", source, "error", err)
+		}
+
+		result.Success = false
+		result.Error = fmt.Sprintf("%d conflicting field(s) need manual review; run with --force to keep the local values", manual)
+		result.Duration = time.Since(start)
+		result.Timestamp = time.Now()
+		return result, nil
+	}
+
+	merged := merge.Merged
+	task.Title = merged.Title
+	task.Description = merged.Description
+	task.Status = merged.Status
+	task.Priority = merged.Priority
+	task.Owner = merged.Owner
+	task.Labels = merged.Labels
+	explainMerge(ctx, merge, task, source)
+
+	// Snapshot the task before the merge changes it, so that it can be undone
+	if len(merge.LocalChanges) > 0 {
+		undoID, err := m.recordSyncUndo(task, source, SyncDirectionBidirectional)
+		if err != nil {
+			m.logger.Warn("failed to snapshot task before sync", "task_id", task.ID, "source", source, "error", err)
+		}
+		result.UndoID = undoID
+	}
+
+	if len(merge.RemoteChanges) > 0 {
+		if _, err := m.pushTask(ctx, task, source); err != nil {
+			return fail(fmt.Errorf("push failed: %w", err))
+		}
+	} else {
+		taskSource.LastSync = time.Now()
+		taskSource.Snapshot = merged
+		taskSource.PendingConflicts = nil
+		if err := m.saveTask(ctx, task); err != nil {
+			return fail(fmt.Errorf("failed to save task: %w", err))
+		}
+	}
+
+	result.Success = true
+	result.ChangedFields = mergeFields(merge.LocalChanges, merge.RemoteChanges)
+	result.Duration = time.Since(start)
+	result.Timestamp = time.Now()
+
+	m.logger.Info("task merged with source", "task_id", task.ID, "source", source,
+		"local_changes", merge.LocalChanges, "remote_changes", merge.RemoteChanges, "conflicts", len(merge.Conflicts))
+
+	return result, nil
+}
+
+// mergeFields returns the fields in either list, sorted and without duplicates
+func mergeFields(a, b []string) []string {
+	seen := map[string]bool{}
+	var fields []string
+	for _, field := range append(append([]string{}, a...), b...) {
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,117 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeThreeWay(t *testing.T) {
+	base := &SyncSnapshot{
+		Title:    "Add login",
+		Status:   "proposed",
+		Priority: "P2",
+		Owner:    "alice",
+		Labels:   []string{"backend", "auth"},
+	}
+	local := &Task{
+		Title:    "Add login",
+		Status:   "in_progress",
+		Priority: "P1",
+		Owner:    "alice",
+		Labels:   []string{"backend", "auth", "api"},
+	}
+	remote := &TaskData{
+		Title:    "Add SSO login",
+		Status:   "proposed",
+		Priority: "P3",
+		Owner:    "alice",
+		Labels:   []string{"backend"},
+	}
+
+	merge, err := mergeThreeWay(base, local, remote, &SyncOptions{
+		ConflictStrategy: ConflictStrategyManualReview,
+		FieldPolicies:    map[string]ConflictStrategy{"priority": ConflictStrategyRemoteWins},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Add SSO login", merge.Merged.Title, "remote change is taken")
+	assert.Equal(t, "in_progress", merge.Merged.Status, "local change is kept")
+	assert.Equal(t, "P3", merge.Merged.Priority, "change on both sides resolved by its policy")
+	assert.Equal(t, []string{"backend", "api"}, merge.Merged.Labels, "label added locally and removed remotely")
+
+	require.Len(t, merge.Conflicts, 1, "only fields changed on both sides conflict")
+	assert.Equal(t, "priority", merge.Conflicts[0].Field)
+	assert.Zero(t, merge.manualConflicts())
+
+	assert.Equal(t, []string{"labels", "priority", "title"}, merge.LocalChanges)
+	assert.Equal(t, []string{"labels", "status"}, merge.RemoteChanges)
+}
+
+func TestMergeThreeWay_WithoutBase(t *testing.T) {
+	now := time.Now()
+	local := &Task{Title: "Local", Status: "done", Updated: now.Add(-time.Hour)}
+	remote := &TaskData{Title: "Remote", Status: "done", Priority: "P1", Updated: now}
+
+	merge, err := mergeThreeWay(nil, local, remote, &SyncOptions{ConflictStrategy: ConflictStrategyManualReview})
+	require.NoError(t, err)
+
+	require.Len(t, merge.Conflicts, 1, "every differing field conflicts without a base")
+	assert.Equal(t, "title", merge.Conflicts[0].Field)
+	assert.Equal(t, 1, merge.manualConflicts())
+	assert.Equal(t, "Local", merge.Merged.Title, "manual review keeps the local value")
+	assert.Equal(t, "P1", merge.Merged.Priority, "a field only one side has is not a conflict")
+}
+
+func TestMergeLists(t *testing.T) {
+	assert.Equal(t, []string{"a", "c", "d"}, mergeLists([]string{"a", "b"}, []string{"a", "c"}, []string{"A", "b", "d"}))
+	assert.Nil(t, mergeLists([]string{"a"}, []string{}, []string{"a"}))
+}
+
+func TestSourceSnapshotRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	m := &Manager{}
+	snapshot := &SyncSnapshot{Title: "Add login", Status: "done", Labels: []string{"auth"}}
+
+	require.NoError(t, m.updateSourceMetadata(dir, "jira", &TaskSource{
+		ExternalID: "PROJ-1",
+		LastSync:   time.Now(),
+		Snapshot:   snapshot,
+	}))
+	_, err := os.Stat(filepath.Join(dir, "jira.json"))
+	require.NoError(t, err)
+
+	task := &Task{MetadataPath: dir, Sources: map[string]*TaskSource{}}
+	require.NoError(t, m.loadTaskSources(task))
+	require.NotNil(t, task.Sources["jira"].Snapshot)
+	assert.Equal(t, "Add login", task.Sources["jira"].Snapshot.Title)
+	assert.Equal(t, []string{"auth"}, task.Sources["jira"].Snapshot.Labels)
+}
+
+func TestSourcePendingConflictsRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	m := &Manager{}
+	source := &TaskSource{
+		ExternalID: "PROJ-1",
+		LastSync:   time.Now(),
+		PendingConflicts: []Conflict{
+			{Field: "title", LocalValue: "Local", RemoteValue: "Remote", Resolution: "manual"},
+		},
+	}
+
+	require.NoError(t, m.updateSourceMetadata(dir, "jira", source))
+	task := &Task{MetadataPath: dir, Sources: map[string]*TaskSource{}}
+	require.NoError(t, m.loadTaskSources(task))
+	require.Len(t, task.Sources["jira"].PendingConflicts, 1)
+	assert.Equal(t, "title", task.Sources["jira"].PendingConflicts[0].Field)
+
+	source.PendingConflicts = nil
+	require.NoError(t, m.updateSourceMetadata(dir, "jira", source))
+	task = &Task{MetadataPath: dir, Sources: map[string]*TaskSource{}}
+	require.NoError(t, m.loadTaskSources(task))
+	assert.Empty(t, task.Sources["jira"].PendingConflicts, "a successful sync clears pending conflicts")
+}
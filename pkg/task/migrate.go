@@ -0,0 +1,457 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// migrationHistoryLimit is the number of changes of an item written to the history
+// summary of its task; earlier changes are counted
+const migrationHistoryLimit = 50
+
+// migratedFields are the fields of Jira issues that tasks hold, and fields Jira derives
+// from others; the values of other fields are reported as unmapped
+var migratedFields = map[string]bool{
+	"summary": true, "description": true, "status": true, "priority": true, "issuetype": true,
+	"assignee": true, "reporter": true, "creator": true, "labels": true, "components": true,
+	"created": true, "updated": true, "project": true, "comment": true,
+	"statuscategorychangedate": true, "lastViewed": true, "watches": true, "votes": true,
+	"workratio": true, "issuerestriction": true, "progress": true, "aggregateprogress": true,
+	"aggregatetimespent": true, "aggregatetimeestimate": true, "aggregatetimeoriginalestimate": true,
+}
+
+// MigrationRequest contains parameters for migrating the items of an export to tasks
+type MigrationRequest struct {
+	// Source is the system the items were exported from, such as jira
+	Source string
+
+	// Query is the query the items were exported with, for the report
+	Query string
+
+	Export *plugin.Export
+
+	// Statuses and Types map the statuses and types of the source to those of tasks,
+	// over the defaults of MigrationMappings
+	Statuses map[string]string
+	Types    map[string]string
+
+	// NoLink creates tasks without sync records. Tasks are not linked to the source
+	// when tasks are tracked locally either.
+	NoLink bool
+
+	// DryRun reports the tasks a migration would create without creating them
+	DryRun bool
+}
+
+// MigrationMapping maps a status or type of a source to that of tasks
+type MigrationMapping struct {
+	External string `json:"external"`
+	Zen      string `json:"zen"`
+
+	// Tasks is the number of items of the export with the status or type
+	Tasks int `json:"tasks"`
+}
+
+// MigrationResult describes the task of an item of an export; its status is one of
+// the import statuses
+type MigrationResult struct {
+	ExternalID string `json:"external_id"`
+	TaskID     string `json:"task_id"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// UnmappedField is a field the items of an export have values in that tasks do not hold
+type UnmappedField struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Tasks int    `json:"tasks"`
+}
+
+// MigrationReport describes a migration
+type MigrationReport struct {
+	Source string    `json:"source"`
+	Query  string    `json:"query,omitempty"`
+	Date   time.Time `json:"date"`
+	DryRun bool      `json:"dry_run"`
+
+	// Linked reports whether tasks were created with sync records of their items
+	Linked bool `json:"linked"`
+
+	Results        []*MigrationResult `json:"results"`
+	Statuses       []MigrationMapping `json:"statuses"`
+	Types          []MigrationMapping `json:"types"`
+	UnmappedFields []UnmappedField    `json:"unmapped_fields"`
+
+	// File is the report written to the workspace; dry runs write none
+	File string `json:"file,omitempty"`
+}
+
+// MigrationMappings returns the statuses and types of the items of an export, most
+// common first, with the status and type of tasks each maps to by default. Statuses are
+// mapped by name, or by their category where the source groups statuses.
+func MigrationMappings(export *plugin.Export) (statuses, taskTypes []MigrationMapping) {
+	statusIndex := map[string]int{}
+	typeIndex := map[string]int{}
+	for _, item := range export.Items {
+		if i, ok := statusIndex[item.Status]; ok {
+			statuses[i].Tasks++
+		} else {
+			statusIndex[item.Status] = len(statuses)
+			statuses = append(statuses, MigrationMapping{External: item.Status, Zen: migrationStatus(item), Tasks: 1})
+		}
+		if i, ok := typeIndex[item.Type]; ok {
+			taskTypes[i].Tasks++
+		} else {
+			typeIndex[item.Type] = len(taskTypes)
+			taskTypes = append(taskTypes, MigrationMapping{External: item.Type, Zen: importTaskType(item.Type), Tasks: 1})
+		}
+	}
+
+	byCount := func(mappings []MigrationMapping) {
+		sort.SliceStable(mappings, func(i, j int) bool { return mappings[i].Tasks > mappings[j].Tasks })
+	}
+	byCount(statuses)
+	byCount(taskTypes)
+	return statuses, taskTypes
+}
+
+// migrationStatus returns the default task status of an item
+func migrationStatus(item *plugin.ExportItem) string {
+	if status, ok := importStatus(item.Status); ok {
+		return status
+	}
+	switch item.StatusCategory {
+	case "indeterminate":
+		return StatusInProgress
+	case "done":
+		return StatusCompleted
+	default:
+		return StatusProposed
+	}
+}
+
+// MigrateTasks creates a task for each item of an export, keeping the key of the item
+// as its ID, in the status and of the type its item maps to. Each task gets a summary
+// of the history of its item in its index.md and, unless the request says otherwise, a
+// sync record of the item. Items whose task exists are skipped, so a migration can be
+// run again. Unless it is a dry run, the report is written to the migrations directory
+// of the workspace.
+func (m *Manager) MigrateTasks(ctx context.Context, request *MigrationRequest) (*MigrationReport, error) {
+	report := &MigrationReport{
+		Source: request.Source,
+		Query:  request.Query,
+		Date:   time.Now(),
+		DryRun: request.DryRun,
+		Linked: !request.NoLink && !m.localOnly(),
+	}
+
+	report.Statuses, report.Types = MigrationMappings(request.Export)
+	for i, mapping := range report.Statuses {
+		if status, ok := request.Statuses[mapping.External]; ok {
+			if !slices.Contains(Statuses, status) {
+				return nil, &types.Error{
+					Code:    types.ErrorCodeInvalidInput,
+					Message: fmt.Sprintf("unknown status %q for %q", status, mapping.External),
+					Details: fmt.Sprintf("statuses are %s", strings.Join(Statuses, ", ")),
+				}
+			}
+			report.Statuses[i].Zen = status
+		}
+	}
+	for i, mapping := range report.Types {
+		if taskType, ok := request.Types[mapping.External]; ok {
+			if !slices.Contains(TaskTypes, taskType) {
+				return nil, &types.Error{
+					Code:    types.ErrorCodeInvalidInput,
+					Message: fmt.Sprintf("unknown task type %q for %q", taskType, mapping.External),
+					Details: fmt.Sprintf("task types are %s", strings.Join(TaskTypes, ", ")),
+				}
+			}
+			report.Types[i].Zen = taskType
+		}
+	}
+	statuses := map[string]string{}
+	for _, mapping := range report.Statuses {
+		statuses[mapping.External] = mapping.Zen
+	}
+	taskTypes := map[string]string{}
+	for _, mapping := range report.Types {
+		taskTypes[mapping.External] = mapping.Zen
+	}
+
+	report.UnmappedFields = unmappedFields(request.Export)
+
+	ops := NewOperations(m.factory)
+	for _, item := range request.Export.Items {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		data := m.localizeSourceData(ctx, ops.convertPluginTaskDataToTaskData(item.Task), request.Source)
+		data.Type = taskTypes[item.Type]
+		data.Status = statuses[item.Status]
+		if priority, ok := importPriority(data.Priority); ok {
+			data.Priority = priority
+		} else {
+			data.Priority = ""
+		}
+
+		result := &MigrationResult{ExternalID: data.ExternalID, TaskID: data.ExternalID, Title: data.Title, Status: ImportValid}
+		report.Results = append(report.Results, result)
+		switch {
+		case m.taskExists(result.TaskID):
+			result.Status = ImportExists
+		case request.DryRun:
+		default:
+			m.migrateTask(ctx, request.Source, item, data, report.Linked, result)
+		}
+	}
+
+	if request.DryRun {
+		return report, nil
+	}
+
+	tasksDir, err := m.tasksDirectory()
+	if err != nil {
+		return report, err
+	}
+	report.File = filepath.Join(filepath.Dir(tasksDir), "migrations",
+		fmt.Sprintf("%s-%s.md", request.Source, report.Date.Format("20060102-150405")))
+	if err := os.MkdirAll(filepath.Dir(report.File), 0755); err != nil {
+		return report, fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+	if err := os.WriteFile(report.File, []byte(report.Markdown()), 0644); err != nil {
+		return report, fmt.Errorf("failed to write migration report: %w", err)
+	}
+
+	m.logger.Info("tasks migrated", "source", request.Source, "items", len(report.Results), "report", report.File)
+	return report, nil
+}
+
+// migrateTask creates the task of an item, recording the outcome in result
+func (m *Manager) migrateTask(ctx context.Context, source string, item *plugin.ExportItem, data *TaskData, link bool, result *MigrationResult) {
+	request := &CreateTaskRequest{
+		ID:       data.ExternalID,
+		Title:    data.Title,
+		Type:     data.Type,
+		Owner:    data.Owner,
+		Priority: data.Priority,
+	}
+	if _, err := m.labelConfig().Check(data.Labels); err == nil && len(data.Labels) > 0 {
+		request.Labels = data.Labels
+	}
+	if link {
+		request.FromSource, request.SourceData = source, data
+	}
+
+	task, err := m.CreateTask(ctx, request)
+	var zenErr *types.Error
+	switch {
+	case err == nil:
+	case errors.As(err, &zenErr) && zenErr.Code == types.ErrorCodeAlreadyExists:
+		result.Status = ImportExists
+		return
+	default:
+		result.Status, result.Error = ImportFailed, err.Error()
+		return
+	}
+	result.TaskID, result.Status = task.ID, ImportCreated
+
+	// Migrated tasks start in the status of their item, which is not a transition of the
+	// status workflow
+	if data.Status != task.Status {
+		if err := updateManifestFields(task.ManifestPath, map[string]string{"task.status": data.Status}); err != nil {
+			result.Status, result.Error = ImportFailed, fmt.Sprintf("created, but failed to set its status: %v", err)
+			return
+		}
+	}
+
+	index := filepath.Join(task.WorkspacePath, "index.md")
+	f, err := os.OpenFile(index, os.O_APPEND|os.O_WRONLY, 0644) // #nosec G304 - the index of the task created
+	if err == nil {
+		_, err = f.WriteString("\n" + migrationHistory(source, item))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		result.Status, result.Error = ImportFailed, fmt.Sprintf("created, but failed to write its history: %v", err)
+		return
+	}
+	m.recordRevision(task)
+}
+
+// migrationHistory returns the Markdown summary of the history of an item in its source
+func migrationHistory(source string, item *plugin.ExportItem) string {
+	name := sourceName(source)
+	var b strings.Builder
+	fmt.Fprintf(&b, "## History in %s\n\n", name)
+
+	reference := item.Task.ExternalID
+	if item.Task.ExternalURL != "" {
+		reference = fmt.Sprintf("[%s](%s)", item.Task.ExternalID, item.Task.ExternalURL)
+	}
+	fmt.Fprintf(&b, "Migrated from %s %s", name, reference)
+	if !item.Task.Created.IsZero() {
+		fmt.Fprintf(&b, ", created on %s", item.Task.Created.Format("2006-01-02"))
+		if item.Reporter != "" {
+			fmt.Fprintf(&b, " by %s", item.Reporter)
+		}
+	}
+	b.WriteString(".")
+	if item.Task.Assignee != "" {
+		fmt.Fprintf(&b, " It was assigned to %s.", item.Task.Assignee)
+	}
+	if len(item.Task.Labels) > 0 {
+		fmt.Fprintf(&b, " Labels: %s.", strings.Join(item.Task.Labels, ", "))
+	}
+	if len(item.Task.Components) > 0 {
+		fmt.Fprintf(&b, " Components: %s.", strings.Join(item.Task.Components, ", "))
+	}
+	fmt.Fprintf(&b, " It has %d comments in %s.\n", item.Comments, name)
+
+	if len(item.History) == 0 {
+		return b.String()
+	}
+	history := item.History
+	if len(history) > migrationHistoryLimit {
+		fmt.Fprintf(&b, "\nThe %d earlier changes are left out.\n", len(history)-migrationHistoryLimit)
+		history = history[len(history)-migrationHistoryLimit:]
+	}
+	b.WriteString("\n| Date | Author | Field | From | To |\n|------|--------|-------|------|----|\n")
+	for _, change := range history {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", change.At.Format("2006-01-02"),
+			markdownCell(change.Author), markdownCell(change.Field), markdownCell(change.From), markdownCell(change.To))
+	}
+	return b.String()
+}
+
+// unmappedFields returns the fields items have values in that tasks do not hold, most
+// common first
+func unmappedFields(export *plugin.Export) []UnmappedField {
+	counts := map[string]int{}
+	for _, item := range export.Items {
+		for id, value := range item.Fields {
+			if !migratedFields[id] && !emptyFieldValue(value) {
+				counts[id]++
+			}
+		}
+	}
+
+	fields := make([]UnmappedField, 0, len(counts))
+	for id, count := range counts {
+		name := export.FieldNames[id]
+		if name == "" {
+			name = id
+		}
+		fields = append(fields, UnmappedField{ID: id, Name: name, Tasks: count})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Tasks != fields[j].Tasks {
+			return fields[i].Tasks > fields[j].Tasks
+		}
+		return fields[i].Name < fields[j].Name
+	})
+	return fields
+}
+
+// emptyFieldValue reports whether a field holds nothing, such as an empty list, or a
+// page of a list with no entries
+func emptyFieldValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		if total, ok := v["total"].(float64); ok {
+			return total == 0
+		}
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// Markdown returns the report as a Markdown document
+func (r *MigrationReport) Markdown() string {
+	name := sourceName(r.Source)
+	counts := map[string]int{}
+	for _, result := range r.Results {
+		counts[result.Status]++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Migration from %s\n\n", name)
+	fmt.Fprintf(&b, "- Date: %s\n", r.Date.Format("2006-01-02 15:04"))
+	if r.Query != "" {
+		fmt.Fprintf(&b, "- Query: `%s`\n", r.Query)
+	}
+	fmt.Fprintf(&b, "- Items: %d (%d created, %d existed, %d failed)\n",
+		len(r.Results), counts[ImportCreated], counts[ImportExists], counts[ImportFailed])
+	if r.Linked {
+		fmt.Fprintf(&b, "- Sync: tasks are linked to their %s items\n", name)
+	} else {
+		fmt.Fprintf(&b, "- Sync: tasks are not linked to %s\n", name)
+	}
+
+	writeMappings := func(title string, mappings []MigrationMapping) {
+		fmt.Fprintf(&b, "\n## %s\n\n| %s | Zen | Items |\n|------|-----|-------|\n", title, name)
+		for _, mapping := range mappings {
+			fmt.Fprintf(&b, "| %s | %s | %d |\n", markdownCell(mapping.External), mapping.Zen, mapping.Tasks)
+		}
+	}
+	writeMappings("Statuses", r.Statuses)
+	writeMappings("Types", r.Types)
+
+	b.WriteString("\n## Unmapped Fields\n\n")
+	if len(r.UnmappedFields) == 0 {
+		b.WriteString("Every field with values was carried over.\n")
+	} else {
+		fmt.Fprintf(&b, "Tasks do not hold these fields, so their values remain in %s", name)
+		if r.Linked {
+			b.WriteString(" and in the sync records of the tasks")
+		}
+		b.WriteString(".\n\n| Field | ID | Items |\n|-------|----|-------|\n")
+		for _, field := range r.UnmappedFields {
+			fmt.Fprintf(&b, "| %s | %s | %d |\n", markdownCell(field.Name), field.ID, field.Tasks)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Items\n\n| %s | Task | Title | Result |\n|------|------|-------|--------|\n", name)
+	for _, result := range r.Results {
+		status := result.Status
+		if result.Error != "" {
+			status += ": " + result.Error
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", result.ExternalID, result.TaskID, markdownCell(result.Title), markdownCell(status))
+	}
+	return b.String()
+}
+
+// sourceName returns the name of a source as it is written in prose, such as Jira
+func sourceName(source string) string {
+	if source == "" {
+		return source
+	}
+	return strings.ToUpper(source[:1]) + source[1:]
+}
+
+// markdownCell escapes text for a cell of a Markdown table
+func markdownCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(text)
+}
@@ -0,0 +1,150 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExport() *plugin.Export {
+	created := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	return &plugin.Export{
+		FieldNames: map[string]string{"customfield_10016": "Story Points", "fixVersions": "Fix versions"},
+		Items: []*plugin.ExportItem{
+			{
+				Task: &plugin.TaskData{ID: "PROJ-2", ExternalID: "PROJ-2", Title: "Add SSO", Priority: "P1", Assignee: "Bob",
+					Created: created, ExternalURL: "https://acme.atlassian.net/browse/PROJ-2", Metadata: map[string]interface{}{"external_system": "jira"}},
+				Status: "Ready for QA", StatusCategory: "indeterminate", Type: "Story", Reporter: "Ada", Comments: 2,
+				Fields: map[string]interface{}{"summary": "Add SSO", "customfield_10016": float64(5), "fixVersions": []interface{}{},
+					"worklog": map[string]interface{}{"total": float64(0)}},
+				History: []plugin.Change{
+					{At: created.Add(24 * time.Hour), Author: "Bob", Field: "status", From: "To Do", To: "Ready for QA"},
+				},
+			},
+			{
+				Task:   &plugin.TaskData{ID: "PROJ-3", ExternalID: "PROJ-3", Title: "Fix logout | again", Priority: "Unknown"},
+				Status: "Done", StatusCategory: "done", Type: "Defect",
+				Fields: map[string]interface{}{"customfield_10016": float64(2)},
+			},
+			{
+				Task:   &plugin.TaskData{ID: "PROJ-1", ExternalID: "PROJ-1", Title: "Add login page"},
+				Status: "Ready for QA", StatusCategory: "indeterminate", Type: "Story",
+			},
+		},
+	}
+}
+
+func TestMigrationMappings(t *testing.T) {
+	statuses, taskTypes := MigrationMappings(newTestExport())
+	assert.Equal(t, []MigrationMapping{
+		{External: "Ready for QA", Zen: StatusInProgress, Tasks: 2},
+		{External: "Done", Zen: StatusCompleted, Tasks: 1},
+	}, statuses, "statuses unknown by name are mapped by their category")
+	assert.Equal(t, []MigrationMapping{
+		{External: "Story", Zen: "story", Tasks: 2},
+		{External: "Defect", Zen: "bug", Tasks: 1},
+	}, taskTypes)
+}
+
+func TestManagerMigrateTasks(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	request := &MigrationRequest{Source: "jira", Query: "project = PROJ", Export: newTestExport(),
+		Statuses: map[string]string{"Ready for QA": StatusInReview}, DryRun: true}
+
+	report, err := m.MigrateTasks(ctx, request)
+	require.NoError(t, err)
+	assert.False(t, report.Linked, "tasks tracked locally are not linked")
+	assert.Empty(t, report.File)
+	require.Len(t, report.Results, 3)
+	assert.Equal(t, MigrationResult{ExternalID: "PROJ-2", TaskID: "PROJ-2", Title: "Add SSO", Status: ImportValid}, *report.Results[0])
+	assert.Equal(t, ImportExists, report.Results[2].Status)
+	assert.Equal(t, []UnmappedField{{ID: "customfield_10016", Name: "Story Points", Tasks: 2}}, report.UnmappedFields)
+	assert.False(t, m.taskExists("PROJ-2"), "dry runs create no tasks")
+
+	request.DryRun = false
+	report, err = m.MigrateTasks(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, ImportCreated, report.Results[0].Status)
+	assert.Equal(t, ImportCreated, report.Results[1].Status)
+
+	sso, err := m.GetTask(ctx, "PROJ-2")
+	require.NoError(t, err)
+	assert.Equal(t, StatusInReview, sso.Status, "statuses are mapped over their defaults")
+	assert.Equal(t, "story", sso.Type)
+	assert.Equal(t, "P1", sso.Priority)
+	index, err := os.ReadFile(filepath.Join(tasksDir, "PROJ-2", "index.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "## History in Jira\n\nMigrated from Jira [PROJ-2](https://acme.atlassian.net/browse/PROJ-2), "+
+		"created on 2026-09-01 by Ada. It was assigned to Bob. It has 2 comments in Jira.\n")
+	assert.Contains(t, string(index), "| 2026-09-02 | Bob | status | To Do | Ready for QA |\n")
+
+	logout, err := m.GetTask(ctx, "PROJ-3")
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, logout.Status)
+	assert.Equal(t, "bug", logout.Type)
+	assert.Equal(t, "P2", logout.Priority, "unknown priorities take the default")
+
+	require.NotEmpty(t, report.File)
+	assert.Equal(t, filepath.Join(filepath.Dir(tasksDir), "migrations"), filepath.Dir(report.File))
+	written, err := os.ReadFile(report.File)
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "- Items: 3 (2 created, 1 existed, 0 failed)\n")
+	assert.Contains(t, string(written), "| Ready for QA | in_review | 2 |\n")
+	assert.Contains(t, string(written), "| Story Points | customfield_10016 | 2 |\n")
+	assert.Contains(t, string(written), `| PROJ-3 | PROJ-3 | Fix logout \| again | created |`)
+
+	report, err = m.MigrateTasks(ctx, request)
+	require.NoError(t, err)
+	for _, result := range report.Results {
+		assert.Equal(t, ImportExists, result.Status, "migrating again skips the tasks created")
+	}
+}
+
+func TestManagerMigrateTasks_Linked(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	cfg, err := m.factory.Config()
+	require.NoError(t, err)
+	cfg.Integrations.TaskSystem = "jira"
+
+	report, err := m.MigrateTasks(context.Background(), &MigrationRequest{Source: "jira", Export: newTestExport()})
+	require.NoError(t, err)
+	assert.True(t, report.Linked)
+	assert.Equal(t, ImportCreated, report.Results[0].Status, report.Results[0].Error)
+
+	record, err := os.ReadFile(filepath.Join(tasksDir, "PROJ-2", "metadata", "jira.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(record), `"external_id": "PROJ-2"`)
+	written, err := os.ReadFile(report.File)
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "and in the sync records of the tasks")
+}
+
+func TestManagerMigrateTasks_InvalidMapping(t *testing.T) {
+	m, _ := newTestManager(t)
+	tests := []struct {
+		name    string
+		request *MigrationRequest
+	}{
+		{name: "status", request: &MigrationRequest{Source: "jira", Export: newTestExport(), Statuses: map[string]string{"Done": "shipped"}}},
+		{name: "type", request: &MigrationRequest{Source: "jira", Export: newTestExport(), Types: map[string]string{"Story": "feature"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.MigrateTasks(context.Background(), tt.request)
+			var zenErr *types.Error
+			require.True(t, errors.As(err, &zenErr), "got %v", err)
+			assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+			assert.False(t, m.taskExists("PROJ-2"))
+		})
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/daddia/zen/pkg/clients/httpx"
+	"github.com/daddia/zen/pkg/clients/servicenow"
 	"github.com/daddia/zen/pkg/cmdutil"
 	"github.com/daddia/zen/pkg/integration/factory"
 	"github.com/daddia/zen/pkg/integration/orchestrator"
@@ -80,7 +83,7 @@ func (ops *Operations) initializeIntegrationComponents() error {
 	}
 
 	// Check if task source is configured for external integration
-	if config.Task.TaskSource == "" || config.Task.TaskSource == "none" || config.Task.TaskSource == "local" {
+	if LocalOnly(config) {
 		return fmt.Errorf("no external task system configured")
 	}
 
@@ -130,9 +133,9 @@ func (ops *Operations) FetchFromSource(ctx context.Context, taskID string, sourc
 		ExternalID: taskID,
 		Options: map[string]interface{}{
 			"include_raw": true,
-			"timeout":     30 * time.Second,
+			"timeout":     httpx.TimeoutFor(source, httpx.CommandTimeout(ctx)),
 		},
-		Timeout: 30 * time.Second,
+		Timeout: httpx.TimeoutFor(source, httpx.CommandTimeout(ctx)),
 	}
 
 	// Execute operation through orchestrator
@@ -156,12 +159,45 @@ func (ops *Operations) FetchFromSource(ctx context.Context, taskID string, sourc
 	return ops.fetchFromSourceDirect(ctx, taskID, source, pluginInstance)
 }
 
+// FetchBatchFromSource fetches many tasks from a source in as few requests as the source's
+// plugin allows. It returns an empty result when the plugin cannot fetch in bulk, so callers
+// fetch those tasks one at a time with FetchFromSource.
+func (ops *Operations) FetchBatchFromSource(ctx context.Context, externalIDs []string, source string) (map[string]*TaskData, error) {
+	tasks := map[string]*TaskData{}
+	if ops.clientFactory == nil || len(externalIDs) == 0 {
+		return tasks, nil
+	}
+
+	pluginInstance, err := ops.clientFactory.CreatePlugin(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin: %w", err)
+	}
+
+	batcher, ok := pluginInstance.(plugin.BatchFetcher)
+	if !ok {
+		return tasks, nil
+	}
+
+	pluginTasks, err := batcher.FetchTasks(ctx, externalIDs, &plugin.FetchOptions{
+		IncludeRaw: true,
+		Timeout:    httpx.TimeoutFor(source, httpx.CommandTimeout(ctx)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tasks from %s: %w", source, err)
+	}
+
+	for id, pluginTaskData := range pluginTasks {
+		tasks[id] = ops.convertPluginTaskDataToTaskData(pluginTaskData)
+	}
+	return tasks, nil
+}
+
 // fetchFromSourceDirect fetches directly from plugin (bypass orchestrator)
 func (ops *Operations) fetchFromSourceDirect(ctx context.Context, taskID string, source string, pluginInstance plugin.IntegrationPluginInterface) (*TaskData, error) {
 	// Fetch task data using plugin
 	pluginTaskData, err := pluginInstance.FetchTask(ctx, taskID, &plugin.FetchOptions{
 		IncludeRaw: true,
-		Timeout:    30 * time.Second,
+		Timeout:    httpx.TimeoutFor(source, httpx.CommandTimeout(ctx)),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch task from %s: %w", source, err)
@@ -263,7 +299,7 @@ func (ops *Operations) extractSourceFromMetadata(metadata map[string]interface{}
 // CreateSourceMetadata creates metadata file for any external source system
 func (ops *Operations) CreateSourceMetadata(ctx context.Context, taskDir string, taskData *TaskData) error {
 	fmt.Fprintf(ops.io.Out, "%s Creating %s metadata...\n",
-		ops.io.ColorInfo("ℹ"), taskData.Source)
+		ops.io.InfoIcon(), taskData.Source)
 
 	// Create metadata directory
 	metadataDir := filepath.Join(taskDir, "metadata")
@@ -315,7 +351,7 @@ func (ops *Operations) CreateSourceMetadata(ctx context.Context, taskDir string,
 	}
 
 	fmt.Fprintf(ops.io.Out, "%s Created %s metadata: %s\n",
-		ops.io.FormatSuccess("✓"), taskData.Source, fmt.Sprintf("metadata/%s.json", taskData.Source))
+		ops.io.SuccessIcon(), taskData.Source, fmt.Sprintf("metadata/%s.json", taskData.Source))
 
 	return nil
 }
@@ -415,6 +451,14 @@ func (ops *Operations) buildExternalURL(source string, taskID string) string {
 		return fmt.Sprintf("%s/issues/%s", providerConfig.URL, taskID)
 	case "linear":
 		return fmt.Sprintf("%s/issue/%s", providerConfig.URL, taskID)
+	case "notion":
+		return "https://www.notion.so/" + strings.ReplaceAll(taskID, "-", "")
+	case "servicenow":
+		instanceURL := providerConfig.URL
+		if instance, ok := providerConfig.Settings["instance"].(string); ok && instanceURL == "" {
+			instanceURL = servicenow.InstanceURL(instance)
+		}
+		return fmt.Sprintf("%s/task.do?sysparm_query=number=%s", instanceURL, taskID)
 	default:
 		return providerConfig.URL
 	}
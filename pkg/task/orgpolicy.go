@@ -0,0 +1,102 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/daddia/zen/pkg/policy"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// CheckBranchName returns an error unless branch follows the branch naming rule of the
+// organization policy of the workspace
+func (m *Manager) CheckBranchName(ctx context.Context, branch string) error {
+	org, err := m.orgPolicy()
+	if err != nil {
+		return err
+	}
+	return org.CheckName(policy.NamingBranch, branch)
+}
+
+// checkTaskNames returns an error unless the ID and labels of a task follow the naming
+// rules of the organization policy of the workspace
+func (m *Manager) checkTaskNames(id string, labels []string) error {
+	org, err := m.orgPolicy()
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if err := org.CheckName(policy.NamingTaskID, id); err != nil {
+			return err
+		}
+	}
+	for _, label := range labels {
+		if err := org.CheckName(policy.NamingLabel, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRequiredGates returns an error unless the task has passed the quality gates the
+// organization policy requires of the stages it leaves to move to stage
+func (m *Manager) checkRequiredGates(task *Task, stage string) error {
+	from, to := StageIndex(task.CurrentStage), StageIndex(stage)
+	if to <= from {
+		return nil
+	}
+	org, err := m.orgPolicy()
+	if err != nil || org == nil {
+		return err
+	}
+
+	gates, err := qualityGates(task.ManifestPath)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for i := max(from, 0); i < to; i++ {
+		for _, gate := range org.RequiredGates(WorkflowStages[i], task.Type) {
+			if gates[gate.Name].Status != GatePassed {
+				missing = append(missing, fmt.Sprintf("%s (%s)", gate.Name, StageName(gate.Stage)))
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &types.Error{
+		Code:    types.ErrorCodeInvalidInput,
+		Message: fmt.Sprintf("%s cannot move to %s: organization policy %s requires %d quality gates", task.ID, StageName(stage), org.Name(), len(missing)),
+		Details: fmt.Sprintf("pass them with 'zen task gate pass %s <gate>':\n  %s", task.ID, strings.Join(missing, "\n  ")),
+	}
+}
+
+// orgPolicy returns the organization policy installed in the workspace, or nil without
+// one, loading it on first use
+func (m *Manager) orgPolicy() (*policy.OrgPolicy, error) {
+	if m.orgLoaded {
+		return m.org, nil
+	}
+
+	zenConfig, err := m.factory.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	ws, err := m.factory.WorkspaceManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace manager: %w", err)
+	}
+	status, err := ws.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace status: %w", err)
+	}
+	org, err := policy.LoadOrg(zenConfig, filepath.Join(status.Root, ".zen"))
+	if err != nil {
+		return nil, err
+	}
+	m.org, m.orgLoaded = org, true
+	return m.org, nil
+}
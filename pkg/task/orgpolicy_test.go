@@ -0,0 +1,91 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/policy"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// installPolicyBundle installs an organization policy bundle in the workspace of a test
+// manager
+func installPolicyBundle(t *testing.T, tasksDir, bundle string) {
+	t.Helper()
+	path := policy.BundlePath(filepath.Dir(filepath.Dir(tasksDir)))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(bundle), 0644))
+}
+
+func TestManager_OrgPolicyNaming(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	installPolicyBundle(t, tasksDir, `
+name: acme
+naming:
+  task_id: "^PROJ-[0-9]+$"
+  label: "^[a-z-]+$"
+  branch: "^(feat|fix)/"
+`)
+	ctx := context.Background()
+
+	_, err := m.CreateTask(ctx, &CreateTaskRequest{ID: "PROJ-2", Title: "Add signup page", Labels: []string{"frontend"}})
+	require.NoError(t, err)
+
+	_, err = m.CreateTask(ctx, &CreateTaskRequest{ID: "ACME-1", Title: "Add help page"})
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, "task ID ACME-1 does not follow the naming rule of organization policy acme", zenErr.Message)
+	assert.False(t, m.taskExists("ACME-1"))
+
+	_, err = m.UpdateTask(ctx, "PROJ-1", &TaskUpdates{Labels: []string{"Needs_Review"}})
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, "labels must match ^[a-z-]+$", zenErr.Details)
+
+	_, err = m.RenameTask(ctx, "PROJ-1", "WEB-1", false)
+	assert.Error(t, err)
+	assert.True(t, m.taskExists("PROJ-1"))
+
+	assert.NoError(t, m.CheckBranchName(ctx, "feat/PROJ-1-add-login"))
+	assert.Error(t, m.CheckBranchName(ctx, "PROJ-1"))
+}
+
+func TestManager_OrgPolicyRequiredGates(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	installPolicyBundle(t, tasksDir, `
+name: acme
+required_gates:
+  - name: design-review
+    stage: 02-discover
+  - name: security-review
+    stage: 02-discover
+    types: [spike]
+`)
+	ctx := context.Background()
+
+	require.NoError(t, m.ProgressTask(ctx, "PROJ-1", "02-discover"), "gates are required to leave their stage")
+
+	err := m.ProgressTask(ctx, "PROJ-1", "04-design")
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr), "got %v", err)
+	assert.Equal(t, types.ErrorCodeInvalidInput, zenErr.Code)
+	assert.Equal(t, "PROJ-1 cannot move to Design: organization policy acme requires 1 quality gates", zenErr.Message)
+	assert.Contains(t, zenErr.Details, "design-review (Discover)")
+
+	_, err = m.PassGate(ctx, "PROJ-1", "design-review", &GatePassRequest{})
+	require.NoError(t, err)
+	require.NoError(t, m.ProgressTask(ctx, "PROJ-1", "04-design"))
+}
+
+func TestManager_OrgPolicyWithoutBundle(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	org, err := m.orgPolicy()
+	require.NoError(t, err)
+	assert.Nil(t, org)
+	assert.NoError(t, m.CheckBranchName(context.Background(), "anything"))
+}
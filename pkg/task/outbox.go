@@ -0,0 +1,474 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/fs"
+)
+
+// outboxDir is the directory, in the metadata of a task, holding the changes waiting to
+// be pushed to its sources, one entry for each source
+const outboxDir = "outbox"
+
+// outboxRetryDelay is the wait before the first retry of a failed push, doubled after
+// each further failure up to outboxMaxRetryDelay
+const outboxRetryDelay = time.Minute
+
+// outboxMaxRetryDelay bounds the wait between retries of a failed push
+const outboxMaxRetryDelay = time.Hour
+
+// syncedFields are the fields of a task a push sends to its sources
+var syncedFields = []string{"title", "description", "status", "priority", "owner", "labels"}
+
+// Outbox delivery outcomes
+const (
+	OutboxDelivered = "delivered"
+	OutboxCleared   = "cleared"
+	OutboxFailed    = "failed"
+)
+
+// OutboxEntry is a push of the local changes of a task to a source that has not yet
+// succeeded. It is recorded before the push is attempted and removed once it succeeds,
+// so a push that fails, or is interrupted, is retried by 'zen watch' and the next sync
+// rather than lost. A push the source accepted is marked delivered in its entry before
+// the task records it, so that a retry records it rather than pushing it again.
+type OutboxEntry struct {
+	// ID identifies the entry across retries; changes made to the task while it waits
+	// join the entry, since a push sends the task as it is
+	ID         string `json:"id"`
+	TaskID     string `json:"task_id"`
+	Source     string `json:"source"`
+	ExternalID string `json:"external_id"`
+
+	// Direction, ConflictStrategy and FieldPolicies are those of the sync retried
+	Direction        SyncDirection               `json:"direction"`
+	ConflictStrategy ConflictStrategy            `json:"conflict_strategy,omitempty"`
+	FieldPolicies    map[string]ConflictStrategy `json:"field_policies,omitempty"`
+
+	// Fields are the fields changed locally since the last sync with the source
+	Fields []string `json:"fields"`
+
+	Created     time.Time `json:"created"`
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error,omitempty"`
+	NextAttempt time.Time `json:"next_attempt"`
+
+	// Delivered are the values the source accepted, when zen stopped before recording
+	// them in the task
+	Delivered *SyncSnapshot `json:"delivered,omitempty"`
+}
+
+// OutboxResult is the outcome of a retry of an outbox entry
+type OutboxResult struct {
+	TaskID  string `json:"task_id"`
+	Source  string `json:"source"`
+	EntryID string `json:"entry_id"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+type outboxHeldKey struct{}
+
+// withOutboxHeld returns a context whose syncs run with the outbox lock of their task
+// already held, as retries of outbox entries do
+func withOutboxHeld(ctx context.Context) context.Context {
+	return context.WithValue(ctx, outboxHeldKey{}, true)
+}
+
+// outboxHeld reports whether syncs in ctx run with the outbox lock held
+func outboxHeld(ctx context.Context) bool {
+	held, _ := ctx.Value(outboxHeldKey{}).(bool)
+	return held
+}
+
+// outboxLockPath returns the lock file of the outbox entry of source in the task in
+// taskDir, kept with the locks of tasks in .zen/run
+func outboxLockPath(taskDir, source string) string {
+	zenDir := filepath.Dir(filepath.Dir(filepath.Dir(taskDir)))
+	return filepath.Join(zenDir, "run", "outbox", filepath.Base(taskDir)+"."+source+".lock")
+}
+
+// lockOutbox takes the lock serializing pushes of task to source, so that an entry is
+// retried by one command at a time. With noWait, it fails at once with fs.ErrLocked
+// when another command holds the lock.
+func lockOutbox(ctx context.Context, task *Task, source string, noWait bool) (*fs.FileLock, error) {
+	if task.WorkspacePath == "" {
+		return nil, nil
+	}
+	lock, err := fs.AcquireLock(ctx, outboxLockPath(task.WorkspacePath, source), fs.LockOptions{Timeout: taskLockTimeout, NoWait: noWait})
+	if err != nil {
+		if errors.Is(err, fs.ErrLocked) && !noWait {
+			return nil, lockedByOtherCommand(fmt.Sprintf("task %s is being pushed to %s by another command", task.ID, source), err)
+		}
+		return nil, err
+	}
+	return lock, nil
+}
+
+// throughOutbox runs a sync of task that pushes its local changes to source, recording
+// the push in the outbox of the task first. The entry is removed once the sync succeeds,
+// and kept, with the failure, for retries when it does not.
+func (m *Manager) throughOutbox(ctx context.Context, task *Task, source string, opts *SyncOptions, sync func(ctx context.Context) (*SyncResult, error)) (*SyncResult, error) {
+	if !outboxHeld(ctx) {
+		lock, err := lockOutbox(ctx, task, source, false)
+		if err != nil {
+			return nil, err
+		}
+		defer lock.Release()
+	}
+
+	// A push the source accepted before zen stopped is recorded rather than sent again
+	delivered, err := m.recordDeliveredPush(task, source)
+	if err != nil {
+		m.logger.Warn("failed to record delivered push", "task_id", task.ID, "source", source, "error", err)
+	}
+	if delivered && opts.Direction == SyncDirectionPush && len(pendingFields(task, source)) == 0 {
+		return &SyncResult{
+			TaskID:    task.ID,
+			Source:    source,
+			Success:   true,
+			Direction: opts.Direction,
+			Timestamp: time.Now(),
+		}, nil
+	}
+
+	entry, err := m.queuePush(task, source, opts)
+	if err != nil {
+		m.logger.Warn("failed to record push in outbox", "task_id", task.ID, "source", source, "error", err)
+	}
+
+	result, err := sync(ctx)
+	if entry == nil {
+		return result, err
+	}
+
+	if err == nil && result != nil && result.Success {
+		if err := os.Remove(outboxEntryPath(task, source)); err != nil && !os.IsNotExist(err) {
+			m.logger.Warn("failed to remove delivered outbox entry", "task_id", task.ID, "source", source, "error", err)
+		}
+		return result, nil
+	}
+
+	// The push may have reached the source before the sync failed
+	if current, readErr := readOutboxEntry(outboxEntryPath(task, source)); readErr == nil && current != nil {
+		entry.Delivered = current.Delivered
+	}
+	entry.Attempts++
+	entry.LastError = syncFailure(result, err)
+	entry.NextAttempt = time.Now().Add(outboxBackoff(entry.Attempts))
+	if writeErr := writeOutboxEntry(task, entry); writeErr != nil {
+		m.logger.Warn("failed to record failed push in outbox", "task_id", task.ID, "source", source, "error", writeErr)
+	}
+	if result != nil {
+		result.Queued = true
+	}
+	return result, err
+}
+
+// queuePush records the push of the local changes of task to source in its outbox,
+// joining the entry already waiting when there is one. Nothing is recorded for a task
+// without local changes and without a waiting entry.
+func (m *Manager) queuePush(task *Task, source string, opts *SyncOptions) (*OutboxEntry, error) {
+	if task.MetadataPath == "" {
+		return nil, nil
+	}
+
+	entry, err := readOutboxEntry(outboxEntryPath(task, source))
+	if err != nil {
+		return nil, err
+	}
+	fields := pendingFields(task, source)
+	if entry == nil {
+		if len(fields) == 0 {
+			return nil, nil
+		}
+		now := time.Now().UTC()
+		id, err := newULID(now)
+		if err != nil {
+			return nil, err
+		}
+		entry = &OutboxEntry{ID: id, TaskID: task.ID, Source: source, Created: now}
+	}
+
+	if taskSource, ok := task.Sources[source]; ok {
+		entry.ExternalID = taskSource.ExternalID
+	}
+	entry.Direction = opts.Direction
+	entry.ConflictStrategy = opts.ConflictStrategy
+	entry.FieldPolicies = opts.FieldPolicies
+	entry.Fields = fields
+	if err := writeOutboxEntry(task, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// markPushDelivered records in the outbox entry of the push of task to source the values
+// the source accepted, as soon as it did
+func (m *Manager) markPushDelivered(task *Task, source string, accepted *SyncSnapshot) {
+	if task.MetadataPath == "" {
+		return
+	}
+	entry, err := readOutboxEntry(outboxEntryPath(task, source))
+	if err == nil && entry != nil {
+		entry.Delivered = accepted
+		err = writeOutboxEntry(task, entry)
+	}
+	if err != nil {
+		m.logger.Warn("failed to mark push delivered in outbox", "task_id", task.ID, "source", source, "error", err)
+	}
+}
+
+// recordDeliveredPush records in task the values of the push to source that its outbox
+// entry marks delivered, as the push would have once the source accepted it. The entry
+// is removed unless the task has changed since; it reports whether there was one.
+func (m *Manager) recordDeliveredPush(task *Task, source string) (bool, error) {
+	taskSource, linked := task.Sources[source]
+	if task.MetadataPath == "" || !linked {
+		return false, nil
+	}
+	entry, err := readOutboxEntry(outboxEntryPath(task, source))
+	if err != nil || entry == nil || entry.Delivered == nil {
+		return false, err
+	}
+
+	taskSource.LastSync = entry.Delivered.SyncedAt
+	taskSource.Snapshot = entry.Delivered
+	taskSource.PendingConflicts = nil
+	if err := m.updateSourceMetadata(task.MetadataPath, source, taskSource); err != nil {
+		return false, err
+	}
+	m.logger.Info("outbox entry already delivered", "task_id", task.ID, "source", source, "entry", entry.ID)
+
+	if len(pendingFields(task, source)) == 0 {
+		if err := os.Remove(outboxEntryPath(task, source)); err != nil && !os.IsNotExist(err) {
+			return true, err
+		}
+		return true, nil
+	}
+	entry.Delivered = nil
+	return true, writeOutboxEntry(task, entry)
+}
+
+// ListOutbox returns the entries waiting in the outboxes of tasks, oldest first, for
+// the task taskID only when it is set
+func (m *Manager) ListOutbox(ctx context.Context, taskID string) ([]*OutboxEntry, error) {
+	var tasks []*Task
+	if taskID != "" {
+		task, err := m.GetTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		tasks = []*Task{task}
+	} else {
+		var err error
+		if tasks, err = m.ListTasks(ctx, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	entries := []*OutboxEntry{}
+	for _, task := range tasks {
+		dir := filepath.Join(task.MetadataPath, outboxDir)
+		files, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read outbox of %s: %w", task.ID, err)
+		}
+		for _, file := range files {
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+				continue
+			}
+			entry, err := readOutboxEntry(filepath.Join(dir, file.Name()))
+			if err != nil {
+				return nil, err
+			}
+			if entry != nil {
+				// A task renamed since the entry was recorded keeps its entries
+				entry.TaskID = task.ID
+				entries = append(entries, entry)
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries, nil
+}
+
+// DeliverOutbox retries the outbox entries whose next attempt is due by now, returning
+// the outcome of each. Entries another command is pushing are skipped. An entry is
+// retried only while it is waiting and the task still has changes to push, so that
+// each change reaches its source once.
+func (m *Manager) DeliverOutbox(ctx context.Context, now time.Time) []*OutboxResult {
+	entries, err := m.ListOutbox(ctx, "")
+	if err != nil {
+		m.logger.Debug("failed to list outbox", "error", err)
+		return nil
+	}
+
+	results := []*OutboxResult{}
+	for _, entry := range entries {
+		if entry.NextAttempt.After(now) {
+			continue
+		}
+		if result := m.deliverOutboxEntry(ctx, entry); result != nil {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// deliverOutboxEntry retries the sync of an outbox entry, returning nil when another
+// command is pushing the task or delivered the entry since it was read
+func (m *Manager) deliverOutboxEntry(ctx context.Context, entry *OutboxEntry) *OutboxResult {
+	result := &OutboxResult{TaskID: entry.TaskID, Source: entry.Source, EntryID: entry.ID}
+
+	task, err := m.GetTask(ctx, entry.TaskID)
+	if err != nil {
+		result.Status, result.Error = OutboxFailed, err.Error()
+		return result
+	}
+	lock, err := lockOutbox(ctx, task, entry.Source, true)
+	if err != nil {
+		return nil
+	}
+	defer lock.Release()
+
+	// The task and its entry are read again under the lock, as they were before the
+	// push holding it ended
+	task, err = m.GetTask(ctx, entry.TaskID)
+	if err != nil {
+		result.Status, result.Error = OutboxFailed, err.Error()
+		return result
+	}
+	current, err := readOutboxEntry(outboxEntryPath(task, entry.Source))
+	if err != nil || current == nil || current.ID != entry.ID {
+		return nil
+	}
+	if current.Delivered != nil {
+		if _, err := m.recordDeliveredPush(task, current.Source); err != nil {
+			result.Status, result.Error = OutboxFailed, err.Error()
+			return result
+		}
+		if len(pendingFields(task, current.Source)) == 0 {
+			result.Status = OutboxDelivered
+			return result
+		}
+	}
+	if _, linked := task.Sources[entry.Source]; !linked || len(pendingFields(task, entry.Source)) == 0 {
+		// Unlinked from the source, or its changes reached the source by a sync since
+		if err := os.Remove(outboxEntryPath(task, entry.Source)); err != nil && !os.IsNotExist(err) {
+			result.Status, result.Error = OutboxFailed, err.Error()
+			return result
+		}
+		result.Status = OutboxCleared
+		return result
+	}
+
+	synced, err := m.syncTask(withOutboxHeld(ctx), task.ID, &SyncOptions{
+		Direction:        current.Direction,
+		ConflictStrategy: current.ConflictStrategy,
+		FieldPolicies:    current.FieldPolicies,
+		Sources:          []string{current.Source},
+	}, nil)
+	if err != nil || synced == nil || !synced.Success {
+		result.Status, result.Error = OutboxFailed, syncFailure(synced, err)
+		return result
+	}
+	result.Status = OutboxDelivered
+	m.logger.Info("outbox entry delivered", "task_id", task.ID, "source", current.Source, "entry", current.ID)
+	return result
+}
+
+// pendingFields returns the fields of task changed locally since its last sync with
+// source, every synced field when it has not been synced
+func pendingFields(task *Task, source string) []string {
+	taskSource, ok := task.Sources[source]
+	if !ok {
+		return nil
+	}
+	if taskSource.Snapshot == nil {
+		return append([]string{}, syncedFields...)
+	}
+	base := &Task{
+		Title:       taskSource.Snapshot.Title,
+		Description: taskSource.Snapshot.Description,
+		Status:      taskSource.Snapshot.Status,
+		Priority:    taskSource.Snapshot.Priority,
+		Owner:       taskSource.Snapshot.Owner,
+		Labels:      taskSource.Snapshot.Labels,
+	}
+	return changedFieldNames(diffTaskFields(ChangeSideLocal, base, task, syncedFields))
+}
+
+// outboxBackoff returns the wait before the retry following attempts failed pushes
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxRetryDelay
+	for i := 1; i < attempts && delay < outboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxRetryDelay)
+}
+
+// syncFailure describes why a sync did not succeed
+func syncFailure(result *SyncResult, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	if result != nil && result.Error != "" {
+		return result.Error
+	}
+	return "sync did not complete"
+}
+
+// outboxEntryPath returns the file of the outbox entry of source in task
+func outboxEntryPath(task *Task, source string) string {
+	return filepath.Join(task.MetadataPath, outboxDir, source+".json")
+}
+
+// readOutboxEntry reads the outbox entry in path, returning nil when there is none
+func readOutboxEntry(path string) (*OutboxEntry, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - reading task metadata from workspace path
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox entry: %w", err)
+	}
+	entry := &OutboxEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, fmt.Errorf("invalid outbox entry %s: %w", filepath.Base(path), err)
+	}
+	return entry, nil
+}
+
+// writeOutboxEntry writes entry to the outbox of task, replacing the file so that an
+// interrupted write leaves the entry as it was
+func writeOutboxEntry(task *Task, entry *OutboxEntry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox entry: %w", err)
+	}
+	path := outboxEntryPath(task, entry.Source)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write outbox entry: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write outbox entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/factory"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTracker records the updates pushed to it, failing while err is set
+type fakeTracker struct {
+	plugin.IntegrationPluginInterface
+	err      error
+	updates  []*plugin.TaskData
+	onUpdate func()
+}
+
+func (f *fakeTracker) UpdateTask(ctx context.Context, externalID string, data *plugin.TaskData, opts *plugin.UpdateOptions) (*plugin.TaskData, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.onUpdate != nil {
+		f.onUpdate()
+	}
+	f.updates = append(f.updates, data)
+	return data, nil
+}
+
+type fakeClientFactory struct {
+	factory.ClientFactoryInterface
+	tracker *fakeTracker
+}
+
+func (f *fakeClientFactory) CreatePlugin(ctx context.Context, providerName string) (plugin.IntegrationPluginInterface, error) {
+	return f.tracker, nil
+}
+
+// newOutboxTestManager returns a manager whose PROJ-1 is linked to jira, with its title
+// changed locally since the last sync
+func newOutboxTestManager(t *testing.T) (*Manager, *fakeTracker) {
+	t.Helper()
+	m, tasksDir := newTestManager(t)
+	tracker := &fakeTracker{}
+	m.clientFactory = &fakeClientFactory{tracker: tracker}
+
+	metadataDir := filepath.Join(tasksDir, "PROJ-1", "metadata")
+	require.NoError(t, os.MkdirAll(metadataDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(metadataDir, "jira.json"), []byte(`{
+  "external_id": "PROJ-1",
+  "last_sync": "2026-01-01T00:00:00Z",
+  "snapshot": {"title": "Add a login page", "status": "proposed", "priority": "P2", "owner": "alice"}
+}`), 0600))
+	return m, tracker
+}
+
+func TestSyncTask_Outbox(t *testing.T) {
+	m, tracker := newOutboxTestManager(t)
+	ctx := context.Background()
+	tracker.err = errors.New("jira unavailable")
+
+	result, err := m.SyncTask(ctx, "PROJ-1", &SyncOptions{Direction: SyncDirectionPush})
+	require.Error(t, err)
+	assert.True(t, result.Queued)
+
+	entries, err := m.ListOutbox(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "PROJ-1", entry.TaskID)
+	assert.Equal(t, "jira", entry.Source)
+	assert.Equal(t, []string{"title"}, entry.Fields)
+	assert.Equal(t, 1, entry.Attempts)
+	assert.Equal(t, "jira unavailable", entry.LastError)
+
+	assert.Empty(t, m.DeliverOutbox(ctx, time.Now()), "retries wait for their next attempt")
+
+	tracker.err = nil
+	results := m.DeliverOutbox(ctx, entry.NextAttempt)
+	require.Len(t, results, 1)
+	assert.Equal(t, OutboxDelivered, results[0].Status)
+	assert.Equal(t, entry.ID, results[0].EntryID)
+	require.Len(t, tracker.updates, 1)
+	assert.Equal(t, "Add login page", tracker.updates[0].Title)
+
+	entries, err = m.ListOutbox(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Empty(t, m.DeliverOutbox(ctx, entry.NextAttempt), "a delivered push is not sent again")
+	assert.Len(t, tracker.updates, 1)
+}
+
+func TestSyncTask_OutboxRetriedBySync(t *testing.T) {
+	m, tracker := newOutboxTestManager(t)
+	ctx := context.Background()
+	tracker.err = errors.New("jira unavailable")
+
+	_, err := m.SyncTask(ctx, "PROJ-1", &SyncOptions{Direction: SyncDirectionPush})
+	require.Error(t, err)
+
+	tracker.err = nil
+	result, err := m.SyncTask(ctx, "PROJ-1", &SyncOptions{Direction: SyncDirectionPush})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.False(t, result.Queued)
+
+	entries, err := m.ListOutbox(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestDeliverOutbox_Cleared(t *testing.T) {
+	m, tracker := newOutboxTestManager(t)
+	ctx := context.Background()
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+
+	entry, err := m.queuePush(task, "jira", &SyncOptions{Direction: SyncDirectionPush})
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+
+	// The change reaches jira by other means, such as a pull of the same title
+	task.Sources["jira"].Snapshot = snapshotOf(task)
+	require.NoError(t, m.saveTask(ctx, task))
+
+	results := m.DeliverOutbox(ctx, time.Now())
+	require.Len(t, results, 1)
+	assert.Equal(t, OutboxCleared, results[0].Status)
+	assert.Empty(t, tracker.updates, "nothing is pushed once the changes reached the source")
+}
+
+func TestDeliverOutbox_AlreadyDelivered(t *testing.T) {
+	m, tracker := newOutboxTestManager(t)
+	ctx := context.Background()
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+
+	// zen stops after jira accepted the push, before the task recorded it
+	_, err = m.queuePush(task, "jira", &SyncOptions{Direction: SyncDirectionPush})
+	require.NoError(t, err)
+	m.markPushDelivered(task, "jira", snapshotOf(task))
+
+	results := m.DeliverOutbox(ctx, time.Now())
+	require.Len(t, results, 1)
+	assert.Equal(t, OutboxDelivered, results[0].Status)
+	assert.Empty(t, tracker.updates, "a push the source accepted is not sent again")
+
+	task, err = m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Add login page", task.Sources["jira"].Snapshot.Title)
+	entries, err := m.ListOutbox(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestSyncTask_OutboxAlreadyDelivered(t *testing.T) {
+	m, tracker := newOutboxTestManager(t)
+	ctx := context.Background()
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+
+	_, err = m.queuePush(task, "jira", &SyncOptions{Direction: SyncDirectionPush})
+	require.NoError(t, err)
+	m.markPushDelivered(task, "jira", snapshotOf(task))
+
+	result, err := m.SyncTask(ctx, "PROJ-1", &SyncOptions{Direction: SyncDirectionPush})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Empty(t, tracker.updates, "a push the source accepted is not sent again")
+
+	// Changes made since are pushed, once
+	title := "Add a login form"
+	_, err = m.UpdateTask(ctx, "PROJ-1", &TaskUpdates{Title: &title})
+	require.NoError(t, err)
+	_, err = m.SyncTask(ctx, "PROJ-1", &SyncOptions{Direction: SyncDirectionPush})
+	require.NoError(t, err)
+	require.Len(t, tracker.updates, 1)
+	assert.Equal(t, "Add a login form", tracker.updates[0].Title)
+
+	entries, err := m.ListOutbox(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestSyncTask_OutboxMarksDelivered(t *testing.T) {
+	m, tracker := newOutboxTestManager(t)
+	ctx := context.Background()
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+
+	_, err = m.queuePush(task, "jira", &SyncOptions{Direction: SyncDirectionPush})
+	require.NoError(t, err)
+	tracker.onUpdate = func() {
+		// The entry is not yet marked while the source has not answered
+		entry, err := readOutboxEntry(outboxEntryPath(task, "jira"))
+		require.NoError(t, err)
+		assert.Nil(t, entry.Delivered)
+	}
+	_, err = m.pushTask(ctx, task, "jira")
+	require.NoError(t, err)
+
+	entry, err := readOutboxEntry(outboxEntryPath(task, "jira"))
+	require.NoError(t, err)
+	require.NotNil(t, entry.Delivered)
+	assert.Equal(t, "Add login page", entry.Delivered.Title)
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, outboxBackoff(1))
+	assert.Equal(t, 4*time.Minute, outboxBackoff(3))
+	assert.Equal(t, time.Hour, outboxBackoff(20))
+}
@@ -0,0 +1,105 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/daddia/zen/internal/integration"
+)
+
+// SyncPlan is what syncing a task with a source would change, worked out without
+// changing anything on either side
+type SyncPlan struct {
+	TaskID    string        `json:"task_id"`
+	Source    string        `json:"source"`
+	Direction SyncDirection `json:"direction"`
+
+	// Conflicts are the fields that differ between the task and the source, with the
+	// policy that resolves each one and the value it keeps
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// PlanSync works out how syncing a task would resolve the fields changed on both the
+// task and its source since their last sync, using opts.FieldPolicies and
+// opts.ConflictStrategy. The source is read but nothing is written.
+func (m *Manager) PlanSync(ctx context.Context, taskID string, opts *SyncOptions) (*SyncPlan, error) {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	source := syncSource(task, opts)
+	taskSource, ok := task.Sources[source]
+	if !ok {
+		return nil, fmt.Errorf("task %s is not linked to source %s", taskID, source)
+	}
+
+	remote, err := m.fetchFromSource(ctx, taskSource.ExternalID, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from %s: %w", source, err)
+	}
+
+	conflicts, err := planConflicts(taskSource.Snapshot, task, remote, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SyncPlan{
+		TaskID:    taskID,
+		Source:    source,
+		Direction: opts.Direction,
+		Conflicts: conflicts,
+	}, nil
+}
+
+// planConflicts merges the task with its source against base, the snapshot of their
+// last sync, and returns the conflicts the merge resolves
+func planConflicts(base *SyncSnapshot, task *Task, remote *TaskData, opts *SyncOptions) ([]Conflict, error) {
+	merge, err := mergeThreeWay(base, task, remote, opts)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Conflict{}, merge.Conflicts...), nil
+}
+
+// ValidateFieldPolicies checks that every field policy is a known conflict strategy, and
+// that union is only used for list fields
+func ValidateFieldPolicies(policies map[string]ConflictStrategy) error {
+	return fieldPolicies(policies).Validate()
+}
+
+// fieldPolicies converts field policies to the integration service's form
+func fieldPolicies(policies map[string]ConflictStrategy) integration.FieldPolicies {
+	converted := make(integration.FieldPolicies, len(policies))
+	for field, strategy := range policies {
+		converted[field] = integration.ConflictStrategy(strategy)
+	}
+	return converted
+}
+
+// sameValue reports whether two field values are equal. Lists are equal when they hold
+// the same values in any order, ignoring case.
+func sameValue(a, b interface{}) bool {
+	aList, aOK := a.([]string)
+	bList, bOK := b.([]string)
+	if !aOK || !bOK {
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	}
+
+	if len(aList) != len(bList) {
+		return false
+	}
+	counts := map[string]int{}
+	for _, value := range aList {
+		counts[strings.ToLower(value)]++
+	}
+	for _, value := range bList {
+		key := strings.ToLower(value)
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanConflicts(t *testing.T) {
+	now := time.Now()
+	local := &Task{
+		Title:       "Local title",
+		Description: "Local description",
+		Status:      "in_progress",
+		Priority:    "P1",
+		Labels:      []string{"backend", "api"},
+		Updated:     now.Add(-time.Hour),
+	}
+	remote := &TaskData{
+		Title:       "Remote title",
+		Description: "Remote description",
+		Status:      "in_progress",
+		Priority:    "",
+		Labels:      []string{"API", "urgent"},
+		Updated:     now,
+	}
+
+	conflicts, err := planConflicts(nil, local, remote, &SyncOptions{
+		ConflictStrategy: ConflictStrategyLocalWins,
+		FieldPolicies: map[string]ConflictStrategy{
+			"title":  ConflictStrategyRemoteWins,
+			"labels": ConflictStrategyUnion,
+		},
+	})
+	require.NoError(t, err)
+
+	byField := map[string]Conflict{}
+	for _, conflict := range conflicts {
+		byField[conflict.Field] = conflict
+	}
+	assert.Len(t, byField, 3, "equal fields and fields the source leaves empty are not conflicts")
+
+	assert.Equal(t, ConflictStrategyRemoteWins, byField["title"].Strategy)
+	assert.Equal(t, "remote", byField["title"].Resolution)
+	assert.Equal(t, "Remote title", byField["title"].Value)
+
+	assert.Equal(t, ConflictStrategyLocalWins, byField["description"].Strategy, "fields without a policy use the conflict strategy")
+	assert.Equal(t, "Local description", byField["description"].Value)
+
+	assert.Equal(t, "merged", byField["labels"].Resolution)
+	assert.Equal(t, []string{"backend", "api", "urgent"}, byField["labels"].Value)
+}
+
+func TestPlanConflicts_InvalidPolicy(t *testing.T) {
+	_, err := planConflicts(nil, &Task{}, &TaskData{}, &SyncOptions{
+		ConflictStrategy: ConflictStrategyTimestamp,
+		FieldPolicies:    map[string]ConflictStrategy{"status": ConflictStrategyUnion},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid field policies")
+}
+
+func TestSameValue(t *testing.T) {
+	assert.True(t, sameValue("a", "a"))
+	assert.False(t, sameValue("a", "b"))
+	assert.True(t, sameValue([]string{"API", "backend"}, []string{"backend", "api"}), "lists ignore order and case")
+	assert.False(t, sameValue([]string{"api"}, []string{"api", "api"}))
+}
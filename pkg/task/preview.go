@@ -0,0 +1,166 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Sides of a sync that a FieldChange applies to
+const (
+	ChangeSideLocal  = "local"
+	ChangeSideRemote = "remote"
+)
+
+// FieldChange is a change sync makes to a field of the task (local) or of its source
+// (remote)
+type FieldChange struct {
+	Side  string      `json:"side"`
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// previewFields are the task fields a sync can change, in the order they are reported
+var previewFields = []string{"title", "description", "status", "priority", "owner", "team", "labels"}
+
+// previewSync works out the field changes syncing a task with source would make on each
+// side, in opts.Direction, using prefetched instead of fetching when it is set. Nothing
+// is written to the task or the source.
+func (m *Manager) previewSync(ctx context.Context, task *Task, source string, opts *SyncOptions, prefetched *TaskData) (*SyncResult, error) {
+	start := time.Now()
+	result := &SyncResult{
+		TaskID:    task.ID,
+		Source:    source,
+		Direction: opts.Direction,
+		DryRun:    true,
+	}
+	fail := func(err error) (*SyncResult, error) {
+		result.Error = err.Error()
+		result.Duration = time.Since(start)
+		result.Timestamp = time.Now()
+		return result, err
+	}
+
+	taskSource, ok := task.Sources[source]
+	if !ok {
+		return nil, fmt.Errorf("task %s is not linked to source %s", task.ID, source)
+	}
+
+	remote := prefetched
+	if remote == nil {
+		var err error
+		remote, err = m.fetchFromSource(ctx, taskSource.ExternalID, source)
+		if err != nil {
+			return fail(fmt.Errorf("failed to fetch from %s: %w", source, err))
+		}
+	}
+	remoteTask := &Task{
+		Title:       remote.Title,
+		Description: remote.Description,
+		Status:      remote.Status,
+		Priority:    remote.Priority,
+		Owner:       remote.Owner,
+		Team:        remote.Team,
+		Labels:      remote.Labels,
+	}
+
+	switch opts.Direction {
+	case SyncDirectionPull:
+		pulled := *task
+		if err := m.updateTaskFromSourceData(ctx, &pulled, remote, source); err != nil {
+			return fail(err)
+		}
+		explainPull(ctx, task, &pulled, remote, source)
+		result.Changes = diffTaskFields(ChangeSideLocal, task, &pulled, previewFields)
+
+	case SyncDirectionPush:
+		result.Changes = diffTaskFields(ChangeSideRemote, remoteTask, task, previewFields)
+
+	case SyncDirectionBidirectional:
+		merge, err := mergeThreeWay(taskSource.Snapshot, task, remote, opts)
+		if err != nil {
+			return fail(err)
+		}
+		explainAll(ctx, merge.Decisions)
+		result.Conflicts = merge.Conflicts
+
+		if manual := merge.manualConflicts(); manual > 0 && !opts.Force {
+			result.Error = fmt.Sprintf("%d conflicting field(s) need manual review; the sync would change nothing", manual)
+			result.Duration = time.Since(start)
+			result.Timestamp = time.Now()
+			return result, nil
+		}
+
+		merged := *task
+		merged.Title = merge.Merged.Title
+		merged.Description = merge.Merged.Description
+		merged.Status = merge.Merged.Status
+		merged.Priority = merge.Merged.Priority
+		merged.Owner = merge.Merged.Owner
+		merged.Labels = merge.Merged.Labels
+		explainMerge(ctx, merge, &merged, source)
+
+		result.Changes = append(
+			diffTaskFields(ChangeSideLocal, task, &merged, merge.LocalChanges),
+			diffTaskFields(ChangeSideRemote, remoteTask, &merged, merge.RemoteChanges)...)
+	}
+
+	result.Success = true
+	result.ChangedFields = changedFieldNames(result.Changes)
+	result.Duration = time.Since(start)
+	result.Timestamp = time.Now()
+	return result, nil
+}
+
+// diffTaskFields returns the changes to fields that turn the values of from into those
+// of to
+func diffTaskFields(side string, from, to *Task, fields []string) []FieldChange {
+	allowed := map[string]bool{}
+	for _, field := range fields {
+		allowed[field] = true
+	}
+
+	before, after := taskFieldValues(from), taskFieldValues(to)
+	var changes []FieldChange
+	for _, field := range previewFields {
+		if !allowed[field] || sameValue(before[field], after[field]) {
+			continue
+		}
+		changes = append(changes, FieldChange{Side: side, Field: field, From: before[field], To: after[field]})
+	}
+	return changes
+}
+
+// taskFieldValues returns the value of each field in previewFields
+func taskFieldValues(task *Task) map[string]interface{} {
+	labels := task.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	return map[string]interface{}{
+		"title":       task.Title,
+		"description": task.Description,
+		"status":      task.Status,
+		"priority":    task.Priority,
+		"owner":       task.Owner,
+		"team":        task.Team,
+		"labels":      labels,
+	}
+}
+
+// changedFieldNames returns the fields changed on either side, in previewFields order
+func changedFieldNames(changes []FieldChange) []string {
+	changed := map[string]bool{}
+	for _, change := range changes {
+		changed[change.Field] = true
+	}
+
+	var fields []string
+	for _, field := range previewFields {
+		if changed[field] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
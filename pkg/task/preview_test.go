@@ -0,0 +1,92 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewSync(t *testing.T) {
+	newTask := func() *Task {
+		return &Task{
+			ID:       "PROJ-1",
+			Title:    "Add login",
+			Status:   "in_progress",
+			Priority: "P2",
+			Labels:   []string{"auth"},
+			Sources: map[string]*TaskSource{"jira": {
+				ExternalID: "PROJ-1",
+				Snapshot:   &SyncSnapshot{Title: "Add login", Status: "proposed", Priority: "P2", Labels: []string{"auth"}},
+			}},
+		}
+	}
+	remote := &TaskData{Title: "Add SSO login", Status: "proposed", Priority: "P2", Labels: []string{"auth"}}
+
+	tests := []struct {
+		name      string
+		direction SyncDirection
+		changes   []FieldChange
+	}{
+		{
+			name:      "pull changes the task",
+			direction: SyncDirectionPull,
+			changes: []FieldChange{
+				{Side: ChangeSideLocal, Field: "title", From: "Add login", To: "Add SSO login"},
+				{Side: ChangeSideLocal, Field: "status", From: "in_progress", To: "proposed"},
+			},
+		},
+		{
+			name:      "push changes the source",
+			direction: SyncDirectionPush,
+			changes: []FieldChange{
+				{Side: ChangeSideRemote, Field: "title", From: "Add SSO login", To: "Add login"},
+				{Side: ChangeSideRemote, Field: "status", From: "proposed", To: "in_progress"},
+			},
+		},
+		{
+			name:      "bidirectional merges both ways",
+			direction: SyncDirectionBidirectional,
+			changes: []FieldChange{
+				{Side: ChangeSideLocal, Field: "title", From: "Add login", To: "Add SSO login"},
+				{Side: ChangeSideRemote, Field: "status", From: "proposed", To: "in_progress"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := newTask()
+			result, err := (&Manager{}).previewSync(context.Background(), task, "jira", &SyncOptions{
+				Direction:        tt.direction,
+				ConflictStrategy: ConflictStrategyManualReview,
+			}, remote)
+			require.NoError(t, err)
+
+			assert.True(t, result.DryRun)
+			assert.True(t, result.Success)
+			assert.Equal(t, tt.changes, result.Changes)
+			assert.Equal(t, "Add login", task.Title, "the task is not changed")
+		})
+	}
+}
+
+func TestPreviewSync_ManualConflicts(t *testing.T) {
+	task := &Task{
+		ID:      "PROJ-1",
+		Title:   "Local",
+		Sources: map[string]*TaskSource{"jira": {ExternalID: "PROJ-1"}},
+	}
+
+	result, err := (&Manager{}).previewSync(context.Background(), task, "jira", &SyncOptions{
+		Direction:        SyncDirectionBidirectional,
+		ConflictStrategy: ConflictStrategyManualReview,
+	}, &TaskData{Title: "Remote"})
+	require.NoError(t, err)
+
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "need manual review")
+	assert.Empty(t, result.Changes)
+	assert.Len(t, result.Conflicts, 1)
+}
@@ -0,0 +1,149 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/plugin"
+)
+
+// stageOptions returns the options of the status field of the project board of source
+// that the workflow stages map to: settings.stage_options of the provider, with the
+// stages it leaves out mapped to the option of the same name ("05-build" to "Build")
+func (m *Manager) stageOptions(source string) map[string]string {
+	options := map[string]string{}
+	for _, stage := range WorkflowStages {
+		options[stage] = StageName(stage)
+	}
+
+	cfg, err := m.factory.Config()
+	if err != nil {
+		return options
+	}
+	configured, _ := cfg.Integrations.Providers[source].Settings["stage_options"].(map[string]interface{})
+	for stage, option := range configured {
+		if name, ok := option.(string); ok && StageIndex(stage) >= 0 {
+			options[stage] = name
+		}
+	}
+	return options
+}
+
+// stageOfOption returns the stage a status option maps to, or "" when none does. When
+// several stages share the option, the task is in the first of them.
+func stageOfOption(option string, options map[string]string) string {
+	if option == "" {
+		return ""
+	}
+	for _, stage := range WorkflowStages {
+		if strings.EqualFold(options[stage], option) {
+			return stage
+		}
+	}
+	return ""
+}
+
+// projectFieldSyncer returns the plugin of source when it syncs project board fields
+func (m *Manager) projectFieldSyncer(ctx context.Context, source string) plugin.ProjectFieldSyncer {
+	pluginInstance, err := m.getOrCreatePlugin(ctx, source)
+	if err != nil {
+		return nil
+	}
+	syncer, _ := pluginInstance.(plugin.ProjectFieldSyncer)
+	return syncer
+}
+
+// pullProjectFields moves a task to the stage of its item on the project board of source.
+// Boards are secondary to the task itself, so failures are logged rather than returned.
+func (m *Manager) pullProjectFields(ctx context.Context, task *Task, source string) {
+	syncer := m.projectFieldSyncer(ctx, source)
+	if syncer == nil {
+		return
+	}
+	if err := applyProjectFields(ctx, task, source, syncer, m.stageOptions(source)); err != nil {
+		m.logger.Warn("failed to pull project fields", "task_id", task.ID, "source", source, "error", err)
+	}
+}
+
+// pushProjectFields sets the status of a task's item on the project board of source to
+// the option of its stage, reporting whether it did
+func (m *Manager) pushProjectFields(ctx context.Context, task *Task, source string) bool {
+	syncer := m.projectFieldSyncer(ctx, source)
+	if syncer == nil {
+		return false
+	}
+	if err := pushProjectStage(ctx, task, source, syncer, m.stageOptions(source)); err != nil {
+		m.logger.Warn("failed to push project fields", "task_id", task.ID, "source", source, "error", err)
+		return false
+	}
+	return true
+}
+
+// applyProjectFields records the board fields of a task's item and moves the task to the
+// stage its status maps to
+func applyProjectFields(ctx context.Context, task *Task, source string, syncer plugin.ProjectFieldSyncer, options map[string]string) error {
+	taskSource, ok := task.Sources[source]
+	if !ok {
+		return fmt.Errorf("task %s is not linked to source %s", task.ID, source)
+	}
+	fields, err := syncer.FetchProjectFields(ctx, taskSource.ExternalID)
+	if err != nil {
+		return err
+	}
+	taskSource.Project = fields
+	if fields == nil {
+		return nil
+	}
+
+	stage := stageOfOption(fields.Status, options)
+	if stage == "" || stage == task.CurrentStage {
+		return nil
+	}
+	updates := stageTransitionFields(task, stage, time.Now())
+	updates["workflow.current_stage"] = stage
+	if err := checkTaskVersion(ctx, task); err != nil {
+		return err
+	}
+	if err := updateManifestFields(task.ManifestPath, updates); err != nil {
+		return fmt.Errorf("failed to move task to stage %s: %w", stage, err)
+	}
+	refreshTaskVersion(task)
+	task.CurrentStage = stage
+	return nil
+}
+
+// pushProjectStage sets the status of a task's item on the board to the option of its
+// stage, adding the task to the board when it is not on it
+func pushProjectStage(ctx context.Context, task *Task, source string, syncer plugin.ProjectFieldSyncer, options map[string]string) error {
+	taskSource, ok := task.Sources[source]
+	if !ok {
+		return fmt.Errorf("task %s is not linked to source %s", task.ID, source)
+	}
+	option := options[task.CurrentStage]
+	if option == "" {
+		return nil
+	}
+	fields, err := syncer.UpdateProjectFields(ctx, taskSource.ExternalID, &plugin.ProjectFields{Status: option})
+	if err != nil {
+		return err
+	}
+	taskSource.Project = fields
+	return nil
+}
+
+// decodeProjectFields reads board fields from parsed source metadata, returning nil when
+// they cannot be read
+func decodeProjectFields(value interface{}) *plugin.ProjectFields {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var fields plugin.ProjectFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return &fields
+}
@@ -0,0 +1,109 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProjectSyncer keeps the board fields of one item in memory
+type fakeProjectSyncer struct {
+	fields  *plugin.ProjectFields
+	updates []plugin.ProjectFields
+}
+
+func (f *fakeProjectSyncer) FetchProjectFields(ctx context.Context, externalID string) (*plugin.ProjectFields, error) {
+	return f.fields, nil
+}
+
+func (f *fakeProjectSyncer) UpdateProjectFields(ctx context.Context, externalID string, fields *plugin.ProjectFields) (*plugin.ProjectFields, error) {
+	f.updates = append(f.updates, *fields)
+	f.fields = &plugin.ProjectFields{Project: "my-org/7", Status: fields.Status}
+	return f.fields, nil
+}
+
+func TestManagerStageOptions(t *testing.T) {
+	m, _ := newTestManager(t)
+	cfg, err := m.factory.Config()
+	require.NoError(t, err)
+	cfg.Integrations.Providers = map[string]config.IntegrationProviderConfig{
+		"github": {Settings: map[string]interface{}{"stage_options": map[string]interface{}{
+			"01-align":    "Backlog",
+			"02-discover": "Backlog",
+			"05-build":    "In Progress",
+			"99-unknown":  "Ignored",
+		}}},
+	}
+
+	options := m.stageOptions("github")
+	assert.Equal(t, "In Progress", options["05-build"])
+	assert.Equal(t, "Ship", options["06-ship"], "stages left out map to the option of their name")
+	assert.NotContains(t, options, "99-unknown")
+
+	assert.Equal(t, "01-align", stageOfOption("backlog", options), "the first of the stages sharing an option")
+	assert.Equal(t, "05-build", stageOfOption("In Progress", options))
+	assert.Empty(t, stageOfOption("Won't do", options))
+	assert.Equal(t, "04-design", stageOfOption("Design", m.stageOptions("jira")))
+}
+
+func TestApplyProjectFields(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	task.Sources["github"] = &TaskSource{System: "github", ExternalID: "12"}
+	options := m.stageOptions("github")
+
+	syncer := &fakeProjectSyncer{fields: &plugin.ProjectFields{Project: "my-org/7", Status: "Build", Iteration: "Sprint 4"}}
+	require.NoError(t, applyProjectFields(ctx, task, "github", syncer, options))
+	assert.Equal(t, "05-build", task.CurrentStage)
+	assert.Equal(t, "Sprint 4", task.Sources["github"].Project.Iteration)
+
+	data, err := os.ReadFile(filepath.Join(tasksDir, "PROJ-1", "manifest.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "current_stage: 05-build")
+
+	// Options without a stage leave the task where it is
+	syncer.fields.Status = "Triage"
+	require.NoError(t, applyProjectFields(ctx, task, "github", syncer, options))
+	assert.Equal(t, "05-build", task.CurrentStage)
+
+	// and so do tasks that are not on the board
+	syncer.fields = nil
+	require.NoError(t, applyProjectFields(ctx, task, "github", syncer, options))
+	assert.Nil(t, task.Sources["github"].Project)
+
+	assert.ErrorContains(t, applyProjectFields(ctx, task, "linear", syncer, options), "not linked to source linear")
+}
+
+func TestPushProjectStage(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	task.Sources["github"] = &TaskSource{System: "github", ExternalID: "12"}
+
+	syncer := &fakeProjectSyncer{}
+	require.NoError(t, pushProjectStage(ctx, task, "github", syncer, map[string]string{"01-align": "Todo"}))
+	require.Len(t, syncer.updates, 1)
+	assert.Equal(t, plugin.ProjectFields{Status: "Todo"}, syncer.updates[0])
+
+	// The board fields are kept with the source metadata
+	require.NoError(t, os.MkdirAll(task.MetadataPath, 0755))
+	require.NoError(t, m.updateSourceMetadata(task.MetadataPath, "github", task.Sources["github"]))
+	task, err = m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	require.NotNil(t, task.Sources["github"].Project)
+	assert.Equal(t, "Todo", task.Sources["github"].Project.Status)
+	assert.FileExists(t, filepath.Join(tasksDir, "PROJ-1", "metadata", "github.json"))
+
+	// Stages without an option are not pushed
+	require.NoError(t, pushProjectStage(ctx, task, "github", syncer, map[string]string{}))
+	assert.Len(t, syncer.updates, 1)
+}
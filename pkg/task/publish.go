@@ -0,0 +1,270 @@
+package task
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Artifacts a task publishes, as named on the command line
+const (
+	PublishIndex    = "index"
+	PublishADRs     = "adr"
+	PublishOutcomes = "outcomes"
+)
+
+// PublishArtifacts are the artifacts a task publishes when none are selected
+var PublishArtifacts = []string{PublishIndex, PublishADRs, PublishOutcomes}
+
+// indexFile is the overview of a task, in the task directory
+const indexFile = "index.md"
+
+// outcomesDir holds the outcome reports of a task, in the task directory
+const outcomesDir = "outcomes"
+
+// Publish actions reported by PublishResult
+const (
+	PublishCreated   = "created"
+	PublishUpdated   = "updated"
+	PublishUnchanged = "unchanged"
+)
+
+// PublishPage is a task artifact rendered as a page of a wiki
+type PublishPage struct {
+	// Artifact is the file of the page, relative to the task directory
+	Artifact string
+
+	Title string
+
+	// Markdown is the content of the artifact without its title heading
+	Markdown string
+
+	// ID is the page the artifact was published as before, which is updated in place;
+	// empty for new pages
+	ID string
+
+	// ParentID is the page the page is published under, or empty for the root of the
+	// target, such as the parent page or space of its configuration
+	ParentID string
+}
+
+// PublishedPage is a page a task artifact was published as, recorded in the manifest of
+// the task so that publishing again updates it in place
+type PublishedPage struct {
+	ID      string `json:"id" yaml:"id"`
+	URL     string `json:"url,omitempty" yaml:"url,omitempty"`
+	Version int    `json:"version,omitempty" yaml:"version,omitempty"`
+
+	// Checksum is of the title and content published, to skip artifacts that are unchanged
+	Checksum  string    `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+	Published time.Time `json:"published" yaml:"published"`
+}
+
+// Publisher publishes task artifacts as pages of a wiki, such as Confluence
+type Publisher interface {
+	// Name returns the target, as recorded in the manifest of tasks
+	Name() string
+
+	// PublishPage creates a page, or updates the page of page.ID in place
+	PublishPage(ctx context.Context, page *PublishPage) (*PublishedPage, error)
+}
+
+// PublishRequest contains parameters for publishing task artifacts
+type PublishRequest struct {
+	// Artifacts selects PublishIndex, PublishADRs and PublishOutcomes; empty selects all
+	Artifacts []string
+
+	// Force republishes artifacts that are unchanged since they were last published
+	Force bool
+}
+
+// PublishResult is an artifact as published
+type PublishResult struct {
+	Artifact string `json:"artifact"`
+	Title    string `json:"title"`
+	Action   string `json:"action"`
+	PublishedPage
+}
+
+// Publish publishes the selected artifacts of a task through publisher, with the index
+// as the parent page of the others. Artifacts published before are updated in place,
+// and those unchanged since are skipped unless the request forces them.
+func (m *Manager) Publish(ctx context.Context, taskID string, publisher Publisher, request *PublishRequest) ([]PublishResult, error) {
+	for _, artifact := range request.Artifacts {
+		if !slices.Contains(PublishArtifacts, artifact) {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("unknown artifact: %s", artifact),
+				Details: fmt.Sprintf("artifacts are %s", strings.Join(PublishArtifacts, ", ")),
+			}
+		}
+	}
+
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := publishPages(task, request.Artifacts)
+	if err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("task %s has no artifacts to publish", taskID),
+		}
+	}
+
+	target := publisher.Name()
+	if task.Published == nil {
+		task.Published = map[string]map[string]PublishedPage{}
+	}
+	published := task.Published[target]
+	if published == nil {
+		published = map[string]PublishedPage{}
+		task.Published[target] = published
+	}
+	parentID := published[indexFile].ID
+
+	var results []PublishResult
+	var publishErr error
+	changed := false
+	for _, page := range pages {
+		checksum := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(page.Title+"\n"+page.Markdown)))
+		prior, ok := published[page.Artifact]
+		if ok && prior.Checksum == checksum && !request.Force {
+			results = append(results, PublishResult{Artifact: page.Artifact, Title: page.Title, Action: PublishUnchanged, PublishedPage: prior})
+			continue
+		}
+
+		page.ID = prior.ID
+		if page.Artifact != indexFile {
+			page.ParentID = parentID
+		}
+		result, err := publisher.PublishPage(ctx, page)
+		if err != nil {
+			publishErr = fmt.Errorf("failed to publish %s: %w", page.Artifact, err)
+			break
+		}
+		result.Checksum, result.Published = checksum, time.Now()
+
+		action := PublishCreated
+		if ok && result.ID == prior.ID {
+			action = PublishUpdated
+		}
+		published[page.Artifact] = *result
+		changed = true
+		if page.Artifact == indexFile {
+			parentID = result.ID
+		}
+		results = append(results, PublishResult{Artifact: page.Artifact, Title: page.Title, Action: action, PublishedPage: *result})
+	}
+
+	// Record the pages that were published, so they are updated rather than duplicated
+	if changed {
+		if err := setManifestValue(task.ManifestPath, "published", task.Published); err != nil {
+			return results, fmt.Errorf("failed to record published pages: %w", err)
+		}
+		if err := updateManifestFields(task.ManifestPath, map[string]string{
+			"dates.last_updated": time.Now().Format("2006-01-02 15:04:05"),
+		}); err != nil {
+			return results, fmt.Errorf("failed to update task manifest: %w", err)
+		}
+	}
+
+	m.logger.Debug("task published", "task_id", taskID, "target", target, "pages", len(results))
+	return results, publishErr
+}
+
+// publishPages returns the pages of the selected artifacts of a task: its index, then
+// its decision records and outcome reports in order of their paths
+func publishPages(task *Task, artifacts []string) ([]*PublishPage, error) {
+	if len(artifacts) == 0 {
+		artifacts = PublishArtifacts
+	}
+
+	var files []string
+	if slices.Contains(artifacts, PublishIndex) {
+		files = append(files, indexFile)
+	}
+	for _, kind := range []struct{ name, dir string }{
+		{PublishADRs, DocumentADR.Dir},
+		{PublishOutcomes, outcomesDir},
+	} {
+		if !slices.Contains(artifacts, kind.name) {
+			continue
+		}
+		found, err := markdownFiles(task.WorkspacePath, kind.dir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, found...)
+	}
+
+	pages := make([]*PublishPage, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(task.WorkspacePath, filepath.FromSlash(file))) // #nosec G304 - reading task artifact from workspace path
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		title, markdown := splitTitle(string(content), strings.TrimSuffix(filepath.Base(file), ".md"))
+		if !strings.HasPrefix(title, task.ID) {
+			// Wiki page titles are often unique across a space, so they name the task
+			title = task.ID + ": " + title
+		}
+		pages = append(pages, &PublishPage{Artifact: file, Title: title, Markdown: markdown})
+	}
+	return pages, nil
+}
+
+// markdownFiles returns the Markdown files under dir of a task directory, relative to
+// the task directory, in order of their paths
+func markdownFiles(taskDir, dir string) ([]string, error) {
+	var files []string
+	root := filepath.Join(taskDir, filepath.FromSlash(dir))
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+		rel, err := filepath.Rel(taskDir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// splitTitle returns the text of the first line of a Markdown document when it is a
+// top-level heading, and the rest of the document; documents without one are titled
+// fallback
+func splitTitle(markdown, fallback string) (string, string) {
+	first, rest, _ := strings.Cut(strings.TrimLeft(markdown, "\n"), "\n")
+	if heading, ok := strings.CutPrefix(first, "# "); ok {
+		return strings.TrimSpace(heading), strings.TrimLeft(rest, "\n")
+	}
+	return fallback, markdown
+}
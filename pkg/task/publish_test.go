@@ -0,0 +1,142 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePublisher keeps published pages in memory
+type fakePublisher struct {
+	pages map[string]*PublishPage
+	calls []string
+}
+
+func (p *fakePublisher) Name() string {
+	return "confluence"
+}
+
+func (p *fakePublisher) PublishPage(ctx context.Context, page *PublishPage) (*PublishedPage, error) {
+	if p.pages == nil {
+		p.pages = map[string]*PublishPage{}
+	}
+	id := page.ID
+	if id == "" {
+		id = fmt.Sprintf("%d", len(p.pages)+1)
+	}
+	p.pages[id] = page
+	p.calls = append(p.calls, page.Artifact)
+	return &PublishedPage{ID: id, URL: "https://wiki.example.com/pages/" + id, Version: len(p.calls)}, nil
+}
+
+func writeArtifacts(t *testing.T, taskDir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(taskDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestManagerPublish(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	writeArtifacts(t, filepath.Join(tasksDir, "PROJ-1"), map[string]string{
+		"index.md":                           "# PROJ-1: Add login page\n\nUsers sign in.\n",
+		"design/decisions/0001-use-oauth.md": "# Use OAuth\n\nWe use OAuth.\n",
+		"design/decisions/notes.txt":         "not published",
+		"outcomes/release.md":                "Shipped.\n",
+	})
+	publisher := &fakePublisher{}
+
+	results, err := m.Publish(ctx, "PROJ-1", publisher, &PublishRequest{})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, []string{"index.md", "design/decisions/0001-use-oauth.md", "outcomes/release.md"}, publisher.calls)
+	for _, result := range results {
+		assert.Equal(t, PublishCreated, result.Action)
+	}
+
+	index := publisher.pages[results[0].ID]
+	assert.Equal(t, "PROJ-1: Add login page", index.Title, "titles naming the task are kept")
+	assert.Equal(t, "Users sign in.\n", index.Markdown)
+	assert.Empty(t, index.ParentID)
+
+	adr := publisher.pages[results[1].ID]
+	assert.Equal(t, "PROJ-1: Use OAuth", adr.Title)
+	assert.Equal(t, results[0].ID, adr.ParentID, "artifacts are published under the index")
+	assert.Equal(t, "PROJ-1: release", publisher.pages[results[2].ID].Title, "files without a heading are titled by name")
+
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	recorded := task.Published["confluence"]
+	require.Len(t, recorded, 3)
+	assert.Equal(t, results[1].ID, recorded["design/decisions/0001-use-oauth.md"].ID)
+	assert.NotEmpty(t, recorded["index.md"].Checksum)
+	assert.False(t, recorded["index.md"].Published.IsZero())
+}
+
+func TestManagerPublish_Republish(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	taskDir := filepath.Join(tasksDir, "PROJ-1")
+	writeArtifacts(t, taskDir, map[string]string{
+		"index.md":                           "# Add login page\n",
+		"design/decisions/0001-use-oauth.md": "# Use OAuth\n",
+	})
+	publisher := &fakePublisher{}
+
+	first, err := m.Publish(ctx, "PROJ-1", publisher, &PublishRequest{})
+	require.NoError(t, err)
+
+	writeArtifacts(t, taskDir, map[string]string{"design/decisions/0001-use-oauth.md": "# Use OAuth\n\nWith PKCE.\n"})
+	publisher.calls = nil
+	results, err := m.Publish(ctx, "PROJ-1", publisher, &PublishRequest{})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, PublishUnchanged, results[0].Action)
+	assert.Equal(t, first[0].ID, results[0].ID)
+	assert.Equal(t, PublishUpdated, results[1].Action)
+	assert.Equal(t, first[1].ID, results[1].ID, "pages are updated in place")
+	assert.Equal(t, []string{"design/decisions/0001-use-oauth.md"}, publisher.calls)
+	assert.Equal(t, first[0].ID, publisher.pages[first[1].ID].ParentID, "the parent of skipped indexes is kept")
+
+	publisher.calls = nil
+	results, err = m.Publish(ctx, "PROJ-1", publisher, &PublishRequest{Artifacts: []string{PublishIndex}, Force: true})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, PublishUpdated, results[0].Action)
+	assert.Equal(t, []string{"index.md"}, publisher.calls)
+}
+
+func TestManagerPublish_Errors(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		taskID  string
+		request *PublishRequest
+		code    types.ErrorCode
+	}{
+		{name: "unknown artifact", taskID: "PROJ-1", request: &PublishRequest{Artifacts: []string{"plan"}}, code: types.ErrorCodeInvalidInput},
+		{name: "no artifacts", taskID: "PROJ-1", request: &PublishRequest{}, code: types.ErrorCodeInvalidInput},
+		{name: "not found", taskID: "PROJ-2", request: &PublishRequest{}, code: types.ErrorCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.Publish(ctx, tt.taskID, &fakePublisher{}, tt.request)
+			var zenErr *types.Error
+			require.True(t, errors.As(err, &zenErr), "got %v", err)
+			assert.Equal(t, tt.code, zenErr.Code)
+		})
+	}
+}
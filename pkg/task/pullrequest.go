@@ -0,0 +1,87 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// pullRequestsDir holds the pull requests linked to a task. It lives under metadata/ but apart
+// from the per-system source files, so linked pull requests are never picked up by task sync.
+const pullRequestsDir = "pull_requests"
+
+var pullRequestFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// LinkPullRequest records a pull or merge request for the task, replacing an earlier record
+// of the same request
+func (m *Manager) LinkPullRequest(ctx context.Context, taskID string, source *TaskSource) error {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := writePullRequest(task.MetadataPath, source); err != nil {
+		return fmt.Errorf("failed to link pull request: %w", err)
+	}
+
+	if err := updateManifestFields(task.ManifestPath, map[string]string{
+		"dates.last_updated": time.Now().Format("2006-01-02 15:04:05"),
+	}); err != nil {
+		return fmt.Errorf("failed to update task manifest: %w", err)
+	}
+
+	m.logger.Debug("pull request linked", "task_id", taskID, "system", source.System, "external_id", source.ExternalID)
+	return nil
+}
+
+// writePullRequest stores a pull request record in the task's metadata directory
+func writePullRequest(metadataDir string, source *TaskSource) error {
+	dir := filepath.Join(metadataDir, pullRequestsDir)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(source, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	name := pullRequestFileChars.ReplaceAllString(source.System+"-"+source.ExternalID, "-")
+	return os.WriteFile(filepath.Join(dir, name+".json"), data, 0600)
+}
+
+// loadPullRequests reads the pull requests linked to a task
+func loadPullRequests(task *Task) error {
+	dir := filepath.Join(task.MetadataPath, pullRequestsDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name())) // #nosec G304 - reading task metadata from workspace path
+		if err != nil {
+			return err
+		}
+
+		var source TaskSource
+		if err := json.Unmarshal(data, &source); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", entry.Name(), err)
+		}
+		task.PullRequests = append(task.PullRequests, &source)
+	}
+
+	return nil
+}
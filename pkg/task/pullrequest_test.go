@@ -0,0 +1,41 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestRecords(t *testing.T) {
+	metadataDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(metadataDir, "jira.json"), []byte(`{"external_id": "PROJ-1"}`), 0600))
+
+	source := &TaskSource{
+		System:      "github",
+		ExternalID:  "acme/app#42",
+		ExternalURL: "https://github.com/acme/app/pull/42",
+		Metadata:    map[string]interface{}{"state": "open"},
+	}
+	require.NoError(t, writePullRequest(metadataDir, source))
+
+	// Updating the same pull request replaces its record
+	source.Metadata["state"] = "merged"
+	require.NoError(t, writePullRequest(metadataDir, source))
+	assert.FileExists(t, filepath.Join(metadataDir, "pull_requests", "github-acme-app-42.json"))
+
+	task := &Task{MetadataPath: metadataDir, Sources: make(map[string]*TaskSource)}
+	require.NoError(t, loadPullRequests(task))
+	require.Len(t, task.PullRequests, 1)
+	assert.Equal(t, "acme/app#42", task.PullRequests[0].ExternalID)
+	assert.Equal(t, "merged", task.PullRequests[0].Metadata["state"])
+
+	// Pull requests are not treated as synced sources
+	m := &Manager{logger: logging.NewBasic()}
+	require.NoError(t, m.loadTaskSources(task))
+	assert.Len(t, task.Sources, 1)
+	assert.Contains(t, task.Sources, "jira")
+}
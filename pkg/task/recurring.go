@@ -0,0 +1,225 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/cron"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Outcomes of generating the task of a recurring task's period
+const (
+	// RecurringCreated is a task created for the period
+	RecurringCreated = "created"
+
+	// RecurringExists is a period whose task already exists
+	RecurringExists = "exists"
+
+	// RecurringDue is a period whose task a dry run would create
+	RecurringDue = "due"
+
+	// RecurringNotDue is a recurring task whose schedule has not fired yet
+	RecurringNotDue = "not_due"
+
+	// RecurringFailed is a period whose task could not be created
+	RecurringFailed = "failed"
+)
+
+// periodPlaceholders are the placeholders of a period in the IDs of recurring tasks, at
+// least one of which tells the tasks of periods apart
+var periodPlaceholders = []string{"{date}", "{week}", "{month}", "{quarter}", "{year}"}
+
+// RecurringTask defines a task created on a schedule, in the recurring list of the task
+// configuration
+type RecurringTask struct {
+	// Name identifies the recurring task
+	Name string `yaml:"name" json:"name" mapstructure:"name"`
+
+	// Schedule is a cron schedule (e.g. "0 9 * * mon" or @monthly), in local time
+	Schedule string `yaml:"schedule" json:"schedule" mapstructure:"schedule"`
+
+	// Template is the local task template the tasks are created from; without one,
+	// tasks of Type are created
+	Template string `yaml:"template,omitempty" json:"template,omitempty" mapstructure:"template"`
+
+	// ID of the task of each period, with placeholders; defaults to "{NAME}-{date}"
+	ID string `yaml:"id,omitempty" json:"id,omitempty" mapstructure:"id"`
+
+	// Title of the tasks, with placeholders; defaults to the title of the template
+	Title string `yaml:"title,omitempty" json:"title,omitempty" mapstructure:"title"`
+
+	Type  string `yaml:"type,omitempty" json:"type,omitempty" mapstructure:"type"`
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty" mapstructure:"owner"`
+	Team  string `yaml:"team,omitempty" json:"team,omitempty" mapstructure:"team"`
+
+	// Include are the content kinds copied from the template besides index.md
+	Include []string `yaml:"include,omitempty" json:"include,omitempty" mapstructure:"include"`
+
+	// Variables fill {name} placeholders in the ID, the title and the markdown
+	// documents of the template
+	Variables map[string]string `yaml:"variables,omitempty" json:"variables,omitempty" mapstructure:"variables"`
+}
+
+// RecurringResult describes the task of the current period of a recurring task
+type RecurringResult struct {
+	Name string `json:"name"`
+
+	// Period is when the schedule last fired; zero when it has not yet
+	Period time.Time `json:"period"`
+
+	// Next is when the schedule fires next
+	Next time.Time `json:"next"`
+
+	TaskID string `json:"task_id,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Validate checks the definition of a recurring task
+func (r RecurringTask) Validate() error {
+	if !templateName.MatchString(r.Name) {
+		return fmt.Errorf("invalid name %q (names contain letters, digits, '-' and '_')", r.Name)
+	}
+	if _, err := cron.Parse(r.Schedule); err != nil {
+		return fmt.Errorf("%s: %w", r.Name, err)
+	}
+	if r.Template == "" && r.Title == "" {
+		return fmt.Errorf("%s: a template or a title is required", r.Name)
+	}
+	if !containsAny(r.idTemplate(), periodPlaceholders) {
+		return fmt.Errorf("%s: id %q has none of the placeholders %s, so every period would get the same task",
+			r.Name, r.ID, strings.Join(periodPlaceholders, ", "))
+	}
+	if err := validateInclude(r.Include); err != nil {
+		return fmt.Errorf("%s: %w", r.Name, err)
+	}
+	return nil
+}
+
+func (r RecurringTask) idTemplate() string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return "{NAME}-{date}"
+}
+
+// expand fills the placeholders of s for the period starting at period: the variables
+// of the recurring task, {name} and {NAME}, and the labels of the period: {date}
+// (2026-10-16), {week} (2026-W42), {month} (2026-10), {quarter} (2026-Q4) and {year}
+func (r RecurringTask) expand(s string, period time.Time) string {
+	year, week := period.ISOWeek()
+	pairs := []string{
+		"{name}", r.Name,
+		"{NAME}", strings.ToUpper(r.Name),
+		"{date}", period.Format("2006-01-02"),
+		"{week}", fmt.Sprintf("%d-W%02d", year, week),
+		"{month}", period.Format("2006-01"),
+		"{quarter}", fmt.Sprintf("%d-Q%d", period.Year(), (int(period.Month())+2)/3),
+		"{year}", strconv.Itoa(period.Year()),
+	}
+	for name, value := range r.Variables {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
+
+// GenerateDueTasks creates the task of the current period of every recurring task, the
+// period that started when its schedule last fired before now, unless the task already
+// exists. Periods missed before the current one are not caught up. With dryRun, the
+// tasks due are reported without creating them.
+func (m *Manager) GenerateDueTasks(ctx context.Context, now time.Time, dryRun bool) []*RecurringResult {
+	recurring := m.taskConfig().Recurring
+	results := make([]*RecurringResult, 0, len(recurring))
+
+	for _, definition := range recurring {
+		result := &RecurringResult{Name: definition.Name}
+		results = append(results, result)
+
+		if err := definition.Validate(); err != nil {
+			result.Status, result.Error = RecurringFailed, err.Error()
+			continue
+		}
+		schedule, _ := cron.Parse(definition.Schedule)
+		result.Period, result.Next = schedule.Prev(now), schedule.Next(now)
+		if result.Period.IsZero() {
+			result.Status = RecurringNotDue
+			continue
+		}
+
+		result.TaskID = definition.expand(definition.idTemplate(), result.Period)
+		if m.taskExists(result.TaskID) {
+			result.Status = RecurringExists
+			continue
+		}
+		if dryRun {
+			result.Status = RecurringDue
+			continue
+		}
+
+		err := m.createRecurringTask(ctx, definition, result)
+		var zenErr *types.Error
+		switch {
+		case err == nil:
+			result.Status = RecurringCreated
+			m.logger.Info("recurring task created", "name", definition.Name, "task_id", result.TaskID)
+		case errors.As(err, &zenErr) && zenErr.Code == types.ErrorCodeAlreadyExists:
+			// Created by another run at the same time
+			result.Status = RecurringExists
+		default:
+			result.Status, result.Error = RecurringFailed, err.Error()
+		}
+	}
+
+	return results
+}
+
+func (m *Manager) createRecurringTask(ctx context.Context, definition RecurringTask, result *RecurringResult) error {
+	if definition.Template == "" {
+		_, err := m.CreateTask(ctx, &CreateTaskRequest{
+			ID:    result.TaskID,
+			Title: definition.expand(definition.Title, result.Period),
+			Type:  definition.Type,
+			Owner: definition.Owner,
+			Team:  definition.Team,
+		})
+		return err
+	}
+
+	request := &CloneRequest{
+		ID:        result.TaskID,
+		Title:     definition.expand(definition.Title, result.Period),
+		Owner:     definition.Owner,
+		Team:      definition.Team,
+		Include:   definition.Include,
+		Variables: definition.periodVariables(result.Period),
+	}
+	_, err := m.CreateFromTemplate(ctx, definition.Template, request)
+	return err
+}
+
+// periodVariables returns the placeholders filled in the documents of the template of
+// a recurring task, without the braces
+func (r RecurringTask) periodVariables(period time.Time) map[string]string {
+	variables := map[string]string{}
+	for _, name := range append([]string{"{name}", "{NAME}"}, periodPlaceholders...) {
+		variables[strings.Trim(name, "{}")] = r.expand(name, period)
+	}
+	for name, value := range r.Variables {
+		variables[name] = value
+	}
+	return variables
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerGenerateDueTasks(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, os.WriteFile(filepath.Join(tasksDir, "PROJ-1", "index.md"), []byte("# PROJ-1: Add login page\n\nShip {service} for {month}.\n\n- [x] Notes are written\n"), 0644))
+	_, err := m.SaveTaskTemplate(ctx, "PROJ-1", "release", nil)
+	require.NoError(t, err)
+
+	m.settings = &Config{Recurring: []RecurringTask{
+		{Name: "standup", Schedule: "0 9 * * mon-fri", Title: "Standup {date}", Type: "task"},
+		{Name: "release", Schedule: "@monthly", Template: "release", ID: "REL-{month}", Title: "Release {month}", Variables: map[string]string{"service": "billing"}},
+		{Name: "retro", Schedule: "0 9 29 2 *", Template: "missing", ID: "RETRO-{year}"},
+		{Name: "review", Schedule: "0 9 * * *", Template: "missing", ID: "REVIEW-{date}"},
+	}}
+
+	// Friday 16 October 2026, before the standup
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.Local)
+
+	results := m.GenerateDueTasks(ctx, now, true)
+	require.Len(t, results, 4)
+	assert.Equal(t, RecurringDue, results[0].Status)
+	assert.Equal(t, "STANDUP-2026-10-15", results[0].TaskID, "the period of the last standup")
+	assert.Equal(t, time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local), results[0].Next)
+	assert.NoDirExists(t, filepath.Join(tasksDir, "STANDUP-2026-10-15"))
+
+	results = m.GenerateDueTasks(ctx, now, false)
+	assert.Equal(t, RecurringCreated, results[0].Status)
+	assert.Equal(t, RecurringCreated, results[1].Status)
+	assert.Equal(t, "REL-2026-10", results[1].TaskID)
+	assert.Equal(t, RecurringFailed, results[2].Status)
+	assert.Contains(t, results[2].Error, "task template not found: missing")
+	assert.Equal(t, RecurringFailed, results[3].Status)
+
+	standup, err := m.GetTask(ctx, "STANDUP-2026-10-15")
+	require.NoError(t, err)
+	assert.Equal(t, "Standup 2026-10-15", standup.Title)
+	assert.Equal(t, "task", standup.Type)
+
+	release, err := m.GetTask(ctx, "REL-2026-10")
+	require.NoError(t, err)
+	assert.Equal(t, "Release 2026-10", release.Title)
+	index, err := os.ReadFile(release.IndexPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# REL-2026-10: Release 2026-10\n\nShip billing for 2026-10.\n\n- [ ] Notes are written\n", string(index))
+
+	// Generating again skips the tasks of the periods that exist
+	results = m.GenerateDueTasks(ctx, now.Add(2*time.Hour), false)
+	assert.Equal(t, RecurringCreated, results[0].Status)
+	assert.Equal(t, "STANDUP-2026-10-16", results[0].TaskID)
+	assert.Equal(t, RecurringExists, results[1].Status)
+	assert.DirExists(t, filepath.Join(tasksDir, "STANDUP-2026-10-15"))
+}
+
+func TestRecurringTaskExpand(t *testing.T) {
+	r := RecurringTask{Name: "audit", Variables: map[string]string{"team": "platform"}}
+	period := time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "AUDIT-2027-01-02 2026-W53 2027-01 2027-Q1 2027 platform",
+		r.expand("{NAME}-{date} {week} {month} {quarter} {year} {team}", period))
+	assert.Equal(t, "AUDIT-2027-01-02", r.expand(r.idTemplate(), period))
+}
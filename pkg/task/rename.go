@@ -0,0 +1,266 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// redirectFile is the file of the stub left in place of a renamed task
+const redirectFile = "redirect.yaml"
+
+// maxRedirects bounds the redirects followed to resolve a task ID
+const maxRedirects = 10
+
+// Redirect points the old ID of a renamed task to its new ID
+type Redirect struct {
+	TaskID    string    `json:"task_id" yaml:"task_id"`
+	RenamedAt time.Time `json:"renamed_at" yaml:"renamed_at"`
+	RenamedBy string    `json:"renamed_by" yaml:"renamed_by"`
+}
+
+// RenameResult describes a renamed task
+type RenameResult struct {
+	OldID string `json:"old_id"`
+	NewID string `json:"new_id"`
+	Path  string `json:"path"`
+
+	// Rewritten are the files whose references to the old ID were rewritten, relative
+	// to the task directory
+	Rewritten []string `json:"rewritten"`
+
+	// Redirect is set when a stub redirecting the old ID was left
+	Redirect bool `json:"redirect"`
+
+	// Branch is the git branch of the task, which keeps its name
+	Branch string `json:"branch,omitempty"`
+}
+
+// RenameTask moves a task to a new ID: its directory is renamed, with its sync
+// metadata, and references to the old ID in its manifest, .taskrc.yaml and markdown
+// documents are rewritten. With redirect, a stub is left under the old ID, so that
+// looking the old ID up finds the task.
+func (m *Manager) RenameTask(ctx context.Context, oldID, newID string, redirect bool) (*RenameResult, error) {
+	if err := ValidateTaskID(newID); err != nil {
+		return nil, &types.Error{Code: types.ErrorCodeInvalidInput, Message: "invalid task ID", Details: err.Error()}
+	}
+	if oldID == newID {
+		return nil, &types.Error{Code: types.ErrorCodeInvalidInput, Message: fmt.Sprintf("task is already %s", newID)}
+	}
+	if err := m.checkTaskNames(newID, nil); err != nil {
+		return nil, err
+	}
+
+	tasksDir, err := m.tasksDirectory()
+	if err != nil {
+		return nil, err
+	}
+	oldDir, newDir := filepath.Join(tasksDir, oldID), filepath.Join(tasksDir, newID)
+
+	if !m.taskExists(oldID) {
+		return nil, taskNotFound(oldID)
+	}
+	if redirect, err := readRedirect(oldDir); err == nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("%s was renamed to %s", oldID, redirect.TaskID),
+			Details: fmt.Sprintf("rename %s instead", redirect.TaskID),
+		}
+	}
+	task, err := m.loadTaskFromManifest(oldID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+
+	// A redirect back to the task is replaced, as when undoing a rename
+	if existing, err := readRedirect(newDir); err == nil && existing.TaskID == oldID {
+		if err := os.RemoveAll(newDir); err != nil {
+			return nil, fmt.Errorf("failed to remove redirect %s: %w", newID, err)
+		}
+	}
+	if m.taskExists(newID) {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeAlreadyExists,
+			Message: fmt.Sprintf("task already exists: %s", newID),
+		}
+	}
+
+	if err := os.Rename(oldDir, newDir); err != nil {
+		return nil, fmt.Errorf("failed to move task directory: %w", err)
+	}
+
+	result := &RenameResult{OldID: oldID, NewID: newID, Path: newDir}
+	if task.Git != nil {
+		result.Branch = task.Git.Branch
+	}
+
+	if err := updateManifestFields(filepath.Join(newDir, "manifest.yaml"), map[string]string{
+		"task.id":            newID,
+		"dates.last_updated": time.Now().Format("2006-01-02 15:04:05"),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update task manifest: %w", err)
+	}
+	result.Rewritten = append(result.Rewritten, "manifest.yaml")
+
+	if _, err := os.Stat(filepath.Join(newDir, ".taskrc.yaml")); err == nil {
+		if err := updateManifestFields(filepath.Join(newDir, ".taskrc.yaml"), map[string]string{"task.id": newID}); err != nil {
+			return nil, fmt.Errorf("failed to update .taskrc.yaml: %w", err)
+		}
+		result.Rewritten = append(result.Rewritten, ".taskrc.yaml")
+	}
+
+	documents, err := rewriteReferences(newDir, oldID, newID)
+	if err != nil {
+		return nil, err
+	}
+	result.Rewritten = append(result.Rewritten, documents...)
+	if err := recordRevision(newDir); err != nil {
+		m.logger.Warn("failed to record task revision", "task_id", newID, "error", err)
+	}
+
+	if redirect {
+		if err := writeRedirect(oldDir, &Redirect{TaskID: newID, RenamedAt: time.Now(), RenamedBy: m.identity().Current()}); err != nil {
+			return nil, err
+		}
+		result.Redirect = true
+	}
+
+	// The task index finds the moved task by itself; drop the entry of the old ID
+	index := openTaskIndex(TaskIndexPath(filepath.Dir(filepath.Dir(tasksDir))))
+	index.remove(oldID)
+	if err := index.save(); err != nil {
+		m.logger.Debug("failed to save task index", "error", err)
+	}
+
+	m.logger.Info("task renamed", "from", oldID, "to", newID, "redirect", redirect)
+	return result, nil
+}
+
+// replaceTaskID replaces the references to a task ID in text: the ID as a whole word,
+// not as part of a longer ID
+func replaceTaskID(text []byte, oldID, newID string) []byte {
+	var out bytes.Buffer
+	old := []byte(oldID)
+	for i := 0; i < len(text); {
+		j := bytes.Index(text[i:], old)
+		if j < 0 {
+			out.Write(text[i:])
+			break
+		}
+		start, end := i+j, i+j+len(old)
+		if (start == 0 || !isIDChar(text[start-1])) && (end == len(text) || !isIDChar(text[end])) {
+			out.Write(text[i:start])
+			out.WriteString(newID)
+		} else {
+			out.Write(text[i:end])
+		}
+		i = end
+	}
+	return out.Bytes()
+}
+
+func isIDChar(c byte) bool {
+	return isAlphanumeric(c) || c == '-' || c == '_'
+}
+
+// rewriteReferences replaces the references to a task ID in the markdown documents of
+// a task directory, and returns the files changed
+func rewriteReferences(taskDir, oldID, newID string) ([]string, error) {
+	var rewritten []string
+
+	err := filepath.WalkDir(taskDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != taskDir && (d.Name() == "attachments" || d.Name() == "metadata" || d.Name() == ".zenflow" || d.Name() == historyDir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path) // #nosec G304 - reading task documents from workspace path
+		if err != nil {
+			return err
+		}
+		updated := replaceTaskID(data, oldID, newID)
+		if bytes.Equal(updated, data) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, updated, info.Mode().Perm()); err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(taskDir, path)
+		rewritten = append(rewritten, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewrite task references: %w", err)
+	}
+	return rewritten, nil
+}
+
+// readRedirect reads the redirect stub in a task directory
+func readRedirect(taskDir string) (*Redirect, error) {
+	data, err := os.ReadFile(filepath.Join(taskDir, redirectFile)) // #nosec G304 - reading redirect from workspace path
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Redirect Redirect `yaml:"redirect"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", redirectFile, err)
+	}
+	if doc.Redirect.TaskID == "" {
+		return nil, fmt.Errorf("%s names no task", redirectFile)
+	}
+	return &doc.Redirect, nil
+}
+
+func writeRedirect(taskDir string, redirect *Redirect) error {
+	data, err := yaml.Marshal(map[string]*Redirect{"redirect": redirect})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(taskDir, 0755); err != nil {
+		return fmt.Errorf("failed to create redirect: %w", err)
+	}
+	content := "# This task was renamed; zen resolves this ID to the task below\n" + string(data)
+	if err := os.WriteFile(filepath.Join(taskDir, redirectFile), []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to create redirect: %w", err)
+	}
+	return nil
+}
+
+// resolveTaskID follows the redirects left by renames to the current ID of a task
+func (m *Manager) resolveTaskID(taskID string) string {
+	tasksDir, err := m.tasksDirectory()
+	if err != nil {
+		return taskID
+	}
+	for i := 0; i < maxRedirects && m.taskExists(taskID); i++ {
+		redirect, err := readRedirect(filepath.Join(tasksDir, taskID))
+		if err != nil {
+			break
+		}
+		m.logger.Debug("following task redirect", "from", taskID, "to", redirect.TaskID)
+		taskID = redirect.TaskID
+	}
+	return taskID
+}
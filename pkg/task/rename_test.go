@@ -0,0 +1,102 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerRenameTask(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+
+	oldDir := filepath.Join(tasksDir, "PROJ-1")
+	require.NoError(t, os.WriteFile(filepath.Join(oldDir, "index.md"), []byte("# PROJ-1: Add login page\n\nFollows PROJ-12 and LOCAL-PROJ-1; see PROJ-1.\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(oldDir, "design", "decisions"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(oldDir, "design", "decisions", "0001-use-sso.md"), []byte("**Task:** PROJ-1\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(oldDir, "metadata"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(oldDir, "metadata", "jira.json"), []byte(`{"external_id": "PROJ-1"}`), 0644))
+
+	_, err := m.ListTasks(ctx, nil)
+	require.NoError(t, err)
+
+	result, err := m.RenameTask(ctx, "PROJ-1", "PROJ-101", true)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tasksDir, "PROJ-101"), result.Path)
+	assert.Equal(t, []string{"manifest.yaml", "design/decisions/0001-use-sso.md", "index.md"}, result.Rewritten)
+	assert.True(t, result.Redirect)
+
+	task, err := m.GetTask(ctx, "PROJ-101")
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-101", task.ID)
+	assert.Equal(t, "Add login page", task.Title)
+	assert.Equal(t, "PROJ-1", task.Sources["jira"].ExternalID, "sync metadata moves with the task")
+
+	manifest, err := os.ReadFile(task.ManifestPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), "id: PROJ-101")
+	assert.Contains(t, string(manifest), "# shown in lists")
+
+	index, err := os.ReadFile(task.IndexPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# PROJ-101: Add login page\n\nFollows PROJ-12 and LOCAL-PROJ-1; see PROJ-101.\n", string(index))
+
+	// The redirect resolves the old ID, and is not listed as a task
+	redirected, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-101", redirected.ID)
+
+	tasks, err := m.ListTasks(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "PROJ-101", tasks[0].ID)
+
+	_, err = m.RenameTask(ctx, "PROJ-1", "PROJ-102", false)
+	assert.ErrorContains(t, err, "PROJ-1 was renamed to PROJ-101")
+
+	// Renaming back replaces the redirect
+	_, err = m.RenameTask(ctx, "PROJ-101", "PROJ-1", false)
+	require.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(tasksDir, "PROJ-101"))
+	_, err = m.GetTask(ctx, "PROJ-101")
+	assert.Error(t, err)
+}
+
+func TestManagerRenameTask_Errors(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	require.NoError(t, os.MkdirAll(filepath.Join(tasksDir, "PROJ-2"), 0755))
+
+	tests := []struct {
+		name   string
+		oldID  string
+		newID  string
+		code   types.ErrorCode
+		errMsg string
+	}{
+		{name: "missing task", oldID: "PROJ-9", newID: "PROJ-10", code: types.ErrorCodeNotFound},
+		{name: "existing task", oldID: "PROJ-1", newID: "PROJ-2", code: types.ErrorCodeAlreadyExists},
+		{name: "invalid ID", oldID: "PROJ-1", newID: "PROJ/2", code: types.ErrorCodeInvalidInput},
+		{name: "same ID", oldID: "PROJ-1", newID: "PROJ-1", code: types.ErrorCodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.RenameTask(ctx, tt.oldID, tt.newID, false)
+			var zenErr *types.Error
+			require.ErrorAs(t, err, &zenErr)
+			assert.Equal(t, tt.code, zenErr.Code)
+		})
+	}
+	assert.DirExists(t, filepath.Join(tasksDir, "PROJ-1"))
+}
+
+func TestReplaceTaskID(t *testing.T) {
+	assert.Equal(t, "PROJ-1.v2 PROJ-1.v2", string(replaceTaskID([]byte("PROJ-1 PROJ-1"), "PROJ-1", "PROJ-1.v2")))
+	assert.Equal(t, "PROJ-10 PROJ-2 XPROJ-1", string(replaceTaskID([]byte("PROJ-10 PROJ-1 XPROJ-1"), "PROJ-1", "PROJ-2")))
+}
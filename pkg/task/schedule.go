@@ -0,0 +1,63 @@
+package task
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultSyncConcurrency is the number of tasks synced at once with each source when
+// SyncOptions.Concurrency is not set. Sources are synced independently, so a slow or
+// rate limited source does not hold up the others.
+const DefaultSyncConcurrency = 8
+
+// syncJob is a task to sync with one of its sources
+type syncJob struct {
+	taskID     string
+	source     string
+	externalID string
+}
+
+// syncSource returns the source a task is synced with: the first of opts.Sources when
+// they are given, otherwise the first of the task's own sources in name order
+func syncSource(task *Task, opts *SyncOptions) string {
+	if len(opts.Sources) > 0 {
+		return opts.Sources[0]
+	}
+
+	sources := make([]string, 0, len(task.Sources))
+	for source := range task.Sources {
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return ""
+	}
+	sort.Strings(sources)
+	return sources[0]
+}
+
+// runPerSource calls fn with the index of every job, running up to limit jobs at once
+// for each source, and returns when all of them are done
+func runPerSource(jobs []syncJob, limit int, fn func(i int)) {
+	if limit <= 0 {
+		limit = DefaultSyncConcurrency
+	}
+
+	slots := map[string]chan struct{}{}
+	for _, job := range jobs {
+		if _, ok := slots[job.source]; !ok {
+			slots[job.source] = make(chan struct{}, limit)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, slot chan struct{}) {
+			defer wg.Done()
+			slot <- struct{}{}
+			defer func() { <-slot }()
+			fn(i)
+		}(i, slots[job.source])
+	}
+	wg.Wait()
+}
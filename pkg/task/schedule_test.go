@@ -0,0 +1,60 @@
+package task
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncSource(t *testing.T) {
+	task := &Task{Sources: map[string]*TaskSource{
+		"linear": {ExternalID: "LIN-1"},
+		"jira":   {ExternalID: "PROJ-1"},
+	}}
+
+	assert.Equal(t, "jira", syncSource(task, &SyncOptions{}), "first source in name order")
+	assert.Equal(t, "linear", syncSource(task, &SyncOptions{Sources: []string{"linear"}}))
+	assert.Empty(t, syncSource(&Task{}, &SyncOptions{}))
+}
+
+func TestRunPerSource(t *testing.T) {
+	var jobs []syncJob
+	for i := 0; i < 12; i++ {
+		jobs = append(jobs, syncJob{source: "jira"})
+	}
+	for i := 0; i < 4; i++ {
+		jobs = append(jobs, syncJob{source: "github"})
+	}
+
+	var mu sync.Mutex
+	running := map[string]int{}
+	peak := map[string]int{}
+	done := make([]bool, len(jobs))
+
+	runPerSource(jobs, 3, func(i int) {
+		source := jobs[i].source
+
+		mu.Lock()
+		running[source]++
+		if running[source] > peak[source] {
+			peak[source] = running[source]
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running[source]--
+		done[i] = true
+		mu.Unlock()
+	})
+
+	for i := range done {
+		assert.True(t, done[i], "job %d ran", i)
+	}
+	assert.LessOrEqual(t, peak["jira"], 3, "jira jobs are limited")
+	assert.LessOrEqual(t, peak["github"], 3, "github jobs are limited")
+	assert.Greater(t, peak["jira"]+peak["github"], 3, "sources run alongside each other")
+}
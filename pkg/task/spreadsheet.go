@@ -0,0 +1,273 @@
+package task
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/daddia/zen/pkg/types"
+)
+
+// spreadsheetRow is a row of a spreadsheet and its number, counting from 1
+type spreadsheetRow struct {
+	Number int
+	Cells  []string
+}
+
+// Cell returns the cell of a column, or "" when the row is shorter
+func (r spreadsheetRow) Cell(column int) string {
+	if column < 0 || column >= len(r.Cells) {
+		return ""
+	}
+	return r.Cells[column]
+}
+
+// readSpreadsheet returns the header and rows of a CSV, TSV or Excel (.xlsx) file, by
+// its extension. Excel workbooks are read from their first sheet. The header is the
+// first row that is not empty, and empty rows are left out.
+func readSpreadsheet(file string) ([]string, []spreadsheetRow, error) {
+	var rows [][]string
+	var err error
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv":
+		rows, err = readDelimited(file, ',')
+	case ".tsv":
+		rows, err = readDelimited(file, '\t')
+	case ".xlsx":
+		rows, err = readWorkbook(file)
+	default:
+		return nil, nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("unsupported spreadsheet: %s", filepath.Base(file)),
+			Details: "import .csv, .tsv or .xlsx files; save other formats as CSV first",
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var header []string
+	var records []spreadsheetRow
+	for i, row := range rows {
+		empty := true
+		for i, cell := range row {
+			row[i] = strings.TrimSpace(cell)
+			if row[i] != "" {
+				empty = false
+			}
+		}
+		switch {
+		case empty:
+			continue
+		case header == nil:
+			header = row
+		default:
+			records = append(records, spreadsheetRow{Number: i + 1, Cells: row})
+		}
+	}
+	if header == nil {
+		return nil, nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("%s has no header row", filepath.Base(file)),
+		}
+	}
+	return header, records, nil
+}
+
+func readDelimited(file string, delimiter rune) ([][]string, error) {
+	f, err := os.Open(file) // #nosec G304 - reading the spreadsheet the user names
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filepath.Base(file), err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("failed to parse %s", filepath.Base(file)),
+			Details: err.Error(),
+		}
+	}
+	// Spreadsheets saved as CSV often start with a byte order mark
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	return rows, nil
+}
+
+// Parts of Excel workbooks
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	xlsxText struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+	xlsxSheet struct {
+		Rows []struct {
+			Number int `xml:"r,attr"`
+			Cells  []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+// String returns the text of a string, which rich text splits into runs
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+// readWorkbook returns the rows of the first sheet of an Excel workbook. Cells are read
+// as the text or number they hold; dates are read as the serial numbers Excel stores.
+func readWorkbook(file string) ([][]string, error) {
+	archive, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeInvalidInput,
+			Message: fmt.Sprintf("failed to open %s as an Excel workbook", filepath.Base(file)),
+			Details: err.Error(),
+		}
+	}
+	defer archive.Close()
+
+	parts := map[string]*zip.File{}
+	for _, f := range archive.File {
+		parts[strings.TrimPrefix(f.Name, "/")] = f
+	}
+	readPart := func(name string, v interface{}) error {
+		f, ok := parts[name]
+		if !ok {
+			return os.ErrNotExist
+		}
+		r, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		if err := xml.NewDecoder(io.LimitReader(r, maxWorkbookPart)).Decode(v); err != nil {
+			return fmt.Errorf("failed to parse %s of %s: %w", name, filepath.Base(file), err)
+		}
+		return nil
+	}
+
+	sheet := "xl/worksheets/sheet1.xml"
+	var workbook xlsxWorkbook
+	var relationships xlsxRelationships
+	if readPart("xl/workbook.xml", &workbook) == nil && len(workbook.Sheets) > 0 &&
+		readPart("xl/_rels/workbook.xml.rels", &relationships) == nil {
+		for _, rel := range relationships.Relationships {
+			if rel.ID != workbook.Sheets[0].RelID {
+				continue
+			}
+			if strings.HasPrefix(rel.Target, "/") {
+				sheet = strings.TrimPrefix(rel.Target, "/")
+			} else {
+				sheet = path.Join("xl", rel.Target)
+			}
+		}
+	}
+
+	var shared xlsxSharedStrings
+	if err := readPart("xl/sharedStrings.xml", &shared); err != nil && err != os.ErrNotExist {
+		return nil, err
+	}
+	var worksheet xlsxSheet
+	if err := readPart(sheet, &worksheet); err != nil {
+		if err == os.ErrNotExist {
+			return nil, &types.Error{
+				Code:    types.ErrorCodeInvalidInput,
+				Message: fmt.Sprintf("%s has no sheets", filepath.Base(file)),
+			}
+		}
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(worksheet.Rows))
+	for _, sheetRow := range worksheet.Rows {
+		// Workbooks leave out empty rows, which are kept so that rows keep their numbers
+		for sheetRow.Number > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+
+		var row []string
+		for _, cell := range sheetRow.Cells {
+			column := columnIndex(cell.Ref)
+			if column < 0 {
+				column = len(row)
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				if i, err := strconv.Atoi(value); err == nil && i >= 0 && i < len(shared.Items) {
+					value = shared.Items[i].String()
+				}
+			case "inlineStr":
+				value = cell.Inline.String()
+			case "b":
+				value = strconv.FormatBool(value == "1")
+			}
+			row[column] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// maxWorkbookPart limits the parts of workbooks read, against archives that expand
+// without bound
+const maxWorkbookPart = 64 << 20
+
+// columnIndex returns the index of the column of a cell reference, such as 27 for AB3,
+// or -1 when it has none
+func columnIndex(ref string) int {
+	index := 0
+	for _, r := range strings.ToUpper(ref) {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A') + 1
+	}
+	return index - 1
+}
@@ -0,0 +1,97 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/daddia/zen/pkg/fs"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// stagingDir is the directory, next to the tasks directory, in which new tasks are
+// written before they are moved into place
+const stagingDir = ".staging"
+
+// staleStagingAge is the age past which a staged task is taken to be left by a command
+// that did not finish, and is removed
+const staleStagingAge = time.Hour
+
+// stagedTask is the directory of a task being created. Its files are written in a
+// staging directory, and the directory is renamed into place once all are written, so
+// that the tasks directory never holds a half-created task.
+type stagedTask struct {
+	id        string
+	dir       string
+	tasksDir  string
+	committed bool
+}
+
+// stageTaskDirectory creates the staging directory of a new task, on the filesystem of
+// tasksDir so that it can be renamed into place, and removes stale staging directories
+// left by commands that were killed
+func stageTaskDirectory(tasksDir, taskID string) (*stagedTask, error) {
+	if err := os.MkdirAll(fs.LongPath(tasksDir), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create tasks directory: %w", err)
+	}
+	root := filepath.Join(filepath.Dir(tasksDir), stagingDir)
+	if err := os.MkdirAll(fs.LongPath(root), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	sweepStaging(root, time.Now())
+
+	dir, err := os.MkdirTemp(fs.LongPath(root), taskID+"-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	return &stagedTask{id: taskID, dir: dir, tasksDir: tasksDir}, nil
+}
+
+// path returns the directory the task has once committed
+func (s *stagedTask) path() string {
+	return filepath.Join(s.tasksDir, s.id)
+}
+
+// commit renames the staging directory into place, failing when the task exists, so
+// that of concurrent creations of a task only one succeeds
+func (s *stagedTask) commit() error {
+	if err := os.Rename(fs.LongPath(s.dir), fs.LongPath(s.path())); err != nil {
+		// Renaming onto a task directory fails with EEXIST or ENOTEMPTY depending on the
+		// platform, so the directory is checked instead of the error
+		if _, statErr := os.Stat(fs.LongPath(s.path())); statErr == nil {
+			return &types.Error{
+				Code:    types.ErrorCodeAlreadyExists,
+				Message: fmt.Sprintf("task already exists: %s", s.id),
+			}
+		}
+		return fmt.Errorf("failed to move task directory into place: %w", err)
+	}
+	s.committed = true
+	return nil
+}
+
+// discard removes the staging directory of a task that was not committed. It is
+// deferred by the creation of the task, so that errors, panics, and interrupts leave
+// nothing behind.
+func (s *stagedTask) discard() {
+	if s == nil || s.committed {
+		return
+	}
+	_ = os.RemoveAll(fs.LongPath(s.dir))
+}
+
+// sweepStaging removes the staging directories in root older than staleStagingAge
+func sweepStaging(root string, now time.Time) {
+	entries, err := os.ReadDir(fs.LongPath(root))
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < staleStagingAge {
+			continue
+		}
+		_ = os.RemoveAll(fs.LongPath(filepath.Join(root, entry.Name())))
+	}
+}
@@ -0,0 +1,74 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStagedTask(t *testing.T) {
+	tasksDir := filepath.Join(t.TempDir(), "tasks")
+	root := filepath.Join(filepath.Dir(tasksDir), stagingDir)
+
+	staged, err := stageTaskDirectory(tasksDir, "PROJ-1")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(staged.dir, "manifest.yaml"), []byte("task:\n  id: PROJ-1\n"), 0644))
+	assert.NoDirExists(t, filepath.Join(tasksDir, "PROJ-1"), "the task is not in place until committed")
+
+	require.NoError(t, staged.commit())
+	staged.discard()
+	assert.FileExists(t, filepath.Join(tasksDir, "PROJ-1", "manifest.yaml"), "committed tasks are not discarded")
+
+	again, err := stageTaskDirectory(tasksDir, "PROJ-1")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(again.dir, "manifest.yaml"), []byte("task:\n  id: PROJ-1\n"), 0644))
+	err = again.commit()
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeAlreadyExists, zenErr.Code)
+
+	again.discard()
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "discarded tasks leave nothing in the staging directory")
+}
+
+func TestSweepStaging(t *testing.T) {
+	root := t.TempDir()
+	stale := filepath.Join(root, "PROJ-1-123")
+	fresh := filepath.Join(root, "PROJ-2-456")
+	require.NoError(t, os.Mkdir(stale, 0755))
+	require.NoError(t, os.Mkdir(fresh, 0755))
+	old := time.Now().Add(-2 * staleStagingAge)
+	require.NoError(t, os.Chtimes(stale, old, old))
+
+	sweepStaging(root, time.Now())
+	assert.NoDirExists(t, stale)
+	assert.DirExists(t, fresh, "tasks being created by running commands are kept")
+}
+
+func TestCreateTask_Interrupted(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := m.CreateTask(ctx, &CreateTaskRequest{ID: "PROJ-2", Title: "Add signup page"})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NoDirExists(t, filepath.Join(tasksDir, "PROJ-2"))
+
+	entries, err := os.ReadDir(filepath.Join(filepath.Dir(tasksDir), stagingDir))
+	require.NoError(t, err)
+	assert.Empty(t, entries, "interrupted creations leave nothing behind")
+
+	task, err := m.CreateTask(context.Background(), &CreateTaskRequest{ID: "PROJ-2", Title: "Add signup page"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tasksDir, "PROJ-2"), task.WorkspacePath)
+	assert.FileExists(t, task.ManifestPath)
+	assert.FileExists(t, filepath.Join(task.WorkspacePath, historyDir, historyLog), "the history moves with the task")
+}
@@ -132,7 +132,7 @@ func (m *Manager) syncJiraSpecificData(variables map[string]interface{}, rawData
 			}
 		}
 		if len(labelStrings) > 0 {
-			variables["LABELS"] = labelStrings
+			variables["LABELS"] = m.labelConfig().FromProvider("jira", labelStrings)
 			variables["JIRA_LABELS"] = labelStrings
 		}
 	}
@@ -274,7 +274,7 @@ func (m *Manager) syncGitHubSpecificData(variables map[string]interface{}, rawDa
 			}
 		}
 		if len(labelNames) > 0 {
-			variables["LABELS"] = labelNames
+			variables["LABELS"] = m.labelConfig().FromProvider("github", labelNames)
 			variables["GITHUB_LABELS"] = labelNames
 		}
 	}
@@ -323,6 +323,40 @@ func (m *Manager) syncLinearSpecificData(variables map[string]interface{}, rawDa
 	}
 }
 
+// syncNotionSpecificData extracts Notion-specific data from raw response
+func (m *Manager) syncNotionSpecificData(variables map[string]interface{}, rawData map[string]interface{}) {
+	if id, ok := rawData["id"].(string); ok {
+		variables["NOTION_PAGE_ID"] = id
+	}
+
+	if url, ok := rawData["url"].(string); ok {
+		variables["NOTION_URL"] = url
+	}
+
+	if properties, ok := rawData["properties"].(map[string]interface{}); ok {
+		variables["NOTION_PROPERTIES"] = properties
+	}
+}
+
+// syncServiceNowSpecificData extracts ServiceNow-specific data from raw response
+func (m *Manager) syncServiceNowSpecificData(variables map[string]interface{}, rawData map[string]interface{}) {
+	if number, ok := rawData["number"].(string); ok {
+		variables["SERVICENOW_NUMBER"] = number
+	}
+
+	if table, ok := rawData["table"].(string); ok {
+		variables["SERVICENOW_TABLE"] = table
+	}
+
+	if sysID, ok := rawData["sys_id"].(string); ok {
+		variables["SERVICENOW_SYS_ID"] = sysID
+	}
+
+	if closeCode, ok := rawData["close_code"].(string); ok && closeCode != "" {
+		variables["SERVICENOW_CLOSE_CODE"] = closeCode
+	}
+}
+
 // mapJiraIssueTypeToZenType maps Jira issue types to Zen task types
 func (m *Manager) mapJiraIssueTypeToZenType(issueType string) string {
 	switch strings.ToLower(issueType) {
@@ -545,14 +579,19 @@ func (m *Manager) loadTaskFromManifest(taskID string) (*Task, error) {
 		return nil, fmt.Errorf("task manifest not found: %s", taskID)
 	}
 
+	// The version is read first, so that a write between the two reads is taken for a
+	// concurrent modification rather than missed
+	version, err := manifestVersion(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
 	// Read and parse manifest
-	_, err = os.ReadFile(manifestPath)
+	doc, err := readManifest(manifestPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read manifest: %w", err)
 	}
 
-	// Parse YAML manifest (simplified - would use proper YAML parsing)
-	// For now, create a basic task structure
 	task := &Task{
 		ID:            taskID,
 		WorkspacePath: taskDir,
@@ -561,12 +600,21 @@ func (m *Manager) loadTaskFromManifest(taskID string) (*Task, error) {
 		MetadataPath:  filepath.Join(taskDir, "metadata"),
 		Sources:       make(map[string]*TaskSource),
 		Metadata:      make(map[string]interface{}),
+		version:       version,
+	}
+	doc.applyTo(task)
+
+	if info, err := os.Stat(manifestPath); err == nil {
+		task.Updated = info.ModTime()
 	}
 
 	// Load source metadata
 	if err := m.loadTaskSources(task); err != nil {
 		m.logger.Warn("failed to load task sources", "task_id", taskID, "error", err)
 	}
+	if err := loadPullRequests(task); err != nil {
+		m.logger.Warn("failed to load task pull requests", "task_id", taskID, "error", err)
+	}
 
 	return task, nil
 }
@@ -624,6 +672,15 @@ func (m *Manager) loadTaskSources(task *Task) error {
 				taskSource.LastSync = lastSync
 			}
 		}
+		if snapshot, ok := metadata["snapshot"]; ok {
+			taskSource.Snapshot = decodeSnapshot(snapshot)
+		}
+		if pending, ok := metadata["pending_conflicts"]; ok {
+			taskSource.PendingConflicts = decodeConflicts(pending)
+		}
+		if project, ok := metadata["project"]; ok {
+			taskSource.Project = decodeProjectFields(project)
+		}
 
 		task.Sources[source] = taskSource
 	}
@@ -631,13 +688,23 @@ func (m *Manager) loadTaskSources(task *Task) error {
 	return nil
 }
 
-// saveTask saves task data back to the manifest and metadata
+// saveTask saves task data back to the manifest and metadata. It holds the lock of the
+// task while it does, and fails when another command changed the task since it was read.
 func (m *Manager) saveTask(ctx context.Context, task *Task) error {
+	lock, err := lockTask(ctx, task)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	// Update task metadata
 	task.Updated = time.Now()
 
-	// Save to manifest file (simplified - would use proper YAML generation)
-	// For now, just update the metadata files
+	// Save the synced fields to the manifest
+	if err := saveManifestFields(task); err != nil {
+		return err
+	}
+	refreshTaskVersion(task)
 
 	// Save source metadata
 	for source, sourceInfo := range task.Sources {
@@ -649,6 +716,41 @@ func (m *Manager) saveTask(ctx context.Context, task *Task) error {
 	return nil
 }
 
+// saveManifestFields writes the task fields that sync updates to its manifest, leaving
+// fields without a value as they are
+func saveManifestFields(task *Task) error {
+	if task.ManifestPath == "" {
+		return nil
+	}
+	if _, err := os.Stat(task.ManifestPath); err != nil {
+		return nil
+	}
+
+	fields := map[string]string{}
+	for key, value := range map[string]string{
+		"task.title":    task.Title,
+		"task.status":   task.Status,
+		"task.priority": task.Priority,
+		"owner.name":    task.Owner,
+		"team.name":     task.Team,
+	} {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	if len(fields) > 0 {
+		if err := updateManifestFields(task.ManifestPath, fields); err != nil {
+			return fmt.Errorf("failed to save task manifest: %w", err)
+		}
+	}
+	if task.Labels != nil {
+		if err := setManifestValue(task.ManifestPath, "labels", task.Labels); err != nil {
+			return fmt.Errorf("failed to save task manifest: %w", err)
+		}
+	}
+	return nil
+}
+
 // updateSourceMetadata updates source metadata file
 func (m *Manager) updateSourceMetadata(metadataDir, source string, sourceInfo *TaskSource) error {
 	sourceMetadataPath := filepath.Join(metadataDir, fmt.Sprintf("%s.json", source))
@@ -668,6 +770,19 @@ func (m *Manager) updateSourceMetadata(metadataDir, source string, sourceInfo *T
 	metadata["last_sync"] = sourceInfo.LastSync.Format(time.RFC3339)
 	metadata["sync_enabled"] = sourceInfo.SyncEnabled
 	metadata["sync_direction"] = sourceInfo.SyncDirection
+	if sourceInfo.Snapshot != nil {
+		metadata["snapshot"] = sourceInfo.Snapshot
+	}
+	if len(sourceInfo.PendingConflicts) > 0 {
+		metadata["pending_conflicts"] = sourceInfo.PendingConflicts
+	} else {
+		delete(metadata, "pending_conflicts")
+	}
+	if sourceInfo.Project != nil {
+		metadata["project"] = sourceInfo.Project
+	} else {
+		delete(metadata, "project")
+	}
 
 	// Write back to file
 	jsonData, err := json.MarshalIndent(metadata, "", "  ")
@@ -677,3 +792,31 @@ func (m *Manager) updateSourceMetadata(metadataDir, source string, sourceInfo *T
 
 	return os.WriteFile(sourceMetadataPath, jsonData, 0600)
 }
+
+// decodeSnapshot reads a sync snapshot from parsed source metadata, returning nil when it
+// cannot be read
+func decodeSnapshot(value interface{}) *SyncSnapshot {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var snapshot SyncSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil
+	}
+	return &snapshot
+}
+
+// decodeConflicts reads pending conflicts from parsed source metadata, returning nil when
+// they cannot be read
+func decodeConflicts(value interface{}) []Conflict {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var conflicts []Conflict
+	if err := json.Unmarshal(data, &conflicts); err != nil {
+		return nil
+	}
+	return conflicts
+}
@@ -0,0 +1,262 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/types"
+)
+
+// syncUndoDir is the directory, in the metadata of a task, holding the snapshots taken
+// before syncs changed the task
+const syncUndoDir = "sync-undo"
+
+// DefaultSyncUndoKeep is the number of sync snapshots kept for each task by default
+const DefaultSyncUndoKeep = 10
+
+// DefaultSyncUndoMaxAge is the age past which sync snapshots are removed by default
+const DefaultSyncUndoMaxAge = 30 * 24 * time.Hour
+
+// SyncUndoConfig is the retention policy of the snapshots taken before syncs change a
+// task, which 'zen task sync undo' restores
+type SyncUndoConfig struct {
+	// Keep is the number of snapshots kept for each task; 0 turns snapshots off
+	Keep int `yaml:"keep" json:"keep" mapstructure:"keep"`
+
+	// MaxAge removes snapshots older than it; 0 keeps snapshots regardless of age
+	MaxAge time.Duration `yaml:"max_age" json:"max_age" mapstructure:"max_age"`
+}
+
+// Validate validates the retention policy
+func (c SyncUndoConfig) Validate() error {
+	if c.Keep < 0 {
+		return fmt.Errorf("keep must not be negative, got %d", c.Keep)
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("max_age must not be negative, got %s", c.MaxAge)
+	}
+	return nil
+}
+
+// SyncUndoRecord is the local state of a task as it was before a sync changed it
+type SyncUndoRecord struct {
+	ID        string        `json:"id"`
+	TaskID    string        `json:"task_id"`
+	Source    string        `json:"source"`
+	Direction SyncDirection `json:"direction"`
+	TakenAt   time.Time     `json:"taken_at"`
+
+	// UndoneAt is set once 'zen task sync undo' has restored the snapshot
+	UndoneAt *time.Time `json:"undone_at,omitempty"`
+
+	// Files are the files of the task the sync writes, as they were before it
+	Files []SnapshotFile `json:"files"`
+}
+
+// SnapshotFile is a file of a task as it was before a sync
+type SnapshotFile struct {
+	// Path is relative to the task directory
+	Path    string `json:"path"`
+	Content []byte `json:"content,omitempty"`
+
+	// Missing marks a file that did not exist, which undoing the sync removes
+	Missing bool `json:"missing,omitempty"`
+}
+
+// syncUndoFiles are the files of a task, relative to its directory, a sync changes and
+// undoing it restores. The sync metadata of the sources is left as the sync wrote it,
+// so that the restored values count as local changes and the next bidirectional sync
+// pushes them instead of taking the remote values again.
+var syncUndoFiles = []string{"manifest.yaml"}
+
+// recordSyncUndo snapshots the local state of task before a sync with source changes
+// it, and removes the snapshots the retention policy no longer keeps. It returns the ID
+// of the snapshot, or "" when snapshots are turned off.
+func (m *Manager) recordSyncUndo(task *Task, source string, direction SyncDirection) (string, error) {
+	record, err := m.takeSyncUndo(task, source, direction)
+	if err != nil || record == nil {
+		return "", err
+	}
+	return m.saveSyncUndo(task, record)
+}
+
+// takeSyncUndo reads the local state of task before a sync with source changes it,
+// returning nil when snapshots are turned off. Nothing is saved until saveSyncUndo, so
+// that a sync can take the snapshot before its first write and keep it only when it
+// changed the task.
+func (m *Manager) takeSyncUndo(task *Task, source string, direction SyncDirection) (*SyncUndoRecord, error) {
+	if m.syncUndoConfig().Keep == 0 || task.ManifestPath == "" {
+		return nil, nil
+	}
+
+	taskDir := filepath.Dir(task.ManifestPath)
+	now := time.Now().UTC()
+	record := &SyncUndoRecord{
+		ID:        now.Format("20060102T150405.000000000Z") + "-" + source,
+		TaskID:    task.ID,
+		Source:    source,
+		Direction: direction,
+		TakenAt:   now,
+	}
+	for _, path := range syncUndoFiles {
+		content, err := os.ReadFile(filepath.Join(taskDir, path))
+		switch {
+		case os.IsNotExist(err):
+			record.Files = append(record.Files, SnapshotFile{Path: path, Missing: true})
+		case err != nil:
+			return nil, fmt.Errorf("failed to snapshot %s: %w", path, err)
+		default:
+			record.Files = append(record.Files, SnapshotFile{Path: path, Content: content})
+		}
+	}
+	return record, nil
+}
+
+// saveSyncUndo saves a snapshot taken by takeSyncUndo, and removes the snapshots the
+// retention policy no longer keeps. It returns the ID of the snapshot.
+func (m *Manager) saveSyncUndo(task *Task, record *SyncUndoRecord) (string, error) {
+	undoDir := filepath.Join(task.MetadataPath, syncUndoDir)
+	if err := os.MkdirAll(undoDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create sync snapshot directory: %w", err)
+	}
+	if err := writeSyncUndoRecord(undoDir, record); err != nil {
+		return "", err
+	}
+
+	m.pruneSyncUndo(undoDir, m.syncUndoConfig(), record.TakenAt)
+	return record.ID, nil
+}
+
+// pruneSyncUndo removes the snapshots in undoDir beyond the newest policy.Keep, and
+// those older than policy.MaxAge
+func (m *Manager) pruneSyncUndo(undoDir string, policy SyncUndoConfig, now time.Time) {
+	records, err := readSyncUndoRecords(undoDir)
+	if err != nil {
+		m.logger.Debug("failed to read sync snapshots", "dir", undoDir, "error", err)
+		return
+	}
+	for i, record := range records {
+		expired := policy.MaxAge > 0 && now.Sub(record.TakenAt) > policy.MaxAge
+		if i < len(records)-policy.Keep || expired {
+			if err := os.Remove(filepath.Join(undoDir, record.ID+".json")); err != nil {
+				m.logger.Debug("failed to remove sync snapshot", "id", record.ID, "error", err)
+			}
+		}
+	}
+}
+
+// UndoSync restores a task to its state before its last sync not yet undone, and marks
+// the snapshot restored. Undoing again restores the sync before it.
+func (m *Manager) UndoSync(ctx context.Context, taskID string) (*SyncUndoRecord, error) {
+	task, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	undoDir := filepath.Join(task.MetadataPath, syncUndoDir)
+	records, err := readSyncUndoRecords(undoDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync snapshots: %w", err)
+	}
+
+	var record *SyncUndoRecord
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].UndoneAt == nil {
+			record = records[i]
+			break
+		}
+	}
+	if record == nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNotFound,
+			Message: fmt.Sprintf("no sync of %s to undo", taskID),
+			Details: "a snapshot is taken each time a sync changes the task, and kept as task.sync_undo configures",
+		}
+	}
+
+	lock, err := lockTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Release()
+
+	taskDir := filepath.Dir(task.ManifestPath)
+	for _, file := range record.Files {
+		path := filepath.Join(taskDir, filepath.FromSlash(file.Path))
+		if file.Missing {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to restore %s: %w", file.Path, err)
+			}
+			continue
+		}
+		if err := os.WriteFile(path, file.Content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", file.Path, err)
+		}
+	}
+
+	m.recordRevision(task)
+
+	now := time.Now().UTC()
+	record.UndoneAt = &now
+	if err := writeSyncUndoRecord(undoDir, record); err != nil {
+		return nil, err
+	}
+
+	m.logger.Info("sync undone", "task_id", taskID, "source", record.Source, "snapshot", record.ID)
+	return record, nil
+}
+
+// syncUndoConfig returns the retention policy of sync snapshots
+func (m *Manager) syncUndoConfig() SyncUndoConfig {
+	if m.settings == nil && m.factory == nil {
+		return DefaultConfig().SyncUndo
+	}
+	return m.taskConfig().SyncUndo
+}
+
+// readSyncUndoRecords returns the snapshots in undoDir, oldest first
+func readSyncUndoRecords(undoDir string) ([]*SyncUndoRecord, error) {
+	entries, err := os.ReadDir(undoDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*SyncUndoRecord{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(undoDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		record := &SyncUndoRecord{}
+		if err := json.Unmarshal(data, record); err != nil {
+			return nil, fmt.Errorf("invalid sync snapshot %s: %w", entry.Name(), err)
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	return records, nil
+}
+
+// writeSyncUndoRecord writes record to undoDir
+func writeSyncUndoRecord(undoDir string, record *SyncUndoRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sync snapshot: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(undoDir, record.ID+".json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync snapshot: %w", err)
+	}
+	return nil
+}
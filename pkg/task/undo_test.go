@@ -0,0 +1,126 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/integration/factory"
+	"github.com/daddia/zen/pkg/integration/plugin"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndoSync(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	manifestPath := filepath.Join(tasksDir, "PROJ-1", "manifest.yaml")
+	original, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+
+	task, err := m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	undoID, err := m.recordSyncUndo(task, "jira", SyncDirectionPull)
+	require.NoError(t, err)
+	require.NotEmpty(t, undoID)
+
+	task.Title = "Clobbered by the sync"
+	require.NoError(t, saveManifestFields(task))
+
+	record, err := m.UndoSync(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, undoID, record.ID)
+	assert.Equal(t, "jira", record.Source)
+	assert.NotNil(t, record.UndoneAt)
+
+	restored, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(restored))
+
+	_, err = m.UndoSync(ctx, "PROJ-1")
+	var typed *types.Error
+	require.ErrorAs(t, err, &typed, "a snapshot is restored once")
+	assert.Equal(t, types.ErrorCodeNotFound, typed.Code)
+}
+
+// boardTracker is a source whose issues are items on a project board
+type boardTracker struct {
+	plugin.IntegrationPluginInterface
+	*fakeProjectSyncer
+}
+
+type boardClientFactory struct {
+	factory.ClientFactoryInterface
+	tracker *boardTracker
+}
+
+func (f *boardClientFactory) CreatePlugin(ctx context.Context, providerName string) (plugin.IntegrationPluginInterface, error) {
+	return f.tracker, nil
+}
+
+func TestUndoSync_PulledStage(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	ctx := context.Background()
+	syncer := &fakeProjectSyncer{fields: &plugin.ProjectFields{Project: "my-org/7", Status: "Build"}}
+	m.clientFactory = &boardClientFactory{tracker: &boardTracker{fakeProjectSyncer: syncer}}
+
+	metadataDir := filepath.Join(tasksDir, "PROJ-1", "metadata")
+	require.NoError(t, os.MkdirAll(metadataDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(metadataDir, "github.json"), []byte(`{"external_id": "12"}`), 0600))
+
+	// The issue is unchanged; only its item moved on the board
+	unchanged := &TaskData{Title: "Add login page", Status: "proposed", Priority: "P2", Owner: "alice"}
+	task, undoID, err := m.pullFromSource(ctx, "PROJ-1", "github", unchanged)
+	require.NoError(t, err)
+	assert.Equal(t, "05-build", task.CurrentStage)
+	require.NotEmpty(t, undoID, "a pull that only moves the stage can be undone")
+
+	record, err := m.UndoSync(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, undoID, record.ID)
+
+	task, err = m.GetTask(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "01-align", task.CurrentStage)
+}
+
+func TestUndoSync_Retention(t *testing.T) {
+	m, tasksDir := newTestManager(t)
+	m.settings = &Config{SyncUndo: SyncUndoConfig{Keep: 2, MaxAge: time.Hour}}
+	task, err := m.GetTask(context.Background(), "PROJ-1")
+	require.NoError(t, err)
+
+	undoDir := filepath.Join(tasksDir, "PROJ-1", "metadata", syncUndoDir)
+	require.NoError(t, os.MkdirAll(undoDir, 0755))
+	require.NoError(t, writeSyncUndoRecord(undoDir, &SyncUndoRecord{ID: "20000101T000000.000000000Z-jira", TakenAt: time.Now().Add(-2 * time.Hour)}))
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id, err := m.recordSyncUndo(task, "jira", SyncDirectionBidirectional)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	records, err := readSyncUndoRecords(undoDir)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, ids[1:], []string{records[0].ID, records[1].ID}, "the newest snapshots are kept")
+
+	m.settings = &Config{SyncUndo: SyncUndoConfig{Keep: 0}}
+	id, err := m.recordSyncUndo(task, "jira", SyncDirectionPull)
+	require.NoError(t, err)
+	assert.Empty(t, id, "keep 0 turns snapshots off")
+}
+
+func TestSyncUndoConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().SyncUndo.Validate())
+	assert.Error(t, SyncUndoConfig{Keep: -1}.Validate())
+	assert.Error(t, SyncUndoConfig{MaxAge: -time.Hour}.Validate())
+
+	cfg, err := ConfigParser{}.Parse(map[string]interface{}{"sync_undo": map[string]interface{}{"keep": 3, "max_age": "72h"}})
+	require.NoError(t, err)
+	assert.Equal(t, SyncUndoConfig{Keep: 3, MaxAge: 72 * time.Hour}, cfg.SyncUndo)
+}
@@ -0,0 +1,214 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+)
+
+// CurrentUser is the value of owner conditions that stands for the user running zen
+const CurrentUser = "me"
+
+// FilterFields are the fields the conditions of filters compare
+var FilterFields = []string{"type", "status", "priority", "owner", "team", "stage", "label", "source"}
+
+// ListTable is the table tasks are listed in, with the columns they can be listed
+// with and sorted by
+var ListTable = cmdutil.Table[*Task]{
+	Columns: []cmdutil.Column[*Task]{
+		{Name: "id", Value: func(t *Task) string { return t.ID }},
+		{Name: "title", Value: func(t *Task) string { return t.Title }},
+		{Name: "type", Value: func(t *Task) string { return t.Type }},
+		{Name: "stage", Value: func(t *Task) string { return StageName(t.CurrentStage) },
+			Compare: func(a, b *Task) int { return strings.Compare(a.CurrentStage, b.CurrentStage) }},
+		{Name: "status", Value: func(t *Task) string { return t.Status }},
+		{Name: "priority", Value: func(t *Task) string { return t.Priority }},
+		{Name: "owner", Value: func(t *Task) string { return t.Owner }},
+		{Name: "team", Value: func(t *Task) string { return t.Team }},
+		{Name: "labels", Value: func(t *Task) string { return strings.Join(t.Labels, ", ") }},
+		{Name: "created", Value: func(t *Task) string { return formatDate(t.Created) },
+			Compare: func(a, b *Task) int { return a.Created.Compare(b.Created) }},
+		{Name: "updated", Value: func(t *Task) string { return formatDate(t.Updated) },
+			Compare: func(a, b *Task) int { return a.Updated.Compare(b.Updated) }},
+	},
+	Default: []string{"id", "title", "type", "stage", "status", "owner"},
+}
+
+// ListColumns are the columns tasks can be listed with
+var ListColumns = ListTable.Names()
+
+// DefaultListColumns are the columns tasks are listed with by default
+var DefaultListColumns = ListTable.Default
+
+// View is a saved filter of the task list, in the views of the task configuration
+type View struct {
+	// Filter is a list of conditions, such as "owner=me,status!=completed"
+	Filter string `yaml:"filter" json:"filter" mapstructure:"filter"`
+
+	// Columns the tasks of the view are listed with; defaults to DefaultListColumns
+	Columns []string `yaml:"columns,omitempty" json:"columns,omitempty" mapstructure:"columns"`
+}
+
+// Validate checks the filter and the columns of a view
+func (v View) Validate() error {
+	if _, err := ParseFilter(v.Filter); err != nil {
+		return err
+	}
+	return ValidateColumns(v.Columns)
+}
+
+// Condition compares a field of tasks with a value, or with any of the values separated
+// by '|'
+type Condition struct {
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	Negate bool   `json:"negate,omitempty"`
+}
+
+// String returns the condition as it is written in filters
+func (c Condition) String() string {
+	op := "="
+	if c.Negate {
+		op = "!="
+	}
+	return c.Field + op + c.Value
+}
+
+// ParseFilter parses a filter, a comma-separated list of conditions that must all
+// hold: field=value, or field!=value for tasks whose field has none of the values.
+// Values list alternatives separated by '|', as in status=proposed|in_progress, and
+// the owner "me" is the user running zen.
+func ParseFilter(filter string) ([]Condition, error) {
+	var conditions []Condition
+	for _, term := range strings.Split(filter, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		i := strings.Index(term, "=")
+		if i <= 0 || i == len(term)-1 {
+			return nil, fmt.Errorf("invalid condition %q (use field=value or field!=value)", term)
+		}
+		condition := Condition{Field: term[:i], Value: strings.TrimSpace(term[i+1:])}
+		if strings.HasSuffix(condition.Field, "!") {
+			condition.Field, condition.Negate = strings.TrimSuffix(condition.Field, "!"), true
+		}
+		condition.Field = strings.ToLower(strings.TrimSpace(condition.Field))
+		if !slices.Contains(FilterFields, condition.Field) {
+			return nil, fmt.Errorf("unknown field %q in condition %q (fields: %s)", condition.Field, term, strings.Join(FilterFields, ", "))
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// ValidateColumns returns an error unless columns are all columns of the task list
+func ValidateColumns(columns []string) error {
+	for _, column := range columns {
+		if !slices.Contains(ListColumns, strings.ToLower(column)) {
+			return fmt.Errorf("unknown column %q (columns: %s)", column, strings.Join(ListColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the field of task has one of the values of the condition, or
+// none of them when the condition is negated
+func (c Condition) Matches(task *Task) bool {
+	matched := false
+	for _, value := range strings.Split(c.Value, "|") {
+		if c.matchesValue(task, strings.TrimSpace(value)) {
+			matched = true
+			break
+		}
+	}
+	return matched != c.Negate
+}
+
+func (c Condition) matchesValue(task *Task, value string) bool {
+	switch c.Field {
+	case "type":
+		return strings.EqualFold(task.Type, value)
+	case "status":
+		return strings.EqualFold(task.Status, value)
+	case "priority":
+		return strings.EqualFold(task.Priority, value)
+	case "owner":
+		return strings.EqualFold(task.Owner, value)
+	case "team":
+		return strings.EqualFold(task.Team, value)
+	case "stage":
+		return task.CurrentStage == value || strings.EqualFold(StageName(task.CurrentStage), value)
+	case "label":
+		for _, label := range task.Labels {
+			if strings.EqualFold(label, value) {
+				return true
+			}
+		}
+	case "source":
+		_, ok := task.Sources[value]
+		return ok
+	}
+	return false
+}
+
+// forUser returns the filter with the owner "me" replaced by user
+func (f *TaskFilter) forUser(user string) *TaskFilter {
+	if f == nil || user == "" {
+		return f
+	}
+	resolved := *f
+	if strings.EqualFold(resolved.Owner, CurrentUser) {
+		resolved.Owner = user
+	}
+	resolved.Conditions = make([]Condition, len(f.Conditions))
+	for i, condition := range f.Conditions {
+		if condition.Field == "owner" {
+			values := strings.Split(condition.Value, "|")
+			for j, value := range values {
+				if strings.EqualFold(strings.TrimSpace(value), CurrentUser) {
+					values[j] = user
+				}
+			}
+			condition.Value = strings.Join(values, "|")
+		}
+		resolved.Conditions[i] = condition
+	}
+	return &resolved
+}
+
+// View returns the view named name, compared case-insensitively
+func (c Config) View(name string) (View, bool) {
+	if view, ok := c.Views[name]; ok {
+		return view, true
+	}
+	for viewName, view := range c.Views {
+		if strings.EqualFold(viewName, name) {
+			return view, true
+		}
+	}
+	return View{}, false
+}
+
+// ViewNames returns the names of the views, sorted
+func (c Config) ViewNames() []string {
+	names := make([]string, 0, len(c.Views))
+	for name := range c.Views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatDate returns the date of t, or nothing when t is unset
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	conditions, err := ParseFilter("owner=me, Status!=completed,label=frontend|backend,")
+	require.NoError(t, err)
+	assert.Equal(t, []Condition{
+		{Field: "owner", Value: "me"},
+		{Field: "status", Value: "completed", Negate: true},
+		{Field: "label", Value: "frontend|backend"},
+	}, conditions)
+	assert.Equal(t, "status!=completed", conditions[1].String())
+
+	conditions, err = ParseFilter("")
+	require.NoError(t, err)
+	assert.Empty(t, conditions)
+
+	for _, filter := range []string{"owner", "owner=", "=me", "assignee=me"} {
+		_, err := ParseFilter(filter)
+		assert.Error(t, err, filter)
+	}
+}
+
+func TestTaskFilter_Conditions(t *testing.T) {
+	tasks := []*Task{
+		{ID: "PROJ-1", Status: "in_progress", Owner: "alice", Labels: []string{"frontend"}, CurrentStage: "04-design"},
+		{ID: "PROJ-2", Status: "completed", Owner: "alice", Labels: []string{"backend"}, CurrentStage: "07-learn"},
+		{ID: "PROJ-3", Status: "proposed", Owner: "bob", CurrentStage: "01-align"},
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{filter: "owner=me,status!=completed", want: []string{"PROJ-1"}},
+		{filter: "status=proposed|in_progress", want: []string{"PROJ-1", "PROJ-3"}},
+		{filter: "label!=frontend|backend", want: []string{"PROJ-3"}},
+		{filter: "stage=design", want: []string{"PROJ-1"}},
+		{filter: "owner=BOB", want: []string{"PROJ-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			conditions, err := ParseFilter(tt.filter)
+			require.NoError(t, err)
+			filter := (&TaskFilter{Conditions: conditions}).forUser("alice")
+
+			var got []string
+			for _, task := range tasks {
+				if filter.Matches(task) {
+					got = append(got, task.ID)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaskFilter_ForUser(t *testing.T) {
+	filter := &TaskFilter{Owner: "me", Conditions: []Condition{{Field: "owner", Value: "me|bob"}}}
+
+	resolved := filter.forUser("alice")
+	assert.Equal(t, "alice", resolved.Owner)
+	assert.Equal(t, "alice|bob", resolved.Conditions[0].Value)
+	assert.Equal(t, "me|bob", filter.Conditions[0].Value, "the filter given is unchanged")
+}
+
+func TestConfig_View(t *testing.T) {
+	cfg := Config{Views: map[string]View{"my-open": {Filter: "owner=me"}, "web": {}}}
+
+	view, ok := cfg.View("My-Open")
+	require.True(t, ok)
+	assert.Equal(t, "owner=me", view.Filter)
+
+	_, ok = cfg.View("mine")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"my-open", "web"}, cfg.ViewNames())
+}
@@ -0,0 +1,110 @@
+package team
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// DefaultRole is the role of members added without one
+const DefaultRole = "member"
+
+// Member is a user on a team
+type Member struct {
+	// Name is the local user name, as in identity.users
+	Name string `yaml:"name" json:"name" mapstructure:"name"`
+
+	// Role is the role of the user on the team, such as lead or member
+	Role string `yaml:"role" json:"role" mapstructure:"role"`
+}
+
+// Team is a group of users who own tasks together
+type Team struct {
+	Description string   `yaml:"description" json:"description,omitempty" mapstructure:"description"`
+	Members     []Member `yaml:"members" json:"members" mapstructure:"members"`
+
+	// Reviewers are the users asked to review the team's pull requests
+	Reviewers []string `yaml:"reviewers" json:"reviewers,omitempty" mapstructure:"reviewers"`
+
+	// Capacity is the number of tasks the team works on at once; 0 is unlimited
+	Capacity int `yaml:"capacity" json:"capacity,omitempty" mapstructure:"capacity"`
+}
+
+// Config contains team configuration
+type Config struct {
+	// Default is the team of new tasks whose owner is on no team
+	Default string `yaml:"default" json:"default" mapstructure:"default"`
+
+	// Teams are the teams by name
+	Teams map[string]Team `yaml:"teams" json:"teams" mapstructure:"teams"`
+}
+
+// DefaultConfig returns default team configuration
+func DefaultConfig() Config {
+	return Config{
+		Teams: map[string]Team{},
+	}
+}
+
+// Implement config.Configurable interface
+
+// Validate validates the team configuration
+func (c Config) Validate() error {
+	for name, team := range c.Teams {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("team name cannot be empty")
+		}
+		if team.Capacity < 0 {
+			return fmt.Errorf("invalid capacity of team %s: %d", name, team.Capacity)
+		}
+		seen := map[string]bool{}
+		for _, member := range team.Members {
+			if strings.TrimSpace(member.Name) == "" {
+				return fmt.Errorf("team %s has a member without a name", name)
+			}
+			if seen[strings.ToLower(member.Name)] {
+				return fmt.Errorf("team %s lists %s twice", name, member.Name)
+			}
+			seen[strings.ToLower(member.Name)] = true
+		}
+	}
+	return nil
+}
+
+// Defaults returns a new Config with default values
+func (c Config) Defaults() config.Configurable {
+	return DefaultConfig()
+}
+
+// ConfigParser implements config.ConfigParser[Config] interface
+type ConfigParser struct{}
+
+// Parse converts raw configuration data to Config
+func (p ConfigParser) Parse(raw map[string]interface{}) (Config, error) {
+	cfg := DefaultConfig()
+
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return cfg, fmt.Errorf("failed to decode team config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Section returns the configuration section name for teams
+func (p ConfigParser) Section() string {
+	return "team"
+}
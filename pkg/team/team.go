@@ -0,0 +1,136 @@
+// Package team defines the teams of a workspace: their members and roles, the
+// reviewers of their pull requests, and their capacity. Teams set the default team of
+// new tasks, restrict who tasks of a team can be assigned to, and set the WIP limits
+// shown in flow metrics.
+package team
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Names returns the names of the teams, sorted
+func (c Config) Names() []string {
+	names := make([]string, 0, len(c.Teams))
+	for name := range c.Teams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Team returns the team named name, compared case-insensitively
+func (c Config) Team(name string) (Team, bool) {
+	if team, ok := c.Teams[name]; ok {
+		return team, true
+	}
+	for teamName, team := range c.Teams {
+		if strings.EqualFold(teamName, name) {
+			return team, true
+		}
+	}
+	return Team{}, false
+}
+
+// TeamOf returns the first team, by name, that user is a member of, or "" when they
+// are on no team
+func (c Config) TeamOf(user string) string {
+	for _, name := range c.Names() {
+		if c.Teams[name].HasMember(user) {
+			return name
+		}
+	}
+	return ""
+}
+
+// RolesOf returns the roles user has on any team, sorted and without duplicates
+func (c Config) RolesOf(user string) []string {
+	seen := map[string]bool{}
+	var roles []string
+	for _, name := range c.Names() {
+		member := c.Teams[name].Member(user)
+		if member == nil || member.Role == "" || seen[member.Role] {
+			continue
+		}
+		seen[member.Role] = true
+		roles = append(roles, member.Role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// DefaultTeam returns the team of a new task owned by owner: the owner's team, else
+// the default team
+func (c Config) DefaultTeam(owner string) string {
+	if name := c.TeamOf(owner); name != "" {
+		return name
+	}
+	return c.Default
+}
+
+// CheckAssignment returns an error when a task of team cannot be assigned to owner:
+// the team lists its members and owner is not one of them. Teams that are not defined
+// or list no members accept any owner.
+func (c Config) CheckAssignment(teamName, owner string) error {
+	team, ok := c.Team(teamName)
+	if !ok || len(team.Members) == 0 || owner == "" {
+		return nil
+	}
+	if !team.HasMember(owner) {
+		return fmt.Errorf("%s is not a member of team %s", owner, teamName)
+	}
+	return nil
+}
+
+// AddMember adds user to a team with role, defining the team when it does not exist.
+// A user already on the team has their role updated. It reports whether the user was
+// added rather than updated.
+func (c *Config) AddMember(teamName, user, role string) (bool, error) {
+	if strings.TrimSpace(teamName) == "" {
+		return false, fmt.Errorf("team name cannot be empty")
+	}
+	if strings.TrimSpace(user) == "" {
+		return false, fmt.Errorf("member name cannot be empty")
+	}
+	if role == "" {
+		role = DefaultRole
+	}
+	if c.Teams == nil {
+		c.Teams = map[string]Team{}
+	}
+
+	for name := range c.Teams {
+		if strings.EqualFold(name, teamName) {
+			teamName = name
+			break
+		}
+	}
+
+	team := c.Teams[teamName]
+	for i, member := range team.Members {
+		if strings.EqualFold(member.Name, user) {
+			team.Members[i].Role = role
+			c.Teams[teamName] = team
+			return false, nil
+		}
+	}
+	team.Members = append(team.Members, Member{Name: user, Role: role})
+	c.Teams[teamName] = team
+	return true, nil
+}
+
+// HasMember reports whether user is a member of the team
+func (t Team) HasMember(user string) bool {
+	return t.Member(user) != nil
+}
+
+// Member returns the member named user, or nil when they are not on the team
+func (t Team) Member(user string) *Member {
+	for i, member := range t.Members {
+		if strings.EqualFold(member.Name, user) {
+			return &t.Members[i]
+		}
+	}
+	return nil
+}
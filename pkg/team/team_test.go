@@ -0,0 +1,99 @@
+package team
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() Config {
+	return Config{
+		Default: "platform",
+		Teams: map[string]Team{
+			"platform": {Members: []Member{{Name: "ana", Role: "lead"}, {Name: "ben", Role: "member"}}, Capacity: 4},
+			"web":      {Members: []Member{{Name: "ben", Role: "member"}}},
+			"design":   {},
+		},
+	}
+}
+
+func TestConfig_DefaultTeam(t *testing.T) {
+	cfg := testConfig()
+
+	assert.Equal(t, "platform", cfg.DefaultTeam("ANA"))
+	assert.Equal(t, "platform", cfg.DefaultTeam("ben"), "the first team by name")
+	assert.Equal(t, "platform", cfg.DefaultTeam("carla"), "the default team")
+
+	cfg.Default = ""
+	assert.Equal(t, "", cfg.DefaultTeam("carla"))
+}
+
+func TestConfig_RolesOf(t *testing.T) {
+	cfg := testConfig()
+	cfg.Teams["web"].Members[0].Role = "reviewer"
+
+	assert.Equal(t, []string{"lead"}, cfg.RolesOf("ana"))
+	assert.Equal(t, []string{"member", "reviewer"}, cfg.RolesOf("ben"))
+	assert.Empty(t, cfg.RolesOf("carla"))
+}
+
+func TestConfig_CheckAssignment(t *testing.T) {
+	cfg := testConfig()
+
+	assert.NoError(t, cfg.CheckAssignment("platform", "ana"))
+	assert.NoError(t, cfg.CheckAssignment("Platform", "Ben"))
+	assert.EqualError(t, cfg.CheckAssignment("web", "ana"), "ana is not a member of team web")
+	assert.NoError(t, cfg.CheckAssignment("design", "ana"), "teams without members accept anyone")
+	assert.NoError(t, cfg.CheckAssignment("mobile", "ana"), "undefined teams accept anyone")
+}
+
+func TestConfig_AddMember(t *testing.T) {
+	cfg := testConfig()
+
+	added, err := cfg.AddMember("Web", "carla", "")
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, Member{Name: "carla", Role: DefaultRole}, cfg.Teams["web"].Members[1], "added to the existing team")
+
+	added, err = cfg.AddMember("web", "Carla", "lead")
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Len(t, cfg.Teams["web"].Members, 2)
+	assert.Equal(t, "lead", cfg.Teams["web"].Member("carla").Role)
+
+	added, err = (&Config{}).AddMember("mobile", "dan", "member")
+	require.NoError(t, err)
+	assert.True(t, added, "teams are defined as needed")
+
+	_, err = cfg.AddMember("web", " ", "member")
+	assert.EqualError(t, err, "member name cannot be empty")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := testConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Teams["web"] = Team{Members: []Member{{Name: "ben"}, {Name: "Ben"}}}
+	assert.EqualError(t, cfg.Validate(), "team web lists Ben twice")
+
+	cfg.Teams["web"] = Team{Capacity: -1}
+	assert.EqualError(t, cfg.Validate(), "invalid capacity of team web: -1")
+}
+
+func TestConfigParser(t *testing.T) {
+	cfg, err := ConfigParser{}.Parse(map[string]interface{}{
+		"default": "platform",
+		"teams": map[string]interface{}{
+			"platform": map[string]interface{}{
+				"members":   []interface{}{map[string]interface{}{"name": "ana", "role": "lead"}},
+				"reviewers": []interface{}{"ana"},
+				"capacity":  "4",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "platform", cfg.Default)
+	assert.Equal(t, Team{Members: []Member{{Name: "ana", Role: "lead"}}, Reviewers: []string{"ana"}, Capacity: 4}, cfg.Teams["platform"])
+	assert.Equal(t, "team", ConfigParser{}.Section())
+}
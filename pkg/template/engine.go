@@ -195,6 +195,11 @@ func (e *Engine) LoadTemplate(ctx context.Context, name string) (*Template, erro
 		return nil, err
 	}
 
+	// Templates and partials the template depends on can be included by name
+	if err := e.associateDependencies(tmpl, assetContent.Dependencies); err != nil {
+		return nil, err
+	}
+
 	// Cache compiled template if enabled
 	if e.config.CacheEnabled {
 		if err := e.cache.Set(name, tmpl); err != nil {
@@ -253,6 +258,29 @@ func (e *Engine) CompileTemplate(ctx context.Context, name, content string, meta
 	return tmpl, nil
 }
 
+// associateDependencies parses the templates and partials among dependencies as templates
+// associated with tmpl, named after their assets, so that tmpl can include them with
+// {{template "name" .}}. Other dependencies, such as schemas, are left out.
+func (e *Engine) associateDependencies(tmpl *Template, dependencies []assets.AssetContent) error {
+	for _, dependency := range dependencies {
+		switch dependency.Metadata.Type {
+		case assets.AssetTypeTemplate, assets.AssetTypePartial:
+		default:
+			continue
+		}
+
+		if _, err := tmpl.Compiled.New(dependency.Metadata.Name).Parse(dependency.Content); err != nil {
+			return &TemplateEngineError{
+				Code:    ErrorCodeCompilationFailed,
+				Message: fmt.Sprintf("failed to compile '%s', a dependency of template '%s': %v", dependency.Metadata.Name, tmpl.Name, err),
+				Details: err,
+			}
+		}
+		e.logger.Debug("template dependency associated", "name", tmpl.Name, "dependency", dependency.Metadata.Name)
+	}
+	return nil
+}
+
 // RenderTemplate renders a template with provided variables
 func (e *Engine) RenderTemplate(ctx context.Context, tmpl *Template, variables map[string]interface{}) (string, error) {
 	e.logger.Debug("rendering template", "name", tmpl.Name, "variables", len(variables))
@@ -264,6 +264,48 @@ func TestEngine_LoadTemplate(t *testing.T) {
 	mockAssetClient.AssertExpectations(t)
 }
 
+func TestEngine_LoadTemplateWithDependencies(t *testing.T) {
+	mockAssetClient := &MockAssetClient{}
+	config := Config{WorkspaceRoot: "/test/workspace"}
+	config.DefaultDelims.Left = "{{"
+	config.DefaultDelims.Right = "}}"
+	engine := NewEngine(logging.NewBasic(), mockAssetClient, config)
+
+	templateContent := &assets.AssetContent{
+		Metadata: assets.AssetMetadata{
+			Name:         "page",
+			Type:         assets.AssetTypeTemplate,
+			Dependencies: []string{"header", "page-schema"},
+		},
+		Content: `{{template "header" .}}Body of {{.name}}`,
+		Dependencies: []assets.AssetContent{
+			{Metadata: assets.AssetMetadata{Name: "header", Type: assets.AssetTypePartial}, Content: "# {{.name}}\n"},
+			{Metadata: assets.AssetMetadata{Name: "page-schema", Type: assets.AssetTypeSchema}, Content: `{"type": "object"}`},
+		},
+	}
+	mockAssetClient.On("GetAsset", mock.Anything, "page", mock.Anything).Return(templateContent, nil)
+
+	ctx := context.Background()
+	tmpl, err := engine.LoadTemplate(ctx, "page")
+	require.NoError(t, err)
+	assert.Nil(t, tmpl.Compiled.Lookup("page-schema"), "schemas are not templates")
+
+	result, err := engine.RenderTemplate(ctx, tmpl, map[string]interface{}{"name": "Ada"})
+	require.NoError(t, err)
+	assert.Equal(t, "# Ada\nBody of Ada", result)
+
+	t.Run("invalid partial", func(t *testing.T) {
+		broken := *templateContent
+		broken.Metadata.Name = "broken"
+		broken.Dependencies = []assets.AssetContent{{Metadata: assets.AssetMetadata{Name: "header", Type: assets.AssetTypePartial}, Content: "{{.name"}}
+		mockAssetClient.On("GetAsset", mock.Anything, "broken", mock.Anything).Return(&broken, nil)
+
+		_, err := engine.LoadTemplate(ctx, "broken")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a dependency of template 'broken'")
+	})
+}
+
 func TestEngine_LoadTemplateWithCache(t *testing.T) {
 	logger := logging.NewBasic()
 	mockAssetClient := &MockAssetClient{}
@@ -0,0 +1,606 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"unicode"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/assets"
+	"gopkg.in/yaml.v3"
+)
+
+// LintManifestPath is where the manifest lives in an asset repository
+const LintManifestPath = "assets/manifest.yaml"
+
+// LintSeverity is how serious a lint issue is. Errors fail a lint; warnings fail only a
+// strict one.
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// Lint rules, each reported with the issues it finds
+const (
+	LintRuleManifest           = "manifest"
+	LintRuleMissingFile        = "missing-file"
+	LintRuleSyntax             = "syntax"
+	LintRuleUndefinedVariable  = "undefined-variable"
+	LintRuleUnreachablePartial = "unreachable-partial"
+	LintRuleFrontMatter        = "front-matter"
+	LintRuleBrokenLink         = "broken-link"
+)
+
+// LintIssue is a problem found in an asset or in the manifest
+type LintIssue struct {
+	Asset    string       `json:"asset,omitempty" yaml:"asset,omitempty"`
+	Path     string       `json:"path" yaml:"path"`
+	Line     int          `json:"line,omitempty" yaml:"line,omitempty"`
+	Rule     string       `json:"rule" yaml:"rule"`
+	Severity LintSeverity `json:"severity" yaml:"severity"`
+	Message  string       `json:"message" yaml:"message"`
+}
+
+// LintReport lists the issues found in an asset repository
+type LintReport struct {
+	Assets   int         `json:"assets" yaml:"assets"`
+	Errors   int         `json:"errors" yaml:"errors"`
+	Warnings int         `json:"warnings" yaml:"warnings"`
+	Issues   []LintIssue `json:"issues" yaml:"issues"`
+}
+
+// Failed reports whether the lint found errors, or with strict, any issue at all
+func (r *LintReport) Failed(strict bool) bool {
+	return r.Errors > 0 || (strict && r.Warnings > 0)
+}
+
+// LintOptions configures a Linter
+type LintOptions struct {
+	// Variables are available to every template without being declared, such as the
+	// task variables zen draft passes to templates
+	Variables []string
+}
+
+// Linter checks the template, prompt, and partial assets of an asset repository for
+// undefined variables, partials that cannot be reached, front matter that does not
+// follow the template metadata schema, and broken internal links
+type Linter struct {
+	logger    logging.Logger
+	functions template.FuncMap
+	variables map[string]bool
+}
+
+// NewLinter creates a linter that parses templates with the functions of the engine
+func NewLinter(logger logging.Logger, opts LintOptions) *Linter {
+	functions := NewFunctionRegistry(logger, "")
+	if err := functions.RegisterZenFunctions(); err != nil {
+		logger.Warn("failed to register Zen functions", "error", err)
+	}
+
+	variables := make(map[string]bool, len(opts.Variables))
+	for _, name := range opts.Variables {
+		variables[name] = true
+	}
+
+	return &Linter{
+		logger:    logger,
+		functions: functions.GetFunctions(),
+		variables: variables,
+	}
+}
+
+// lintedAsset is an asset of the manifest with what its template uses and defines
+type lintedAsset struct {
+	metadata  assets.AssetMetadata
+	content   string
+	parsed    bool
+	variables map[string]bool // Declared in front matter, or else in the manifest
+	fields    []templateUse   // Top-level variables the template reads
+	templates []templateUse   // Templates it includes with {{template}}
+	defines   []string        // Templates it defines with {{define}} or {{block}}
+}
+
+// templateUse is a name used by a template, at the line of its first use
+type templateUse struct {
+	name string
+	line int
+}
+
+// Lint checks the asset repository in fsys, with the manifest at LintManifestPath. It
+// fails only when the manifest cannot be read; problems in assets are issues of the
+// report.
+func (l *Linter) Lint(ctx context.Context, fsys fs.FS) (*LintReport, error) {
+	content, err := fs.ReadFile(fsys, LintManifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", LintManifestPath, err)
+	}
+
+	report := &LintReport{}
+	parser := assets.NewYAMLManifestParser(l.logger)
+	if err := parser.Validate(ctx, content); err != nil {
+		report.add(LintIssue{Path: LintManifestPath, Rule: LintRuleManifest, Severity: LintSeverityError, Message: err.Error()})
+	}
+	manifest, err := parser.Parse(ctx, content)
+	if err != nil {
+		report.add(LintIssue{Path: LintManifestPath, Rule: LintRuleManifest, Severity: LintSeverityError, Message: err.Error()})
+		report.sort()
+		return report, nil
+	}
+
+	byName := make(map[string]*lintedAsset, len(manifest))
+	var linted []*lintedAsset
+	for _, metadata := range manifest {
+		if _, ok := byName[metadata.Name]; ok {
+			continue
+		}
+		asset := &lintedAsset{metadata: metadata}
+		byName[metadata.Name] = asset
+		linted = append(linted, asset)
+		report.Assets++
+
+		data, err := fs.ReadFile(fsys, metadata.Path)
+		if err != nil {
+			report.add(asset.issue(0, LintRuleMissingFile, LintSeverityError, fmt.Sprintf("file %s of asset '%s' not found", metadata.Path, metadata.Name)))
+			continue
+		}
+		if !isTemplated(metadata.Type) {
+			continue
+		}
+		asset.content = string(data)
+
+		l.lintFrontMatter(report, asset)
+		l.parseTemplate(report, asset)
+		lintLinks(report, fsys, asset)
+	}
+
+	l.lintVariables(report, linted, byName)
+	lintPartials(report, linted, byName)
+
+	report.sort()
+	return report, nil
+}
+
+// isTemplated reports whether assets of type t are text templates
+func isTemplated(t assets.AssetType) bool {
+	return t == assets.AssetTypeTemplate || t == assets.AssetTypePrompt || t == assets.AssetTypePartial
+}
+
+func (a *lintedAsset) issue(line int, rule string, severity LintSeverity, message string) LintIssue {
+	return LintIssue{Asset: a.metadata.Name, Path: a.metadata.Path, Line: line, Rule: rule, Severity: severity, Message: message}
+}
+
+func (r *LintReport) add(issue LintIssue) {
+	if issue.Severity == LintSeverityError {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+	r.Issues = append(r.Issues, issue)
+}
+
+func (r *LintReport) sort() {
+	sort.SliceStable(r.Issues, func(i, j int) bool {
+		a, b := r.Issues[i], r.Issues[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Line < b.Line
+	})
+}
+
+var (
+	lintFrontMatterPattern = regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---\s*\n`)
+	yamlErrorLinePattern   = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	parseErrorLinePattern  = regexp.MustCompile(`^template: [^:]*:(\d+):(?:\d+:)? ?(.*)$`)
+)
+
+// lintVariableTypes are the variable types the validator knows
+var lintVariableTypes = map[string]bool{
+	"": true, "string": true, "str": true, "int": true, "integer": true, "float": true,
+	"float64": true, "number": true, "bool": true, "boolean": true, "slice": true,
+	"array": true, "list": true, "map": true, "object": true, "any": true,
+	"interface{}": true, "interface": true,
+}
+
+// lintFrontMatter checks the front matter of an asset against TemplateMetadata, and sets
+// the variables the asset declares
+func (l *Linter) lintFrontMatter(report *LintReport, asset *lintedAsset) {
+	asset.variables = make(map[string]bool)
+	for _, variable := range asset.metadata.Variables {
+		asset.variables[variable.Name] = true
+	}
+
+	matches := lintFrontMatterPattern.FindStringSubmatch(asset.content)
+	if matches == nil {
+		return
+	}
+
+	// The front matter starts on the line after the opening ---
+	decoder := yaml.NewDecoder(strings.NewReader(matches[1]))
+	decoder.KnownFields(true)
+	var metadata TemplateMetadata
+	if err := decoder.Decode(&metadata); err != nil {
+		messages := []string{err.Error()}
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			messages = typeErr.Errors
+		}
+		for _, message := range messages {
+			line := 1
+			if m := yamlErrorLinePattern.FindStringSubmatch(message); m != nil {
+				n, _ := strconv.Atoi(m[1])
+				line, message = n+1, m[2]
+			}
+			report.add(asset.issue(line, LintRuleFrontMatter, LintSeverityError, "invalid front matter: "+message))
+		}
+		return
+	}
+
+	if len(metadata.Variables) == 0 {
+		return
+	}
+	// Variables of the front matter take precedence over those of the manifest
+	asset.variables = make(map[string]bool)
+	for i, variable := range metadata.Variables {
+		switch {
+		case variable.Name == "":
+			report.add(asset.issue(1, LintRuleFrontMatter, LintSeverityError, fmt.Sprintf("variable %d of the front matter has no name", i+1)))
+			continue
+		case asset.variables[variable.Name]:
+			report.add(asset.issue(1, LintRuleFrontMatter, LintSeverityError, fmt.Sprintf("variable '%s' is declared more than once", variable.Name)))
+		case !lintVariableTypes[strings.ToLower(variable.Type)]:
+			report.add(asset.issue(1, LintRuleFrontMatter, LintSeverityError, fmt.Sprintf("variable '%s' has unknown type '%s'", variable.Name, variable.Type)))
+		}
+		asset.variables[variable.Name] = true
+	}
+}
+
+// parseTemplate parses an asset as a template, recording the variables it reads and the
+// templates it includes and defines
+func (l *Linter) parseTemplate(report *LintReport, asset *lintedAsset) {
+	name := asset.metadata.Name
+	tmpl, err := template.New(name).Funcs(l.functions).Parse(asset.content)
+	if err != nil {
+		line, message := 0, err.Error()
+		if m := parseErrorLinePattern.FindStringSubmatch(message); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			message = m[2]
+		}
+		report.add(asset.issue(line, LintRuleSyntax, LintSeverityError, message))
+		return
+	}
+	asset.parsed = true
+
+	walker := &templateWalker{content: asset.content, seen: make(map[string]bool)}
+	for _, associated := range tmpl.Templates() {
+		if associated.Tree == nil {
+			continue
+		}
+		if associated.Name() == name {
+			walker.dollarIsRoot = true
+			walker.walk(associated.Tree.Root, true)
+			continue
+		}
+		asset.defines = append(asset.defines, associated.Name())
+		// The data of defined templates is whatever they are called with
+		walker.dollarIsRoot = false
+		walker.walk(associated.Tree.Root, false)
+	}
+	asset.fields, asset.templates = walker.fields, walker.templates
+}
+
+// templateWalker collects the top-level fields read and the templates included by the
+// nodes of a parsed template
+type templateWalker struct {
+	content      string
+	dollarIsRoot bool
+	seen         map[string]bool
+	fields       []templateUse
+	templates    []templateUse
+}
+
+// walk visits node, where root tells whether dot is the data of the template
+func (w *templateWalker) walk(node parse.Node, root bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			w.walk(child, root)
+		}
+	case *parse.ActionNode:
+		w.pipe(n.Pipe, root)
+	case *parse.IfNode:
+		w.pipe(n.Pipe, root)
+		w.walk(n.List, root)
+		w.walk(n.ElseList, root)
+	case *parse.RangeNode:
+		w.pipe(n.Pipe, root)
+		w.walk(n.List, false)
+		w.walk(n.ElseList, root)
+	case *parse.WithNode:
+		w.pipe(n.Pipe, root)
+		w.walk(n.List, false)
+		w.walk(n.ElseList, root)
+	case *parse.TemplateNode:
+		w.use(&w.templates, "template:", n.Name, n.Pos)
+		w.pipe(n.Pipe, root)
+	}
+}
+
+func (w *templateWalker) pipe(pipe *parse.PipeNode, root bool) {
+	if pipe == nil {
+		return
+	}
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			w.arg(arg, root)
+		}
+	}
+}
+
+func (w *templateWalker) arg(node parse.Node, root bool) {
+	switch n := node.(type) {
+	case *parse.FieldNode:
+		if root {
+			w.use(&w.fields, "field:", n.Ident[0], n.Pos)
+		}
+	case *parse.VariableNode:
+		if w.dollarIsRoot && len(n.Ident) > 1 && n.Ident[0] == "$" {
+			w.use(&w.fields, "field:", n.Ident[1], n.Pos)
+		}
+	case *parse.ChainNode:
+		w.arg(n.Node, root)
+	case *parse.PipeNode:
+		w.pipe(n, root)
+	}
+}
+
+// use records the first use of a name
+func (w *templateWalker) use(uses *[]templateUse, kind, name string, pos parse.Pos) {
+	if w.seen[kind+name] {
+		return
+	}
+	w.seen[kind+name] = true
+	*uses = append(*uses, templateUse{name: name, line: 1 + strings.Count(w.content[:min(int(pos), len(w.content))], "\n")})
+}
+
+// lintVariables reports the variables templates read that neither they nor the linter
+// declare. Partials may also read the variables of the assets that depend on them.
+func (l *Linter) lintVariables(report *LintReport, linted []*lintedAsset, byName map[string]*lintedAsset) {
+	inherited := make(map[string]map[string]bool)
+	for _, asset := range linted {
+		if asset.metadata.Type == assets.AssetTypePartial {
+			continue
+		}
+		for _, dependency := range lintClosure(asset.metadata.Name, byName) {
+			if inherited[dependency] == nil {
+				inherited[dependency] = make(map[string]bool)
+			}
+			for variable := range asset.variables {
+				inherited[dependency][variable] = true
+			}
+		}
+	}
+
+	for _, asset := range linted {
+		for _, field := range asset.fields {
+			if asset.variables[field.name] || l.variables[field.name] || inherited[asset.metadata.Name][field.name] {
+				continue
+			}
+			report.add(asset.issue(field.line, LintRuleUndefinedVariable, LintSeverityError,
+				fmt.Sprintf("variable '%s' is not declared by the asset", field.name)))
+		}
+	}
+}
+
+// lintPartials reports templates included by assets that neither define them nor depend
+// on them, and partials that no asset depends on
+func lintPartials(report *LintReport, linted []*lintedAsset, byName map[string]*lintedAsset) {
+	reachable := make(map[string]bool)
+	for _, asset := range linted {
+		if !asset.parsed {
+			continue
+		}
+		closure := lintClosure(asset.metadata.Name, byName)
+		if asset.metadata.Type != assets.AssetTypePartial {
+			for _, dependency := range closure {
+				reachable[dependency] = true
+			}
+		}
+
+		// The engine associates templates and partials among dependencies by name, with
+		// the templates they define
+		available := make(map[string]bool)
+		for _, name := range asset.defines {
+			available[name] = true
+		}
+		for _, dependency := range closure {
+			if d := byName[dependency]; isTemplated(d.metadata.Type) {
+				available[dependency] = true
+				for _, name := range d.defines {
+					available[name] = true
+				}
+			}
+		}
+		for _, included := range asset.templates {
+			if available[included.name] {
+				continue
+			}
+			message := fmt.Sprintf("template '%s' is not defined by the asset or its dependencies", included.name)
+			if d, ok := byName[included.name]; ok && isTemplated(d.metadata.Type) {
+				message = fmt.Sprintf("template '%s' is included but is not a dependency of the asset", included.name)
+			}
+			report.add(asset.issue(included.line, LintRuleUnreachablePartial, LintSeverityError, message))
+		}
+	}
+
+	for _, asset := range linted {
+		if asset.metadata.Type == assets.AssetTypePartial && !reachable[asset.metadata.Name] {
+			report.add(asset.issue(0, LintRuleUnreachablePartial, LintSeverityWarning,
+				fmt.Sprintf("partial '%s' is not a dependency of any template or prompt", asset.metadata.Name)))
+		}
+	}
+}
+
+// lintClosure returns the names of the assets in the manifest that the asset named name
+// depends on, directly or not. Missing dependencies and cycles are left to the manifest
+// validation.
+func lintClosure(name string, byName map[string]*lintedAsset) []string {
+	visited := map[string]bool{name: true}
+	var closure []string
+	var visit func(name string)
+	visit = func(name string) {
+		for _, dependency := range byName[name].metadata.Dependencies {
+			if visited[dependency] || byName[dependency] == nil {
+				continue
+			}
+			visited[dependency] = true
+			closure = append(closure, dependency)
+			visit(dependency)
+		}
+	}
+	visit(name)
+	return closure
+}
+
+var (
+	inlineLinkPattern    = regexp.MustCompile(`\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	referenceLinkPattern = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?([^\s>]+)>?`)
+	headingPattern       = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	linkSchemePattern    = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+)
+
+// lintLinks reports Markdown links of an asset to files that are not in the repository,
+// and to headings that are not in the linked document. Links to other sites, and links
+// built by template actions, are not checked.
+func lintLinks(report *LintReport, fsys fs.FS, asset *lintedAsset) {
+	inFence := false
+	for i, line := range strings.Split(asset.content, "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		var targets []string
+		for _, m := range inlineLinkPattern.FindAllStringSubmatch(line, -1) {
+			targets = append(targets, m[1])
+		}
+		if m := referenceLinkPattern.FindStringSubmatch(line); m != nil {
+			targets = append(targets, m[1])
+		}
+
+		for _, target := range targets {
+			if message := checkLink(fsys, asset, target); message != "" {
+				report.add(asset.issue(i+1, LintRuleBrokenLink, LintSeverityError, message))
+			}
+		}
+	}
+}
+
+// checkLink returns why target, linked from asset, is broken, or "" when it is not
+func checkLink(fsys fs.FS, asset *lintedAsset, target string) string {
+	if strings.Contains(target, "{{") || linkSchemePattern.MatchString(target) || strings.HasPrefix(target, "//") {
+		return ""
+	}
+
+	file, anchor, _ := strings.Cut(target, "#")
+	content := asset.content
+	if file != "" {
+		resolved := path.Clean(path.Join(path.Dir(asset.metadata.Path), file))
+		if strings.HasPrefix(file, "/") {
+			resolved = strings.TrimPrefix(path.Clean(file), "/")
+		}
+		if !fs.ValidPath(resolved) {
+			return fmt.Sprintf("link to %s leaves the repository", target)
+		}
+		info, err := fs.Stat(fsys, resolved)
+		if err != nil {
+			return fmt.Sprintf("link to %s: %s is not in the repository", target, resolved)
+		}
+		if anchor == "" || info.IsDir() || !isMarkdown(resolved) {
+			return ""
+		}
+		data, err := fs.ReadFile(fsys, resolved)
+		if err != nil {
+			return ""
+		}
+		content = string(data)
+	}
+
+	if anchor == "" {
+		return ""
+	}
+	anchors, templated := headingAnchors(content)
+	if anchors[strings.ToLower(anchor)] || templated {
+		return ""
+	}
+	return fmt.Sprintf("link to %s: no heading with anchor #%s", target, anchor)
+}
+
+func isMarkdown(name string) bool {
+	name = strings.TrimSuffix(strings.TrimSuffix(name, ".tmpl"), ".template")
+	return strings.HasSuffix(name, ".md") || strings.HasSuffix(name, ".markdown")
+}
+
+// headingAnchors returns the anchors GitHub gives the Markdown headings of content, and
+// whether a heading is built by template actions, so that its anchor is unknown
+func headingAnchors(content string) (map[string]bool, bool) {
+	anchors := make(map[string]bool)
+	counts := make(map[string]int)
+	templated := false
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		m := headingPattern.FindStringSubmatch(line)
+		if inFence || m == nil {
+			continue
+		}
+		if strings.Contains(m[1], "{{") {
+			templated = true
+			continue
+		}
+
+		slug := headingSlug(m[1])
+		if n := counts[slug]; n > 0 {
+			anchors[fmt.Sprintf("%s-%d", slug, n)] = true
+		} else {
+			anchors[slug] = true
+		}
+		counts[slug]++
+	}
+	return anchors, templated
+}
+
+// headingSlug returns the anchor of a heading: lower case, with spaces turned into
+// hyphens and punctuation left out
+func headingSlug(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
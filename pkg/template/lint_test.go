@@ -0,0 +1,164 @@
+package template
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lintManifest = `
+schema_version: "2.0"
+activities:
+  feature-spec:
+    name: "feature-spec"
+    command: "feature-spec"
+    description: "Feature specification"
+    format: markdown
+    dependencies: ["header"]
+    variables:
+      - {name: FEATURE, description: "Feature name", required: true, type: string}
+    assets:
+      output: ["templates/feature-spec.md.tmpl"]
+assets:
+  header:
+    type: partial
+    path: partials/header.md.tmpl
+  review-prompt:
+    type: prompt
+    path: prompts/review.md
+  orphan:
+    type: partial
+    path: partials/orphan.md.tmpl
+  api-schema:
+    type: schema
+    path: schemas/api.json
+`
+
+func lintFS(files map[string]string) fstest.MapFS {
+	fsys := fstest.MapFS{"assets/manifest.yaml": {Data: []byte(lintManifest)}}
+	for name, content := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	return fsys
+}
+
+func TestLinter_Clean(t *testing.T) {
+	fsys := lintFS(map[string]string{
+		"templates/feature-spec.md.tmpl": "{{template \"header\" .}}\n# {{.FEATURE}} for {{.TASK_ID}}\n\n## Design Notes\n\nSee [notes](#design-notes) and [the guide](../docs/guide.md#usage).\n" +
+			"{{range .LABELS}}- {{.}}\n{{end}}{{with .FEATURE}}{{.Name}}{{end}}\n[site](https://example.com/x.md)\n",
+		"partials/header.md.tmpl": "> {{.FEATURE}} {{upper .TASK_ID}}\n",
+		"partials/orphan.md.tmpl": "{{define \"row\"}}{{.Cell}}{{end}}\n",
+		"prompts/review.md":       "---\nname: review\nvariables:\n  - {name: CODE, type: string, required: true}\n---\nReview {{.CODE}}\n",
+		"docs/guide.md":           "# Guide\n\n## Usage\n",
+		"schemas/api.json":        "{}",
+	})
+
+	report, err := NewLinter(logging.NewBasic(), LintOptions{Variables: []string{"TASK_ID", "LABELS"}}).Lint(context.Background(), fsys)
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.Assets)
+	assert.Equal(t, 0, report.Errors, "%+v", report.Issues)
+
+	// The only issue is the partial nothing depends on
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, LintRuleUnreachablePartial, report.Issues[0].Rule)
+	assert.Equal(t, LintSeverityWarning, report.Issues[0].Severity)
+	assert.Equal(t, "orphan", report.Issues[0].Asset)
+	assert.False(t, report.Failed(false))
+	assert.True(t, report.Failed(true))
+}
+
+func TestLinter_Issues(t *testing.T) {
+	fsys := lintFS(map[string]string{
+		"templates/feature-spec.md.tmpl": "Spec {{.FEATURE}}\n{{.OWNER}} {{$.OWNER}}\n{{template \"footer\" .}}\n{{template \"orphan\" .}}\n" +
+			"[missing](missing.md) [anchor](#nowhere) [up](../../etc/passwd)\n```\n[ignored](in-code.md)\n```\n",
+		"partials/header.md.tmpl": "{{.FEATURE}}\n",
+		"partials/orphan.md.tmpl": "{{if}}\n",
+		"prompts/review.md":       "---\nname: review\nauthor: [not, a, string]\nvariables:\n  - {type: string}\n  - {name: CODE, type: widget}\nunknown: 1\n---\nReview\n",
+	})
+
+	report, err := NewLinter(logging.NewBasic(), LintOptions{}).Lint(context.Background(), fsys)
+	require.NoError(t, err)
+
+	type found struct {
+		Asset string
+		Line  int
+		Rule  string
+	}
+	var issues []found
+	for _, issue := range report.Issues {
+		issues = append(issues, found{issue.Asset, issue.Line, issue.Rule})
+	}
+	assert.ElementsMatch(t, []found{
+		{"orphan", 1, LintRuleSyntax},
+		{"review-prompt", 3, LintRuleFrontMatter},
+		{"review-prompt", 7, LintRuleFrontMatter},
+		{"api-schema", 0, LintRuleMissingFile},
+		{"feature-spec", 2, LintRuleUndefinedVariable},
+		{"feature-spec", 3, LintRuleUnreachablePartial},
+		{"feature-spec", 4, LintRuleUnreachablePartial},
+		{"feature-spec", 5, LintRuleBrokenLink},
+		{"feature-spec", 5, LintRuleBrokenLink},
+		{"feature-spec", 5, LintRuleBrokenLink},
+		{"orphan", 0, LintRuleUnreachablePartial},
+	}, issues, "%+v", report.Issues)
+	assert.True(t, report.Failed(false))
+
+	messages := make(map[string]bool)
+	for _, issue := range report.Issues {
+		messages[issue.Message] = true
+	}
+	assert.True(t, messages["variable 'OWNER' is not declared by the asset"])
+	assert.True(t, messages["template 'footer' is not defined by the asset or its dependencies"])
+	assert.True(t, messages["template 'orphan' is included but is not a dependency of the asset"])
+	assert.True(t, messages["link to #nowhere: no heading with anchor #nowhere"])
+	assert.True(t, messages["link to ../../etc/passwd leaves the repository"])
+	assert.True(t, messages["file schemas/api.json of asset 'api-schema' not found"])
+}
+
+func TestLinter_FrontMatterVariables(t *testing.T) {
+	fsys := lintFS(map[string]string{
+		"templates/feature-spec.md.tmpl": "{{template \"header\" .}}{{.FEATURE}}",
+		"partials/header.md.tmpl":        "---\nvariables:\n  - {name: TITLE, type: string}\n  - {name: TITLE, type: string}\n---\n{{.TITLE}} {{.FEATURE}}\n",
+		"partials/orphan.md.tmpl":        "",
+		"prompts/review.md":              "",
+		"schemas/api.json":               "{}",
+	})
+
+	report, err := NewLinter(logging.NewBasic(), LintOptions{}).Lint(context.Background(), fsys)
+	require.NoError(t, err)
+
+	var rules []string
+	for _, issue := range report.Issues {
+		if issue.Asset == "header" {
+			rules = append(rules, issue.Rule)
+		}
+	}
+	// FEATURE is declared by feature-spec, which depends on the partial
+	assert.Equal(t, []string{LintRuleFrontMatter}, rules)
+}
+
+func TestLinter_InvalidManifest(t *testing.T) {
+	fsys := fstest.MapFS{"assets/manifest.yaml": {Data: []byte("schema_version: \"9.0\"\n")}}
+
+	report, err := NewLinter(logging.NewBasic(), LintOptions{}).Lint(context.Background(), fsys)
+	require.NoError(t, err)
+	require.NotEmpty(t, report.Issues)
+	assert.Equal(t, LintRuleManifest, report.Issues[0].Rule)
+	assert.Equal(t, LintManifestPath, report.Issues[0].Path)
+
+	_, err = NewLinter(logging.NewBasic(), LintOptions{}).Lint(context.Background(), fstest.MapFS{})
+	assert.ErrorContains(t, err, "failed to read assets/manifest.yaml")
+}
+
+func TestHeadingAnchors(t *testing.T) {
+	anchors, templated := headingAnchors("# Getting Started!\n## Usage\n## Usage\n```\n# not a heading\n```\n")
+	assert.False(t, templated)
+	assert.Equal(t, map[string]bool{"getting-started": true, "usage": true, "usage-1": true}, anchors)
+
+	_, templated = headingAnchors("# {{.TITLE}}\n")
+	assert.True(t, templated)
+}
@@ -16,6 +16,8 @@ const (
 	ErrorCodeAlreadyExists    ErrorCode = "ALREADY_EXISTS"
 	ErrorCodePermissionDenied ErrorCode = "PERMISSION_DENIED"
 	ErrorCodeTimeout          ErrorCode = "TIMEOUT"
+	ErrorCodeConflict         ErrorCode = "CONFLICT"
+	ErrorCodeLocked           ErrorCode = "LOCKED"
 
 	// Configuration error codes
 	ErrorCodeInvalidConfig  ErrorCode = "INVALID_CONFIG"
@@ -0,0 +1,155 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/cli"
+	"github.com/daddia/zen/pkg/dirs"
+	"github.com/daddia/zen/pkg/errors"
+)
+
+// CheckInterval is how long the result of a version check is reused before releases are
+// looked up again
+const CheckInterval = 24 * time.Hour
+
+// releaseFinder looks up the newest release on a channel
+type releaseFinder interface {
+	Latest(ctx context.Context, channel Channel) (*Release, error)
+}
+
+// checkState is the result of the last version check, saved between runs
+type checkState struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Channel   Channel   `json:"channel"`
+	Latest    string    `json:"latest"`
+	URL       string    `json:"url,omitempty"`
+}
+
+// Checker reports newer releases, looking them up at most once per CheckInterval and
+// channel and caching the result in a state file
+type Checker struct {
+	finder    releaseFinder
+	stateFile string
+	now       func() time.Time
+}
+
+// NewChecker creates a checker that looks up releases with finder and caches them in
+// stateFile
+func NewChecker(finder releaseFinder, stateFile string) *Checker {
+	return &Checker{finder: finder, stateFile: stateFile, now: time.Now}
+}
+
+// DefaultStateFile returns the file the version check is cached in, in the user state
+// directory
+func DefaultStateFile() (string, error) {
+	dir, err := dirs.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "update-check.json"), nil
+}
+
+// Check returns the newest release if it is newer than current, and nil otherwise.
+// Prereleases of current follow the beta channel, and releases the stable channel.
+// Development builds, whose version is not a release version, are never behind.
+func (c *Checker) Check(ctx context.Context, current string) (*Release, error) {
+	version, err := ParseVersion(current)
+	if err != nil {
+		return nil, nil
+	}
+	channel := ChannelStable
+	if version.IsPrerelease() {
+		channel = ChannelBeta
+	}
+
+	latest, err := c.latest(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+	if latest.Version.Compare(version) <= 0 {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+// latest returns the newest release on channel from the state file while it is fresh,
+// and from the release finder otherwise
+func (c *Checker) latest(ctx context.Context, channel Channel) (*Release, error) {
+	if state, ok := c.readState(); ok && state.Channel == channel && c.now().Sub(state.CheckedAt) < CheckInterval {
+		if version, err := ParseVersion(state.Latest); err == nil {
+			return &Release{Version: version, Tag: state.Latest, URL: state.URL}, nil
+		}
+	}
+
+	release, err := c.finder.Latest(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+	// A state file that cannot be written only means the next run looks releases up again
+	_ = c.writeState(checkState{CheckedAt: c.now(), Channel: channel, Latest: release.Tag, URL: release.URL})
+	return release, nil
+}
+
+func (c *Checker) readState() (checkState, bool) {
+	var state checkState
+	data, err := os.ReadFile(c.stateFile)
+	if err != nil {
+		return state, false
+	}
+	return state, json.Unmarshal(data, &state) == nil
+}
+
+func (c *Checker) writeState(state checkState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.stateFile), 0755); err != nil {
+		return errors.Wrap(err, "failed to save version check")
+	}
+	return errors.Wrap(os.WriteFile(c.stateFile, data, 0644), "failed to save version check")
+}
+
+// NoticesEnabled reports whether zen mentions newer or required versions under cfg.
+// The cli.update_check setting turns notices off, as does ZEN_NO_UPDATE_CHECK.
+func NoticesEnabled(cfg cli.Config) bool {
+	return cfg.UpdateCheck && !envSet("ZEN_NO_UPDATE_CHECK")
+}
+
+// ChecksEnabled reports whether zen looks up newer releases under cfg: when notices are
+// enabled and zen is not offline
+func ChecksEnabled(cfg cli.Config) bool {
+	return NoticesEnabled(cfg) && !IsOffline(cfg)
+}
+
+// IsOffline reports whether zen must not use the network for checks of its own, from
+// cli.offline or ZEN_OFFLINE
+func IsOffline(cfg cli.Config) bool {
+	return cfg.Offline || envSet("ZEN_OFFLINE")
+}
+
+// envSet reports whether a boolean environment variable is on: set, and not "false" or "0"
+func envSet(name string) bool {
+	value := strings.TrimSpace(os.Getenv(name))
+	return value != "" && value != "false" && value != "0"
+}
+
+// Requires reports whether a zen at current is older than the minimum version required,
+// for example by an asset manifest. It is false when either version is not a release
+// version, as for development builds.
+func Requires(minimum, current string) bool {
+	required, err := ParseVersion(minimum)
+	if err != nil {
+		return false
+	}
+	installed, err := ParseVersion(current)
+	if err != nil {
+		return false
+	}
+	return installed.Compare(required) < 0
+}
@@ -0,0 +1,94 @@
+package update
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingFinder returns fixed releases and counts the lookups
+type countingFinder struct {
+	releases map[Channel]string
+	lookups  int
+}
+
+func (f *countingFinder) Latest(ctx context.Context, channel Channel) (*Release, error) {
+	f.lookups++
+	version, err := ParseVersion(f.releases[channel])
+	if err != nil {
+		return nil, err
+	}
+	return &Release{Version: version, Tag: version.String()}, nil
+}
+
+func TestChecker_Check(t *testing.T) {
+	finder := &countingFinder{releases: map[Channel]string{ChannelStable: "1.4.0", ChannelBeta: "1.5.0-beta.1"}}
+	checker := NewChecker(finder, filepath.Join(t.TempDir(), "zen", "update-check.json"))
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	checker.now = func() time.Time { return now }
+
+	latest, err := checker.Check(context.Background(), "1.3.0")
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "v1.4.0", latest.Version.String())
+	assert.Equal(t, 1, finder.lookups)
+
+	latest, err = checker.Check(context.Background(), "v1.4.0")
+	require.NoError(t, err)
+	assert.Nil(t, latest, "up to date")
+	assert.Equal(t, 1, finder.lookups, "the cached result is reused")
+
+	latest, err = checker.Check(context.Background(), "1.5.0-alpha.1")
+	require.NoError(t, err)
+	require.NotNil(t, latest, "prereleases follow the beta channel")
+	assert.Equal(t, "v1.5.0-beta.1", latest.Version.String())
+	assert.Equal(t, 2, finder.lookups)
+
+	now = now.Add(CheckInterval)
+	_, err = checker.Check(context.Background(), "1.5.0-alpha.1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, finder.lookups, "a stale result is looked up again")
+
+	latest, err = checker.Check(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.Nil(t, latest, "development builds are never behind")
+	assert.Equal(t, 3, finder.lookups)
+}
+
+func TestChecksEnabled(t *testing.T) {
+	cfg := cli.DefaultConfig()
+	t.Setenv("ZEN_OFFLINE", "")
+	t.Setenv("ZEN_NO_UPDATE_CHECK", "")
+	assert.True(t, ChecksEnabled(cfg))
+	assert.True(t, NoticesEnabled(cfg))
+
+	t.Setenv("ZEN_OFFLINE", "1")
+	assert.False(t, ChecksEnabled(cfg))
+	assert.True(t, NoticesEnabled(cfg), "notices need no network")
+
+	t.Setenv("ZEN_OFFLINE", "false")
+	t.Setenv("ZEN_NO_UPDATE_CHECK", "true")
+	assert.False(t, ChecksEnabled(cfg))
+	assert.False(t, NoticesEnabled(cfg))
+
+	t.Setenv("ZEN_NO_UPDATE_CHECK", "")
+	cfg.Offline = true
+	assert.False(t, ChecksEnabled(cfg))
+	cfg.Offline = false
+	cfg.UpdateCheck = false
+	assert.False(t, NoticesEnabled(cfg))
+}
+
+func TestRequires(t *testing.T) {
+	assert.True(t, Requires("1.4.0", "1.3.9"))
+	assert.True(t, Requires("v1.4.0", "1.4.0-beta.2"))
+	assert.False(t, Requires("1.4.0", "1.4.0"))
+	assert.False(t, Requires("1.4.0", "v2.0.0"))
+	assert.False(t, Requires("1.4.0", "dev"))
+	assert.False(t, Requires("", "1.0.0"))
+}
@@ -0,0 +1,270 @@
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/errors"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// Result describes an installed release
+type Result struct {
+	Release *Release
+	// Path is the executable that was replaced
+	Path string
+	// SignatureVerified is true when the checksums were verified against the release
+	// signature, and SignatureSkipped gives the reason when they were not
+	SignatureVerified bool
+	SignatureSkipped  string
+}
+
+// InstallOptions controls how a release is installed
+type InstallOptions struct {
+	// SkipSignature installs a release whose checksums cannot be verified against the
+	// release signature, because it is not signed or gpg is not installed. The
+	// checksums then only show that the download is intact, not who made it.
+	SkipSignature bool
+}
+
+// Install downloads release, verifies it, and replaces the executable at exe with it.
+// Releases that are not signed, or whose signature cannot be checked, are refused unless
+// opts.SkipSignature is set. If the new binary cannot replace exe or does not run, exe is
+// restored.
+func (u *Updater) Install(ctx context.Context, release *Release, exe string, opts InstallOptions) (*Result, error) {
+	archive := u.ArchiveName()
+	archiveURL := release.asset(archive)
+	if archiveURL == "" {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNotFound,
+			Message: fmt.Sprintf("release %s has no build for %s/%s", release.Tag, u.goos, u.goarch),
+			Details: fmt.Sprintf("expected a release asset named %s", archive),
+		}
+	}
+	checksumsURL := release.asset(checksumsAsset)
+	if checksumsURL == "" {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeIntegrityError,
+			Message: fmt.Sprintf("release %s has no %s to verify the download against", release.Tag, checksumsAsset),
+		}
+	}
+
+	result := &Result{Release: release, Path: exe}
+
+	checksums, err := u.download(ctx, checksumsAsset, checksumsURL)
+	if err != nil {
+		return nil, err
+	}
+	if opts.SkipSignature {
+		result.SignatureSkipped = "signature verification was skipped"
+	} else {
+		sigURL := release.asset(signatureAsset)
+		if sigURL == "" {
+			return nil, unverifiedSignature(release, "the release is not signed")
+		}
+		signature, err := u.download(ctx, signatureAsset, sigURL)
+		if err != nil {
+			return nil, err
+		}
+		switch err := u.verifySignature(ctx, checksums, signature); {
+		case err == errGPGNotFound:
+			return nil, unverifiedSignature(release, err.Error())
+		case err != nil:
+			return nil, err
+		default:
+			result.SignatureVerified = true
+		}
+	}
+
+	u.logger.Debug("downloading zen release", "tag", release.Tag, "asset", archive)
+	data, err := u.download(ctx, archive, archiveURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksum(checksums, archive, data); err != nil {
+		return nil, err
+	}
+
+	binary, err := extractBinary(archive, data, u.binaryName())
+	if err != nil {
+		return nil, err
+	}
+	if err := replaceExecutable(ctx, exe, binary); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// unverifiedSignature is the error of a release whose signature cannot be verified
+func unverifiedSignature(release *Release, reason string) error {
+	return &types.Error{
+		Code:    types.ErrorCodeIntegrityError,
+		Message: fmt.Sprintf("cannot verify the signature of release %s: %s", release.Tag, reason),
+		Details: "Install gpg to verify it, or pass --skip-signature to install the release verified against its checksums only",
+	}
+}
+
+// binaryName returns the file name of the zen executable in release archives
+func (u *Updater) binaryName() string {
+	if u.goos == "windows" {
+		return "zen.exe"
+	}
+	return "zen"
+}
+
+// extractBinary returns the named executable from a .tar.gz or .zip release archive
+func extractBinary(archive string, data []byte, name string) ([]byte, error) {
+	notFound := &types.Error{
+		Code:    types.ErrorCodeIntegrityError,
+		Message: fmt.Sprintf("%s does not contain %s", archive, name),
+	}
+
+	if strings.HasSuffix(archive, ".zip") {
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", archive)
+		}
+		for _, f := range zr.File {
+			if path.Base(f.Name) != name || f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read %s", archive)
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+		return nil, notFound
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", archive)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, notFound
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", archive)
+		}
+		if header.Typeflag == tar.TypeReg && path.Base(header.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replaceExecutable atomically replaces exe with binary. The current executable is kept
+// as exe.old until the new one has been shown to run, and is moved back otherwise.
+func replaceExecutable(ctx context.Context, exe string, binary []byte) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return errors.Wrap(err, "failed to find the zen executable")
+	}
+
+	// Stage the new binary next to exe so the final rename stays on one file system
+	staged := exe + ".new"
+	old := exe + ".old"
+	os.Remove(old) // left behind by an earlier upgrade on Windows, where it was still running
+	if err := os.WriteFile(staged, binary, info.Mode().Perm()|0111); err != nil {
+		return permissionHint(err, exe, "failed to write the new zen executable")
+	}
+
+	if err := os.Rename(exe, old); err != nil {
+		os.Remove(staged)
+		return permissionHint(err, exe, "failed to replace the zen executable")
+	}
+	if err := os.Rename(staged, exe); err != nil {
+		os.Rename(old, exe)
+		os.Remove(staged)
+		return errors.Wrap(err, "failed to replace the zen executable")
+	}
+
+	if err := checkExecutable(ctx, exe); err != nil {
+		os.Remove(exe)
+		if rbErr := os.Rename(old, exe); rbErr != nil {
+			return fmt.Errorf("the new zen executable does not run (%v) and the previous one could not be restored from %s: %w", err, old, rbErr)
+		}
+		return &types.Error{
+			Code:    types.ErrorCodeIntegrityError,
+			Message: "the new zen executable does not run; the previous version was restored",
+			Details: err.Error(),
+		}
+	}
+
+	// On Windows the running executable cannot be removed; the next upgrade cleans it up
+	os.Remove(old)
+	return nil
+}
+
+// checkExecutable runs "<exe> version" to check that an installed binary works
+func checkExecutable(ctx context.Context, exe string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, exe, "version").CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// permissionHint wraps err with message, explaining how to upgrade an executable the
+// user cannot write to
+func permissionHint(err error, exe, message string) error {
+	if !errors.Is(err, fs.ErrPermission) {
+		return errors.Wrap(err, message)
+	}
+	return &types.Error{
+		Code:    types.ErrorCodePermissionDenied,
+		Message: fmt.Sprintf("no permission to replace %s", exe),
+		Details: fmt.Sprintf("Run the upgrade with permission to write to %s, or reinstall zen with the package manager it came from", filepath.Dir(exe)),
+	}
+}
+
+// Executable returns the path of the running zen executable with symlinks resolved
+func Executable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to find the zen executable")
+	}
+	resolved, err := filepath.EvalSymlinks(exe)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to find the zen executable")
+	}
+	return resolved, nil
+}
+
+// PackageManager returns the package manager that installed exe, or "" when zen was
+// installed directly. Package managers should upgrade the binaries they installed, so
+// that they keep track of the installed version.
+func PackageManager(exe string) string {
+	slashed := filepath.ToSlash(exe)
+	switch {
+	case strings.Contains(slashed, "/Cellar/") || strings.Contains(slashed, "/homebrew/"):
+		return "brew"
+	case strings.Contains(slashed, "/scoop/apps/"):
+		return "scoop"
+	case strings.HasPrefix(slashed, "/snap/"):
+		return "snap"
+	}
+	return ""
+}
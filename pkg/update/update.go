@@ -0,0 +1,239 @@
+// Package update keeps the zen binary up to date from GitHub Releases.
+//
+// Releases are looked up on a channel: stable follows full releases only, and beta
+// follows prereleases as well. Before a release is installed its archive is checked
+// against the release's checksums.txt, whose detached GPG signature is verified with the
+// zen release signing key; releases without a signature gpg can verify are refused
+// unless the signature check is skipped explicitly. The running binary is then replaced
+// atomically and restored if the new binary does not run.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/errors"
+	"github.com/daddia/zen/pkg/types"
+)
+
+const (
+	// defaultAPIURL is the GitHub API releases are looked up on
+	defaultAPIURL = "https://api.github.com"
+	// Repository is the GitHub repository zen is released from
+	Repository = "daddia/zen"
+
+	// checksumsAsset and signatureAsset name the release assets that verify the archives
+	checksumsAsset = "checksums.txt"
+	signatureAsset = "checksums.txt.sig"
+)
+
+// Channel selects which releases an upgrade follows
+type Channel string
+
+const (
+	// ChannelStable follows full releases
+	ChannelStable Channel = "stable"
+	// ChannelBeta follows prereleases as well as full releases
+	ChannelBeta Channel = "beta"
+)
+
+// ParseChannel parses a release channel, treating an empty value as ChannelStable
+func ParseChannel(value string) (Channel, error) {
+	switch Channel(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ChannelStable:
+		return ChannelStable, nil
+	case ChannelBeta:
+		return ChannelBeta, nil
+	}
+	return "", fmt.Errorf("invalid channel %q, must be one of: stable, beta", value)
+}
+
+// Release is a published zen release
+type Release struct {
+	Version    Version   `json:"-"`
+	Tag        string    `json:"tag"`
+	Prerelease bool      `json:"prerelease"`
+	URL        string    `json:"url"`
+	Published  time.Time `json:"published_at"`
+	Assets     []Asset   `json:"-"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string
+	URL  string
+}
+
+// asset returns the URL of the named asset, or "" when the release has none
+func (r *Release) asset(name string) string {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a.URL
+		}
+	}
+	return ""
+}
+
+// githubRelease is a release as returned by the GitHub API
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []struct {
+		Name        string `json:"name"`
+		DownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// Updater looks up and installs zen releases
+type Updater struct {
+	logger      logging.Logger
+	client      *http.Client
+	apiURL      string
+	repository  string
+	goos        string
+	goarch      string
+	gpg         string
+	signingKey  []byte
+	fingerprint string
+}
+
+// NewUpdater creates an updater for the official zen releases
+func NewUpdater(logger logging.Logger) *Updater {
+	return &Updater{
+		logger:      logger,
+		client:      &http.Client{Timeout: 5 * time.Minute},
+		apiURL:      defaultAPIURL,
+		repository:  Repository,
+		goos:        runtime.GOOS,
+		goarch:      runtime.GOARCH,
+		gpg:         "gpg",
+		signingKey:  signingKey,
+		fingerprint: signingKeyFingerprint,
+	}
+}
+
+// Latest returns the newest release on channel
+func (u *Updater) Latest(ctx context.Context, channel Channel) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=30", u.apiURL, u.repository)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: "failed to look up zen releases",
+			Details: err.Error(),
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: fmt.Sprintf("failed to look up zen releases: HTTP %d", resp.StatusCode),
+		}
+	}
+
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, errors.Wrap(err, "failed to parse releases")
+	}
+
+	var latest *Release
+	for _, gr := range releases {
+		if gr.Draft || (gr.Prerelease && channel != ChannelBeta) {
+			continue
+		}
+		version, err := ParseVersion(gr.TagName)
+		if err != nil {
+			u.logger.Debug("skipping release with invalid tag", "tag", gr.TagName)
+			continue
+		}
+		if latest != nil && version.Compare(latest.Version) <= 0 {
+			continue
+		}
+
+		release := &Release{
+			Version:    version,
+			Tag:        gr.TagName,
+			Prerelease: gr.Prerelease,
+			URL:        gr.HTMLURL,
+			Published:  gr.PublishedAt,
+		}
+		for _, a := range gr.Assets {
+			release.Assets = append(release.Assets, Asset{Name: a.Name, URL: a.DownloadURL})
+		}
+		latest = release
+	}
+
+	if latest == nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNotFound,
+			Message: fmt.Sprintf("no %s release of zen found", channel),
+		}
+	}
+	return latest, nil
+}
+
+// ArchiveName returns the name of the release archive for this platform, following the
+// release naming: zen_<Os>_<arch>.tar.gz, or .zip on Windows. macOS ships a universal binary.
+func (u *Updater) ArchiveName() string {
+	arch := u.goarch
+	switch {
+	case u.goos == "darwin":
+		arch = "all"
+	case arch == "amd64":
+		arch = "x86_64"
+	case arch == "386":
+		arch = "i386"
+	}
+
+	ext := "tar.gz"
+	if u.goos == "windows" {
+		ext = "zip"
+	}
+	return fmt.Sprintf("zen_%s_%s.%s", strings.ToUpper(u.goos[:1])+u.goos[1:], arch, ext)
+}
+
+// download fetches a release asset into memory
+func (u *Updater) download(ctx context.Context, name, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: fmt.Sprintf("failed to download %s", name),
+			Details: err.Error(),
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &types.Error{
+			Code:    types.ErrorCodeNetworkError,
+			Message: fmt.Sprintf("failed to download %s: HTTP %d", name, resp.StatusCode),
+		}
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download %s", name)
+	}
+	return data, nil
+}
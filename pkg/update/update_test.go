@@ -0,0 +1,349 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/daddia/zen/internal/logging"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// releaseServer serves a GitHub releases API and release assets
+type releaseServer struct {
+	*httptest.Server
+	releases []map[string]interface{}
+	assets   map[string][]byte
+}
+
+func newReleaseServer(t *testing.T) *releaseServer {
+	t.Helper()
+	rs := &releaseServer{assets: make(map[string][]byte)}
+	rs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/daddia/zen/releases" {
+			json.NewEncoder(w).Encode(rs.releases)
+			return
+		}
+		data, ok := rs.assets[strings.TrimPrefix(r.URL.Path, "/download/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(rs.Close)
+	return rs
+}
+
+// addRelease publishes a release with the given assets
+func (rs *releaseServer) addRelease(tag string, prerelease bool, assets map[string][]byte) {
+	var list []map[string]string
+	for name, data := range assets {
+		rs.assets[tag+"/"+name] = data
+		list = append(list, map[string]string{"name": name, "browser_download_url": rs.URL + "/download/" + tag + "/" + name})
+	}
+	rs.releases = append(rs.releases, map[string]interface{}{
+		"tag_name":   tag,
+		"prerelease": prerelease,
+		"assets":     list,
+	})
+}
+
+func testUpdater(server *releaseServer) *Updater {
+	u := NewUpdater(logging.NewBasic())
+	u.apiURL = server.URL
+	u.goos = "linux"
+	u.goarch = "amd64"
+	return u
+}
+
+// fakeBinary returns a shell script standing in for a zen binary that prints version
+func fakeBinary(version string) []byte {
+	return []byte(fmt.Sprintf("#!/bin/sh\necho \"zen version %s\"\n", version))
+}
+
+func tarGz(t *testing.T, name string, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func checksumsFor(files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	for name, data := range files {
+		sum := sha256.Sum256(data)
+		fmt.Fprintf(&buf, "%s  %s\n", hex.EncodeToString(sum[:]), name)
+	}
+	return buf.Bytes()
+}
+
+func installedExecutable(t *testing.T, version string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("release binaries are shell scripts in these tests")
+	}
+	exe := filepath.Join(t.TempDir(), "zen")
+	require.NoError(t, os.WriteFile(exe, fakeBinary(version), 0755))
+	return exe
+}
+
+func TestParseChannel(t *testing.T) {
+	channel, err := ParseChannel("")
+	require.NoError(t, err)
+	assert.Equal(t, ChannelStable, channel)
+
+	channel, err = ParseChannel("Beta")
+	require.NoError(t, err)
+	assert.Equal(t, ChannelBeta, channel)
+
+	_, err = ParseChannel("nightly")
+	assert.Error(t, err)
+}
+
+func TestUpdater_Latest(t *testing.T) {
+	server := newReleaseServer(t)
+	server.addRelease("v1.2.0", false, nil)
+	server.addRelease("v1.3.0-beta.1", true, nil)
+	server.addRelease("v1.1.0", false, nil)
+	server.addRelease("nightly", false, nil)
+	u := testUpdater(server)
+
+	stable, err := u.Latest(context.Background(), ChannelStable)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", stable.Tag)
+
+	beta, err := u.Latest(context.Background(), ChannelBeta)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.3.0-beta.1", beta.Tag)
+	assert.True(t, beta.Prerelease)
+}
+
+func TestUpdater_LatestNoRelease(t *testing.T) {
+	server := newReleaseServer(t)
+	server.addRelease("v2.0.0-rc.1", true, nil)
+
+	_, err := testUpdater(server).Latest(context.Background(), ChannelStable)
+
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeNotFound, zenErr.Code)
+}
+
+func TestUpdater_ArchiveName(t *testing.T) {
+	u := &Updater{}
+	for _, tt := range []struct{ goos, goarch, want string }{
+		{"linux", "amd64", "zen_Linux_x86_64.tar.gz"},
+		{"linux", "arm64", "zen_Linux_arm64.tar.gz"},
+		{"darwin", "arm64", "zen_Darwin_all.tar.gz"},
+		{"windows", "amd64", "zen_Windows_x86_64.zip"},
+	} {
+		u.goos, u.goarch = tt.goos, tt.goarch
+		assert.Equal(t, tt.want, u.ArchiveName())
+	}
+}
+
+func TestUpdater_Install(t *testing.T) {
+	exe := installedExecutable(t, "1.2.0")
+	archive := tarGz(t, "zen", fakeBinary("1.3.0"))
+
+	server := newReleaseServer(t)
+	server.addRelease("v1.3.0", false, map[string][]byte{
+		"zen_Linux_x86_64.tar.gz": archive,
+		"checksums.txt":           checksumsFor(map[string][]byte{"zen_Linux_x86_64.tar.gz": archive}),
+	})
+	u := testUpdater(server)
+	release, err := u.Latest(context.Background(), ChannelStable)
+	require.NoError(t, err)
+
+	result, err := u.Install(context.Background(), release, exe, InstallOptions{SkipSignature: true})
+	require.NoError(t, err)
+
+	assert.False(t, result.SignatureVerified)
+	assert.Equal(t, "signature verification was skipped", result.SignatureSkipped)
+	installed, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	assert.Equal(t, fakeBinary("1.3.0"), installed)
+	assert.NoFileExists(t, exe+".old")
+	assert.NoFileExists(t, exe+".new")
+}
+
+func TestUpdater_InstallUnverifiedSignature(t *testing.T) {
+	exe := installedExecutable(t, "1.2.0")
+	archive := tarGz(t, "zen", fakeBinary("1.3.0"))
+	checksums := checksumsFor(map[string][]byte{"zen_Linux_x86_64.tar.gz": archive})
+
+	server := newReleaseServer(t)
+	server.addRelease("v1.3.0", false, map[string][]byte{
+		"zen_Linux_x86_64.tar.gz": archive,
+		"checksums.txt":           checksums,
+	})
+	server.addRelease("v1.4.0", false, map[string][]byte{
+		"zen_Linux_x86_64.tar.gz": archive,
+		"checksums.txt":           checksums,
+		"checksums.txt.sig":       []byte("signature"),
+	})
+	u := testUpdater(server)
+	u.gpg = "zen-test-no-such-gpg"
+
+	unsigned := &Release{Tag: "v1.3.0"}
+	for _, name := range []string{"zen_Linux_x86_64.tar.gz", "checksums.txt"} {
+		unsigned.Assets = append(unsigned.Assets, Asset{Name: name, URL: server.URL + "/download/v1.3.0/" + name})
+	}
+	_, err := u.Install(context.Background(), unsigned, exe, InstallOptions{})
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeIntegrityError, zenErr.Code)
+	assert.Equal(t, "cannot verify the signature of release v1.3.0: the release is not signed", zenErr.Message)
+
+	signed, err := u.Latest(context.Background(), ChannelStable)
+	require.NoError(t, err)
+	require.Equal(t, "v1.4.0", signed.Tag)
+	_, err = u.Install(context.Background(), signed, exe, InstallOptions{})
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, "cannot verify the signature of release v1.4.0: gpg is not installed", zenErr.Message)
+
+	installed, _ := os.ReadFile(exe)
+	assert.Equal(t, fakeBinary("1.2.0"), installed, "releases whose signature is not verified are not installed")
+}
+
+func TestUpdater_InstallChecksumMismatch(t *testing.T) {
+	exe := installedExecutable(t, "1.2.0")
+	archive := tarGz(t, "zen", fakeBinary("1.3.0"))
+
+	server := newReleaseServer(t)
+	server.addRelease("v1.3.0", false, map[string][]byte{
+		"zen_Linux_x86_64.tar.gz": archive,
+		"checksums.txt":           checksumsFor(map[string][]byte{"zen_Linux_x86_64.tar.gz": []byte("tampered")}),
+	})
+	u := testUpdater(server)
+	release, err := u.Latest(context.Background(), ChannelStable)
+	require.NoError(t, err)
+
+	_, err = u.Install(context.Background(), release, exe, InstallOptions{SkipSignature: true})
+
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, types.ErrorCodeIntegrityError, zenErr.Code)
+	installed, _ := os.ReadFile(exe)
+	assert.Equal(t, fakeBinary("1.2.0"), installed, "the current binary is untouched")
+}
+
+func TestUpdater_InstallRollsBackBrokenBinary(t *testing.T) {
+	exe := installedExecutable(t, "1.2.0")
+	archive := tarGz(t, "zen", []byte("#!/bin/sh\nexit 1\n"))
+
+	server := newReleaseServer(t)
+	server.addRelease("v1.3.0", false, map[string][]byte{
+		"zen_Linux_x86_64.tar.gz": archive,
+		"checksums.txt":           checksumsFor(map[string][]byte{"zen_Linux_x86_64.tar.gz": archive}),
+	})
+	u := testUpdater(server)
+	release, err := u.Latest(context.Background(), ChannelStable)
+	require.NoError(t, err)
+
+	_, err = u.Install(context.Background(), release, exe, InstallOptions{SkipSignature: true})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "previous version was restored")
+	installed, _ := os.ReadFile(exe)
+	assert.Equal(t, fakeBinary("1.2.0"), installed)
+	assert.NoFileExists(t, exe+".old")
+}
+
+func TestUpdater_InstallVerifiesSignature(t *testing.T) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg is not available")
+	}
+	exe := installedExecutable(t, "1.2.0")
+	archive := tarGz(t, "zen", fakeBinary("1.3.0"))
+	checksums := checksumsFor(map[string][]byte{"zen_Linux_x86_64.tar.gz": archive})
+	key, fingerprint, sign := testSigningKey(t)
+
+	server := newReleaseServer(t)
+	server.addRelease("v1.3.0", false, map[string][]byte{
+		"zen_Linux_x86_64.tar.gz": archive,
+		"checksums.txt":           checksums,
+		"checksums.txt.sig":       sign(checksums),
+	})
+	server.addRelease("v1.4.0", false, map[string][]byte{
+		"zen_Linux_x86_64.tar.gz": archive,
+		"checksums.txt":           checksums,
+		"checksums.txt.sig":       sign([]byte("other content")),
+	})
+	u := testUpdater(server)
+	u.signingKey, u.fingerprint = key, fingerprint
+
+	release := &Release{Tag: "v1.3.0"}
+	for _, name := range []string{"zen_Linux_x86_64.tar.gz", "checksums.txt", "checksums.txt.sig"} {
+		release.Assets = append(release.Assets, Asset{Name: name, URL: server.URL + "/download/v1.3.0/" + name})
+	}
+	result, err := u.Install(context.Background(), release, exe, InstallOptions{})
+	require.NoError(t, err)
+	assert.True(t, result.SignatureVerified)
+
+	bad, err := u.Latest(context.Background(), ChannelStable)
+	require.NoError(t, err)
+	require.Equal(t, "v1.4.0", bad.Tag)
+	_, err = u.Install(context.Background(), bad, exe, InstallOptions{})
+	var zenErr *types.Error
+	require.ErrorAs(t, err, &zenErr)
+	assert.Equal(t, "invalid signature for checksums.txt", zenErr.Message)
+}
+
+// testSigningKey generates a throwaway signing key, returning the armored public key, its
+// fingerprint, and a function that makes detached signatures with it
+func testSigningKey(t *testing.T) ([]byte, string, func([]byte) []byte) {
+	t.Helper()
+	// gpg-agent sockets need a short path, so avoid the long t.TempDir
+	home, err := os.MkdirTemp("", "gpg")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		exec.Command("gpgconf", "--homedir", home, "--kill", "gpg-agent").Run()
+		os.RemoveAll(home)
+	})
+
+	gpg := func(stdin []byte, args ...string) []byte {
+		cmd := exec.Command("gpg", append([]string{"--homedir", home, "--batch", "--passphrase", "", "--pinentry-mode", "loopback"}, args...)...)
+		cmd.Stdin = bytes.NewReader(stdin)
+		out, err := cmd.Output()
+		require.NoError(t, err, "gpg %s", strings.Join(args, " "))
+		return out
+	}
+
+	gpg(nil, "--quick-gen-key", "Zen Test <test@example.com>", "ed25519", "sign", "never")
+	var fingerprint string
+	for _, line := range strings.Split(string(gpg(nil, "--list-keys", "--with-colons")), "\n") {
+		if fields := strings.Split(line, ":"); fields[0] == "fpr" {
+			fingerprint = fields[9]
+			break
+		}
+	}
+	require.NotEmpty(t, fingerprint)
+
+	key := gpg(nil, "--armor", "--export", fingerprint)
+	return key, fingerprint, func(data []byte) []byte {
+		return gpg(data, "--detach-sign", "--output", "-")
+	}
+}
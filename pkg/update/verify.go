@@ -0,0 +1,128 @@
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/daddia/zen/pkg/errors"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// signingKey is the public key zen releases are signed with, a copy of
+// keys/zen-signing-key.gpg that must be rotated together with it
+//
+//go:embed signing-key.gpg
+var signingKey []byte
+
+// signingKeyFingerprint identifies signingKey
+const signingKeyFingerprint = "0627E96AC3B78B66847CC2F8C4F5A887D4E02E41"
+
+// errGPGNotFound means signatures cannot be checked because gpg is not installed
+var errGPGNotFound = errors.New("gpg is not installed")
+
+// parseChecksums parses a checksums.txt file of "<sha256>  <file name>" lines
+func parseChecksums(data []byte) map[string]string {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+// verifyChecksum checks data against the checksum listed for name
+func verifyChecksum(checksums []byte, name string, data []byte) error {
+	expected, ok := parseChecksums(checksums)[name]
+	if !ok {
+		return &types.Error{
+			Code:    types.ErrorCodeIntegrityError,
+			Message: fmt.Sprintf("%s is not listed in %s", name, checksumsAsset),
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return &types.Error{
+			Code:    types.ErrorCodeIntegrityError,
+			Message: fmt.Sprintf("checksum mismatch for %s", name),
+			Details: fmt.Sprintf("expected sha256 %s, got %s", expected, actual),
+		}
+	}
+	return nil
+}
+
+// verifySignature checks the detached signature of checksums with the release signing
+// key, using gpg with a throwaway keyring. It returns errGPGNotFound when gpg is missing.
+func (u *Updater) verifySignature(ctx context.Context, checksums, signature []byte) error {
+	gpg, err := exec.LookPath(u.gpg)
+	if err != nil {
+		return errGPGNotFound
+	}
+
+	home, err := os.MkdirTemp("", "zen-gpg-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create keyring")
+	}
+	defer os.RemoveAll(home)
+
+	keyFile := filepath.Join(home, "signing-key.gpg")
+	sumsFile := filepath.Join(home, checksumsAsset)
+	sigFile := filepath.Join(home, signatureAsset)
+	for path, data := range map[string][]byte{keyFile: u.signingKey, sumsFile: checksums, sigFile: signature} {
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return errors.Wrap(err, "failed to prepare signature check")
+		}
+	}
+
+	run := func(args ...string) ([]byte, error) {
+		cmd := exec.CommandContext(ctx, gpg, append([]string{"--homedir", home, "--batch", "--no-tty"}, args...)...)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			return stdout.Bytes(), fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.Bytes(), nil
+	}
+
+	if _, err := run("--import", keyFile); err != nil {
+		return errors.Wrap(err, "failed to import release signing key")
+	}
+	status, err := run("--status-fd", "1", "--verify", sigFile, sumsFile)
+	if err != nil || !hasValidSignature(status, u.fingerprint) {
+		details := "the signature was not made with the zen release signing key"
+		if err != nil {
+			details = err.Error()
+		}
+		return &types.Error{
+			Code:    types.ErrorCodeIntegrityError,
+			Message: fmt.Sprintf("invalid signature for %s", checksumsAsset),
+			Details: details,
+		}
+	}
+	return nil
+}
+
+// hasValidSignature reports whether gpg status output shows a good signature by the key
+// with fingerprint
+func hasValidSignature(status []byte, fingerprint string) bool {
+	for _, line := range strings.Split(string(status), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[0] == "[GNUPG:]" && fields[1] == "VALIDSIG" && fields[2] == fingerprint {
+			return true
+		}
+	}
+	return false
+}
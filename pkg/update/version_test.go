@@ -0,0 +1,51 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("v1.5.0-beta.2+abc123")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 5, Prerelease: "beta.2"}, v)
+	assert.Equal(t, "v1.5.0-beta.2", v.String())
+	assert.True(t, v.IsPrerelease())
+
+	v, err = ParseVersion("2.1")
+	require.NoError(t, err)
+	assert.Equal(t, "v2.1.0", v.String())
+
+	for _, invalid := range []string{"", "dev", "v1.x.0", "1.2.3.4"} {
+		_, err := ParseVersion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{
+		"v1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-beta",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"v1.0.1",
+		"v1.2.0",
+		"v2.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		older, err := ParseVersion(ordered[i])
+		require.NoError(t, err)
+		newer, err := ParseVersion(ordered[i+1])
+		require.NoError(t, err)
+
+		assert.Equal(t, -1, older.Compare(newer), "%s < %s", older, newer)
+		assert.Equal(t, 1, newer.Compare(older), "%s > %s", newer, older)
+		assert.Equal(t, 0, older.Compare(older))
+	}
+}
@@ -0,0 +1,122 @@
+// Package watch reports changes to the files of a directory tree, in batches
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDelay is how long a watcher waits for changes to settle before reporting them
+const DefaultDelay = 250 * time.Millisecond
+
+// Watcher watches a directory and all directories below it, including the ones
+// created while it runs
+type Watcher struct {
+	root    string
+	delay   time.Duration
+	watcher *fsnotify.Watcher
+}
+
+// New returns a watcher of the tree at root that reports changes once none followed
+// for delay
+func New(root string, delay time.Duration) (*Watcher, error) {
+	if delay <= 0 {
+		delay = DefaultDelay
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start file watcher: %w", err)
+	}
+
+	w := &Watcher{root: root, delay: delay, watcher: watcher}
+	if err := w.add(root); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Run calls handle with the paths changed in each batch of changes, sorted, until ctx
+// is done or handle fails
+func (w *Watcher) Run(ctx context.Context, handle func(paths []string) error) error {
+	defer w.watcher.Close()
+
+	changed := map[string]bool{}
+	timer := time.NewTimer(w.delay)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// Files written to the new directory before it is watched are reported
+					// with it; it may be gone again already
+					if err := w.add(event.Name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+						return err
+					}
+				}
+			}
+			changed[event.Name] = true
+			timer.Reset(w.delay)
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// Changes were lost, so report the whole tree as changed
+				changed[w.root] = true
+				timer.Reset(w.delay)
+				continue
+			}
+			return fmt.Errorf("file watcher failed: %w", err)
+
+		case <-timer.C:
+			paths := make([]string, 0, len(changed))
+			for path := range changed {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			changed = map[string]bool{}
+
+			if err := handle(paths); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// add watches dir and the directories below it
+func (w *Watcher) add(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path != dir && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := w.watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,85 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nextBatch waits for the next batch of changes
+func nextBatch(t *testing.T, batches <-chan []string) []string {
+	t.Helper()
+	select {
+	case paths := <-batches:
+		return paths
+	case <-time.After(5 * time.Second):
+		t.Fatal("no changes reported")
+		return nil
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "PROJ-1"), 0755))
+
+	w, err := New(root, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	batches := make(chan []string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(ctx, func(paths []string) error {
+			batches <- paths
+			return nil
+		})
+	}()
+
+	// Changes in quick succession are reported together
+	manifest := filepath.Join(root, "PROJ-1", "manifest.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte("task:\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "PROJ-1", "index.md"), []byte("# PROJ-1\n"), 0644))
+	paths := nextBatch(t, batches)
+	assert.Contains(t, paths, manifest)
+	assert.Contains(t, paths, filepath.Join(root, "PROJ-1", "index.md"))
+
+	// Directories created while running are watched
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "PROJ-2"), 0755))
+	assert.Contains(t, nextBatch(t, batches), filepath.Join(root, "PROJ-2"))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "PROJ-2", "manifest.yaml"), []byte("task:\n"), 0644))
+	assert.Contains(t, nextBatch(t, batches), filepath.Join(root, "PROJ-2", "manifest.yaml"))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestWatcher_HandlerError(t *testing.T) {
+	root := t.TempDir()
+	w, err := New(root, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(context.Background(), func(paths []string) error {
+			return assert.AnError
+		})
+	}()
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "manifest.yaml"), []byte("task:\n"), 0644))
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, assert.AnError)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher did not stop")
+	}
+}
+
+func TestNew_MissingRoot(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing"), 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,167 @@
+package zenapi
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/cmd/status"
+	taskstatus "github.com/daddia/zen/pkg/cmd/task/status"
+	"github.com/daddia/zen/pkg/cmd/version"
+	"github.com/daddia/zen/pkg/task"
+)
+
+// Version returns the version of Zen, as in 'zen version'
+func (c *Client) Version(ctx context.Context) (*version.BuildInfo, error) {
+	var info version.BuildInfo
+	if err := c.RunJSON(ctx, &info, "version"); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Status returns the status of the workspace, as in 'zen status'; sections limits it
+// to sections such as "workspace" and "tasks"
+func (c *Client) Status(ctx context.Context, sections ...string) (*status.Status, error) {
+	args := []string{"status"}
+	if len(sections) > 0 {
+		args = append(args, "--sections", strings.Join(sections, ","))
+	}
+
+	var result status.Status
+	if err := c.RunJSON(ctx, &result, args...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListTasks returns the tasks of the workspace matching filter, as in 'zen task list'
+func (c *Client) ListTasks(ctx context.Context, filter task.TaskFilter) ([]*task.Task, error) {
+	args := []string{"task", "list"}
+	args = appendFlag(args, "--type", filter.Type)
+	args = appendFlag(args, "--status", filter.Status)
+	args = appendFlag(args, "--owner", filter.Owner)
+	args = appendFlag(args, "--team", filter.Team)
+	args = appendFlag(args, "--stage", filter.Stage)
+	for _, label := range filter.Labels {
+		args = appendFlag(args, "--label", label)
+	}
+
+	var tasks []*task.Task
+	if err := c.RunJSON(ctx, &tasks, args...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// TaskStatusOptions are the options of TaskStatus
+type TaskStatusOptions struct {
+	// Offline reports the saved pull request status without refreshing it
+	Offline bool
+	// NoCache fetches pull request status without cached API responses
+	NoCache bool
+}
+
+// TaskStatus returns the status of the task with id, as in 'zen task status'
+func (c *Client) TaskStatus(ctx context.Context, id string, opts TaskStatusOptions) (*taskstatus.TaskStatus, error) {
+	args := []string{"task", "status", id}
+	args = appendBool(args, "--offline", opts.Offline)
+	args = appendBool(args, "--no-cache", opts.NoCache)
+
+	var result taskstatus.TaskStatus
+	if err := c.RunJSON(ctx, &result, args...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAssets returns the assets matching filter, as in 'zen assets list'. A zero
+// limit uses the command's default.
+func (c *Client) ListAssets(ctx context.Context, filter assets.AssetFilter) (*assets.AssetList, error) {
+	args := []string{"assets", "list"}
+	args = appendFlag(args, "--type", string(filter.Type))
+	args = appendFlag(args, "--category", filter.Category)
+	if len(filter.Tags) > 0 {
+		args = appendFlag(args, "--tags", strings.Join(filter.Tags, ","))
+	}
+	if filter.Limit > 0 {
+		args = appendFlag(args, "--limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		args = appendFlag(args, "--offset", strconv.Itoa(filter.Offset))
+	}
+	args = appendFlag(args, "--page-token", filter.PageToken)
+
+	var list assets.AssetList
+	if err := c.RunJSON(ctx, &list, args...); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// AssetInfoOptions are the options of AssetInfo
+type AssetInfoOptions struct {
+	// IncludeContent returns the content of the asset as well as its metadata
+	IncludeContent bool
+}
+
+// AssetInfo returns the asset named name, as in 'zen assets info'
+func (c *Client) AssetInfo(ctx context.Context, name string, opts AssetInfoOptions) (*assets.AssetContent, error) {
+	args := []string{"assets", "info", name}
+	args = appendBool(args, "--include-content", opts.IncludeContent)
+
+	var content assets.AssetContent
+	if err := c.RunJSON(ctx, &content, args...); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// SyncAssetsOptions are the options of SyncAssets
+type SyncAssetsOptions struct {
+	// Branch is the branch to synchronize; empty uses the command's default
+	Branch string
+	// Force refreshes cached metadata
+	Force bool
+	// DryRun reports what would change without changing anything
+	DryRun bool
+	// NoWait fails at once if another operation is using the asset repository
+	NoWait bool
+	// LockTimeout bounds the wait for another operation to finish
+	LockTimeout time.Duration
+}
+
+// SyncAssets synchronizes the asset repository, as in 'zen assets sync'. When the sync
+// runs but reports an error, the result is returned with the error.
+func (c *Client) SyncAssets(ctx context.Context, opts SyncAssetsOptions) (*assets.SyncResult, error) {
+	args := []string{"assets", "sync"}
+	args = appendFlag(args, "--branch", opts.Branch)
+	args = appendBool(args, "--force", opts.Force)
+	args = appendBool(args, "--dry-run", opts.DryRun)
+	args = appendBool(args, "--no-wait", opts.NoWait)
+	if opts.LockTimeout > 0 {
+		args = appendFlag(args, "--lock-timeout", opts.LockTimeout.String())
+	}
+
+	var result *assets.SyncResult
+	err := c.RunJSON(ctx, &result, args...)
+	return result, err
+}
+
+// appendFlag appends a flag with value, unless value is empty
+func appendFlag(args []string, flag, value string) []string {
+	if value == "" {
+		return args
+	}
+	return append(args, flag+"="+value)
+}
+
+// appendBool appends a boolean flag when it is set
+func appendBool(args []string, flag string, set bool) []string {
+	if !set {
+		return args
+	}
+	return append(args, flag)
+}
@@ -0,0 +1,184 @@
+// Package zenapi runs Zen commands from Go programs, in process, with typed options
+// and structured results instead of parsing terminal output.
+//
+//	client := zenapi.New()
+//	tasks, err := client.ListTasks(ctx, task.TaskFilter{Status: "in_progress"})
+//	var zenErr *zenapi.Error
+//	if errors.As(err, &zenErr) && zenErr.Code == types.ErrorCodeWorkspaceNotInit {
+//		...
+//	}
+//
+// Commands run exactly as they do from the command line, in the working directory and
+// environment of the process, never prompting. Commands share process-wide state, so a
+// Client runs one command at a time.
+package zenapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/daddia/zen/internal/zencmd"
+	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// execute runs a command; tests replace it
+var execute = zencmd.Execute
+
+// runMu serializes commands, which share the process-wide HTTP and logging setup
+var runMu sync.Mutex
+
+// Client runs Zen commands
+type Client struct {
+	// ConfigFile is the configuration file commands read, as with --config; empty
+	// uses the workspace configuration
+	ConfigFile string
+	// Stdin is what commands read from standard input; nil reads nothing
+	Stdin io.Reader
+	// Verbose enables verbose logging, written to Result.Stderr
+	Verbose bool
+}
+
+// New creates a client with the default configuration
+func New() *Client {
+	return &Client{}
+}
+
+// Result is the outcome of a command
+type Result struct {
+	// Args are the arguments the command ran with
+	Args []string
+	// Stdout is what the command wrote to standard output
+	Stdout []byte
+	// Stderr is what the command wrote to standard error: progress, warnings, and logs
+	Stderr []byte
+	// ExitCode is the code zen would exit with
+	ExitCode int
+}
+
+// Error is a command that failed. It wraps the error the command returned, so
+// errors.As finds *types.Error and other error types of Zen.
+type Error struct {
+	// Command is the command line that failed, such as "zen task list"
+	Command string
+	// Code classifies the failure, as in the "code" of --output json errors
+	Code types.ErrorCode
+	// Message describes the failure
+	Message string
+	// Details suggests how to recover, when known
+	Details string
+	// ExitCode is the code zen would exit with
+	ExitCode int
+	// Stderr is what the command wrote to standard error
+	Stderr string
+
+	err error
+}
+
+// Error returns the error the command returned
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error the command returned
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// newError describes err, the failure of the command run with args
+func newError(args []string, err error, result *Result) *Error {
+	e := &Error{
+		Command:  commandLine(args),
+		Code:     types.ErrorCodeUnknown,
+		Message:  err.Error(),
+		ExitCode: result.ExitCode,
+		Stderr:   string(result.Stderr),
+		err:      err,
+	}
+
+	var zenErr *types.Error
+	var authErr *auth.Error
+	switch {
+	case errors.As(err, &zenErr):
+		e.Code, e.Message, e.Details = zenErr.Code, zenErr.Message, zenErr.Details
+	case errors.As(err, &authErr):
+		e.Code, e.Message = types.ErrorCodeAuthenticationFailed, authErr.Message
+	}
+	return e
+}
+
+// commandLine names a command by its subcommands, leaving out flags and everything
+// after them so that no flag values end up in errors
+func commandLine(args []string) string {
+	words := []string{"zen"}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// Run runs the command with args, such as "task", "list", "--status", "done". The
+// result is returned even when the command fails, with an *Error.
+func (c *Client) Run(ctx context.Context, args ...string) (*Result, error) {
+	runMu.Lock()
+	defer runMu.Unlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.ConfigFile != "" {
+		args = append(args, "--config", c.ConfigFile)
+	}
+	if c.Verbose {
+		args = append(args, "--verbose")
+	}
+
+	stdin, stdout, stderr := c.Stdin, &bytes.Buffer{}, &bytes.Buffer{}
+	if stdin == nil {
+		stdin = &bytes.Buffer{}
+	}
+	streams := iostreams.Test()
+	streams.In = io.NopCloser(stdin)
+	streams.Out = stdout
+	streams.ErrOut = stderr
+	streams.SetNonInteractive(true)
+	streams.SetPagerEnabled(false)
+
+	err := execute(ctx, args, streams)
+	result := &Result{
+		Args:     args,
+		Stdout:   stdout.Bytes(),
+		Stderr:   stderr.Bytes(),
+		ExitCode: int(zencmd.ExitCodeOf(err)),
+	}
+	if err != nil && result.ExitCode != 0 {
+		return result, newError(args, err, result)
+	}
+	return result, nil
+}
+
+// RunJSON runs the command with args and --output json, decoding its output into out.
+// Output is decoded even when the command fails, since some commands report failures
+// in their output as well.
+func (c *Client) RunJSON(ctx context.Context, out interface{}, args ...string) error {
+	result, err := c.Run(ctx, append(args, "--output", "json")...)
+
+	if data := bytes.TrimSpace(result.Stdout); len(data) > 0 {
+		if decodeErr := json.Unmarshal(data, out); decodeErr != nil && err == nil {
+			return fmt.Errorf("failed to decode output of %s: %w", commandLine(args), decodeErr)
+		}
+	} else if err == nil {
+		return fmt.Errorf("%s wrote no output", commandLine(args))
+	}
+	return err
+}
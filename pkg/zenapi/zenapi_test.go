@@ -0,0 +1,145 @@
+package zenapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+	"github.com/daddia/zen/pkg/task"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExecute replaces the command runner, restoring it when the test finishes
+func fakeExecute(t *testing.T, run func(args []string, streams *iostreams.IOStreams) error) *[]string {
+	t.Helper()
+	var got []string
+	original := execute
+	execute = func(ctx context.Context, args []string, streams *iostreams.IOStreams) error {
+		got = args
+		return run(args, streams)
+	}
+	t.Cleanup(func() { execute = original })
+	return &got
+}
+
+func TestVersion(t *testing.T) {
+	info, err := New().Version(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, info.Version)
+	assert.NotEmpty(t, info.GoVersion)
+}
+
+func TestRun(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fakeExecute(t, func(args []string, streams *iostreams.IOStreams) error {
+			assert.True(t, streams.IsNonInteractive(), "commands never prompt")
+			fmt.Fprint(streams.Out, "out")
+			fmt.Fprint(streams.ErrOut, "err")
+			return nil
+		})
+
+		result, err := New().Run(context.Background(), "status")
+		require.NoError(t, err)
+		assert.Equal(t, "out", string(result.Stdout))
+		assert.Equal(t, "err", string(result.Stderr))
+		assert.Equal(t, 0, result.ExitCode)
+	})
+
+	t.Run("failure is structured", func(t *testing.T) {
+		cause := &types.Error{
+			Code:    types.ErrorCodeWorkspaceNotInit,
+			Message: "workspace not initialized",
+			Details: "run 'zen init' to initialize a workspace first",
+		}
+		fakeExecute(t, func(args []string, streams *iostreams.IOStreams) error {
+			fmt.Fprint(streams.ErrOut, "warning")
+			return fmt.Errorf("failed: %w", cause)
+		})
+
+		result, err := New().Run(context.Background(), "task", "list", "--owner", "jane")
+		var zenErr *Error
+		require.True(t, errors.As(err, &zenErr))
+		assert.Equal(t, "zen task list", zenErr.Command, "flag values are left out")
+		assert.Equal(t, types.ErrorCodeWorkspaceNotInit, zenErr.Code)
+		assert.Equal(t, "workspace not initialized", zenErr.Message)
+		assert.Equal(t, cause.Details, zenErr.Details)
+		assert.Equal(t, int(cmdutil.ExitError), zenErr.ExitCode)
+		assert.Equal(t, "warning", zenErr.Stderr)
+		assert.ErrorIs(t, err, cause)
+		require.NotNil(t, result)
+		assert.Equal(t, zenErr.ExitCode, result.ExitCode)
+	})
+
+	t.Run("no results is not a failure", func(t *testing.T) {
+		fakeExecute(t, func(args []string, streams *iostreams.IOStreams) error {
+			return cmdutil.NoResultsError{}
+		})
+
+		_, err := New().Run(context.Background(), "task", "list")
+		assert.NoError(t, err)
+	})
+
+	t.Run("client options", func(t *testing.T) {
+		args := fakeExecute(t, func(args []string, streams *iostreams.IOStreams) error { return nil })
+
+		_, err := (&Client{ConfigFile: "zen.yaml", Verbose: true}).Run(context.Background(), "status")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"status", "--config", "zen.yaml", "--verbose"}, *args)
+	})
+}
+
+func TestListTasks(t *testing.T) {
+	args := fakeExecute(t, func(args []string, streams *iostreams.IOStreams) error {
+		fmt.Fprint(streams.Out, `[{"id":"PROJ-1","title":"Add login","status":"in_progress"}]`)
+		return nil
+	})
+
+	tasks, err := New().ListTasks(context.Background(), task.TaskFilter{Status: "in_progress", Labels: []string{"api", "auth"}})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "PROJ-1", tasks[0].ID)
+	assert.Equal(t, []string{"task", "list", "--status=in_progress", "--label=api", "--label=auth", "--output", "json"}, *args)
+}
+
+func TestListAssets(t *testing.T) {
+	args := fakeExecute(t, func(args []string, streams *iostreams.IOStreams) error {
+		fmt.Fprint(streams.Out, `{"assets":[{"name":"prd","type":"template"}],"total":1,"has_more":false}`)
+		return nil
+	})
+
+	list, err := New().ListAssets(context.Background(), assets.AssetFilter{Type: assets.AssetTypeTemplate, Tags: []string{"a", "b"}, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, list.Assets, 1)
+	assert.Equal(t, "prd", list.Assets[0].Name)
+	assert.Equal(t, []string{"assets", "list", "--type=template", "--tags=a,b", "--limit=5", "--output", "json"}, *args)
+}
+
+func TestSyncAssets_ReportsResultOfFailedSync(t *testing.T) {
+	args := fakeExecute(t, func(args []string, streams *iostreams.IOStreams) error {
+		fmt.Fprint(streams.Out, `{"status":"error","error":"manifest is invalid"}`)
+		return &cmdutil.ExitCodeError{Code: cmdutil.ExitError, Err: errors.New("sync failed")}
+	})
+
+	result, err := New().SyncAssets(context.Background(), SyncAssetsOptions{Force: true, LockTimeout: 30 * time.Second})
+	require.Error(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, "manifest is invalid", result.Error)
+	assert.Equal(t, []string{"assets", "sync", "--force", "--lock-timeout=30s", "--output", "json"}, *args)
+}
+
+func TestRunJSON_InvalidOutput(t *testing.T) {
+	fakeExecute(t, func(args []string, streams *iostreams.IOStreams) error {
+		fmt.Fprint(streams.Out, "not json")
+		return nil
+	})
+
+	_, err := New().Version(context.Background())
+	assert.ErrorContains(t, err, "failed to decode output of zen version")
+}
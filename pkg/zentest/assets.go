@@ -0,0 +1,194 @@
+package zentest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/pkg/assets"
+)
+
+// AssetClient is a fake assets.AssetClientInterface that serves the assets added to it
+type AssetClient struct {
+	// SyncResult, when set, is returned by SyncRepository instead of a successful sync
+	// of every asset
+	SyncResult *assets.SyncResult
+	// Err, when set, is returned by every operation
+	Err error
+
+	mu       sync.Mutex
+	assets   map[string]assets.AssetContent
+	syncs    []assets.SyncRequest
+	lastSync time.Time
+	closed   bool
+}
+
+// NewAssetClient creates an asset client serving contents
+func NewAssetClient(contents ...assets.AssetContent) *AssetClient {
+	c := &AssetClient{assets: make(map[string]assets.AssetContent)}
+	for _, content := range contents {
+		c.assets[content.Metadata.Name] = content
+	}
+	return c
+}
+
+// Add adds an asset, with its checksum computed from content
+func (c *AssetClient) Add(metadata assets.AssetMetadata, content string) {
+	sum := sha256.Sum256([]byte(content))
+	checksum := "sha256:" + hex.EncodeToString(sum[:])
+	if metadata.Checksum == "" {
+		metadata.Checksum = checksum
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.assets[metadata.Name] = assets.AssetContent{
+		Metadata: metadata,
+		Content:  content,
+		Checksum: checksum,
+	}
+}
+
+// Syncs returns the sync requests the client received, in order
+func (c *AssetClient) Syncs() []assets.SyncRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]assets.SyncRequest(nil), c.syncs...)
+}
+
+// Closed reports whether the client was closed
+func (c *AssetClient) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// ListAssets returns the assets matching filter, sorted by name
+func (c *AssetClient) ListAssets(ctx context.Context, filter assets.AssetFilter) (*assets.AssetList, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	var matched []assets.AssetMetadata
+	for _, content := range c.assets {
+		m := content.Metadata
+		if filter.Type != "" && m.Type != filter.Type {
+			continue
+		}
+		if filter.Category != "" && m.Category != filter.Category {
+			continue
+		}
+		if !containsAll(m.Tags, filter.Tags) {
+			continue
+		}
+		matched = append(matched, m)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+
+	total := len(matched)
+	start := min(max(filter.Offset, 0), total)
+	end := total
+	if filter.Limit > 0 {
+		end = min(start+filter.Limit, total)
+	}
+
+	return &assets.AssetList{
+		Assets:  append([]assets.AssetMetadata{}, matched[start:end]...),
+		Total:   total,
+		HasMore: end < total,
+	}, nil
+}
+
+// GetAsset returns the asset named name, or an asset_not_found error
+func (c *AssetClient) GetAsset(ctx context.Context, name string, opts assets.GetAssetOptions) (*assets.AssetContent, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	content, ok := c.assets[name]
+	if !ok {
+		return nil, assets.AssetClientError{
+			Code:    assets.ErrorCodeAssetNotFound,
+			Message: fmt.Sprintf("asset '%s' not found", name),
+		}
+	}
+	content.Cached = opts.UseCache
+	return &content, nil
+}
+
+// SyncRepository records req and returns SyncResult, or a successful sync
+func (c *AssetClient) SyncRepository(ctx context.Context, req assets.SyncRequest) (*assets.SyncResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.syncs = append(c.syncs, req)
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	if c.SyncResult != nil {
+		result := *c.SyncResult
+		return &result, nil
+	}
+
+	if !req.DryRun {
+		c.lastSync = time.Now()
+	}
+	return &assets.SyncResult{
+		Status:   "success",
+		LastSync: c.lastSync,
+	}, nil
+}
+
+// GetCacheInfo reports every asset as cached
+func (c *AssetClient) GetCacheInfo(ctx context.Context) (*assets.CacheInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	var size int64
+	for _, content := range c.assets {
+		size += int64(len(content.Content))
+	}
+	return &assets.CacheInfo{
+		TotalSize:  size,
+		AssetCount: len(c.assets),
+		LastSync:   c.lastSync,
+	}, nil
+}
+
+// ClearCache does nothing; the assets stay available
+func (c *AssetClient) ClearCache(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Err
+}
+
+// Close marks the client closed
+func (c *AssetClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+// containsAll reports whether values holds every one of want
+func containsAll(values, want []string) bool {
+	for _, w := range want {
+		if !slices.Contains(values, w) {
+			return false
+		}
+	}
+	return true
+}
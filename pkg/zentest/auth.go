@@ -0,0 +1,96 @@
+package zentest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/daddia/zen/pkg/auth"
+)
+
+// AuthManager is a fake auth.Manager holding the credentials set on it
+type AuthManager struct {
+	mu          sync.Mutex
+	credentials map[string]string
+}
+
+// NewAuthManager creates an auth manager with no credentials
+func NewAuthManager() *AuthManager {
+	return &AuthManager{credentials: make(map[string]string)}
+}
+
+// SetCredentials stores a credential for provider
+func (a *AuthManager) SetCredentials(provider, credential string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.credentials[provider] = credential
+}
+
+// Authenticate fails unless a credential is stored for provider
+func (a *AuthManager) Authenticate(ctx context.Context, provider string) error {
+	_, err := a.GetCredentials(provider)
+	return err
+}
+
+// GetCredentials returns the credential stored for provider, or a
+// credential_not_found error
+func (a *AuthManager) GetCredentials(provider string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	credential, ok := a.credentials[provider]
+	if !ok {
+		return "", auth.NewAuthError(auth.ErrorCodeCredentialNotFound,
+			fmt.Sprintf("no credentials found for %s", provider), provider)
+	}
+	return credential, nil
+}
+
+// ValidateCredentials fails unless a credential is stored for provider
+func (a *AuthManager) ValidateCredentials(ctx context.Context, provider string) error {
+	_, err := a.GetCredentials(provider)
+	return err
+}
+
+// RefreshCredentials fails unless a credential is stored for provider
+func (a *AuthManager) RefreshCredentials(ctx context.Context, provider string) error {
+	_, err := a.GetCredentials(provider)
+	return err
+}
+
+// IsAuthenticated reports whether a credential is stored for provider
+func (a *AuthManager) IsAuthenticated(ctx context.Context, provider string) bool {
+	_, err := a.GetCredentials(provider)
+	return err == nil
+}
+
+// ListProviders returns the providers with stored credentials, sorted
+func (a *AuthManager) ListProviders() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	providers := make([]string, 0, len(a.credentials))
+	for provider := range a.credentials {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// DeleteCredentials removes the credential stored for provider
+func (a *AuthManager) DeleteCredentials(provider string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.credentials, provider)
+	return nil
+}
+
+// GetProviderInfo describes provider as a token provider
+func (a *AuthManager) GetProviderInfo(provider string) (*auth.ProviderInfo, error) {
+	return &auth.ProviderInfo{
+		Name:        provider,
+		Type:        "token",
+		Description: fmt.Sprintf("%s (test)", provider),
+	}, nil
+}
@@ -0,0 +1,184 @@
+// Package zentest helps extension and plugin authors test code that runs inside Zen.
+// It builds command factories backed by fakes: a workspace that keeps its state in
+// memory, an asset client holding the assets a test adds, an auth manager holding the
+// credentials a test sets, and integration providers storing tasks in memory.
+//
+//	f := zentest.NewFactory(t).WithAssets(assets.AssetMetadata{Name: "prd", Type: assets.AssetTypeTemplate}).Build()
+//	cmd := mycommand.NewCmd(f.Factory)
+//	...
+//	assert.Contains(t, f.Stdout(), "prd")
+//
+// The package is a supported API: fakes follow the contracts of the interfaces they
+// implement, including the errors they return, so tests against them hold against Zen.
+package zentest
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/daddia/zen/internal/config"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/iostreams"
+)
+
+// Factory is a command factory backed by fakes, with the output commands wrote
+type Factory struct {
+	*cmdutil.Factory
+
+	Workspace   *Workspace
+	Assets      *AssetClient
+	Auth        *AuthManager
+	Integration *IntegrationManager
+
+	stdin  *bytes.Buffer
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+}
+
+// Stdout returns what commands wrote to standard output
+func (f *Factory) Stdout() string {
+	return f.stdout.String()
+}
+
+// Stderr returns what commands wrote to standard error
+func (f *Factory) Stderr() string {
+	return f.stderr.String()
+}
+
+// SetStdin sets what commands read from standard input
+func (f *Factory) SetStdin(input string) {
+	f.stdin.Reset()
+	f.stdin.WriteString(input)
+}
+
+// FactoryBuilder configures a Factory; every With method returns the builder
+type FactoryBuilder struct {
+	t           testing.TB
+	config      *config.Config
+	workspace   *Workspace
+	assets      *AssetClient
+	auth        *AuthManager
+	integration *IntegrationManager
+	colors      bool
+	outputFmt   string
+}
+
+// NewFactory starts a factory with the default configuration, an initialized
+// workspace, no assets, no credentials, and no integration
+func NewFactory(t testing.TB) *FactoryBuilder {
+	t.Helper()
+	workspace := NewWorkspace(t)
+	workspace.SetInitialized(true)
+	return &FactoryBuilder{
+		t:           t,
+		config:      config.LoadDefaults(),
+		workspace:   workspace,
+		assets:      NewAssetClient(),
+		auth:        NewAuthManager(),
+		integration: &IntegrationManager{},
+	}
+}
+
+// WithConfig replaces the configuration
+func (b *FactoryBuilder) WithConfig(cfg *config.Config) *FactoryBuilder {
+	b.config = cfg
+	return b
+}
+
+// WithWorkspace replaces the workspace
+func (b *FactoryBuilder) WithWorkspace(workspace *Workspace) *FactoryBuilder {
+	b.workspace = workspace
+	return b
+}
+
+// WithoutWorkspace uses a workspace that has not been initialized, as when Zen runs
+// outside a project
+func (b *FactoryBuilder) WithoutWorkspace() *FactoryBuilder {
+	b.workspace = NewWorkspace(b.t)
+	return b
+}
+
+// WithAssets adds assets to the asset client, with empty content
+func (b *FactoryBuilder) WithAssets(metadata ...assets.AssetMetadata) *FactoryBuilder {
+	for _, m := range metadata {
+		b.assets.Add(m, "")
+	}
+	return b
+}
+
+// WithAssetClient replaces the asset client
+func (b *FactoryBuilder) WithAssetClient(client *AssetClient) *FactoryBuilder {
+	b.assets = client
+	return b
+}
+
+// WithCredentials stores a credential for provider in the auth manager
+func (b *FactoryBuilder) WithCredentials(provider, credential string) *FactoryBuilder {
+	b.auth.SetCredentials(provider, credential)
+	return b
+}
+
+// WithProvider configures provider as the task system of record, with sync enabled
+func (b *FactoryBuilder) WithProvider(provider *Provider) *FactoryBuilder {
+	b.integration.Register(provider)
+	b.integration.TaskSystem = provider.Name()
+	b.integration.SyncEnabled = true
+	return b
+}
+
+// WithColors enables colored output
+func (b *FactoryBuilder) WithColors() *FactoryBuilder {
+	b.colors = true
+	return b
+}
+
+// WithOutputFormat sets the global --output flag
+func (b *FactoryBuilder) WithOutputFormat(format string) *FactoryBuilder {
+	b.outputFmt = format
+	return b
+}
+
+// Build returns the factory
+func (b *FactoryBuilder) Build() *Factory {
+	stdin, stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+	streams := iostreams.Test()
+	streams.In = io.NopCloser(stdin)
+	streams.Out = stdout
+	streams.ErrOut = stderr
+	streams.SetColorEnabled(b.colors)
+	streams.SetNeverPrompt(true)
+
+	f := cmdutil.NewTestFactory(streams)
+	f.OutputFormat = b.outputFmt
+
+	cfg, workspace, client, authManager, integration := b.config, b.workspace, b.assets, b.auth, b.integration
+	f.Config = func() (*config.Config, error) {
+		return cfg, nil
+	}
+	f.WorkspaceManager = func() (cmdutil.WorkspaceManager, error) {
+		return workspace, nil
+	}
+	f.AssetClient = func() (assets.AssetClientInterface, error) {
+		return client, nil
+	}
+	f.AuthManager = func() (auth.Manager, error) {
+		return authManager, nil
+	}
+	f.IntegrationManager = func() (cmdutil.IntegrationManagerInterface, error) {
+		return integration, nil
+	}
+
+	return &Factory{
+		Factory:     f,
+		Workspace:   workspace,
+		Assets:      client,
+		Auth:        authManager,
+		Integration: integration,
+		stdin:       stdin,
+		stdout:      stdout,
+		stderr:      stderr,
+	}
+}
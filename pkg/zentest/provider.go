@@ -0,0 +1,364 @@
+package zentest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daddia/zen/internal/integration"
+	"github.com/daddia/zen/pkg/clients"
+)
+
+// Provider is a fake integration.IntegrationProvider that stores tasks in memory. Tasks
+// get keys like "FAKE-1" from the upper-cased provider name, statuses and priorities are
+// mapped unchanged, and errors are *clients.ClientError values like real providers
+// return.
+type Provider struct {
+	name string
+
+	mu     sync.Mutex
+	tasks  map[string]*integration.ExternalTaskData
+	nextID int
+	errs   []error
+	calls  []string
+}
+
+// NewProvider creates a provider named name holding tasks; tasks without an ID get one
+func NewProvider(name string, tasks ...integration.ExternalTaskData) *Provider {
+	p := &Provider{name: name, tasks: make(map[string]*integration.ExternalTaskData)}
+	for _, task := range tasks {
+		p.Add(task)
+	}
+	return p
+}
+
+// Add stores task, assigning an ID and timestamps when it has none, and returns it
+func (p *Provider) Add(task integration.ExternalTaskData) integration.ExternalTaskData {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return *p.addLocked(task)
+}
+
+func (p *Provider) addLocked(task integration.ExternalTaskData) *integration.ExternalTaskData {
+	p.nextID++
+	if task.ID == "" {
+		task.ID = fmt.Sprintf("%s-%d", strings.ToUpper(p.name), p.nextID)
+	}
+	if task.Created.IsZero() {
+		task.Created = time.Now()
+	}
+	if task.Updated.IsZero() {
+		task.Updated = task.Created
+	}
+	stored := task
+	p.tasks[task.ID] = &stored
+	return &stored
+}
+
+// Task returns the task with id
+func (p *Provider) Task(id string) (integration.ExternalTaskData, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	task, ok := p.tasks[id]
+	if !ok {
+		return integration.ExternalTaskData{}, false
+	}
+	return *task, true
+}
+
+// FailNext makes the next calls fail with errs, one call per error
+func (p *Provider) FailNext(errs ...error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.errs = append(p.errs, errs...)
+}
+
+// Calls returns the names of the methods called that reach the external system, such
+// as "GetTaskData", in order
+func (p *Provider) Calls() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.calls...)
+}
+
+// callLocked records a call, returning the error injected for it, if any
+func (p *Provider) callLocked(method string) error {
+	p.calls = append(p.calls, method)
+	if len(p.errs) == 0 {
+		return nil
+	}
+	err := p.errs[0]
+	p.errs = p.errs[1:]
+	return err
+}
+
+func (p *Provider) notFound(id string) error {
+	return &clients.ClientError{
+		Code:       clients.ErrorCodeNotFound,
+		Message:    fmt.Sprintf("%s task %s not found", p.name, id),
+		StatusCode: 404,
+	}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// GetTaskData returns a copy of the task with externalID
+func (p *Provider) GetTaskData(ctx context.Context, externalID string) (*integration.ExternalTaskData, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.callLocked("GetTaskData"); err != nil {
+		return nil, err
+	}
+	task, ok := p.tasks[externalID]
+	if !ok {
+		return nil, p.notFound(externalID)
+	}
+	copied := *task
+	return &copied, nil
+}
+
+// CreateTask stores a new task from taskData
+func (p *Provider) CreateTask(ctx context.Context, taskData *integration.ZenTaskData) (*integration.ExternalTaskData, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.callLocked("CreateTask"); err != nil {
+		return nil, err
+	}
+	if taskData == nil || taskData.Title == "" {
+		return nil, &clients.ClientError{
+			Code:       clients.ErrorCodeInvalidRequest,
+			Message:    "a task needs a title",
+			StatusCode: 400,
+		}
+	}
+	created := p.addLocked(integration.ExternalTaskData{
+		Title:       taskData.Title,
+		Description: taskData.Description,
+		Status:      taskData.Status,
+		Priority:    taskData.Priority,
+		Assignee:    taskData.Owner,
+	})
+	copied := *created
+	return &copied, nil
+}
+
+// UpdateTask sets the fields of the task with externalID that taskData fills in
+func (p *Provider) UpdateTask(ctx context.Context, externalID string, taskData *integration.ZenTaskData) (*integration.ExternalTaskData, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.callLocked("UpdateTask"); err != nil {
+		return nil, err
+	}
+	task, ok := p.tasks[externalID]
+	if !ok {
+		return nil, p.notFound(externalID)
+	}
+	if taskData != nil {
+		for field, value := range map[*string]string{
+			&task.Title:       taskData.Title,
+			&task.Description: taskData.Description,
+			&task.Status:      taskData.Status,
+			&task.Priority:    taskData.Priority,
+			&task.Assignee:    taskData.Owner,
+		} {
+			if value != "" {
+				*field = value
+			}
+		}
+	}
+	task.Updated = time.Now()
+	copied := *task
+	return &copied, nil
+}
+
+// SearchTasks returns the tasks whose status, priority, and assignee equal those in
+// query, sorted by ID
+func (p *Provider) SearchTasks(ctx context.Context, query map[string]interface{}) ([]*integration.ExternalTaskData, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.callLocked("SearchTasks"); err != nil {
+		return nil, err
+	}
+	return p.matchLocked(func(task *integration.ExternalTaskData) bool {
+		for key, field := range map[string]string{"status": task.Status, "priority": task.Priority, "assignee": task.Assignee} {
+			if want, ok := query[key].(string); ok && want != "" && want != field {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+// SearchTasksUpdatedSince returns the tasks updated at or after since, sorted by ID
+func (p *Provider) SearchTasksUpdatedSince(ctx context.Context, since time.Time) ([]*integration.ExternalTaskData, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.callLocked("SearchTasksUpdatedSince"); err != nil {
+		return nil, err
+	}
+	return p.matchLocked(func(task *integration.ExternalTaskData) bool {
+		return !task.Updated.Before(since)
+	}), nil
+}
+
+func (p *Provider) matchLocked(match func(*integration.ExternalTaskData) bool) []*integration.ExternalTaskData {
+	var tasks []*integration.ExternalTaskData
+	for _, task := range p.tasks {
+		if match(task) {
+			copied := *task
+			tasks = append(tasks, &copied)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks
+}
+
+// ValidateConnection succeeds unless an error is injected
+func (p *Provider) ValidateConnection(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.callLocked("ValidateConnection")
+}
+
+// GetFieldMapping maps every field to itself
+func (p *Provider) GetFieldMapping() map[string]string {
+	return map[string]string{
+		"task_id":     "id",
+		"title":       "title",
+		"description": "description",
+		"status":      "status",
+		"priority":    "priority",
+		"assignee":    "assignee",
+	}
+}
+
+// MapToZen converts external task data to Zen format, keeping values unchanged
+func (p *Provider) MapToZen(external *integration.ExternalTaskData) (*integration.ZenTaskData, error) {
+	if external == nil {
+		return nil, fmt.Errorf("external task data cannot be nil")
+	}
+	return &integration.ZenTaskData{
+		ID:          external.ID,
+		Title:       external.Title,
+		Description: external.Description,
+		Status:      external.Status,
+		Priority:    external.Priority,
+		Owner:       external.Assignee,
+		Created:     external.Created,
+		Updated:     external.Updated,
+		Metadata: map[string]interface{}{
+			"external_system": p.name,
+			"external_id":     external.ID,
+		},
+	}, nil
+}
+
+// MapToExternal converts Zen task data to the provider's format, keeping values
+// unchanged
+func (p *Provider) MapToExternal(zen *integration.ZenTaskData) (*integration.ExternalTaskData, error) {
+	if zen == nil {
+		return nil, fmt.Errorf("zen task data cannot be nil")
+	}
+	return &integration.ExternalTaskData{
+		ID:          zen.ID,
+		Title:       zen.Title,
+		Description: zen.Description,
+		Status:      zen.Status,
+		Priority:    zen.Priority,
+		Assignee:    zen.Owner,
+		Created:     zen.Created,
+		Updated:     zen.Updated,
+		Fields:      map[string]interface{}{},
+	}, nil
+}
+
+// HealthCheck reports the provider healthy unless an error is injected
+func (p *Provider) HealthCheck(ctx context.Context) (*integration.ProviderHealth, error) {
+	health := &integration.ProviderHealth{
+		Provider:    p.name,
+		Healthy:     true,
+		LastChecked: time.Now(),
+	}
+	if err := p.ValidateConnection(ctx); err != nil {
+		health.Healthy = false
+		health.ErrorCount = 1
+		health.LastError = err.Error()
+	}
+	return health, nil
+}
+
+// GetRateLimitInfo reports an unused rate limit
+func (p *Provider) GetRateLimitInfo(ctx context.Context) (*integration.RateLimitInfo, error) {
+	return &integration.RateLimitInfo{
+		Limit:     1000,
+		Remaining: 1000,
+		ResetTime: time.Now().Add(time.Hour),
+	}, nil
+}
+
+// SupportsRealtime returns false
+func (p *Provider) SupportsRealtime() bool {
+	return false
+}
+
+// GetWebhookURL returns no URL
+func (p *Provider) GetWebhookURL() string {
+	return ""
+}
+
+// IntegrationManager is a fake cmdutil.IntegrationManagerInterface
+type IntegrationManager struct {
+	// TaskSystem is the task system of record; empty means integration is not
+	// configured
+	TaskSystem string
+	// SyncEnabled reports whether sync is enabled
+	SyncEnabled bool
+
+	mu        sync.Mutex
+	providers map[string]*Provider
+}
+
+// Register adds provider to the manager
+func (m *IntegrationManager) Register(provider *Provider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.providers == nil {
+		m.providers = make(map[string]*Provider)
+	}
+	m.providers[provider.Name()] = provider
+}
+
+// Provider returns the registered provider named name
+func (m *IntegrationManager) Provider(name string) (*Provider, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	provider, ok := m.providers[name]
+	return provider, ok
+}
+
+// IsConfigured reports whether a task system is set
+func (m *IntegrationManager) IsConfigured() bool {
+	return m.TaskSystem != ""
+}
+
+// GetTaskSystem returns the task system of record
+func (m *IntegrationManager) GetTaskSystem() string {
+	return m.TaskSystem
+}
+
+// IsSyncEnabled reports whether sync is enabled
+func (m *IntegrationManager) IsSyncEnabled() bool {
+	return m.IsConfigured() && m.SyncEnabled
+}
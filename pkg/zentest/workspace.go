@@ -0,0 +1,169 @@
+package zentest
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/daddia/zen/pkg/cmdutil"
+	"github.com/daddia/zen/pkg/types"
+)
+
+// WorkTypeDirectories are the directories a fake workspace reports for each task
+var WorkTypeDirectories = []string{"research", "spikes", "design", "execution", "outcomes"}
+
+// Workspace is a fake cmdutil.WorkspaceManager that keeps its state in memory. Nothing
+// is written to disk; the root is an empty temporary directory, so commands that do
+// write files stay isolated from each other.
+type Workspace struct {
+	// Project is the project the workspace reports in its status
+	Project cmdutil.ProjectInfo
+	// Err, when set, is returned by every operation that changes the workspace
+	Err error
+
+	root string
+
+	mu          sync.Mutex
+	initialized bool
+	directories map[string]bool
+}
+
+// NewWorkspace creates a workspace that has not been initialized, rooted in a
+// temporary directory removed when the test finishes
+func NewWorkspace(t testing.TB) *Workspace {
+	return &Workspace{
+		Project: cmdutil.ProjectInfo{
+			Type:     "test",
+			Name:     "test-project",
+			Language: "go",
+		},
+		root:        t.TempDir(),
+		directories: make(map[string]bool),
+	}
+}
+
+// WorkspaceAt creates an initialized workspace rooted in root, which is not created,
+// for tests of commands that only read the workspace status or that are given a
+// directory of their own
+func WorkspaceAt(root string) *Workspace {
+	return &Workspace{
+		Project: cmdutil.ProjectInfo{
+			Type:     "test",
+			Name:     "test-project",
+			Language: "go",
+		},
+		root:        root,
+		initialized: true,
+		directories: make(map[string]bool),
+	}
+}
+
+// SetInitialized sets whether the workspace has been initialized
+func (w *Workspace) SetInitialized(initialized bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.initialized = initialized
+}
+
+// Initialized reports whether the workspace has been initialized
+func (w *Workspace) Initialized() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.initialized
+}
+
+// Directories returns the task and work type directories created, relative to the
+// root, in order
+func (w *Workspace) Directories() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	dirs := make([]string, 0, len(w.directories))
+	for dir := range w.directories {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// Root returns the workspace root
+func (w *Workspace) Root() string {
+	return w.root
+}
+
+// ConfigFile returns the path of the workspace configuration file
+func (w *Workspace) ConfigFile() string {
+	return filepath.Join(w.ZenDirectory(), "config")
+}
+
+// ZenDirectory returns the path of the .zen directory
+func (w *Workspace) ZenDirectory() string {
+	return filepath.Join(w.root, ".zen")
+}
+
+// Initialize initializes the workspace, failing when it already is
+func (w *Workspace) Initialize() error {
+	return w.InitializeWithForce(false)
+}
+
+// InitializeWithForce initializes the workspace, failing when it already is unless
+// force is set
+func (w *Workspace) InitializeWithForce(force bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.Err != nil {
+		return w.Err
+	}
+	if w.initialized && !force {
+		return &types.Error{
+			Code:    types.ErrorCodeAlreadyExists,
+			Message: "workspace already initialized",
+		}
+	}
+	w.initialized = true
+	return nil
+}
+
+// Status returns the state of the workspace
+func (w *Workspace) Status() (cmdutil.WorkspaceStatus, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return cmdutil.WorkspaceStatus{
+		Initialized: w.initialized,
+		ConfigPath:  w.ConfigFile(),
+		Root:        w.root,
+		Project:     w.Project,
+	}, nil
+}
+
+// CreateTaskDirectory records the creation of a task directory
+func (w *Workspace) CreateTaskDirectory(taskDir string) error {
+	return w.create(taskDir)
+}
+
+// CreateWorkTypeDirectory records the creation of a work type directory of a task
+func (w *Workspace) CreateWorkTypeDirectory(taskDir, workType string) error {
+	return w.create(filepath.Join(taskDir, workType))
+}
+
+// GetWorkTypeDirectories returns WorkTypeDirectories
+func (w *Workspace) GetWorkTypeDirectories() []string {
+	return append([]string(nil), WorkTypeDirectories...)
+}
+
+func (w *Workspace) create(dir string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.Err != nil {
+		return w.Err
+	}
+	if rel, err := filepath.Rel(w.root, dir); err == nil && filepath.IsAbs(dir) {
+		dir = rel
+	}
+	w.directories[filepath.ToSlash(dir)] = true
+	return nil
+}
@@ -0,0 +1,188 @@
+package zentest_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daddia/zen/internal/integration"
+	"github.com/daddia/zen/pkg/assets"
+	"github.com/daddia/zen/pkg/auth"
+	"github.com/daddia/zen/pkg/clients"
+	"github.com/daddia/zen/pkg/cmd/assets/list"
+	"github.com/daddia/zen/pkg/types"
+	"github.com/daddia/zen/pkg/zentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactory_RunsCommands(t *testing.T) {
+	f := zentest.NewFactory(t).
+		WithAssets(
+			assets.AssetMetadata{Name: "prd", Type: assets.AssetTypeTemplate, Category: "planning"},
+			assets.AssetMetadata{Name: "adr", Type: assets.AssetTypeTemplate, Category: "design"},
+		).
+		Build()
+
+	cmd := list.NewCmdAssetsList(f.Factory)
+	cmd.SetArgs([]string{"--category", "planning"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, f.Stdout(), "prd")
+	assert.NotContains(t, f.Stdout(), "adr")
+	assert.True(t, f.Assets.Closed(), "commands close the asset client")
+}
+
+func TestFactory_Defaults(t *testing.T) {
+	f := zentest.NewFactory(t).Build()
+
+	cfg, err := f.Config()
+	require.NoError(t, err)
+	assert.NotNil(t, cfg)
+
+	wm, err := f.WorkspaceManager()
+	require.NoError(t, err)
+	status, err := wm.Status()
+	require.NoError(t, err)
+	assert.True(t, status.Initialized)
+	assert.DirExists(t, wm.Root())
+
+	im, err := f.IntegrationManager()
+	require.NoError(t, err)
+	assert.False(t, im.IsConfigured())
+
+	f.SetStdin("yes\n")
+	buf := make([]byte, 4)
+	n, _ := f.IOStreams.In.Read(buf)
+	assert.Equal(t, "yes\n", string(buf[:n]))
+}
+
+func TestWorkspace(t *testing.T) {
+	ws := zentest.NewWorkspace(t)
+	require.False(t, ws.Initialized())
+
+	require.NoError(t, ws.Initialize())
+	err := ws.Initialize()
+	var zenErr *types.Error
+	require.True(t, errors.As(err, &zenErr))
+	assert.Equal(t, types.ErrorCodeAlreadyExists, zenErr.Code)
+	assert.NoError(t, ws.InitializeWithForce(true))
+
+	require.NoError(t, ws.CreateTaskDirectory(".zen/work/PROJ-1"))
+	require.NoError(t, ws.CreateWorkTypeDirectory(".zen/work/PROJ-1", "design"))
+	assert.Equal(t, []string{".zen/work/PROJ-1", ".zen/work/PROJ-1/design"}, ws.Directories())
+	assert.NoDirExists(t, ws.ZenDirectory(), "nothing is written to disk")
+
+	ws.Err = errors.New("disk full")
+	assert.EqualError(t, ws.CreateTaskDirectory("x"), "disk full")
+}
+
+func TestWorkspaceAt(t *testing.T) {
+	ws := zentest.WorkspaceAt("/workspace")
+
+	status, err := ws.Status()
+	require.NoError(t, err)
+	assert.True(t, status.Initialized)
+	assert.Equal(t, "/workspace", status.Root)
+	assert.Equal(t, filepath.Join("/workspace", ".zen"), ws.ZenDirectory())
+}
+
+func TestAssetClient(t *testing.T) {
+	ctx := context.Background()
+	client := zentest.NewAssetClient()
+	client.Add(assets.AssetMetadata{Name: "b", Type: assets.AssetTypeTemplate, Tags: []string{"api", "design"}}, "# B")
+	client.Add(assets.AssetMetadata{Name: "a", Type: assets.AssetTypePrompt, Tags: []string{"api"}}, "# A")
+	client.Add(assets.AssetMetadata{Name: "c", Type: assets.AssetTypeTemplate}, "# C")
+
+	list, err := client.ListAssets(ctx, assets.AssetFilter{Tags: []string{"api"}})
+	require.NoError(t, err)
+	require.Len(t, list.Assets, 2)
+	assert.Equal(t, "a", list.Assets[0].Name)
+
+	list, err = client.ListAssets(ctx, assets.AssetFilter{Type: assets.AssetTypeTemplate, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Total)
+	assert.True(t, list.HasMore)
+
+	content, err := client.GetAsset(ctx, "b", assets.GetAssetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "# B", content.Content)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, content.Checksum)
+
+	_, err = client.GetAsset(ctx, "missing", assets.GetAssetOptions{})
+	var assetErr assets.AssetClientError
+	require.True(t, errors.As(err, &assetErr))
+	assert.Equal(t, assets.ErrorCodeAssetNotFound, assetErr.Code)
+
+	result, err := client.SyncRepository(ctx, assets.SyncRequest{Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, "success", result.Status)
+	assert.Equal(t, []assets.SyncRequest{{Force: true}}, client.Syncs())
+}
+
+func TestAuthManager(t *testing.T) {
+	ctx := context.Background()
+	f := zentest.NewFactory(t).WithCredentials("github", "ghp_test").Build()
+
+	_, err := f.Auth.GetCredentials("gitlab")
+	assert.Equal(t, auth.ErrorCodeCredentialNotFound, auth.GetErrorCode(err))
+
+	token, err := f.Auth.GetCredentials("github")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_test", token)
+	assert.True(t, f.Auth.IsAuthenticated(ctx, "github"))
+	assert.Equal(t, []string{"github"}, f.Auth.ListProviders())
+
+	require.NoError(t, f.Auth.DeleteCredentials("github"))
+	assert.False(t, f.Auth.IsAuthenticated(ctx, "github"))
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+	provider := zentest.NewProvider("fake",
+		integration.ExternalTaskData{Title: "Old", Status: "done", Updated: time.Now().Add(-48 * time.Hour)},
+		integration.ExternalTaskData{Title: "New", Status: "todo"},
+	)
+	f := zentest.NewFactory(t).WithProvider(provider).Build()
+
+	im, err := f.IntegrationManager()
+	require.NoError(t, err)
+	assert.Equal(t, "fake", im.GetTaskSystem())
+	assert.True(t, im.IsSyncEnabled())
+
+	task, err := provider.GetTaskData(ctx, "FAKE-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Old", task.Title)
+
+	_, err = provider.GetTaskData(ctx, "FAKE-9")
+	var clientErr *clients.ClientError
+	require.True(t, errors.As(err, &clientErr))
+	assert.Equal(t, clients.ErrorCodeNotFound, clientErr.Code)
+
+	created, err := provider.CreateTask(ctx, &integration.ZenTaskData{Title: "Third", Status: "todo"})
+	require.NoError(t, err)
+	assert.Equal(t, "FAKE-3", created.ID)
+
+	updated, err := provider.UpdateTask(ctx, "FAKE-3", &integration.ZenTaskData{Status: "in_progress"})
+	require.NoError(t, err)
+	assert.Equal(t, "Third", updated.Title)
+	assert.Equal(t, "in_progress", updated.Status)
+
+	todo, err := provider.SearchTasks(ctx, map[string]interface{}{"status": "todo"})
+	require.NoError(t, err)
+	require.Len(t, todo, 1)
+	assert.Equal(t, "FAKE-2", todo[0].ID)
+
+	recent, err := provider.SearchTasksUpdatedSince(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, recent, 2)
+
+	provider.FailNext(&clients.ClientError{Code: clients.ErrorCodeRateLimited, Retryable: true})
+	health, err := provider.HealthCheck(ctx)
+	require.NoError(t, err)
+	assert.False(t, health.Healthy)
+
+	assert.Equal(t, []string{"GetTaskData", "GetTaskData", "CreateTask", "UpdateTask", "SearchTasks", "SearchTasksUpdatedSince", "ValidateConnection"}, provider.Calls())
+}